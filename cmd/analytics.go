@@ -14,8 +14,13 @@
 package cmd
 
 import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
+	"github.com/okteto/okteto/pkg/config"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/spf13/cobra"
 )
@@ -27,6 +32,9 @@ func Analytics() *cobra.Command {
 		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#analytics"),
 		Use:   "analytics",
 		Short: "Enable / Disable analytics",
+		Long: `Enable / Disable analytics
+
+Run 'okteto analytics status' to check if analytics are sent, and 'okteto analytics show-last' to inspect the properties of the last events sent.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if disable {
 				return disableAnalytics()
@@ -36,9 +44,108 @@ func Analytics() *cobra.Command {
 		},
 	}
 	cmd.Flags().BoolVarP(&disable, "disable", "d", false, "disable analytics")
+	cmd.AddCommand(analyticsStatus())
+	cmd.AddCommand(analyticsEnable())
+	cmd.AddCommand(analyticsDisable())
+	cmd.AddCommand(analyticsShowLast())
 	return cmd
 }
 
+func analyticsStatus() *cobra.Command {
+	return &cobra.Command{
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#analytics"),
+		Use:   "status",
+		Short: "Show if analytics are sent",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			status := analytics.GetStatus()
+			if status.Enabled {
+				oktetoLog.Success("Analytics are enabled")
+			} else {
+				oktetoLog.Information("Analytics are disabled: %s", status.Reason)
+			}
+			oktetoLog.Printf("Machine ID: %s\n", status.MachineID)
+			oktetoLog.Printf("Events sent are recorded at %s\n", config.GetAnalyticsEventsPath())
+			return nil
+		},
+	}
+}
+
+func analyticsEnable() *cobra.Command {
+	return &cobra.Command{
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#analytics"),
+		Use:   "enable",
+		Short: "Enable analytics",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return enableAnalytics()
+		},
+	}
+}
+
+func analyticsDisable() *cobra.Command {
+	return &cobra.Command{
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#analytics"),
+		Use:   "disable",
+		Short: "Disable analytics",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return disableAnalytics()
+		},
+	}
+}
+
+func analyticsShowLast() *cobra.Command {
+	var number int
+	var output string
+	cmd := &cobra.Command{
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#analytics"),
+		Use:   "show-last",
+		Short: "Show the properties of the last analytics events sent",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "" && output != "json" {
+				return fmt.Errorf("output format '%s' is not supported. One of: ['json']", output)
+			}
+			events, err := analytics.GetLastEvents(number)
+			if err != nil {
+				return err
+			}
+			return showEvents(events, output)
+		},
+	}
+	cmd.Flags().IntVarP(&number, "number", "n", 5, "number of events to show")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "output format. One of: ['json']")
+	return cmd
+}
+
+func showEvents(events []analytics.RecordedEvent, output string) error {
+	if output == "json" {
+		if events == nil {
+			events = []analytics.RecordedEvent{}
+		}
+		b, err := json.MarshalIndent(events, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal the analytics events: %w", err)
+		}
+		oktetoLog.Println(string(b))
+		return nil
+	}
+
+	if len(events) == 0 {
+		oktetoLog.Information("No analytics events have been sent")
+		return nil
+	}
+	for _, e := range events {
+		oktetoLog.Printf("%s  %s (distinct id: %s)\n", e.Time.Format("2006-01-02 15:04:05"), e.Event, e.DistinctID)
+		keys := make([]string, 0, len(e.Properties))
+		for k := range e.Properties {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			oktetoLog.Printf("    %s: %v\n", k, e.Properties[k])
+		}
+	}
+	return nil
+}
+
 func disableAnalytics() error {
 	if err := analytics.Disable(); err != nil {
 		return err
@@ -0,0 +1,86 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/audit"
+	"github.com/okteto/okteto/pkg/config"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+// Audit manages the audit log of the shell sessions opened in development containers
+func Audit() *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#audit"),
+		Use:   "audit",
+		Short: "Manage the audit log of the shell sessions in development containers",
+		Long: `Manage the audit log of the shell sessions in development containers.
+
+When enabled, okteto records the start and the end of every shell session opened by 'okteto exec' and 'okteto up', with the user, namespace, pod and command of the session.
+The records are appended to a local file and, if an audit endpoint is set for the okteto context, sent to it as JSON documents.`,
+	}
+	cmd.AddCommand(auditEnable())
+	cmd.AddCommand(auditDisable())
+	return cmd
+}
+
+func auditEnable() *cobra.Command {
+	var endpoint, token string
+	cmd := &cobra.Command{
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#audit"),
+		Use:   "enable",
+		Short: "Start recording the shell sessions in the audit log",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if endpoint != "" {
+				if u, err := url.Parse(endpoint); err != nil || u.Scheme == "" || u.Host == "" {
+					return fmt.Errorf("the audit endpoint '%s' is not a valid url", endpoint)
+				}
+			}
+			if token != "" && endpoint == "" {
+				return fmt.Errorf("the audit token requires an audit endpoint")
+			}
+			if err := audit.Enable(endpoint, token); err != nil {
+				return err
+			}
+			oktetoLog.Success("Shell sessions are recorded at %s", config.GetAuditLogPath())
+			if endpoint != "" {
+				oktetoLog.Information("The records of the current okteto context are sent to %s", endpoint)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&endpoint, "endpoint", "", "", "the url where the audit records of the current okteto context are sent")
+	cmd.Flags().StringVarP(&token, "token", "", "", "the bearer token sent to the audit endpoint. The okteto token of the context is never sent")
+	return cmd
+}
+
+func auditDisable() *cobra.Command {
+	return &cobra.Command{
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#audit"),
+		Use:   "disable",
+		Short: "Stop recording the shell sessions in the audit log",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := audit.Disable(); err != nil {
+				return err
+			}
+			oktetoLog.Success("The audit log has been disabled")
+			return nil
+		},
+	}
+}
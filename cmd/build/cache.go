@@ -0,0 +1,285 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	units "github.com/docker/go-units"
+	"github.com/moby/buildkit/client"
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/cmd/build"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/cobra"
+)
+
+var errNoBuilder = oktetoErrors.UserError{
+	E:    fmt.Errorf("the build cache is only available when using a BuildKit builder"),
+	Hint: "Configure a builder endpoint with 'okteto context --builder BUILDKIT_URL' or use an Okteto context",
+}
+
+// buildkitCacheClient manages the cache of a BuildKit builder
+type buildkitCacheClient interface {
+	DiskUsage(ctx context.Context, opts ...client.DiskUsageOption) ([]*client.UsageInfo, error)
+	Prune(ctx context.Context, ch chan client.UsageInfo, opts ...client.PruneOption) error
+}
+
+// CacheOptions defines the options for okteto build cache
+type CacheOptions struct {
+	K8sContext   string
+	Namespace    string
+	Filters      []string
+	All          bool
+	KeepDuration time.Duration
+	KeepStorage  string
+	Yes          bool
+}
+
+// cacheUsage is the cache usage of a type of BuildKit records
+type cacheUsage struct {
+	recordType  string
+	records     int
+	size        int64
+	reclaimable int64
+}
+
+// projectUsage is the size of the build contexts of a project uploaded to the builder
+type projectUsage struct {
+	project string
+	records int
+	size    int64
+}
+
+// Cache manages the cache of the builder shared by the team.
+// It's not a subcommand of 'okteto build' so it doesn't shadow the build of a service named 'cache'
+func Cache(ctx context.Context) *cobra.Command {
+	options := &CacheOptions{}
+	cmd := &cobra.Command{
+		Use:   "build-cache",
+		Short: "Manage the cache of the builder shared by your team",
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#build"),
+	}
+	cmd.PersistentFlags().StringVarP(&options.K8sContext, "context", "c", "", "context where the builder is configured")
+	cmd.PersistentFlags().StringVarP(&options.Namespace, "namespace", "n", "", "namespace of the okteto context")
+	cmd.PersistentFlags().StringArrayVar(&options.Filters, "filter", nil, "filter the cache records, i.e. 'description~=npm' (can be set more than once)")
+
+	cmd.AddCommand(cacheStatus(ctx, options))
+	cmd.AddCommand(cachePrune(ctx, options))
+	return cmd
+}
+
+func cacheStatus(ctx context.Context, options *CacheOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show the cache usage of the builder and the size of the build contexts of each project",
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#build"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := getCacheClient(ctx, options)
+			if err != nil {
+				return err
+			}
+			return runCacheStatus(ctx, c, options, os.Stdout)
+		},
+	}
+}
+
+func cachePrune(ctx context.Context, options *CacheOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove the cache of the builder that is not in use",
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#build"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !options.Yes {
+				answer, err := utils.AskYesNo("The cache of the builder is shared by your team. Do you want to prune it?", utils.YesNoDefault_No)
+				if err != nil {
+					return err
+				}
+				if !answer {
+					return nil
+				}
+			}
+			c, err := getCacheClient(ctx, options)
+			if err != nil {
+				return err
+			}
+			return runCachePrune(ctx, c, options)
+		},
+	}
+	cmd.Flags().BoolVar(&options.All, "all", false, "include internal and frontend records")
+	cmd.Flags().DurationVar(&options.KeepDuration, "keep-duration", 0, "keep the cache records used within this duration, i.e. '24h'")
+	cmd.Flags().StringVar(&options.KeepStorage, "keep-storage", "", "keep this amount of cache, i.e. '10GB'")
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", false, "prune the cache without asking for confirmation")
+	return cmd
+}
+
+func getCacheClient(ctx context.Context, options *CacheOptions) (buildkitCacheClient, error) {
+	ctxOpts := &contextCMD.ContextOptions{
+		Context:   options.K8sContext,
+		Namespace: options.Namespace,
+		Show:      true,
+	}
+	if err := contextCMD.NewContextCommand().Run(ctx, ctxOpts); err != nil {
+		return nil, err
+	}
+	if okteto.Context().Builder == "" {
+		return nil, errNoBuilder
+	}
+	return build.GetBuildkitClient(ctx)
+}
+
+func runCacheStatus(ctx context.Context, c buildkitCacheClient, options *CacheOptions, out io.Writer) error {
+	records, err := c.DiskUsage(ctx, client.WithFilter(options.Filters))
+	if err != nil {
+		return fmt.Errorf("failed to get the cache usage of the builder: %w", err)
+	}
+	usage := getCacheUsage(records)
+
+	w := tabwriter.NewWriter(out, 1, 1, 2, ' ', 0)
+	fmt.Fprintf(w, "Type\tRecords\tSize\tReclaimable\n")
+	total := cacheUsage{}
+	for _, u := range usage {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", u.recordType, u.records, units.HumanSize(float64(u.size)), units.HumanSize(float64(u.reclaimable)))
+		total.records += u.records
+		total.size += u.size
+		total.reclaimable += u.reclaimable
+	}
+	fmt.Fprintf(w, "Total\t%d\t%s\t%s\n", total.records, units.HumanSize(float64(total.size)), units.HumanSize(float64(total.reclaimable)))
+
+	projects := getProjectUsage(records)
+	if len(projects) > 0 {
+		fmt.Fprintf(w, "\nProject\tContexts\tSize\n")
+		for _, p := range projects {
+			fmt.Fprintf(w, "%s\t%d\t%s\n", p.project, p.records, units.HumanSize(float64(p.size)))
+		}
+	}
+	return w.Flush()
+}
+
+// getProjectUsage groups the build contexts uploaded to the builder by project, sorted by size.
+// The rest of the records, like layers and cache mounts, can be shared by several projects
+func getProjectUsage(records []*client.UsageInfo) []projectUsage {
+	byProject := map[string]*projectUsage{}
+	for _, r := range records {
+		if r.RecordType != client.UsageRecordTypeLocalSource {
+			continue
+		}
+		project := build.GetCacheRecordProject(r.Description)
+		if project == "" {
+			continue
+		}
+		u, ok := byProject[project]
+		if !ok {
+			u = &projectUsage{project: project}
+			byProject[project] = u
+		}
+		u.records++
+		u.size += r.Size
+	}
+
+	result := []projectUsage{}
+	for _, u := range byProject {
+		result = append(result, *u)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].size == result[j].size {
+			return result[i].project < result[j].project
+		}
+		return result[i].size > result[j].size
+	})
+	return result
+}
+
+// getCacheUsage groups the cache records by type, sorted by size
+func getCacheUsage(records []*client.UsageInfo) []cacheUsage {
+	byType := map[string]*cacheUsage{}
+	for _, r := range records {
+		recordType := string(r.RecordType)
+		if recordType == "" {
+			recordType = "unknown"
+		}
+		u, ok := byType[recordType]
+		if !ok {
+			u = &cacheUsage{recordType: recordType}
+			byType[recordType] = u
+		}
+		u.records++
+		u.size += r.Size
+		if !r.InUse && !r.Shared {
+			u.reclaimable += r.Size
+		}
+	}
+
+	result := []cacheUsage{}
+	for _, u := range byType {
+		result = append(result, *u)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].size == result[j].size {
+			return result[i].recordType < result[j].recordType
+		}
+		return result[i].size > result[j].size
+	})
+	return result
+}
+
+func runCachePrune(ctx context.Context, c buildkitCacheClient, options *CacheOptions) error {
+	opts := []client.PruneOption{client.WithFilter(options.Filters)}
+	if options.All {
+		opts = append(opts, client.PruneAll)
+	}
+	if options.KeepDuration != 0 || options.KeepStorage != "" {
+		var keepBytes int64
+		if options.KeepStorage != "" {
+			var err error
+			keepBytes, err = units.FromHumanSize(options.KeepStorage)
+			if err != nil {
+				return fmt.Errorf("invalid value for '--keep-storage': %w", err)
+			}
+		}
+		opts = append(opts, client.WithKeepOpt(options.KeepDuration, keepBytes))
+	}
+
+	ch := make(chan client.UsageInfo)
+	done := make(chan struct{})
+	var records int
+	var reclaimed int64
+	go func() {
+		defer close(done)
+		for r := range ch {
+			records++
+			reclaimed += r.Size
+		}
+	}()
+
+	oktetoLog.Spinner("Pruning the builder cache...")
+	oktetoLog.StartSpinner()
+	err := c.Prune(ctx, ch, opts...)
+	close(ch)
+	<-done
+	oktetoLog.StopSpinner()
+	if err != nil {
+		return fmt.Errorf("failed to prune the cache of the builder: %w", err)
+	}
+	oktetoLog.Success("Pruned %d cache records, %s reclaimed", records, units.HumanSize(float64(reclaimed)))
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moby/buildkit/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCacheClient struct {
+	records    []*client.UsageInfo
+	pruneOpts  client.PruneInfo
+	pruneItems []client.UsageInfo
+}
+
+func (c *fakeCacheClient) DiskUsage(context.Context, ...client.DiskUsageOption) ([]*client.UsageInfo, error) {
+	return c.records, nil
+}
+
+func (c *fakeCacheClient) Prune(_ context.Context, ch chan client.UsageInfo, opts ...client.PruneOption) error {
+	for _, o := range opts {
+		o.SetPruneOption(&c.pruneOpts)
+	}
+	for _, item := range c.pruneItems {
+		ch <- item
+	}
+	return nil
+}
+
+func TestGetCacheUsage(t *testing.T) {
+	records := []*client.UsageInfo{
+		{ID: "1", RecordType: client.UsageRecordTypeRegular, Size: 100},
+		{ID: "2", RecordType: client.UsageRecordTypeRegular, Size: 300, InUse: true},
+		{ID: "3", RecordType: client.UsageRecordTypeLocalSource, Size: 50},
+		{ID: "4", RecordType: client.UsageRecordTypeCacheMount, Size: 500, Shared: true},
+	}
+	assert.Equal(t, []cacheUsage{
+		{recordType: "exec.cachemount", records: 1, size: 500},
+		{recordType: "regular", records: 2, size: 400, reclaimable: 100},
+		{recordType: "source.local", records: 1, size: 50, reclaimable: 50},
+	}, getCacheUsage(records))
+}
+
+func TestRunCacheStatus(t *testing.T) {
+	c := &fakeCacheClient{
+		records: []*client.UsageInfo{
+			{ID: "1", RecordType: client.UsageRecordTypeRegular, Size: 2000},
+		},
+	}
+	out := &bytes.Buffer{}
+	require.NoError(t, runCacheStatus(context.Background(), c, &CacheOptions{}, out))
+	assert.Contains(t, out.String(), "regular  1        2kB   2kB")
+	assert.Contains(t, out.String(), "Total    1        2kB   2kB")
+}
+
+func TestRunCacheStatusByProject(t *testing.T) {
+	c := &fakeCacheClient{
+		records: []*client.UsageInfo{
+			{ID: "1", RecordType: client.UsageRecordTypeRegular, Size: 2000},
+			{ID: "2", RecordType: client.UsageRecordTypeLocalSource, Size: 3000, Description: "local source for context-movies"},
+			{ID: "3", RecordType: client.UsageRecordTypeLocalSource, Size: 1000, Description: "local source for context-movies"},
+			{ID: "4", RecordType: client.UsageRecordTypeLocalSource, Size: 5000, Description: "local source for context-voting"},
+			{ID: "5", RecordType: client.UsageRecordTypeLocalSource, Size: 500, Description: "local source for dockerfile"},
+		},
+	}
+	assert.Equal(t, []projectUsage{
+		{project: "voting", records: 1, size: 5000},
+		{project: "movies", records: 2, size: 4000},
+	}, getProjectUsage(c.records))
+
+	out := &bytes.Buffer{}
+	require.NoError(t, runCacheStatus(context.Background(), c, &CacheOptions{}, out))
+	assert.Contains(t, out.String(), "\nProject  Contexts  Size\nvoting   1         5kB\nmovies   2         4kB\n")
+}
+
+func TestRunCachePrune(t *testing.T) {
+	c := &fakeCacheClient{
+		pruneItems: []client.UsageInfo{{ID: "1", Size: 100}, {ID: "2", Size: 200}},
+	}
+	options := &CacheOptions{All: true, KeepDuration: 48 * time.Hour, KeepStorage: "1GB"}
+	require.NoError(t, runCachePrune(context.Background(), c, options))
+	assert.True(t, c.pruneOpts.All)
+	assert.Equal(t, 48*time.Hour, c.pruneOpts.KeepDuration)
+	assert.Equal(t, int64(1000000000), c.pruneOpts.KeepBytes)
+
+	assert.Error(t, runCachePrune(context.Background(), c, &CacheOptions{KeepStorage: "lots"}))
+}
@@ -87,6 +87,18 @@ func Build(ctx context.Context, at analyticsTrackerInterface) *cobra.Command {
 		Short: "Build and push the images defined in the 'build' section of your okteto manifest",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			options.CommandArgs = args
+			if err := validateSBOMOptions(options); err != nil {
+				return err
+			}
+			if err := build.ValidateContextCompression(options.ContextCompression); err != nil {
+				return err
+			}
+			if options.SignKey != "" && !options.Sign {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("the '--sign-key' flag requires the '--sign' flag"),
+					Hint: "Use '--sign' to sign the images",
+				}
+			}
 			bc := NewBuildCommand(at)
 			// The context must be loaded before reading manifest. Otherwise,
 			// secrets will not be resolved when GetManifest is called and
@@ -126,6 +138,15 @@ func Build(ctx context.Context, at analyticsTrackerInterface) *cobra.Command {
 	cmd.Flags().StringVar(&options.Platform, "platform", "", "set platform if server is multi-platform capable")
 	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "namespace against which the image will be consumed. Default is the one defined at okteto context or okteto manifest")
 	cmd.Flags().BoolVarP(&options.BuildToGlobal, "global", "", false, "push the image to the global registry")
+	cmd.Flags().StringVar(&options.SBOM, "sbom", "", "generate the software bill of materials of the images. Supported formats: spdx, cyclonedx")
+	cmd.Flags().StringVar(&options.SBOMOutput, "sbom-output", "", "folder where the sbom documents are written (default is the current folder)")
+	cmd.Flags().BoolVar(&options.SBOMAttest, "sbom-attest", false, "attach the sbom documents to the images as cosign attestations")
+	cmd.Flags().StringVar(&options.SBOMKey, "sbom-key", "", "cosign key used to sign the sbom attestations (keyless signing is used by default)")
+	cmd.Flags().BoolVar(&options.Sign, "sign", false, "sign the pushed images with cosign")
+	cmd.Flags().StringVar(&options.SignKey, "sign-key", "", "cosign key used to sign the images (keyless OIDC signing is used by default)")
+	cmd.Flags().BoolVar(&options.Strict, "strict", false, "fail the build if the Dockerfile doesn't follow the best practices")
+	cmd.Flags().BoolVar(&options.ShowContext, "show-context", false, "list the files of the build context uploaded to the builder after applying the .dockerignore and .oktetoignore files, without building")
+	cmd.Flags().StringVar(&options.ContextCompression, "context-compression", "", "compression of the build context sent to the docker daemon. Supported compressions: gzip, zstd (requires Docker Engine 23 or newer)")
 	return cmd
 }
 
@@ -153,6 +174,19 @@ func (bc *Command) getBuilder(options *types.BuildOptions) (Builder, error) {
 	return builder, nil
 }
 
+func validateSBOMOptions(options *types.BuildOptions) error {
+	if options.SBOM == "" {
+		if options.SBOMAttest || options.SBOMOutput != "" || options.SBOMKey != "" {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("the sbom flags require the '--sbom' flag"),
+				Hint: "Set the sbom format with '--sbom spdx' or '--sbom cyclonedx'",
+			}
+		}
+		return nil
+	}
+	return build.ValidateSBOMFormat(options.SBOM)
+}
+
 func isBuildV2(m *model.Manifest) bool {
 	return m.IsV2 && len(m.Build) != 0
 }
@@ -386,3 +386,10 @@ func Test_NewBuildCommand(t *testing.T) {
 	require.NotNil(t, got.Registry)
 	require.IsType(t, fakeAnalyticsTracker{}, got.analyticsTracker)
 }
+
+func TestValidateSBOMOptions(t *testing.T) {
+	assert.NoError(t, validateSBOMOptions(&types.BuildOptions{}))
+	assert.NoError(t, validateSBOMOptions(&types.BuildOptions{SBOM: "spdx", SBOMAttest: true}))
+	assert.Error(t, validateSBOMOptions(&types.BuildOptions{SBOM: "xml"}))
+	assert.Error(t, validateSBOMOptions(&types.BuildOptions{SBOMAttest: true}))
+}
@@ -16,6 +16,7 @@ package v1
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 
 	"github.com/okteto/okteto/pkg/model"
@@ -28,6 +29,7 @@ import (
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/registry"
 	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
 )
 
 // OktetoBuilderInterface runs the build of an image
@@ -40,10 +42,21 @@ type oktetoRegistryInterface interface {
 	HasGlobalPushAccess() (bool, error)
 }
 
+type sbomGeneratorInterface interface {
+	Generate(ctx context.Context, image, format, outputDir string) (string, error)
+	Attest(ctx context.Context, image, format, file, key string) error
+}
+
+type imageSignerInterface interface {
+	Sign(ctx context.Context, image, key string) error
+}
+
 // OktetoBuilder builds the images
 type OktetoBuilder struct {
 	Builder  OktetoBuilderInterface
 	Registry oktetoRegistryInterface
+	SBOM     sbomGeneratorInterface
+	Signer   imageSignerInterface
 }
 
 // NewBuilder creates a new okteto builder
@@ -51,6 +64,8 @@ func NewBuilder(builder OktetoBuilderInterface, registry oktetoRegistryInterface
 	return &OktetoBuilder{
 		Builder:  builder,
 		Registry: registry,
+		SBOM:     build.NewSBOMGenerator(),
+		Signer:   build.NewImageSigner(),
 	}
 }
 
@@ -76,20 +91,36 @@ func (bc *OktetoBuilder) Build(ctx context.Context, options *types.BuildOptions)
 		path = options.CommandArgs[0]
 	}
 
-	if err := utils.CheckIfDirectory(path); err != nil {
-		return fmt.Errorf("invalid build context: %s", err.Error())
+	if model.IsRemoteBuildContext(path) {
+		// remote contexts are cloned by the builder, the Dockerfile is relative to the repository
+		options.Path = model.GetRemoteBuildContext(path)
+	} else {
+		if err := utils.CheckIfDirectory(path); err != nil {
+			return fmt.Errorf("invalid build context: %s", err.Error())
+		}
+		options.Path = path
+
+		if options.File == "" {
+			options.File = filepath.Join(path, "Dockerfile")
+		}
+
+		if err := utils.CheckIfRegularFile(options.File); err != nil {
+			return fmt.Errorf("%s: %s", oktetoErrors.InvalidDockerfile, err.Error())
+		}
 	}
-	options.Path = path
 
-	if options.File == "" {
-		options.File = filepath.Join(path, "Dockerfile")
+	if options.ShowContext {
+		return build.ShowContext(afero.NewOsFs(), options, os.Stdout)
 	}
 
-	if err := utils.CheckIfRegularFile(options.File); err != nil {
-		return fmt.Errorf("%s: %s", oktetoErrors.InvalidDockerfile, err.Error())
+	if err := build.LintDockerfile(options); err != nil {
+		return err
 	}
 
 	buildMsg := fmt.Sprintf("Building '%s'", options.File)
+	if model.IsRemoteBuildContext(options.Path) {
+		buildMsg = fmt.Sprintf("Building '%s'", options.Path)
+	}
 	if okteto.Context().Builder == "" {
 		oktetoLog.Information("%s using your local docker daemon", buildMsg)
 	} else {
@@ -110,14 +141,92 @@ func (bc *OktetoBuilder) Build(ctx context.Context, options *types.BuildOptions)
 	if options.Tag == "" {
 		oktetoLog.Success("Build succeeded")
 		oktetoLog.Information("Your image won't be pushed. To push your image specify the flag '-t'.")
+		if options.SBOM != "" || options.Sign {
+			oktetoLog.Warning("Only pushed images can be signed or have a sbom")
+		}
+	} else if options.PushQueue != nil {
+		oktetoLog.Success(fmt.Sprintf("Image '%s' successfully built, pushing it in the background", options.Tag))
 	} else {
 		displayTag := options.Tag
 		if options.DevTag != "" {
 			displayTag = options.DevTag
 		}
 		oktetoLog.Success(fmt.Sprintf("Image '%s' successfully pushed", displayTag))
+		if err := bc.SignImage(ctx, options); err != nil {
+			analytics.TrackBuild(false)
+			return err
+		}
+		if err := bc.GenerateSBOM(ctx, options); err != nil {
+			analytics.TrackBuild(false)
+			return err
+		}
 	}
 
 	analytics.TrackBuild(true)
 	return nil
 }
+
+// GenerateSBOM generates the sbom of the pushed image and optionally attaches it as an attestation
+func (bc *OktetoBuilder) GenerateSBOM(ctx context.Context, options *types.BuildOptions) error {
+	if options.SBOM == "" {
+		return nil
+	}
+	if bc.SBOM == nil {
+		bc.SBOM = build.NewSBOMGenerator()
+	}
+
+	image := bc.getPushedImageWithDigest(options.Tag)
+
+	oktetoLog.Spinner(fmt.Sprintf("Generating %s sbom of '%s'...", options.SBOM, options.Tag))
+	oktetoLog.StartSpinner()
+	file, err := bc.SBOM.Generate(ctx, image, options.SBOM, options.SBOMOutput)
+	oktetoLog.StopSpinner()
+	if err != nil {
+		return err
+	}
+	oktetoLog.Success("SBOM of '%s' written to '%s'", options.Tag, file)
+
+	if !options.SBOMAttest {
+		return nil
+	}
+	oktetoLog.Spinner(fmt.Sprintf("Attaching sbom attestation to '%s'...", options.Tag))
+	oktetoLog.StartSpinner()
+	err = bc.SBOM.Attest(ctx, image, options.SBOM, file, options.SBOMKey)
+	oktetoLog.StopSpinner()
+	if err != nil {
+		return err
+	}
+	oktetoLog.Success("SBOM attestation attached to '%s'", options.Tag)
+	return nil
+}
+
+// SignImage signs the pushed image with cosign
+func (bc *OktetoBuilder) SignImage(ctx context.Context, options *types.BuildOptions) error {
+	if !options.Sign {
+		return nil
+	}
+	if bc.Signer == nil {
+		bc.Signer = build.NewImageSigner()
+	}
+
+	oktetoLog.Spinner(fmt.Sprintf("Signing '%s'...", options.Tag))
+	oktetoLog.StartSpinner()
+	err := bc.Signer.Sign(ctx, bc.getPushedImageWithDigest(options.Tag), options.SignKey)
+	oktetoLog.StopSpinner()
+	if err != nil {
+		return err
+	}
+	oktetoLog.Success("Image '%s' successfully signed", options.Tag)
+	return nil
+}
+
+// getPushedImageWithDigest returns the image reference with its digest,
+// so signatures and attestations refer to the exact image that was pushed
+func (bc *OktetoBuilder) getPushedImageWithDigest(tag string) string {
+	imageWithDigest, err := bc.Registry.GetImageTagWithDigest(tag)
+	if err != nil {
+		oktetoLog.Infof("could not get digest of image '%s': %s", tag, err)
+		return tag
+	}
+	return imageWithDigest
+}
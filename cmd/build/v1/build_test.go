@@ -208,3 +208,63 @@ func createDockerfile(t *testing.T) (string, error) {
 	}
 	return dir, nil
 }
+
+type fakeSBOMGenerator struct {
+	generated []string
+	attested  []string
+}
+
+func (g *fakeSBOMGenerator) Generate(_ context.Context, image, format, outputDir string) (string, error) {
+	g.generated = append(g.generated, image)
+	return filepath.Join(outputDir, fmt.Sprintf("sbom.%s.json", format)), nil
+}
+
+func (g *fakeSBOMGenerator) Attest(_ context.Context, image, _, _, _ string) error {
+	g.attested = append(g.attested, image)
+	return nil
+}
+
+type fakeImageSigner struct {
+	signed []string
+}
+
+func (s *fakeImageSigner) Sign(_ context.Context, image, _ string) error {
+	s.signed = append(s.signed, image)
+	return nil
+}
+
+func TestBuildWithSignAndSBOM(t *testing.T) {
+	ctx := context.Background()
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {
+				Namespace: "test",
+			},
+		},
+		CurrentContext: "test",
+	}
+
+	registry := newFakeRegistry()
+	sbom := &fakeSBOMGenerator{}
+	signer := &fakeImageSigner{}
+	bc := &OktetoBuilder{
+		Builder:  test.NewFakeOktetoBuilder(registry),
+		Registry: registry,
+		SBOM:     sbom,
+		Signer:   signer,
+	}
+	dir, err := createDockerfile(t)
+	assert.NoError(t, err)
+
+	options := &types.BuildOptions{
+		CommandArgs: []string{dir},
+		Tag:         "okteto.dev/test",
+		SBOM:        "spdx",
+		SBOMAttest:  true,
+		Sign:        true,
+	}
+	assert.NoError(t, bc.Build(ctx, options))
+	assert.Equal(t, []string{"okteto.dev/test"}, signer.signed)
+	assert.Equal(t, []string{"okteto.dev/test"}, sbom.generated)
+	assert.Equal(t, []string{"okteto.dev/test"}, sbom.attested)
+}
@@ -19,7 +19,9 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -33,11 +35,13 @@ import (
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/filesystem"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	localMetrics "github.com/okteto/okteto/pkg/metrics"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/registry"
 	"github.com/okteto/okteto/pkg/repository"
 	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
 )
 
 // OktetoBuilderInterface runs the build of an image
@@ -65,12 +69,18 @@ type oktetoBuilderConfigInterface interface {
 	IsOkteto() bool
 	GetAnonymizedRepo() string
 	GetBuildContextHash(*model.BuildInfo) string
+	GetBuildHash(*model.BuildInfo) string
 }
 
 type analyticsTrackerInterface interface {
 	TrackImageBuild(meta ...*analytics.ImageBuildMetadata)
 }
 
+// externalBuilderInterface builds and pushes images with a build tool different from BuildKit
+type externalBuilderInterface interface {
+	Build(ctx context.Context, buildInfo *model.BuildInfo, image string) error
+}
+
 // OktetoBuilder builds the images
 type OktetoBuilder struct {
 	Builder   OktetoBuilderInterface
@@ -78,12 +88,19 @@ type OktetoBuilder struct {
 	V1Builder *buildv1.OktetoBuilder
 
 	Config oktetoBuilderConfigInterface
+	// Scanner scans the built images of the services with a scan policy
+	Scanner imageScannerInterface
+	// ExternalBuilder builds the images of the services with 'builder: earthly|bazel'
+	ExternalBuilder externalBuilderInterface
 	// buildEnvironments are the environment variables created by the build steps
 	buildEnvironments map[string]string
 
 	// lock is a mutex to provide builEnvironments map safe concurrency
 	lock             sync.RWMutex
 	analyticsTracker analyticsTrackerInterface
+
+	// newPushPipeline creates the pipeline that pushes the images in the background. It defaults to build.NewPushPipeline
+	newPushPipeline func() pushPipelineInterface
 }
 
 // NewBuilder creates a new okteto builder
@@ -111,6 +128,8 @@ func NewBuilderFromScratch(analyticsTracker analyticsTrackerInterface) *OktetoBu
 		V1Builder:         buildv1.NewBuilder(builder, registry),
 		buildEnvironments: map[string]string{},
 		Config:            getConfig(registry, gitRepo),
+		Scanner:           build.NewTrivyScanner(),
+		ExternalBuilder:   build.NewExternalBuilder(),
 		analyticsTracker:  analyticsTracker,
 	}
 }
@@ -157,6 +176,10 @@ func (bc *OktetoBuilder) Build(ctx context.Context, options *types.BuildOptions)
 		return err
 	}
 
+	if options.ShowContext {
+		return bc.showContext(afero.NewOsFs(), options.Manifest, toBuildSvcs, options, os.Stdout)
+	}
+
 	buildManifest := options.Manifest.Build
 
 	// builtImagesControl represents the controller for the built services
@@ -171,6 +194,16 @@ func (bc *OktetoBuilder) Build(ctx context.Context, options *types.BuildOptions)
 		bc.analyticsTracker.TrackImageBuild(buildsAnalytics...)
 	}(buildsAnalytics)
 
+	// images pushed in the background, their digests are resolved once all the images are built
+	var backgroundPushes []*backgroundPush
+	pushPipeline := bc.getPushPipeline(options, toBuildSvcs)
+	if pushPipeline != nil {
+		options.PushQueue = pushPipeline
+		defer func() {
+			options.PushQueue = nil
+		}()
+	}
+
 	oktetoLog.Infof("Images to build: [%s]", strings.Join(toBuildSvcs, ", "))
 	for len(builtImagesControl) != len(toBuildSvcs) {
 		for _, svcToBuild := range toBuildSvcs {
@@ -186,7 +219,11 @@ func (bc *OktetoBuilder) Build(ctx context.Context, options *types.BuildOptions)
 				oktetoLog.SetStage(fmt.Sprintf("Building service %s", svcToBuild))
 			}
 
-			buildSvcInfo := buildManifest[svcToBuild]
+			// build args from the command override the ones from the manifest, so they have to be part of the build hash
+			buildSvcInfo, err := getBuildInfoWithBuildArgs(buildManifest[svcToBuild], options.BuildArgs)
+			if err != nil {
+				return err
+			}
 
 			// create the meta pointer and append it to the analytics slice
 			meta := analytics.NewImageBuildMetadata()
@@ -197,7 +234,7 @@ func (bc *OktetoBuilder) Build(ctx context.Context, options *types.BuildOptions)
 
 			repoHashDurationStart := time.Now()
 			repoCommit := bc.Config.GetGitCommit()
-			buildHash := getBuildHashFromCommit(buildSvcInfo, repoCommit)
+			buildHash := bc.Config.GetBuildHash(buildSvcInfo)
 
 			meta.RepoHash = buildHash
 			meta.RepoHashDuration = time.Since(repoHashDurationStart)
@@ -230,6 +267,15 @@ func (bc *OktetoBuilder) Build(ctx context.Context, options *types.BuildOptions)
 						imageWithDigest = devImage
 					}
 
+					if err := bc.scanImage(ctx, svcToBuild, imageWithDigest, buildSvcInfo.Scan, meta); err != nil {
+						return err
+					}
+					if err := bc.signReusedImage(ctx, imageWithDigest, options); err != nil {
+						return err
+					}
+					if err := bc.generateReusedImageSBOM(ctx, imageWithDigest, options); err != nil {
+						return err
+					}
 					bc.SetServiceEnvVars(svcToBuild, imageWithDigest)
 					builtImagesControl[svcToBuild] = true
 					meta.Success = true
@@ -246,12 +292,35 @@ func (bc *OktetoBuilder) Build(ctx context.Context, options *types.BuildOptions)
 				return fmt.Errorf("error building service '%s': %w", svcToBuild, err)
 			}
 			meta.BuildDuration = time.Since(buildDurationStart)
+			localMetrics.Observe(localMetrics.Build, meta.BuildDuration)
+
+			if isPushedInBackground(buildSvcInfo, options) {
+				backgroundPushes = append(backgroundPushes, &backgroundPush{
+					service: svcToBuild,
+					image:   imageTag,
+					scan:    buildSvcInfo.Scan,
+					meta:    meta,
+				})
+				// the images that depend on this one are built from the local image, the env vars are set again with the digest after the push
+				bc.SetServiceEnvVars(svcToBuild, imageTag)
+				builtImagesControl[svcToBuild] = true
+				continue
+			}
+
+			if err := bc.scanImage(ctx, svcToBuild, imageTag, buildSvcInfo.Scan, meta); err != nil {
+				return err
+			}
 			meta.Success = true
 
 			bc.SetServiceEnvVars(svcToBuild, imageTag)
 			builtImagesControl[svcToBuild] = true
 		}
 	}
+	if pushPipeline != nil {
+		if err := bc.waitForPushes(ctx, pushPipeline, backgroundPushes); err != nil {
+			return err
+		}
+	}
 	if options.EnableStages {
 		oktetoLog.SetStage("")
 	}
@@ -287,8 +356,20 @@ func (bc *OktetoBuilder) buildServiceImages(ctx context.Context, manifest *model
 			E:    fmt.Errorf("Build with volume mounts is not supported on vanilla contexts"),
 			Hint: "Please connect to a okteto context and try again",
 		}
+	case serviceHasExternalBuilder(buildSvcInfo) && serviceHasVolumesToInclude(buildSvcInfo):
+		image, err := bc.buildSvcWithExternalBuilder(ctx, manifest, svcName, options)
+		if err != nil {
+			return "", err
+		}
+		buildSvcInfo.Image = image
+		return bc.addVolumeMounts(ctx, manifest, svcName, options)
+	case serviceHasExternalBuilder(buildSvcInfo):
+		return bc.buildSvcWithExternalBuilder(ctx, manifest, svcName, options)
 	case serviceHasDockerfile(buildSvcInfo) && serviceHasVolumesToInclude(buildSvcInfo):
-		image, err := bc.buildSvcFromDockerfile(ctx, manifest, svcName, options)
+		// the image with the volume mounts is built from this image, so it has to be pushed before
+		syncOptions := *options
+		syncOptions.PushQueue = nil
+		image, err := bc.buildSvcFromDockerfile(ctx, manifest, svcName, &syncOptions)
 		if err != nil {
 			return "", err
 		}
@@ -311,7 +392,12 @@ func (bc *OktetoBuilder) buildSvcFromDockerfile(ctx context.Context, manifest *m
 	oktetoLog.Infof("Building image for service '%s'", svcName)
 	isStackManifest := manifest.Type == model.StackType
 	buildSvcInfo := bc.getBuildInfoWithoutVolumeMounts(manifest.Build[svcName], isStackManifest)
-	buildHash := getBuildHashFromCommit(buildSvcInfo, bc.Config.GetGitCommit())
+	// the build args from the command are added to the build options, they're only added here for the build hash
+	hashBuildInfo, err := getBuildInfoWithBuildArgs(buildSvcInfo, options.BuildArgs)
+	if err != nil {
+		return "", err
+	}
+	buildHash := bc.Config.GetBuildHash(hashBuildInfo)
 	tagToBuild := newImageTagger(bc.Config).getServiceImageReference(manifest.Name, svcName, buildSvcInfo, buildHash)
 	buildSvcInfo.Image = tagToBuild
 	if err := buildSvcInfo.AddBuildArgs(bc.buildEnvironments); err != nil {
@@ -323,6 +409,10 @@ func (bc *OktetoBuilder) buildSvcFromDockerfile(ctx context.Context, manifest *m
 	if err := bc.V1Builder.Build(ctx, buildOptions); err != nil {
 		return "", err
 	}
+	if buildOptions.PushQueue != nil {
+		// the digest is resolved once the push pipeline finishes
+		return buildOptions.Tag, nil
+	}
 	// check if the image is pushed to the dev registry if DevTag is set
 	reference := buildOptions.Tag
 	if buildOptions.DevTag != "" {
@@ -335,6 +425,47 @@ func (bc *OktetoBuilder) buildSvcFromDockerfile(ctx context.Context, manifest *m
 	return imageTagWithDigest, nil
 }
 
+// showContext writes the files of the build context uploaded for each service built from a Dockerfile
+func (bc *OktetoBuilder) showContext(fs afero.Fs, manifest *model.Manifest, svcsToBuild []string, options *types.BuildOptions, out io.Writer) error {
+	for _, svcName := range svcsToBuild {
+		buildSvcInfo := manifest.Build[svcName]
+		if !serviceHasDockerfile(buildSvcInfo) {
+			continue
+		}
+		buildOptions := build.OptsFromBuildInfo(manifest.Name, svcName, buildSvcInfo.Copy(), options, bc.Registry)
+		fmt.Fprintf(out, "Service '%s':\n", svcName)
+		if err := build.ShowContext(fs, buildOptions, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildSvcWithExternalBuilder builds and pushes the image of the service with its build tool and returns the image reference with digest
+func (bc *OktetoBuilder) buildSvcWithExternalBuilder(ctx context.Context, manifest *model.Manifest, svcName string, options *types.BuildOptions) (string, error) {
+	isStackManifest := manifest.Type == model.StackType
+	buildSvcInfo, err := getBuildInfoWithBuildArgs(bc.getBuildInfoWithoutVolumeMounts(manifest.Build[svcName], isStackManifest), options.BuildArgs)
+	if err != nil {
+		return "", err
+	}
+	buildHash := bc.Config.GetBuildHash(buildSvcInfo)
+	tagToBuild := newImageTagger(bc.Config).getServiceImageReference(manifest.Name, svcName, buildSvcInfo, buildHash)
+	if err := buildSvcInfo.AddBuildArgs(bc.buildEnvironments); err != nil {
+		return "", fmt.Errorf("error expanding build args from service '%s': %w", svcName, err)
+	}
+	if bc.ExternalBuilder == nil {
+		bc.ExternalBuilder = build.NewExternalBuilder()
+	}
+	if err := bc.ExternalBuilder.Build(ctx, buildSvcInfo, tagToBuild); err != nil {
+		return "", err
+	}
+	imageTagWithDigest, err := bc.Registry.GetImageTagWithDigest(tagToBuild)
+	if err != nil {
+		return "", fmt.Errorf("error accessing image at registry %s: %v", tagToBuild, err)
+	}
+	return imageTagWithDigest, nil
+}
+
 func (bc *OktetoBuilder) addVolumeMounts(ctx context.Context, manifest *model.Manifest, svcName string, options *types.BuildOptions) (string, error) {
 	oktetoLog.Information("Including volume hosts for service '%s'", svcName)
 	isStackManifest := (manifest.Type == model.StackType) || (manifest.Deploy != nil && manifest.Deploy.ComposeSection != nil)
@@ -343,9 +474,12 @@ func (bc *OktetoBuilder) addVolumeMounts(ctx context.Context, manifest *model.Ma
 		fromImage = options.Tag
 	}
 
-	buildInfoCopy := manifest.Build[svcName].Copy()
+	buildInfoCopy, err := getBuildInfoWithBuildArgs(manifest.Build[svcName], options.BuildArgs)
+	if err != nil {
+		return "", err
+	}
 	buildInfoCopy.Image = ""
-	buildHash := getBuildHashFromCommit(buildInfoCopy, bc.Config.GetGitCommit())
+	buildHash := bc.Config.GetBuildHash(buildInfoCopy)
 
 	tagToBuild := newImageWithVolumesTagger(bc.Config).getServiceImageReference(manifest.Name, svcName, buildInfoCopy, buildHash)
 	buildSvcInfo := getBuildInfoWithVolumeMounts(manifest.Build[svcName], isStackManifest)
@@ -355,6 +489,8 @@ func (bc *OktetoBuilder) addVolumeMounts(ctx context.Context, manifest *model.Ma
 	}
 	buildOptions := build.OptsFromBuildInfo(manifest.Name, svcName, svcBuild, options, bc.Registry)
 	buildOptions.Tag = tagToBuild
+	buildOptions.PushQueue = nil
+	buildOptions.NoLint = true
 
 	if err := bc.V1Builder.Build(ctx, buildOptions); err != nil {
 		return "", err
@@ -366,9 +502,20 @@ func (bc *OktetoBuilder) addVolumeMounts(ctx context.Context, manifest *model.Ma
 	return imageTagWithDigest, nil
 }
 
-// serviceHasDockerfile returns true when service BuildInfo Dockerfile is not empty
+// serviceHasDockerfile returns true when service BuildInfo Dockerfile is not empty or the context is a git repository,
+// which has its Dockerfile in the repository
 func serviceHasDockerfile(buildInfo *model.BuildInfo) bool {
-	return buildInfo.Dockerfile != ""
+	return buildInfo.Dockerfile != "" || model.IsRemoteBuildContext(buildInfo.Context)
+}
+
+// serviceHasExternalBuilder returns true when service BuildInfo is built with a build tool different from BuildKit
+func serviceHasExternalBuilder(buildInfo *model.BuildInfo) bool {
+	return buildInfo.Builder != ""
+}
+
+// serviceBuildsImage returns true when the service image is built from a Dockerfile or with an external build tool
+func serviceBuildsImage(buildInfo *model.BuildInfo) bool {
+	return serviceHasDockerfile(buildInfo) || serviceHasExternalBuilder(buildInfo)
 }
 
 // serviceHasVolumesToInclude returns true when service BuildInfo VolumesToInclude are more than 0
@@ -457,12 +604,100 @@ func getImageChecker(buildInfo *model.BuildInfo, cfg oktetoBuilderConfigInterfac
 	return newImageChecker(cfg, registry, tagger)
 }
 
-// getBuildHashFromCommit parses buildInfo and commit into a hashed string
-func getBuildHashFromCommit(buildInfo *model.BuildInfo, commit string) string {
-	return getBuildHashFromGitHash(buildInfo, commit, "commit")
+// getCacheInputsText returns the content hash of the files matching the cache inputs,
+// so lockfiles outside of the build context invalidate the smart build cache when they change
+func getCacheInputsText(fs afero.Fs, inputs []string) string {
+	hashes := []string{}
+	for _, input := range inputs {
+		matches, err := afero.Glob(fs, input)
+		if err != nil || len(matches) == 0 {
+			oktetoLog.Infof("cache input '%s' doesn't match any file", input)
+			hashes = append(hashes, fmt.Sprintf("%s=", input))
+			continue
+		}
+		sort.Strings(matches)
+		for _, match := range matches {
+			content, err := afero.ReadFile(fs, match)
+			if err != nil {
+				oktetoLog.Infof("could not read cache input '%s': %s", match, err)
+				hashes = append(hashes, fmt.Sprintf("%s=", match))
+				continue
+			}
+			fileHash := sha256.Sum256(content)
+			hashes = append(hashes, fmt.Sprintf("%s=%s", match, hex.EncodeToString(fileHash[:])))
+		}
+	}
+	return strings.Join(hashes, ";")
+}
+
+// getBuildInfoWithBuildArgs returns a copy of the build info with the build args of the manifest overridden by the ones from the command
+func getBuildInfoWithBuildArgs(buildInfo *model.BuildInfo, buildArgs []string) (*model.BuildInfo, error) {
+	result := buildInfo.Copy()
+	for _, arg := range buildArgs {
+		name, value, _ := strings.Cut(arg, "=")
+		if name == "" {
+			return nil, fmt.Errorf("invalid build-arg value %s", arg)
+		}
+		found := false
+		for i := range result.Args {
+			if result.Args[i].Name == name {
+				result.Args[i].Value = value
+				found = true
+				break
+			}
+		}
+		if !found {
+			result.Args = append(result.Args, model.BuildArg{Name: name, Value: value})
+		}
+	}
+	return result, nil
+}
+
+// getBuildHashFromCommit parses buildInfo and commit into a hashed string. The cache inputs are read from fs
+func getBuildHashFromCommit(fs afero.Fs, buildInfo *model.BuildInfo, commit string) string {
+	return getBuildHashFromGitHash(fs, buildInfo, commit, "commit")
+}
+
+// remoteContextTimeout is the maximum time to resolve the commit of a remote build context
+const remoteContextTimeout = 10 * time.Second
+
+var (
+	// getRemoteCommit resolves the commit of a git ref without cloning the repository
+	getRemoteCommit = repository.GetRemoteCommit
+
+	remoteContextCommits   = map[string]string{}
+	remoteContextCommitsMu sync.Mutex
+)
+
+// getRemoteContextCommit returns the commit of the ref of a remote git build context.
+// The result is memoized so every hash computed during the command matches. If the commit can't be resolved
+// a unique value is returned to force the image to be rebuilt
+func getRemoteContextCommit(buildContext string) string {
+	remoteContextCommitsMu.Lock()
+	defer remoteContextCommitsMu.Unlock()
+	if commit, ok := remoteContextCommits[buildContext]; ok {
+		return commit
+	}
+
+	repositoryURL, ref := model.ParseGitBuildContext(buildContext)
+	ctx, cancel := context.WithTimeout(context.Background(), remoteContextTimeout)
+	defer cancel()
+	commit, err := getRemoteCommit(ctx, repositoryURL, ref)
+	if err != nil {
+		oktetoLog.Infof("could not resolve the commit of the build context '%s', the image will be rebuilt: %s", buildContext, err)
+		commit = fmt.Sprintf("unresolved-%d", time.Now().UnixNano())
+	}
+	remoteContextCommits[buildContext] = commit
+	return commit
 }
 
-func getBuildHashFromGitHash(buildInfo *model.BuildInfo, gitHash string, hashType string) string {
+func getBuildHashFromGitHash(fs afero.Fs, buildInfo *model.BuildInfo, gitHash string, hashType string) string {
+	if model.IsGitBuildContext(buildInfo.Context) {
+		// remote contexts are cloned by the builder, so their sources are identified by the commit of the remote ref
+		// instead of the commit of the local repository
+		gitHash, hashType = getRemoteContextCommit(buildInfo.Context), "remote_commit"
+	}
+
 	args := []string{}
 	for _, arg := range buildInfo.Args {
 		args = append(args, arg.String())
@@ -484,6 +719,13 @@ func getBuildHashFromGitHash(buildInfo *model.BuildInfo, gitHash string, hashTyp
 	fmt.Fprintf(&b, "context:%s;", buildInfo.Context)
 	fmt.Fprintf(&b, "dockerfile:%s;", buildInfo.Dockerfile)
 	fmt.Fprintf(&b, "image:%s;", buildInfo.Image)
+	// cache inputs are only added when defined so the hashes of existing builds are kept
+	if len(buildInfo.CacheInputs) > 0 {
+		fmt.Fprintf(&b, "cache_inputs:%s;", getCacheInputsText(fs, buildInfo.CacheInputs))
+	}
+	if buildInfo.Builder != "" {
+		fmt.Fprintf(&b, "builder:%s;", buildInfo.Builder)
+	}
 
 	oktetoBuildHash := sha256.Sum256([]byte(b.String()))
 	return hex.EncodeToString(oktetoBuildHash[:])
@@ -14,6 +14,7 @@
 package v2
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
@@ -30,6 +31,7 @@ import (
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/registry"
+	"github.com/okteto/okteto/pkg/repository"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
@@ -378,6 +380,79 @@ func TestBuildWithoutVolumeMountWithoutImage(t *testing.T) {
 	assert.NotEmpty(t, image)
 }
 
+type fakeExternalBuilder struct {
+	registry fakeRegistry
+	built    []string
+}
+
+func (b *fakeExternalBuilder) Build(_ context.Context, _ *model.BuildInfo, image string) error {
+	b.built = append(b.built, image)
+	return b.registry.AddImageByName(image)
+}
+
+func TestBuildWithExternalBuilder(t *testing.T) {
+	registry := newFakeRegistry()
+	builder := test.NewFakeOktetoBuilder(registry)
+	bc := NewFakeBuilder(builder, registry, fakeConfig{isOkteto: true}, &fakeAnalyticsTracker{})
+	externalBuilder := &fakeExternalBuilder{registry: registry}
+	bc.ExternalBuilder = externalBuilder
+	manifest := &model.Manifest{
+		Name: "test",
+		Build: model.ManifestBuild{
+			"api": &model.BuildInfo{
+				Context: "api",
+				Builder: model.EarthlyBuilder,
+			},
+		},
+	}
+
+	image, err := bc.buildServiceImages(context.Background(), manifest, "api", &types.BuildOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "okteto.dev/test-api:okteto", image)
+	assert.Equal(t, []string{"okteto.dev/test-api:okteto"}, externalBuilder.built)
+}
+
+func TestShowContext(t *testing.T) {
+	previous := okteto.CurrentStore
+	t.Cleanup(func() { okteto.CurrentStore = previous })
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {
+				Namespace: "test",
+				IsOkteto:  true,
+			},
+		},
+		CurrentContext: "test",
+	}
+	registry := newFakeRegistry()
+	builder := test.NewFakeOktetoBuilder(registry)
+	bc := NewFakeBuilder(builder, registry, fakeConfig{isOkteto: true}, &fakeAnalyticsTracker{})
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "api/Dockerfile", []byte("FROM alpine"), 0600))
+	manifest := &model.Manifest{
+		Name: "test",
+		Build: model.ManifestBuild{
+			"api": &model.BuildInfo{
+				Context:    "api",
+				Dockerfile: "Dockerfile",
+			},
+			"web": &model.BuildInfo{
+				Context: "web",
+				Builder: model.EarthlyBuilder,
+			},
+		},
+	}
+
+	out := &bytes.Buffer{}
+	err := bc.showContext(fs, manifest, []string{"api", "web"}, &types.BuildOptions{}, out)
+	require.NoError(t, err)
+	assert.Equal(t, `Service 'api':
+File        Size
+Dockerfile  11B
+1 files, 11B
+`, out.String())
+}
+
 func TestBuildWithoutVolumeMountWithImage(t *testing.T) {
 	ctx := context.Background()
 	okteto.CurrentStore = &okteto.OktetoContextStore{
@@ -807,10 +882,92 @@ func Test_getBuildHashFromCommit(t *testing.T) {
 	}
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			got := getBuildHashFromCommit(tc.input.buildInfo, tc.input.repo.sha)
+			got := getBuildHashFromCommit(afero.NewMemMapFs(), tc.input.buildInfo, tc.input.repo.sha)
 			expectedHash := sha256.Sum256([]byte(tc.expected))
 			assert.Equal(t, hex.EncodeToString(expectedHash[:]), got)
 		})
 	}
 
 }
+
+func Test_getCacheInputsText(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "go.sum", []byte("github.com/okteto/okteto v1"), 0600))
+	require.NoError(t, afero.WriteFile(fs, "frontend/package-lock.json", []byte("{}"), 0600))
+
+	text := getCacheInputsText(fs, []string{"go.sum", "*/package-lock.json", "missing.lock"})
+	assert.Contains(t, text, "go.sum=")
+	assert.Contains(t, text, "frontend/package-lock.json=")
+	assert.Contains(t, text, ";missing.lock=")
+
+	require.NoError(t, afero.WriteFile(fs, "go.sum", []byte("github.com/okteto/okteto v2"), 0600))
+	assert.NotEqual(t, text, getCacheInputsText(fs, []string{"go.sum", "*/package-lock.json", "missing.lock"}))
+}
+
+func Test_getBuildHashFromCommitWithCacheInputs(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	lockfile := filepath.Join("/app", "go.sum")
+	require.NoError(t, afero.WriteFile(fs, lockfile, []byte("v1"), 0600))
+	buildInfo := &model.BuildInfo{Context: "api", CacheInputs: []string{lockfile}}
+
+	hash := getBuildHashFromCommit(fs, buildInfo, "123")
+	assert.NotEqual(t, getBuildHashFromCommit(fs, &model.BuildInfo{Context: "api"}, "123"), hash)
+	assert.Equal(t, hash, getBuildHashFromCommit(fs, buildInfo, "123"))
+
+	require.NoError(t, afero.WriteFile(fs, lockfile, []byte("v2"), 0600))
+	assert.NotEqual(t, hash, getBuildHashFromCommit(fs, buildInfo, "123"))
+}
+
+func Test_getBuildHashFromCommitWithRemoteContext(t *testing.T) {
+	remoteCommit := "abc"
+	calls := 0
+	getRemoteCommit = func(_ context.Context, repositoryURL, ref string) (string, error) {
+		calls++
+		assert.Equal(t, "https://github.com/okteto/movies.git", repositoryURL)
+		assert.Equal(t, "main", ref)
+		return remoteCommit, nil
+	}
+	t.Cleanup(func() {
+		getRemoteCommit = repository.GetRemoteCommit
+		remoteContextCommits = map[string]string{}
+	})
+	buildInfo := &model.BuildInfo{Context: "https://github.com/okteto/movies#main:api"}
+
+	hash := getBuildHashFromCommit(afero.NewMemMapFs(), buildInfo, "123")
+	assert.Equal(t, hash, getBuildHashFromCommit(afero.NewMemMapFs(), buildInfo, "456"))
+	assert.Equal(t, 1, calls)
+
+	remoteContextCommits = map[string]string{}
+	remoteCommit = "def"
+	assert.NotEqual(t, hash, getBuildHashFromCommit(afero.NewMemMapFs(), buildInfo, "123"))
+
+	remoteContextCommits = map[string]string{}
+	getRemoteCommit = func(context.Context, string, string) (string, error) {
+		return "", assert.AnError
+	}
+	unresolved := getBuildHashFromCommit(afero.NewMemMapFs(), buildInfo, "123")
+	assert.NotEqual(t, hash, unresolved)
+	assert.Equal(t, unresolved, getBuildHashFromCommit(afero.NewMemMapFs(), buildInfo, "123"))
+}
+
+func Test_getBuildHashFromCommitWithBuilder(t *testing.T) {
+	buildInfo := &model.BuildInfo{Context: "api"}
+	hash := getBuildHashFromCommit(afero.NewMemMapFs(), buildInfo, "123")
+	buildInfo.Builder = model.BazelBuilder
+	assert.NotEqual(t, hash, getBuildHashFromCommit(afero.NewMemMapFs(), buildInfo, "123"))
+}
+
+func Test_getBuildInfoWithBuildArgs(t *testing.T) {
+	buildInfo := &model.BuildInfo{Args: model.BuildArgs{{Name: "NODE_ENV", Value: "production"}}}
+	result, err := getBuildInfoWithBuildArgs(buildInfo, []string{"NODE_ENV=development", "DEBUG=true", "EMPTY"})
+	require.NoError(t, err)
+	assert.Equal(t, model.BuildArgs{
+		{Name: "NODE_ENV", Value: "development"},
+		{Name: "DEBUG", Value: "true"},
+		{Name: "EMPTY", Value: ""},
+	}, result.Args)
+	assert.Equal(t, model.BuildArgs{{Name: "NODE_ENV", Value: "production"}}, buildInfo.Args)
+
+	_, err = getBuildInfoWithBuildArgs(buildInfo, []string{"=value"})
+	assert.Error(t, err)
+}
@@ -94,5 +94,10 @@ func (oc oktetoBuilderConfig) GetBuildContextHash(buildInfo *model.BuildInfo) st
 		oktetoLog.Info("error trying to get tree hash for build context '%s': %w", buildContext, err)
 	}
 
-	return getBuildHashFromGitHash(buildInfo, treeHash, "tree_hash")
+	return getBuildHashFromGitHash(oc.fs, buildInfo, treeHash, "tree_hash")
+}
+
+// GetBuildHash returns the build hash of a service for the commit of the repository
+func (oc oktetoBuilderConfig) GetBuildHash(buildInfo *model.BuildInfo) string {
+	return getBuildHashFromCommit(oc.fs, buildInfo, oc.GetGitCommit())
 }
@@ -84,7 +84,7 @@ func (ic imageChecker) getImageDigestReferenceForService(manifestName, svcToBuil
 		possibleReferences = []string{buildInfo.Image}
 	} else if serviceHasVolumesToInclude(buildInfo) {
 		possibleReferences = ic.tagger.getImageReferencesForTagWithDefaults(manifestName, svcToBuild, buildHash)
-	} else if serviceHasDockerfile(buildInfo) && buildInfo.Image == "" {
+	} else if serviceBuildsImage(buildInfo) && buildInfo.Image == "" {
 		possibleReferences = ic.tagger.getImageReferencesForTagWithDefaults(manifestName, svcToBuild, buildHash)
 	} else if buildInfo.Image != "" {
 		possibleReferences = []string{buildInfo.Image}
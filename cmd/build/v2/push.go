@@ -0,0 +1,151 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/okteto/okteto/pkg/analytics"
+	"github.com/okteto/okteto/pkg/cmd/build"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+)
+
+// pushPipelineInterface pushes the built images in the background
+type pushPipelineInterface interface {
+	types.ImagePushQueue
+	Wait() []build.PushResult
+}
+
+// backgroundPush is a built image whose push has been queued in the push pipeline
+type backgroundPush struct {
+	service string
+	image   string
+	scan    *model.BuildScan
+	meta    *analytics.ImageBuildMetadata
+}
+
+// getPushPipeline returns the pipeline to push the images in the background, or nil if the images have to be pushed as part of their build.
+// The okteto builder pushes the images while building them, and the images to sign or with a sbom have to be pushed before the next step
+func (bc *OktetoBuilder) getPushPipeline(options *types.BuildOptions, toBuildSvcs []string) pushPipelineInterface {
+	if okteto.Context().Builder != "" || len(toBuildSvcs) < 2 {
+		return nil
+	}
+	if options.Sign || options.SBOM != "" || options.LocalOutputPath != "" {
+		return nil
+	}
+	if bc.newPushPipeline != nil {
+		return bc.newPushPipeline()
+	}
+	return build.NewPushPipeline()
+}
+
+// isPushedInBackground returns true if the image of the service is pushed by the push pipeline.
+// The images with volume mounts are built from the image of the service, so they are pushed as part of the build
+func isPushedInBackground(buildInfo *model.BuildInfo, options *types.BuildOptions) bool {
+	return options.PushQueue != nil && serviceHasDockerfile(buildInfo) && !serviceHasVolumesToInclude(buildInfo)
+}
+
+// waitForPushes waits for the images pushed in the background, shows the timing of every image and
+// sets the env vars of the services with the digest of the pushed images
+func (bc *OktetoBuilder) waitForPushes(ctx context.Context, pipeline pushPipelineInterface, pushes []*backgroundPush) error {
+	if len(pushes) == 0 {
+		return nil
+	}
+	results := map[string]build.PushResult{}
+	for _, r := range pipeline.Wait() {
+		results[r.Tag] = r
+	}
+	var pushErr error
+	for _, p := range pushes {
+		p.meta.PushDuration = results[p.image].Duration
+		if err := results[p.image].Err; err != nil && pushErr == nil {
+			pushErr = fmt.Errorf("error pushing image of service '%s': %w", p.service, err)
+		}
+	}
+
+	// the images are scanned before printing the summary, so it includes the vulnerabilities found
+	imagesWithDigest := map[string]string{}
+	var scanErr error
+	if pushErr == nil {
+		for _, p := range pushes {
+			imageWithDigest, err := bc.Registry.GetImageTagWithDigest(p.image)
+			if err != nil {
+				scanErr = fmt.Errorf("error accessing image at registry %s: %v", p.image, err)
+				break
+			}
+			if err := bc.scanImage(ctx, p.service, imageWithDigest, p.scan, p.meta); err != nil {
+				scanErr = err
+				break
+			}
+			imagesWithDigest[p.service] = imageWithDigest
+		}
+	}
+
+	summary := &bytes.Buffer{}
+	printBuildSummary(summary, pushes, results)
+	oktetoLog.Println(strings.TrimSuffix(summary.String(), "\n"))
+
+	if pushErr != nil {
+		return pushErr
+	}
+	if scanErr != nil {
+		return scanErr
+	}
+	for _, p := range pushes {
+		p.meta.Success = true
+		bc.SetServiceEnvVars(p.service, imagesWithDigest[p.service])
+	}
+	return nil
+}
+
+// printBuildSummary writes the build and push duration of every image pushed in the background,
+// and the vulnerabilities found in the images with a scan policy
+func printBuildSummary(out io.Writer, pushes []*backgroundPush, results map[string]build.PushResult) {
+	withScan := false
+	for _, p := range pushes {
+		if p.scan != nil {
+			withScan = true
+		}
+	}
+
+	w := tabwriter.NewWriter(out, 1, 1, 2, ' ', 0)
+	header := "Service\tImage\tBuild\tPush"
+	if withScan {
+		header += "\tVulnerabilities"
+	}
+	fmt.Fprintln(w, header)
+	for _, p := range pushes {
+		push := results[p.image].Duration.Round(time.Second).String()
+		if results[p.image].Err != nil {
+			push = "failed"
+		}
+		row := fmt.Sprintf("%s\t%s\t%s\t%s", p.service, p.image, p.meta.BuildDuration.Round(time.Second), push)
+		if withScan {
+			row += fmt.Sprintf("\t%s", getScanSummaryColumn(p.scan, p.meta))
+		}
+		fmt.Fprintln(w, row)
+	}
+	if err := w.Flush(); err != nil {
+		oktetoLog.Infof("failed to print the build summary: %s", err)
+	}
+}
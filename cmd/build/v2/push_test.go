@@ -0,0 +1,234 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/internal/test"
+	"github.com/okteto/okteto/pkg/analytics"
+	"github.com/okteto/okteto/pkg/cmd/build"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePushPipeline struct {
+	enqueued []string
+	errs     map[string]error
+}
+
+func (f *fakePushPipeline) Enqueue(_ context.Context, tag string) {
+	f.enqueued = append(f.enqueued, tag)
+}
+
+func (f *fakePushPipeline) Wait() []build.PushResult {
+	results := []build.PushResult{}
+	for _, tag := range f.enqueued {
+		results = append(results, build.PushResult{Tag: tag, Duration: 3 * time.Second, Err: f.errs[tag]})
+	}
+	return results
+}
+
+// fakeQueueBuilder builds the images and queues their push like the docker daemon builder
+type fakeQueueBuilder struct {
+	*test.FakeOktetoBuilder
+}
+
+func (fb fakeQueueBuilder) Run(ctx context.Context, opts *types.BuildOptions) error {
+	if err := fb.FakeOktetoBuilder.Run(ctx, opts); err != nil {
+		return err
+	}
+	if opts.PushQueue != nil && opts.Tag != "" {
+		opts.PushQueue.Enqueue(ctx, opts.Tag)
+	}
+	return nil
+}
+
+func newPushTestManifest(t *testing.T) *model.Manifest {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM alpine"), 0600))
+	return &model.Manifest{
+		Name: "test",
+		Build: model.ManifestBuild{
+			"a": &model.BuildInfo{
+				Context:    dir,
+				Dockerfile: filepath.Join(dir, "Dockerfile"),
+				Image:      "okteto/a:test",
+			},
+			"b": &model.BuildInfo{
+				Context:    dir,
+				Dockerfile: filepath.Join(dir, "Dockerfile"),
+				Image:      "okteto/b:test",
+				DependsOn:  []string{"a"},
+			},
+		},
+	}
+}
+
+func setPushTestContext(t *testing.T, builder string) {
+	previous := okteto.CurrentStore
+	t.Cleanup(func() { okteto.CurrentStore = previous })
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {
+				Namespace: "test",
+				Registry:  "my-registry",
+				Builder:   builder,
+			},
+		},
+		CurrentContext: "test",
+	}
+}
+
+func TestBuildWithPushPipeline(t *testing.T) {
+	setPushTestContext(t, "")
+	registry := newFakeRegistry()
+	pipeline := &fakePushPipeline{}
+	tracker := &fakeAnalyticsTracker{}
+	bc := NewFakeBuilder(fakeQueueBuilder{test.NewFakeOktetoBuilder(registry)}, registry, fakeConfig{}, tracker)
+	bc.newPushPipeline = func() pushPipelineInterface { return pipeline }
+
+	options := &types.BuildOptions{Manifest: newPushTestManifest(t)}
+	require.NoError(t, bc.Build(context.Background(), options))
+
+	assert.Equal(t, []string{"okteto/a:test", "okteto/b:test"}, pipeline.enqueued)
+	assert.Nil(t, options.PushQueue)
+	assert.Equal(t, "okteto/b:test", bc.GetBuildEnvVars()["OKTETO_BUILD_B_IMAGE"])
+
+	require.Len(t, tracker.metaPayload, 2)
+	for _, meta := range tracker.metaPayload {
+		assert.True(t, meta.Success)
+		assert.Equal(t, 3*time.Second, meta.PushDuration)
+	}
+}
+
+func TestBuildWithPushPipelineError(t *testing.T) {
+	setPushTestContext(t, "")
+	registry := newFakeRegistry()
+	pipeline := &fakePushPipeline{errs: map[string]error{"okteto/b:test": errors.New("denied")}}
+	bc := NewFakeBuilder(fakeQueueBuilder{test.NewFakeOktetoBuilder(registry)}, registry, fakeConfig{}, &fakeAnalyticsTracker{})
+	bc.newPushPipeline = func() pushPipelineInterface { return pipeline }
+
+	err := bc.Build(context.Background(), &types.BuildOptions{Manifest: newPushTestManifest(t)})
+	assert.ErrorContains(t, err, "error pushing image of service 'b': denied")
+}
+
+func TestGetPushPipeline(t *testing.T) {
+	pipeline := &fakePushPipeline{}
+	bc := &OktetoBuilder{newPushPipeline: func() pushPipelineInterface { return pipeline }}
+	svcs := []string{"a", "b"}
+
+	tests := []struct {
+		name     string
+		builder  string
+		options  *types.BuildOptions
+		svcs     []string
+		expected pushPipelineInterface
+	}{
+		{
+			name:     "docker daemon with several images",
+			options:  &types.BuildOptions{},
+			svcs:     svcs,
+			expected: pipeline,
+		},
+		{
+			name:    "okteto builder",
+			builder: "buildkit.okteto.dev",
+			options: &types.BuildOptions{},
+			svcs:    svcs,
+		},
+		{
+			name:    "single image",
+			options: &types.BuildOptions{},
+			svcs:    []string{"a"},
+		},
+		{
+			name:    "signed images",
+			options: &types.BuildOptions{Sign: true},
+			svcs:    svcs,
+		},
+		{
+			name:    "sbom",
+			options: &types.BuildOptions{SBOM: "spdx-json"},
+			svcs:    svcs,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setPushTestContext(t, tt.builder)
+			result := bc.getPushPipeline(tt.options, tt.svcs)
+			if tt.expected == nil {
+				assert.Nil(t, result)
+				return
+			}
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestPrintBuildSummary(t *testing.T) {
+	pushes := []*backgroundPush{
+		{service: "api", image: "okteto/api:dev", meta: &analytics.ImageBuildMetadata{BuildDuration: 62 * time.Second}},
+		{service: "frontend", image: "okteto/frontend:dev", meta: &analytics.ImageBuildMetadata{BuildDuration: 5 * time.Second}},
+	}
+	results := map[string]build.PushResult{
+		"okteto/api:dev":      {Tag: "okteto/api:dev", Duration: 12 * time.Second},
+		"okteto/frontend:dev": {Tag: "okteto/frontend:dev", Duration: time.Second, Err: errors.New("denied")},
+	}
+	out := &bytes.Buffer{}
+	printBuildSummary(out, pushes, results)
+	assert.Equal(t, `Service   Image                Build  Push
+api       okteto/api:dev       1m2s   12s
+frontend  okteto/frontend:dev  5s     failed
+`, out.String())
+}
+
+func TestPrintBuildSummaryWithScan(t *testing.T) {
+	pushes := []*backgroundPush{
+		{
+			service: "api",
+			image:   "okteto/api:dev",
+			scan:    &model.BuildScan{FailOn: "critical"},
+			meta:    &analytics.ImageBuildMetadata{BuildDuration: 62 * time.Second, Vulnerabilities: map[string]int{"CRITICAL": 1, "LOW": 2}, ScanFailed: true},
+		},
+		{
+			service: "worker",
+			image:   "okteto/worker:dev",
+			scan:    &model.BuildScan{},
+			meta:    &analytics.ImageBuildMetadata{BuildDuration: 5 * time.Second, Vulnerabilities: map[string]int{}},
+		},
+		{service: "frontend", image: "okteto/frontend:dev", meta: &analytics.ImageBuildMetadata{BuildDuration: 5 * time.Second}},
+	}
+	results := map[string]build.PushResult{
+		"okteto/api:dev":      {Tag: "okteto/api:dev", Duration: 12 * time.Second},
+		"okteto/worker:dev":   {Tag: "okteto/worker:dev", Duration: time.Second},
+		"okteto/frontend:dev": {Tag: "okteto/frontend:dev", Duration: time.Second},
+	}
+	out := &bytes.Buffer{}
+	printBuildSummary(out, pushes, results)
+	assert.Equal(t, `Service   Image                Build  Push  Vulnerabilities
+api       okteto/api:dev       1m2s   12s   1 CRITICAL, 2 LOW (failed)
+worker    okteto/worker:dev    5s     1s    no vulnerabilities found
+frontend  okteto/frontend:dev  5s     1s    -
+`, out.String())
+}
@@ -0,0 +1,35 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+
+	"github.com/okteto/okteto/pkg/types"
+)
+
+// generateReusedImageSBOM generates the sbom of the image of a service that wasn't built because it was already in the registry.
+// The sbom of the built images is generated by the v1 builder after their push, so every image of the build has a sbom with '--sbom'
+func (bc *OktetoBuilder) generateReusedImageSBOM(ctx context.Context, image string, options *types.BuildOptions) error {
+	if options.SBOM == "" {
+		return nil
+	}
+	return bc.V1Builder.GenerateSBOM(ctx, &types.BuildOptions{
+		Tag:        image,
+		SBOM:       options.SBOM,
+		SBOMOutput: options.SBOMOutput,
+		SBOMAttest: options.SBOMAttest,
+		SBOMKey:    options.SBOMKey,
+	})
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/internal/test"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSBOMGenerator struct {
+	generated []string
+	attested  []string
+}
+
+func (g *fakeSBOMGenerator) Generate(_ context.Context, image, format, outputDir string) (string, error) {
+	g.generated = append(g.generated, image)
+	return filepath.Join(outputDir, fmt.Sprintf("sbom.%s.json", format)), nil
+}
+
+func (g *fakeSBOMGenerator) Attest(_ context.Context, image, _, _, _ string) error {
+	g.attested = append(g.attested, image)
+	return nil
+}
+
+func TestBuildWithSBOM(t *testing.T) {
+	ctx := context.Background()
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {
+				Namespace: "test",
+				IsOkteto:  true,
+			},
+		},
+		CurrentContext: "test",
+	}
+
+	dir, err := createDockerfile(t)
+	require.NoError(t, err)
+
+	registry := newFakeRegistry()
+	builder := test.NewFakeOktetoBuilder(registry)
+	bc := NewFakeBuilder(builder, registry, fakeConfig{isOkteto: true}, &fakeAnalyticsTracker{})
+	sbom := &fakeSBOMGenerator{}
+	bc.V1Builder.SBOM = sbom
+	manifest := &model.Manifest{
+		Name: "test",
+		Build: model.ManifestBuild{
+			"test": &model.BuildInfo{
+				Context:    dir,
+				Dockerfile: filepath.Join(dir, "Dockerfile"),
+			},
+		},
+	}
+	options := &types.BuildOptions{SBOM: "spdx", SBOMAttest: true}
+
+	_, err = bc.buildServiceImages(ctx, manifest, "test", options)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"okteto.dev/test-test:okteto"}, sbom.generated)
+	assert.Equal(t, []string{"okteto.dev/test-test:okteto"}, sbom.attested)
+
+	require.NoError(t, bc.generateReusedImageSBOM(ctx, "okteto.dev/test-test@sha256:1234", options))
+	assert.Equal(t, []string{"okteto.dev/test-test:okteto", "okteto.dev/test-test@sha256:1234"}, sbom.generated)
+
+	require.NoError(t, bc.generateReusedImageSBOM(ctx, "okteto.dev/other@sha256:1234", &types.BuildOptions{}))
+	assert.Len(t, sbom.generated, 2)
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/pkg/analytics"
+	"github.com/okteto/okteto/pkg/cmd/build"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+// imageScannerInterface scans images for vulnerabilities
+type imageScannerInterface interface {
+	Scan(ctx context.Context, image string) (*build.ScanReport, error)
+}
+
+// scanImage applies the scan policy of the service to the built image
+func (bc *OktetoBuilder) scanImage(ctx context.Context, svcName, image string, scan *model.BuildScan, meta *analytics.ImageBuildMetadata) error {
+	if scan == nil || image == "" {
+		return nil
+	}
+	if bc.Scanner == nil {
+		bc.Scanner = build.NewTrivyScanner()
+	}
+
+	oktetoLog.Spinner(fmt.Sprintf("Scanning image of '%s' for vulnerabilities...", svcName))
+	oktetoLog.StartSpinner()
+	scanDurationStart := time.Now()
+	report, err := bc.Scanner.Scan(ctx, image)
+	oktetoLog.StopSpinner()
+	meta.ScanDuration = time.Since(scanDurationStart)
+	if err != nil {
+		var uErr oktetoErrors.UserError
+		if errors.As(err, &uErr) {
+			// keep the hint, i.e. how to install trivy
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("error scanning image of service '%s': %w", svcName, uErr.E),
+				Hint: uErr.Hint,
+			}
+		}
+		return fmt.Errorf("error scanning image of service '%s': %w", svcName, err)
+	}
+
+	counts := report.CountBySeverity()
+	meta.Vulnerabilities = counts
+	summary := getScanSummary(counts)
+	oktetoLog.Information("Vulnerability scan of '%s': %s", svcName, summary)
+
+	if failing := countFromSeverity(counts, scan.FailOn); failing > 0 {
+		meta.ScanFailed = true
+		return fmt.Errorf("image of service '%s' has %d vulnerabilities with severity %s or higher", svcName, failing, strings.ToUpper(scan.FailOn))
+	}
+	if warning := countFromSeverity(counts, scan.GetWarnOn()); warning > 0 {
+		oktetoLog.Warning("image of service '%s' has %d vulnerabilities with severity %s or higher", svcName, warning, strings.ToUpper(scan.GetWarnOn()))
+	}
+	return nil
+}
+
+// countFromSeverity returns the number of vulnerabilities with the given severity or higher
+func countFromSeverity(counts map[string]int, severity string) int {
+	if severity == "" {
+		return 0
+	}
+	total := 0
+	for _, s := range model.ScanSeverities[model.GetScanSeverityLevel(severity):] {
+		total += counts[s]
+	}
+	return total
+}
+
+// getScanSummaryColumn returns the vulnerabilities of an image for the build summary
+func getScanSummaryColumn(scan *model.BuildScan, meta *analytics.ImageBuildMetadata) string {
+	switch {
+	case scan == nil || meta.Vulnerabilities == nil:
+		return "-"
+	case meta.ScanFailed:
+		return fmt.Sprintf("%s (failed)", getScanSummary(meta.Vulnerabilities))
+	default:
+		return getScanSummary(meta.Vulnerabilities)
+	}
+}
+
+func getScanSummary(counts map[string]int) string {
+	parts := []string{}
+	for i := len(model.ScanSeverities) - 1; i >= 0; i-- {
+		if n := counts[model.ScanSeverities[i]]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, model.ScanSeverities[i]))
+		}
+	}
+	if len(parts) == 0 {
+		return "no vulnerabilities found"
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,107 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/analytics"
+	"github.com/okteto/okteto/pkg/cmd/build"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeImageScanner struct {
+	report *build.ScanReport
+	err    error
+}
+
+func (s fakeImageScanner) Scan(context.Context, string) (*build.ScanReport, error) {
+	return s.report, s.err
+}
+
+func TestScanImage(t *testing.T) {
+	report := &build.ScanReport{
+		Vulnerabilities: []build.Vulnerability{
+			{ID: "CVE-1", Severity: "CRITICAL"},
+			{ID: "CVE-2", Severity: "HIGH"},
+			{ID: "CVE-3", Severity: "LOW"},
+		},
+	}
+	var tests = []struct {
+		name      string
+		scan      *model.BuildScan
+		report    *build.ScanReport
+		expectErr bool
+	}{
+		{
+			name:   "no scan policy",
+			report: report,
+		},
+		{
+			name:   "warn only",
+			scan:   &model.BuildScan{},
+			report: report,
+		},
+		{
+			name:      "fail on critical",
+			scan:      &model.BuildScan{FailOn: "critical"},
+			report:    report,
+			expectErr: true,
+		},
+		{
+			name:   "fail on critical without critical vulnerabilities",
+			scan:   &model.BuildScan{FailOn: "CRITICAL"},
+			report: &build.ScanReport{Vulnerabilities: []build.Vulnerability{{ID: "CVE-2", Severity: "HIGH"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bc := &OktetoBuilder{Scanner: fakeImageScanner{report: tt.report}}
+			meta := analytics.NewImageBuildMetadata()
+			err := bc.scanImage(context.Background(), "api", "okteto.dev/api:okteto", tt.scan, meta)
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectErr, meta.ScanFailed)
+			if tt.scan != nil {
+				assert.Equal(t, tt.report.CountBySeverity(), meta.Vulnerabilities)
+			}
+		})
+	}
+}
+
+func TestScanImageKeepsHint(t *testing.T) {
+	bc := &OktetoBuilder{Scanner: fakeImageScanner{err: oktetoErrors.UserError{
+		E:    errors.New("trivy is required to scan images for vulnerabilities"),
+		Hint: "Install trivy",
+	}}}
+	err := bc.scanImage(context.Background(), "api", "okteto.dev/api:okteto", &model.BuildScan{}, analytics.NewImageBuildMetadata())
+
+	uErr, ok := err.(oktetoErrors.UserError)
+	require.True(t, ok)
+	assert.Equal(t, "error scanning image of service 'api': trivy is required to scan images for vulnerabilities", uErr.Error())
+	assert.Equal(t, "Install trivy", uErr.Hint)
+}
+
+func TestGetScanSummary(t *testing.T) {
+	assert.Equal(t, "no vulnerabilities found", getScanSummary(map[string]int{}))
+	assert.Equal(t, "1 CRITICAL, 3 HIGH", getScanSummary(map[string]int{"HIGH": 3, "CRITICAL": 1}))
+}
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/require"
 )
 
@@ -228,3 +229,6 @@ func (fc fakeConfig) GetGitCommit() string                        { return fc.sh
 func (fc fakeConfig) IsOkteto() bool                              { return fc.isOkteto }
 func (fc fakeConfig) GetAnonymizedRepo() string                   { return fc.repoURL }
 func (fc fakeConfig) GetBuildContextHash(*model.BuildInfo) string { return "" }
+func (fc fakeConfig) GetBuildHash(buildInfo *model.BuildInfo) string {
+	return getBuildHashFromCommit(afero.NewMemMapFs(), buildInfo, fc.sha)
+}
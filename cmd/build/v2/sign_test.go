@@ -0,0 +1,78 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/internal/test"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeImageSigner struct {
+	signed []string
+}
+
+func (s *fakeImageSigner) Sign(_ context.Context, image, _ string) error {
+	s.signed = append(s.signed, image)
+	return nil
+}
+
+func TestBuildWithSign(t *testing.T) {
+	ctx := context.Background()
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {
+				Namespace: "test",
+				IsOkteto:  true,
+			},
+		},
+		CurrentContext: "test",
+	}
+
+	dir, err := createDockerfile(t)
+	require.NoError(t, err)
+
+	registry := newFakeRegistry()
+	builder := test.NewFakeOktetoBuilder(registry)
+	bc := NewFakeBuilder(builder, registry, fakeConfig{isOkteto: true}, &fakeAnalyticsTracker{})
+	signer := &fakeImageSigner{}
+	bc.V1Builder.Signer = signer
+	manifest := &model.Manifest{
+		Name: "test",
+		Build: model.ManifestBuild{
+			"test": &model.BuildInfo{
+				Context:    dir,
+				Dockerfile: filepath.Join(dir, "Dockerfile"),
+			},
+		},
+	}
+	options := &types.BuildOptions{Sign: true}
+
+	image, err := bc.buildServiceImages(ctx, manifest, "test", options)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"okteto.dev/test-test:okteto"}, signer.signed)
+
+	require.NoError(t, bc.signReusedImage(ctx, "okteto.dev/test-test@sha256:1234", options))
+	assert.Equal(t, []string{image, "okteto.dev/test-test@sha256:1234"}, signer.signed)
+
+	require.NoError(t, bc.signReusedImage(ctx, "okteto.dev/other@sha256:1234", &types.BuildOptions{}))
+	assert.Len(t, signer.signed, 2)
+}
@@ -62,7 +62,7 @@ Inferred tag is constructed using the following:
 */
 func (i imageTagger) getServiceImageReference(manifestName, svcName string, b *model.BuildInfo, buildHash string) string {
 	// when b.Image is set or services does not have dockerfile then no infer reference and return what is set on the manifest
-	if b.Image != "" || !serviceHasDockerfile(b) {
+	if b.Image != "" || !serviceBuildsImage(b) {
 		return b.Image
 	}
 
@@ -142,7 +142,7 @@ func Test_ImageTaggerWithoutVolumes_GetServiceImageReference(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			tagger := newImageTagger(tc.cfg)
-			buildHash := getBuildHashFromCommit(tc.b, tc.cfg.GetGitCommit())
+			buildHash := tc.cfg.GetBuildHash(tc.b)
 			assert.Equal(t, tc.expectedImage, tagger.getServiceImageReference("test", "test", tc.b, buildHash))
 		})
 	}
@@ -198,7 +198,7 @@ func TestImageTaggerWithVolumesTag(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			tagger := newImageWithVolumesTagger(tc.cfg)
-			buildHash := getBuildHashFromCommit(tc.b, tc.cfg.GetGitCommit())
+			buildHash := tc.cfg.GetBuildHash(tc.b)
 			assert.Equal(t, tc.expectedImage, tagger.getServiceImageReference("test", "test", tc.b, buildHash))
 		})
 	}
@@ -0,0 +1,86 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// Catalog development environment catalog commands
+func Catalog(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "catalog",
+		Short: "Development environment catalog commands",
+		Args:  utils.NoArgsAccepted("https://www.okteto.com/docs/reference/cli/#catalog"),
+	}
+	cmd.AddCommand(list(ctx))
+	cmd.AddCommand(deploy(ctx))
+	return cmd
+}
+
+// initOktetoContext loads the okteto context of the namespace and checks it's an okteto context
+func initOktetoContext(ctx context.Context, namespace string, show bool) error {
+	ctxResource := &model.ContextResource{}
+	if err := ctxResource.UpdateNamespace(namespace); err != nil {
+		return err
+	}
+
+	ctxOptions := &contextCMD.ContextOptions{
+		Namespace: ctxResource.Namespace,
+		Show:      show,
+	}
+	if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+		return err
+	}
+
+	if !okteto.IsOkteto() {
+		return oktetoErrors.ErrContextIsNotOktetoCluster
+	}
+	return nil
+}
+
+// getCatalogItem returns the item of the catalog with the given name or id
+func getCatalogItem(ctx context.Context, c types.CatalogInterface, name string) (*types.CatalogItem, error) {
+	items, err := c.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		if items[i].Name == name || items[i].ID == name {
+			return &items[i], nil
+		}
+	}
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		names = append(names, item.Name)
+	}
+	hint := "Your catalog is empty. Ask your administrator to add development environments to the catalog"
+	if len(names) > 0 {
+		hint = fmt.Sprintf("Available items: %s", strings.Join(names, ", "))
+	}
+	return nil, oktetoErrors.UserError{
+		E:    fmt.Errorf("catalog item '%s' not found", name),
+		Hint: hint,
+	}
+}
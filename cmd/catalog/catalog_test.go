@@ -0,0 +1,181 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package catalog
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/cmd/pipeline"
+	"github.com/okteto/okteto/internal/test/client"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var fakeItems = []types.CatalogItem{
+	{
+		ID:            "1",
+		Name:          "movies",
+		RepositoryURL: "https://github.com/okteto/movies",
+		Branch:        "main",
+		ManifestPath:  "okteto.yml",
+		Variables: []types.Variable{
+			{Name: "DB", Value: "mongo"},
+			{Name: "REPLICAS", Value: "1"},
+		},
+	},
+	{
+		ID:            "2",
+		Name:          "voting",
+		RepositoryURL: "https://github.com/okteto/voting-app",
+		Branch:        "dev",
+		Variables: []types.Variable{
+			{Name: "DB", Value: "redis"},
+		},
+		ReadOnly: true,
+	},
+}
+
+func TestExecuteListCatalog(t *testing.T) {
+	c := client.NewFakeCatalogClient(fakeItems, nil)
+
+	var b bytes.Buffer
+	require.NoError(t, executeListCatalog(context.Background(), c, "", &b))
+	expected := "Name    Repository                            Branch  Variables\n" +
+		"movies  https://github.com/okteto/movies      main    DB, REPLICAS\n" +
+		"voting  https://github.com/okteto/voting-app  dev     DB\n"
+	assert.Equal(t, expected, b.String())
+
+	b.Reset()
+	require.NoError(t, executeListCatalog(context.Background(), c, "yaml", &b))
+	assert.Contains(t, b.String(), "- id: \"1\"\n  name: movies\n")
+
+	b.Reset()
+	require.NoError(t, executeListCatalog(context.Background(), c, "json", &b))
+	assert.Contains(t, b.String(), `"repository": "https://github.com/okteto/voting-app"`)
+
+	err := executeListCatalog(context.Background(), client.NewFakeCatalogClient(nil, assert.AnError), "", &b)
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestGetDeployOptions(t *testing.T) {
+	askDefault := func(_, defaultValue string) (string, error) {
+		return "", nil
+	}
+	tests := []struct {
+		name     string
+		item     string
+		flags    deployFlags
+		ask      askVariableFn
+		expected *pipeline.DeployOptions
+		err      bool
+	}{
+		{
+			name:  "catalog defaults",
+			item:  "movies",
+			flags: deployFlags{timeout: time.Minute},
+			expected: &pipeline.DeployOptions{
+				Name:       "movies",
+				Repository: "https://github.com/okteto/movies",
+				Branch:     "main",
+				File:       "okteto.yml",
+				Variables:  []string{"DB=mongo", "REPLICAS=1"},
+				Timeout:    time.Minute,
+			},
+		},
+		{
+			name: "flags override the catalog",
+			item: "1",
+			flags: deployFlags{
+				name:      "my-movies",
+				namespace: "cindy",
+				branch:    "feature",
+				variables: []string{"DB=postgres", "EXTRA=value"},
+				labels:    []string{"team"},
+				wait:      true,
+			},
+			ask: func(name, _ string) (string, error) {
+				assert.Equal(t, "REPLICAS", name)
+				return "3", nil
+			},
+			expected: &pipeline.DeployOptions{
+				Name:       "my-movies",
+				Namespace:  "cindy",
+				Repository: "https://github.com/okteto/movies",
+				Branch:     "feature",
+				File:       "okteto.yml",
+				Variables:  []string{"DB=postgres", "REPLICAS=3", "EXTRA=value"},
+				Labels:     []string{"team"},
+				Wait:       true,
+			},
+		},
+		{
+			name: "empty answers keep the default",
+			item: "movies",
+			ask:  askDefault,
+			expected: &pipeline.DeployOptions{
+				Name:       "movies",
+				Repository: "https://github.com/okteto/movies",
+				Branch:     "main",
+				File:       "okteto.yml",
+				Variables:  []string{"DB=mongo", "REPLICAS=1"},
+			},
+		},
+		{
+			name: "read-only items are not asked",
+			item: "voting",
+			ask: func(_, _ string) (string, error) {
+				return "", assert.AnError
+			},
+			expected: &pipeline.DeployOptions{
+				Name:       "voting",
+				Repository: "https://github.com/okteto/voting-app",
+				Branch:     "dev",
+				Variables:  []string{"DB=redis"},
+			},
+		},
+		{
+			name:  "read-only items reject variables",
+			item:  "voting",
+			flags: deployFlags{variables: []string{"DB=mysql"}},
+			err:   true,
+		},
+		{
+			name:  "invalid variable",
+			item:  "movies",
+			flags: deployFlags{variables: []string{"DB"}},
+			err:   true,
+		},
+		{
+			name: "not found",
+			item: "unknown",
+			err:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := client.NewFakeCatalogClient(fakeItems, nil)
+			opts, err := getDeployOptions(context.Background(), c, tt.item, tt.flags, tt.ask)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, opts)
+		})
+	}
+}
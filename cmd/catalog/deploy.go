@@ -0,0 +1,177 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package catalog
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/cmd/pipeline"
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// deployFlags represents the user input for a catalog deploy command
+type deployFlags struct {
+	name      string
+	namespace string
+	branch    string
+	wait      bool
+	timeout   time.Duration
+	variables []string
+	labels    []string
+}
+
+// askVariableFn asks the user for the value of a variable of a catalog item
+type askVariableFn func(name, defaultValue string) (string, error)
+
+func deploy(ctx context.Context) *cobra.Command {
+	flags := &deployFlags{}
+	cmd := &cobra.Command{
+		Use:   "deploy <name>",
+		Short: "Deploy a development environment of the catalog",
+		Args:  utils.ExactArgsAccepted(1, "https://www.okteto.com/docs/reference/cli/#catalog"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := initOktetoContext(ctx, flags.namespace, true); err != nil {
+				return err
+			}
+			c, err := okteto.NewOktetoClient()
+			if err != nil {
+				return err
+			}
+
+			var ask askVariableFn
+			if oktetoLog.IsInteractive() {
+				ask = askVariable
+			}
+			opts, err := getDeployOptions(ctx, c.Catalog(), args[0], *flags, ask)
+			if err != nil {
+				return err
+			}
+
+			pipelineCmd, err := pipeline.NewCommand()
+			if err != nil {
+				return err
+			}
+			if err := pipelineCmd.ExecuteDeployPipeline(ctx, opts); err != nil {
+				return fmt.Errorf("catalog deploy failed: %w", err)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.name, "name", "p", "", "name of the development environment (defaults to the name of the catalog item)")
+	cmd.Flags().StringVarP(&flags.namespace, "namespace", "n", "", "namespace where the development environment is deployed (defaults to the current namespace)")
+	cmd.Flags().StringVarP(&flags.branch, "branch", "b", "", "the branch to deploy (defaults to the branch of the catalog item)")
+	cmd.Flags().BoolVarP(&flags.wait, "wait", "w", false, "wait until the development environment finishes (defaults to false)")
+	cmd.Flags().DurationVarP(&flags.timeout, "timeout", "t", (5 * time.Minute), "the length of time to wait for completion, zero means never. Any other values should contain a corresponding time unit e.g. 1s, 2m, 3h ")
+	cmd.Flags().StringArrayVarP(&flags.variables, "var", "v", []string{}, "set a variable of the catalog item (can be set more than once)")
+	cmd.Flags().StringArrayVarP(&flags.labels, "label", "", []string{}, "set an environment label (can be set more than once)")
+	return cmd
+}
+
+// getDeployOptions translates a catalog item into the options to deploy it as a pipeline.
+// Variables of the item not set with --var are asked to the user if ask is not nil, using the value of the catalog as default
+func getDeployOptions(ctx context.Context, c types.CatalogInterface, itemName string, flags deployFlags, ask askVariableFn) (*pipeline.DeployOptions, error) {
+	item, err := getCatalogItem(ctx, c, itemName)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := map[string]string{}
+	for _, v := range flags.variables {
+		kv := strings.SplitN(v, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid variable value '%s': must follow KEY=VALUE format", v)
+		}
+		overrides[kv[0]] = kv[1]
+	}
+	if item.ReadOnly && len(overrides) > 0 {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("the variables of the catalog item '%s' are read-only", item.Name),
+			Hint: "Remove the '--var' flags and try again",
+		}
+	}
+
+	variables := []string{}
+	for _, v := range item.Variables {
+		value, ok := overrides[v.Name]
+		if !ok {
+			value = v.Value
+			if ask != nil && !item.ReadOnly {
+				answer, err := ask(v.Name, v.Value)
+				if err != nil {
+					return nil, err
+				}
+				if answer != "" {
+					value = answer
+				}
+			}
+		}
+		delete(overrides, v.Name)
+		variables = append(variables, fmt.Sprintf("%s=%s", v.Name, value))
+	}
+	for _, v := range flags.variables {
+		name := strings.SplitN(v, "=", 2)[0]
+		if _, ok := overrides[name]; ok {
+			variables = append(variables, v)
+		}
+	}
+
+	name := flags.name
+	if name == "" {
+		name = item.Name
+	}
+	branch := flags.branch
+	if branch == "" {
+		branch = item.Branch
+	}
+	return &pipeline.DeployOptions{
+		Name:       name,
+		Namespace:  flags.namespace,
+		Repository: item.RepositoryURL,
+		Branch:     branch,
+		File:       item.ManifestPath,
+		Variables:  variables,
+		Labels:     flags.labels,
+		Wait:       flags.wait,
+		Timeout:    flags.timeout,
+	}, nil
+}
+
+// askVariable asks the user for the value of a variable. An empty answer keeps the default value
+func askVariable(name, defaultValue string) (string, error) {
+	question := name
+	if defaultValue != "" {
+		question = fmt.Sprintf("%s [%s]", name, defaultValue)
+	}
+	if err := oktetoLog.Question("%s: ", question); err != nil {
+		return "", err
+	}
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("could not read the value of '%s': %w", name, err)
+	}
+	return strings.TrimSpace(answer), nil
+}
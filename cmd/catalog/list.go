@@ -0,0 +1,105 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+type listFlags struct {
+	output string
+}
+
+func list(ctx context.Context) *cobra.Command {
+	flags := &listFlags{}
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the development environments of the catalog",
+		Args:  utils.NoArgsAccepted("https://www.okteto.com/docs/reference/cli/#catalog"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(flags.output); err != nil {
+				return err
+			}
+			if err := initOktetoContext(ctx, "", flags.output == ""); err != nil {
+				return err
+			}
+			c, err := okteto.NewOktetoClient()
+			if err != nil {
+				return err
+			}
+			return executeListCatalog(ctx, c.Catalog(), flags.output, os.Stdout)
+		},
+	}
+	cmd.Flags().StringVarP(&flags.output, "output", "o", "", "output format. One of: ['json', 'yaml']")
+	return cmd
+}
+
+func validateOutput(output string) error {
+	switch output {
+	case "", "json", "yaml":
+		return nil
+	default:
+		return fmt.Errorf("output format is not accepted. Value must be one of: ['json', 'yaml']")
+	}
+}
+
+// executeListCatalog writes the items of the catalog in the given output format
+func executeListCatalog(ctx context.Context, c types.CatalogInterface, output string, w io.Writer) error {
+	items, err := c.List(ctx)
+	if err != nil {
+		return err
+	}
+	switch output {
+	case "json":
+		bytes, err := json.MarshalIndent(items, "", " ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(w, string(bytes))
+	case "yaml":
+		bytes, err := yaml.Marshal(items)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(w, string(bytes))
+	default:
+		tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join([]string{"Name", "Repository", "Branch", "Variables"}, "\t"))
+		for _, item := range items {
+			variables := "-"
+			if len(item.Variables) > 0 {
+				names := make([]string, 0, len(item.Variables))
+				for _, v := range item.Variables {
+					names = append(names, v.Name)
+				}
+				variables = strings.Join(names, ", ")
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", item.Name, item.RepositoryURL, item.Branch, variables)
+		}
+		tw.Flush()
+	}
+	return nil
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/cobra"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
 // oktetoClientProvider provides an okteto client ready to use or fail
@@ -54,10 +55,19 @@ type ContextCommand struct {
 
 	kubetokenController kubeconfigTokenController
 	OktetoContextWriter okteto.ContextConfigWriterInterface
+
+	// inClusterConfigGetter returns the kubeconfig of the credentials of the pod running okteto
+	inClusterConfigGetter func(namespace string) (*clientcmdapi.Config, error)
 }
 
 type ctxCmdOption func(*ContextCommand)
 
+func withInClusterConfigGetter(getter func(namespace string) (*clientcmdapi.Config, error)) ctxCmdOption {
+	return func(c *ContextCommand) {
+		c.inClusterConfigGetter = getter
+	}
+}
+
 func withKubeTokenController(k kubeconfigTokenController) ctxCmdOption {
 	return func(c *ContextCommand) {
 		c.kubetokenController = k
@@ -71,6 +81,8 @@ func NewContextCommand(ctxCmdOption ...ctxCmdOption) *ContextCommand {
 		LoginController:      login.NewLoginController(),
 		OktetoClientProvider: okteto.NewOktetoClientProvider(),
 		OktetoContextWriter:  okteto.NewContextConfigWriter(),
+
+		inClusterConfigGetter: kubeconfig.GetInCluster,
 	}
 	if utils.LoadBoolean(OktetoUseStaticKubetokenEnvVar) {
 		cfg.kubetokenController = newStaticKubetokenController()
@@ -135,6 +147,14 @@ func (c *ContextCommand) UseContext(ctx context.Context, ctxOptions *ContextOpti
 	created := false
 
 	ctxStore := okteto.ContextStore()
+	if ctxOptions.Context == kubeconfig.InClusterContext {
+		ctxOptions.InCluster = true
+	}
+	if ctxOptions.InCluster {
+		ctxOptions.Context = kubeconfig.InClusterContext
+		ctxOptions.IsOkteto = false
+	}
+
 	if okCtx, ok := ctxStore.Contexts[ctxOptions.Context]; ok && okCtx.IsOkteto {
 		ctxOptions.IsOkteto = true
 	}
@@ -148,7 +168,7 @@ func (c *ContextCommand) UseContext(ctx context.Context, ctxOptions *ContextOpti
 		ctxOptions.IsOkteto = true
 	}
 
-	if !ctxOptions.IsOkteto {
+	if !ctxOptions.IsOkteto && !ctxOptions.InCluster {
 
 		if isUrl(ctxOptions.Context) {
 			ctxOptions.Context = strings.TrimSuffix(ctxOptions.Context, "/")
@@ -181,6 +201,10 @@ func (c *ContextCommand) UseContext(ctx context.Context, ctxOptions *ContextOpti
 
 	}
 
+	if err := setNetworkSettings(ctxStore.Contexts[ctxOptions.Context], ctxOptions); err != nil {
+		return err
+	}
+
 	ctxStore.CurrentContext = ctxOptions.Context
 	c.initEnvVars()
 
@@ -188,6 +212,10 @@ func (c *ContextCommand) UseContext(ctx context.Context, ctxOptions *ContextOpti
 		if err := c.initOktetoContext(ctx, ctxOptions); err != nil {
 			return err
 		}
+	} else if ctxOptions.InCluster {
+		if err := c.initInClusterContext(ctxOptions); err != nil {
+			return err
+		}
 	} else {
 		if err := c.initKubernetesContext(ctxOptions); err != nil {
 			return err
@@ -253,6 +281,10 @@ func getClusterMetadata(ctx context.Context, namespace string, okClientProvider
 }
 
 func hasAccessToNamespace(ctx context.Context, c *ContextCommand, ctxOptions *ContextOptions) (bool, error) {
+	if ctxOptions.InCluster {
+		// service accounts usually can't get namespaces, the credentials are validated by the commands using them
+		return true, nil
+	}
 	if ctxOptions.IsOkteto {
 		okClient, err := c.OktetoClientProvider.Provide()
 		if err != nil {
@@ -326,6 +358,8 @@ func (c *ContextCommand) initOktetoContext(ctx context.Context, ctxOptions *Cont
 	okteto.Context().IsTrial = clusterMetadata.IsTrialLicense
 	okteto.Context().CompanyName = clusterMetadata.CompanyName
 
+	utils.WarnIfNewerVersionRequired(clusterMetadata.MinimumCLIVersion)
+
 	setSecrets(userContext.Secrets)
 
 	os.Setenv(model.OktetoUserNameEnvVar, okteto.Context().Username)
@@ -384,6 +418,24 @@ func (*ContextCommand) initKubernetesContext(ctxOptions *ContextOptions) error {
 	return nil
 }
 
+// initInClusterContext initializes the context with the credentials of the pod running okteto. No kubeconfig file is read or written
+func (c *ContextCommand) initInClusterContext(ctxOptions *ContextOptions) error {
+	cfg, err := c.inClusterConfigGetter(ctxOptions.Namespace)
+	if err != nil {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("failed to load the in-cluster credentials: %w", err),
+			Hint: "The in-cluster context is only available when okteto runs inside a Kubernetes pod",
+		}
+	}
+	ctxOptions.Namespace = cfg.Contexts[kubeconfig.InClusterContext].Namespace
+
+	okteto.AddInClusterContext(ctxOptions.Namespace, ctxOptions.Builder)
+	okteto.Context().Cfg = cfg
+	okteto.Context().IsOkteto = false
+
+	return nil
+}
+
 func (c ContextCommand) getUserContext(ctx context.Context, ctxName, ns, token string) (*types.UserContext, error) {
 	client, err := c.OktetoClientProvider.Provide(
 		okteto.WithCtxName(ctxName),
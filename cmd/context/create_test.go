@@ -23,11 +23,13 @@ import (
 	"testing"
 
 	authenticationv1 "k8s.io/api/authentication/v1"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
 	"github.com/okteto/okteto/internal/test"
 	"github.com/okteto/okteto/internal/test/client"
 	"github.com/okteto/okteto/pkg/constants"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/kubeconfig"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/stretchr/testify/assert"
@@ -735,3 +737,83 @@ func Test_replaceCredentialsTokenWithDynamicKubetoken(t *testing.T) {
 		})
 	}
 }
+
+func Test_useInClusterContext(t *testing.T) {
+	ctx := context.Background()
+
+	fakeInClusterConfig := func(namespace string) (*clientcmdapi.Config, error) {
+		if namespace == "" {
+			namespace = "ci"
+		}
+		return &clientcmdapi.Config{
+			Clusters: map[string]*clientcmdapi.Cluster{
+				kubeconfig.InClusterContext: {Server: "https://10.0.0.1:443"},
+			},
+			AuthInfos: map[string]*clientcmdapi.AuthInfo{
+				kubeconfig.InClusterContext: {TokenFile: "/var/run/secrets/kubernetes.io/serviceaccount/token"},
+			},
+			Contexts: map[string]*clientcmdapi.Context{
+				kubeconfig.InClusterContext: {Cluster: kubeconfig.InClusterContext, AuthInfo: kubeconfig.InClusterContext, Namespace: namespace},
+			},
+			CurrentContext: kubeconfig.InClusterContext,
+		}, nil
+	}
+
+	var tests = []struct {
+		name              string
+		ctxOptions        *ContextOptions
+		getter            func(namespace string) (*clientcmdapi.Config, error)
+		expectedNamespace string
+		expectedErr       bool
+	}{
+		{
+			name:              "in-cluster flag",
+			ctxOptions:        &ContextOptions{InCluster: true, Save: true},
+			getter:            fakeInClusterConfig,
+			expectedNamespace: "ci",
+		},
+		{
+			name:              "in-cluster context name",
+			ctxOptions:        &ContextOptions{Context: kubeconfig.InClusterContext, Save: true},
+			getter:            fakeInClusterConfig,
+			expectedNamespace: "ci",
+		},
+		{
+			name:              "in-cluster with namespace",
+			ctxOptions:        &ContextOptions{InCluster: true, Namespace: "staging", Save: true},
+			getter:            fakeInClusterConfig,
+			expectedNamespace: "staging",
+		},
+		{
+			name:       "not running in a cluster",
+			ctxOptions: &ContextOptions{InCluster: true, Save: true},
+			getter: func(string) (*clientcmdapi.Config, error) {
+				return nil, assert.AnError
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			okteto.CurrentStore = &okteto.OktetoContextStore{
+				Contexts: map[string]*okteto.OktetoContext{},
+			}
+			ctxController := newFakeContextCommand(&client.FakeOktetoClient{}, nil, nil)
+			withInClusterConfigGetter(tt.getter)(ctxController)
+
+			err := ctxController.UseContext(ctx, tt.ctxOptions)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, kubeconfig.InClusterContext, okteto.CurrentStore.CurrentContext)
+			okCtx := okteto.Context()
+			assert.True(t, okCtx.IsInCluster)
+			assert.False(t, okCtx.IsOkteto)
+			assert.Equal(t, tt.expectedNamespace, okCtx.Namespace)
+			assert.Equal(t, kubeconfig.InClusterContext, okCtx.Cfg.CurrentContext)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package context
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/discovery"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
+)
+
+// getDefaultManifest returns the default okteto manifest of the git repository of the current folder in the current namespace
+func getDefaultManifest(ctx context.Context) (*model.Manifest, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the current working directory: %w", err)
+	}
+	c, err := okteto.NewOktetoClient()
+	if err != nil {
+		oktetoLog.Infof("the default manifest is not available: %s", err)
+		return nil, discovery.ErrOktetoManifestNotFound
+	}
+	return loadDefaultManifest(ctx, cwd, okteto.Context().Namespace, c, afero.NewOsFs())
+}
+
+// loadDefaultManifest loads the default okteto manifest stored in okteto for the git repository of cwd and a namespace.
+// The manifest is loaded as if it was in cwd, so its relative paths point to the local checkout, but it's never written to disk
+func loadDefaultManifest(ctx context.Context, cwd, namespace string, c types.OktetoInterface, fs afero.Fs) (*model.Manifest, error) {
+	repository, err := model.GetRepositoryURL(cwd)
+	if err != nil {
+		oktetoLog.Infof("the default manifest is not available: %s", err)
+		return nil, discovery.ErrOktetoManifestNotFound
+	}
+
+	content, err := c.Pipeline().GetDefaultManifest(ctx, namespace, repository)
+	if err != nil {
+		if errors.Is(err, oktetoErrors.ErrNotFound) {
+			oktetoLog.Infof("repository '%s' doesn't have a default manifest in namespace '%s'", repository, namespace)
+			return nil, discovery.ErrOktetoManifestNotFound
+		}
+		return nil, err
+	}
+
+	manifest, err := model.GetDefaultManifestWithFilesystem(cwd, content, fs)
+	if err != nil {
+		return nil, err
+	}
+	// the manifest is passed along to the commands run by okteto, e.g. 'okteto build' in the deploy section,
+	// and to the later reads of this command, so it's only fetched once
+	if err := os.Setenv(constants.OktetoDefaultManifestEnvVar, string(content)); err != nil {
+		return nil, err
+	}
+	oktetoLog.Information("Using the default okteto manifest of '%s' from namespace '%s'", repository, namespace)
+	return manifest, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package context
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/okteto/okteto/internal/test/client"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/discovery"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDefaultManifest(t *testing.T) {
+	t.Setenv(constants.OktetoDefaultManifestEnvVar, "")
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	_, err = repo.CreateRemote(&gitconfig.RemoteConfig{Name: "origin", URLs: []string{"https://github.com/okteto/movies"}})
+	require.NoError(t, err)
+
+	content := []byte("build:\n  api:\n    context: api\ndeploy:\n  - okteto build\ndev:\n  api:\n    command: bash\n    sync:\n      - api:/usr/src/app\n")
+	c := &client.FakeOktetoClient{
+		PipelineClient: client.NewFakePipelineClient(&client.FakePipelineResponses{DefaultManifest: content}),
+	}
+	fs := afero.NewOsFs()
+
+	manifest, err := loadDefaultManifest(context.Background(), dir, "cindy", c, fs)
+	require.NoError(t, err)
+	assert.True(t, manifest.IsV2)
+	require.Contains(t, manifest.Dev, "api")
+	assert.Equal(t, filepath.Join(dir, "api"), manifest.Dev["api"].Sync.Folders[0].LocalPath)
+	assert.NoFileExists(t, filepath.Join(dir, "okteto.yml"))
+	assert.Equal(t, string(content), os.Getenv(constants.OktetoDefaultManifestEnvVar))
+
+	c.PipelineClient = client.NewFakePipelineClient(&client.FakePipelineResponses{DefaultManifestErr: oktetoErrors.ErrNotFound})
+	_, err = loadDefaultManifest(context.Background(), dir, "cindy", c, fs)
+	assert.ErrorIs(t, err, discovery.ErrOktetoManifestNotFound)
+
+	c.PipelineClient = client.NewFakePipelineClient(&client.FakePipelineResponses{DefaultManifestErr: assert.AnError})
+	_, err = loadDefaultManifest(context.Background(), dir, "cindy", c, fs)
+	assert.ErrorIs(t, err, assert.AnError)
+
+	_, err = loadDefaultManifest(context.Background(), t.TempDir(), "cindy", c, fs)
+	assert.ErrorIs(t, err, discovery.ErrOktetoManifestNotFound)
+}
@@ -34,8 +34,13 @@ type ContextOptions struct {
 	IsCtxCommand          bool
 	CheckNamespaceAccess  bool
 	IsOkteto              bool
+	InCluster             bool
 	raiseNotCtxError      bool
 	InsecureSkipTlsVerify bool
+	Proxy                 string
+	NoProxy               string
+	CABundle              string
+	CertFingerprint       string
 }
 
 func (o *ContextOptions) InitFromContext() {
@@ -56,6 +61,7 @@ func (o *ContextOptions) InitFromContext() {
 			o.Namespace = okCtx.Namespace
 		}
 		o.IsOkteto = okCtx.IsOkteto
+		o.InCluster = okCtx.IsInCluster
 	}
 }
 
@@ -74,6 +74,11 @@ func UpdateKubeconfigCMD(okClientProvider oktetoClientProvider) *cobra.Command {
 }
 
 func (k *KubeconfigCMD) execute(okCtx *okteto.OktetoContext, kubeconfigPaths []string) error {
+	if okCtx.IsInCluster {
+		oktetoLog.Information("The context '%s' uses the in-cluster credentials, no kubeconfig file is needed", okCtx.Name)
+		return nil
+	}
+
 	contextName := okCtx.Name
 	if okCtx.IsOkteto {
 		contextName = okteto.UrlToKubernetesContext(contextName)
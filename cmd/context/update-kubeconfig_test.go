@@ -15,6 +15,7 @@ package context
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/okteto/okteto/internal/test"
@@ -356,3 +357,22 @@ func Test_ExecuteUpdateKubeconfig_ForNonOktetoContext(t *testing.T) {
 	cfg := kubeconfig.Get(kubeconfigPaths)
 	assert.NotNil(t, cfg.AuthInfos["test-user"].Exec)
 }
+
+func Test_ExecuteUpdateKubeconfig_ForInClusterContext(t *testing.T) {
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		CurrentContext: kubeconfig.InClusterContext,
+		Contexts: map[string]*okteto.OktetoContext{
+			kubeconfig.InClusterContext: {
+				Name:        kubeconfig.InClusterContext,
+				Namespace:   "ci",
+				Cfg:         &api.Config{CurrentContext: kubeconfig.InClusterContext},
+				IsInCluster: true,
+			},
+		},
+	}
+
+	file := filepath.Join(t.TempDir(), "config")
+	err := newKubeconfigController(nil).execute(okteto.Context(), []string{file})
+	assert.NoError(t, err)
+	assert.NoFileExists(t, file)
+}
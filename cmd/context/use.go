@@ -38,9 +38,10 @@ const (
 func Use() *cobra.Command {
 	ctxOptions := &ContextOptions{}
 	cmd := &cobra.Command{
-		Use:   "use [<url>|Kubernetes context]",
-		Args:  utils.MaximumNArgsAccepted(1, "https://okteto.com/docs/reference/cli/#use"),
-		Short: "Set the default context",
+		Use:               "use [<url>|Kubernetes context]",
+		Args:              utils.MaximumNArgsAccepted(1, "https://okteto.com/docs/reference/cli/#use"),
+		ValidArgsFunction: utils.CompleteContexts,
+		Short:             "Set the default context",
 		Long: `Set the default context
 
 A context is a group of cluster access parameters. Each context contains a Kubernetes cluster, a user, and a namespace.
@@ -59,6 +60,10 @@ You can also specify an Okteto URL:
 Or a Kubernetes context:
 
     $ okteto context use kubernetes_context_name
+
+When okteto runs inside a Kubernetes pod, like a CI runner, it can use the credentials of the pod's service account:
+
+    $ okteto context use --in-cluster
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
@@ -84,6 +89,11 @@ Or a Kubernetes context:
 	cmd.Flags().StringVarP(&ctxOptions.Token, "token", "t", "", "API token for authentication")
 	cmd.Flags().StringVarP(&ctxOptions.Namespace, "namespace", "n", "", "namespace of your okteto context")
 	cmd.Flags().StringVarP(&ctxOptions.Builder, "builder", "b", "", "url of the builder service")
+	cmd.Flags().StringVarP(&ctxOptions.Proxy, "proxy", "", "", "proxy used by the context for HTTP and HTTPS requests. Defaults to the HTTP_PROXY and HTTPS_PROXY env vars")
+	cmd.Flags().StringVarP(&ctxOptions.NoProxy, "no-proxy", "", "", "comma-separated list of hosts excluded from the proxy. Defaults to the NO_PROXY env var")
+	cmd.Flags().StringVarP(&ctxOptions.CertFingerprint, "certificate-fingerprint", "", "", "sha256 fingerprint of the server certificate trusted by the context, as printed by 'openssl x509 -noout -fingerprint -sha256'")
+	cmd.Flags().StringVarP(&ctxOptions.CABundle, "ca-bundle", "", "", "path to a PEM file with certificate authorities trusted by the context in addition to the system ones")
+	cmd.Flags().BoolVarP(&ctxOptions.InCluster, "in-cluster", "", false, "use the credentials of the service account of the pod running okteto, without a kubeconfig file")
 	cmd.Flags().BoolVarP(&ctxOptions.OnlyOkteto, "okteto", "", false, "only shows okteto context options")
 	if err := cmd.Flags().MarkHidden("okteto"); err != nil {
 		oktetoLog.Infof("failed to mark 'okteto' flag as hidden: %s", err)
@@ -106,14 +116,29 @@ func (c *ContextCommand) Run(ctx context.Context, ctxOptions *ContextOptions) er
 	ctxOptions.InitFromContext()
 	ctxOptions.InitFromEnvVars()
 
-	if ctxOptions.Token == "" && kubeconfig.InCluster() && !isValidCluster(ctxOptions.Context) {
-		if ctxOptions.IsCtxCommand {
-			return oktetoErrors.ErrTokenFlagNeeded
+	if ctxOptions.Token == "" && !ctxOptions.InCluster && kubeconfig.InCluster() && !isValidCluster(ctxOptions.Context) {
+		if ctxOptions.Context != "" || ctxOptions.IsOkteto {
+			if ctxOptions.IsCtxCommand {
+				return oktetoErrors.ErrTokenFlagNeeded
+			}
+			return oktetoErrors.UserError{
+				E:    oktetoErrors.ErrTokenEnvVarNeeded,
+				Hint: fmt.Sprintf("Visit %s for more information about getting your token.", personalAccessTokenURL),
+			}
 		}
-		return oktetoErrors.UserError{
-			E:    oktetoErrors.ErrTokenEnvVarNeeded,
-			Hint: fmt.Sprintf("Visit %s for more information about getting your token.", personalAccessTokenURL),
+		// without a context, the credentials of the pod running okteto are used
+		oktetoLog.Information("Using the in-cluster credentials of the pod running okteto")
+		ctxOptions.InCluster = true
+	}
+
+	if ctxOptions.InCluster {
+		if ctxOptions.Context != "" && ctxOptions.Context != kubeconfig.InClusterContext {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("the context '%s' can't be used with the in-cluster credentials", ctxOptions.Context),
+				Hint: "Remove the '--in-cluster' flag or the context argument",
+			}
 		}
+		ctxOptions.Context = kubeconfig.InClusterContext
 	}
 
 	if ctxOptions.Context == "" {
@@ -18,17 +18,22 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/devcontainer"
 	"github.com/okteto/okteto/pkg/discovery"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoHttp "github.com/okteto/okteto/pkg/http"
 	"github.com/okteto/okteto/pkg/k8s/kubeconfig"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/afero"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
@@ -114,6 +119,47 @@ func isValidCluster(cluster string) bool {
 	return false
 }
 
+// setNetworkSettings stores the proxy, the CA bundle and the certificate fingerprint flags of 'okteto context use' in the okteto context
+func setNetworkSettings(okCtx *okteto.OktetoContext, ctxOptions *ContextOptions) error {
+	if ctxOptions.Proxy != "" {
+		u, err := url.Parse(ctxOptions.Proxy)
+		if err != nil || u.Host == "" {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("invalid proxy '%s'", ctxOptions.Proxy),
+				Hint: "Use a proxy url like 'http://proxy.example.com:3128'",
+			}
+		}
+		okCtx.Proxy = ctxOptions.Proxy
+	}
+	if ctxOptions.NoProxy != "" {
+		okCtx.NoProxy = ctxOptions.NoProxy
+	}
+	if ctxOptions.CABundle != "" {
+		path, err := filepath.Abs(ctxOptions.CABundle)
+		if err != nil {
+			return err
+		}
+		if _, err := oktetoHttp.LoadCABundle(path); err != nil {
+			return oktetoErrors.UserError{
+				E:    err,
+				Hint: "The CA bundle must be a file with one or more PEM encoded certificates",
+			}
+		}
+		okCtx.CABundle = path
+	}
+	if ctxOptions.CertFingerprint != "" {
+		fingerprint, err := oktetoHttp.ParseCertificateFingerprint(ctxOptions.CertFingerprint)
+		if err != nil {
+			return oktetoErrors.UserError{
+				E:    err,
+				Hint: "Get the fingerprint of the certificate with 'openssl x509 -noout -fingerprint -sha256 -in <certificate>'",
+			}
+		}
+		okCtx.CertFingerprint = fingerprint
+	}
+	return nil
+}
+
 func addKubernetesContext(cfg *clientcmdapi.Config, ctxResource *model.ContextResource) error {
 	if cfg == nil {
 		return fmt.Errorf(oktetoErrors.ErrKubernetesContextNotFound, ctxResource.Context, config.GetKubeconfigPath())
@@ -175,13 +221,26 @@ func LoadManifestWithContext(ctx context.Context, opts ManifestOptions) (*model.
 		}
 		manifest, err = model.GetManifestV2(opts.Filename)
 		if err != nil {
-			return nil, err
+			notFound := errors.Is(err, discovery.ErrOktetoManifestNotFound) || errors.Is(err, oktetoErrors.ErrCouldNotInferAnyManifest)
+			if !notFound || opts.Filename != "" || !okteto.IsOkteto() {
+				return nil, err
+			}
+			manifest, err = getDefaultManifest(ctx)
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 
 	manifest.Namespace = okteto.Context().Namespace
 	manifest.Context = okteto.Context().Name
 
+	if len(manifest.Dev) == 0 {
+		if err := importDevContainer(afero.NewOsFs(), manifest, opts.Filename); err != nil {
+			return nil, err
+		}
+	}
+
 	for _, dev := range manifest.Dev {
 		if err := utils.LoadManifestRc(dev); err != nil {
 			return nil, err
@@ -194,6 +253,46 @@ func LoadManifestWithContext(ctx context.Context, opts ManifestOptions) (*model.
 	return manifest, nil
 }
 
+// importDevContainer configures the dev section from the devcontainer.json of the folder of the manifest, if any
+func importDevContainer(fs afero.Fs, manifest *model.Manifest, manifestPath string) error {
+	workspace, err := getDevContainerWorkspace(manifestPath)
+	if err != nil {
+		return err
+	}
+	dc, err := devcontainer.Load(fs, workspace)
+	if err != nil {
+		if errors.Is(err, devcontainer.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	dev, err := dc.ToDev(workspace)
+	if err != nil {
+		return fmt.Errorf("error importing devcontainer.json: %w", err)
+	}
+	if err := dev.SetDefaults(); err != nil {
+		return fmt.Errorf("error importing devcontainer.json: %w", err)
+	}
+	if manifest.Dev == nil {
+		manifest.Dev = model.ManifestDevs{}
+	}
+	manifest.Dev[dev.Name] = dev
+	oktetoLog.Information("Development container '%s' imported from devcontainer.json", dev.Name)
+	return nil
+}
+
+// getDevContainerWorkspace returns the folder of the manifest, or the current folder when the manifest is discovered from it
+func getDevContainerWorkspace(manifestPath string) (string, error) {
+	if manifestPath == "" {
+		return os.Getwd()
+	}
+	absPath, err := filepath.Abs(manifestPath)
+	if err != nil {
+		return "", err
+	}
+	return model.GetWorkdirFromManifestPath(absPath), nil
+}
+
 func LoadStackWithContext(ctx context.Context, name, namespace string, stackPaths []string) (*model.Stack, error) {
 	ctxResource, err := utils.LoadStackContext(stackPaths)
 	if err != nil {
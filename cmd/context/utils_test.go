@@ -15,13 +15,17 @@ package context
 
 import (
 	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/okteto/okteto/pkg/externalresource"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
@@ -272,3 +276,96 @@ context: manifest-context
 		})
 	}
 }
+
+func Test_setNetworkSettings(t *testing.T) {
+	invalidBundle := filepath.Join(t.TempDir(), "invalid.pem")
+	require.NoError(t, os.WriteFile(invalidBundle, []byte("not a certificate"), 0600))
+
+	var tests = []struct {
+		name     string
+		opts     *ContextOptions
+		expected *okteto.OktetoContext
+		wantErr  bool
+	}{
+		{
+			name:     "no flags keep the previous values",
+			opts:     &ContextOptions{},
+			expected: &okteto.OktetoContext{Proxy: "http://old:3128", NoProxy: "old"},
+		},
+		{
+			name:     "proxy flags",
+			opts:     &ContextOptions{Proxy: "http://proxy.example.com:3128", NoProxy: ".cluster.local"},
+			expected: &okteto.OktetoContext{Proxy: "http://proxy.example.com:3128", NoProxy: ".cluster.local"},
+		},
+		{
+			name:    "invalid proxy",
+			opts:    &ContextOptions{Proxy: "proxy"},
+			wantErr: true,
+		},
+		{
+			name:     "certificate fingerprint",
+			opts:     &ContextOptions{CertFingerprint: "SHA256:AB" + strings.Repeat(":00", 31)},
+			expected: &okteto.OktetoContext{Proxy: "http://old:3128", NoProxy: "old", CertFingerprint: "ab" + strings.Repeat("0", 62)},
+		},
+		{
+			name:    "invalid certificate fingerprint",
+			opts:    &ContextOptions{CertFingerprint: "ab:cd"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid ca bundle",
+			opts:    &ContextOptions{CABundle: invalidBundle},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			okCtx := &okteto.OktetoContext{Proxy: "http://old:3128", NoProxy: "old"}
+			err := setNetworkSettings(okCtx, tt.opts)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, okCtx)
+		})
+	}
+}
+
+func Test_importDevContainer(t *testing.T) {
+	workspace := filepath.Join("/", "src", "movies")
+	var tests = []struct {
+		name         string
+		manifestPath string
+	}{
+		{
+			name:         "manifest in the workspace",
+			manifestPath: filepath.Join(workspace, "okteto.yml"),
+		},
+		{
+			name:         "manifest in the .okteto folder",
+			manifestPath: filepath.Join(workspace, ".okteto", "okteto.yml"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fs, filepath.Join(workspace, ".devcontainer.json"), []byte(`{"name": "api", "image": "node:18", "postCreateCommand": "npm install"}`), 0600))
+
+			manifest := &model.Manifest{}
+			require.NoError(t, importDevContainer(fs, manifest, tt.manifestPath))
+			require.Contains(t, manifest.Dev, "api")
+			assert.Equal(t, workspace, manifest.Dev["api"].Sync.Folders[0].LocalPath)
+			assert.True(t, manifest.Dev["api"].PersistentVolumeEnabled())
+		})
+	}
+}
+
+func Test_importDevContainerNotFound(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, filepath.Join("/", "src", ".devcontainer.json"), []byte(`{"image": "node:18"}`), 0600))
+
+	manifest := &model.Manifest{}
+	require.NoError(t, importDevContainer(fs, manifest, filepath.Join("/", "src", "movies", "okteto.yml")))
+	assert.Empty(t, manifest.Dev)
+}
@@ -0,0 +1,174 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/cost"
+	"github.com/okteto/okteto/pkg/format"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// defaultPricingFile is the pricing file used when '--pricing' is not set
+const defaultPricingFile = "pricing.yml"
+
+type estimateFlags struct {
+	dir       string
+	name      string
+	namespace string
+	context   string
+	pricing   string
+	output    string
+}
+
+func estimate(ctx context.Context) *cobra.Command {
+	flags := &estimateFlags{}
+	cmd := &cobra.Command{
+		Use:   "estimate",
+		Short: "Estimate the resources and the cost of a development environment",
+		Long: `Estimate the resources and the cost of a development environment.
+
+The estimation adds up the resources requested by the containers and the volumes of the development environment.
+Use '--dir' with the folder written by 'okteto deploy --export-dir' to estimate it before deploying it,
+or run it without '--dir' to estimate the development environments deployed in a namespace.
+
+The cost is calculated with the prices of '--pricing' (defaults to '$OKTETO_HOME/pricing.yml', if it exists):
+
+  currency: USD
+  cpu: 0.03      # price of a CPU per hour
+  memory: 0.004  # price of a GB of memory per hour
+  storage: 0.1   # price of a GB of storage per month`,
+		Args: utils.NoArgsAccepted("https://www.okteto.com/docs/reference/cli/#cost"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(flags.output); err != nil {
+				return err
+			}
+			fs := afero.NewOsFs()
+			pricing, err := getPricing(fs, flags.pricing)
+			if err != nil {
+				return err
+			}
+
+			var objects []runtime.Object
+			if flags.dir != "" {
+				objects, err = cost.GetObjectsFromDir(fs, flags.dir)
+				if err != nil {
+					return err
+				}
+			} else {
+				ctxOptions := &contextCMD.ContextOptions{
+					Context:   flags.context,
+					Namespace: flags.namespace,
+					Show:      flags.output == "",
+				}
+				if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+					return err
+				}
+				c, _, err := okteto.GetK8sClient()
+				if err != nil {
+					return fmt.Errorf("failed to load okteto context '%s': %w", okteto.Context().Name, err)
+				}
+				labelSelector := ""
+				if flags.name != "" {
+					labelSelector = fmt.Sprintf("%s=%s", model.DeployedByLabel, format.ResourceK8sMetaString(flags.name))
+				}
+				objects, err = cost.GetObjectsFromNamespace(ctx, okteto.Context().Namespace, labelSelector, c)
+				if err != nil {
+					return err
+				}
+			}
+			return printEstimate(os.Stdout, cost.NewEstimate(objects, pricing), flags.output)
+		},
+	}
+	cmd.Flags().StringVar(&flags.dir, "dir", "", "folder with the kubernetes manifests of the development environment, like the one written by 'okteto deploy --export-dir'")
+	cmd.Flags().StringVar(&flags.name, "name", "", "name of the development environment deployed in the namespace (defaults to all the development environments of the namespace)")
+	cmd.Flags().StringVarP(&flags.namespace, "namespace", "n", "", "namespace of the development environment (defaults to the current namespace)")
+	cmd.Flags().StringVarP(&flags.context, "context", "c", "", "context of the development environment (defaults to the current context)")
+	cmd.Flags().StringVar(&flags.pricing, "pricing", "", "path of the pricing file (defaults to '$OKTETO_HOME/pricing.yml')")
+	cmd.Flags().StringVarP(&flags.output, "output", "o", "", "output format. One of: ['json', 'yaml']")
+	return cmd
+}
+
+func validateOutput(output string) error {
+	switch output {
+	case "", "json", "yaml":
+		return nil
+	default:
+		return fmt.Errorf("output format is not accepted. Value must be one of: ['json', 'yaml']")
+	}
+}
+
+// getPricing returns the pricing of the file. Without a file, the default pricing file is used if it exists
+func getPricing(fs afero.Fs, path string) (*cost.Pricing, error) {
+	if path == "" {
+		path = filepath.Join(config.GetOktetoHome(), defaultPricingFile)
+		if _, err := fs.Stat(path); err != nil {
+			return nil, nil
+		}
+	}
+	return cost.LoadPricing(fs, path)
+}
+
+// printEstimate writes the estimate in the given output format
+func printEstimate(w io.Writer, e *cost.Estimate, output string) error {
+	switch output {
+	case "json":
+		bytes, err := json.MarshalIndent(e, "", " ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(bytes))
+	case "yaml":
+		bytes, err := yaml.Marshal(e)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(w, string(bytes))
+	default:
+		tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+		cols := []string{"Kind", "Name", "Replicas", "CPU", "Memory", "Storage"}
+		if e.Pricing != nil {
+			cols = append(cols, strings.TrimSpace(fmt.Sprintf("Monthly %s", e.Currency)))
+		}
+		fmt.Fprintln(tw, strings.Join(cols, "\t"))
+		for _, item := range append(e.Items, e.Total) {
+			row := []string{item.Kind, item.Name, fmt.Sprint(item.Replicas), cost.FormatCPU(item.CPU), cost.FormatGB(item.Memory), cost.FormatGB(item.Storage)}
+			if item.Name == "" {
+				row[1] = "-"
+			}
+			if item.Monthly != nil {
+				row = append(row, fmt.Sprintf("%.2f", *item.Monthly))
+			}
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		tw.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/cost"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintEstimate(t *testing.T) {
+	monthly := 12.5
+	total := 13.25
+	e := &cost.Estimate{
+		Items: []cost.Item{
+			{Kind: "Deployment", Name: "api", Replicas: 2, CPU: 0.5, Memory: 0.5, Monthly: &monthly},
+		},
+		Total:    cost.Item{Kind: "Total", Replicas: 2, CPU: 0.5, Memory: 0.5, Storage: 1, Monthly: &total},
+		Currency: "USD",
+		Pricing:  &cost.Pricing{Currency: "USD"},
+	}
+
+	var b bytes.Buffer
+	require.NoError(t, printEstimate(&b, e, ""))
+	expected := "Kind        Name  Replicas  CPU   Memory  Storage  Monthly USD\n" +
+		"Deployment  api   2         0.50  0.50Gi  0.00Gi   12.50\n" +
+		"Total       -     2         0.50  0.50Gi  1.00Gi   13.25\n"
+	assert.Equal(t, expected, b.String())
+
+	b.Reset()
+	require.NoError(t, printEstimate(&b, e, "json"))
+	assert.Contains(t, b.String(), `"monthly": 13.25`)
+
+	b.Reset()
+	require.NoError(t, printEstimate(&b, e, "yaml"))
+	assert.Contains(t, b.String(), "currency: USD\n")
+}
+
+func TestGetPricing(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("OKTETO_HOME", dir)
+	fs := afero.NewMemMapFs()
+
+	p, err := getPricing(fs, "")
+	require.NoError(t, err)
+	assert.Nil(t, p)
+
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, ".okteto", defaultPricingFile), []byte("cpu: 0.1\n"), 0600))
+	p, err = getPricing(fs, "")
+	require.NoError(t, err)
+	assert.Equal(t, &cost.Pricing{CPU: 0.1}, p)
+
+	_, err = getPricing(fs, "missing.yml")
+	assert.Error(t, err)
+}
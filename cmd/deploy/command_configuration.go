@@ -18,6 +18,7 @@ import (
 	"net/url"
 	"os"
 	"reflect"
+	"strconv"
 
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/cmd/stack"
@@ -74,10 +75,11 @@ func setDeployOptionsValuesFromManifest(ctx context.Context, deployOptions *Opti
 
 		mergeServicesToDeployFromOptionsAndManifest(deployOptions)
 		if len(deployOptions.servicesToDeploy) == 0 {
-			deployOptions.servicesToDeploy = []string{}
-			for service := range deployOptions.Manifest.Deploy.ComposeSection.Stack.Services {
-				deployOptions.servicesToDeploy = append(deployOptions.servicesToDeploy, service)
+			servicesToDeploy, err := stack.GetServicesEnabledByProfiles(deployOptions.Manifest.Deploy.ComposeSection.Stack, deployOptions.Profiles)
+			if err != nil {
+				return err
 			}
+			deployOptions.servicesToDeploy = servicesToDeploy
 		}
 		if len(deployOptions.Manifest.Deploy.ComposeSection.ComposesInfo) > 0 {
 			if err := stack.ValidateDefinedServices(deployOptions.Manifest.Deploy.ComposeSection.Stack, deployOptions.servicesToDeploy); err != nil {
@@ -157,6 +159,8 @@ func (dc *DeployCommand) addEnvVars(cwd string) {
 				oktetoLog.Infof("could not status: %s", err)
 			}
 		}
+		os.Setenv(constants.OktetoGitHeadEnvVar, sha)
+		os.Setenv(constants.OktetoGitDirtyEnvVar, strconv.FormatBool(!isClean))
 		if !isClean {
 			sha = utils.GetRandomSHA()
 		}
@@ -0,0 +1,138 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/repository"
+)
+
+// parseDependencyOverrides returns the local folder of the dependencies set with '--dependency-override'
+func parseDependencyOverrides(overrides []string) (map[string]string, error) {
+	result := map[string]string{}
+	for _, o := range overrides {
+		name, path, found := strings.Cut(o, "=")
+		if !found || name == "" || path == "" {
+			return nil, oktetoErrors.UserError{
+				E:    fmt.Errorf("invalid dependency override '%s'", o),
+				Hint: "Use the format '--dependency-override name=path'",
+			}
+		}
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dependency override '%s': %w", o, err)
+		}
+		info, err := os.Stat(absPath)
+		if err != nil || !info.IsDir() {
+			return nil, oktetoErrors.UserError{
+				E:    fmt.Errorf("the path '%s' of the dependency '%s' is not a folder", path, name),
+				Hint: "Set the path to a local copy of the repository of the dependency",
+			}
+		}
+		result[name] = absPath
+	}
+	return result, nil
+}
+
+// getDependencyRef returns the git reference of the repository of a dependency to deploy.
+// A version constraint is resolved to the highest tag of the repository that satisfies it
+func (dc *DeployCommand) getDependencyRef(ctx context.Context, name string, dep *model.Dependency) (string, error) {
+	switch {
+	case dep.Tag != "":
+		return dep.Tag, nil
+	case dep.Version != "":
+		listRemoteTags := dc.listRemoteTags
+		if listRemoteTags == nil {
+			listRemoteTags = repository.ListRemoteTags
+		}
+		tags, err := listRemoteTags(ctx, dep.Repository)
+		if err != nil {
+			return "", fmt.Errorf("could not resolve the version of the dependency '%s': %w", name, err)
+		}
+		tag, err := repository.ResolveVersion(tags, dep.Version)
+		if err != nil {
+			return "", oktetoErrors.UserError{
+				E:    fmt.Errorf("could not resolve the version of the dependency '%s': %w", name, err),
+				Hint: fmt.Sprintf("Check the tags of '%s'", dep.Repository),
+			}
+		}
+		oktetoLog.Information("Using version '%s' of dependency '%s'", tag, name)
+		return tag, nil
+	default:
+		return dep.Branch, nil
+	}
+}
+
+// deployLocalDependency deploys a dependency from a local folder running 'okteto deploy' on it
+func (dc *DeployCommand) deployLocalDependency(ctx context.Context, name, path string, dep *model.Dependency, namespace string, timeout time.Duration) error {
+	oktetoLog.Information("Using the local folder '%s' for dependency '%s'", path, name)
+	args := []string{"deploy", "--name", name, "--namespace", namespace}
+	if dep.ManifestPath != "" {
+		args = append(args, "--file", dep.ManifestPath)
+	}
+	for _, v := range model.SerializeEnvironmentVars(dep.Variables) {
+		args = append(args, "--var", v)
+	}
+	if dep.Wait {
+		args = append(args, "--wait", "--timeout", dep.GetTimeout(timeout).String())
+	}
+
+	runOktetoCommand := dc.runOktetoCommand
+	if runOktetoCommand == nil {
+		runOktetoCommand = runOktetoCommandInDir
+	}
+	if err := runOktetoCommand(ctx, path, args); err != nil {
+		return fmt.Errorf("could not deploy the dependency '%s' from '%s': %w", name, path, err)
+	}
+	return nil
+}
+
+// runOktetoCommandInDir runs the okteto binary in a folder
+func runOktetoCommandInDir(ctx context.Context, dir string, args []string) error {
+	return runOktetoCommandWithOutput(ctx, dir, args, nil, os.Stdout, os.Stderr)
+}
+
+// runOktetoCommandWithOutput runs the okteto binary in a folder with the extra environment variables writing its output to the given writers
+func runOktetoCommandWithOutput(ctx context.Context, dir string, args, env []string, stdout, stderr io.Writer) error {
+	bin, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	oktetoLog.Infof("running 'okteto %s' in %s", strings.Join(args, " "), dir)
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("'okteto deploy' exited with code %d", exitErr.ExitCode())
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pipelineCMD "github.com/okteto/okteto/cmd/pipeline"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingPipelineDeployer struct {
+	deployed []*pipelineCMD.DeployOptions
+}
+
+func (rd *recordingPipelineDeployer) ExecuteDeployPipeline(_ context.Context, opts *pipelineCMD.DeployOptions) error {
+	rd.deployed = append(rd.deployed, opts)
+	return nil
+}
+
+func TestParseDependencyOverrides(t *testing.T) {
+	dir := t.TempDir()
+	var tests = []struct {
+		name        string
+		overrides   []string
+		expected    map[string]string
+		expectedErr bool
+	}{
+		{
+			name:     "no overrides",
+			expected: map[string]string{},
+		},
+		{
+			name:      "valid override",
+			overrides: []string{"movies=" + dir},
+			expected:  map[string]string{"movies": dir},
+		},
+		{
+			name:        "missing path",
+			overrides:   []string{"movies"},
+			expectedErr: true,
+		},
+		{
+			name:        "path doesn't exist",
+			overrides:   []string{"movies=" + filepath.Join(dir, "not-found")},
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseDependencyOverrides(tt.overrides)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestGetDependencyRef(t *testing.T) {
+	dc := &DeployCommand{
+		listRemoteTags: func(_ context.Context, _ string) ([]string, error) {
+			return []string{"v1.0.0", "v1.1.0", "v2.0.0"}, nil
+		},
+	}
+	var tests = []struct {
+		name        string
+		dependency  *model.Dependency
+		expected    string
+		expectedErr bool
+	}{
+		{name: "default branch", dependency: &model.Dependency{}, expected: ""},
+		{name: "branch", dependency: &model.Dependency{Branch: "main"}, expected: "main"},
+		{name: "tag", dependency: &model.Dependency{Tag: "v1.0.0"}, expected: "v1.0.0"},
+		{name: "version", dependency: &model.Dependency{Version: "^1.0"}, expected: "v1.1.0"},
+		{name: "unsatisfied version", dependency: &model.Dependency{Version: "^3.0"}, expectedErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, err := dc.getDependencyRef(context.Background(), "movies", tt.dependency)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ref)
+		})
+	}
+}
+
+func TestDeployDependenciesWithOverride(t *testing.T) {
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {Name: "test", Namespace: "cindy", IsOkteto: true},
+		},
+		CurrentContext: "test",
+	}
+	dir := t.TempDir()
+	manifest := &model.Manifest{
+		Dependencies: model.ManifestDependencies{
+			"movies": &model.Dependency{Repository: "https://github.com/okteto/movies", ManifestPath: "okteto.yml"},
+			"api":    &model.Dependency{Repository: "https://github.com/okteto/api", Tag: "v1.0.0"},
+		},
+	}
+
+	pipeline := &recordingPipelineDeployer{}
+	var runDir string
+	var runArgs []string
+	dc := &DeployCommand{
+		PipelineCMD: pipeline,
+		runOktetoCommand: func(_ context.Context, dir string, args []string) error {
+			runDir = dir
+			runArgs = args
+			return nil
+		},
+	}
+	err := dc.deployDependencies(context.Background(), &Options{
+		Manifest:            manifest,
+		DependencyOverrides: []string{"movies=" + dir},
+		Timeout:             time.Minute,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, dir, runDir)
+	assert.Equal(t, []string{"deploy", "--name", "movies", "--namespace", "cindy", "--file", "okteto.yml", "--var", "OKTETO_ORIGIN=okteto-deploy"}, runArgs)
+	require.Len(t, pipeline.deployed, 1)
+	assert.Equal(t, "api", pipeline.deployed[0].Name)
+	assert.Equal(t, "v1.0.0", pipeline.deployed[0].Branch)
+
+	// overrides of unknown dependencies are rejected
+	err = dc.deployDependencies(context.Background(), &Options{
+		Manifest:            manifest,
+		DependencyOverrides: []string{"frontend=" + dir},
+	})
+	assert.Error(t, err)
+}
@@ -18,7 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"os/signal"
+	"strings"
 	"time"
 
 	buildv2 "github.com/okteto/okteto/cmd/build/v2"
@@ -27,6 +27,7 @@ import (
 	pipelineCMD "github.com/okteto/okteto/cmd/pipeline"
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
+	buildCMD "github.com/okteto/okteto/pkg/cmd/build"
 	"github.com/okteto/okteto/pkg/cmd/pipeline"
 	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/constants"
@@ -38,7 +39,9 @@ import (
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	oktetoPath "github.com/okteto/okteto/pkg/path"
+	"github.com/okteto/okteto/pkg/signals"
 	"github.com/okteto/okteto/pkg/types"
+	"github.com/okteto/okteto/pkg/webhook"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -61,16 +64,24 @@ type Options struct {
 	ManifestPathFlag string
 	// ManifestPath is the path to the manifest used though the command execution.
 	// This might change its value during execution
-	ManifestPath     string
-	Name             string
-	Namespace        string
-	K8sContext       string
-	Variables        []string
-	Manifest         *model.Manifest
-	Build            bool
+	ManifestPath    string
+	Name            string
+	Namespace       string
+	K8sContext      string
+	Variables       []string
+	SecretVariables []string
+	Manifest        *model.Manifest
+	Build           bool
+	// NoBuild deploys with the images already in the registry instead of building the missing ones
+	NoBuild          bool
 	Dependencies     bool
 	RunWithoutBash   bool
 	RunInRemote      bool
+	Profiles         []string
+	VerifyImages     bool
+	Verify           buildCMD.VerifyOptions
+	Sign             bool
+	SignKey          string
 	servicesToDeploy []string
 
 	Repository string
@@ -78,7 +89,23 @@ type Options struct {
 	Wait       bool
 	Timeout    time.Duration
 
-	ShowCTA bool
+	ShowCTA  bool
+	PrintEnv bool
+
+	// DependencyOverrides are the dependencies deployed from a local folder instead of their repository, in 'name=path' format
+	DependencyOverrides []string
+
+	// Parallelism is the maximum number of services of 'deploy.services' deployed at the same time
+	Parallelism int
+
+	// ExportDir is the directory where the rendered Kubernetes manifests are written instead of applying them
+	ExportDir string
+
+	// Contexts and Namespaces are the targets of the deploy. The manifest is deployed to every combination of them
+	Contexts   []string
+	Namespaces []string
+	// ParallelTargets deploys to the targets at the same time instead of one after the other
+	ParallelTargets bool
 }
 
 type builderInterface interface {
@@ -113,10 +140,16 @@ type DeployCommand struct {
 	DivertDriver       divert.Driver
 	PipelineCMD        pipelineCMD.PipelineDeployerInterface
 	AnalyticsTracker   analyticsTrackerInterface
+	ImageVerifier      imageVerifierInterface
+	DigestResolver     digestResolverInterface
 
 	PipelineType       model.Archetype
 	isRemote           bool
 	runningInInstaller bool
+
+	// listRemoteTags and runOktetoCommand are overridden in the tests of the dependencies
+	listRemoteTags   func(ctx context.Context, repositoryURL string) ([]string, error)
+	runOktetoCommand func(ctx context.Context, dir string, args []string) error
 }
 
 type analyticsTrackerInterface interface {
@@ -157,6 +190,31 @@ func Deploy(ctx context.Context, at analyticsTrackerInterface) *cobra.Command {
 				return err
 			}
 
+			if _, err := parseDependencyOverrides(options.DependencyOverrides); err != nil {
+				return err
+			}
+
+			if options.Parallelism < 1 {
+				return fmt.Errorf("invalid value for '--parallelism': must be greater than 0")
+			}
+
+			if options.Build && options.NoBuild {
+				return fmt.Errorf("'--build' and '--no-build' can't be used together")
+			}
+
+			if options.VerifyImages {
+				if err := options.Verify.Validate(); err != nil {
+					return err
+				}
+			}
+
+			targets := getDeployTargets(options.Contexts, options.Namespaces)
+			if len(targets) > 1 {
+				return deployToTargets(ctx, targets, getFanOutArgs(cmd.Flags(), args), options.ParallelTargets, runDeployTarget, os.Stdout)
+			}
+			options.K8sContext = targets[0].Context
+			options.Namespace = targets[0].Namespace
+
 			// This is needed because the deploy command needs the original kubeconfig configuration even in the execution within another
 			// deploy command. If not, we could be proxying a proxy and we would be applying the incorrect deployed-by label
 			os.Setenv(constants.OktetoSkipConfigCredentialsUpdate, "false")
@@ -219,7 +277,7 @@ func Deploy(ctx context.Context, at analyticsTrackerInterface) *cobra.Command {
 			startTime := time.Now()
 
 			stop := make(chan os.Signal, 1)
-			signal.Notify(stop, os.Interrupt)
+			signals.Notify(stop)
 			exit := make(chan error, 1)
 
 			go func() {
@@ -250,14 +308,27 @@ func Deploy(ctx context.Context, at analyticsTrackerInterface) *cobra.Command {
 
 	cmd.Flags().StringVar(&options.Name, "name", "", "development environment name")
 	cmd.Flags().StringVarP(&options.ManifestPath, "file", "f", "", "path to the okteto manifest file")
-	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "overwrites the namespace where the development environment is deployed")
-	cmd.Flags().StringVarP(&options.K8sContext, "context", "c", "", "context where the development environment is deployed")
+	cmd.Flags().StringArrayVarP(&options.Namespaces, "namespace", "n", []string{}, "overwrites the namespace where the development environment is deployed (can be set more than once)")
+	cmd.Flags().StringArrayVarP(&options.Contexts, "context", "c", []string{}, "context where the development environment is deployed (can be set more than once)")
+	cmd.Flags().BoolVarP(&options.ParallelTargets, "parallel-targets", "", false, "deploy to the contexts and namespaces at the same time instead of one after the other")
 	cmd.Flags().StringArrayVarP(&options.Variables, "var", "v", []string{}, "set a variable (can be set more than once)")
 	cmd.Flags().BoolVarP(&options.Build, "build", "", false, "force build of images when deploying the development environment")
+	cmd.Flags().BoolVarP(&options.NoBuild, "no-build", "", false, "don't build the images of the development environment: the images already in the registry are used and the variables of the others are not set")
 	cmd.Flags().BoolVarP(&options.Dependencies, "dependencies", "", false, "deploy the dependencies from manifest")
+	cmd.Flags().StringArrayVarP(&options.DependencyOverrides, "dependency-override", "", []string{}, "deploy a dependency from a local folder instead of its repository, in 'name=path' format (can be set more than once)")
 	cmd.Flags().BoolVarP(&options.RunWithoutBash, "no-bash", "", false, "execute commands without bash")
 	cmd.Flags().BoolVarP(&options.RunInRemote, "remote", "", false, "force run deploy commands in remote")
-
+	cmd.Flags().StringArrayVarP(&options.Profiles, "profile", "", []string{}, "enable the compose services of a profile (can be set more than once)")
+	cmd.Flags().BoolVarP(&options.VerifyImages, "verify-images", "", false, "refuse to deploy images without a valid cosign signature")
+	cmd.Flags().StringVarP(&options.Verify.Key, "verify-key", "", "", "cosign public key used to verify the images")
+	cmd.Flags().StringVarP(&options.Verify.Identity, "verify-identity", "", "", "regular expression the identity of keyless signatures must match")
+	cmd.Flags().StringVarP(&options.Verify.Issuer, "verify-issuer", "", "", "regular expression the OIDC issuer of keyless signatures must match")
+	cmd.Flags().BoolVarP(&options.Sign, "sign", "", false, "sign the images built for the deploy with cosign, so they pass '--verify-images'")
+	cmd.Flags().StringVarP(&options.SignKey, "sign-key", "", "", "cosign key used to sign the images built for the deploy (keyless OIDC signing is used by default)")
+
+	cmd.Flags().BoolVarP(&options.PrintEnv, "print-env", "", false, "print the variables resolved from 'deploy.envFiles' and '--var' and exit. '--var' takes precedence over the local environment, and the local environment over 'deploy.envFiles', where the last file wins")
+	cmd.Flags().StringVarP(&options.ExportDir, "export-dir", "", "", "write the rendered Kubernetes manifests to a directory instead of applying them. The images are not built")
+	cmd.Flags().IntVarP(&options.Parallelism, "parallelism", "", defaultParallelism, "maximum number of services of 'deploy.services' deployed at the same time")
 	cmd.Flags().BoolVarP(&options.Wait, "wait", "w", false, "wait until the development environment is deployed (defaults to false)")
 	cmd.Flags().DurationVarP(&options.Timeout, "timeout", "t", getDefaultTimeout(), "the length of time to wait for completion, zero means never. Any other values should contain a corresponding time unit e.g. 1s, 2m, 3h ")
 
@@ -265,7 +336,7 @@ func Deploy(ctx context.Context, at analyticsTrackerInterface) *cobra.Command {
 }
 
 // RunDeploy runs the deploy sequence
-func (dc *DeployCommand) RunDeploy(ctx context.Context, deployOptions *Options) error {
+func (dc *DeployCommand) RunDeploy(ctx context.Context, deployOptions *Options) (err error) {
 	oktetoLog.SetStage("Load manifest")
 	manifest, err := dc.GetManifest(deployOptions.ManifestPath)
 	if err != nil {
@@ -275,6 +346,10 @@ func (dc *DeployCommand) RunDeploy(ctx context.Context, deployOptions *Options)
 	oktetoLog.Debug("found okteto manifest")
 	dc.PipelineType = deployOptions.Manifest.Type
 
+	if deployOptions.PrintEnv {
+		return printEnvironment(deployOptions)
+	}
+
 	if deployOptions.Manifest.Deploy == nil && !deployOptions.Manifest.HasDependencies() {
 		return oktetoErrors.ErrManifestFoundButNoDeployAndDependenciesCommands
 	}
@@ -300,6 +375,20 @@ func (dc *DeployCommand) RunDeploy(ctx context.Context, deployOptions *Options)
 		return err
 	}
 
+	if len(deployOptions.Manifest.Variables) > 0 && !dc.isRemote && !dc.runningInInstaller {
+		// secret variables are not stored in the configmap, they are sent to the remote deploy as a build secret
+		manifestVars, secretVars, err := newVariablesResolver(cwd, dc.Fs).resolve(deployOptions.Name, deployOptions.Manifest.Variables)
+		if err != nil {
+			return err
+		}
+		deployOptions.Variables = mergeVariables(manifestVars, deployOptions.Variables)
+		deployOptions.SecretVariables = secretVars
+	}
+
+	if deployOptions.ExportDir != "" {
+		return dc.runExport(ctx, deployOptions)
+	}
+
 	if dc.isRemote || dc.runningInInstaller {
 		currentVars, err := dc.CfgMapHandler.getConfigmapVariablesEncoded(ctx, deployOptions.Name, deployOptions.Manifest.Namespace)
 		if err != nil {
@@ -311,6 +400,9 @@ func (dc *DeployCommand) RunDeploy(ctx context.Context, deployOptions *Options)
 		for _, v := range types.DecodeStringToDeployVariable(currentVars) {
 			deployOptions.Variables = append(deployOptions.Variables, fmt.Sprintf("%s=%s", v.Name, v.Value))
 		}
+		if err := loadSecretVariables(dc.Fs, secretVariablesPath, os.Setenv); err != nil {
+			return err
+		}
 	}
 
 	data := &pipeline.CfgData{
@@ -336,6 +428,19 @@ func (dc *DeployCommand) RunDeploy(ctx context.Context, deployOptions *Options)
 
 	os.Setenv(constants.OktetoNameEnvVar, deployOptions.Name)
 
+	if !dc.isRemote {
+		// the remote deploys call the webhooks from the local okteto deploy command
+		notifier := webhook.NewNotifier(deployOptions.Manifest.Webhooks)
+		notifier.Notify(ctx, newDeployWebhookEvent(model.WebhookEventDeployStart, deployOptions, nil))
+		defer func() {
+			if err != nil {
+				notifier.Notify(ctx, newDeployWebhookEvent(model.WebhookEventDeployFailure, deployOptions, err))
+				return
+			}
+			notifier.Notify(ctx, newDeployWebhookEvent(model.WebhookEventDeploySuccess, deployOptions, nil))
+		}()
+	}
+
 	if err := dc.deployDependencies(ctx, deployOptions); err != nil {
 		if errStatus := dc.CfgMapHandler.updateConfigMap(ctx, cfg, data, err); errStatus != nil {
 			return errStatus
@@ -343,6 +448,13 @@ func (dc *DeployCommand) RunDeploy(ctx context.Context, deployOptions *Options)
 		return err
 	}
 
+	if err := dc.resolveLinks(ctx, deployOptions.Manifest.Links, c); err != nil {
+		if errStatus := dc.CfgMapHandler.updateConfigMap(ctx, cfg, data, err); errStatus != nil {
+			return errStatus
+		}
+		return err
+	}
+
 	if deployOptions.Manifest.Deploy == nil {
 		return nil
 	}
@@ -354,6 +466,20 @@ func (dc *DeployCommand) RunDeploy(ctx context.Context, deployOptions *Options)
 		return err
 	}
 
+	if err := dc.pinImageDigests(deployOptions); err != nil {
+		if errStatus := dc.CfgMapHandler.updateConfigMap(ctx, cfg, data, err); errStatus != nil {
+			return errStatus
+		}
+		return err
+	}
+
+	if err := dc.verifyImages(ctx, deployOptions); err != nil {
+		if errStatus := dc.CfgMapHandler.updateConfigMap(ctx, cfg, data, err); errStatus != nil {
+			return errStatus
+		}
+		return err
+	}
+
 	if err := dc.recreateFailedPods(ctx, deployOptions.Name); err != nil {
 		oktetoLog.Infof("failed to recreate failed pods: %s", err.Error())
 	}
@@ -425,11 +551,27 @@ func buildImages(ctx context.Context, builder builderInterface, deployOptions *O
 
 	servicesToBuildSet := setUnion(oktetoManifestServicesWithBuild, servicesToDeployWithBuild)
 
+	if deployOptions.NoBuild {
+		servicesToBuild, err := builder.GetServicesToBuild(ctx, deployOptions.Manifest, setToSlice(servicesToBuildSet))
+		if err != nil {
+			return err
+		}
+		for _, svc := range servicesToBuild {
+			oktetoLog.Warning("The image of service '%s' is not built: its OKTETO_BUILD_%s_* variables are not set", svc, strings.ToUpper(strings.ReplaceAll(svc, "-", "_")))
+		}
+		if len(servicesToBuild) == 0 {
+			return nil
+		}
+		return deployOptions.Manifest.ExpandEnvVars()
+	}
+
 	if deployOptions.Build {
 		buildOptions := &types.BuildOptions{
 			EnableStages: true,
 			Manifest:     deployOptions.Manifest,
 			CommandArgs:  setToSlice(servicesToBuildSet),
+			Sign:         deployOptions.Sign,
+			SignKey:      deployOptions.SignKey,
 		}
 		oktetoLog.Debug("force build from manifest definition")
 		if errBuild := builder.Build(ctx, buildOptions); errBuild != nil {
@@ -446,6 +588,8 @@ func buildImages(ctx context.Context, builder builderInterface, deployOptions *O
 				EnableStages: true,
 				Manifest:     deployOptions.Manifest,
 				CommandArgs:  servicesToBuild,
+				Sign:         deployOptions.Sign,
+				SignKey:      deployOptions.SignKey,
 			}
 
 			if errBuild := builder.Build(ctx, buildOptions); errBuild != nil {
@@ -593,6 +737,19 @@ func (dc *DeployCommand) deployDependencies(ctx context.Context, deployOptions *
 		return errDepenNotAvailableInVanilla
 	}
 
+	overrides, err := parseDependencyOverrides(deployOptions.DependencyOverrides)
+	if err != nil {
+		return err
+	}
+	for name := range overrides {
+		if _, ok := deployOptions.Manifest.Dependencies[name]; !ok {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("the dependency '%s' of '--dependency-override' is not defined in your okteto manifest", name),
+				Hint: "Use the name of a dependency of the 'dependencies' section",
+			}
+		}
+	}
+
 	for depName, dep := range deployOptions.Manifest.Dependencies {
 		oktetoLog.Information("Deploying dependency '%s'", depName)
 		oktetoLog.SetStage(fmt.Sprintf("Deploying dependency %s", depName))
@@ -609,10 +766,21 @@ func (dc *DeployCommand) deployDependencies(ctx context.Context, deployOptions *
 		if err != nil {
 			return fmt.Errorf("could not expand variables in dependencies: %w", err)
 		}
+		if path, ok := overrides[depName]; ok {
+			if err := dc.deployLocalDependency(ctx, depName, path, dep, namespace, deployOptions.Timeout); err != nil {
+				return err
+			}
+			continue
+		}
+
+		ref, err := dc.getDependencyRef(ctx, depName, dep)
+		if err != nil {
+			return err
+		}
 		pipOpts := &pipelineCMD.DeployOptions{
 			Name:         depName,
 			Repository:   dep.Repository,
-			Branch:       dep.Branch,
+			Branch:       ref,
 			File:         dep.ManifestPath,
 			Variables:    model.SerializeEnvironmentVars(dep.Variables),
 			Wait:         dep.Wait,
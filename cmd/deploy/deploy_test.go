@@ -39,6 +39,7 @@ import (
 	"github.com/okteto/okteto/pkg/k8s/configmaps"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/repository"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
@@ -220,6 +221,12 @@ func (*fakeProxy) SetName(_ string) {}
 
 func (*fakeProxy) SetDivert(_ divert.Driver) {}
 
+func (*fakeProxy) SetExporter(_ *manifestExporter) {}
+
+func (*fakeProxy) SetQuotaChecker(_ *quotaChecker) {}
+
+func (*fakeProxy) SetProvenance(_ repository.Provenance) {}
+
 func (fk *fakeProxy) Shutdown(_ context.Context) error {
 	if fk.errOnShutdown != nil {
 		return fk.errOnShutdown
@@ -898,6 +905,37 @@ func TestBuildImages(t *testing.T) {
 
 }
 
+func TestBuildImagesWithNoBuild(t *testing.T) {
+	builder := &fakeV2Builder{servicesAlreadyBuilt: []string{"api"}}
+	deployOptions := &Options{
+		NoBuild: true,
+		Manifest: &model.Manifest{
+			Build:  model.ManifestBuild{"api": &model.BuildInfo{}, "frontend": &model.BuildInfo{}},
+			Deploy: &model.DeployInfo{},
+		},
+	}
+
+	require.NoError(t, buildImages(context.Background(), builder, deployOptions))
+	assert.Nil(t, builder.buildOptionsStorage)
+}
+
+func TestBuildImagesWithSign(t *testing.T) {
+	builder := &fakeV2Builder{}
+	deployOptions := &Options{
+		Build: true,
+		Manifest: &model.Manifest{
+			Build:  model.ManifestBuild{"api": &model.BuildInfo{}},
+			Deploy: &model.DeployInfo{},
+		},
+		Sign:    true,
+		SignKey: "cosign.key",
+	}
+
+	require.NoError(t, buildImages(context.Background(), builder, deployOptions))
+	assert.True(t, builder.buildOptionsStorage.Sign)
+	assert.Equal(t, "cosign.key", builder.buildOptionsStorage.SignKey)
+}
+
 type fakeExternalControl struct {
 	externals []externalresource.ExternalResource
 	err       error
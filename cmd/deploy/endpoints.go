@@ -171,7 +171,8 @@ func validateOutput(output string) error {
 	}
 }
 
-func (eg *EndpointGetter) getEndpoints(ctx context.Context, opts *EndpointsOptions) ([]string, error) {
+// GetEndpoints returns the endpoints of the development environment and its external resources
+func (eg *EndpointGetter) GetEndpoints(ctx context.Context, opts *EndpointsOptions) ([]string, error) {
 	if opts.Output == "" {
 		oktetoLog.Spinner("Retrieving endpoints...")
 		oktetoLog.StartSpinner()
@@ -209,7 +210,7 @@ func (eg *EndpointGetter) getEndpoints(ctx context.Context, opts *EndpointsOptio
 }
 
 func (dc *EndpointGetter) showEndpoints(ctx context.Context, opts *EndpointsOptions) error {
-	eps, err := dc.getEndpoints(ctx, opts)
+	eps, err := dc.GetEndpoints(ctx, opts)
 	if err != nil {
 		return err
 	}
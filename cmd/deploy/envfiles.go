@@ -0,0 +1,61 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"fmt"
+	"os"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+// getEnvFilesVariables returns the variables defined in the 'deploy.envFiles' of the manifest.
+// The variables of the local environment, including the ones set with '--var', take precedence over them
+func getEnvFilesVariables(manifest *model.Manifest, lookupEnv func(string) (string, bool)) ([]string, error) {
+	if manifest == nil || manifest.Deploy == nil || len(manifest.Deploy.EnvFiles) == 0 {
+		return nil, nil
+	}
+	envFileVars, err := model.LoadEnvFiles(manifest.Deploy.EnvFiles)
+	if err != nil {
+		return nil, err
+	}
+	result := []string{}
+	for _, e := range envFileVars {
+		if _, ok := lookupEnv(e.Name); ok {
+			continue
+		}
+		result = append(result, e.String())
+	}
+	return result, nil
+}
+
+// printEnvironment prints the variables resolved for the deploy commands and exits
+func printEnvironment(opts *Options) error {
+	envFilesVars, err := getEnvFilesVariables(opts.Manifest, os.LookupEnv)
+	if err != nil {
+		return err
+	}
+	for _, v := range envFilesVars {
+		oktetoLog.Println(v)
+	}
+	vars, err := parse(opts.Variables)
+	if err != nil {
+		return err
+	}
+	for _, v := range vars {
+		oktetoLog.Println(fmt.Sprintf("%s=%s", v.key, v.value))
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEnvFilesVariables(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, ".env")
+	second := filepath.Join(dir, ".env.prod")
+	require.NoError(t, os.WriteFile(first, []byte("A=first\nB=first\nC=first\n"), 0600))
+	require.NoError(t, os.WriteFile(second, []byte("B=second\n"), 0600))
+
+	localEnv := map[string]string{"C": "local"}
+	lookupEnv := func(name string) (string, bool) {
+		v, ok := localEnv[name]
+		return v, ok
+	}
+
+	var tests = []struct {
+		name     string
+		manifest *model.Manifest
+		expected []string
+	}{
+		{
+			name:     "no deploy section",
+			manifest: &model.Manifest{},
+		},
+		{
+			name:     "no env files",
+			manifest: &model.Manifest{Deploy: &model.DeployInfo{}},
+		},
+		{
+			name:     "later files and local environment win",
+			manifest: &model.Manifest{Deploy: &model.DeployInfo{EnvFiles: model.EnvFiles{first, second}}},
+			expected: []string{"A=first", "B=second"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := getEnvFilesVariables(tt.manifest, lookupEnv)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/externalresource"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/afero"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// dryRunParam is the query parameter that makes the API server render the objects of a request without persisting them
+	dryRunParam = "dryRun"
+
+	helmReleaseSecretTypePrefix = "helm.sh/release"
+)
+
+var errExportRemote = oktetoErrors.UserError{
+	E:    errors.New("'--export-dir' is not supported by remote deploys"),
+	Hint: "Remove '--remote', 'deploy.image' and 'deploy.remote' to run your deploy commands locally",
+}
+
+// manifestExporter writes the objects rendered by the API server to a directory, one file per object
+type manifestExporter struct {
+	fs  afero.Fs
+	dir string
+
+	mu    sync.Mutex
+	files map[string]bool
+}
+
+func newManifestExporter(fs afero.Fs, dir string) (*manifestExporter, error) {
+	if err := fs.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create the export directory '%s': %w", dir, err)
+	}
+	return &manifestExporter{
+		fs:    fs,
+		dir:   dir,
+		files: map[string]bool{},
+	}, nil
+}
+
+// export writes the object of a response of the API server.
+// The fields owned by the API server and the objects used by okteto to track the deploys, like helm releases, are skipped
+func (e *manifestExporter) export(body []byte) error {
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(body, &obj); err != nil {
+		oktetoLog.Infof("skipping the export of a non json object: %s", err)
+		return nil
+	}
+	kind, _ := obj["kind"].(string)
+	metadata, _ := obj["metadata"].(map[string]interface{})
+	if kind == "" || kind == "Status" || strings.HasSuffix(kind, "List") || metadata == nil {
+		return nil
+	}
+	if secretType, _ := obj["type"].(string); kind == "Secret" && strings.HasPrefix(secretType, helmReleaseSecretTypePrefix) {
+		return nil
+	}
+	if labels, ok := metadata["labels"].(map[string]interface{}); ok {
+		if labels[model.GitDeployLabel] != nil || labels[model.StackLabel] != nil {
+			return nil
+		}
+	}
+
+	delete(obj, "status")
+	for _, field := range []string{"resourceVersion", "managedFields", "creationTimestamp", "uid", "generation", "selfLink"} {
+		delete(metadata, field)
+	}
+	if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+		delete(annotations, "kubectl.kubernetes.io/last-applied-configuration")
+		if len(annotations) == 0 {
+			delete(metadata, "annotations")
+		}
+	}
+
+	name, _ := metadata["name"].(string)
+	namespace, _ := metadata["namespace"].(string)
+	apiVersion, _ := obj["apiVersion"].(string)
+	b, err := yaml.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s '%s': %w", kind, name, err)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	file := getExportFileName(apiVersion, kind, namespace, name)
+	if err := afero.WriteFile(e.fs, filepath.Join(e.dir, file), b, 0600); err != nil {
+		return fmt.Errorf("failed to export %s '%s': %w", kind, name, err)
+	}
+	e.files[file] = true
+	return nil
+}
+
+// getExportFileName returns the file of an object, in '<namespace>_<kind>.<group>_<name>.yaml' format.
+// The namespace and the group are omitted for cluster objects and the core group. Kubernetes names can't contain '_',
+// so the objects of different namespaces or API groups with the same kind and name don't collide
+func getExportFileName(apiVersion, kind, namespace, name string) string {
+	kind = strings.ToLower(kind)
+	if i := strings.LastIndex(apiVersion, "/"); i != -1 {
+		kind = fmt.Sprintf("%s.%s", kind, apiVersion[:i])
+	}
+	file := fmt.Sprintf("%s_%s.yaml", kind, name)
+	if namespace != "" {
+		file = fmt.Sprintf("%s_%s", namespace, file)
+	}
+	return file
+}
+
+// getFiles returns the files written by the exporter
+func (e *manifestExporter) getFiles() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	result := make([]string, 0, len(e.files))
+	for f := range e.files {
+		result = append(result, f)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// exportTransport sends the requests that create, update or delete objects as dry-run requests and exports the objects rendered by the API server
+type exportTransport struct {
+	rt       http.RoundTripper
+	exporter *manifestExporter
+}
+
+func newExportTransport(rt http.RoundTripper, exporter *manifestExporter) *exportTransport {
+	return &exportTransport{
+		rt:       rt,
+		exporter: exporter,
+	}
+}
+
+// RoundTrip implements the RoundTripper interface
+func (t *exportTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isMutatingRequest(req) || req.URL.Query().Has(dryRunParam) {
+		return t.rt.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	query := req.URL.Query()
+	query.Set(dryRunParam, "All")
+	req.URL.RawQuery = query.Encode()
+
+	resp, err := t.rt.RoundTrip(req)
+	if err != nil || req.Method == http.MethodDelete || resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.Body.Close(); err != nil {
+		oktetoLog.Infof("failed to close the response body: %s", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err := t.exporter.export(body); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func isMutatingRequest(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// exportK8sClientProvider provides kubernetes clients that export the objects instead of applying them
+type exportK8sClientProvider struct {
+	provider okteto.K8sClientProvider
+	exporter *manifestExporter
+}
+
+// Provide implements the K8sClientProvider interface
+func (p *exportK8sClientProvider) Provide(clientAPIConfig *clientcmdapi.Config) (kubernetes.Interface, *rest.Config, error) {
+	_, cfg, err := p.provider.Provide(clientAPIConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	cfg = rest.CopyConfig(cfg)
+	cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return newExportTransport(rt, p.exporter)
+	})
+	c, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, cfg, nil
+}
+
+// exportExternal writes the custom resource of an external resource of the manifest
+func (ld *localDeployer) exportExternal(name, namespace string, external *externalresource.ExternalResource) error {
+	b, err := json.Marshal(externalresource.Translate(name, namespace, external))
+	if err != nil {
+		return fmt.Errorf("failed to encode the external resource '%s': %w", name, err)
+	}
+	return ld.exporter.export(b)
+}
+
+// runExport runs the deploy section writing the rendered objects to the export directory instead of applying them.
+// The images are not built nor pushed: the images already in the registry are used, as with '--no-build'.
+// The status of the development environment and its dependencies are not updated
+func (dc *DeployCommand) runExport(ctx context.Context, deployOptions *Options) error {
+	if shouldRunInRemote(deployOptions) {
+		return errExportRemote
+	}
+	if deployOptions.Manifest.Deploy == nil {
+		return oktetoErrors.ErrManifestFoundButNoDeployAndDependenciesCommands
+	}
+	if deployOptions.Manifest.HasDependencies() {
+		oktetoLog.Warning("The dependencies of your okteto manifest are not exported")
+	}
+
+	exporter, err := newManifestExporter(dc.Fs, deployOptions.ExportDir)
+	if err != nil {
+		return err
+	}
+
+	deployOptions.NoBuild = true
+	if err := buildImages(ctx, dc.Builder, deployOptions); err != nil {
+		return err
+	}
+
+	provider := &exportK8sClientProvider{provider: dc.K8sClientProvider, exporter: exporter}
+	deployer, err := newLocalDeployer(ctx, deployOptions, newDeployInsideDeployConfigMapHandler(provider), provider, NewKubeConfig(), model.GetAvailablePort)
+	if err != nil {
+		return fmt.Errorf("could not initialize local deploy command: %w", err)
+	}
+	deployer.Proxy.SetExporter(exporter)
+	deployer.exporter = exporter
+
+	if err := deployer.deploy(ctx, deployOptions); err != nil {
+		if err == oktetoErrors.ErrIntSig {
+			return nil
+		}
+		return oktetoErrors.UserError{E: err}
+	}
+
+	files := exporter.getFiles()
+	if len(files) == 0 {
+		oktetoLog.Warning("Your deploy section didn't render any Kubernetes manifest")
+		return nil
+	}
+	oktetoLog.Success("%d Kubernetes manifests of '%s' exported to '%s'", len(files), deployOptions.Name, deployOptions.ExportDir)
+	return nil
+}
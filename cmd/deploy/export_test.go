@@ -0,0 +1,145 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/externalresource"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManifestExporter(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	exporter, err := newManifestExporter(fs, "/rendered")
+	require.NoError(t, err)
+
+	deployment := `{
+  "apiVersion": "apps/v1",
+  "kind": "Deployment",
+  "metadata": {
+    "name": "api",
+    "namespace": "ns",
+    "uid": "1234",
+    "resourceVersion": "10",
+    "creationTimestamp": "2023-01-01T00:00:00Z",
+    "managedFields": [{"manager": "okteto"}],
+    "labels": {"dev.okteto.com/deployed-by": "movies"},
+    "annotations": {"kubectl.kubernetes.io/last-applied-configuration": "{}"}
+  },
+  "spec": {"replicas": 1},
+  "status": {"replicas": 0}
+}`
+	require.NoError(t, exporter.export([]byte(deployment)))
+	require.NoError(t, exporter.export([]byte(`{"apiVersion": "v1", "kind": "Secret", "type": "helm.sh/release.v1", "metadata": {"name": "sh.helm.release.v1.movies.v1"}}`)))
+	require.NoError(t, exporter.export([]byte(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "okteto-git-movies", "labels": {"`+model.GitDeployLabel+`": "true"}}}`)))
+	require.NoError(t, exporter.export([]byte(`{"apiVersion": "v1", "kind": "Status", "status": "Success", "metadata": {}}`)))
+	require.NoError(t, exporter.export([]byte(`not json`)))
+
+	assert.Equal(t, []string{"ns_deployment.apps_api.yaml"}, exporter.getFiles())
+	b, err := afero.ReadFile(fs, "/rendered/ns_deployment.apps_api.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  labels:
+    dev.okteto.com/deployed-by: movies
+  name: api
+  namespace: ns
+spec:
+  replicas: 1
+`, string(b))
+}
+
+func TestExportTransport(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.String())
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		_, err = w.Write(b)
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	fs := afero.NewMemMapFs()
+	exporter, err := newManifestExporter(fs, "/rendered")
+	require.NoError(t, err)
+	client := &http.Client{Transport: newExportTransport(http.DefaultTransport, exporter)}
+
+	send := func(method, path, body string) {
+		req, err := http.NewRequestWithContext(context.Background(), method, server.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+	send(http.MethodGet, "/api/v1/namespaces/ns/configmaps/env", "")
+	send(http.MethodPost, "/api/v1/namespaces/ns/configmaps", `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "env"}}`)
+	send(http.MethodDelete, "/api/v1/namespaces/ns/configmaps/old", "")
+	send(http.MethodPatch, "/api/v1/namespaces/ns/configmaps/user?dryRun=All", `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "user"}}`)
+
+	assert.Equal(t, []string{
+		"GET /api/v1/namespaces/ns/configmaps/env",
+		"POST /api/v1/namespaces/ns/configmaps?dryRun=All",
+		"DELETE /api/v1/namespaces/ns/configmaps/old?dryRun=All",
+		"PATCH /api/v1/namespaces/ns/configmaps/user?dryRun=All",
+	}, requests)
+	assert.Equal(t, []string{"configmap_env.yaml"}, exporter.getFiles())
+}
+
+func TestGetExportFileName(t *testing.T) {
+	assert.Equal(t, "ns_deployment.apps_api.yaml", getExportFileName("apps/v1", "Deployment", "ns", "api"))
+	assert.Equal(t, "other_deployment.apps_api.yaml", getExportFileName("apps/v1", "Deployment", "other", "api"))
+	assert.Equal(t, "ns_service_api.yaml", getExportFileName("v1", "Service", "ns", "api"))
+	assert.Equal(t, "ns_service.serving.knative.dev_api.yaml", getExportFileName("serving.knative.dev/v1", "Service", "ns", "api"))
+	assert.Equal(t, "clusterrole.rbac.authorization.k8s.io_api.yaml", getExportFileName("rbac.authorization.k8s.io/v1", "ClusterRole", "", "api"))
+}
+
+func TestExportExternal(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	exporter, err := newManifestExporter(fs, "/rendered")
+	require.NoError(t, err)
+	ld := &localDeployer{exporter: exporter}
+
+	external := &externalresource.ExternalResource{
+		Endpoints: []*externalresource.ExternalEndpoint{{Name: "admin", Url: "https://db.example.com"}},
+	}
+	require.NoError(t, ld.exportExternal("db", "ns", external))
+	assert.Equal(t, []string{"ns_external.dev.okteto.com_db.yaml"}, exporter.getFiles())
+	b, err := afero.ReadFile(fs, "/rendered/ns_external.dev.okteto.com_db.yaml")
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "url: https://db.example.com")
+}
+
+func TestRunExportRemote(t *testing.T) {
+	dc := &DeployCommand{Fs: afero.NewMemMapFs()}
+	opts := &Options{
+		ExportDir: "/rendered",
+		Manifest:  &model.Manifest{Deploy: &model.DeployInfo{Image: "okteto/installer"}},
+	}
+	assert.ErrorIs(t, dc.runExport(context.Background(), opts), errExportRemote)
+}
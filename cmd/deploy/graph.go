@@ -0,0 +1,460 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/compose-spec/godotenv"
+	"github.com/manifoldco/promptui/screenbuf"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+// defaultParallelism is the maximum number of services of the deploy section deployed at the same time
+const defaultParallelism = 4
+
+// serviceStatus is the state of a service of the deploy section
+type serviceStatus string
+
+const (
+	servicePending   serviceStatus = "pending"
+	serviceDeploying serviceStatus = "deploying"
+	serviceWaiting   serviceStatus = "waiting for readiness"
+	serviceReady     serviceStatus = "ready"
+	serviceFailed    serviceStatus = "failed"
+	serviceSkipped   serviceStatus = "skipped"
+)
+
+// serviceCommandRunner runs the commands of the services of the deploy section.
+// Services run in parallel, so their output is captured instead of being displayed
+type serviceCommandRunner interface {
+	run(ctx context.Context, command string, env []string, output io.Writer) error
+}
+
+// shellCommandRunner runs the commands with the same shell as the deploy commands
+type shellCommandRunner struct {
+	shell          string
+	runWithoutBash bool
+}
+
+func newShellCommandRunner(runWithoutBash bool) shellCommandRunner {
+	shell := "bash"
+	if utils.LoadBoolean(constants.OktetoDeployRemote) {
+		shell = "sh"
+	}
+	return shellCommandRunner{shell: shell, runWithoutBash: runWithoutBash}
+}
+
+func (r shellCommandRunner) run(ctx context.Context, command string, env []string, output io.Writer) error {
+	cmd := exec.CommandContext(ctx, r.shell, "-c", command)
+	if r.runWithoutBash {
+		cmd = exec.CommandContext(ctx, command)
+	}
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	return cmd.Run()
+}
+
+// graphProgress displays the state of the services of the deploy section
+type graphProgress interface {
+	update(name string, status serviceStatus)
+	stop()
+}
+
+// serviceGraphDeployer deploys the services of the deploy section, running every service once its dependencies are ready
+type serviceGraphDeployer struct {
+	services    model.DeployServices
+	runner      serviceCommandRunner
+	progress    graphProgress
+	parallelism int
+}
+
+// serviceResult is the result of deploying a service
+type serviceResult struct {
+	err     error
+	exports map[string]string
+	name    string
+	output  string
+}
+
+func newServiceGraphDeployer(services model.DeployServices, runWithoutBash bool, parallelism int) *serviceGraphDeployer {
+	var progress graphProgress
+	if oktetoLog.IsInteractive() {
+		progress = newTTYGraphProgress(services)
+	} else {
+		progress = newPlainGraphProgress(services)
+	}
+	return &serviceGraphDeployer{
+		services:    services,
+		runner:      newShellCommandRunner(runWithoutBash),
+		progress:    progress,
+		parallelism: parallelism,
+	}
+}
+
+// deploy deploys the services and returns the variables they exported to $OKTETO_ENV.
+// If a service fails, the services not started yet are skipped and the running ones are waited for
+func (g *serviceGraphDeployer) deploy(ctx context.Context, variables []string) (map[string]string, error) {
+	envDir, err := os.MkdirTemp("", "")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := os.RemoveAll(envDir); err != nil {
+			oktetoLog.Infof("error removing okteto env file dir: %s", err)
+		}
+	}()
+
+	order := g.services.GetDeployOrder()
+	statuses := map[string]serviceStatus{}
+	for _, name := range order {
+		statuses[name] = servicePending
+	}
+	exports := map[string]map[string]string{}
+	results := make(chan serviceResult)
+	running := 0
+	var failed []serviceResult
+
+	for {
+		if len(failed) == 0 {
+			for _, name := range order {
+				if running >= g.parallelism {
+					break
+				}
+				if statuses[name] != servicePending || !g.areDependenciesReady(name, statuses) {
+					continue
+				}
+				statuses[name] = serviceDeploying
+				g.progress.update(name, serviceDeploying)
+				oktetoLog.AddToBuffer(oktetoLog.InfoLevel, "Deploying service '%s'...", name)
+				running++
+				serviceVariables := append(append([]string{}, variables...), g.getExportsFromDependencies(name, order, exports)...)
+				oktetoEnvFile := filepath.Join(envDir, fmt.Sprintf("%s.env", name))
+				go func(name string, variables []string) {
+					results <- g.deployService(ctx, name, variables, oktetoEnvFile)
+				}(name, serviceVariables)
+			}
+		}
+		if running == 0 {
+			break
+		}
+
+		r := <-results
+		running--
+		if r.err != nil {
+			statuses[r.name] = serviceFailed
+			g.progress.update(r.name, serviceFailed)
+			oktetoLog.AddToBuffer(oktetoLog.ErrorLevel, "error deploying service '%s': %s", r.name, r.err.Error())
+			failed = append(failed, r)
+			continue
+		}
+		statuses[r.name] = serviceReady
+		exports[r.name] = r.exports
+		g.progress.update(r.name, serviceReady)
+		oktetoLog.AddToBuffer(oktetoLog.InfoLevel, "Service '%s' successfully deployed", r.name)
+	}
+
+	for _, name := range order {
+		if statuses[name] == servicePending {
+			statuses[name] = serviceSkipped
+			g.progress.update(name, serviceSkipped)
+		}
+	}
+	g.progress.stop()
+
+	if len(failed) > 0 {
+		for _, r := range failed {
+			if r.output != "" {
+				oktetoLog.Println(strings.TrimSuffix(r.output, "\n"))
+			}
+		}
+		return nil, fmt.Errorf("error deploying service '%s': %w", failed[0].name, failed[0].err)
+	}
+
+	result := map[string]string{}
+	for _, name := range order {
+		for k, v := range exports[name] {
+			result[k] = v
+		}
+	}
+	return result, nil
+}
+
+func (g *serviceGraphDeployer) areDependenciesReady(name string, statuses map[string]serviceStatus) bool {
+	for _, dependency := range g.services[name].DependsOn {
+		if statuses[dependency] != serviceReady {
+			return false
+		}
+	}
+	return true
+}
+
+// getExportsFromDependencies returns the variables exported by the direct and indirect dependencies of a service, in deploy order
+func (g *serviceGraphDeployer) getExportsFromDependencies(name string, order []string, exports map[string]map[string]string) []string {
+	dependencies := map[string]bool{}
+	toVisit := append([]string{}, g.services[name].DependsOn...)
+	for len(toVisit) > 0 {
+		dependency := toVisit[0]
+		toVisit = toVisit[1:]
+		if dependencies[dependency] {
+			continue
+		}
+		dependencies[dependency] = true
+		toVisit = append(toVisit, g.services[dependency].DependsOn...)
+	}
+
+	result := []string{}
+	for _, dependency := range order {
+		if !dependencies[dependency] {
+			continue
+		}
+		result = append(result, envMapToList(exports[dependency])...)
+	}
+	return result
+}
+
+// deployService runs the commands of a service and waits for its readiness gate.
+// Every service has its own $OKTETO_ENV file, so the variables it exports are only available to the services that depend on it
+func (g *serviceGraphDeployer) deployService(ctx context.Context, name string, variables []string, oktetoEnvFile string) serviceResult {
+	service := g.services[name]
+	variables = append(variables, fmt.Sprintf("%s=%s", constants.OktetoEnvFile, oktetoEnvFile))
+	output := &bytes.Buffer{}
+	exports := map[string]string{}
+	for _, command := range service.Commands {
+		env := append(append([]string{}, variables...), envMapToList(exports)...)
+		if err := g.runner.run(ctx, command.Command, env, output); err != nil {
+			return serviceResult{name: name, output: output.String(), err: fmt.Errorf("error executing command '%s': %w", command.Name, err)}
+		}
+		var err error
+		exports, err = readOktetoEnvFile(oktetoEnvFile)
+		if err != nil {
+			oktetoLog.Warning("no valid format used in the okteto env file of the service '%s': %s", name, err.Error())
+		}
+	}
+
+	if service.Readiness != nil {
+		g.progress.update(name, serviceWaiting)
+		env := append(append([]string{}, variables...), envMapToList(exports)...)
+		if err := g.waitForReadiness(ctx, service.Readiness, env, output); err != nil {
+			return serviceResult{name: name, output: output.String(), err: err}
+		}
+	}
+	return serviceResult{name: name, exports: exports}
+}
+
+// waitForReadiness runs the readiness gate until it succeeds or its timeout expires.
+// Only the output of the last attempt is kept
+func (g *serviceGraphDeployer) waitForReadiness(ctx context.Context, readiness *model.ReadinessGate, env []string, output *bytes.Buffer) error {
+	ctx, cancel := context.WithTimeout(ctx, readiness.GetTimeout())
+	defer cancel()
+
+	ticker := time.NewTicker(readiness.GetInterval())
+	defer ticker.Stop()
+	commandsOutput := output.Len()
+	for {
+		output.Truncate(commandsOutput)
+		err := g.runner.run(ctx, readiness.Command, env, output)
+		if err == nil {
+			return nil
+		}
+		oktetoLog.Infof("readiness gate '%s' failed: %s", readiness.Command, err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("readiness gate '%s' didn't succeed after %s: %w", readiness.Command, readiness.GetTimeout(), err)
+		case <-ticker.C:
+		}
+	}
+}
+
+func readOktetoEnvFile(path string) (map[string]string, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+	return godotenv.Read(path)
+}
+
+func envMapToList(envs map[string]string) []string {
+	keys := make([]string, 0, len(envs))
+	for k := range envs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	result := make([]string, 0, len(envs))
+	for _, k := range keys {
+		result = append(result, fmt.Sprintf("%s=%s", k, envs[k]))
+	}
+	return result
+}
+
+// ttyGraphProgress redraws the tree of services on every change
+type ttyGraphProgress struct {
+	services  model.DeployServices
+	statuses  map[string]serviceStatus
+	screenbuf *screenbuf.ScreenBuf
+	mu        sync.Mutex
+}
+
+func newTTYGraphProgress(services model.DeployServices) *ttyGraphProgress {
+	return &ttyGraphProgress{
+		services:  services,
+		statuses:  map[string]serviceStatus{},
+		screenbuf: screenbuf.New(os.Stdout),
+	}
+}
+
+func (p *ttyGraphProgress) update(name string, status serviceStatus) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.statuses[name] = status
+	p.render()
+}
+
+func (p *ttyGraphProgress) stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.render()
+}
+
+func (p *ttyGraphProgress) render() {
+	for _, line := range renderServiceTree(p.services, p.statuses) {
+		if _, err := p.screenbuf.Write([]byte(line)); err != nil {
+			oktetoLog.Infof("error writing service tree: %s", err)
+		}
+	}
+	if err := p.screenbuf.Flush(); err != nil {
+		oktetoLog.Infof("error flushing service tree: %s", err)
+	}
+}
+
+// plainGraphProgress prints a line on every change and the tree of services at the end
+type plainGraphProgress struct {
+	services model.DeployServices
+	statuses map[string]serviceStatus
+	mu       sync.Mutex
+}
+
+func newPlainGraphProgress(services model.DeployServices) *plainGraphProgress {
+	return &plainGraphProgress{
+		services: services,
+		statuses: map[string]serviceStatus{},
+	}
+}
+
+func (p *plainGraphProgress) update(name string, status serviceStatus) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.statuses[name] = status
+	switch status {
+	case serviceDeploying:
+		oktetoLog.Information("Deploying service '%s'...", name)
+	case serviceWaiting:
+		oktetoLog.Information("Waiting for service '%s' to be ready...", name)
+	case serviceReady:
+		oktetoLog.Success("Service '%s' is ready", name)
+	case serviceFailed:
+		oktetoLog.Fail("Service '%s' failed", name)
+	case serviceSkipped:
+		oktetoLog.Warning("Service '%s' was skipped", name)
+	}
+}
+
+func (p *plainGraphProgress) stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, line := range renderServiceTree(p.services, p.statuses) {
+		oktetoLog.Println(line)
+	}
+}
+
+// renderServiceTree renders the services as a tree where every service is under its deepest dependency.
+// Services with more than one dependency list all of them
+func renderServiceTree(services model.DeployServices, statuses map[string]serviceStatus) []string {
+	order := services.GetDeployOrder()
+	depths := map[string]int{}
+	children := map[string][]string{}
+	roots := []string{}
+	for _, name := range order {
+		parent := ""
+		for _, dependency := range services[name].DependsOn {
+			if parent == "" || depths[dependency] > depths[parent] || (depths[dependency] == depths[parent] && dependency < parent) {
+				parent = dependency
+			}
+		}
+		if parent == "" {
+			roots = append(roots, name)
+			continue
+		}
+		depths[name] = depths[parent] + 1
+		children[parent] = append(children[parent], name)
+	}
+
+	lines := []string{}
+	var render func(name, prefix, childPrefix string)
+	render = func(name, prefix, childPrefix string) {
+		status := statuses[name]
+		if status == "" {
+			status = servicePending
+		}
+		line := fmt.Sprintf("%s%s %s %s", prefix, getStatusSymbol(status), name, status)
+		if len(services[name].DependsOn) > 1 {
+			dependencies := append([]string{}, services[name].DependsOn...)
+			sort.Strings(dependencies)
+			line = fmt.Sprintf("%s (after %s)", line, strings.Join(dependencies, ", "))
+		}
+		lines = append(lines, line)
+
+		sort.Strings(children[name])
+		for i, child := range children[name] {
+			if i == len(children[name])-1 {
+				render(child, childPrefix+"└─ ", childPrefix+"   ")
+			} else {
+				render(child, childPrefix+"├─ ", childPrefix+"│  ")
+			}
+		}
+	}
+	sort.Strings(roots)
+	for _, root := range roots {
+		render(root, "", "")
+	}
+	return lines
+}
+
+func getStatusSymbol(status serviceStatus) string {
+	switch status {
+	case serviceReady:
+		return "✓"
+	case serviceFailed:
+		return "x"
+	case serviceSkipped:
+		return "-"
+	case serviceDeploying, serviceWaiting:
+		return "•"
+	default:
+		return " "
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServiceRunner runs the commands in memory. A command 'export KEY=VALUE' writes the variable to $OKTETO_ENV
+type fakeServiceRunner struct {
+	// failures is the number of times a command fails before succeeding, -1 to always fail
+	failures      map[string]int
+	envs          map[string][]string
+	mu            sync.Mutex
+	running       int
+	maxConcurrent int
+}
+
+func (r *fakeServiceRunner) run(_ context.Context, command string, env []string, output io.Writer) error {
+	r.mu.Lock()
+	r.running++
+	if r.running > r.maxConcurrent {
+		r.maxConcurrent = r.running
+	}
+	if r.envs == nil {
+		r.envs = map[string][]string{}
+	}
+	r.envs[command] = env
+	failures, ok := r.failures[command]
+	if ok && failures != 0 {
+		r.failures[command] = failures - 1
+	}
+	r.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	r.mu.Lock()
+	r.running--
+	r.mu.Unlock()
+
+	if ok && failures != 0 {
+		fmt.Fprintf(output, "%s failed\n", command)
+		return errors.New("exit status 1")
+	}
+	if variable, found := strings.CutPrefix(command, "export "); found {
+		return os.WriteFile(getEnv(env, constants.OktetoEnvFile), []byte(variable+"\n"), 0600)
+	}
+	return nil
+}
+
+// getEnv returns the last value of a variable, as exec does with duplicated variables
+func getEnv(env []string, key string) string {
+	value := ""
+	for _, e := range env {
+		if k, v, found := strings.Cut(e, "="); found && k == key {
+			value = v
+		}
+	}
+	return value
+}
+
+type fakeGraphProgress struct {
+	statuses map[string]serviceStatus
+	mu       sync.Mutex
+}
+
+func (p *fakeGraphProgress) update(name string, status serviceStatus) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.statuses[name] = status
+}
+
+func (*fakeGraphProgress) stop() {}
+
+func newFakeServiceGraphDeployer(services model.DeployServices, runner *fakeServiceRunner, parallelism int) (*serviceGraphDeployer, *fakeGraphProgress) {
+	progress := &fakeGraphProgress{statuses: map[string]serviceStatus{}}
+	return &serviceGraphDeployer{
+		services:    services,
+		runner:      runner,
+		progress:    progress,
+		parallelism: parallelism,
+	}, progress
+}
+
+func newDeployCommands(commands ...string) []model.DeployCommand {
+	result := []model.DeployCommand{}
+	for _, c := range commands {
+		result = append(result, model.DeployCommand{Name: c, Command: c})
+	}
+	return result
+}
+
+func TestServiceGraphDeployerExports(t *testing.T) {
+	services := model.DeployServices{
+		"db":       {Commands: newDeployCommands("export DB_HOST=db")},
+		"queue":    {Commands: newDeployCommands("export QUEUE_HOST=queue")},
+		"api":      {Commands: newDeployCommands("export API_URL=http://api", "deploy api"), DependsOn: []string{"db", "queue"}},
+		"frontend": {Commands: newDeployCommands("deploy frontend"), DependsOn: []string{"api"}},
+	}
+	runner := &fakeServiceRunner{}
+	g, progress := newFakeServiceGraphDeployer(services, runner, defaultParallelism)
+
+	exports, err := g.deploy(context.Background(), []string{"NAME=value"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"DB_HOST": "db", "QUEUE_HOST": "queue", "API_URL": "http://api"}, exports)
+
+	assert.Equal(t, "value", getEnv(runner.envs["export DB_HOST=db"], "NAME"))
+	assert.Empty(t, getEnv(runner.envs["export QUEUE_HOST=queue"], "DB_HOST"))
+	assert.Equal(t, "db", getEnv(runner.envs["deploy api"], "DB_HOST"))
+	assert.Equal(t, "http://api", getEnv(runner.envs["deploy api"], "API_URL"))
+	assert.Equal(t, "queue", getEnv(runner.envs["deploy frontend"], "QUEUE_HOST"))
+	assert.Equal(t, "http://api", getEnv(runner.envs["deploy frontend"], "API_URL"))
+	assert.NotEqual(t, getEnv(runner.envs["deploy api"], constants.OktetoEnvFile), getEnv(runner.envs["deploy frontend"], constants.OktetoEnvFile))
+
+	for name := range services {
+		assert.Equal(t, serviceReady, progress.statuses[name])
+	}
+	assert.Equal(t, 2, runner.maxConcurrent)
+}
+
+func TestServiceGraphDeployerParallelism(t *testing.T) {
+	services := model.DeployServices{}
+	for i := 0; i < 6; i++ {
+		services[fmt.Sprintf("svc-%d", i)] = &model.DeployService{Commands: newDeployCommands(fmt.Sprintf("deploy %d", i))}
+	}
+	runner := &fakeServiceRunner{}
+	g, _ := newFakeServiceGraphDeployer(services, runner, 3)
+
+	_, err := g.deploy(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, runner.maxConcurrent)
+}
+
+func TestServiceGraphDeployerFailure(t *testing.T) {
+	services := model.DeployServices{
+		"db":       {Commands: newDeployCommands("deploy db")},
+		"queue":    {Commands: newDeployCommands("deploy queue")},
+		"api":      {Commands: newDeployCommands("deploy api"), DependsOn: []string{"db"}},
+		"frontend": {Commands: newDeployCommands("deploy frontend"), DependsOn: []string{"api"}},
+	}
+	runner := &fakeServiceRunner{failures: map[string]int{"deploy db": -1}}
+	g, progress := newFakeServiceGraphDeployer(services, runner, defaultParallelism)
+
+	_, err := g.deploy(context.Background(), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error deploying service 'db'")
+	assert.Equal(t, map[string]serviceStatus{
+		"db":       serviceFailed,
+		"queue":    serviceReady,
+		"api":      serviceSkipped,
+		"frontend": serviceSkipped,
+	}, progress.statuses)
+	assert.NotContains(t, runner.envs, "deploy api")
+}
+
+func TestServiceGraphDeployerReadiness(t *testing.T) {
+	var tests = []struct {
+		name      string
+		failures  int
+		expectErr bool
+	}{
+		{
+			name:     "ready after some attempts",
+			failures: 2,
+		},
+		{
+			name:      "never ready",
+			failures:  -1,
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			services := model.DeployServices{
+				"db": {
+					Commands:  newDeployCommands("deploy db"),
+					Readiness: &model.ReadinessGate{Command: "pg_isready", Timeout: 200 * time.Millisecond, Interval: time.Millisecond},
+				},
+				"api": {Commands: newDeployCommands("deploy api"), DependsOn: []string{"db"}},
+			}
+			runner := &fakeServiceRunner{failures: map[string]int{"pg_isready": tt.failures}}
+			g, progress := newFakeServiceGraphDeployer(services, runner, defaultParallelism)
+
+			_, err := g.deploy(context.Background(), nil)
+			if tt.expectErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "readiness gate 'pg_isready'")
+				assert.Equal(t, serviceSkipped, progress.statuses["api"])
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, serviceReady, progress.statuses["api"])
+		})
+	}
+}
+
+func TestRenderServiceTree(t *testing.T) {
+	command := newDeployCommands("make")
+	services := model.DeployServices{
+		"db":       {Commands: command},
+		"queue":    {Commands: command},
+		"cache":    {Commands: command, DependsOn: []string{"db"}},
+		"api":      {Commands: command, DependsOn: []string{"queue", "cache"}},
+		"worker":   {Commands: command, DependsOn: []string{"queue"}},
+		"frontend": {Commands: command, DependsOn: []string{"api"}},
+	}
+	statuses := map[string]serviceStatus{
+		"db":     serviceReady,
+		"queue":  serviceReady,
+		"cache":  serviceReady,
+		"api":    serviceDeploying,
+		"worker": serviceFailed,
+	}
+	assert.Equal(t, []string{
+		"✓ db ready",
+		"└─ ✓ cache ready",
+		"   └─ • api deploying (after cache, queue)",
+		"      └─   frontend pending",
+		"✓ queue ready",
+		"└─ x worker failed",
+	}, renderServiceTree(services, statuses))
+}
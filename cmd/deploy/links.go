@@ -0,0 +1,138 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/format"
+	"github.com/okteto/okteto/pkg/k8s/configmaps"
+	"github.com/okteto/okteto/pkg/k8s/ingresses"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	linkVariableTemplate  = "OKTETO_LINK_%s_VARIABLE_%s"
+	linkEndpointsTemplate = "OKTETO_LINK_%s_ENDPOINTS"
+)
+
+var errLinkNotAvailableInVanilla = errors.New("the 'link' section is only supported in contexts with Okteto installed")
+
+// linkResolver sets the outputs and endpoints of the development environments of the 'link' section as environment variables
+type linkResolver struct {
+	pipelines types.PipelineInterface
+	k8sClient kubernetes.Interface
+	setEnv    func(string, string) error
+}
+
+// resolve sets, for every link, the variables exported to $OKTETO_ENV by the linked environment as 'OKTETO_LINK_<NAME>_VARIABLE_<VARIABLE>'
+// and its endpoints as 'OKTETO_LINK_<NAME>_ENDPOINTS'. Access to the namespace of the link is checked through the Okteto API
+func (lr linkResolver) resolve(ctx context.Context, links model.ManifestLinks) error {
+	names := make([]string, 0, len(links))
+	for name := range links {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, linkName := range names {
+		link := links[linkName]
+		envName := link.GetName(linkName)
+		oktetoLog.Information("Linking '%s' from namespace '%s'", envName, link.Namespace)
+
+		gitDeploy, err := lr.pipelines.GetByName(ctx, envName, link.Namespace)
+		if err != nil {
+			if errors.Is(err, oktetoErrors.ErrNotFound) {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("the development environment '%s' of link '%s' is not deployed in namespace '%s'", envName, linkName, link.Namespace),
+					Hint: fmt.Sprintf("Deploy '%s' in namespace '%s' or update the 'link' section of your okteto manifest", envName, link.Namespace),
+				}
+			}
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("could not access namespace '%s' of link '%s': %w", link.Namespace, linkName, err),
+				Hint: fmt.Sprintf("Ask the owner of namespace '%s' to add you as a member", link.Namespace),
+			}
+		}
+		if gitDeploy.Status != pipeline.DeployedStatus {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("the development environment '%s' of link '%s' is '%s' in namespace '%s'", envName, linkName, gitDeploy.Status, link.Namespace),
+				Hint: fmt.Sprintf("Wait until '%s' is deployed and try again", envName),
+			}
+		}
+
+		variables, err := lr.getVariables(ctx, envName, link.Namespace)
+		if err != nil {
+			return fmt.Errorf("could not get the variables of link '%s': %w", linkName, err)
+		}
+		prefix := linkEnvName(linkName)
+		for k, v := range variables {
+			if err := lr.setEnv(fmt.Sprintf(linkVariableTemplate, prefix, k), v); err != nil {
+				return err
+			}
+		}
+
+		endpoints, err := ingresses.NewIngressClient(lr.k8sClient, true).GetEndpointsBySelector(ctx, link.Namespace, fmt.Sprintf("%s=%s", model.DeployedByLabel, format.ResourceK8sMetaString(envName)))
+		if err != nil {
+			return fmt.Errorf("could not get the endpoints of link '%s': %w", linkName, err)
+		}
+		if err := lr.setEnv(fmt.Sprintf(linkEndpointsTemplate, prefix), strings.Join(endpoints, ",")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveLinks sets the environment variables of the 'link' section of the manifest
+func (*DeployCommand) resolveLinks(ctx context.Context, links model.ManifestLinks, c kubernetes.Interface) error {
+	if len(links) == 0 {
+		return nil
+	}
+	if !okteto.IsOkteto() {
+		return errLinkNotAvailableInVanilla
+	}
+	oc, err := okteto.NewOktetoClient()
+	if err != nil {
+		return err
+	}
+	lr := linkResolver{
+		pipelines: oc.Pipeline(),
+		k8sClient: c,
+		setEnv:    os.Setenv,
+	}
+	return lr.resolve(ctx, links)
+}
+
+// getVariables returns the variables exported to $OKTETO_ENV by a development environment
+func (lr linkResolver) getVariables(ctx context.Context, name, namespace string) (map[string]string, error) {
+	cmap, err := configmaps.Get(ctx, pipeline.TranslatePipelineName(name), namespace, lr.k8sClient)
+	if err != nil {
+		return nil, err
+	}
+	return pipeline.GetDependencyEnvs(cmap)
+}
+
+// linkEnvName returns the name of a link in the format of an environment variable
+func linkEnvName(name string) string {
+	return strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/okteto/okteto/internal/test/client"
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestLinkResolverResolve(t *testing.T) {
+	cmap := &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pipeline.TranslatePipelineName("database"),
+			Namespace: "staging",
+		},
+		Data: map[string]string{
+			constants.OktetoDependencyEnvsKey: base64.StdEncoding.EncodeToString([]byte(`{"HOST":"postgres.staging"}`)),
+		},
+	}
+	var tests = []struct {
+		name         string
+		responses    *client.FakePipelineResponses
+		expectedEnvs map[string]string
+		expectedErr  bool
+	}{
+		{
+			name:      "deployed link",
+			responses: &client.FakePipelineResponses{GitDeploy: &types.GitDeploy{Name: "database", Status: pipeline.DeployedStatus}},
+			expectedEnvs: map[string]string{
+				"OKTETO_LINK_SHARED_DB_VARIABLE_HOST": "postgres.staging",
+				"OKTETO_LINK_SHARED_DB_ENDPOINTS":     "",
+			},
+		},
+		{
+			name:        "not deployed link",
+			responses:   &client.FakePipelineResponses{GitDeploy: &types.GitDeploy{Name: "database", Status: pipeline.ErrorStatus}},
+			expectedErr: true,
+		},
+		{
+			name:        "link not found",
+			responses:   &client.FakePipelineResponses{GitDeployErr: oktetoErrors.ErrNotFound},
+			expectedErr: true,
+		},
+		{
+			name:        "namespace without access",
+			responses:   &client.FakePipelineResponses{GitDeployErr: assert.AnError},
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envs := map[string]string{}
+			lr := linkResolver{
+				pipelines: client.NewFakePipelineClient(tt.responses),
+				k8sClient: fake.NewSimpleClientset(cmap),
+				setEnv: func(k, v string) error {
+					envs[k] = v
+					return nil
+				},
+			}
+			err := lr.resolve(context.Background(), model.ManifestLinks{
+				"shared-db": &model.Link{Namespace: "staging", Name: "database"},
+			})
+			if tt.expectedErr {
+				var userErr oktetoErrors.UserError
+				assert.ErrorAs(t, err, &userErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedEnvs, envs)
+		})
+	}
+}
@@ -22,17 +22,21 @@ import (
 
 	"github.com/compose-spec/godotenv"
 	stackCMD "github.com/okteto/okteto/cmd/stack"
+	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/cmd/utils/executor"
 	"github.com/okteto/okteto/pkg/cmd/stack"
 	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/devenvironment"
 	"github.com/okteto/okteto/pkg/divert"
+	"github.com/okteto/okteto/pkg/envprovider"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/format"
 	"github.com/okteto/okteto/pkg/k8s/ingresses"
 	kconfig "github.com/okteto/okteto/pkg/k8s/kubeconfig"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/repository"
 	"github.com/spf13/afero"
 	"k8s.io/client-go/rest"
 )
@@ -51,6 +55,9 @@ type localDeployer struct {
 	isRemote     bool
 	Fs           afero.Fs
 	DivertDriver divert.Driver
+
+	// exporter writes the rendered objects instead of applying them, see '--export-dir'
+	exporter *manifestExporter
 }
 
 // newLocalDeployer initializes a local deployer from a name and a boolean indicating if we should run with bash or not
@@ -100,7 +107,7 @@ func (ld *localDeployer) deploy(ctx context.Context, deployOptions *Options) err
 
 	// We need to create a client that doesn't go through the proxy to create
 	// the configmap without the deployedByLabel
-	c, _, err := ld.K8sClientProvider.Provide(okteto.Context().Cfg)
+	c, cfg, err := ld.K8sClientProvider.Provide(okteto.Context().Cfg)
 	if err != nil {
 		return err
 	}
@@ -117,8 +124,9 @@ func (ld *localDeployer) deploy(ctx context.Context, deployOptions *Options) err
 	}
 
 	ld.Proxy.SetName(format.ResourceK8sMetaString(deployOptions.Name))
+	ld.Proxy.SetProvenance(repository.ProvenanceFromEnv())
 	if deployOptions.Manifest.Deploy.Divert != nil {
-		driver, err := divert.New(deployOptions.Manifest, c)
+		driver, err := divert.NewWithConfig(deployOptions.Manifest, c, cfg)
 		if err != nil {
 			return err
 		}
@@ -126,6 +134,15 @@ func (ld *localDeployer) deploy(ctx context.Context, deployOptions *Options) err
 		ld.DivertDriver = driver
 	}
 
+	// the quotas are checked per object sent through the proxy, not before the deploy starts
+	quotaChecker, err := newQuotaChecker(ctx, c, okteto.Context().Namespace)
+	if err != nil {
+		oktetoLog.Infof("resource quotas won't be checked: %s", err)
+	}
+	if quotaChecker != nil {
+		ld.Proxy.SetQuotaChecker(quotaChecker)
+	}
+
 	os.Setenv(constants.OktetoNameEnvVar, deployOptions.Name)
 
 	if err := setDeployOptionsValuesFromManifest(ctx, deployOptions, cwd, c); err != nil {
@@ -174,6 +191,11 @@ func (ld *localDeployer) deploy(ctx context.Context, deployOptions *Options) err
 	}
 	oktetoLog.EnableMasking()
 	err = ld.runDeploySection(ctx, deployOptions)
+	if quotaErr := quotaChecker.err(); err != nil && quotaErr != nil {
+		// the deploy commands fail with a less descriptive error when the quota checker rejects an object
+		oktetoLog.Infof("deploy failed: %s", err)
+		err = quotaErr
+	}
 	oktetoLog.DisableMasking()
 	oktetoLog.SetStage("done")
 	oktetoLog.AddToBuffer(oktetoLog.InfoLevel, "EOF")
@@ -181,6 +203,11 @@ func (ld *localDeployer) deploy(ctx context.Context, deployOptions *Options) err
 }
 
 func (ld *localDeployer) runDeploySection(ctx context.Context, opts *Options) error {
+	envFilesVars, err := getEnvFilesVariables(opts.Manifest, os.LookupEnv)
+	if err != nil {
+		return err
+	}
+
 	oktetoEnvFile, err := ld.createTempOktetoEnvFile()
 	if err != nil {
 		return err
@@ -199,7 +226,17 @@ func (ld *localDeployer) runDeploySection(ctx context.Context, opts *Options) er
 		oktetoLog.SetStage(command.Name)
 		oktetoLog.AddToBuffer(oktetoLog.InfoLevel, "Executing command '%s'...", command.Name)
 
-		if err := ld.Executor.Execute(command, opts.Variables); err != nil {
+		// the variables of 'deploy.envFiles' go first so the ones in opts.Variables take precedence.
+		// They are not added to opts.Variables to not store them in the configmap of the development environment
+		variables := append(append([]string{}, envFilesVars...), opts.Variables...)
+		// the references to secret managers are resolved into env vars, so their values aren't interpreted by the shell
+		expanded, secretVars, err := envprovider.ExpandCommand(command.Command)
+		if err != nil {
+			return fmt.Errorf("error executing command '%s': %w", command.Name, err)
+		}
+		command.Command = expanded
+		variables = append(variables, secretVars...)
+		if err := ld.Executor.Execute(command, variables); err != nil {
 			oktetoLog.AddToBuffer(oktetoLog.ErrorLevel, "error executing command '%s': %s", command.Name, err.Error())
 			return fmt.Errorf("error executing command '%s': %s", command.Name, err.Error())
 		}
@@ -224,6 +261,33 @@ func (ld *localDeployer) runDeploySection(ctx context.Context, opts *Options) er
 		oktetoLog.SetLevel("")
 	}
 
+	// deploy services if any, once the commands are done
+	if len(opts.Manifest.Deploy.Services) > 0 {
+		oktetoLog.SetStage("Deploying services")
+		parallelism := opts.Parallelism
+		if parallelism < 1 {
+			parallelism = defaultParallelism
+		}
+		variables := append(append([]string{}, envFilesVars...), opts.Variables...)
+		exports, err := newServiceGraphDeployer(opts.Manifest.Deploy.Services, opts.RunWithoutBash, parallelism).deploy(ctx, variables)
+		if err != nil {
+			return err
+		}
+		if envMapFromOktetoEnvFile == nil {
+			envMapFromOktetoEnvFile = map[string]string{}
+		}
+		for k, v := range exports {
+			envMapFromOktetoEnvFile[k] = v
+		}
+		opts.Variables = append(opts.Variables, envMapToList(exports)...)
+		oktetoLog.SetStage("")
+	}
+
+	if err := validateOutputs(opts.Manifest, envMapFromOktetoEnvFile); err != nil {
+		oktetoLog.AddToBuffer(oktetoLog.ErrorLevel, "error validating outputs: %s", err.Error())
+		return err
+	}
+
 	err = ld.ConfigMapHandler.updateEnvsFromCommands(ctx, opts.Name, opts.Manifest.Namespace, opts.Variables)
 	if err != nil {
 		return fmt.Errorf("could not update config map with environment variables: %w", err)
@@ -261,7 +325,7 @@ func (ld *localDeployer) runDeploySection(ctx context.Context, opts *Options) er
 	// deploy externals if any
 	if len(opts.Manifest.External) > 0 {
 		oktetoLog.SetStage("External configuration")
-		if !okteto.IsOkteto() {
+		if !okteto.IsOkteto() && ld.exporter == nil {
 			oktetoLog.Warning("external resources cannot be deployed on a context not managed by okteto")
 			return nil
 		}
@@ -276,6 +340,22 @@ func (ld *localDeployer) runDeploySection(ctx context.Context, opts *Options) er
 	return nil
 }
 
+// validateOutputs checks the outputs exported to $OKTETO_ENV, so the environments that depend on this one fail here instead of getting empty values
+func validateOutputs(manifest *model.Manifest, envs map[string]string) error {
+	for _, o := range manifest.Outputs {
+		if o.IsSecret() && envs[o.Name] != "" {
+			oktetoLog.AddMaskedWord(envs[o.Name])
+		}
+	}
+	if err := manifest.ValidateOutputs(envs); err != nil {
+		return oktetoErrors.UserError{
+			E:    err,
+			Hint: "Export the outputs declared in your okteto manifest from your deploy commands with 'echo \"NAME=value\" >> $OKTETO_ENV'",
+		}
+	}
+	return nil
+}
+
 func (ld *localDeployer) deployStack(ctx context.Context, opts *Options) error {
 	composeSectionInfo := opts.Manifest.Deploy.ComposeSection
 	composeSectionInfo.Stack.Namespace = okteto.Context().Namespace
@@ -289,8 +369,14 @@ func (ld *localDeployer) deployStack(ctx context.Context, opts *Options) error {
 		ForceBuild:       false,
 		Wait:             opts.Wait,
 		Timeout:          opts.Timeout,
+		RolloutTimeout:   okteto.GetTimeouts(opts.Manifest).Rollout,
 		ServicesToDeploy: opts.servicesToDeploy,
 		InsidePipeline:   true,
+		WarningsAsErrors: utils.LoadBoolean(constants.OktetoComposeWarningsAsErrorsEnvVar),
+		DryRun:           ld.exporter != nil,
+	}
+	if stackOpts.DryRun {
+		stackOpts.Wait = false
 	}
 
 	c, cfg, err := ld.K8sClientProvider.Provide(kconfig.Get([]string{ld.TempKubeconfigFile}))
@@ -351,6 +437,14 @@ func (ld *localDeployer) deployExternals(ctx context.Context, opts *Options, dyn
 			return err
 		}
 
+		if ld.exporter != nil {
+			// the external resources are exported even if the cluster doesn't have their custom resource definition
+			if err := ld.exportExternal(externalName, opts.Manifest.Namespace, externalInfo); err != nil {
+				return err
+			}
+			continue
+		}
+
 		err := control.Deploy(ctx, externalName, opts.Manifest.Namespace, externalInfo)
 		if err != nil {
 			return err
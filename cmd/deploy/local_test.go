@@ -15,9 +15,11 @@ package deploy
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"testing"
 
+	"github.com/okteto/okteto/pkg/envprovider"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/spf13/afero"
@@ -49,6 +51,56 @@ func TestDeployNotRemovingEnvFile(t *testing.T) {
 
 }
 
+type secretProvider struct{}
+
+func (secretProvider) Name() string {
+	return "deploytest"
+}
+
+func (secretProvider) Resolve(ref string) (string, error) {
+	return fmt.Sprintf("%s-p4$$word", ref), nil
+}
+
+type variablesExecutor struct {
+	commands  []string
+	variables []string
+}
+
+func (e *variablesExecutor) Execute(command model.DeployCommand, variables []string) error {
+	e.commands = append(e.commands, command.Command)
+	e.variables = variables
+	return nil
+}
+
+func (*variablesExecutor) CleanUp(_ error) {}
+
+func TestDeployCommandsWithSecretReferences(t *testing.T) {
+	envprovider.Register(secretProvider{})
+	t.Cleanup(envprovider.ClearCache)
+	executor := &variablesExecutor{}
+	opts := &Options{
+		Manifest: &model.Manifest{
+			Deploy: &model.DeployInfo{
+				Commands: []model.DeployCommand{
+					{Name: "migrate", Command: `./migrate.sh --password "${deploytest:db}"`},
+				},
+			},
+		},
+		Variables: []string{"A=b"},
+	}
+	localDeployer := localDeployer{
+		ConfigMapHandler: &fakeCmapHandler{},
+		Fs:               afero.NewMemMapFs(),
+		Executor:         executor,
+	}
+
+	require.NoError(t, localDeployer.runDeploySection(context.Background(), opts))
+	assert.Equal(t, []string{`./migrate.sh --password "${OKTETO_SECRET_REF_0}"`}, executor.commands)
+	assert.Contains(t, executor.variables, "A=b")
+	assert.Contains(t, executor.variables, "OKTETO_SECRET_REF_0=db-p4$$word")
+	assert.Equal(t, []string{"A=b"}, opts.Variables)
+}
+
 type fakeK8sProvider struct {
 	err error
 }
@@ -134,3 +186,17 @@ func Test_newLocalDeployer(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateOutputs(t *testing.T) {
+	manifest := &model.Manifest{
+		Outputs: []model.ManifestOutput{
+			{Name: "API_URL", Type: model.OutputTypeURL, Required: true},
+		},
+	}
+	assert.NoError(t, validateOutputs(manifest, map[string]string{"API_URL": "https://api.okteto.dev"}))
+
+	err := validateOutputs(manifest, nil)
+	var userErr oktetoErrors.UserError
+	require.ErrorAs(t, err, &userErr)
+	assert.Contains(t, userErr.Hint, "$OKTETO_ENV")
+}
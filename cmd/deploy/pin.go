@@ -0,0 +1,85 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/registry"
+)
+
+type digestResolverInterface interface {
+	GetImageTagWithDigest(image string) (string, error)
+}
+
+// pinImageDigests replaces the tags of the built images and of the images of the compose services by the digest
+// they have at the registry, so the deployed environment doesn't change if a tag is pushed again
+func (dc *DeployCommand) pinImageDigests(deployOptions *Options) error {
+	if deployOptions.Manifest.Deploy == nil || !deployOptions.Manifest.Deploy.PinDigests {
+		return nil
+	}
+	if dc.DigestResolver == nil {
+		dc.DigestResolver = registry.NewOktetoRegistry(okteto.Config{})
+	}
+
+	buildEnvVars := dc.Builder.GetBuildEnvVars()
+	keys := []string{}
+	for k := range buildEnvVars {
+		if strings.HasPrefix(k, "OKTETO_BUILD_") && strings.HasSuffix(k, "_IMAGE") {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		pinned, err := dc.pinImage(buildEnvVars[k])
+		if err != nil {
+			return err
+		}
+		buildEnvVars[k] = pinned
+		os.Setenv(k, pinned)
+	}
+
+	if stack := deployOptions.Manifest.GetStack(); stack != nil {
+		for _, svc := range stack.Services {
+			// images referencing the build env vars are expanded with the pinned values
+			if strings.Contains(svc.Image, "$") {
+				continue
+			}
+			pinned, err := dc.pinImage(svc.Image)
+			if err != nil {
+				return err
+			}
+			svc.Image = pinned
+		}
+	}
+	return nil
+}
+
+// pinImage returns the image reference by digest. Images already referenced by digest are not resolved again
+func (dc *DeployCommand) pinImage(image string) (string, error) {
+	if image == "" || strings.Contains(image, "@") {
+		return image, nil
+	}
+	pinned, err := dc.DigestResolver.GetImageTagWithDigest(image)
+	if err != nil {
+		return "", fmt.Errorf("failed to pin the digest of image '%s': %w", image, err)
+	}
+	oktetoLog.Infof("image '%s' pinned to '%s'", image, pinned)
+	return pinned, nil
+}
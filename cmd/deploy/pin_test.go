@@ -0,0 +1,105 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pinnedDigest = "sha256:7075f1094117e418764bb9b47a5dfc093466e714ec385223fb582d78220c7252"
+
+type fakeDigestResolver struct {
+	notFound map[string]bool
+	resolved []string
+}
+
+func (r *fakeDigestResolver) GetImageTagWithDigest(image string) (string, error) {
+	if r.notFound[image] {
+		return "", errors.New("not found")
+	}
+	r.resolved = append(r.resolved, image)
+	return fmt.Sprintf("%s@%s", image, pinnedDigest), nil
+}
+
+func TestPinImageDigests(t *testing.T) {
+	newManifest := func(pin bool) *model.Manifest {
+		return &model.Manifest{
+			Deploy: &model.DeployInfo{
+				PinDigests: pin,
+				ComposeSection: &model.ComposeSectionInfo{
+					Stack: &model.Stack{
+						Services: map[string]*model.Service{
+							"db":     {Image: "postgres:15"},
+							"api":    {Image: "${OKTETO_BUILD_API_IMAGE}"},
+							"worker": {Image: "okteto.dev/worker@" + pinnedDigest},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		resolver := &fakeDigestResolver{}
+		dc := &DeployCommand{
+			Builder:        &fakeBuilderWithEnvVars{envVars: map[string]string{"OKTETO_BUILD_API_IMAGE": "okteto.dev/api:okteto"}},
+			DigestResolver: resolver,
+		}
+		require.NoError(t, dc.pinImageDigests(&Options{Manifest: newManifest(false)}))
+		assert.Empty(t, resolver.resolved)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		t.Setenv("OKTETO_BUILD_API_IMAGE", "")
+		envVars := map[string]string{
+			"OKTETO_BUILD_API_IMAGE":      "okteto.dev/api:okteto",
+			"OKTETO_BUILD_API_REPOSITORY": "api",
+			"OKTETO_BUILD_WEB_IMAGE":      "okteto.dev/web@" + pinnedDigest,
+		}
+		resolver := &fakeDigestResolver{}
+		dc := &DeployCommand{
+			Builder:        &fakeBuilderWithEnvVars{envVars: envVars},
+			DigestResolver: resolver,
+		}
+		manifest := newManifest(true)
+		require.NoError(t, dc.pinImageDigests(&Options{Manifest: manifest}))
+
+		assert.Equal(t, []string{"okteto.dev/api:okteto", "postgres:15"}, resolver.resolved)
+		assert.Equal(t, "okteto.dev/api:okteto@"+pinnedDigest, envVars["OKTETO_BUILD_API_IMAGE"])
+		assert.Equal(t, "okteto.dev/api:okteto@"+pinnedDigest, os.Getenv("OKTETO_BUILD_API_IMAGE"))
+		assert.Equal(t, "okteto.dev/web@"+pinnedDigest, envVars["OKTETO_BUILD_WEB_IMAGE"])
+		assert.Equal(t, "api", envVars["OKTETO_BUILD_API_REPOSITORY"])
+
+		services := manifest.Deploy.ComposeSection.Stack.Services
+		assert.Equal(t, "postgres:15@"+pinnedDigest, services["db"].Image)
+		assert.Equal(t, "${OKTETO_BUILD_API_IMAGE}", services["api"].Image)
+		assert.Equal(t, "okteto.dev/worker@"+pinnedDigest, services["worker"].Image)
+	})
+
+	t.Run("image not found", func(t *testing.T) {
+		dc := &DeployCommand{
+			Builder:        &fakeBuilderWithEnvVars{envVars: map[string]string{}},
+			DigestResolver: &fakeDigestResolver{notFound: map[string]bool{"postgres:15": true}},
+		}
+		err := dc.pinImageDigests(&Options{Manifest: newManifest(true)})
+		assert.EqualError(t, err, "failed to pin the digest of image 'postgres:15': not found")
+	})
+}
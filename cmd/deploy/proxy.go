@@ -25,9 +25,7 @@ import (
 	"net/http/httputil"
 	"net/url"
 	"os"
-	"os/signal"
 	"strings"
-	"syscall"
 
 	"github.com/google/uuid"
 	"github.com/okteto/okteto/cmd/utils"
@@ -37,12 +35,16 @@ import (
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/repository"
+	"github.com/okteto/okteto/pkg/signals"
 	istioNetworkingV1beta1 "istio.io/api/networking/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
+	"sigs.k8s.io/yaml"
 )
 
 type proxyInterface interface {
@@ -52,6 +54,9 @@ type proxyInterface interface {
 	GetToken() string
 	SetName(name string)
 	SetDivert(driver divert.Driver)
+	SetExporter(exporter *manifestExporter)
+	SetQuotaChecker(checker *quotaChecker)
+	SetProvenance(provenance repository.Provenance)
 }
 
 type proxyConfig struct {
@@ -70,6 +75,12 @@ type proxyHandler struct {
 	// Name is sanitized version of the pipeline name
 	Name         string
 	DivertDriver divert.Driver
+	// Exporter exports the objects of the requests instead of applying them
+	Exporter *manifestExporter
+	// QuotaChecker rejects the objects that exceed the resource quotas of the namespace
+	QuotaChecker *quotaChecker
+	// Provenance is the version of the source code annotated in the deployed objects
+	Provenance repository.Provenance
 }
 
 // NewProxy creates a new proxy
@@ -139,7 +150,7 @@ func NewProxy(kubeconfig kubeConfigHandler, portGetter portGetterFunc) (*Proxy,
 func (p *Proxy) Start() {
 	go func() {
 		sigint := make(chan os.Signal, 1)
-		signal.Notify(sigint, syscall.SIGTERM, syscall.SIGINT)
+		signals.Notify(sigint)
 		<-sigint
 		if p.s == nil {
 			return
@@ -187,6 +198,21 @@ func (p *Proxy) SetDivert(driver divert.Driver) {
 	p.proxyHandler.SetDivert(driver)
 }
 
+// SetExporter sets the exporter of the objects sent through the proxy
+func (p *Proxy) SetExporter(exporter *manifestExporter) {
+	p.proxyHandler.SetExporter(exporter)
+}
+
+// SetQuotaChecker sets the checker of the resource quotas of the objects sent through the proxy
+func (p *Proxy) SetQuotaChecker(checker *quotaChecker) {
+	p.proxyHandler.SetQuotaChecker(checker)
+}
+
+// SetProvenance sets the version of the source code to annotate in the deployed objects
+func (p *Proxy) SetProvenance(provenance repository.Provenance) {
+	p.proxyHandler.SetProvenance(provenance)
+}
+
 func (ph *proxyHandler) getProxyHandler(token string, clusterConfig *rest.Config) (http.Handler, error) {
 	// By default we don't disable HTTP/2
 	trans, err := newProtocolTransport(clusterConfig, false)
@@ -237,9 +263,15 @@ func (ph *proxyHandler) getProxyHandler(token string, clusterConfig *rest.Config
 			reverseProxy.Transport = t
 		}
 
+		if ph.Exporter != nil {
+			reverseProxy = httputil.NewSingleHostReverseProxy(destinationURL)
+			reverseProxy.Transport = newExportTransport(trans, ph.Exporter)
+		}
+
 		r.Host = destinationURL.Host
 		// Modify all resources updated or created to include the label.
-		if r.Method == "PUT" || r.Method == "POST" {
+		// Server-side apply patches declare the whole object, so they are labeled as well
+		if r.Method == "PUT" || r.Method == "POST" || isApplyPatch(r) {
 			b, err := io.ReadAll(r.Body)
 			if err != nil {
 				oktetoLog.Infof("could not read the request body: %s", err)
@@ -252,13 +284,24 @@ func (ph *proxyHandler) getProxyHandler(token string, clusterConfig *rest.Config
 				return
 			}
 
-			b, err = ph.translateBody(b)
+			if isApplyPatch(r) {
+				b, err = ph.translateApplyPatchBody(b)
+			} else {
+				b, err = ph.translateBody(b)
+			}
 			if err != nil {
 				oktetoLog.Info(err)
 				rw.WriteHeader(500)
 				return
 			}
 
+			if ph.QuotaChecker != nil && ph.Exporter == nil && len(b) > 0 {
+				if err := ph.QuotaChecker.check(r.Context(), b); err != nil {
+					writeForbidden(rw, err)
+					return
+				}
+			}
+
 			// Needed to set the new Content-Length
 			r.ContentLength = int64(len(b))
 			r.Body = io.NopCloser(bytes.NewBuffer(b))
@@ -280,6 +323,18 @@ func (ph *proxyHandler) SetDivert(driver divert.Driver) {
 	ph.DivertDriver = driver
 }
 
+func (ph *proxyHandler) SetExporter(exporter *manifestExporter) {
+	ph.Exporter = exporter
+}
+
+func (ph *proxyHandler) SetQuotaChecker(checker *quotaChecker) {
+	ph.QuotaChecker = checker
+}
+
+func (ph *proxyHandler) SetProvenance(provenance repository.Provenance) {
+	ph.Provenance = provenance
+}
+
 func (ph *proxyHandler) translateBody(b []byte) ([]byte, error) {
 	var body map[string]json.RawMessage
 	if err := json.Unmarshal(b, &body); err != nil {
@@ -335,6 +390,23 @@ func (ph *proxyHandler) translateBody(b []byte) ([]byte, error) {
 	return json.Marshal(body)
 }
 
+// translateApplyPatchBody translates the body of a server-side apply patch. Its YAML content is sent as JSON, which is also valid YAML
+func (ph *proxyHandler) translateApplyPatchBody(b []byte) ([]byte, error) {
+	j, err := yaml.YAMLToJSON(b)
+	if err != nil {
+		oktetoLog.Infof("error converting apply patch body on proxy: %s", err.Error())
+		return b, nil
+	}
+	result, err := ph.translateBody(j)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return b, nil
+	}
+	return result, nil
+}
+
 func (ph *proxyHandler) translateMetadata(body map[string]json.RawMessage) error {
 	m, ok := body["metadata"]
 	if !ok {
@@ -355,6 +427,7 @@ func (ph *proxyHandler) translateMetadata(body map[string]json.RawMessage) error
 	if utils.IsOktetoRepo() {
 		metadata.Annotations[model.OktetoSampleAnnotation] = "true"
 	}
+	ph.Provenance.SetAnnotations(metadata.Annotations)
 
 	metadataAsByte, err := json.Marshal(metadata)
 	if err != nil {
@@ -516,6 +589,28 @@ func newProtocolTransport(clusterConfig *rest.Config, disableHTTP2 bool) (http.R
 	return rest.TransportFor(copiedConfig)
 }
 
+// writeForbidden rejects a request with a kubernetes status, so clients like kubectl or helm show its message
+func writeForbidden(rw http.ResponseWriter, err error) {
+	status := metav1.Status{
+		TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   metav1.StatusFailure,
+		Message:  err.Error(),
+		Reason:   metav1.StatusReasonForbidden,
+		Code:     http.StatusForbidden,
+	}
+	b, _ := json.Marshal(status)
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusForbidden)
+	if _, err := rw.Write(b); err != nil {
+		oktetoLog.Infof("failed to write the response: %s", err)
+	}
+}
+
+// isApplyPatch returns if the request is a server-side apply patch
+func isApplyPatch(r *http.Request) bool {
+	return r.Method == http.MethodPatch && strings.HasPrefix(r.Header.Get("Content-Type"), string(types.ApplyPatchType))
+}
+
 func isSPDY(r *http.Request) bool {
 	return strings.HasPrefix(strings.ToLower(r.Header.Get(headerUpgrade)), "spdy/")
 }
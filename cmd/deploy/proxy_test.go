@@ -3,8 +3,12 @@ package deploy
 import (
 	"encoding/json"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/repository"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -102,3 +106,76 @@ func Test_NewProxy(t *testing.T) {
 		})
 	}
 }
+
+func Test_TranslateApplyPatchBody(t *testing.T) {
+	handler := &proxyHandler{Name: "movies"}
+	body := []byte(`apiVersion: kafka.strimzi.io/v1beta2
+kind: KafkaTopic
+metadata:
+  name: orders
+  labels:
+    strimzi.io/cluster: kafka
+spec:
+  partitions: 3
+`)
+	result, err := handler.translateApplyPatchBody(body)
+	require.NoError(t, err)
+
+	var obj map[string]interface{}
+	require.NoError(t, json.Unmarshal(result, &obj))
+	metadata := obj["metadata"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"strimzi.io/cluster": "kafka", model.DeployedByLabel: "movies"}, metadata["labels"])
+	assert.Equal(t, map[string]interface{}{"partitions": float64(3)}, obj["spec"])
+
+	invalid := []byte("key: [")
+	result, err = handler.translateApplyPatchBody(invalid)
+	require.NoError(t, err)
+	assert.Equal(t, invalid, result)
+}
+
+func Test_TranslateProvenance(t *testing.T) {
+	handler := &proxyHandler{
+		Name: "movies",
+		Provenance: repository.Provenance{
+			Repository: "https://github.com/okteto/movies",
+			Branch:     "main",
+			Commit:     "1234567890",
+			Dirty:      true,
+		},
+	}
+	result, err := handler.translateBody([]byte(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "cfg", "annotations": {"key": "value"}}}`))
+	require.NoError(t, err)
+
+	var obj map[string]interface{}
+	require.NoError(t, json.Unmarshal(result, &obj))
+	metadata := obj["metadata"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"key":                            "value",
+		model.OktetoRepositoryAnnotation: "https://github.com/okteto/movies",
+		model.OktetoGitBranchAnnotation:  "main",
+		model.OktetoGitCommitAnnotation:  "1234567890",
+		model.OktetoGitDirtyAnnotation:   "true",
+	}, metadata["annotations"])
+}
+
+func Test_IsApplyPatch(t *testing.T) {
+	var tests = []struct {
+		name        string
+		method      string
+		contentType string
+		expected    bool
+	}{
+		{name: "apply patch", method: http.MethodPatch, contentType: "application/apply-patch+yaml", expected: true},
+		{name: "apply patch with charset", method: http.MethodPatch, contentType: "application/apply-patch+yaml; charset=utf-8", expected: true},
+		{name: "merge patch", method: http.MethodPatch, contentType: "application/merge-patch+json", expected: false},
+		{name: "strategic merge patch", method: http.MethodPatch, contentType: "application/strategic-merge-patch+json", expected: false},
+		{name: "post", method: http.MethodPost, contentType: "application/json", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, "/apis/kafka.strimzi.io/v1beta2/namespaces/test/kafkatopics/orders", nil)
+			r.Header.Set("Content-Type", tt.contentType)
+			assert.Equal(t, tt.expected, isApplyPatch(r))
+		})
+	}
+}
@@ -0,0 +1,415 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// quotaResourceAliases are the names of the quota resources that are an alias of another one
+var quotaResourceAliases = map[apiv1.ResourceName]apiv1.ResourceName{
+	apiv1.ResourceCPU:    apiv1.ResourceRequestsCPU,
+	apiv1.ResourceMemory: apiv1.ResourceRequestsMemory,
+}
+
+// quotaViolation is an object that requests more resources than the remaining of a quota or the maximum of a limit range
+type quotaViolation struct {
+	Kind      string
+	Name      string
+	Quota     string
+	Resource  apiv1.ResourceName
+	Requested resource.Quantity
+	Remaining resource.Quantity
+}
+
+// namespaceQuota is the remaining of a resource quota of the namespace
+type namespaceQuota struct {
+	name      string
+	remaining apiv1.ResourceList
+}
+
+// quotaChecker compares the resources requested by the objects sent through the proxy against the resource quotas and limit ranges of the namespace.
+// The check happens per object, while the deploy commands run: an object that exceeds the remaining quota is rejected instead of failing
+// later when its pods are scheduled, but the objects sent before it are already applied. Compose stacks aren't sent through the proxy,
+// so their services aren't checked. The resources of the objects accepted are reserved, because their pods aren't counted by the quotas
+// until they are created
+type quotaChecker struct {
+	c           kubernetes.Interface
+	namespace   string
+	quotas      []*namespaceQuota
+	limitRanges []apiv1.LimitRange
+
+	mu         sync.Mutex
+	violations []quotaViolation
+}
+
+// newQuotaChecker returns a checker of the resource quotas and limit ranges of the namespace, or nil if the namespace doesn't have any.
+// Quotas with scopes are not checked because they only apply to some pods
+func newQuotaChecker(ctx context.Context, c kubernetes.Interface, namespace string) (*quotaChecker, error) {
+	quotaList, err := c.CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the resource quotas of '%s': %w", namespace, err)
+	}
+	qc := &quotaChecker{c: c, namespace: namespace}
+	for _, q := range quotaList.Items {
+		if len(q.Spec.Scopes) > 0 || q.Spec.ScopeSelector != nil {
+			continue
+		}
+		remaining := apiv1.ResourceList{}
+		for name, hard := range q.Spec.Hard {
+			if alias, ok := quotaResourceAliases[name]; ok {
+				name = alias
+			}
+			r := hard.DeepCopy()
+			if used, ok := q.Status.Used[name]; ok {
+				r.Sub(used)
+			}
+			remaining[name] = r
+		}
+		qc.quotas = append(qc.quotas, &namespaceQuota{name: q.Name, remaining: remaining})
+	}
+
+	lrList, err := c.CoreV1().LimitRanges(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the limit ranges of '%s': %w", namespace, err)
+	}
+	qc.limitRanges = lrList.Items
+	if len(qc.quotas) == 0 && len(qc.limitRanges) == 0 {
+		return nil, nil
+	}
+	return qc, nil
+}
+
+// check checks the object of a request body against the remaining quota. Objects that don't request resources are always accepted
+func (qc *quotaChecker) check(ctx context.Context, body []byte) error {
+	obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(body, nil, nil)
+	if err != nil {
+		return nil
+	}
+	kind, name, usage, ok := qc.getUsage(obj)
+	if !ok {
+		return nil
+	}
+	if existing := qc.getExisting(ctx, obj); existing != nil {
+		if _, _, current, ok := qc.getUsage(existing); ok {
+			for r, q := range current {
+				u := usage[r]
+				u.Sub(q)
+				usage[r] = u
+			}
+		}
+	}
+
+	qc.mu.Lock()
+	defer qc.mu.Unlock()
+	violations := qc.checkLimitRanges(kind, name, obj)
+	for _, quota := range qc.quotas {
+		for r, remaining := range quota.remaining {
+			requested, ok := usage[r]
+			if !ok || requested.Sign() <= 0 {
+				continue
+			}
+			if requested.Cmp(remaining) > 0 {
+				violations = append(violations, quotaViolation{
+					Kind:      kind,
+					Name:      name,
+					Quota:     quota.name,
+					Resource:  r,
+					Requested: requested,
+					Remaining: remaining,
+				})
+			}
+		}
+	}
+	if len(violations) > 0 {
+		sortViolations(violations)
+		qc.violations = append(qc.violations, violations...)
+		v := violations[0]
+		return fmt.Errorf("%s '%s' requests %s of '%s' but the limit of '%s' in namespace '%s' is %s", strings.ToLower(kind), name, v.Requested.String(), v.Resource, v.Quota, qc.namespace, v.Remaining.String())
+	}
+
+	for _, quota := range qc.quotas {
+		for r, remaining := range quota.remaining {
+			if requested, ok := usage[r]; ok && requested.Sign() > 0 {
+				remaining.Sub(requested)
+				quota.remaining[r] = remaining
+			}
+		}
+	}
+	return nil
+}
+
+// checkLimitRanges returns the containers of an object that request more than the maximum of the limit ranges of the namespace
+func (qc *quotaChecker) checkLimitRanges(kind, name string, obj runtime.Object) []quotaViolation {
+	spec := getPodSpec(obj)
+	if spec == nil {
+		return nil
+	}
+	violations := []quotaViolation{}
+	for _, lr := range qc.limitRanges {
+		for _, item := range lr.Spec.Limits {
+			if item.Type != apiv1.LimitTypeContainer {
+				continue
+			}
+			for _, c := range spec.Containers {
+				requests, limits := qc.getContainerResources(c.Resources)
+				for r, max := range item.Max {
+					for prefix, list := range map[string]apiv1.ResourceList{"requests": requests, "limits": limits} {
+						if q, ok := list[r]; ok && q.Cmp(max) > 0 {
+							violations = append(violations, quotaViolation{
+								Kind:      kind,
+								Name:      name,
+								Quota:     lr.Name,
+								Resource:  apiv1.ResourceName(fmt.Sprintf("%s.%s", prefix, r)),
+								Requested: q,
+								Remaining: max,
+							})
+						}
+					}
+				}
+			}
+		}
+	}
+	return violations
+}
+
+func getPodSpec(obj runtime.Object) *apiv1.PodSpec {
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		return &o.Spec.Template.Spec
+	case *appsv1.ReplicaSet:
+		return &o.Spec.Template.Spec
+	case *apiv1.ReplicationController:
+		if o.Spec.Template == nil {
+			return nil
+		}
+		return &o.Spec.Template.Spec
+	case *batchv1.Job:
+		return &o.Spec.Template.Spec
+	case *appsv1.StatefulSet:
+		return &o.Spec.Template.Spec
+	case *apiv1.Pod:
+		return &o.Spec
+	default:
+		return nil
+	}
+}
+
+// getUsage returns the resources counted by the quotas of an object
+func (qc *quotaChecker) getUsage(obj runtime.Object) (string, string, apiv1.ResourceList, bool) {
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		return "Deployment", o.Name, qc.getPodUsage(o.Spec.Template.Spec, getReplicas(o.Spec.Replicas)), true
+	case *appsv1.ReplicaSet:
+		return "ReplicaSet", o.Name, qc.getPodUsage(o.Spec.Template.Spec, getReplicas(o.Spec.Replicas)), true
+	case *apiv1.ReplicationController:
+		if o.Spec.Template == nil {
+			return "", "", nil, false
+		}
+		return "ReplicationController", o.Name, qc.getPodUsage(o.Spec.Template.Spec, getReplicas(o.Spec.Replicas)), true
+	case *batchv1.Job:
+		return "Job", o.Name, qc.getPodUsage(o.Spec.Template.Spec, getReplicas(o.Spec.Parallelism)), true
+	case *appsv1.StatefulSet:
+		replicas := getReplicas(o.Spec.Replicas)
+		usage := qc.getPodUsage(o.Spec.Template.Spec, replicas)
+		for _, pvc := range o.Spec.VolumeClaimTemplates {
+			addUsage(usage, qc.getPVCUsage(pvc), replicas)
+		}
+		return "StatefulSet", o.Name, usage, true
+	case *apiv1.Pod:
+		if len(o.OwnerReferences) > 0 {
+			return "", "", nil, false
+		}
+		return "Pod", o.Name, qc.getPodUsage(o.Spec, 1), true
+	case *apiv1.PersistentVolumeClaim:
+		return "PersistentVolumeClaim", o.Name, qc.getPVCUsage(*o), true
+	default:
+		return "", "", nil, false
+	}
+}
+
+// getExisting returns the current version of the object in the namespace, if it exists
+func (qc *quotaChecker) getExisting(ctx context.Context, obj runtime.Object) runtime.Object {
+	var result runtime.Object
+	var err error
+	opts := metav1.GetOptions{}
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		result, err = qc.c.AppsV1().Deployments(qc.namespace).Get(ctx, o.Name, opts)
+	case *appsv1.ReplicaSet:
+		result, err = qc.c.AppsV1().ReplicaSets(qc.namespace).Get(ctx, o.Name, opts)
+	case *apiv1.ReplicationController:
+		result, err = qc.c.CoreV1().ReplicationControllers(qc.namespace).Get(ctx, o.Name, opts)
+	case *batchv1.Job:
+		result, err = qc.c.BatchV1().Jobs(qc.namespace).Get(ctx, o.Name, opts)
+	case *appsv1.StatefulSet:
+		result, err = qc.c.AppsV1().StatefulSets(qc.namespace).Get(ctx, o.Name, opts)
+	case *apiv1.Pod:
+		result, err = qc.c.CoreV1().Pods(qc.namespace).Get(ctx, o.Name, opts)
+	case *apiv1.PersistentVolumeClaim:
+		result, err = qc.c.CoreV1().PersistentVolumeClaims(qc.namespace).Get(ctx, o.Name, opts)
+	default:
+		return nil
+	}
+	if err != nil {
+		if !oktetoErrors.IsNotFound(err) {
+			oktetoLog.Infof("failed to get the current version of the object: %s", err)
+		}
+		return nil
+	}
+	return result
+}
+
+// getPodUsage returns the resources of the replicas of a pod, with the defaults of the limit ranges of the namespace
+func (qc *quotaChecker) getPodUsage(spec apiv1.PodSpec, replicas int64) apiv1.ResourceList {
+	usage := apiv1.ResourceList{
+		apiv1.ResourcePods: *resource.NewQuantity(replicas, resource.DecimalSI),
+	}
+	for _, c := range spec.Containers {
+		requests, limits := qc.getContainerResources(c.Resources)
+		pod := apiv1.ResourceList{}
+		if q, ok := requests[apiv1.ResourceCPU]; ok {
+			pod[apiv1.ResourceRequestsCPU] = q
+		}
+		if q, ok := requests[apiv1.ResourceMemory]; ok {
+			pod[apiv1.ResourceRequestsMemory] = q
+		}
+		if q, ok := limits[apiv1.ResourceCPU]; ok {
+			pod[apiv1.ResourceLimitsCPU] = q
+		}
+		if q, ok := limits[apiv1.ResourceMemory]; ok {
+			pod[apiv1.ResourceLimitsMemory] = q
+		}
+		addUsage(usage, pod, replicas)
+	}
+	return usage
+}
+
+// getContainerResources returns the requests and limits of a container as kubernetes sets them: the defaults of the limit ranges are applied
+// and the requests not set take the value of their limit
+func (qc *quotaChecker) getContainerResources(r apiv1.ResourceRequirements) (apiv1.ResourceList, apiv1.ResourceList) {
+	requests := r.Requests.DeepCopy()
+	if requests == nil {
+		requests = apiv1.ResourceList{}
+	}
+	limits := r.Limits.DeepCopy()
+	if limits == nil {
+		limits = apiv1.ResourceList{}
+	}
+	for _, lr := range qc.limitRanges {
+		for _, item := range lr.Spec.Limits {
+			if item.Type != apiv1.LimitTypeContainer {
+				continue
+			}
+			for name, q := range item.Default {
+				if _, ok := limits[name]; !ok {
+					limits[name] = q
+				}
+			}
+			for name, q := range item.DefaultRequest {
+				if _, ok := requests[name]; !ok {
+					requests[name] = q
+				}
+			}
+		}
+	}
+	for name, q := range limits {
+		if _, ok := requests[name]; !ok {
+			requests[name] = q
+		}
+	}
+	return requests, limits
+}
+
+// getPVCUsage returns the resources of a persistent volume claim
+func (*quotaChecker) getPVCUsage(pvc apiv1.PersistentVolumeClaim) apiv1.ResourceList {
+	usage := apiv1.ResourceList{
+		apiv1.ResourcePersistentVolumeClaims: *resource.NewQuantity(1, resource.DecimalSI),
+	}
+	if q, ok := pvc.Spec.Resources.Requests[apiv1.ResourceStorage]; ok {
+		usage[apiv1.ResourceRequestsStorage] = q
+	}
+	return usage
+}
+
+// err returns an error with the breakdown of the objects rejected by the checker, or nil if all of them were accepted
+func (qc *quotaChecker) err() error {
+	if qc == nil {
+		return nil
+	}
+	qc.mu.Lock()
+	defer qc.mu.Unlock()
+	if len(qc.violations) == 0 {
+		return nil
+	}
+
+	var b bytes.Buffer
+	tw := tabwriter.NewWriter(&b, 1, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, "    Object\tLimit\tResource\tRequested\tAvailable")
+	for _, v := range qc.violations {
+		fmt.Fprintf(tw, "    %s/%s\t%s\t%s\t%s\t%s\n", v.Kind, v.Name, v.Quota, v.Resource, v.Requested.String(), v.Remaining.String())
+	}
+	tw.Flush()
+	return oktetoErrors.UserError{
+		E: fmt.Errorf("your development environment exceeds the resource quota of namespace '%s'", qc.namespace),
+		Hint: fmt.Sprintf(`These resources request more than the remaining quota and were not applied:
+%s
+    The resources deployed before them were applied.
+    Reduce their requests or replicas, or free up quota by destroying other development environments of the namespace`, strings.TrimRight(b.String(), "\n")),
+	}
+}
+
+func getReplicas(r *int32) int64 {
+	if r == nil {
+		return 1
+	}
+	return int64(*r)
+}
+
+// addUsage adds the resources of a list multiplied by a number of replicas
+func addUsage(usage, add apiv1.ResourceList, replicas int64) {
+	for name, q := range add {
+		total := usage[name]
+		for i := int64(0); i < replicas; i++ {
+			total.Add(q)
+		}
+		usage[name] = total
+	}
+}
+
+func sortViolations(violations []quotaViolation) {
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].Quota != violations[j].Quota {
+			return violations[i].Quota < violations[j].Quota
+		}
+		return violations[i].Resource < violations[j].Resource
+	})
+}
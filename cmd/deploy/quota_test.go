@@ -0,0 +1,160 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestQuota(hard, used apiv1.ResourceList) *apiv1.ResourceQuota {
+	return &apiv1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: "test"},
+		Spec:       apiv1.ResourceQuotaSpec{Hard: hard},
+		Status:     apiv1.ResourceQuotaStatus{Hard: hard, Used: used},
+	}
+}
+
+func newTestDeployment(name string, replicas int32, cpu string) *appsv1.Deployment {
+	resources := apiv1.ResourceRequirements{}
+	if cpu != "" {
+		resources.Requests = apiv1.ResourceList{apiv1.ResourceCPU: resource.MustParse(cpu)}
+	}
+	return &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: apiv1.PodTemplateSpec{
+				Spec: apiv1.PodSpec{
+					Containers: []apiv1.Container{{Name: "app", Image: "app", Resources: resources}},
+				},
+			},
+		},
+	}
+}
+
+func marshalTestObject(t *testing.T, obj runtime.Object) []byte {
+	b, err := json.Marshal(obj)
+	require.NoError(t, err)
+	return b
+}
+
+func Test_NewQuotaChecker(t *testing.T) {
+	ctx := context.Background()
+
+	qc, err := newQuotaChecker(ctx, fake.NewSimpleClientset(), "test")
+	require.NoError(t, err)
+	assert.Nil(t, qc)
+
+	scoped := newTestQuota(apiv1.ResourceList{apiv1.ResourcePods: resource.MustParse("1")}, nil)
+	scoped.Spec.Scopes = []apiv1.ResourceQuotaScope{apiv1.ResourceQuotaScopeBestEffort}
+	qc, err = newQuotaChecker(ctx, fake.NewSimpleClientset(scoped), "test")
+	require.NoError(t, err)
+	assert.Nil(t, qc)
+
+	quota := newTestQuota(
+		apiv1.ResourceList{apiv1.ResourceCPU: resource.MustParse("2")},
+		apiv1.ResourceList{apiv1.ResourceRequestsCPU: resource.MustParse("500m")},
+	)
+	qc, err = newQuotaChecker(ctx, fake.NewSimpleClientset(quota), "test")
+	require.NoError(t, err)
+	require.Len(t, qc.quotas, 1)
+	remaining := qc.quotas[0].remaining[apiv1.ResourceRequestsCPU]
+	assert.Equal(t, "1500m", remaining.String())
+}
+
+func Test_QuotaCheckerCheck(t *testing.T) {
+	ctx := context.Background()
+	quota := newTestQuota(
+		apiv1.ResourceList{apiv1.ResourceRequestsCPU: resource.MustParse("2")},
+		apiv1.ResourceList{apiv1.ResourceRequestsCPU: resource.MustParse("500m")},
+	)
+	qc, err := newQuotaChecker(ctx, fake.NewSimpleClientset(quota), "test")
+	require.NoError(t, err)
+
+	assert.NoError(t, qc.check(ctx, marshalTestObject(t, newTestDeployment("api", 2, "500m"))))
+	assert.NoError(t, qc.check(ctx, []byte(`{"kind":"ConfigMap","apiVersion":"v1","metadata":{"name":"cfg"}}`)))
+	assert.Nil(t, qc.err())
+
+	// the resources of the api are reserved, only 500m are left
+	err = qc.check(ctx, marshalTestObject(t, newTestDeployment("worker", 1, "1")))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "deployment 'worker' requests 1 of 'requests.cpu'")
+
+	err = qc.err()
+	require.Error(t, err)
+	var uErr oktetoErrors.UserError
+	require.ErrorAs(t, err, &uErr)
+	assert.Equal(t, "your development environment exceeds the resource quota of namespace 'test'", uErr.E.Error())
+	assert.Contains(t, uErr.Hint, "Deployment/worker  quota  requests.cpu  1          500m")
+}
+
+func Test_QuotaCheckerCheckExistingObject(t *testing.T) {
+	ctx := context.Background()
+	quota := newTestQuota(
+		apiv1.ResourceList{apiv1.ResourceRequestsCPU: resource.MustParse("2")},
+		apiv1.ResourceList{apiv1.ResourceRequestsCPU: resource.MustParse("1500m")},
+	)
+	existing := newTestDeployment("api", 3, "500m")
+	qc, err := newQuotaChecker(ctx, fake.NewSimpleClientset(quota, existing), "test")
+	require.NoError(t, err)
+
+	// only the additional replica counts against the quota
+	assert.NoError(t, qc.check(ctx, marshalTestObject(t, newTestDeployment("api", 4, "500m"))))
+	assert.Error(t, qc.check(ctx, marshalTestObject(t, newTestDeployment("api", 6, "500m"))))
+}
+
+func Test_QuotaCheckerLimitRanges(t *testing.T) {
+	ctx := context.Background()
+	quota := newTestQuota(apiv1.ResourceList{apiv1.ResourceRequestsCPU: resource.MustParse("1")}, nil)
+	lr := &apiv1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Name: "limits", Namespace: "test"},
+		Spec: apiv1.LimitRangeSpec{
+			Limits: []apiv1.LimitRangeItem{
+				{
+					Type:           apiv1.LimitTypeContainer,
+					DefaultRequest: apiv1.ResourceList{apiv1.ResourceCPU: resource.MustParse("400m")},
+					Max:            apiv1.ResourceList{apiv1.ResourceCPU: resource.MustParse("800m")},
+				},
+			},
+		},
+	}
+	qc, err := newQuotaChecker(ctx, fake.NewSimpleClientset(quota, lr), "test")
+	require.NoError(t, err)
+
+	// containers without requests take the default of the limit range
+	assert.NoError(t, qc.check(ctx, marshalTestObject(t, newTestDeployment("api", 2, ""))))
+	assert.Error(t, qc.check(ctx, marshalTestObject(t, newTestDeployment("worker", 1, ""))))
+
+	err = qc.check(ctx, marshalTestObject(t, newTestDeployment("big", 0, "900m")))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the limit of 'limits' in namespace 'test' is 800m")
+}
+
+func Test_QuotaCheckerErrNil(t *testing.T) {
+	var qc *quotaChecker
+	assert.NoError(t, qc.err())
+}
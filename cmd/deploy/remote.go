@@ -29,6 +29,7 @@ import (
 
 	"github.com/okteto/okteto/pkg/config"
 
+	"github.com/alessio/shellescape"
 	"github.com/mitchellh/go-homedir"
 
 	builder "github.com/okteto/okteto/cmd/build"
@@ -48,7 +49,11 @@ import (
 const (
 	templateName           = "dockerfile"
 	dockerfileTemporalName = "Dockerfile.deploy"
-	dockerfileTemplate     = `
+
+	// dockerfileTemplate is built with the working directory as build context, so the
+	// remote deploy runs with its uncommitted and untracked files, except the ones
+	// excluded by the .oktetodeployignore file
+	dockerfileTemplate = `
 FROM {{ .OktetoCLIImage }} as okteto-cli
 
 FROM {{ .UserDeployImage }} as deploy
@@ -75,11 +80,15 @@ ENV {{$key}} {{$val}}
 {{end}}
 
 ARG {{ .GitCommitArgName }}
+ARG {{ .GitHeadArgName }}
+ARG {{ .GitDirtyArgName }}
+ARG {{ .GitBranchArgName }}
+ARG {{ .RepositoryArgName }}
 ARG {{ .InvalidateCacheArgName }}
 
 RUN okteto registrytoken install --force --log-output=json
 
-RUN --mount=type=secret,id=known_hosts --mount=id=remote,type=ssh \
+RUN --mount=type=secret,id=known_hosts --mount=type=secret,id={{ .SecretVariablesID }} --mount=id=remote,type=ssh \
   mkdir -p $HOME/.ssh && echo "UserKnownHostsFile=/run/secrets/known_hosts" >> $HOME/.ssh/config && \
   okteto deploy --log-output=json --server-name="${{ .InternalServerName }}" {{ .DeployFlags }}
 `
@@ -97,7 +106,12 @@ type dockerfileTemplateProperties struct {
 	InternalServerName     string
 	ActionNameArgName      string
 	GitCommitArgName       string
+	GitHeadArgName         string
+	GitDirtyArgName        string
+	GitBranchArgName       string
+	RepositoryArgName      string
 	InvalidateCacheArgName string
+	SecretVariablesID      string
 	DeployFlags            string
 }
 
@@ -180,6 +194,8 @@ func (rd *remoteDeployCommand) deploy(ctx context.Context, deployOptions *Option
 
 	buildOptions := build.OptsFromBuildInfoForRemoteDeploy(buildInfo, &types.BuildOptions{OutputMode: "deploy"})
 	buildOptions.Manifest = deployOptions.Manifest
+	// the Dockerfile of the remote execution is generated by okteto
+	buildOptions.NoLint = true
 	buildOptions.BuildArgs = append(
 		buildOptions.BuildArgs,
 		fmt.Sprintf("%s=%s", model.OktetoContextEnvVar, okteto.Context().Name),
@@ -189,6 +205,10 @@ func (rd *remoteDeployCommand) deploy(ctx context.Context, deployOptions *Option
 		fmt.Sprintf("%s=%s", constants.OktetoInternalServerNameEnvVar, sc.ServerName),
 		fmt.Sprintf("%s=%s", model.OktetoActionNameEnvVar, os.Getenv(model.OktetoActionNameEnvVar)),
 		fmt.Sprintf("%s=%s", constants.OktetoGitCommitEnvVar, os.Getenv(constants.OktetoGitCommitEnvVar)),
+		fmt.Sprintf("%s=%s", constants.OktetoGitHeadEnvVar, os.Getenv(constants.OktetoGitHeadEnvVar)),
+		fmt.Sprintf("%s=%s", constants.OktetoGitDirtyEnvVar, os.Getenv(constants.OktetoGitDirtyEnvVar)),
+		fmt.Sprintf("%s=%s", constants.OktetoGitBranchEnvVar, os.Getenv(constants.OktetoGitBranchEnvVar)),
+		fmt.Sprintf("%s=%s", model.GithubRepositoryEnvVar, os.Getenv(model.GithubRepositoryEnvVar)),
 		fmt.Sprintf("%s=%d", constants.OktetoInvalidateCacheEnvVar, int(randomNumber.Int64())),
 	)
 
@@ -205,6 +225,19 @@ func (rd *remoteDeployCommand) deploy(ctx context.Context, deployOptions *Option
 		}
 	}
 
+	if len(deployOptions.SecretVariables) > 0 {
+		secretVariablesFile, err := writeSecretVariables(rd.fs, tmpDir, deployOptions.SecretVariables)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := rd.fs.Remove(secretVariablesFile); err != nil {
+				oktetoLog.Infof("error removing the secret variables: %s", err)
+			}
+		}()
+		buildOptions.Secrets = append(buildOptions.Secrets, fmt.Sprintf("id=%s,src=%s", secretVariablesID, secretVariablesFile))
+	}
+
 	sshSock := os.Getenv(rd.sshAuthSockEnvvar)
 	if sshSock == "" {
 		sshSock = os.Getenv("SSH_AUTH_SOCK")
@@ -286,7 +319,12 @@ func (rd *remoteDeployCommand) createDockerfile(tmpDir string, opts *Options) (s
 		TokenArgName:           model.OktetoTokenEnvVar,
 		ActionNameArgName:      model.OktetoActionNameEnvVar,
 		GitCommitArgName:       constants.OktetoGitCommitEnvVar,
+		GitHeadArgName:         constants.OktetoGitHeadEnvVar,
+		GitDirtyArgName:        constants.OktetoGitDirtyEnvVar,
+		GitBranchArgName:       constants.OktetoGitBranchEnvVar,
+		RepositoryArgName:      model.GithubRepositoryEnvVar,
 		InvalidateCacheArgName: constants.OktetoInvalidateCacheEnvVar,
+		SecretVariablesID:      secretVariablesID,
 		DeployFlags:            strings.Join(getDeployFlags(opts), " "),
 	}
 
@@ -310,6 +348,8 @@ func (rd *remoteDeployCommand) createDockerfile(tmpDir string, opts *Options) (s
 	return dockerfile.Name(), nil
 }
 
+// getDeployFlags returns the flags of the okteto deploy command run by the remote deploy.
+// The images and the dependencies are built and deployed before running the remote deploy, so '--build' and '--dependencies' aren't forwarded
 func getDeployFlags(opts *Options) []string {
 	var deployFlags []string
 
@@ -333,10 +373,54 @@ func getDeployFlags(opts *Options) []string {
 		deployFlags = append(deployFlags, strings.Join(varsToAddForDeploy, " "))
 	}
 
+	for _, profile := range opts.Profiles {
+		deployFlags = append(deployFlags, fmt.Sprintf("--profile %s", shellescape.Quote(profile)))
+	}
+
+	for _, override := range opts.DependencyOverrides {
+		deployFlags = append(deployFlags, fmt.Sprintf("--dependency-override %s", shellescape.Quote(override)))
+	}
+
+	if opts.VerifyImages {
+		deployFlags = append(deployFlags, "--verify-images")
+	}
+
+	if opts.Verify.Key != "" {
+		deployFlags = append(deployFlags, fmt.Sprintf("--verify-key %s", shellescape.Quote(opts.Verify.Key)))
+	}
+
+	if opts.Verify.Identity != "" {
+		deployFlags = append(deployFlags, fmt.Sprintf("--verify-identity %s", shellescape.Quote(opts.Verify.Identity)))
+	}
+
+	if opts.Verify.Issuer != "" {
+		deployFlags = append(deployFlags, fmt.Sprintf("--verify-issuer %s", shellescape.Quote(opts.Verify.Issuer)))
+	}
+
+	if opts.Sign {
+		deployFlags = append(deployFlags, "--sign")
+	}
+
+	if opts.SignKey != "" {
+		deployFlags = append(deployFlags, fmt.Sprintf("--sign-key %s", shellescape.Quote(opts.SignKey)))
+	}
+
+	if opts.RunWithoutBash {
+		deployFlags = append(deployFlags, "--no-bash")
+	}
+
+	if model.IsStrictEnv() {
+		deployFlags = append(deployFlags, "--strict-env")
+	}
+
 	if opts.Wait {
 		deployFlags = append(deployFlags, "--wait")
 	}
 
+	if opts.Parallelism > 0 && opts.Parallelism != defaultParallelism {
+		deployFlags = append(deployFlags, fmt.Sprintf("--parallelism %d", opts.Parallelism))
+	}
+
 	deployFlags = append(deployFlags, fmt.Sprintf("--timeout %s", opts.Timeout))
 
 	return deployFlags
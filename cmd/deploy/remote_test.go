@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -26,8 +27,10 @@ import (
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	filesystem "github.com/okteto/okteto/pkg/filesystem/fake"
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/afero"
+	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -198,6 +201,54 @@ func TestExtraHosts(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestRemoteDeployWithSecretVariables(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	var secretFile string
+	assertFn := func(o *types.BuildOptions) {
+		for _, arg := range o.BuildArgs {
+			assert.NotContains(t, arg, "s3cr3t")
+		}
+		prefix := fmt.Sprintf("id=%s,src=", secretVariablesID)
+		for _, s := range o.Secrets {
+			if strings.HasPrefix(s, prefix) {
+				secretFile = strings.TrimPrefix(s, prefix)
+			}
+		}
+		require.NotEmpty(t, secretFile)
+
+		envs := map[string]string{}
+		require.NoError(t, loadSecretVariables(fs, secretFile, func(k, v string) error {
+			envs[k] = v
+			return nil
+		}))
+		assert.Equal(t, map[string]string{"TOKEN": "s3cr3t"}, envs)
+	}
+	rdc := remoteDeployCommand{
+		getBuildEnvVars:      func() map[string]string { return nil },
+		builderV1:            fakeV1Builder{assertOptions: assertFn},
+		fs:                   fs,
+		workingDirectoryCtrl: filesystem.NewFakeWorkingDirectoryCtrl(filepath.Clean("/")),
+		temporalCtrl:         filesystem.NewTemporalDirectoryCtrl(fs),
+		clusterMetadata: func(context.Context) (*types.ClusterMetadata, error) {
+			return &types.ClusterMetadata{}, nil
+		},
+	}
+
+	err := rdc.deploy(context.Background(), &Options{
+		Manifest: &model.Manifest{
+			Deploy: &model.DeployInfo{
+				Image: "test-image",
+			},
+		},
+		SecretVariables: []string{"TOKEN=s3cr3t"},
+	})
+	require.NoError(t, err)
+
+	// the secret variables are removed once the remote deploy finishes
+	_, err = fs.Stat(secretFile)
+	assert.True(t, os.IsNotExist(err))
+}
+
 func TestRemoteDeployWithSshAgent(t *testing.T) {
 	fs := afero.NewMemMapFs()
 	socket, err := os.CreateTemp("", "okteto-test-ssh-*")
@@ -329,6 +380,26 @@ func TestGetDeployFlags(t *testing.T) {
 			},
 			expected: []string{"--namespace test", "--timeout 5m0s"},
 		},
+		{
+			name: "parallelism set",
+			config: config{
+				opts: &Options{
+					Parallelism: 8,
+					Timeout:     5 * time.Minute,
+				},
+			},
+			expected: []string{"--parallelism 8", "--timeout 5m0s"},
+		},
+		{
+			name: "default parallelism",
+			config: config{
+				opts: &Options{
+					Parallelism: defaultParallelism,
+					Timeout:     5 * time.Minute,
+				},
+			},
+			expected: []string{"--timeout 5m0s"},
+		},
 		{
 			name: "manifest path set",
 			config: config{
@@ -372,6 +443,65 @@ func TestGetDeployFlags(t *testing.T) {
 	}
 }
 
+func TestGetDeployFlagsCoversEveryDeployFlag(t *testing.T) {
+	// every flag of 'okteto deploy' is either forwarded to the remote deploy or resolved before running it
+	var tests = []struct {
+		flag     string
+		opts     *Options
+		expected string
+	}{
+		{flag: "name", opts: &Options{Name: "movies"}, expected: `--name "movies"`},
+		{flag: "file", opts: &Options{ManifestPathFlag: "okteto.yml"}, expected: "--file okteto.yml"},
+		{flag: "namespace", opts: &Options{Namespace: "ns"}, expected: "--namespace ns"},
+		{flag: "var", opts: &Options{Variables: []string{"a=b"}}, expected: "--var a=b"},
+		{flag: "profile", opts: &Options{Profiles: []string{"frontend"}}, expected: "--profile frontend"},
+		{flag: "dependency-override", opts: &Options{DependencyOverrides: []string{"api=../api"}}, expected: "--dependency-override api=../api"},
+		{flag: "verify-images", opts: &Options{VerifyImages: true}, expected: "--verify-images"},
+		{flag: "verify-key", opts: &Options{Verify: build.VerifyOptions{Key: "cosign.pub"}}, expected: "--verify-key cosign.pub"},
+		{flag: "verify-identity", opts: &Options{Verify: build.VerifyOptions{Identity: ".*@okteto.com"}}, expected: "--verify-identity '.*@okteto.com'"},
+		{flag: "verify-issuer", opts: &Options{Verify: build.VerifyOptions{Issuer: "https://accounts.google.com"}}, expected: "--verify-issuer https://accounts.google.com"},
+		{flag: "sign", opts: &Options{Sign: true}, expected: "--sign"},
+		{flag: "sign-key", opts: &Options{SignKey: "cosign.key"}, expected: "--sign-key cosign.key"},
+		{flag: "no-bash", opts: &Options{RunWithoutBash: true}, expected: "--no-bash"},
+		{flag: "parallelism", opts: &Options{Parallelism: 2}, expected: "--parallelism 2"},
+		{flag: "wait", opts: &Options{Wait: true}, expected: "--wait"},
+		{flag: "timeout", opts: &Options{Timeout: time.Minute}, expected: "--timeout 1m0s"},
+
+		// the context and the namespaces are set up before running the remote deploy
+		{flag: "context"},
+		{flag: "parallel-targets"},
+		// the images and the dependencies are built and deployed before running the remote deploy
+		{flag: "build"},
+		{flag: "no-build"},
+		{flag: "dependencies"},
+		// the remote deploy is the one run by '--remote'
+		{flag: "remote"},
+		// these flags exit before running the remote deploy
+		{flag: "print-env"},
+		{flag: "export-dir"},
+	}
+
+	covered := map[string]bool{}
+	for _, tt := range tests {
+		covered[tt.flag] = true
+		if tt.opts == nil {
+			continue
+		}
+		t.Run(tt.flag, func(t *testing.T) {
+			assert.Contains(t, getDeployFlags(tt.opts), tt.expected)
+		})
+	}
+
+	Deploy(context.Background(), nil).Flags().VisitAll(func(f *pflag.Flag) {
+		assert.True(t, covered[f.Name], "the flag '--%s' is not covered by getDeployFlags", f.Name)
+	})
+
+	// '--strict-env' is a global flag exported as OKTETO_STRICT_ENV
+	assert.NotContains(t, getDeployFlags(&Options{}), "--strict-env")
+	t.Setenv(constants.OktetoStrictEnvEnvVar, "true")
+	assert.Contains(t, getDeployFlags(&Options{}), "--strict-env")
+}
+
 func TestCreateDockerfile(t *testing.T) {
 	wdCtrl := filesystem.NewFakeWorkingDirectoryCtrl(filepath.Clean("/"))
 	fs := afero.NewMemMapFs()
@@ -445,11 +575,15 @@ ENV OKTETO_BUIL_SVC_IMAGE ONE_VALUE
 
 
 ARG OKTETO_GIT_COMMIT
+ARG OKTETO_GIT_HEAD
+ARG OKTETO_GIT_DIRTY
+ARG OKTETO_GIT_BRANCH
+ARG GITHUB_REPOSITORY
 ARG OKTETO_INVALIDATE_CACHE
 
 RUN okteto registrytoken install --force --log-output=json
 
-RUN --mount=type=secret,id=known_hosts --mount=id=remote,type=ssh \
+RUN --mount=type=secret,id=known_hosts --mount=type=secret,id=okteto_secret_variables --mount=id=remote,type=ssh \
   mkdir -p $HOME/.ssh && echo "UserKnownHostsFile=/run/secrets/known_hosts" >> $HOME/.ssh/config && \
   okteto deploy --log-output=json --server-name="$INTERNAL_SERVER_NAME" --timeout 0s
 `,
@@ -487,6 +621,59 @@ RUN --mount=type=secret,id=known_hosts --mount=id=remote,type=ssh \
 	}
 }
 
+func TestRemoteDeployUploadsWorkingDirectory(t *testing.T) {
+	ctx := context.Background()
+	wdCtrl := filesystem.NewFakeWorkingDirectoryCtrl(filepath.Clean("/project"))
+	fs := afero.NewMemMapFs()
+	tempCreator := filesystem.NewTemporalDirectoryCtrl(fs)
+	t.Setenv(constants.OktetoGitDirtyEnvVar, "true")
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {
+				Namespace: "test",
+			},
+		},
+		CurrentContext: "test",
+	}
+
+	builderCalled := false
+	rdc := remoteDeployCommand{
+		builderV1: fakeV1Builder{
+			assertOptions: func(o *types.BuildOptions) {
+				builderCalled = true
+
+				// the build context is the working directory, not a clone of the last commit
+				assert.Empty(t, o.Path)
+				wd, err := wdCtrl.Get()
+				assert.NoError(t, err)
+				assert.Equal(t, filepath.Clean("/project"), wd)
+				assert.Contains(t, o.BuildArgs, fmt.Sprintf("%s=true", constants.OktetoGitDirtyEnvVar))
+
+				bFile, err := afero.ReadFile(fs, o.File)
+				assert.NoError(t, err)
+				assert.Contains(t, string(bFile), "COPY . /okteto/src\n")
+			},
+		},
+		fs:                   fs,
+		workingDirectoryCtrl: wdCtrl,
+		temporalCtrl:         tempCreator,
+		clusterMetadata: func(context.Context) (*types.ClusterMetadata, error) {
+			return &types.ClusterMetadata{}, nil
+		},
+		getBuildEnvVars: func() map[string]string { return nil },
+	}
+
+	err := rdc.deploy(ctx, &Options{
+		Manifest: &model.Manifest{
+			Deploy: &model.DeployInfo{
+				Image: "test-image",
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.True(t, builderCalled)
+}
+
 func Test_getOktetoCLIVersion(t *testing.T) {
 	var tests = []struct {
 		name                                 string
@@ -0,0 +1,209 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/pflag"
+)
+
+// fanOutFlags are the flags that select the targets of a deploy. They are not forwarded to the deploy of each target
+var fanOutFlags = map[string]bool{
+	"context":          true,
+	"namespace":        true,
+	"parallel-targets": true,
+}
+
+// deployTarget is a context and namespace where a manifest is deployed
+type deployTarget struct {
+	Context   string
+	Namespace string
+}
+
+func (t deployTarget) String() string {
+	switch {
+	case t.Context == "":
+		return t.Namespace
+	case t.Namespace == "":
+		return t.Context
+	default:
+		return fmt.Sprintf("%s/%s", t.Context, t.Namespace)
+	}
+}
+
+// env returns the environment variables that select the target in the deploy of the target.
+// The target is not selected with '--context' or '--namespace' and the context store isn't updated,
+// so the deploys of the targets don't change the current context nor write the store at the same time
+func (t deployTarget) env() []string {
+	env := []string{fmt.Sprintf("%s=true", constants.OktetoSkipContextStoreUpdate)}
+	if t.Context != "" {
+		// OKTETO_URL takes precedence over OKTETO_CONTEXT
+		env = append(env, fmt.Sprintf("%s=", model.OktetoURLEnvVar), fmt.Sprintf("%s=%s", model.OktetoContextEnvVar, t.Context))
+	}
+	if t.Namespace != "" {
+		env = append(env, fmt.Sprintf("%s=%s", model.OktetoNamespaceEnvVar, t.Namespace))
+	}
+	return env
+}
+
+// targetResult is the result of deploying to a target
+type targetResult struct {
+	target   deployTarget
+	duration time.Duration
+	output   bytes.Buffer
+	err      error
+}
+
+// runTargetFn runs 'okteto deploy' with the given args and environment variables writing its output to w
+type runTargetFn func(ctx context.Context, args, env []string, w io.Writer) error
+
+// getDeployTargets returns every combination of the contexts and namespaces of the deploy command.
+// An empty context or namespace means the current one
+func getDeployTargets(contexts, namespaces []string) []deployTarget {
+	if len(contexts) == 0 {
+		contexts = []string{""}
+	}
+	if len(namespaces) == 0 {
+		namespaces = []string{""}
+	}
+	targets := []deployTarget{}
+	seen := map[deployTarget]bool{}
+	for _, k8sContext := range contexts {
+		for _, namespace := range namespaces {
+			t := deployTarget{Context: k8sContext, Namespace: namespace}
+			if seen[t] {
+				continue
+			}
+			seen[t] = true
+			targets = append(targets, t)
+		}
+	}
+	return targets
+}
+
+// getFanOutArgs returns the args to deploy the same manifest to a target, without the flags that select the targets
+func getFanOutArgs(flags *pflag.FlagSet, services []string) []string {
+	args := []string{"deploy"}
+	flags.Visit(func(f *pflag.Flag) {
+		if fanOutFlags[f.Name] {
+			return
+		}
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			for _, v := range sv.GetSlice() {
+				args = append(args, fmt.Sprintf("--%s=%s", f.Name, v))
+			}
+			return
+		}
+		args = append(args, fmt.Sprintf("--%s=%s", f.Name, f.Value.String()))
+	})
+	return append(args, services...)
+}
+
+// deployToTargets deploys the manifest to every target, one after the other or at the same time, and prints a summary of the results.
+// The output of the targets deployed in parallel is buffered and printed when each of them finishes
+func deployToTargets(ctx context.Context, targets []deployTarget, args []string, parallel bool, run runTargetFn, w io.Writer) error {
+	results := make([]*targetResult, len(targets))
+	var mu sync.Mutex
+	deployTo := func(i int) {
+		r := &targetResult{target: targets[i]}
+		results[i] = r
+		env := r.target.env()
+
+		start := time.Now()
+		if !parallel {
+			oktetoLog.Information("Deploying to '%s'", r.target)
+			r.err = run(ctx, args, env, w)
+			r.duration = time.Since(start)
+			return
+		}
+		r.err = run(ctx, args, env, &r.output)
+		r.duration = time.Since(start)
+
+		mu.Lock()
+		defer mu.Unlock()
+		oktetoLog.Information("Output of the deploy to '%s':", r.target)
+		fmt.Fprint(w, r.output.String())
+	}
+
+	if parallel {
+		var wg sync.WaitGroup
+		for i := range targets {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				deployTo(i)
+			}(i)
+		}
+		wg.Wait()
+	} else {
+		for i := range targets {
+			deployTo(i)
+		}
+	}
+
+	printTargetResults(w, results)
+
+	failed := 0
+	for _, r := range results {
+		if r.err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("deploy failed in %d of %d targets", failed, len(targets)),
+			Hint: "Check the output of each target for more details",
+		}
+	}
+	return nil
+}
+
+// printTargetResults prints a table with the result of the deploy to each target
+func printTargetResults(w io.Writer, results []*targetResult) {
+	fmt.Fprintln(w)
+	tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join([]string{"Target", "Status", "Duration", "Error"}, "\t"))
+	for _, r := range results {
+		status := "deployed"
+		errMsg := "-"
+		if r.err != nil {
+			status = "error"
+			errMsg = r.err.Error()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.target, status, r.duration.Round(time.Second), errMsg)
+	}
+	tw.Flush()
+}
+
+// runDeployTarget runs 'okteto deploy' for a target in the current folder
+func runDeployTarget(ctx context.Context, args, env []string, w io.Writer) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get the current working directory: %w", err)
+	}
+	return runOktetoCommandWithOutput(ctx, cwd, args, env, w, w)
+}
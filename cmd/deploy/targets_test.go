@@ -0,0 +1,118 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDeployTargets(t *testing.T) {
+	tests := []struct {
+		name       string
+		contexts   []string
+		namespaces []string
+		expected   []deployTarget
+	}{
+		{
+			name:     "current context",
+			expected: []deployTarget{{}},
+		},
+		{
+			name:     "contexts",
+			contexts: []string{"prod-eu", "prod-us", "prod-eu"},
+			expected: []deployTarget{{Context: "prod-eu"}, {Context: "prod-us"}},
+		},
+		{
+			name:       "contexts and namespaces",
+			contexts:   []string{"prod-eu", "prod-us"},
+			namespaces: []string{"a", "b"},
+			expected: []deployTarget{
+				{Context: "prod-eu", Namespace: "a"},
+				{Context: "prod-eu", Namespace: "b"},
+				{Context: "prod-us", Namespace: "a"},
+				{Context: "prod-us", Namespace: "b"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getDeployTargets(tt.contexts, tt.namespaces))
+		})
+	}
+}
+
+func TestGetFanOutArgs(t *testing.T) {
+	flags := pflag.NewFlagSet("deploy", pflag.ContinueOnError)
+	flags.StringArrayP("context", "c", nil, "")
+	flags.StringArrayP("namespace", "n", nil, "")
+	flags.Bool("parallel-targets", false, "")
+	flags.StringArrayP("var", "v", nil, "")
+	flags.Bool("build", false, "")
+	flags.String("name", "", "")
+	flags.StringP("file", "f", "", "")
+
+	err := flags.Parse([]string{"-c", "prod-eu", "--context", "prod-us", "-n", "ns", "--parallel-targets", "--var", "A=1", "-v", "B=a=b", "--build", "--name", "movies"})
+	require.NoError(t, err)
+
+	args := getFanOutArgs(flags, []string{"api"})
+	assert.Equal(t, []string{"deploy", "--build=true", "--name=movies", "--var=A=1", "--var=B=a=b", "api"}, args)
+}
+
+func TestDeployToTargets(t *testing.T) {
+	targets := []deployTarget{
+		{Context: "prod-eu"},
+		{Context: "prod-us", Namespace: "movies"},
+	}
+	for _, parallel := range []bool{false, true} {
+		t.Run(fmt.Sprintf("parallel=%t", parallel), func(t *testing.T) {
+			var mu sync.Mutex
+			calls := [][]string{}
+			run := func(_ context.Context, args, env []string, w io.Writer) error {
+				mu.Lock()
+				calls = append(calls, env)
+				mu.Unlock()
+				fmt.Fprintf(w, "deploying %s with %s\n", strings.Join(args, " "), strings.Join(env, " "))
+				if strings.Contains(strings.Join(env, " "), "prod-us") {
+					return assert.AnError
+				}
+				return nil
+			}
+
+			var b bytes.Buffer
+			err := deployToTargets(context.Background(), targets, []string{"deploy", "--build=true"}, parallel, run, &b)
+			assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+			assert.ErrorContains(t, err, "deploy failed in 1 of 2 targets")
+
+			assert.ElementsMatch(t, [][]string{
+				{"OKTETO_SKIP_CONTEXT_STORE_UPDATE=true", "OKTETO_URL=", "OKTETO_CONTEXT=prod-eu"},
+				{"OKTETO_SKIP_CONTEXT_STORE_UPDATE=true", "OKTETO_URL=", "OKTETO_CONTEXT=prod-us", "OKTETO_NAMESPACE=movies"},
+			}, calls)
+			out := b.String()
+			assert.Contains(t, out, "deploying deploy --build=true with OKTETO_SKIP_CONTEXT_STORE_UPDATE=true OKTETO_URL= OKTETO_CONTEXT=prod-eu\n")
+			assert.Contains(t, out, "prod-eu         deployed")
+			assert.Contains(t, out, fmt.Sprintf("prod-us/movies  error     0s        %s", assert.AnError))
+		})
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
+	"golang.org/x/term"
+)
+
+const (
+	variablesStateFilename = "variables.json"
+
+	// secretVariablesID is the id of the build secret with the secret variables of a remote deploy
+	secretVariablesID = "okteto_secret_variables"
+
+	// secretVariablesPath is where the build secret with the secret variables is mounted in the remote deploy
+	secretVariablesPath = "/run/secrets/" + secretVariablesID
+
+	// variablesGitignore keeps the answers out of the git repository
+	variablesGitignore = "*\n"
+)
+
+// variablesStore stores the answers to the variables declared in the manifest, so they are not asked again in subsequent runs.
+// The answers are stored per development environment at '.okteto/state'. Secret variables are never stored
+type variablesStore struct {
+	path       string
+	filesystem afero.Fs
+}
+
+func newVariablesStore(wd string, fs afero.Fs) variablesStore {
+	return variablesStore{
+		path:       filepath.Join(wd, ".okteto", "state", variablesStateFilename),
+		filesystem: fs,
+	}
+}
+
+// load returns the stored answers of a development environment
+func (vs variablesStore) load(name string) (map[string]string, error) {
+	all, err := vs.loadAll()
+	if err != nil {
+		return nil, err
+	}
+	if all[name] == nil {
+		return map[string]string{}, nil
+	}
+	return all[name], nil
+}
+
+func (vs variablesStore) loadAll() (map[string]map[string]string, error) {
+	b, err := afero.ReadFile(vs.filesystem, vs.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return map[string]map[string]string{}, nil
+		}
+		return nil, fmt.Errorf("could not read the stored variables: %w", err)
+	}
+	all := map[string]map[string]string{}
+	if err := json.Unmarshal(b, &all); err != nil {
+		return nil, fmt.Errorf("could not parse the stored variables '%s': %w", vs.path, err)
+	}
+	return all, nil
+}
+
+// save stores the answers of a development environment
+func (vs variablesStore) save(name string, answers map[string]string) error {
+	all, err := vs.loadAll()
+	if err != nil {
+		return err
+	}
+	all[name] = answers
+	b, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := vs.filesystem.MkdirAll(filepath.Dir(vs.path), 0700); err != nil {
+		return fmt.Errorf("could not create the variables folder: %w", err)
+	}
+	gitignorePath := filepath.Join(filepath.Dir(vs.path), ".gitignore")
+	if _, err := vs.filesystem.Stat(gitignorePath); errors.Is(err, os.ErrNotExist) {
+		if err := afero.WriteFile(vs.filesystem, gitignorePath, []byte(variablesGitignore), 0600); err != nil {
+			oktetoLog.Infof("could not write %s: %s", gitignorePath, err)
+		}
+	}
+	if err := afero.WriteFile(vs.filesystem, vs.path, b, 0600); err != nil {
+		return fmt.Errorf("could not write the stored variables: %w", err)
+	}
+	return nil
+}
+
+// variablesResolver resolves the values of the variables declared in the manifest
+type variablesResolver struct {
+	store       variablesStore
+	lookupEnv   func(string) (string, bool)
+	setEnv      func(string, string) error
+	ask         func(v model.ManifestVariable) (string, error)
+	interactive bool
+}
+
+func newVariablesResolver(wd string, fs afero.Fs) variablesResolver {
+	return variablesResolver{
+		store:       newVariablesStore(wd, fs),
+		lookupEnv:   os.LookupEnv,
+		setEnv:      os.Setenv,
+		ask:         askVariable,
+		interactive: oktetoLog.IsInteractive(),
+	}
+}
+
+// resolve sets the value of the variables declared in the manifest as environment variables and returns the non-secret and the secret ones.
+// The value of a variable is, in order of precedence: the local environment (including '--var'), the answer of a previous run,
+// the answer of the user when running interactively and its default value. The values of the secret variables are masked in the output
+func (vr variablesResolver) resolve(name string, variables []model.ManifestVariable) ([]string, []string, error) {
+	if len(variables) == 0 {
+		return nil, nil, nil
+	}
+	stored, err := vr.store.load(name)
+	if err != nil {
+		oktetoLog.Infof("ignoring stored variables: %s", err)
+		stored = map[string]string{}
+	}
+
+	result := []string{}
+	secrets := []string{}
+	hasNewAnswers := false
+	for _, v := range variables {
+		value, ok := vr.lookupEnv(v.Name)
+		if !ok && !v.Secret {
+			value, ok = stored[v.Name]
+		}
+		if !ok && vr.interactive {
+			value, err = vr.ask(v)
+			if err != nil {
+				return nil, nil, err
+			}
+			if value != "" {
+				ok = true
+				if !v.Secret {
+					stored[v.Name] = value
+					hasNewAnswers = true
+				}
+			}
+		}
+		if !ok {
+			value = v.Default
+		}
+		if value == "" && v.Required {
+			return nil, nil, oktetoErrors.UserError{
+				E:    fmt.Errorf("the variable '%s' is required", v.Name),
+				Hint: fmt.Sprintf("Set its value with '--var %s=<value>'", v.Name),
+			}
+		}
+		if err := vr.setEnv(v.Name, value); err != nil {
+			return nil, nil, err
+		}
+		if v.Secret {
+			oktetoLog.AddMaskedSecret(value)
+			secrets = append(secrets, fmt.Sprintf("%s=%s", v.Name, value))
+		} else {
+			result = append(result, fmt.Sprintf("%s=%s", v.Name, value))
+		}
+	}
+
+	if hasNewAnswers {
+		if err := vr.store.save(name, stored); err != nil {
+			oktetoLog.Infof("could not store the variables: %s", err)
+		}
+	}
+	return result, secrets, nil
+}
+
+// writeSecretVariables writes the secret variables encoded in a file of dir to send them to the remote deploy as a build secret.
+// They are encoded to keep their values out of the env expansion of the build secrets
+func writeSecretVariables(fs afero.Fs, dir string, secrets []string) (string, error) {
+	path := filepath.Join(dir, secretVariablesID)
+	if err := afero.WriteFile(fs, path, []byte(pipeline.EncodeVariables(secrets)), 0600); err != nil {
+		return "", fmt.Errorf("could not write the secret variables: %w", err)
+	}
+	return path, nil
+}
+
+// loadSecretVariables sets the secret variables sent to the remote deploy as environment variables and masks their values
+func loadSecretVariables(fs afero.Fs, path string, setEnv func(string, string) error) error {
+	b, err := afero.ReadFile(fs, path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("could not read the secret variables: %w", err)
+	}
+	for _, v := range types.DecodeStringToDeployVariable(strings.TrimSpace(string(b))) {
+		oktetoLog.AddMaskedSecret(v.Value)
+		if err := setEnv(v.Name, v.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeVariables returns the variables of the manifest that are not set with '--var' followed by the '--var' ones
+func mergeVariables(manifestVars, flagVars []string) []string {
+	names := map[string]bool{}
+	for _, v := range flagVars {
+		names[strings.SplitN(v, "=", 2)[0]] = true
+	}
+	result := []string{}
+	for _, v := range manifestVars {
+		if !names[strings.SplitN(v, "=", 2)[0]] {
+			result = append(result, v)
+		}
+	}
+	return append(result, flagVars...)
+}
+
+// askVariable asks the user for the value of a variable. The input of secret variables is not echoed
+func askVariable(v model.ManifestVariable) (string, error) {
+	question := v.Name
+	if v.Description != "" {
+		question = fmt.Sprintf("%s (%s)", v.Name, v.Description)
+	}
+	if v.Default != "" {
+		question = fmt.Sprintf("%s [%s]", question, v.Default)
+	}
+	if err := oktetoLog.Question("%s: ", question); err != nil {
+		return "", err
+	}
+
+	if v.Secret {
+		b, err := term.ReadPassword(int(os.Stdin.Fd()))
+		oktetoLog.Println("")
+		if err != nil {
+			return "", fmt.Errorf("could not read the value of '%s': %w", v.Name, err)
+		}
+		return strings.TrimSpace(string(b)), nil
+	}
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("could not read the value of '%s': %w", v.Name, err)
+	}
+	return strings.TrimSpace(answer), nil
+}
@@ -0,0 +1,174 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFakeVariablesResolver(env, answers map[string]string, interactive bool) (variablesResolver, map[string]string) {
+	setVars := map[string]string{}
+	return variablesResolver{
+		store: variablesStore{
+			path:       "/app/.okteto/state/variables.json",
+			filesystem: afero.NewMemMapFs(),
+		},
+		lookupEnv: func(k string) (string, bool) {
+			v, ok := env[k]
+			return v, ok
+		},
+		setEnv: func(k, v string) error {
+			setVars[k] = v
+			return nil
+		},
+		ask: func(v model.ManifestVariable) (string, error) {
+			return answers[v.Name], nil
+		},
+		interactive: interactive,
+	}, setVars
+}
+
+func TestVariablesResolverResolve(t *testing.T) {
+	variables := []model.ManifestVariable{
+		{Name: "REGION", Default: "us-east-1"},
+		{Name: "REPLICAS"},
+		{Name: "TOKEN", Secret: true},
+	}
+	var tests = []struct {
+		name         string
+		variables    []model.ManifestVariable
+		env          map[string]string
+		answers      map[string]string
+		interactive  bool
+		expected     []string
+		expectedSecs []string
+		expectedEnvs map[string]string
+		expectedErr  bool
+	}{
+		{
+			name: "no variables",
+		},
+		{
+			name:         "defaults when not interactive",
+			variables:    variables,
+			expected:     []string{"REGION=us-east-1", "REPLICAS="},
+			expectedSecs: []string{"TOKEN="},
+			expectedEnvs: map[string]string{"REGION": "us-east-1", "REPLICAS": "", "TOKEN": ""},
+		},
+		{
+			name:         "local environment takes precedence",
+			variables:    variables,
+			env:          map[string]string{"REGION": "eu-west-1", "TOKEN": "secret"},
+			answers:      map[string]string{"REGION": "us-west-1", "REPLICAS": "2"},
+			interactive:  true,
+			expected:     []string{"REGION=eu-west-1", "REPLICAS=2"},
+			expectedSecs: []string{"TOKEN=secret"},
+			expectedEnvs: map[string]string{"REGION": "eu-west-1", "REPLICAS": "2", "TOKEN": "secret"},
+		},
+		{
+			name:         "empty answer uses the default",
+			variables:    variables,
+			answers:      map[string]string{"TOKEN": "secret"},
+			interactive:  true,
+			expected:     []string{"REGION=us-east-1", "REPLICAS="},
+			expectedSecs: []string{"TOKEN=secret"},
+			expectedEnvs: map[string]string{"REGION": "us-east-1", "REPLICAS": "", "TOKEN": "secret"},
+		},
+		{
+			name:        "required variable without value",
+			variables:   []model.ManifestVariable{{Name: "TOKEN", Required: true}},
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, setVars := newFakeVariablesResolver(tt.env, tt.answers, tt.interactive)
+			result, secrets, err := vr.resolve("movies", tt.variables)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.expectedSecs, secrets)
+			if tt.expectedEnvs == nil {
+				tt.expectedEnvs = map[string]string{}
+			}
+			assert.Equal(t, tt.expectedEnvs, setVars)
+		})
+	}
+}
+
+func TestVariablesResolverStoresAnswers(t *testing.T) {
+	variables := []model.ManifestVariable{
+		{Name: "REGION"},
+		{Name: "TOKEN", Secret: true},
+	}
+	vr, _ := newFakeVariablesResolver(nil, map[string]string{"REGION": "eu-west-1", "TOKEN": "secret"}, true)
+	result, _, err := vr.resolve("movies", variables)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"REGION=eu-west-1"}, result)
+
+	stored, err := vr.store.load("movies")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"REGION": "eu-west-1"}, stored)
+
+	gitignore, err := afero.ReadFile(vr.store.filesystem, "/app/.okteto/state/.gitignore")
+	require.NoError(t, err)
+	assert.Equal(t, variablesGitignore, string(gitignore))
+
+	// the stored answer is used in subsequent runs without asking again, secrets are asked again
+	asked := []string{}
+	vr.ask = func(v model.ManifestVariable) (string, error) {
+		asked = append(asked, v.Name)
+		return "other", nil
+	}
+	result, _, err = vr.resolve("movies", variables)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"REGION=eu-west-1"}, result)
+	assert.Equal(t, []string{"TOKEN"}, asked)
+
+	// answers are stored per development environment
+	stored, err = vr.store.load("other")
+	require.NoError(t, err)
+	assert.Empty(t, stored)
+}
+
+func TestMergeVariables(t *testing.T) {
+	result := mergeVariables([]string{"REGION=us-east-1", "REPLICAS=2"}, []string{"REGION=eu-west-1"})
+	assert.Equal(t, []string{"REPLICAS=2", "REGION=eu-west-1"}, result)
+}
+
+func TestLoadSecretVariables(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	envs := map[string]string{}
+	setEnv := func(k, v string) error {
+		envs[k] = v
+		return nil
+	}
+
+	// nothing to load when the remote deploy has no secret variables
+	require.NoError(t, loadSecretVariables(fs, secretVariablesPath, setEnv))
+	assert.Empty(t, envs)
+
+	path, err := writeSecretVariables(fs, "/tmp", []string{"TOKEN=a$b=c", "PASSWORD=p4ss"})
+	require.NoError(t, err)
+	require.NoError(t, loadSecretVariables(fs, path, setEnv))
+	assert.Equal(t, map[string]string{"TOKEN": "a$b=c", "PASSWORD": "p4ss"}, envs)
+}
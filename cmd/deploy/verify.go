@@ -0,0 +1,83 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	buildCMD "github.com/okteto/okteto/pkg/cmd/build"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+type imageVerifierInterface interface {
+	Verify(ctx context.Context, image string, opts buildCMD.VerifyOptions) error
+}
+
+// verifyImages refuses to deploy images without a valid signature
+func (dc *DeployCommand) verifyImages(ctx context.Context, deployOptions *Options) error {
+	if !deployOptions.VerifyImages {
+		return nil
+	}
+	if dc.ImageVerifier == nil {
+		dc.ImageVerifier = buildCMD.NewImageSigner()
+	}
+
+	images := getImagesToVerify(deployOptions.Manifest, dc.Builder.GetBuildEnvVars())
+	for _, image := range images {
+		oktetoLog.Spinner(fmt.Sprintf("Verifying signature of '%s'...", image))
+		oktetoLog.StartSpinner()
+		err := dc.ImageVerifier.Verify(ctx, image, deployOptions.Verify)
+		oktetoLog.StopSpinner()
+		if err != nil {
+			if deployOptions.Sign {
+				return err
+			}
+			return oktetoErrors.UserError{
+				E:    err,
+				Hint: "Sign the images built by okteto with 'okteto deploy --sign' or 'okteto build --sign'",
+			}
+		}
+		oktetoLog.Success("Image '%s' verified", image)
+	}
+	return nil
+}
+
+// getImagesToVerify returns the images built for the deploy and the images of the compose services
+func getImagesToVerify(manifest *model.Manifest, buildEnvVars map[string]string) []string {
+	set := map[string]bool{}
+	for k, v := range buildEnvVars {
+		if strings.HasPrefix(k, "OKTETO_BUILD_") && strings.HasSuffix(k, "_IMAGE") && v != "" {
+			set[v] = true
+		}
+	}
+	if stack := manifest.GetStack(); stack != nil {
+		for _, svc := range stack.Services {
+			if svc.Image != "" {
+				set[svc.Image] = true
+			}
+		}
+	}
+
+	result := []string{}
+	for image := range set {
+		result = append(result, image)
+	}
+	sort.Strings(result)
+	return result
+}
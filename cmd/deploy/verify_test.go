@@ -0,0 +1,110 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	buildCMD "github.com/okteto/okteto/pkg/cmd/build"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeImageVerifier struct {
+	unsigned map[string]bool
+	verified []string
+}
+
+func (v *fakeImageVerifier) Verify(_ context.Context, image string, _ buildCMD.VerifyOptions) error {
+	if v.unsigned[image] {
+		return fmt.Errorf("no signatures found for '%s'", image)
+	}
+	v.verified = append(v.verified, image)
+	return nil
+}
+
+func TestGetImagesToVerify(t *testing.T) {
+	manifest := &model.Manifest{
+		Deploy: &model.DeployInfo{
+			ComposeSection: &model.ComposeSectionInfo{
+				Stack: &model.Stack{
+					Services: map[string]*model.Service{
+						"db":  {Image: "postgres:15"},
+						"api": {Image: "okteto.dev/api:okteto"},
+					},
+				},
+			},
+		},
+	}
+	buildEnvVars := map[string]string{
+		"OKTETO_BUILD_API_IMAGE":      "okteto.dev/api:okteto",
+		"OKTETO_BUILD_API_REPOSITORY": "api",
+		"OKTETO_BUILD_WORKER_IMAGE":   "okteto.dev/worker:okteto",
+	}
+	assert.Equal(t, []string{"okteto.dev/api:okteto", "okteto.dev/worker:okteto", "postgres:15"}, getImagesToVerify(manifest, buildEnvVars))
+}
+
+func TestVerifyImages(t *testing.T) {
+	manifest := &model.Manifest{}
+	var tests = []struct {
+		name      string
+		options   *Options
+		unsigned  map[string]bool
+		expected  []string
+		expectErr bool
+	}{
+		{
+			name:    "verification disabled",
+			options: &Options{Manifest: manifest},
+		},
+		{
+			name:     "signed images",
+			options:  &Options{Manifest: manifest, VerifyImages: true},
+			expected: []string{"okteto.dev/api:okteto"},
+		},
+		{
+			name:      "unsigned images",
+			options:   &Options{Manifest: manifest, VerifyImages: true},
+			unsigned:  map[string]bool{"okteto.dev/api:okteto": true},
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := &fakeImageVerifier{unsigned: tt.unsigned}
+			dc := &DeployCommand{
+				Builder:       &fakeBuilderWithEnvVars{envVars: map[string]string{"OKTETO_BUILD_API_IMAGE": "okteto.dev/api:okteto"}},
+				ImageVerifier: verifier,
+			}
+			err := dc.verifyImages(context.Background(), tt.options)
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, verifier.verified)
+		})
+	}
+}
+
+type fakeBuilderWithEnvVars struct {
+	fakeV2Builder
+	envVars map[string]string
+}
+
+func (b *fakeBuilderWithEnvVars) GetBuildEnvVars() map[string]string {
+	return b.envVars
+}
@@ -17,7 +17,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/signal"
 	"time"
 
 	"github.com/okteto/okteto/pkg/cmd/pipeline"
@@ -26,6 +25,7 @@ import (
 	"github.com/okteto/okteto/pkg/k8s/statefulsets"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/signals"
 )
 
 type DeployWaiter struct {
@@ -44,7 +44,7 @@ func (dw *DeployWaiter) wait(ctx context.Context, opts *Options) error {
 	defer oktetoLog.StopSpinner()
 
 	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt)
+	signals.Notify(stop)
 	exit := make(chan error, 1)
 	go func() {
 		exit <- dw.waitForResourcesToBeRunning(ctx, opts)
@@ -0,0 +1,38 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/webhook"
+)
+
+// newDeployWebhookEvent returns the event sent to the webhooks of the manifest on the deploy lifecycle
+func newDeployWebhookEvent(event model.WebhookEvent, deployOptions *Options, err error) webhook.Event {
+	e := webhook.Event{
+		Event:     event,
+		Name:      deployOptions.Name,
+		Namespace: deployOptions.Manifest.Namespace,
+		Context:   okteto.Context().Name,
+		User:      okteto.Context().Username,
+	}
+	if e.Namespace == "" {
+		e.Namespace = okteto.Context().Namespace
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	return e
+}
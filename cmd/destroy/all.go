@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"os/signal"
 	"sync"
 	"time"
 
@@ -16,6 +15,7 @@ import (
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/signals"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -63,7 +63,7 @@ func (dc *localDestroyAllCommand) destroy(ctx context.Context, opts *Options) er
 	stop := make(chan os.Signal, 1)
 	defer close(stop)
 
-	signal.Notify(stop, os.Interrupt)
+	signals.Notify(stop)
 	exit := make(chan error, 1)
 	defer close(exit)
 
@@ -49,6 +49,7 @@ const (
 type destroyer interface {
 	DestroyWithLabel(ctx context.Context, ns string, opts namespaces.DeleteAllOptions) error
 	DestroySFSVolumes(ctx context.Context, ns string, opts namespaces.DeleteAllOptions) error
+	DestroyCustomResources(ctx context.Context, ns string, opts namespaces.DeleteAllOptions) error
 }
 
 type secretHandler interface {
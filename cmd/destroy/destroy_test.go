@@ -53,10 +53,12 @@ var fakeManifest *model.Manifest = &model.Manifest{
 }
 
 type fakeDestroyer struct {
-	destroyed        bool
-	destroyedVolumes bool
-	err              error
-	errOnVolumes     error
+	destroyed                bool
+	destroyedVolumes         bool
+	destroyedCustomResources bool
+	err                      error
+	errOnVolumes             error
+	errOnCustomResources     error
 }
 
 type fakeSecretHandler struct {
@@ -87,6 +89,15 @@ func (fd *fakeDestroyer) DestroySFSVolumes(_ context.Context, _ string, _ namesp
 	return nil
 }
 
+func (fd *fakeDestroyer) DestroyCustomResources(_ context.Context, _ string, _ namespaces.DeleteAllOptions) error {
+	if fd.errOnCustomResources != nil {
+		return fd.errOnCustomResources
+	}
+
+	fd.destroyedCustomResources = true
+	return nil
+}
+
 func (fd *fakeSecretHandler) List(_ context.Context, _, _ string) ([]v1.Secret, error) {
 	if fd.err != nil {
 		return nil, fd.err
@@ -166,6 +177,68 @@ func TestDestroyWithErrorDeletingVolumes(t *testing.T) {
 	assert.NotNil(t, cfg)
 }
 
+func TestDestroyWithErrorDeletingCustomResources(t *testing.T) {
+	ctx := context.Background()
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {
+				Namespace: "test",
+			},
+		},
+		CurrentContext: "test",
+	}
+	tests := []struct {
+		name            string
+		force           bool
+		expectErr       bool
+		expectDestroyed bool
+	}{
+		{
+			name:            "WithoutForce",
+			expectErr:       true,
+			expectDestroyed: false,
+		},
+		{
+			name:            "WithForce",
+			force:           true,
+			expectErr:       false,
+			expectDestroyed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClientProvider := test.NewFakeK8sProvider()
+			fakeClient, _, err := k8sClientProvider.Provide(api.NewConfig())
+			if err != nil {
+				t.Fatal("could not create fake k8s client")
+			}
+			destroyer := &fakeDestroyer{
+				errOnCustomResources: assert.AnError,
+			}
+			ld := localDestroyCommand{
+				&localDestroyAllCommand{
+					ConfigMapHandler:  NewConfigmapHandler(fakeClient),
+					nsDestroyer:       destroyer,
+					executor:          &fakeExecutor{},
+					k8sClientProvider: k8sClientProvider,
+					secrets:           &fakeSecretHandler{},
+				},
+				fakeManifest,
+			}
+
+			err = ld.runDestroy(ctx, &Options{Name: "test-app", ForceDestroy: tt.force})
+
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.True(t, destroyer.destroyedVolumes)
+			assert.Equal(t, tt.expectDestroyed, destroyer.destroyed)
+		})
+	}
+}
+
 func TestDestroyWithErrorListingSecrets(t *testing.T) {
 	ctx := context.Background()
 	secretHandler := fakeSecretHandler{
@@ -5,19 +5,20 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"os/signal"
 	"strings"
 
 	pipelineCMD "github.com/okteto/okteto/cmd/pipeline"
 	"github.com/okteto/okteto/pkg/cmd/pipeline"
 	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/divert"
+	"github.com/okteto/okteto/pkg/envprovider"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/format"
 	"github.com/okteto/okteto/pkg/k8s/namespaces"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/signals"
 	"github.com/okteto/okteto/pkg/types"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
@@ -138,7 +139,7 @@ func (ld *localDestroyCommand) runDestroy(ctx context.Context, opts *Options) er
 
 	var commandErr error
 	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt)
+	signals.Notify(stop)
 	exit := make(chan error, 1)
 
 	// destroy divert if any
@@ -159,7 +160,13 @@ func (ld *localDestroyCommand) runDestroy(ctx context.Context, opts *Options) er
 		for _, command := range ld.manifest.Destroy.Commands {
 			oktetoLog.Information("Running '%s'", command.Name)
 			oktetoLog.SetStage(command.Name)
-			if err := ld.executor.Execute(command, opts.Variables); err != nil {
+			// the references to secret managers are resolved into env vars, so their values aren't interpreted by the shell
+			expanded, secretVars, err := envprovider.ExpandCommand(command.Command)
+			if err == nil {
+				command.Command = expanded
+				err = ld.executor.Execute(command, append(append([]string{}, opts.Variables...), secretVars...))
+			}
+			if err != nil {
 				err = fmt.Errorf("error executing command '%s': %s", command.Name, err.Error())
 				if !opts.ForceDestroy {
 					if err := ld.ConfigMapHandler.setErrorStatus(ctx, cfg, data, err); err != nil {
@@ -223,6 +230,16 @@ func (ld *localDestroyCommand) runDestroy(ctx context.Context, opts *Options) er
 		return err
 	}
 
+	oktetoLog.SetStage("Destroying custom resources")
+	if err := ld.nsDestroyer.DestroyCustomResources(ctx, opts.Namespace, deleteOpts); err != nil {
+		if !opts.ForceDestroy {
+			if err := ld.ConfigMapHandler.setErrorStatus(ctx, cfg, data, err); err != nil {
+				return err
+			}
+			return err
+		}
+	}
+
 	oktetoLog.SetStage("Destroying Helm release")
 	if err := ld.destroyHelmReleasesIfPresent(ctx, opts, deployedBySelector); err != nil {
 		if !opts.ForceDestroy {
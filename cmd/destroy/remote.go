@@ -184,6 +184,8 @@ func (rd *remoteDestroyCommand) destroy(ctx context.Context, opts *Options) erro
 
 	buildOptions := build.OptsFromBuildInfoForRemoteDeploy(buildInfo, &types.BuildOptions{Path: cwd, OutputMode: "destroy"})
 	buildOptions.Manifest = rd.manifest
+	// the Dockerfile of the remote execution is generated by okteto
+	buildOptions.NoLint = true
 	buildOptions.BuildArgs = append(
 		buildOptions.BuildArgs,
 		fmt.Sprintf("%s=%s", model.OktetoContextEnvVar, okteto.Context().Name),
@@ -0,0 +1,349 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/cmd/stack"
+	"github.com/okteto/okteto/pkg/devenvironment"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/format"
+	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/k8s/statefulsets"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/cobra"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// diffOptions are the options of the diff command
+type diffOptions struct {
+	devPath    string
+	name       string
+	namespace  string
+	k8sContext string
+	exitCode   bool
+}
+
+// workloadState is the part of a deployment or statefulset compared by the diff command
+type workloadState struct {
+	kind     string
+	name     string
+	replicas int32
+	// images are the images of the containers by container name
+	images map[string]string
+	// envs are the environment variables of the containers by container name
+	envs map[string]map[string]string
+}
+
+func (w workloadState) String() string {
+	return fmt.Sprintf("%s/%s", w.kind, w.name)
+}
+
+// workloadDiff are the differences of a workload between the local manifest and the namespace
+type workloadDiff struct {
+	workload string
+	// status is '+' for workloads that are not deployed, '-' for workloads that are not in the manifest and '~' for changed workloads
+	status  string
+	changes []string
+}
+
+// Diff compares the workloads rendered from the local manifest with the ones deployed in the namespace
+func Diff() *cobra.Command {
+	opts := &diffOptions{}
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Compare your okteto manifest with your deployed development environment",
+		Long: `Compare your okteto manifest with your deployed development environment.
+
+The images, environment variables and replicas of the deployments and statefulsets rendered from the compose section of your okteto manifest are compared with the ones deployed in your namespace.
+The workloads deployed by the commands of the deploy section can't be rendered locally, so they are only listed if they are missing from the namespace.`,
+		Args: utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#diff"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			manifestOpts := contextCMD.ManifestOptions{Filename: opts.devPath, Namespace: opts.namespace, K8sContext: opts.k8sContext}
+			manifest, err := contextCMD.LoadManifestWithContext(ctx, manifestOpts)
+			if err != nil {
+				return err
+			}
+
+			c, _, err := okteto.GetK8sClient()
+			if err != nil {
+				return err
+			}
+
+			if opts.name == "" {
+				opts.name = manifest.Name
+			}
+			if opts.name == "" {
+				cwd, err := os.Getwd()
+				if err != nil {
+					return fmt.Errorf("failed to get the current working directory: %w", err)
+				}
+				opts.name = devenvironment.NewNameInferer(c).InferName(ctx, cwd, okteto.Context().Namespace, opts.devPath)
+			}
+
+			local, err := renderLocalWorkloads(manifest, opts.name, okteto.Context().Namespace)
+			if err != nil {
+				return err
+			}
+			deployed, err := getDeployedWorkloads(ctx, opts.name, okteto.Context().Namespace, c)
+			if err != nil {
+				return err
+			}
+
+			diffs := getWorkloadDiffs(local, deployed, hasDeployCommands(manifest))
+			if len(diffs) == 0 {
+				oktetoLog.Success("Development environment '%s' is up to date", opts.name)
+				return nil
+			}
+			printWorkloadDiffs(diffs)
+			if opts.exitCode {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("%d workloads of development environment '%s' differ from your okteto manifest", len(diffs), opts.name),
+					Hint: "Run 'okteto deploy' to update them",
+				}
+			}
+			oktetoLog.Information("Run 'okteto deploy' to update %d workloads of development environment '%s'", len(diffs), opts.name)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.devPath, "file", "f", "", "path to the okteto manifest file")
+	cmd.Flags().StringVar(&opts.name, "name", "", "development environment name")
+	cmd.Flags().StringVarP(&opts.namespace, "namespace", "n", "", "namespace where the development environment is deployed")
+	cmd.Flags().StringVarP(&opts.k8sContext, "context", "c", "", "context where the development environment is deployed")
+	cmd.Flags().BoolVar(&opts.exitCode, "exit-code", false, "fail if the development environment differs from the okteto manifest")
+	return cmd
+}
+
+// hasDeployCommands returns true if the deploy section has commands, whose workloads can't be rendered locally
+func hasDeployCommands(manifest *model.Manifest) bool {
+	return manifest.Deploy != nil && (len(manifest.Deploy.Commands) > 0 || len(manifest.Deploy.Services) > 0)
+}
+
+// renderLocalWorkloads returns the workloads rendered from the compose section of the manifest by workload
+func renderLocalWorkloads(manifest *model.Manifest, name, namespace string) (map[string]workloadState, error) {
+	result := map[string]workloadState{}
+	if manifest.Deploy == nil || manifest.Deploy.ComposeSection == nil || manifest.Deploy.ComposeSection.Stack == nil {
+		return result, nil
+	}
+
+	s := manifest.Deploy.ComposeSection.Stack
+	s.Name = name
+	s.Namespace = namespace
+	services, err := stack.GetServicesEnabledByProfiles(s, nil)
+	if err != nil {
+		return nil, err
+	}
+	deploymentList, statefulsetList := stack.RenderWorkloads(s, services)
+	for _, d := range deploymentList {
+		w := newWorkloadState(deploymentKind, d.Name, d.Spec.Replicas, d.Spec.Template.Spec.Containers)
+		result[w.String()] = w
+	}
+	for _, sfs := range statefulsetList {
+		w := newWorkloadState(statefulsetKind, sfs.Name, sfs.Spec.Replicas, sfs.Spec.Template.Spec.Containers)
+		result[w.String()] = w
+	}
+	return result, nil
+}
+
+// getDeployedWorkloads returns the deployments and statefulsets of a development environment by workload.
+// The replicas of the workloads in development mode are the ones they had before activating it
+func getDeployedWorkloads(ctx context.Context, name, namespace string, c kubernetes.Interface) (map[string]workloadState, error) {
+	result := map[string]workloadState{}
+	belongsToDevEnvironment := func(m metav1.ObjectMeta) bool {
+		if m.Labels[model.DevCloneLabel] != "" {
+			return false
+		}
+		sanitizedName := format.ResourceK8sMetaString(name)
+		return m.Labels[model.DeployedByLabel] == sanitizedName || m.Labels[model.StackNameLabel] == sanitizedName
+	}
+
+	deploymentList, err := deployments.List(ctx, namespace, "", c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the deployments of namespace '%s': %w", namespace, err)
+	}
+	for _, d := range deploymentList {
+		if !belongsToDevEnvironment(d.ObjectMeta) {
+			continue
+		}
+		w := newWorkloadState(deploymentKind, d.Name, d.Spec.Replicas, d.Spec.Template.Spec.Containers)
+		setReplicasBeforeDevMode(&w, d.Annotations)
+		result[w.String()] = w
+	}
+
+	statefulsetList, err := statefulsets.List(ctx, namespace, "", c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the statefulsets of namespace '%s': %w", namespace, err)
+	}
+	for _, sfs := range statefulsetList {
+		if !belongsToDevEnvironment(sfs.ObjectMeta) {
+			continue
+		}
+		w := newWorkloadState(statefulsetKind, sfs.Name, sfs.Spec.Replicas, sfs.Spec.Template.Spec.Containers)
+		setReplicasBeforeDevMode(&w, sfs.Annotations)
+		result[w.String()] = w
+	}
+	return result, nil
+}
+
+func newWorkloadState(kind, name string, replicas *int32, containers []apiv1.Container) workloadState {
+	w := workloadState{
+		kind:     kind,
+		name:     name,
+		replicas: 1,
+		images:   map[string]string{},
+		envs:     map[string]map[string]string{},
+	}
+	if replicas != nil {
+		w.replicas = *replicas
+	}
+	for _, container := range containers {
+		w.images[container.Name] = container.Image
+		w.envs[container.Name] = map[string]string{}
+		for _, env := range container.Env {
+			if env.ValueFrom != nil {
+				continue
+			}
+			w.envs[container.Name][env.Name] = env.Value
+		}
+	}
+	return w
+}
+
+func setReplicasBeforeDevMode(w *workloadState, annotations map[string]string) {
+	replicas, ok := annotations[model.AppReplicasAnnotation]
+	if !ok {
+		return
+	}
+	n, err := strconv.ParseInt(replicas, 10, 32)
+	if err != nil {
+		oktetoLog.Infof("invalid annotation '%s' in %s: %s", model.AppReplicasAnnotation, w, err)
+		return
+	}
+	w.replicas = int32(n)
+}
+
+// getWorkloadDiffs compares the local and deployed workloads.
+// When the deploy section has commands, the deployed workloads missing from the local ones are not reported, as they might be deployed by the commands
+func getWorkloadDiffs(local, deployed map[string]workloadState, hasCommands bool) []workloadDiff {
+	keys := map[string]bool{}
+	for k := range local {
+		keys[k] = true
+	}
+	for k := range deployed {
+		keys[k] = true
+	}
+	sortedKeys := make([]string, 0, len(keys))
+	for k := range keys {
+		sortedKeys = append(sortedKeys, k)
+	}
+	sort.Strings(sortedKeys)
+
+	result := []workloadDiff{}
+	for _, k := range sortedKeys {
+		l, inLocal := local[k]
+		d, inDeployed := deployed[k]
+		switch {
+		case !inDeployed:
+			result = append(result, workloadDiff{workload: k, status: "+", changes: []string{"not deployed"}})
+		case !inLocal:
+			if !hasCommands {
+				result = append(result, workloadDiff{workload: k, status: "-", changes: []string{"not in the okteto manifest"}})
+			}
+		default:
+			if changes := compareWorkloads(l, d); len(changes) > 0 {
+				result = append(result, workloadDiff{workload: k, status: "~", changes: changes})
+			}
+		}
+	}
+	return result
+}
+
+// compareWorkloads returns the changes from the deployed workload to the local one.
+// The values of the environment variables are not shown, as they might contain secrets
+func compareWorkloads(local, deployed workloadState) []string {
+	changes := []string{}
+	if local.replicas != deployed.replicas {
+		changes = append(changes, fmt.Sprintf("- replicas: %d", deployed.replicas), fmt.Sprintf("+ replicas: %d", local.replicas))
+	}
+
+	containers := make([]string, 0, len(local.images))
+	for container := range local.images {
+		containers = append(containers, container)
+	}
+	sort.Strings(containers)
+	for _, container := range containers {
+		deployedImage, ok := deployed.images[container]
+		if !ok {
+			changes = append(changes, fmt.Sprintf("+ container: %s", container))
+			continue
+		}
+		localImage := local.images[container]
+		if isRenderedImage(localImage) && localImage != deployedImage {
+			changes = append(changes, fmt.Sprintf("- image (%s): %s", container, deployedImage), fmt.Sprintf("+ image (%s): %s", container, localImage))
+		}
+
+		envs := []string{}
+		for env := range local.envs[container] {
+			envs = append(envs, env)
+		}
+		for env := range deployed.envs[container] {
+			if _, ok := local.envs[container][env]; !ok {
+				envs = append(envs, env)
+			}
+		}
+		sort.Strings(envs)
+		for _, env := range envs {
+			localValue, inLocal := local.envs[container][env]
+			deployedValue, inDeployed := deployed.envs[container][env]
+			switch {
+			case !inDeployed:
+				changes = append(changes, fmt.Sprintf("+ env (%s): %s", container, env))
+			case !inLocal:
+				changes = append(changes, fmt.Sprintf("- env (%s): %s", container, env))
+			case localValue != deployedValue:
+				changes = append(changes, fmt.Sprintf("~ env (%s): %s", container, env))
+			}
+		}
+	}
+	return changes
+}
+
+// isRenderedImage returns false for the images that are only known after building them on deploy
+func isRenderedImage(image string) bool {
+	return image != "" && !strings.Contains(image, "$")
+}
+
+func printWorkloadDiffs(diffs []workloadDiff) {
+	for _, d := range diffs {
+		oktetoLog.Println(fmt.Sprintf("%s %s", d.status, d.workload))
+		for _, change := range d.changes {
+			oktetoLog.Println(fmt.Sprintf("    %s", change))
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
+)
+
+func TestRenderLocalWorkloads(t *testing.T) {
+	manifest := &model.Manifest{
+		Deploy: &model.DeployInfo{
+			ComposeSection: &model.ComposeSectionInfo{
+				Stack: &model.Stack{
+					Services: map[string]*model.Service{
+						"api": {
+							Image:         "okteto/api:2",
+							Replicas:      2,
+							Environment:   model.Environment{{Name: "PORT", Value: "8080"}},
+							RestartPolicy: apiv1.RestartPolicyAlways,
+						},
+						"db": {
+							Image:         "postgres:15",
+							Replicas:      1,
+							RestartPolicy: apiv1.RestartPolicyAlways,
+							Volumes:       []model.StackVolume{{RemotePath: "/var/lib/postgresql/data"}},
+							Resources:     &model.StackResources{},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	local, err := renderLocalWorkloads(manifest, "movies", "ns")
+	require.NoError(t, err)
+	require.Contains(t, local, "deployment/api")
+	require.Contains(t, local, "statefulset/db")
+	assert.Equal(t, int32(2), local["deployment/api"].replicas)
+	assert.Equal(t, "okteto/api:2", local["deployment/api"].images["api"])
+	assert.Equal(t, map[string]string{"PORT": "8080"}, local["deployment/api"].envs["api"])
+}
+
+func TestGetDeployedWorkloads(t *testing.T) {
+	c := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "api",
+				Namespace:   "ns",
+				Labels:      map[string]string{model.DeployedByLabel: "movies"},
+				Annotations: map[string]string{model.AppReplicasAnnotation: "3"},
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: pointer.Int32(0),
+				Template: apiv1.PodTemplateSpec{Spec: apiv1.PodSpec{Containers: []apiv1.Container{{Name: "api", Image: "okteto/api:1"}}}},
+			},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "api-okteto",
+				Namespace: "ns",
+				Labels:    map[string]string{model.DeployedByLabel: "movies", model.DevCloneLabel: "uid"},
+			},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "ns", Labels: map[string]string{model.DeployedByLabel: "other"}},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "ns", Labels: map[string]string{model.StackNameLabel: "movies"}},
+		},
+	)
+
+	deployed, err := getDeployedWorkloads(context.Background(), "movies", "ns", c)
+	require.NoError(t, err)
+	assert.Len(t, deployed, 2)
+	assert.Equal(t, int32(3), deployed["deployment/api"].replicas)
+	assert.Equal(t, "okteto/api:1", deployed["deployment/api"].images["api"])
+	assert.Contains(t, deployed, "statefulset/db")
+}
+
+func TestGetWorkloadDiffs(t *testing.T) {
+	api := workloadState{
+		kind:     deploymentKind,
+		name:     "api",
+		replicas: 1,
+		images:   map[string]string{"api": "okteto/api:1"},
+		envs:     map[string]map[string]string{"api": {"PORT": "8080", "DEBUG": "true", "TOKEN": "a"}},
+	}
+	localAPI := workloadState{
+		kind:     deploymentKind,
+		name:     "api",
+		replicas: 2,
+		images:   map[string]string{"api": "okteto/api:2"},
+		envs:     map[string]map[string]string{"api": {"PORT": "8080", "TOKEN": "b", "LOG_LEVEL": "info"}},
+	}
+	worker := workloadState{kind: deploymentKind, name: "worker", replicas: 1, images: map[string]string{"worker": "${OKTETO_BUILD_WORKER_IMAGE}"}}
+	db := workloadState{kind: statefulsetKind, name: "db", replicas: 1}
+
+	local := map[string]workloadState{"deployment/api": localAPI, "deployment/worker": worker}
+	deployed := map[string]workloadState{"deployment/api": api, "statefulset/db": db}
+
+	assert.Equal(t, []workloadDiff{
+		{
+			workload: "deployment/api",
+			status:   "~",
+			changes: []string{
+				"- replicas: 1",
+				"+ replicas: 2",
+				"- image (api): okteto/api:1",
+				"+ image (api): okteto/api:2",
+				"- env (api): DEBUG",
+				"+ env (api): LOG_LEVEL",
+				"~ env (api): TOKEN",
+			},
+		},
+		{workload: "deployment/worker", status: "+", changes: []string{"not deployed"}},
+		{workload: "statefulset/db", status: "-", changes: []string{"not in the okteto manifest"}},
+	}, getWorkloadDiffs(local, deployed, false))
+
+	diffs := getWorkloadDiffs(local, deployed, true)
+	assert.Len(t, diffs, 2)
+
+	worker.images["worker"] = "okteto/worker:1"
+	assert.Empty(t, getWorkloadDiffs(map[string]workloadState{"deployment/worker": worker}, map[string]workloadState{"deployment/worker": worker}, false))
+}
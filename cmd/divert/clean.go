@@ -0,0 +1,80 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package divert
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/divert/istio"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+// Clean removes the stale diverts of the developer
+func Clean(ctx context.Context) *cobra.Command {
+	var namespace string
+	var all bool
+	cmd := &cobra.Command{
+		Use:   "clean",
+		Short: "Remove the diverts left by interrupted development environments",
+		Args:  utils.NoArgsAccepted("https://www.okteto.com/docs/reference/cli/#divert"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := loadOktetoContext(ctx, ""); err != nil {
+				return err
+			}
+			dc, err := NewCommand()
+			if err != nil {
+				return err
+			}
+			diverts, err := dc.listDiverts(ctx, namespace)
+			if err != nil {
+				return err
+			}
+			return dc.clean(ctx, selectDivertsToClean(diverts, all))
+		},
+	}
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "only remove the diverts targeting this namespace")
+	cmd.Flags().BoolVar(&all, "all", false, "remove active diverts too, not only the stale ones")
+	return cmd
+}
+
+func selectDivertsToClean(diverts []istio.DivertStatus, all bool) []istio.DivertStatus {
+	result := []istio.DivertStatus{}
+	for _, d := range diverts {
+		if all || d.Stale {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+func (dc *Command) clean(ctx context.Context, diverts []istio.DivertStatus) error {
+	if len(diverts) == 0 {
+		oktetoLog.Success("There are no diverts to clean")
+		return nil
+	}
+	for _, d := range diverts {
+		oktetoLog.Spinner(fmt.Sprintf("Restoring virtual service %s/%s...", d.Namespace, d.VirtualService))
+		oktetoLog.StartSpinner()
+		err := istio.CleanDivert(ctx, d, dc.istioClient)
+		oktetoLog.StopSpinner()
+		if err != nil {
+			return fmt.Errorf("failed to remove divert of virtual service '%s/%s' to namespace '%s': %w", d.Namespace, d.VirtualService, d.TargetNamespace, err)
+		}
+		oktetoLog.Success("Divert of virtual service '%s/%s' to namespace '%s' removed", d.Namespace, d.VirtualService, d.TargetNamespace)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package divert
+
+import (
+	"context"
+	"fmt"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/divert/istio"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/virtualservices"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/cobra"
+	istioclientset "istio.io/client-go/pkg/clientset/versioned"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Command has all the divert subcommands
+type Command struct {
+	okClient    types.OktetoInterface
+	k8sClient   kubernetes.Interface
+	istioClient istioclientset.Interface
+}
+
+// NewCommand creates a divert command for use in further operations
+func NewCommand() (*Command, error) {
+	okClient, err := okteto.NewOktetoClient()
+	if err != nil {
+		return nil, err
+	}
+	c, _, err := okteto.NewK8sClientProvider().Provide(okteto.Context().Cfg)
+	if err != nil {
+		return nil, err
+	}
+	ic, err := virtualservices.GetIstioClient()
+	if err != nil {
+		return nil, fmt.Errorf("error creating istio client: %w", err)
+	}
+	return &Command{
+		okClient:    okClient,
+		k8sClient:   c,
+		istioClient: ic,
+	}, nil
+}
+
+// Divert divert management commands
+func Divert(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "divert",
+		Short: "Divert management commands",
+		Args:  utils.NoArgsAccepted("https://www.okteto.com/docs/reference/cli/#divert"),
+	}
+	cmd.AddCommand(Status(ctx))
+	cmd.AddCommand(Clean(ctx))
+	return cmd
+}
+
+// listDiverts returns the diverts targeting the developer namespaces, or only namespace if it's not empty
+func (dc *Command) listDiverts(ctx context.Context, namespace string) ([]istio.DivertStatus, error) {
+	spaces, err := dc.okClient.Namespaces().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespaces: %w", err)
+	}
+
+	namespaces := []string{}
+	targets := map[string]bool{}
+	for _, space := range spaces {
+		namespaces = append(namespaces, space.ID)
+		targets[space.ID] = true
+	}
+	if namespace != "" {
+		targets = map[string]bool{namespace: true}
+	}
+
+	diverts, err := istio.ListDiverts(ctx, namespaces, targets, dc.istioClient)
+	if err != nil {
+		return nil, err
+	}
+	if err := istio.MarkStaleDiverts(ctx, diverts, dc.k8sClient); err != nil {
+		return nil, err
+	}
+	return diverts, nil
+}
+
+func loadOktetoContext(ctx context.Context, namespace string) error {
+	ctxOptions := &contextCMD.ContextOptions{
+		Namespace: namespace,
+		Show:      true,
+	}
+	if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+		return err
+	}
+
+	if !okteto.IsOkteto() {
+		return oktetoErrors.ErrContextIsNotOktetoCluster
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package divert
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/divert/istio"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// Status shows the active diverts of the developer
+func Status(ctx context.Context) *cobra.Command {
+	var namespace string
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "List the active diverts created by your development environments",
+		Args:  utils.NoArgsAccepted("https://www.okteto.com/docs/reference/cli/#divert"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := loadOktetoContext(ctx, ""); err != nil {
+				return err
+			}
+			dc, err := NewCommand()
+			if err != nil {
+				return err
+			}
+			diverts, err := dc.listDiverts(ctx, namespace)
+			if err != nil {
+				return err
+			}
+			if len(diverts) == 0 {
+				oktetoLog.Println("There are no active diverts")
+				return nil
+			}
+			displayDiverts(os.Stdout, diverts, time.Now())
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "only show the diverts targeting this namespace")
+	return cmd
+}
+
+func displayDiverts(out io.Writer, diverts []istio.DivertStatus, now time.Time) {
+	w := tabwriter.NewWriter(out, 1, 1, 2, ' ', 0)
+	fmt.Fprintf(w, "Virtual Service\tTarget Namespace\tAge\tStatus\n")
+	for _, d := range diverts {
+		age := "-"
+		if !d.CreatedAt.IsZero() {
+			age = duration.HumanDuration(now.Sub(d.CreatedAt))
+		}
+		status := "active"
+		if d.Stale {
+			status = "stale"
+		}
+		fmt.Fprintf(w, "%s/%s\t%s\t%s\t%s\n", d.Namespace, d.VirtualService, d.TargetNamespace, age, status)
+	}
+	w.Flush()
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package divert
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/divert/istio"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_displayDiverts(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	diverts := []istio.DivertStatus{
+		{
+			VirtualService:  "frontend",
+			Namespace:       "staging",
+			TargetNamespace: "cindy",
+			CreatedAt:       now.Add(-2 * time.Hour),
+		},
+		{
+			VirtualService:  "api",
+			Namespace:       "staging",
+			TargetNamespace: "cindy",
+			Stale:           true,
+		},
+	}
+	var b bytes.Buffer
+	displayDiverts(&b, diverts, now)
+	expected := "Virtual Service   Target Namespace  Age   Status\n" +
+		"staging/frontend  cindy             120m  active\n" +
+		"staging/api       cindy             -     stale\n"
+	assert.Equal(t, expected, b.String())
+}
+
+func Test_selectDivertsToClean(t *testing.T) {
+	diverts := []istio.DivertStatus{
+		{VirtualService: "frontend", Namespace: "staging"},
+		{VirtualService: "api", Namespace: "staging", Stale: true},
+	}
+	assert.Equal(t, []istio.DivertStatus{diverts[1]}, selectDivertsToClean(diverts, false))
+	assert.Equal(t, diverts, selectDivertsToClean(diverts, true))
+}
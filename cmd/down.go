@@ -18,7 +18,6 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"os/signal"
 
 	contextCMD "github.com/okteto/okteto/cmd/context"
 	"github.com/okteto/okteto/cmd/utils"
@@ -32,6 +31,7 @@ import (
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/signals"
 	"github.com/okteto/okteto/pkg/syncthing"
 	"github.com/spf13/cobra"
 )
@@ -45,9 +45,10 @@ func Down() *cobra.Command {
 	var all bool
 
 	cmd := &cobra.Command{
-		Use:   "down [svc]",
-		Short: "Deactivate your development container",
-		Args:  utils.MaximumNArgsAccepted(1, "https://okteto.com/docs/reference/cli/#down"),
+		Use:               "down [svc]",
+		Short:             "Deactivate your development container",
+		Args:              utils.MaximumNArgsAccepted(1, "https://okteto.com/docs/reference/cli/#down"),
+		ValidArgsFunction: utils.CompleteDevs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 
@@ -110,7 +111,7 @@ func Down() *cobra.Command {
 					return err
 				}
 
-				if apps.IsDevModeOn(app) {
+				if apps.IsDevModeActive(ctx, app, c) {
 					if err := runDown(ctx, dev, rm); err != nil {
 						analytics.TrackDown(false)
 						return fmt.Errorf("%w\n    Find additional logs at: %s/okteto.log", err, config.GetAppHome(dev.Namespace, dev.Name))
@@ -153,7 +154,7 @@ func allDown(ctx context.Context, manifest *model.Manifest, rm bool) error {
 			return err
 		}
 
-		if apps.IsDevModeOn(app) {
+		if apps.IsDevModeActive(ctx, app, c) {
 			oktetoLog.StopSpinner()
 			if err := runDown(ctx, dev, rm); err != nil {
 				analytics.TrackDown(false)
@@ -172,7 +173,7 @@ func runDown(ctx context.Context, dev *model.Dev, rm bool) error {
 	defer oktetoLog.StopSpinner()
 
 	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt)
+	signals.Notify(stop)
 	exit := make(chan error, 1)
 
 	go func() {
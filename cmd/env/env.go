@@ -0,0 +1,116 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// bundleVersion is the version of the format of the environment bundles
+const bundleVersion = 1
+
+// bundle is a portable snapshot of a development environment
+type bundle struct {
+	Version    int              `yaml:"version"`
+	Name       string           `yaml:"name"`
+	Context    string           `yaml:"context,omitempty"`
+	Namespace  string           `yaml:"namespace,omitempty"`
+	Repository string           `yaml:"repository,omitempty"`
+	Branch     string           `yaml:"branch,omitempty"`
+	Filename   string           `yaml:"filename,omitempty"`
+	Manifest   string           `yaml:"manifest"`
+	Variables  []types.Variable `yaml:"variables,omitempty"`
+	Images     []bundleImage    `yaml:"images,omitempty"`
+	Externals  []bundleExternal `yaml:"externals,omitempty"`
+}
+
+// bundleImage is the image by digest of a container of a workload of the environment
+type bundleImage struct {
+	Kind      string `yaml:"kind"`
+	Name      string `yaml:"name"`
+	Container string `yaml:"container"`
+	Image     string `yaml:"image"`
+}
+
+// bundleExternal is an external resource of the environment
+type bundleExternal struct {
+	Name      string           `yaml:"name"`
+	Endpoints []bundleEndpoint `yaml:"endpoints,omitempty"`
+}
+
+// bundleEndpoint is an endpoint of an external resource
+type bundleEndpoint struct {
+	Name string `yaml:"name"`
+	URL  string `yaml:"url"`
+}
+
+// Env environment bundle commands
+func Env(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "env",
+		Short: "Export and import development environments",
+		Args:  utils.NoArgsAccepted("https://www.okteto.com/docs/reference/cli/#env"),
+	}
+	cmd.AddCommand(exportCmd(ctx))
+	cmd.AddCommand(importCmd(ctx))
+	return cmd
+}
+
+// writeBundle writes the bundle to a file. The file is only readable by the user because it contains the variables of the environment
+func writeBundle(fs afero.Fs, path string, b *bundle) error {
+	bytes, err := yaml.Marshal(b)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the environment bundle: %w", err)
+	}
+	if err := afero.WriteFile(fs, path, bytes, 0600); err != nil {
+		return fmt.Errorf("failed to write the environment bundle: %w", err)
+	}
+	return nil
+}
+
+// readBundle reads a bundle from a file
+func readBundle(fs afero.Fs, path string) (*bundle, error) {
+	bytes, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the environment bundle: %w", err)
+	}
+	b := &bundle{}
+	if err := yaml.UnmarshalStrict(bytes, b); err != nil {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("'%s' is not a valid environment bundle: %w", path, err),
+			Hint: "Generate the bundle with 'okteto env export'",
+		}
+	}
+	if b.Version != bundleVersion {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("the version '%d' of the environment bundle is not supported", b.Version),
+			Hint: "Update okteto to the latest version and try again",
+		}
+	}
+	if b.Name == "" || b.Manifest == "" {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("'%s' is not a valid environment bundle: the name and the manifest are required", path),
+			Hint: "Generate the bundle with 'okteto env export'",
+		}
+	}
+	return b, nil
+}
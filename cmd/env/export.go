@@ -0,0 +1,312 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	externalK8s "github.com/okteto/okteto/pkg/externalresource/k8s"
+	"github.com/okteto/okteto/pkg/format"
+	"github.com/okteto/okteto/pkg/k8s/configmaps"
+	"github.com/okteto/okteto/pkg/k8s/cronjobs"
+	"github.com/okteto/okteto/pkg/k8s/daemonsets"
+	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/k8s/pods"
+	"github.com/okteto/okteto/pkg/k8s/statefulsets"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type exportFlags struct {
+	namespace string
+	context   string
+	output    string
+}
+
+// exporter builds the bundle of a deployed development environment
+type exporter struct {
+	k8sClient      kubernetes.Interface
+	externalClient externalK8s.ExternalResourceV1Interface
+}
+
+func exportCmd(ctx context.Context) *cobra.Command {
+	flags := &exportFlags{}
+	cmd := &cobra.Command{
+		Use:   "export <name>",
+		Short: "Export a development environment to a portable bundle",
+		Long: `Export a development environment to a portable bundle.
+
+The bundle contains the okteto manifest, the variables, the images by digest and the external resources of the development environment.
+Use 'okteto env import' to reproduce it in another namespace or cluster.`,
+		Args: utils.ExactArgsAccepted(1, "https://www.okteto.com/docs/reference/cli/#env"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctxOptions := &contextCMD.ContextOptions{
+				Context:   flags.context,
+				Namespace: flags.namespace,
+				Show:      true,
+			}
+			if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+				return err
+			}
+
+			okCtx := okteto.Context()
+			k8sClient, cfg, err := okteto.GetK8sClient()
+			if err != nil {
+				return fmt.Errorf("failed to load okteto context '%s': %w", okCtx.Name, err)
+			}
+			externalClient, err := externalK8s.GetExternalClient(cfg)
+			if err != nil {
+				return fmt.Errorf("failed to create the external resources client: %w", err)
+			}
+			e := &exporter{
+				k8sClient:      k8sClient,
+				externalClient: externalClient,
+			}
+
+			b, err := e.export(ctx, args[0], okCtx.Namespace)
+			if err != nil {
+				return err
+			}
+			b.Context = okCtx.Name
+
+			output := flags.output
+			if output == "" {
+				output = fmt.Sprintf("%s.okteto-env.yml", format.ResourceK8sMetaString(args[0]))
+			}
+			if err := writeBundle(afero.NewOsFs(), output, b); err != nil {
+				return err
+			}
+			oktetoLog.Success("Development environment '%s' exported to '%s'", b.Name, output)
+			if len(b.Variables) > 0 {
+				oktetoLog.Warning("The bundle contains the variables of the development environment. Don't share it if they are sensitive")
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&flags.namespace, "namespace", "n", "", "namespace where the development environment is deployed (defaults to the current namespace)")
+	cmd.Flags().StringVarP(&flags.context, "context", "c", "", "context where the development environment is deployed (defaults to the current context)")
+	cmd.Flags().StringVarP(&flags.output, "output", "o", "", "path of the bundle (defaults to '<name>.okteto-env.yml')")
+	return cmd
+}
+
+// export returns the bundle of the development environment deployed in the namespace
+func (e *exporter) export(ctx context.Context, name, namespace string) (*bundle, error) {
+	cmap, err := configmaps.Get(ctx, pipeline.TranslatePipelineName(name), namespace, e.k8sClient)
+	if err != nil {
+		if oktetoErrors.IsNotFound(err) {
+			return nil, oktetoErrors.UserError{
+				E:    fmt.Errorf("development environment '%s' not found in namespace '%s'", name, namespace),
+				Hint: "Deploy it with 'okteto deploy' or check the name with 'okteto pipeline list'",
+			}
+		}
+		return nil, err
+	}
+	data, err := pipeline.TranslateConfigMapToCfgData(cmap)
+	if err != nil {
+		return nil, err
+	}
+	if len(data.Manifest) == 0 {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("development environment '%s' has no okteto manifest", name),
+			Hint: "Redeploy it with a recent version of okteto and try again",
+		}
+	}
+
+	b := &bundle{
+		Version:    bundleVersion,
+		Name:       data.Name,
+		Namespace:  namespace,
+		Repository: data.Repository,
+		Branch:     data.Branch,
+		Filename:   data.Filename,
+		Manifest:   string(data.Manifest),
+	}
+	for _, v := range data.Variables {
+		kv := strings.SplitN(v, "=", 2)
+		if len(kv) != 2 {
+			oktetoLog.Infof("skipping a variable of '%s' without a value", name)
+			continue
+		}
+		b.Variables = append(b.Variables, types.Variable{Name: kv[0], Value: kv[1]})
+	}
+
+	b.Images, err = e.getImages(ctx, data.Name, namespace)
+	if err != nil {
+		return nil, err
+	}
+	b.Externals, err = e.getExternals(ctx, data.Manifest, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// getImages returns the images by digest of the deployments, statefulsets, daemonsets and cronjobs of the development environment
+func (e *exporter) getImages(ctx context.Context, name, namespace string) ([]bundleImage, error) {
+	labelSelector := fmt.Sprintf("%s=%s", model.DeployedByLabel, format.ResourceK8sMetaString(name))
+	result := []bundleImage{}
+
+	dList, err := deployments.List(ctx, namespace, labelSelector, e.k8sClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the deployments of '%s': %w", name, err)
+	}
+	for _, d := range dList {
+		images, err := e.getWorkloadImages(ctx, "Deployment", d.Name, namespace, d.Spec.Selector, d.Spec.Template.Spec.Containers)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, images...)
+	}
+
+	sfsList, err := statefulsets.List(ctx, namespace, labelSelector, e.k8sClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the statefulsets of '%s': %w", name, err)
+	}
+	for _, sfs := range sfsList {
+		images, err := e.getWorkloadImages(ctx, "StatefulSet", sfs.Name, namespace, sfs.Spec.Selector, sfs.Spec.Template.Spec.Containers)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, images...)
+	}
+
+	dsList, err := daemonsets.List(ctx, namespace, labelSelector, e.k8sClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the daemonsets of '%s': %w", name, err)
+	}
+	for _, ds := range dsList {
+		images, err := e.getWorkloadImages(ctx, "DaemonSet", ds.Name, namespace, ds.Spec.Selector, ds.Spec.Template.Spec.Containers)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, images...)
+	}
+
+	cjList, err := cronjobs.List(ctx, namespace, labelSelector, e.k8sClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the cronjobs of '%s': %w", name, err)
+	}
+	for _, cj := range cjList {
+		// the pods of a cronjob only exist while its jobs run, so its images are exported by tag
+		images, err := e.getWorkloadImages(ctx, "CronJob", cj.Name, namespace, nil, cj.Spec.JobTemplate.Spec.Template.Spec.Containers)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, images...)
+	}
+	return result, nil
+}
+
+// getWorkloadImages returns the images of the containers of a workload, by digest if any of its pods is running them
+func (e *exporter) getWorkloadImages(ctx context.Context, kind, name, namespace string, selector *metav1.LabelSelector, containers []apiv1.Container) ([]bundleImage, error) {
+	imageIDs := map[string]string{}
+	if selector != nil && len(selector.MatchLabels) > 0 {
+		podList, err := pods.ListBySelector(ctx, namespace, selector.MatchLabels, e.k8sClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the pods of %s '%s': %w", strings.ToLower(kind), name, err)
+		}
+		for _, p := range podList {
+			for _, status := range p.Status.ContainerStatuses {
+				if status.ImageID != "" {
+					imageIDs[status.Name] = status.ImageID
+				}
+			}
+		}
+	}
+
+	result := []bundleImage{}
+	for _, c := range containers {
+		image := c.Image
+		if imageID, ok := imageIDs[c.Name]; ok {
+			image = getImageWithDigest(c.Image, imageID)
+		} else {
+			oktetoLog.Warning("The image of container '%s' of %s '%s' is exported by tag: no pod is running it", c.Name, strings.ToLower(kind), name)
+		}
+		result = append(result, bundleImage{
+			Kind:      kind,
+			Name:      name,
+			Container: c.Name,
+			Image:     image,
+		})
+	}
+	return result, nil
+}
+
+// getImageWithDigest returns the image referenced by the digest of the image id reported by the container runtime
+func getImageWithDigest(image, imageID string) string {
+	i := strings.LastIndex(imageID, "@")
+	if i == -1 || !strings.HasPrefix(imageID[i+1:], "sha256:") {
+		return image
+	}
+	digest := imageID[i+1:]
+
+	repository := image
+	if j := strings.Index(repository, "@"); j != -1 {
+		repository = repository[:j]
+	}
+	if j := strings.LastIndex(repository, ":"); j > strings.LastIndex(repository, "/") {
+		repository = repository[:j]
+	}
+	return fmt.Sprintf("%s@%s", repository, digest)
+}
+
+// getExternals returns the endpoints of the external resources of the manifest deployed in the namespace
+func (e *exporter) getExternals(ctx context.Context, manifest []byte, namespace string) ([]bundleExternal, error) {
+	m := struct {
+		External map[string]interface{} `yaml:"external"`
+	}{}
+	if err := yaml.Unmarshal(manifest, &m); err != nil {
+		oktetoLog.Infof("failed to read the external resources of the manifest: %s", err)
+		return nil, nil
+	}
+
+	names := []string{}
+	for name := range m.External {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := []bundleExternal{}
+	for _, name := range names {
+		external, err := e.externalClient.ExternalResources(namespace).Get(ctx, format.ResourceK8sMetaString(name), metav1.GetOptions{})
+		if err != nil {
+			if oktetoErrors.IsNotFound(err) {
+				oktetoLog.Warning("External resource '%s' not found in namespace '%s'", name, namespace)
+				continue
+			}
+			return nil, fmt.Errorf("failed to get the external resource '%s': %w", name, err)
+		}
+		be := bundleExternal{Name: name}
+		for _, ep := range external.Spec.Endpoints {
+			be.Endpoints = append(be.Endpoints, bundleEndpoint{Name: ep.Name, URL: ep.Url})
+		}
+		result = append(result, be)
+	}
+	return result, nil
+}
@@ -0,0 +1,182 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	externalK8s "github.com/okteto/okteto/pkg/externalresource/k8s"
+	"github.com/okteto/okteto/pkg/externalresource/k8s/fake"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+const testManifest = `deploy:
+  - kubectl apply -f k8s.yml
+external:
+  db:
+    endpoints:
+      - name: admin
+        url: https://db.example.com
+`
+
+func newTestConfigMap(t *testing.T, name, namespace string) *apiv1.ConfigMap {
+	t.Helper()
+	c := k8sfake.NewSimpleClientset()
+	cmap, err := pipeline.TranslateConfigMapAndDeploy(context.Background(), &pipeline.CfgData{
+		Name:       name,
+		Namespace:  namespace,
+		Status:     pipeline.DeployedStatus,
+		Repository: "https://github.com/okteto/movies",
+		Branch:     "main",
+		Filename:   "okteto.yml",
+		Manifest:   []byte(testManifest),
+		// variables without a value are skipped
+		Variables: []string{"DB=mongo", "EMPTY"},
+	}, c)
+	require.NoError(t, err)
+	return cmap
+}
+
+func TestExport(t *testing.T) {
+	labels := map[string]string{model.DeployedByLabel: "movies"}
+	selector := map[string]string{"app": "api"}
+	objects := []runtime.Object{
+		newTestConfigMap(t, "movies", "ns"),
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "ns", Labels: labels},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: selector},
+				Template: apiv1.PodTemplateSpec{
+					Spec: apiv1.PodSpec{
+						Containers: []apiv1.Container{
+							{Name: "api", Image: "okteto.dev/api:okteto"},
+							{Name: "sidecar", Image: "busybox"},
+						},
+					},
+				},
+			},
+		},
+		&apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "ns", Labels: selector},
+			Status: apiv1.PodStatus{
+				ContainerStatuses: []apiv1.ContainerStatus{
+					{Name: "api", ImageID: "docker-pullable://okteto.dev/api@sha256:1234"},
+				},
+			},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "mongodb", Namespace: "ns", Labels: labels},
+			Spec: appsv1.StatefulSetSpec{
+				Template: apiv1.PodTemplateSpec{
+					Spec: apiv1.PodSpec{
+						Containers: []apiv1.Container{{Name: "mongodb", Image: "mongo:5"}},
+					},
+				},
+			},
+		},
+	}
+	external := &externalK8s.External{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "ns"},
+		Spec: externalK8s.ExternalResourceSpec{
+			Name:      "db",
+			Endpoints: []externalK8s.Endpoint{{Name: "admin", Url: "https://db.example.com"}},
+		},
+	}
+
+	e := &exporter{
+		k8sClient:      k8sfake.NewSimpleClientset(objects...),
+		externalClient: fake.NewFakeExternalResourceV1(fake.PossibleERErrors{}, external),
+	}
+	b, err := e.export(context.Background(), "movies", "ns")
+	require.NoError(t, err)
+
+	expected := &bundle{
+		Version:    bundleVersion,
+		Name:       "movies",
+		Namespace:  "ns",
+		Repository: "https://github.com/okteto/movies",
+		Branch:     "main",
+		Filename:   "okteto.yml",
+		Manifest:   testManifest,
+		Variables:  []types.Variable{{Name: "DB", Value: "mongo"}},
+		Images: []bundleImage{
+			{Kind: "Deployment", Name: "api", Container: "api", Image: "okteto.dev/api@sha256:1234"},
+			{Kind: "Deployment", Name: "api", Container: "sidecar", Image: "busybox"},
+			{Kind: "StatefulSet", Name: "mongodb", Container: "mongodb", Image: "mongo:5"},
+		},
+		Externals: []bundleExternal{
+			{Name: "db", Endpoints: []bundleEndpoint{{Name: "admin", URL: "https://db.example.com"}}},
+		},
+	}
+	assert.Equal(t, expected, b)
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, writeBundle(fs, "movies.okteto-env.yml", b))
+	read, err := readBundle(fs, "movies.okteto-env.yml")
+	require.NoError(t, err)
+	assert.Equal(t, b, read)
+}
+
+func TestExportNotFound(t *testing.T) {
+	e := &exporter{
+		k8sClient:      k8sfake.NewSimpleClientset(),
+		externalClient: fake.NewFakeExternalResourceV1(fake.PossibleERErrors{}),
+	}
+	_, err := e.export(context.Background(), "movies", "ns")
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+}
+
+func TestGetImageWithDigest(t *testing.T) {
+	tests := []struct {
+		image    string
+		imageID  string
+		expected string
+	}{
+		{image: "nginx", imageID: "docker-pullable://nginx@sha256:abc", expected: "nginx@sha256:abc"},
+		{image: "localhost:5000/api:1.0", imageID: "localhost:5000/api@sha256:abc", expected: "localhost:5000/api@sha256:abc"},
+		{image: "api@sha256:old", imageID: "api@sha256:new", expected: "api@sha256:new"},
+		{image: "api:1.0", imageID: "sha256:abc", expected: "api:1.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getImageWithDigest(tt.image, tt.imageID))
+		})
+	}
+}
+
+func TestReadBundleErrors(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "invalid.yml", []byte("unknown: field\n"), 0600))
+	require.NoError(t, afero.WriteFile(fs, "version.yml", []byte("version: 2\nname: movies\nmanifest: deploy\n"), 0600))
+	require.NoError(t, afero.WriteFile(fs, "empty.yml", []byte("version: 1\nname: movies\n"), 0600))
+
+	for _, path := range []string{"invalid.yml", "version.yml", "empty.yml"} {
+		_, err := readBundle(fs, path)
+		assert.ErrorAs(t, err, &oktetoErrors.UserError{}, path)
+	}
+	_, err := readBundle(fs, "missing.yml")
+	assert.Error(t, err)
+}
@@ -0,0 +1,388 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	pipelineCMD "github.com/okteto/okteto/cmd/pipeline"
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/externalresource"
+	externalK8s "github.com/okteto/okteto/pkg/externalresource/k8s"
+	"github.com/okteto/okteto/pkg/format"
+	"github.com/okteto/okteto/pkg/k8s/cronjobs"
+	"github.com/okteto/okteto/pkg/k8s/daemonsets"
+	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/k8s/statefulsets"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+type importFlags struct {
+	name       string
+	namespace  string
+	context    string
+	timeout    time.Duration
+	skipImages bool
+}
+
+// importer reproduces the development environment of a bundle
+type importer struct {
+	k8sClient      kubernetes.Interface
+	externalClient externalK8s.ExternalResourceV1Interface
+	fs             afero.Fs
+
+	// deployFromRepository deploys the environment from its repository as a pipeline
+	deployFromRepository func(ctx context.Context, opts *pipelineCMD.DeployOptions) error
+	// runOktetoDeploy runs 'okteto deploy' in the current folder
+	runOktetoDeploy func(ctx context.Context, args []string) error
+}
+
+func importCmd(ctx context.Context) *cobra.Command {
+	flags := &importFlags{}
+	cmd := &cobra.Command{
+		Use:   "import <bundle>",
+		Short: "Reproduce a development environment from a bundle",
+		Long: `Reproduce a development environment from a bundle generated by 'okteto env export'.
+
+Environments deployed from a repository are deployed from the same repository and branch by an Okteto pipeline,
+which only builds the images that aren't already in the registry.
+Environments deployed from a local folder must be imported from a checkout of their repository. They are deployed
+without building any image: the images of the build section are set to the images of the bundle.
+The containers are updated to the images by digest of the bundle and the endpoints of the external resources
+are restored once the environment is deployed.`,
+		Args: utils.ExactArgsAccepted(1, "https://www.okteto.com/docs/reference/cli/#env"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fs := afero.NewOsFs()
+			b, err := readBundle(fs, args[0])
+			if err != nil {
+				return err
+			}
+
+			ctxOptions := &contextCMD.ContextOptions{
+				Context:   flags.context,
+				Namespace: flags.namespace,
+				Show:      true,
+			}
+			if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+				return err
+			}
+			if b.Repository != "" && !okteto.IsOkteto() {
+				return oktetoErrors.ErrContextIsNotOktetoCluster
+			}
+
+			k8sClient, cfg, err := okteto.GetK8sClient()
+			if err != nil {
+				return fmt.Errorf("failed to load okteto context '%s': %w", okteto.Context().Name, err)
+			}
+			externalClient, err := externalK8s.GetExternalClient(cfg)
+			if err != nil {
+				return fmt.Errorf("failed to create the external resources client: %w", err)
+			}
+			i := &importer{
+				k8sClient:      k8sClient,
+				externalClient: externalClient,
+				fs:             fs,
+				deployFromRepository: func(ctx context.Context, opts *pipelineCMD.DeployOptions) error {
+					pc, err := pipelineCMD.NewCommand()
+					if err != nil {
+						return err
+					}
+					return pc.ExecuteDeployPipeline(ctx, opts)
+				},
+				runOktetoDeploy: runOktetoDeploy,
+			}
+
+			name := flags.name
+			if name == "" {
+				name = b.Name
+			}
+			namespace := okteto.Context().Namespace
+			if err := i.run(ctx, b, name, namespace, *flags); err != nil {
+				return err
+			}
+			oktetoLog.Success("Development environment '%s' imported to namespace '%s'", name, namespace)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&flags.name, "name", "", "name of the development environment (defaults to the name in the bundle)")
+	cmd.Flags().StringVarP(&flags.namespace, "namespace", "n", "", "namespace where the development environment is imported (defaults to the current namespace)")
+	cmd.Flags().StringVarP(&flags.context, "context", "c", "", "context where the development environment is imported (defaults to the current context)")
+	cmd.Flags().DurationVarP(&flags.timeout, "timeout", "t", 5*time.Minute, "the length of time to wait for the deployment, zero means never. Any other values should contain a corresponding time unit e.g. 1s, 2m, 3h ")
+	cmd.Flags().BoolVar(&flags.skipImages, "skip-images", false, "keep the images deployed by the manifest instead of the images by digest of the bundle")
+	return cmd
+}
+
+// run deploys the development environment of the bundle, restores its external resources and pins its images
+func (i *importer) run(ctx context.Context, b *bundle, name, namespace string, flags importFlags) error {
+	variables := []string{}
+	for _, v := range b.Variables {
+		variables = append(variables, fmt.Sprintf("%s=%s", v.Name, v.Value))
+	}
+
+	if b.Repository != "" {
+		oktetoLog.Information("Deploying '%s' from '%s'", name, b.Repository)
+		err := i.deployFromRepository(ctx, &pipelineCMD.DeployOptions{
+			Name:       name,
+			Namespace:  namespace,
+			Repository: b.Repository,
+			Branch:     b.Branch,
+			File:       b.Filename,
+			Variables:  variables,
+			Wait:       true,
+			Timeout:    flags.timeout,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to deploy '%s': %w", name, err)
+		}
+	} else {
+		if err := i.deployFromManifest(ctx, b, name, namespace, variables, flags.timeout); err != nil {
+			return err
+		}
+	}
+
+	if err := i.restoreExternals(ctx, b.Externals, namespace); err != nil {
+		return err
+	}
+
+	if flags.skipImages {
+		return nil
+	}
+	return i.pinImages(ctx, b.Images, namespace)
+}
+
+// deployFromManifest deploys the manifest of the bundle from the current folder without building its images
+func (i *importer) deployFromManifest(ctx context.Context, b *bundle, name, namespace string, variables []string, timeout time.Duration) error {
+	manifestPath := fmt.Sprintf(".okteto-env-%s.yml", format.ResourceK8sMetaString(name))
+	if b.Filename != "" {
+		manifestPath = filepath.Join(filepath.Dir(b.Filename), manifestPath)
+	}
+	if err := afero.WriteFile(i.fs, manifestPath, []byte(b.Manifest), 0600); err != nil {
+		return fmt.Errorf("failed to write the manifest of the bundle: %w", err)
+	}
+	defer func() {
+		if err := i.fs.Remove(manifestPath); err != nil {
+			oktetoLog.Infof("failed to remove '%s': %s", manifestPath, err)
+		}
+	}()
+
+	oktetoLog.Information("Deploying '%s' from the current folder", name)
+	args := []string{"deploy", "--file", manifestPath, "--name", name, "--namespace", namespace, "--wait", "--timeout", timeout.String(), "--no-build"}
+	for _, v := range append(variables, getBuildVariables(b)...) {
+		args = append(args, "--var", v)
+	}
+	if err := i.runOktetoDeploy(ctx, args); err != nil {
+		return fmt.Errorf("failed to deploy '%s': %w", name, err)
+	}
+	return nil
+}
+
+// getBuildVariables returns the image variables of the services of the build section of the manifest of the bundle.
+// The image of a service is the image of the bundle of the container, or else the workload, with the same name.
+// The workloads are pinned to the images of the bundle after the deploy, so these images only need to be valid
+func getBuildVariables(b *bundle) []string {
+	m := struct {
+		Build map[string]interface{} `yaml:"build"`
+	}{}
+	if err := yaml.Unmarshal([]byte(b.Manifest), &m); err != nil {
+		oktetoLog.Infof("failed to read the build section of the manifest: %s", err)
+		return nil
+	}
+
+	services := []string{}
+	for svc := range m.Build {
+		services = append(services, svc)
+	}
+	sort.Strings(services)
+
+	result := []string{}
+	for _, svc := range services {
+		image := ""
+		for _, bi := range b.Images {
+			if bi.Container == svc {
+				image = bi.Image
+				break
+			}
+			if bi.Name == svc && image == "" {
+				image = bi.Image
+			}
+		}
+		if image == "" {
+			oktetoLog.Warning("The bundle has no image for the service '%s' of the build section", svc)
+			continue
+		}
+		result = append(result, fmt.Sprintf("OKTETO_BUILD_%s_IMAGE=%s", strings.ToUpper(strings.ReplaceAll(svc, "-", "_")), image))
+	}
+	return result
+}
+
+// restoreExternals sets the endpoints of the external resources of the environment to the endpoints of the bundle
+func (i *importer) restoreExternals(ctx context.Context, externals []bundleExternal, namespace string) error {
+	for _, be := range externals {
+		external, err := i.externalClient.ExternalResources(namespace).Get(ctx, format.ResourceK8sMetaString(be.Name), metav1.GetOptions{})
+		if err != nil {
+			if !oktetoErrors.IsNotFound(err) {
+				return fmt.Errorf("failed to get the external resource '%s': %w", be.Name, err)
+			}
+			control := &externalresource.K8sControl{
+				ClientProvider: func(*rest.Config) (externalK8s.ExternalResourceV1Interface, error) {
+					return i.externalClient, nil
+				},
+			}
+			er := &externalresource.ExternalResource{}
+			for _, ep := range be.Endpoints {
+				er.Endpoints = append(er.Endpoints, &externalresource.ExternalEndpoint{Name: ep.Name, Url: ep.URL})
+			}
+			if err := control.Deploy(ctx, be.Name, namespace, er); err != nil {
+				return fmt.Errorf("failed to restore the external resource '%s': %w", be.Name, err)
+			}
+			oktetoLog.Information("Restored external resource '%s'", be.Name)
+			continue
+		}
+
+		external.Spec.Endpoints = []externalK8s.Endpoint{}
+		for _, ep := range be.Endpoints {
+			external.Spec.Endpoints = append(external.Spec.Endpoints, externalK8s.Endpoint{Name: ep.Name, Url: ep.URL})
+		}
+		if _, err := i.externalClient.ExternalResources(namespace).Update(ctx, external); err != nil {
+			return fmt.Errorf("failed to restore the external resource '%s': %w", be.Name, err)
+		}
+		oktetoLog.Information("Restored external resource '%s'", be.Name)
+	}
+	return nil
+}
+
+// pinImages updates the containers of the workloads to the images of the bundle
+func (i *importer) pinImages(ctx context.Context, images []bundleImage, namespace string) error {
+	for _, image := range images {
+		switch image.Kind {
+		case "Deployment":
+			d, err := deployments.Get(ctx, image.Name, namespace, i.k8sClient)
+			if err != nil {
+				if oktetoErrors.IsNotFound(err) {
+					oktetoLog.Warning("Deployment '%s' not found: its image can't be pinned", image.Name)
+					continue
+				}
+				return err
+			}
+			if !setContainerImage(d.Spec.Template.Spec.Containers, image.Container, image.Image) {
+				continue
+			}
+			if _, err := deployments.Deploy(ctx, d, i.k8sClient); err != nil {
+				return fmt.Errorf("failed to pin the image of deployment '%s': %w", image.Name, err)
+			}
+		case "StatefulSet":
+			sfs, err := statefulsets.Get(ctx, image.Name, namespace, i.k8sClient)
+			if err != nil {
+				if oktetoErrors.IsNotFound(err) {
+					oktetoLog.Warning("StatefulSet '%s' not found: its image can't be pinned", image.Name)
+					continue
+				}
+				return err
+			}
+			if !setContainerImage(sfs.Spec.Template.Spec.Containers, image.Container, image.Image) {
+				continue
+			}
+			if _, err := statefulsets.Deploy(ctx, sfs, i.k8sClient); err != nil {
+				return fmt.Errorf("failed to pin the image of statefulset '%s': %w", image.Name, err)
+			}
+		case "DaemonSet":
+			ds, err := daemonsets.Get(ctx, image.Name, namespace, i.k8sClient)
+			if err != nil {
+				if oktetoErrors.IsNotFound(err) {
+					oktetoLog.Warning("DaemonSet '%s' not found: its image can't be pinned", image.Name)
+					continue
+				}
+				return err
+			}
+			if !setContainerImage(ds.Spec.Template.Spec.Containers, image.Container, image.Image) {
+				continue
+			}
+			if _, err := daemonsets.Deploy(ctx, ds, i.k8sClient); err != nil {
+				return fmt.Errorf("failed to pin the image of daemonset '%s': %w", image.Name, err)
+			}
+		case "CronJob":
+			cj, err := cronjobs.Get(ctx, image.Name, namespace, i.k8sClient)
+			if err != nil {
+				if oktetoErrors.IsNotFound(err) {
+					oktetoLog.Warning("CronJob '%s' not found: its image can't be pinned", image.Name)
+					continue
+				}
+				return err
+			}
+			if !setContainerImage(cj.Spec.JobTemplate.Spec.Template.Spec.Containers, image.Container, image.Image) {
+				continue
+			}
+			if _, err := cronjobs.Deploy(ctx, cj, i.k8sClient); err != nil {
+				return fmt.Errorf("failed to pin the image of cronjob '%s': %w", image.Name, err)
+			}
+		default:
+			oktetoLog.Infof("skipping image of unknown kind '%s'", image.Kind)
+			continue
+		}
+		oktetoLog.Information("Pinned container '%s' of '%s' to '%s'", image.Container, image.Name, image.Image)
+	}
+	return nil
+}
+
+// setContainerImage sets the image of a container. It returns false if the container doesn't exist or already runs the image
+func setContainerImage(containers []apiv1.Container, name, image string) bool {
+	for j := range containers {
+		if containers[j].Name == name {
+			if containers[j].Image == image {
+				return false
+			}
+			containers[j].Image = image
+			return true
+		}
+	}
+	return false
+}
+
+// runOktetoDeploy runs the okteto binary in the current folder
+func runOktetoDeploy(ctx context.Context, args []string) error {
+	bin, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Env = os.Environ()
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("'okteto deploy' exited with code %d", exitErr.ExitCode())
+		}
+		return err
+	}
+	return nil
+}
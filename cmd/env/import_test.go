@@ -0,0 +1,217 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pipelineCMD "github.com/okteto/okteto/cmd/pipeline"
+	externalK8s "github.com/okteto/okteto/pkg/externalresource/k8s"
+	"github.com/okteto/okteto/pkg/externalresource/k8s/fake"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+var testBundle = &bundle{
+	Version:   bundleVersion,
+	Name:      "movies",
+	Manifest:  testManifest,
+	Variables: []types.Variable{{Name: "DB", Value: "mongo"}},
+	Images: []bundleImage{
+		{Kind: "Deployment", Name: "api", Container: "api", Image: "okteto.dev/api@sha256:1234"},
+		{Kind: "StatefulSet", Name: "mongodb", Container: "mongodb", Image: "mongo@sha256:5678"},
+	},
+}
+
+func newTestDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "cindy"},
+		Spec: appsv1.DeploymentSpec{
+			Template: apiv1.PodTemplateSpec{
+				Spec: apiv1.PodSpec{
+					Containers: []apiv1.Container{{Name: "api", Image: "okteto.dev/api:okteto"}},
+				},
+			},
+		},
+	}
+}
+
+func TestImportFromRepository(t *testing.T) {
+	b := *testBundle
+	b.Repository = "https://github.com/okteto/movies"
+	b.Branch = "main"
+	b.Filename = "okteto.yml"
+
+	c := k8sfake.NewSimpleClientset(newTestDeployment())
+	var deployed *pipelineCMD.DeployOptions
+	i := &importer{
+		k8sClient: c,
+		fs:        afero.NewMemMapFs(),
+		deployFromRepository: func(_ context.Context, opts *pipelineCMD.DeployOptions) error {
+			deployed = opts
+			return nil
+		},
+	}
+	err := i.run(context.Background(), &b, "movies-bug", "cindy", importFlags{timeout: time.Minute})
+	require.NoError(t, err)
+
+	assert.Equal(t, &pipelineCMD.DeployOptions{
+		Name:       "movies-bug",
+		Namespace:  "cindy",
+		Repository: "https://github.com/okteto/movies",
+		Branch:     "main",
+		File:       "okteto.yml",
+		Variables:  []string{"DB=mongo"},
+		Wait:       true,
+		Timeout:    time.Minute,
+	}, deployed)
+
+	d, err := c.AppsV1().Deployments("cindy").Get(context.Background(), "api", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "okteto.dev/api@sha256:1234", d.Spec.Template.Spec.Containers[0].Image)
+}
+
+func TestImportFromManifest(t *testing.T) {
+	c := k8sfake.NewSimpleClientset(newTestDeployment())
+	fs := afero.NewMemMapFs()
+	var args []string
+	i := &importer{
+		k8sClient: c,
+		fs:        fs,
+		runOktetoDeploy: func(_ context.Context, a []string) error {
+			args = a
+			content, err := afero.ReadFile(fs, ".okteto-env-movies.yml")
+			require.NoError(t, err)
+			assert.Equal(t, testManifest, string(content))
+			return nil
+		},
+	}
+	err := i.run(context.Background(), testBundle, "movies", "cindy", importFlags{timeout: time.Minute, skipImages: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"deploy", "--file", ".okteto-env-movies.yml", "--name", "movies", "--namespace", "cindy", "--wait", "--timeout", "1m0s", "--no-build", "--var", "DB=mongo"}, args)
+	exists, err := afero.Exists(fs, ".okteto-env-movies.yml")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	d, err := c.AppsV1().Deployments("cindy").Get(context.Background(), "api", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "okteto.dev/api:okteto", d.Spec.Template.Spec.Containers[0].Image)
+}
+
+func TestImportRestoresExternals(t *testing.T) {
+	existing := &externalK8s.External{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "cindy"},
+		Spec: externalK8s.ExternalResourceSpec{
+			Name:      "db",
+			Icon:      "database",
+			Endpoints: []externalK8s.Endpoint{{Name: "admin", Url: "https://db-new.example.com"}},
+		},
+	}
+	externalClient := fake.NewFakeExternalResourceV1(fake.PossibleERErrors{}, existing)
+	b := *testBundle
+	b.Externals = []bundleExternal{
+		{Name: "db", Endpoints: []bundleEndpoint{{Name: "admin", URL: "https://db.example.com"}}},
+		{Name: "queue", Endpoints: []bundleEndpoint{{Name: "console", URL: "https://queue.example.com"}}},
+	}
+	i := &importer{
+		externalClient: externalClient,
+		fs:             afero.NewMemMapFs(),
+		runOktetoDeploy: func(_ context.Context, _ []string) error {
+			return nil
+		},
+	}
+	require.NoError(t, i.run(context.Background(), &b, "movies", "cindy", importFlags{skipImages: true}))
+
+	db, err := externalClient.ExternalResources("cindy").Get(context.Background(), "db", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "database", db.Spec.Icon)
+	assert.Equal(t, []externalK8s.Endpoint{{Name: "admin", Url: "https://db.example.com"}}, db.Spec.Endpoints)
+
+	queue, err := externalClient.ExternalResources("cindy").Get(context.Background(), "queue", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []externalK8s.Endpoint{{Name: "console", Url: "https://queue.example.com"}}, queue.Spec.Endpoints)
+}
+
+func TestGetBuildVariables(t *testing.T) {
+	b := &bundle{
+		Manifest: `build:
+  api:
+    context: api
+  frontend-app:
+    context: frontend
+  worker:
+    context: worker
+deploy:
+  - helm upgrade --install movies chart
+`,
+		Images: []bundleImage{
+			{Kind: "Deployment", Name: "api", Container: "api", Image: "okteto.dev/api@sha256:1234"},
+			{Kind: "Deployment", Name: "frontend-app", Container: "nginx", Image: "okteto.dev/frontend@sha256:5678"},
+		},
+	}
+	assert.Equal(t, []string{
+		"OKTETO_BUILD_API_IMAGE=okteto.dev/api@sha256:1234",
+		"OKTETO_BUILD_FRONTEND_APP_IMAGE=okteto.dev/frontend@sha256:5678",
+	}, getBuildVariables(b))
+}
+
+func TestPinImagesOfDaemonSetsAndCronJobs(t *testing.T) {
+	podSpec := apiv1.PodSpec{Containers: []apiv1.Container{{Name: "agent", Image: "agent:okteto"}}}
+	c := k8sfake.NewSimpleClientset(
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "cindy"},
+			Spec:       appsv1.DaemonSetSpec{Template: apiv1.PodTemplateSpec{Spec: podSpec}},
+		},
+		&batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "cindy"},
+			Spec: batchv1.CronJobSpec{JobTemplate: batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{
+				Template: apiv1.PodTemplateSpec{Spec: podSpec},
+			}}},
+		},
+	)
+	i := &importer{k8sClient: c}
+	err := i.pinImages(context.Background(), []bundleImage{
+		{Kind: "DaemonSet", Name: "agent", Container: "agent", Image: "agent@sha256:1234"},
+		{Kind: "CronJob", Name: "backup", Container: "agent", Image: "agent@sha256:1234"},
+	}, "cindy")
+	require.NoError(t, err)
+
+	ds, err := c.AppsV1().DaemonSets("cindy").Get(context.Background(), "agent", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "agent@sha256:1234", ds.Spec.Template.Spec.Containers[0].Image)
+	cj, err := c.BatchV1().CronJobs("cindy").Get(context.Background(), "backup", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "agent@sha256:1234", cj.Spec.JobTemplate.Spec.Template.Spec.Containers[0].Image)
+}
+
+func TestImportDeployError(t *testing.T) {
+	i := &importer{
+		fs: afero.NewMemMapFs(),
+		runOktetoDeploy: func(_ context.Context, _ []string) error {
+			return assert.AnError
+		},
+	}
+	err := i.run(context.Background(), testBundle, "movies", "cindy", importFlags{})
+	assert.ErrorIs(t, err, assert.AnError)
+}
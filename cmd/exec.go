@@ -17,6 +17,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"time"
 
@@ -24,6 +25,7 @@ import (
 	"github.com/okteto/okteto/cmd/up"
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
+	"github.com/okteto/okteto/pkg/audit"
 	"github.com/okteto/okteto/pkg/cmd/status"
 	"github.com/okteto/okteto/pkg/config"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
@@ -42,7 +44,10 @@ type execFlags struct {
 	manifestPath     string
 	namespace        string
 	k8sContext       string
+	record           string
 	commandToExecute []string
+	// scopedCredentials fails the command if the exec credentials scoped to the container can't be issued
+	scopedCredentials bool
 }
 
 // Exec executes a command on the CND container
@@ -81,9 +86,26 @@ func Exec() *cobra.Command {
 			}
 			execFlags.commandToExecute = getCommandToRunFromArgs(manifest, args)
 
+			var stdout, stderr io.Writer = os.Stdout, os.Stderr
+			if execFlags.record != "" {
+				if dev.IsHybridModeEnabled() {
+					return oktetoErrors.UserError{
+						E:    fmt.Errorf("'--record' is not supported by development containers in hybrid mode"),
+						Hint: "Remove '--record' and try again",
+					}
+				}
+				recording, err := newExecRecording(execFlags.record, dev.Name, execFlags.commandToExecute)
+				if err != nil {
+					return err
+				}
+				defer recording.close()
+				// only the streams are recorded: the remote terminal is still sized from the terminal of os.Stdout
+				stdout, stderr = recording.recorder.Output(os.Stdout), recording.recorder.Output(os.Stderr)
+			}
+
 			t := time.NewTicker(1 * time.Second)
 			iter := 0
-			err = executeExec(ctx, dev, execFlags.commandToExecute)
+			err = executeExecWithStreams(ctx, dev, execFlags.commandToExecute, stdout, stderr, execFlags.scopedCredentials)
 			for oktetoErrors.IsTransient(err) {
 				if iter == 0 {
 					oktetoLog.Yellow("Connection lost to your development container, reconnecting...")
@@ -91,7 +113,7 @@ func Exec() *cobra.Command {
 				iter++
 				iter = iter % 10
 				<-t.C
-				err = executeExec(ctx, dev, execFlags.commandToExecute)
+				err = executeExecWithStreams(ctx, dev, execFlags.commandToExecute, stdout, stderr, execFlags.scopedCredentials)
 			}
 
 			analytics.TrackExec(&analytics.TrackExecMetadata{
@@ -120,11 +142,18 @@ func Exec() *cobra.Command {
 	cmd.Flags().StringVarP(&execFlags.manifestPath, "file", "f", utils.DefaultManifest, "path to the manifest file")
 	cmd.Flags().StringVarP(&execFlags.namespace, "namespace", "n", "", "namespace where the exec command is executed")
 	cmd.Flags().StringVarP(&execFlags.k8sContext, "context", "c", "", "context where the exec command is executed")
+	cmd.Flags().StringVarP(&execFlags.record, "record", "", "", "record the session in an asciinema file (asciicast v2 format)")
+	cmd.Flags().BoolVarP(&execFlags.scopedCredentials, "scoped-credentials", "", false, "fail if Okteto can't issue short-lived credentials scoped to the container, instead of using the credentials of your kubeconfig")
 
 	return cmd
 }
 
 func executeExec(ctx context.Context, dev *model.Dev, args []string) error {
+	return executeExecWithStreams(ctx, dev, args, os.Stdout, os.Stderr, false)
+}
+
+// executeExecWithStreams executes the command in the development container writing its output to stdout and stderr
+func executeExecWithStreams(ctx context.Context, dev *model.Dev, args []string, stdout, stderr io.Writer, scopedCredentials bool) (err error) {
 	oktetoLog.Spinner("Preparing your container")
 	oktetoLog.StartSpinner()
 	defer oktetoLog.StopSpinner()
@@ -148,7 +177,7 @@ func executeExec(ctx context.Context, dev *model.Dev, args []string) error {
 		retries := 0
 		ticker := time.NewTicker(500 * time.Millisecond)
 		for {
-			if apps.IsDevModeOn(app) {
+			if apps.IsDevModeActive(ctx, app, c) {
 				break
 			}
 			retries++
@@ -186,6 +215,37 @@ func executeExec(ctx context.Context, dev *model.Dev, args []string) error {
 		dev.Container = pod.Spec.Containers[0].Name
 	}
 
+	credentials := ""
+	if scopedCredentials && (!okteto.IsOkteto() || dev.RemoteModeEnabled()) {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("'--scoped-credentials' is only supported in Okteto contexts and development containers without remote mode"),
+			Hint: "Run the command without '--scoped-credentials' to use the credentials of your kubeconfig",
+		}
+	}
+	if okteto.IsOkteto() && !dev.RemoteModeEnabled() {
+		okClient, err := okteto.NewOktetoClient()
+		if err != nil {
+			return err
+		}
+		cfg, credentials, err = getExecCredentials(cfg, okClient.Kubetoken(), okteto.Context().Name, dev.Namespace, pod.Name, dev.Container, scopedCredentials)
+		if err != nil {
+			return err
+		}
+	}
+
+	session := audit.Start(ctx, audit.Session{
+		Command:     audit.ExecCommand,
+		Namespace:   dev.Namespace,
+		Dev:         devName,
+		Pod:         pod.Name,
+		Container:   dev.Container,
+		Args:        args,
+		Credentials: credentials,
+	})
+	defer func() {
+		session.Stop(err)
+	}()
+
 	if dev.RemoteModeEnabled() {
 		p, err := ssh.GetPort(devName)
 		if err != nil {
@@ -227,10 +287,10 @@ func executeExec(ctx context.Context, dev *model.Dev, args []string) error {
 			return executor.RunCommand(cmd)
 		}
 
-		return ssh.Exec(ctx, dev.Interface, dev.RemotePort, true, os.Stdin, os.Stdout, os.Stderr, wrapped)
+		return ssh.Exec(ctx, dev.Interface, dev.RemotePort, true, os.Stdin, stdout, stderr, wrapped)
 	}
 	oktetoLog.StopSpinner()
-	return exec.Exec(ctx, c, cfg, dev.Namespace, pod.Name, dev.Container, true, os.Stdin, os.Stdout, os.Stderr, wrapped)
+	return exec.Exec(ctx, c, cfg, dev.Namespace, pod.Name, dev.Container, true, os.Stdin, stdout, stderr, wrapped)
 }
 
 func getDevFromArgs(manifest *model.Manifest, args, activeDevMode []string) (*model.Dev, error) {
@@ -0,0 +1,50 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/okteto/okteto/pkg/audit"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"k8s.io/client-go/rest"
+)
+
+// getExecCredentials returns a copy of cfg authenticated with a short-lived token that only allows to exec into the container of the pod.
+// cfg is returned unchanged if the exec token can't be issued, unless the user asked for scoped credentials
+func getExecCredentials(cfg *rest.Config, kubetoken types.KubetokenInterface, baseURL, namespace, pod, container string, scoped bool) (*rest.Config, string, error) {
+	token, err := kubetoken.GetExecToken(baseURL, namespace, pod, container)
+	if err != nil {
+		if scoped {
+			if errors.Is(err, okteto.ErrExecTokenNotAvailable) {
+				return nil, "", oktetoErrors.UserError{
+					E:    fmt.Errorf("your Okteto instance doesn't issue exec credentials"),
+					Hint: "Run the command without '--scoped-credentials' to use the credentials of your kubeconfig",
+				}
+			}
+			return nil, "", fmt.Errorf("failed to get the exec credentials: %w", err)
+		}
+		oktetoLog.Infof("using the credentials of the kubeconfig, the exec credentials are not available: %s", err)
+		return cfg, "", nil
+	}
+
+	oktetoLog.Infof("using exec credentials for pod '%s' that expire at %s", pod, token.Status.ExpirationTimestamp)
+	execCfg := rest.AnonymousClientConfig(cfg)
+	execCfg.BearerToken = token.Status.Token
+	return execCfg, audit.ExecTokenCredentials, nil
+}
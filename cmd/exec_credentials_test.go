@@ -0,0 +1,94 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/okteto/okteto/internal/test/client"
+	"github.com/okteto/okteto/pkg/audit"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/client-go/rest"
+)
+
+func TestGetExecCredentials(t *testing.T) {
+	cfg := &rest.Config{
+		Host:            "https://cluster.okteto.dev",
+		BearerToken:     "kubeconfig-token",
+		TLSClientConfig: rest.TLSClientConfig{CAData: []byte("ca"), CertData: []byte("cert"), KeyData: []byte("key")},
+	}
+
+	t.Run("exec token", func(t *testing.T) {
+		kubetoken := client.NewFakeKubetokenClient(client.FakeKubetokenResponse{
+			Token: types.KubeTokenResponse{
+				TokenRequest: authenticationv1.TokenRequest{
+					Status: authenticationv1.TokenRequestStatus{Token: "exec-token"},
+				},
+			},
+		})
+		got, credentials, err := getExecCredentials(cfg, kubetoken, "https://okteto.dev", "ns", "pod", "dev", false)
+		require.NoError(t, err)
+		assert.Equal(t, audit.ExecTokenCredentials, credentials)
+		assert.Equal(t, "exec-token", got.BearerToken)
+		assert.Equal(t, cfg.Host, got.Host)
+		assert.Equal(t, []byte("ca"), got.CAData)
+		assert.Empty(t, got.CertData)
+		assert.Empty(t, got.KeyData)
+		assert.Equal(t, "kubeconfig-token", cfg.BearerToken)
+	})
+
+	t.Run("exec tokens not available", func(t *testing.T) {
+		kubetoken := client.NewFakeKubetokenClient(client.FakeKubetokenResponse{
+			Err: fmt.Errorf("GetExecToken %w", okteto.ErrExecTokenNotAvailable),
+		})
+		got, credentials, err := getExecCredentials(cfg, kubetoken, "https://okteto.dev", "ns", "pod", "dev", false)
+		require.NoError(t, err)
+		assert.Empty(t, credentials)
+		assert.Same(t, cfg, got)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		kubetoken := client.NewFakeKubetokenClient(client.FakeKubetokenResponse{
+			Err: errors.New("internal server error"),
+		})
+		got, credentials, err := getExecCredentials(cfg, kubetoken, "https://okteto.dev", "ns", "pod", "dev", false)
+		require.NoError(t, err)
+		assert.Empty(t, credentials)
+		assert.Same(t, cfg, got)
+	})
+
+	t.Run("scoped credentials not available", func(t *testing.T) {
+		kubetoken := client.NewFakeKubetokenClient(client.FakeKubetokenResponse{
+			Err: fmt.Errorf("GetExecToken %w", okteto.ErrExecTokenNotAvailable),
+		})
+		_, _, err := getExecCredentials(cfg, kubetoken, "https://okteto.dev", "ns", "pod", "dev", true)
+		var uErr oktetoErrors.UserError
+		assert.ErrorAs(t, err, &uErr)
+	})
+
+	t.Run("scoped credentials error", func(t *testing.T) {
+		kubetoken := client.NewFakeKubetokenClient(client.FakeKubetokenResponse{
+			Err: errors.New("internal server error"),
+		})
+		_, _, err := getExecCredentials(cfg, kubetoken, "https://okteto.dev", "ns", "pod", "dev", true)
+		assert.Error(t, err)
+	})
+}
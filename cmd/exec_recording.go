@@ -0,0 +1,79 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/asciicast"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"golang.org/x/term"
+)
+
+const (
+	defaultRecordingWidth  = 80
+	defaultRecordingHeight = 24
+)
+
+// execRecording is the asciicast file of an 'okteto exec' session
+type execRecording struct {
+	path     string
+	file     *os.File
+	recorder *asciicast.Recorder
+}
+
+func newExecRecording(path, devName string, command []string) (*execRecording, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the recording file '%s': %w", path, err)
+	}
+
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		oktetoLog.Infof("failed to get the terminal size: %s", err)
+		width, height = defaultRecordingWidth, defaultRecordingHeight
+	}
+	header := asciicast.Header{
+		Width:  width,
+		Height: height,
+		Title:  fmt.Sprintf("okteto exec %s -- %s", devName, strings.Join(command, " ")),
+		Env:    map[string]string{},
+	}
+	for _, name := range []string{"TERM", "SHELL"} {
+		if value := os.Getenv(name); value != "" {
+			header.Env[name] = value
+		}
+	}
+
+	recorder, err := asciicast.NewRecorder(f, header)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &execRecording{path: path, file: f, recorder: recorder}, nil
+}
+
+func (r *execRecording) close() {
+	if err := r.file.Close(); err != nil {
+		oktetoLog.Warning("Failed to close the recording file '%s': %s", r.path, err)
+		return
+	}
+	if err := r.recorder.Err(); err != nil {
+		oktetoLog.Warning("The recording '%s' is incomplete: %s", r.path, err)
+		return
+	}
+	oktetoLog.Success("Session recorded at '%s'. Run 'asciinema play %s' to replay it", r.path, r.path)
+}
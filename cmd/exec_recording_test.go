@@ -0,0 +1,57 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/asciicast"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecRecording(t *testing.T) {
+	t.Setenv("TERM", "xterm-256color")
+	path := filepath.Join(t.TempDir(), "session.cast")
+
+	recording, err := newExecRecording(path, "api", []string{"bash"})
+	require.NoError(t, err)
+	stdout := &bytes.Buffer{}
+	_, err = recording.recorder.Output(stdout).Write([]byte("hello\n"))
+	require.NoError(t, err)
+	recording.close()
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	require.Len(t, lines, 2)
+
+	header := asciicast.Header{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &header))
+	assert.Equal(t, 2, header.Version)
+	assert.Equal(t, defaultRecordingWidth, header.Width)
+	assert.Equal(t, "okteto exec api -- bash", header.Title)
+	assert.Equal(t, "xterm-256color", header.Env["TERM"])
+	assert.True(t, strings.HasSuffix(lines[1], `"o","hello\n"]`))
+}
+
+func TestExecRecordingInvalidPath(t *testing.T) {
+	_, err := newExecRecording(filepath.Join(t.TempDir(), "missing", "session.cast"), "api", []string{"bash"})
+	assert.ErrorContains(t, err, "failed to create the recording file")
+}
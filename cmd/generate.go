@@ -0,0 +1,90 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/manifest"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+// Generate scaffolds an okteto manifest from existing resources
+func Generate() *cobra.Command {
+	opts := &manifest.GenerateOpts{}
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#generate"),
+		Short: "Generate an okteto manifest from the resources of a namespace, a Dockerfile or a compose file",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			if opts.Compose != "" && opts.Dockerfile != "" {
+				return errors.New("flags '--compose' and '--dockerfile' can't be used together")
+			}
+
+			cwd, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			opts.Workdir = cwd
+
+			g := &manifest.Generator{
+				Fs: afero.NewOsFs(),
+			}
+			// the cluster is only needed to inspect the resources of the namespace
+			if opts.Compose != "" || opts.Dockerfile != "" {
+				return g.RunGenerate(ctx, opts)
+			}
+
+			ctxResource := &model.ContextResource{}
+			if err := ctxResource.UpdateNamespace(opts.Namespace); err != nil {
+				return err
+			}
+			if err := ctxResource.UpdateContext(opts.Context); err != nil {
+				return err
+			}
+			ctxOptions := &contextCMD.ContextOptions{
+				Context:   ctxResource.Context,
+				Namespace: ctxResource.Namespace,
+				Show:      true,
+			}
+			if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+				return err
+			}
+
+			opts.Namespace = okteto.Context().Namespace
+
+			g.K8sClient, _, err = okteto.NewK8sClientProvider().Provide(okteto.Context().Cfg)
+			if err != nil {
+				return err
+			}
+			return g.RunGenerate(ctx, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Namespace, "namespace", "n", "", "namespace whose resources are inspected to generate the okteto manifest")
+	cmd.Flags().StringVarP(&opts.Context, "context", "c", "", "context target for generating the okteto manifest")
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", utils.DefaultManifest, "path of the generated okteto manifest")
+	cmd.Flags().StringVarP(&opts.Compose, "compose", "", "", "generate the okteto manifest from a compose file")
+	cmd.Flags().StringVarP(&opts.Dockerfile, "dockerfile", "", "", "generate the okteto manifest from a Dockerfile")
+	cmd.Flags().BoolVarP(&opts.Overwrite, "replace", "r", false, "overwrite existing manifest file")
+	return cmd
+}
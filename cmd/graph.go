@@ -0,0 +1,177 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+const (
+	dotGraphFormat     = "dot"
+	mermaidGraphFormat = "mermaid"
+)
+
+var mermaidIDRegex = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// graphOptions are the options of the graph command
+type graphOptions struct {
+	devPath string
+	output  string
+}
+
+// Graph renders the builds, services, dependencies and external resources of the okteto manifest
+func Graph() *cobra.Command {
+	opts := &graphOptions{}
+	cmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Render the dependency graph of your okteto manifest",
+		Long: `Render the dependency graph of your okteto manifest.
+
+The builds, services, dependencies and external resources of your okteto manifest are printed as a DOT or Mermaid graph.
+Services are linked to the services they depend on, and to the builds and external resources whose environment variables they use.
+The command fails if the graph has a cycle.`,
+		Args: utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#graph"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.output != dotGraphFormat && opts.output != mermaidGraphFormat {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("output format '%s' is not supported", opts.output),
+					Hint: fmt.Sprintf("Use one of: [%s, %s]", dotGraphFormat, mermaidGraphFormat),
+				}
+			}
+
+			manifest, err := model.GetManifestV2(opts.devPath)
+			if err != nil {
+				return err
+			}
+
+			g := manifest.Graph()
+			cycle := g.Cycle()
+			// the graph is written to stdout as is, so it stays valid even with the timestamps of the CI log format
+			if opts.output == mermaidGraphFormat {
+				renderMermaidGraph(os.Stdout, g, cycle)
+			} else {
+				renderDOTGraph(os.Stdout, g, cycle)
+			}
+
+			if len(cycle) > 0 {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("cyclic dependency found between %s", strings.Join(cycle, ", ")),
+					Hint: "Remove one of the dependencies of the cycle from your okteto manifest",
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.devPath, "file", "f", "", "path to the okteto manifest file")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", dotGraphFormat, "output format of the graph. One of: ['dot', 'mermaid']")
+	return cmd
+}
+
+// renderDOTGraph writes the graph in the graphviz DOT language, grouping the nodes by kind. The nodes of the cycle are red
+func renderDOTGraph(w io.Writer, g *model.ManifestGraph, cycle []string) {
+	shapes := map[model.ManifestNodeKind]string{
+		model.BuildNode:      "box",
+		model.ServiceNode:    "ellipse",
+		model.DependencyNode: "folder",
+		model.ExternalNode:   "cylinder",
+	}
+	inCycle := toSet(cycle)
+
+	fmt.Fprintln(w, "digraph okteto {")
+	fmt.Fprintln(w, "  rankdir=LR;")
+	for _, kind := range model.ManifestNodeKinds {
+		nodes := nodesOfKind(g, kind)
+		if len(nodes) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "  subgraph cluster_%s {\n", kind)
+		fmt.Fprintf(w, "    label=%q;\n", kind)
+		for _, n := range nodes {
+			attrs := fmt.Sprintf("label=%q, shape=%s", n.Name, shapes[kind])
+			if inCycle[n.ID()] {
+				attrs += ", color=red"
+			}
+			fmt.Fprintf(w, "    %q [%s];\n", n.ID(), attrs)
+		}
+		fmt.Fprintln(w, "  }")
+	}
+	for _, e := range g.Edges {
+		if inCycle[e.From] && inCycle[e.To] {
+			fmt.Fprintf(w, "  %q -> %q [color=red];\n", e.From, e.To)
+			continue
+		}
+		fmt.Fprintf(w, "  %q -> %q;\n", e.From, e.To)
+	}
+	fmt.Fprintln(w, "}")
+}
+
+// renderMermaidGraph writes the graph as a Mermaid flowchart, grouping the nodes by kind. The nodes of the cycle use the 'cycle' class
+func renderMermaidGraph(w io.Writer, g *model.ManifestGraph, cycle []string) {
+	fmt.Fprintln(w, "flowchart LR")
+	for _, kind := range model.ManifestNodeKinds {
+		nodes := nodesOfKind(g, kind)
+		if len(nodes) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "  subgraph %s\n", kind)
+		for _, n := range nodes {
+			fmt.Fprintf(w, "    %s[\"%s\"]\n", mermaidID(n.ID()), n.Name)
+		}
+		fmt.Fprintln(w, "  end")
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(w, "  %s --> %s\n", mermaidID(e.From), mermaidID(e.To))
+	}
+	if len(cycle) > 0 {
+		ids := make([]string, 0, len(cycle))
+		for _, id := range cycle {
+			ids = append(ids, mermaidID(id))
+		}
+		fmt.Fprintln(w, "  classDef cycle stroke:#f00,stroke-width:2px")
+		fmt.Fprintf(w, "  class %s cycle\n", strings.Join(ids, ","))
+	}
+}
+
+func nodesOfKind(g *model.ManifestGraph, kind model.ManifestNodeKind) []model.ManifestNode {
+	result := []model.ManifestNode{}
+	for _, n := range g.Nodes {
+		if n.Kind == kind {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+// mermaidID returns an identifier that mermaid accepts for a node of the graph
+func mermaidID(id string) string {
+	return mermaidIDRegex.ReplaceAllString(id, "_")
+}
+
+func toSet(values []string) map[string]bool {
+	result := map[string]bool{}
+	for _, v := range values {
+		result[v] = true
+	}
+	return result
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+var testGraph = &model.ManifestGraph{
+	Nodes: []model.ManifestNode{
+		{Kind: model.BuildNode, Name: "api"},
+		{Kind: model.ServiceNode, Name: "api"},
+		{Kind: model.ServiceNode, Name: "db"},
+	},
+	Edges: []model.ManifestEdge{
+		{From: "service/api", To: "build/api"},
+		{From: "service/api", To: "service/db"},
+		{From: "service/db", To: "service/api"},
+	},
+}
+
+func TestRenderDOTGraph(t *testing.T) {
+	var buf bytes.Buffer
+	renderDOTGraph(&buf, testGraph, []string{"service/api", "service/db"})
+	expected := `digraph okteto {
+  rankdir=LR;
+  subgraph cluster_build {
+    label="build";
+    "build/api" [label="api", shape=box];
+  }
+  subgraph cluster_service {
+    label="service";
+    "service/api" [label="api", shape=ellipse, color=red];
+    "service/db" [label="db", shape=ellipse, color=red];
+  }
+  "service/api" -> "build/api";
+  "service/api" -> "service/db" [color=red];
+  "service/db" -> "service/api" [color=red];
+}
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestRenderMermaidGraph(t *testing.T) {
+	var buf bytes.Buffer
+	renderMermaidGraph(&buf, testGraph, nil)
+	expected := `flowchart LR
+  subgraph build
+    build_api["api"]
+  end
+  subgraph service
+    service_api["api"]
+    service_db["db"]
+  end
+  service_api --> build_api
+  service_api --> service_db
+  service_db --> service_api
+`
+	assert.Equal(t, expected, buf.String())
+
+	buf.Reset()
+	renderMermaidGraph(&buf, testGraph, []string{"service/api", "service/db"})
+	assert.Contains(t, buf.String(), "  class service_api,service_db cycle\n")
+}
@@ -0,0 +1,185 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ideserver
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/deploy"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/devenvironment"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/signals"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// tokenEnvVar defines the token used to authenticate the requests to the ide server. A random one is generated if it is empty
+	tokenEnvVar = "OKTETO_IDE_SERVER_TOKEN"
+
+	rpcPath = "/rpc"
+)
+
+// Options defines the options for okteto ide-server
+type Options struct {
+	ManifestPath string
+	Namespace    string
+	K8sContext   string
+	Port         int
+}
+
+// ConnectionInfo is printed to stdout when the server is ready, so editor extensions know how to connect to it
+type ConnectionInfo struct {
+	URL   string `json:"url"`
+	Token string `json:"token"`
+}
+
+// IDEServer starts a local JSON-RPC server for editor extensions
+func IDEServer(ctx context.Context) *cobra.Command {
+	options := &Options{}
+	cmd := &cobra.Command{
+		Use:   "ide-server",
+		Short: "Start a local API for editor extensions",
+		Long: `Start a local JSON-RPC 2.0 API for editor extensions.
+
+The server listens on localhost and prints a JSON line with its URL and token when it's ready.
+Requests must be sent as HTTP POST with the header 'Authorization: Bearer <token>'.
+The available methods are 'session.status', 'up.start', 'up.stop', 'logs.get' and 'endpoints.list'.`,
+		Args: utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifest, err := contextCMD.LoadManifestWithContext(ctx, contextCMD.ManifestOptions{Filename: options.ManifestPath, Namespace: options.Namespace, K8sContext: options.K8sContext})
+			if err != nil {
+				return err
+			}
+
+			if manifest.Name == "" {
+				c, _, err := okteto.NewK8sClientProvider().Provide(okteto.Context().Cfg)
+				if err != nil {
+					return err
+				}
+				wd, err := os.Getwd()
+				if err != nil {
+					return err
+				}
+				manifest.Name = devenvironment.NewNameInferer(c).InferName(ctx, wd, okteto.Context().Namespace, options.ManifestPath)
+			}
+
+			token := os.Getenv(tokenEnvVar)
+			if token == "" {
+				token, err = generateToken()
+				if err != nil {
+					return err
+				}
+			}
+
+			svc := &service{
+				manifest:     manifest,
+				opts:         options,
+				ups:          newUpManager(),
+				k8sClient:    getK8sClient,
+				getState:     config.GetState,
+				getEndpoints: getEndpoints,
+			}
+			return run(ctx, svc, token, options.Port, os.Stdout)
+		},
+	}
+	cmd.Flags().StringVarP(&options.ManifestPath, "file", "f", "", "path to the okteto manifest file")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "namespace of the development environment")
+	cmd.Flags().StringVarP(&options.K8sContext, "context", "c", "", "context of the development environment")
+	cmd.Flags().IntVarP(&options.Port, "port", "p", 0, "port where the server listens on localhost (a random port is used by default)")
+	return cmd
+}
+
+// run serves the API until the command is interrupted
+func run(ctx context.Context, svc *service, token string, port int, out io.Writer) error {
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return fmt.Errorf("error starting the ide server: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(rpcPath, &rpcServer{token: token, handlers: svc.handlers()})
+	srv := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	info := ConnectionInfo{
+		URL:   fmt.Sprintf("http://%s%s", listener.Addr().String(), rpcPath),
+		Token: token,
+	}
+	if err := json.NewEncoder(out).Encode(info); err != nil {
+		return err
+	}
+
+	ctx, stop := signals.NotifyContext(ctx)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.Serve(listener)
+	}()
+
+	select {
+	case err := <-errCh:
+		svc.ups.stopAll()
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+		oktetoLog.Infof("ide-server: shutting down")
+	}
+
+	svc.ups.stopAll()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
+}
+
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating the ide server token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func getK8sClient() (kubernetes.Interface, error) {
+	c, _, err := okteto.NewK8sClientProvider().Provide(okteto.Context().Cfg)
+	return c, err
+}
+
+func getEndpoints(ctx context.Context, name, namespace string) ([]string, error) {
+	eg, err := deploy.NewEndpointGetter()
+	if err != nil {
+		return nil, err
+	}
+	// the json output disables the spinner of the endpoints command
+	return eg.GetEndpoints(ctx, &deploy.EndpointsOptions{Name: name, Namespace: namespace, Output: "json"})
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ideserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	jsonRPCVersion = "2.0"
+
+	// error codes defined by the JSON-RPC 2.0 spec
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeServerError    = -32000
+)
+
+// errInvalidParams is returned by the handlers when the params of the request are not valid
+var errInvalidParams = errors.New("invalid params")
+
+// rpcRequest is a JSON-RPC 2.0 request
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// rpcResponse is a JSON-RPC 2.0 response
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcError is the error of a JSON-RPC 2.0 response
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// rpcHandler handles the params of a method and returns its result
+type rpcHandler func(ctx context.Context, params json.RawMessage) (interface{}, error)
+
+// rpcServer serves the JSON-RPC 2.0 methods over HTTP. Requests must be authenticated with the server token
+type rpcServer struct {
+	token    string
+	handlers map[string]rpcHandler
+}
+
+// ServeHTTP implements the http.Handler interface
+func (s *rpcServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	req := &rpcRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		s.write(w, nil, nil, &rpcError{Code: codeParseError, Message: err.Error()})
+		return
+	}
+	if req.JSONRPC != jsonRPCVersion || req.Method == "" {
+		s.write(w, req.ID, nil, &rpcError{Code: codeInvalidRequest, Message: "invalid JSON-RPC 2.0 request"})
+		return
+	}
+	handler, ok := s.handlers[req.Method]
+	if !ok {
+		s.write(w, req.ID, nil, &rpcError{Code: codeMethodNotFound, Message: "method not found: " + req.Method})
+		return
+	}
+
+	oktetoLog.Infof("ide-server: handling '%s'", req.Method)
+	result, err := handler(r.Context(), req.Params)
+	if err != nil {
+		code := codeServerError
+		if errors.Is(err, errInvalidParams) {
+			code = codeInvalidParams
+		}
+		s.write(w, req.ID, nil, &rpcError{Code: code, Message: err.Error()})
+		return
+	}
+	s.write(w, req.ID, result, nil)
+}
+
+func (*rpcServer) write(w http.ResponseWriter, id json.RawMessage, result interface{}, rpcErr *rpcError) {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	resp := rpcResponse{JSONRPC: jsonRPCVersion, ID: id, Result: result, Error: rpcErr}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		oktetoLog.Infof("ide-server: error writing response: %s", err)
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ideserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRPCServer(t *testing.T) {
+	s := &rpcServer{
+		token: "secret",
+		handlers: map[string]rpcHandler{
+			"echo": func(_ context.Context, params json.RawMessage) (interface{}, error) {
+				return params, nil
+			},
+			"fail": func(_ context.Context, _ json.RawMessage) (interface{}, error) {
+				return nil, fmt.Errorf("%w: 'name' is required", errInvalidParams)
+			},
+		},
+	}
+
+	var tests = []struct {
+		name           string
+		method         string
+		token          string
+		body           string
+		expectedStatus int
+		expectedResult string
+		expectedCode   int
+	}{
+		{
+			name:           "unauthorized",
+			method:         http.MethodPost,
+			token:          "wrong",
+			body:           `{"jsonrpc":"2.0","id":1,"method":"echo"}`,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "get not allowed",
+			method:         http.MethodGet,
+			token:          "secret",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "success",
+			method:         http.MethodPost,
+			token:          "secret",
+			body:           `{"jsonrpc":"2.0","id":1,"method":"echo","params":{"name":"api"}}`,
+			expectedStatus: http.StatusOK,
+			expectedResult: `{"name":"api"}`,
+		},
+		{
+			name:           "parse error",
+			method:         http.MethodPost,
+			token:          "secret",
+			body:           `{`,
+			expectedStatus: http.StatusOK,
+			expectedCode:   codeParseError,
+		},
+		{
+			name:           "invalid request",
+			method:         http.MethodPost,
+			token:          "secret",
+			body:           `{"id":1,"method":"echo"}`,
+			expectedStatus: http.StatusOK,
+			expectedCode:   codeInvalidRequest,
+		},
+		{
+			name:           "method not found",
+			method:         http.MethodPost,
+			token:          "secret",
+			body:           `{"jsonrpc":"2.0","id":1,"method":"unknown"}`,
+			expectedStatus: http.StatusOK,
+			expectedCode:   codeMethodNotFound,
+		},
+		{
+			name:           "invalid params",
+			method:         http.MethodPost,
+			token:          "secret",
+			body:           `{"jsonrpc":"2.0","id":1,"method":"fail"}`,
+			expectedStatus: http.StatusOK,
+			expectedCode:   codeInvalidParams,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, rpcPath, strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+			resp := struct {
+				JSONRPC string          `json:"jsonrpc"`
+				Result  json.RawMessage `json:"result"`
+				Error   *rpcError       `json:"error"`
+			}{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, jsonRPCVersion, resp.JSONRPC)
+			if tt.expectedCode != 0 {
+				require.NotNil(t, resp.Error)
+				assert.Equal(t, tt.expectedCode, resp.Error.Code)
+				return
+			}
+			assert.Nil(t, resp.Error)
+			assert.JSONEq(t, tt.expectedResult, string(resp.Result))
+		})
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ideserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/k8s/pods"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"k8s.io/client-go/kubernetes"
+)
+
+const stateInactive = "inactive"
+
+// service implements the methods exposed by the ide server
+type service struct {
+	manifest     *model.Manifest
+	opts         *Options
+	ups          *upManager
+	k8sClient    func() (kubernetes.Interface, error)
+	getState     func(devName, namespace string) (config.UpState, error)
+	getEndpoints func(ctx context.Context, name, namespace string) ([]string, error)
+}
+
+// SessionStatus is the result of the 'session.status' method
+type SessionStatus struct {
+	Context   string      `json:"context"`
+	Namespace string      `json:"namespace"`
+	Name      string      `json:"name"`
+	Devs      []DevStatus `json:"devs"`
+}
+
+// DevStatus is the state of a dev container of the manifest
+type DevStatus struct {
+	Name    string `json:"name"`
+	State   string `json:"state"`
+	Running bool   `json:"running"`
+}
+
+// devParams are the params of the methods that act on a dev container
+type devParams struct {
+	Name string `json:"name"`
+	Tail int    `json:"tail,omitempty"`
+}
+
+// UpResult is the result of the 'up.start' and 'up.stop' methods
+type UpResult struct {
+	Name    string `json:"name"`
+	Running bool   `json:"running"`
+}
+
+// LogsResult is the result of the 'logs.get' method
+type LogsResult struct {
+	Name string `json:"name"`
+	Pod  string `json:"pod"`
+	Logs string `json:"logs"`
+}
+
+// EndpointsResult is the result of the 'endpoints.list' method
+type EndpointsResult struct {
+	Endpoints []string `json:"endpoints"`
+}
+
+func (s *service) handlers() map[string]rpcHandler {
+	return map[string]rpcHandler{
+		"session.status": s.status,
+		"up.start":       s.startUp,
+		"up.stop":        s.stopUp,
+		"logs.get":       s.logs,
+		"endpoints.list": s.endpoints,
+	}
+}
+
+func (s *service) status(_ context.Context, _ json.RawMessage) (interface{}, error) {
+	result := SessionStatus{
+		Context:   okteto.Context().Name,
+		Namespace: s.manifest.Namespace,
+		Name:      s.manifest.Name,
+		Devs:      []DevStatus{},
+	}
+	for name, dev := range s.manifest.Dev {
+		state := stateInactive
+		if upState, err := s.getState(dev.Name, s.manifest.Namespace); err == nil {
+			state = string(upState)
+		}
+		result.Devs = append(result.Devs, DevStatus{
+			Name:    name,
+			State:   state,
+			Running: s.ups.isRunning(name),
+		})
+	}
+	sort.Slice(result.Devs, func(i, j int) bool {
+		return result.Devs[i].Name < result.Devs[j].Name
+	})
+	return result, nil
+}
+
+func (s *service) startUp(_ context.Context, params json.RawMessage) (interface{}, error) {
+	p, err := s.getDevParams(params)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ups.start(p.Name, s.getUpArgs(p.Name)); err != nil {
+		return nil, err
+	}
+	return UpResult{Name: p.Name, Running: true}, nil
+}
+
+func (s *service) stopUp(_ context.Context, params json.RawMessage) (interface{}, error) {
+	p, err := s.getDevParams(params)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ups.stop(p.Name); err != nil {
+		return nil, err
+	}
+	return UpResult{Name: p.Name, Running: false}, nil
+}
+
+func (s *service) logs(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	p, err := s.getDevParams(params)
+	if err != nil {
+		return nil, err
+	}
+	dev := s.manifest.Dev[p.Name]
+	c, err := s.k8sClient()
+	if err != nil {
+		return nil, err
+	}
+	pod, err := pods.GetBySelector(ctx, s.manifest.Namespace, map[string]string{model.InteractiveDevLabel: dev.Name}, c)
+	if err != nil {
+		return nil, fmt.Errorf("development container '%s' is not running: %w", p.Name, err)
+	}
+	container := dev.Container
+	if container == "" && len(pod.Spec.Containers) > 0 {
+		container = pod.Spec.Containers[0].Name
+	}
+	logs, err := pods.ContainerLogs(ctx, container, pod.Name, pod.Namespace, false, c)
+	if err != nil {
+		return nil, err
+	}
+	return LogsResult{Name: p.Name, Pod: pod.Name, Logs: tailLines(logs, p.Tail)}, nil
+}
+
+func (s *service) endpoints(ctx context.Context, _ json.RawMessage) (interface{}, error) {
+	eps, err := s.getEndpoints(ctx, s.manifest.Name, s.manifest.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if eps == nil {
+		eps = []string{}
+	}
+	return EndpointsResult{Endpoints: eps}, nil
+}
+
+func (s *service) getDevParams(params json.RawMessage) (*devParams, error) {
+	p := &devParams{}
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, p); err != nil {
+			return nil, fmt.Errorf("%w: %s", errInvalidParams, err)
+		}
+	}
+	if p.Name == "" {
+		return nil, fmt.Errorf("%w: 'name' is required", errInvalidParams)
+	}
+	if _, ok := s.manifest.Dev[p.Name]; !ok {
+		return nil, fmt.Errorf("%w: '%s' is not defined in the dev section of your okteto manifest", errInvalidParams, p.Name)
+	}
+	return p, nil
+}
+
+// getUpArgs returns the arguments of the 'okteto up' process of a dev container
+func (s *service) getUpArgs(name string) []string {
+	args := []string{"up", name, "--namespace", s.manifest.Namespace, "--context", okteto.Context().Name}
+	if s.opts.ManifestPath != "" {
+		args = append(args, "--file", s.opts.ManifestPath)
+	}
+	return append(args, "--log-output", "json")
+}
+
+// tailLines returns the last n lines of the logs, or all of them if n is 0
+func tailLines(logs string, n int) string {
+	if n <= 0 {
+		return logs
+	}
+	lines := strings.Split(strings.TrimSuffix(logs, "\n"), "\n")
+	if len(lines) <= n {
+		return logs
+	}
+	return strings.Join(lines[len(lines)-n:], "\n") + "\n"
+}
@@ -0,0 +1,170 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ideserver
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type fakeProcess struct {
+	done chan struct{}
+}
+
+func newFakeProcess() *fakeProcess {
+	return &fakeProcess{done: make(chan struct{})}
+}
+
+func (p *fakeProcess) Wait() error {
+	<-p.done
+	return nil
+}
+
+func (p *fakeProcess) Stop() error {
+	close(p.done)
+	return nil
+}
+
+func newTestService(t *testing.T, objects ...*apiv1.Pod) (*service, *[][]string) {
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {Name: "test", Namespace: "ns"},
+		},
+		CurrentContext: "test",
+	}
+	started := &[][]string{}
+	ups := newUpManager()
+	ups.startFn = func(args []string) (process, error) {
+		*started = append(*started, args)
+		return newFakeProcess(), nil
+	}
+	c := fake.NewSimpleClientset()
+	for _, o := range objects {
+		_, err := c.CoreV1().Pods(o.Namespace).Create(context.Background(), o, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	return &service{
+		manifest: &model.Manifest{
+			Name:      "movies",
+			Namespace: "ns",
+			Dev: model.ManifestDevs{
+				"api":      {Name: "api"},
+				"frontend": {Name: "frontend"},
+			},
+		},
+		opts: &Options{ManifestPath: "okteto.yml"},
+		ups:  ups,
+		k8sClient: func() (kubernetes.Interface, error) {
+			return c, nil
+		},
+		getState: func(devName, _ string) (config.UpState, error) {
+			if devName == "api" {
+				return config.Ready, nil
+			}
+			return config.Failed, assert.AnError
+		},
+		getEndpoints: func(_ context.Context, name, namespace string) ([]string, error) {
+			return []string{"https://movies-ns.okteto.example.com"}, nil
+		},
+	}, started
+}
+
+func TestServiceStatus(t *testing.T) {
+	s, _ := newTestService(t)
+	require.NoError(t, s.ups.start("frontend", nil))
+
+	result, err := s.status(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, SessionStatus{
+		Context:   "test",
+		Namespace: "ns",
+		Name:      "movies",
+		Devs: []DevStatus{
+			{Name: "api", State: "ready"},
+			{Name: "frontend", State: stateInactive, Running: true},
+		},
+	}, result)
+}
+
+func TestServiceUp(t *testing.T) {
+	s, started := newTestService(t)
+
+	result, err := s.startUp(context.Background(), json.RawMessage(`{"name":"api"}`))
+	require.NoError(t, err)
+	assert.Equal(t, UpResult{Name: "api", Running: true}, result)
+	assert.Equal(t, [][]string{{"up", "api", "--namespace", "ns", "--context", "test", "--file", "okteto.yml", "--log-output", "json"}}, *started)
+
+	_, err = s.startUp(context.Background(), json.RawMessage(`{"name":"api"}`))
+	assert.Error(t, err)
+
+	result, err = s.stopUp(context.Background(), json.RawMessage(`{"name":"api"}`))
+	require.NoError(t, err)
+	assert.Equal(t, UpResult{Name: "api", Running: false}, result)
+	assert.False(t, s.ups.isRunning("api"))
+
+	_, err = s.stopUp(context.Background(), json.RawMessage(`{"name":"api"}`))
+	assert.Error(t, err)
+
+	_, err = s.startUp(context.Background(), json.RawMessage(`{"name":"unknown"}`))
+	assert.ErrorIs(t, err, errInvalidParams)
+	_, err = s.startUp(context.Background(), nil)
+	assert.ErrorIs(t, err, errInvalidParams)
+}
+
+func TestServiceLogs(t *testing.T) {
+	s, _ := newTestService(t, &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "api-123",
+			Namespace: "ns",
+			Labels:    map[string]string{model.InteractiveDevLabel: "api"},
+		},
+		Spec: apiv1.PodSpec{Containers: []apiv1.Container{{Name: "api"}}},
+	})
+
+	result, err := s.logs(context.Background(), json.RawMessage(`{"name":"api"}`))
+	require.NoError(t, err)
+	logs, ok := result.(LogsResult)
+	require.True(t, ok)
+	assert.Equal(t, "api", logs.Name)
+	assert.Equal(t, "api-123", logs.Pod)
+	assert.NotEmpty(t, logs.Logs)
+
+	_, err = s.logs(context.Background(), json.RawMessage(`{"name":"frontend"}`))
+	assert.Error(t, err)
+}
+
+func TestServiceEndpoints(t *testing.T) {
+	s, _ := newTestService(t)
+	result, err := s.endpoints(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, EndpointsResult{Endpoints: []string{"https://movies-ns.okteto.example.com"}}, result)
+}
+
+func TestTailLines(t *testing.T) {
+	logs := "line 1\nline 2\nline 3\n"
+	assert.Equal(t, logs, tailLines(logs, 0))
+	assert.Equal(t, logs, tailLines(logs, 5))
+	assert.Equal(t, "line 2\nline 3\n", tailLines(logs, 2))
+}
@@ -0,0 +1,135 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ideserver
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// process is a running 'okteto up' command
+type process interface {
+	Wait() error
+	Stop() error
+}
+
+// upManager keeps track of the 'okteto up' processes started by the ide server
+type upManager struct {
+	mu        sync.Mutex
+	processes map[string]process
+	startFn   func(args []string) (process, error)
+}
+
+func newUpManager() *upManager {
+	return &upManager{
+		processes: map[string]process{},
+		startFn:   startOktetoProcess,
+	}
+}
+
+func (m *upManager) start(name string, args []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.processes[name]; ok {
+		return fmt.Errorf("development container '%s' is already running", name)
+	}
+	p, err := m.startFn(args)
+	if err != nil {
+		return fmt.Errorf("error starting development container '%s': %w", name, err)
+	}
+	m.processes[name] = p
+
+	go func() {
+		if err := p.Wait(); err != nil {
+			oktetoLog.Infof("ide-server: 'okteto up' of '%s' exited: %s", name, err)
+		}
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if m.processes[name] == p {
+			delete(m.processes, name)
+		}
+	}()
+	return nil
+}
+
+func (m *upManager) stop(name string) error {
+	m.mu.Lock()
+	p, ok := m.processes[name]
+	if ok {
+		delete(m.processes, name)
+	}
+	m.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("development container '%s' was not started by the ide server", name)
+	}
+	return p.Stop()
+}
+
+func (m *upManager) isRunning(name string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.processes[name]
+	return ok
+}
+
+// stopAll stops all the running processes, it is called when the ide server shuts down
+func (m *upManager) stopAll() {
+	m.mu.Lock()
+	names := []string{}
+	for name := range m.processes {
+		names = append(names, name)
+	}
+	m.mu.Unlock()
+	for _, name := range names {
+		if err := m.stop(name); err != nil {
+			oktetoLog.Infof("ide-server: error stopping '%s': %s", name, err)
+		}
+	}
+}
+
+// oktetoProcess runs the okteto binary of the ide server
+type oktetoProcess struct {
+	cmd *exec.Cmd
+}
+
+func startOktetoProcess(args []string) (process, error) {
+	bin, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	oktetoLog.Infof("ide-server: running 'okteto %s'", strings.Join(args, " "))
+	cmd := exec.Command(bin, args...)
+	cmd.Env = os.Environ()
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return &oktetoProcess{cmd: cmd}, nil
+}
+
+func (p *oktetoProcess) Wait() error {
+	return p.cmd.Wait()
+}
+
+// Stop interrupts the process so 'okteto up' can clean up before exiting
+func (p *oktetoProcess) Stop() error {
+	if err := p.cmd.Process.Signal(os.Interrupt); err != nil {
+		return p.cmd.Process.Kill()
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ignore
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/ignore"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+// CheckOptions defines the options for okteto ignore check
+type CheckOptions struct {
+	Service string
+}
+
+// Check explains which rule of the ignore files of the current folder matches a path
+func Check() *cobra.Command {
+	options := &CheckOptions{}
+	cmd := &cobra.Command{
+		Use:   "check <path>",
+		Short: "Explain which rule of your .dockerignore and .oktetoignore files matches a path",
+		Long: `Explain which rule of your .dockerignore and .oktetoignore files matches a path.
+
+It reads the ignore files of the current folder, which is the build context. The rules of the .oktetoignore are applied after the ones of the .dockerignore.
+Use '--service' to apply the rules of the section of the service of the .oktetoignore.`,
+		Args: utils.ExactArgsAccepted(1, "https://okteto.com/docs/reference/cli/#ignore"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wd, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			return runCheck(afero.NewOsFs(), wd, args[0], options, os.Stdout)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Service, "service", "s", "", "service of the okteto manifest whose section of the .oktetoignore is applied")
+	return cmd
+}
+
+func runCheck(fs afero.Fs, dir, path string, options *CheckOptions, out io.Writer) error {
+	rel, err := getRelativePath(dir, path)
+	if err != nil {
+		return err
+	}
+
+	dockerignore, err := ignore.ReadDockerIgnore(fs, filepath.Join(dir, ignore.DockerIgnoreFile))
+	if err != nil {
+		return err
+	}
+	oktetoignore, err := ignore.ReadOktetoIgnore(fs, dir)
+	if err != nil {
+		return err
+	}
+	rules := append(dockerignore.Rules(""), oktetoignore.Rules(options.Service)...)
+
+	rule, err := ignore.Match(rules, rel)
+	if err != nil {
+		return err
+	}
+	switch {
+	case rule == nil:
+		fmt.Fprintf(out, "'%s' is not excluded by any rule of %s or %s\n", rel, ignore.DockerIgnoreFile, ignore.OktetoIgnoreFile)
+	case rule.IsException():
+		fmt.Fprintf(out, "'%s' is included by the exception '%s' of %s\n", rel, rule.Pattern, rule.Location())
+	default:
+		fmt.Fprintf(out, "'%s' is excluded by the rule '%s' of %s\n", rel, rule.Pattern, rule.Location())
+	}
+	return nil
+}
+
+// getRelativePath returns the slash separated path relative to the build context
+func getRelativePath(dir, path string) (string, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return "", err
+	}
+	if rel == ".." || strings.HasPrefix(rel, fmt.Sprintf("..%c", filepath.Separator)) {
+		return "", oktetoErrors.UserError{
+			E:    fmt.Errorf("'%s' is not part of the build context '%s'", path, dir),
+			Hint: "Run 'okteto ignore check' from the folder of the build context",
+		}
+	}
+	return filepath.ToSlash(rel), nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ignore
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCheck(t *testing.T) {
+	dir := filepath.Join(string(filepath.Separator), "app")
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, ".dockerignore"), []byte("node_modules\n*.md"), 0600))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, ".oktetoignore"), []byte("!README.md\n[api]\nfrontend"), 0600))
+
+	tests := []struct {
+		name     string
+		path     string
+		service  string
+		expected string
+	}{
+		{
+			name:     "not excluded",
+			path:     "main.go",
+			expected: "'main.go' is not excluded by any rule of .dockerignore or .oktetoignore\n",
+		},
+		{
+			name:     "excluded by the dockerignore",
+			path:     "node_modules/react/index.js",
+			expected: "'node_modules/react/index.js' is excluded by the rule 'node_modules' of .dockerignore:1\n",
+		},
+		{
+			name:     "exception of the oktetoignore",
+			path:     filepath.Join(dir, "README.md"),
+			expected: "'README.md' is included by the exception '!README.md' of .oktetoignore:1\n",
+		},
+		{
+			name:     "section of the service",
+			path:     "frontend/index.js",
+			service:  "api",
+			expected: "'frontend/index.js' is excluded by the rule 'frontend' of .oktetoignore:3 [api]\n",
+		},
+		{
+			name:     "section of other service",
+			path:     "frontend/index.js",
+			service:  "frontend",
+			expected: "'frontend/index.js' is not excluded by any rule of .dockerignore or .oktetoignore\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			require.NoError(t, runCheck(fs, dir, tt.path, &CheckOptions{Service: tt.service}, out))
+			assert.Equal(t, tt.expected, out.String())
+		})
+	}
+
+	err := runCheck(fs, dir, "../other/main.go", &CheckOptions{}, &bytes.Buffer{})
+	assert.Error(t, err)
+}
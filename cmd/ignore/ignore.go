@@ -0,0 +1,30 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ignore
+
+import (
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/spf13/cobra"
+)
+
+// Ignore inspects the .dockerignore and .oktetoignore files
+func Ignore() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ignore",
+		Short: "Inspect the rules of your .dockerignore and .oktetoignore files",
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#ignore"),
+	}
+	cmd.AddCommand(Check())
+	return cmd
+}
@@ -0,0 +1,183 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	units "github.com/docker/go-units"
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/images"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/registry"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/kubernetes"
+)
+
+var errInvalidOutput = fmt.Errorf("output format is not accepted. Value must be one of: ['json']")
+
+// ListOptions defines the options for okteto images list
+type ListOptions struct {
+	K8sContext string
+	Namespace  string
+	Output     string
+}
+
+// repositoryCtrl lists the images of the repositories of a namespace
+type repositoryCtrl interface {
+	ListNamespaceTags(ctx context.Context, namespace string) ([]registry.ImageTag, error)
+}
+
+type imageOutput struct {
+	Image   string     `json:"image"`
+	Digest  string     `json:"digest"`
+	Size    int64      `json:"size"`
+	Created *time.Time `json:"created,omitempty"`
+	UsedBy  []string   `json:"usedBy"`
+}
+
+// List lists the images of the okteto registry of a namespace
+func List(ctx context.Context) *cobra.Command {
+	options := &ListOptions{}
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the images of your namespace in the Okteto Registry",
+		Long: `List the images of your namespace in the Okteto Registry.
+
+For every image tag it shows its size, creation date, digest and the pods, deployments, statefulsets, daemonsets, jobs and cronjobs of the namespace that use it.`,
+		Args: utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#images"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(options.Output); err != nil {
+				return err
+			}
+			ctxOpts := &contextCMD.ContextOptions{
+				Context:   options.K8sContext,
+				Namespace: options.Namespace,
+				Show:      options.Output == "",
+			}
+			if err := contextCMD.NewContextCommand().Run(ctx, ctxOpts); err != nil {
+				return err
+			}
+			if !okteto.IsOkteto() {
+				return oktetoErrors.ErrContextIsNotOktetoCluster
+			}
+			if options.Namespace == "" {
+				options.Namespace = okteto.Context().Namespace
+			}
+			c, _, err := okteto.GetK8sClient()
+			if err != nil {
+				return err
+			}
+			rc := registry.NewRepositoryCtrl(okteto.Config{})
+			return runList(ctx, rc, c, options, os.Stdout, time.Now())
+		},
+	}
+	cmd.Flags().StringVarP(&options.K8sContext, "context", "c", "", "context where the command is executed")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "namespace of the images to list")
+	cmd.Flags().StringVarP(&options.Output, "output", "o", "", "output format. One of: ['json']")
+	return cmd
+}
+
+func runList(ctx context.Context, rc repositoryCtrl, c kubernetes.Interface, options *ListOptions, out io.Writer, now time.Time) error {
+	tags, err := rc.ListNamespaceTags(ctx, options.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to list the images of namespace '%s': %w", options.Namespace, err)
+	}
+	inUse, err := images.ListInUse(ctx, options.Namespace, c)
+	if err != nil {
+		return fmt.Errorf("failed to get the images in use in namespace '%s': %w", options.Namespace, err)
+	}
+	return displayImages(out, getImagesOutput(tags, inUse), options, now)
+}
+
+// getImagesOutput transforms the registry tags into imageOutput type
+func getImagesOutput(tags []registry.ImageTag, inUse *images.InUse) []imageOutput {
+	result := []imageOutput{}
+	for _, t := range tags {
+		o := imageOutput{
+			Image:  t.Image(),
+			Digest: t.Digest,
+			Size:   t.Size,
+			UsedBy: inUse.Workloads(t.Image(), t.Digest),
+		}
+		if !t.Created.IsZero() {
+			created := t.Created
+			o.Created = &created
+		}
+		result = append(result, o)
+	}
+	return result
+}
+
+// displayImages prints the list of images
+func displayImages(out io.Writer, list []imageOutput, options *ListOptions, now time.Time) error {
+	switch options.Output {
+	case "json":
+		bytes, err := json.MarshalIndent(list, "", " ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(bytes))
+	default:
+		if len(list) == 0 {
+			fmt.Fprintf(out, "There are no images in namespace '%s'\n", options.Namespace)
+			return nil
+		}
+		w := tabwriter.NewWriter(out, 1, 1, 2, ' ', 0)
+		fmt.Fprint(w, "Image\tSize\tCreated\tDigest\tUsed By\n")
+		for _, i := range list {
+			fmt.Fprint(w, getImageDefaultOutput(i, now))
+		}
+		w.Flush()
+	}
+	return nil
+}
+
+// getImageDefaultOutput returns the row of an image for the default list output format
+func getImageDefaultOutput(i imageOutput, now time.Time) string {
+	size := "-"
+	if i.Size > 0 {
+		size = units.HumanSize(float64(i.Size))
+	}
+	created := "-"
+	if i.Created != nil {
+		created = fmt.Sprintf("%s ago", duration.HumanDuration(now.Sub(*i.Created)))
+	}
+	usedBy := "-"
+	if len(i.UsedBy) > 0 {
+		usedBy = strings.Join(i.UsedBy, ", ")
+	}
+	digest := registry.ImageTag{Digest: i.Digest}.ShortDigest()
+	return fmt.Sprintf("%s\t%s\t%s\t%s\t%s\n", i.Image, size, created, digest, usedBy)
+}
+
+// validateOutput returns error if output flag is not valid
+func validateOutput(output string) error {
+	switch output {
+	case "", "json":
+		return nil
+	default:
+		return errInvalidOutput
+	}
+}
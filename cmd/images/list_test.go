@@ -0,0 +1,104 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const (
+	repo    = "registry.okteto.dev/cindy/api"
+	digest1 = "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	digest2 = "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+)
+
+var now = time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+
+type fakeRepositoryCtrl struct {
+	tags []registry.ImageTag
+	err  error
+}
+
+func (f *fakeRepositoryCtrl) ListNamespaceTags(_ context.Context, _ string) ([]registry.ImageTag, error) {
+	return f.tags, f.err
+}
+
+func TestRunList(t *testing.T) {
+	rc := &fakeRepositoryCtrl{
+		tags: []registry.ImageTag{
+			{Repository: repo, Tag: "okteto", Digest: digest1, Created: now.Add(-5 * time.Hour), Size: 25 * 1000 * 1000},
+			{Repository: repo, Tag: "v1", Digest: digest2},
+		},
+	}
+	c := fake.NewSimpleClientset(
+		&apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "cindy"},
+			Spec:       apiv1.PodSpec{Containers: []apiv1.Container{{Image: repo + ":okteto"}}},
+		},
+	)
+
+	t.Run("table", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		err := runList(context.Background(), rc, c, &ListOptions{Namespace: "cindy"}, out, now)
+		require.NoError(t, err)
+		assert.Equal(t, `Image                                 Size  Created  Digest        Used By
+registry.okteto.dev/cindy/api:okteto  25MB  5h ago   111111111111  pod/api
+registry.okteto.dev/cindy/api:v1      -     -        222222222222  -
+`, out.String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		err := runList(context.Background(), rc, c, &ListOptions{Namespace: "cindy", Output: "json"}, out, now)
+		require.NoError(t, err)
+		assert.JSONEq(t, `[
+  {"image": "registry.okteto.dev/cindy/api:okteto", "digest": "`+digest1+`", "size": 25000000, "created": "2023-06-01T05:00:00Z", "usedBy": ["pod/api"]},
+  {"image": "registry.okteto.dev/cindy/api:v1", "digest": "`+digest2+`", "size": 0, "usedBy": []}
+]`, out.String())
+	})
+
+	t.Run("no images", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		err := runList(context.Background(), &fakeRepositoryCtrl{}, c, &ListOptions{Namespace: "cindy", Output: "json"}, out, now)
+		require.NoError(t, err)
+		assert.Equal(t, "[]\n", out.String())
+
+		out.Reset()
+		err = runList(context.Background(), &fakeRepositoryCtrl{}, c, &ListOptions{Namespace: "cindy"}, out, now)
+		require.NoError(t, err)
+		assert.Equal(t, "There are no images in namespace 'cindy'\n", out.String())
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		err := runList(context.Background(), &fakeRepositoryCtrl{err: errors.New("unauthorized")}, c, &ListOptions{Namespace: "cindy"}, &bytes.Buffer{}, now)
+		assert.EqualError(t, err, "failed to list the images of namespace 'cindy': unauthorized")
+	})
+}
+
+func TestValidateOutput(t *testing.T) {
+	assert.NoError(t, validateOutput(""))
+	assert.NoError(t, validateOutput("json"))
+	assert.ErrorIs(t, validateOutput("yaml"), errInvalidOutput)
+}
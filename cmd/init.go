@@ -35,6 +35,9 @@ func Init() *cobra.Command {
 		Short: "Automatically generate your okteto manifest",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
+			if err := manifest.ValidateFramework(opts.Language); err != nil {
+				return err
+			}
 
 			ctxResource := &model.ContextResource{}
 			if err := ctxResource.UpdateNamespace(opts.Namespace); err != nil {
@@ -81,5 +84,6 @@ func Init() *cobra.Command {
 	cmd.Flags().BoolVarP(&opts.Version1, "v1", "", false, "create a v1 okteto manifest: www.okteto.com/docs/0.10/reference/manifest/")
 	cmd.Flags().BoolVarP(&opts.AutoDeploy, "deploy", "", false, "deploy the application after generate the okteto manifest")
 	cmd.Flags().BoolVarP(&opts.AutoConfigureDev, "configure-devs", "", false, "configure devs after deploying the application")
+	cmd.Flags().StringVarP(&opts.Language, "framework", "", "", "language or framework of the development containers instead of detecting it")
 	return cmd
 }
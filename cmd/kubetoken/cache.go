@@ -0,0 +1,141 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubetoken
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/okteto/okteto/pkg/config"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/types"
+)
+
+const (
+	// kubetokenCacheFolder is the folder of the okteto home where the kubetokens are cached
+	kubetokenCacheFolder = "kubetokens"
+
+	// tokenExpirationMargin is the time before the expiration of a cached kubetoken when it's requested again,
+	// so kubectl never receives a token that expires in the middle of a request
+	tokenExpirationMargin = 1 * time.Minute
+
+	lockRetryDelay = 50 * time.Millisecond
+	lockTimeout    = 30 * time.Second
+)
+
+// tokenCacheKey identifies a cached kubetoken. The okteto token of the user is part of the key,
+// so the kubetoken of a user is never returned to another user of the same context and namespace
+type tokenCacheKey struct {
+	Context   string
+	Namespace string
+	UserToken string
+}
+
+// tokenCache keeps the kubetokens on disk by user, context and namespace until they are about to expire.
+// Parallel kubectl invocations wait on a file lock, so only one of them requests a new token
+type tokenCache struct {
+	dir string
+	now func() time.Time
+}
+
+func newTokenCache() *tokenCache {
+	return &tokenCache{
+		dir: filepath.Join(config.GetOktetoHome(), kubetokenCacheFolder),
+		now: time.Now,
+	}
+}
+
+// path returns the file of the kubetoken of a key. The key is hashed so the okteto token is never written to disk
+func (c *tokenCache) path(key tokenCacheKey) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\n%s\n%s", key.Context, key.Namespace, key.UserToken)))
+	return filepath.Join(c.dir, fmt.Sprintf("%s.json", hex.EncodeToString(sum[:])))
+}
+
+// lock blocks until no other okteto process is requesting the kubetoken of the same key
+func (c *tokenCache) lock(ctx context.Context, key tokenCacheKey) (func(), error) {
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create the kubetoken cache folder: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, lockTimeout)
+	defer cancel()
+
+	l := flock.New(fmt.Sprintf("%s.lock", c.path(key)))
+	locked, err := l.TryLockContext(ctx, lockRetryDelay)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock the kubetoken cache: %w", err)
+	}
+	if !locked {
+		return nil, fmt.Errorf("failed to lock the kubetoken cache")
+	}
+	return func() {
+		if err := l.Unlock(); err != nil {
+			oktetoLog.Infof("failed to unlock the kubetoken cache: %s", err)
+		}
+	}, nil
+}
+
+// get returns the cached kubetoken of a key if it doesn't expire soon
+func (c *tokenCache) get(key tokenCacheKey) (types.KubeTokenResponse, bool) {
+	token := types.KubeTokenResponse{}
+	b, err := os.ReadFile(c.path(key))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			oktetoLog.Infof("failed to read the cached kubetoken: %s", err)
+		}
+		return token, false
+	}
+	if err := json.Unmarshal(b, &token); err != nil {
+		oktetoLog.Infof("failed to decode the cached kubetoken: %s", err)
+		return token, false
+	}
+	expiration := token.Status.ExpirationTimestamp.Time
+	if token.Status.Token == "" || expiration.IsZero() || c.now().Add(tokenExpirationMargin).After(expiration) {
+		return token, false
+	}
+	return token, true
+}
+
+// set saves the kubetoken of a key. Tokens without expiration are not cached
+func (c *tokenCache) set(key tokenCacheKey, token types.KubeTokenResponse) error {
+	if token.Status.Token == "" || token.Status.ExpirationTimestamp.IsZero() {
+		return nil
+	}
+	b, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(c.dir, ".kubetoken-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(key))
+}
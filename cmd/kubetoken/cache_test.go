@@ -0,0 +1,89 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubetoken
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestToken(token string, expiration time.Time) types.KubeTokenResponse {
+	return types.KubeTokenResponse{
+		TokenRequest: authenticationv1.TokenRequest{
+			Status: authenticationv1.TokenRequestStatus{
+				Token:               token,
+				ExpirationTimestamp: metav1.NewTime(expiration),
+			},
+		},
+	}
+}
+
+func TestTokenCache(t *testing.T) {
+	now := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+	c := &tokenCache{dir: t.TempDir(), now: func() time.Time { return now }}
+	cindy := tokenCacheKey{Context: "https://okteto.dev", Namespace: "cindy", UserToken: "cindy-token"}
+	other := tokenCacheKey{Context: "https://okteto.dev", Namespace: "other", UserToken: "cindy-token"}
+
+	_, ok := c.get(cindy)
+	assert.False(t, ok)
+
+	require.NoError(t, c.set(cindy, newTestToken("token", now.Add(10*time.Minute))))
+	token, ok := c.get(cindy)
+	require.True(t, ok)
+	assert.Equal(t, "token", token.Status.Token)
+
+	_, ok = c.get(other)
+	assert.False(t, ok)
+
+	_, ok = c.get(tokenCacheKey{Context: "https://okteto.dev", Namespace: "cindy", UserToken: "john-token"})
+	assert.False(t, ok, "the kubetokens of other users are not returned")
+
+	now = now.Add(9*time.Minute + 30*time.Second)
+	_, ok = c.get(cindy)
+	assert.False(t, ok, "tokens that expire in less than a minute are not returned")
+
+	empty := tokenCacheKey{Context: "https://okteto.dev", Namespace: "empty"}
+	require.NoError(t, c.set(empty, types.KubeTokenResponse{}))
+	assert.NoFileExists(t, c.path(empty))
+}
+
+func TestTokenCacheLock(t *testing.T) {
+	c := &tokenCache{dir: t.TempDir(), now: time.Now}
+	cindy := tokenCacheKey{Context: "https://okteto.dev", Namespace: "cindy", UserToken: "cindy-token"}
+	other := tokenCacheKey{Context: "https://okteto.dev", Namespace: "other", UserToken: "cindy-token"}
+
+	unlock, err := c.lock(context.Background(), cindy)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_, err = c.lock(ctx, cindy)
+	assert.Error(t, err)
+
+	otherUnlock, err := c.lock(context.Background(), other)
+	require.NoError(t, err)
+	otherUnlock()
+
+	unlock()
+	unlock, err = c.lock(context.Background(), cindy)
+	require.NoError(t, err)
+	unlock()
+}
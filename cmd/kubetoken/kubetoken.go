@@ -30,8 +30,9 @@ import (
 
 // KubetokenFlags represents the flags available for kubetoken
 type KubetokenFlags struct {
-	Namespace string
-	Context   string
+	Namespace    string
+	Context      string
+	ForceRefresh bool
 }
 
 // oktetoClientProvider provides an okteto client ready to use or fail
@@ -69,6 +70,7 @@ type KubetokenCmd struct {
 	oktetoCtxCmdRunner   oktetoCtxCmdRunner
 	serializer           *Serializer
 	initCtxFunc          initCtxOptsFunc
+	cache                *tokenCache
 }
 
 // KubetokenOptions represents the options for kubetoken
@@ -108,12 +110,14 @@ func NewKubetokenCmd(optFunc ...kubetokenOption) *KubetokenCmd {
 		ctxStore:             opts.ctxStore,
 		oktetoCtxCmdRunner:   opts.oktetoCtxCmdRunner,
 		initCtxFunc:          getCtxResource,
+		cache:                newTokenCache(),
 	}
 }
 
 func (kc *KubetokenCmd) Cmd() *cobra.Command {
 	var namespace string
 	var contextName string
+	var forceRefresh bool
 
 	cmd := &cobra.Command{
 		Use:   "kubetoken",
@@ -125,20 +129,82 @@ You can find more information on 'ExecCredential' and 'client side authenticatio
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 			flags := KubetokenFlags{
-				Namespace: namespace,
-				Context:   contextName,
+				Namespace:    namespace,
+				Context:      contextName,
+				ForceRefresh: forceRefresh,
 			}
 			return kc.Run(ctx, flags)
 		},
 	}
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "okteto context's namespace")
 	cmd.Flags().StringVarP(&contextName, "context", "c", "", "okteto context's name")
+	cmd.Flags().BoolVar(&forceRefresh, "force-refresh", false, "request a new token instead of using the cached one")
 	return cmd
 }
 
-// Run executes the kubetoken command
+// Run executes the kubetoken command. Tokens are cached until they are about to expire
 func (kc *KubetokenCmd) Run(ctx context.Context, flags KubetokenFlags) error {
 	oktetoLog.SetOutputFormat("silent")
+
+	token, err := kc.getCachedKubeToken(ctx, flags)
+	if err != nil {
+		return err
+	}
+	return kc.print(token)
+}
+
+// getCachedKubeToken returns the cached kubetoken of the context and namespace, or requests a new one if it's about to expire.
+// The kubetoken is requested without cache if the cache can't be locked
+func (kc *KubetokenCmd) getCachedKubeToken(ctx context.Context, flags KubetokenFlags) (types.KubeTokenResponse, error) {
+	ctxResource := kc.initCtxFunc(flags.Context, flags.Namespace)
+	if kc.cache == nil || ctxResource.Context == "" {
+		return kc.getKubeToken(ctx, flags)
+	}
+
+	key := kc.getCacheKey(ctxResource)
+	unlock, err := kc.cache.lock(ctx, key)
+	if err != nil {
+		oktetoLog.Infof("kubetoken cache disabled: %s", err)
+		return kc.getKubeToken(ctx, flags)
+	}
+	defer unlock()
+
+	if !flags.ForceRefresh {
+		if token, ok := kc.cache.get(key); ok {
+			return token, nil
+		}
+	}
+	token, err := kc.getKubeToken(ctx, flags)
+	if err != nil {
+		return token, err
+	}
+	if err := kc.cache.set(key, token); err != nil {
+		oktetoLog.Infof("failed to cache the kubetoken: %s", err)
+	}
+	return token, nil
+}
+
+// getCacheKey returns the cache key of the kubetoken of a context and namespace for the okteto token of the user,
+// which is the one of the environment or the one stored in the context
+func (kc *KubetokenCmd) getCacheKey(ctxResource *contextCMD.ContextOptions) tokenCacheKey {
+	key := tokenCacheKey{
+		Context:   ctxResource.Context,
+		Namespace: ctxResource.Namespace,
+		UserToken: ctxResource.Token,
+	}
+	if key.UserToken != "" || kc.ctxStore == nil {
+		return key
+	}
+	if okCtx, ok := kc.ctxStore.Contexts[ctxResource.Context]; ok {
+		key.UserToken = okCtx.Token
+	} else if okCtx, ok := kc.ctxStore.Contexts[okteto.AddSchema(ctxResource.Context)]; ok {
+		key.UserToken = okCtx.Token
+	}
+	return key
+}
+
+// getKubeToken requests a new kubetoken to the okteto api
+func (kc *KubetokenCmd) getKubeToken(ctx context.Context, flags KubetokenFlags) (types.KubeTokenResponse, error) {
 	err := newPreReqValidator(
 		withCtxName(flags.Context),
 		withNamespace(flags.Namespace),
@@ -148,7 +214,7 @@ func (kc *KubetokenCmd) Run(ctx context.Context, flags KubetokenFlags) error {
 		withInitContextFunc(kc.initCtxFunc),
 	).validate(ctx)
 	if err != nil {
-		return fmt.Errorf("dynamic kubernetes token cannot be requested: %w", err)
+		return types.KubeTokenResponse{}, fmt.Errorf("dynamic kubernetes token cannot be requested: %w", err)
 	}
 
 	err = kc.oktetoCtxCmdRunner.Run(ctx, &contextCMD.ContextOptions{
@@ -156,7 +222,7 @@ func (kc *KubetokenCmd) Run(ctx context.Context, flags KubetokenFlags) error {
 		Namespace: flags.Namespace,
 	})
 	if err != nil {
-		return err
+		return types.KubeTokenResponse{}, err
 	}
 
 	ctxResource := kc.initCtxFunc(flags.Context, flags.Namespace)
@@ -165,15 +231,19 @@ func (kc *KubetokenCmd) Run(ctx context.Context, flags KubetokenFlags) error {
 		okteto.WithToken(ctxResource.Token),
 	)
 	if err != nil {
-		return fmt.Errorf("failed to create okteto client: %w", err)
+		return types.KubeTokenResponse{}, fmt.Errorf("failed to create okteto client: %w", err)
 	}
 
 	out, err := c.Kubetoken().GetKubeToken(ctxResource.Context, ctxResource.Namespace)
 	if err != nil {
-		return fmt.Errorf("failed to get the kubetoken: %w", err)
+		return types.KubeTokenResponse{}, fmt.Errorf("failed to get the kubetoken: %w", err)
 	}
+	return out, nil
+}
 
-	jsonStr, err := kc.serializer.ToJson(out)
+// print writes the kubetoken in ExecCredential format
+func (kc *KubetokenCmd) print(token types.KubeTokenResponse) error {
+	jsonStr, err := kc.serializer.ToJson(token)
 	if err != nil {
 		return fmt.Errorf("failed to marshal KubeTokenResponse: %w", err)
 	}
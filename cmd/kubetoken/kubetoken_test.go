@@ -16,12 +16,14 @@ package kubetoken
 import (
 	"context"
 	"testing"
+	"time"
 
 	contextCMD "github.com/okteto/okteto/cmd/context"
 	"github.com/okteto/okteto/internal/test/client"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
@@ -148,6 +150,7 @@ func TestKubetoken(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			cmd := NewKubetokenCmd()
+			cmd.cache = &tokenCache{dir: t.TempDir(), now: time.Now}
 			cmd.ctxStore = tc.input.contextStore
 			cmd.oktetoClientProvider = tc.input.fakeOktetoClientProvider
 			cmd.oktetoCtxCmdRunner = tc.input.fakeCtxCmdRunner
@@ -164,3 +167,45 @@ func TestKubetoken(t *testing.T) {
 		})
 	}
 }
+
+func TestKubetokenCache(t *testing.T) {
+	ctx := context.Background()
+	flags := KubetokenFlags{Context: "https://okteto.dev", Namespace: "cindy"}
+	kubetokenClient := client.NewFakeKubetokenClient(client.FakeKubetokenResponse{
+		Token: newTestToken("first", time.Now().Add(time.Hour)),
+	})
+
+	cmd := NewKubetokenCmd()
+	cmd.cache = &tokenCache{dir: t.TempDir(), now: time.Now}
+	cmd.ctxStore = &okteto.OktetoContextStore{
+		CurrentContext: "https://okteto.dev",
+		Contexts: map[string]*okteto.OktetoContext{
+			"https://okteto.dev": {IsOkteto: true, Token: "cindy-token"},
+		},
+	}
+	cmd.oktetoClientProvider = fakeOktetoClientProvider{
+		client: &client.FakeOktetoClient{KubetokenClient: kubetokenClient},
+	}
+	cmd.oktetoCtxCmdRunner = fakeCtxCmdRunner{}
+	cmd.initCtxFunc = func(string, string) *contextCMD.ContextOptions {
+		return &contextCMD.ContextOptions{Context: flags.Context, Namespace: flags.Namespace}
+	}
+
+	token, err := cmd.getCachedKubeToken(ctx, flags)
+	require.NoError(t, err)
+	assert.Equal(t, "first", token.Status.Token)
+
+	cmd.oktetoClientProvider = fakeOktetoClientProvider{err: assert.AnError}
+	token, err = cmd.getCachedKubeToken(ctx, flags)
+	require.NoError(t, err)
+	assert.Equal(t, "first", token.Status.Token)
+
+	flags.ForceRefresh = true
+	_, err = cmd.getCachedKubeToken(ctx, flags)
+	assert.ErrorIs(t, err, assert.AnError)
+
+	flags.ForceRefresh = false
+	cmd.ctxStore.Contexts["https://okteto.dev"].Token = "john-token"
+	_, err = cmd.getCachedKubeToken(ctx, flags)
+	assert.ErrorIs(t, err, assert.AnError, "the kubetoken cached for another user is not used")
+}
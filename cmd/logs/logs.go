@@ -17,9 +17,7 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/signal"
 	"path/filepath"
-	"syscall"
 	"time"
 
 	contextCMD "github.com/okteto/okteto/cmd/context"
@@ -30,6 +28,7 @@ import (
 	"github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/k8s/kubeconfig"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/signals"
 	"github.com/spf13/cobra"
 	"github.com/stern/stern/stern"
 )
@@ -100,7 +99,7 @@ func Logs(ctx context.Context) *cobra.Command {
 
 			go func() {
 				sigint := make(chan os.Signal, 1)
-				signal.Notify(sigint, syscall.SIGTERM, syscall.SIGINT)
+				signals.Notify(sigint)
 				<-sigint
 				cancel()
 			}()
@@ -0,0 +1,70 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/okteto/okteto/cmd/manifest"
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+// Manifest manages okteto manifests
+func Manifest() *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#manifest"),
+		Use:   "manifest",
+		Short: "Manage okteto manifests",
+	}
+	cmd.AddCommand(manifestMigrate())
+	return cmd
+}
+
+func manifestMigrate() *cobra.Command {
+	opts := &manifest.MigrateOpts{}
+	cmd := &cobra.Command{
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#manifest"),
+		Use:   "migrate",
+		Short: "Convert a v1 dev manifest or a compose file into the okteto manifest v2 format",
+		Long: `Convert a v1 dev manifest or a compose file into the okteto manifest v2 format.
+
+v1 dev manifests are rewritten in-place. Compose files are kept as they are, and an okteto manifest that deploys them is created in the same folder.
+Every converted field is annotated with a comment, and the constructs that can't be converted are reported.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			m := &manifest.Migrator{Fs: afero.NewOsFs()}
+			report, err := m.Migrate(opts)
+			if err != nil {
+				return err
+			}
+
+			if opts.DryRun {
+				oktetoLog.Printf("%s", string(report.Content))
+			}
+			for _, converted := range report.Summary() {
+				oktetoLog.Information("Converted %s", converted)
+			}
+			for _, unconvertible := range report.Unconvertible {
+				oktetoLog.Warning(unconvertible)
+			}
+			if !opts.DryRun {
+				oktetoLog.Success("Okteto manifest (%s) migrated successfully", report.Output)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&opts.File, "file", "f", utils.DefaultManifest, "path to the v1 dev manifest or compose file to migrate")
+	cmd.Flags().BoolVarP(&opts.DryRun, "dry-run", "", false, "print the migrated manifest instead of writing it")
+	return cmd
+}
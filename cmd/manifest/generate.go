@@ -0,0 +1,300 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/apps"
+	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/k8s/services"
+	"github.com/okteto/okteto/pkg/linguist"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/okteto/okteto/pkg/registry"
+	"github.com/spf13/afero"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+const defaultGeneratedRemotePath = "/okteto"
+
+// GenerateOpts defines the options for okteto generate
+type GenerateOpts struct {
+	Namespace  string
+	Context    string
+	Output     string
+	Compose    string
+	Dockerfile string
+	Overwrite  bool
+	Workdir    string
+}
+
+// Generator scaffolds okteto manifests from existing resources
+type Generator struct {
+	// K8sClient is the client of the cluster, it is nil when the manifest is not generated from a namespace
+	K8sClient kubernetes.Interface
+	Fs        afero.Fs
+}
+
+// RunGenerate writes an okteto manifest scaffolded from a compose file, a Dockerfile or the resources of a namespace.
+// K8sClient is only used to scaffold it from the resources of a namespace
+func (g *Generator) RunGenerate(ctx context.Context, opts *GenerateOpts) error {
+	if !opts.Overwrite {
+		if _, err := g.Fs.Stat(opts.Output); err == nil {
+			return fmt.Errorf("%s already exists. Use the '--replace' flag to overwrite it", opts.Output)
+		}
+	}
+
+	var manifest *model.Manifest
+	var err error
+	switch {
+	case opts.Compose != "":
+		manifest, err = inferFromCompose(opts.Compose)
+	case opts.Dockerfile != "":
+		manifest, err = g.FromDockerfile(opts.Workdir, opts.Dockerfile)
+	default:
+		manifest, err = g.FromNamespace(ctx, opts.Namespace, opts.Workdir)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := manifest.WriteToFileWithFilesystem(opts.Output, g.Fs); err != nil {
+		return err
+	}
+	oktetoLog.Success("Okteto manifest (%s) generated successfully", opts.Output)
+	return nil
+}
+
+// FromDockerfile scaffolds a manifest that builds the Dockerfile and develops on its context
+func (g *Generator) FromDockerfile(cwd, dockerfile string) (*model.Manifest, error) {
+	if _, err := g.Fs.Stat(filepath.Join(cwd, dockerfile)); err != nil {
+		return nil, fmt.Errorf("could not read Dockerfile '%s': %w", dockerfile, err)
+	}
+	buildContext := filepath.Dir(dockerfile)
+	name, err := model.GetValidNameFromFolder(filepath.Join(cwd, buildContext))
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := model.NewManifest()
+	manifest.Build[name] = &model.BuildInfo{
+		Context:    buildContext,
+		Dockerfile: dockerfile,
+	}
+	manifest.Deploy, err = inferGeneratedDeploySection(cwd)
+	if err != nil {
+		return nil, err
+	}
+
+	language, err := linguist.ProcessDirectory(filepath.Join(cwd, buildContext))
+	if err != nil {
+		oktetoLog.Infof("failed to process directory: %s", err)
+		language = linguist.Unrecognized
+	}
+	dev, err := linguist.GetDevDefaults(language, buildContext, registry.ImageMetadata{})
+	if err != nil {
+		return nil, err
+	}
+	dev.Image = nil
+	manifest.Dev[name] = dev
+	return manifest, nil
+}
+
+// FromNamespace scaffolds a manifest with a dev container for every deployment of the namespace,
+// the build of the Dockerfiles found in the working directory and the endpoints of its ingresses
+func (g *Generator) FromNamespace(ctx context.Context, namespace, cwd string) (*model.Manifest, error) {
+	dList, err := deployments.List(ctx, namespace, "", g.K8sClient)
+	if err != nil {
+		return nil, fmt.Errorf("error listing deployments of namespace '%s': %w", namespace, err)
+	}
+	svcList, err := services.List(ctx, namespace, "", g.K8sClient)
+	if err != nil {
+		return nil, fmt.Errorf("error listing services of namespace '%s': %w", namespace, err)
+	}
+
+	manifest := model.NewManifest()
+	for i := range dList {
+		d := &dList[i]
+		if apps.IsDevModeOn(apps.NewDeploymentApp(d)) || len(d.Spec.Template.Spec.Containers) == 0 {
+			continue
+		}
+		localPath := "."
+		if isDir, err := afero.DirExists(g.Fs, filepath.Join(cwd, d.Name)); err == nil && isDir {
+			localPath = d.Name
+		}
+		if dockerfile := filepath.Join(localPath, dockerfileName); g.fileExists(filepath.Join(cwd, dockerfile)) && (localPath != "." || len(dList) == 1) {
+			manifest.Build[d.Name] = &model.BuildInfo{
+				Context:    localPath,
+				Dockerfile: dockerfile,
+			}
+		}
+		manifest.Dev[d.Name] = translateDevFromDeployment(d, svcList, localPath)
+	}
+	if len(manifest.Dev) == 0 {
+		return nil, fmt.Errorf("no deployments found in namespace '%s'", namespace)
+	}
+
+	manifest.Deploy, err = inferGeneratedDeploySection(cwd)
+	if err != nil {
+		return nil, err
+	}
+	manifest.Deploy.Endpoints = g.getEndpoints(ctx, namespace, svcList)
+	return manifest, nil
+}
+
+// inferGeneratedDeploySection returns a placeholder deploy command when there are no manifests to deploy in cwd
+func inferGeneratedDeploySection(cwd string) (*model.DeployInfo, error) {
+	deploy, err := inferDeploySection(cwd)
+	if errors.Is(err, oktetoErrors.ErrCouldNotInferAnyManifest) {
+		return model.NewDeployInfo(), nil
+	}
+	return deploy, err
+}
+
+func (g *Generator) fileExists(path string) bool {
+	info, err := g.Fs.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+func translateDevFromDeployment(d *appsv1.Deployment, svcList []apiv1.Service, localPath string) *model.Dev {
+	container := d.Spec.Template.Spec.Containers[0]
+	remotePath := container.WorkingDir
+	if remotePath == "" {
+		remotePath = defaultGeneratedRemotePath
+	}
+
+	dev := &model.Dev{
+		Command: model.Command{Values: []string{"bash"}},
+		Sync: model.Sync{
+			RescanInterval: model.DefaultSyncthingRescanInterval,
+			Folders: []model.SyncFolder{
+				{
+					LocalPath:  localPath,
+					RemotePath: remotePath,
+				},
+			},
+		},
+		Forward: getForwardsFromServices(d, svcList, container),
+	}
+	if len(d.Spec.Template.Spec.Containers) > 1 {
+		dev.Container = container.Name
+	}
+	return dev
+}
+
+// getForwardsFromServices forwards the ports of the services exposing the deployment, or the container ports if there are none
+func getForwardsFromServices(d *appsv1.Deployment, svcList []apiv1.Service, container apiv1.Container) []forward.Forward {
+	ports := map[int]int{}
+	podLabels := labels.Set(d.Spec.Template.Labels)
+	for _, svc := range svcList {
+		if len(svc.Spec.Selector) == 0 || !labels.SelectorFromSet(svc.Spec.Selector).Matches(podLabels) {
+			continue
+		}
+		for _, p := range svc.Spec.Ports {
+			remote := p.TargetPort.IntValue()
+			if remote == 0 {
+				remote = getContainerPortByName(container, p.TargetPort.String(), int(p.Port))
+			}
+			ports[int(p.Port)] = remote
+		}
+	}
+	if len(ports) == 0 {
+		for _, p := range container.Ports {
+			ports[int(p.ContainerPort)] = int(p.ContainerPort)
+		}
+	}
+
+	result := []forward.Forward{}
+	for local, remote := range ports {
+		result = append(result, forward.Forward{Local: local, Remote: remote})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Local < result[j].Local
+	})
+	return result
+}
+
+func getContainerPortByName(container apiv1.Container, name string, defaultPort int) int {
+	for _, p := range container.Ports {
+		if p.Name == name {
+			return int(p.ContainerPort)
+		}
+	}
+	return defaultPort
+}
+
+// getEndpoints translates the rules of the ingresses of the namespace into manifest endpoints
+func (g *Generator) getEndpoints(ctx context.Context, namespace string, svcList []apiv1.Service) model.EndpointSpec {
+	iList, err := g.K8sClient.NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		oktetoLog.Infof("could not list ingresses of namespace '%s': %s", namespace, err)
+		return nil
+	}
+
+	result := model.EndpointSpec{}
+	for _, i := range iList.Items {
+		endpoint := model.Endpoint{Rules: []model.EndpointRule{}}
+		for _, rule := range i.Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+			for _, path := range rule.HTTP.Paths {
+				if path.Backend.Service == nil {
+					continue
+				}
+				port := path.Backend.Service.Port.Number
+				if port == 0 {
+					port = getServicePortByName(svcList, path.Backend.Service.Name, path.Backend.Service.Port.Name)
+				}
+				endpoint.Rules = append(endpoint.Rules, model.EndpointRule{
+					Path:    path.Path,
+					Service: path.Backend.Service.Name,
+					Port:    port,
+				})
+			}
+		}
+		if len(endpoint.Rules) > 0 {
+			result[i.Name] = endpoint
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+func getServicePortByName(svcList []apiv1.Service, svcName, portName string) int32 {
+	for _, svc := range svcList {
+		if svc.Name != svcName {
+			continue
+		}
+		for _, p := range svc.Spec.Ports {
+			if p.Name == portName {
+				return p.Port
+			}
+		}
+	}
+	return 0
+}
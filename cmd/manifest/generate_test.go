@@ -0,0 +1,152 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGenerateFromNamespace(t *testing.T) {
+	ctx := context.Background()
+	cwd := t.TempDir()
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(cwd, "api", "Dockerfile"), []byte("FROM golang"), 0600))
+
+	c := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "test"},
+			Spec: appsv1.DeploymentSpec{
+				Template: apiv1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "api"}},
+					Spec: apiv1.PodSpec{
+						Containers: []apiv1.Container{
+							{
+								Name:       "api",
+								WorkingDir: "/usr/src/app",
+								Ports:      []apiv1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+							},
+						},
+					},
+				},
+			},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "test"},
+			Spec: appsv1.DeploymentSpec{
+				Template: apiv1.PodTemplateSpec{
+					Spec: apiv1.PodSpec{
+						Containers: []apiv1.Container{
+							{Name: "worker", Ports: []apiv1.ContainerPort{{ContainerPort: 9000}}},
+							{Name: "sidecar"},
+						},
+					},
+				},
+			},
+		},
+		&apiv1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "test"},
+			Spec: apiv1.ServiceSpec{
+				Selector: map[string]string{"app": "api"},
+				Ports:    []apiv1.ServicePort{{Name: "web", Port: 80, TargetPort: intstr.FromString("http")}},
+			},
+		},
+		&networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "test"},
+			Spec: networkingv1.IngressSpec{
+				Rules: []networkingv1.IngressRule{
+					{
+						IngressRuleValue: networkingv1.IngressRuleValue{
+							HTTP: &networkingv1.HTTPIngressRuleValue{
+								Paths: []networkingv1.HTTPIngressPath{
+									{
+										Path: "/",
+										Backend: networkingv1.IngressBackend{
+											Service: &networkingv1.IngressServiceBackend{
+												Name: "api",
+												Port: networkingv1.ServiceBackendPort{Name: "web"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	)
+
+	g := &Generator{K8sClient: c, Fs: fs}
+	manifest, err := g.FromNamespace(ctx, "test", cwd)
+	require.NoError(t, err)
+
+	assert.Equal(t, model.ManifestBuild{
+		"api": {Context: "api", Dockerfile: filepath.Join("api", "Dockerfile")},
+	}, manifest.Build)
+
+	require.Contains(t, manifest.Dev, "api")
+	assert.Equal(t, []model.SyncFolder{{LocalPath: "api", RemotePath: "/usr/src/app"}}, manifest.Dev["api"].Sync.Folders)
+	assert.Equal(t, []forward.Forward{{Local: 80, Remote: 8080}}, manifest.Dev["api"].Forward)
+	assert.Empty(t, manifest.Dev["api"].Container)
+
+	require.Contains(t, manifest.Dev, "worker")
+	assert.Equal(t, []model.SyncFolder{{LocalPath: ".", RemotePath: defaultGeneratedRemotePath}}, manifest.Dev["worker"].Sync.Folders)
+	assert.Equal(t, []forward.Forward{{Local: 9000, Remote: 9000}}, manifest.Dev["worker"].Forward)
+	assert.Equal(t, "worker", manifest.Dev["worker"].Container)
+
+	assert.Equal(t, model.EndpointSpec{
+		"api": {Rules: []model.EndpointRule{{Path: "/", Service: "api", Port: 80}}},
+	}, manifest.Deploy.Endpoints)
+}
+
+func TestGenerateFromEmptyNamespace(t *testing.T) {
+	g := &Generator{K8sClient: fake.NewSimpleClientset(), Fs: afero.NewMemMapFs()}
+	_, err := g.FromNamespace(context.Background(), "test", t.TempDir())
+	assert.Error(t, err)
+}
+
+func TestRunGenerateFromDockerfile(t *testing.T) {
+	cwd := t.TempDir()
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(cwd, "Dockerfile"), []byte("FROM alpine"), 0600))
+
+	// the manifest is generated from the Dockerfile without a cluster
+	g := &Generator{Fs: fs}
+	output := filepath.Join(cwd, "okteto.yml")
+	err := g.RunGenerate(context.Background(), &GenerateOpts{
+		Output:     output,
+		Dockerfile: "Dockerfile",
+		Workdir:    cwd,
+	})
+	require.NoError(t, err)
+
+	content, err := afero.ReadFile(fs, output)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "dockerfile: Dockerfile")
+	assert.NoFileExists(t, output)
+}
@@ -80,6 +80,9 @@ func Init(at analyticsTrackerInterface) *cobra.Command {
 		Short: "Automatically generate your okteto manifest",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
+			if err := ValidateFramework(opts.Language); err != nil {
+				return err
+			}
 
 			ctxResource := &model.ContextResource{}
 			if err := ctxResource.UpdateNamespace(opts.Namespace); err != nil {
@@ -127,9 +130,18 @@ func Init(at analyticsTrackerInterface) *cobra.Command {
 	cmd.Flags().BoolVarP(&opts.Version1, "v1", "", false, "create a v1 okteto manifest: https://www.okteto.com/docs/reference/manifest/")
 	cmd.Flags().BoolVarP(&opts.AutoDeploy, "deploy", "", false, "deploy the application after generate the okteto manifest if it's not running already")
 	cmd.Flags().BoolVarP(&opts.AutoConfigureDev, "configure-devs", "", false, "configure devs after deploying the application")
+	cmd.Flags().StringVarP(&opts.Language, "framework", "", "", "language or framework of the development containers instead of detecting it")
 	return cmd
 }
 
+// ValidateFramework checks that the framework passed to okteto init has defaults
+func ValidateFramework(framework string) error {
+	if framework == "" || linguist.IsSupported(framework) {
+		return nil
+	}
+	return fmt.Errorf("framework '%s' is not supported. Supported values are: [%s]", framework, strings.Join(linguist.GetSupportedLanguages(), ", "))
+}
+
 // RunInitV2 initializes a new okteto manifest
 func (mc *ManifestCommand) RunInitV2(ctx context.Context, opts *InitOpts) (*model.Manifest, error) {
 	c, _, er := mc.K8sClientProvider.Provide(okteto.Context().Cfg)
@@ -166,7 +178,7 @@ func (mc *ManifestCommand) RunInitV2(ctx context.Context, opts *InitOpts) (*mode
 		}
 
 		if manifest.IsDeployDefault() && len(manifest.Build) == 1 {
-			if err := configureAutoCreateDev(manifest); err != nil {
+			if err := configureAutoCreateDev(manifest, opts.Language); err != nil {
 				return nil, err
 			}
 			manifest.Deploy = nil
@@ -218,7 +230,7 @@ func (mc *ManifestCommand) RunInitV2(ctx context.Context, opts *InitOpts) (*mode
 			}
 
 			if configureDevEnvsAnswer || opts.AutoConfigureDev {
-				if err := mc.configureDevsByResources(ctx, namespace); err != nil {
+				if err := mc.configureDevsByResources(ctx, namespace, opts.Language); err != nil {
 					return nil, err
 				}
 			}
@@ -306,7 +318,7 @@ func (mc *ManifestCommand) deploy(ctx context.Context, opts *InitOpts) error {
 	return nil
 }
 
-func (mc *ManifestCommand) configureDevsByResources(ctx context.Context, namespace string) error {
+func (mc *ManifestCommand) configureDevsByResources(ctx context.Context, namespace, framework string) error {
 	c, _, err := okteto.GetK8sClient()
 	if err != nil {
 		return err
@@ -339,7 +351,7 @@ func (mc *ManifestCommand) configureDevsByResources(ctx context.Context, namespa
 
 		path := getPathFromApp(wd, app.ObjectMeta().Name)
 
-		language, err := getLanguageFromPath(wd, path)
+		language, err := getLanguageFromPath(framework, wd, path)
 		if err != nil {
 			return err
 		}
@@ -374,7 +386,10 @@ func setFromImageConfig(dev *model.Dev, imageConfig registry.ImageMetadata) {
 	}
 }
 
-func getLanguageFromPath(wd, appName string) (string, error) {
+func getLanguageFromPath(framework, wd, appName string) (string, error) {
+	if framework != "" {
+		return framework, nil
+	}
 	possibleAppPath := filepath.Join(wd, appName)
 	language := ""
 	var err error
@@ -411,6 +426,17 @@ func getPathFromApp(wd, appName string) string {
 }
 
 func createFromCompose(composePath string) (*model.Manifest, error) {
+	manifest, err := inferFromCompose(composePath)
+	if err != nil {
+		return nil, err
+	}
+	manifest.Context = okteto.Context().Name
+	manifest.Namespace = okteto.Context().Namespace
+	return manifest, nil
+}
+
+// inferFromCompose returns the manifest that deploys a compose file, without the okteto context
+func inferFromCompose(composePath string) (*model.Manifest, error) {
 	stack, err := model.LoadStack("", []string{composePath}, true)
 	if err != nil {
 		return nil, err
@@ -437,8 +463,6 @@ func createFromCompose(composePath string) (*model.Manifest, error) {
 	if err != nil {
 		return nil, err
 	}
-	manifest.Context = okteto.Context().Name
-	manifest.Namespace = okteto.Context().Namespace
 
 	for _, build := range manifest.Build {
 		context, err := filepath.Abs(build.Context)
@@ -584,12 +608,12 @@ func (mc *ManifestCommand) getManifest(path string) (*model.Manifest, error) {
 	return model.GetManifestV2(path)
 }
 
-func configureAutoCreateDev(manifest *model.Manifest) error {
+func configureAutoCreateDev(manifest *model.Manifest, framework string) error {
 	wd, err := os.Getwd()
 	if err != nil {
 		return err
 	}
-	language, err := GetLanguage("", wd)
+	language, err := GetLanguage(framework, wd)
 	if err != nil {
 		return err
 	}
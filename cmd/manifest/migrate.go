@@ -0,0 +1,380 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
+	yaml3 "gopkg.in/yaml.v3"
+)
+
+const migratedComment = "migrated from '%s'"
+
+var (
+	// errAlreadyV2 is returned when the file to migrate is already an okteto manifest v2
+	errAlreadyV2 = errors.New("the file is already in the okteto manifest v2 format")
+
+	// v2OnlyFields are the top level fields that are only valid in the okteto manifest v2
+	v2OnlyFields = []string{"build", "deploy", "destroy", "dev", "dependencies", "external", "test"}
+
+	// composeBuildFields are the fields of the compose 'build' section supported by the okteto manifest 'build' section
+	composeBuildFields = map[string]bool{
+		"context":      true,
+		"dockerfile":   true,
+		"cache_from":   true,
+		"target":       true,
+		"args":         true,
+		"export_cache": true,
+	}
+
+	// composeUnsupportedFields are the top level fields of a compose file ignored by okteto deploy
+	composeUnsupportedFields = []string{"configs", "include", "networks", "secrets"}
+)
+
+// MigrateOpts defines the options for okteto manifest migrate
+type MigrateOpts struct {
+	File   string
+	DryRun bool
+}
+
+// MigrationReport is the result of migrating a file to the okteto manifest v2 format
+type MigrationReport struct {
+	// Output is the path of the migrated manifest
+	Output string
+	// Converted are the fields converted, as '<original> -> <migrated>'
+	Converted []string
+	// Unconvertible are the constructs that have no equivalent in the okteto manifest v2
+	Unconvertible []string
+	// Content is the migrated manifest
+	Content []byte
+}
+
+// Migrator converts v1 dev manifests and compose files into the okteto manifest v2 format
+type Migrator struct {
+	Fs afero.Fs
+}
+
+// Migrate converts a file into the okteto manifest v2 format.
+// v1 dev manifests are rewritten in-place, while compose files get an okteto manifest next to them that deploys them
+func (m *Migrator) Migrate(opts *MigrateOpts) (*MigrationReport, error) {
+	b, err := afero.ReadFile(m.Fs, opts.File)
+	if err != nil {
+		return nil, err
+	}
+	doc := &yaml3.Node{}
+	if err := yaml3.Unmarshal(b, doc); err != nil {
+		return nil, fmt.Errorf("could not parse '%s': %w", opts.File, err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml3.MappingNode {
+		return nil, fmt.Errorf("'%s' is not an okteto manifest or a compose file", opts.File)
+	}
+	root := doc.Content[0]
+
+	var report *MigrationReport
+	switch {
+	case isComposeDoc(root):
+		report, err = migrateCompose(root, opts.File)
+	case isV2Doc(root):
+		return nil, errAlreadyV2
+	default:
+		report, err = migrateDevV1(root, opts.File)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.DryRun {
+		return report, nil
+	}
+	if report.Output != opts.File {
+		if _, err := m.Fs.Stat(report.Output); err == nil {
+			return nil, fmt.Errorf("%s already exists, remove it before migrating '%s'", report.Output, opts.File)
+		}
+	}
+	if err := afero.WriteFile(m.Fs, report.Output, report.Content, 0600); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func isComposeDoc(root *yaml3.Node) bool {
+	services := getMappingValue(root, "services")
+	return services != nil && services.Kind == yaml3.MappingNode
+}
+
+func isV2Doc(root *yaml3.Node) bool {
+	for _, field := range v2OnlyFields {
+		if getMappingValue(root, field) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// migrateDevV1 moves the fields of a v1 dev manifest under 'dev.<name>'.
+// The extended syntax of 'image' is moved to 'build.<name>'
+func migrateDevV1(root *yaml3.Node, path string) (*MigrationReport, error) {
+	report := &MigrationReport{Output: path}
+
+	name := ""
+	if nameNode := getMappingValue(root, "name"); nameNode != nil {
+		name = nameNode.Value
+	}
+	if name == "" {
+		var err error
+		name, err = model.GetValidNameFromFolder(filepath.Dir(path))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	manifest := newMappingNode()
+	dev := newMappingNode()
+	var build *yaml3.Node
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		switch key.Value {
+		case "name":
+			report.addConverted(key.Value, fmt.Sprintf("dev.%s", name))
+		case "namespace", "context":
+			addMigratedField(manifest, key, value, key.Value)
+			report.addConverted(key.Value, key.Value)
+		case "push":
+			report.Unconvertible = append(report.Unconvertible, "'push' is not supported by the okteto manifest v2, define the image in the 'build' section and run 'okteto build' instead")
+		case "image":
+			if value.Kind != yaml3.MappingNode {
+				addMigratedField(dev, key, value, key.Value)
+				report.addConverted(key.Value, fmt.Sprintf("dev.%s.image", name))
+				continue
+			}
+			build = newMappingNode()
+			hasName := false
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				imageKey, imageValue := value.Content[j], value.Content[j+1]
+				if imageKey.Value == "name" {
+					hasName = true
+					addMigratedField(build, newScalarNode("image"), imageValue, "image.name")
+					addMigratedField(dev, newScalarNode("image"), imageValue, "image.name")
+					report.addConverted("image.name", fmt.Sprintf("build.%s.image", name))
+					continue
+				}
+				addMigratedField(build, imageKey, imageValue, fmt.Sprintf("image.%s", imageKey.Value))
+				report.addConverted(fmt.Sprintf("image.%s", imageKey.Value), fmt.Sprintf("build.%s.%s", name, imageKey.Value))
+			}
+			if !hasName {
+				// the image built by okteto is referenced by its env var, it's only inferred for dev containers with 'autocreate'
+				builtImage := newScalarNode(fmt.Sprintf("${OKTETO_BUILD_%s_IMAGE}", strings.ToUpper(strings.ReplaceAll(name, "-", "_"))))
+				addMigratedField(dev, newScalarNode("image"), builtImage, "image")
+				report.addConverted("image", fmt.Sprintf("dev.%s.image", name))
+			}
+		default:
+			addMigratedField(dev, key, value, key.Value)
+			report.addConverted(key.Value, fmt.Sprintf("dev.%s.%s", name, key.Value))
+		}
+	}
+
+	if build != nil {
+		manifest.Content = append(manifest.Content, newScalarNode("build"), newMappingNode(newScalarNode(name), build))
+	}
+	devName := newScalarNode(name)
+	devName.LineComment = fmt.Sprintf(migratedComment, "name")
+	manifest.Content = append(manifest.Content, newScalarNode("dev"), newMappingNode(devName, dev))
+	if len(root.Content) > 0 {
+		manifest.HeadComment = root.Content[0].HeadComment
+		root.Content[0].HeadComment = ""
+	}
+
+	content, err := encodeManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
+	report.Content = content
+	return report, nil
+}
+
+// migrateCompose creates an okteto manifest that deploys the compose file, builds the services with a 'build' section
+// and develops the services with bind mounts
+func migrateCompose(root *yaml3.Node, path string) (*MigrationReport, error) {
+	report := &MigrationReport{Output: filepath.Join(filepath.Dir(path), "okteto.yml")}
+	composeFile := filepath.Base(path)
+
+	for _, field := range composeUnsupportedFields {
+		if getMappingValue(root, field) != nil {
+			report.Unconvertible = append(report.Unconvertible, fmt.Sprintf("'%s' is not supported by okteto deploy and will be ignored", field))
+		}
+	}
+
+	build := newMappingNode()
+	dev := newMappingNode()
+	services := getMappingValue(root, "services")
+	for i := 0; i+1 < len(services.Content); i += 2 {
+		svcName, svc := services.Content[i].Value, services.Content[i+1]
+		if svc.Kind != yaml3.MappingNode {
+			continue
+		}
+		if svcBuild := migrateComposeBuild(svcName, svc, report); svcBuild != nil {
+			key := newScalarNode(svcName)
+			key.LineComment = fmt.Sprintf(migratedComment, fmt.Sprintf("services.%s.build", svcName))
+			build.Content = append(build.Content, key, svcBuild)
+		}
+		if svcDev := migrateComposeVolumes(svcName, svc, report); svcDev != nil {
+			key := newScalarNode(svcName)
+			key.LineComment = fmt.Sprintf(migratedComment, fmt.Sprintf("services.%s.volumes", svcName))
+			dev.Content = append(dev.Content, key, svcDev)
+		}
+	}
+
+	manifest := newMappingNode()
+	if len(build.Content) > 0 {
+		manifest.Content = append(manifest.Content, newScalarNode("build"), build)
+	}
+	composeKey := newScalarNode("compose")
+	composeKey.LineComment = fmt.Sprintf(migratedComment, composeFile)
+	manifest.Content = append(manifest.Content, newScalarNode("deploy"), newMappingNode(composeKey, newScalarNode(composeFile)))
+	report.addConverted(composeFile, "deploy.compose")
+	if len(dev.Content) > 0 {
+		manifest.Content = append(manifest.Content, newScalarNode("dev"), dev)
+	}
+
+	content, err := encodeManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
+	report.Content = content
+	return report, nil
+}
+
+func migrateComposeBuild(svcName string, svc *yaml3.Node, report *MigrationReport) *yaml3.Node {
+	svcBuild := getMappingValue(svc, "build")
+	if svcBuild == nil {
+		return nil
+	}
+	result := newMappingNode()
+	if svcBuild.Kind == yaml3.ScalarNode {
+		addMigratedField(result, newScalarNode("context"), svcBuild, fmt.Sprintf("services.%s.build", svcName))
+	} else {
+		for i := 0; i+1 < len(svcBuild.Content); i += 2 {
+			key, value := svcBuild.Content[i], svcBuild.Content[i+1]
+			path := fmt.Sprintf("services.%s.build.%s", svcName, key.Value)
+			if !composeBuildFields[key.Value] {
+				report.Unconvertible = append(report.Unconvertible, fmt.Sprintf("'%s' is not supported by the okteto manifest 'build' section", path))
+				continue
+			}
+			addMigratedField(result, key, value, path)
+		}
+	}
+	if image := getMappingValue(svc, "image"); image != nil {
+		addMigratedField(result, newScalarNode("image"), image, fmt.Sprintf("services.%s.image", svcName))
+	}
+	report.addConverted(fmt.Sprintf("services.%s.build", svcName), fmt.Sprintf("build.%s", svcName))
+	return result
+}
+
+// migrateComposeVolumes converts the bind mounts of a service into the sync folders of a dev container
+func migrateComposeVolumes(svcName string, svc *yaml3.Node, report *MigrationReport) *yaml3.Node {
+	volumes := getMappingValue(svc, "volumes")
+	if volumes == nil || volumes.Kind != yaml3.SequenceNode {
+		return nil
+	}
+	sync := &yaml3.Node{Kind: yaml3.SequenceNode}
+	for _, volume := range volumes.Content {
+		if volume.Kind != yaml3.ScalarNode {
+			report.Unconvertible = append(report.Unconvertible, fmt.Sprintf("the long syntax of 'services.%s.volumes' can't be converted to 'sync' folders", svcName))
+			continue
+		}
+		parts := strings.SplitN(volume.Value, ":", 3)
+		if len(parts) < 2 || !isBindMount(parts[0]) {
+			continue
+		}
+		sync.Content = append(sync.Content, newScalarNode(fmt.Sprintf("%s:%s", parts[0], parts[1])))
+	}
+	if len(sync.Content) == 0 {
+		return nil
+	}
+	report.addConverted(fmt.Sprintf("services.%s.volumes", svcName), fmt.Sprintf("dev.%s.sync", svcName))
+	return newMappingNode(
+		newScalarNode("command"), newScalarNode("bash"),
+		newScalarNode("sync"), sync,
+	)
+}
+
+func isBindMount(source string) bool {
+	return strings.HasPrefix(source, ".") || strings.HasPrefix(source, "/") || strings.HasPrefix(source, "~")
+}
+
+func (r *MigrationReport) addConverted(from, to string) {
+	r.Converted = append(r.Converted, fmt.Sprintf("%s -> %s", from, to))
+}
+
+// Summary returns the converted fields sorted alphabetically
+func (r *MigrationReport) Summary() []string {
+	result := append([]string{}, r.Converted...)
+	sort.Strings(result)
+	return result
+}
+
+func addMigratedField(mapping, key, value *yaml3.Node, from string) {
+	key = &yaml3.Node{
+		Kind:        yaml3.ScalarNode,
+		Value:       key.Value,
+		HeadComment: key.HeadComment,
+	}
+	comment := fmt.Sprintf(migratedComment, from)
+	// the encoder drops the line comments of keys followed by flow collections
+	if value.Kind != yaml3.ScalarNode && value.Style&yaml3.FlowStyle != 0 {
+		if value.LineComment == "" {
+			value.LineComment = comment
+		}
+	} else {
+		key.LineComment = comment
+	}
+	mapping.Content = append(mapping.Content, key, value)
+}
+
+func getMappingValue(mapping *yaml3.Node, key string) *yaml3.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func newMappingNode(content ...*yaml3.Node) *yaml3.Node {
+	return &yaml3.Node{Kind: yaml3.MappingNode, Content: content}
+}
+
+func newScalarNode(value string) *yaml3.Node {
+	return &yaml3.Node{Kind: yaml3.ScalarNode, Value: value}
+}
+
+func encodeManifest(manifest *yaml3.Node) ([]byte, error) {
+	buffer := bytes.NewBuffer(nil)
+	encoder := yaml3.NewEncoder(buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(manifest); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateDevV1(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	v1 := `# my dev environment
+name: api
+namespace: test
+image:
+  name: okteto/api:dev
+  context: .
+  dockerfile: Dockerfile
+push: okteto/api:dev
+command: ["bash"]
+sync:
+  - .:/usr/src/app
+`
+	require.NoError(t, afero.WriteFile(fs, "/app/okteto.yml", []byte(v1), 0600))
+
+	m := &Migrator{Fs: fs}
+	report, err := m.Migrate(&MigrateOpts{File: "/app/okteto.yml"})
+	require.NoError(t, err)
+
+	expected := `# my dev environment
+namespace: test # migrated from 'namespace'
+build:
+  api:
+    image: okteto/api:dev # migrated from 'image.name'
+    context: . # migrated from 'image.context'
+    dockerfile: Dockerfile # migrated from 'image.dockerfile'
+dev:
+  api: # migrated from 'name'
+    image: okteto/api:dev # migrated from 'image.name'
+    command: ["bash"] # migrated from 'command'
+    sync: # migrated from 'sync'
+      - .:/usr/src/app
+`
+	b, err := afero.ReadFile(fs, "/app/okteto.yml")
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(b))
+	assert.Equal(t, "/app/okteto.yml", report.Output)
+	assert.Len(t, report.Unconvertible, 1)
+	assert.Contains(t, report.Summary(), "sync -> dev.api.sync")
+}
+
+func TestMigrateDevV1WithoutImageName(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	v1 := `name: my-api
+image:
+  context: .
+command: ["bash"]
+`
+	require.NoError(t, afero.WriteFile(fs, "/app/okteto.yml", []byte(v1), 0600))
+
+	m := &Migrator{Fs: fs}
+	_, err := m.Migrate(&MigrateOpts{File: "/app/okteto.yml"})
+	require.NoError(t, err)
+
+	expected := `build:
+  my-api:
+    context: . # migrated from 'image.context'
+dev:
+  my-api: # migrated from 'name'
+    image: ${OKTETO_BUILD_MY_API_IMAGE} # migrated from 'image'
+    command: ["bash"] # migrated from 'command'
+`
+	b, err := afero.ReadFile(fs, "/app/okteto.yml")
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(b))
+}
+
+func TestMigrateCompose(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	compose := `services:
+  api:
+    image: okteto/api
+    build:
+      context: api
+      ssh: default
+    volumes:
+      - ./api:/usr/src/app
+      - data:/data
+  db:
+    image: postgres
+networks:
+  default: {}
+`
+	require.NoError(t, afero.WriteFile(fs, "/app/docker-compose.yml", []byte(compose), 0600))
+
+	m := &Migrator{Fs: fs}
+	report, err := m.Migrate(&MigrateOpts{File: "/app/docker-compose.yml"})
+	require.NoError(t, err)
+
+	expected := `build:
+  api: # migrated from 'services.api.build'
+    context: api # migrated from 'services.api.build.context'
+    image: okteto/api # migrated from 'services.api.image'
+deploy:
+  compose: docker-compose.yml # migrated from 'docker-compose.yml'
+dev:
+  api: # migrated from 'services.api.volumes'
+    command: bash
+    sync:
+      - ./api:/usr/src/app
+`
+	b, err := afero.ReadFile(fs, "/app/okteto.yml")
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(b))
+	assert.ElementsMatch(t, []string{
+		"'networks' is not supported by okteto deploy and will be ignored",
+		"'services.api.build.ssh' is not supported by the okteto manifest 'build' section",
+	}, report.Unconvertible)
+
+	original, err := afero.ReadFile(fs, "/app/docker-compose.yml")
+	require.NoError(t, err)
+	assert.Equal(t, compose, string(original))
+}
+
+func TestMigrateErrors(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/v2/okteto.yml", []byte("deploy:\n  - kubectl apply -f k8s\n"), 0600))
+	require.NoError(t, afero.WriteFile(fs, "/compose/docker-compose.yml", []byte("services:\n  api:\n    image: api\n"), 0600))
+	require.NoError(t, afero.WriteFile(fs, "/compose/okteto.yml", []byte("deploy:\n  - echo\n"), 0600))
+
+	m := &Migrator{Fs: fs}
+	_, err := m.Migrate(&MigrateOpts{File: "/v2/okteto.yml"})
+	assert.ErrorIs(t, err, errAlreadyV2)
+
+	_, err = m.Migrate(&MigrateOpts{File: "/compose/docker-compose.yml"})
+	assert.Error(t, err)
+
+	report, err := m.Migrate(&MigrateOpts{File: "/compose/docker-compose.yml", DryRun: true})
+	require.NoError(t, err)
+	assert.Contains(t, string(report.Content), "compose: docker-compose.yml")
+}
@@ -0,0 +1,154 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/config"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/metrics"
+	"github.com/spf13/cobra"
+)
+
+// Metrics manages the local metrics of the execution of okteto commands
+func Metrics() *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#metrics"),
+		Use:   "metrics",
+		Short: "Manage the local metrics of the okteto commands",
+		Long: `Manage the local metrics of the okteto commands.
+
+When enabled, okteto records histograms of the activation time and the initial file synchronization time of 'okteto up', and the build time of the images, grouped by repository.
+The metrics are saved in your machine and are never sent anywhere. Run 'okteto metrics report' to summarize them.`,
+	}
+	cmd.AddCommand(metricsEnable())
+	cmd.AddCommand(metricsDisable())
+	cmd.AddCommand(metricsReport())
+	cmd.AddCommand(metricsReset())
+	return cmd
+}
+
+func metricsEnable() *cobra.Command {
+	return &cobra.Command{
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#metrics"),
+		Use:   "enable",
+		Short: "Start recording local metrics",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := metrics.Enable(); err != nil {
+				return err
+			}
+			oktetoLog.Success("Local metrics are recorded at %s", config.GetLocalMetricsPath())
+			return nil
+		},
+	}
+}
+
+func metricsDisable() *cobra.Command {
+	return &cobra.Command{
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#metrics"),
+		Use:   "disable",
+		Short: "Stop recording local metrics",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := metrics.Disable(); err != nil {
+				return err
+			}
+			oktetoLog.Success("Local metrics have been disabled")
+			return nil
+		},
+	}
+}
+
+func metricsReset() *cobra.Command {
+	return &cobra.Command{
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#metrics"),
+		Use:   "reset",
+		Short: "Delete the local metrics recorded",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := metrics.Reset(); err != nil {
+				return err
+			}
+			oktetoLog.Success("Local metrics have been deleted")
+			return nil
+		},
+	}
+}
+
+func metricsReport() *cobra.Command {
+	var repository string
+	var output string
+	cmd := &cobra.Command{
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#metrics"),
+		Use:   "report",
+		Short: "Summarize the local metrics recorded",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "" && output != "json" {
+				return fmt.Errorf("output format '%s' is not supported. One of: ['json']", output)
+			}
+			f, err := metrics.Read()
+			if err != nil {
+				return err
+			}
+			summaries := f.Summarize(repository)
+			if output == "json" {
+				b, err := json.MarshalIndent(summaries, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal the local metrics: %w", err)
+				}
+				oktetoLog.Println(string(b))
+				return nil
+			}
+
+			if len(summaries) == 0 {
+				if !metrics.IsEnabled() {
+					oktetoLog.Information("Local metrics are disabled. Run 'okteto metrics enable' to start recording them")
+					return nil
+				}
+				oktetoLog.Information("No local metrics have been recorded yet")
+				return nil
+			}
+			printMetricsReport(os.Stdout, summaries)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&repository, "repository", "r", "", "show only the metrics of this repository")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "output format. One of: ['json']")
+	return cmd
+}
+
+func printMetricsReport(out io.Writer, summaries []metrics.Summary) {
+	w := tabwriter.NewWriter(out, 1, 1, 2, ' ', 0)
+	fmt.Fprintf(w, "Repository\tMetric\tCount\tMean\tP50\tP90\tP99\tMax\tLast\n")
+	for _, s := range summaries {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			s.Repository, s.Metric, s.Count,
+			formatSeconds(s.Mean), formatSeconds(s.P50), formatSeconds(s.P90), formatSeconds(s.P99), formatSeconds(s.Max),
+			s.LastObserved.Local().Format("2006-01-02 15:04"))
+	}
+	w.Flush()
+}
+
+func formatSeconds(seconds float64) string {
+	d := time.Duration(seconds * float64(time.Second))
+	if d < time.Minute {
+		return d.Round(100 * time.Millisecond).String()
+	}
+	return d.Round(time.Second).String()
+}
@@ -17,7 +17,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/signal"
 	"sync"
 	"time"
 
@@ -28,6 +27,7 @@ import (
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/signals"
 	"github.com/spf13/cobra"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -35,15 +35,35 @@ import (
 
 // Delete deletes a namespace
 func Delete(ctx context.Context) *cobra.Command {
+	var expired bool
 	cmd := &cobra.Command{
-		Use:   "delete <name>",
-		Short: "Delete a namespace",
-		Args:  utils.MaximumNArgsAccepted(1, ""),
+		Use:               "delete <name>",
+		Short:             "Delete a namespace",
+		Args:              utils.MaximumNArgsAccepted(1, ""),
+		ValidArgsFunction: utils.CompleteNamespaces,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if expired && len(args) > 0 {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("the namespace name can't be used with the flag '--expired'"),
+					Hint: "Run 'okteto namespace delete --expired' to delete all the namespaces whose time to live has expired",
+				}
+			}
+
 			if err := contextCMD.NewContextCommand().Run(ctx, &contextCMD.ContextOptions{}); err != nil {
 				return err
 			}
 
+			if expired {
+				if !okteto.IsOkteto() {
+					return oktetoErrors.ErrContextIsNotOktetoCluster
+				}
+				nsCmd, err := NewCommand()
+				if err != nil {
+					return err
+				}
+				return nsCmd.ExecuteDeleteExpiredNamespaces(ctx)
+			}
+
 			nsToDelete := okteto.Context().Namespace
 			if len(args) > 0 {
 				nsToDelete = args[0]
@@ -62,9 +82,38 @@ func Delete(ctx context.Context) *cobra.Command {
 			return err
 		},
 	}
+	cmd.Flags().BoolVar(&expired, "expired", false, "delete the namespaces whose time to live has expired")
 	return cmd
 }
 
+// ExecuteDeleteExpiredNamespaces deletes the namespaces whose time to live has expired.
+// It's meant to be run periodically, for example from a scheduled pipeline
+func (nc *NamespaceCommand) ExecuteDeleteExpiredNamespaces(ctx context.Context) error {
+	spaces, err := nc.okClient.Namespaces().List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get namespaces: %s", err)
+	}
+
+	expirations := nc.getExpirations(ctx, spaces)
+	now := time.Now()
+	deleted := 0
+	for _, space := range spaces {
+		expiresAt, ok := expirations[space.ID]
+		if !ok || expiresAt.After(now) {
+			continue
+		}
+		if err := nc.ExecuteDeleteNamespace(ctx, space.ID); err != nil {
+			return err
+		}
+		analytics.TrackDeleteNamespace(true)
+		deleted++
+	}
+	if deleted == 0 {
+		oktetoLog.Information("There are no expired namespaces")
+	}
+	return nil
+}
+
 func (nc *NamespaceCommand) ExecuteDeleteNamespace(ctx context.Context, namespace string) error {
 	oktetoLog.Spinner(fmt.Sprintf("Deleting %s namespace", namespace))
 	oktetoLog.StartSpinner()
@@ -103,7 +152,7 @@ func (nc *NamespaceCommand) watchDelete(ctx context.Context, namespace string) e
 	stop := make(chan os.Signal, 1)
 	defer close(stop)
 
-	signal.Notify(stop, os.Interrupt)
+	signals.Notify(stop)
 	exit := make(chan error, 1)
 	defer close(exit)
 
@@ -11,3 +11,5 @@ var errDeleteNamespaceTimeout = errors.New("namespace delete didn't finish")
 var errFailedSleepNamespace = errors.New("failed to sleep namespace")
 
 var errFailedWakeNamespace = errors.New("failed to wake namespace")
+
+var errFailedSetTTLNamespace = errors.New("failed to set the time to live of the namespace")
@@ -18,12 +18,17 @@ import (
 	"fmt"
 	"os"
 	"text/tabwriter"
+	"time"
 
 	contextCMD "github.com/okteto/okteto/cmd/context"
 	"github.com/okteto/okteto/cmd/utils"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/namespaces"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // List all namespace in current context
@@ -58,15 +63,43 @@ func (nc *NamespaceCommand) executeListNamespaces(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to get namespaces: %s", err)
 	}
+	expirations := nc.getExpirations(ctx, spaces)
+	now := time.Now()
 	w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
-	fmt.Fprintf(w, "Namespace\tStatus\n")
+	fmt.Fprintf(w, "Namespace\tStatus\tExpires\n")
 	for _, space := range spaces {
+		expires := "-"
+		if expiresAt, ok := expirations[space.ID]; ok {
+			expires = formatExpiration(expiresAt, now)
+		}
 		if space.ID == okteto.Context().Namespace {
 			space.ID += " *"
 		}
-		fmt.Fprintf(w, "%s\t%v\n", space.ID, space.Status)
+		fmt.Fprintf(w, "%s\t%v\t%s\n", space.ID, space.Status, expires)
 	}
 
 	w.Flush()
 	return nil
 }
+
+// getExpirations returns when the namespaces with a time to live expire.
+// The expiration of a namespace is not shown if it can't be retrieved from the cluster
+func (nc *NamespaceCommand) getExpirations(ctx context.Context, spaces []types.Namespace) map[string]time.Time {
+	result := map[string]time.Time{}
+	c, _, err := nc.k8sClientProvider.Provide(okteto.Context().Cfg)
+	if err != nil {
+		oktetoLog.Infof("failed to get the expiration of the namespaces: %s", err)
+		return result
+	}
+	for _, space := range spaces {
+		ns, err := c.CoreV1().Namespaces().Get(ctx, space.ID, metav1.GetOptions{})
+		if err != nil {
+			oktetoLog.Infof("failed to get the expiration of namespace '%s': %s", space.ID, err)
+			continue
+		}
+		if expiresAt, ok := namespaces.GetExpiration(ns); ok {
+			result[space.ID] = expiresAt
+		}
+	}
+	return result
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 func Test_listNamespace(t *testing.T) {
@@ -70,8 +71,9 @@ func Test_listNamespace(t *testing.T) {
 				Users:     client.NewFakeUsersClient(usr),
 			}
 			nsCmd := &NamespaceCommand{
-				okClient: fakeOktetoClient,
-				ctxCmd:   newFakeContextCommand(fakeOktetoClient, usr),
+				okClient:          fakeOktetoClient,
+				ctxCmd:            newFakeContextCommand(fakeOktetoClient, usr),
+				k8sClientProvider: &fakeK8sProvider{k8sClient: fake.NewSimpleClientset()},
 			}
 			err := nsCmd.executeListNamespaces(ctx)
 			if tt.err != nil {
@@ -62,5 +62,7 @@ func Namespace(ctx context.Context) *cobra.Command {
 	cmd.AddCommand(Delete(ctx))
 	cmd.AddCommand(Sleep(ctx))
 	cmd.AddCommand(Wake(ctx))
+	cmd.AddCommand(SetTTL(ctx))
+	cmd.AddCommand(Top(ctx))
 	return cmd
 }
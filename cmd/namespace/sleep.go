@@ -27,9 +27,10 @@ import (
 // Sleep sleeps a namespace
 func Sleep(ctx context.Context) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "sleep <name>",
-		Short: "Sleeps a namespace",
-		Args:  utils.MaximumNArgsAccepted(1, ""),
+		Use:               "sleep <name>",
+		Short:             "Sleeps a namespace",
+		Args:              utils.MaximumNArgsAccepted(1, ""),
+		ValidArgsFunction: utils.CompleteNamespaces,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := contextCMD.NewContextCommand().Run(ctx, &contextCMD.ContextOptions{}); err != nil {
 				return err
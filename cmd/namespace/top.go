@@ -0,0 +1,235 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/k8s/pods"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/cobra"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// TopOptions are the options of the namespace top command
+type TopOptions struct {
+	Output string
+}
+
+// getPodMetricsFn returns the cpu and memory usage of the pods of a namespace
+type getPodMetricsFn func(ctx context.Context, namespace string, c kubernetes.Interface) (map[string]apiv1.ResourceList, error)
+
+// namespaceTop is the resource usage of a namespace
+type namespaceTop struct {
+	Namespace        string          `json:"namespace"`
+	MetricsAvailable bool            `json:"metricsAvailable"`
+	Workloads        []workloadTop   `json:"workloads"`
+	Quotas           []quotaResource `json:"quotas"`
+}
+
+// workloadTop is the sum of the requests and the usage of the running pods of a workload
+type workloadTop struct {
+	Name                 string `json:"name"`
+	Kind                 string `json:"kind"`
+	Pods                 int    `json:"pods"`
+	CPURequestMillicores int64  `json:"cpuRequestMillicores"`
+	CPUUsageMillicores   int64  `json:"cpuUsageMillicores"`
+	MemoryRequestBytes   int64  `json:"memoryRequestBytes"`
+	MemoryUsageBytes     int64  `json:"memoryUsageBytes"`
+}
+
+// quotaResource is a resource limited by a resource quota of the namespace
+type quotaResource struct {
+	Quota    string `json:"quota"`
+	Resource string `json:"resource"`
+	Used     string `json:"used"`
+	Hard     string `json:"hard"`
+}
+
+// Top shows the resource requests and usage of the workloads of a namespace
+func Top(ctx context.Context) *cobra.Command {
+	options := &TopOptions{}
+	cmd := &cobra.Command{
+		Use:               "top [name]",
+		Short:             "Show the CPU and memory requests and usage of the workloads of a namespace",
+		Args:              utils.MaximumNArgsAccepted(1, ""),
+		ValidArgsFunction: utils.CompleteNamespaces,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.Output != "" && options.Output != "json" {
+				return fmt.Errorf("invalid output format '%s': only 'json' is supported", options.Output)
+			}
+			ctxOptions := &contextCMD.ContextOptions{Show: options.Output == ""}
+			if len(args) > 0 {
+				ctxOptions.Namespace = args[0]
+			}
+			if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+				return err
+			}
+
+			c, _, err := okteto.NewK8sClientProvider().Provide(okteto.Context().Cfg)
+			if err != nil {
+				return err
+			}
+			return executeTop(ctx, okteto.Context().Namespace, options.Output, c, pods.GetMetrics, os.Stdout)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Output, "output", "o", "", "output format. One of: ['json']")
+	return cmd
+}
+
+func executeTop(ctx context.Context, namespace, output string, c kubernetes.Interface, getPodMetrics getPodMetricsFn, w io.Writer) error {
+	top, err := getNamespaceTop(ctx, namespace, c, getPodMetrics)
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		b, err := json.MarshalIndent(top, "", " ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(b))
+		return nil
+	}
+
+	if !top.MetricsAvailable {
+		oktetoLog.Warning("The usage of the pods is not available, metrics-server is not installed in your cluster")
+	}
+	tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, "Workload\tPods\tCPU requests\tCPU usage\tMemory requests\tMemory usage")
+	for _, wl := range top.Workloads {
+		cpuUsage, memoryUsage := "-", "-"
+		if top.MetricsAvailable {
+			cpuUsage = formatCPU(wl.CPUUsageMillicores)
+			memoryUsage = formatMemory(wl.MemoryUsageBytes)
+		}
+		fmt.Fprintf(tw, "%s/%s\t%d\t%s\t%s\t%s\t%s\n", strings.ToLower(wl.Kind), wl.Name, wl.Pods, formatCPU(wl.CPURequestMillicores), cpuUsage, formatMemory(wl.MemoryRequestBytes), memoryUsage)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(top.Quotas) == 0 {
+		return nil
+	}
+	fmt.Fprintln(w)
+	tw = tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, "Quota\tResource\tUsed\tHard")
+	for _, q := range top.Quotas {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", q.Quota, q.Resource, q.Used, q.Hard)
+	}
+	return tw.Flush()
+}
+
+// getNamespaceTop returns the requests and the usage of the running pods of a namespace grouped by workload, and its resource quotas
+func getNamespaceTop(ctx context.Context, namespace string, c kubernetes.Interface, getPodMetrics getPodMetricsFn) (*namespaceTop, error) {
+	podList, err := c.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the pods of namespace '%s': %w", namespace, err)
+	}
+
+	top := &namespaceTop{Namespace: namespace, MetricsAvailable: true, Workloads: []workloadTop{}, Quotas: []quotaResource{}}
+	metrics, err := getPodMetrics(ctx, namespace, c)
+	if err != nil {
+		oktetoLog.Infof("metrics not available: %s", err)
+		top.MetricsAvailable = false
+	}
+
+	workloads := map[string]*workloadTop{}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Status.Phase != apiv1.PodRunning && pod.Status.Phase != apiv1.PodPending {
+			continue
+		}
+		kind, name := getPodWorkload(pod)
+		key := kind + "/" + name
+		wl, ok := workloads[key]
+		if !ok {
+			wl = &workloadTop{Name: name, Kind: kind}
+			workloads[key] = wl
+		}
+		wl.Pods++
+		for _, container := range pod.Spec.Containers {
+			wl.CPURequestMillicores += container.Resources.Requests.Cpu().MilliValue()
+			wl.MemoryRequestBytes += container.Resources.Requests.Memory().Value()
+		}
+		if usage, ok := metrics[pod.Name]; ok {
+			wl.CPUUsageMillicores += usage.Cpu().MilliValue()
+			wl.MemoryUsageBytes += usage.Memory().Value()
+		}
+	}
+	for _, wl := range workloads {
+		top.Workloads = append(top.Workloads, *wl)
+	}
+	sort.Slice(top.Workloads, func(i, j int) bool {
+		if top.Workloads[i].Kind != top.Workloads[j].Kind {
+			return top.Workloads[i].Kind < top.Workloads[j].Kind
+		}
+		return top.Workloads[i].Name < top.Workloads[j].Name
+	})
+
+	quotas, err := c.CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		oktetoLog.Infof("failed to list the resource quotas of namespace '%s': %s", namespace, err)
+		return top, nil
+	}
+	for _, q := range quotas.Items {
+		resources := []string{}
+		for name := range q.Status.Hard {
+			resources = append(resources, string(name))
+		}
+		sort.Strings(resources)
+		for _, name := range resources {
+			hard := q.Status.Hard[apiv1.ResourceName(name)]
+			used := q.Status.Used[apiv1.ResourceName(name)]
+			top.Quotas = append(top.Quotas, quotaResource{Quota: q.Name, Resource: name, Used: used.String(), Hard: hard.String()})
+		}
+	}
+	return top, nil
+}
+
+// getPodWorkload returns the kind and the name of the workload that created a pod
+func getPodWorkload(pod *apiv1.Pod) (string, string) {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return "Pod", pod.Name
+	}
+	if owner.Kind == "ReplicaSet" {
+		if hash := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; hash != "" && strings.HasSuffix(owner.Name, "-"+hash) {
+			return "Deployment", strings.TrimSuffix(owner.Name, "-"+hash)
+		}
+	}
+	return owner.Kind, owner.Name
+}
+
+func formatCPU(millicores int64) string {
+	return fmt.Sprintf("%dm", millicores)
+}
+
+func formatMemory(bytes int64) string {
+	return fmt.Sprintf("%dMi", bytes/(1024*1024))
+}
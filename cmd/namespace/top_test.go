@@ -0,0 +1,137 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTopPod(name string, owner *metav1.OwnerReference, labels map[string]string, cpu, memory string) *apiv1.Pod {
+	pod := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test", Labels: labels},
+		Spec: apiv1.PodSpec{
+			Containers: []apiv1.Container{
+				{
+					Name: "app",
+					Resources: apiv1.ResourceRequirements{
+						Requests: apiv1.ResourceList{
+							apiv1.ResourceCPU:    resource.MustParse(cpu),
+							apiv1.ResourceMemory: resource.MustParse(memory),
+						},
+					},
+				},
+			},
+		},
+		Status: apiv1.PodStatus{Phase: apiv1.PodRunning},
+	}
+	if owner != nil {
+		controller := true
+		owner.Controller = &controller
+		pod.OwnerReferences = []metav1.OwnerReference{*owner}
+	}
+	return pod
+}
+
+func newTopClient() kubernetes.Interface {
+	return fake.NewSimpleClientset(
+		newTopPod("api-5d8f9-a", &metav1.OwnerReference{Kind: "ReplicaSet", Name: "api-5d8f9"}, map[string]string{"pod-template-hash": "5d8f9"}, "100m", "128Mi"),
+		newTopPod("api-5d8f9-b", &metav1.OwnerReference{Kind: "ReplicaSet", Name: "api-5d8f9"}, map[string]string{"pod-template-hash": "5d8f9"}, "100m", "128Mi"),
+		newTopPod("db-0", &metav1.OwnerReference{Kind: "StatefulSet", Name: "db"}, nil, "500m", "1Gi"),
+		newTopPod("debug", nil, nil, "10m", "16Mi"),
+		&apiv1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: "test"},
+			Status: apiv1.ResourceQuotaStatus{
+				Hard: apiv1.ResourceList{apiv1.ResourceRequestsCPU: resource.MustParse("1")},
+				Used: apiv1.ResourceList{apiv1.ResourceRequestsCPU: resource.MustParse("710m")},
+			},
+		},
+	)
+}
+
+func fakePodMetrics(usage map[string]apiv1.ResourceList, err error) getPodMetricsFn {
+	return func(context.Context, string, kubernetes.Interface) (map[string]apiv1.ResourceList, error) {
+		return usage, err
+	}
+}
+
+func Test_getNamespaceTop(t *testing.T) {
+	usage := map[string]apiv1.ResourceList{
+		"api-5d8f9-a": {apiv1.ResourceCPU: resource.MustParse("50m"), apiv1.ResourceMemory: resource.MustParse("100Mi")},
+		"api-5d8f9-b": {apiv1.ResourceCPU: resource.MustParse("70m"), apiv1.ResourceMemory: resource.MustParse("60Mi")},
+		"db-0":        {apiv1.ResourceCPU: resource.MustParse("900m"), apiv1.ResourceMemory: resource.MustParse("512Mi")},
+	}
+	top, err := getNamespaceTop(context.Background(), "test", newTopClient(), fakePodMetrics(usage, nil))
+	require.NoError(t, err)
+
+	assert.True(t, top.MetricsAvailable)
+	assert.Equal(t, []workloadTop{
+		{Name: "api", Kind: "Deployment", Pods: 2, CPURequestMillicores: 200, CPUUsageMillicores: 120, MemoryRequestBytes: 256 * 1024 * 1024, MemoryUsageBytes: 160 * 1024 * 1024},
+		{Name: "debug", Kind: "Pod", Pods: 1, CPURequestMillicores: 10, MemoryRequestBytes: 16 * 1024 * 1024},
+		{Name: "db", Kind: "StatefulSet", Pods: 1, CPURequestMillicores: 500, CPUUsageMillicores: 900, MemoryRequestBytes: 1024 * 1024 * 1024, MemoryUsageBytes: 512 * 1024 * 1024},
+	}, top.Workloads)
+	assert.Equal(t, []quotaResource{{Quota: "quota", Resource: "requests.cpu", Used: "710m", Hard: "1"}}, top.Quotas)
+}
+
+func Test_executeTop(t *testing.T) {
+	var tests = []struct {
+		name     string
+		output   string
+		err      error
+		expected []string
+	}{
+		{
+			name:     "table",
+			expected: []string{"deployment/api", "200m", "120m", "256Mi", "requests.cpu", "710m"},
+		},
+		{
+			name:     "table without metrics",
+			err:      fmt.Errorf("the server could not find the requested resource"),
+			expected: []string{"statefulset/db", "500m", "-"},
+		},
+	}
+	usage := map[string]apiv1.ResourceList{
+		"api-5d8f9-a": {apiv1.ResourceCPU: resource.MustParse("120m"), apiv1.ResourceMemory: resource.MustParse("100Mi")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			require.NoError(t, executeTop(context.Background(), "test", tt.output, newTopClient(), fakePodMetrics(usage, tt.err), &b))
+			for _, e := range tt.expected {
+				assert.Contains(t, b.String(), e)
+			}
+		})
+	}
+}
+
+func Test_executeTopJSON(t *testing.T) {
+	var b bytes.Buffer
+	require.NoError(t, executeTop(context.Background(), "test", "json", newTopClient(), fakePodMetrics(nil, fmt.Errorf("not found")), &b))
+	top := &namespaceTop{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), top))
+	assert.Equal(t, "test", top.Namespace)
+	assert.False(t, top.MetricsAvailable)
+	assert.Len(t, top.Workloads, 3)
+}
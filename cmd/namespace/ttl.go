@@ -0,0 +1,113 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/namespaces"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/cobra"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// SetTTL sets the time to live of a namespace
+func SetTTL(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-ttl <name> <ttl>",
+		Short: "Set the time to live of a namespace",
+		Long: `Set the time to live of a namespace.
+
+The time to live counts from now and is stored as an annotation of the namespace.
+Okteto doesn't destroy expired namespaces by itself: they are deleted when 'okteto namespace delete --expired' runs,
+for example from a scheduled pipeline. Use a time to live of 0 to keep the namespace until it's deleted.
+
+Setting the time to live updates the Kubernetes namespace, so it requires permissions to patch namespaces.`,
+		Example:           "okteto namespace set-ttl my-namespace 72h",
+		Args:              utils.ExactArgsAccepted(2, ""),
+		ValidArgsFunction: utils.CompleteNamespaces,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ttl, err := parseTTL(args[1])
+			if err != nil {
+				return err
+			}
+
+			if err := contextCMD.NewContextCommand().Run(ctx, &contextCMD.ContextOptions{}); err != nil {
+				return err
+			}
+
+			if !okteto.IsOkteto() {
+				return oktetoErrors.ErrContextIsNotOktetoCluster
+			}
+
+			nsCmd, err := NewCommand()
+			if err != nil {
+				return err
+			}
+			return nsCmd.ExecuteSetTTL(ctx, args[0], ttl)
+		},
+	}
+	return cmd
+}
+
+// ExecuteSetTTL sets the time to live of a namespace
+func (nc *NamespaceCommand) ExecuteSetTTL(ctx context.Context, namespace string, ttl time.Duration) error {
+	c, _, err := nc.k8sClientProvider.Provide(okteto.Context().Cfg)
+	if err != nil {
+		return err
+	}
+	if err := namespaces.SetTTL(ctx, namespace, ttl, c); err != nil {
+		if k8sErrors.IsForbidden(err) {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("%w: you don't have permissions to update the namespace '%s'", errFailedSetTTLNamespace, namespace),
+				Hint: "Ask an administrator of your Okteto instance to set the time to live of the namespace",
+			}
+		}
+		return fmt.Errorf("%w: %v", errFailedSetTTLNamespace, err)
+	}
+
+	if ttl == 0 {
+		oktetoLog.Success("Namespace '%s' doesn't expire", namespace)
+		return nil
+	}
+	oktetoLog.Success("Namespace '%s' expires in %s", namespace, duration.HumanDuration(ttl))
+	oktetoLog.Information("Expired namespaces are deleted when 'okteto namespace delete --expired' runs")
+	return nil
+}
+
+func parseTTL(value string) (time.Duration, error) {
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl < 0 {
+		return 0, oktetoErrors.UserError{
+			E:    fmt.Errorf("invalid time to live '%s'", value),
+			Hint: "Use a positive duration like '72h' or '30m', or '0' to remove the time to live",
+		}
+	}
+	return ttl, nil
+}
+
+// formatExpiration returns how long until the namespace expires
+func formatExpiration(expiresAt, now time.Time) string {
+	if !expiresAt.After(now) {
+		return "expired"
+	}
+	return fmt.Sprintf("in %s", duration.HumanDuration(expiresAt.Sub(now)))
+}
@@ -0,0 +1,148 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/internal/test/client"
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+func setTTLTestContext() {
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"test-context": {
+				Name:      "test-context",
+				Token:     "test-token",
+				IsOkteto:  true,
+				Namespace: "current",
+				UserID:    "1",
+			},
+		},
+		CurrentContext: "test-context",
+	}
+}
+
+func Test_parseTTL(t *testing.T) {
+	ttl, err := parseTTL("72h")
+	require.NoError(t, err)
+	assert.Equal(t, 72*time.Hour, ttl)
+
+	ttl, err = parseTTL("0")
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), ttl)
+
+	_, err = parseTTL("-1h")
+	assert.Error(t, err)
+
+	_, err = parseTTL("3 days")
+	assert.Error(t, err)
+}
+
+func Test_formatExpiration(t *testing.T) {
+	now := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, "in 3d", formatExpiration(now.Add(72*time.Hour), now))
+	assert.Equal(t, "in 90m", formatExpiration(now.Add(90*time.Minute), now))
+	assert.Equal(t, "expired", formatExpiration(now, now))
+	assert.Equal(t, "expired", formatExpiration(now.Add(-time.Hour), now))
+}
+
+func Test_ExecuteSetTTL(t *testing.T) {
+	ctx := context.Background()
+	setTTLTestContext()
+	usr := &types.User{Token: "test-token"}
+	k8sClient := fake.NewSimpleClientset(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test"}})
+	nsCmd := NewFakeNamespaceCommand(&client.FakeOktetoClient{Users: client.NewFakeUsersClient(usr)}, k8sClient, usr)
+
+	require.NoError(t, nsCmd.ExecuteSetTTL(ctx, "test", 72*time.Hour))
+	ns, err := k8sClient.CoreV1().Namespaces().Get(ctx, "test", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "72h0m0s", ns.Annotations[constants.NamespaceTTLAnnotation])
+	assert.NotEmpty(t, ns.Annotations[constants.NamespaceExpiresAtAnnotation])
+
+	err = nsCmd.ExecuteSetTTL(ctx, "non-existing", time.Hour)
+	assert.ErrorIs(t, err, errFailedSetTTLNamespace)
+}
+
+func Test_ExecuteSetTTLForbidden(t *testing.T) {
+	ctx := context.Background()
+	setTTLTestContext()
+	usr := &types.User{Token: "test-token"}
+	k8sClient := fake.NewSimpleClientset(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test"}})
+	k8sClient.PrependReactor("patch", "namespaces", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		return true, nil, k8sErrors.NewForbidden(v1.Resource("namespaces"), "test", nil)
+	})
+	nsCmd := NewFakeNamespaceCommand(&client.FakeOktetoClient{Users: client.NewFakeUsersClient(usr)}, k8sClient, usr)
+
+	err := nsCmd.ExecuteSetTTL(ctx, "test", time.Hour)
+	assert.ErrorIs(t, err, errFailedSetTTLNamespace)
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+}
+
+func Test_ExecuteDeleteExpiredNamespaces(t *testing.T) {
+	ctx := context.Background()
+	setTTLTestContext()
+	usr := &types.User{Token: "test-token"}
+	okClient := &client.FakeOktetoClient{
+		Namespace:       client.NewFakeNamespaceClient([]types.Namespace{{ID: "current"}, {ID: "expired"}, {ID: "active"}}, nil),
+		Users:           client.NewFakeUsersClient(usr),
+		StreamClient:    client.NewFakeStreamClient(&client.FakeStreamResponse{}),
+		KubetokenClient: client.NewFakeKubetokenClient(client.FakeKubetokenResponse{}),
+	}
+	k8sClient := fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "current"}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:        "expired",
+			Annotations: map[string]string{constants.NamespaceExpiresAtAnnotation: time.Now().Add(-time.Hour).Format(time.RFC3339)},
+		}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:        "active",
+			Annotations: map[string]string{constants.NamespaceExpiresAtAnnotation: time.Now().Add(time.Hour).Format(time.RFC3339)},
+		}},
+	)
+	// namespaces deleted by the okteto client are not found in the cluster
+	k8sClient.PrependReactor("get", "namespaces", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		name := action.(k8sTesting.GetAction).GetName()
+		spaces, err := okClient.Namespaces().List(ctx)
+		if err != nil {
+			return true, nil, err
+		}
+		for _, space := range spaces {
+			if space.ID == name {
+				return false, nil, nil
+			}
+		}
+		return true, nil, k8sErrors.NewNotFound(v1.Resource("namespaces"), name)
+	})
+	nsCmd := NewFakeNamespaceCommand(okClient, k8sClient, usr)
+
+	require.NoError(t, nsCmd.ExecuteDeleteExpiredNamespaces(ctx))
+	spaces, err := okClient.Namespaces().List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Namespace{{ID: "current"}, {ID: "active"}}, spaces)
+}
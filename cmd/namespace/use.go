@@ -39,10 +39,11 @@ type UseOptions struct {
 func Use(ctx context.Context) *cobra.Command {
 	options := &UseOptions{}
 	cmd := &cobra.Command{
-		Use:     "use [namespace]",
-		Short:   "Configure the current namespace of the okteto context",
-		Aliases: []string{"ns"},
-		Args:    utils.MaximumNArgsAccepted(1, "https://okteto.com/docs/reference/cli/#use-1"),
+		Use:               "use [namespace]",
+		Short:             "Configure the current namespace of the okteto context",
+		Aliases:           []string{"ns"},
+		Args:              utils.MaximumNArgsAccepted(1, "https://okteto.com/docs/reference/cli/#use-1"),
+		ValidArgsFunction: utils.CompleteNamespaces,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			namespace := ""
 			if len(args) > 0 {
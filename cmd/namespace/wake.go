@@ -26,9 +26,10 @@ import (
 
 func Wake(ctx context.Context) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "wake <name>",
-		Short: "Wakes a namespace",
-		Args:  utils.MaximumNArgsAccepted(1, ""),
+		Use:               "wake <name>",
+		Short:             "Wakes a namespace",
+		Args:              utils.MaximumNArgsAccepted(1, ""),
+		ValidArgsFunction: utils.CompleteNamespaces,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			nsToWake := okteto.Context().Namespace
 			if len(args) > 0 {
@@ -0,0 +1,115 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/format"
+	"github.com/okteto/okteto/pkg/k8s/ingresses"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/repository"
+	"k8s.io/client-go/kubernetes"
+)
+
+// newCommitStatusReporter returns a reporter for the repository being deployed.
+// Commit statuses are only reported when OKTETO_GIT_STATUS_TOKEN is set and the commit sha is known
+func newCommitStatusReporter(opts *DeployOptions) pipeline.CommitStatusReporter {
+	token := os.Getenv(constants.OktetoGitStatusTokenEnvVar)
+	if token == "" {
+		return nil
+	}
+
+	sha := os.Getenv(constants.OktetoGitCommitEnvVar)
+	if sha == "" {
+		sha = getLocalCommitSHA(opts.Repository)
+	}
+	if sha == "" {
+		oktetoLog.Info("skipping commit status: could not infer the commit sha of the repository")
+		return nil
+	}
+	return pipeline.NewCommitStatusReporter(opts.Repository, sha, token)
+}
+
+// getCommitStatusReporter returns the reporter of the commit statuses of the deploy. The final state is only known when
+// the command waits for the deploy, so no status is reported without '--wait' instead of leaving the commit pending forever
+func (pc *Command) getCommitStatusReporter(opts *DeployOptions) pipeline.CommitStatusReporter {
+	if !opts.Wait {
+		if os.Getenv(constants.OktetoGitStatusTokenEnvVar) != "" {
+			oktetoLog.Information("Commit statuses are only reported when the deploy runs with '--wait'")
+		}
+		return nil
+	}
+	if pc.newCommitStatusReporter != nil {
+		return pc.newCommitStatusReporter(opts)
+	}
+	return newCommitStatusReporter(opts)
+}
+
+// getLocalCommitSHA returns the sha of the cwd repository if it matches the repository being deployed
+func getLocalCommitSHA(repoURL string) string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	currentRepoURL, err := model.GetRepositoryURL(cwd)
+	if err != nil {
+		return ""
+	}
+	if !repository.NewRepository(currentRepoURL).IsEqual(repository.NewRepository(repoURL)) {
+		return ""
+	}
+	sha, err := repository.NewRepository(cwd).GetSHA()
+	if err != nil {
+		oktetoLog.Infof("could not get the commit sha: %s", err)
+		return ""
+	}
+	return sha
+}
+
+// reportCommitStatus reports the status without failing the deploy if the git provider is not reachable
+func reportCommitStatus(ctx context.Context, reporter pipeline.CommitStatusReporter, status pipeline.CommitStatus) {
+	if reporter == nil {
+		return
+	}
+	if err := reporter.Report(ctx, status); err != nil {
+		oktetoLog.Warning("could not report the commit status to the git provider")
+		oktetoLog.Infof("could not report the commit status '%s': %s", status.State, err)
+	}
+}
+
+// getPipelineEndpoint returns the first endpoint of the pipeline to be used as target url of the commit status
+func getPipelineEndpoint(ctx context.Context, c kubernetes.Interface, name, namespace string) string {
+	iClient, err := ingresses.GetClient(c)
+	if err != nil {
+		oktetoLog.Infof("could not get ingress client: %s", err)
+		return ""
+	}
+	labelSelector := fmt.Sprintf("%s=%s", model.DeployedByLabel, format.ResourceK8sMetaString(name))
+	eps, err := iClient.GetEndpointsBySelector(ctx, namespace, labelSelector)
+	if err != nil {
+		oktetoLog.Infof("could not get endpoints for '%s': %s", name, err)
+		return ""
+	}
+	if len(eps) == 0 {
+		return okteto.Context().Name
+	}
+	return eps[0]
+}
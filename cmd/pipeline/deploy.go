@@ -20,7 +20,6 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"os/signal"
 	"strings"
 	"sync"
 	"time"
@@ -36,6 +35,7 @@ import (
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/repository"
+	"github.com/okteto/okteto/pkg/signals"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/cobra"
 	v1 "k8s.io/api/core/v1"
@@ -217,8 +217,18 @@ func (pc *Command) ExecuteDeployPipeline(ctx context.Context, opts *DeployOption
 		}
 	}
 
+	reporter := pc.getCommitStatusReporter(opts)
+	reportCommitStatus(ctx, reporter, pipeline.CommitStatus{
+		State:       pipeline.CommitStatePending,
+		Description: fmt.Sprintf("Deploying '%s' in namespace '%s'", opts.Name, opts.Namespace),
+	})
+
 	resp, err := pc.deployPipeline(ctx, opts)
 	if err != nil {
+		reportCommitStatus(ctx, reporter, pipeline.CommitStatus{
+			State:       pipeline.CommitStateFailure,
+			Description: fmt.Sprintf("Failed to deploy '%s'", opts.Name),
+		})
 		return fmt.Errorf("failed to deploy pipeline '%s': %w", opts.Name, err)
 	}
 
@@ -232,9 +242,21 @@ func (pc *Command) ExecuteDeployPipeline(ctx context.Context, opts *DeployOption
 	defer oktetoLog.StopSpinner()
 
 	if err := pc.waitUntilRunning(ctx, opts.Name, opts.Namespace, resp.Action, opts.Timeout); err != nil {
+		reportCommitStatus(ctx, reporter, pipeline.CommitStatus{
+			State:       pipeline.CommitStateFailure,
+			Description: fmt.Sprintf("'%s' deployed with errors", opts.Name),
+		})
 		return fmt.Errorf("wait for pipeline '%s' to finish failed: %w", opts.Name, err)
 	}
 
+	if reporter != nil {
+		reportCommitStatus(ctx, reporter, pipeline.CommitStatus{
+			State:       pipeline.CommitStateSuccess,
+			Description: fmt.Sprintf("'%s' successfully deployed", opts.Name),
+			TargetURL:   getPipelineEndpoint(ctx, c, opts.Name, opts.Namespace),
+		})
+	}
+
 	cmap, err := configmaps.Get(ctx, cfgName, opts.Namespace, c)
 	if err != nil {
 		return err
@@ -252,25 +274,15 @@ type envSetter func(name, value string) error
 
 // setEnvsFromDependency sets the environment variables found at configmap.Data[dependencyEnvs]
 func setEnvsFromDependency(cmap *v1.ConfigMap, envSetter envSetter) error {
-	if cmap == nil || cmap.Data == nil {
-		return nil
+	envsToSet, err := pipeline.GetDependencyEnvs(cmap)
+	if err != nil {
+		return err
 	}
-
-	dependencyEnvsEncoded, ok := cmap.Data[constants.OktetoDependencyEnvsKey]
-	if !ok {
+	if len(envsToSet) == 0 {
 		return nil
 	}
 
 	name := cmap.Name
-
-	decodedEnvs, err := base64.StdEncoding.DecodeString(dependencyEnvsEncoded)
-	if err != nil {
-		return err
-	}
-	envsToSet := make(map[string]string)
-	if err = json.Unmarshal(decodedEnvs, &envsToSet); err != nil {
-		return err
-	}
 	for envKey, envValue := range envsToSet {
 		envName := fmt.Sprintf(dependencyEnvTemplate, strings.ToUpper(name), envKey)
 		if err := envSetter(envName, envValue); err != nil {
@@ -287,7 +299,7 @@ func (pc *Command) deployPipeline(ctx context.Context, opts *DeployOptions) (*ty
 	defer oktetoLog.StopSpinner()
 
 	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt)
+	signals.Notify(stop)
 	exit := make(chan error, 1)
 
 	var resp *types.GitDeployResponse
@@ -332,7 +344,7 @@ func (pc *Command) waitUntilRunning(ctx context.Context, name, namespace string,
 	defer ctxCancel()
 
 	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt)
+	signals.Notify(stop)
 	exit := make(chan error, 1)
 
 	var wg sync.WaitGroup
@@ -279,6 +279,82 @@ func TestDeployPipelineSuccesfulWithWait(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+type fakeCommitStatusReporter struct {
+	states []pipeline.CommitState
+}
+
+func (r *fakeCommitStatusReporter) Report(_ context.Context, status pipeline.CommitStatus) error {
+	r.states = append(r.states, status.State)
+	return nil
+}
+
+func TestDeployPipelineCommitStatus(t *testing.T) {
+	ctx := context.Background()
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		CurrentContext: "test",
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {},
+		},
+	}
+	response := &client.FakePipelineResponses{
+		DeployResponse: &types.GitDeployResponse{
+			Action: &types.Action{
+				ID:   "test",
+				Name: "test",
+			},
+		},
+		ResourcesMap: map[string]string{
+			"svc": okteto.CompletedStatus,
+		},
+	}
+	cmap := &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pipeline.TranslatePipelineName("test"),
+			Namespace: "test",
+			Labels:    map[string]string{},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		wait     bool
+		expected []pipeline.CommitState
+	}{
+		{
+			name: "without wait",
+		},
+		{
+			name:     "with wait",
+			wait:     true,
+			expected: []pipeline.CommitState{pipeline.CommitStatePending, pipeline.CommitStateSuccess},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reporter := &fakeCommitStatusReporter{}
+			pc := &Command{
+				okClient: &client.FakeOktetoClient{
+					PipelineClient: client.NewFakePipelineClient(response),
+					StreamClient:   client.NewFakeStreamClient(&client.FakeStreamResponse{}),
+				},
+				k8sClientProvider: test.NewFakeK8sProvider(cmap),
+				newCommitStatusReporter: func(*DeployOptions) pipeline.CommitStatusReporter {
+					return reporter
+				},
+			}
+			opts := &DeployOptions{
+				Repository: "test",
+				Name:       "test",
+				Namespace:  "test",
+				Wait:       tt.wait,
+				Timeout:    2 * time.Second,
+			}
+			require.NoError(t, pc.ExecuteDeployPipeline(ctx, opts))
+			assert.Equal(t, tt.expected, reporter.states)
+		})
+	}
+}
+
 func TestDeployWithError(t *testing.T) {
 	ctx := context.Background()
 	okteto.CurrentStore = &okteto.OktetoContextStore{
@@ -17,7 +17,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/signal"
 	"sync"
 	"time"
 
@@ -28,6 +27,7 @@ import (
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/signals"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/cobra"
 )
@@ -127,7 +127,7 @@ func (pc *Command) destroyPipeline(ctx context.Context, name, namespace string,
 	defer oktetoLog.StopSpinner()
 
 	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt)
+	signals.Notify(stop)
 	exit := make(chan error, 1)
 
 	var err error
@@ -163,7 +163,7 @@ func (pc *Command) waitUntilDestroyed(ctx context.Context, name, namespace strin
 	defer oktetoLog.StopSpinner()
 
 	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt)
+	signals.Notify(stop)
 	exit := make(chan error, 1)
 
 	var wg sync.WaitGroup
@@ -17,6 +17,7 @@ import (
 	"context"
 
 	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/cobra"
@@ -33,6 +34,9 @@ type PipelineInterface interface {
 type Command struct {
 	okClient          types.OktetoInterface
 	k8sClientProvider okteto.K8sClientProvider
+
+	// newCommitStatusReporter returns the reporter of the commit statuses, it defaults to newCommitStatusReporter
+	newCommitStatusReporter func(opts *DeployOptions) pipeline.CommitStatusReporter
 }
 
 // NewCommand creates a namespace command to
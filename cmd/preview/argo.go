@@ -0,0 +1,91 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preview
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/okteto/okteto/pkg/analytics"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/argocd"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/types"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/dynamic"
+)
+
+// executeDeployArgoPreview deploys a preview environment creating or updating the Argo CD application that syncs its branch
+func executeDeployArgoPreview(ctx context.Context, opts *DeployOptions, c dynamic.Interface) error {
+	err := deployArgoPreview(ctx, opts, c)
+	analytics.TrackPreviewDeploy(err == nil, opts.scope)
+	if err != nil {
+		return err
+	}
+
+	if !opts.wait {
+		oktetoLog.Success("Argo CD application '%s' of preview environment '%s' scheduled for sync", opts.name, opts.name)
+		return nil
+	}
+
+	oktetoLog.Spinner("Waiting for Argo CD to sync the preview environment...")
+	oktetoLog.StartSpinner()
+	defer oktetoLog.StopSpinner()
+	if err := argocd.WaitUntilSynced(ctx, opts.name, opts.argoNamespace, c, opts.timeout); err != nil {
+		return err
+	}
+	oktetoLog.Success("Preview environment '%s' successfully synced by Argo CD", opts.name)
+	return nil
+}
+
+func deployArgoPreview(ctx context.Context, opts *DeployOptions, c dynamic.Interface) error {
+	if len(opts.variables) > 0 || len(opts.labels) > 0 || opts.file != "" {
+		oktetoLog.Warning("The flags 'var', 'label' and 'file' are ignored by Argo CD preview environments")
+	}
+
+	oktetoLog.Spinner("Deploying your preview environment with Argo CD...")
+	oktetoLog.StartSpinner()
+	defer oktetoLog.StopSpinner()
+
+	app := argocd.Translate(argocd.ApplicationOptions{
+		Name:        opts.name,
+		Namespace:   opts.argoNamespace,
+		Project:     opts.argoProject,
+		Repository:  opts.repository,
+		Branch:      opts.branch,
+		Path:        opts.argoPath,
+		Destination: opts.name,
+	})
+	return argocd.Deploy(ctx, app, c)
+}
+
+// getArgoPreviewEndpoints returns the endpoints of a preview environment from the status of its Argo CD application
+func getArgoPreviewEndpoints(ctx context.Context, name, argoNamespace string, c dynamic.Interface) ([]types.Endpoint, *argocd.ApplicationStatus, error) {
+	status, err := argocd.GetStatus(ctx, name, argoNamespace, c)
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return nil, nil, oktetoErrors.UserError{
+				E:    fmt.Errorf("argo cd application '%s' not found in namespace '%s'", name, argoNamespace),
+				Hint: "Deploy the preview environment with 'okteto preview deploy --argo' or set the namespace of Argo CD with '--argo-namespace'",
+			}
+		}
+		return nil, nil, fmt.Errorf("failed to get argo cd application '%s': %w", name, err)
+	}
+
+	endpoints := make([]types.Endpoint, 0, len(status.URLs))
+	for _, url := range status.URLs {
+		endpoints = append(endpoints, types.Endpoint{URL: url})
+	}
+	return endpoints, status, nil
+}
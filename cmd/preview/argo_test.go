@@ -0,0 +1,72 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preview
+
+import (
+	"context"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/argocd"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+)
+
+var applicationResource = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "applications"}
+
+func newFakeArgoClient(objects ...runtime.Object) *dynamicFake.FakeDynamicClient {
+	return dynamicFake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{applicationResource: "ApplicationList"},
+		objects...,
+	)
+}
+
+func TestExecuteDeployArgoPreview(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeArgoClient()
+	opts := &DeployOptions{
+		name:          "pr-1",
+		scope:         "personal",
+		repository:    "https://github.com/okteto/movies",
+		branch:        "feature",
+		argoNamespace: argocd.DefaultNamespace,
+	}
+	require.NoError(t, executeDeployArgoPreview(ctx, opts, c))
+
+	status, err := argocd.GetStatus(ctx, "pr-1", argocd.DefaultNamespace, c)
+	require.NoError(t, err)
+	assert.Empty(t, status.Sync)
+}
+
+func TestGetArgoPreviewEndpoints(t *testing.T) {
+	ctx := context.Background()
+	app := argocd.Translate(argocd.ApplicationOptions{Name: "pr-1", Namespace: argocd.DefaultNamespace, Destination: "pr-1"})
+	require.NoError(t, unstructured.SetNestedStringSlice(app.Object, []string{"https://movies-pr-1.okteto.example.com"}, "status", "summary", "externalURLs"))
+	require.NoError(t, unstructured.SetNestedField(app.Object, argocd.SyncedStatus, "status", "sync", "status"))
+	c := newFakeArgoClient(app)
+
+	endpoints, status, err := getArgoPreviewEndpoints(ctx, "pr-1", argocd.DefaultNamespace, c)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Endpoint{{URL: "https://movies-pr-1.okteto.example.com"}}, endpoints)
+	assert.Equal(t, argocd.SyncedStatus, status.Sync)
+
+	_, _, err = getArgoPreviewEndpoints(ctx, "pr-2", argocd.DefaultNamespace, c)
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+}
@@ -18,7 +18,6 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"os/signal"
 	"strings"
 	"sync"
 	"time"
@@ -28,8 +27,10 @@ import (
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/argocd"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/signals"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/cobra"
 )
@@ -50,6 +51,10 @@ type DeployOptions struct {
 	variables          []string
 	wait               bool
 	labels             []string
+	argo               bool
+	argoNamespace      string
+	argoProject        string
+	argoPath           string
 }
 
 // Deploy Deploy a preview environment
@@ -74,6 +79,14 @@ func Deploy(ctx context.Context) *cobra.Command {
 			}
 			oktetoLog.Information("Using %s @ %s as context", opts.name, okteto.RemoveSchema(okteto.Context().Name))
 
+			if opts.argo {
+				c, _, err := okteto.GetDynamicClient()
+				if err != nil {
+					return err
+				}
+				return executeDeployArgoPreview(ctx, opts, c)
+			}
+
 			if !okteto.IsOkteto() {
 				return oktetoErrors.ErrContextIsNotOktetoCluster
 			}
@@ -94,6 +107,10 @@ func Deploy(ctx context.Context) *cobra.Command {
 	cmd.Flags().BoolVarP(&opts.wait, "wait", "w", false, "wait until the preview environment deployment finishes (defaults to false)")
 	cmd.Flags().StringVarP(&opts.file, "file", "f", "", "relative path within the repository to the okteto manifest (default to okteto.yaml or .okteto/okteto.yaml)")
 	cmd.Flags().StringArrayVarP(&opts.labels, "label", "", []string{}, "set a preview environment label (can be set more than once)")
+	cmd.Flags().BoolVarP(&opts.argo, "argo", "", false, "deploy the preview environment creating or updating an Argo CD application that syncs the branch")
+	cmd.Flags().StringVarP(&opts.argoNamespace, "argo-namespace", "", argocd.DefaultNamespace, "the namespace where Argo CD is installed")
+	cmd.Flags().StringVarP(&opts.argoProject, "argo-project", "", argocd.DefaultProject, "the Argo CD project of the application")
+	cmd.Flags().StringVarP(&opts.argoPath, "argo-path", "", ".", "relative path within the repository to the manifests synced by Argo CD")
 
 	cmd.Flags().StringVarP(&opts.deprecatedFilename, "filename", "", "", "relative path within the repository to the manifest file (default to okteto-pipeline.yaml or .okteto/okteto-pipeline.yaml)")
 	if err := cmd.Flags().MarkHidden("filename"); err != nil {
@@ -151,7 +168,7 @@ func (pw *Command) waitUntilRunning(ctx context.Context, name, namespace string,
 	defer oktetoLog.StopSpinner()
 
 	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt)
+	signals.Notify(stop)
 	exit := make(chan error, 1)
 
 	var wg sync.WaitGroup
@@ -83,7 +83,7 @@ func getRandomName(scope string) string {
 }
 
 func getExpandedName(name string) string {
-	expandedName, err := model.ExpandEnv(name, true)
+	expandedName, err := model.ExpandEnvValue(name, true)
 	if err != nil {
 		return name
 	}
@@ -17,7 +17,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/signal"
 	"sync"
 	"time"
 
@@ -29,6 +28,7 @@ import (
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/signals"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/cobra"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
@@ -57,9 +57,10 @@ type DestroyOptions struct {
 func Destroy(ctx context.Context) *cobra.Command {
 	opts := &DestroyOptions{}
 	cmd := &cobra.Command{
-		Use:   "destroy <name>",
-		Short: "Destroy a preview environment",
-		Args:  utils.ExactArgsAccepted(1, ""),
+		Use:               "destroy <name>",
+		Short:             "Destroy a preview environment",
+		Args:              utils.ExactArgsAccepted(1, ""),
+		ValidArgsFunction: utils.CompletePreviews,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.name = getExpandedName(args[0])
 
@@ -125,7 +126,7 @@ func (c destroyPreviewCommand) watchDestroy(ctx context.Context, preview string)
 	stop := make(chan os.Signal, 1)
 	defer close(stop)
 
-	signal.Notify(stop, os.Interrupt)
+	signals.Notify(stop)
 	exit := make(chan error, 1)
 	defer close(exit)
 
@@ -25,20 +25,26 @@ import (
 	contextCMD "github.com/okteto/okteto/cmd/context"
 	"github.com/okteto/okteto/cmd/utils"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/argocd"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/dynamic"
 )
 
 // Endpoints show all the endpoints of a preview environment
 func Endpoints(ctx context.Context) *cobra.Command {
 	var output string
+	var argo bool
+	var argoNamespace string
 
 	cmd := &cobra.Command{
-		Use:   "endpoints <name>",
-		Short: "Show endpoints for a preview environment",
-		Args:  utils.ExactArgsAccepted(1, ""),
+		Use:               "endpoints <name>",
+		Short:             "Show endpoints for a preview environment",
+		Args:              utils.ExactArgsAccepted(1, ""),
+		ValidArgsFunction: utils.CompletePreviews,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
 			previewName := args[0]
@@ -63,18 +69,28 @@ func Endpoints(ctx context.Context) *cobra.Command {
 				oktetoLog.SetOutput(os.Stdout)
 			}
 
-			if !okteto.IsOkteto() {
-				return oktetoErrors.ErrContextIsNotOktetoCluster
-			}
-
 			if err := validateOutput(output); err != nil {
 				return err
 			}
+
+			if argo {
+				c, _, err := okteto.GetDynamicClient()
+				if err != nil {
+					return err
+				}
+				return executeListArgoPreviewEndpoints(ctx, previewName, argoNamespace, output, c)
+			}
+
+			if !okteto.IsOkteto() {
+				return oktetoErrors.ErrContextIsNotOktetoCluster
+			}
 			err := executeListPreviewEndpoints(ctx, previewName, output)
 			return err
 		},
 	}
 	cmd.Flags().StringVarP(&output, "output", "o", "", "output format. One of: ['json', 'md']")
+	cmd.Flags().BoolVarP(&argo, "argo", "", false, "resolve the endpoints from the status of the Argo CD application of the preview environment")
+	cmd.Flags().StringVarP(&argoNamespace, "argo-namespace", "", argocd.DefaultNamespace, "the namespace where Argo CD is installed")
 
 	return cmd
 }
@@ -97,7 +113,25 @@ func executeListPreviewEndpoints(ctx context.Context, name, output string) error
 	if err != nil {
 		return fmt.Errorf("failed to get preview environments: %s", err)
 	}
+	return printEndpoints(name, output, endpointList)
+}
+
+func executeListArgoPreviewEndpoints(ctx context.Context, name, argoNamespace, output string, c dynamic.Interface) error {
+	endpointList, status, err := getArgoPreviewEndpoints(ctx, name, argoNamespace, c)
+	if err != nil {
+		return err
+	}
+	if status.Sync != argocd.SyncedStatus || status.Health != argocd.HealthyStatus {
+		if output == "json" {
+			oktetoLog.Infof("argo cd application '%s' is not synced: sync status '%s', health status '%s'", name, status.Sync, status.Health)
+		} else {
+			oktetoLog.Warning("Argo CD application '%s' is not synced yet: the sync status is '%s' and the health status is '%s'", name, status.Sync, status.Health)
+		}
+	}
+	return printEndpoints(name, output, endpointList)
+}
 
+func printEndpoints(name, output string, endpointList []types.Endpoint) error {
 	switch output {
 	case "json":
 		bytes, err := json.MarshalIndent(endpointList, "", "  ")
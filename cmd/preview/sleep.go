@@ -28,9 +28,10 @@ import (
 // Sleep sleeps a preview environment
 func Sleep(ctx context.Context) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "sleep <name>",
-		Short: "Sleeps a preview environment",
-		Args:  utils.ExactArgsAccepted(1, ""),
+		Use:               "sleep <name>",
+		Short:             "Sleeps a preview environment",
+		Args:              utils.ExactArgsAccepted(1, ""),
+		ValidArgsFunction: utils.CompletePreviews,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			prToSleep := args[0]
 			if err := contextCMD.NewContextCommand().Run(ctx, &contextCMD.ContextOptions{}); err != nil {
@@ -28,9 +28,10 @@ import (
 // Wake wakes a preview environment
 func Wake(ctx context.Context) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "wake <name>",
-		Short: "Wakes a preview environment",
-		Args:  utils.ExactArgsAccepted(1, ""),
+		Use:               "wake <name>",
+		Short:             "Wakes a preview environment",
+		Args:              utils.ExactArgsAccepted(1, ""),
+		ValidArgsFunction: utils.CompletePreviews,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			prToWake := args[0]
 			if err := contextCMD.NewContextCommand().Run(ctx, &contextCMD.ContextOptions{}); err != nil {
@@ -0,0 +1,125 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/format"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/repository"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// workloadProvenance is the version of the source code of a deployed workload
+type workloadProvenance struct {
+	kind       string
+	name       string
+	provenance repository.Provenance
+}
+
+// getWorkloadsProvenance returns the provenance of the workloads deployed by the dev environment name
+func getWorkloadsProvenance(ctx context.Context, c kubernetes.Interface, namespace, name string) ([]workloadProvenance, error) {
+	opts := metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", model.DeployedByLabel, format.ResourceK8sMetaString(name))}
+	result := []workloadProvenance{}
+
+	deployments, err := c.AppsV1().Deployments(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for _, d := range deployments.Items {
+		result = append(result, workloadProvenance{kind: "Deployment", name: d.Name, provenance: repository.ProvenanceFromAnnotations(d.Annotations)})
+	}
+
+	statefulsets, err := c.AppsV1().StatefulSets(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for _, sfs := range statefulsets.Items {
+		result = append(result, workloadProvenance{kind: "StatefulSet", name: sfs.Name, provenance: repository.ProvenanceFromAnnotations(sfs.Annotations)})
+	}
+
+	daemonsets, err := c.AppsV1().DaemonSets(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	for _, ds := range daemonsets.Items {
+		result = append(result, workloadProvenance{kind: "DaemonSet", name: ds.Name, provenance: repository.ProvenanceFromAnnotations(ds.Annotations)})
+	}
+
+	cronjobs, err := c.BatchV1().CronJobs(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cronjobs: %w", err)
+	}
+	for _, cj := range cronjobs.Items {
+		result = append(result, workloadProvenance{kind: "CronJob", name: cj.Name, provenance: repository.ProvenanceFromAnnotations(cj.Annotations)})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].kind != result[j].kind {
+			return result[i].kind < result[j].kind
+		}
+		return result[i].name < result[j].name
+	})
+	return result, nil
+}
+
+// printProvenance prints the version of the source code that each workload of the dev environment runs
+func printProvenance(ctx context.Context, out io.Writer, c kubernetes.Interface, namespace, name string) error {
+	workloads, err := getWorkloadsProvenance(ctx, c, namespace, name)
+	if err != nil {
+		return err
+	}
+	if len(workloads) == 0 {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("there are no workloads deployed by '%s' in namespace '%s'", name, namespace),
+			Hint: "Run 'okteto deploy' to deploy your development environment",
+		}
+	}
+
+	w := tabwriter.NewWriter(out, 1, 1, 2, ' ', 0)
+	fmt.Fprintf(w, "Workload\tBranch\tCommit\tRepository\n")
+	for _, wp := range workloads {
+		p := wp.provenance
+		fmt.Fprintf(w, "%s/%s\t%s\t%s\t%s\n", wp.kind, wp.name, orDash(p.Branch), formatProvenanceCommit(p), orDash(p.Repository))
+	}
+	return w.Flush()
+}
+
+func formatProvenanceCommit(p repository.Provenance) string {
+	if p.Commit == "" {
+		return "-"
+	}
+	commit := p.Commit
+	if len(commit) > 7 {
+		commit = commit[:7]
+	}
+	if p.Dirty {
+		commit += " (uncommitted changes)"
+	}
+	return commit
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_printProvenance(t *testing.T) {
+	c := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "api",
+				Namespace: "ns",
+				Labels:    map[string]string{model.DeployedByLabel: "movies"},
+				Annotations: map[string]string{
+					model.OktetoRepositoryAnnotation: "https://github.com/okteto/movies",
+					model.OktetoGitBranchAnnotation:  "main",
+					model.OktetoGitCommitAnnotation:  "1234567890",
+					model.OktetoGitDirtyAnnotation:   "true",
+				},
+			},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "db",
+				Namespace: "ns",
+				Labels:    map[string]string{model.DeployedByLabel: "movies"},
+			},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "other",
+				Namespace: "ns",
+				Labels:    map[string]string{model.DeployedByLabel: "other"},
+			},
+		},
+	)
+
+	out := &bytes.Buffer{}
+	require.NoError(t, printProvenance(context.Background(), out, c, "ns", "movies"))
+	expected := "Workload        Branch  Commit                         Repository\n" +
+		"Deployment/api  main    1234567 (uncommitted changes)  https://github.com/okteto/movies\n" +
+		"StatefulSet/db  -       -                              -\n"
+	assert.Equal(t, expected, out.String())
+
+	err := printProvenance(context.Background(), out, c, "ns", "unknown")
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+}
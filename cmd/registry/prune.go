@@ -0,0 +1,215 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/images"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/registry"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/kubernetes"
+)
+
+const defaultKeep = 3
+
+// PruneOptions defines the options for okteto registry prune
+type PruneOptions struct {
+	K8sContext string
+	Namespace  string
+	Keep       int
+	OlderThan  time.Duration
+	DryRun     bool
+	Yes        bool
+}
+
+// repositoryCtrl lists and deletes the images of the repositories of a namespace
+type repositoryCtrl interface {
+	ListNamespaceTags(ctx context.Context, namespace string) ([]registry.ImageTag, error)
+	DeleteImage(imageWithDigest string) error
+}
+
+// Prune deletes the old image tags of the repositories of a namespace
+func Prune(ctx context.Context) *cobra.Command {
+	options := &PruneOptions{}
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Delete the old image tags of the repositories of your namespace",
+		Long: `Delete the old image tags of the repositories of your namespace.
+
+The most recent tags of every repository are kept, as well as the images used by the pods, deployments, statefulsets, daemonsets, jobs and cronjobs of the namespace.
+Images are deleted by digest, so all the tags of a deleted image are removed.`,
+		Args: utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#registry"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.Keep < 0 {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("invalid value for '--keep': %d", options.Keep),
+					Hint: "The number of tags to keep must be 0 or greater",
+				}
+			}
+			ctxOpts := &contextCMD.ContextOptions{
+				Context:   options.K8sContext,
+				Namespace: options.Namespace,
+				Show:      true,
+			}
+			if err := contextCMD.NewContextCommand().Run(ctx, ctxOpts); err != nil {
+				return err
+			}
+			if !okteto.IsOkteto() {
+				return oktetoErrors.ErrContextIsNotOktetoCluster
+			}
+			if options.Namespace == "" {
+				options.Namespace = okteto.Context().Namespace
+			}
+			c, _, err := okteto.GetK8sClient()
+			if err != nil {
+				return err
+			}
+			rc := registry.NewRepositoryCtrl(okteto.Config{})
+			return runPrune(ctx, rc, c, options, os.Stdout, time.Now())
+		},
+	}
+	cmd.Flags().StringVarP(&options.K8sContext, "context", "c", "", "context where the command is executed")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "namespace of the repositories to prune")
+	cmd.Flags().IntVar(&options.Keep, "keep", defaultKeep, "number of most recent tags to keep in every repository")
+	cmd.Flags().DurationVar(&options.OlderThan, "older-than", 0, "only delete the tags created before this duration, i.e. '168h'")
+	cmd.Flags().BoolVar(&options.DryRun, "dry-run", false, "list the tags to delete without deleting them")
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", false, "delete the tags without asking for confirmation")
+	return cmd
+}
+
+func runPrune(ctx context.Context, rc repositoryCtrl, c kubernetes.Interface, options *PruneOptions, out io.Writer, now time.Time) error {
+	oktetoLog.Spinner(fmt.Sprintf("Listing the images of namespace '%s'...", options.Namespace))
+	oktetoLog.StartSpinner()
+	tags, err := rc.ListNamespaceTags(ctx, options.Namespace)
+	if err != nil {
+		oktetoLog.StopSpinner()
+		return fmt.Errorf("failed to list the images of namespace '%s': %w", options.Namespace, err)
+	}
+	inUse, err := images.ListInUse(ctx, options.Namespace, c)
+	oktetoLog.StopSpinner()
+	if err != nil {
+		return fmt.Errorf("failed to get the images in use in namespace '%s': %w", options.Namespace, err)
+	}
+
+	toPrune := selectTagsToPrune(tags, inUse, options.Keep, options.OlderThan, now)
+	if len(toPrune) == 0 {
+		oktetoLog.Success("There are no image tags to prune in namespace '%s'", options.Namespace)
+		return nil
+	}
+	displayTags(out, toPrune, now)
+
+	if options.DryRun {
+		oktetoLog.Information("%d image tags would be deleted", len(toPrune))
+		return nil
+	}
+	if !options.Yes {
+		answer, err := utils.AskYesNo(fmt.Sprintf("Do you want to delete %d image tags?", len(toPrune)), utils.YesNoDefault_No)
+		if err != nil {
+			return err
+		}
+		if !answer {
+			return nil
+		}
+	}
+
+	deleted := map[string]bool{}
+	failed := 0
+	for _, t := range toPrune {
+		image := t.ImageWithDigest()
+		if deleted[image] {
+			continue
+		}
+		if err := rc.DeleteImage(image); err != nil {
+			oktetoLog.Warning("Failed to delete '%s': %s", t.Image(), err)
+			failed++
+			continue
+		}
+		deleted[image] = true
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d images from the registry", failed)
+	}
+	oktetoLog.Success("Deleted %d image tags from the registry", len(toPrune))
+	return nil
+}
+
+// selectTagsToPrune returns the tags to delete of every repository. It keeps the most recent tags, the tags created within olderThan
+// and the tags in use by name or by digest. A tag is never deleted if its digest is shared with a tag that is kept
+func selectTagsToPrune(tags []registry.ImageTag, inUse *images.InUse, keep int, olderThan time.Duration, now time.Time) []registry.ImageTag {
+	byRepository := map[string][]registry.ImageTag{}
+	repositories := []string{}
+	for _, t := range tags {
+		if _, ok := byRepository[t.Repository]; !ok {
+			repositories = append(repositories, t.Repository)
+		}
+		byRepository[t.Repository] = append(byRepository[t.Repository], t)
+	}
+	sort.Strings(repositories)
+
+	result := []registry.ImageTag{}
+	for _, repository := range repositories {
+		repoTags := byRepository[repository]
+		sort.SliceStable(repoTags, func(i, j int) bool {
+			if repoTags[i].Created.Equal(repoTags[j].Created) {
+				return repoTags[i].Tag < repoTags[j].Tag
+			}
+			return repoTags[i].Created.After(repoTags[j].Created)
+		})
+
+		kept := map[string]bool{}
+		for i, t := range repoTags {
+			switch {
+			case i < keep:
+			case inUse.IsInUse(t.Image(), t.Digest):
+			case olderThan > 0 && (t.Created.IsZero() || now.Sub(t.Created) < olderThan):
+			default:
+				continue
+			}
+			kept[t.Digest] = true
+		}
+
+		for _, t := range repoTags {
+			if !kept[t.Digest] {
+				result = append(result, t)
+			}
+		}
+	}
+	return result
+}
+
+func displayTags(out io.Writer, tags []registry.ImageTag, now time.Time) {
+	w := tabwriter.NewWriter(out, 1, 1, 2, ' ', 0)
+	fmt.Fprintf(w, "Image\tDigest\tAge\n")
+	for _, t := range tags {
+		age := "-"
+		if !t.Created.IsZero() {
+			age = duration.HumanDuration(now.Sub(t.Created))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", t.Image(), t.ShortDigest(), age)
+	}
+	w.Flush()
+}
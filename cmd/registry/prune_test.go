@@ -0,0 +1,193 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/k8s/images"
+	"github.com/okteto/okteto/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const (
+	repo    = "registry.okteto.dev/cindy/api"
+	digest1 = "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	digest2 = "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+	digest3 = "sha256:3333333333333333333333333333333333333333333333333333333333333333"
+	digest4 = "sha256:4444444444444444444444444444444444444444444444444444444444444444"
+)
+
+var now = time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+
+type fakeRepositoryCtrl struct {
+	tags    []registry.ImageTag
+	err     error
+	deleted []string
+}
+
+func (f *fakeRepositoryCtrl) ListNamespaceTags(_ context.Context, _ string) ([]registry.ImageTag, error) {
+	return f.tags, f.err
+}
+
+func (f *fakeRepositoryCtrl) DeleteImage(image string) error {
+	f.deleted = append(f.deleted, image)
+	return nil
+}
+
+func newInUse(refs ...string) *images.InUse {
+	u := images.NewInUse()
+	for _, ref := range refs {
+		u.Add("deployment/api", ref)
+	}
+	return u
+}
+
+func tagNames(tags []registry.ImageTag) []string {
+	result := []string{}
+	for _, t := range tags {
+		result = append(result, t.Tag)
+	}
+	return result
+}
+
+func TestSelectTagsToPrune(t *testing.T) {
+	tags := []registry.ImageTag{
+		{Repository: repo, Tag: "old", Digest: digest1, Created: now.Add(-30 * 24 * time.Hour)},
+		{Repository: repo, Tag: "okteto", Digest: digest4, Created: now.Add(-time.Hour)},
+		{Repository: repo, Tag: "week", Digest: digest2, Created: now.Add(-7 * 24 * time.Hour)},
+		{Repository: repo, Tag: "week-alias", Digest: digest2, Created: now.Add(-7 * 24 * time.Hour)},
+		{Repository: repo, Tag: "unknown", Digest: digest3},
+	}
+
+	tests := []struct {
+		name      string
+		inUse     *images.InUse
+		keep      int
+		olderThan time.Duration
+		expected  []string
+	}{
+		{
+			name:     "keep the most recent",
+			inUse:    newInUse(),
+			keep:     1,
+			expected: []string{"week", "week-alias", "old", "unknown"},
+		},
+		{
+			name:     "tags sharing the digest of a kept tag",
+			inUse:    newInUse(),
+			keep:     2,
+			expected: []string{"old", "unknown"},
+		},
+		{
+			name:     "keep nothing",
+			inUse:    newInUse(),
+			keep:     0,
+			expected: []string{"okteto", "week", "week-alias", "old", "unknown"},
+		},
+		{
+			name:     "in use by name",
+			inUse:    newInUse(repo + ":old"),
+			keep:     1,
+			expected: []string{"week", "week-alias", "unknown"},
+		},
+		{
+			name:     "in use by digest",
+			inUse:    newInUse("docker-pullable://" + repo + "@" + digest2),
+			keep:     1,
+			expected: []string{"old", "unknown"},
+		},
+		{
+			name:      "older than",
+			inUse:     newInUse(),
+			keep:      0,
+			olderThan: 14 * 24 * time.Hour,
+			expected:  []string{"old"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := selectTagsToPrune(tags, tt.inUse, tt.keep, tt.olderThan, now)
+			assert.Equal(t, tt.expected, tagNames(result))
+		})
+	}
+}
+
+func TestRunPrune(t *testing.T) {
+	tags := []registry.ImageTag{
+		{Repository: repo, Tag: "okteto", Digest: digest1, Created: now.Add(-time.Hour)},
+		{Repository: repo, Tag: "v1", Digest: digest2, Created: now.Add(-48 * time.Hour)},
+		{Repository: repo, Tag: "v1-alias", Digest: digest2, Created: now.Add(-48 * time.Hour)},
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		rc := &fakeRepositoryCtrl{tags: tags}
+		out := &bytes.Buffer{}
+		err := runPrune(context.Background(), rc, fake.NewSimpleClientset(), &PruneOptions{Namespace: "cindy", Keep: 1, DryRun: true}, out, now)
+		require.NoError(t, err)
+		assert.Empty(t, rc.deleted)
+		assert.Equal(t, `Image                                   Digest        Age
+registry.okteto.dev/cindy/api:v1        222222222222  2d
+registry.okteto.dev/cindy/api:v1-alias  222222222222  2d
+`, out.String())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		rc := &fakeRepositoryCtrl{tags: tags}
+		err := runPrune(context.Background(), rc, fake.NewSimpleClientset(), &PruneOptions{Namespace: "cindy", Keep: 1, Yes: true}, &bytes.Buffer{}, now)
+		require.NoError(t, err)
+		assert.Equal(t, []string{repo + "@" + digest2}, rc.deleted)
+	})
+
+	t.Run("nothing to prune", func(t *testing.T) {
+		rc := &fakeRepositoryCtrl{tags: tags}
+		out := &bytes.Buffer{}
+		err := runPrune(context.Background(), rc, fake.NewSimpleClientset(), &PruneOptions{Namespace: "cindy", Keep: 3, Yes: true}, out, now)
+		require.NoError(t, err)
+		assert.Empty(t, rc.deleted)
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("in use by a cronjob", func(t *testing.T) {
+		rc := &fakeRepositoryCtrl{tags: tags}
+		c := fake.NewSimpleClientset(&batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "cindy"},
+			Spec: batchv1.CronJobSpec{
+				JobTemplate: batchv1.JobTemplateSpec{
+					Spec: batchv1.JobSpec{
+						Template: apiv1.PodTemplateSpec{Spec: apiv1.PodSpec{Containers: []apiv1.Container{{Image: repo + ":v1"}}}},
+					},
+				},
+			},
+		})
+		err := runPrune(context.Background(), rc, c, &PruneOptions{Namespace: "cindy", Keep: 1, Yes: true}, &bytes.Buffer{}, now)
+		require.NoError(t, err)
+		assert.Empty(t, rc.deleted)
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		rc := &fakeRepositoryCtrl{err: errors.New("unauthorized")}
+		err := runPrune(context.Background(), rc, fake.NewSimpleClientset(), &PruneOptions{Namespace: "cindy", Keep: 1, Yes: true}, &bytes.Buffer{}, now)
+		assert.EqualError(t, err, "failed to list the images of namespace 'cindy': unauthorized")
+	})
+}
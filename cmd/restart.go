@@ -18,36 +18,90 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"os/signal"
+	"strings"
+	"time"
 
 	contextCMD "github.com/okteto/okteto/cmd/context"
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/apps"
+	"github.com/okteto/okteto/pkg/k8s/deployments"
 	"github.com/okteto/okteto/pkg/k8s/pods"
+	"github.com/okteto/okteto/pkg/k8s/statefulsets"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/signals"
 
 	"github.com/okteto/okteto/pkg/model"
 
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 )
 
+const (
+	deploymentKind  = "deployment"
+	statefulsetKind = "statefulset"
+)
+
+// restartOptions are the options of the restart command
+type restartOptions struct {
+	namespace  string
+	k8sContext string
+	devPath    string
+	wait       bool
+	rollout    bool
+	timeout    time.Duration
+}
+
+// restartTarget is a deployment or statefulset to restart
+type restartTarget struct {
+	kind string
+	name string
+}
+
+func (t restartTarget) String() string {
+	return fmt.Sprintf("%s/%s", t.kind, t.name)
+}
+
 // Restart restarts the pods of a given dev mode deployment
 func Restart() *cobra.Command {
-	var namespace string
-	var k8sContext string
-	var devPath string
+	opts := &restartOptions{}
 
 	cmd := &cobra.Command{
-		Use:    "restart [svc]",
-		Short:  "Restart the deployments listed in the services field of a development container",
-		Args:   utils.MaximumNArgsAccepted(1, "https://okteto.com/docs/reference/cli/#restart"),
+		Use:   "restart [svc|deployment/<name>|statefulset/<name>...]",
+		Short: "Restart the deployments listed in the services field of a development container",
+		Long: `Restart the deployments listed in the services field of a development container.
+
+Use 'deployment/<name>' or 'statefulset/<name>' to restart individual workloads of the namespace instead.
+By default, the pods are deleted. With '--rollout', the pods are replaced following the update strategy of the workload.`,
 		Hidden: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 
-			manifestOpts := contextCMD.ManifestOptions{Filename: devPath, Namespace: namespace, K8sContext: k8sContext}
+			targets, args, err := parseRestartTargets(args)
+			if err != nil {
+				return err
+			}
+			if len(args) > 1 || (len(args) > 0 && len(targets) > 0) {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("%q accepts at most 1 service name", cmd.CommandPath()),
+					Hint: "Visit https://okteto.com/docs/reference/cli/#restart for more information.",
+				}
+			}
+
+			if len(targets) > 0 {
+				err = restartTargets(ctx, opts, targets)
+				analytics.TrackRestart(err == nil)
+				if err != nil {
+					return fmt.Errorf("failed to restart your workloads: %w", err)
+				}
+				oktetoLog.Success("Workloads restarted")
+				return nil
+			}
+
+			manifestOpts := contextCMD.ManifestOptions{Filename: opts.devPath, Namespace: opts.namespace, K8sContext: opts.k8sContext}
 			manifest, err := contextCMD.LoadManifestWithContext(ctx, manifestOpts)
 			if err != nil {
 				return err
@@ -77,7 +131,7 @@ func Restart() *cobra.Command {
 			if len(args) > 0 {
 				serviceName = args[0]
 			}
-			err = executeRestart(ctx, dev, serviceName)
+			err = executeRestart(ctx, dev, serviceName, opts)
 			if err != nil {
 				return fmt.Errorf("failed to restart your deployments: %s", err)
 			}
@@ -89,30 +143,192 @@ func Restart() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVarP(&devPath, "file", "f", utils.DefaultManifest, "path to the manifest file")
-	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace where the restart command is executed")
-	cmd.Flags().StringVarP(&k8sContext, "context", "c", "", "context where the restart command is executed")
+	cmd.Flags().StringVarP(&opts.devPath, "file", "f", utils.DefaultManifest, "path to the manifest file")
+	cmd.Flags().StringVarP(&opts.namespace, "namespace", "n", "", "namespace where the restart command is executed")
+	cmd.Flags().StringVarP(&opts.k8sContext, "context", "c", "", "context where the restart command is executed")
+	cmd.Flags().BoolVarP(&opts.wait, "wait", "w", false, "wait until the restarted workloads are ready")
+	cmd.Flags().BoolVarP(&opts.rollout, "rollout", "", false, "replace the pods following the update strategy of the workloads instead of deleting them")
+	cmd.Flags().DurationVarP(&opts.timeout, "timeout", "t", 5*time.Minute, "the length of time to wait for the workloads to be ready")
 
 	return cmd
 }
 
-func executeRestart(ctx context.Context, dev *model.Dev, sn string) error {
-	oktetoLog.Infof("restarting services")
-	client, _, err := okteto.GetK8sClient()
+// parseRestartTargets returns the workloads in '<kind>/<name>' format and the rest of args
+func parseRestartTargets(args []string) ([]restartTarget, []string, error) {
+	targets := []restartTarget{}
+	rest := []string{}
+	for _, arg := range args {
+		kind, name, found := strings.Cut(arg, "/")
+		if !found {
+			rest = append(rest, arg)
+			continue
+		}
+		switch strings.ToLower(kind) {
+		case "deployment", "deployments", "deploy":
+			kind = deploymentKind
+		case "statefulset", "statefulsets", "sts":
+			kind = statefulsetKind
+		default:
+			return nil, nil, oktetoErrors.UserError{
+				E:    fmt.Errorf("invalid workload '%s'", arg),
+				Hint: "Use 'deployment/<name>' or 'statefulset/<name>'",
+			}
+		}
+		if name == "" {
+			return nil, nil, oktetoErrors.UserError{
+				E:    fmt.Errorf("invalid workload '%s'", arg),
+				Hint: "Use 'deployment/<name>' or 'statefulset/<name>'",
+			}
+		}
+		targets = append(targets, restartTarget{kind: kind, name: name})
+	}
+	return targets, rest, nil
+}
+
+// restartTargets restarts individual workloads of the namespace
+func restartTargets(ctx context.Context, opts *restartOptions, targets []restartTarget) error {
+	ctxResource := &model.ContextResource{}
+	if err := ctxResource.UpdateNamespace(opts.namespace); err != nil {
+		return err
+	}
+	if err := ctxResource.UpdateContext(opts.k8sContext); err != nil {
+		return err
+	}
+	ctxOptions := &contextCMD.ContextOptions{
+		Context:   ctxResource.Context,
+		Namespace: ctxResource.Namespace,
+		Show:      true,
+	}
+	if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+		return err
+	}
+
+	c, _, err := okteto.GetK8sClient()
 	if err != nil {
 		return err
 	}
+	namespace := okteto.Context().Namespace
 
-	oktetoLog.Spinner("Restarting deployments...")
+	return runWithInterrupt(func() error {
+		for _, t := range targets {
+			oktetoLog.Spinner(fmt.Sprintf("Restarting %s...", t))
+			if err := restartWorkload(ctx, t, opts.rollout, namespace, c); err != nil {
+				return err
+			}
+			oktetoLog.StopSpinner()
+			oktetoLog.Success("Restarted %s", t)
+		}
+		if !opts.wait {
+			return nil
+		}
+		oktetoLog.Spinner("Waiting for the workloads to be ready...")
+		return waitForRollout(ctx, targets, namespace, opts.timeout, c)
+	})
+}
+
+// restartWorkload restarts the pods of a deployment or statefulset
+func restartWorkload(ctx context.Context, t restartTarget, rollout bool, namespace string, c kubernetes.Interface) error {
+	var selector *metav1.LabelSelector
+	switch t.kind {
+	case deploymentKind:
+		d, err := deployments.Get(ctx, t.name, namespace, c)
+		if err != nil {
+			return fmt.Errorf("could not get %s: %w", t, err)
+		}
+		if rollout {
+			return deployments.RolloutRestart(ctx, t.name, namespace, c)
+		}
+		selector = d.Spec.Selector
+	case statefulsetKind:
+		sfs, err := statefulsets.Get(ctx, t.name, namespace, c)
+		if err != nil {
+			return fmt.Errorf("could not get %s: %w", t, err)
+		}
+		if rollout {
+			return statefulsets.RolloutRestart(ctx, t.name, namespace, c)
+		}
+		selector = sfs.Spec.Selector
+	}
+
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return fmt.Errorf("invalid selector of %s: %w", t, err)
+	}
+	podList, err := c.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector.String()})
+	if err != nil {
+		return fmt.Errorf("could not list the pods of %s: %w", t, err)
+	}
+	for i := range podList.Items {
+		if err := c.CoreV1().Pods(namespace).Delete(ctx, podList.Items[i].Name, metav1.DeleteOptions{}); err != nil && !oktetoErrors.IsNotFound(err) {
+			return fmt.Errorf("could not delete pod '%s': %w", podList.Items[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// isWorkloadRolledOut returns true if all the replicas of a workload are updated and ready
+func isWorkloadRolledOut(ctx context.Context, t restartTarget, namespace string, c kubernetes.Interface) (bool, error) {
+	switch t.kind {
+	case deploymentKind:
+		d, err := deployments.Get(ctx, t.name, namespace, c)
+		if err != nil {
+			return false, err
+		}
+		return deployments.IsRolledOut(d), nil
+	default:
+		sfs, err := statefulsets.Get(ctx, t.name, namespace, c)
+		if err != nil {
+			return false, err
+		}
+		return statefulsets.IsRolledOut(sfs), nil
+	}
+}
+
+// waitForRollout waits until all the replicas of the workloads are updated and ready
+func waitForRollout(ctx context.Context, targets []restartTarget, namespace string, timeout time.Duration, c kubernetes.Interface) error {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	to := time.NewTimer(timeout)
+	defer to.Stop()
+
+	pending := append([]restartTarget{}, targets...)
+	for {
+		notReady := []restartTarget{}
+		for _, t := range pending {
+			ready, err := isWorkloadRolledOut(ctx, t, namespace, c)
+			if err != nil {
+				return fmt.Errorf("could not get %s: %w", t, err)
+			}
+			if !ready {
+				notReady = append(notReady, t)
+			}
+		}
+		if len(notReady) == 0 {
+			return nil
+		}
+		pending = notReady
+
+		select {
+		case <-ticker.C:
+		case <-to.C:
+			return fmt.Errorf("%w: %s not ready after %s", oktetoErrors.ErrTimeout, pending[0], timeout)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// runWithInterrupt runs f with a spinner until it finishes or the user cancels the command
+func runWithInterrupt(f func() error) error {
 	oktetoLog.StartSpinner()
 	defer oktetoLog.StopSpinner()
 
 	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt)
+	signals.Notify(stop)
 	exit := make(chan error, 1)
 
 	go func() {
-		exit <- pods.Restart(ctx, dev, client, sn)
+		exit <- f()
 	}()
 
 	select {
@@ -128,3 +344,72 @@ func executeRestart(ctx context.Context, dev *model.Dev, sn string) error {
 	}
 	return nil
 }
+
+func executeRestart(ctx context.Context, dev *model.Dev, sn string, opts *restartOptions) error {
+	oktetoLog.Infof("restarting services")
+	client, _, err := okteto.GetK8sClient()
+	if err != nil {
+		return err
+	}
+
+	oktetoLog.Spinner("Restarting deployments...")
+	return runWithInterrupt(func() error {
+		if !opts.rollout {
+			if err := pods.Restart(ctx, dev, client, sn); err != nil {
+				return err
+			}
+			if !opts.wait {
+				return nil
+			}
+		}
+
+		targets, err := getDevRestartTargets(ctx, dev, sn, client)
+		if err != nil {
+			return err
+		}
+		if opts.rollout {
+			for _, t := range targets {
+				if err := restartWorkload(ctx, t, true, dev.Namespace, client); err != nil {
+					return err
+				}
+			}
+		}
+		if !opts.wait {
+			return nil
+		}
+		oktetoLog.Spinner("Waiting for the deployments to be ready...")
+		return waitForRollout(ctx, targets, dev.Namespace, opts.timeout, client)
+	})
+}
+
+// getDevRestartTargets returns the workloads of the services of a development container, or of the service sn if it's set
+func getDevRestartTargets(ctx context.Context, dev *model.Dev, sn string, c kubernetes.Interface) ([]restartTarget, error) {
+	targets := []restartTarget{}
+	for _, svc := range dev.Services {
+		if sn != "" && svc.Name != sn {
+			continue
+		}
+		app, err := apps.Get(ctx, svc, dev.Namespace, c)
+		if err != nil {
+			return nil, err
+		}
+		// services in dev mode run in their dev clones
+		if clone, err := app.GetDevClone(ctx, c); err == nil {
+			app = clone
+		}
+		t := restartTarget{name: app.ObjectMeta().Name}
+		switch app.(type) {
+		case *apps.DeploymentApp:
+			t.kind = deploymentKind
+		case *apps.StatefulSetApp:
+			t.kind = statefulsetKind
+		default:
+			return nil, fmt.Errorf("%s '%s' can't be restarted with --rollout or --wait", strings.ToLower(app.Kind()), t.name)
+		}
+		targets = append(targets, t)
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("service '%s' not found in the services of '%s'", sn, dev.Name)
+	}
+	return targets, nil
+}
@@ -0,0 +1,168 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseRestartTargets(t *testing.T) {
+	var tests = []struct {
+		name            string
+		args            []string
+		expectedTargets []restartTarget
+		expectedArgs    []string
+		expectErr       bool
+	}{
+		{
+			name:            "no args",
+			expectedTargets: []restartTarget{},
+			expectedArgs:    []string{},
+		},
+		{
+			name:            "service name",
+			args:            []string{"api"},
+			expectedTargets: []restartTarget{},
+			expectedArgs:    []string{"api"},
+		},
+		{
+			name: "workloads",
+			args: []string{"deployment/api", "deploy/worker", "statefulset/db", "sts/cache"},
+			expectedTargets: []restartTarget{
+				{kind: deploymentKind, name: "api"},
+				{kind: deploymentKind, name: "worker"},
+				{kind: statefulsetKind, name: "db"},
+				{kind: statefulsetKind, name: "cache"},
+			},
+			expectedArgs: []string{},
+		},
+		{
+			name:      "invalid kind",
+			args:      []string{"daemonset/agent"},
+			expectErr: true,
+		},
+		{
+			name:      "missing name",
+			args:      []string{"deployment/"},
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			targets, args, err := parseRestartTargets(tt.args)
+			if tt.expectErr {
+				assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedTargets, targets)
+			assert.Equal(t, tt.expectedArgs, args)
+		})
+	}
+}
+
+func TestRestartWorkload(t *testing.T) {
+	ctx := context.Background()
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}}
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "test"},
+		Spec:       appsv1.DeploymentSpec{Selector: selector},
+	}
+	apiPod := &apiv1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "test", Labels: map[string]string{"app": "api"}}}
+	dbPod := &apiv1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db-0", Namespace: "test", Labels: map[string]string{"app": "db"}}}
+
+	t.Run("delete pods", func(t *testing.T) {
+		c := fake.NewSimpleClientset(d, apiPod, dbPod)
+		require.NoError(t, restartWorkload(ctx, restartTarget{kind: deploymentKind, name: "api"}, false, "test", c))
+
+		podList, err := c.CoreV1().Pods("test").List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		require.Len(t, podList.Items, 1)
+		assert.Equal(t, "db-0", podList.Items[0].Name)
+	})
+
+	t.Run("rollout", func(t *testing.T) {
+		c := fake.NewSimpleClientset(d, apiPod)
+		require.NoError(t, restartWorkload(ctx, restartTarget{kind: deploymentKind, name: "api"}, true, "test", c))
+
+		restarted, err := c.AppsV1().Deployments("test").Get(ctx, "api", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.NotEmpty(t, restarted.Spec.Template.Annotations[model.RestartedAtAnnotation])
+
+		podList, err := c.CoreV1().Pods("test").List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		assert.Len(t, podList.Items, 1)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		c := fake.NewSimpleClientset()
+		assert.Error(t, restartWorkload(ctx, restartTarget{kind: statefulsetKind, name: "db"}, false, "test", c))
+	})
+}
+
+func TestWaitForRollout(t *testing.T) {
+	ctx := context.Background()
+	sfs := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "test"},
+		Status:     appsv1.StatefulSetStatus{Replicas: 1, ReadyReplicas: 1},
+	}
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "test"},
+		Status:     appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 0, ReadyReplicas: 1},
+	}
+	c := fake.NewSimpleClientset(sfs, d)
+
+	err := waitForRollout(ctx, []restartTarget{{kind: statefulsetKind, name: "db"}}, "test", time.Second, c)
+	assert.NoError(t, err)
+
+	err = waitForRollout(ctx, []restartTarget{{kind: statefulsetKind, name: "db"}, {kind: deploymentKind, name: "api"}}, "test", time.Second, c)
+	assert.ErrorIs(t, err, oktetoErrors.ErrTimeout)
+}
+
+func TestGetDevRestartTargets(t *testing.T) {
+	ctx := context.Background()
+	api := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "test"}}
+	db := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "test"}}
+	c := fake.NewSimpleClientset(api, db)
+	dev := &model.Dev{
+		Name:      "dev",
+		Namespace: "test",
+		Services: []*model.Dev{
+			{Name: "api"},
+			{Name: "db"},
+		},
+	}
+
+	targets, err := getDevRestartTargets(ctx, dev, "", c)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []restartTarget{{kind: deploymentKind, name: "api"}, {kind: statefulsetKind, name: "db"}}, targets)
+
+	targets, err = getDevRestartTargets(ctx, dev, "db", c)
+	require.NoError(t, err)
+	assert.Equal(t, []restartTarget{{kind: statefulsetKind, name: "db"}}, targets)
+
+	_, err = getDevRestartTargets(ctx, dev, "web", c)
+	assert.Error(t, err)
+}
@@ -0,0 +1,293 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/okteto/okteto/pkg/format"
+	"github.com/okteto/okteto/pkg/k8s/jobs"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	jobContainerName = "job"
+
+	// jobNameLabel is the label set by kubernetes in the pods of a job
+	jobNameLabel = "job-name"
+
+	// jobTTLAfterFinished removes the job from the namespace if okteto can't delete it
+	jobTTLAfterFinished int32 = 300
+
+	defaultPollInterval = time.Second
+
+	// defaultStartTimeout is the time the container of the job has to start, i.e. while its pod can't be scheduled
+	defaultStartTimeout = 5 * time.Minute
+)
+
+var errJobPodFailed = errors.New("the pod of the job failed before running the command")
+
+// jobRunner runs a manifest job as a Kubernetes job
+type jobRunner struct {
+	client       kubernetes.Interface
+	out          io.Writer
+	pollInterval time.Duration
+	startTimeout time.Duration
+}
+
+func newJobRunner(c kubernetes.Interface, out io.Writer) *jobRunner {
+	return &jobRunner{
+		client:       c,
+		out:          out,
+		pollInterval: defaultPollInterval,
+		startTimeout: defaultStartTimeout,
+	}
+}
+
+// run creates the job, streams its logs and returns the exit code of its command. The job is deleted when it finishes
+func (r *jobRunner) run(ctx context.Context, name string, job *model.ManifestJob, namespace string) (int, error) {
+	k8sJob := translateJob(name, job, namespace)
+	if err := jobs.Create(ctx, k8sJob, r.client); err != nil {
+		return 0, fmt.Errorf("error creating the job '%s': %w", name, err)
+	}
+	defer func() {
+		if err := jobs.Destroy(context.Background(), k8sJob.Name, namespace, r.client); err != nil {
+			oktetoLog.Infof("error deleting job '%s': %s", k8sJob.Name, err)
+		}
+	}()
+
+	if job.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, job.Timeout)
+		defer cancel()
+	}
+
+	exitCode, err := r.waitForJob(ctx, k8sJob)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return 0, jobTimeoutError(name, job.Timeout)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("error running the job '%s': %w", name, err)
+	}
+	return exitCode, nil
+}
+
+func (r *jobRunner) waitForJob(ctx context.Context, job *batchv1.Job) (int, error) {
+	podName, err := r.waitForPodStarted(ctx, job)
+	if err != nil {
+		return 0, err
+	}
+	if err := r.streamLogs(ctx, podName, job.Namespace); err != nil {
+		return 0, err
+	}
+	return r.waitForExitCode(ctx, podName, job.Namespace)
+}
+
+// waitForPodStarted waits until the container of the job is running or terminated and returns the name of its pod.
+// It fails if the container can't be created or if it doesn't start before the start timeout
+func (r *jobRunner) waitForPodStarted(ctx context.Context, job *batchv1.Job) (string, error) {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+	startTimeout := time.NewTimer(r.startTimeout)
+	defer startTimeout.Stop()
+	pending := "the pod of the job is not created"
+	for {
+		pods, err := r.client.CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("%s=%s", jobNameLabel, job.Name),
+		})
+		if err != nil {
+			return "", err
+		}
+		for i := range pods.Items {
+			if msg := getUnschedulableMessage(&pods.Items[i]); msg != "" {
+				pending = fmt.Sprintf("the pod of the job can't be scheduled: %s", msg)
+			}
+			status := getJobContainerStatus(&pods.Items[i])
+			if status == nil {
+				continue
+			}
+			if status.State.Running != nil || status.State.Terminated != nil {
+				return pods.Items[i].Name, nil
+			}
+			if status.State.Waiting == nil {
+				continue
+			}
+			if isImagePullError(status.State.Waiting.Reason) {
+				return "", fmt.Errorf("the image '%s' can't be pulled: %s", status.Image, status.State.Waiting.Message)
+			}
+			if isCreateContainerError(status.State.Waiting.Reason) {
+				return "", fmt.Errorf("the container of the job can't be created: %s", status.State.Waiting.Message)
+			}
+			pending = fmt.Sprintf("the container of the job is waiting: %s", status.State.Waiting.Reason)
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-startTimeout.C:
+			return "", fmt.Errorf("the job didn't start in %s, %s", r.startTimeout, pending)
+		case <-ticker.C:
+		}
+	}
+}
+
+// streamLogs writes the logs of the job container until it finishes
+func (r *jobRunner) streamLogs(ctx context.Context, podName, namespace string) error {
+	req := r.client.CoreV1().Pods(namespace).GetLogs(podName, &apiv1.PodLogOptions{
+		Container: jobContainerName,
+		Follow:    true,
+	})
+	stream, err := req.Stream(ctx)
+	if err != nil {
+		return fmt.Errorf("error streaming the logs of the job: %w", err)
+	}
+	defer func() {
+		if err := stream.Close(); err != nil {
+			oktetoLog.Debugf("error closing the logs of the job: %s", err)
+		}
+	}()
+	if _, err := io.Copy(r.out, stream); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("error streaming the logs of the job: %w", err)
+	}
+	return ctx.Err()
+}
+
+// waitForExitCode waits until the job container terminates and returns its exit code
+func (r *jobRunner) waitForExitCode(ctx context.Context, podName, namespace string) (int, error) {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+	for {
+		pod, err := r.client.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			return 0, err
+		}
+		if status := getJobContainerStatus(pod); status != nil && status.State.Terminated != nil {
+			return int(status.State.Terminated.ExitCode), nil
+		}
+		if pod.Status.Phase == apiv1.PodFailed {
+			return 0, errJobPodFailed
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func getJobContainerStatus(pod *apiv1.Pod) *apiv1.ContainerStatus {
+	for i := range pod.Status.ContainerStatuses {
+		if pod.Status.ContainerStatuses[i].Name == jobContainerName {
+			return &pod.Status.ContainerStatuses[i]
+		}
+	}
+	return nil
+}
+
+func isImagePullError(reason string) bool {
+	return reason == "ErrImagePull" || reason == "ImagePullBackOff" || reason == "InvalidImageName"
+}
+
+// isCreateContainerError returns true for the errors that don't go away by retrying, i.e. a secret or configmap that doesn't exist
+func isCreateContainerError(reason string) bool {
+	return reason == "CreateContainerConfigError" || reason == "CreateContainerError" || reason == "RunContainerError"
+}
+
+// getUnschedulableMessage returns the message of the scheduler if the pod can't be scheduled
+func getUnschedulableMessage(pod *apiv1.Pod) string {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == apiv1.PodScheduled && c.Status == apiv1.ConditionFalse && c.Reason == apiv1.PodReasonUnschedulable {
+			return c.Message
+		}
+	}
+	return ""
+}
+
+// translateJob translates a manifest job into a Kubernetes job that runs once
+func translateJob(name string, job *model.ManifestJob, namespace string) *batchv1.Job {
+	jobName := fmt.Sprintf("%s-%s", format.ResourceK8sMetaString(name), uuid.New().String()[:8])
+	labels := map[string]string{
+		model.RunJobLabel: format.ResourceK8sMetaString(name),
+	}
+	backoffLimit := int32(0)
+	ttl := jobTTLAfterFinished
+
+	env := []apiv1.EnvVar{}
+	for _, e := range job.Environment {
+		env = append(env, apiv1.EnvVar{Name: e.Name, Value: e.Value})
+	}
+
+	volumes := []apiv1.Volume{}
+	volumeMounts := []apiv1.VolumeMount{}
+	for i, m := range job.Mounts {
+		volumeName := fmt.Sprintf("mount-%d", i)
+		volume := apiv1.Volume{Name: volumeName}
+		switch {
+		case m.Secret != "":
+			volume.VolumeSource = apiv1.VolumeSource{Secret: &apiv1.SecretVolumeSource{SecretName: m.Secret}}
+		case m.ConfigMap != "":
+			volume.VolumeSource = apiv1.VolumeSource{ConfigMap: &apiv1.ConfigMapVolumeSource{LocalObjectReference: apiv1.LocalObjectReference{Name: m.ConfigMap}}}
+		default:
+			volume.VolumeSource = apiv1.VolumeSource{PersistentVolumeClaim: &apiv1.PersistentVolumeClaimVolumeSource{ClaimName: m.Volume}}
+		}
+		volumes = append(volumes, volume)
+		volumeMounts = append(volumeMounts, apiv1.VolumeMount{Name: volumeName, MountPath: m.Path})
+	}
+
+	k8sJob := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            &backoffLimit,
+			TTLSecondsAfterFinished: &ttl,
+			Template: apiv1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: apiv1.PodSpec{
+					RestartPolicy: apiv1.RestartPolicyNever,
+					Containers: []apiv1.Container{
+						{
+							Name:         jobContainerName,
+							Image:        job.Image,
+							Command:      job.Command.Values,
+							Env:          env,
+							VolumeMounts: volumeMounts,
+						},
+					},
+					Volumes: volumes,
+				},
+			},
+		},
+	}
+	if job.Timeout > 0 {
+		deadline := int64(job.Timeout.Seconds())
+		k8sJob.Spec.ActiveDeadlineSeconds = &deadline
+	}
+	return k8sJob
+}
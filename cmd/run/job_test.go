@@ -0,0 +1,198 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package run
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+// newFakeClientWithJobPod returns a fake client that creates the pod of every job created, with the given container state
+func newFakeClientWithJobPod(state apiv1.ContainerState) *fake.Clientset {
+	return newFakeClientWithJobPodStatus(apiv1.PodStatus{
+		ContainerStatuses: []apiv1.ContainerStatus{{Name: jobContainerName, State: state}},
+	})
+}
+
+// newFakeClientWithJobPodStatus returns a fake client that creates the pod of every job created, with the given status
+func newFakeClientWithJobPodStatus(status apiv1.PodStatus) *fake.Clientset {
+	c := fake.NewSimpleClientset()
+	c.PrependReactor("create", "jobs", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		job := action.(k8sTesting.CreateAction).GetObject().(*batchv1.Job)
+		pod := &apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      job.Name + "-pod",
+				Namespace: job.Namespace,
+				Labels:    map[string]string{jobNameLabel: job.Name},
+			},
+			Status: status,
+		}
+		return false, nil, c.Tracker().Add(pod)
+	})
+	return c
+}
+
+func TestJobRunnerRun(t *testing.T) {
+	tests := []struct {
+		name             string
+		state            apiv1.ContainerState
+		expectedExitCode int
+		expectedErr      bool
+	}{
+		{
+			name:  "succeeded",
+			state: apiv1.ContainerState{Terminated: &apiv1.ContainerStateTerminated{ExitCode: 0}},
+		},
+		{
+			name:             "failed",
+			state:            apiv1.ContainerState{Terminated: &apiv1.ContainerStateTerminated{ExitCode: 3}},
+			expectedExitCode: 3,
+		},
+		{
+			name:        "image pull error",
+			state:       apiv1.ContainerState{Waiting: &apiv1.ContainerStateWaiting{Reason: "ErrImagePull"}},
+			expectedErr: true,
+		},
+		{
+			name:        "create container config error",
+			state:       apiv1.ContainerState{Waiting: &apiv1.ContainerStateWaiting{Reason: "CreateContainerConfigError", Message: "secret \"db\" not found"}},
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newFakeClientWithJobPod(tt.state)
+			out := &bytes.Buffer{}
+			r := &jobRunner{client: c, out: out, pollInterval: time.Millisecond, startTimeout: time.Minute}
+			job := &model.ManifestJob{Image: "postgres:15", Command: model.Command{Values: []string{"psql"}}}
+
+			exitCode, err := r.run(context.Background(), "seed-db", job, "test")
+			if tt.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "fake logs", out.String())
+			}
+			assert.Equal(t, tt.expectedExitCode, exitCode)
+
+			jobList, err := c.BatchV1().Jobs("test").List(context.Background(), metav1.ListOptions{})
+			require.NoError(t, err)
+			assert.Empty(t, jobList.Items)
+		})
+	}
+}
+
+func TestJobRunnerRunTimeout(t *testing.T) {
+	c := newFakeClientWithJobPod(apiv1.ContainerState{Waiting: &apiv1.ContainerStateWaiting{Reason: "ContainerCreating"}})
+	r := &jobRunner{client: c, out: &bytes.Buffer{}, pollInterval: time.Millisecond, startTimeout: time.Minute}
+	job := &model.ManifestJob{Image: "postgres:15", Command: model.Command{Values: []string{"psql"}}, Timeout: 10 * time.Millisecond}
+
+	_, err := r.run(context.Background(), "seed-db", job, "test")
+	var uErr oktetoErrors.UserError
+	assert.True(t, errors.As(err, &uErr))
+}
+
+func TestJobRunnerRunUnschedulable(t *testing.T) {
+	c := newFakeClientWithJobPodStatus(apiv1.PodStatus{
+		Phase: apiv1.PodPending,
+		Conditions: []apiv1.PodCondition{
+			{
+				Type:    apiv1.PodScheduled,
+				Status:  apiv1.ConditionFalse,
+				Reason:  apiv1.PodReasonUnschedulable,
+				Message: "0/3 nodes are available: 3 Insufficient memory.",
+			},
+		},
+	})
+	r := &jobRunner{client: c, out: &bytes.Buffer{}, pollInterval: time.Millisecond, startTimeout: 10 * time.Millisecond}
+	job := &model.ManifestJob{Image: "postgres:15", Command: model.Command{Values: []string{"psql"}}}
+
+	_, err := r.run(context.Background(), "seed-db", job, "test")
+	assert.ErrorContains(t, err, "the pod of the job can't be scheduled: 0/3 nodes are available: 3 Insufficient memory.")
+}
+
+func TestTranslateJob(t *testing.T) {
+	job := &model.ManifestJob{
+		Image:       "postgres:15",
+		Command:     model.Command{Values: []string{"sh", "-c", "psql -f /seed/seed.sql"}},
+		Environment: model.Environment{{Name: "PGHOST", Value: "db"}},
+		Mounts: []model.JobMount{
+			{Path: "/seed", ConfigMap: "seed"},
+			{Path: "/creds", Secret: "creds"},
+			{Path: "/data", Volume: "data"},
+		},
+		Timeout: time.Minute,
+	}
+
+	result := translateJob("seed_db", job, "test")
+	assert.Regexp(t, "^seed-db-[a-f0-9]{8}$", result.Name)
+	assert.Equal(t, "test", result.Namespace)
+	assert.Equal(t, "seed-db", result.Labels[model.RunJobLabel])
+	assert.Equal(t, int32(0), *result.Spec.BackoffLimit)
+	assert.Equal(t, int64(60), *result.Spec.ActiveDeadlineSeconds)
+
+	spec := result.Spec.Template.Spec
+	assert.Equal(t, apiv1.RestartPolicyNever, spec.RestartPolicy)
+	require.Len(t, spec.Containers, 1)
+	assert.Equal(t, "postgres:15", spec.Containers[0].Image)
+	assert.Equal(t, []string{"sh", "-c", "psql -f /seed/seed.sql"}, spec.Containers[0].Command)
+	assert.Equal(t, []apiv1.EnvVar{{Name: "PGHOST", Value: "db"}}, spec.Containers[0].Env)
+	assert.Equal(t, []apiv1.VolumeMount{
+		{Name: "mount-0", MountPath: "/seed"},
+		{Name: "mount-1", MountPath: "/creds"},
+		{Name: "mount-2", MountPath: "/data"},
+	}, spec.Containers[0].VolumeMounts)
+	require.Len(t, spec.Volumes, 3)
+	assert.Equal(t, "seed", spec.Volumes[0].ConfigMap.Name)
+	assert.Equal(t, "creds", spec.Volumes[1].Secret.SecretName)
+	assert.Equal(t, "data", spec.Volumes[2].PersistentVolumeClaim.ClaimName)
+}
+
+func TestGetJob(t *testing.T) {
+	jobs := model.ManifestJobs{"seed-db": {Image: "postgres:15"}, "migrate": {Image: "migrate"}}
+
+	job, err := getJob(jobs, "seed-db")
+	require.NoError(t, err)
+	assert.Equal(t, "postgres:15", job.Image)
+
+	_, err = getJob(jobs, "unknown")
+	var uErr oktetoErrors.UserError
+	require.True(t, errors.As(err, &uErr))
+	assert.Equal(t, "The available jobs are: migrate, seed-db", uErr.Hint)
+
+	_, err = getJob(nil, "seed-db")
+	assert.Error(t, err)
+}
+
+func TestGetExitCodeError(t *testing.T) {
+	assert.NoError(t, getExitCodeError("seed-db", 0))
+
+	err := getExitCodeError("seed-db", 3)
+	assert.Equal(t, 3, oktetoErrors.GetExitCode(err))
+	assert.Equal(t, 1, oktetoErrors.GetExitCode(errors.New("error")))
+}
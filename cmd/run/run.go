@@ -0,0 +1,134 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package run
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/signals"
+	"github.com/spf13/cobra"
+)
+
+// Options defines the options for okteto run
+type Options struct {
+	ManifestPath string
+	Namespace    string
+	K8sContext   string
+	Variables    []string
+}
+
+// Run executes a job of the okteto manifest as a Kubernetes job in the namespace
+func Run(ctx context.Context) *cobra.Command {
+	options := &Options{}
+	cmd := &cobra.Command{
+		Use:   "run <job>",
+		Short: "Run a job defined in your okteto manifest",
+		Long: `Run a job defined in the 'jobs' section of your okteto manifest.
+
+The job runs as a Kubernetes job in your namespace. Its logs are streamed to your terminal,
+and okteto exits with the exit code of the job command.`,
+		Args: utils.ExactArgsAccepted(1, "https://okteto.com/docs/reference/cli/#run"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.SetVariables(options.Variables); err != nil {
+				return err
+			}
+
+			manifest, err := contextCMD.LoadManifestWithContext(ctx, contextCMD.ManifestOptions{Filename: options.ManifestPath, Namespace: options.Namespace, K8sContext: options.K8sContext})
+			if err != nil {
+				return err
+			}
+
+			name := args[0]
+			job, err := getJob(manifest.Jobs, name)
+			if err != nil {
+				return err
+			}
+
+			c, _, err := okteto.GetK8sClient()
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signals.NotifyContext(ctx)
+			defer stop()
+
+			oktetoLog.Information("Running job '%s' in namespace '%s'...", name, okteto.Context().Namespace)
+			r := newJobRunner(c, os.Stdout)
+			exitCode, err := r.run(ctx, name, job, okteto.Context().Namespace)
+			if err != nil {
+				return err
+			}
+			return getExitCodeError(name, exitCode)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.ManifestPath, "file", "f", "", "path to the okteto manifest file")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "namespace where the job runs")
+	cmd.Flags().StringVarP(&options.K8sContext, "context", "c", "", "context where the job runs")
+	cmd.Flags().StringArrayVarP(&options.Variables, "var", "v", []string{}, "set a variable available to the job (can be set more than once)")
+	return cmd
+}
+
+// getJob returns the job of the manifest with the given name
+func getJob(jobs model.ManifestJobs, name string) (*model.ManifestJob, error) {
+	if job, ok := jobs[name]; ok {
+		return job, nil
+	}
+	if len(jobs) == 0 {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("there are no jobs defined in your okteto manifest"),
+			Hint: "Define your jobs in the 'jobs' section of your okteto manifest",
+		}
+	}
+	names := []string{}
+	for n := range jobs {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return nil, oktetoErrors.UserError{
+		E:    fmt.Errorf("job '%s' is not defined in your okteto manifest", name),
+		Hint: fmt.Sprintf("The available jobs are: %s", strings.Join(names, ", ")),
+	}
+}
+
+// getExitCodeError returns the error that makes okteto exit with the exit code of the job
+func getExitCodeError(name string, exitCode int) error {
+	if exitCode == 0 {
+		oktetoLog.Success("Job '%s' finished successfully", name)
+		return nil
+	}
+	return oktetoErrors.ExitCodeError{
+		E:    fmt.Errorf("job '%s' failed with exit code %d", name, exitCode),
+		Code: exitCode,
+	}
+}
+
+// jobTimeoutError is returned when a job doesn't finish before its timeout
+func jobTimeoutError(name string, timeout time.Duration) error {
+	return oktetoErrors.UserError{
+		E:    fmt.Errorf("job '%s' didn't finish after %s", name, timeout.String()),
+		Hint: fmt.Sprintf("Increase the value of 'jobs.%s.timeout' in your okteto manifest", name),
+	}
+}
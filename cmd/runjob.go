@@ -0,0 +1,121 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/alessio/shellescape"
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/apps"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+// runJobFlags is the input of the user to run-job command
+type runJobFlags struct {
+	manifestPath string
+	namespace    string
+	k8sContext   string
+}
+
+// RunJob runs the command of a job or a cronjob in its development container
+func RunJob() *cobra.Command {
+	flags := &runJobFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "run-job [devContainer]",
+		Short: "Run the command of a job or a cronjob in its development container",
+		Long: `Run the command of a job or a cronjob in its development container.
+
+The schedule of the job is suspended while its development container is active.
+Use this command to run it on demand with your synchronized code.`,
+		Args: utils.MaximumNArgsAccepted(1, "https://okteto.com/docs/reference/cli/#run-job"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			manifestOpts := contextCMD.ManifestOptions{Filename: flags.manifestPath, Namespace: flags.namespace, K8sContext: flags.k8sContext}
+			manifest, err := contextCMD.LoadManifestWithContext(ctx, manifestOpts)
+			if err != nil {
+				return err
+			}
+
+			c, _, err := okteto.GetK8sClient()
+			if err != nil {
+				return err
+			}
+
+			devName := ""
+			if len(args) == 1 {
+				devName = args[0]
+			}
+			dev, err := utils.GetDevFromManifest(manifest, devName)
+			if err != nil {
+				if !errors.Is(err, utils.ErrNoDevSelected) {
+					return err
+				}
+				selector := utils.NewOktetoSelector("Select which development container to run:", "Development container")
+				dev, err = utils.SelectDevFromManifest(manifest, selector, apps.ListDevModeOn(ctx, manifest, c))
+				if err != nil {
+					return err
+				}
+			}
+
+			command, err := getJobCommand(ctx, dev, c)
+			if err != nil {
+				return err
+			}
+
+			oktetoLog.Information("Running '%s' in your development container...", command)
+			err = executeExec(ctx, dev, []string{command})
+			if oktetoErrors.IsNotFound(err) {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("development container not found in namespace '%s'", dev.Namespace),
+					Hint: "Run 'okteto up' to launch your development container or use 'okteto context' to change your current context",
+				}
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.manifestPath, "file", "f", utils.DefaultManifest, "path to the manifest file")
+	cmd.Flags().StringVarP(&flags.namespace, "namespace", "n", "", "namespace where the run-job command is executed")
+	cmd.Flags().StringVarP(&flags.k8sContext, "context", "c", "", "context where the run-job command is executed")
+
+	return cmd
+}
+
+// getJobCommand returns the shell command of the job or cronjob of a development container
+func getJobCommand(ctx context.Context, dev *model.Dev, c kubernetes.Interface) (string, error) {
+	app, err := apps.Get(ctx, dev, dev.Namespace, c)
+	if err != nil {
+		return "", err
+	}
+	command, err := apps.GetJobCommand(app, dev.Container)
+	if err != nil {
+		return "", oktetoErrors.UserError{
+			E:    err,
+			Hint: fmt.Sprintf("'okteto run-job' runs the command of the job or cronjob of the development container '%s'", dev.Name),
+		}
+	}
+	return shellescape.QuoteCommand(command), nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetJobCommand(t *testing.T) {
+	ctx := context.Background()
+	template := apiv1.PodTemplateSpec{
+		Spec: apiv1.PodSpec{
+			Containers: []apiv1.Container{
+				{Name: "report", Command: []string{"sh", "-c"}, Args: []string{"echo $(date) > /tmp/report"}},
+			},
+		},
+	}
+	cj := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "report", Namespace: "test"},
+		Spec: batchv1.CronJobSpec{
+			JobTemplate: batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: template}},
+		},
+	}
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "test"},
+		Spec:       appsv1.DeploymentSpec{Template: template},
+	}
+	c := fake.NewSimpleClientset(cj, d)
+
+	command, err := getJobCommand(ctx, &model.Dev{Name: "report", Namespace: "test"}, c)
+	require.NoError(t, err)
+	assert.Equal(t, `sh -c 'echo $(date) > /tmp/report'`, command)
+
+	_, err = getJobCommand(ctx, &model.Dev{Name: "api", Namespace: "test"}, c)
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+}
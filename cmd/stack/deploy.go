@@ -69,6 +69,7 @@ func deploy(ctx context.Context, at analyticsTrackerInterface) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			options.RolloutTimeout = okteto.GetTimeouts(nil).Rollout
 			dc := &DeployCommand{
 				K8sClient:        c,
 				Config:           config,
@@ -85,6 +86,8 @@ func deploy(ctx context.Context, at analyticsTrackerInterface) *cobra.Command {
 	cmd.Flags().BoolVarP(&options.NoCache, "no-cache", "", false, "do not use cache when building the image")
 	cmd.Flags().DurationVarP(&options.Timeout, "timeout", "t", (10 * time.Minute), "the length of time to wait for completion, zero means never. Any other values should contain a corresponding time unit e.g. 1s, 2m, 3h ")
 	cmd.Flags().StringVarP(&options.Progress, "progress", "", oktetoLog.TTYFormat, "show plain/tty build output (default \"tty\")")
+	cmd.Flags().BoolVarP(&options.WarningsAsErrors, "warnings-as-errors", "", false, "fail if the compose file has fields not supported by okteto")
+	cmd.Flags().StringArrayVarP(&options.Profiles, "profile", "", []string{}, "enable the compose services of a profile (can be set more than once)")
 	return cmd
 }
 
@@ -110,11 +113,11 @@ func (c *DeployCommand) RunDeploy(ctx context.Context, s *model.Stack, options *
 	analytics.TrackStackWarnings(s.Warnings.NotSupportedFields)
 
 	if len(options.ServicesToDeploy) == 0 {
-		definedServices := []string{}
-		for serviceName := range s.Services {
-			definedServices = append(definedServices, serviceName)
+		servicesToDeploy, err := stack.GetServicesEnabledByProfiles(s, options.Profiles)
+		if err != nil {
+			return err
 		}
-		options.ServicesToDeploy = definedServices
+		options.ServicesToDeploy = servicesToDeploy
 	}
 
 	stackDeployer := &stack.Stack{
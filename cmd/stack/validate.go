@@ -0,0 +1,55 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+// Compose compose management commands
+func Compose() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compose",
+		Short: "Compose management commands",
+		Args:  utils.NoArgsAccepted("https://www.okteto.com/docs/reference/compose/"),
+	}
+	cmd.AddCommand(Validate())
+	return cmd
+}
+
+// Validate checks that a compose file only uses the fields supported by okteto
+func Validate() *cobra.Command {
+	var stackPaths []string
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate a compose file and list the fields not supported by okteto",
+		Args:  utils.NoArgsAccepted("https://www.okteto.com/docs/reference/compose/"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := model.LoadStack("", loadComposePaths(stackPaths), true)
+			if err != nil {
+				return err
+			}
+			if err := s.ValidateNotSupportedFields(); err != nil {
+				return err
+			}
+			oktetoLog.Success("Compose file is valid")
+			return nil
+		},
+	}
+	cmd.Flags().StringArrayVarP(&stackPaths, "file", "f", []string{}, "path to the compose manifest files. If more than one is passed the latest will overwrite the fields from the previous")
+	return cmd
+}
@@ -16,8 +16,8 @@ package cmd
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
-	"os/signal"
 	"time"
 
 	contextCMD "github.com/okteto/okteto/cmd/context"
@@ -26,8 +26,10 @@ import (
 	"github.com/okteto/okteto/pkg/cmd/status"
 	"github.com/okteto/okteto/pkg/config"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/events"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/signals"
 	"github.com/okteto/okteto/pkg/syncthing"
 	"github.com/spf13/cobra"
 )
@@ -39,6 +41,8 @@ func Status() *cobra.Command {
 	var k8sContext string
 	var showInfo bool
 	var watch bool
+	var showEvents bool
+	var showProvenance bool
 	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Status of the synchronization process",
@@ -57,6 +61,14 @@ func Status() *cobra.Command {
 				return err
 			}
 
+			if showProvenance {
+				c, _, err := okteto.GetK8sClient()
+				if err != nil {
+					return err
+				}
+				return printProvenance(ctx, os.Stdout, c, okteto.Context().Namespace, manifest.Name)
+			}
+
 			devName := ""
 			if len(args) == 1 {
 				devName = args[0]
@@ -73,6 +85,10 @@ func Status() *cobra.Command {
 				}
 			}
 
+			if showEvents {
+				return printSessionEvents(dev.Name)
+			}
+
 			waitForStates := []config.UpState{config.Synchronizing, config.Ready}
 			if err := status.Wait(dev, waitForStates); err != nil {
 				return err
@@ -105,9 +121,48 @@ func Status() *cobra.Command {
 	cmd.Flags().StringVarP(&k8sContext, "context", "c", "", "context where the up command is executing")
 	cmd.Flags().BoolVarP(&showInfo, "info", "i", false, "show syncthing links for troubleshooting the synchronization service")
 	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "watch for changes")
+	cmd.Flags().BoolVarP(&showEvents, "events", "", false, "show the events of the last 'okteto up' session of the development container")
+	cmd.Flags().BoolVarP(&showProvenance, "provenance", "", false, "show the git branch and commit deployed in each workload of the development environment")
 	return cmd
 }
 
+func printSessionEvents(devName string) error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	dir := events.GetSessionsDir(wd)
+	ids, err := events.List(dir, devName)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("there are no 'okteto up' sessions of '%s' in this folder", devName),
+			Hint: "Session events are stored in the '.okteto/sessions' folder of the folder where 'okteto up' runs",
+		}
+	}
+
+	id := ids[len(ids)-1]
+	sessionEvents, err := events.Read(dir, id)
+	if err != nil {
+		return err
+	}
+	oktetoLog.Information("Events of session '%s':", id)
+	for _, e := range sessionEvents {
+		oktetoLog.Println(formatSessionEvent(e))
+	}
+	return nil
+}
+
+func formatSessionEvent(e events.Event) string {
+	line := fmt.Sprintf("%s  %-10s  %s", e.Time.Local().Format(time.RFC3339), e.Type, e.Message)
+	if e.Error != "" {
+		line = fmt.Sprintf("%s: %s", line, e.Error)
+	}
+	return line
+}
+
 func runWithWatch(ctx context.Context, sy *syncthing.Syncthing) error {
 	textSpinner := "Synchronizing your files..."
 	oktetoLog.Spinner(textSpinner)
@@ -116,7 +171,7 @@ func runWithWatch(ctx context.Context, sy *syncthing.Syncthing) error {
 	defer oktetoLog.StopSpinner()
 
 	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt)
+	signals.Notify(stop)
 	exit := make(chan error, 1)
 
 	go func() {
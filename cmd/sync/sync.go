@@ -0,0 +1,85 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sync
+
+import (
+	"fmt"
+
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/syncthing"
+	"github.com/spf13/cobra"
+)
+
+// Sync manages the syncthing binary used by okteto to synchronize files
+func Sync() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Manage the file synchronization binary",
+		Long: fmt.Sprintf(`Manage the syncthing binary used by okteto to synchronize files.
+
+Set %s to pin the syncthing version, or %s to use a system-installed syncthing binary.`, model.SyncthingVersionEnvVar, model.SyncthingPathEnvVar),
+		Args: utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/"),
+	}
+	cmd.AddCommand(Upgrade())
+	cmd.AddCommand(Verify())
+	return cmd
+}
+
+// Upgrade downloads and installs the syncthing version used by okteto
+func Upgrade() *cobra.Command {
+	return &cobra.Command{
+		Use:   "upgrade",
+		Short: "Download and install the syncthing version used by okteto",
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if syncthing.IsSystemInstalled() {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("okteto is using the syncthing binary at '%s'", syncthing.GetBinaryPath()),
+					Hint: fmt.Sprintf("Upgrade it with your package manager or unset %s", model.SyncthingPathEnvVar),
+				}
+			}
+
+			version := syncthing.GetMinimumVersion()
+			oktetoLog.Information("Installing syncthing %s...", version.String())
+			if err := syncthing.Install(&utils.ProgressBar{}); err != nil {
+				return err
+			}
+			oktetoLog.Success("Syncthing %s installed at %s", version.String(), syncthing.GetBinaryPath())
+			return nil
+		},
+	}
+}
+
+// Verify checks the version and the checksum of the syncthing binary used by okteto
+func Verify() *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify",
+		Short: "Verify the syncthing binary used by okteto",
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := syncthing.Verify(); err != nil {
+				hint := "Run 'okteto sync upgrade' to install the syncthing version used by okteto"
+				if syncthing.IsSystemInstalled() {
+					hint = fmt.Sprintf("Upgrade it with your package manager or unset %s", model.SyncthingPathEnvVar)
+				}
+				return oktetoErrors.UserError{E: err, Hint: hint}
+			}
+			oktetoLog.Success("Syncthing %s at %s is valid", syncthing.GetInstalledVersion().String(), syncthing.GetBinaryPath())
+			return nil
+		},
+	}
+}
@@ -0,0 +1,333 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	builder "github.com/okteto/okteto/cmd/build"
+	remoteBuild "github.com/okteto/okteto/cmd/build/remote"
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/filesystem"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/remote"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
+)
+
+const (
+	templateName           = "dockerfile"
+	dockerfileTemporalName = "Dockerfile.test"
+	testScriptArgName      = "OKTETO_TEST_SCRIPT"
+
+	// outputFolder is the folder of the runner exported to the local machine when the test finishes
+	outputFolder   = "/okteto/output"
+	artifactsDir   = "artifacts"
+	exitCodeFile   = "exit-code"
+	srcFolder      = "/okteto/src"
+	dockerfileTmpl = `
+FROM {{ .OktetoCLIImage }} as okteto-cli
+
+FROM {{ .TestImage }} as runner
+
+ENV PATH="${PATH}:/okteto/bin"
+COPY --from=okteto-cli /usr/local/bin/* /okteto/bin/
+
+ARG {{ .NamespaceArgName }}
+ARG {{ .ContextArgName }}
+ARG {{ .TokenArgName }}
+ARG {{ .TlsCertBase64ArgName }}
+RUN mkdir -p /etc/ssl/certs/
+RUN echo "${{ .TlsCertBase64ArgName }}" | base64 -d > /etc/ssl/certs/okteto.crt
+
+COPY . {{ .SrcFolder }}
+WORKDIR {{ .Workdir }}
+
+ARG {{ .InvalidateCacheArgName }}
+ARG {{ .TestScriptArgName }}
+
+RUN echo "${{ .TestScriptArgName }}" | base64 -d > /tmp/okteto-test.sh && sh /tmp/okteto-test.sh
+
+FROM scratch
+COPY --from=runner {{ .OutputFolder }}/ /
+`
+)
+
+type dockerfileTemplateProperties struct {
+	OktetoCLIImage         string
+	TestImage              string
+	ContextArgName         string
+	NamespaceArgName       string
+	TokenArgName           string
+	TlsCertBase64ArgName   string
+	InvalidateCacheArgName string
+	TestScriptArgName      string
+	SrcFolder              string
+	Workdir                string
+	OutputFolder           string
+}
+
+// remoteTestRunner runs a test suite as a build in the okteto builder and exports its artifacts
+type remoteTestRunner struct {
+	builder         builder.Builder
+	fs              afero.Fs
+	temporalCtrl    filesystem.TemporalDirectoryInterface
+	clusterMetadata func(context.Context) (*types.ClusterMetadata, error)
+}
+
+func newRemoteTestRunner() *remoteTestRunner {
+	fs := afero.NewOsFs()
+	return &remoteTestRunner{
+		builder:         remoteBuild.NewBuilderFromScratch(),
+		fs:              fs,
+		temporalCtrl:    filesystem.NewTemporalDirectoryCtrl(fs),
+		clusterMetadata: fetchClusterMetadata,
+	}
+}
+
+// run runs the test suite and returns its exit code. The artifacts are copied into the local test context
+func (r *remoteTestRunner) run(ctx context.Context, name string, test *model.Test, opts *Options) (int, error) {
+	sc, err := r.clusterMetadata(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	image := test.Image
+	if image == "" {
+		image = sc.PipelineRunnerImage
+	}
+
+	tmpDir, err := r.temporalCtrl.Create()
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err := r.fs.RemoveAll(tmpDir); err != nil {
+			oktetoLog.Infof("error removing temporal folder: %s", err)
+		}
+	}()
+
+	dockerfile, err := r.createDockerfile(tmpDir, image, test, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	outputDir := filepath.Join(tmpDir, "output")
+	if err := r.fs.MkdirAll(outputDir, 0700); err != nil {
+		return 0, err
+	}
+
+	randomNumber, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return 0, err
+	}
+
+	buildOptions := &types.BuildOptions{
+		File:            dockerfile,
+		Path:            opts.srcDir,
+		OutputMode:      oktetoLog.PlainFormat,
+		LocalOutputPath: outputDir,
+		BuildArgs: []string{
+			fmt.Sprintf("%s=%s", model.OktetoContextEnvVar, okteto.Context().Name),
+			fmt.Sprintf("%s=%s", model.OktetoNamespaceEnvVar, okteto.Context().Namespace),
+			fmt.Sprintf("%s=%s", model.OktetoTokenEnvVar, okteto.Context().Token),
+			fmt.Sprintf("%s=%s", constants.OktetoTlsCertBase64EnvVar, base64.StdEncoding.EncodeToString(sc.Certificate)),
+			fmt.Sprintf("%s=%d", constants.OktetoInvalidateCacheEnvVar, int(randomNumber.Int64())),
+			fmt.Sprintf("%s=%s", testScriptArgName, base64.StdEncoding.EncodeToString([]byte(getTestScript(test, opts.Variables)))),
+		},
+	}
+
+	if sc.ServerName != "" {
+		registryUrl := okteto.Context().Registry
+		subdomain := strings.TrimPrefix(registryUrl, "registry.")
+		ip, _, err := net.SplitHostPort(sc.ServerName)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse server name network address: %w", err)
+		}
+		buildOptions.ExtraHosts = []types.HostMap{
+			{Hostname: registryUrl, IP: ip},
+			{Hostname: fmt.Sprintf("kubernetes.%s", subdomain), IP: ip},
+		}
+	}
+
+	oktetoLog.Information("Running test '%s'...", name)
+	if err := r.builder.Build(ctx, buildOptions); err != nil {
+		return 0, fmt.Errorf("error running test '%s': %w", name, err)
+	}
+
+	if len(test.Artifacts) > 0 {
+		to := filepath.Join(opts.srcDir, test.Context)
+		if err := copyArtifacts(r.fs, filepath.Join(outputDir, artifactsDir), to); err != nil {
+			return 0, fmt.Errorf("error copying the artifacts of test '%s': %w", name, err)
+		}
+	}
+	return readExitCode(r.fs, filepath.Join(outputDir, exitCodeFile))
+}
+
+func (r *remoteTestRunner) createDockerfile(tmpDir, image string, test *model.Test, opts *Options) (string, error) {
+	tmpl := template.Must(template.New(templateName).Parse(dockerfileTmpl))
+
+	dockerfileSyntax := dockerfileTemplateProperties{
+		OktetoCLIImage:         getOktetoCLIVersion(config.VersionString),
+		TestImage:              image,
+		ContextArgName:         model.OktetoContextEnvVar,
+		NamespaceArgName:       model.OktetoNamespaceEnvVar,
+		TokenArgName:           model.OktetoTokenEnvVar,
+		TlsCertBase64ArgName:   constants.OktetoTlsCertBase64EnvVar,
+		InvalidateCacheArgName: constants.OktetoInvalidateCacheEnvVar,
+		TestScriptArgName:      testScriptArgName,
+		SrcFolder:              srcFolder,
+		Workdir:                path.Join(srcFolder, filepath.ToSlash(test.Context)),
+		OutputFolder:           outputFolder,
+	}
+
+	dockerfile, err := r.fs.Create(filepath.Join(tmpDir, dockerfileTemporalName))
+	if err != nil {
+		return "", err
+	}
+	defer dockerfile.Close()
+
+	// the .dockerignore of the source folder is used to build the services,
+	// so we create one for the runner in the same way remote deploy does
+	if err := remote.CreateDockerignoreFileWithFilesystem(opts.srcDir, tmpDir, opts.ManifestPath, r.fs); err != nil {
+		return "", err
+	}
+
+	if err := tmpl.Execute(dockerfile, dockerfileSyntax); err != nil {
+		return "", err
+	}
+	return dockerfile.Name(), nil
+}
+
+// getTestScript returns the script executed in the runner. The commands run in a subshell so
+// the exit code and the artifacts are exported even if a command fails
+func getTestScript(test *model.Test, variables []string) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("mkdir -p %s\n", path.Join(outputFolder, artifactsDir)))
+	for _, v := range variables {
+		name, value, _ := strings.Cut(v, "=")
+		sb.WriteString(fmt.Sprintf("export %s=%s\n", name, quote(value)))
+	}
+	sb.WriteString("(\n  set -e\n")
+	for _, c := range test.Commands {
+		sb.WriteString(fmt.Sprintf("  echo %s\n", quote(fmt.Sprintf("Running '%s'", c.Name))))
+		sb.WriteString(fmt.Sprintf("  %s\n", c.Command))
+	}
+	sb.WriteString(")\n")
+	sb.WriteString(fmt.Sprintf("echo $? > %s\n", path.Join(outputFolder, exitCodeFile)))
+	for _, a := range test.Artifacts {
+		artifact := quote(filepath.ToSlash(filepath.Clean(a)))
+		dst := fmt.Sprintf("%s/%s", path.Join(outputFolder, artifactsDir), artifact)
+		sb.WriteString(fmt.Sprintf("if [ -e %s ]; then mkdir -p \"$(dirname %s)\" && cp -r %s %s; fi\n", artifact, dst, artifact, dst))
+	}
+	return sb.String()
+}
+
+// quote quotes a value for the shell
+func quote(value string) string {
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(value, "'", `'"'"'`))
+}
+
+// copyArtifacts copies the artifacts exported by the runner into the local test context
+func copyArtifacts(fs afero.Fs, from, to string) error {
+	if _, err := fs.Stat(from); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return afero.Walk(fs, from, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(from, p)
+		if err != nil {
+			return err
+		}
+		dst := filepath.Join(to, rel)
+		if info.IsDir() {
+			return fs.MkdirAll(dst, 0755)
+		}
+		content, err := afero.ReadFile(fs, p)
+		if err != nil {
+			return err
+		}
+		oktetoLog.Infof("copying artifact '%s'", dst)
+		return afero.WriteFile(fs, dst, content, 0644)
+	})
+}
+
+func readExitCode(fs afero.Fs, file string) (int, error) {
+	content, err := afero.ReadFile(fs, file)
+	if err != nil {
+		return 0, fmt.Errorf("error reading the exit code of the test: %w", err)
+	}
+	code, err := strconv.Atoi(strings.TrimSpace(string(content)))
+	if err != nil {
+		return 0, fmt.Errorf("invalid exit code of the test '%s'", strings.TrimSpace(string(content)))
+	}
+	return code, nil
+}
+
+func getOktetoCLIVersion(versionString string) string {
+	var version string
+	if match, err := regexp.MatchString(`\d+\.\d+\.\d+`, versionString); match {
+		version = fmt.Sprintf(constants.OktetoCLIImageForRemoteTemplate, versionString)
+	} else {
+		oktetoLog.Infof("invalid version string: %s, using latest: %s", versionString, err)
+		remoteOktetoImage := os.Getenv(constants.OktetoDeployRemoteImage)
+		if remoteOktetoImage != "" {
+			version = remoteOktetoImage
+		} else {
+			version = fmt.Sprintf(constants.OktetoCLIImageForRemoteTemplate, "latest")
+		}
+	}
+
+	return version
+}
+
+func fetchClusterMetadata(ctx context.Context) (*types.ClusterMetadata, error) {
+	cp := okteto.NewOktetoClientProvider()
+	c, err := cp.Provide()
+	if err != nil {
+		return nil, fmt.Errorf("failed to provide okteto client for fetching certs: %s", err)
+	}
+	uc := c.User()
+
+	metadata, err := uc.GetClusterMetadata(ctx, okteto.Context().Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if metadata.Certificate == nil {
+		metadata.Certificate, err = uc.GetClusterCertificate(ctx, okteto.Context().Name, okteto.Context().Namespace)
+	}
+
+	return &metadata, err
+}
@@ -0,0 +1,176 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	filesystem "github.com/okteto/okteto/pkg/filesystem/fake"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBuilder struct {
+	err   error
+	build func(o *types.BuildOptions)
+}
+
+func (f fakeBuilder) Build(_ context.Context, opts *types.BuildOptions) error {
+	if f.build != nil {
+		f.build(opts)
+	}
+	return f.err
+}
+
+func (fakeBuilder) IsV1() bool { return true }
+
+func TestRemoteTestRunner(t *testing.T) {
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {
+				Name:      "test",
+				Namespace: "test",
+			},
+		},
+		CurrentContext: "test",
+	}
+	srcDir := filepath.Join("/", "src")
+	test := &model.Test{
+		Context:   "api",
+		Commands:  []model.DeployCommand{{Name: "make test", Command: "make test"}},
+		Artifacts: []string{"reports"},
+	}
+
+	var tests = []struct {
+		name         string
+		exitCode     string
+		builderErr   error
+		expectedCode int
+		expectedErr  bool
+	}{
+		{
+			name:         "passed",
+			exitCode:     "0\n",
+			expectedCode: 0,
+		},
+		{
+			name:         "failed",
+			exitCode:     "2\n",
+			expectedCode: 2,
+		},
+		{
+			name:        "build error",
+			builderErr:  assert.AnError,
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			r := &remoteTestRunner{
+				builder: fakeBuilder{
+					err: tt.builderErr,
+					build: func(o *types.BuildOptions) {
+						assert.Equal(t, srcDir, o.Path)
+						assert.Empty(t, o.Tag)
+						require.NotEmpty(t, o.LocalOutputPath)
+						require.NoError(t, afero.WriteFile(fs, filepath.Join(o.LocalOutputPath, exitCodeFile), []byte(tt.exitCode), 0600))
+						require.NoError(t, afero.WriteFile(fs, filepath.Join(o.LocalOutputPath, artifactsDir, "reports", "junit.xml"), []byte("<testsuites/>"), 0600))
+					},
+				},
+				fs:           fs,
+				temporalCtrl: filesystem.NewTemporalDirectoryCtrl(fs),
+				clusterMetadata: func(context.Context) (*types.ClusterMetadata, error) {
+					return &types.ClusterMetadata{PipelineRunnerImage: "okteto/pipeline-runner:1.0.0"}, nil
+				},
+			}
+
+			code, err := r.run(context.Background(), "unit", test, &Options{srcDir: srcDir})
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, code)
+			content, err := afero.ReadFile(fs, filepath.Join(srcDir, "api", "reports", "junit.xml"))
+			require.NoError(t, err)
+			assert.Equal(t, "<testsuites/>", string(content))
+		})
+	}
+}
+
+func TestCreateDockerfile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	r := &remoteTestRunner{fs: fs}
+	tmpDir := filepath.Join("/", "tmp")
+	require.NoError(t, fs.MkdirAll(tmpDir, 0700))
+
+	dockerfile, err := r.createDockerfile(tmpDir, "golang:1.20", &model.Test{Context: "api"}, &Options{srcDir: filepath.Join("/", "src")})
+	require.NoError(t, err)
+
+	content, err := afero.ReadFile(fs, dockerfile)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "FROM golang:1.20 as runner")
+	assert.Contains(t, string(content), "WORKDIR /okteto/src/api")
+	assert.Contains(t, string(content), "COPY --from=runner /okteto/output/ /")
+
+	_, err = fs.Stat(filepath.Join(tmpDir, ".dockerignore"))
+	assert.NoError(t, err)
+}
+
+func TestGetTestScript(t *testing.T) {
+	test := &model.Test{
+		Commands: []model.DeployCommand{
+			{Name: "unit", Command: "go test ./..."},
+			{Name: "coverage", Command: "go tool cover -html=cover.out -o reports/cover.html"},
+		},
+		Artifacts: []string{"reports/", "junit.xml"},
+	}
+	expected := `mkdir -p /okteto/output/artifacts
+export NAME='it'"'"'s me'
+(
+  set -e
+  echo 'Running '"'"'unit'"'"''
+  go test ./...
+  echo 'Running '"'"'coverage'"'"''
+  go tool cover -html=cover.out -o reports/cover.html
+)
+echo $? > /okteto/output/exit-code
+if [ -e 'reports' ]; then mkdir -p "$(dirname /okteto/output/artifacts/'reports')" && cp -r 'reports' /okteto/output/artifacts/'reports'; fi
+if [ -e 'junit.xml' ]; then mkdir -p "$(dirname /okteto/output/artifacts/'junit.xml')" && cp -r 'junit.xml' /okteto/output/artifacts/'junit.xml'; fi
+`
+	assert.Equal(t, expected, getTestScript(test, []string{"NAME=it's me"}))
+}
+
+func TestReadExitCode(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/valid", []byte("1\n"), 0600))
+	require.NoError(t, afero.WriteFile(fs, "/invalid", []byte("error"), 0600))
+
+	code, err := readExitCode(fs, "/valid")
+	require.NoError(t, err)
+	assert.Equal(t, 1, code)
+
+	_, err = readExitCode(fs, "/invalid")
+	assert.Error(t, err)
+
+	_, err = readExitCode(fs, "/not-found")
+	assert.Error(t, err)
+}
@@ -0,0 +1,204 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/analytics"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/cobra"
+)
+
+const (
+	statusPassed  = "passed"
+	statusFailed  = "failed"
+	statusError   = "error"
+	statusSkipped = "skipped"
+)
+
+// Options defines the options for okteto test
+type Options struct {
+	ManifestPath string
+	Namespace    string
+	K8sContext   string
+	Variables    []string
+
+	// srcDir is the folder of the okteto manifest, the test contexts are relative to it
+	srcDir string
+}
+
+// testRunner runs a test suite and returns its exit code
+type testRunner interface {
+	run(ctx context.Context, name string, test *model.Test, opts *Options) (int, error)
+}
+
+// testResult is the result of running a test suite
+type testResult struct {
+	name     string
+	status   string
+	duration time.Duration
+	err      error
+}
+
+// Test runs the test suites defined in the okteto manifest
+func Test(ctx context.Context) *cobra.Command {
+	options := &Options{}
+	cmd := &cobra.Command{
+		Use:   "test [suite...]",
+		Short: "Run the test suites defined in your okteto manifest",
+		Long: `Run the test suites defined in the 'test' section of your okteto manifest.
+
+The suites run remotely in your namespace, in the order defined by their dependencies.
+The artifacts of each suite, like JUnit reports or coverage files, are copied into your local folder when the suite finishes.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.SetVariables(options.Variables); err != nil {
+				return err
+			}
+
+			manifest, err := contextCMD.LoadManifestWithContext(ctx, contextCMD.ManifestOptions{Filename: options.ManifestPath, Namespace: options.Namespace, K8sContext: options.K8sContext})
+			if err != nil {
+				return err
+			}
+			if !okteto.IsOkteto() {
+				return oktetoErrors.ErrContextIsNotOktetoCluster
+			}
+			if len(manifest.Test) == 0 {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("there are no tests defined in your okteto manifest"),
+					Hint: "Define your test suites in the 'test' section of your okteto manifest",
+				}
+			}
+
+			names, err := manifest.Test.GetTestsToRun(args)
+			if err != nil {
+				return err
+			}
+
+			options.srcDir, err = getSrcDir(options.ManifestPath)
+			if err != nil {
+				return err
+			}
+
+			results := runTests(ctx, newRemoteTestRunner(), manifest.Test, names, options)
+			printResults(os.Stdout, results)
+			err = getResultsError(results)
+			analytics.TrackTest(err == nil)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.ManifestPath, "file", "f", "", "path to the okteto manifest file")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "namespace where the tests run")
+	cmd.Flags().StringVarP(&options.K8sContext, "context", "c", "", "context where the tests run")
+	cmd.Flags().StringArrayVarP(&options.Variables, "var", "v", []string{}, "set a variable available to the tests (can be set more than once)")
+	return cmd
+}
+
+// getSrcDir returns the absolute path of the folder of the okteto manifest
+func getSrcDir(manifestPath string) (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	if manifestPath == "" {
+		return cwd, nil
+	}
+	if filepath.IsAbs(manifestPath) {
+		return filepath.Dir(manifestPath), nil
+	}
+	return filepath.Join(cwd, filepath.Dir(manifestPath)), nil
+}
+
+// runTests runs the test suites in order. A suite is skipped if any of its dependencies doesn't pass
+func runTests(ctx context.Context, runner testRunner, tests model.ManifestTests, names []string, opts *Options) []testResult {
+	results := []testResult{}
+	passed := map[string]bool{}
+	for _, name := range names {
+		test := tests[name]
+		result := testResult{name: name}
+
+		for _, dependency := range test.DependsOn {
+			if !passed[dependency] {
+				result.status = statusSkipped
+				break
+			}
+		}
+		if result.status == statusSkipped {
+			oktetoLog.Warning("Skipping test '%s' because its dependencies didn't pass", name)
+			results = append(results, result)
+			continue
+		}
+
+		start := time.Now()
+		exitCode, err := runner.run(ctx, name, test, opts)
+		result.duration = time.Since(start)
+		switch {
+		case err != nil:
+			result.status = statusError
+			result.err = err
+			oktetoLog.Fail("%s", err.Error())
+		case exitCode != 0:
+			result.status = statusFailed
+			oktetoLog.Fail("Test '%s' failed with exit code %d", name, exitCode)
+		default:
+			result.status = statusPassed
+			passed[name] = true
+			oktetoLog.Success("Test '%s' passed", name)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func printResults(out io.Writer, results []testResult) {
+	w := tabwriter.NewWriter(out, 1, 1, 2, ' ', 0)
+	fmt.Fprintf(w, "Suite\tStatus\tDuration\n")
+	for _, r := range results {
+		duration := "-"
+		if r.status != statusSkipped {
+			duration = r.duration.Round(time.Second).String()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", r.name, r.status, duration)
+	}
+	w.Flush()
+}
+
+func getResultsError(results []testResult) error {
+	failed := []string{}
+	for _, r := range results {
+		if r.status != statusPassed {
+			failed = append(failed, r.name)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return oktetoErrors.UserError{
+		E:    fmt.Errorf("%d of %d test suites didn't pass: %s", len(failed), len(results), strings.Join(failed, ", ")),
+		Hint: "Check the logs of the failed test suites above",
+	}
+}
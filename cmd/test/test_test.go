@@ -0,0 +1,95 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTestRunner struct {
+	exitCodes map[string]int
+	errors    map[string]error
+	ran       []string
+}
+
+func (f *fakeTestRunner) run(_ context.Context, name string, _ *model.Test, _ *Options) (int, error) {
+	f.ran = append(f.ran, name)
+	return f.exitCodes[name], f.errors[name]
+}
+
+func TestRunTests(t *testing.T) {
+	tests := model.ManifestTests{
+		"unit":        {Commands: []model.DeployCommand{{Command: "make unit"}}},
+		"integration": {Commands: []model.DeployCommand{{Command: "make integration"}}, DependsOn: []string{"unit"}},
+		"e2e":         {Commands: []model.DeployCommand{{Command: "make e2e"}}, DependsOn: []string{"integration"}},
+		"lint":        {Commands: []model.DeployCommand{{Command: "make lint"}}},
+	}
+	runner := &fakeTestRunner{
+		exitCodes: map[string]int{"integration": 1},
+		errors:    map[string]error{"lint": assert.AnError},
+	}
+
+	results := runTests(context.Background(), runner, tests, []string{"lint", "unit", "integration", "e2e"}, &Options{})
+	assert.Equal(t, []string{"lint", "unit", "integration"}, runner.ran)
+
+	statuses := map[string]string{}
+	for _, r := range results {
+		statuses[r.name] = r.status
+	}
+	assert.Equal(t, map[string]string{
+		"lint":        statusError,
+		"unit":        statusPassed,
+		"integration": statusFailed,
+		"e2e":         statusSkipped,
+	}, statuses)
+
+	err := getResultsError(results)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "3 of 4 test suites didn't pass: lint, integration, e2e")
+}
+
+func TestGetResultsErrorAllPassed(t *testing.T) {
+	assert.NoError(t, getResultsError([]testResult{{name: "unit", status: statusPassed}}))
+}
+
+func TestPrintResults(t *testing.T) {
+	out := &bytes.Buffer{}
+	printResults(out, []testResult{
+		{name: "unit", status: statusPassed, duration: 65e8},
+		{name: "e2e", status: statusSkipped},
+	})
+	expected := "Suite  Status   Duration\nunit   passed   7s\ne2e    skipped  -\n"
+	assert.Equal(t, expected, out.String())
+}
+
+func TestGetSrcDir(t *testing.T) {
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+
+	dir, err := getSrcDir("")
+	require.NoError(t, err)
+	assert.Equal(t, cwd, dir)
+
+	dir, err = getSrcDir(filepath.Join("api", "okteto.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(cwd, "api"), dir)
+}
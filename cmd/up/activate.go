@@ -18,30 +18,36 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/constants"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/events"
 	"github.com/okteto/okteto/pkg/k8s/apps"
 	"github.com/okteto/okteto/pkg/k8s/pods"
 	"github.com/okteto/okteto/pkg/k8s/secrets"
 	"github.com/okteto/okteto/pkg/k8s/services"
 	"github.com/okteto/okteto/pkg/k8s/volumes"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	localMetrics "github.com/okteto/okteto/pkg/metrics"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	oktetoTypes "github.com/okteto/okteto/pkg/types"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
-func (up *upContext) activate() error {
+func (up *upContext) activate() (err error) {
 
 	oktetoLog.Infof("activating development container retry=%t", up.isRetry)
+	isFirstActivation := !up.isRetry
+	up.events.Record(events.Activation, fmt.Sprintf("Activating development container '%s'", up.Dev.Name), nil)
 
-	if err := config.UpdateStateFile(up.Dev.Name, up.Dev.Namespace, config.Activating); err != nil {
+	if err := config.UpdateStateFileWithFilesystem(up.Dev.Name, up.Dev.Namespace, config.Activating, up.Fs); err != nil {
 		return err
 	}
 
@@ -51,6 +57,26 @@ func (up *upContext) activate() error {
 	up.ShutdownCompleted = make(chan bool, 1)
 	up.Sy = nil
 	up.Forwarder = nil
+
+	// the activation timeout covers the whole activation, until the development container is ready and synchronized
+	timeout := up.getTimeouts().Activation
+	var timedOut atomic.Bool
+	activationTimer := time.AfterFunc(timeout, func() {
+		oktetoLog.Infof("the activation didn't finish after %s, cancelling it", timeout)
+		timedOut.Store(true)
+		cancel()
+	})
+	defer func() {
+		activationTimer.Stop()
+		if !timedOut.Load() || err == nil {
+			return
+		}
+		err = oktetoErrors.UserError{
+			E:    fmt.Errorf("development container '%s' wasn't ready after %s", up.Dev.Name, timeout),
+			Hint: "Increase 'timeouts.activation' in your okteto manifest or okteto context and try again",
+		}
+	}()
+
 	defer func() {
 		if up.Dev.IsHybridModeEnabled() {
 			// interrupt signal handler already performs a graceful shutdown
@@ -83,7 +109,7 @@ func (up *upContext) activate() error {
 		}
 	}
 
-	if up.isRetry && !apps.IsDevModeOn(app) {
+	if up.isRetry && !apps.IsDevModeActive(ctx, app, k8sClient) {
 		if up.Dev.IsHybridModeEnabled() {
 			up.shutdownHybridMode()
 		}
@@ -151,10 +177,17 @@ func (up *upContext) activate() error {
 	if up.isRetry {
 		if lastPodUID != up.Pod.UID {
 			up.analyticsMeta.ReconnectDevPodRecreated()
+			up.metrics.recordReconnect(reconnectCauseDevPodRecreated)
+			up.events.Record(events.Reconnect, fmt.Sprintf("Reconnected to the recreated development pod '%s'", up.Pod.Name), nil)
 		} else {
 			up.analyticsMeta.ReconnectDefault()
+			up.metrics.recordReconnect(reconnectCauseDefault)
+			up.events.Record(events.Reconnect, fmt.Sprintf("Reconnected to the development pod '%s'", up.Pod.Name), nil)
 		}
+	} else {
+		up.events.Record(events.Activation, fmt.Sprintf("Development container running in pod '%s'", up.Pod.Name), nil)
 	}
+	up.metrics.setPod(up.Pod)
 
 	up.isRetry = true
 
@@ -172,6 +205,10 @@ func (up *upContext) activate() error {
 	}
 	go up.cleanCommand(ctx)
 
+	if isFirstActivation && !up.Dev.IsHybridModeEnabled() {
+		up.checkCaseConflicts(ctx)
+	}
+
 	if err := up.sync(ctx); err != nil {
 		if up.shouldRetry(ctx, err) {
 			return oktetoErrors.ErrLostSyncthing
@@ -181,6 +218,13 @@ func (up *upContext) activate() error {
 
 	// success means all context is ready to run the activation
 	up.success = true
+	activationTimer.Stop()
+
+	go up.watchRepositoryHead(ctx)
+
+	if up.Options.Share {
+		up.shareSession(ctx)
+	}
 
 	go func() {
 		output := <-up.cleaned
@@ -210,12 +254,32 @@ func (up *upContext) activate() error {
 			}
 
 		}
-		printDisplayContext(up)
+		if !up.isRetry && up.Dev.Lifecycle != nil && up.Dev.Lifecycle.PostSync != nil {
+			if err := up.runPostSyncHook(ctx); err != nil {
+				up.events.Record(events.Error, "postSync hook failed", err)
+				oktetoLog.Warning("postSync hook failed: %s", err)
+			}
+		}
+
+		if up.dashboard == nil {
+			printDisplayContext(up)
+		}
 		durationActivateUp := time.Since(up.StartTime)
 		up.analyticsMeta.ActivateDuration(durationActivateUp)
+		if isFirstActivation {
+			localMetrics.Observe(localMetrics.UpActivation, durationActivateUp)
+			up.notifyActivation(ctx)
+		}
 
 		startRunCommand := time.Now()
-		up.CommandResult <- up.RunCommand(ctx, up.Dev.Command.Values)
+		if up.dashboard != nil {
+			up.dashboard.SetStatus("Running '%s'", strings.Join(up.Dev.Command.Values, " "))
+			up.CommandResult <- up.dashboard.runCommand(ctx, func(cmdCtx context.Context) error {
+				return up.RunCommand(cmdCtx, up.Dev.Command.Values)
+			})
+		} else {
+			up.CommandResult <- up.RunCommand(ctx, up.Dev.Command.Values)
+		}
 		up.analyticsMeta.ExecDuration(time.Since(startRunCommand))
 
 	}()
@@ -223,6 +287,7 @@ func (up *upContext) activate() error {
 	prevError := up.waitUntilExitOrInterruptOrApply(ctx)
 
 	if up.shouldRetry(ctx, prevError) {
+		up.events.Record(events.Reconnect, "Connection lost to the development container", prevError)
 		if !up.Dev.PersistentVolumeEnabled() {
 			if err := pods.Destroy(ctx, up.Pod.Name, up.Dev.Namespace, k8sClient); err != nil {
 				return err
@@ -267,7 +332,7 @@ func (up *upContext) createDevContainer(ctx context.Context, app apps.App, creat
 	oktetoLog.StartSpinner()
 	defer oktetoLog.StopSpinner()
 
-	if err := config.UpdateStateFile(up.Dev.Name, up.Dev.Namespace, config.Starting); err != nil {
+	if err := config.UpdateStateFileWithFilesystem(up.Dev.Name, up.Dev.Namespace, config.Starting, up.Fs); err != nil {
 		return err
 	}
 
@@ -298,8 +363,13 @@ func (up *upContext) createDevContainer(ctx context.Context, app apps.App, creat
 		return initSyncErr
 	}
 
+	platformSecrets, err := up.getPlatformSecrets(ctx)
+	if err != nil {
+		return err
+	}
+
 	oktetoLog.Info("create deployment secrets")
-	if err := secrets.Create(ctx, up.Dev, k8sClient, up.Sy); err != nil {
+	if err := secrets.Create(ctx, up.Dev, k8sClient, up.Sy, platformSecrets); err != nil {
 		return err
 	}
 
@@ -309,8 +379,10 @@ func (up *upContext) createDevContainer(ctx context.Context, app apps.App, creat
 		if err := tr.DevApp.Deploy(ctx, k8sClient); err != nil {
 			return err
 		}
-		if err := tr.App.Deploy(ctx, k8sClient); err != nil {
-			return err
+		if tr.ModifiesApp() {
+			if err := tr.App.Deploy(ctx, k8sClient); err != nil {
+				return err
+			}
 		}
 		if tr.MainDev == tr.Dev {
 			devApp = tr.DevApp
@@ -323,7 +395,7 @@ func (up *upContext) createDevContainer(ctx context.Context, app apps.App, creat
 		}
 	}
 
-	pod, err := apps.GetRunningPodInLoop(ctx, up.Dev, devApp, k8sClient)
+	pod, err := apps.GetRunningPodInLoop(ctx, up.Dev, devApp, up.getTimeouts().APIRetries, k8sClient)
 	if err != nil {
 		return err
 	}
@@ -333,13 +405,68 @@ func (up *upContext) createDevContainer(ctx context.Context, app apps.App, creat
 	return nil
 }
 
+// getPlatformSecrets returns the values of the secrets from the Okteto platform used by the development container.
+// Secrets are looked up in the secrets of the user and in the secrets of the namespace of the development container.
+// The values are only stored in the okteto secret of the development container, never in the manifest
+func (up *upContext) getPlatformSecrets(ctx context.Context) (map[string]string, error) {
+	if !up.Dev.HasPlatformSecrets() {
+		return nil, nil
+	}
+	if !okteto.IsOkteto() {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("secrets with 'fromPlatform' are only supported in Okteto contexts"),
+			Hint: "Run 'okteto context' to select an Okteto context or remove 'fromPlatform' from your okteto manifest",
+		}
+	}
+	oc, err := okteto.NewOktetoClient()
+	if err != nil {
+		return nil, err
+	}
+	userSecrets, err := oc.User().GetUserSecrets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the secrets from the Okteto platform: %w", err)
+	}
+	namespaceSecrets, err := oc.User().GetNamespaceSecrets(ctx, up.Dev.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the secrets of namespace '%s' from the Okteto platform: %w", up.Dev.Namespace, err)
+	}
+	return getPlatformSecretValues(up.Dev, namespaceSecrets, userSecrets)
+}
+
+// getPlatformSecretValues returns the values of the platform secrets of the development container.
+// The secrets of the user take precedence over the secrets of the namespace with the same name
+func getPlatformSecretValues(dev *model.Dev, namespaceSecrets, userSecrets []oktetoTypes.Secret) (map[string]string, error) {
+	available := map[string]string{}
+	for _, s := range namespaceSecrets {
+		available[s.Name] = s.Value
+	}
+	for _, s := range userSecrets {
+		available[s.Name] = s.Value
+	}
+	result := map[string]string{}
+	for _, s := range dev.Secrets {
+		if s.FromPlatform == "" {
+			continue
+		}
+		value, ok := available[s.FromPlatform]
+		if !ok {
+			return nil, oktetoErrors.UserError{
+				E:    fmt.Errorf("secret '%s' not found in the Okteto platform", s.FromPlatform),
+				Hint: "Add it to your secrets or to the secrets of the namespace in the Okteto UI and try again",
+			}
+		}
+		result[s.FromPlatform] = value
+	}
+	return result, nil
+}
+
 func (up *upContext) waitUntilDevelopmentContainerIsRunning(ctx context.Context, app apps.App) error {
 	msg := "Preparing development environment..."
 	if !up.Dev.IsHybridModeEnabled() {
 		msg = "Pulling images..."
 		if up.Dev.PersistentVolumeEnabled() {
 			msg = "Attaching persistent volume..."
-			if err := config.UpdateStateFile(up.Dev.Name, up.Dev.Namespace, config.Attaching); err != nil {
+			if err := config.UpdateStateFileWithFilesystem(up.Dev.Name, up.Dev.Namespace, config.Attaching, up.Fs); err != nil {
 				oktetoLog.Infof("error updating state: %s", err.Error())
 			}
 		}
@@ -426,6 +553,10 @@ func (up *upContext) waitUntilDevelopmentContainerIsRunning(ctx context.Context,
 				oktetoLog.Spinner("Waiting for the cluster to scale up...")
 				continue
 			case "Failed", "FailedCreatePodSandBox", "ErrImageNeverPull", "InspectFailed", "FailedCreatePodContainer":
+				if image := getImageFromPullEvent(e); image != "" {
+					diagnoser := &imagePullDiagnoser{registry: up.Registry, k8sClient: k8sClient}
+					return diagnoser.diagnose(ctx, up.Pod, image, e.Message)
+				}
 				if strings.Contains(e.Message, "pod has unbound immediate PersistentVolumeClaims") {
 					continue
 				}
@@ -441,6 +572,18 @@ func (up *upContext) waitUntilDevelopmentContainerIsRunning(ctx context.Context,
 					}
 				}
 				return fmt.Errorf(e.Message)
+			case "BackOff":
+				if !strings.Contains(e.Message, "restarting failed container") {
+					continue
+				}
+				pod, err := k8sClient.CoreV1().Pods(up.Dev.Namespace).Get(ctx, up.Pod.Name, metav1.GetOptions{})
+				if err != nil {
+					oktetoLog.Infof("error getting the development pod: %s", err)
+					continue
+				}
+				if status, reason, ok := getCrashedContainer(pod); ok {
+					return crashedContainerError(ctx, k8sClient, pod, status, reason)
+				}
 			case "SuccessfulAttachVolume":
 				failedSchedulingEvent = nil
 				oktetoLog.Success("Persistent volume successfully attached")
@@ -460,7 +603,7 @@ func (up *upContext) waitUntilDevelopmentContainerIsRunning(ctx context.Context,
 				failedSchedulingEvent = nil
 				message := getPullingMessage(e.Message, up.Dev.Namespace)
 				oktetoLog.Spinner(fmt.Sprintf("%s...", message))
-				if err := config.UpdateStateFile(up.Dev.Name, up.Dev.Namespace, config.Pulling); err != nil {
+				if err := config.UpdateStateFileWithFilesystem(up.Dev.Name, up.Dev.Namespace, config.Pulling, up.Fs); err != nil {
 					oktetoLog.Infof("error updating state: %s", err.Error())
 				}
 			}
@@ -481,6 +624,9 @@ func (up *upContext) waitUntilDevelopmentContainerIsRunning(ctx context.Context,
 			}
 
 			oktetoLog.Infof("dev pod %s is now %s", pod.Name, pod.Status.Phase)
+			if status, reason, ok := getCrashedContainer(pod); ok {
+				return crashedContainerError(ctx, k8sClient, pod, status, reason)
+			}
 			if pod.Status.Phase == apiv1.PodRunning {
 				if !up.Dev.IsHybridModeEnabled() {
 					oktetoLog.Success("Images successfully pulled")
@@ -490,6 +636,10 @@ func (up *upContext) waitUntilDevelopmentContainerIsRunning(ctx context.Context,
 			if pod.DeletionTimestamp != nil {
 				return oktetoErrors.ErrDevPodDeleted
 			}
+			if image, message, ok := getImagePullFailure(pod); ok {
+				diagnoser := &imagePullDiagnoser{registry: up.Registry, k8sClient: k8sClient}
+				return diagnoser.diagnose(ctx, pod, image, message)
+			}
 		case <-ctx.Done():
 			oktetoLog.Debug("call to waitUntilDevelopmentContainerIsRunning cancelled")
 			return ctx.Err()
@@ -532,20 +682,16 @@ func (up *upContext) waitUntilAppIsAwaken(ctx context.Context, app apps.App) err
 		return nil
 	}
 
-	timeout := 5 * time.Minute
-	to := time.NewTicker(timeout)
 	ticker := time.NewTicker(2 * time.Second)
-	defer to.Stop()
 	defer ticker.Stop()
 	oktetoLog.Spinner(fmt.Sprintf("Dev environment '%s' is sleeping. Waiting for it to wake up...", appToCheck.ObjectMeta().Name))
 	oktetoLog.StartSpinner()
 	defer oktetoLog.StopSpinner()
 	for {
 		select {
-		case <-to.C:
-			// In case of timeout, we just print a warning to avoid the command to fail
-			oktetoLog.Warning("Dev environment '%s' didn't wake up after %s", appToCheck.ObjectMeta().Name, timeout.String())
-			return nil
+		// the wake up is bounded by the activation timeout, that cancels ctx
+		case <-ctx.Done():
+			return ctx.Err()
 		case <-ticker.C:
 			if err := appToCheck.Refresh(ctx, k8sClient); err != nil {
 				return err
@@ -7,6 +7,7 @@ import (
 	"github.com/okteto/okteto/internal/test"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	oktetoTypes "github.com/okteto/okteto/pkg/types"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/client-go/tools/clientcmd/api"
 )
@@ -87,3 +88,30 @@ func TestWaitUntilDevelopmentContainerIsRunning(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPlatformSecretValues(t *testing.T) {
+	userSecrets := []oktetoTypes.Secret{
+		{Name: "GITHUB_TOKEN", Value: "token"},
+		{Name: "NPMRC", Value: "registry=https://registry.npmjs.org"},
+	}
+	namespaceSecrets := []oktetoTypes.Secret{
+		{Name: "GITHUB_TOKEN", Value: "namespace-token"},
+		{Name: "DB_PASSWORD", Value: "password"},
+	}
+
+	dev := &model.Dev{
+		Secrets: []model.Secret{
+			{LocalPath: "/local", RemotePath: "/remote"},
+			{FromPlatform: "GITHUB_TOKEN"},
+			{FromPlatform: "NPMRC", RemotePath: "/root/.npmrc"},
+			{FromPlatform: "DB_PASSWORD"},
+		},
+	}
+	values, err := getPlatformSecretValues(dev, namespaceSecrets, userSecrets)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"GITHUB_TOKEN": "token", "NPMRC": "registry=https://registry.npmjs.org", "DB_PASSWORD": "password"}, values)
+
+	dev.Secrets = append(dev.Secrets, model.Secret{FromPlatform: "MISSING"})
+	_, err = getPlatformSecretValues(dev, namespaceSecrets, userSecrets)
+	assert.Error(t, err)
+}
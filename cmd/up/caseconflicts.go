@@ -0,0 +1,158 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/syncthing"
+)
+
+// listRemoteFilesScript lists the paths of a folder of the development container relative to it, except the ones of the '.git' folder
+const listRemoteFilesScript = `cd "$1" 2>/dev/null || exit 0; find . -mindepth 1 -not -path './.git' -not -path './.git/*'`
+
+// checkCaseConflicts warns before the initial synchronization if a sync folder of the development container has paths that only differ in case from the local ones, or from each other.
+// A case-insensitive local filesystem can't hold both, so the synchronization would overwrite one with the other. The paths ignored by the '.stignore' file are not synchronized, so they are not checked
+func (up *upContext) checkCaseConflicts(ctx context.Context) {
+	conflicts := []string{}
+	for _, folder := range up.Dev.Sync.Folders {
+		if !isCaseInsensitiveFS(folder.LocalPath) {
+			continue
+		}
+
+		ignore, err := syncthing.LoadIgnore(folder.LocalPath)
+		if err != nil {
+			oktetoLog.Infof("failed to read the .stignore file of %s: %s", folder.LocalPath, err)
+			continue
+		}
+		local, err := getLocalPaths(folder.LocalPath, ignore)
+		if err != nil {
+			oktetoLog.Infof("failed to list the files of %s: %s", folder.LocalPath, err)
+			continue
+		}
+		stdout := &bytes.Buffer{}
+		cmd := []string{"sh", "-c", listRemoteFilesScript, "sh", folder.RemotePath}
+		if err := up.execInDevContainer(ctx, cmd, nil, stdout, &bytes.Buffer{}); err != nil {
+			oktetoLog.Infof("failed to list the files of %s in the development container: %s", folder.RemotePath, err)
+			continue
+		}
+		remote := []string{}
+		for _, line := range strings.Split(stdout.String(), "\n") {
+			if line = strings.TrimPrefix(strings.TrimSpace(line), "./"); line != "" && !ignore.Match(line) {
+				remote = append(remote, line)
+			}
+		}
+		for _, conflict := range getCaseConflicts(local, remote) {
+			conflicts = append(conflicts, fmt.Sprintf("%s (%s)", conflict, folder.RemotePath))
+		}
+	}
+	if len(conflicts) == 0 {
+		return
+	}
+
+	sort.Strings(conflicts)
+	oktetoLog.Warning("Your local filesystem is case-insensitive and the following paths of your development container only differ in case, only one of them will be synchronized:\n    - %s", strings.Join(conflicts, "\n    - "))
+	oktetoLog.Println(fmt.Sprintf("    Rename or remove them in your development container, or run '%s' to reset its persistent volume", utils.GetDownCommand(up.Options.ManifestPathFlag)))
+}
+
+// getCaseConflicts returns the remote paths that differ only in case from a local path or from another remote path
+func getCaseConflicts(local, remote []string) []string {
+	localByLower := map[string]string{}
+	for _, p := range local {
+		localByLower[strings.ToLower(p)] = p
+	}
+
+	conflicts := map[string]bool{}
+	remoteByLower := map[string]string{}
+	for _, p := range remote {
+		lower := strings.ToLower(p)
+		if l, ok := localByLower[lower]; ok && l != p {
+			conflicts[fmt.Sprintf("'%s' and the local '%s'", p, l)] = true
+		}
+		if r, ok := remoteByLower[lower]; ok && r != p {
+			first, second := r, p
+			if second < first {
+				first, second = second, first
+			}
+			conflicts[fmt.Sprintf("'%s' and '%s'", first, second)] = true
+		}
+		remoteByLower[lower] = p
+	}
+
+	result := make([]string, 0, len(conflicts))
+	for c := range conflicts {
+		result = append(result, c)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// getLocalPaths returns the paths of a sync folder relative to it, with forward slashes, except the ones ignored by syncthing
+func getLocalPaths(folder string, ignore *syncthing.Ignore) ([]string, error) {
+	paths := []string{}
+	err := filepath.WalkDir(folder, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == folder {
+			return nil
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		rel, err := filepath.Rel(folder, path)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && ignore.SkipDir(rel) {
+			return filepath.SkipDir
+		}
+		if ignore.Match(rel) {
+			return nil
+		}
+		paths = append(paths, filepath.ToSlash(rel))
+		return nil
+	})
+	return paths, err
+}
+
+// isCaseInsensitiveFS returns true if the filesystem of a folder ignores the case of the file names
+func isCaseInsensitiveFS(folder string) bool {
+	f, err := os.CreateTemp(folder, ".okteto-case-check-")
+	if err != nil {
+		oktetoLog.Infof("failed to check if %s is case-insensitive: %s", folder, err)
+		return false
+	}
+	name := f.Name()
+	if err := f.Close(); err != nil {
+		oktetoLog.Infof("failed to close %s: %s", name, err)
+	}
+	defer func() {
+		if err := os.Remove(name); err != nil {
+			oktetoLog.Infof("failed to remove %s: %s", name, err)
+		}
+	}()
+
+	_, err = os.Stat(filepath.Join(filepath.Dir(name), strings.ToUpper(filepath.Base(name))))
+	return err == nil
+}
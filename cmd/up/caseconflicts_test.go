@@ -0,0 +1,51 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/syncthing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_getCaseConflicts(t *testing.T) {
+	local := []string{"Makefile", "src", "src/main.go"}
+	remote := []string{"makefile", "src", "src/main.go", "docs/README.md", "docs/readme.md"}
+
+	assert.Equal(t, []string{
+		"'docs/README.md' and 'docs/readme.md'",
+		"'makefile' and the local 'Makefile'",
+	}, getCaseConflicts(local, remote))
+	assert.Empty(t, getCaseConflicts(local, local))
+}
+
+func Test_getLocalPaths(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "src"), 0700))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".git"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "src", "main.go"), []byte("package main"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".git", "HEAD"), []byte("ref"), 0600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "node_modules", "react"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".stignore"), []byte("node_modules\n"), 0600))
+
+	ignore, err := syncthing.LoadIgnore(dir)
+	require.NoError(t, err)
+	paths, err := getLocalPaths(dir, ignore)
+	require.NoError(t, err)
+	assert.Equal(t, []string{".stignore", "src", "src/main.go"}, paths)
+}
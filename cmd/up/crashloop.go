@@ -0,0 +1,113 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	crashLoopBackOffReason = "CrashLoopBackOff"
+	oomKilledReason        = "OOMKilled"
+
+	// crashedContainerTailLines is the number of lines of the logs of a crashed container included in the error
+	crashedContainerTailLines int64 = 20
+)
+
+// getCrashedContainer returns the status of the first container of the pod that is crashlooping or was OOMKilled, and the reason
+func getCrashedContainer(pod *apiv1.Pod) (*apiv1.ContainerStatus, string, bool) {
+	statuses := append([]apiv1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+	for i := range statuses {
+		s := &statuses[i]
+		if s.State.Terminated != nil && s.State.Terminated.Reason == oomKilledReason {
+			return s, oomKilledReason, true
+		}
+		if s.State.Waiting == nil || s.State.Waiting.Reason != crashLoopBackOffReason {
+			continue
+		}
+		if s.LastTerminationState.Terminated != nil && s.LastTerminationState.Terminated.Reason == oomKilledReason {
+			return s, oomKilledReason, true
+		}
+		return s, crashLoopBackOffReason, true
+	}
+	return nil, "", false
+}
+
+// crashedContainerError returns an error with the reason why a container of the development pod crashed and its last logs
+func crashedContainerError(ctx context.Context, c kubernetes.Interface, pod *apiv1.Pod, status *apiv1.ContainerStatus, reason string) error {
+	// the logs of the last execution are in the previous container when it's waiting to be restarted
+	previous := status.State.Terminated == nil
+	logs := getContainerTailLogs(ctx, c, pod, status.Name, previous)
+
+	var e error
+	hint := ""
+	switch reason {
+	case oomKilledReason:
+		e = fmt.Errorf("the container '%s' of your development container ran out of memory and was killed (OOMKilled)", status.Name)
+		hint = "Increase the memory limit in the 'resources' field of your okteto manifest"
+	default:
+		e = fmt.Errorf("the container '%s' of your development container is crashing", status.Name)
+		if t := status.LastTerminationState.Terminated; t != nil {
+			e = fmt.Errorf("the container '%s' of your development container is crashing: it exited with code %d", status.Name, t.ExitCode)
+		}
+		hint = "Check the 'command' and 'image' fields of your okteto manifest"
+	}
+	if logs != "" {
+		hint = fmt.Sprintf("%s\n    Last logs of the container:\n%s", hint, logs)
+	}
+	return oktetoErrors.UserError{E: e, Hint: hint}
+}
+
+// getContainerTailLogs returns the last lines of the logs of a container, indented to be printed in a hint
+func getContainerTailLogs(ctx context.Context, c kubernetes.Interface, pod *apiv1.Pod, container string, previous bool) string {
+	tail := crashedContainerTailLines
+	req := c.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &apiv1.PodLogOptions{
+		Container: container,
+		Previous:  previous,
+		TailLines: &tail,
+	})
+	stream, err := req.Stream(ctx)
+	if err != nil {
+		oktetoLog.Infof("failed to get the logs of container '%s': %s", container, err)
+		return ""
+	}
+	defer func() {
+		if err := stream.Close(); err != nil {
+			oktetoLog.Debugf("Error closing logStream: %s", err)
+		}
+	}()
+	b, err := io.ReadAll(stream)
+	if err != nil {
+		oktetoLog.Infof("failed to read the logs of container '%s': %s", container, err)
+		return ""
+	}
+
+	lines := strings.Split(strings.TrimRight(string(b), "\n"), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		return ""
+	}
+	for i := range lines {
+		lines[i] = fmt.Sprintf("      %s", lines[i])
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,105 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetCrashedContainer(t *testing.T) {
+	var tests = []struct {
+		name           string
+		status         apiv1.ContainerStatus
+		init           bool
+		expectedReason string
+	}{
+		{
+			name: "running",
+			status: apiv1.ContainerStatus{
+				State: apiv1.ContainerState{Running: &apiv1.ContainerStateRunning{}},
+			},
+		},
+		{
+			name: "crashloop",
+			status: apiv1.ContainerStatus{
+				State:                apiv1.ContainerState{Waiting: &apiv1.ContainerStateWaiting{Reason: crashLoopBackOffReason}},
+				LastTerminationState: apiv1.ContainerState{Terminated: &apiv1.ContainerStateTerminated{Reason: "Error", ExitCode: 1}},
+			},
+			expectedReason: crashLoopBackOffReason,
+		},
+		{
+			name: "crashloop after OOMKilled",
+			status: apiv1.ContainerStatus{
+				State:                apiv1.ContainerState{Waiting: &apiv1.ContainerStateWaiting{Reason: crashLoopBackOffReason}},
+				LastTerminationState: apiv1.ContainerState{Terminated: &apiv1.ContainerStateTerminated{Reason: oomKilledReason, ExitCode: 137}},
+			},
+			expectedReason: oomKilledReason,
+		},
+		{
+			name: "init container OOMKilled",
+			status: apiv1.ContainerStatus{
+				State: apiv1.ContainerState{Terminated: &apiv1.ContainerStateTerminated{Reason: oomKilledReason, ExitCode: 137}},
+			},
+			init:           true,
+			expectedReason: oomKilledReason,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.status.Name = "dev"
+			pod := &apiv1.Pod{}
+			if tt.init {
+				pod.Status.InitContainerStatuses = []apiv1.ContainerStatus{tt.status}
+			} else {
+				pod.Status.ContainerStatuses = []apiv1.ContainerStatus{tt.status}
+			}
+			status, reason, ok := getCrashedContainer(pod)
+			assert.Equal(t, tt.expectedReason != "", ok)
+			assert.Equal(t, tt.expectedReason, reason)
+			if ok {
+				assert.Equal(t, "dev", status.Name)
+			}
+		})
+	}
+}
+
+func TestCrashedContainerError(t *testing.T) {
+	pod := &apiv1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "cindy"}}
+	c := fake.NewSimpleClientset(pod)
+	status := &apiv1.ContainerStatus{
+		Name:                 "dev",
+		State:                apiv1.ContainerState{Waiting: &apiv1.ContainerStateWaiting{Reason: crashLoopBackOffReason}},
+		LastTerminationState: apiv1.ContainerState{Terminated: &apiv1.ContainerStateTerminated{Reason: "Error", ExitCode: 127}},
+	}
+
+	err := crashedContainerError(context.Background(), c, pod, status, crashLoopBackOffReason)
+	var uErr oktetoErrors.UserError
+	require.ErrorAs(t, err, &uErr)
+	assert.Equal(t, "the container 'dev' of your development container is crashing: it exited with code 127", uErr.E.Error())
+	// the fake clientset returns 'fake logs' as the logs of any container
+	assert.Contains(t, uErr.Hint, "Last logs of the container:\n      fake logs")
+
+	err = crashedContainerError(context.Background(), c, pod, status, oomKilledReason)
+	require.ErrorAs(t, err, &uErr)
+	assert.Contains(t, uErr.E.Error(), "(OOMKilled)")
+	assert.Contains(t, uErr.Hint, "memory limit")
+}
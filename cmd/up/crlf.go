@@ -0,0 +1,126 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"runtime"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+const (
+	// maxCRLFFileSize is the size of the biggest file checked for CRLF line endings
+	maxCRLFFileSize = 10 * 1024 * 1024
+
+	// textDetectionSize is the number of bytes read to tell text files from binary files
+	textDetectionSize = 8000
+)
+
+// checkLineEndings applies 'sync.crlf' to the text files synchronized from Windows before the initial synchronization.
+// With 'auto' it warns about the files with CRLF line endings, with 'convert' it converts them to LF, and with 'keep' it does nothing.
+// Other operating systems don't use CRLF line endings, so their files are not read
+func checkLineEndings(dev *model.Dev) error {
+	if runtime.GOOS != "windows" {
+		return nil
+	}
+	return applyLineEndings(dev)
+}
+
+// applyLineEndings applies 'sync.crlf' to the text files of the sync folders, except the ones ignored by their '.stignore' file
+func applyLineEndings(dev *model.Dev) error {
+	if dev.IsHybridModeEnabled() {
+		return nil
+	}
+	mode := dev.Sync.GetCRLF()
+	if mode == model.SyncCRLFKeep {
+		return nil
+	}
+
+	crlfFiles := []string{}
+	for _, folder := range dev.Sync.Folders {
+		err := walkSyncFolder(folder.LocalPath, func(path string, info fs.FileInfo) bool {
+			if info.Size() == 0 || info.Size() > maxCRLFFileSize || !info.Mode().IsRegular() {
+				return true
+			}
+			hasCRLF, err := hasCRLFLineEndings(path)
+			if err != nil {
+				oktetoLog.Infof("failed to check the line endings of %s: %s", path, err)
+				return true
+			}
+			if hasCRLF {
+				crlfFiles = append(crlfFiles, path)
+			}
+			return true
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(crlfFiles) == 0 {
+		return nil
+	}
+
+	if mode == model.SyncCRLFAuto {
+		oktetoLog.Warning("%d files have CRLF line endings, scripts with CRLF line endings fail to run in your development container:", len(crlfFiles))
+		printFiles(crlfFiles)
+		oktetoLog.Println("    Set 'sync.crlf: convert' to convert them to LF, or 'sync.crlf: keep' to hide this warning")
+		return nil
+	}
+
+	for _, path := range crlfFiles {
+		if err := convertToLF(path); err != nil {
+			return err
+		}
+	}
+	oktetoLog.Information("Converted the CRLF line endings of %d files to LF", len(crlfFiles))
+	return nil
+}
+
+// hasCRLFLineEndings returns true if the file is a text file with CRLF line endings
+func hasCRLFLineEndings(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	head := make([]byte, textDetectionSize)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return false, err
+	}
+	head = head[:n]
+	if bytes.IndexByte(head, 0) != -1 {
+		return false, nil
+	}
+	return bytes.Contains(head, []byte("\r\n")), nil
+}
+
+// convertToLF replaces the CRLF line endings of a file with LF, keeping its permissions
+func convertToLF(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n")), info.Mode().Perm())
+}
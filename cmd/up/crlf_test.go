@@ -0,0 +1,66 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_applyLineEndings(t *testing.T) {
+	var tests = []struct {
+		name     string
+		crlf     string
+		expected string
+	}{
+		{name: "auto", crlf: "", expected: "echo hello\r\necho world\r\n"},
+		{name: "keep", crlf: model.SyncCRLFKeep, expected: "echo hello\r\necho world\r\n"},
+		{name: "convert", crlf: model.SyncCRLFConvert, expected: "echo hello\necho world\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			script := filepath.Join(dir, "run.sh")
+			binary := filepath.Join(dir, "image.bin")
+			require.NoError(t, os.WriteFile(script, []byte("echo hello\r\necho world\r\n"), 0700))
+			require.NoError(t, os.WriteFile(binary, []byte("\x00\r\n\x01"), 0600))
+			ignored := filepath.Join(dir, "ignored.bat")
+			require.NoError(t, os.WriteFile(ignored, []byte("echo hello\r\n"), 0600))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, ".stignore"), []byte("*.bat\n"), 0600))
+
+			dev := &model.Dev{Sync: model.Sync{CRLF: tt.crlf, Folders: []model.SyncFolder{{LocalPath: dir, RemotePath: "/app"}}}}
+			require.NoError(t, applyLineEndings(dev))
+
+			content, err := os.ReadFile(script)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(content))
+			info, err := os.Stat(script)
+			require.NoError(t, err)
+			assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+
+			content, err = os.ReadFile(binary)
+			require.NoError(t, err)
+			assert.Equal(t, "\x00\r\n\x01", string(content))
+
+			content, err = os.ReadFile(ignored)
+			require.NoError(t, err)
+			assert.Equal(t, "echo hello\r\n", string(content))
+		})
+	}
+}
@@ -0,0 +1,361 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/skratchdot/open-golang/open"
+)
+
+const (
+	// maxDashboardLogs is the number of lines of the logs kept by the dashboard
+	maxDashboardLogs      = 1000
+	dashboardSyncBarWidth = 30
+)
+
+type dashboardStatusMsg string
+
+type dashboardSyncMsg int
+
+type dashboardLogMsg string
+
+type dashboardNoticeMsg string
+
+// dashboardActions are the actions of the shortcuts of the dashboard
+type dashboardActions struct {
+	restart func()
+	open    func(url string) error
+	verbose func(enabled bool)
+	quit    func()
+}
+
+// dashboardModel is the view of the dashboard of okteto up: the status of the session, the progress of the file synchronization,
+// the forwarded ports and the logs of the command and of okteto
+type dashboardModel struct {
+	title     string
+	forwards  []string
+	endpoints []string
+	status    string
+	sync      int
+	logs      []string
+	message   string
+	verbose   bool
+	width     int
+	height    int
+	actions   dashboardActions
+}
+
+// Init implements tea.Model
+func (dashboardModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model
+func (m dashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	case dashboardStatusMsg:
+		m.status = string(msg)
+	case dashboardSyncMsg:
+		m.sync = int(msg)
+	case dashboardNoticeMsg:
+		m.message = string(msg)
+	case dashboardLogMsg:
+		m.logs = append(m.logs, string(msg))
+		if len(m.logs) > maxDashboardLogs {
+			m.logs = m.logs[len(m.logs)-maxDashboardLogs:]
+		}
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "r":
+			m.message = "Restarting the command..."
+			m.actions.restart()
+		case "o":
+			if len(m.endpoints) == 0 {
+				m.message = "There are no forwarded ports to open"
+				break
+			}
+			m.message = fmt.Sprintf("Opening %s...", m.endpoints[0])
+			if err := m.actions.open(m.endpoints[0]); err != nil {
+				m.message = fmt.Sprintf("Failed to open %s: %s", m.endpoints[0], err)
+			}
+		case "v":
+			m.verbose = !m.verbose
+			m.message = fmt.Sprintf("Verbose logs %s", onOff(m.verbose))
+			m.actions.verbose(m.verbose)
+		case "q", "ctrl+c":
+			m.actions.quit()
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// View implements tea.Model
+func (m dashboardModel) View() string {
+	header := []string{
+		oktetoLog.BlueString(m.title),
+		fmt.Sprintf("%s   %s", oktetoLog.BlueString("Status:"), m.status),
+		fmt.Sprintf("%s     %s", oktetoLog.BlueString("Sync:"), syncBar(m.sync)),
+	}
+	for i, f := range m.forwards {
+		label := "         "
+		if i == 0 {
+			label = oktetoLog.BlueString("Forward:") + " "
+		}
+		header = append(header, fmt.Sprintf("%s %s", label, f))
+	}
+	header = append(header, strings.Repeat("─", m.lineWidth()))
+
+	footer := []string{
+		strings.Repeat("─", m.lineWidth()),
+		fmt.Sprintf("r restart command · o open endpoint · v verbose (%s) · q quit", onOff(m.verbose)),
+	}
+	if m.message != "" {
+		footer = append(footer, m.message)
+	}
+
+	logs := m.logs
+	if available := m.height - len(header) - len(footer); m.height > 0 && len(logs) > available {
+		if available < 0 {
+			available = 0
+		}
+		logs = logs[len(logs)-available:]
+	}
+	lines := append([]string{}, header...)
+	for _, l := range logs {
+		lines = append(lines, m.truncate(l))
+	}
+	lines = append(lines, footer...)
+	return strings.Join(lines, "\n")
+}
+
+func (m dashboardModel) lineWidth() int {
+	if m.width <= 0 {
+		return 80
+	}
+	return m.width
+}
+
+func (m dashboardModel) truncate(line string) string {
+	if m.width <= 0 || len(line) <= m.width {
+		return line
+	}
+	return line[:m.width]
+}
+
+func syncBar(progress int) string {
+	if progress < 0 {
+		progress = 0
+	}
+	if progress > 100 {
+		progress = 100
+	}
+	done := progress * dashboardSyncBarWidth / 100
+	return fmt.Sprintf("[%s%s] %d%%", strings.Repeat("█", done), strings.Repeat("░", dashboardSyncBarWidth-done), progress)
+}
+
+func onOff(enabled bool) string {
+	if enabled {
+		return "on"
+	}
+	return "off"
+}
+
+// dashboard runs the interactive full-screen view of 'okteto up --tui'. The logs of okteto and the output of the command are written to it.
+// The methods of a nil dashboard do nothing, so they can be called when okteto up uses the plain output
+type dashboard struct {
+	program *tea.Program
+	done    chan struct{}
+
+	// buf keeps the last line written until it's completed
+	buf bytes.Buffer
+
+	// cancelCommand cancels the current execution of the command to restart it
+	cancelCommand    context.CancelFunc
+	restartRequested bool
+	restoreLogs      func()
+	mu               sync.Mutex
+}
+
+// newDashboard creates the dashboard of the development container. quit is called when the user exits from the dashboard
+func newDashboard(title string, forwards []string, endpoints []string, quit func()) *dashboard {
+	d := &dashboard{done: make(chan struct{})}
+	m := dashboardModel{
+		title:     title,
+		forwards:  forwards,
+		endpoints: endpoints,
+		status:    "Activating your development container...",
+	}
+	m.actions = dashboardActions{
+		restart: d.restartCommand,
+		open:    open.Run,
+		verbose: verboseLogsToggler(oktetoLog.GetLevel()),
+		quit:    quit,
+	}
+	d.program = tea.NewProgram(m, tea.WithAltScreen(), tea.WithInput(os.Stdin), tea.WithOutput(os.Stdout))
+	return d
+}
+
+// newUpDashboard creates the dashboard of the development container of an okteto up session
+func newUpDashboard(up *upContext, quit func()) *dashboard {
+	title := fmt.Sprintf("%s · %s · %s", okteto.RemoveSchema(up.Dev.Context), up.Dev.Namespace, up.Dev.Name)
+	forwards := []string{}
+	endpoints := []string{}
+	for _, f := range up.Manifest.GlobalForward {
+		forwards = append(forwards, fmt.Sprintf("%d -> %s:%d", f.Local, f.ServiceName, f.Remote))
+		endpoints = append(endpoints, fmt.Sprintf("http://localhost:%d", f.Local))
+	}
+	for _, f := range up.Dev.Forward {
+		if f.Service {
+			forwards = append(forwards, fmt.Sprintf("%d -> %s:%d", f.Local, f.ServiceName, f.Remote))
+		} else {
+			forwards = append(forwards, fmt.Sprintf("%d -> %d", f.Local, f.Remote))
+		}
+		endpoints = append(endpoints, fmt.Sprintf("http://localhost:%d", f.Local))
+	}
+	for _, r := range up.Dev.Reverse {
+		forwards = append(forwards, fmt.Sprintf("%d <- %d", r.Local, r.Remote))
+	}
+	return newDashboard(title, forwards, endpoints, quit)
+}
+
+// Start renders the dashboard and redirects the logs of okteto to it
+func (d *dashboard) Start() {
+	if d == nil {
+		return
+	}
+	d.restoreLogs = oktetoLog.RedirectOutput(d)
+	go func() {
+		defer close(d.done)
+		if _, err := d.program.Run(); err != nil {
+			oktetoLog.Infof("dashboard failed: %s", err)
+		}
+	}()
+}
+
+// Stop closes the dashboard and restores the terminal and the logs of okteto
+func (d *dashboard) Stop() {
+	if d == nil {
+		return
+	}
+	d.program.Quit()
+	<-d.done
+	d.restoreLogs()
+}
+
+// SetStatus updates the status of the session
+func (d *dashboard) SetStatus(format string, args ...interface{}) {
+	if d == nil {
+		return
+	}
+	d.program.Send(dashboardStatusMsg(fmt.Sprintf(format, args...)))
+}
+
+// SetSyncProgress updates the percentage of the file synchronization
+func (d *dashboard) SetSyncProgress(progress int) {
+	if d == nil {
+		return
+	}
+	d.program.Send(dashboardSyncMsg(progress))
+}
+
+// Notify shows a message to the user in the footer of the dashboard
+func (d *dashboard) Notify(format string, args ...interface{}) {
+	if d == nil {
+		return
+	}
+	d.program.Send(dashboardNoticeMsg(fmt.Sprintf(format, args...)))
+}
+
+// Write adds the written lines to the logs of the dashboard.
+// The lines are sent after releasing the lock: Send blocks until the event loop reads them, and the event loop takes the lock to restart the command
+func (d *dashboard) Write(p []byte) (int, error) {
+	for _, line := range d.splitLines(p) {
+		d.program.Send(dashboardLogMsg(line))
+	}
+	return len(p), nil
+}
+
+// splitLines returns the complete lines written so far and keeps the incomplete one until the rest of it is written
+func (d *dashboard) splitLines(p []byte) []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.buf.Write(p)
+	lines := []string{}
+	for {
+		line, err := d.buf.ReadString('\n')
+		if err != nil {
+			d.buf.Reset()
+			d.buf.WriteString(line)
+			return lines
+		}
+		lines = append(lines, strings.TrimRight(line, "\r\n"))
+	}
+}
+
+// runCommand runs the command until it exits without being restarted from the dashboard
+func (d *dashboard) runCommand(ctx context.Context, run func(context.Context) error) error {
+	for {
+		cmdCtx, cancel := context.WithCancel(ctx)
+		d.mu.Lock()
+		d.cancelCommand = cancel
+		d.restartRequested = false
+		d.mu.Unlock()
+
+		err := run(cmdCtx)
+		cancel()
+
+		d.mu.Lock()
+		restart := d.restartRequested
+		d.mu.Unlock()
+		if !restart || ctx.Err() != nil {
+			return err
+		}
+	}
+}
+
+func (d *dashboard) restartCommand() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.cancelCommand == nil {
+		return
+	}
+	d.restartRequested = true
+	d.cancelCommand()
+}
+
+// verboseLogsToggler switches the logs between the debug level and the level of the session
+func verboseLogsToggler(level string) func(enabled bool) {
+	return func(enabled bool) {
+		if enabled {
+			oktetoLog.SetLevel(oktetoLog.DebugLevel)
+			return
+		}
+		oktetoLog.SetLevel(level)
+	}
+}
+
+var _ io.Writer = (*dashboard)(nil)
@@ -0,0 +1,152 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDashboardActions struct {
+	restarted int
+	opened    []string
+	verbose   []bool
+	quitted   bool
+}
+
+func (f *fakeDashboardActions) actions() dashboardActions {
+	return dashboardActions{
+		restart: func() { f.restarted++ },
+		open: func(url string) error {
+			f.opened = append(f.opened, url)
+			return nil
+		},
+		verbose: func(enabled bool) { f.verbose = append(f.verbose, enabled) },
+		quit:    func() { f.quitted = true },
+	}
+}
+
+func key(k string) tea.KeyMsg {
+	if k == "ctrl+c" {
+		return tea.KeyMsg{Type: tea.KeyCtrlC}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+}
+
+func update(t *testing.T, m dashboardModel, msg tea.Msg) (dashboardModel, tea.Cmd) {
+	t.Helper()
+	result, cmd := m.Update(msg)
+	return result.(dashboardModel), cmd
+}
+
+func TestDashboardModelShortcuts(t *testing.T) {
+	fake := &fakeDashboardActions{}
+	m := dashboardModel{endpoints: []string{"http://localhost:8080"}, actions: fake.actions()}
+
+	m, _ = update(t, m, key("r"))
+	assert.Equal(t, 1, fake.restarted)
+	assert.Equal(t, "Restarting the command...", m.message)
+
+	m, _ = update(t, m, key("o"))
+	assert.Equal(t, []string{"http://localhost:8080"}, fake.opened)
+
+	m, _ = update(t, m, key("v"))
+	m, _ = update(t, m, key("v"))
+	assert.Equal(t, []bool{true, false}, fake.verbose)
+	assert.False(t, m.verbose)
+
+	_, cmd := update(t, m, key("ctrl+c"))
+	assert.True(t, fake.quitted)
+	require.NotNil(t, cmd)
+	assert.Equal(t, tea.Quit(), cmd())
+}
+
+func TestDashboardModelOpenWithoutForwards(t *testing.T) {
+	fake := &fakeDashboardActions{}
+	m := dashboardModel{actions: fake.actions()}
+	m, _ = update(t, m, key("o"))
+	assert.Empty(t, fake.opened)
+	assert.Equal(t, "There are no forwarded ports to open", m.message)
+}
+
+func TestDashboardModelView(t *testing.T) {
+	m := dashboardModel{
+		title:    "cluster · cindy · api",
+		forwards: []string{"8080 -> 8080", "5432 -> postgres:5432"},
+	}
+	m, _ = update(t, m, tea.WindowSizeMsg{Width: 40, Height: 11})
+	m, _ = update(t, m, dashboardStatusMsg("Synchronizing your files..."))
+	m, _ = update(t, m, dashboardSyncMsg(50))
+	for _, l := range []string{"first", "second", "third", strings.Repeat("x", 50)} {
+		m, _ = update(t, m, dashboardLogMsg(l))
+	}
+
+	view := m.View()
+	lines := strings.Split(view, "\n")
+	assert.Len(t, lines, 11)
+	assert.Contains(t, view, "Synchronizing your files...")
+	assert.Contains(t, view, "[███████████████░░░░░░░░░░░░░░░] 50%")
+	assert.Contains(t, view, "5432 -> postgres:5432")
+	// only the last lines of the logs fit in the window, truncated to its width
+	assert.NotContains(t, view, "first")
+	assert.Contains(t, view, "third")
+	assert.Contains(t, view, strings.Repeat("x", 40))
+	assert.NotContains(t, view, strings.Repeat("x", 41))
+	assert.Contains(t, lines[len(lines)-1], "q quit")
+}
+
+func TestDashboardModelKeepsLastLogs(t *testing.T) {
+	m := dashboardModel{}
+	for i := 0; i < maxDashboardLogs+10; i++ {
+		m, _ = update(t, m, dashboardLogMsg("line"))
+	}
+	assert.Len(t, m.logs, maxDashboardLogs)
+}
+
+func TestSyncBar(t *testing.T) {
+	assert.Equal(t, "["+strings.Repeat("░", dashboardSyncBarWidth)+"] 0%", syncBar(-5))
+	assert.Equal(t, "["+strings.Repeat("█", dashboardSyncBarWidth)+"] 100%", syncBar(120))
+}
+
+func TestDashboardRunCommand(t *testing.T) {
+	d := &dashboard{}
+	runs := 0
+	err := d.runCommand(context.Background(), func(ctx context.Context) error {
+		runs++
+		if runs == 1 {
+			d.restartCommand()
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return errors.New("command failed")
+	})
+	assert.Equal(t, 2, runs)
+	assert.EqualError(t, err, "command failed")
+}
+
+func TestDashboardSplitLines(t *testing.T) {
+	d := &dashboard{}
+	assert.Equal(t, []string{"first"}, d.splitLines([]byte("first\nsec")))
+	assert.Equal(t, []string{"second", "third"}, d.splitLines([]byte("ond\r\nthird\n")))
+	assert.Empty(t, d.splitLines([]byte("fourth")))
+
+	// the lock is released once the lines are split, so the command can be restarted while they are sent
+	d.restartCommand()
+}
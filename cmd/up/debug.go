@@ -0,0 +1,129 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+// jetbrainsRunConfigurations are the run configurations of JetBrains IDEs that attach to each debugger
+var jetbrainsRunConfigurations = map[string]string{
+	model.DebugLanguageGo:     "Go Remote",
+	model.DebugLanguageNode:   "Attach to Node.js/Chrome",
+	model.DebugLanguagePython: "Python Debug Server",
+	model.DebugLanguageJava:   "Remote JVM Debug",
+}
+
+// printDebugInfo prints the IDE configurations to attach to the debugger of the dev container
+func printDebugInfo(dev *model.Dev) {
+	if dev.Debug == nil {
+		return
+	}
+	oktetoLog.Println(fmt.Sprintf("    %s     %s debugger on localhost:%d", oktetoLog.BlueString("Debug:"), dev.Debug.Language, dev.Debug.Port))
+	if !dev.IsDebuggerInCommand() {
+		oktetoLog.Println(fmt.Sprintf("               start your process with '%s' to debug it", getDebugStartCommand(dev.Debug)))
+	}
+
+	config, err := getVSCodeAttachConfig(dev)
+	if err != nil {
+		oktetoLog.Infof("error generating the VS Code attach configuration: %s", err)
+		return
+	}
+	oktetoLog.Println("               VS Code launch configuration:")
+	for _, line := range strings.Split(config, "\n") {
+		oktetoLog.Println(fmt.Sprintf("                 %s", line))
+	}
+	oktetoLog.Println(fmt.Sprintf("               JetBrains: create a '%s' run configuration on localhost:%d", jetbrainsRunConfigurations[dev.Debug.Language], dev.Debug.Port))
+}
+
+// getVSCodeAttachConfig returns the launch.json configuration that attaches VS Code to the debugger
+func getVSCodeAttachConfig(dev *model.Dev) (string, error) {
+	name := fmt.Sprintf("Attach to %s (okteto)", dev.Name)
+	remotePath := getDebugRemotePath(dev)
+	var config map[string]interface{}
+	switch dev.Debug.Language {
+	case model.DebugLanguageGo:
+		config = map[string]interface{}{
+			"name":    name,
+			"type":    "go",
+			"request": "attach",
+			"mode":    "remote",
+			"host":    "localhost",
+			"port":    dev.Debug.Port,
+			"substitutePath": []map[string]string{
+				{"from": "${workspaceFolder}", "to": remotePath},
+			},
+		}
+	case model.DebugLanguageNode:
+		config = map[string]interface{}{
+			"name":       name,
+			"type":       "node",
+			"request":    "attach",
+			"address":    "localhost",
+			"port":       dev.Debug.Port,
+			"localRoot":  "${workspaceFolder}",
+			"remoteRoot": remotePath,
+		}
+	case model.DebugLanguagePython:
+		config = map[string]interface{}{
+			"name":    name,
+			"type":    "debugpy",
+			"request": "attach",
+			"connect": map[string]interface{}{"host": "localhost", "port": dev.Debug.Port},
+			"pathMappings": []map[string]string{
+				{"localRoot": "${workspaceFolder}", "remoteRoot": remotePath},
+			},
+		}
+	case model.DebugLanguageJava:
+		config = map[string]interface{}{
+			"name":     name,
+			"type":     "java",
+			"request":  "attach",
+			"hostName": "localhost",
+			"port":     dev.Debug.Port,
+		}
+	default:
+		return "", fmt.Errorf("unsupported debug language '%s'", dev.Debug.Language)
+	}
+	b, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// getDebugRemotePath returns the folder where the source code is synchronized in the dev container
+func getDebugRemotePath(dev *model.Dev) string {
+	if len(dev.Sync.Folders) > 0 {
+		return dev.Sync.Folders[0].RemotePath
+	}
+	return dev.Workdir
+}
+
+// getDebugStartCommand returns the command to start the debugger when it can't be injected into the command of the dev container
+func getDebugStartCommand(debug *model.Debug) string {
+	switch debug.Language {
+	case model.DebugLanguageGo:
+		return fmt.Sprintf("dlv debug --headless --listen=:%d --api-version=2 --accept-multiclient", debug.Port)
+	case model.DebugLanguagePython:
+		return fmt.Sprintf("python -m debugpy --listen 0.0.0.0:%d <your-script.py>", debug.Port)
+	default:
+		return ""
+	}
+}
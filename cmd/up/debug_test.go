@@ -0,0 +1,98 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetVSCodeAttachConfig(t *testing.T) {
+	var tests = []struct {
+		name     string
+		dev      *model.Dev
+		expected map[string]interface{}
+	}{
+		{
+			name: "go",
+			dev: &model.Dev{
+				Name:  "api",
+				Sync:  model.Sync{Folders: []model.SyncFolder{{LocalPath: ".", RemotePath: "/usr/src/app"}}},
+				Debug: &model.Debug{Language: model.DebugLanguageGo, Port: 2345},
+			},
+			expected: map[string]interface{}{
+				"name":           "Attach to api (okteto)",
+				"type":           "go",
+				"request":        "attach",
+				"mode":           "remote",
+				"host":           "localhost",
+				"port":           float64(2345),
+				"substitutePath": []interface{}{map[string]interface{}{"from": "${workspaceFolder}", "to": "/usr/src/app"}},
+			},
+		},
+		{
+			name: "python",
+			dev: &model.Dev{
+				Name:    "api",
+				Workdir: "/app",
+				Debug:   &model.Debug{Language: model.DebugLanguagePython, Port: 5678},
+			},
+			expected: map[string]interface{}{
+				"name":         "Attach to api (okteto)",
+				"type":         "debugpy",
+				"request":      "attach",
+				"connect":      map[string]interface{}{"host": "localhost", "port": float64(5678)},
+				"pathMappings": []interface{}{map[string]interface{}{"localRoot": "${workspaceFolder}", "remoteRoot": "/app"}},
+			},
+		},
+		{
+			name: "java",
+			dev: &model.Dev{
+				Name:  "api",
+				Debug: &model.Debug{Language: model.DebugLanguageJava, Port: 5005},
+			},
+			expected: map[string]interface{}{
+				"name":     "Attach to api (okteto)",
+				"type":     "java",
+				"request":  "attach",
+				"hostName": "localhost",
+				"port":     float64(5005),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := getVSCodeAttachConfig(tt.dev)
+			require.NoError(t, err)
+			result := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal([]byte(config), &result))
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestGetVSCodeAttachConfigUnsupported(t *testing.T) {
+	_, err := getVSCodeAttachConfig(&model.Dev{Debug: &model.Debug{Language: "ruby"}})
+	assert.Error(t, err)
+}
+
+func TestGetDebugStartCommand(t *testing.T) {
+	assert.Equal(t, "dlv debug --headless --listen=:4000 --api-version=2 --accept-multiclient", getDebugStartCommand(&model.Debug{Language: model.DebugLanguageGo, Port: 4000}))
+	assert.Equal(t, "python -m debugpy --listen 0.0.0.0:5678 <your-script.py>", getDebugStartCommand(&model.Debug{Language: model.DebugLanguagePython, Port: 5678}))
+	assert.Empty(t, getDebugStartCommand(&model.Debug{Language: model.DebugLanguageNode, Port: 9229}))
+}
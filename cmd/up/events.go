@@ -0,0 +1,42 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package up
+
+import (
+	"os"
+
+	"github.com/okteto/okteto/pkg/events"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// startEvents opens the event log of the session. The session continues if it can't be created
+func (up *upContext) startEvents() {
+	wd, err := os.Getwd()
+	if err != nil {
+		oktetoLog.Infof("failed to get the working directory for the session log: %s", err)
+		return
+	}
+	l, err := events.Open(events.GetSessionsDir(wd), up.Dev.Name)
+	if err != nil {
+		oktetoLog.Infof("failed to open the session log: %s", err)
+		return
+	}
+	oktetoLog.Infof("session events stored as '%s'", l.ID)
+	up.events = l
+}
+
+// stopEvents closes the event log of the session
+func (up *upContext) stopEvents() {
+	up.events.Record(events.Activation, "Session finished", nil)
+	up.events.Close()
+}
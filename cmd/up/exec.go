@@ -19,6 +19,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -28,10 +29,12 @@ import (
 	"github.com/okteto/okteto/pkg/k8s/secrets"
 
 	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/audit"
 	"github.com/okteto/okteto/pkg/cmd/pipeline"
 	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/constants"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/events"
 	"github.com/okteto/okteto/pkg/k8s/apps"
 	"github.com/okteto/okteto/pkg/k8s/configmaps"
 	k8sExec "github.com/okteto/okteto/pkg/k8s/exec"
@@ -43,6 +46,7 @@ import (
 	"github.com/okteto/okteto/pkg/ssh"
 	"github.com/okteto/okteto/pkg/types"
 	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -396,17 +400,27 @@ func (up *upContext) cleanCommand(ctx context.Context) {
 	up.cleaned <- out.String()
 }
 
-func (up *upContext) RunCommand(ctx context.Context, cmd []string) error {
+func (up *upContext) RunCommand(ctx context.Context, cmd []string) (err error) {
 	oktetoLog.Infof("starting remote command")
-	if err := config.UpdateStateFile(up.Dev.Name, up.Dev.Namespace, config.Ready); err != nil {
+	if err := config.UpdateStateFileWithFilesystem(up.Dev.Name, up.Dev.Namespace, config.Ready, up.Fs); err != nil {
 		return err
 	}
 
+	session := audit.Start(ctx, up.getAuditSession(cmd))
+	defer func() {
+		session.Stop(err)
+	}()
+
 	k8sClient, restConfig, err := up.K8sClientProvider.Provide(okteto.Context().Cfg)
 	if err != nil {
 		return err
 	}
 
+	if up.dashboard != nil {
+		// the dashboard owns the terminal, the command runs without a tty and its output is shown in the dashboard
+		return up.execInDevContainer(ctx, cmd, strings.NewReader(""), up.dashboard, up.dashboard)
+	}
+
 	if up.Dev.RemoteModeEnabled() {
 		if up.Dev.IsHybridModeEnabled() {
 			hybridCtx := &HybridExecCtx{
@@ -427,6 +441,7 @@ func (up *upContext) RunCommand(ctx context.Context, cmd []string) error {
 			}
 
 			up.hybridCommand = cmd
+			up.registerRelease("local process", up.shutdownHybridMode)
 
 			return executor.RunCommand(cmd)
 		} else {
@@ -451,12 +466,45 @@ func (up *upContext) RunCommand(ctx context.Context, cmd []string) error {
 	)
 }
 
+// runPostSyncHook runs the 'lifecycle.postSync' command in the development container after the initial file synchronization
+func (up *upContext) runPostSyncHook(ctx context.Context) error {
+	cmd := up.Dev.Lifecycle.PostSync.Values
+	oktetoLog.Information("Running postSync hook: %s", strings.Join(cmd, " "))
+	up.events.Record(events.Sync, "Running postSync hook", nil)
+	if up.dashboard != nil {
+		return up.execInDevContainer(ctx, cmd, strings.NewReader(""), up.dashboard, up.dashboard)
+	}
+	return up.execInDevContainer(ctx, cmd, os.Stdin, os.Stdout, os.Stderr)
+}
+
+// execInDevContainer runs a command in the development container without a tty
+func (up *upContext) execInDevContainer(ctx context.Context, cmd []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	if up.Dev.RemoteModeEnabled() {
+		return ssh.Exec(ctx, up.Dev.Interface, up.Dev.RemotePort, false, stdin, stdout, stderr, cmd)
+	}
+
+	k8sClient, restConfig, err := up.K8sClientProvider.Provide(okteto.Context().Cfg)
+	if err != nil {
+		return err
+	}
+	return k8sExec.Exec(ctx, k8sClient, restConfig, up.Dev.Namespace, up.Pod.Name, up.Dev.Container, false, stdin, stdout, stderr, cmd)
+}
+
 func (up *upContext) checkOktetoStartError(ctx context.Context, msg string) error {
 	k8sClient, _, err := up.K8sClientProvider.Provide(okteto.Context().Cfg)
 	if err != nil {
 		return err
 	}
 
+	if up.Pod != nil {
+		// the development container might crash after it started running
+		if pod, err := k8sClient.CoreV1().Pods(up.Dev.Namespace).Get(ctx, up.Pod.Name, metav1.GetOptions{}); err == nil {
+			if status, reason, ok := getCrashedContainer(pod); ok {
+				return crashedContainerError(ctx, k8sClient, pod, status, reason)
+			}
+		}
+	}
+
 	app, err := apps.Get(ctx, up.Dev, up.Dev.Namespace, k8sClient)
 	if err != nil {
 		return err
@@ -496,3 +544,18 @@ func (up *upContext) checkOktetoStartError(ctx context.Context, msg string) erro
     Run '%s' to reset your development container and try again`, up.Pod.Name, utils.GetDownCommand(up.Options.ManifestPathFlag)),
 	}
 }
+
+// getAuditSession returns the audit information of the shell session running the command of the development container
+func (up *upContext) getAuditSession(cmd []string) audit.Session {
+	s := audit.Session{
+		Command:   audit.UpCommand,
+		Namespace: up.Dev.Namespace,
+		Dev:       up.Dev.Name,
+		Container: up.Dev.Container,
+		Args:      cmd,
+	}
+	if up.Pod != nil {
+		s.Pod = up.Pod.Name
+	}
+	return s
+}
@@ -48,6 +48,7 @@ func (up *upContext) forwards(ctx context.Context) error {
 
 	oktetoLog.Infof("starting port forwards")
 	up.Forwarder = forwardk8s.NewPortForwardManager(ctx, up.Dev.Interface, restConfig, k8sClient, up.Dev.Namespace)
+	up.registerRelease("port forwards", up.Forwarder.Stop)
 
 	for idx, f := range up.Dev.Forward {
 		if f.Labels != nil {
@@ -96,7 +97,8 @@ func (up *upContext) sshForwards(ctx context.Context) error {
 		return err
 	}
 
-	up.Forwarder = ssh.NewForwardManager(ctx, fmt.Sprintf(":%d", up.Dev.RemotePort), up.Dev.Interface, "0.0.0.0", f, up.Dev.Namespace)
+	up.Forwarder = ssh.NewForwardManager(ctx, fmt.Sprintf(":%d", up.Dev.RemotePort), up.Dev.Interface, "0.0.0.0", f, up.Dev.Namespace, up.getTimeouts().PortForward)
+	up.registerRelease("SSH port forwards", up.Forwarder.Stop)
 	if err := up.Forwarder.Add(forward.Forward{Local: up.Sy.RemotePort, Remote: syncthing.ClusterPort}); err != nil {
 		return err
 	}
@@ -129,7 +131,8 @@ func (up *upContext) sshForwards(ctx context.Context) error {
 
 func addToForwarder(up *upContext) error {
 	ticker := time.NewTicker(1 * time.Second)
-	to := time.NewTicker(10 * time.Second)
+	timeout := up.getTimeouts().PortForward
+	to := time.NewTicker(timeout)
 	var forwardErr error
 	alreadyAdded := map[int]bool{}
 	for {
@@ -183,7 +186,7 @@ func addToForwarder(up *upContext) error {
 			if forwardErr != nil {
 				return forwardErr
 			}
-			return fmt.Errorf("could not create local ports after %s", up.Dev.Timeout.Resources.String())
+			return fmt.Errorf("could not create local ports after %s", timeout.String())
 		}
 	}
 }
@@ -47,7 +47,7 @@ func TestGlobalForwarderStartsWhenRequired(t *testing.T) {
 }
 
 func TestGlobalForwarderAddsProperlyPortsToForward(t *testing.T) {
-	f := ssh.NewForwardManager(context.Background(), ":8080", "0.0.0.0", "0.0.0.0", nil, "test")
+	f := ssh.NewForwardManager(context.Background(), ":8080", "0.0.0.0", "0.0.0.0", nil, "test", model.DefaultPortForwardTimeout)
 
 	var tests = []struct {
 		name        string
@@ -0,0 +1,79 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/okteto/okteto/pkg/events"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/repository"
+)
+
+// headWatchInterval is the frequency of the checks of the HEAD of the repository of the manifest
+const headWatchInterval = 2 * time.Second
+
+// folderResyncer forces the synchronization of all the files of the sync folders
+type folderResyncer interface {
+	Rescan(ctx context.Context) error
+	Overwrite(ctx context.Context) error
+}
+
+// watchRepositoryHead detects branch switches, rebases and resets of the repository of the manifest during the session.
+// They replace many files at once, so the files are resynchronized and the user is asked to restart the command
+func (up *upContext) watchRepositoryHead(ctx context.Context) {
+	if up.Dev.IsHybridModeEnabled() || up.Sy == nil {
+		return
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		oktetoLog.Infof("failed to get the working directory to watch the repository: %s", err)
+		return
+	}
+	watchEvents, err := repository.NewWatcher(wd, headWatchInterval, repository.WithWatchEvents(repository.HeadMoved)).Watch(ctx)
+	if err != nil {
+		oktetoLog.Infof("repository changes won't be detected: %s", err)
+		return
+	}
+	for e := range watchEvents {
+		if !e.Head.RewritesWorktree() {
+			continue
+		}
+		up.onHeadChange(ctx, e.Head, up.Sy)
+	}
+}
+
+// onHeadChange resynchronizes the files after a change of the HEAD of the repository and offers to restart the command,
+// because processes started with the previous files behave unexpectedly
+func (up *upContext) onHeadChange(ctx context.Context, change repository.HeadChange, resyncer folderResyncer) {
+	oktetoLog.Infof("repository HEAD changed: %s", change)
+	up.events.Record(events.Sync, fmt.Sprintf("Repository %s, resynchronizing files", change), nil)
+
+	if err := resyncer.Rescan(ctx); err != nil {
+		oktetoLog.Infof("failed to rescan the sync folders: %s", err)
+	}
+	if err := resyncer.Overwrite(ctx); err != nil {
+		up.events.Record(events.Error, "failed to resynchronize files after a repository change", err)
+		oktetoLog.Infof("failed to overwrite the remote files: %s", err)
+	}
+
+	if up.dashboard != nil {
+		up.dashboard.Notify("Repository %s. Files resynchronized, press 'r' to restart the command", change)
+		return
+	}
+	oktetoLog.Warning("Repository %s. Your files have been resynchronized.\n    Restart your command in the development container to avoid running stale processes", change)
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeFolderResyncer struct {
+	rescanned   bool
+	overwritten bool
+	err         error
+}
+
+func (f *fakeFolderResyncer) Rescan(context.Context) error {
+	f.rescanned = true
+	return f.err
+}
+
+func (f *fakeFolderResyncer) Overwrite(context.Context) error {
+	f.overwritten = true
+	return f.err
+}
+
+func Test_onHeadChange(t *testing.T) {
+	var tests = []struct {
+		name string
+		err  error
+	}{
+		{
+			name: "resync succeeds",
+		},
+		{
+			name: "resync fails",
+			err:  assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			up := &upContext{Dev: &model.Dev{}}
+			resyncer := &fakeFolderResyncer{err: tt.err}
+			up.onHeadChange(context.Background(), repository.HeadChange{PreviousBranch: "main", Branch: "feature"}, resyncer)
+			assert.True(t, resyncer.rescanned)
+			assert.True(t, resyncer.overwritten)
+		})
+	}
+}
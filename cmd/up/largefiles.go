@@ -0,0 +1,164 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/syncthing"
+)
+
+// walkSyncFolder calls fn for every file of a sync folder synchronized by syncthing until fn returns false.
+// The files of the '.git' folder and the ones matching the '.stignore' file are skipped
+func walkSyncFolder(folder string, fn func(path string, info fs.FileInfo) bool) error {
+	ignore, err := syncthing.LoadIgnore(folder)
+	if err != nil {
+		return err
+	}
+	return filepath.WalkDir(folder, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == folder {
+			return nil
+		}
+		rel, err := filepath.Rel(folder, path)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" || ignore.SkipDir(rel) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if ignore.Match(rel) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if !fn(path, info) {
+			return fs.SkipAll
+		}
+		return nil
+	})
+}
+
+// checkSkipLargeFiles stops 'okteto up' before transferring the files of the sync folders bigger than 'sync.skipLargeFiles'.
+// The '.stignore' files belong to the user, so the lines that skip these files are suggested instead of written.
+// It also warns about the git LFS pointers of the sync folders, as the development container would receive them instead of their content
+func checkSkipLargeFiles(dev *model.Dev) error {
+	if dev.IsHybridModeEnabled() {
+		return nil
+	}
+	maxSize, err := dev.Sync.GetSkipLargeFiles()
+	if err != nil {
+		return err
+	}
+
+	suggestions := []string{}
+	skipped := 0
+	lfsPointers := []string{}
+	for _, folder := range dev.Sync.Folders {
+		patterns := []string{}
+		checkLFS := syncthing.UsesLFS(folder.LocalPath)
+		if maxSize == 0 && !checkLFS {
+			continue
+		}
+		err := walkSyncFolder(folder.LocalPath, func(path string, info fs.FileInfo) bool {
+			if maxSize > 0 && info.Size() > maxSize {
+				rel, err := filepath.Rel(folder.LocalPath, path)
+				if err == nil {
+					patterns = append(patterns, toStignorePattern(rel))
+				}
+			}
+			if checkLFS && syncthing.IsLFSPointer(path, info.Size()) {
+				lfsPointers = append(lfsPointers, path)
+			}
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		if len(patterns) == 0 {
+			continue
+		}
+		skipped += len(patterns)
+		suggestions = append(suggestions, fmt.Sprintf("    %s:", filepath.Join(folder.LocalPath, ".stignore")))
+		suggestions = append(suggestions, getSuggestedLines(patterns)...)
+	}
+
+	if len(lfsPointers) > 0 {
+		oktetoLog.Warning("%d git LFS files haven't been fetched, your development container will receive their pointers instead of their content:", len(lfsPointers))
+		printFiles(lfsPointers)
+		oktetoLog.Println("    Run 'git lfs pull' to fetch them")
+	}
+	if skipped == 0 {
+		return nil
+	}
+	return oktetoErrors.UserError{
+		E: fmt.Errorf("%d files of your sync folders are bigger than 'sync.skipLargeFiles' (%s)", skipped, dev.Sync.SkipLargeFiles),
+		Hint: fmt.Sprintf("Add the following lines to your '.stignore' files to skip them, or increase 'sync.skipLargeFiles' in your okteto manifest:\n%s",
+			strings.Join(suggestions, "\n")),
+	}
+}
+
+// getSuggestedLines returns the '.stignore' lines suggested to skip the given patterns, up to maxLargeFilesReported
+func getSuggestedLines(patterns []string) []string {
+	lines := []string{}
+	for i, p := range patterns {
+		if i == maxLargeFilesReported {
+			lines = append(lines, fmt.Sprintf("      ... and %d more", len(patterns)-maxLargeFilesReported))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("      %s", p))
+	}
+	return lines
+}
+
+func printFiles(files []string) {
+	for i, f := range files {
+		if i == maxLargeFilesReported {
+			oktetoLog.Println(fmt.Sprintf("    ... and %d more", len(files)-maxLargeFilesReported))
+			return
+		}
+		oktetoLog.Println(fmt.Sprintf("    - %s", f))
+	}
+}
+
+// toStignorePattern returns the pattern that matches exactly a path relative to the sync folder
+func toStignorePattern(rel string) string {
+	escape := `\`
+	if runtime.GOOS == "windows" {
+		escape = "|"
+	}
+	var b strings.Builder
+	b.WriteString("/")
+	for _, c := range filepath.ToSlash(rel) {
+		if strings.ContainsRune(`*?[]{}`+escape, c) {
+			b.WriteString(escape)
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
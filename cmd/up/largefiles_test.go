@@ -0,0 +1,77 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_toStignorePattern(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the escape character is different on windows")
+	}
+	assert.Equal(t, "/assets/video.mp4", toStignorePattern(filepath.Join("assets", "video.mp4")))
+	assert.Equal(t, `/assets/\[draft\] video\*.mp4`, toStignorePattern(filepath.Join("assets", "[draft] video*.mp4")))
+}
+
+func Test_checkSkipLargeFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".stignore"), []byte(".git\nignored.bin\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".gitattributes"), []byte("*.psd filter=lfs diff=lfs merge=lfs -text\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "small.txt"), []byte("small"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "large.bin"), make([]byte, 2048), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ignored.bin"), make([]byte, 2048), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "design.psd"), []byte("version https://git-lfs.github.com/spec/v1\noid sha256:4d7a\nsize 12345\n"), 0600))
+
+	dev := &model.Dev{
+		Sync: model.Sync{
+			SkipLargeFiles: "1Ki",
+			Folders:        []model.SyncFolder{{LocalPath: dir, RemotePath: "/app"}},
+		},
+	}
+	err := checkSkipLargeFiles(dev)
+	uErr := oktetoErrors.UserError{}
+	require.ErrorAs(t, err, &uErr)
+	assert.Equal(t, "1 files of your sync folders are bigger than 'sync.skipLargeFiles' (1Ki)", uErr.E.Error())
+	assert.Contains(t, uErr.Hint, filepath.Join(dir, ".stignore")+":\n      /large.bin")
+	assert.NotContains(t, uErr.Hint, "ignored.bin")
+
+	// the '.stignore' file is not modified
+	content, err := os.ReadFile(filepath.Join(dir, ".stignore"))
+	require.NoError(t, err)
+	assert.Equal(t, ".git\nignored.bin\n", string(content))
+
+	dev.Sync.SkipLargeFiles = ""
+	require.NoError(t, checkSkipLargeFiles(dev))
+}
+
+func Test_getSuggestedLines(t *testing.T) {
+	patterns := []string{}
+	for i := 0; i < maxLargeFilesReported+2; i++ {
+		patterns = append(patterns, fmt.Sprintf("/file-%d", i))
+	}
+	lines := getSuggestedLines(patterns)
+	assert.Len(t, lines, maxLargeFilesReported+1)
+	assert.Equal(t, "      /file-0", lines[0])
+	assert.Equal(t, "      ... and 2 more", lines[maxLargeFilesReported])
+}
@@ -0,0 +1,240 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package up
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/syncthing"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	metricsNamespace       = "okteto_up"
+	metricsUpdateInterval  = 10 * time.Second
+	metricsShutdownTimeout = 5 * time.Second
+
+	reconnectCauseDefault         = "default"
+	reconnectCauseDevPodRecreated = "dev-pod-recreated"
+	syncDirectionIn               = "in"
+	syncDirectionOut              = "out"
+)
+
+// syncStatusGetter returns the status of the file synchronization
+type syncStatusGetter interface {
+	GetCompletion(ctx context.Context, local bool, device string) (*syncthing.Completion, error)
+	GetConnections(ctx context.Context) (*syncthing.Connections, error)
+}
+
+// upMetrics exposes the state of an 'okteto up' session as prometheus metrics
+type upMetrics struct {
+	registry         *prometheus.Registry
+	syncLag          prometheus.Gauge
+	syncPendingBytes prometheus.Gauge
+	syncBytes        *prometheus.CounterVec
+	reconnects       *prometheus.CounterVec
+	restarts         prometheus.Gauge
+
+	mu         sync.Mutex
+	sy         syncStatusGetter
+	pod        *apiv1.Pod
+	lastInSync time.Time
+	inBytes    int64
+	outBytes   int64
+}
+
+func newUpMetrics(dev *model.Dev) *upMetrics {
+	labels := prometheus.Labels{"dev": dev.Name, "namespace": dev.Namespace}
+	m := &upMetrics{
+		registry: prometheus.NewRegistry(),
+		syncLag: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace:   metricsNamespace,
+			Name:        "sync_lag_seconds",
+			Help:        "Seconds since the local and remote files were last in sync, 0 if they are in sync.",
+			ConstLabels: labels,
+		}),
+		syncPendingBytes: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace:   metricsNamespace,
+			Name:        "sync_pending_bytes",
+			Help:        "Bytes pending to be synchronized to the development container.",
+			ConstLabels: labels,
+		}),
+		syncBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   metricsNamespace,
+			Name:        "sync_transferred_bytes_total",
+			Help:        "Bytes transferred by the file synchronization.",
+			ConstLabels: labels,
+		}, []string{"direction"}),
+		reconnects: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   metricsNamespace,
+			Name:        "forward_reconnects_total",
+			Help:        "Times the port forwards and the file synchronization were reconnected to the development container.",
+			ConstLabels: labels,
+		}, []string{"cause"}),
+		restarts: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace:   metricsNamespace,
+			Name:        "dev_container_restarts",
+			Help:        "Restarts of the containers of the current development pod.",
+			ConstLabels: labels,
+		}),
+	}
+	m.registry.MustRegister(m.syncLag, m.syncPendingBytes, m.syncBytes, m.reconnects, m.restarts)
+	return m
+}
+
+// setSyncthing sets the syncthing instance of the current activation
+func (m *upMetrics) setSyncthing(sy syncStatusGetter) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sy = sy
+	m.lastInSync = time.Now()
+	m.inBytes = 0
+	m.outBytes = 0
+}
+
+// setPod sets the development pod of the current activation
+func (m *upMetrics) setPod(pod *apiv1.Pod) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pod = pod
+}
+
+// recordReconnect counts a reconnection to the development container
+func (m *upMetrics) recordReconnect(cause string) {
+	if m == nil {
+		return
+	}
+	m.reconnects.WithLabelValues(cause).Inc()
+}
+
+// update refreshes the metrics of the file synchronization and the development pod
+func (m *upMetrics) update(ctx context.Context, c kubernetes.Interface) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.sy != nil {
+		m.updateSync(ctx)
+	}
+
+	if m.pod != nil && c != nil {
+		pod, err := c.CoreV1().Pods(m.pod.Namespace).Get(ctx, m.pod.Name, metav1.GetOptions{})
+		if err != nil {
+			oktetoLog.Infof("failed to get development pod for metrics: %s", err)
+			return
+		}
+		restarts := int32(0)
+		for _, cs := range pod.Status.ContainerStatuses {
+			restarts += cs.RestartCount
+		}
+		m.restarts.Set(float64(restarts))
+	}
+}
+
+func (m *upMetrics) updateSync(ctx context.Context) {
+	completion, err := m.sy.GetCompletion(ctx, true, syncthing.DefaultRemoteDeviceID)
+	if err != nil {
+		oktetoLog.Infof("failed to get syncthing completion for metrics: %s", err)
+	} else {
+		m.syncPendingBytes.Set(float64(completion.NeedBytes))
+		if completion.NeedBytes == 0 && completion.NeedItems == 0 && completion.NeedDeletes == 0 {
+			m.lastInSync = time.Now()
+		}
+		m.syncLag.Set(time.Since(m.lastInSync).Truncate(time.Second).Seconds())
+	}
+
+	connections, err := m.sy.GetConnections(ctx)
+	if err != nil {
+		oktetoLog.Infof("failed to get syncthing connections for metrics: %s", err)
+		return
+	}
+	// syncthing resets its totals when it restarts, only the increments are added to the counters
+	if in := connections.Total.InBytesTotal; in >= m.inBytes {
+		m.syncBytes.WithLabelValues(syncDirectionIn).Add(float64(in - m.inBytes))
+		m.inBytes = in
+	}
+	if out := connections.Total.OutBytesTotal; out >= m.outBytes {
+		m.syncBytes.WithLabelValues(syncDirectionOut).Add(float64(out - m.outBytes))
+		m.outBytes = out
+	}
+}
+
+// startMetrics serves the metrics of the session at 'http://<interface>:<metrics-port>/metrics' until the returned function is called
+func (up *upContext) startMetrics() (func(), error) {
+	address := net.JoinHostPort(up.Dev.Interface, strconv.Itoa(up.Options.MetricsPort))
+	l, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("failed to expose the metrics at %s: %w", address, err),
+			Hint: "Use the '--metrics-port' flag to expose them on a different port",
+		}
+	}
+
+	up.metrics = newUpMetrics(up.Dev)
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(up.metrics.registry, promhttp.HandlerOpts{}))
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: metricsShutdownTimeout}
+	go func() {
+		if err := srv.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			oktetoLog.Infof("metrics server failed: %s", err)
+		}
+	}()
+	oktetoLog.Information("Metrics available at http://%s/metrics", address)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		ticker := time.NewTicker(metricsUpdateInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				c, _, err := up.K8sClientProvider.Provide(okteto.Context().Cfg)
+				if err != nil {
+					oktetoLog.Infof("failed to get kubernetes client for metrics: %s", err)
+				}
+				up.metrics.update(ctx, c)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), metricsShutdownTimeout)
+		defer cancelShutdown()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			oktetoLog.Infof("failed to stop metrics server: %s", err)
+		}
+	}, nil
+}
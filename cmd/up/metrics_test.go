@@ -0,0 +1,87 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package up
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/syncthing"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type fakeSyncStatusGetter struct {
+	completion  *syncthing.Completion
+	connections *syncthing.Connections
+}
+
+func (f *fakeSyncStatusGetter) GetCompletion(_ context.Context, _ bool, _ string) (*syncthing.Completion, error) {
+	return f.completion, nil
+}
+
+func (f *fakeSyncStatusGetter) GetConnections(_ context.Context) (*syncthing.Connections, error) {
+	return f.connections, nil
+}
+
+func TestUpMetricsUpdate(t *testing.T) {
+	m := newUpMetrics(&model.Dev{Name: "api", Namespace: "cindy"})
+	sy := &fakeSyncStatusGetter{
+		completion:  &syncthing.Completion{NeedBytes: 1024, NeedItems: 1},
+		connections: &syncthing.Connections{Total: syncthing.ConnectionsTotal{InBytesTotal: 100, OutBytesTotal: 2000}},
+	}
+	m.setSyncthing(sy)
+
+	pod := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-123", Namespace: "cindy"},
+		Status: apiv1.PodStatus{
+			ContainerStatuses: []apiv1.ContainerStatus{{Name: "api", RestartCount: 2}},
+		},
+	}
+	m.setPod(pod)
+	c := fake.NewSimpleClientset(pod)
+
+	m.update(context.Background(), c)
+	assert.Equal(t, float64(1024), testutil.ToFloat64(m.syncPendingBytes))
+	assert.Equal(t, float64(100), testutil.ToFloat64(m.syncBytes.WithLabelValues(syncDirectionIn)))
+	assert.Equal(t, float64(2000), testutil.ToFloat64(m.syncBytes.WithLabelValues(syncDirectionOut)))
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.restarts))
+
+	// a new syncthing process starts its totals from zero
+	m.setSyncthing(sy)
+	sy.completion = &syncthing.Completion{}
+	sy.connections = &syncthing.Connections{Total: syncthing.ConnectionsTotal{InBytesTotal: 50, OutBytesTotal: 500}}
+	m.update(context.Background(), c)
+	assert.Equal(t, float64(0), testutil.ToFloat64(m.syncPendingBytes))
+	assert.Equal(t, float64(0), testutil.ToFloat64(m.syncLag))
+	assert.Equal(t, float64(150), testutil.ToFloat64(m.syncBytes.WithLabelValues(syncDirectionIn)))
+	assert.Equal(t, float64(2500), testutil.ToFloat64(m.syncBytes.WithLabelValues(syncDirectionOut)))
+}
+
+func TestUpMetricsRecordReconnect(t *testing.T) {
+	var m *upMetrics
+	// metrics are optional, a nil upMetrics ignores the records
+	m.recordReconnect(reconnectCauseDefault)
+	m.setPod(&apiv1.Pod{})
+
+	m = newUpMetrics(&model.Dev{Name: "api", Namespace: "cindy"})
+	m.recordReconnect(reconnectCauseDefault)
+	m.recordReconnect(reconnectCauseDevPodRecreated)
+	m.recordReconnect(reconnectCauseDefault)
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.reconnects.WithLabelValues(reconnectCauseDefault)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.reconnects.WithLabelValues(reconnectCauseDevPodRecreated)))
+}
@@ -18,11 +18,13 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/okteto/okteto/pkg/config"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/shirou/gopsutil/process"
 	"github.com/spf13/afero"
 )
 
@@ -135,6 +137,24 @@ func (pc pidController) delete() {
 	}
 }
 
+// isRunning returns if the okteto process that owns the PID file is still running
+func (pc pidController) isRunning() bool {
+	filePID, err := pc.get()
+	if err != nil {
+		return false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(filePID))
+	if err != nil || pid == pc.pidProvider.provide() {
+		return false
+	}
+	exists, err := process.PidExists(int32(pid))
+	if err != nil {
+		oktetoLog.Infof("failed to check if the okteto process %d is running: %s", pid, err)
+		return false
+	}
+	return exists
+}
+
 // notifyIfPIDFileChange returns a message in the channel whenever the content of the PID file has changed
 func (pc pidController) notifyIfPIDFileChange(notifyCh chan error) {
 	for {
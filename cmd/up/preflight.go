@@ -0,0 +1,540 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/shirou/gopsutil/disk"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	preflightPassed  = "passed"
+	preflightWarning = "warning"
+	preflightFailed  = "failed"
+	preflightSkipped = "skipped"
+
+	preflightDisk    = "disk"
+	preflightClock   = "clock"
+	preflightCluster = "cluster"
+	preflightQuota   = "quota"
+	preflightImage   = "image"
+	preflightPorts   = "ports"
+	preflightGPU     = "gpu"
+
+	// skipAllPreflightChecks skips all the pre-flight checks
+	skipAllPreflightChecks = "all"
+
+	// minFreeDiskBytes is the free disk space required by the file synchronization database
+	minFreeDiskBytes = 100 * 1024 * 1024
+	// lowFreeDiskBytes is the free disk space below which a warning is shown
+	lowFreeDiskBytes = 1024 * 1024 * 1024
+
+	// maxClockSkew is the clock skew with the cluster above which a warning is shown. It breaks token expiration and certificates validation
+	maxClockSkew = 30 * time.Second
+
+	// quotaHeadroomRatio is the usage ratio of a resource quota above which a warning is shown
+	quotaHeadroomRatio = 0.9
+
+	preflightTimeout = 10 * time.Second
+)
+
+var preflightChecks = []string{preflightDisk, preflightClock, preflightCluster, preflightQuota, preflightImage, preflightPorts, preflightGPU}
+
+// preflightResult is the result of a pre-flight check
+type preflightResult struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+// preflightChecker runs the checks to detect problems that would make 'okteto up' fail before activating the development container
+type preflightChecker struct {
+	dev           *model.Dev
+	k8sClient     kubernetes.Interface
+	registry      registryInterface
+	freeDisk      func(path string) (uint64, error)
+	portAvailable func(iface string, port int) bool
+	serverTime    func(ctx context.Context) (time.Time, error)
+	now           func() time.Time
+	offline       bool
+}
+
+func newPreflightChecker(dev *model.Dev, c kubernetes.Interface, restConfig *rest.Config, registry registryInterface) *preflightChecker {
+	return &preflightChecker{
+		dev:           dev,
+		k8sClient:     c,
+		registry:      registry,
+		freeDisk:      getFreeDisk,
+		portAvailable: model.IsPortAvailable,
+		serverTime: func(ctx context.Context) (time.Time, error) {
+			return getServerTime(ctx, restConfig)
+		},
+		now:     time.Now,
+		offline: config.IsOffline(),
+	}
+}
+
+// validatePreflightSkips returns an error if skip contains checks that don't exist
+func validatePreflightSkips(skip []string) error {
+	for _, s := range skip {
+		if s == skipAllPreflightChecks {
+			continue
+		}
+		found := false
+		for _, name := range preflightChecks {
+			if s == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("'%s' is not a pre-flight check", s),
+				Hint: fmt.Sprintf("Use one of: %s, %s", strings.Join(preflightChecks, ", "), skipAllPreflightChecks),
+			}
+		}
+	}
+	return nil
+}
+
+// run runs all the checks except the skipped ones
+func (pc *preflightChecker) run(ctx context.Context, skip []string) []preflightResult {
+	skipped := map[string]bool{}
+	for _, s := range skip {
+		skipped[s] = true
+	}
+
+	results := make([]preflightResult, 0, len(preflightChecks))
+	for _, name := range preflightChecks {
+		if skipped[name] || skipped[skipAllPreflightChecks] {
+			results = append(results, preflightResult{Name: name, Status: preflightSkipped})
+			continue
+		}
+		result := pc.runCheck(ctx, name)
+		result.Name = name
+		results = append(results, result)
+	}
+	return results
+}
+
+func (pc *preflightChecker) runCheck(ctx context.Context, name string) preflightResult {
+	ctx, cancel := context.WithTimeout(ctx, preflightTimeout)
+	defer cancel()
+
+	switch name {
+	case preflightDisk:
+		return pc.checkDisk()
+	case preflightClock:
+		return pc.checkClock(ctx)
+	case preflightCluster:
+		return pc.checkCluster()
+	case preflightQuota:
+		return pc.checkQuota(ctx)
+	case preflightImage:
+		return pc.checkImage(ctx)
+	case preflightPorts:
+		return pc.checkPorts()
+	case preflightGPU:
+		return pc.checkGPU(ctx)
+	}
+	return preflightResult{Status: preflightSkipped}
+}
+
+// checkDisk checks the free disk space of the synchronized folders and of the folder of the synchronization database
+func (pc *preflightChecker) checkDisk() preflightResult {
+	paths := []string{config.GetOktetoHome()}
+	for _, f := range pc.dev.Sync.Folders {
+		paths = append(paths, f.LocalPath)
+	}
+
+	lowest := uint64(0)
+	lowestPath := ""
+	for _, p := range paths {
+		free, err := pc.freeDisk(p)
+		if err != nil {
+			oktetoLog.Infof("failed to get the free disk space of %s: %s", p, err)
+			continue
+		}
+		if lowestPath == "" || free < lowest {
+			lowest = free
+			lowestPath = p
+		}
+	}
+
+	switch {
+	case lowestPath == "":
+		return preflightResult{Status: preflightWarning, Message: "the free disk space couldn't be checked"}
+	case lowest < minFreeDiskBytes:
+		return preflightResult{
+			Status:  preflightFailed,
+			Message: fmt.Sprintf("only %s free at %s", formatBytes(lowest), lowestPath),
+			Hint:    "Free up disk space, the file synchronization needs space for its database and temporary files",
+		}
+	case lowest < lowFreeDiskBytes:
+		return preflightResult{
+			Status:  preflightWarning,
+			Message: fmt.Sprintf("only %s free at %s", formatBytes(lowest), lowestPath),
+			Hint:    "The file synchronization might fail if the disk runs out of space",
+		}
+	}
+	return preflightResult{Status: preflightPassed, Message: fmt.Sprintf("%s free", formatBytes(lowest))}
+}
+
+// checkClock checks the clock skew between this machine and the cluster
+func (pc *preflightChecker) checkClock(ctx context.Context) preflightResult {
+	start := pc.now()
+	serverTime, err := pc.serverTime(ctx)
+	if err != nil {
+		return preflightResult{Status: preflightWarning, Message: fmt.Sprintf("the clock of the cluster couldn't be checked: %s", err)}
+	}
+	end := pc.now()
+	// the server time is compared with the middle of the request, its precision is one second
+	local := start.Add(end.Sub(start) / 2)
+	skew := local.Sub(serverTime)
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > maxClockSkew {
+		return preflightResult{
+			Status:  preflightWarning,
+			Message: fmt.Sprintf("the clock of this machine differs %s from the cluster", skew.Round(time.Second)),
+			Hint:    "Synchronize the clock of your machine, a clock skew breaks the validation of tokens and certificates",
+		}
+	}
+	return preflightResult{Status: preflightPassed}
+}
+
+// checkCluster checks the connectivity with the Kubernetes API
+func (pc *preflightChecker) checkCluster() preflightResult {
+	version, err := pc.k8sClient.Discovery().ServerVersion()
+	if err != nil {
+		return preflightResult{
+			Status:  preflightFailed,
+			Message: fmt.Sprintf("the cluster is not reachable: %s", err),
+			Hint:    "Check your connection and run 'okteto context' to select a valid context",
+		}
+	}
+	return preflightResult{Status: preflightPassed, Message: fmt.Sprintf("kubernetes %s", version.GitVersion)}
+}
+
+// checkQuota checks that the resource quotas of the namespace have room for the resources requested by the development container.
+// The quotas are enforced by the cluster when the pod is created, so an exhausted quota is reported as a warning
+func (pc *preflightChecker) checkQuota(ctx context.Context) preflightResult {
+	requested := getQuotaResources(pc.dev)
+	if len(requested) == 0 {
+		return preflightResult{Status: preflightPassed, Message: "no resources requested"}
+	}
+	quotas, err := pc.k8sClient.CoreV1().ResourceQuotas(pc.dev.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return preflightResult{Status: preflightWarning, Message: fmt.Sprintf("the resource quotas couldn't be checked: %s", err)}
+	}
+
+	var exhausted, low []string
+	for _, q := range quotas.Items {
+		for resource, hard := range q.Status.Hard {
+			used, ok := q.Status.Used[resource]
+			if !ok || hard.IsZero() || !requested[resource] {
+				continue
+			}
+			name := fmt.Sprintf("%s (%s/%s in quota '%s')", resource, used.String(), hard.String(), q.Name)
+			if used.Cmp(hard) >= 0 {
+				exhausted = append(exhausted, name)
+				continue
+			}
+			if used.AsApproximateFloat64() >= quotaHeadroomRatio*hard.AsApproximateFloat64() {
+				low = append(low, name)
+			}
+		}
+	}
+	sort.Strings(exhausted)
+	sort.Strings(low)
+
+	switch {
+	case len(exhausted) > 0:
+		return preflightResult{
+			Status:  preflightWarning,
+			Message: fmt.Sprintf("namespace '%s' has exhausted: %s", pc.dev.Namespace, strings.Join(exhausted, ", ")),
+			Hint:    "Free up resources of the namespace or ask your administrator to increase its quota",
+		}
+	case len(low) > 0:
+		return preflightResult{
+			Status:  preflightWarning,
+			Message: fmt.Sprintf("namespace '%s' is close to its quota: %s", pc.dev.Namespace, strings.Join(low, ", ")),
+		}
+	}
+	return preflightResult{Status: preflightPassed}
+}
+
+// getQuotaResources returns the names of the quota resources consumed by the requests and limits of the development container
+func getQuotaResources(dev *model.Dev) map[apiv1.ResourceName]bool {
+	result := map[apiv1.ResourceName]bool{}
+	for name := range dev.Resources.Requests {
+		result[name] = true
+		result[apiv1.ResourceName(fmt.Sprintf("requests.%s", name))] = true
+	}
+	for name := range dev.Resources.Limits {
+		result[apiv1.ResourceName(fmt.Sprintf("limits.%s", name))] = true
+	}
+	if dev.GPU != nil {
+		result[apiv1.ResourceName(fmt.Sprintf("requests.%s", dev.GPU.Resource))] = true
+	}
+	return result
+}
+
+// checkImage checks that the image of the development container can be pulled with the credentials of this machine
+func (pc *preflightChecker) checkImage(ctx context.Context) preflightResult {
+	if pc.dev.Image == nil || pc.dev.Image.Name == "" {
+		return preflightResult{Status: preflightPassed, Message: "the image of the original container is used"}
+	}
+	image := pc.dev.Image.Name
+	if strings.Contains(image, "$") {
+		return preflightResult{Status: preflightPassed, Message: fmt.Sprintf("the image '%s' is built by okteto", image)}
+	}
+	if pc.offline {
+		return preflightResult{Status: preflightSkipped, Message: "the registry is not checked in offline mode"}
+	}
+	if err := pc.getImageDigest(ctx, image); err != nil {
+		if errors.Is(err, oktetoErrors.ErrNotFound) {
+			return preflightResult{
+				Status:  preflightFailed,
+				Message: fmt.Sprintf("the image '%s' doesn't exist", image),
+				Hint:    "Check the 'image' field of your okteto manifest",
+			}
+		}
+		// the cluster might pull the image with its own credentials
+		return preflightResult{
+			Status:  preflightWarning,
+			Message: fmt.Sprintf("the image '%s' couldn't be accessed: %s", image, err),
+			Hint:    "Check that the cluster has credentials to pull the image",
+		}
+	}
+	return preflightResult{Status: preflightPassed}
+}
+
+// getImageDigest gets the digest of the image from the registry, giving up when ctx is done
+func (pc *preflightChecker) getImageDigest(ctx context.Context, image string) error {
+	result := make(chan error, 1)
+	go func() {
+		_, err := pc.registry.GetImageTagWithDigest(image)
+		result <- err
+	}()
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("the registry didn't answer in %s", preflightTimeout)
+	}
+}
+
+// checkPorts checks that the local ports of the forwards are available
+func (pc *preflightChecker) checkPorts() preflightResult {
+	ports := []int{}
+	if pc.dev.RemotePort > 0 {
+		ports = append(ports, pc.dev.RemotePort)
+	}
+	for _, f := range pc.dev.Forward {
+		ports = append(ports, f.Local)
+	}
+
+	var taken []string
+	for _, p := range ports {
+		if !pc.portAvailable(pc.dev.Interface, p) {
+			taken = append(taken, fmt.Sprintf("%d", p))
+		}
+	}
+	if len(taken) > 0 {
+		return preflightResult{
+			Status:  preflightFailed,
+			Message: fmt.Sprintf("local ports already in use: %s", strings.Join(taken, ", ")),
+			Hint:    "Stop the processes using them or change the 'forward' field of your okteto manifest",
+		}
+	}
+	return preflightResult{Status: preflightPassed}
+}
+
+// checkGPU checks that the cluster has schedulable nodes with enough GPUs for the development containers that request them
+func (pc *preflightChecker) checkGPU(ctx context.Context) preflightResult {
+	devs := []*model.Dev{}
+	for _, d := range append([]*model.Dev{pc.dev}, pc.dev.Services...) {
+		if d.GPU != nil {
+			devs = append(devs, d)
+		}
+	}
+	if len(devs) == 0 {
+		return preflightResult{Status: preflightPassed, Message: "no GPUs requested"}
+	}
+
+	nodes, err := pc.k8sClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		// users of namespaces managed by okteto are not allowed to list nodes
+		return preflightResult{Status: preflightWarning, Message: fmt.Sprintf("the GPU nodes couldn't be checked: %s", err)}
+	}
+
+	var unfeasible []string
+	for _, d := range devs {
+		selector := labels.SelectorFromSet(d.NodeSelector)
+		largest := int64(0)
+		for _, n := range nodes.Items {
+			if n.Spec.Unschedulable || !selector.Matches(labels.Set(n.Labels)) {
+				continue
+			}
+			if q, ok := n.Status.Allocatable[d.GPU.Resource]; ok && q.Value() > largest {
+				largest = q.Value()
+			}
+		}
+		if largest < d.GPU.Count {
+			unfeasible = append(unfeasible, fmt.Sprintf("'%s' requests %d %s but the largest node has %d", d.Name, d.GPU.Count, d.GPU.Resource, largest))
+		}
+	}
+	if len(unfeasible) > 0 {
+		return preflightResult{
+			Status:  preflightFailed,
+			Message: strings.Join(unfeasible, ", "),
+			Hint:    "Check the 'gpu' and 'nodeSelector' fields of your okteto manifest or ask your administrator to add GPU nodes to the cluster",
+		}
+	}
+	return preflightResult{Status: preflightPassed}
+}
+
+// preflightError returns an error with the failed checks, or nil if none failed
+func preflightError(results []preflightResult) error {
+	var failed []string
+	hint := ""
+	for _, r := range results {
+		if r.Status != preflightFailed {
+			continue
+		}
+		failed = append(failed, fmt.Sprintf("%s: %s", r.Name, r.Message))
+		if hint == "" {
+			hint = r.Hint
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return oktetoErrors.UserError{
+		E: fmt.Errorf("pre-flight checks failed:\n      - %s", strings.Join(failed, "\n      - ")),
+		Hint: fmt.Sprintf(`%s
+    Use '--skip-checks <name>' to skip a check`, hint),
+	}
+}
+
+// printPreflightResults prints the results of the checks. Only the warnings are printed unless verbose is true
+func printPreflightResults(results []preflightResult, output string, verbose bool) error {
+	if output == "json" {
+		b, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal the pre-flight checks: %w", err)
+		}
+		oktetoLog.Println(string(b))
+		return nil
+	}
+
+	for _, r := range results {
+		msg := r.Name
+		if r.Message != "" {
+			msg = fmt.Sprintf("%s: %s", r.Name, r.Message)
+		}
+		switch r.Status {
+		case preflightPassed:
+			if verbose {
+				oktetoLog.Success(msg)
+			} else {
+				oktetoLog.Infof("pre-flight check %s", msg)
+			}
+		case preflightWarning:
+			oktetoLog.Warning(msg)
+			if r.Hint != "" && verbose {
+				oktetoLog.Hint("    %s", r.Hint)
+			}
+		case preflightFailed:
+			if verbose {
+				oktetoLog.Fail(msg)
+				if r.Hint != "" {
+					oktetoLog.Hint("    %s", r.Hint)
+				}
+			}
+		case preflightSkipped:
+			if verbose {
+				oktetoLog.Information("%s: skipped", r.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func getFreeDisk(path string) (uint64, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return 0, err
+	}
+	usage, err := disk.Usage(abs)
+	if err != nil {
+		return 0, err
+	}
+	return usage.Free, nil
+}
+
+// getServerTime returns the time of the Kubernetes API from the 'Date' header of its response
+func getServerTime(ctx context.Context, restConfig *rest.Config) (time.Time, error) {
+	if restConfig == nil {
+		return time.Time{}, fmt.Errorf("okteto context not initialized")
+	}
+	client, err := rest.HTTPClientFor(restConfig)
+	if err != nil {
+		return time.Time{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(restConfig.Host, "/")+"/version", nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer resp.Body.Close()
+	return http.ParseTime(resp.Header.Get("Date"))
+}
+
+func formatBytes(b uint64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := uint64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
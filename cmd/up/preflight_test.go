@@ -0,0 +1,291 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type fakePreflightRegistry struct {
+	err       error
+	platforms []string
+	block     bool
+}
+
+func (f fakePreflightRegistry) GetImageTagWithDigest(image string) (string, error) {
+	if f.block {
+		select {}
+	}
+	return image, f.err
+}
+
+func (fakePreflightRegistry) GetImageTag(image, _, _ string) string {
+	return image
+}
+
+func (f fakePreflightRegistry) GetImagePlatforms(_ string) ([]string, error) {
+	return f.platforms, f.err
+}
+
+func newFakePreflightChecker(objects ...runtime.Object) *preflightChecker {
+	now := time.Now()
+	return &preflightChecker{
+		dev: &model.Dev{
+			Name:      "api",
+			Namespace: "cindy",
+			Interface: model.Localhost,
+			Image:     &model.BuildInfo{Name: "okteto/golang:1"},
+			Sync:      model.Sync{Folders: []model.SyncFolder{{LocalPath: ".", RemotePath: "/app"}}},
+			Forward:   []forward.Forward{{Local: 8080, Remote: 8080}},
+		},
+		k8sClient:     fake.NewSimpleClientset(objects...),
+		registry:      fakePreflightRegistry{},
+		freeDisk:      func(string) (uint64, error) { return 10 * lowFreeDiskBytes, nil },
+		portAvailable: func(string, int) bool { return true },
+		serverTime:    func(context.Context) (time.Time, error) { return now, nil },
+		now:           func() time.Time { return now },
+	}
+}
+
+func newResourceQuota(used string) *apiv1.ResourceQuota {
+	return &apiv1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: "cindy"},
+		Status: apiv1.ResourceQuotaStatus{
+			Hard: apiv1.ResourceList{apiv1.ResourceRequestsCPU: resource.MustParse("10")},
+			Used: apiv1.ResourceList{apiv1.ResourceRequestsCPU: resource.MustParse(used)},
+		},
+	}
+}
+
+func requestCPU(pc *preflightChecker) {
+	pc.dev.Resources.Requests = model.ResourceList{apiv1.ResourceCPU: resource.MustParse("1")}
+}
+
+func newGPUNode(name, gpus string, nodeLabels map[string]string) *apiv1.Node {
+	return &apiv1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nodeLabels},
+		Status: apiv1.NodeStatus{
+			Allocatable: apiv1.ResourceList{model.NvidiaGPUResource: resource.MustParse(gpus)},
+		},
+	}
+}
+
+func requestGPUs(count int64, nodeSelector map[string]string) func(pc *preflightChecker) {
+	return func(pc *preflightChecker) {
+		pc.dev.GPU = &model.GPU{Count: count, Resource: model.NvidiaGPUResource}
+		pc.dev.NodeSelector = nodeSelector
+	}
+}
+
+func TestPreflightCheckerRun(t *testing.T) {
+	pc := newFakePreflightChecker()
+	results := pc.run(context.Background(), []string{preflightClock})
+	require.Len(t, results, len(preflightChecks))
+	for _, r := range results {
+		expected := preflightPassed
+		if r.Name == preflightClock {
+			expected = preflightSkipped
+		}
+		assert.Equal(t, expected, r.Status, r.Name)
+	}
+	assert.NoError(t, preflightError(results))
+
+	for _, r := range pc.run(context.Background(), []string{skipAllPreflightChecks}) {
+		assert.Equal(t, preflightSkipped, r.Status, r.Name)
+	}
+}
+
+func TestPreflightChecks(t *testing.T) {
+	var tests = []struct {
+		name     string
+		check    string
+		modify   func(pc *preflightChecker)
+		objects  []runtime.Object
+		expected string
+	}{
+		{
+			name:  "disk almost full",
+			check: preflightDisk,
+			modify: func(pc *preflightChecker) {
+				pc.freeDisk = func(string) (uint64, error) { return minFreeDiskBytes / 2, nil }
+			},
+			expected: preflightFailed,
+		},
+		{
+			name:  "low disk",
+			check: preflightDisk,
+			modify: func(pc *preflightChecker) {
+				pc.freeDisk = func(string) (uint64, error) { return lowFreeDiskBytes / 2, nil }
+			},
+			expected: preflightWarning,
+		},
+		{
+			name:  "clock skew",
+			check: preflightClock,
+			modify: func(pc *preflightChecker) {
+				pc.serverTime = func(context.Context) (time.Time, error) { return pc.now().Add(-2 * time.Minute), nil }
+			},
+			expected: preflightWarning,
+		},
+		{
+			name:  "clock not available",
+			check: preflightClock,
+			modify: func(pc *preflightChecker) {
+				pc.serverTime = func(context.Context) (time.Time, error) { return time.Time{}, fmt.Errorf("timeout") }
+			},
+			expected: preflightWarning,
+		},
+		{
+			name:     "quota exhausted",
+			check:    preflightQuota,
+			modify:   requestCPU,
+			objects:  []runtime.Object{newResourceQuota("10")},
+			expected: preflightWarning,
+		},
+		{
+			name:     "quota exhausted for resources not requested",
+			check:    preflightQuota,
+			objects:  []runtime.Object{newResourceQuota("10")},
+			expected: preflightPassed,
+		},
+		{
+			name:     "quota close to its limit",
+			check:    preflightQuota,
+			modify:   requestCPU,
+			objects:  []runtime.Object{newResourceQuota("9500m")},
+			expected: preflightWarning,
+		},
+		{
+			name:     "quota with headroom",
+			check:    preflightQuota,
+			modify:   requestCPU,
+			objects:  []runtime.Object{newResourceQuota("2")},
+			expected: preflightPassed,
+		},
+		{
+			name:  "image not found",
+			check: preflightImage,
+			modify: func(pc *preflightChecker) {
+				pc.registry = fakePreflightRegistry{err: fmt.Errorf("error getting image descriptor: %w", oktetoErrors.ErrNotFound)}
+			},
+			expected: preflightFailed,
+		},
+		{
+			name:  "image unauthorized",
+			check: preflightImage,
+			modify: func(pc *preflightChecker) {
+				pc.registry = fakePreflightRegistry{err: fmt.Errorf("unauthorized")}
+			},
+			expected: preflightWarning,
+		},
+		{
+			name:  "image not checked offline",
+			check: preflightImage,
+			modify: func(pc *preflightChecker) {
+				pc.offline = true
+				pc.registry = fakePreflightRegistry{err: fmt.Errorf("unauthorized")}
+			},
+			expected: preflightSkipped,
+		},
+		{
+			name:  "registry timeout",
+			check: preflightImage,
+			modify: func(pc *preflightChecker) {
+				pc.registry = fakePreflightRegistry{block: true}
+			},
+			expected: preflightWarning,
+		},
+		{
+			name:  "image built by okteto",
+			check: preflightImage,
+			modify: func(pc *preflightChecker) {
+				pc.dev.Image.Name = "${OKTETO_BUILD_API_IMAGE}"
+				pc.registry = fakePreflightRegistry{err: fmt.Errorf("unauthorized")}
+			},
+			expected: preflightPassed,
+		},
+		{
+			name:  "port taken",
+			check: preflightPorts,
+			modify: func(pc *preflightChecker) {
+				pc.portAvailable = func(_ string, port int) bool { return port != 8080 }
+			},
+			expected: preflightFailed,
+		},
+		{
+			name:     "gpu node available",
+			check:    preflightGPU,
+			modify:   requestGPUs(2, nil),
+			objects:  []runtime.Object{newGPUNode("cpu", "0", nil), newGPUNode("gpu", "4", nil)},
+			expected: preflightPassed,
+		},
+		{
+			name:     "gpu nodes too small",
+			check:    preflightGPU,
+			modify:   requestGPUs(8, nil),
+			objects:  []runtime.Object{newGPUNode("gpu", "4", nil)},
+			expected: preflightFailed,
+		},
+		{
+			name:     "gpu node not selected",
+			check:    preflightGPU,
+			modify:   requestGPUs(1, map[string]string{"pool": "a100"}),
+			objects:  []runtime.Object{newGPUNode("gpu", "4", map[string]string{"pool": "t4"})},
+			expected: preflightFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := newFakePreflightChecker(tt.objects...)
+			if tt.modify != nil {
+				tt.modify(pc)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			result := pc.runCheck(ctx, tt.check)
+			assert.Equal(t, tt.expected, result.Status, result.Message)
+		})
+	}
+}
+
+func TestPreflightError(t *testing.T) {
+	err := preflightError([]preflightResult{
+		{Name: preflightDisk, Status: preflightPassed},
+		{Name: preflightPorts, Status: preflightFailed, Message: "local ports already in use: 8080", Hint: "Stop them"},
+	})
+	require.Error(t, err)
+	uErr, ok := err.(oktetoErrors.UserError)
+	require.True(t, ok)
+	assert.Contains(t, uErr.E.Error(), "ports: local ports already in use: 8080")
+	assert.Contains(t, uErr.Hint, "Stop them")
+}
+
+func TestValidatePreflightSkips(t *testing.T) {
+	assert.NoError(t, validatePreflightSkips([]string{preflightDisk, skipAllPreflightChecks}))
+	assert.Error(t, validatePreflightSkips([]string{"unknown"}))
+}
@@ -0,0 +1,170 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// imagePullReasons are the waiting reasons of a container that can't pull its image
+var imagePullReasons = map[string]bool{
+	"ErrImagePull":     true,
+	"ImagePullBackOff": true,
+}
+
+var pullEventImageRegex = regexp.MustCompile(`^Failed to pull image "([^"]+)"`)
+
+// imagePullDiagnoser finds out why the cluster can't pull the image of a development container
+type imagePullDiagnoser struct {
+	registry  registryInterface
+	k8sClient kubernetes.Interface
+}
+
+// getImageFromPullEvent returns the image of a 'Failed to pull image' event of the kubelet, or an empty string for any other event
+func getImageFromPullEvent(e *apiv1.Event) string {
+	matches := pullEventImageRegex.FindStringSubmatch(e.Message)
+	if len(matches) < 2 {
+		return ""
+	}
+	return matches[1]
+}
+
+// getImagePullFailure returns the image and the message of the first container of the pod that can't pull its image
+func getImagePullFailure(pod *apiv1.Pod) (string, string, bool) {
+	statuses := append([]apiv1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+	for _, s := range statuses {
+		if s.State.Waiting != nil && imagePullReasons[s.State.Waiting.Reason] {
+			return s.Image, s.State.Waiting.Message, true
+		}
+	}
+	return "", "", false
+}
+
+// diagnose checks if the image exists, if it can be pulled with the credentials of this machine and if it's built for the platform of the node of the pod.
+// It returns an error with the cause of the failure and how to fix it
+func (d *imagePullDiagnoser) diagnose(ctx context.Context, pod *apiv1.Pod, image, message string) error {
+	oktetoLog.Infof("diagnosing image pull failure of '%s': %s", image, message)
+	if strings.Contains(message, "no matching manifest for") {
+		return d.platformError(image, d.getNodePlatform(ctx, pod), nil)
+	}
+	if d.registry == nil {
+		return d.clusterError(image, message)
+	}
+
+	if _, err := d.registry.GetImageTagWithDigest(image); err != nil {
+		if errors.Is(err, oktetoErrors.ErrNotFound) {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("the image '%s' doesn't exist", image),
+				Hint: "Check the tag of the image in your okteto manifest, or build and push the image before running 'okteto up'",
+			}
+		}
+		if isUnauthorizedError(err) {
+			return oktetoErrors.UserError{
+				E: fmt.Errorf("you don't have access to the image '%s'", image),
+				Hint: `Check that the image is not private or run 'docker login' with credentials that can pull it.
+    The cluster also needs credentials to pull private images: add them to the 'imagePullSecrets' of the service account of your namespace`,
+			}
+		}
+		oktetoLog.Infof("failed to check the image '%s': %s", image, err)
+		return d.clusterError(image, message)
+	}
+
+	nodePlatform := d.getNodePlatform(ctx, pod)
+	if nodePlatform != "" {
+		platforms, err := d.registry.GetImagePlatforms(image)
+		if err != nil {
+			oktetoLog.Infof("failed to get the platforms of the image '%s': %s", image, err)
+		} else if !supportsPlatform(platforms, nodePlatform) {
+			return d.platformError(image, nodePlatform, platforms)
+		}
+	}
+	return d.clusterError(image, message)
+}
+
+// getNodePlatform returns the platform of the node of the pod, or an empty string if it can't be retrieved
+func (d *imagePullDiagnoser) getNodePlatform(ctx context.Context, pod *apiv1.Pod) string {
+	if pod == nil || d.k8sClient == nil {
+		return ""
+	}
+	nodeName := pod.Spec.NodeName
+	if nodeName == "" {
+		current, err := d.k8sClient.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			oktetoLog.Infof("failed to get the pod '%s': %s", pod.Name, err)
+			return ""
+		}
+		nodeName = current.Spec.NodeName
+	}
+	if nodeName == "" {
+		return ""
+	}
+	node, err := d.k8sClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		// users of namespaces managed by okteto are not allowed to get nodes
+		oktetoLog.Infof("failed to get the node '%s': %s", nodeName, err)
+		return ""
+	}
+	return fmt.Sprintf("%s/%s", node.Status.NodeInfo.OperatingSystem, node.Status.NodeInfo.Architecture)
+}
+
+func (*imagePullDiagnoser) platformError(image, nodePlatform string, platforms []string) error {
+	e := fmt.Errorf("the image '%s' is not built for the platform of the cluster", image)
+	if nodePlatform != "" {
+		e = fmt.Errorf("the image '%s' is not built for the platform of the cluster '%s'", image, nodePlatform)
+	}
+	hint := "Build the image for the platform of the nodes of the cluster using 'okteto build --platform'"
+	if len(platforms) > 0 {
+		hint = fmt.Sprintf("The image is built for: %s.\n    %s", strings.Join(platforms, ", "), hint)
+	}
+	return oktetoErrors.UserError{E: e, Hint: hint}
+}
+
+func (*imagePullDiagnoser) clusterError(image, message string) error {
+	return oktetoErrors.UserError{
+		E: fmt.Errorf("the cluster failed to pull the image '%s'", image),
+		Hint: fmt.Sprintf(`%s
+    If the image is private, add the credentials of its registry to the 'imagePullSecrets' of the service account of your namespace`, message),
+	}
+}
+
+// supportsPlatform returns if any of the platforms of an image runs in a node platform. Variants are ignored
+func supportsPlatform(platforms []string, nodePlatform string) bool {
+	for _, p := range platforms {
+		if p == nodePlatform || strings.HasPrefix(p, nodePlatform+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func isUnauthorizedError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, s := range []string{"unauthorized", "denied", "forbidden", "401", "403"} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetImageFromPullEvent(t *testing.T) {
+	e := &apiv1.Event{Reason: "Failed", Message: `Failed to pull image "okteto/golang:2": rpc error: code = NotFound`}
+	assert.Equal(t, "okteto/golang:2", getImageFromPullEvent(e))
+
+	e = &apiv1.Event{Reason: "Failed", Message: "Error: ErrImagePull"}
+	assert.Empty(t, getImageFromPullEvent(e))
+}
+
+func TestGetImagePullFailure(t *testing.T) {
+	pod := &apiv1.Pod{
+		Status: apiv1.PodStatus{
+			ContainerStatuses: []apiv1.ContainerStatus{
+				{
+					Image: "okteto/golang:2",
+					State: apiv1.ContainerState{Waiting: &apiv1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "Back-off pulling image"}},
+				},
+			},
+		},
+	}
+	image, message, ok := getImagePullFailure(pod)
+	require.True(t, ok)
+	assert.Equal(t, "okteto/golang:2", image)
+	assert.Equal(t, "Back-off pulling image", message)
+
+	pod.Status.ContainerStatuses[0].State.Waiting.Reason = "ContainerCreating"
+	_, _, ok = getImagePullFailure(pod)
+	assert.False(t, ok)
+}
+
+func TestImagePullDiagnoserDiagnose(t *testing.T) {
+	pod := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "cindy"},
+		Spec:       apiv1.PodSpec{NodeName: "node"},
+	}
+	node := &apiv1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node"},
+		Status:     apiv1.NodeStatus{NodeInfo: apiv1.NodeSystemInfo{OperatingSystem: "linux", Architecture: "arm64"}},
+	}
+	var tests = []struct {
+		name     string
+		registry registryInterface
+		message  string
+		expected string
+	}{
+		{
+			name:     "image not found",
+			registry: fakePreflightRegistry{err: fmt.Errorf("error getting image descriptor: %w", oktetoErrors.ErrNotFound)},
+			expected: "the image 'okteto/golang:2' doesn't exist",
+		},
+		{
+			name:     "unauthorized",
+			registry: fakePreflightRegistry{err: fmt.Errorf("GET https://index.docker.io: UNAUTHORIZED: authentication required")},
+			expected: "you don't have access to the image 'okteto/golang:2'",
+		},
+		{
+			name:     "platform mismatch",
+			registry: fakePreflightRegistry{platforms: []string{"linux/amd64"}},
+			expected: "the image 'okteto/golang:2' is not built for the platform of the cluster 'linux/arm64'",
+		},
+		{
+			name:     "platform mismatch in the pull message",
+			registry: fakePreflightRegistry{},
+			message:  "no matching manifest for linux/arm64 in the manifest list entries",
+			expected: "the image 'okteto/golang:2' is not built for the platform of the cluster 'linux/arm64'",
+		},
+		{
+			name:     "image available",
+			registry: fakePreflightRegistry{platforms: []string{"linux/amd64", "linux/arm64/v8"}},
+			expected: "the cluster failed to pull the image 'okteto/golang:2'",
+		},
+		{
+			name:     "registry not available",
+			registry: fakePreflightRegistry{err: fmt.Errorf("connection refused")},
+			expected: "the cluster failed to pull the image 'okteto/golang:2'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &imagePullDiagnoser{registry: tt.registry, k8sClient: fake.NewSimpleClientset(pod, node)}
+			err := d.diagnose(context.Background(), pod, "okteto/golang:2", tt.message)
+			require.Error(t, err)
+			var uErr oktetoErrors.UserError
+			require.ErrorAs(t, err, &uErr)
+			assert.Equal(t, tt.expected, uErr.E.Error())
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/cmd/down"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/apps"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// repairToOriginal restores the original state of a half-translated app and exits
+	repairToOriginal = "original"
+	// repairToDev restores the original state of a half-translated app and activates the development container again
+	repairToDev = "dev"
+)
+
+func validateRepair(repair string) error {
+	switch repair {
+	case "", repairToOriginal, repairToDev:
+		return nil
+	}
+	return oktetoErrors.UserError{
+		E:    fmt.Errorf("'%s' is not a valid value for '--repair'", repair),
+		Hint: fmt.Sprintf("Use one of: %s", strings.Join([]string{repairToOriginal, repairToDev}, ", ")),
+	}
+}
+
+// repairDevMode reconciles an app left half-translated by an 'okteto up' command that crashed.
+// It returns if 'okteto up' has to continue activating the development container
+func repairDevMode(ctx context.Context, dev *model.Dev, target string, isRunning func() bool, c kubernetes.Interface) (bool, error) {
+	if isRunning() {
+		return false, oktetoErrors.UserError{
+			E:    fmt.Errorf("development container '%s' is being used by another 'okteto up' command", dev.Name),
+			Hint: "Stop the other 'okteto up' command or run 'okteto down' to deactivate it",
+		}
+	}
+
+	app, _, err := utils.GetApp(ctx, dev, c, false)
+	if err != nil {
+		return false, err
+	}
+
+	diagnosis, err := apps.Diagnose(ctx, dev, app, c)
+	if err != nil {
+		return false, fmt.Errorf("failed to check the dev mode of '%s': %w", app.ObjectMeta().Name, err)
+	}
+	if !diagnosis.IsHalfTranslated() {
+		oktetoLog.Success("%s '%s' doesn't need to be repaired", app.Kind(), app.ObjectMeta().Name)
+		return target == repairToDev, nil
+	}
+
+	for _, issue := range diagnosis.Issues {
+		oktetoLog.Warning(issue)
+	}
+	diagnosis.PrepareRestore()
+
+	trMap, err := apps.GetTranslations(ctx, dev, app, false, c)
+	if err != nil {
+		return false, err
+	}
+	if err := down.Run(dev, app, trMap, true, c); err != nil {
+		return false, fmt.Errorf("failed to restore '%s': %w", app.ObjectMeta().Name, err)
+	}
+	oktetoLog.Success("%s '%s' restored to its original state", app.Kind(), app.ObjectMeta().Name)
+	return target == repairToDev, nil
+}
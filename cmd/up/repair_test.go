@@ -0,0 +1,113 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
+)
+
+func TestValidateRepair(t *testing.T) {
+	assert.NoError(t, validateRepair(""))
+	assert.NoError(t, validateRepair(repairToOriginal))
+	assert.NoError(t, validateRepair(repairToDev))
+	assert.Error(t, validateRepair("unknown"))
+}
+
+func TestRepairDevMode(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(constants.OktetoFolderEnvVar, dir)
+	t.Setenv(constants.OktetoHomeEnvVar, dir)
+
+	newDeployment := func() *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "api",
+				Namespace:   "cindy",
+				Labels:      map[string]string{constants.DevLabel: "true"},
+				Annotations: map[string]string{model.AppReplicasAnnotation: "2"},
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: pointer.Int32(0),
+				Template: apiv1.PodTemplateSpec{
+					Spec: apiv1.PodSpec{Containers: []apiv1.Container{{Name: "api", Image: "api"}}},
+				},
+			},
+		}
+	}
+	dev := &model.Dev{Name: "api", Namespace: "cindy", Image: &model.BuildInfo{}}
+	require.NoError(t, dev.SetDefaults())
+	notRunning := func() bool { return false }
+
+	var tests = []struct {
+		name             string
+		target           string
+		expectedActivate bool
+	}{
+		{name: "restore the original workload", target: repairToOriginal},
+		{name: "activate the development container again", target: repairToDev, expectedActivate: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewSimpleClientset(newDeployment())
+			activate, err := repairDevMode(context.Background(), dev, tt.target, notRunning, c)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedActivate, activate)
+
+			d, err := c.AppsV1().Deployments("cindy").Get(context.Background(), "api", metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, int32(2), *d.Spec.Replicas)
+			assert.NotContains(t, d.Labels, constants.DevLabel)
+			assert.NotContains(t, d.Annotations, model.AppReplicasAnnotation)
+		})
+	}
+
+	t.Run("another okteto up is running", func(t *testing.T) {
+		c := fake.NewSimpleClientset(newDeployment())
+		_, err := repairDevMode(context.Background(), dev, repairToOriginal, func() bool { return true }, c)
+		assert.Error(t, err)
+
+		d, err := c.AppsV1().Deployments("cindy").Get(context.Background(), "api", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, int32(0), *d.Spec.Replicas)
+	})
+}
+
+func TestPIDControllerIsRunning(t *testing.T) {
+	pc := pidController{
+		pidFilePath: "/okteto.pid",
+		filesystem:  afero.NewMemMapFs(),
+		pidProvider: fakePIDProvider{pid: os.Getpid()},
+	}
+	assert.False(t, pc.isRunning())
+
+	require.NoError(t, afero.WriteFile(pc.filesystem, pc.pidFilePath, []byte(strconv.Itoa(os.Getpid())), 0600))
+	assert.False(t, pc.isRunning())
+
+	require.NoError(t, afero.WriteFile(pc.filesystem, pc.pidFilePath, []byte(strconv.Itoa(os.Getppid())), 0600))
+	assert.True(t, pc.isRunning())
+}
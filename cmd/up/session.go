@@ -0,0 +1,191 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+const (
+	sessionStateFilename = "up.json"
+
+	// sessionGitignore keeps the session state out of the git repository, as it might contain the values of the '--env' flag
+	sessionGitignore = "*\n"
+)
+
+// sessionFlags are the flags of 'okteto up' persisted in the session state. A session is only resumed if none of them is set
+var sessionFlags = []string{"file", "namespace", "context", "env", "remote", "command"}
+
+// sessionState is the state of the last 'okteto up' session of a folder, used to resume it without prompting
+type sessionState struct {
+	DevName      string           `json:"dev"`
+	ManifestPath string           `json:"manifestPath,omitempty"`
+	Namespace    string           `json:"namespace,omitempty"`
+	K8sContext   string           `json:"context,omitempty"`
+	Envs         []string         `json:"envs,omitempty"`
+	Remote       int              `json:"remote,omitempty"`
+	Command      []string         `json:"command,omitempty"`
+	Forwards     []sessionForward `json:"forwards,omitempty"`
+}
+
+// sessionForward is a port mapping of a session. forward.Forward can't be used because it doesn't serialize the 'Service' field
+type sessionForward struct {
+	Local       int               `json:"local"`
+	Remote      int               `json:"remote"`
+	Service     bool              `json:"service,omitempty"`
+	ServiceName string            `json:"serviceName,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	IsGlobal    bool              `json:"global,omitempty"`
+}
+
+// sessionController reads and writes the session state stored at '.okteto/state'
+type sessionController struct {
+	path       string
+	filesystem afero.Fs
+}
+
+func newSessionController(wd string) sessionController {
+	return sessionController{
+		path:       filepath.Join(wd, ".okteto", "state", sessionStateFilename),
+		filesystem: afero.NewOsFs(),
+	}
+}
+
+// load returns the session state, or nil if there is no previous session
+func (sc sessionController) load() (*sessionState, error) {
+	b, err := afero.ReadFile(sc.filesystem, sc.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("could not read session state: %w", err)
+	}
+	s := &sessionState{}
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("could not parse session state '%s': %w", sc.path, err)
+	}
+	if s.DevName == "" {
+		return nil, nil
+	}
+	return s, nil
+}
+
+// save stores the session state
+func (sc sessionController) save(s *sessionState) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := sc.filesystem.MkdirAll(filepath.Dir(sc.path), 0700); err != nil {
+		return fmt.Errorf("could not create session state folder: %w", err)
+	}
+	gitignorePath := filepath.Join(filepath.Dir(sc.path), ".gitignore")
+	if err := afero.WriteFile(sc.filesystem, gitignorePath, []byte(sessionGitignore), 0600); err != nil {
+		oktetoLog.Infof("could not write %s: %s", gitignorePath, err)
+	}
+	if err := afero.WriteFile(sc.filesystem, sc.path, b, 0600); err != nil {
+		return fmt.Errorf("could not write session state: %w", err)
+	}
+	return nil
+}
+
+// delete removes the session state
+func (sc sessionController) delete() {
+	if err := sc.filesystem.Remove(sc.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		oktetoLog.Infof("unable to delete session state at %s: %s", sc.path, err)
+	}
+}
+
+// canResumeSession returns true if 'okteto up' is executed without args nor session flags
+func canResumeSession(cmd *cobra.Command, args []string) bool {
+	if len(args) > 0 {
+		return false
+	}
+	for _, name := range sessionFlags {
+		if cmd.Flags().Changed(name) {
+			return false
+		}
+	}
+	return true
+}
+
+// newSessionState returns the state of the session of a dev container
+func newSessionState(manifestPath string, opts *UpOptions, dev *model.Dev) *sessionState {
+	s := &sessionState{
+		DevName:      dev.Name,
+		ManifestPath: manifestPath,
+		Namespace:    dev.Namespace,
+		K8sContext:   okteto.Context().Name,
+		Envs:         opts.Envs,
+		Remote:       opts.Remote,
+		Command:      opts.commandToExecute,
+	}
+	for _, f := range dev.Forward {
+		s.Forwards = append(s.Forwards, sessionForward{
+			Local:       f.Local,
+			Remote:      f.Remote,
+			Service:     f.Service,
+			ServiceName: f.ServiceName,
+			Labels:      f.Labels,
+			IsGlobal:    f.IsGlobal,
+		})
+	}
+	return s
+}
+
+// apply sets the options of the session state
+func (s *sessionState) apply(opts *UpOptions) {
+	opts.DevName = s.DevName
+	opts.ManifestPath = s.ManifestPath
+	opts.Namespace = s.Namespace
+	opts.K8sContext = s.K8sContext
+	opts.Envs = s.Envs
+	opts.Remote = s.Remote
+	opts.commandToExecute = s.Command
+}
+
+// applyForwards restores the port mappings of the session state
+func (s *sessionState) applyForwards(dev *model.Dev) {
+	forwards := []forward.Forward{}
+	for _, f := range s.Forwards {
+		forwards = append(forwards, forward.Forward{
+			Local:       f.Local,
+			Remote:      f.Remote,
+			Service:     f.Service,
+			ServiceName: f.ServiceName,
+			Labels:      f.Labels,
+			IsGlobal:    f.IsGlobal,
+		})
+	}
+	if len(forwards) == 0 && len(dev.Forward) == 0 {
+		return
+	}
+	if !reflect.DeepEqual(forwards, dev.Forward) {
+		oktetoLog.Warning("The port mappings of '%s' have changed since the last session, the previous ones will be used", dev.Name)
+		oktetoLog.Println("    Run 'okteto up --reset' to use the port mappings defined in your okteto manifest")
+	}
+	dev.Forward = forwards
+}
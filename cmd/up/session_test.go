@@ -0,0 +1,174 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFakeSessionController() sessionController {
+	return sessionController{
+		path:       "/app/.okteto/state/up.json",
+		filesystem: afero.NewMemMapFs(),
+	}
+}
+
+func TestSessionControllerSaveAndLoad(t *testing.T) {
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {Name: "test", Namespace: "cindy"},
+		},
+		CurrentContext: "test",
+	}
+	sc := newFakeSessionController()
+
+	s, err := sc.load()
+	require.NoError(t, err)
+	assert.Nil(t, s)
+
+	opts := &UpOptions{
+		Envs:             []string{"DEBUG=true"},
+		Remote:           2222,
+		commandToExecute: []string{"bash"},
+	}
+	dev := &model.Dev{
+		Name:      "api",
+		Namespace: "cindy",
+		Forward: []forward.Forward{
+			{Local: 8080, Remote: 8080},
+			{Local: 5432, Remote: 5432, Service: true, ServiceName: "db"},
+		},
+	}
+	require.NoError(t, sc.save(newSessionState("okteto.yml", opts, dev)))
+
+	s, err = sc.load()
+	require.NoError(t, err)
+	expected := &sessionState{
+		DevName:      "api",
+		ManifestPath: "okteto.yml",
+		Namespace:    "cindy",
+		K8sContext:   "test",
+		Envs:         []string{"DEBUG=true"},
+		Remote:       2222,
+		Command:      []string{"bash"},
+		Forwards: []sessionForward{
+			{Local: 8080, Remote: 8080},
+			{Local: 5432, Remote: 5432, Service: true, ServiceName: "db"},
+		},
+	}
+	assert.Equal(t, expected, s)
+
+	gitignore, err := afero.ReadFile(sc.filesystem, "/app/.okteto/state/.gitignore")
+	require.NoError(t, err)
+	assert.Equal(t, sessionGitignore, string(gitignore))
+
+	sc.delete()
+	s, err = sc.load()
+	require.NoError(t, err)
+	assert.Nil(t, s)
+}
+
+func TestSessionControllerLoadInvalid(t *testing.T) {
+	sc := newFakeSessionController()
+	require.NoError(t, afero.WriteFile(sc.filesystem, sc.path, []byte("{"), 0600))
+	_, err := sc.load()
+	assert.Error(t, err)
+}
+
+func TestCanResumeSession(t *testing.T) {
+	var tests = []struct {
+		name     string
+		args     []string
+		flags    map[string]string
+		expected bool
+	}{
+		{name: "bare up", expected: true},
+		{name: "dev name", args: []string{"api"}, expected: false},
+		{name: "namespace flag", flags: map[string]string{"namespace": "cindy"}, expected: false},
+		{name: "env flag", flags: map[string]string{"env": "A=B"}, expected: false},
+		{name: "other flags", flags: map[string]string{"deploy": "true"}, expected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := Up(nil)
+			for k, v := range tt.flags {
+				require.NoError(t, cmd.Flags().Set(k, v))
+			}
+			assert.Equal(t, tt.expected, canResumeSession(cmd, tt.args))
+		})
+	}
+}
+
+func TestSessionStateApply(t *testing.T) {
+	s := &sessionState{
+		DevName:      "api",
+		ManifestPath: "okteto.yml",
+		Namespace:    "cindy",
+		K8sContext:   "test",
+		Envs:         []string{"DEBUG=true"},
+		Remote:       2222,
+		Command:      []string{"bash"},
+	}
+	opts := &UpOptions{}
+	s.apply(opts)
+	assert.Equal(t, &UpOptions{
+		DevName:          "api",
+		ManifestPath:     "okteto.yml",
+		Namespace:        "cindy",
+		K8sContext:       "test",
+		Envs:             []string{"DEBUG=true"},
+		Remote:           2222,
+		commandToExecute: []string{"bash"},
+	}, opts)
+}
+
+func TestSessionStateApplyForwards(t *testing.T) {
+	var tests = []struct {
+		name     string
+		session  []sessionForward
+		dev      []forward.Forward
+		expected []forward.Forward
+	}{
+		{
+			name: "no forwards",
+		},
+		{
+			name:     "same forwards",
+			session:  []sessionForward{{Local: 8080, Remote: 8080}},
+			dev:      []forward.Forward{{Local: 8080, Remote: 8080}},
+			expected: []forward.Forward{{Local: 8080, Remote: 8080}},
+		},
+		{
+			name:     "changed forwards",
+			session:  []sessionForward{{Local: 8081, Remote: 8080}},
+			dev:      []forward.Forward{{Local: 8080, Remote: 8080}},
+			expected: []forward.Forward{{Local: 8081, Remote: 8080}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &sessionState{DevName: "api", Forwards: tt.session}
+			dev := &model.Dev{Name: "api", Forward: tt.dev}
+			s.applyForwards(dev)
+			assert.Equal(t, tt.expected, dev.Forward)
+		})
+	}
+}
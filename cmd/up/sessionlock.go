@@ -0,0 +1,249 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/alessio/shellescape"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/format"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// sessionLeaseDuration is how long a session holds the lease without renewing it
+	sessionLeaseDuration = 30 * time.Second
+
+	// sessionLeaseRenewInterval is how often an active session renews its lease
+	sessionLeaseRenewInterval = 10 * time.Second
+
+	// localSessionHolder describes a session held by another 'okteto up' command of this computer
+	localSessionHolder = "another 'okteto up' command on this computer"
+)
+
+// sessionAction is what 'okteto up' does when the development container has an active session
+type sessionAction int
+
+const (
+	// sessionStart starts a new session, taking over the active one if any
+	sessionStart sessionAction = iota
+	// sessionJoin opens a terminal in the development container of the active session
+	sessionJoin
+)
+
+// sessionLock is a lease in the namespace held by the 'okteto up' session of a development container,
+// so concurrent sessions are detected even if they run on other computers
+type sessionLock struct {
+	name      string
+	namespace string
+	identity  string
+	client    kubernetes.Interface
+	now       func() time.Time
+}
+
+func newSessionLock(dev *model.Dev, c kubernetes.Interface) *sessionLock {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return &sessionLock{
+		name:      format.ResourceK8sMetaString(fmt.Sprintf("okteto-up-%s", dev.Name)),
+		namespace: dev.Namespace,
+		identity:  fmt.Sprintf("%s (pid %d)", hostname, os.Getpid()),
+		client:    c,
+		now:       time.Now,
+	}
+}
+
+// getActiveHolder returns the identity of another session holding the lease, or an empty string if it's free
+func (l *sessionLock) getActiveHolder(ctx context.Context) (string, error) {
+	lease, err := l.client.CoordinationV1().Leases(l.namespace).Get(ctx, l.name, metav1.GetOptions{})
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity == l.identity || l.isExpired(lease) {
+		return "", nil
+	}
+	return fmt.Sprintf("'okteto up' on %s", *lease.Spec.HolderIdentity), nil
+}
+
+func (l *sessionLock) isExpired(lease *coordinationv1.Lease) bool {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return true
+	}
+	expiresAt := lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second)
+	return l.now().After(expiresAt)
+}
+
+// acquire creates the lease, taking it over if another session holds it
+func (l *sessionLock) acquire(ctx context.Context) error {
+	lease, err := l.client.CoordinationV1().Leases(l.namespace).Get(ctx, l.name, metav1.GetOptions{})
+	if err != nil {
+		if !k8sErrors.IsNotFound(err) {
+			return err
+		}
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      l.name,
+				Namespace: l.namespace,
+			},
+		}
+		l.setHolder(lease)
+		_, err = l.client.CoordinationV1().Leases(l.namespace).Create(ctx, lease, metav1.CreateOptions{})
+		return err
+	}
+	l.setHolder(lease)
+	_, err = l.client.CoordinationV1().Leases(l.namespace).Update(ctx, lease, metav1.UpdateOptions{})
+	return err
+}
+
+func (l *sessionLock) setHolder(lease *coordinationv1.Lease) {
+	now := metav1.NewMicroTime(l.now())
+	identity := l.identity
+	duration := int32(sessionLeaseDuration.Seconds())
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != identity {
+		lease.Spec.AcquireTime = &now
+		delete(lease.Annotations, sharedSessionAnnotation)
+	}
+	lease.Spec.HolderIdentity = &identity
+	lease.Spec.LeaseDurationSeconds = &duration
+	lease.Spec.RenewTime = &now
+}
+
+// renew renews the lease. It returns an error if another session took it over
+func (l *sessionLock) renew(ctx context.Context) error {
+	lease, err := l.client.CoordinationV1().Leases(l.namespace).Get(ctx, l.name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity != l.identity {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("development container has been taken over by 'okteto up' on %s", *lease.Spec.HolderIdentity),
+			Hint: "Run 'okteto up --join' to open a terminal in the development container of the active session",
+		}
+	}
+	l.setHolder(lease)
+	_, err = l.client.CoordinationV1().Leases(l.namespace).Update(ctx, lease, metav1.UpdateOptions{})
+	return err
+}
+
+// keepAlive renews the lease until ctx is done. It sends an error to ch if another session takes it over
+func (l *sessionLock) keepAlive(ctx context.Context, ch chan error) {
+	ticker := time.NewTicker(sessionLeaseRenewInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := l.renew(ctx)
+			if err == nil {
+				continue
+			}
+			if _, ok := err.(oktetoErrors.UserError); ok {
+				ch <- err
+				return
+			}
+			oktetoLog.Infof("failed to renew the session lease: %s", err)
+		}
+	}
+}
+
+// release deletes the lease if this session still holds it
+func (l *sessionLock) release(ctx context.Context) {
+	lease, err := l.client.CoordinationV1().Leases(l.namespace).Get(ctx, l.name, metav1.GetOptions{})
+	if err != nil {
+		oktetoLog.Infof("failed to get the session lease: %s", err)
+		return
+	}
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != l.identity {
+		return
+	}
+	if err := l.client.CoordinationV1().Leases(l.namespace).Delete(ctx, l.name, metav1.DeleteOptions{}); err != nil && !k8sErrors.IsNotFound(err) {
+		oktetoLog.Infof("failed to delete the session lease: %s", err)
+	}
+}
+
+// getSessionAction returns what to do if the development container has an active session,
+// held by another local 'okteto up' command or by the lease in the namespace
+func getSessionAction(ctx context.Context, opts *UpOptions, isLocalRunning bool, lock *sessionLock) (sessionAction, error) {
+	holder := ""
+	if isLocalRunning {
+		holder = localSessionHolder
+	} else if lock != nil {
+		var err error
+		holder, err = lock.getActiveHolder(ctx)
+		if err != nil {
+			oktetoLog.Infof("failed to check the session lease: %s", err)
+		}
+	}
+
+	switch {
+	case holder == "":
+		return sessionStart, nil
+	case opts.StealSession:
+		oktetoLog.Warning("Taking over the development container from %s", holder)
+		return sessionStart, nil
+	case opts.Join != "":
+		oktetoLog.Information("Joining the development container of %s", holder)
+		return sessionJoin, nil
+	}
+	return sessionStart, oktetoErrors.UserError{
+		E: fmt.Errorf("development container is being used by %s", holder),
+		Hint: `Run 'okteto up --join' to open a terminal in the development container of the active session
+    or 'okteto up --steal' to stop the active session and start a new one`,
+	}
+}
+
+// joinSession runs the command of the development container with 'okteto exec', without synchronizing files
+func joinSession(ctx context.Context, dev *model.Dev, opts *UpOptions) error {
+	binary, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	args := []string{"exec", dev.Name, "--namespace", dev.Namespace}
+	if opts.ManifestPath != "" {
+		args = append(args, "--file", opts.ManifestPath)
+	}
+	args = append(args, "--", shellescape.QuoteCommand(dev.Command.Values))
+
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+func validateSessionFlags(opts *UpOptions) error {
+	if opts.StealSession && opts.Join != "" {
+		return fmt.Errorf("the flags '--steal' and '--join' can't be used together")
+	}
+	if opts.Share && opts.Join != "" {
+		return fmt.Errorf("the flags '--share' and '--join' can't be used together")
+	}
+	return nil
+}
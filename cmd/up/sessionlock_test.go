@@ -0,0 +1,161 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestSessionLock(c *fake.Clientset, identity string, now time.Time) *sessionLock {
+	return &sessionLock{
+		name:      "okteto-up-api",
+		namespace: "test",
+		identity:  identity,
+		client:    c,
+		now:       func() time.Time { return now },
+	}
+}
+
+func TestSessionLock(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	c := fake.NewSimpleClientset()
+	first := newTestSessionLock(c, "laptop (pid 1)", now)
+	second := newTestSessionLock(c, "desktop (pid 2)", now)
+
+	holder, err := second.getActiveHolder(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, holder)
+
+	require.NoError(t, first.acquire(ctx))
+	holder, err = second.getActiveHolder(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "'okteto up' on laptop (pid 1)", holder)
+	holder, err = first.getActiveHolder(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, holder)
+	require.NoError(t, first.renew(ctx))
+
+	// the second session steals the lease
+	require.NoError(t, second.acquire(ctx))
+	err = first.renew(ctx)
+	var uErr oktetoErrors.UserError
+	assert.ErrorAs(t, err, &uErr)
+
+	// only the holder deletes the lease
+	first.release(ctx)
+	_, err = c.CoordinationV1().Leases("test").Get(ctx, "okteto-up-api", metav1.GetOptions{})
+	require.NoError(t, err)
+	second.release(ctx)
+	_, err = c.CoordinationV1().Leases("test").Get(ctx, "okteto-up-api", metav1.GetOptions{})
+	assert.Error(t, err)
+}
+
+func TestSessionLockExpired(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	c := fake.NewSimpleClientset()
+	require.NoError(t, newTestSessionLock(c, "laptop (pid 1)", now).acquire(ctx))
+
+	later := newTestSessionLock(c, "desktop (pid 2)", now.Add(sessionLeaseDuration+time.Second))
+	holder, err := later.getActiveHolder(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, holder)
+}
+
+func TestGetSessionAction(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	c := fake.NewSimpleClientset()
+	require.NoError(t, newTestSessionLock(c, "laptop (pid 1)", now).acquire(ctx))
+	remoteLock := newTestSessionLock(c, "desktop (pid 2)", now)
+
+	tests := []struct {
+		name           string
+		opts           *UpOptions
+		isLocalRunning bool
+		lock           *sessionLock
+		expected       sessionAction
+		expectedErr    bool
+	}{
+		{
+			name:     "no active session",
+			opts:     &UpOptions{},
+			lock:     newTestSessionLock(fake.NewSimpleClientset(), "desktop (pid 2)", now),
+			expected: sessionStart,
+		},
+		{
+			name:     "no cluster lease",
+			opts:     &UpOptions{},
+			expected: sessionStart,
+		},
+		{
+			name:           "local session",
+			opts:           &UpOptions{},
+			isLocalRunning: true,
+			expectedErr:    true,
+		},
+		{
+			name:        "remote session",
+			opts:        &UpOptions{},
+			lock:        remoteLock,
+			expectedErr: true,
+		},
+		{
+			name:     "steal remote session",
+			opts:     &UpOptions{StealSession: true},
+			lock:     remoteLock,
+			expected: sessionStart,
+		},
+		{
+			name:           "join local session",
+			opts:           &UpOptions{Join: joinActiveSession},
+			isLocalRunning: true,
+			expected:       sessionJoin,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action, err := getSessionAction(ctx, tt.opts, tt.isLocalRunning, tt.lock)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, action)
+		})
+	}
+}
+
+func TestNewSessionLock(t *testing.T) {
+	lock := newSessionLock(&model.Dev{Name: "My_API", Namespace: "test"}, fake.NewSimpleClientset())
+	assert.Equal(t, "okteto-up-my-api", lock.name)
+	assert.Equal(t, "test", lock.namespace)
+	assert.Contains(t, lock.identity, "(pid ")
+}
+
+func TestValidateSessionFlags(t *testing.T) {
+	assert.NoError(t, validateSessionFlags(&UpOptions{StealSession: true}))
+	assert.NoError(t, validateSessionFlags(&UpOptions{Join: joinActiveSession}))
+	assert.Error(t, validateSessionFlags(&UpOptions{StealSession: true, Join: joinActiveSession}))
+}
@@ -0,0 +1,263 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	k8sExec "github.com/okteto/okteto/pkg/k8s/exec"
+	forwardk8s "github.com/okteto/okteto/pkg/k8s/forward"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/okteto/okteto/pkg/okteto"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// joinActiveSession is the value of '--join' when no token is given
+	joinActiveSession = "active"
+
+	// shareTokenPrefix identifies the tokens printed by 'okteto up --share'
+	shareTokenPrefix = "okteto-share-"
+
+	// sharedSessionAnnotation is the annotation of the session lease with the shared session
+	sharedSessionAnnotation = "dev.okteto.com/shared-session"
+
+	// sharedSessionShell opens bash in the development container, or sh if bash isn't available
+	sharedSessionShell = "if command -v bash >/dev/null 2>&1; then exec bash; else exec sh; fi"
+)
+
+// shareToken is what a teammate needs to join a shared session
+type shareToken struct {
+	Context   string `json:"context"`
+	Namespace string `json:"namespace"`
+	Dev       string `json:"dev"`
+	Secret    string `json:"secret"`
+}
+
+// sharedSession is the information of a shared session stored in the session lease
+type sharedSession struct {
+	SecretHash string          `json:"secretHash"`
+	Pod        string          `json:"pod"`
+	Container  string          `json:"container"`
+	Forwards   []sharedForward `json:"forwards,omitempty"`
+}
+
+// sharedForward is a forward of the development container to be started by the teammates
+type sharedForward struct {
+	Local   int    `json:"local"`
+	Remote  int    `json:"remote"`
+	Service string `json:"service,omitempty"`
+}
+
+func (t shareToken) encode() (string, error) {
+	bytes, err := json.Marshal(t)
+	if err != nil {
+		return "", err
+	}
+	return shareTokenPrefix + base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+func isShareToken(value string) bool {
+	return strings.HasPrefix(value, shareTokenPrefix)
+}
+
+func decodeShareToken(value string) (*shareToken, error) {
+	errInvalid := oktetoErrors.UserError{
+		E:    fmt.Errorf("invalid share token"),
+		Hint: "Ask your teammate to run 'okteto up --share' and copy the token it prints",
+	}
+	bytes, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(value, shareTokenPrefix))
+	if err != nil {
+		return nil, errInvalid
+	}
+	t := &shareToken{}
+	if err := json.Unmarshal(bytes, t); err != nil || t.Namespace == "" || t.Dev == "" || t.Secret == "" {
+		return nil, errInvalid
+	}
+	return t, nil
+}
+
+func hashShareSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// setJoinTokenFromArgs supports 'okteto up --join <token>', where the token is parsed as the argument of 'okteto up'
+func (o *UpOptions) setJoinTokenFromArgs(args []string) []string {
+	if o.Join == joinActiveSession && len(args) == 1 && isShareToken(args[0]) {
+		o.Join = args[0]
+		return nil
+	}
+	return args
+}
+
+// share stores the shared session in the lease
+func (l *sessionLock) share(ctx context.Context, session sharedSession) error {
+	lease, err := l.client.CoordinationV1().Leases(l.namespace).Get(ctx, l.name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	bytes, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	if lease.Annotations == nil {
+		lease.Annotations = map[string]string{}
+	}
+	lease.Annotations[sharedSessionAnnotation] = string(bytes)
+	_, err = l.client.CoordinationV1().Leases(l.namespace).Update(ctx, lease, metav1.UpdateOptions{})
+	return err
+}
+
+// getSharedSession returns the shared session of the lease if the secret is valid and the session is active
+func (l *sessionLock) getSharedSession(ctx context.Context, secret string) (*sharedSession, error) {
+	errFinished := oktetoErrors.UserError{
+		E:    fmt.Errorf("the shared session of '%s' has finished", l.name),
+		Hint: "Ask your teammate to run 'okteto up --share' again and use the new token",
+	}
+	lease, err := l.client.CoordinationV1().Leases(l.namespace).Get(ctx, l.name, metav1.GetOptions{})
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return nil, errFinished
+		}
+		return nil, err
+	}
+	value, ok := lease.Annotations[sharedSessionAnnotation]
+	if !ok || l.isExpired(lease) {
+		return nil, errFinished
+	}
+	session := &sharedSession{}
+	if err := json.Unmarshal([]byte(value), session); err != nil {
+		return nil, fmt.Errorf("failed to read the shared session: %w", err)
+	}
+	if session.SecretHash != hashShareSecret(secret) {
+		return nil, errFinished
+	}
+	return session, nil
+}
+
+// shareSession shares the session with the teammates and prints the token to join it the first time
+func (up *upContext) shareSession(ctx context.Context) {
+	if up.sessionLock == nil {
+		oktetoLog.Warning("The session can't be shared because its lease couldn't be created in the namespace")
+		return
+	}
+
+	isFirstShare := up.shareSecret == ""
+	if isFirstShare {
+		up.shareSecret = uuid.NewString()
+	}
+	session := sharedSession{
+		SecretHash: hashShareSecret(up.shareSecret),
+		Pod:        up.Pod.Name,
+		Container:  up.Dev.Container,
+	}
+	for _, f := range up.Dev.Forward {
+		session.Forwards = append(session.Forwards, sharedForward{Local: f.Local, Remote: f.Remote, Service: f.ServiceName})
+	}
+	if err := up.sessionLock.share(ctx, session); err != nil {
+		oktetoLog.Infof("failed to share the session: %s", err)
+		oktetoLog.Warning("The session couldn't be shared")
+		return
+	}
+	if !isFirstShare {
+		return
+	}
+
+	token, err := shareToken{
+		Context:   okteto.Context().Name,
+		Namespace: up.Dev.Namespace,
+		Dev:       up.Dev.Name,
+		Secret:    up.shareSecret,
+	}.encode()
+	if err != nil {
+		oktetoLog.Infof("failed to encode the share token: %s", err)
+		return
+	}
+	oktetoLog.Information("Your teammates can join this session running:")
+	oktetoLog.Println(fmt.Sprintf("    okteto up --join %s", token))
+}
+
+// validateContext checks that the session was shared from the current context.
+// Switching to the context of the token would send the credentials to a server chosen by whoever created it
+func (t shareToken) validateContext(current string) error {
+	if okteto.AddSchema(t.Context) == okteto.AddSchema(current) {
+		return nil
+	}
+	return oktetoErrors.UserError{
+		E:    fmt.Errorf("the session was shared from the context '%s' but the current context is '%s'", okteto.RemoveSchema(t.Context), okteto.RemoveSchema(current)),
+		Hint: fmt.Sprintf("Run 'okteto context use %s' if you trust it and try again", t.Context),
+	}
+}
+
+// joinSharedSession opens a terminal in the development container of a shared session and starts its forwards.
+// The local files of the teammate are not synchronized
+func joinSharedSession(ctx context.Context, value string) error {
+	t, err := decodeShareToken(value)
+	if err != nil {
+		return err
+	}
+
+	// the current context is loaded as is: the context of the token is never used to authenticate
+	if err := contextCMD.NewContextCommand().Run(ctx, &contextCMD.ContextOptions{}); err != nil {
+		return err
+	}
+	if err := t.validateContext(okteto.Context().Name); err != nil {
+		return err
+	}
+
+	c, restConfig, err := okteto.GetK8sClient()
+	if err != nil {
+		return err
+	}
+	lock := newSessionLock(&model.Dev{Name: t.Dev, Namespace: t.Namespace}, c)
+	session, err := lock.getSharedSession(ctx, t.Secret)
+	if err != nil {
+		return err
+	}
+
+	if len(session.Forwards) > 0 {
+		forwarder := forwardk8s.NewPortForwardManager(ctx, model.Localhost, restConfig, c, t.Namespace)
+		for _, f := range session.Forwards {
+			if err := forwarder.Add(forward.Forward{Local: f.Local, Remote: f.Remote, Service: f.Service != "", ServiceName: f.Service}); err != nil {
+				return err
+			}
+		}
+		if err := forwarder.Start(session.Pod, t.Namespace); err != nil {
+			return fmt.Errorf("failed to start the forwards of the shared session: %w", err)
+		}
+		defer forwarder.Stop()
+	}
+
+	oktetoLog.Success("Joined the shared session of '%s'", t.Dev)
+	for _, f := range session.Forwards {
+		oktetoLog.Println(fmt.Sprintf("    %s   %d -> %d", oktetoLog.BlueString("Forward:"), f.Local, f.Remote))
+	}
+	oktetoLog.Information("Files are synchronized from the computer of the session owner")
+
+	return k8sExec.Exec(ctx, c, restConfig, t.Namespace, session.Pod, session.Container, true, os.Stdin, os.Stdout, os.Stderr, []string{"sh", "-c", sharedSessionShell})
+}
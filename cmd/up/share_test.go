@@ -0,0 +1,119 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestShareToken(t *testing.T) {
+	token := shareToken{Context: "https://okteto.example.com", Namespace: "test", Dev: "api", Secret: "secret"}
+	encoded, err := token.encode()
+	require.NoError(t, err)
+	assert.True(t, isShareToken(encoded))
+
+	decoded, err := decodeShareToken(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, token, *decoded)
+
+	_, err = decodeShareToken(shareTokenPrefix + "invalid")
+	assert.Error(t, err)
+
+	empty, err := shareToken{Context: "https://okteto.example.com"}.encode()
+	require.NoError(t, err)
+	_, err = decodeShareToken(empty)
+	assert.Error(t, err)
+}
+
+func TestShareTokenValidateContext(t *testing.T) {
+	token := shareToken{Context: "https://okteto.example.com", Namespace: "test", Dev: "api", Secret: "secret"}
+	assert.NoError(t, token.validateContext("https://okteto.example.com"))
+	assert.NoError(t, token.validateContext("okteto.example.com"))
+
+	err := token.validateContext("https://okteto.attacker.com")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "okteto.example.com")
+
+	k8sToken := shareToken{Context: "minikube", Namespace: "test", Dev: "api", Secret: "secret"}
+	assert.NoError(t, k8sToken.validateContext("minikube"))
+	assert.Error(t, k8sToken.validateContext("https://okteto.example.com"))
+}
+
+func TestSetJoinTokenFromArgs(t *testing.T) {
+	opts := &UpOptions{Join: joinActiveSession}
+	args := opts.setJoinTokenFromArgs([]string{"okteto-share-token"})
+	assert.Empty(t, args)
+	assert.Equal(t, "okteto-share-token", opts.Join)
+
+	opts = &UpOptions{Join: joinActiveSession}
+	args = opts.setJoinTokenFromArgs([]string{"api"})
+	assert.Equal(t, []string{"api"}, args)
+	assert.Equal(t, joinActiveSession, opts.Join)
+
+	opts = &UpOptions{}
+	args = opts.setJoinTokenFromArgs([]string{"okteto-share-token"})
+	assert.Equal(t, []string{"okteto-share-token"}, args)
+	assert.Empty(t, opts.Join)
+}
+
+func TestSharedSession(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	c := fake.NewSimpleClientset()
+	owner := newTestSessionLock(c, "laptop (pid 1)", now)
+	teammate := newTestSessionLock(c, "desktop (pid 2)", now)
+
+	_, err := teammate.getSharedSession(ctx, "secret")
+	assert.Error(t, err)
+
+	require.NoError(t, owner.acquire(ctx))
+	_, err = teammate.getSharedSession(ctx, "secret")
+	assert.Error(t, err)
+
+	session := sharedSession{
+		SecretHash: hashShareSecret("secret"),
+		Pod:        "api-123",
+		Container:  "api",
+		Forwards:   []sharedForward{{Local: 8080, Remote: 8080}, {Local: 5432, Remote: 5432, Service: "db"}},
+	}
+	require.NoError(t, owner.share(ctx, session))
+	require.NoError(t, owner.renew(ctx))
+
+	result, err := teammate.getSharedSession(ctx, "secret")
+	require.NoError(t, err)
+	assert.Equal(t, session, *result)
+
+	_, err = teammate.getSharedSession(ctx, "wrong-secret")
+	assert.Error(t, err)
+
+	// taking over the session stops sharing it
+	require.NoError(t, teammate.acquire(ctx))
+	lease, err := c.CoordinationV1().Leases("test").Get(ctx, "okteto-up-api", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotContains(t, lease.Annotations, sharedSessionAnnotation)
+	_, err = teammate.getSharedSession(ctx, "secret")
+	assert.Error(t, err)
+}
+
+func TestValidateShareFlags(t *testing.T) {
+	assert.NoError(t, validateSessionFlags(&UpOptions{Share: true}))
+	assert.Error(t, validateSessionFlags(&UpOptions{Share: true, Join: joinActiveSession}))
+}
@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	iofs "io/fs"
 	"os"
 	"path"
 	"path/filepath"
@@ -32,16 +33,17 @@ import (
 	"github.com/okteto/okteto/pkg/linguist"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
 )
 
-func addStignoreSecrets(dev *model.Dev) error {
+func addStignoreSecrets(dev *model.Dev, fs afero.Fs) error {
 	output := ""
 	for i, folder := range dev.Sync.Folders {
 		stignorePath := filepath.Join(folder.LocalPath, ".stignore")
-		if !filesystem.FileExists(stignorePath) {
+		if !filesystem.FileExistsWithFilesystem(stignorePath, fs) {
 			continue
 		}
-		infile, err := os.Open(stignorePath)
+		infile, err := fs.Open(stignorePath)
 		if err != nil {
 			return oktetoErrors.UserError{
 				E:    err,
@@ -57,7 +59,10 @@ func addStignoreSecrets(dev *model.Dev) error {
 
 		stignoreName := fmt.Sprintf(".stignore-%d", i+1)
 		transformedStignorePath := filepath.Join(config.GetAppHome(dev.Namespace, dev.Name), stignoreName)
-		outfile, err := os.OpenFile(transformedStignorePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+		if err := fs.MkdirAll(filepath.Dir(transformedStignorePath), 0700); err != nil {
+			return err
+		}
+		outfile, err := fs.OpenFile(transformedStignorePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
 		if err != nil {
 			return err
 		}
@@ -121,7 +126,54 @@ func addSyncFieldHash(dev *model.Dev) error {
 	return nil
 }
 
-func checkStignoreConfiguration(dev *model.Dev) error {
+// maxLargeFilesReported is the maximum number of files bigger than 'sync.options.maxFileSize' listed by checkLargeFiles
+const maxLargeFilesReported = 10
+
+// checkLargeFiles warns about the files of the sync folders bigger than 'sync.options.maxFileSize'.
+// Syncthing doesn't support a maximum file size, they have to be added to the '.stignore' file
+func checkLargeFiles(dev *model.Dev) error {
+	maxFileSize, err := dev.Sync.Options.GetMaxFileSize()
+	if err != nil || maxFileSize == 0 {
+		return err
+	}
+
+	largeFiles, err := getLargeFiles(dev.Sync.Folders, maxFileSize)
+	if err != nil {
+		return err
+	}
+	if len(largeFiles) == 0 {
+		return nil
+	}
+	oktetoLog.Warning("The following files are bigger than 'sync.options.maxFileSize' (%s):", dev.Sync.Options.MaxFileSize)
+	for _, f := range largeFiles {
+		oktetoLog.Println(fmt.Sprintf("    - %s", f))
+	}
+	oktetoLog.Println("    Add them to your '.stignore' file if they don't need to be synchronized")
+	return nil
+}
+
+// getLargeFiles returns up to maxLargeFilesReported files of the sync folders bigger than maxFileSize.
+// The files already ignored by the '.stignore' file are not returned
+func getLargeFiles(folders []model.SyncFolder, maxFileSize int64) ([]string, error) {
+	largeFiles := []string{}
+	for _, folder := range folders {
+		err := walkSyncFolder(folder.LocalPath, func(path string, info iofs.FileInfo) bool {
+			if info.Size() > maxFileSize {
+				largeFiles = append(largeFiles, path)
+			}
+			return len(largeFiles) < maxLargeFilesReported
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(largeFiles) >= maxLargeFilesReported {
+			break
+		}
+	}
+	return largeFiles, nil
+}
+
+func checkStignoreConfiguration(dev *model.Dev, fs afero.Fs) error {
 	if dev.IsHybridModeEnabled() {
 		return nil
 	}
@@ -129,26 +181,26 @@ func checkStignoreConfiguration(dev *model.Dev) error {
 	for _, folder := range dev.Sync.Folders {
 		stignorePath := filepath.Join(folder.LocalPath, ".stignore")
 		gitPath := filepath.Join(folder.LocalPath, ".git")
-		if !filesystem.FileExists(stignorePath) {
-			if err := askIfCreateStignoreDefaults(folder.LocalPath, stignorePath); err != nil {
+		if !filesystem.FileExistsWithFilesystem(stignorePath, fs) {
+			if err := askIfCreateStignoreDefaults(folder.LocalPath, stignorePath, fs); err != nil {
 				return err
 			}
 			continue
 		}
 
 		oktetoLog.Infof("'.stignore' exists in folder '%s'", folder.LocalPath)
-		if !filesystem.FileExists(gitPath) {
+		if !filesystem.FileExistsWithFilesystem(gitPath, fs) {
 			continue
 		}
 
-		if err := checkIfStignoreHasGitFolder(stignorePath); err != nil {
+		if err := checkIfStignoreHasGitFolder(stignorePath, fs); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func askIfCreateStignoreDefaults(folder, stignorePath string) error {
+func askIfCreateStignoreDefaults(folder, stignorePath string, fs afero.Fs) error {
 	autogenerateStignore := utils.LoadBoolean(model.OktetoAutogenerateStignoreEnvVar)
 
 	oktetoLog.Information("'.stignore' doesn't exist in folder '%s'.", folder)
@@ -160,7 +212,7 @@ func askIfCreateStignoreDefaults(folder, stignorePath string) error {
 			l = linguist.Unrecognized
 		}
 		c := linguist.GetSTIgnore(l)
-		if err := os.WriteFile(stignorePath, c, 0600); err != nil {
+		if err := afero.WriteFile(fs, stignorePath, c, 0600); err != nil {
 			return fmt.Errorf("failed to write stignore file for '%s': %s", folder, err.Error())
 		}
 		return nil
@@ -174,7 +226,7 @@ func askIfCreateStignoreDefaults(folder, stignorePath string) error {
 
 	if !stignoreDefaults {
 		stignoreContent := ""
-		if err := os.WriteFile(stignorePath, []byte(stignoreContent), 0600); err != nil {
+		if err := afero.WriteFile(fs, stignorePath, []byte(stignoreContent), 0600); err != nil {
 			return fmt.Errorf("failed to create empty '%s': %s", stignorePath, err.Error())
 		}
 		return nil
@@ -185,14 +237,14 @@ func askIfCreateStignoreDefaults(folder, stignorePath string) error {
 		return fmt.Errorf("failed to get language for '%s': %s", folder, err.Error())
 	}
 	c := linguist.GetSTIgnore(language)
-	if err := os.WriteFile(stignorePath, c, 0600); err != nil {
+	if err := afero.WriteFile(fs, stignorePath, c, 0600); err != nil {
 		return fmt.Errorf("failed to write stignore file for '%s': %s", folder, err.Error())
 	}
 	return nil
 }
 
-func checkIfStignoreHasGitFolder(stignorePath string) error {
-	stignoreBytes, err := os.ReadFile(stignorePath)
+func checkIfStignoreHasGitFolder(stignorePath string, fs afero.Fs) error {
+	stignoreBytes, err := afero.ReadFile(fs, stignorePath)
 	if err != nil {
 		return fmt.Errorf("failed to read '%s': %s", stignorePath, err.Error())
 	}
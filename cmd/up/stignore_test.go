@@ -9,6 +9,7 @@ import (
 
 	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -68,12 +69,13 @@ func Test_addStignoreSecrets(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 
+			fs := afero.NewMemMapFs()
 			stignorePath := filepath.Join(localPath, ".stignore")
-			if err := os.WriteFile(stignorePath, []byte(tt.stignoreContent), 0600); err != nil {
+			if err := afero.WriteFile(fs, stignorePath, []byte(tt.stignoreContent), 0600); err != nil {
 				t.Fatal(err)
 			}
 
-			err := addStignoreSecrets(tt.dev)
+			err := addStignoreSecrets(tt.dev, fs)
 			if err == nil && tt.expectedError {
 				t.Fatal("expected Error, but no error")
 			}
@@ -83,7 +85,7 @@ func Test_addStignoreSecrets(t *testing.T) {
 			assert.Equal(t, tt.expectedAnnotation, tt.dev.Metadata.Annotations)
 
 			transformedStignorePath := filepath.Join(config.GetAppHome(tt.dev.Namespace, tt.dev.Name), ".stignore-1")
-			file, err := os.ReadFile(transformedStignorePath)
+			file, err := afero.ReadFile(fs, transformedStignorePath)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -94,3 +96,18 @@ func Test_addStignoreSecrets(t *testing.T) {
 		})
 	}
 }
+
+func Test_getLargeFiles(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "small.txt"), []byte("small"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "large.bin"), make([]byte, 2048), 0600))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, ".git"), 0700))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, ".git", "pack"), make([]byte, 2048), 0600))
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, ".stignore"), []byte("ignored.bin\n"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "ignored.bin"), make([]byte, 2048), 0600))
+
+	largeFiles, err := getLargeFiles([]model.SyncFolder{{LocalPath: dir, RemotePath: "/app"}}, 1024)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "large.bin")}, largeFiles)
+}
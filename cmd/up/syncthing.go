@@ -21,7 +21,9 @@ import (
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/config"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/events"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	localMetrics "github.com/okteto/okteto/pkg/metrics"
 	"github.com/okteto/okteto/pkg/syncthing"
 	"github.com/spf13/afero"
 )
@@ -33,6 +35,7 @@ func (up *upContext) initializeSyncthing() error {
 	}
 	sy.ResetDatabase = up.resetSyncthing
 	up.Sy = sy
+	up.metrics.setSyncthing(sy)
 
 	oktetoLog.Infof("local syncthing initialized: gui -> %d, sync -> %d", up.Sy.LocalGUIPort, up.Sy.LocalPort)
 	oktetoLog.Infof("remote syncthing initialized: gui -> %d, sync -> %d", up.Sy.RemoteGUIPort, up.Sy.RemotePort)
@@ -52,15 +55,18 @@ func (up *upContext) sync(ctx context.Context) error {
 	}
 
 	start := time.Now()
-	if err := config.UpdateStateFile(up.Dev.Name, up.Dev.Namespace, config.Synchronizing); err != nil {
+	if err := config.UpdateStateFileWithFilesystem(up.Dev.Name, up.Dev.Namespace, config.Synchronizing, up.Fs); err != nil {
 		return err
 	}
 
 	startSyncFiles := time.Now()
+	up.events.Record(events.Sync, "Synchronizing files", nil)
 	if err := up.synchronizeFiles(ctx); err != nil {
+		up.events.Record(events.Error, "File synchronization failed", err)
 		return err
 	}
 	up.analyticsMeta.ContextSync(time.Since(startSyncFiles))
+	up.events.Record(events.Sync, fmt.Sprintf("Files synchronized in %s", time.Since(startSyncFiles).Round(time.Second)), nil)
 
 	msg := "Files synchronized"
 	if up.Dev.IsHybridModeEnabled() {
@@ -70,6 +76,7 @@ func (up *upContext) sync(ctx context.Context) error {
 
 	elapsed := time.Since(start)
 	up.analyticsMeta.InitialSyncDuration(elapsed)
+	localMetrics.Observe(localMetrics.UpSync, elapsed)
 	maxDuration := time.Duration(1) * time.Minute
 	if elapsed > maxDuration {
 		minutes := elapsed / time.Minute
@@ -99,13 +106,21 @@ func (up *upContext) startSyncthing(ctx context.Context) error {
 		defer oktetoLog.StopSpinner()
 	}
 
-	if err := config.UpdateStateFile(up.Dev.Name, up.Dev.Namespace, config.StartingSync); err != nil {
+	if err := config.UpdateStateFileWithFilesystem(up.Dev.Name, up.Dev.Namespace, config.StartingSync, up.Fs); err != nil {
 		return err
 	}
 
 	if err := up.Sy.Run(); err != nil {
 		return err
 	}
+	// the syncthing process would be orphaned if okteto exits before the shutdown sequence finishes
+	sy := up.Sy
+	up.registerRelease("syncthing", func() {
+		oktetoLog.Infof("stopping syncthing")
+		if err := sy.SoftTerminate(); err != nil {
+			oktetoLog.Infof("failed to stop syncthing during shutdown: %s", err.Error())
+		}
+	})
 
 	if err := up.Sy.WaitForPing(ctx, true); err != nil {
 		return err
@@ -136,6 +151,9 @@ func (up *upContext) startSyncthing(ctx context.Context) error {
 }
 
 func (up *upContext) synchronizeFiles(ctx context.Context) error {
+	if up.dashboard != nil {
+		return up.synchronizeFilesWithDashboard(ctx)
+	}
 	if !up.Dev.IsHybridModeEnabled() {
 		oktetoLog.Spinner("Synchronizing your files...")
 		oktetoLog.StartSpinner()
@@ -179,28 +197,7 @@ func (up *upContext) synchronizeFiles(ctx context.Context) error {
 	}()
 
 	if err := up.Sy.WaitForCompletion(ctx, reporter); err != nil {
-		up.analyticsMeta.ErrSync()
-		switch err {
-		case oktetoErrors.ErrLostSyncthing:
-			up.analyticsMeta.ErrSyncLostSyncthing()
-			return err
-		case oktetoErrors.ErrInsufficientSpace:
-			up.analyticsMeta.ErrSyncInsufficientSpace()
-			return up.getInsufficientSpaceError(err)
-		case oktetoErrors.ErrNeedsResetSyncError:
-			up.analyticsMeta.ErrSyncResetDatabase()
-			return oktetoErrors.UserError{
-				E:    fmt.Errorf("the synchronization service state is inconsistent"),
-				Hint: `Try running 'okteto up --reset' to reset the synchronization service`,
-			}
-		default:
-			return oktetoErrors.UserError{
-				E: err,
-				Hint: fmt.Sprintf(`Help us improve okteto by filing an issue in https://github.com/okteto/okteto/issues/new.
-    Please include the file generated by 'okteto doctor' if possible.
-    Then, try to run '%s' + 'okteto up' again`, utils.GetDownCommand(up.Options.ManifestPathFlag)),
-			}
-		}
+		return up.getSyncError(err)
 	}
 
 	progressBar.SetCurrent(100)
@@ -208,6 +205,48 @@ func (up *upContext) synchronizeFiles(ctx context.Context) error {
 	return nil
 }
 
+// synchronizeFilesWithDashboard reports the progress of the file synchronization to the dashboard of 'okteto up --tui'
+func (up *upContext) synchronizeFilesWithDashboard(ctx context.Context) error {
+	up.dashboard.SetStatus("Synchronizing your files...")
+	reporter := make(chan float64)
+	go func() {
+		for c := range reporter {
+			up.dashboard.SetSyncProgress(int(c))
+		}
+	}()
+	if err := up.Sy.WaitForCompletion(ctx, reporter); err != nil {
+		return up.getSyncError(err)
+	}
+	up.dashboard.SetSyncProgress(100)
+	return nil
+}
+
+// getSyncError returns the error of a failed file synchronization
+func (up *upContext) getSyncError(err error) error {
+	up.analyticsMeta.ErrSync()
+	switch err {
+	case oktetoErrors.ErrLostSyncthing:
+		up.analyticsMeta.ErrSyncLostSyncthing()
+		return err
+	case oktetoErrors.ErrInsufficientSpace:
+		up.analyticsMeta.ErrSyncInsufficientSpace()
+		return up.getInsufficientSpaceError(err)
+	case oktetoErrors.ErrNeedsResetSyncError:
+		up.analyticsMeta.ErrSyncResetDatabase()
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the synchronization service state is inconsistent"),
+			Hint: `Try running 'okteto up --reset' to reset the synchronization service`,
+		}
+	default:
+		return oktetoErrors.UserError{
+			E: err,
+			Hint: fmt.Sprintf(`Help us improve okteto by filing an issue in https://github.com/okteto/okteto/issues/new.
+    Please include the file generated by 'okteto doctor' if possible.
+    Then, try to run '%s' + 'okteto up' again`, utils.GetDownCommand(up.Options.ManifestPathFlag)),
+		}
+	}
+}
+
 func (up *upContext) getSyncTempDir() (string, error) {
 	return afero.TempDir(up.Fs, "", "")
 }
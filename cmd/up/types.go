@@ -16,10 +16,12 @@ package up
 import (
 	"context"
 	"os/exec"
+	"sync"
 	"time"
 
 	"github.com/moby/term"
 	"github.com/okteto/okteto/pkg/analytics"
+	"github.com/okteto/okteto/pkg/events"
 	"github.com/okteto/okteto/pkg/k8s/apps"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/model/forward"
@@ -33,6 +35,7 @@ import (
 type registryInterface interface {
 	GetImageTagWithDigest(imageTag string) (string, error)
 	GetImageTag(image, service, namespace string) string
+	GetImagePlatforms(image string) ([]string, error)
 }
 
 type builderInterface interface {
@@ -63,6 +66,8 @@ type upContext struct {
 	CommandResult         chan error
 	Exit                  chan error
 	Sy                    *syncthing.Syncthing
+	releases              []func()
+	releasesMu            sync.Mutex
 	cleaned               chan string
 	hardTerminate         chan error
 	success               bool
@@ -80,7 +85,13 @@ type upContext struct {
 	interruptReceived     bool
 	analyticsTracker      analyticsTrackerInterface
 	analyticsMeta         *analytics.UpMetricsMetadata
+	metrics               *upMetrics
+	events                *events.Log
 	builder               builderInterface
+	timeouts              *model.Timeouts
+	dashboard             *dashboard
+	sessionLock           *sessionLock
+	shareSecret           string
 }
 
 // Forwarder is an interface for the port-forwarding features
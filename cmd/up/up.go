@@ -18,7 +18,6 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"os/signal"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -39,18 +38,22 @@ import (
 	"github.com/okteto/okteto/pkg/devenvironment"
 	"github.com/okteto/okteto/pkg/discovery"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
 	"k8s.io/client-go/kubernetes"
 
 	pipelineCMD "github.com/okteto/okteto/cmd/pipeline"
 	"github.com/okteto/okteto/pkg/cmd/pipeline"
 	"github.com/okteto/okteto/pkg/config"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/events"
 	"github.com/okteto/okteto/pkg/k8s/apps"
+	"github.com/okteto/okteto/pkg/k8s/namespaces"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	oktetoPath "github.com/okteto/okteto/pkg/path"
 	"github.com/okteto/okteto/pkg/registry"
+	"github.com/okteto/okteto/pkg/signals"
 	"github.com/okteto/okteto/pkg/ssh"
 	"github.com/okteto/okteto/pkg/syncthing"
 	"github.com/okteto/okteto/pkg/types"
@@ -64,6 +67,9 @@ const (
 	ReconnectingMessage = "Trying to reconnect to your cluster. File synchronization will automatically resume when the connection improves."
 
 	composeVolumesUrl = "https://www.okteto.com/docs/reference/compose/#volumes-string-optional"
+
+	// namespaceExpirationWarning is how long before the namespace expires 'okteto up' warns about it
+	namespaceExpirationWarning = 24 * time.Hour
 )
 
 var (
@@ -86,6 +92,16 @@ type UpOptions struct {
 	Deploy           bool
 	ForcePull        bool
 	Reset            bool
+	MetricsPort      int
+	PrintEnv         bool
+	Preflight        bool
+	SkipChecks       []string
+	Output           string
+	Repair           string
+	TUI              bool
+	StealSession     bool
+	Join             string
+	Share            bool
 	commandToExecute []string
 }
 
@@ -93,18 +109,65 @@ type UpOptions struct {
 func Up(at analyticsTrackerInterface) *cobra.Command {
 	upOptions := &UpOptions{}
 	cmd := &cobra.Command{
-		Use:   "up [svc]",
-		Short: "Launch your development environment",
-		Args:  utils.MaximumNArgsAccepted(1, "https://okteto.com/docs/reference/cli/#up"),
+		Use:               "up [svc]",
+		Short:             "Launch your development environment",
+		Args:              utils.MaximumNArgsAccepted(1, "https://okteto.com/docs/reference/cli/#up"),
+		ValidArgsFunction: utils.CompleteDevs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if okteto.InDevContainer() {
 				return oktetoErrors.ErrNotInDevContainer
 			}
 
+			args = upOptions.setJoinTokenFromArgs(args)
+			if isShareToken(upOptions.Join) {
+				return joinSharedSession(context.Background(), upOptions.Join)
+			}
+
 			if err := upOptions.AddArgs(cmd, args); err != nil {
 				return err
 			}
 
+			if err := validatePreflightSkips(upOptions.SkipChecks); err != nil {
+				return err
+			}
+			if err := validateRepair(upOptions.Repair); err != nil {
+				return err
+			}
+			if err := validateSessionFlags(upOptions); err != nil {
+				return err
+			}
+			if upOptions.Output != "" && upOptions.Output != "json" {
+				return fmt.Errorf("output format '%s' is not supported. One of: ['json']", upOptions.Output)
+			}
+			if upOptions.Output != "" && !upOptions.Preflight {
+				return fmt.Errorf("the '--output' flag can only be used with '--preflight'")
+			}
+			if upOptions.TUI && (!oktetoLog.IsInteractive() || oktetoLog.GetOutputFormat() != oktetoLog.TTYFormat) {
+				oktetoLog.Information("The dashboard requires an interactive terminal, using the plain output")
+				upOptions.TUI = false
+			}
+
+			sessionDir, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("failed to get the current working directory: %w", err)
+			}
+			sessionCtrl := newSessionController(sessionDir)
+			var session *sessionState
+			if upOptions.Reset {
+				sessionCtrl.delete()
+			} else if canResumeSession(cmd, args) {
+				session, err = sessionCtrl.load()
+				if err != nil {
+					oktetoLog.Infof("failed to load the session state: %s", err)
+				}
+				if session != nil {
+					oktetoLog.Information("Resuming the session of '%s'. Run 'okteto up --reset' to start a new one", session.DevName)
+					session.apply(upOptions)
+				}
+			}
+			// the manifest path is saved relative to the folder of the session state
+			sessionManifestPath := upOptions.ManifestPath
+
 			u := utils.UpgradeAvailable()
 			if len(u) > 0 {
 				warningFolder := filepath.Join(config.GetOktetoHome(), ".warnings")
@@ -251,6 +314,20 @@ func Up(at analyticsTrackerInterface) *cobra.Command {
 				}
 			}
 
+			if _, ok := oktetoManifest.Dev[upOptions.DevName]; session != nil && !ok {
+				oktetoLog.Infof("dev container '%s' of the previous session is no longer defined in the manifest", upOptions.DevName)
+				upOptions.DevName = ""
+				session = nil
+			}
+
+			if upOptions.PrintEnv {
+				dev, err := getDev(oktetoManifest, upOptions.DevName)
+				if err != nil {
+					return err
+				}
+				return printEnvironment(dev, upOptions.Envs)
+			}
+
 			up := &upContext{
 				Manifest:          oktetoManifest,
 				Dev:               nil,
@@ -265,6 +342,7 @@ func Up(at analyticsTrackerInterface) *cobra.Command {
 				K8sClientProvider: okteto.NewK8sClientProvider(),
 				tokenUpdater:      newTokenUpdaterController(),
 				builder:           buildv2.NewBuilderFromScratch(at),
+				timeouts:          okteto.GetTimeouts(oktetoManifest),
 			}
 			up.inFd, up.isTerm = term.GetFdInfo(os.Stdin)
 			if up.isTerm {
@@ -275,13 +353,50 @@ func Up(at analyticsTrackerInterface) *cobra.Command {
 					return fmt.Errorf("failed to save the state of the terminal")
 				}
 				oktetoLog.Infof("Terminal: %v", up.stateTerm)
+				// the terminal would stay in raw mode if okteto exits before the shutdown sequence restores it
+				signals.RegisterCleanup("terminal", func() {
+					if err := term.RestoreTerminal(up.inFd, up.stateTerm); err != nil {
+						oktetoLog.Infof("failed to restore terminal: %s", err.Error())
+					}
+				})
 			}
 
-			k8sClient, _, err := okteto.GetK8sClient()
+			k8sClient, restConfig, err := okteto.GetK8sClient()
 			if err != nil {
 				return fmt.Errorf("failed to load k8s client: %v", err)
 			}
 
+			if upOptions.Preflight {
+				dev, err := getDev(oktetoManifest, upOptions.DevName)
+				if err != nil {
+					return err
+				}
+				if err := loadManifestOverrides(dev, upOptions); err != nil {
+					return err
+				}
+				results := newPreflightChecker(dev, k8sClient, restConfig, up.Registry).run(ctx, upOptions.SkipChecks)
+				if err := printPreflightResults(results, upOptions.Output, true); err != nil {
+					return err
+				}
+				return preflightError(results)
+			}
+
+			if upOptions.Repair != "" {
+				dev, err := getDev(oktetoManifest, upOptions.DevName)
+				if err != nil {
+					return err
+				}
+				upOptions.DevName = dev.Name
+				pc := newPIDController(dev.Namespace, dev.Name)
+				activate, err := repairDevMode(ctx, dev, upOptions.Repair, pc.isRunning, k8sClient)
+				if err != nil {
+					return err
+				}
+				if !activate {
+					return nil
+				}
+			}
+
 			// if manifest v1 - either set autocreate: true or pass --deploy (okteto forces autocreate: true)
 			// if manifest v2 - either set autocreate: true or pass --deploy with a deploy section at the manifest
 			forceAutocreate := false
@@ -300,16 +415,9 @@ func Up(at analyticsTrackerInterface) *cobra.Command {
 				oktetoLog.Information("'%s' was already deployed. To redeploy run 'okteto deploy' or 'okteto up --deploy'", up.Manifest.Name)
 			}
 
-			dev, err := utils.GetDevFromManifest(oktetoManifest, upOptions.DevName)
+			dev, err := getDev(oktetoManifest, upOptions.DevName)
 			if err != nil {
-				if !errors.Is(err, utils.ErrNoDevSelected) {
-					return err
-				}
-				selector := utils.NewOktetoSelector("Select which development container to activate:", "Development container")
-				dev, err = utils.SelectDevFromManifest(oktetoManifest, selector, oktetoManifest.Dev.GetDevs())
-				if err != nil {
-					return err
-				}
+				return err
 			}
 			if len(upOptions.commandToExecute) > 0 {
 				dev.Command.Values = upOptions.commandToExecute
@@ -324,6 +432,8 @@ func Up(at analyticsTrackerInterface) *cobra.Command {
 
 			// only if the context is an okteto one, we should verify if the namespace has to be woken up
 			if okteto.Context().IsOkteto {
+				warnNamespaceExpiration(ctx, up.Dev.Namespace, k8sClient, time.Now())
+
 				// We execute it in a goroutine to not impact the command performance
 				go func() {
 					okClient, err := okteto.NewOktetoClient()
@@ -347,7 +457,22 @@ func Up(at analyticsTrackerInterface) *cobra.Command {
 				return err
 			}
 
-			if syncthing.ShouldUpgrade() {
+			if session != nil {
+				session.applyForwards(dev)
+			}
+			if err := sessionCtrl.save(newSessionState(sessionManifestPath, upOptions, dev)); err != nil {
+				oktetoLog.Infof("failed to save the session state: %s", err)
+			}
+
+			if syncthing.ShouldUpgrade() && config.IsOffline() {
+				if !syncthing.IsInstalled() {
+					return oktetoErrors.UserError{
+						E:    fmt.Errorf("syncthing is not installed at '%s' and it can't be downloaded in offline mode", syncthing.GetBinaryPath()),
+						Hint: fmt.Sprintf("Run 'okteto sync upgrade' while online, or set %s to a pre-installed syncthing binary", model.SyncthingPathEnvVar),
+					}
+				}
+				oktetoLog.Warning("Syncthing %s is required, its upgrade is skipped in offline mode", syncthing.GetMinimumVersion().String())
+			} else if syncthing.ShouldUpgrade() {
 				oktetoLog.Println("Installing dependencies...")
 				if err := downloadSyncthing(); err != nil {
 					oktetoLog.Infof("failed to upgrade syncthing: %s", err)
@@ -365,11 +490,23 @@ func Up(at analyticsTrackerInterface) *cobra.Command {
 
 			oktetoLog.ConfigureFileLogger(config.GetAppHome(dev.Namespace, dev.Name), config.VersionString)
 
-			if err := checkStignoreConfiguration(dev); err != nil {
+			if err := checkStignoreConfiguration(dev, up.Fs); err != nil {
 				oktetoLog.Infof("failed to check '.stignore' configuration: %s", err.Error())
 			}
 
-			if err := addStignoreSecrets(dev); err != nil {
+			if err := checkLargeFiles(dev); err != nil {
+				oktetoLog.Infof("failed to check the size of the synchronized files: %s", err.Error())
+			}
+
+			if err := checkSkipLargeFiles(dev); err != nil {
+				return err
+			}
+
+			if err := checkLineEndings(dev); err != nil {
+				return err
+			}
+
+			if err := addStignoreSecrets(dev, up.Fs); err != nil {
 				return err
 			}
 
@@ -391,6 +528,14 @@ func Up(at analyticsTrackerInterface) *cobra.Command {
     https://www.okteto.com/docs/reference/manifest-migration/`))
 			}
 
+			results := newPreflightChecker(dev, k8sClient, restConfig, up.Registry).run(ctx, upOptions.SkipChecks)
+			if err := printPreflightResults(results, "", false); err != nil {
+				return err
+			}
+			if err := preflightError(results); err != nil {
+				return err
+			}
+
 			if err = up.start(); err != nil {
 				switch err.(type) {
 				default:
@@ -418,8 +563,20 @@ func Up(at analyticsTrackerInterface) *cobra.Command {
 	if err := cmd.Flags().MarkHidden("pull"); err != nil {
 		oktetoLog.Infof("failed to mark 'pull' flag as hidden: %s", err)
 	}
-	cmd.Flags().BoolVarP(&upOptions.Reset, "reset", "", false, "reset the file synchronization database")
+	cmd.Flags().BoolVarP(&upOptions.Reset, "reset", "", false, "reset the file synchronization database and the saved session")
 	cmd.Flags().StringArrayVarP(&upOptions.commandToExecute, "command", "", []string{}, "external commands to be supplied to 'okteto up'")
+	cmd.Flags().BoolVarP(&upOptions.PrintEnv, "print-env", "", false, "print the environment of the development container and exit. '--env' takes precedence over 'environment', and 'environment' over 'envFiles', where the last file wins")
+	cmd.Flags().IntVarP(&upOptions.MetricsPort, "metrics-port", "", 0, "expose prometheus metrics of the session on this local port")
+	cmd.Flags().BoolVarP(&upOptions.Preflight, "preflight", "", false, "run the pre-flight checks and exit without activating the development container")
+	cmd.Flags().StringSliceVarP(&upOptions.SkipChecks, "skip-checks", "", []string{}, fmt.Sprintf("pre-flight checks to skip. Any of: [%s, %s]", strings.Join(preflightChecks, ", "), skipAllPreflightChecks))
+	cmd.Flags().StringVarP(&upOptions.Repair, "repair", "", "", fmt.Sprintf("repair a development container left half-activated by a failed 'okteto up'. Restores the original workload with '%s' (default) or activates the development container again with '%s'", repairToOriginal, repairToDev))
+	cmd.Flags().Lookup("repair").NoOptDefVal = repairToOriginal
+	cmd.Flags().StringVarP(&upOptions.Output, "output", "o", "", "output format of the pre-flight checks when using '--preflight'. One of: ['json']")
+	cmd.Flags().BoolVarP(&upOptions.StealSession, "steal", "", false, "stop the 'okteto up' session of the development container, if any, and start a new one")
+	cmd.Flags().StringVarP(&upOptions.Join, "join", "", "", "open a terminal in the development container of the active 'okteto up' session, if any, instead of starting a new one. Use a token printed by 'okteto up --share' to join the session of a teammate")
+	cmd.Flags().Lookup("join").NoOptDefVal = joinActiveSession
+	cmd.Flags().BoolVarP(&upOptions.Share, "share", "", false, "print a token to share the session with your teammates. They get their own terminal and forwards, and the files are only synchronized from your computer")
+	cmd.Flags().BoolVarP(&upOptions.TUI, "tui", "", false, "show an interactive dashboard with the file synchronization, the forwarded ports and the logs of the command, which runs without a terminal. Shortcuts: 'r' restarts the command, 'o' opens the first forwarded port, 'v' toggles verbose logs and 'q' stops the session")
 	return cmd
 }
 
@@ -485,6 +642,41 @@ func LoadManifestWithInit(ctx context.Context, k8sContext, namespace, devPath st
 	return manifest, nil
 }
 
+// getDev returns the development container to activate, asking the user to select one if needed
+func getDev(oktetoManifest *model.Manifest, devName string) (*model.Dev, error) {
+	dev, err := utils.GetDevFromManifest(oktetoManifest, devName)
+	if err == nil {
+		return dev, nil
+	}
+	if !errors.Is(err, utils.ErrNoDevSelected) {
+		return nil, err
+	}
+	selector := utils.NewOktetoSelector("Select which development container to activate:", "Development container")
+	return utils.SelectDevFromManifest(oktetoManifest, selector, oktetoManifest.Dev.GetDevs())
+}
+
+// printEnvironment prints the resolved environment of a development container and its services
+func printEnvironment(dev *model.Dev, envs []string) error {
+	environment := dev.Environment
+	if len(envs) > 0 {
+		overridedEnvVars, err := getOverridedEnvVarsFromCmd(dev.Environment, envs)
+		if err != nil {
+			return err
+		}
+		environment = *overridedEnvVars
+	}
+	for _, e := range environment {
+		oktetoLog.Println(e.String())
+	}
+	for _, s := range dev.Services {
+		oktetoLog.Println(fmt.Sprintf("# %s", s.Name))
+		for _, e := range s.Environment {
+			oktetoLog.Println(e.String())
+		}
+	}
+	return nil
+}
+
 func loadManifestOverrides(dev *model.Dev, upOptions *UpOptions) error {
 	if upOptions.Remote > 0 {
 		dev.RemotePort = upOptions.Remote
@@ -555,7 +747,7 @@ func getOverridedEnvVarsFromCmd(manifestEnvVars model.Environment, commandEnvVar
 			return nil, oktetoErrors.ErrBuiltInOktetoEnvVarSetFromCMD
 		}
 
-		expandedEnv, err := model.ExpandEnv(varValueToAdd, true)
+		expandedEnv, err := model.ExpandEnvValue(varValueToAdd, true)
 		if err != nil {
 			return nil, err
 		}
@@ -631,9 +823,28 @@ func (up *upContext) getManifest(path string) (*model.Manifest, error) {
 	return model.GetManifestV2(path)
 }
 
+// getTimeouts returns the timeouts of the manifest and the okteto context, or the defaults if they weren't resolved
+func (up *upContext) getTimeouts() *model.Timeouts {
+	if up.timeouts == nil {
+		return model.ResolveTimeouts()
+	}
+	return up.timeouts
+}
+
 func (up *upContext) start() error {
 	up.pidController = newPIDController(up.Dev.Namespace, up.Dev.Name)
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	lock := up.newSessionLock()
+	action, err := getSessionAction(ctx, up.Options, up.pidController.isRunning(), lock)
+	if err != nil {
+		return err
+	}
+	if action == sessionJoin {
+		return joinSession(ctx, up.Dev, up.Options)
+	}
+
 	if err := up.pidController.create(); err != nil {
 		oktetoLog.Infof("failed to create pid file for %s - %s: %s", up.Dev.Namespace, up.Dev.Name, err)
 
@@ -646,24 +857,67 @@ func (up *upContext) start() error {
 		}
 	}
 
-	defer up.pidController.delete()
+	releasePIDFile := signals.RegisterRelease("pid file", up.pidController.delete)
+	defer releasePIDFile()
+
+	sessionLockCh := make(chan error, 1)
+	if lock != nil {
+		if err := lock.acquire(ctx); err != nil {
+			oktetoLog.Infof("failed to acquire the session lease: %s", err)
+		} else {
+			up.sessionLock = lock
+			releaseLock := signals.RegisterRelease("session lease", func() { lock.release(context.Background()) })
+			defer releaseLock()
+			go lock.keepAlive(ctx, sessionLockCh)
+		}
+	}
+
+	up.startEvents()
+	defer up.stopEvents()
+
+	if up.Options.MetricsPort > 0 {
+		stopMetrics, err := up.startMetrics()
+		if err != nil {
+			return err
+		}
+		defer stopMetrics()
+	}
 
 	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	signals.Notify(stop)
 
 	pidFileCh := make(chan error, 1)
 
+	if up.Options.TUI {
+		if up.Dev.IsHybridModeEnabled() {
+			oktetoLog.Information("The dashboard is not available in hybrid mode, using the plain output")
+		} else {
+			up.dashboard = newUpDashboard(up, func() {
+				select {
+				case stop <- os.Interrupt:
+				default:
+				}
+			})
+			up.dashboard.Start()
+			defer up.dashboard.Stop()
+		}
+	}
+
 	up.analyticsMeta.ManifestProps(up.Manifest)
 	up.analyticsMeta.DevProps(up.Dev)
 	up.analyticsMeta.RepositoryProps(utils.IsOktetoRepo())
+	if analytics.GetStatus().Enabled {
+		up.analyticsMeta.ClusterProps(up.getClusterVersion())
+	}
 
 	go up.activateLoop()
 
 	go up.pidController.notifyIfPIDFileChange(pidFileCh)
 
 	select {
-	case <-stop:
-		oktetoLog.Infof("CTRL+C received, starting shutdown sequence")
+	case s := <-stop:
+		oktetoLog.Infof("%s received, starting shutdown sequence", s)
+		up.events.Record(events.Activation, fmt.Sprintf("%s received, stopping the session", s), nil)
 		up.interruptReceived = true
 		up.shutdown()
 		oktetoLog.Println()
@@ -681,10 +935,41 @@ func (up *upContext) start() error {
 		}
 		oktetoLog.Infof("exit signal received due to pid file modification: %s", err)
 		return err
+	case err := <-sessionLockCh:
+		if up.Dev.IsHybridModeEnabled() {
+			up.shutdownHybridMode()
+		}
+		oktetoLog.Infof("exit signal received due to session lease takeover: %s", err)
+		return err
 	}
 	return nil
 }
 
+// newSessionLock returns the lease of the session in the namespace, or nil if the cluster isn't available
+func (up *upContext) newSessionLock() *sessionLock {
+	k8sClient, _, err := up.K8sClientProvider.Provide(okteto.Context().Cfg)
+	if err != nil {
+		oktetoLog.Infof("failed to get the kubernetes client: %s", err)
+		return nil
+	}
+	return newSessionLock(up.Dev, k8sClient)
+}
+
+// getClusterVersion returns the kubernetes version of the cluster, or an empty string if it can't be retrieved
+func (up *upContext) getClusterVersion() string {
+	k8sClient, _, err := up.K8sClientProvider.Provide(okteto.Context().Cfg)
+	if err != nil {
+		oktetoLog.Infof("failed to get the kubernetes client: %s", err)
+		return ""
+	}
+	version, err := k8sClient.Discovery().ServerVersion()
+	if err != nil {
+		oktetoLog.Infof("failed to get the kubernetes version: %s", err)
+		return ""
+	}
+	return version.GitVersion
+}
+
 // activateLoop activates the development container in a retry loop
 func (up *upContext) activateLoop() {
 	isTransientError := false
@@ -693,7 +978,7 @@ func (up *upContext) activateLoop() {
 	defer t.Stop()
 
 	defer func() {
-		if err := config.DeleteStateFile(up.Dev.Name, up.Dev.Namespace); err != nil {
+		if err := config.DeleteStateFileWithFilesystem(up.Dev.Name, up.Dev.Namespace, up.Fs); err != nil {
 			oktetoLog.Infof("failed to delete state file: %s", err)
 		}
 	}()
@@ -728,6 +1013,7 @@ func (up *upContext) activateLoop() {
 		err := up.activate()
 		if err != nil {
 			oktetoLog.Infof("activate failed with: %s", err)
+			up.events.Record(events.Error, "Activation failed", err)
 
 			if err == oktetoErrors.ErrLostSyncthing {
 				isTransientError = false
@@ -930,28 +1216,33 @@ func (up *upContext) shutdown() {
 		oktetoLog.Info("sent cancellation signal")
 	}
 
-	if up.Sy != nil {
-		oktetoLog.Infof("stopping syncthing")
-		if err := up.Sy.SoftTerminate(); err != nil {
-			oktetoLog.Infof("failed to stop syncthing during shutdown: %s", err.Error())
-		}
-	}
-
-	oktetoLog.Infof("stopping forwarders")
-	if up.Forwarder != nil {
-		up.Forwarder.Stop()
-	}
-
-	if up.Dev.IsHybridModeEnabled() {
-		oktetoLog.Infof("stopping local process...")
-		up.shutdownHybridMode()
-	}
+	up.releaseResources()
 
 	oktetoLog.Info("completed shutdown sequence")
 	up.ShutdownCompleted <- true
 
 }
 
+// registerRelease registers the release of a resource of the current activation, like syncthing or the port forwards.
+// The shutdown sequence releases it, or it is released on exit if okteto exits before the shutdown sequence runs
+func (up *upContext) registerRelease(name string, fn func()) {
+	up.releasesMu.Lock()
+	defer up.releasesMu.Unlock()
+	up.releases = append(up.releases, signals.RegisterRelease(name, fn))
+}
+
+// releaseResources releases the resources of the current activation in reverse order of registration
+func (up *upContext) releaseResources() {
+	up.releasesMu.Lock()
+	pending := up.releases
+	up.releases = nil
+	up.releasesMu.Unlock()
+
+	for i := len(pending) - 1; i >= 0; i-- {
+		pending[i]()
+	}
+}
+
 func (up *upContext) shutdownHybridMode() {
 	if up.hybridCommand == nil {
 		return
@@ -1056,6 +1347,8 @@ func printDisplayContext(up *upContext) {
 		}
 	}
 
+	printDebugInfo(up.Dev)
+
 	oktetoLog.Println()
 }
 
@@ -1091,6 +1384,21 @@ func wakeNamespaceIfApplies(ctx context.Context, ns string, k8sClient kubernetes
 	return okClient.Namespaces().Wake(ctx, ns)
 }
 
+// warnNamespaceExpiration warns if the time to live of the namespace expires soon
+func warnNamespaceExpiration(ctx context.Context, ns string, k8sClient kubernetes.Interface, now time.Time) {
+	n, err := k8sClient.CoreV1().Namespaces().Get(ctx, ns, metav1.GetOptions{})
+	if err != nil {
+		oktetoLog.Infof("failed to get the expiration of the namespace: %s", err)
+		return
+	}
+	expiresAt, ok := namespaces.GetExpiration(n)
+	if !ok || expiresAt.Sub(now) > namespaceExpirationWarning {
+		return
+	}
+	oktetoLog.Warning("Namespace '%s' expires in %s and will be deleted the next time 'okteto namespace delete --expired' runs", ns, duration.HumanDuration(expiresAt.Sub(now)))
+	oktetoLog.Hint("    Run 'okteto namespace set-ttl %s <ttl>' to extend it", ns)
+}
+
 // tokenUpgrader updates the token of the config when the token is outdated
 type tokenUpdater interface {
 	UpdateKubeConfigToken() error
@@ -14,18 +14,22 @@
 package up
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/okteto/okteto/internal/test"
 	"github.com/okteto/okteto/internal/test/client"
 	"github.com/okteto/okteto/pkg/constants"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/model/forward"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/signals"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -408,6 +412,45 @@ func TestWakeNamespaceIfAppliesWithoutErrors(t *testing.T) {
 	}
 }
 
+func TestWarnNamespaceExpiration(t *testing.T) {
+	now := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    string
+	}{
+		{
+			name: "no ttl",
+		},
+		{
+			name:        "expires later",
+			annotations: map[string]string{constants.NamespaceExpiresAtAnnotation: now.Add(72 * time.Hour).Format(time.RFC3339)},
+		},
+		{
+			name:        "expires soon",
+			annotations: map[string]string{constants.NamespaceExpiresAtAnnotation: now.Add(3 * time.Hour).Format(time.RFC3339)},
+			expected:    "Namespace 'test' expires in 3h and will be deleted the next time 'okteto namespace delete --expired' runs",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := fake.NewSimpleClientset(&v1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Annotations: tt.annotations},
+			})
+			out := &bytes.Buffer{}
+			restore := oktetoLog.RedirectOutput(out)
+			defer restore()
+
+			warnNamespaceExpiration(context.Background(), "test", k8sClient, now)
+			if tt.expected == "" {
+				assert.Empty(t, out.String())
+				return
+			}
+			assert.Contains(t, out.String(), tt.expected)
+		})
+	}
+}
+
 func TestSetSyncDefaultsByDevMode(t *testing.T) {
 	fakeSyncFolderName := "test"
 	tests := []struct {
@@ -746,3 +789,27 @@ func Test_buildServicesAndSetBuildEnvs(t *testing.T) {
 		})
 	}
 }
+
+func TestReleaseResources(t *testing.T) {
+	released := []string{}
+	up := &upContext{}
+	up.registerRelease("port forwards", func() { released = append(released, "port forwards") })
+	up.registerRelease("syncthing", func() { released = append(released, "syncthing") })
+
+	up.releaseResources()
+	assert.Equal(t, []string{"syncthing", "port forwards"}, released)
+
+	// the resources released by the shutdown sequence are not released again on exit
+	signals.RunCleanups()
+	assert.Equal(t, []string{"syncthing", "port forwards"}, released)
+}
+
+func TestReleaseResourcesOnExit(t *testing.T) {
+	released := false
+	up := &upContext{}
+	up.registerRelease("syncthing", func() { released = true })
+
+	signals.RunCleanups()
+	assert.True(t, released)
+	up.releaseResources()
+}
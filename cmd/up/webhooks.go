@@ -0,0 +1,37 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/webhook"
+)
+
+// notifyActivation calls the webhooks of the manifest subscribed to the activation of development containers
+func (up *upContext) notifyActivation(ctx context.Context) {
+	if up.Manifest == nil || len(up.Manifest.Webhooks) == 0 {
+		return
+	}
+	webhook.NewNotifier(up.Manifest.Webhooks).Notify(ctx, webhook.Event{
+		Event:     model.WebhookEventUpActivate,
+		Name:      up.Manifest.Name,
+		Namespace: up.Dev.Namespace,
+		Context:   okteto.Context().Name,
+		User:      okteto.Context().Username,
+		Dev:       up.Dev.Name,
+	})
+}
@@ -14,12 +14,8 @@
 package cmd
 
 import (
-	"fmt"
 	"runtime"
 
-	"github.com/Masterminds/semver/v3"
-	"github.com/okteto/okteto/cmd/utils"
-	"github.com/okteto/okteto/pkg/config"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/spf13/cobra"
 )
@@ -29,51 +25,15 @@ const (
 	INSTALL_PATH = "/usr/local/bin/okteto"
 )
 
-// UpdateDeprecated checks if there is a new version available and updates it
+// UpdateDeprecated updates okteto to the latest version of its release channel
 func UpdateDeprecated() *cobra.Command {
-	return &cobra.Command{
-		Use:   "update",
-		Short: "Update Okteto CLI version",
-		RunE: func(cmd *cobra.Command, args []string) error {
-			oktetoLog.Warning("'okteto update' is deprecated in favor of 'okteto version update', and will be removed in a future version")
-			currentVersion, err := semver.NewVersion(config.VersionString)
-			if err != nil {
-				return fmt.Errorf("could not retrieve version")
-			}
-
-			if isUpdateAvailable(currentVersion) {
-				displayUpdateSteps()
-			} else {
-				oktetoLog.Success("The latest okteto version is already installed")
-			}
-
-			return nil
-		},
-	}
-}
-
-// isUpdateAvailable checks if there is a new version available
-func isUpdateAvailable(currentVersion *semver.Version) bool {
-	v, err := utils.GetLatestVersionFromGithub()
-	if err != nil {
-		oktetoLog.Infof("failed to get latest version from github: %s", err)
-		return false
+	cmd := Update()
+	runE := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		oktetoLog.Warning("'okteto update' is deprecated in favor of 'okteto version update', and will be removed in a future version")
+		return runE(cmd, args)
 	}
-
-	if len(v) > 0 {
-		latest, err := semver.NewVersion(v)
-		if err != nil {
-			oktetoLog.Infof("failed to parse latest version '%s': %s", v, err)
-			return false
-		}
-
-		if latest.GreaterThan(currentVersion) {
-			oktetoLog.Infof("new version available: %s -> %s", currentVersion.String(), latest)
-			return true
-		}
-	}
-
-	return false
+	return cmd
 }
 
 func displayUpdateSteps() {
@@ -0,0 +1,157 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoHttp "github.com/okteto/okteto/pkg/http"
+)
+
+const (
+	// StableChannel is the release channel of the okteto releases
+	StableChannel = "stable"
+	// BetaChannel is the release channel of the okteto release candidates
+	BetaChannel = "beta"
+	// NightlyChannel is the release channel of the daily builds of the main branch
+	NightlyChannel = "nightly"
+
+	// OktetoChannelEnvVar overrides the release channel saved in the okteto folder, as the installation script does
+	OktetoChannelEnvVar = "OKTETO_CHANNEL"
+
+	// devChannel is the former name of the nightly channel, still accepted by the installation script
+	devChannel = "dev"
+
+	channelFile = "channel"
+
+	downloadsURL = "https://downloads.okteto.com/cli"
+)
+
+// ReleaseChannels are the release channels of the okteto cli
+var ReleaseChannels = []string{StableChannel, BetaChannel, NightlyChannel}
+
+// ValidateReleaseChannel returns an error if the channel is not a release channel
+func ValidateReleaseChannel(channel string) error {
+	for _, c := range ReleaseChannels {
+		if c == channel {
+			return nil
+		}
+	}
+	return oktetoErrors.UserError{
+		E:    fmt.Errorf("release channel '%s' is not supported", channel),
+		Hint: fmt.Sprintf("Use one of: [%s]", strings.Join(ReleaseChannels, ", ")),
+	}
+}
+
+// GetReleaseChannel returns the release channel set by OKTETO_CHANNEL or saved in the okteto folder. It defaults to the stable channel
+func GetReleaseChannel() string {
+	if channel := normalizeReleaseChannel(os.Getenv(OktetoChannelEnvVar)); channel != "" {
+		return channel
+	}
+	b, err := os.ReadFile(filepath.Join(config.GetOktetoHome(), channelFile))
+	if err != nil {
+		return StableChannel
+	}
+	if channel := normalizeReleaseChannel(string(b)); channel != "" {
+		return channel
+	}
+	return StableChannel
+}
+
+// SetReleaseChannel saves the release channel in the okteto folder, where the installation script also reads it
+func SetReleaseChannel(channel string) error {
+	if err := ValidateReleaseChannel(channel); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(config.GetOktetoHome(), channelFile), []byte(channel), 0600)
+}
+
+func normalizeReleaseChannel(channel string) string {
+	channel = strings.ToLower(strings.TrimSpace(channel))
+	if channel == devChannel {
+		return NightlyChannel
+	}
+	if ValidateReleaseChannel(channel) != nil {
+		return ""
+	}
+	return channel
+}
+
+// releaseClient downloads the okteto releases of a release channel
+type releaseClient struct {
+	baseURL   string
+	client    *http.Client
+	publicKey string
+}
+
+func newReleaseClient() *releaseClient {
+	return &releaseClient{
+		baseURL:   downloadsURL,
+		client:    &http.Client{Transport: oktetoHttp.DefaultTransport()},
+		publicKey: config.ReleasePublicKey,
+	}
+}
+
+// GetLatestVersionFromChannel returns the latest okteto version of a release channel
+func GetLatestVersionFromChannel(ctx context.Context, channel string) (string, error) {
+	if err := config.CheckOnline("checking the latest okteto version"); err != nil {
+		return "", err
+	}
+	return newReleaseClient().getLatestVersion(ctx, channel)
+}
+
+// getLatestVersion returns the last version of the versions file of the channel
+func (rc *releaseClient) getLatestVersion(ctx context.Context, channel string) (string, error) {
+	b, err := rc.get(ctx, fmt.Sprintf("%s/%s/versions", rc.baseURL, channel))
+	if err != nil {
+		return "", fmt.Errorf("failed to get the versions of the %s channel: %w", channel, err)
+	}
+	latest := ""
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			latest = line
+		}
+	}
+	if latest == "" {
+		return "", fmt.Errorf("the %s channel doesn't have any version", channel)
+	}
+	return latest, nil
+}
+
+func (rc *releaseClient) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := rc.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s returned %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
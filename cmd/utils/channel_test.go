@@ -0,0 +1,90 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetReleaseChannel(t *testing.T) {
+	var tests = []struct {
+		name     string
+		env      string
+		file     string
+		expected string
+	}{
+		{name: "default", expected: StableChannel},
+		{name: "env", env: "beta", expected: BetaChannel},
+		{name: "file", file: "nightly\n", expected: NightlyChannel},
+		{name: "env-overrides-file", env: "stable", file: "beta", expected: StableChannel},
+		{name: "dev-alias", file: "dev", expected: NightlyChannel},
+		{name: "invalid", env: "canary", file: "unknown", expected: StableChannel},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Setenv(constants.OktetoFolderEnvVar, dir)
+			t.Setenv(OktetoChannelEnvVar, tt.env)
+			if tt.file != "" {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, channelFile), []byte(tt.file), 0600))
+			}
+			assert.Equal(t, tt.expected, GetReleaseChannel())
+		})
+	}
+}
+
+func TestSetReleaseChannel(t *testing.T) {
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+	t.Setenv(OktetoChannelEnvVar, "")
+
+	require.NoError(t, SetReleaseChannel(BetaChannel))
+	assert.Equal(t, BetaChannel, GetReleaseChannel())
+
+	assert.Error(t, SetReleaseChannel("canary"))
+	assert.Equal(t, BetaChannel, GetReleaseChannel())
+}
+
+func TestGetLatestVersion(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/beta/versions":
+			w.Write([]byte("2.14.0-rc.1\n2.15.0-rc.1\n\n"))
+		case "/nightly/versions":
+			w.Write([]byte("\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer s.Close()
+	rc := &releaseClient{baseURL: s.URL, client: s.Client()}
+
+	latest, err := rc.getLatestVersion(context.Background(), BetaChannel)
+	require.NoError(t, err)
+	assert.Equal(t, "2.15.0-rc.1", latest)
+
+	_, err = rc.getLatestVersion(context.Background(), NightlyChannel)
+	assert.Error(t, err)
+
+	_, err = rc.getLatestVersion(context.Background(), StableChannel)
+	assert.Error(t, err)
+}
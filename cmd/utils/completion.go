@@ -0,0 +1,213 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// completionTimeout is the maximum time to wait for the API when completing values, so the shell doesn't hang
+const completionTimeout = 5 * time.Second
+
+// CompletionFunc returns the values suggested by the shell completion scripts
+type CompletionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// CompleteContexts suggests the contexts of the okteto context store
+func CompleteContexts(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	contexts := []string{}
+	for name := range okteto.ContextStore().Contexts {
+		contexts = append(contexts, name)
+	}
+	return filterCompletions(contexts, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompleteNamespaces suggests the namespaces of the current context
+func CompleteNamespaces(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 || !setCompletionContext(cmd) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+
+	var namespaces []string
+	var err error
+	if okteto.IsOkteto() {
+		var okClient types.OktetoInterface
+		okClient, err = okteto.NewOktetoClient()
+		if err == nil {
+			namespaces, err = listOktetoNamespaces(ctx, okClient)
+		}
+	} else {
+		namespaces, err = listK8sNamespaces(ctx)
+	}
+	if err != nil {
+		oktetoLog.Infof("error completing namespaces: %s", err)
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return filterCompletions(namespaces, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompletePreviews suggests the preview environments of the current okteto context
+func CompletePreviews(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 || !setCompletionContext(cmd) || !okteto.IsOkteto() {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	okClient, err := okteto.NewOktetoClient()
+	if err != nil {
+		oktetoLog.Infof("error completing previews: %s", err)
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+	previews, err := listPreviews(ctx, okClient)
+	if err != nil {
+		oktetoLog.Infof("error completing previews: %s", err)
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return filterCompletions(previews, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompleteDevs suggests the dev containers of the manifest defined by the '--file' flag
+func CompleteDevs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	manifestPath := ""
+	if f := cmd.Flags().Lookup("file"); f != nil {
+		manifestPath = f.Value.String()
+	}
+	manifest, err := model.GetManifestV2(manifestPath)
+	if err != nil {
+		oktetoLog.Infof("error completing dev containers: %s", err)
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return filterCompletions(getDevNames(manifest), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// RegisterFlagCompletions registers the completion of the '--namespace' and '--context' flags of a command and its subcommands
+func RegisterFlagCompletions(cmd *cobra.Command) {
+	for _, c := range cmd.Commands() {
+		RegisterFlagCompletions(c)
+	}
+
+	completions := map[string]CompletionFunc{
+		"namespace": CompleteNamespaces,
+		"context":   CompleteContexts,
+	}
+	for flag, f := range completions {
+		if cmd.Flags().Lookup(flag) == nil {
+			continue
+		}
+		f := f
+		if err := cmd.RegisterFlagCompletionFunc(flag, func(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return f(cmd, nil, toComplete)
+		}); err != nil {
+			oktetoLog.Infof("error registering completion of flag '%s': %s", flag, err)
+		}
+	}
+}
+
+// setCompletionContext selects the context of the '--context' flag, if any. It returns false if there is no context to use
+func setCompletionContext(cmd *cobra.Command) bool {
+	ctxStore := okteto.ContextStore()
+	if f := cmd.Flags().Lookup("context"); f != nil && f.Value.String() != "" {
+		name := f.Value.String()
+		if _, ok := ctxStore.Contexts[name]; !ok {
+			name = okteto.AddSchema(name)
+		}
+		if _, ok := ctxStore.Contexts[name]; !ok {
+			return false
+		}
+		ctxStore.CurrentContext = name
+	}
+	if ctxStore.CurrentContext == "" {
+		return false
+	}
+	_, ok := ctxStore.Contexts[ctxStore.CurrentContext]
+	return ok
+}
+
+func listOktetoNamespaces(ctx context.Context, okClient types.OktetoInterface) ([]string, error) {
+	spaces, err := okClient.Namespaces().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := []string{}
+	for _, space := range spaces {
+		result = append(result, space.ID)
+	}
+	return result, nil
+}
+
+func listK8sNamespaces(ctx context.Context) ([]string, error) {
+	c, _, err := okteto.NewK8sClientProvider().Provide(okteto.Context().Cfg)
+	if err != nil {
+		return nil, err
+	}
+	nsList, err := c.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	result := []string{}
+	for _, ns := range nsList.Items {
+		result = append(result, ns.Name)
+	}
+	return result, nil
+}
+
+func listPreviews(ctx context.Context, okClient types.OktetoInterface) ([]string, error) {
+	previews, err := okClient.Previews().List(ctx, []string{})
+	if err != nil {
+		return nil, err
+	}
+	result := []string{}
+	for _, preview := range previews {
+		result = append(result, preview.ID)
+	}
+	return result, nil
+}
+
+func getDevNames(manifest *model.Manifest) []string {
+	result := []string{}
+	for name := range manifest.Dev {
+		result = append(result, name)
+	}
+	return result
+}
+
+// filterCompletions returns the sorted values that start with toComplete
+func filterCompletions(values []string, toComplete string) []string {
+	result := []string{}
+	for _, v := range values {
+		if strings.HasPrefix(v, toComplete) {
+			result = append(result, v)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
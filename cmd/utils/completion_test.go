@@ -0,0 +1,135 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/internal/test/client"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompleteContexts(t *testing.T) {
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"https://okteto.example.com": {Name: "https://okteto.example.com"},
+			"https://cloud.okteto.com":   {Name: "https://cloud.okteto.com"},
+			"minikube":                   {Name: "minikube"},
+		},
+		CurrentContext: "minikube",
+	}
+
+	result, directive := CompleteContexts(&cobra.Command{}, nil, "")
+	assert.Equal(t, []string{"https://cloud.okteto.com", "https://okteto.example.com", "minikube"}, result)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	result, _ = CompleteContexts(&cobra.Command{}, nil, "https://ok")
+	assert.Equal(t, []string{"https://okteto.example.com"}, result)
+
+	result, _ = CompleteContexts(&cobra.Command{}, []string{"minikube"}, "")
+	assert.Empty(t, result)
+}
+
+func TestCompleteDevs(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "okteto.yml")
+	manifest := []byte(`dev:
+  api:
+    image: alpine
+  frontend:
+    image: alpine
+  worker:
+    image: alpine
+`)
+	require.NoError(t, os.WriteFile(manifestPath, manifest, 0600))
+
+	cmd := &cobra.Command{}
+	cmd.Flags().StringP("file", "f", "", "")
+	require.NoError(t, cmd.Flags().Set("file", manifestPath))
+
+	result, directive := CompleteDevs(cmd, nil, "")
+	assert.Equal(t, []string{"api", "frontend", "worker"}, result)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	result, _ = CompleteDevs(cmd, nil, "f")
+	assert.Equal(t, []string{"frontend"}, result)
+
+	require.NoError(t, cmd.Flags().Set("file", filepath.Join(dir, "missing.yml")))
+	result, _ = CompleteDevs(cmd, nil, "")
+	assert.Empty(t, result)
+}
+
+func TestListOktetoNamespaces(t *testing.T) {
+	okClient := &client.FakeOktetoClient{
+		Namespace: client.NewFakeNamespaceClient([]types.Namespace{{ID: "cindy"}, {ID: "staging"}}, nil),
+	}
+	result, err := listOktetoNamespaces(context.Background(), okClient)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cindy", "staging"}, result)
+
+	okClient.Namespace = client.NewFakeNamespaceClient(nil, assert.AnError)
+	_, err = listOktetoNamespaces(context.Background(), okClient)
+	assert.Error(t, err)
+}
+
+func TestListPreviews(t *testing.T) {
+	okClient := &client.FakeOktetoClient{
+		Preview: client.NewFakePreviewClient(&client.FakePreviewResponse{
+			PreviewList: []types.Preview{{ID: "pr-1"}, {ID: "pr-2"}},
+		}),
+	}
+	result, err := listPreviews(context.Background(), okClient)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pr-1", "pr-2"}, result)
+}
+
+func TestSetCompletionContext(t *testing.T) {
+	newStore := func() {
+		okteto.CurrentStore = &okteto.OktetoContextStore{
+			Contexts: map[string]*okteto.OktetoContext{
+				"https://okteto.example.com": {Name: "https://okteto.example.com", IsOkteto: true},
+				"minikube":                   {Name: "minikube"},
+			},
+			CurrentContext: "minikube",
+		}
+	}
+	var tests = []struct {
+		name            string
+		flag            string
+		expected        bool
+		expectedContext string
+	}{
+		{name: "current context", expected: true, expectedContext: "minikube"},
+		{name: "context flag", flag: "https://okteto.example.com", expected: true, expectedContext: "https://okteto.example.com"},
+		{name: "context flag without schema", flag: "okteto.example.com", expected: true, expectedContext: "https://okteto.example.com"},
+		{name: "unknown context", flag: "unknown", expected: false, expectedContext: "minikube"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newStore()
+			cmd := &cobra.Command{}
+			cmd.Flags().StringP("context", "c", "", "")
+			require.NoError(t, cmd.Flags().Set("context", tt.flag))
+			assert.Equal(t, tt.expected, setCompletionContext(cmd))
+			assert.Equal(t, tt.expectedContext, okteto.CurrentStore.CurrentContext)
+		})
+	}
+}
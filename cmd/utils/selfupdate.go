@@ -0,0 +1,199 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+var (
+	// errUnsignedBuild is returned when the okteto binary was built without the public key of the releases
+	errUnsignedBuild = errors.New("this okteto binary can't verify the signature of the okteto releases")
+)
+
+// GetReleaseBinaryName returns the name of the okteto binary of the releases for an OS and architecture
+func GetReleaseBinaryName(goos, goarch string) (string, error) {
+	switch {
+	case goos == "darwin" && goarch == "amd64":
+		return "okteto-Darwin-x86_64", nil
+	case goos == "darwin" && goarch == "arm64":
+		return "okteto-Darwin-arm64", nil
+	case goos == "linux" && goarch == "amd64":
+		return "okteto-Linux-x86_64", nil
+	case goos == "linux" && goarch == "arm64":
+		return "okteto-Linux-arm64", nil
+	case goos == "windows" && goarch == "amd64":
+		return "okteto.exe", nil
+	default:
+		return "", fmt.Errorf("there are no okteto releases for %s/%s", goos, goarch)
+	}
+}
+
+// SelfUpdate replaces the running okteto binary with a version of a release channel, once its checksum and signature are verified
+func SelfUpdate(ctx context.Context, channel, version string) error {
+	if err := config.CheckOnline("updating okteto"); err != nil {
+		return err
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to get the path of the okteto binary: %w", err)
+	}
+	executable, err = filepath.EvalSymlinks(executable)
+	if err != nil {
+		return fmt.Errorf("failed to get the path of the okteto binary: %w", err)
+	}
+	binaryName, err := GetReleaseBinaryName(runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return err
+	}
+
+	rc := newReleaseClient()
+	binary, err := rc.download(ctx, channel, version, binaryName)
+	if err != nil {
+		if errors.Is(err, errUnsignedBuild) {
+			return oktetoErrors.UserError{
+				E:    err,
+				Hint: fmt.Sprintf("Install the latest version with '%s'", GetUpgradeCommand()),
+			}
+		}
+		return err
+	}
+	return replaceExecutable(executable, binary)
+}
+
+// download returns the okteto binary of a version of a channel, once its checksum and signature are verified
+func (rc *releaseClient) download(ctx context.Context, channel, version, binaryName string) ([]byte, error) {
+	if rc.publicKey == "" {
+		return nil, errUnsignedBuild
+	}
+	url := fmt.Sprintf("%s/%s/%s/%s", rc.baseURL, channel, version, binaryName)
+	oktetoLog.Infof("downloading %s", url)
+	binary, err := rc.get(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download okteto %s: %w", version, err)
+	}
+	checksum, err := rc.get(ctx, fmt.Sprintf("%s.sha256", url))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download the checksum of okteto %s: %w", version, err)
+	}
+	signature, err := rc.get(ctx, fmt.Sprintf("%s.sha256.sig", url))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download the signature of okteto %s: %w", version, err)
+	}
+	if err := verifyRelease(binary, checksum, signature, rc.publicKey); err != nil {
+		return nil, fmt.Errorf("failed to verify okteto %s: %w", version, err)
+	}
+	return binary, nil
+}
+
+// verifyRelease checks that the checksum file is signed by the key of the releases and that it matches the binary
+func verifyRelease(binary, checksum, signature []byte, publicKey string) error {
+	key, err := parseReleasePublicKey(publicKey)
+	if err != nil {
+		return err
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+	if !ed25519.Verify(key, checksum, sig) {
+		return fmt.Errorf("the signature of the checksum is not valid")
+	}
+
+	fields := strings.Fields(string(checksum))
+	if len(fields) == 0 {
+		return fmt.Errorf("the checksum file is empty")
+	}
+	sum := sha256.Sum256(binary)
+	if !strings.EqualFold(fields[0], hex.EncodeToString(sum[:])) {
+		return fmt.Errorf("the checksum of the binary doesn't match")
+	}
+	return nil
+}
+
+// parseReleasePublicKey decodes a raw ed25519 public key or a PKIX one, as exported by openssl
+func parseReleasePublicKey(publicKey string) (ed25519.PublicKey, error) {
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(publicKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid release public key: %w", err)
+	}
+	if len(b) == ed25519.PublicKeySize {
+		return ed25519.PublicKey(b), nil
+	}
+	key, err := x509.ParsePKIXPublicKey(b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid release public key: %w", err)
+	}
+	edKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("invalid release public key: it's not an ed25519 key")
+	}
+	return edKey, nil
+}
+
+// replaceExecutable writes the binary next to the executable and renames it over the executable.
+// The running executable is moved aside first because windows doesn't allow to overwrite it
+func replaceExecutable(executable string, binary []byte) error {
+	dir := filepath.Dir(executable)
+	tmp, err := os.CreateTemp(dir, ".okteto-update-")
+	if err != nil {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("failed to write the new okteto binary into '%s': %w", dir, err),
+			Hint: "Run the command with permissions to write into the folder of the okteto binary",
+		}
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write the new okteto binary: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write the new okteto binary: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0755); err != nil {
+		return fmt.Errorf("failed to write the new okteto binary: %w", err)
+	}
+
+	old := fmt.Sprintf("%s.old", executable)
+	_ = os.Remove(old)
+	if err := os.Rename(executable, old); err != nil {
+		return fmt.Errorf("failed to replace the okteto binary: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), executable); err != nil {
+		if rollbackErr := os.Rename(old, executable); rollbackErr != nil {
+			oktetoLog.Infof("failed to restore the okteto binary: %s", rollbackErr)
+		}
+		return fmt.Errorf("failed to replace the okteto binary: %w", err)
+	}
+	if err := os.Remove(old); err != nil {
+		// windows doesn't allow to delete the running executable, it's deleted by the next update
+		oktetoLog.Infof("failed to delete the previous okteto binary: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signRelease(t *testing.T, key ed25519.PrivateKey, binary []byte) ([]byte, []byte) {
+	t.Helper()
+	sum := sha256.Sum256(binary)
+	checksum := []byte(fmt.Sprintf("%s  okteto-Linux-x86_64\n", hex.EncodeToString(sum[:])))
+	signature := []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(key, checksum)))
+	return checksum, signature
+}
+
+func TestVerifyRelease(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, otherPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	publicKey := base64.StdEncoding.EncodeToString(pub)
+
+	binary := []byte("okteto binary")
+	checksum, signature := signRelease(t, priv, binary)
+	assert.NoError(t, verifyRelease(binary, checksum, signature, publicKey))
+
+	assert.Error(t, verifyRelease([]byte("tampered binary"), checksum, signature, publicKey))
+
+	otherChecksum, otherSignature := signRelease(t, otherPriv, binary)
+	assert.Error(t, verifyRelease(binary, otherChecksum, otherSignature, publicKey))
+
+	assert.Error(t, verifyRelease(binary, checksum, []byte("not-base64!"), publicKey))
+	assert.Error(t, verifyRelease(binary, checksum, signature, "invalid"))
+}
+
+func TestParseReleasePublicKey(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	key, err := parseReleasePublicKey(base64.StdEncoding.EncodeToString(pub))
+	require.NoError(t, err)
+	assert.Equal(t, pub, key)
+
+	pkix, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+	key, err = parseReleasePublicKey(base64.StdEncoding.EncodeToString(pkix))
+	require.NoError(t, err)
+	assert.Equal(t, pub, key)
+
+	_, err = parseReleasePublicKey(base64.StdEncoding.EncodeToString([]byte("short")))
+	assert.Error(t, err)
+}
+
+func TestDownloadRelease(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	binary := []byte("okteto 2.15.0")
+	checksum, signature := signRelease(t, priv, binary)
+
+	files := map[string][]byte{
+		"/stable/2.15.0/okteto-Linux-x86_64":            binary,
+		"/stable/2.15.0/okteto-Linux-x86_64.sha256":     checksum,
+		"/stable/2.15.0/okteto-Linux-x86_64.sha256.sig": signature,
+	}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, ok := files[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(b)
+	}))
+	defer s.Close()
+
+	rc := &releaseClient{baseURL: s.URL, client: s.Client(), publicKey: base64.StdEncoding.EncodeToString(pub)}
+	result, err := rc.download(context.Background(), StableChannel, "2.15.0", "okteto-Linux-x86_64")
+	require.NoError(t, err)
+	assert.Equal(t, binary, result)
+
+	_, err = rc.download(context.Background(), StableChannel, "2.16.0", "okteto-Linux-x86_64")
+	assert.Error(t, err)
+
+	rc.publicKey = ""
+	_, err = rc.download(context.Background(), StableChannel, "2.15.0", "okteto-Linux-x86_64")
+	assert.ErrorIs(t, err, errUnsignedBuild)
+}
+
+func TestGetReleaseBinaryName(t *testing.T) {
+	name, err := GetReleaseBinaryName("linux", "arm64")
+	require.NoError(t, err)
+	assert.Equal(t, "okteto-Linux-arm64", name)
+
+	name, err = GetReleaseBinaryName("windows", "amd64")
+	require.NoError(t, err)
+	assert.Equal(t, "okteto.exe", name)
+
+	_, err = GetReleaseBinaryName("freebsd", "amd64")
+	assert.Error(t, err)
+}
+
+func TestReplaceExecutable(t *testing.T) {
+	executable := filepath.Join(t.TempDir(), "okteto")
+	require.NoError(t, os.WriteFile(executable, []byte("old"), 0755))
+
+	require.NoError(t, replaceExecutable(executable, []byte("new")))
+
+	b, err := os.ReadFile(executable)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(b))
+	assert.NoFileExists(t, executable+".old")
+	entries, err := os.ReadDir(filepath.Dir(executable))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
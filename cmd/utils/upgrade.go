@@ -25,6 +25,10 @@ import (
 )
 
 func UpgradeAvailable() string {
+	if config.IsOffline() {
+		return ""
+	}
+
 	current, err := semver.NewVersion(config.VersionString)
 	if err != nil {
 		return ""
@@ -54,6 +58,9 @@ func UpgradeAvailable() string {
 
 // GetLatestVersionFromGithub returns the latest okteto version from GitHub
 func GetLatestVersionFromGithub() (string, error) {
+	if err := config.CheckOnline("checking the latest okteto version"); err != nil {
+		return "", err
+	}
 	client := github.NewClient(nil)
 	ctx := context.Background()
 	releases, _, err := client.Repositories.ListReleases(ctx, "okteto", "okteto", &github.ListOptions{PerPage: 10})
@@ -100,3 +107,30 @@ func GetUpgradeCommand() string {
 
 	return `curl https://get.okteto.com -sSfL | sh`
 }
+
+// IsNewerVersionRequired returns true if the current version is older than the required one.
+// Development builds without a semantic version are never considered outdated
+func IsNewerVersionRequired(current, required string) bool {
+	if required == "" {
+		return false
+	}
+	currentVersion, err := semver.NewVersion(current)
+	if err != nil {
+		return false
+	}
+	requiredVersion, err := semver.NewVersion(required)
+	if err != nil {
+		oktetoLog.Infof("failed to parse required version '%s': %s", required, err)
+		return false
+	}
+	return currentVersion.LessThan(requiredVersion)
+}
+
+// WarnIfNewerVersionRequired warns when the okteto server requires a newer version of the okteto cli
+func WarnIfNewerVersionRequired(required string) {
+	if !IsNewerVersionRequired(config.VersionString, required) {
+		return
+	}
+	oktetoLog.Warning("Your okteto server requires okteto %s or newer, but you are using %s", required, config.VersionString)
+	oktetoLog.Hint("    Run 'okteto version update' to update it")
+}
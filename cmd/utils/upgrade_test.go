@@ -52,3 +52,24 @@ func Test_shouldNotify(t *testing.T) {
 		})
 	}
 }
+
+func TestIsNewerVersionRequired(t *testing.T) {
+	var tests = []struct {
+		current  string
+		required string
+		expected bool
+	}{
+		{current: "2.14.0", required: "", expected: false},
+		{current: "2.14.0", required: "2.15.0", expected: true},
+		{current: "2.15.0", required: "2.15.0", expected: false},
+		{current: "2.16.1", required: "2.15.0", expected: false},
+		{current: "2.15.0-rc.1", required: "2.15.0", expected: true},
+		{current: "dev", required: "2.15.0", expected: false},
+		{current: "2.14.0", required: "latest", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.current+"-"+tt.required, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsNewerVersionRequired(tt.current, tt.required))
+		})
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetVariables(t *testing.T) {
+	t.Setenv("OKTETO_TEST_VARIABLE", "")
+	require.NoError(t, SetVariables([]string{"OKTETO_TEST_VARIABLE=a=b"}))
+	assert.Equal(t, "a=b", os.Getenv("OKTETO_TEST_VARIABLE"))
+
+	assert.Error(t, SetVariables([]string{"INVALID"}))
+	assert.Error(t, SetVariables([]string{"=value"}))
+}
@@ -14,11 +14,16 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/spf13/cobra"
 )
@@ -32,30 +37,128 @@ func Version() *cobra.Command {
 		RunE:  Show().RunE,
 	}
 	cmd.AddCommand(Update())
+	cmd.AddCommand(Check())
 	cmd.AddCommand(Show())
 	return cmd
 }
 
-// Update checks if there is a new version available and updates it
+// UpdateOptions are the options of the update command
+type UpdateOptions struct {
+	Channel string
+	Version string
+}
+
+// Update updates okteto to the latest version of its release channel
 func Update() *cobra.Command {
-	return &cobra.Command{
+	options := &UpdateOptions{}
+	cmd := &cobra.Command{
 		Use:   "update",
 		Short: "Update Okteto CLI version",
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#version"),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			currentVersion, err := semver.NewVersion(config.VersionString)
-			if err != nil {
-				return fmt.Errorf("could not retrieve version")
-			}
+			return runUpdate(cmd.Context(), options)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Channel, "channel", "", "", fmt.Sprintf("release channel to update from and to save as the default one. One of: [%s]", strings.Join(utils.ReleaseChannels, ", ")))
+	cmd.Flags().StringVarP(&options.Version, "version", "", "", "version to install instead of the latest one of the release channel")
+	return cmd
+}
 
-			if isUpdateAvailable(currentVersion) {
-				displayUpdateSteps()
-			} else {
-				oktetoLog.Success("The latest okteto version is already installed")
-			}
+func runUpdate(ctx context.Context, options *UpdateOptions) error {
+	channel := utils.GetReleaseChannel()
+	if options.Channel != "" {
+		if err := utils.SetReleaseChannel(options.Channel); err != nil {
+			return err
+		}
+		channel = options.Channel
+	}
+
+	version := options.Version
+	if version == "" {
+		latest, err := utils.GetLatestVersionFromChannel(ctx, channel)
+		if err != nil {
+			return err
+		}
+		if !isUpdateAvailable(config.VersionString, latest) {
+			oktetoLog.Success("The latest okteto version of the %s channel is already installed", channel)
+			return nil
+		}
+		version = latest
+	}
+
+	oktetoLog.Spinner(fmt.Sprintf("Updating okteto to %s...", version))
+	oktetoLog.StartSpinner()
+	defer oktetoLog.StopSpinner()
+	if err := utils.SelfUpdate(ctx, channel, version); err != nil {
+		if uErr, ok := err.(oktetoErrors.UserError); ok {
+			return uErr
+		}
+		oktetoLog.StopSpinner()
+		displayUpdateSteps()
+		return err
+	}
+	oktetoLog.Success("Okteto updated to %s", version)
+	return nil
+}
+
+// CheckOptions are the options of the check command
+type CheckOptions struct {
+	Channel string
+}
 
+// Check shows if there is a newer version in the release channel
+func Check() *cobra.Command {
+	options := &CheckOptions{}
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check if there is a newer version of the Okteto CLI",
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#version"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			channel := utils.GetReleaseChannel()
+			if options.Channel != "" {
+				if err := utils.ValidateReleaseChannel(options.Channel); err != nil {
+					return err
+				}
+				channel = options.Channel
+			}
+			latest, err := utils.GetLatestVersionFromChannel(cmd.Context(), channel)
+			if err != nil {
+				return err
+			}
+			printVersionCheck(os.Stdout, config.VersionString, channel, latest)
 			return nil
 		},
 	}
+	cmd.Flags().StringVarP(&options.Channel, "channel", "", "", fmt.Sprintf("release channel to check instead of the default one. One of: [%s]", strings.Join(utils.ReleaseChannels, ", ")))
+	return cmd
+}
+
+func printVersionCheck(w io.Writer, current, channel, latest string) {
+	fmt.Fprintf(w, "Current version: %s\n", current)
+	fmt.Fprintf(w, "Latest version of the %s channel: %s\n", channel, latest)
+	if isUpdateAvailable(current, latest) {
+		fmt.Fprintf(w, "A new version is available. Run 'okteto version update' to install it\n")
+		return
+	}
+	fmt.Fprintf(w, "You are using the latest version\n")
+}
+
+// isUpdateAvailable checks if the latest version is newer than the current one. Development builds can always be updated
+func isUpdateAvailable(current, latest string) bool {
+	latestVersion, err := semver.NewVersion(latest)
+	if err != nil {
+		oktetoLog.Infof("failed to parse latest version '%s': %s", latest, err)
+		return false
+	}
+	currentVersion, err := semver.NewVersion(current)
+	if err != nil {
+		return true
+	}
+	if latestVersion.GreaterThan(currentVersion) {
+		oktetoLog.Infof("new version available: %s -> %s", currentVersion.String(), latestVersion)
+		return true
+	}
+	return false
 }
 
 // Show shows the current Okteto CLI version
@@ -0,0 +1,40 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintVersionCheck(t *testing.T) {
+	var buf bytes.Buffer
+	printVersionCheck(&buf, "2.14.0", "stable", "2.15.0")
+	assert.Equal(t, "Current version: 2.14.0\nLatest version of the stable channel: 2.15.0\nA new version is available. Run 'okteto version update' to install it\n", buf.String())
+
+	buf.Reset()
+	printVersionCheck(&buf, "2.15.0", "stable", "2.15.0")
+	assert.Contains(t, buf.String(), "You are using the latest version")
+}
+
+func TestIsUpdateAvailable(t *testing.T) {
+	assert.True(t, isUpdateAvailable("2.14.0", "2.15.0"))
+	assert.True(t, isUpdateAvailable("2.15.0-rc.1", "2.15.0"))
+	assert.True(t, isUpdateAvailable("dev", "2.15.0"))
+	assert.False(t, isUpdateAvailable("2.15.0", "2.15.0"))
+	assert.False(t, isUpdateAvailable("2.16.0", "2.15.0"))
+	assert.False(t, isUpdateAvailable("2.14.0", "not-a-version"))
+}
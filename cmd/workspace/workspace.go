@@ -0,0 +1,304 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/configmaps"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+// dependencyEnvTemplate is the name of the env vars with the variables exported to $OKTETO_ENV by the manifests of the workspace
+const dependencyEnvTemplate = "OKTETO_DEPENDENCY_%s_VARIABLE_%s"
+
+// Options defines the options shared by the workspace commands
+type Options struct {
+	File       string
+	Namespace  string
+	K8sContext string
+}
+
+// commandRunner runs an okteto command in the folder of a manifest
+type commandRunner interface {
+	run(ctx context.Context, dir string, args []string) error
+}
+
+// variablesGetter returns the variables exported to $OKTETO_ENV by a deployed manifest
+type variablesGetter func(ctx context.Context, name, namespace string) (map[string]string, error)
+
+// workspaceCommand runs okteto commands on the manifests of a workspace
+type workspaceCommand struct {
+	workspace    *model.Workspace
+	namespace    string
+	runner       commandRunner
+	getVariables variablesGetter
+	setEnv       func(key, value string) error
+}
+
+// Workspace runs okteto commands on all the okteto manifests of a monorepo
+func Workspace(ctx context.Context) *cobra.Command {
+	options := &Options{}
+	cmd := &cobra.Command{
+		Use:   "workspace",
+		Short: "Deploy, develop and destroy the okteto manifests of a monorepo",
+		Long: fmt.Sprintf(`Deploy, develop and destroy the okteto manifests of a monorepo.
+
+The manifests are listed in the '%s' file of the root folder of the monorepo, and they share the same namespace.
+The variables exported to $OKTETO_ENV by a manifest are available to the manifests that depend on it as OKTETO_DEPENDENCY_<NAME>_VARIABLE_<KEY>.`, model.WorkspaceFile),
+	}
+	cmd.PersistentFlags().StringVarP(&options.File, "file", "f", model.WorkspaceFile, "path to the workspace file")
+	cmd.PersistentFlags().StringVarP(&options.Namespace, "namespace", "n", "", "namespace where the manifests are deployed")
+	cmd.PersistentFlags().StringVarP(&options.K8sContext, "context", "c", "", "context where the manifests are deployed")
+
+	cmd.AddCommand(deploy(ctx, options))
+	cmd.AddCommand(up(ctx, options))
+	cmd.AddCommand(destroy(ctx, options))
+	return cmd
+}
+
+func deploy(ctx context.Context, options *Options) *cobra.Command {
+	var wait bool
+	cmd := &cobra.Command{
+		Use:   "deploy [manifest...]",
+		Short: "Deploy the manifests of the workspace and their dependencies",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wc, err := newWorkspaceCommand(ctx, options)
+			if err != nil {
+				return err
+			}
+			return wc.deploy(ctx, args, wait)
+		},
+	}
+	cmd.Flags().BoolVarP(&wait, "wait", "w", false, "wait until each manifest is deployed before deploying the manifests that depend on it")
+	return cmd
+}
+
+func up(ctx context.Context, options *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "up <manifest> [devContainer]",
+		Short: "Activate a development container of a manifest of the workspace",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wc, err := newWorkspaceCommand(ctx, options)
+			if err != nil {
+				return err
+			}
+			return wc.up(ctx, args[0], args[1:])
+		},
+	}
+	return cmd
+}
+
+func destroy(ctx context.Context, options *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "destroy [manifest...]",
+		Short: "Destroy the manifests of the workspace",
+		Long:  "Destroy the manifests of the workspace. The dependencies of the given manifests are not destroyed, since other manifests might depend on them",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wc, err := newWorkspaceCommand(ctx, options)
+			if err != nil {
+				return err
+			}
+			return wc.destroy(ctx, args)
+		},
+	}
+	return cmd
+}
+
+func newWorkspaceCommand(ctx context.Context, options *Options) (*workspaceCommand, error) {
+	w, err := model.GetWorkspaceFromFile(afero.NewOsFs(), options.File)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, oktetoErrors.UserError{
+				E:    fmt.Errorf("the workspace file '%s' doesn't exist", options.File),
+				Hint: fmt.Sprintf("Create a '%s' file listing the okteto manifests of your monorepo", model.WorkspaceFile),
+			}
+		}
+		return nil, err
+	}
+
+	if err := contextCMD.NewContextCommand().Run(ctx, &contextCMD.ContextOptions{Context: options.K8sContext, Namespace: options.Namespace}); err != nil {
+		return nil, err
+	}
+	c, _, err := okteto.GetK8sClient()
+	if err != nil {
+		return nil, err
+	}
+	return &workspaceCommand{
+		workspace:    w,
+		namespace:    okteto.Context().Namespace,
+		runner:       &oktetoRunner{},
+		getVariables: newVariablesGetter(c),
+		setEnv:       os.Setenv,
+	}, nil
+}
+
+// deploy deploys the manifests and their dependencies. The variables of each manifest are set before deploying the manifests that depend on it
+func (wc *workspaceCommand) deploy(ctx context.Context, names []string, wait bool) error {
+	order, err := wc.workspace.GetDeployOrder(names)
+	if err != nil {
+		return err
+	}
+	for _, name := range order {
+		m := wc.workspace.Manifests[name]
+		oktetoLog.Information("Deploying manifest '%s'...", name)
+		args := []string{"deploy", "--name", name, "--namespace", wc.namespace}
+		if m.File != "" {
+			args = append(args, "--file", m.File)
+		}
+		keys := make([]string, 0, len(m.Variables))
+		for key := range m.Variables {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			value, err := model.ExpandEnv(m.Variables[key], true)
+			if err != nil {
+				return fmt.Errorf("invalid variable '%s' of the manifest '%s': %w", key, name, err)
+			}
+			args = append(args, "--var", fmt.Sprintf("%s=%s", key, value))
+		}
+		if wait {
+			args = append(args, "--wait")
+		}
+		if err := wc.runner.run(ctx, wc.workspace.GetDir(name), args); err != nil {
+			return fmt.Errorf("could not deploy the manifest '%s': %w", name, err)
+		}
+		if err := wc.setDependencyEnvs(ctx, name); err != nil {
+			return err
+		}
+	}
+	oktetoLog.Success("Workspace successfully deployed")
+	return nil
+}
+
+// up activates a development container of a manifest with the variables of its deployed dependencies
+func (wc *workspaceCommand) up(ctx context.Context, name string, args []string) error {
+	order, err := wc.workspace.GetDeployOrder([]string{name})
+	if err != nil {
+		return err
+	}
+	for _, dependency := range order {
+		if dependency == name {
+			continue
+		}
+		if err := wc.setDependencyEnvs(ctx, dependency); err != nil {
+			return oktetoErrors.UserError{
+				E:    err,
+				Hint: fmt.Sprintf("Run 'okteto workspace deploy %s' to deploy the manifest and its dependencies", name),
+			}
+		}
+	}
+	upArgs := []string{"up", "--namespace", wc.namespace}
+	if m := wc.workspace.Manifests[name]; m.File != "" {
+		upArgs = append(upArgs, "--file", m.File)
+	}
+	upArgs = append(upArgs, args...)
+	return wc.runner.run(ctx, wc.workspace.GetDir(name), upArgs)
+}
+
+// destroy destroys the manifests before their dependencies
+func (wc *workspaceCommand) destroy(ctx context.Context, names []string) error {
+	order, err := wc.workspace.GetDestroyOrder(names)
+	if err != nil {
+		return err
+	}
+	for _, name := range order {
+		oktetoLog.Information("Destroying manifest '%s'...", name)
+		args := []string{"destroy", "--name", name, "--namespace", wc.namespace}
+		if m := wc.workspace.Manifests[name]; m.File != "" {
+			args = append(args, "--file", m.File)
+		}
+		if err := wc.runner.run(ctx, wc.workspace.GetDir(name), args); err != nil {
+			return fmt.Errorf("could not destroy the manifest '%s': %w", name, err)
+		}
+	}
+	oktetoLog.Success("Workspace successfully destroyed")
+	return nil
+}
+
+// setDependencyEnvs sets the variables exported by a deployed manifest as env vars of the next commands
+func (wc *workspaceCommand) setDependencyEnvs(ctx context.Context, name string) error {
+	variables, err := wc.getVariables(ctx, name, wc.namespace)
+	if err != nil {
+		return fmt.Errorf("could not get the variables of the manifest '%s': %w", name, err)
+	}
+	keys := make([]string, 0, len(variables))
+	for key := range variables {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := wc.setEnv(dependencyEnvName(name, key), variables[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dependencyEnvName returns the name of the env var of a variable exported by a manifest
+func dependencyEnvName(name, key string) string {
+	return fmt.Sprintf(dependencyEnvTemplate, strings.ToUpper(strings.ReplaceAll(name, "-", "_")), key)
+}
+
+// newVariablesGetter returns the variables stored in the configmap of a deployed manifest
+func newVariablesGetter(c kubernetes.Interface) variablesGetter {
+	return func(ctx context.Context, name, namespace string) (map[string]string, error) {
+		cmap, err := configmaps.Get(ctx, pipeline.TranslatePipelineName(name), namespace, c)
+		if err != nil {
+			return nil, err
+		}
+		return pipeline.GetDependencyEnvs(cmap)
+	}
+}
+
+// oktetoRunner runs the okteto binary attached to the terminal
+type oktetoRunner struct{}
+
+func (*oktetoRunner) run(ctx context.Context, dir string, args []string) error {
+	bin, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	oktetoLog.Infof("running 'okteto %s' in %s", strings.Join(args, " "), dir)
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Dir = dir
+	cmd.Env = os.Environ()
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("'okteto %s' exited with code %d", args[0], exitErr.ExitCode())
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type runnerCall struct {
+	dir  string
+	args []string
+}
+
+type fakeRunner struct {
+	calls  []runnerCall
+	failOn string
+}
+
+func (f *fakeRunner) run(_ context.Context, dir string, args []string) error {
+	f.calls = append(f.calls, runnerCall{dir: dir, args: args})
+	if f.failOn != "" && filepath.Base(dir) == f.failOn {
+		return assert.AnError
+	}
+	return nil
+}
+
+func newTestWorkspaceCommand(t *testing.T, variables map[string]map[string]string) (*workspaceCommand, *fakeRunner, string) {
+	t.Helper()
+	dir := t.TempDir()
+	for _, d := range []string{"api", "db", "frontend"} {
+		require.NoError(t, os.Mkdir(filepath.Join(dir, d), 0700))
+	}
+	content := `manifests:
+  frontend:
+    path: frontend
+    depends_on: [api]
+  api:
+    path: api
+    file: okteto.api.yml
+    depends_on: [db]
+    variables:
+      DB_HOST: ${OKTETO_DEPENDENCY_DB_VARIABLE_HOST}
+  db:
+    path: db
+`
+	path := filepath.Join(dir, model.WorkspaceFile)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	w, err := model.GetWorkspaceFromFile(afero.NewOsFs(), path)
+	require.NoError(t, err)
+
+	runner := &fakeRunner{}
+	return &workspaceCommand{
+		workspace: w,
+		namespace: "ns",
+		runner:    runner,
+		getVariables: func(_ context.Context, name, _ string) (map[string]string, error) {
+			v, ok := variables[name]
+			if !ok {
+				return nil, assert.AnError
+			}
+			return v, nil
+		},
+		setEnv: func(key, value string) error {
+			t.Setenv(key, value)
+			return nil
+		},
+	}, runner, dir
+}
+
+func Test_deploy(t *testing.T) {
+	wc, runner, dir := newTestWorkspaceCommand(t, map[string]map[string]string{
+		"db":  {"HOST": "db.ns.svc"},
+		"api": {},
+	})
+	require.NoError(t, wc.deploy(context.Background(), []string{"api"}, true))
+	assert.Equal(t, []runnerCall{
+		{dir: filepath.Join(dir, "db"), args: []string{"deploy", "--name", "db", "--namespace", "ns", "--wait"}},
+		{dir: filepath.Join(dir, "api"), args: []string{"deploy", "--name", "api", "--namespace", "ns", "--file", "okteto.api.yml", "--var", "DB_HOST=db.ns.svc", "--wait"}},
+	}, runner.calls)
+	assert.Equal(t, "db.ns.svc", os.Getenv("OKTETO_DEPENDENCY_DB_VARIABLE_HOST"))
+}
+
+func Test_deployFails(t *testing.T) {
+	wc, runner, _ := newTestWorkspaceCommand(t, map[string]map[string]string{"db": {}})
+	runner.failOn = "db"
+	err := wc.deploy(context.Background(), nil, false)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Len(t, runner.calls, 1)
+}
+
+func Test_up(t *testing.T) {
+	wc, runner, dir := newTestWorkspaceCommand(t, map[string]map[string]string{
+		"db":  {"HOST": "db.ns.svc"},
+		"api": {"URL": "https://api.ns.dev"},
+	})
+	require.NoError(t, wc.up(context.Background(), "frontend", []string{"web"}))
+	assert.Equal(t, []runnerCall{
+		{dir: filepath.Join(dir, "frontend"), args: []string{"up", "--namespace", "ns", "web"}},
+	}, runner.calls)
+	assert.Equal(t, "https://api.ns.dev", os.Getenv("OKTETO_DEPENDENCY_API_VARIABLE_URL"))
+}
+
+func Test_upDependencyNotDeployed(t *testing.T) {
+	wc, runner, _ := newTestWorkspaceCommand(t, map[string]map[string]string{})
+	err := wc.up(context.Background(), "api", nil)
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+	assert.Empty(t, runner.calls)
+}
+
+func Test_destroy(t *testing.T) {
+	wc, runner, dir := newTestWorkspaceCommand(t, nil)
+	require.NoError(t, wc.destroy(context.Background(), nil))
+	assert.Equal(t, []runnerCall{
+		{dir: filepath.Join(dir, "frontend"), args: []string{"destroy", "--name", "frontend", "--namespace", "ns"}},
+		{dir: filepath.Join(dir, "api"), args: []string{"destroy", "--name", "api", "--namespace", "ns", "--file", "okteto.api.yml"}},
+		{dir: filepath.Join(dir, "db"), args: []string{"destroy", "--name", "db", "--namespace", "ns"}},
+	}, runner.calls)
+}
+
+func Test_dependencyEnvName(t *testing.T) {
+	assert.Equal(t, "OKTETO_DEPENDENCY_MY_API_VARIABLE_URL", dependencyEnvName("my-api", "URL"))
+}
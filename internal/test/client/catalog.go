@@ -0,0 +1,39 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+
+	"github.com/okteto/okteto/pkg/types"
+)
+
+// FakeCatalogClient mocks the catalog client interface
+type FakeCatalogClient struct {
+	Items []types.CatalogItem
+	Err   error
+}
+
+// NewFakeCatalogClient returns a new fake catalog client
+func NewFakeCatalogClient(items []types.CatalogItem, err error) *FakeCatalogClient {
+	return &FakeCatalogClient{
+		Items: items,
+		Err:   err,
+	}
+}
+
+// List returns the items of the fake catalog
+func (c *FakeCatalogClient) List(_ context.Context) ([]types.CatalogItem, error) {
+	return c.Items, c.Err
+}
@@ -38,6 +38,11 @@ func (c *FakeKubetokenClient) GetKubeToken(_, _ string) (types.KubeTokenResponse
 	return c.response.Token, c.response.Err
 }
 
+// GetExecToken returns a temp token
+func (c *FakeKubetokenClient) GetExecToken(_, _, _, _ string) (types.KubeTokenResponse, error) {
+	return c.response.Token, c.response.Err
+}
+
 // CheckService returns a temp token
 func (c *FakeKubetokenClient) CheckService(_, _ string) error {
 	return c.response.Err
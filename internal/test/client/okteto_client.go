@@ -40,6 +40,7 @@ type FakeOktetoClient struct {
 	PipelineClient  types.PipelineInterface
 	StreamClient    types.StreamInterface
 	KubetokenClient types.KubetokenInterface
+	CatalogClient   types.CatalogInterface
 }
 
 func NewFakeOktetoClient() *FakeOktetoClient {
@@ -75,3 +76,8 @@ func (c *FakeOktetoClient) Stream() types.StreamInterface {
 func (c *FakeOktetoClient) Kubetoken() types.KubetokenInterface {
 	return c.KubetokenClient
 }
+
+// Catalog retrieves the Catalog client
+func (c *FakeOktetoClient) Catalog() types.CatalogInterface {
+	return c.CatalogClient
+}
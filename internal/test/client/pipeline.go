@@ -37,6 +37,12 @@ type FakePipelineResponses struct {
 	ResourcesMap map[string]string
 	ResourceErr  error
 
+	GitDeploy    *types.GitDeploy
+	GitDeployErr error
+
+	DefaultManifest    []byte
+	DefaultManifestErr error
+
 	CallCount int
 }
 
@@ -70,11 +76,16 @@ func (fc *FakePipelineClient) GetResourcesStatus(_ context.Context, _, _ string)
 }
 
 // GetByName returns the name of the pipeline
-func (*FakePipelineClient) GetByName(_ context.Context, _, _ string) (*types.GitDeploy, error) {
-	return nil, nil
+func (fc *FakePipelineClient) GetByName(_ context.Context, _, _ string) (*types.GitDeploy, error) {
+	return fc.responses.GitDeploy, fc.responses.GitDeployErr
 }
 
 // WaitForActionProgressing waits for a pipeline to start progressing
 func (fc *FakePipelineClient) WaitForActionProgressing(_ context.Context, _, _, _ string, _ time.Duration) error {
 	return fc.responses.WaitErr
 }
+
+// GetDefaultManifest returns the default manifest of a repository
+func (fc *FakePipelineClient) GetDefaultManifest(_ context.Context, _, _ string) ([]byte, error) {
+	return fc.responses.DefaultManifest, fc.responses.DefaultManifestErr
+}
@@ -22,6 +22,7 @@ import (
 type FakeUserClient struct {
 	userCtx           *types.UserContext
 	userSecrets       []types.Secret
+	namespaceSecrets  []types.Secret
 	err               []error
 	errGetUserSecrets error
 }
@@ -53,6 +54,10 @@ func (c *FakeUserClient) GetUserSecrets(_ context.Context) ([]types.Secret, erro
 	return c.userSecrets, nil
 }
 
+func (c *FakeUserClient) GetNamespaceSecrets(_ context.Context, _ string) ([]types.Secret, error) {
+	return c.namespaceSecrets, nil
+}
+
 func (*FakeUserClient) GetClusterCertificate(_ context.Context, _, _ string) ([]byte, error) {
 	return nil, nil
 }
@@ -26,23 +26,38 @@ import (
 
 	"github.com/okteto/okteto/cmd"
 	"github.com/okteto/okteto/cmd/build"
+	"github.com/okteto/okteto/cmd/catalog"
+	costCMD "github.com/okteto/okteto/cmd/cost"
 	contextCMD "github.com/okteto/okteto/cmd/context"
 	"github.com/okteto/okteto/cmd/deploy"
 	"github.com/okteto/okteto/cmd/destroy"
+	"github.com/okteto/okteto/cmd/env"
+	"github.com/okteto/okteto/cmd/divert"
+	"github.com/okteto/okteto/cmd/ideserver"
+	"github.com/okteto/okteto/cmd/ignore"
+	"github.com/okteto/okteto/cmd/images"
 	"github.com/okteto/okteto/cmd/kubetoken"
 	"github.com/okteto/okteto/cmd/logs"
 	"github.com/okteto/okteto/cmd/namespace"
 	"github.com/okteto/okteto/cmd/pipeline"
 	"github.com/okteto/okteto/cmd/preview"
+	"github.com/okteto/okteto/cmd/registry"
 	"github.com/okteto/okteto/cmd/registrytoken"
+	"github.com/okteto/okteto/cmd/run"
 	"github.com/okteto/okteto/cmd/stack"
+	syncCMD "github.com/okteto/okteto/cmd/sync"
+	"github.com/okteto/okteto/cmd/test"
 	"github.com/okteto/okteto/cmd/up"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/cmd/workspace"
 	"github.com/okteto/okteto/pkg/analytics"
 	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/constants"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/signals"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	utilRuntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -94,6 +109,8 @@ func main() {
 	var logLevel string
 	var outputMode string
 	var serverNameOverride string
+	var offline bool
+	var strictEnv bool
 
 	if err := analytics.Init(); err != nil {
 		oktetoLog.Infof("error initializing okteto analytics: %s", err)
@@ -110,9 +127,25 @@ func main() {
 			ccmd.SilenceUsage = true
 			if !registrytoken.IsRegistryCredentialHelperCommand(os.Args) {
 				oktetoLog.SetLevel(logLevel)
-				oktetoLog.SetOutputFormat(outputMode)
+				if ccmd.Flags().Changed("log-output") {
+					oktetoLog.SetExplicitOutputFormat(outputMode)
+				} else {
+					oktetoLog.SetOutputFormat(outputMode)
+				}
 			}
 			okteto.SetServerNameOverride(serverNameOverride)
+			if offline {
+				// the env var is also inherited by the okteto commands executed by this one
+				if err := os.Setenv(constants.OktetoOfflineEnvVar, "true"); err != nil {
+					oktetoLog.Infof("failed to set %s: %s", constants.OktetoOfflineEnvVar, err)
+				}
+			}
+			if strictEnv {
+				// the env var is also inherited by the okteto commands executed by this one
+				if err := os.Setenv(constants.OktetoStrictEnvEnvVar, "true"); err != nil {
+					oktetoLog.Infof("failed to set %s: %s", constants.OktetoStrictEnvEnvVar, err)
+				}
+			}
 			oktetoLog.Infof("started %s", strings.Join(os.Args, " "))
 		},
 		PersistentPostRun: func(ccmd *cobra.Command, args []string) {
@@ -121,8 +154,10 @@ func main() {
 	}
 
 	root.PersistentFlags().StringVarP(&logLevel, "log-level", "l", "warn", "amount of information outputted (debug, info, warn, error)")
-	root.PersistentFlags().StringVar(&outputMode, "log-output", oktetoLog.TTYFormat, "output format for logs (tty, plain, json)")
+	root.PersistentFlags().StringVar(&outputMode, "log-output", oktetoLog.TTYFormat, "output format for logs (tty, plain, json). If it's not set, the OKTETO_LOG_FORMAT env var (plain, json) writes timestamped lines without colors for CI systems")
 
+	root.PersistentFlags().BoolVar(&offline, "offline", false, "air-gapped mode: skip version checks, analytics and external downloads")
+	root.PersistentFlags().BoolVar(&strictEnv, "strict-env", false, "fail loading the manifest when it references an undefined variable instead of expanding it to empty. Every expansion is reported with --log-level=info")
 	root.PersistentFlags().StringVarP(&serverNameOverride, "server-name", "", "", "The address and port of the Okteto Ingress server")
 	err := root.PersistentFlags().MarkHidden("server-name")
 	if err != nil {
@@ -133,6 +168,8 @@ func main() {
 	at := analytics.NewAnalyticsTracker()
 
 	root.AddCommand(cmd.Analytics())
+	root.AddCommand(cmd.Metrics())
+	root.AddCommand(cmd.Audit())
 	root.AddCommand(cmd.Version())
 	root.AddCommand(cmd.Login())
 
@@ -141,22 +178,41 @@ func main() {
 
 	root.AddCommand(kubetoken.NewKubetokenCmd().Cmd())
 	root.AddCommand(registrytoken.RegistryToken(ctx))
+	root.AddCommand(registry.Registry(ctx))
+	root.AddCommand(images.Images(ctx))
 
 	root.AddCommand(build.Build(ctx, at))
+	root.AddCommand(build.Cache(ctx))
+	root.AddCommand(ignore.Ignore())
 
 	root.AddCommand(namespace.Namespace(ctx))
 	root.AddCommand(cmd.Init())
+	root.AddCommand(cmd.Generate())
+	root.AddCommand(cmd.Manifest())
 	root.AddCommand(up.Up(at))
 	root.AddCommand(cmd.Down())
 	root.AddCommand(cmd.Status())
 	root.AddCommand(cmd.Doctor())
 	root.AddCommand(cmd.Exec())
 	root.AddCommand(preview.Preview(ctx))
+	root.AddCommand(catalog.Catalog(ctx))
+	root.AddCommand(env.Env(ctx))
+	root.AddCommand(costCMD.Cost(ctx))
 	root.AddCommand(cmd.Restart())
+	root.AddCommand(cmd.RunJob())
 	root.AddCommand(cmd.UpdateDeprecated())
 	root.AddCommand(deploy.Deploy(ctx, at))
+	root.AddCommand(cmd.Diff())
+	root.AddCommand(cmd.Graph())
 	root.AddCommand(destroy.Destroy(ctx, at))
+	root.AddCommand(test.Test(ctx))
+	root.AddCommand(run.Run(ctx))
+	root.AddCommand(workspace.Workspace(ctx))
+	root.AddCommand(ideserver.IDEServer(ctx))
+	root.AddCommand(syncCMD.Sync())
 	root.AddCommand(deploy.Endpoints(ctx))
+	root.AddCommand(divert.Divert(ctx))
+	root.AddCommand(stack.Compose())
 	root.AddCommand(logs.Logs(ctx))
 	root.AddCommand(generateFigSpec.NewCmdGenFigSpec())
 
@@ -168,9 +224,16 @@ func main() {
 	root.AddCommand(cmd.Push(ctx))
 	root.AddCommand(pipeline.Pipeline(ctx))
 
+	utils.RegisterFlagCompletions(root)
+
 	err = root.Execute()
+	okteto.LogK8sClientMetrics()
+
+	// release the resources that were not cleaned up by the regular execution of the command
+	signals.RunCleanups()
 
 	if err != nil {
+		err = okteto.ExplainForbidden(err)
 		message := err.Error()
 		if len(message) > 0 {
 			tmp := []rune(message)
@@ -183,6 +246,6 @@ func main() {
 				oktetoLog.Hint("    %s", uErr.Hint)
 			}
 		}
-		os.Exit(1)
+		os.Exit(oktetoErrors.GetExitCode(err))
 	}
 }
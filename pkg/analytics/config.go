@@ -143,19 +143,71 @@ func (a *Analytics) save() error {
 	return nil
 }
 
-// Disable disables analytics
+// Disable disables analytics. The preference is saved in the okteto context config
 func Disable() error {
 	a := get()
 	a.Enabled = false
 	trackDisable(true)
-	return a.save()
+	if err := a.save(); err != nil {
+		return err
+	}
+	return saveContextPreference(true)
 }
 
-// Enable enables analytics
+// Enable enables analytics. The preference is saved in the okteto context config
 func Enable() error {
 	a := get()
 	a.Enabled = true
-	return a.save()
+	if err := a.save(); err != nil {
+		return err
+	}
+	return saveContextPreference(false)
+}
+
+func saveContextPreference(disabled bool) error {
+	ctxStore := okteto.ContextStore()
+	if ctxStore.DisableAnalytics == disabled {
+		return nil
+	}
+	ctxStore.DisableAnalytics = disabled
+	return okteto.NewContextConfigWriter().Write()
+}
+
+// Status represents if analytics are sent and why not
+type Status struct {
+	Enabled   bool
+	Reason    string
+	MachineID string
+}
+
+// GetStatus returns if analytics are sent by this machine
+func GetStatus() Status {
+	reason := disabledReason()
+	return Status{
+		Enabled:   reason == "",
+		Reason:    reason,
+		MachineID: get().MachineID,
+	}
+}
+
+// disabledReason returns why analytics aren't sent, or an empty string if they are
+func disabledReason() string {
+	if !get().Enabled || okteto.ContextStore().DisableAnalytics {
+		return "analytics has been disabled"
+	}
+
+	if config.IsOffline() {
+		return "okteto is running in offline mode"
+	}
+
+	if !okteto.IsContextInitialized() {
+		return "okteto context not initialized"
+	}
+
+	if disabledByOktetoAdmin() {
+		return "analytics disabled by admin"
+	}
+	return ""
 }
 
 func getTrackID() string {
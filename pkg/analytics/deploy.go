@@ -21,6 +21,27 @@ import (
 
 const deployEvent = "Deploy"
 
+// deployProperties are the properties of the Deploy event
+var (
+	pipelineTypeProperty   = property[model.Archetype]{"pipelineType"}
+	deployDurationProperty = property[float64]{"duration"}
+	deployTypeProperty     = property[string]{"deployType"}
+	isPreviewProperty      = property[bool]{"isPreview"}
+	deployErrorProperty    = property[string]{"error"}
+
+	deployProperties = []propertyDefinition{
+		pipelineTypeProperty,
+		isOktetoRepositoryProperty,
+		deployDurationProperty,
+		deployTypeProperty,
+		isPreviewProperty,
+		hasDependenciesSectionProperty,
+		hasBuildSectionProperty,
+		isRemoteProperty,
+		deployErrorProperty,
+	}
+)
+
 // DeployMetadata contains the metadata of a deploy event
 type DeployMetadata struct {
 	Success                bool
@@ -40,18 +61,17 @@ func (a *AnalyticsTracker) TrackDeploy(metadata DeployMetadata) {
 	if metadata.PipelineType == "" {
 		metadata.PipelineType = "pipeline"
 	}
-	props := map[string]any{
-		"pipelineType":           metadata.PipelineType,
-		"isOktetoRepository":     metadata.IsOktetoRepo,
-		"duration":               metadata.Duration.Seconds(),
-		"deployType":             metadata.DeployType,
-		"isPreview":              metadata.IsPreview,
-		"hasDependenciesSection": metadata.HasDependenciesSection,
-		"hasBuildSection":        metadata.HasBuildSection,
-		"isRemote":               metadata.IsRemote,
-	}
+	props := map[string]any{}
+	pipelineTypeProperty.set(props, metadata.PipelineType)
+	isOktetoRepositoryProperty.set(props, metadata.IsOktetoRepo)
+	deployDurationProperty.set(props, metadata.Duration.Seconds())
+	deployTypeProperty.set(props, metadata.DeployType)
+	isPreviewProperty.set(props, metadata.IsPreview)
+	hasDependenciesSectionProperty.set(props, metadata.HasDependenciesSection)
+	hasBuildSectionProperty.set(props, metadata.HasBuildSection)
+	isRemoteProperty.set(props, metadata.IsRemote)
 	if metadata.Err != nil {
-		props["error"] = metadata.Err.Error()
+		deployErrorProperty.set(props, metadata.Err.Error())
 	}
 	a.trackFn(deployEvent, metadata.Success, props)
 }
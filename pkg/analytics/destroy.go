@@ -13,6 +13,16 @@
 
 package analytics
 
+// destroyProperties are the properties of the Destroy event
+var (
+	isDestroyAllProperty = property[bool]{"isDestroyAll"}
+
+	destroyProperties = []propertyDefinition{
+		isDestroyAllProperty,
+		isRemoteProperty,
+	}
+)
+
 // DestroyMetadata contains the metadata of a destroy event
 type DestroyMetadata struct {
 	Success      bool
@@ -22,9 +32,8 @@ type DestroyMetadata struct {
 
 // TrackDestroy sends a tracking event to mixpanel when the user destroys a pipeline from local
 func (a *AnalyticsTracker) TrackDestroy(metadata DestroyMetadata) {
-	props := map[string]any{
-		"isDestroyAll": metadata.IsDestroyAll,
-		"isRemote":     metadata.IsRemote,
-	}
+	props := map[string]any{}
+	isDestroyAllProperty.set(props, metadata.IsDestroyAll)
+	isRemoteProperty.set(props, metadata.IsRemote)
 	a.trackFn(destroyEvent, metadata.Success, props)
 }
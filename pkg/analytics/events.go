@@ -0,0 +1,99 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analytics
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// maxRecordedEvents is the number of sent events kept to be inspected with 'okteto analytics show-last'
+const maxRecordedEvents = 50
+
+var eventsMu sync.Mutex
+
+// RecordedEvent is an analytics event sent by this machine
+type RecordedEvent struct {
+	Event      string                 `json:"event"`
+	DistinctID string                 `json:"distinctID"`
+	Time       time.Time              `json:"time"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// recordEvent saves a sent event with the exact properties that were sent
+func recordEvent(event, distinctID string, props map[string]interface{}) {
+	eventsMu.Lock()
+	defer eventsMu.Unlock()
+
+	events, err := readEvents()
+	if err != nil {
+		oktetoLog.Infof("discarding the analytics events sent: %s", err)
+		events = nil
+	}
+	events = append(events, RecordedEvent{
+		Event:      event,
+		DistinctID: distinctID,
+		Time:       time.Now().UTC(),
+		Properties: props,
+	})
+	if len(events) > maxRecordedEvents {
+		events = events[len(events)-maxRecordedEvents:]
+	}
+
+	marshalled, err := json.MarshalIndent(events, "", "\t")
+	if err != nil {
+		oktetoLog.Infof("failed to record the analytics event: %s", err)
+		return
+	}
+	if err := os.WriteFile(config.GetAnalyticsEventsPath(), marshalled, 0600); err != nil {
+		oktetoLog.Infof("failed to record the analytics event: %s", err)
+	}
+}
+
+// GetLastEvents returns the last n events sent by this machine, the most recent last
+func GetLastEvents(n int) ([]RecordedEvent, error) {
+	eventsMu.Lock()
+	defer eventsMu.Unlock()
+
+	events, err := readEvents()
+	if err != nil {
+		return nil, err
+	}
+	if n > 0 && len(events) > n {
+		events = events[len(events)-n:]
+	}
+	return events, nil
+}
+
+func readEvents() ([]RecordedEvent, error) {
+	b, err := os.ReadFile(config.GetAnalyticsEventsPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read the analytics events: %w", err)
+	}
+
+	var events []RecordedEvent
+	if err := json.Unmarshal(b, &events); err != nil {
+		return nil, fmt.Errorf("failed to read the analytics events: %w", err)
+	}
+	return events, nil
+}
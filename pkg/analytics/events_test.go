@@ -0,0 +1,97 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analytics
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordEvent(t *testing.T) {
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+
+	events, err := GetLastEvents(5)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	for i := 0; i < maxRecordedEvents+2; i++ {
+		recordEvent(fmt.Sprintf("event-%d", i), "machine", map[string]interface{}{"index": i})
+	}
+
+	events, err = GetLastEvents(2)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, fmt.Sprintf("event-%d", maxRecordedEvents), events[0].Event)
+	assert.Equal(t, fmt.Sprintf("event-%d", maxRecordedEvents+1), events[1].Event)
+	assert.Equal(t, "machine", events[1].DistinctID)
+	assert.Equal(t, map[string]interface{}{"index": float64(maxRecordedEvents + 1)}, events[1].Properties)
+
+	events, err = GetLastEvents(0)
+	require.NoError(t, err)
+	assert.Len(t, events, maxRecordedEvents)
+}
+
+func TestGetLastEventsCorruptedFile(t *testing.T) {
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+	require.NoError(t, os.WriteFile(config.GetAnalyticsEventsPath(), []byte("{"), 0600))
+
+	_, err := GetLastEvents(5)
+	assert.Error(t, err)
+
+	// a corrupted file is replaced by the next event
+	recordEvent("event", "machine", nil)
+	events, err := GetLastEvents(5)
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+}
+
+func TestEnableDisable(t *testing.T) {
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+	previousAnalytics, previousStore := currentAnalytics, okteto.CurrentStore
+	currentAnalytics = &Analytics{Enabled: true, MachineID: "machine"}
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		CurrentContext: "test",
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {Name: "test", Analytics: true},
+		},
+	}
+	defer func() {
+		currentAnalytics = previousAnalytics
+		okteto.CurrentStore = previousStore
+	}()
+
+	assert.Equal(t, Status{Enabled: true, MachineID: "machine"}, GetStatus())
+
+	require.NoError(t, Disable())
+	assert.True(t, okteto.CurrentStore.DisableAnalytics)
+	assert.Equal(t, Status{Enabled: false, Reason: "analytics has been disabled", MachineID: "machine"}, GetStatus())
+	b, err := os.ReadFile(config.GetOktetoContextsStorePath())
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"disableAnalytics": true`)
+
+	require.NoError(t, Enable())
+	assert.False(t, okteto.CurrentStore.DisableAnalytics)
+	assert.True(t, GetStatus().Enabled)
+
+	// the preference of the context config disables analytics even if the analytics file enables them
+	okteto.CurrentStore.DisableAnalytics = true
+	assert.False(t, GetStatus().Enabled)
+}
@@ -18,31 +18,78 @@ type ImageBuildMetadata struct {
 	CacheHit                 bool
 	CacheHitDuration         time.Duration
 	BuildDuration            time.Duration
-	Success                  bool
+	ScanDuration             time.Duration
+	// PushDuration is only set when the image is pushed in the background after the build
+	PushDuration    time.Duration
+	Vulnerabilities map[string]int
+	ScanFailed      bool
+	Success         bool
 }
 
+// imageBuildProperties are the properties of the imageBuild event
+var (
+	imageNameProperty                = property[string]{"name"}
+	repoURLProperty                  = property[string]{"repoURL"}
+	repoHashProperty                 = property[string]{"repoHash"}
+	repoHashDurationProperty         = property[float64]{"repoHashDurationSeconds"}
+	cacheHitProperty                 = property[bool]{"cacheHit"}
+	cacheHitDurationProperty         = property[float64]{"cacheHitDurationSeconds"}
+	buildDurationProperty            = property[float64]{"buildDurationSeconds"}
+	buildContextHashProperty         = property[string]{"buildContextHash"}
+	buildContextHashDurationProperty = property[float64]{"buildContextHashDurationSeconds"}
+	scanDurationProperty             = property[float64]{"scanDurationSeconds"}
+	pushDurationProperty             = property[float64]{"pushDurationSeconds"}
+	scanFailedProperty               = property[bool]{"scanFailed"}
+	scanVulnerabilitiesProperty      = property[map[string]int]{"scanVulnerabilities"}
+
+	imageBuildProperties = []propertyDefinition{
+		imageNameProperty,
+		repoURLProperty,
+		repoHashProperty,
+		repoHashDurationProperty,
+		cacheHitProperty,
+		cacheHitDurationProperty,
+		buildDurationProperty,
+		buildContextHashProperty,
+		buildContextHashDurationProperty,
+		scanDurationProperty,
+		pushDurationProperty,
+		scanFailedProperty,
+		scanVulnerabilitiesProperty,
+	}
+)
+
 func NewImageBuildMetadata() *ImageBuildMetadata {
 	return &ImageBuildMetadata{}
 }
 
 func (m *ImageBuildMetadata) toProps() map[string]interface{} {
-	props := map[string]interface{}{
-		"name":                            m.Name,
-		"repoURL":                         m.RepoURL,
-		"repoHash":                        m.RepoHash,
-		"repoHashDurationSeconds":         m.RepoHashDuration.Seconds(),
-		"cacheHit":                        m.CacheHit,
-		"cacheHitDurationSeconds":         m.CacheHitDuration.Seconds(),
-		"buildDurationSeconds":            m.BuildDuration.Seconds(),
-		"buildContextHash":                m.BuildContextHash,
-		"buildContextHashDurationSeconds": m.BuildContextHashDuration.Seconds(),
+	props := map[string]interface{}{}
+	imageNameProperty.set(props, m.Name)
+	repoURLProperty.set(props, m.RepoURL)
+	repoHashProperty.set(props, m.RepoHash)
+	repoHashDurationProperty.set(props, m.RepoHashDuration.Seconds())
+	cacheHitProperty.set(props, m.CacheHit)
+	cacheHitDurationProperty.set(props, m.CacheHitDuration.Seconds())
+	buildDurationProperty.set(props, m.BuildDuration.Seconds())
+	buildContextHashProperty.set(props, m.BuildContextHash)
+	buildContextHashDurationProperty.set(props, m.BuildContextHashDuration.Seconds())
+
+	if m.PushDuration > 0 {
+		pushDurationProperty.set(props, m.PushDuration.Seconds())
+	}
+
+	if m.Vulnerabilities != nil {
+		scanDurationProperty.set(props, m.ScanDuration.Seconds())
+		scanFailedProperty.set(props, m.ScanFailed)
+		scanVulnerabilitiesProperty.set(props, m.Vulnerabilities)
 	}
 
 	if m.Name != "" {
-		props["name"] = hashString(m.Name)
+		imageNameProperty.set(props, hashString(m.Name))
 	}
 	if m.RepoURL != "" {
-		props["repoURL"] = hashString(m.RepoURL)
+		repoURLProperty.set(props, hashString(m.RepoURL))
 	}
 
 	return props
@@ -0,0 +1,188 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analytics
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// PropertyType is the type of the value of an analytics property
+type PropertyType string
+
+const (
+	// BoolProperty is a property with a boolean value
+	BoolProperty PropertyType = "bool"
+
+	// StringProperty is a property with a string value
+	StringProperty PropertyType = "string"
+
+	// NumberProperty is a property with a numeric value. Durations are sent in seconds
+	NumberProperty PropertyType = "number"
+
+	// MapProperty is a property with a map of values
+	MapProperty PropertyType = "map"
+
+	// schemaVersionProperty is the property with the version of the schema of the event
+	schemaVersionProperty = "schemaVersion"
+)
+
+// propertyDefinition is the name and the type of a property of an event
+type propertyDefinition interface {
+	Name() string
+	Type() PropertyType
+}
+
+// property is a property whose value has the type T. Setting a value of a different type doesn't compile
+type property[T any] struct {
+	name string
+}
+
+// Name returns the name of the property
+func (p property[T]) Name() string {
+	return p.name
+}
+
+// Type returns the type of the values of the property
+func (property[T]) Type() PropertyType {
+	return propertyTypeOf(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+func (p property[T]) set(props map[string]interface{}, value T) {
+	props[p.name] = value
+}
+
+func propertyTypeOf(t reflect.Type) PropertyType {
+	switch t.Kind() {
+	case reflect.Bool:
+		return BoolProperty
+	case reflect.String:
+		return StringProperty
+	case reflect.Int, reflect.Int32, reflect.Int64, reflect.Float32, reflect.Float64:
+		return NumberProperty
+	case reflect.Map:
+		return MapProperty
+	default:
+		return PropertyType(t.Kind().String())
+	}
+}
+
+// eventSchema defines the properties of an analytics event. Version must be increased when a property is renamed, removed or changes its type
+type eventSchema struct {
+	name       string
+	version    int
+	properties []propertyDefinition
+}
+
+// commonProperties are the properties added to all the events
+var (
+	osProperty          = property[string]{"$os"}
+	versionProperty     = property[string]{"version"}
+	machineIDProperty   = property[string]{"machine_id"}
+	clusterTypeProperty = property[string]{"clusterType"}
+	sourceProperty      = property[string]{"source"}
+	originProperty      = property[string]{"origin"}
+	successProperty     = property[bool]{"success"}
+	contextTypeProperty = property[string]{"contextType"}
+	isOktetoProperty    = property[bool]{"isOkteto"}
+	termTypeProperty    = property[string]{"term-type"}
+	contextProperty     = property[string]{"context"}
+	isTrialProperty     = property[bool]{"isTrial"}
+
+	commonProperties = []propertyDefinition{
+		osProperty,
+		versionProperty,
+		machineIDProperty,
+		clusterTypeProperty,
+		sourceProperty,
+		originProperty,
+		successProperty,
+		contextTypeProperty,
+		isOktetoProperty,
+		termTypeProperty,
+		contextProperty,
+		isTrialProperty,
+	}
+)
+
+// properties shared by several events
+var (
+	isOktetoRepositoryProperty     = property[bool]{"isOktetoRepository"}
+	hasDependenciesSectionProperty = property[bool]{"hasDependenciesSection"}
+	hasBuildSectionProperty        = property[bool]{"hasBuildSection"}
+	isRemoteProperty               = property[bool]{"isRemote"}
+)
+
+// eventRegistry contains the schemas of the events with properties
+var eventRegistry = map[string]eventSchema{
+	upEvent: {
+		name:       upEvent,
+		version:    1,
+		properties: upProperties,
+	},
+	deployEvent: {
+		name:       deployEvent,
+		version:    1,
+		properties: deployProperties,
+	},
+	destroyEvent: {
+		name:       destroyEvent,
+		version:    1,
+		properties: destroyProperties,
+	},
+	imageBuildEvent: {
+		name:       imageBuildEvent,
+		version:    1,
+		properties: imageBuildProperties,
+	},
+}
+
+// getEventSchema returns the schema of an event, or false if the event isn't registered
+func getEventSchema(event string) (eventSchema, bool) {
+	s, ok := eventRegistry[event]
+	return s, ok
+}
+
+// validate returns an error if props contains properties that aren't defined in the schema or have a different type
+func (s eventSchema) validate(props map[string]interface{}) error {
+	definitions := map[string]PropertyType{schemaVersionProperty: NumberProperty}
+	for _, p := range commonProperties {
+		definitions[p.Name()] = p.Type()
+	}
+	for _, p := range s.properties {
+		definitions[p.Name()] = p.Type()
+	}
+
+	var errs []string
+	for name, value := range props {
+		expected, ok := definitions[name]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("'%s' is not defined", name))
+			continue
+		}
+		if value == nil {
+			continue
+		}
+		if actual := propertyTypeOf(reflect.TypeOf(value)); actual != expected {
+			errs = append(errs, fmt.Sprintf("'%s' is %s instead of %s", name, actual, expected))
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	sort.Strings(errs)
+	return fmt.Errorf("properties of event '%s' don't match its schema version %d: %s", s.name, s.version, strings.Join(errs, ", "))
+}
@@ -0,0 +1,109 @@
+package analytics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisteredEventsMatchTheirSchema(t *testing.T) {
+	var received []mockEvent
+	tracker := &AnalyticsTracker{
+		trackFn: func(event string, success bool, props map[string]interface{}) {
+			received = append(received, mockEvent{event: event, success: success, props: props})
+		},
+	}
+
+	up := NewUpMetricsMetadata()
+	up.ActivateDuration(time.Second)
+	up.ReconnectDefault()
+	tracker.TrackUp(up)
+	tracker.TrackDeploy(DeployMetadata{
+		Success:      true,
+		IsOktetoRepo: true,
+		Err:          errors.New("error"),
+		Duration:     time.Second,
+		DeployType:   "automatic",
+	})
+	tracker.TrackDestroy(DestroyMetadata{IsDestroyAll: true})
+	tracker.TrackImageBuild(&ImageBuildMetadata{
+		Name:            "image",
+		RepoURL:         "https://github.com/okteto/okteto",
+		BuildDuration:   time.Second,
+		Vulnerabilities: map[string]int{"HIGH": 1},
+	})
+
+	require.Len(t, received, 4)
+	for _, e := range received {
+		schema, ok := getEventSchema(e.event)
+		require.True(t, ok, "event '%s' is not registered", e.event)
+		props := map[string]interface{}{
+			osProperty.Name():      "Linux",
+			successProperty.Name(): e.success,
+			schemaVersionProperty:  schema.version,
+		}
+		for k, v := range e.props {
+			props[k] = v
+		}
+		assert.NoError(t, schema.validate(props), e.event)
+	}
+}
+
+func TestEventSchemaValidate(t *testing.T) {
+	schema := eventSchema{
+		name:       "test",
+		version:    2,
+		properties: []propertyDefinition{isRemoteProperty, deployDurationProperty},
+	}
+
+	var tests = []struct {
+		name    string
+		props   map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:  "valid",
+			props: map[string]interface{}{"isRemote": true, "duration": 1.5, "$os": "Linux"},
+		},
+		{
+			name:  "nil value",
+			props: map[string]interface{}{"isRemote": nil},
+		},
+		{
+			name:    "undefined property",
+			props:   map[string]interface{}{"isRemote": true, "unknown": "value"},
+			wantErr: "properties of event 'test' don't match its schema version 2: 'unknown' is not defined",
+		},
+		{
+			name:    "wrong type",
+			props:   map[string]interface{}{"isRemote": "true", "duration": "1s"},
+			wantErr: "properties of event 'test' don't match its schema version 2: 'duration' is string instead of number, 'isRemote' is string instead of bool",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := schema.validate(tt.props)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestEventRegistryPropertiesAreUnique(t *testing.T) {
+	for event, schema := range eventRegistry {
+		assert.Equal(t, event, schema.name)
+		assert.Greater(t, schema.version, 0)
+
+		names := map[string]bool{schemaVersionProperty: true}
+		for _, p := range append(append([]propertyDefinition{}, commonProperties...), schema.properties...) {
+			assert.False(t, names[p.Name()], "property '%s' of event '%s' is defined twice", p.Name(), event)
+			names[p.Name()] = true
+		}
+	}
+}
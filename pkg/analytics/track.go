@@ -63,6 +63,7 @@ const (
 	stackNotSupportedField   = "Stack Field Not Supported"
 	buildPullErrorEvent      = "BuildPullError"
 	deleteContexts           = "Contexts Deletion"
+	testEvent                = "Test"
 )
 
 var (
@@ -209,6 +210,11 @@ func TrackBuild(success bool) {
 	track(buildEvent, success, nil)
 }
 
+// TrackTest sends a tracking event to mixpanel when the user runs the test suites of the manifest
+func TrackTest(success bool) {
+	track(testEvent, success, nil)
+}
+
 // TrackBuildTransientError sends a tracking event to mixpanel when the user build fails because of a transient error
 func TrackBuildTransientError(success bool) {
 	track(buildTransientErrorEvent, success, nil)
@@ -284,18 +290,8 @@ func TrackContextDelete(ctxs int, success bool) {
 }
 
 func track(event string, success bool, props map[string]interface{}) {
-	if !get().Enabled {
-		oktetoLog.Info("failed to send analytics: analytics has been disabled")
-		return
-	}
-
-	if !okteto.IsContextInitialized() {
-		oktetoLog.Info("failed to send analytics: okteto context not initialized")
-		return
-	}
-
-	if disabledByOktetoAdmin() {
-		oktetoLog.Info("failed to send analytics: analytics disabled by admin")
+	if reason := disabledReason(); reason != "" {
+		oktetoLog.Infof("failed to send analytics: %s", reason)
 		return
 	}
 
@@ -318,31 +314,41 @@ func track(event string, success bool, props map[string]interface{}) {
 	if props == nil {
 		props = map[string]interface{}{}
 	}
-	props["$os"] = mpOS
-	props["version"] = config.VersionString
-	props["machine_id"] = get().MachineID
+	osProperty.set(props, mpOS)
+	versionProperty.set(props, config.VersionString)
+	machineIDProperty.set(props, get().MachineID)
 	if okteto.Context().ClusterType != "" {
-		props["clusterType"] = okteto.Context().ClusterType
+		clusterTypeProperty.set(props, okteto.Context().ClusterType)
 	}
 
-	props["source"] = origin
-	props["origin"] = origin
-	props["success"] = success
-	props["contextType"] = getContextType(okteto.Context().Name)
-	props["isOkteto"] = okteto.Context().IsOkteto
+	sourceProperty.set(props, origin)
+	originProperty.set(props, origin)
+	successProperty.set(props, success)
+	contextTypeProperty.set(props, getContextType(okteto.Context().Name))
+	isOktetoProperty.set(props, okteto.Context().IsOkteto)
 	if termType := os.Getenv(model.TermEnvVar); termType == "" {
-		props["term-type"] = "other"
+		termTypeProperty.set(props, "other")
 	} else {
-		props["term-type"] = termType
+		termTypeProperty.set(props, termType)
 	}
 
-	props["context"] = okteto.Context().CompanyName
-	props["isTrial"] = okteto.Context().IsTrial
+	contextProperty.set(props, okteto.Context().CompanyName)
+	isTrialProperty.set(props, okteto.Context().IsTrial)
+
+	if schema, ok := getEventSchema(event); ok {
+		props[schemaVersionProperty] = schema.version
+		if err := schema.validate(props); err != nil {
+			oktetoLog.Infof("invalid analytics event: %s", err)
+		}
+	}
 
+	trackID := getTrackID()
 	e := &mixpanel.Event{Properties: props}
-	if err := mixpanelClient.Track(getTrackID(), event, e); err != nil {
+	if err := mixpanelClient.Track(trackID, event, e); err != nil {
 		oktetoLog.Infof("Failed to send analytics: %s", err)
+		return
 	}
+	recordEvent(event, trackID, props)
 }
 
 func disabledByOktetoAdmin() bool {
@@ -40,6 +40,14 @@ type UpMetricsMetadata struct {
 	hasBuildSection          bool
 	hasDeploySection         bool
 	hasReverse               bool
+	forwardCount             int
+	reverseCount             int
+	syncFolderCount          int
+	hasLifecycleHooks        bool
+	hasExternal              bool
+	hasDivert                bool
+	isCompose                bool
+	clusterVersion           string
 	isHybridDev              bool
 	mode                     string
 	failActivate             bool
@@ -66,34 +74,110 @@ func NewUpMetricsMetadata() *UpMetricsMetadata {
 	return &UpMetricsMetadata{}
 }
 
+// upProperties are the properties of the Up event
+var (
+	isInteractiveProperty                = property[bool]{"isInteractive"}
+	isV2Property                         = property[bool]{"isV2"}
+	manifestTypeProperty                 = property[model.Archetype]{"manifestType"}
+	hasDeploySectionProperty             = property[bool]{"hasDeploySection"}
+	hasReverseProperty                   = property[bool]{"hasReverse"}
+	forwardCountProperty                 = property[int]{"forwardCount"}
+	reverseCountProperty                 = property[int]{"reverseCount"}
+	syncFolderCountProperty              = property[int]{"syncFolderCount"}
+	hasLifecycleHooksProperty            = property[bool]{"hasLifecycleHooks"}
+	hasExternalProperty                  = property[bool]{"hasExternal"}
+	hasDivertProperty                    = property[bool]{"hasDivert"}
+	isComposeProperty                    = property[bool]{"isCompose"}
+	clusterVersionProperty               = property[string]{"clusterVersion"}
+	modeProperty                         = property[string]{"mode"}
+	failActivateProperty                 = property[bool]{"failActivate"}
+	activateDurationProperty             = property[float64]{"activateDurationSeconds"}
+	initialSyncDurationProperty          = property[float64]{"initialSyncDurationSeconds"}
+	isReconnectProperty                  = property[bool]{"isReconnect"}
+	reconnectCauseProperty               = property[string]{"reconnectCause"}
+	errSyncProperty                      = property[bool]{"errSync"}
+	errSyncResetDatabaseProperty         = property[bool]{"errSyncResetDatabase"}
+	errSyncInsufficientSpaceProperty     = property[bool]{"errSyncInsufficientSpace"}
+	errSyncLostSyncthingProperty         = property[bool]{"errSyncLostSyncthing"}
+	hasRunDeployProperty                 = property[bool]{"hasRunDeploy"}
+	oktetoCtxConfigDurationProperty      = property[float64]{"oktetoCtxConfigDurationSeconds"}
+	devContainerCreationDurationProperty = property[float64]{"devContainerCreationDurationSeconds"}
+	contextSyncDurationProperty          = property[float64]{"contextSyncDurationSeconds"}
+	localFoldersScanDurationProperty     = property[float64]{"localFoldersScanDurationSeconds"}
+	execDurationProperty                 = property[float64]{"execDurationSeconds"}
+
+	upProperties = []propertyDefinition{
+		isInteractiveProperty,
+		isV2Property,
+		manifestTypeProperty,
+		isOktetoRepositoryProperty,
+		hasDependenciesSectionProperty,
+		hasBuildSectionProperty,
+		hasDeploySectionProperty,
+		hasReverseProperty,
+		forwardCountProperty,
+		reverseCountProperty,
+		syncFolderCountProperty,
+		hasLifecycleHooksProperty,
+		hasExternalProperty,
+		hasDivertProperty,
+		isComposeProperty,
+		clusterVersionProperty,
+		modeProperty,
+		failActivateProperty,
+		activateDurationProperty,
+		initialSyncDurationProperty,
+		isReconnectProperty,
+		reconnectCauseProperty,
+		errSyncProperty,
+		errSyncResetDatabaseProperty,
+		errSyncInsufficientSpaceProperty,
+		errSyncLostSyncthingProperty,
+		hasRunDeployProperty,
+		oktetoCtxConfigDurationProperty,
+		devContainerCreationDurationProperty,
+		contextSyncDurationProperty,
+		localFoldersScanDurationProperty,
+		execDurationProperty,
+	}
+)
+
 // toProps transforms UpMetricsMetadata into a map to be able to send it to mixpanel
 func (u *UpMetricsMetadata) toProps() map[string]interface{} {
-	return map[string]interface{}{
-		"isInteractive":                       u.isInteractive,
-		"isV2":                                u.isV2,
-		"manifestType":                        u.manifestType,
-		"isOktetoRepository":                  u.isOktetoRepository,
-		"hasDependenciesSection":              u.hasDependenciesSection,
-		"hasBuildSection":                     u.hasBuildSection,
-		"hasDeploySection":                    u.hasDeploySection,
-		"hasReverse":                          u.hasReverse,
-		"mode":                                u.mode,
-		"failActivate":                        u.failActivate,
-		"activateDurationSeconds":             u.activateDuration.Seconds(),
-		"initialSyncDurationSeconds":          u.initialSyncDuration.Seconds(),
-		"isReconnect":                         u.isReconnect,
-		"reconnectCause":                      u.reconnectCause,
-		"errSync":                             u.errSync,
-		"errSyncResetDatabase":                u.errSyncResetDatabase,
-		"errSyncInsufficientSpace":            u.errSyncInsufficientSpace,
-		"errSyncLostSyncthing":                u.errSyncLostSyncthing,
-		"hasRunDeploy":                        u.hasRunDeploy,
-		"oktetoCtxConfigDurationSeconds":      u.oktetoCtxConfigDuration.Seconds(),
-		"devContainerCreationDurationSeconds": u.devContainerCreationDuration.Seconds(),
-		"contextSyncDurationSeconds":          u.contextSyncDuration.Seconds(),
-		"localFoldersScanDurationSeconds":     u.localFoldersScanDuration.Seconds(),
-		"execDurationSeconds":                 u.execDuration.Seconds(),
-	}
+	props := map[string]interface{}{}
+	isInteractiveProperty.set(props, u.isInteractive)
+	isV2Property.set(props, u.isV2)
+	manifestTypeProperty.set(props, u.manifestType)
+	isOktetoRepositoryProperty.set(props, u.isOktetoRepository)
+	hasDependenciesSectionProperty.set(props, u.hasDependenciesSection)
+	hasBuildSectionProperty.set(props, u.hasBuildSection)
+	hasDeploySectionProperty.set(props, u.hasDeploySection)
+	hasReverseProperty.set(props, u.hasReverse)
+	forwardCountProperty.set(props, u.forwardCount)
+	reverseCountProperty.set(props, u.reverseCount)
+	syncFolderCountProperty.set(props, u.syncFolderCount)
+	hasLifecycleHooksProperty.set(props, u.hasLifecycleHooks)
+	hasExternalProperty.set(props, u.hasExternal)
+	hasDivertProperty.set(props, u.hasDivert)
+	isComposeProperty.set(props, u.isCompose)
+	clusterVersionProperty.set(props, u.clusterVersion)
+	modeProperty.set(props, u.mode)
+	failActivateProperty.set(props, u.failActivate)
+	activateDurationProperty.set(props, u.activateDuration.Seconds())
+	initialSyncDurationProperty.set(props, u.initialSyncDuration.Seconds())
+	isReconnectProperty.set(props, u.isReconnect)
+	reconnectCauseProperty.set(props, u.reconnectCause)
+	errSyncProperty.set(props, u.errSync)
+	errSyncResetDatabaseProperty.set(props, u.errSyncResetDatabase)
+	errSyncInsufficientSpaceProperty.set(props, u.errSyncInsufficientSpace)
+	errSyncLostSyncthingProperty.set(props, u.errSyncLostSyncthing)
+	hasRunDeployProperty.set(props, u.hasRunDeploy)
+	oktetoCtxConfigDurationProperty.set(props, u.oktetoCtxConfigDuration.Seconds())
+	devContainerCreationDurationProperty.set(props, u.devContainerCreationDuration.Seconds())
+	contextSyncDurationProperty.set(props, u.contextSyncDuration.Seconds())
+	localFoldersScanDurationProperty.set(props, u.localFoldersScanDuration.Seconds())
+	execDurationProperty.set(props, u.execDuration.Seconds())
+	return props
 }
 
 // ManifestProps adds the tracking properties of the repository manifest
@@ -103,6 +187,27 @@ func (u *UpMetricsMetadata) ManifestProps(m *model.Manifest) {
 	u.hasDependenciesSection = m.HasDependenciesSection()
 	u.hasBuildSection = m.HasBuildSection()
 	u.hasDeploySection = m.HasDeploySection()
+	u.hasExternal = len(m.External) > 0
+	u.hasDivert = m.Deploy != nil && m.Deploy.Divert != nil
+	u.isCompose = m.Type == model.StackType || (m.Deploy != nil && m.Deploy.ComposeSection != nil)
+
+	u.forwardCount = len(m.GlobalForward)
+	for _, d := range m.Dev {
+		u.forwardCount += len(d.Forward)
+		u.reverseCount += len(d.Reverse)
+		u.syncFolderCount += len(d.Sync.Folders)
+		if d.Lifecycle.HasHooks() {
+			u.hasLifecycleHooks = true
+		}
+		for _, s := range d.Services {
+			u.syncFolderCount += len(s.Sync.Folders)
+		}
+	}
+}
+
+// ClusterProps adds the tracking properties of the cluster
+func (u *UpMetricsMetadata) ClusterProps(version string) {
+	u.clusterVersion = version
 }
 
 // DevProps adds the tracking properties of the service development manifest
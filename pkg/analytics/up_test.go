@@ -4,7 +4,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/okteto/okteto/pkg/externalresource"
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -69,6 +71,47 @@ func Test_UpMetricsMetadata_ManifestProps(t *testing.T) {
 				manifestType: "manifest",
 			},
 		},
+		{
+			name: "manifest features",
+			manifest: &model.Manifest{
+				Type:          model.StackType,
+				GlobalForward: []forward.GlobalForward{{Local: 8080, Remote: 8080}},
+				External: externalresource.ExternalResourceSection{
+					"docs": &externalresource.ExternalResource{},
+				},
+				Deploy: &model.DeployInfo{
+					Divert: &model.DivertDeploy{Namespace: "staging"},
+				},
+				Dev: model.ManifestDevs{
+					"api": &model.Dev{
+						Forward: []forward.Forward{{Local: 9000, Remote: 9000}, {Local: 5432, Remote: 5432}},
+						Reverse: []model.Reverse{{Local: 8080, Remote: 8080}},
+						Sync: model.Sync{
+							Folders: []model.SyncFolder{{LocalPath: ".", RemotePath: "/app"}},
+						},
+						Lifecycle: &model.Lifecycle{PostSync: &model.Command{Values: []string{"make"}}},
+						Services: []*model.Dev{
+							{Sync: model.Sync{Folders: []model.SyncFolder{{LocalPath: "worker", RemotePath: "/src"}}}},
+						},
+					},
+					"web": &model.Dev{
+						Sync: model.Sync{
+							Folders: []model.SyncFolder{{LocalPath: "web", RemotePath: "/app"}},
+						},
+					},
+				},
+			},
+			expected: &UpMetricsMetadata{
+				manifestType:      model.StackType,
+				forwardCount:      3,
+				reverseCount:      1,
+				syncFolderCount:   3,
+				hasLifecycleHooks: true,
+				hasExternal:       true,
+				hasDivert:         true,
+				isCompose:         true,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -240,6 +283,14 @@ func Test_UpTracker(t *testing.T) {
 					"hasDependenciesSection":              false,
 					"hasDeploySection":                    false,
 					"hasReverse":                          false,
+					"forwardCount":                        0,
+					"reverseCount":                        0,
+					"syncFolderCount":                     0,
+					"hasLifecycleHooks":                   false,
+					"hasExternal":                         false,
+					"hasDivert":                           false,
+					"isCompose":                           false,
+					"clusterVersion":                      "",
 					"initialSyncDurationSeconds":          float64(0),
 					"isInteractive":                       false,
 					"isOktetoRepository":                  false,
@@ -276,6 +327,14 @@ func Test_UpTracker(t *testing.T) {
 					"hasDependenciesSection":              false,
 					"hasDeploySection":                    false,
 					"hasReverse":                          false,
+					"forwardCount":                        0,
+					"reverseCount":                        0,
+					"syncFolderCount":                     0,
+					"hasLifecycleHooks":                   false,
+					"hasExternal":                         false,
+					"hasDivert":                           false,
+					"isCompose":                           false,
+					"clusterVersion":                      "",
 					"initialSyncDurationSeconds":          float64(0),
 					"isInteractive":                       false,
 					"isOktetoRepository":                  false,
@@ -330,6 +389,14 @@ func Test_UpTracker(t *testing.T) {
 					"hasDependenciesSection":              true,
 					"hasDeploySection":                    true,
 					"hasReverse":                          true,
+					"forwardCount":                        0,
+					"reverseCount":                        0,
+					"syncFolderCount":                     0,
+					"hasLifecycleHooks":                   false,
+					"hasExternal":                         false,
+					"hasDivert":                           false,
+					"isCompose":                           false,
+					"clusterVersion":                      "",
 					"initialSyncDurationSeconds":          float64(60),
 					"isInteractive":                       true,
 					"isOktetoRepository":                  true,
@@ -381,6 +448,14 @@ func Test_UpTracker(t *testing.T) {
 					"hasDependenciesSection":              true,
 					"hasDeploySection":                    true,
 					"hasReverse":                          true,
+					"forwardCount":                        0,
+					"reverseCount":                        0,
+					"syncFolderCount":                     0,
+					"hasLifecycleHooks":                   false,
+					"hasExternal":                         false,
+					"hasDivert":                           false,
+					"isCompose":                           false,
+					"clusterVersion":                      "",
 					"initialSyncDurationSeconds":          float64(60),
 					"isInteractive":                       true,
 					"isOktetoRepository":                  true,
@@ -431,6 +506,14 @@ func Test_UpTracker(t *testing.T) {
 					"hasDependenciesSection":              true,
 					"hasDeploySection":                    true,
 					"hasReverse":                          true,
+					"forwardCount":                        0,
+					"reverseCount":                        0,
+					"syncFolderCount":                     0,
+					"hasLifecycleHooks":                   false,
+					"hasExternal":                         false,
+					"hasDivert":                           false,
+					"isCompose":                           false,
+					"clusterVersion":                      "",
 					"initialSyncDurationSeconds":          float64(60),
 					"isInteractive":                       true,
 					"isOktetoRepository":                  true,
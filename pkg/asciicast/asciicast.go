@@ -0,0 +1,138 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package asciicast records terminal sessions in the asciicast v2 format, which can be replayed with 'asciinema play'
+package asciicast
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	version = 2
+
+	// outputEvent is the type of the events with data written to the terminal
+	outputEvent = "o"
+)
+
+// Header is the first line of an asciicast v2 file
+type Header struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Recorder writes the output of a terminal session as asciicast v2 events
+type Recorder struct {
+	mu      sync.Mutex
+	w       io.Writer
+	start   time.Time
+	now     func() time.Time
+	pending []byte
+	err     error
+}
+
+// NewRecorder writes the header of the recording and returns a recorder of the events of the session
+func NewRecorder(w io.Writer, h Header) (*Recorder, error) {
+	return newRecorder(w, h, time.Now)
+}
+
+func newRecorder(w io.Writer, h Header, now func() time.Time) (*Recorder, error) {
+	start := now()
+	h.Version = version
+	if h.Timestamp == 0 {
+		h.Timestamp = start.Unix()
+	}
+	b, err := json.Marshal(h)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fmt.Fprintf(w, "%s\n", b); err != nil {
+		return nil, fmt.Errorf("failed to write the recording header: %w", err)
+	}
+	return &Recorder{w: w, start: start, now: now}, nil
+}
+
+// Output returns a writer that writes to dst and records the data written as output events
+func (r *Recorder) Output(dst io.Writer) io.Writer {
+	return &outputWriter{dst: dst, recorder: r}
+}
+
+// Err returns the first error writing the recording. Recording errors never interrupt the session
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// record writes an output event. Incomplete UTF-8 sequences at the end of data are kept until the next call, because events must be valid strings
+func (r *Recorder) record(data []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+
+	data = append(r.pending, data...)
+	n := completeUTF8Length(data)
+	r.pending = append([]byte{}, data[n:]...)
+	if n == 0 {
+		return
+	}
+
+	elapsed := r.now().Sub(r.start).Seconds()
+	b, err := json.Marshal([]interface{}{elapsed, outputEvent, string(data[:n])})
+	if err != nil {
+		r.err = err
+		return
+	}
+	if _, err := fmt.Fprintf(r.w, "%s\n", b); err != nil {
+		r.err = fmt.Errorf("failed to write the recording: %w", err)
+	}
+}
+
+// completeUTF8Length returns the length of data without an incomplete UTF-8 sequence at its end
+func completeUTF8Length(data []byte) int {
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if !utf8.RuneStart(data[i]) {
+			continue
+		}
+		if utf8.FullRune(data[i:]) {
+			return len(data)
+		}
+		return i
+	}
+	return len(data)
+}
+
+type outputWriter struct {
+	dst      io.Writer
+	recorder *Recorder
+}
+
+// Write implements the io.Writer interface
+func (w *outputWriter) Write(p []byte) (int, error) {
+	n, err := w.dst.Write(p)
+	if n > 0 {
+		w.recorder.record(p[:n])
+	}
+	return n, err
+}
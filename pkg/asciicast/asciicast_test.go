@@ -0,0 +1,97 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package asciicast
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder(t *testing.T) {
+	start := time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)
+	now := start
+	cast := &bytes.Buffer{}
+	r, err := newRecorder(cast, Header{Width: 80, Height: 24, Title: "okteto exec api", Env: map[string]string{"TERM": "xterm"}}, func() time.Time { return now })
+	require.NoError(t, err)
+
+	stdout := &bytes.Buffer{}
+	out := r.Output(stdout)
+
+	now = start.Add(500 * time.Millisecond)
+	_, err = out.Write([]byte("$ ls\r\n"))
+	require.NoError(t, err)
+
+	// the euro sign is split between two writes
+	euro := []byte("€")
+	now = start.Add(1500 * time.Millisecond)
+	_, err = out.Write(append([]byte("price: 5"), euro[:1]...))
+	require.NoError(t, err)
+	_, err = out.Write(append(euro[1:], '\n'))
+	require.NoError(t, err)
+
+	require.NoError(t, r.Err())
+	assert.Equal(t, "$ ls\r\nprice: 5€\n", stdout.String())
+	assert.Equal(t, `{"version":2,"width":80,"height":24,"timestamp":1672567200,"title":"okteto exec api","env":{"TERM":"xterm"}}
+[0.5,"o","$ ls\r\n"]
+[1.5,"o","price: 5"]
+[1.5,"o","€\n"]
+`, cast.String())
+}
+
+func TestCompleteUTF8Length(t *testing.T) {
+	euro := []byte("€")
+	assert.Equal(t, 0, completeUTF8Length(nil))
+	assert.Equal(t, 3, completeUTF8Length([]byte("abc")))
+	assert.Equal(t, 4, completeUTF8Length(append([]byte("a"), euro...)))
+	assert.Equal(t, 1, completeUTF8Length(append([]byte("a"), euro[:2]...)))
+	assert.Equal(t, 2, completeUTF8Length([]byte{'a', 0xff}))
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestRecorderErrors(t *testing.T) {
+	_, err := NewRecorder(failingWriter{}, Header{})
+	assert.Error(t, err)
+
+	// the header is written, the events fail
+	r, err := NewRecorder(&limitedWriter{limit: 1}, Header{})
+	require.NoError(t, err)
+
+	stdout := &bytes.Buffer{}
+	_, err = r.Output(stdout).Write([]byte("hello"))
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", stdout.String())
+	assert.ErrorContains(t, r.Err(), "disk full")
+}
+
+type limitedWriter struct {
+	limit int
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if l.limit > 0 {
+		l.limit--
+		return len(p), nil
+	}
+	return failingWriter{}.Write(p)
+}
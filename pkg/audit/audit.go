@@ -0,0 +1,275 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audit records the shell sessions opened by 'okteto exec' and 'okteto up' in the development containers.
+// It is opt-in: when the audit log is enabled, the records are appended to a local file and sent to the audit endpoint of the okteto context, if it is set.
+// The records sent to the audit endpoint are authenticated with the audit token configured for it, never with the okteto token of the context
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/user"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/afero"
+)
+
+// EventType is the type of an audit record
+type EventType string
+
+const (
+	// SessionStart is recorded when a shell session starts in a development container
+	SessionStart EventType = "session.start"
+
+	// SessionStop is recorded when a shell session finishes
+	SessionStop EventType = "session.stop"
+
+	// ExecCommand is the command of the sessions opened by 'okteto exec'
+	ExecCommand = "exec"
+
+	// UpCommand is the command of the sessions opened by 'okteto up'
+	UpCommand = "up"
+
+	// ExecTokenCredentials are the credentials of the sessions authenticated with a short-lived token scoped to the pod
+	ExecTokenCredentials = "exec-token"
+
+	endpointTimeout = 10 * time.Second
+)
+
+var mu sync.Mutex
+
+// Record is an entry of the audit log
+type Record struct {
+	Timestamp   time.Time `json:"timestamp"`
+	Event       EventType `json:"event"`
+	SessionID   string    `json:"sessionId"`
+	Command     string    `json:"command"`
+	User        string    `json:"user,omitempty"`
+	Context     string    `json:"context,omitempty"`
+	Namespace   string    `json:"namespace"`
+	Dev         string    `json:"dev,omitempty"`
+	Pod         string    `json:"pod,omitempty"`
+	Container   string    `json:"container,omitempty"`
+	Args        []string  `json:"args,omitempty"`
+	Credentials string    `json:"credentials,omitempty"`
+	Duration    string    `json:"duration,omitempty"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// Session is the information of a shell session in a development container
+type Session struct {
+	Command     string
+	Namespace   string
+	Dev         string
+	Pod         string
+	Container   string
+	Args        []string
+	Credentials string
+}
+
+// ActiveSession is a session whose start has been recorded
+type ActiveSession struct {
+	recorder *recorder
+	record   Record
+	start    time.Time
+}
+
+type recorder struct {
+	fs       afero.Fs
+	path     string
+	endpoint string
+	token    string
+	client   *http.Client
+}
+
+// Enable starts recording the sessions in the local audit log. If endpoint is not empty, it is set as the audit endpoint of the current okteto context
+// and token, if any, is the bearer token sent to it
+func Enable(endpoint, token string) error {
+	ctxStore := okteto.ContextStore()
+	ctxStore.AuditLog = true
+	if endpoint != "" {
+		okCtx, ok := ctxStore.Contexts[ctxStore.CurrentContext]
+		if !ok || okCtx == nil {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("the audit endpoint can't be set without an okteto context"),
+				Hint: "Run 'okteto context' to select your okteto context and try again",
+			}
+		}
+		okCtx.AuditEndpoint = endpoint
+		okCtx.AuditToken = token
+	}
+	return okteto.NewContextConfigWriter().Write()
+}
+
+// Disable stops recording the sessions and removes the audit endpoints of every okteto context. The records already written are kept
+func Disable() error {
+	ctxStore := okteto.ContextStore()
+	ctxStore.AuditLog = false
+	for _, okCtx := range ctxStore.Contexts {
+		if okCtx == nil {
+			continue
+		}
+		okCtx.AuditEndpoint = ""
+		okCtx.AuditToken = ""
+	}
+	return okteto.NewContextConfigWriter().Write()
+}
+
+// Start records the start of a shell session. It returns nil if the audit log is not enabled
+func Start(ctx context.Context, s Session) *ActiveSession {
+	r := newRecorder()
+	if r == nil {
+		return nil
+	}
+	return r.start(ctx, s, time.Now().UTC())
+}
+
+func newRecorder() *recorder {
+	ctxStore := okteto.ContextStore()
+	if !ctxStore.AuditLog {
+		return nil
+	}
+	r := &recorder{
+		fs:     afero.NewOsFs(),
+		path:   config.GetAuditLogPath(),
+		client: &http.Client{Timeout: endpointTimeout},
+	}
+	if okCtx := ctxStore.Contexts[ctxStore.CurrentContext]; okCtx != nil {
+		r.endpoint = okCtx.AuditEndpoint
+		r.token = okCtx.AuditToken
+	}
+	return r
+}
+
+func (r *recorder) start(ctx context.Context, s Session, now time.Time) *ActiveSession {
+	a := &ActiveSession{
+		recorder: r,
+		start:    now,
+		record: Record{
+			SessionID:   uuid.NewString(),
+			Command:     s.Command,
+			User:        getUser(),
+			Context:     okteto.Context().Name,
+			Namespace:   s.Namespace,
+			Dev:         s.Dev,
+			Pod:         s.Pod,
+			Container:   s.Container,
+			Args:        s.Args,
+			Credentials: s.Credentials,
+		},
+	}
+	record := a.record
+	record.Timestamp = now
+	record.Event = SessionStart
+	r.write(ctx, record)
+	return a
+}
+
+// Stop records the end of a shell session with the error it returned, if any.
+// It doesn't take the context of the command to record the sessions interrupted by the user
+func (a *ActiveSession) Stop(err error) {
+	if a == nil {
+		return
+	}
+	a.stop(err, time.Now().UTC())
+}
+
+func (a *ActiveSession) stop(err error, now time.Time) {
+	record := a.record
+	record.Timestamp = now
+	record.Event = SessionStop
+	record.Duration = now.Sub(a.start).Round(time.Second).String()
+	if err != nil {
+		record.Error = err.Error()
+	}
+	a.recorder.write(context.Background(), record)
+}
+
+// write appends the record to the local audit log and sends it to the audit endpoint.
+// Audit failures are shown as warnings and never interrupt the session
+func (r *recorder) write(ctx context.Context, record Record) {
+	b, err := json.Marshal(record)
+	if err != nil {
+		oktetoLog.Infof("failed to encode the audit record: %s", err)
+		return
+	}
+	if r.path != "" {
+		if err := r.appendToFile(b); err != nil {
+			oktetoLog.Warning("Failed to write the audit log '%s': %s", r.path, err)
+		}
+	}
+	if r.endpoint != "" {
+		if err := r.send(ctx, b); err != nil {
+			oktetoLog.Warning("Failed to send the audit record to '%s': %s", r.endpoint, err)
+		}
+	}
+}
+
+func (r *recorder) appendToFile(b []byte) error {
+	mu.Lock()
+	defer mu.Unlock()
+	f, err := r.fs.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(b, '\n'))
+	return err
+}
+
+func (r *recorder) send(ctx context.Context, b []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if r.token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", r.token))
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("audit endpoint returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// getUser returns the username of the okteto context, or the local user for contexts that are not okteto clusters
+func getUser() string {
+	if username := okteto.Context().Username; username != "" {
+		return username
+	}
+	u, err := user.Current()
+	if err != nil {
+		oktetoLog.Infof("failed to get the current user: %s", err)
+		return ""
+	}
+	return u.Username
+}
@@ -0,0 +1,147 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setContextStore(t *testing.T, store *okteto.OktetoContextStore) {
+	previous := okteto.CurrentStore
+	t.Cleanup(func() { okteto.CurrentStore = previous })
+	okteto.CurrentStore = store
+}
+
+func TestNewRecorder(t *testing.T) {
+	setContextStore(t, &okteto.OktetoContextStore{
+		CurrentContext: "https://okteto.example.com",
+		Contexts: map[string]*okteto.OktetoContext{
+			"https://okteto.example.com": {Name: "https://okteto.example.com", Token: "okteto-token"},
+		},
+	})
+	assert.Nil(t, newRecorder())
+	assert.Nil(t, Start(context.Background(), Session{Command: ExecCommand}))
+
+	okteto.CurrentStore.AuditLog = true
+	r := newRecorder()
+	require.NotNil(t, r)
+	assert.NotEmpty(t, r.path)
+	assert.Empty(t, r.endpoint)
+	assert.Empty(t, r.token)
+
+	okteto.Context().AuditEndpoint = "https://audit.example.com"
+	okteto.Context().AuditToken = "audit-token"
+	r = newRecorder()
+	require.NotNil(t, r)
+	assert.Equal(t, "https://audit.example.com", r.endpoint)
+	assert.Equal(t, "audit-token", r.token)
+
+	okteto.CurrentStore.AuditLog = false
+	assert.Nil(t, newRecorder())
+}
+
+func TestDisable(t *testing.T) {
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+	setContextStore(t, &okteto.OktetoContextStore{
+		AuditLog:       true,
+		CurrentContext: "https://okteto.example.com",
+		Contexts: map[string]*okteto.OktetoContext{
+			"https://okteto.example.com": {Name: "https://okteto.example.com", AuditEndpoint: "https://audit.example.com", AuditToken: "token"},
+			"https://other.example.com":  {Name: "https://other.example.com", AuditEndpoint: "https://audit.other.com", AuditToken: "other"},
+		},
+	})
+	require.NoError(t, Disable())
+	assert.False(t, okteto.CurrentStore.AuditLog)
+	for _, okCtx := range okteto.CurrentStore.Contexts {
+		assert.Empty(t, okCtx.AuditEndpoint)
+		assert.Empty(t, okCtx.AuditToken)
+	}
+}
+
+func TestSession(t *testing.T) {
+	setContextStore(t, &okteto.OktetoContextStore{
+		CurrentContext: "https://okteto.example.com",
+		Contexts: map[string]*okteto.OktetoContext{
+			"https://okteto.example.com": {Name: "https://okteto.example.com", Username: "cindy"},
+		},
+	})
+
+	var received []Record
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		record := Record{}
+		require.NoError(t, json.Unmarshal(b, &record))
+		received = append(received, record)
+	}))
+	defer server.Close()
+
+	fs := afero.NewMemMapFs()
+	r := &recorder{
+		fs:       fs,
+		path:     "/okteto/audit.log",
+		endpoint: server.URL,
+		token:    "token",
+		client:   server.Client(),
+	}
+
+	start := time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)
+	s := r.start(context.Background(), Session{Command: ExecCommand, Namespace: "ns", Dev: "api", Pod: "api-123", Container: "api", Args: []string{"bash"}}, start)
+	s.stop(errors.New("exit status 1"), start.Add(90*time.Second))
+
+	b, err := afero.ReadFile(fs, "/okteto/audit.log")
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	require.Len(t, lines, 2)
+
+	records := make([]Record, 0, len(lines))
+	for _, l := range lines {
+		record := Record{}
+		require.NoError(t, json.Unmarshal([]byte(l), &record))
+		records = append(records, record)
+	}
+	assert.Equal(t, records, received)
+
+	assert.Equal(t, SessionStart, records[0].Event)
+	assert.Equal(t, "cindy", records[0].User)
+	assert.Equal(t, "https://okteto.example.com", records[0].Context)
+	assert.Equal(t, "api-123", records[0].Pod)
+	assert.Equal(t, []string{"bash"}, records[0].Args)
+	assert.NotEmpty(t, records[0].SessionID)
+
+	assert.Equal(t, SessionStop, records[1].Event)
+	assert.Equal(t, records[0].SessionID, records[1].SessionID)
+	assert.Equal(t, "1m30s", records[1].Duration)
+	assert.Equal(t, "exit status 1", records[1].Error)
+}
+
+func TestStopNil(t *testing.T) {
+	var s *ActiveSession
+	s.Stop(nil)
+}
@@ -96,7 +96,7 @@ func setOutputMode(outputMode string) string {
 
 func (ob *OktetoBuilder) buildWithOkteto(ctx context.Context, buildOptions *types.BuildOptions) error {
 	oktetoLog.Infof("building your image on %s", okteto.Context().Builder)
-	buildkitClient, err := getBuildkitClient(ctx)
+	buildkitClient, err := GetBuildkitClient(ctx)
 	if err != nil {
 		return err
 	}
@@ -214,6 +214,10 @@ func (ob *OktetoBuilder) buildWithDocker(ctx context.Context, buildOptions *type
 		}
 	}
 	if buildOptions.Tag != "" {
+		if buildOptions.PushQueue != nil {
+			buildOptions.PushQueue.Enqueue(ctx, buildOptions.Tag)
+			return nil
+		}
 		return pushImage(ctx, buildOptions.Tag, cli)
 	}
 	return nil
@@ -286,7 +290,11 @@ func OptsFromBuildInfo(manifestName, svcName string, b *model.BuildInfo, o *type
 	}
 
 	file := b.Dockerfile
-	if b.Context != "" && b.Dockerfile != "" {
+	path := b.Context
+	if model.IsRemoteBuildContext(b.Context) {
+		// the Dockerfile of a remote context is relative to the folder of the repository
+		path = model.GetRemoteBuildContext(b.Context)
+	} else if b.Context != "" && b.Dockerfile != "" {
 		file = extractFromContextAndDockerfile(b.Context, b.Dockerfile, svcName)
 	}
 
@@ -344,13 +352,26 @@ func OptsFromBuildInfo(manifestName, svcName string, b *model.BuildInfo, o *type
 	opts := &types.BuildOptions{
 		CacheFrom:   b.CacheFrom,
 		Target:      b.Target,
-		Path:        b.Context,
+		Path:        path,
 		Tag:         b.Image,
 		File:        file,
 		BuildArgs:   model.SerializeBuildArgs(args),
 		NoCache:     o.NoCache,
 		ExportCache: b.ExportCache,
 		Platform:    o.Platform,
+		SBOM:        o.SBOM,
+		SBOMOutput:  o.SBOMOutput,
+		SBOMAttest:  o.SBOMAttest,
+		SBOMKey:     o.SBOMKey,
+		Sign:        o.Sign,
+		SignKey:     o.SignKey,
+		Strict:      o.Strict,
+		PushQueue:   o.PushQueue,
+
+		Service:            svcName,
+		Project:            manifestName,
+		ShowContext:        o.ShowContext,
+		ContextCompression: o.ContextCompression,
 	}
 
 	// if secrets are present at the cmd flag, copy them to opts.Secrets
@@ -452,7 +473,7 @@ func createTempFileWithExpandedEnvsAtSource(fs afero.Fs, sourceFile, tempFolder
 	sc := bufio.NewScanner(srcFile)
 	for sc.Scan() {
 		// expand content
-		srcContent, err := model.ExpandEnv(sc.Text(), true)
+		srcContent, err := model.ExpandEnvValue(sc.Text(), true)
 		if err != nil {
 			return "", err
 		}
@@ -30,8 +30,6 @@ import (
 	"github.com/docker/distribution/reference"
 	dockerTypes "github.com/docker/docker/api/types"
 	"github.com/docker/docker/client"
-	"github.com/docker/docker/pkg/archive"
-	"github.com/docker/docker/pkg/idtools"
 	"github.com/docker/docker/pkg/jsonmessage"
 	"github.com/docker/docker/pkg/progress"
 	"github.com/docker/docker/pkg/streamformatter"
@@ -41,7 +39,6 @@ import (
 	controlapi "github.com/moby/buildkit/api/services/control"
 	buildkitClient "github.com/moby/buildkit/client"
 	"github.com/moby/buildkit/cmd/buildctl/build"
-	"github.com/moby/buildkit/frontend/dockerfile/dockerignore"
 	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/session/auth/authprovider"
 	"github.com/moby/buildkit/session/filesync"
@@ -49,8 +46,10 @@ import (
 	"github.com/moby/buildkit/util/progress/progresswriter"
 	"github.com/moby/term"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/pkg/errors"
+	"github.com/spf13/afero"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -79,24 +78,33 @@ func buildWithDockerDaemonBuildkit(ctx context.Context, buildOptions *types.Buil
 		contextDir    string
 		remote        string
 		dockerfileDir string
+		dockerfile    string
 	)
 
 	switch {
 	case isLocalDir(buildOptions.Path):
 		contextDir = buildOptions.Path
 		dockerfileDir = filepath.Dir(buildOptions.File)
+		dockerfile = filepath.Base(buildOptions.File)
 		remote = "client-session"
-	case isURL(buildOptions.Path):
+	case isURL(buildOptions.Path) || model.IsRemoteBuildContext(buildOptions.Path):
+		// the dockerfile of a remote context is relative to the context
 		remote = buildOptions.Path
+		dockerfile = buildOptions.File
 	default:
 		return errors.Errorf("unable to prepare context: path %q not found", buildOptions.Path)
 	}
 
 	if dockerfileDir != "" {
+		excludes, err := getUploadExcludes(afero.NewOsFs(), contextDir, buildOptions.File, buildOptions.Service)
+		if err != nil {
+			return err
+		}
 		s.Allow(filesync.NewFSSyncProvider([]filesync.SyncedDir{
 			{
-				Name: "context",
-				Dir:  contextDir,
+				Name:     "context",
+				Dir:      contextDir,
+				Excludes: excludes,
 			},
 			{
 				Name: "dockerfile",
@@ -122,7 +130,7 @@ func buildWithDockerDaemonBuildkit(ctx context.Context, buildOptions *types.Buil
 		dockerBuildOptions := dockerTypes.ImageBuildOptions{
 			BuildID:       buildID,
 			Version:       dockerTypes.BuilderBuildKit,
-			Dockerfile:    filepath.Base(buildOptions.File),
+			Dockerfile:    dockerfile,
 			RemoteContext: remote,
 			SessionID:     s.ID(),
 			BuildArgs:     make(map[string]*string),
@@ -169,7 +177,7 @@ func buildWithDockerDaemonBuildkit(ctx context.Context, buildOptions *types.Buil
 func buildWithDockerDaemon(ctx context.Context, buildOptions *types.BuildOptions, cli *client.Client) error {
 	oktetoLog.Infof("building your image with docker client v%s", cli.ClientVersion())
 
-	dockerBuildContext, err := getBuildContext(buildOptions.File)
+	dockerBuildContext, contextSize, err := getBuildContext(buildOptions)
 	if err != nil {
 		return err
 	}
@@ -178,11 +186,20 @@ func buildWithDockerDaemon(ctx context.Context, buildOptions *types.BuildOptions
 	if err != nil {
 		return err
 	}
-	progressOutput := streamformatter.NewProgressOutput(os.Stdout)
+	var out io.Writer = os.Stdout
+	if oktetoLog.IsCIFormat() {
+		// the streamed logs are written as timestamped lines without progress bars
+		out = oktetoLog.GetOutputWriter()
+	}
 
 	var body io.Reader
 	if dockerBuildContext != nil {
-		body = progress.NewProgressReader(dockerBuildContext, progressOutput, 0, "", "Sending build context to Docker daemon")
+		defer dockerBuildContext.Close()
+		body = dockerBuildContext
+		if !oktetoLog.IsCIFormat() {
+			progressOutput := streamformatter.NewProgressOutput(out)
+			body = progress.NewProgressReader(dockerBuildContext, progressOutput, contextSize, "", "Sending build context to Docker daemon")
+		}
 	}
 	res, err := cli.ImageBuild(ctx, body, dockerBuildOptions)
 	if err != nil {
@@ -202,9 +219,9 @@ func buildWithDockerDaemon(ctx context.Context, buildOptions *types.BuildOptions
 			imageID = result.ID
 		}
 	}
-	termFd, isTerm := term.GetFdInfo(os.Stdout)
+	termFd, isTerm := term.GetFdInfo(out)
 
-	err = jsonmessage.DisplayJSONMessagesStream(res.Body, os.Stdout, termFd, isTerm, aux)
+	err = jsonmessage.DisplayJSONMessagesStream(res.Body, out, termFd, isTerm, aux)
 	if err != nil {
 		if jerr, ok := err.(*jsonmessage.JSONError); ok {
 			// If no error code is set, default to 1
@@ -329,58 +346,12 @@ func isURL(path string) bool {
 	return err == nil
 }
 
-// getBuildContext returns the build context
-func getBuildContext(path string) (io.ReadCloser, error) {
-	var dockerBuildContext io.ReadCloser
-	var err error
-	if urlutil.IsURL(path) {
-		return nil, fmt.Errorf("Non url context is unavailable")
-	} else {
-		dockerBuildContext, err = createTarFromPath(path)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return dockerBuildContext, nil
-}
-
-// createTarFromPath creates the context tar file for docker api
-func createTarFromPath(contextDir string) (io.ReadCloser, error) {
-	excludes, err := readDockerignore(contextDir)
-	if err != nil {
-		return nil, err
-	}
-
-	buildCtx, err := archive.TarWithOptions(contextDir, &archive.TarOptions{
-		ExcludePatterns: excludes,
-		ChownOpts:       &idtools.Identity{UID: 0, GID: 0},
-	})
-	if err != nil {
-		return nil, err
-	}
-	return buildCtx, nil
-}
-
-// ReadDockerignore reads the .dockerignore file in the context directory and
-// returns the list of paths to exclude
-func readDockerignore(contextDir string) ([]string, error) {
-	var excludes []string
-
-	path := filepath.Join(contextDir, ".dockerignore")
-	f, err := os.Open(path)
-	switch {
-	case os.IsNotExist(err):
-		return excludes, nil
-	case err != nil:
-		return nil, err
+// getBuildContext returns the build context compressed with the compression of the build options and its size
+func getBuildContext(buildOptions *types.BuildOptions) (io.ReadCloser, int64, error) {
+	if urlutil.IsURL(buildOptions.Path) {
+		return nil, 0, fmt.Errorf("Non url context is unavailable")
 	}
-	defer func() {
-		if err := f.Close(); err != nil {
-			oktetoLog.Debugf("Error closing file %s: %s", path, err)
-		}
-	}()
-
-	return dockerignore.ReadAll(f)
+	return createContextArchive(buildOptions.Path, buildOptions.File, buildOptions.Service, buildOptions.ContextCompression)
 }
 
 // getDockerOptions returns the docker build options
@@ -410,28 +381,36 @@ func getDockerOptions(buildOptions *types.BuildOptions) (dockerTypes.ImageBuildO
 }
 
 func pushImage(ctx context.Context, tag string, client *client.Client) error {
+	dockerCli, responseBody, err := requestImagePush(ctx, tag, client)
+	if err != nil {
+		return err
+	}
+	defer responseBody.Close()
+
+	return jsonmessage.DisplayJSONMessagesToStream(responseBody, dockerCli.Out(), nil)
+}
+
+// requestImagePush starts the push of the image and returns the stream of its progress messages
+func requestImagePush(ctx context.Context, tag string, client *client.Client) (*command.DockerCli, io.ReadCloser, error) {
 	dockerCli, err := command.NewDockerCli()
 	if err != nil {
-		return fmt.Errorf("docker not found")
+		return nil, nil, fmt.Errorf("docker not found")
 	}
 	ref, err := reference.ParseNormalizedNamed(tag)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	repoInfo, err := dockerRegistry.ParseRepositoryInfo(ref)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	authConfig := ResolveAuthConfig(ctx, dockerCli, client, repoInfo)
-	if err != nil {
-		return err
-	}
 
 	encodedAuth, err := command.EncodeAuthToBase64(authConfig)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 	requestPrivilege := command.RegistryAuthenticationPrivilegedFunc(dockerCli, repoInfo.Index, "push")
 	options := dockerTypes.ImagePushOptions{
@@ -441,10 +420,9 @@ func pushImage(ctx context.Context, tag string, client *client.Client) error {
 
 	responseBody, err := client.ImagePush(ctx, tag, options)
 	if err != nil {
-		return errors.Wrap(err, "could not push image")
+		return nil, nil, errors.Wrap(err, "could not push image")
 	}
-
-	return jsonmessage.DisplayJSONMessagesToStream(responseBody, dockerCli.Out(), nil)
+	return dockerCli, responseBody, nil
 }
 
 func ResolveAuthConfig(ctx context.Context, dockerCli *command.DockerCli, cli *client.Client, repoInfo *dockerRegistry.RepositoryInfo) dockerTypes.AuthConfig {
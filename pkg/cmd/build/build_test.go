@@ -166,6 +166,23 @@ func Test_OptsFromBuildInfo(t *testing.T) {
 				BuildArgs:  []string{namespaceEnvVar.String()},
 			},
 		},
+		{
+			name:        "remote-git-context",
+			serviceName: "service",
+			buildInfo: &model.BuildInfo{
+				Context:    "https://github.com/okteto/movies#main:api",
+				Dockerfile: "Dockerfile.prod",
+				Image:      "okteto.dev/movies-api:okteto",
+			},
+			isOkteto: false,
+			expected: &types.BuildOptions{
+				OutputMode: oktetoLog.TTYFormat,
+				Path:       "https://github.com/okteto/movies.git#main:api",
+				File:       "Dockerfile.prod",
+				Tag:        "okteto.dev/movies-api:okteto",
+				BuildArgs:  []string{},
+			},
+		},
 		{
 			name:        "not-okteto-empty-buildInfo",
 			serviceName: "service",
@@ -392,6 +409,10 @@ func Test_OptsFromBuildInfo(t *testing.T) {
 			}
 
 			result := OptsFromBuildInfo(manifest.Name, tt.serviceName, manifest.Build[tt.serviceName], tt.initialOpts, &tt.mr)
+			// the service selects the section of the .oktetoignore
+			tt.expected.Service = tt.serviceName
+			// the manifest name selects the build context cached for the project
+			tt.expected.Project = manifest.Name
 			require.Equal(t, tt.expected, result)
 		})
 	}
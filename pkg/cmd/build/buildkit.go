@@ -17,6 +17,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"net"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -30,10 +31,14 @@ import (
 	"github.com/moby/buildkit/session/sshforward/sshprovider"
 	"github.com/moby/buildkit/util/progress/progressui"
 	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/format"
+	oktetoHttp "github.com/okteto/okteto/pkg/http"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/pkg/errors"
+	"github.com/spf13/afero"
 	"golang.org/x/oauth2"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc/credentials/oauth"
@@ -41,16 +46,47 @@ import (
 
 const (
 	defaultFrontend = "dockerfile.v0"
+
+	// localContextName is the name of the build context uploaded to buildkit
+	localContextName = "context"
+
+	// localSourceDescriptionPrefix is the prefix of the description of the buildkit cache records of the uploaded files
+	localSourceDescriptionPrefix = "local source for "
 )
 
+// getLocalContextName returns the name of the build context uploaded to buildkit. The name includes the project,
+// so the cache records of the uploaded files of each project can be identified in the builder
+func getLocalContextName(project string) string {
+	project = format.ResourceK8sMetaString(project)
+	if project == "" {
+		return localContextName
+	}
+	return fmt.Sprintf("%s-%s", localContextName, project)
+}
+
+// GetCacheRecordProject returns the project of a buildkit cache record of an uploaded build context, or an empty string
+// if the record isn't the build context of a project
+func GetCacheRecordProject(description string) string {
+	name, ok := strings.CutPrefix(description, localSourceDescriptionPrefix)
+	if !ok {
+		return ""
+	}
+	project, ok := strings.CutPrefix(name, localContextName+"-")
+	if !ok {
+		return ""
+	}
+	return project
+}
+
 type buildWriter struct{}
 
 // getSolveOpt returns the buildkit solve options
 func getSolveOpt(buildOptions *types.BuildOptions) (*client.SolveOpt, error) {
 	var localDirs map[string]string
 	var frontendAttrs map[string]string
+	var contextSyncProvider session.Attachable
 
-	if uri, err := url.ParseRequestURI(buildOptions.Path); err != nil || (uri != nil && (uri.Scheme == "" || uri.Host == "")) {
+	if !model.IsRemoteBuildContext(buildOptions.Path) {
 
 		if buildOptions.File == "" {
 			buildOptions.File = filepath.Join(buildOptions.Path, "Dockerfile")
@@ -58,17 +94,33 @@ func getSolveOpt(buildOptions *types.BuildOptions) (*client.SolveOpt, error) {
 		if _, err := os.Stat(buildOptions.File); os.IsNotExist(err) {
 			return nil, fmt.Errorf("Dockerfile '%s' does not exist", buildOptions.File)
 		}
-		localDirs = map[string]string{
-			"context":    buildOptions.Path,
-			"dockerfile": filepath.Dir(buildOptions.File),
+		excludes, err := getUploadExcludes(afero.NewOsFs(), buildOptions.Path, buildOptions.File, buildOptions.Service)
+		if err != nil {
+			return nil, err
+		}
+		contextName := getLocalContextName(buildOptions.Project)
+		if excludes != nil {
+			// the patterns of the .oktetoignore are applied by the client when the context is synced
+			contextSyncProvider = getContextSyncProvider(contextName, buildOptions.Path, filepath.Dir(buildOptions.File), excludes)
+		} else {
+			localDirs = map[string]string{
+				contextName:  buildOptions.Path,
+				"dockerfile": filepath.Dir(buildOptions.File),
+			}
 		}
 		frontendAttrs = map[string]string{
 			"filename": filepath.Base(buildOptions.File),
 		}
+		if contextName != localContextName {
+			frontendAttrs["contextkey"] = contextName
+		}
 	} else {
 		frontendAttrs = map[string]string{
 			"context": buildOptions.Path,
 		}
+		if buildOptions.File != "" {
+			frontendAttrs["filename"] = buildOptions.File
+		}
 	}
 
 	if buildOptions.Platform != "" {
@@ -108,6 +160,10 @@ func getSolveOpt(buildOptions *types.BuildOptions) (*client.SolveOpt, error) {
 		attachable = append(attachable, authprovider.NewDockerAuthProvider(os.Stderr))
 	}
 
+	if contextSyncProvider != nil {
+		attachable = append(attachable, contextSyncProvider)
+	}
+
 	for _, sess := range buildOptions.SshSessions {
 		oktetoLog.Debugf("mounting ssh agent to build from %s with key %s", sess.Target, sess.Id)
 		ssh, err := sshprovider.NewSSHAgentProvider([]sshprovider.AgentConfig{{
@@ -161,6 +217,13 @@ func getSolveOpt(buildOptions *types.BuildOptions) (*client.SolveOpt, error) {
 			}
 		}
 
+	} else if buildOptions.LocalOutputPath != "" {
+		opt.Exports = []client.ExportEntry{
+			{
+				Type:      client.ExporterLocal,
+				OutputDir: buildOptions.LocalOutputPath,
+			},
+		}
 	}
 	for _, cacheFromImage := range buildOptions.CacheFrom {
 		opt.CacheImports = append(
@@ -197,7 +260,8 @@ func getSolveOpt(buildOptions *types.BuildOptions) (*client.SolveOpt, error) {
 	return opt, nil
 }
 
-func getBuildkitClient(ctx context.Context) (*client.Client, error) {
+// GetBuildkitClient returns a client for the builder of the current okteto context
+func GetBuildkitClient(ctx context.Context) (*client.Client, error) {
 	buildkitHost := okteto.Context().Builder
 	octxStore := okteto.ContextStore()
 	for _, octx := range octxStore.Contexts {
@@ -213,6 +277,20 @@ func getBuildkitClient(ctx context.Context) (*client.Client, error) {
 			return nil, fmt.Errorf("certificate decoding error: %w", err)
 		}
 
+		caBundle, err := oktetoHttp.CABundlePEM()
+		if err != nil {
+			oktetoLog.Infof("ignoring CA bundle: %s", err)
+		} else if len(caBundle) > 0 {
+			certBytes = append(append(certBytes, '\n'), caBundle...)
+		}
+
+		pinned, err := getBuildkitPinnedCertificate(ctx)
+		if err != nil {
+			oktetoLog.Infof("ignoring pinned certificate: %s", err)
+		} else if len(pinned) > 0 {
+			certBytes = append(append(certBytes, '\n'), pinned...)
+		}
+
 		if err := os.WriteFile(config.GetCertificatePath(), certBytes, 0600); err != nil {
 			return nil, err
 		}
@@ -247,7 +325,11 @@ func getClientForOktetoCluster(ctx context.Context) (*client.Client, error) {
 	}
 
 	rpc := client.WithRPCCreds(oauth.NewOauthAccess(oauthToken))
-	c, err := client.New(ctx, okteto.Context().Builder, client.WithFailFast(), creds, rpc)
+	opts := []client.ClientOpt{client.WithFailFast(), creds, rpc}
+	if okteto.GetNetworkSettings().Proxy != "" && b.Scheme == "tcp" {
+		opts = append(opts, client.WithContextDialer(dialBuildkitThroughProxy))
+	}
+	c, err := client.New(ctx, okteto.Context().Builder, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -255,6 +337,27 @@ func getClientForOktetoCluster(ctx context.Context) (*client.Client, error) {
 	return c, nil
 }
 
+// getBuildkitPinnedCertificate returns the certificate of the builder if it matches the pinned certificate of the okteto context
+func getBuildkitPinnedCertificate(ctx context.Context) ([]byte, error) {
+	if okteto.GetNetworkSettings().CertificateFingerprint == "" {
+		return nil, nil
+	}
+	b, err := url.Parse(okteto.Context().Builder)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid buildkit host %s", okteto.Context().Builder)
+	}
+	addr := b.Host
+	if b.Port() == "" {
+		addr = net.JoinHostPort(b.Hostname(), "443")
+	}
+	return oktetoHttp.PinnedCertificatePEM(ctx, addr)
+}
+
+// dialBuildkitThroughProxy connects to a 'tcp://' buildkit address through the proxy of the okteto context
+func dialBuildkitThroughProxy(ctx context.Context, addr string) (net.Conn, error) {
+	return oktetoHttp.DialContextThroughProxy(ctx, strings.TrimPrefix(addr, "tcp://"))
+}
+
 func solveBuild(ctx context.Context, c *client.Client, opt *client.SolveOpt, progress string) error {
 	logFilterRules := []Rule{
 		{
@@ -0,0 +1,59 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLocalContextName(t *testing.T) {
+	assert.Equal(t, "context", getLocalContextName(""))
+	assert.Equal(t, "context-movies", getLocalContextName("movies"))
+	assert.Equal(t, "context-my-app", getLocalContextName("My_App"))
+}
+
+func TestGetCacheRecordProject(t *testing.T) {
+	assert.Equal(t, "movies", GetCacheRecordProject("local source for context-movies"))
+	assert.Empty(t, GetCacheRecordProject("local source for context"))
+	assert.Empty(t, GetCacheRecordProject("local source for dockerfile"))
+	assert.Empty(t, GetCacheRecordProject("mount / from exec /bin/sh -c npm install"))
+}
+
+func TestGetSolveOptProjectContext(t *testing.T) {
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		CurrentContext: "test",
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {Name: "test"},
+		},
+	}
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM alpine"), 0600))
+
+	opt, err := getSolveOpt(&types.BuildOptions{Path: dir, Project: "movies"})
+	require.NoError(t, err)
+	assert.Equal(t, "context-movies", opt.FrontendAttrs["contextkey"])
+	assert.Equal(t, map[string]string{"context-movies": dir, "dockerfile": dir}, opt.LocalDirs)
+
+	opt, err = getSolveOpt(&types.BuildOptions{Path: dir})
+	require.NoError(t, err)
+	assert.NotContains(t, opt.FrontendAttrs, "contextkey")
+	assert.Equal(t, map[string]string{"context": dir, "dockerfile": dir}, opt.LocalDirs)
+}
@@ -0,0 +1,263 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/fileutils"
+	"github.com/docker/docker/pkg/idtools"
+	"github.com/docker/go-units"
+	"github.com/klauspost/compress/zstd"
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/session/filesync"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/ignore"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
+	fstypes "github.com/tonistiigi/fsutil/types"
+)
+
+const (
+	// GzipContextCompression compresses the build context with gzip
+	GzipContextCompression = "gzip"
+	// ZstdContextCompression compresses the build context with zstd. It requires Docker Engine 23 or newer
+	ZstdContextCompression = "zstd"
+)
+
+// ContextFile is a file of the build context uploaded to the builder
+type ContextFile struct {
+	// Path is the slash separated path of the file relative to the context
+	Path string
+	Size int64
+}
+
+// ValidateContextCompression returns an error if the compression of the build context is not supported
+func ValidateContextCompression(compression string) error {
+	switch compression {
+	case "", GzipContextCompression, ZstdContextCompression:
+		return nil
+	}
+	return oktetoErrors.UserError{
+		E:    fmt.Errorf("context compression '%s' is not supported", compression),
+		Hint: fmt.Sprintf("Supported compressions are: [%s, %s]", GzipContextCompression, ZstdContextCompression),
+	}
+}
+
+// ListContextFiles returns the files of the build context uploaded to the builder sorted by path,
+// once the patterns of the .dockerignore and the ones of the .oktetoignore that apply to the service are applied
+func ListContextFiles(fs afero.Fs, contextDir, dockerfile, service string) ([]ContextFile, error) {
+	excludes, err := getContextExcludes(fs, contextDir, dockerfile, service)
+	if err != nil {
+		return nil, err
+	}
+	pm, err := fileutils.NewPatternMatcher(excludes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ignore pattern: %w", err)
+	}
+
+	files := []ContextFile{}
+	err = afero.Walk(fs, contextDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(contextDir, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		excluded, err := pm.Matches(rel)
+		if err != nil {
+			return err
+		}
+		if excluded {
+			// the files of an excluded folder can be included again by an exception pattern
+			if info.IsDir() && !pm.Exclusions() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() {
+			return nil
+		}
+		files = append(files, ContextFile{Path: filepath.ToSlash(rel), Size: info.Size()})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+	return files, nil
+}
+
+// ShowContext writes the files of the build context uploaded to the builder and their total size
+func ShowContext(fs afero.Fs, options *types.BuildOptions, out io.Writer) error {
+	path := options.Path
+	if path == "" {
+		path = "."
+	}
+	if model.IsRemoteBuildContext(path) {
+		fmt.Fprintf(out, "The build context '%s' is cloned by the builder, no files are uploaded\n", path)
+		return nil
+	}
+	files, err := ListContextFiles(fs, path, options.File, options.Service)
+	if err != nil {
+		return fmt.Errorf("failed to list the files of the build context '%s': %w", path, err)
+	}
+
+	var total int64
+	w := tabwriter.NewWriter(out, 1, 1, 2, ' ', 0)
+	fmt.Fprintf(w, "File\tSize\n")
+	for _, f := range files {
+		total += f.Size
+		fmt.Fprintf(w, "%s\t%s\n", f.Path, units.HumanSize(float64(f.Size)))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%d files, %s\n", len(files), units.HumanSize(float64(total)))
+	return nil
+}
+
+// getContextExcludes returns the patterns of the files excluded from the build context.
+// The Dockerfile specific '<Dockerfile>.dockerignore' takes precedence over the '.dockerignore' of the context, same as the builder.
+// The patterns of the '.oktetoignore' of the context that apply to the service are added to them
+func getContextExcludes(fs afero.Fs, contextDir, dockerfile, service string) ([]string, error) {
+	dockerignorePath := filepath.Join(contextDir, ignore.DockerIgnoreFile)
+	if dockerfile != "" {
+		if _, err := fs.Stat(dockerfile + ignore.DockerIgnoreFile); err == nil {
+			dockerignorePath = dockerfile + ignore.DockerIgnoreFile
+		}
+	}
+	dockerignore, err := ignore.ReadDockerIgnore(fs, dockerignorePath)
+	if err != nil {
+		return nil, err
+	}
+	oktetoignore, err := ignore.ReadOktetoIgnore(fs, contextDir)
+	if err != nil {
+		return nil, err
+	}
+	return append(dockerignore.Patterns(""), oktetoignore.Patterns(service)...), nil
+}
+
+// getUploadExcludes returns the patterns excluded from the context synced with buildkit when the context has a '.oktetoignore'.
+// It returns nil otherwise, so the builder applies the patterns of the .dockerignore by itself
+func getUploadExcludes(fs afero.Fs, contextDir, dockerfile, service string) ([]string, error) {
+	if _, err := fs.Stat(filepath.Join(contextDir, ignore.OktetoIgnoreFile)); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return getContextExcludes(fs, contextDir, dockerfile, service)
+}
+
+// getContextSyncProvider returns the provider that syncs the context and the Dockerfile folders with buildkit excluding the given patterns
+func getContextSyncProvider(contextName, contextDir, dockerfileDir string, excludes []string) session.Attachable {
+	resetUIDAndGID := func(_ string, st *fstypes.Stat) bool {
+		st.Uid = 0
+		st.Gid = 0
+		return true
+	}
+	return filesync.NewFSSyncProvider([]filesync.SyncedDir{
+		{Name: contextName, Dir: contextDir, Excludes: excludes, Map: resetUIDAndGID},
+		{Name: "dockerfile", Dir: dockerfileDir, Map: resetUIDAndGID},
+	})
+}
+
+// contextArchive is a compressed build context written to a temporary file, which is removed when it's closed
+type contextArchive struct {
+	*os.File
+}
+
+// Close closes and removes the temporary file
+func (a *contextArchive) Close() error {
+	err := a.File.Close()
+	if errRemove := os.Remove(a.File.Name()); errRemove != nil {
+		oktetoLog.Debugf("Error removing file %s: %s", a.File.Name(), errRemove)
+	}
+	return err
+}
+
+// createContextArchive creates the tar of the build context compressed with the given compression.
+// It's written to a temporary file so the upload progress is reported against its actual size
+func createContextArchive(contextDir, dockerfile, service, compression string) (io.ReadCloser, int64, error) {
+	excludes, err := getContextExcludes(afero.NewOsFs(), contextDir, dockerfile, service)
+	if err != nil {
+		return nil, 0, err
+	}
+	tar, err := archive.TarWithOptions(contextDir, &archive.TarOptions{
+		ExcludePatterns: excludes,
+		ChownOpts:       &idtools.Identity{UID: 0, GID: 0},
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	defer tar.Close()
+
+	f, err := os.CreateTemp("", "okteto-context-*.tar")
+	if err != nil {
+		return nil, 0, err
+	}
+	a := &contextArchive{File: f}
+	if err := writeCompressed(f, tar, compression); err != nil {
+		a.Close()
+		return nil, 0, fmt.Errorf("failed to create the build context archive: %w", err)
+	}
+	size, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		a.Close()
+		return nil, 0, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		a.Close()
+		return nil, 0, err
+	}
+	return a, size, nil
+}
+
+func writeCompressed(w io.Writer, r io.Reader, compression string) error {
+	var cw io.WriteCloser
+	switch compression {
+	case GzipContextCompression:
+		cw = gzip.NewWriter(w)
+	case ZstdContextCompression:
+		zw, err := zstd.NewWriter(w)
+		if err != nil {
+			return err
+		}
+		cw = zw
+	default:
+		_, err := io.Copy(w, r)
+		return err
+	}
+	if _, err := io.Copy(cw, r); err != nil {
+		cw.Close()
+		return err
+	}
+	return cw.Close()
+}
@@ -0,0 +1,186 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newContextFs(t *testing.T, files map[string]string) afero.Fs {
+	fs := afero.NewMemMapFs()
+	for name, content := range files {
+		require.NoError(t, afero.WriteFile(fs, filepath.Join("app", name), []byte(content), 0600))
+	}
+	return fs
+}
+
+func TestListContextFiles(t *testing.T) {
+	tests := []struct {
+		name       string
+		files      map[string]string
+		dockerfile string
+		service    string
+		expected   []ContextFile
+	}{
+		{
+			name: "without ignore files",
+			files: map[string]string{
+				"Dockerfile":  "FROM alpine",
+				"src/main.go": "package main",
+			},
+			expected: []ContextFile{
+				{Path: "Dockerfile", Size: 11},
+				{Path: "src/main.go", Size: 12},
+			},
+		},
+		{
+			name: "dockerignore and oktetoignore",
+			files: map[string]string{
+				"Dockerfile":          "FROM alpine",
+				".dockerignore":       "node_modules\n*.md\n!README.md",
+				".oktetoignore":       "tmp",
+				"README.md":           "readme",
+				"CHANGELOG.md":        "changelog",
+				"node_modules/a/a.js": "a",
+				"tmp/cache":           "cache",
+			},
+			expected: []ContextFile{
+				{Path: ".dockerignore", Size: 28},
+				{Path: ".oktetoignore", Size: 3},
+				{Path: "Dockerfile", Size: 11},
+				{Path: "README.md", Size: 6},
+			},
+		},
+		{
+			name: "oktetoignore sections",
+			files: map[string]string{
+				"Dockerfile":    "FROM alpine",
+				".oktetoignore": "*.log\n[api]\nweb\n[web]\napi",
+				"api/main.go":   "package main",
+				"web/index.js":  "x",
+				"debug.log":     "debug",
+			},
+			service: "api",
+			expected: []ContextFile{
+				{Path: ".oktetoignore", Size: 25},
+				{Path: "Dockerfile", Size: 11},
+				{Path: "api/main.go", Size: 12},
+			},
+		},
+		{
+			name: "dockerfile specific dockerignore",
+			files: map[string]string{
+				"api.Dockerfile":              "FROM alpine",
+				"api.Dockerfile.dockerignore": "web",
+				".dockerignore":               "api",
+				"api/main.go":                 "package main",
+				"web/index.js":                "x",
+			},
+			dockerfile: "app/api.Dockerfile",
+			expected: []ContextFile{
+				{Path: ".dockerignore", Size: 3},
+				{Path: "api.Dockerfile", Size: 11},
+				{Path: "api.Dockerfile.dockerignore", Size: 3},
+				{Path: "api/main.go", Size: 12},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := ListContextFiles(newContextFs(t, tt.files), "app", tt.dockerfile, tt.service)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, files)
+		})
+	}
+}
+
+func TestShowContext(t *testing.T) {
+	fs := newContextFs(t, map[string]string{
+		"Dockerfile":    "FROM alpine",
+		".oktetoignore": "*.log",
+		"debug.log":     "debug",
+	})
+
+	out := &bytes.Buffer{}
+	require.NoError(t, ShowContext(fs, &types.BuildOptions{Path: "app", File: "app/Dockerfile"}, out))
+	assert.Equal(t, `File           Size
+.oktetoignore  5B
+Dockerfile     11B
+2 files, 16B
+`, out.String())
+
+	out.Reset()
+	require.NoError(t, ShowContext(fs, &types.BuildOptions{Path: "https://github.com/okteto/movies.git"}, out))
+	assert.Equal(t, "The build context 'https://github.com/okteto/movies.git' is cloned by the builder, no files are uploaded\n", out.String())
+}
+
+func TestGetUploadExcludes(t *testing.T) {
+	excludes, err := getUploadExcludes(newContextFs(t, map[string]string{".dockerignore": "tmp"}), "app", "", "")
+	require.NoError(t, err)
+	assert.Nil(t, excludes)
+
+	excludes, err = getUploadExcludes(newContextFs(t, map[string]string{".dockerignore": "tmp", ".oktetoignore": "*.log\n[api]\nweb"}), "app", "", "api")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tmp", "*.log", "web"}, excludes)
+}
+
+func TestValidateContextCompression(t *testing.T) {
+	assert.NoError(t, ValidateContextCompression(""))
+	assert.NoError(t, ValidateContextCompression(GzipContextCompression))
+	assert.NoError(t, ValidateContextCompression(ZstdContextCompression))
+	assert.Error(t, ValidateContextCompression("xz"))
+}
+
+func TestWriteCompressed(t *testing.T) {
+	content := strings.Repeat("okteto", 100)
+
+	t.Run("uncompressed", func(t *testing.T) {
+		b := &bytes.Buffer{}
+		require.NoError(t, writeCompressed(b, strings.NewReader(content), ""))
+		assert.Equal(t, content, b.String())
+	})
+
+	t.Run("gzip", func(t *testing.T) {
+		b := &bytes.Buffer{}
+		require.NoError(t, writeCompressed(b, strings.NewReader(content), GzipContextCompression))
+		r, err := gzip.NewReader(b)
+		require.NoError(t, err)
+		result, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, content, string(result))
+	})
+
+	t.Run("zstd", func(t *testing.T) {
+		b := &bytes.Buffer{}
+		require.NoError(t, writeCompressed(b, strings.NewReader(content), ZstdContextCompression))
+		assert.Less(t, b.Len(), len(content))
+		r, err := zstd.NewReader(b)
+		require.NoError(t, err)
+		defer r.Close()
+		result, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, content, string(result))
+	})
+}
@@ -0,0 +1,133 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/registry"
+)
+
+const (
+	// defaultEarthlyTarget is the earthly target built if the service doesn't define one
+	defaultEarthlyTarget = "+docker"
+
+	// earthlyImageArg is the earthly argument with the image that the target has to push
+	earthlyImageArg = "OKTETO_IMAGE"
+)
+
+// ExternalBuilder builds the images of the services with a build tool different from BuildKit
+type ExternalBuilder struct {
+	// run executes the build tool in a folder, it is replaced in tests
+	run func(ctx context.Context, dir, binary string, args ...string) error
+	// expandImage translates the okteto registry prefixes of an image
+	expandImage func(image string) string
+}
+
+// NewExternalBuilder creates a builder that runs the build tools available in the PATH
+func NewExternalBuilder() *ExternalBuilder {
+	return &ExternalBuilder{
+		run:         runBuildTool,
+		expandImage: expandOktetoRegistries,
+	}
+}
+
+// Build runs the build tool of the service to build and push the image with the given tag:
+//   - earthly runs the target with '--push' and the image in the 'OKTETO_IMAGE' argument, i.e. 'SAVE IMAGE --push $OKTETO_IMAGE'
+//   - bazel runs the push target with the '--repository' and '--tag' arguments of the image, and the build args as '--define' variables
+func (eb *ExternalBuilder) Build(ctx context.Context, buildInfo *model.BuildInfo, image string) error {
+	image = eb.expandImage(image)
+	binary, args, err := getBuildToolCommand(buildInfo, image)
+	if err != nil {
+		return err
+	}
+	oktetoLog.Information("Building '%s' with %s", image, binary)
+	if err := eb.run(ctx, buildInfo.Context, binary, args...); err != nil {
+		return fmt.Errorf("%s failed to build '%s': %w", binary, image, err)
+	}
+	return nil
+}
+
+// getBuildToolCommand returns the binary and the arguments that build and push the image of a service
+func getBuildToolCommand(buildInfo *model.BuildInfo, image string) (string, []string, error) {
+	switch buildInfo.Builder {
+	case model.EarthlyBuilder:
+		target := buildInfo.Target
+		if target == "" {
+			target = defaultEarthlyTarget
+		}
+		if !strings.Contains(target, "+") {
+			target = fmt.Sprintf("+%s", target)
+		}
+		args := []string{"--push", target, fmt.Sprintf("--%s=%s", earthlyImageArg, image)}
+		for _, arg := range buildInfo.Args {
+			args = append(args, fmt.Sprintf("--%s=%s", arg.Name, arg.Value))
+		}
+		return model.EarthlyBuilder, args, nil
+	case model.BazelBuilder:
+		repository, tag := splitImageTag(image)
+		args := []string{"run"}
+		for _, arg := range buildInfo.Args {
+			args = append(args, fmt.Sprintf("--define=%s=%s", arg.Name, arg.Value))
+		}
+		args = append(args, buildInfo.Target, "--", "--repository", repository)
+		if tag != "" {
+			args = append(args, "--tag", tag)
+		}
+		return model.BazelBuilder, args, nil
+	default:
+		return "", nil, fmt.Errorf("builder '%s' is not supported", buildInfo.Builder)
+	}
+}
+
+// splitImageTag returns the repository and the tag of an image
+func splitImageTag(image string) (string, string) {
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return image, ""
+	}
+	return image[:i], image[i+1:]
+}
+
+func expandOktetoRegistries(image string) string {
+	if !okteto.IsOkteto() {
+		return image
+	}
+	imageCtrl := registry.NewImageCtrl(okteto.Config{})
+	return imageCtrl.ExpandOktetoGlobalRegistry(imageCtrl.ExpandOktetoDevRegistry(image))
+}
+
+func runBuildTool(ctx context.Context, dir, binary string, args ...string) error {
+	if _, err := exec.LookPath(binary); err != nil {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("%s is required to build the images with 'builder: %s'", binary, binary),
+			Hint: fmt.Sprintf("Install %s and make sure it is available in your PATH", binary),
+		}
+	}
+	oktetoLog.Infof("running %s %s", binary, strings.Join(args, " "))
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Dir = dir
+	cmd.Env = os.Environ()
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
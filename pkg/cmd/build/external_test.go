@@ -0,0 +1,120 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_getBuildToolCommand(t *testing.T) {
+	var tests = []struct {
+		name         string
+		buildInfo    *model.BuildInfo
+		image        string
+		expectedBin  string
+		expectedArgs []string
+		expectedErr  bool
+	}{
+		{
+			name:         "earthly default target",
+			buildInfo:    &model.BuildInfo{Builder: model.EarthlyBuilder},
+			image:        "registry.okteto.dev/cindy/api:okteto",
+			expectedBin:  "earthly",
+			expectedArgs: []string{"--push", "+docker", "--OKTETO_IMAGE=registry.okteto.dev/cindy/api:okteto"},
+		},
+		{
+			name: "earthly target with args",
+			buildInfo: &model.BuildInfo{
+				Builder: model.EarthlyBuilder,
+				Target:  "image",
+				Args:    model.BuildArgs{{Name: "VERSION", Value: "1.0"}},
+			},
+			image:        "registry.okteto.dev/cindy/api:okteto",
+			expectedBin:  "earthly",
+			expectedArgs: []string{"--push", "+image", "--OKTETO_IMAGE=registry.okteto.dev/cindy/api:okteto", "--VERSION=1.0"},
+		},
+		{
+			name:         "earthly remote target",
+			buildInfo:    &model.BuildInfo{Builder: model.EarthlyBuilder, Target: "./services/api+docker"},
+			image:        "registry.okteto.dev/cindy/api:okteto",
+			expectedBin:  "earthly",
+			expectedArgs: []string{"--push", "./services/api+docker", "--OKTETO_IMAGE=registry.okteto.dev/cindy/api:okteto"},
+		},
+		{
+			name:         "bazel",
+			buildInfo:    &model.BuildInfo{Builder: model.BazelBuilder, Target: "//api:push"},
+			image:        "localhost:5000/cindy/api:okteto",
+			expectedBin:  "bazel",
+			expectedArgs: []string{"run", "//api:push", "--", "--repository", "localhost:5000/cindy/api", "--tag", "okteto"},
+		},
+		{
+			name: "bazel with args",
+			buildInfo: &model.BuildInfo{
+				Builder: model.BazelBuilder,
+				Target:  "//api:push",
+				Args:    model.BuildArgs{{Name: "VERSION", Value: "1.0"}},
+			},
+			image:        "localhost:5000/cindy/api:okteto",
+			expectedBin:  "bazel",
+			expectedArgs: []string{"run", "--define=VERSION=1.0", "//api:push", "--", "--repository", "localhost:5000/cindy/api", "--tag", "okteto"},
+		},
+		{
+			name:         "bazel without tag",
+			buildInfo:    &model.BuildInfo{Builder: model.BazelBuilder, Target: "//api:push"},
+			image:        "localhost:5000/cindy/api",
+			expectedBin:  "bazel",
+			expectedArgs: []string{"run", "//api:push", "--", "--repository", "localhost:5000/cindy/api"},
+		},
+		{
+			name:        "unknown builder",
+			buildInfo:   &model.BuildInfo{Builder: "pants"},
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bin, args, err := getBuildToolCommand(tt.buildInfo, tt.image)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedBin, bin)
+			assert.Equal(t, tt.expectedArgs, args)
+		})
+	}
+}
+
+func TestExternalBuilderBuild(t *testing.T) {
+	var dir, binary string
+	eb := &ExternalBuilder{
+		run: func(_ context.Context, d, b string, _ ...string) error {
+			dir, binary = d, b
+			return errors.New("exit status 1")
+		},
+		expandImage: func(image string) string {
+			return "registry.okteto.dev/cindy/api:okteto"
+		},
+	}
+	err := eb.Build(context.Background(), &model.BuildInfo{Context: "api", Builder: model.EarthlyBuilder}, "okteto.dev/api:okteto")
+	assert.EqualError(t, err, "earthly failed to build 'registry.okteto.dev/cindy/api:okteto': exit status 1")
+	assert.Equal(t, "api", dir)
+	assert.Equal(t, "earthly", binary)
+}
@@ -0,0 +1,201 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/types"
+)
+
+// dependencyInstallCommands are the commands that install the dependencies of a project
+var dependencyInstallCommands = []string{
+	"npm install", "npm ci", "yarn install", "pip install -r", "go mod download", "bundle install", "composer install",
+}
+
+// LintWarning is a Dockerfile best practice that is not followed
+type LintWarning struct {
+	Line    int
+	Message string
+}
+
+// LintDockerfile returns the warnings of the Dockerfile of the build: missing USER, un-pinned base images and cache-busting patterns.
+// The build fails if there are warnings and the strict option is set
+func LintDockerfile(options *types.BuildOptions) error {
+	if options.NoLint || options.File == "" {
+		return nil
+	}
+	content, err := os.ReadFile(options.File)
+	if err != nil {
+		oktetoLog.Infof("could not read %s to check the best practices: %s", options.File, err)
+		return nil
+	}
+	warnings, err := lintDockerfile(bytes.NewReader(content))
+	if err != nil {
+		// the builder reports the syntax errors of the Dockerfile
+		oktetoLog.Infof("could not parse %s to check the best practices: %s", options.File, err)
+		return nil
+	}
+	for _, w := range warnings {
+		oktetoLog.Warning("%s:%d: %s", options.File, w.Line, w.Message)
+	}
+	if options.Strict && len(warnings) > 0 {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("'%s' doesn't follow %d Dockerfile best practices", options.File, len(warnings)),
+			Hint: "Fix the warnings or run the build without the '--strict' flag",
+		}
+	}
+	return nil
+}
+
+func lintDockerfile(r io.Reader) ([]LintWarning, error) {
+	result, err := parser.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	warnings := []LintWarning{}
+	stages := map[string]bool{}
+	var lastFrom *parser.Node
+	var user string
+	var copiedContext *parser.Node
+	for _, node := range result.AST.Children {
+		args := getNodeArgs(node)
+		switch strings.ToLower(node.Value) {
+		case "from":
+			lastFrom = node
+			user = ""
+			copiedContext = nil
+			if len(args) == 0 {
+				continue
+			}
+			if !isPinnedBaseImage(args[0], stages) {
+				warnings = append(warnings, LintWarning{
+					Line:    node.StartLine,
+					Message: fmt.Sprintf("base image '%s' is not pinned, use a specific tag or digest so the builds are reproducible", args[0]),
+				})
+			}
+			if len(args) == 3 && strings.EqualFold(args[1], "as") {
+				stages[strings.ToLower(args[2])] = true
+			}
+		case "user":
+			if len(args) > 0 {
+				user = args[0]
+			}
+		case "add", "copy":
+			if strings.EqualFold(node.Value, "add") && hasRemoteSource(args) {
+				warnings = append(warnings, LintWarning{
+					Line:    node.StartLine,
+					Message: "'ADD' of a remote URL downloads the file on every build, use 'ADD --checksum' or download it in a 'RUN' instruction",
+				})
+			}
+			if copiedContext == nil && copiesBuildContext(node, args) {
+				copiedContext = node
+			}
+		case "run":
+			command := strings.Join(args, " ")
+			if strings.Contains(command, "apt-get update") && !strings.Contains(command, "apt-get install") {
+				warnings = append(warnings, LintWarning{
+					Line:    node.StartLine,
+					Message: "'apt-get update' is cached on its own and installs outdated packages, run it in the same 'RUN' instruction as 'apt-get install'",
+				})
+			}
+			if copiedContext != nil && installsDependencies(command) {
+				warnings = append(warnings, LintWarning{
+					Line:    copiedContext.StartLine,
+					Message: "the whole build context is copied before installing the dependencies, any change invalidates their cache. Copy the dependency files first",
+				})
+				copiedContext = nil
+			}
+		}
+	}
+
+	if lastFrom != nil && (user == "" || user == "root" || strings.HasPrefix(user, "0:") || user == "0") {
+		warnings = append(warnings, LintWarning{
+			Line:    lastFrom.StartLine,
+			Message: "the image runs as root, add a 'USER' instruction with a non-root user to the final stage",
+		})
+	}
+	return warnings, nil
+}
+
+// getNodeArgs returns the arguments of an instruction without its flags
+func getNodeArgs(node *parser.Node) []string {
+	result := []string{}
+	for n := node.Next; n != nil; n = n.Next {
+		result = append(result, n.Value)
+	}
+	return result
+}
+
+// isPinnedBaseImage returns true if the image has a tag different from latest or a digest. Previous stages, scratch and images defined by build args are not checked
+func isPinnedBaseImage(image string, stages map[string]bool) bool {
+	if image == "scratch" || stages[strings.ToLower(image)] || strings.Contains(image, "$") {
+		return true
+	}
+	if strings.Contains(image, "@") {
+		return true
+	}
+	name := image[strings.LastIndex(image, "/")+1:]
+	i := strings.LastIndex(name, ":")
+	return i >= 0 && name[i+1:] != "latest"
+}
+
+func hasRemoteSource(args []string) bool {
+	for _, src := range getSources(args) {
+		if strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://") {
+			return true
+		}
+	}
+	return false
+}
+
+// copiesBuildContext returns true if the instruction copies the whole build context instead of some files
+func copiesBuildContext(node *parser.Node, args []string) bool {
+	for _, flag := range node.Flags {
+		if strings.HasPrefix(flag, "--from") {
+			return false
+		}
+	}
+	for _, src := range getSources(args) {
+		if src == "." || src == "./" {
+			return true
+		}
+	}
+	return false
+}
+
+func installsDependencies(command string) bool {
+	for _, c := range dependencyInstallCommands {
+		if strings.Contains(command, c) {
+			return true
+		}
+	}
+	return false
+}
+
+// getSources returns the sources of an ADD or COPY instruction, all the arguments but the destination
+func getSources(args []string) []string {
+	if len(args) < 2 {
+		return nil
+	}
+	return args[:len(args)-1]
+}
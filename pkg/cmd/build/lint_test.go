@@ -0,0 +1,105 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_lintDockerfile(t *testing.T) {
+	var tests = []struct {
+		name       string
+		dockerfile string
+		expected   []int
+	}{
+		{
+			name: "best practices",
+			dockerfile: `FROM golang:1.20 AS builder
+WORKDIR /app
+COPY go.mod go.sum ./
+RUN go mod download
+COPY . .
+RUN go build -o /app/server
+
+FROM scratch
+COPY --from=builder /app/server /server
+USER 1000
+`,
+			expected: []int{},
+		},
+		{
+			name: "un-pinned base images",
+			dockerfile: `ARG BASE=alpine:3.18
+FROM node AS deps
+FROM registry.okteto.dev/cindy/base:latest
+FROM deps
+FROM ${BASE}
+FROM localhost:5000/base@sha256:7075f1094117e418764bb9b47a5dfc093466e714ec385223fb582d78220c7252
+USER app
+`,
+			expected: []int{2, 3},
+		},
+		{
+			name: "runs as root",
+			dockerfile: `FROM alpine:3.18 AS builder
+USER app
+FROM alpine:3.18
+USER root
+`,
+			expected: []int{3},
+		},
+		{
+			name: "cache busting",
+			dockerfile: `FROM debian:12
+RUN apt-get update
+RUN apt-get update && apt-get install -y curl
+ADD https://example.com/app.tar.gz /tmp/
+COPY . .
+RUN npm ci
+USER app
+`,
+			expected: []int{2, 4, 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := lintDockerfile(strings.NewReader(tt.dockerfile))
+			require.NoError(t, err)
+			lines := []int{}
+			for _, w := range warnings {
+				lines = append(lines, w.Line)
+			}
+			assert.Equal(t, tt.expected, lines)
+		})
+	}
+}
+
+func TestLintDockerfile(t *testing.T) {
+	dockerfile := filepath.Join(t.TempDir(), "Dockerfile")
+	require.NoError(t, os.WriteFile(dockerfile, []byte("FROM alpine\n"), 0600))
+
+	assert.NoError(t, LintDockerfile(&types.BuildOptions{File: dockerfile}))
+	assert.NoError(t, LintDockerfile(&types.BuildOptions{File: dockerfile, Strict: true, NoLint: true}))
+
+	err := LintDockerfile(&types.BuildOptions{File: dockerfile, Strict: true})
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+}
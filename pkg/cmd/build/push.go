@@ -0,0 +1,293 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/jsonmessage"
+	units "github.com/docker/go-units"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// maxConcurrentPushes is the number of images pushed at the same time by the push pipeline
+	maxConcurrentPushes = 3
+
+	pushRefreshInterval = 200 * time.Millisecond
+)
+
+// imagePusher pushes an image and sends its progress messages to onProgress
+type imagePusher func(ctx context.Context, tag string, onProgress func(jsonmessage.JSONMessage)) error
+
+// PushResult is the result of an image pushed by the push pipeline
+type PushResult struct {
+	Tag      string
+	Duration time.Duration
+	Err      error
+}
+
+// PushPipeline pushes the images built by the docker daemon in the background, so the next images are built while the previous ones are pushed.
+// The progress of the pushes is shown as a single view once all the images are built, instead of interleaving the progress bars of every push
+type PushPipeline struct {
+	push        imagePusher
+	sem         chan struct{}
+	wg          sync.WaitGroup
+	mu          sync.Mutex
+	pushes      []*imagePush
+	out         io.Writer
+	interactive bool
+	now         func() time.Time
+}
+
+type imagePush struct {
+	tag    string
+	start  time.Time
+	end    time.Time
+	layers map[string]*layerProgress
+	// order keeps the layers in the order they are reported by the daemon
+	order []string
+	done  bool
+	err   error
+}
+
+type layerProgress struct {
+	current int64
+	total   int64
+	done    bool
+}
+
+// NewPushPipeline returns a pipeline that pushes the images with the local docker daemon
+func NewPushPipeline() *PushPipeline {
+	return newPushPipeline(pushImageWithProgress, maxConcurrentPushes, oktetoLog.GetOutput(), oktetoLog.IsInteractive())
+}
+
+func newPushPipeline(push imagePusher, concurrency int, out io.Writer, interactive bool) *PushPipeline {
+	return &PushPipeline{
+		push:        push,
+		sem:         make(chan struct{}, concurrency),
+		out:         out,
+		interactive: interactive,
+		now:         time.Now,
+	}
+}
+
+// Enqueue starts the push of an image as soon as there is a free slot in the pipeline
+func (p *PushPipeline) Enqueue(ctx context.Context, tag string) {
+	ip := &imagePush{tag: tag, layers: map[string]*layerProgress{}}
+	p.mu.Lock()
+	p.pushes = append(p.pushes, ip)
+	p.mu.Unlock()
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		select {
+		case p.sem <- struct{}{}:
+		case <-ctx.Done():
+			p.finish(ip, ctx.Err())
+			return
+		}
+		defer func() { <-p.sem }()
+		if err := ctx.Err(); err != nil {
+			p.finish(ip, err)
+			return
+		}
+
+		oktetoLog.Infof("pushing image '%s' in the background", tag)
+		p.mu.Lock()
+		ip.start = p.now()
+		p.mu.Unlock()
+		err := p.push(ctx, tag, func(m jsonmessage.JSONMessage) {
+			p.update(ip, m)
+		})
+		p.finish(ip, err)
+	}()
+}
+
+// Wait shows the progress of the pushes until all of them finish and returns their results in the order they were enqueued
+func (p *PushPipeline) Wait() []PushResult {
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	p.mu.Lock()
+	pending := len(p.pushes)
+	p.mu.Unlock()
+	if pending > 0 {
+		if p.interactive {
+			p.render(done)
+		} else {
+			oktetoLog.Information("Waiting for %d images to be pushed...", pending)
+		}
+	}
+	<-done
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	results := make([]PushResult, 0, len(p.pushes))
+	for _, ip := range p.pushes {
+		r := PushResult{Tag: ip.tag, Err: ip.err}
+		if !ip.start.IsZero() {
+			r.Duration = ip.end.Sub(ip.start)
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+// render redraws the progress of every push in place until done is closed
+func (p *PushPipeline) render(done <-chan struct{}) {
+	ticker := time.NewTicker(pushRefreshInterval)
+	defer ticker.Stop()
+
+	lines := 0
+	for {
+		select {
+		case <-done:
+			p.redraw(lines)
+			return
+		case <-ticker.C:
+			lines = p.redraw(lines)
+		}
+	}
+}
+
+// redraw moves the cursor up the previous lines and writes the current progress, returning the number of lines written
+func (p *PushPipeline) redraw(previous int) int {
+	p.mu.Lock()
+	now := p.now()
+	lines := make([]string, 0, len(p.pushes))
+	for _, ip := range p.pushes {
+		lines = append(lines, ip.progressLine(now))
+	}
+	p.mu.Unlock()
+
+	b := &strings.Builder{}
+	if previous > 0 {
+		fmt.Fprintf(b, "\x1b[%dA", previous)
+	}
+	for _, l := range lines {
+		fmt.Fprintf(b, "\r\x1b[2K%s\n", l)
+	}
+	fmt.Fprint(p.out, b.String())
+	return len(lines)
+}
+
+func (p *PushPipeline) update(ip *imagePush, m jsonmessage.JSONMessage) {
+	if m.ID == "" {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	l, ok := ip.layers[m.ID]
+	if !ok {
+		// the first message of a push is about the repository, not a layer
+		if m.Progress == nil && !isLayerStatus(m.Status) {
+			return
+		}
+		l = &layerProgress{}
+		ip.layers[m.ID] = l
+		ip.order = append(ip.order, m.ID)
+	}
+	if m.Progress != nil && m.Progress.Total > 0 {
+		l.current = m.Progress.Current
+		l.total = m.Progress.Total
+	}
+	if m.Status == "Pushed" || m.Status == "Layer already exists" || strings.HasPrefix(m.Status, "Mounted from") {
+		l.done = true
+		l.current = l.total
+	}
+}
+
+func isLayerStatus(status string) bool {
+	switch status {
+	case "Preparing", "Waiting", "Pushing", "Pushed", "Layer already exists":
+		return true
+	}
+	return strings.HasPrefix(status, "Mounted from")
+}
+
+func (p *PushPipeline) finish(ip *imagePush, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ip.done = true
+	ip.err = err
+	ip.end = p.now()
+}
+
+// progressLine returns the status of the push aggregating the progress of all its layers
+func (ip *imagePush) progressLine(now time.Time) string {
+	switch {
+	case ip.done && ip.err != nil:
+		return fmt.Sprintf(" x %s: %s", ip.tag, ip.err)
+	case ip.done:
+		return fmt.Sprintf(" ✓ %s: pushed in %s", ip.tag, ip.end.Sub(ip.start).Round(time.Second))
+	case ip.start.IsZero():
+		return fmt.Sprintf(" - %s: waiting", ip.tag)
+	}
+
+	var current, total int64
+	layersDone := 0
+	for _, id := range ip.order {
+		l := ip.layers[id]
+		current += l.current
+		total += l.total
+		if l.done {
+			layersDone++
+		}
+	}
+	line := fmt.Sprintf(" - %s: pushing %d/%d layers", ip.tag, layersDone, len(ip.order))
+	if total > 0 {
+		line = fmt.Sprintf("%s, %s/%s", line, units.HumanSize(float64(current)), units.HumanSize(float64(total)))
+	}
+	return fmt.Sprintf("%s (%s)", line, now.Sub(ip.start).Round(time.Second))
+}
+
+// pushImageWithProgress pushes the image with the local docker daemon and sends its progress messages to onProgress
+func pushImageWithProgress(ctx context.Context, tag string, onProgress func(jsonmessage.JSONMessage)) error {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return err
+	}
+	_, responseBody, err := requestImagePush(ctx, tag, cli)
+	if err != nil {
+		return err
+	}
+	defer responseBody.Close()
+
+	decoder := json.NewDecoder(responseBody)
+	for {
+		var m jsonmessage.JSONMessage
+		if err := decoder.Decode(&m); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if m.Error != nil {
+			return m.Error
+		}
+		onProgress(m)
+	}
+}
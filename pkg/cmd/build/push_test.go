@@ -0,0 +1,144 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushPipeline(t *testing.T) {
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	push := func(_ context.Context, tag string, onProgress func(jsonmessage.JSONMessage)) error {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}()
+
+		onProgress(jsonmessage.JSONMessage{ID: "layer", Status: "Pushing", Progress: &jsonmessage.JSONProgress{Current: 5, Total: 10}})
+		time.Sleep(10 * time.Millisecond)
+		if tag == "okteto/b" {
+			return errors.New("denied")
+		}
+		return nil
+	}
+
+	out := &bytes.Buffer{}
+	p := newPushPipeline(push, 2, out, false)
+	for _, tag := range []string{"okteto/a", "okteto/b", "okteto/c", "okteto/d"} {
+		p.Enqueue(context.Background(), tag)
+	}
+	results := p.Wait()
+
+	require.Len(t, results, 4)
+	assert.Equal(t, "okteto/a", results[0].Tag)
+	assert.NoError(t, results[0].Err)
+	assert.Greater(t, results[0].Duration, time.Duration(0))
+	assert.Equal(t, "okteto/b", results[1].Tag)
+	assert.EqualError(t, results[1].Err, "denied")
+	assert.Equal(t, "okteto/d", results[3].Tag)
+	assert.LessOrEqual(t, maxRunning, 2)
+	assert.Empty(t, out.String())
+}
+
+func TestPushPipelineCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	push := func(_ context.Context, _ string, _ func(jsonmessage.JSONMessage)) error {
+		once.Do(func() { close(started) })
+		<-release
+		return nil
+	}
+	p := newPushPipeline(push, 1, &bytes.Buffer{}, false)
+	p.Enqueue(ctx, "okteto/a")
+	<-started
+	p.Enqueue(ctx, "okteto/b")
+	cancel()
+	close(release)
+
+	results := p.Wait()
+	require.Len(t, results, 2)
+	assert.NoError(t, results[0].Err)
+	assert.ErrorIs(t, results[1].Err, context.Canceled)
+}
+
+func TestPushPipelineRender(t *testing.T) {
+	release := make(chan struct{})
+	push := func(_ context.Context, _ string, _ func(jsonmessage.JSONMessage)) error {
+		<-release
+		return nil
+	}
+	out := &bytes.Buffer{}
+	p := newPushPipeline(push, 1, out, true)
+	p.Enqueue(context.Background(), "okteto/a")
+	p.Enqueue(context.Background(), "okteto/b")
+
+	go func() {
+		time.Sleep(3 * pushRefreshInterval)
+		close(release)
+	}()
+	p.Wait()
+
+	// the lines are redrawn in place, and the last view shows both images pushed
+	assert.Contains(t, out.String(), "\x1b[2A")
+	output := out.String()
+	last := output[strings.LastIndex(output, "\x1b[2A"):]
+	assert.Contains(t, last, "okteto/a: pushed in")
+	assert.Contains(t, last, "okteto/b: pushed in")
+}
+
+func TestProgressLine(t *testing.T) {
+	start := time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)
+	p := newPushPipeline(nil, 1, &bytes.Buffer{}, false)
+	ip := &imagePush{tag: "okteto/api", layers: map[string]*layerProgress{}}
+
+	assert.Equal(t, " - okteto/api: waiting", ip.progressLine(start))
+
+	ip.start = start
+	p.update(ip, jsonmessage.JSONMessage{ID: "latest", Status: "The push refers to repository [docker.io/okteto/api]"})
+	p.update(ip, jsonmessage.JSONMessage{ID: "1", Status: "Preparing"})
+	p.update(ip, jsonmessage.JSONMessage{ID: "2", Status: "Preparing"})
+	p.update(ip, jsonmessage.JSONMessage{ID: "3", Status: "Preparing"})
+	p.update(ip, jsonmessage.JSONMessage{ID: "1", Status: "Pushing", Progress: &jsonmessage.JSONProgress{Current: 1000000, Total: 4000000}})
+	p.update(ip, jsonmessage.JSONMessage{ID: "2", Status: "Pushing", Progress: &jsonmessage.JSONProgress{Current: 2000000, Total: 2000000}})
+	p.update(ip, jsonmessage.JSONMessage{ID: "2", Status: "Pushed"})
+	p.update(ip, jsonmessage.JSONMessage{ID: "3", Status: "Layer already exists"})
+	assert.Equal(t, " - okteto/api: pushing 2/3 layers, 3MB/6MB (5s)", ip.progressLine(start.Add(5*time.Second)))
+
+	p.now = func() time.Time { return start.Add(12 * time.Second) }
+	p.finish(ip, nil)
+	assert.Equal(t, " ✓ okteto/api: pushed in 12s", ip.progressLine(start))
+
+	p.finish(ip, errors.New("denied"))
+	assert.Equal(t, " x okteto/api: denied", ip.progressLine(start))
+}
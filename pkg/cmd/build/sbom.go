@@ -0,0 +1,152 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+)
+
+const (
+	// SBOMFormatSPDX generates SPDX json documents
+	SBOMFormatSPDX = "spdx"
+	// SBOMFormatCycloneDX generates CycloneDX json documents
+	SBOMFormatCycloneDX = "cyclonedx"
+
+	syftBinary   = "syft"
+	cosignBinary = "cosign"
+)
+
+// sbomFormats maps the supported formats to the syft output and the cosign predicate type
+var sbomFormats = map[string]struct {
+	syftOutput    string
+	predicateType string
+}{
+	SBOMFormatSPDX:      {syftOutput: "spdx-json", predicateType: "spdxjson"},
+	SBOMFormatCycloneDX: {syftOutput: "cyclonedx-json", predicateType: "cyclonedx"},
+}
+
+// ValidateSBOMFormat returns an error if the format is not supported
+func ValidateSBOMFormat(format string) error {
+	if format == "" {
+		return nil
+	}
+	if _, ok := sbomFormats[strings.ToLower(format)]; !ok {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("sbom format '%s' is not supported", format),
+			Hint: fmt.Sprintf("Supported formats are: [%s, %s]", SBOMFormatSPDX, SBOMFormatCycloneDX),
+		}
+	}
+	return nil
+}
+
+// SBOMGenerator generates the software bill of materials of images and attaches them as attestations
+type SBOMGenerator struct {
+	// run executes the binary and returns its output, it is replaced in tests
+	run func(ctx context.Context, binary string, env []string, args ...string) ([]byte, error)
+}
+
+// NewSBOMGenerator creates a generator that runs the syft and cosign binaries available in the PATH
+func NewSBOMGenerator() *SBOMGenerator {
+	return &SBOMGenerator{run: runBinary}
+}
+
+// Generate writes the SBOM of the image in the given format into the output folder and returns the path of the document
+func (g *SBOMGenerator) Generate(ctx context.Context, image, format, outputDir string) (string, error) {
+	format = strings.ToLower(format)
+	f, ok := sbomFormats[format]
+	if !ok {
+		return "", ValidateSBOMFormat(format)
+	}
+	if outputDir == "" {
+		outputDir = "."
+	}
+	if err := os.MkdirAll(outputDir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", outputDir, err)
+	}
+	file := filepath.Join(outputDir, GetSBOMFileName(image, format))
+
+	env := []string{}
+	if okteto.IsOkteto() {
+		// syft pulls the image from the registry so it needs the credentials of the okteto registry
+		env = append(env,
+			fmt.Sprintf("SYFT_REGISTRY_AUTH_AUTHORITY=%s", okteto.Context().Registry),
+			fmt.Sprintf("SYFT_REGISTRY_AUTH_USERNAME=%s", okteto.Context().UserID),
+			fmt.Sprintf("SYFT_REGISTRY_AUTH_PASSWORD=%s", okteto.Context().Token),
+		)
+	}
+	if _, err := g.run(ctx, syftBinary, env, fmt.Sprintf("registry:%s", image), "--quiet", "-o", fmt.Sprintf("%s=%s", f.syftOutput, file)); err != nil {
+		return "", fmt.Errorf("error generating sbom of '%s': %w", image, err)
+	}
+	return file, nil
+}
+
+// Attest attaches the SBOM document to the image as a cosign compatible attestation
+func (g *SBOMGenerator) Attest(ctx context.Context, image, format, file, key string) error {
+	f, ok := sbomFormats[strings.ToLower(format)]
+	if !ok {
+		return ValidateSBOMFormat(format)
+	}
+	args := []string{"attest", "--yes", "--type", f.predicateType, "--predicate", file}
+	if key != "" {
+		args = append(args, "--key", key)
+	}
+	args = append(args, image)
+	env, cleanup, err := getCosignRegistryEnv()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	if _, err := g.run(ctx, cosignBinary, env, args...); err != nil {
+		return fmt.Errorf("error attaching sbom attestation to '%s': %w", image, err)
+	}
+	return nil
+}
+
+// GetSBOMFileName returns the name of the SBOM document of an image, i.e. "api-1.0.spdx.json"
+func GetSBOMFileName(image, format string) string {
+	name, _, _ := strings.Cut(image, "@")
+	name = path.Base(name)
+	name = strings.ReplaceAll(name, ":", "-")
+	return fmt.Sprintf("%s.%s.json", name, strings.ToLower(format))
+}
+
+func runBinary(ctx context.Context, binary string, env []string, args ...string) ([]byte, error) {
+	if _, err := exec.LookPath(binary); err != nil {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("%s is required to generate the sbom of your images", binary),
+			Hint: fmt.Sprintf("Install %s and make sure it is available in your PATH", binary),
+		}
+	}
+	oktetoLog.Infof("running %s %s", binary, strings.Join(args, " "))
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Env = append(os.Environ(), env...)
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
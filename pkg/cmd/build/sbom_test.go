@@ -0,0 +1,57 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSBOMGenerateAndAttest(t *testing.T) {
+	calls := [][]string{}
+	g := &SBOMGenerator{
+		run: func(_ context.Context, binary string, _ []string, args ...string) ([]byte, error) {
+			calls = append(calls, append([]string{binary}, args...))
+			return nil, nil
+		},
+	}
+	dir := t.TempDir()
+	image := "registry.okteto.dev/cindy/api:1.0@sha256:1234"
+
+	file, err := g.Generate(context.Background(), image, "CycloneDX", dir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "api-1.0.cyclonedx.json"), file)
+
+	require.NoError(t, g.Attest(context.Background(), image, "cyclonedx", file, "cosign.key"))
+	assert.Equal(t, [][]string{
+		{"syft", "registry:" + image, "--quiet", "-o", "cyclonedx-json=" + file},
+		{"cosign", "attest", "--yes", "--type", "cyclonedx", "--predicate", file, "--key", "cosign.key", image},
+	}, calls)
+}
+
+func TestValidateSBOMFormat(t *testing.T) {
+	assert.NoError(t, ValidateSBOMFormat(""))
+	assert.NoError(t, ValidateSBOMFormat("spdx"))
+	assert.NoError(t, ValidateSBOMFormat("CycloneDX"))
+	assert.Error(t, ValidateSBOMFormat("syft"))
+}
+
+func TestGetSBOMFileName(t *testing.T) {
+	assert.Equal(t, "api.spdx.json", GetSBOMFileName("okteto.dev/api", "spdx"))
+	assert.Equal(t, "api-1.0.spdx.json", GetSBOMFileName("registry.okteto.dev/cindy/api:1.0@sha256:1234", "spdx"))
+}
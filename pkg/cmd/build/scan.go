@@ -0,0 +1,163 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/Masterminds/semver/v3"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+)
+
+const trivyBinary = "trivy"
+
+// minTrivyVersion is the first version of trivy with the '--scanners' flag used to scan the images
+var minTrivyVersion = semver.MustParse("0.37.0")
+
+// Vulnerability represents a vulnerability found in an image
+type Vulnerability struct {
+	ID       string `json:"VulnerabilityID"`
+	Package  string `json:"PkgName"`
+	Severity string `json:"Severity"`
+}
+
+// ScanReport represents the vulnerabilities found in an image
+type ScanReport struct {
+	Image           string
+	Vulnerabilities []Vulnerability
+}
+
+// CountBySeverity returns the number of vulnerabilities of each severity
+func (r *ScanReport) CountBySeverity() map[string]int {
+	result := map[string]int{}
+	for _, v := range r.Vulnerabilities {
+		result[strings.ToUpper(v.Severity)]++
+	}
+	return result
+}
+
+// trivyReport is the subset of the trivy json output used by okteto
+type trivyReport struct {
+	Results []struct {
+		Target          string          `json:"Target"`
+		Vulnerabilities []Vulnerability `json:"Vulnerabilities"`
+	} `json:"Results"`
+}
+
+// TrivyScanner scans images for vulnerabilities using trivy.
+// Okteto doesn't include trivy: it runs the trivy binary of the PATH, whose version is checked before the first scan
+type TrivyScanner struct {
+	// run executes trivy and returns its output, it is replaced in tests
+	run func(ctx context.Context, env []string, args ...string) ([]byte, error)
+
+	versionOnce sync.Once
+	versionErr  error
+}
+
+// NewTrivyScanner creates a scanner that runs the trivy binary available in the PATH. It requires trivy 0.37.0 or later
+func NewTrivyScanner() *TrivyScanner {
+	return &TrivyScanner{run: runTrivy}
+}
+
+// Scan returns the vulnerabilities found in the image
+func (s *TrivyScanner) Scan(ctx context.Context, image string) (*ScanReport, error) {
+	if err := s.checkVersion(ctx); err != nil {
+		return nil, err
+	}
+
+	env := []string{}
+	if okteto.IsOkteto() {
+		// trivy pulls the image from the registry so it needs the credentials of the okteto registry
+		env = append(env, fmt.Sprintf("TRIVY_USERNAME=%s", okteto.Context().UserID), fmt.Sprintf("TRIVY_PASSWORD=%s", okteto.Context().Token))
+	}
+	out, err := s.run(ctx, env, "image", "--quiet", "--format", "json", "--scanners", "vuln", image)
+	if err != nil {
+		return nil, err
+	}
+	return parseTrivyReport(image, out)
+}
+
+// checkVersion checks once that the version of trivy supports the flags used to scan the images
+func (s *TrivyScanner) checkVersion(ctx context.Context) error {
+	s.versionOnce.Do(func() {
+		out, err := s.run(ctx, nil, "version", "--format", "json")
+		if err != nil {
+			s.versionErr = err
+			return
+		}
+		s.versionErr = checkTrivyVersion(out)
+	})
+	return s.versionErr
+}
+
+func checkTrivyVersion(out []byte) error {
+	v := struct {
+		Version string `json:"Version"`
+	}{}
+	if err := json.Unmarshal(out, &v); err != nil {
+		return fmt.Errorf("error parsing the version of trivy: %w", err)
+	}
+	version, err := semver.NewVersion(v.Version)
+	if err != nil {
+		return fmt.Errorf("error parsing the version of trivy '%s': %w", v.Version, err)
+	}
+	oktetoLog.Infof("using trivy %s", version)
+	if version.LessThan(minTrivyVersion) {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("trivy %s is not supported: scanning images requires trivy %s or later", version, minTrivyVersion),
+			Hint: "Upgrade trivy following https://aquasecurity.github.io/trivy/latest/getting-started/installation/ or remove the 'scan' field from your okteto manifest",
+		}
+	}
+	return nil
+}
+
+func parseTrivyReport(image string, out []byte) (*ScanReport, error) {
+	report := trivyReport{}
+	if err := json.Unmarshal(out, &report); err != nil {
+		return nil, fmt.Errorf("error parsing vulnerability report of '%s': %w", image, err)
+	}
+	result := &ScanReport{Image: image, Vulnerabilities: []Vulnerability{}}
+	for _, r := range report.Results {
+		result.Vulnerabilities = append(result.Vulnerabilities, r.Vulnerabilities...)
+	}
+	return result, nil
+}
+
+func runTrivy(ctx context.Context, env []string, args ...string) ([]byte, error) {
+	if _, err := exec.LookPath(trivyBinary); err != nil {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("trivy %s or later is required to scan images for vulnerabilities", minTrivyVersion),
+			Hint: "Install trivy following https://aquasecurity.github.io/trivy/latest/getting-started/installation/ or remove the 'scan' field from your okteto manifest",
+		}
+	}
+	oktetoLog.Infof("running trivy %s", strings.Join(args, " "))
+	cmd := exec.CommandContext(ctx, trivyBinary, args...)
+	cmd.Env = append(os.Environ(), env...)
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("error scanning image: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
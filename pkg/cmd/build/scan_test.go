@@ -0,0 +1,71 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"context"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrivyScan(t *testing.T) {
+	output := `{
+  "Results": [
+    {"Target": "debian", "Vulnerabilities": [{"VulnerabilityID": "CVE-1", "PkgName": "openssl", "Severity": "CRITICAL"}]},
+    {"Target": "node", "Vulnerabilities": [{"VulnerabilityID": "CVE-2", "PkgName": "lodash", "Severity": "HIGH"}, {"VulnerabilityID": "CVE-3", "PkgName": "minimist", "Severity": "high"}]},
+    {"Target": "go"}
+  ]
+}`
+	var gotArgs []string
+	s := &TrivyScanner{
+		run: func(_ context.Context, _ []string, args ...string) ([]byte, error) {
+			if args[0] == "version" {
+				return []byte(`{"Version": "0.45.0"}`), nil
+			}
+			gotArgs = args
+			return []byte(output), nil
+		},
+	}
+	report, err := s.Scan(context.Background(), "okteto/api:1.0")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"image", "--quiet", "--format", "json", "--scanners", "vuln", "okteto/api:1.0"}, gotArgs)
+	assert.Len(t, report.Vulnerabilities, 3)
+	assert.Equal(t, map[string]int{"CRITICAL": 1, "HIGH": 2}, report.CountBySeverity())
+}
+
+func TestTrivyScanUnsupportedVersion(t *testing.T) {
+	calls := 0
+	s := &TrivyScanner{
+		run: func(_ context.Context, _ []string, _ ...string) ([]byte, error) {
+			calls++
+			return []byte(`{"Version": "0.36.1"}`), nil
+		},
+	}
+	_, err := s.Scan(context.Background(), "okteto/api:1.0")
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+	assert.ErrorContains(t, err, "trivy 0.36.1 is not supported")
+
+	// the version is only checked once
+	_, err = s.Scan(context.Background(), "okteto/frontend:1.0")
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestParseTrivyReportError(t *testing.T) {
+	_, err := parseTrivyReport("okteto/api", []byte("not json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,158 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+)
+
+// VerifyOptions defines how the signature of an image is verified
+type VerifyOptions struct {
+	// Key is the cosign public key. If empty, the keyless certificate identity and issuer are verified
+	Key string
+	// Identity is the regular expression that the identity of the keyless certificate must match
+	Identity string
+	// Issuer is the regular expression that the OIDC issuer of the keyless certificate must match
+	Issuer string
+}
+
+// Validate returns an error if the options don't define a key or a keyless identity
+func (o *VerifyOptions) Validate() error {
+	if o.Key == "" && (o.Identity == "" || o.Issuer == "") {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("image verification requires a cosign key or a keyless identity and issuer"),
+			Hint: "Use the '--verify-key' flag or the '--verify-identity' and '--verify-issuer' flags",
+		}
+	}
+	return nil
+}
+
+// ImageSigner signs and verifies images using cosign
+type ImageSigner struct {
+	// run executes the binary and returns its output, it is replaced in tests
+	run func(ctx context.Context, binary string, env []string, args ...string) ([]byte, error)
+}
+
+// NewImageSigner creates a signer that runs the cosign binary available in the PATH
+func NewImageSigner() *ImageSigner {
+	return &ImageSigner{run: runBinary}
+}
+
+// Sign signs the image with the cosign key or with keyless OIDC signing if the key is empty
+func (s *ImageSigner) Sign(ctx context.Context, image, key string) error {
+	args := []string{"sign", "--yes"}
+	if key != "" {
+		args = append(args, "--key", key)
+	}
+	args = append(args, image)
+	env, cleanup, err := getCosignRegistryEnv()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	if _, err := s.run(ctx, cosignBinary, env, args...); err != nil {
+		return fmt.Errorf("error signing image '%s': %w", image, err)
+	}
+	return nil
+}
+
+// Verify returns an error if the image doesn't have a valid signature
+func (s *ImageSigner) Verify(ctx context.Context, image string, opts VerifyOptions) error {
+	args := []string{"verify"}
+	if opts.Key != "" {
+		args = append(args, "--key", opts.Key)
+	} else {
+		args = append(args, "--certificate-identity-regexp", opts.Identity, "--certificate-oidc-issuer-regexp", opts.Issuer)
+	}
+	args = append(args, image)
+	env, cleanup, err := getCosignRegistryEnv()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	if _, err := s.run(ctx, cosignBinary, env, args...); err != nil {
+		return fmt.Errorf("image '%s' could not be verified: %w", image, err)
+	}
+	return nil
+}
+
+// getCosignRegistryEnv returns the env to authenticate cosign in the okteto registry. The credentials are written to a temporary
+// docker config that extends the one of the user, so they aren't visible in the arguments of the cosign process.
+// cleanup removes the temporary docker config
+func getCosignRegistryEnv() ([]string, func(), error) {
+	if !okteto.IsOkteto() {
+		return nil, func() {}, nil
+	}
+
+	dockerConfig := map[string]interface{}{}
+	if b, err := os.ReadFile(filepath.Join(getDockerConfigDir(), "config.json")); err == nil {
+		if err := json.Unmarshal(b, &dockerConfig); err != nil {
+			oktetoLog.Infof("failed to read the docker config: %s", err)
+			dockerConfig = map[string]interface{}{}
+		}
+	}
+	auths, ok := dockerConfig["auths"].(map[string]interface{})
+	if !ok {
+		auths = map[string]interface{}{}
+	}
+	okCtx := okteto.Context()
+	auths[okCtx.Registry] = map[string]string{
+		"auth": base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", okCtx.UserID, okCtx.Token))),
+	}
+	dockerConfig["auths"] = auths
+	// the okteto registry credentials take precedence over the credential helpers of the user
+	if helpers, ok := dockerConfig["credHelpers"].(map[string]interface{}); ok {
+		delete(helpers, okCtx.Registry)
+	}
+
+	b, err := json.Marshal(dockerConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	dir, err := os.MkdirTemp("", "okteto-cosign-")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create the cosign docker config: %w", err)
+	}
+	cleanup := func() {
+		if err := os.RemoveAll(dir); err != nil {
+			oktetoLog.Infof("failed to remove the cosign docker config: %s", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), b, 0600); err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("failed to create the cosign docker config: %w", err)
+	}
+	return []string{fmt.Sprintf("DOCKER_CONFIG=%s", dir)}, cleanup, nil
+}
+
+// getDockerConfigDir returns the folder of the docker config of the user
+func getDockerConfigDir() string {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".docker")
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageSigner(t *testing.T) {
+	calls := [][]string{}
+	s := &ImageSigner{
+		run: func(_ context.Context, binary string, _ []string, args ...string) ([]byte, error) {
+			calls = append(calls, append([]string{binary}, args...))
+			return nil, nil
+		},
+	}
+	ctx := context.Background()
+	assert.NoError(t, s.Sign(ctx, "okteto/api@sha256:1234", ""))
+	assert.NoError(t, s.Sign(ctx, "okteto/api@sha256:1234", "cosign.key"))
+	assert.NoError(t, s.Verify(ctx, "okteto/api", VerifyOptions{Key: "cosign.pub"}))
+	assert.NoError(t, s.Verify(ctx, "okteto/api", VerifyOptions{Identity: ".*@okteto.com", Issuer: "https://accounts.google.com"}))
+	assert.Equal(t, [][]string{
+		{"cosign", "sign", "--yes", "okteto/api@sha256:1234"},
+		{"cosign", "sign", "--yes", "--key", "cosign.key", "okteto/api@sha256:1234"},
+		{"cosign", "verify", "--key", "cosign.pub", "okteto/api"},
+		{"cosign", "verify", "--certificate-identity-regexp", ".*@okteto.com", "--certificate-oidc-issuer-regexp", "https://accounts.google.com", "okteto/api"},
+	}, calls)
+}
+
+func TestImageSignerVerifyError(t *testing.T) {
+	s := &ImageSigner{
+		run: func(context.Context, string, []string, ...string) ([]byte, error) {
+			return nil, fmt.Errorf("no matching signatures")
+		},
+	}
+	assert.Error(t, s.Verify(context.Background(), "okteto/api", VerifyOptions{Key: "cosign.pub"}))
+}
+
+func TestVerifyOptionsValidate(t *testing.T) {
+	assert.NoError(t, (&VerifyOptions{Key: "cosign.pub"}).Validate())
+	assert.NoError(t, (&VerifyOptions{Identity: ".*", Issuer: ".*"}).Validate())
+	assert.Error(t, (&VerifyOptions{Identity: ".*"}).Validate())
+}
+
+func TestGetCosignRegistryEnv(t *testing.T) {
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {
+				IsOkteto: true,
+				Registry: "registry.okteto.dev",
+				UserID:   "cindy",
+				Token:    "secret-token",
+			},
+		},
+		CurrentContext: "test",
+	}
+	userConfig := t.TempDir()
+	t.Setenv("DOCKER_CONFIG", userConfig)
+	require.NoError(t, os.WriteFile(filepath.Join(userConfig, "config.json"), []byte(`{"auths":{"docker.io":{"auth":"dXNlcjpwYXNz"}},"credHelpers":{"registry.okteto.dev":"okteto","gcr.io":"gcloud"}}`), 0600))
+
+	calls := [][]string{}
+	s := &ImageSigner{
+		run: func(_ context.Context, binary string, env []string, args ...string) ([]byte, error) {
+			calls = append(calls, args)
+			require.Len(t, env, 1)
+			dir := strings.TrimPrefix(env[0], "DOCKER_CONFIG=")
+			b, err := os.ReadFile(filepath.Join(dir, "config.json"))
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"auths":{"docker.io":{"auth":"dXNlcjpwYXNz"},"registry.okteto.dev":{"auth":"Y2luZHk6c2VjcmV0LXRva2Vu"}},"credHelpers":{"gcr.io":"gcloud"}}`, string(b))
+			return nil, nil
+		},
+	}
+	require.NoError(t, s.Sign(context.Background(), "registry.okteto.dev/test/api@sha256:1234", ""))
+	for _, args := range calls {
+		assert.NotContains(t, strings.Join(args, " "), "secret-token")
+	}
+}
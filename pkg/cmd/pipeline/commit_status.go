@@ -0,0 +1,193 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	giturls "github.com/whilp/git-urls"
+)
+
+// CommitState represents the state of a commit status reported to the git provider
+type CommitState string
+
+const (
+	// CommitStatePending is reported when the pipeline has been scheduled
+	CommitStatePending CommitState = "pending"
+
+	// CommitStateSuccess is reported when the pipeline finished successfully
+	CommitStateSuccess CommitState = "success"
+
+	// CommitStateFailure is reported when the pipeline finished with errors
+	CommitStateFailure CommitState = "failure"
+
+	// commitStatusContext is the name shown by the git provider for the okteto check
+	commitStatusContext = "okteto/pipeline"
+
+	githubHost       = "github.com"
+	githubAPIBaseURL = "https://api.github.com"
+
+	commitStatusTimeout = 10 * time.Second
+)
+
+// CommitStatus is the information reported to the git provider for a commit
+type CommitStatus struct {
+	State       CommitState
+	Description string
+	TargetURL   string
+}
+
+// CommitStatusReporter reports commit statuses to a git provider
+type CommitStatusReporter interface {
+	Report(ctx context.Context, status CommitStatus) error
+}
+
+// NewCommitStatusReporter returns the reporter for the git provider hosting repository.
+// It returns nil if the token is empty, the sha is unknown or the provider is not supported
+func NewCommitStatusReporter(repository, sha, token string) CommitStatusReporter {
+	if token == "" || sha == "" || repository == "" {
+		return nil
+	}
+
+	u, err := giturls.Parse(repository)
+	if err != nil {
+		oktetoLog.Infof("could not parse repository url '%s': %s", repository, err)
+		return nil
+	}
+	repoPath := strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+	if strings.Count(repoPath, "/") < 1 {
+		return nil
+	}
+
+	httpClient := &http.Client{Timeout: commitStatusTimeout}
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case host == githubHost:
+		return &githubStatusReporter{
+			baseURL: githubAPIBaseURL,
+			repo:    repoPath,
+			sha:     sha,
+			token:   token,
+			client:  httpClient,
+		}
+	case strings.Contains(host, "gitlab"):
+		return &gitlabStatusReporter{
+			baseURL: fmt.Sprintf("https://%s/api/v4", u.Hostname()),
+			repo:    repoPath,
+			sha:     sha,
+			token:   token,
+			client:  httpClient,
+		}
+	default:
+		oktetoLog.Infof("git provider '%s' does not support commit statuses", host)
+		return nil
+	}
+}
+
+type githubStatusReporter struct {
+	client  *http.Client
+	baseURL string
+	repo    string
+	sha     string
+	token   string
+}
+
+type githubStatusRequest struct {
+	State       string `json:"state"`
+	TargetURL   string `json:"target_url,omitempty"`
+	Description string `json:"description,omitempty"`
+	Context     string `json:"context"`
+}
+
+// Report creates a commit status using the GitHub statuses API
+func (r *githubStatusReporter) Report(ctx context.Context, status CommitStatus) error {
+	body, err := json.Marshal(githubStatusRequest{
+		State:       string(status.State),
+		TargetURL:   status.TargetURL,
+		Description: status.Description,
+		Context:     commitStatusContext,
+	})
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/repos/%s/statuses/%s", r.baseURL, r.repo, r.sha)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", r.token))
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	return doCommitStatusRequest(r.client, req)
+}
+
+type gitlabStatusReporter struct {
+	client  *http.Client
+	baseURL string
+	repo    string
+	sha     string
+	token   string
+}
+
+// gitlabStates maps the commit states to the values accepted by GitLab
+var gitlabStates = map[CommitState]string{
+	CommitStatePending: "running",
+	CommitStateSuccess: "success",
+	CommitStateFailure: "failed",
+}
+
+// Report creates a commit status using the GitLab commit statuses API
+func (r *gitlabStatusReporter) Report(ctx context.Context, status CommitStatus) error {
+	params := url.Values{}
+	params.Set("state", gitlabStates[status.State])
+	params.Set("name", commitStatusContext)
+	if status.Description != "" {
+		params.Set("description", status.Description)
+	}
+	if status.TargetURL != "" {
+		params.Set("target_url", status.TargetURL)
+	}
+
+	endpoint := fmt.Sprintf("%s/projects/%s/statuses/%s?%s", r.baseURL, url.PathEscape(r.repo), r.sha, params.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("PRIVATE-TOKEN", r.token)
+	return doCommitStatusRequest(r.client, req)
+}
+
+func doCommitStatusRequest(client *http.Client, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("git provider returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(b)))
+	}
+	return nil
+}
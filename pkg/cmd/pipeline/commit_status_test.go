@@ -0,0 +1,139 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCommitStatusReporter(t *testing.T) {
+	var tests = []struct {
+		name       string
+		repository string
+		sha        string
+		token      string
+		expected   CommitStatusReporter
+	}{
+		{
+			name:       "no token",
+			repository: "https://github.com/okteto/movies",
+			sha:        "1234",
+		},
+		{
+			name:       "no sha",
+			repository: "https://github.com/okteto/movies",
+			token:      "token",
+		},
+		{
+			name:       "unsupported provider",
+			repository: "https://bitbucket.org/okteto/movies",
+			sha:        "1234",
+			token:      "token",
+		},
+		{
+			name:       "github ssh",
+			repository: "git@github.com:okteto/movies.git",
+			sha:        "1234",
+			token:      "token",
+			expected: &githubStatusReporter{
+				baseURL: githubAPIBaseURL,
+				repo:    "okteto/movies",
+				sha:     "1234",
+				token:   "token",
+			},
+		},
+		{
+			name:       "self-hosted gitlab",
+			repository: "https://gitlab.okteto.dev/group/sub/movies.git",
+			sha:        "1234",
+			token:      "token",
+			expected: &gitlabStatusReporter{
+				baseURL: "https://gitlab.okteto.dev/api/v4",
+				repo:    "group/sub/movies",
+				sha:     "1234",
+				token:   "token",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewCommitStatusReporter(tt.repository, tt.sha, tt.token)
+			switch r := result.(type) {
+			case *githubStatusReporter:
+				r.client = nil
+			case *gitlabStatusReporter:
+				r.client = nil
+			}
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestGithubStatusReporter(t *testing.T) {
+	var got githubStatusRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/okteto/movies/statuses/1234", r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	r := &githubStatusReporter{
+		client:  server.Client(),
+		baseURL: server.URL,
+		repo:    "okteto/movies",
+		sha:     "1234",
+		token:   "token",
+	}
+	err := r.Report(context.Background(), CommitStatus{
+		State:       CommitStateSuccess,
+		Description: "deployed",
+		TargetURL:   "https://movies.okteto.dev",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, githubStatusRequest{
+		State:       "success",
+		Description: "deployed",
+		TargetURL:   "https://movies.okteto.dev",
+		Context:     commitStatusContext,
+	}, got)
+}
+
+func TestGitlabStatusReporter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/projects/group/movies/statuses/1234", r.URL.Path)
+		assert.Equal(t, "token", r.Header.Get("PRIVATE-TOKEN"))
+		assert.Equal(t, "failed", r.URL.Query().Get("state"))
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	r := &gitlabStatusReporter{
+		client:  server.Client(),
+		baseURL: server.URL,
+		repo:    "group/movies",
+		sha:     "1234",
+		token:   "token",
+	}
+	err := r.Report(context.Background(), CommitStatus{State: CommitStateFailure})
+	assert.Error(t, err)
+}
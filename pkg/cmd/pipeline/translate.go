@@ -164,7 +164,7 @@ func UpdateEnvs(ctx context.Context, name, namespace string, envs []string, c ku
 	if cmap != nil {
 		envsToSet := make(map[string]string, len(envs))
 		for _, env := range envs {
-			result := strings.Split(env, "=")
+			result := strings.SplitN(env, "=", 2)
 			if len(result) != 2 {
 				return fmt.Errorf("invalid env format: '%s'", env)
 			}
@@ -185,6 +185,61 @@ func UpdateEnvs(ctx context.Context, name, namespace string, envs []string, c ku
 	return nil
 }
 
+// GetDependencyEnvs returns the variables exported to $OKTETO_ENV by the deploy of a pipeline, stored in its configmap
+func GetDependencyEnvs(cmap *apiv1.ConfigMap) (map[string]string, error) {
+	result := map[string]string{}
+	if cmap == nil {
+		return result, nil
+	}
+	encoded, ok := cmap.Data[constants.OktetoDependencyEnvsKey]
+	if !ok {
+		return result, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the variables exported by '%s': %w", cmap.Name, err)
+	}
+	if err := json.Unmarshal(decoded, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode the variables exported by '%s': %w", cmap.Name, err)
+	}
+	return result, nil
+}
+
+// TranslateConfigMapToCfgData translates the configmap of a pipeline into its config data
+func TranslateConfigMapToCfgData(cmap *apiv1.ConfigMap) (*CfgData, error) {
+	manifest, err := base64.StdEncoding.DecodeString(cmap.Data[yamlField])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the manifest of '%s': %w", cmap.Name, err)
+	}
+
+	variables := []string{}
+	if encoded := cmap.Data[variablesField]; encoded != "" {
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode the variables of '%s': %w", cmap.Name, err)
+		}
+		var v []types.DeployVariable
+		if err := json.Unmarshal(decoded, &v); err != nil {
+			return nil, fmt.Errorf("failed to decode the variables of '%s': %w", cmap.Name, err)
+		}
+		for _, item := range v {
+			variables = append(variables, fmt.Sprintf("%s=%s", item.Name, item.Value))
+		}
+	}
+
+	return &CfgData{
+		Name:       cmap.Data[nameField],
+		Namespace:  cmap.Namespace,
+		Status:     cmap.Data[statusField],
+		Repository: cmap.Data[repoField],
+		Branch:     cmap.Data[branchField],
+		Filename:   cmap.Data[filenameField],
+		Manifest:   manifest,
+		Icon:       cmap.Data[iconField],
+		Variables:  variables,
+	}, nil
+}
+
 // TranslatePipelineName translate the name into the configmap name
 func TranslatePipelineName(name string) string {
 	return fmt.Sprintf("okteto-git-%s", format.ResourceK8sMetaString(name))
@@ -349,6 +404,11 @@ func removeSensitiveDataFromGitURL(gitURL string) string {
 	return parsedRepo.String()
 }
 
+// EncodeVariables encodes the variables as they are stored in the configmap of a development environment
+func EncodeVariables(variables []string) string {
+	return translateVariables(variables)
+}
+
 func translateVariables(variables []string) string {
 	var v []types.DeployVariable
 	for _, item := range variables {
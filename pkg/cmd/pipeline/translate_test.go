@@ -97,10 +97,46 @@ func Test_updateEnvsWithoutError(t *testing.T) {
 	envs := []string{
 		"ONE=value",
 		"TWO=values",
+		"URL=https://okteto.com?a=b",
 	}
 
 	err := UpdateEnvs(ctx, "test", namespace, envs, fakeClient)
 	assert.NoError(t, err)
+
+	result, err := fakeClient.CoreV1().ConfigMaps(namespace).Get(ctx, TranslatePipelineName("test"), metav1.GetOptions{})
+	assert.NoError(t, err)
+	decoded, err := base64.StdEncoding.DecodeString(result.Data[constants.OktetoDependencyEnvsKey])
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"ONE":"value","TWO":"values","URL":"https://okteto.com?a=b"}`, string(decoded))
+}
+
+func TestGetDependencyEnvs(t *testing.T) {
+	ctx := context.Background()
+	cmap := &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TranslatePipelineName("test"),
+			Namespace: "test",
+		},
+		Data: map[string]string{
+			statusField: DeployedStatus,
+		},
+	}
+	fakeClient := fake.NewSimpleClientset(cmap)
+
+	envs, err := GetDependencyEnvs(cmap)
+	assert.NoError(t, err)
+	assert.Empty(t, envs)
+
+	assert.NoError(t, UpdateEnvs(ctx, "test", "test", []string{"ONE=value", "URL=https://okteto.com?a=b"}, fakeClient))
+	result, err := fakeClient.CoreV1().ConfigMaps("test").Get(ctx, TranslatePipelineName("test"), metav1.GetOptions{})
+	assert.NoError(t, err)
+	envs, err = GetDependencyEnvs(result)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"ONE": "value", "URL": "https://okteto.com?a=b"}, envs)
+
+	result.Data[constants.OktetoDependencyEnvsKey] = "invalid"
+	_, err = GetDependencyEnvs(result)
+	assert.Error(t, err)
 }
 
 func Test_updateEnvsWithError(t *testing.T) {
@@ -258,3 +294,26 @@ func Test_translateVariables(t *testing.T) {
 
 	}
 }
+
+func Test_TranslateConfigMapToCfgData(t *testing.T) {
+	data := &CfgData{
+		Name:       "movies",
+		Namespace:  "test",
+		Status:     DeployedStatus,
+		Repository: "https://github.com/okteto/movies",
+		Branch:     "main",
+		Filename:   "okteto.yml",
+		Manifest:   []byte("deploy:\n  - okteto build\n"),
+		Icon:       "default",
+		Variables:  []string{"A=1", "B=a=b"},
+	}
+	cmap := translateConfigMapSandBox(data)
+
+	result, err := TranslateConfigMapToCfgData(cmap)
+	assert.NoError(t, err)
+	assert.Equal(t, data, result)
+
+	cmap.Data[variablesField] = "not base64"
+	_, err = TranslateConfigMapToCfgData(cmap)
+	assert.Error(t, err)
+}
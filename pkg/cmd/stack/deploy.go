@@ -19,12 +19,12 @@ import (
 	"fmt"
 	"net"
 	"os"
-	"os/signal"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/okteto/okteto/pkg/analytics"
+	"github.com/okteto/okteto/pkg/constants"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/format"
 	"github.com/okteto/okteto/pkg/k8s/configmaps"
@@ -41,6 +41,7 @@ import (
 	"github.com/okteto/okteto/pkg/model/forward"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/registry"
+	"github.com/okteto/okteto/pkg/signals"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -56,9 +57,14 @@ type StackDeployOptions struct {
 	Wait             bool
 	NoCache          bool
 	Timeout          time.Duration
+	RolloutTimeout   time.Duration
 	ServicesToDeploy []string
 	Progress         string
 	InsidePipeline   bool
+	WarningsAsErrors bool
+	Profiles         []string
+	// DryRun deploys the services without waiting for their dependencies, as the objects of dry-run requests are not persisted
+	DryRun bool
 }
 
 type analyticsTrackerInterface interface {
@@ -78,6 +84,11 @@ const (
 
 // Deploy deploys a stack
 func (sd *Stack) Deploy(ctx context.Context, s *model.Stack, options *StackDeployOptions) error {
+	if options.WarningsAsErrors {
+		if err := s.ValidateNotSupportedFields(); err != nil {
+			return err
+		}
+	}
 
 	if err := validateServicesToDeploy(ctx, s, options, sd.K8sClient); err != nil {
 		return err
@@ -124,7 +135,7 @@ func deploy(ctx context.Context, s *model.Stack, c kubernetes.Interface, config
 	defer oktetoLog.StopSpinner()
 
 	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt)
+	signals.Notify(stop)
 	exit := make(chan error, 1)
 
 	go func() {
@@ -209,7 +220,7 @@ func deploy(ctx context.Context, s *model.Stack, c kubernetes.Interface, config
 			if skipIngressDeployForStackNameLabel(ctx, iClient, ingress) {
 				continue
 			}
-			if err := iClient.Deploy(ctx, ingress); err != nil {
+			if err := iClient.Apply(ctx, ingress); err != nil {
 				exit <- err
 				return
 			}
@@ -226,7 +237,11 @@ func deploy(ctx context.Context, s *model.Stack, c kubernetes.Interface, config
 		}
 
 		oktetoLog.Spinner("Waiting for services to be ready...")
-		exit <- waitForPodsToBeRunning(ctx, s, c)
+		rolloutTimeout := options.RolloutTimeout
+		if rolloutTimeout == 0 {
+			rolloutTimeout = model.DefaultRolloutTimeout
+		}
+		exit <- waitForPodsToBeRunning(ctx, s, rolloutTimeout, c)
 	}()
 
 	select {
@@ -319,7 +334,7 @@ func deployServices(ctx context.Context, stack *model.Stack, k8sClient kubernete
 						continue
 					}
 
-					if !canSvcBeDeployed(ctx, stack, svcName, k8sClient, config) {
+					if !options.DryRun && !canSvcBeDeployed(ctx, stack, svcName, k8sClient, config) {
 						if failedJobs := getDependingFailedJobs(ctx, stack, svcName, k8sClient); len(failedJobs) > 0 {
 							if len(failedJobs) == 1 {
 								return fmt.Errorf("service '%s' dependency '%s' failed", svcName, failedJobs[0])
@@ -382,7 +397,7 @@ func deployK8sEndpoint(ctx context.Context, ingressName, svcName string, port mo
 	// create a new endpoint for this port ingress deployment
 	endpoint := model.Endpoint{
 		Labels:      translateLabels(svcName, s),
-		Annotations: translateAnnotations(s.Services[svcName]),
+		Annotations: translateObjectAnnotations(s.Services[svcName]),
 		Rules: []model.EndpointRule{
 			{
 				Path:    "/",
@@ -409,7 +424,7 @@ func deployK8sEndpoint(ctx context.Context, ingressName, svcName string, port mo
 	if skipIngressDeployForStackNameLabel(ctx, c, ingress) {
 		return nil
 	}
-	return c.Deploy(ctx, ingress)
+	return c.Apply(ctx, ingress)
 }
 
 func canSvcBeDeployed(ctx context.Context, stack *model.Stack, svcName string, client kubernetes.Interface, config *rest.Config) bool {
@@ -579,7 +594,7 @@ func deployK8sService(ctx context.Context, svcName string, s *model.Stack, c kub
 		if !oktetoErrors.IsNotFound(err) {
 			return fmt.Errorf("error getting service '%s': %w", svcName, err)
 		}
-		if err := services.Deploy(ctx, svcK8s, c); err != nil {
+		if err := services.Apply(ctx, svcK8s, c); err != nil {
 			return err
 		}
 		oktetoLog.Success("Kubernetes service '%s' created", svcName)
@@ -597,7 +612,7 @@ func deployK8sService(ctx context.Context, svcName string, s *model.Stack, c kub
 	}
 
 	svcK8s.ObjectMeta.ResourceVersion = old.ObjectMeta.ResourceVersion
-	if err := services.Deploy(ctx, svcK8s, c); err != nil {
+	if err := services.Apply(ctx, svcK8s, c); err != nil {
 		return err
 	}
 	oktetoLog.Success("Kubernetes service '%s' updated", svcName)
@@ -635,13 +650,13 @@ func deployDeployment(ctx context.Context, svcName string, s *model.Stack, c kub
 		if err := deployments.Destroy(ctx, old.Name, old.Namespace, c); err != nil {
 			return false, fmt.Errorf("error updating deployment of service '%s': %s", svcName, err.Error())
 		}
-		if _, err := deployments.Deploy(ctx, d, c); err != nil {
+		if _, err := deployments.Apply(ctx, d, c); err != nil {
 			return false, fmt.Errorf("error updating deployment of service '%s': %s", svcName, err.Error())
 		}
 		return isNewDeployment, nil
 	}
 
-	if _, err := deployments.Deploy(ctx, d, c); err != nil {
+	if _, err := deployments.Apply(ctx, d, c); err != nil {
 		if isNewDeployment {
 			return false, fmt.Errorf("error creating deployment of service '%s': %s", svcName, err.Error())
 		}
@@ -658,7 +673,7 @@ func deployStatefulSet(ctx context.Context, svcName string, s *model.Stack, c ku
 		return false, fmt.Errorf("error getting statefulset of service '%s': %s", svcName, err.Error())
 	}
 	if old == nil || old.Name == "" {
-		if _, err := statefulsets.Deploy(ctx, sfs, c); err != nil {
+		if _, err := statefulsets.Apply(ctx, sfs, c); err != nil {
 			return false, fmt.Errorf("error creating statefulset of service '%s': %s", svcName, err.Error())
 		}
 		return true, nil
@@ -676,14 +691,14 @@ func deployStatefulSet(ctx context.Context, svcName string, s *model.Stack, c ku
 			sfs.Labels[model.DeployedByLabel] = format.ResourceK8sMetaString(s.Name)
 		}
 	}
-	if _, err := statefulsets.Deploy(ctx, sfs, c); err != nil {
+	if _, err := statefulsets.Apply(ctx, sfs, c); err != nil {
 		if !strings.Contains(err.Error(), "Forbidden: updates to statefulset spec") {
 			return false, fmt.Errorf("error updating statefulset of service '%s': %s", svcName, err.Error())
 		}
 		if err := statefulsets.Destroy(ctx, sfs.Name, sfs.Namespace, c); err != nil {
 			return false, fmt.Errorf("error updating statefulset of service '%s': %s", svcName, err.Error())
 		}
-		if _, err := statefulsets.Deploy(ctx, sfs, c); err != nil {
+		if _, err := statefulsets.Apply(ctx, sfs, c); err != nil {
 			return false, fmt.Errorf("error updating statefulset of service '%s': %s", svcName, err.Error())
 		}
 	}
@@ -763,14 +778,14 @@ func deployVolume(ctx context.Context, volumeName string, s *model.Stack, c kube
 	return nil
 }
 
-func waitForPodsToBeRunning(ctx context.Context, s *model.Stack, c kubernetes.Interface) error {
+func waitForPodsToBeRunning(ctx context.Context, s *model.Stack, rolloutTimeout time.Duration, c kubernetes.Interface) error {
 	var numPods int32 = 0
 	for _, svc := range s.Services {
 		numPods += svc.Replicas
 	}
 
 	ticker := time.NewTicker(100 * time.Millisecond)
-	timeout := time.Now().Add(600 * time.Second)
+	timeout := time.Now().Add(rolloutTimeout)
 
 	selector := map[string]string{model.StackNameLabel: format.ResourceK8sMetaString(s.Name)}
 	for time.Now().Before(timeout) {
@@ -863,6 +878,22 @@ func validateServicesToDeploy(ctx context.Context, s *model.Stack, options *Stac
 	return nil
 }
 
+// GetServicesEnabledByProfiles returns the services enabled for the profiles passed as flag or defined in COMPOSE_PROFILES
+func GetServicesEnabledByProfiles(s *model.Stack, profiles []string) ([]string, error) {
+	if len(profiles) == 0 {
+		for _, p := range strings.Split(os.Getenv(constants.ComposeProfilesEnvVar), ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				profiles = append(profiles, p)
+			}
+		}
+	}
+	servicesToDeploy := s.GetServicesEnabledByProfiles(profiles)
+	if len(servicesToDeploy) == 0 {
+		return nil, fmt.Errorf("no services enabled for the profiles [%s]", strings.Join(profiles, ", "))
+	}
+	return servicesToDeploy, nil
+}
+
 // ValidateDefinedServices checks that the services to deploy are in the compose file
 func ValidateDefinedServices(s *model.Stack, servicesToDeploy []string) error {
 	for _, svcToDeploy := range servicesToDeploy {
@@ -20,6 +20,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/format"
 	"github.com/okteto/okteto/pkg/k8s/ingresses"
 	"github.com/okteto/okteto/pkg/k8s/services"
@@ -1054,3 +1055,26 @@ func TestDeployK8sEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetServicesEnabledByProfiles(t *testing.T) {
+	s := &model.Stack{
+		Services: model.ComposeServices{
+			"api":      &model.Service{},
+			"debugger": &model.Service{Profiles: []string{"debug"}},
+			"e2e":      &model.Service{Profiles: []string{"test"}},
+		},
+	}
+
+	t.Setenv(constants.ComposeProfilesEnvVar, "test, debug")
+	result, err := GetServicesEnabledByProfiles(s, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"api", "debugger", "e2e"}, result)
+
+	result, err = GetServicesEnabledByProfiles(s, []string{"debug"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"api", "debugger"}, result)
+
+	s.Services = model.ComposeServices{"e2e": &model.Service{Profiles: []string{"test"}}}
+	_, err = GetServicesEnabledByProfiles(s, []string{"debug"})
+	assert.Error(t, err)
+}
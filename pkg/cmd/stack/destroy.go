@@ -18,7 +18,6 @@ import (
 	"encoding/base64"
 	"fmt"
 	"os"
-	"os/signal"
 	"time"
 
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
@@ -34,6 +33,7 @@ import (
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/signals"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -72,7 +72,7 @@ func destroyStack(ctx context.Context, s *model.Stack, removeVolumes bool, c *ku
 	defer oktetoLog.StopSpinner()
 
 	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt)
+	signals.Notify(stop)
 	exit := make(chan error, 1)
 
 	go func() {
@@ -125,6 +125,10 @@ func destroyServicesNotInStack(ctx context.Context, s *model.Stack, c kubernetes
 		return err
 	}
 
+	if err := destroyK8sServices(ctx, s, c); err != nil {
+		return err
+	}
+
 	err := destroyIngresses(ctx, s, c)
 	if err != nil {
 		return err
@@ -206,6 +210,24 @@ func destroyJobs(ctx context.Context, s *model.Stack, c kubernetes.Interface) er
 	return nil
 }
 
+// destroyK8sServices prunes the kubernetes services of the stack whose services are not in the stack or don't expose ports anymore
+func destroyK8sServices(ctx context.Context, s *model.Stack, c kubernetes.Interface) error {
+	svcList, err := services.List(ctx, s.Namespace, s.GetLabelSelector(), c)
+	if err != nil {
+		return err
+	}
+	for i := range svcList {
+		if svc, ok := s.Services[svcList[i].Name]; ok && len(svc.Ports) > 0 {
+			continue
+		}
+		if err := services.Destroy(ctx, svcList[i].Name, svcList[i].Namespace, c); err != nil {
+			return fmt.Errorf("error destroying kubernetes service '%s': %s", svcList[i].Name, err)
+		}
+		oktetoLog.Success("Kubernetes service '%s' destroyed", svcList[i].Name)
+	}
+	return nil
+}
+
 func destroyIngresses(ctx context.Context, s *model.Stack, c kubernetes.Interface) error {
 	iClient, err := ingresses.GetClient(c)
 	if err != nil {
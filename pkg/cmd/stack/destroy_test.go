@@ -19,8 +19,10 @@ import (
 
 	"github.com/okteto/okteto/pkg/k8s/deployments"
 	"github.com/okteto/okteto/pkg/k8s/jobs"
+	"github.com/okteto/okteto/pkg/k8s/services"
 	"github.com/okteto/okteto/pkg/k8s/statefulsets"
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -273,3 +275,60 @@ func Test_destroyJobs(t *testing.T) {
 		})
 	}
 }
+
+func Test_destroyDeploymentsNotApplied(t *testing.T) {
+	ctx := context.Background()
+	dep := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:          "test",
+			Namespace:     "ns",
+			Labels:        map[string]string{model.StackNameLabel: "stack-test"},
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl-client-side-apply", Operation: metav1.ManagedFieldsOperationUpdate}},
+		},
+	}
+	client := fake.NewSimpleClientset(dep)
+	stack := &model.Stack{Namespace: "ns", Name: "stack-test"}
+
+	if err := destroyDeployments(ctx, stack, client); err != nil {
+		t.Fatal("Not destroyed correctly")
+	}
+	depList, err := deployments.List(ctx, "ns", stack.GetLabelSelector(), client)
+	if err != nil {
+		t.Fatal("could not retrieve list correctly")
+	}
+	if len(depList) != 0 {
+		t.Fatal("deployment of the stack created without server-side apply was not destroyed")
+	}
+}
+
+func Test_destroyK8sServices(t *testing.T) {
+	ctx := context.Background()
+	labels := map[string]string{model.StackNameLabel: "stack-test"}
+	client := fake.NewSimpleClientset(
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "ns", Labels: labels}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "ns", Labels: labels}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "old", Namespace: "ns", Labels: labels}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "ns"}},
+	)
+	stack := &model.Stack{
+		Namespace: "ns",
+		Name:      "stack-test",
+		Services: map[string]*model.Service{
+			"api":    {Image: "api", Ports: []model.Port{{ContainerPort: 8080}}},
+			"worker": {Image: "worker"},
+		},
+	}
+
+	if err := destroyK8sServices(ctx, stack, client); err != nil {
+		t.Fatal(err)
+	}
+	svcList, err := services.List(ctx, "ns", "", client)
+	if err != nil {
+		t.Fatal("could not retrieve list correctly")
+	}
+	names := []string{}
+	for _, svc := range svcList {
+		names = append(names, svc.Name)
+	}
+	assert.ElementsMatch(t, []string{"api", "other"}, names)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/format"
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/repository"
 	"github.com/okteto/okteto/pkg/types"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
@@ -124,7 +125,7 @@ func translateDeployment(svcName string, s *model.Stack) *appsv1.Deployment {
 			Name:        svcName,
 			Namespace:   s.Namespace,
 			Labels:      translateLabels(svcName, s),
-			Annotations: translateAnnotations(svc),
+			Annotations: translateObjectAnnotations(svc),
 		},
 		Spec: appsv1.DeploymentSpec{
 			Replicas: pointer.Int32Ptr(svc.Replicas),
@@ -164,12 +165,17 @@ func translateDeployment(svcName string, s *model.Stack) *appsv1.Deployment {
 func translatePersistentVolumeClaim(volumeName string, s *model.Stack) apiv1.PersistentVolumeClaim {
 	volumeSpec := s.Volumes[volumeName]
 	labels := translateVolumeLabels(volumeName, s)
+	annotations := map[string]string{}
+	for k, v := range volumeSpec.Annotations {
+		annotations[k] = v
+	}
+	repository.ProvenanceFromEnv().SetAnnotations(annotations)
 	pvc := apiv1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        volumeName,
 			Namespace:   s.Namespace,
 			Labels:      labels,
-			Annotations: volumeSpec.Annotations,
+			Annotations: annotations,
 		},
 		Spec: apiv1.PersistentVolumeClaimSpec{
 			AccessModes: []apiv1.PersistentVolumeAccessMode{apiv1.ReadWriteOnce},
@@ -195,7 +201,7 @@ func translateStatefulSet(svcName string, s *model.Stack) *appsv1.StatefulSet {
 			Name:        svcName,
 			Namespace:   s.Namespace,
 			Labels:      translateLabels(svcName, s),
-			Annotations: translateAnnotations(svc),
+			Annotations: translateObjectAnnotations(svc),
 		},
 		Spec: appsv1.StatefulSetSpec{
 			Replicas:             pointer.Int32Ptr(svc.Replicas),
@@ -249,7 +255,7 @@ func translateJob(svcName string, s *model.Stack) *batchv1.Job {
 			Name:        svcName,
 			Namespace:   s.Namespace,
 			Labels:      translateLabels(svcName, s),
-			Annotations: translateAnnotations(svc),
+			Annotations: translateObjectAnnotations(svc),
 		},
 		Spec: batchv1.JobSpec{
 			Completions:  pointer.Int32Ptr(svc.Replicas),
@@ -422,7 +428,7 @@ func translateVolumes(svc *model.Service) []apiv1.Volume {
 
 func translateService(svcName string, s *model.Stack) *apiv1.Service {
 	svc := s.Services[svcName]
-	annotations := translateAnnotations(svc)
+	annotations := translateObjectAnnotations(svc)
 	return &apiv1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        svcName,
@@ -525,6 +531,14 @@ func translateAnnotations(svc *model.Service) map[string]string {
 	return result
 }
 
+// translateObjectAnnotations returns the annotations of the resources of a service, including the provenance of the code being deployed.
+// Pod templates don't get the provenance so pods aren't restarted when only the commit changes
+func translateObjectAnnotations(svc *model.Service) map[string]string {
+	result := translateAnnotations(svc)
+	repository.ProvenanceFromEnv().SetAnnotations(result)
+	return result
+}
+
 func getAnnotations() map[string]string {
 	annotations := map[string]string{}
 	if utils.IsOktetoRepo() {
@@ -804,3 +818,23 @@ func getUpdateStrategyByEnvVar() updateStrategy {
 	}
 	return ""
 }
+
+// RenderWorkloads returns the deployments and statefulsets created by deploying the given services of a compose, without applying them
+func RenderWorkloads(s *model.Stack, servicesToDeploy []string) ([]*appsv1.Deployment, []*appsv1.StatefulSet) {
+	sort.Strings(servicesToDeploy)
+	deployments := []*appsv1.Deployment{}
+	statefulsets := []*appsv1.StatefulSet{}
+	for _, svcName := range servicesToDeploy {
+		svc, ok := s.Services[svcName]
+		if !ok {
+			continue
+		}
+		switch {
+		case svc.IsDeployment():
+			deployments = append(deployments, translateDeployment(svcName, s))
+		case svc.IsStatefulset():
+			statefulsets = append(statefulsets, translateStatefulSet(svcName, s))
+		}
+	}
+	return deployments, statefulsets
+}
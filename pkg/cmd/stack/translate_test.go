@@ -20,6 +20,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
@@ -1845,3 +1846,39 @@ func TestGetStrategyStrategy(t *testing.T) {
 		})
 	}
 }
+
+func Test_translateProvenanceAnnotations(t *testing.T) {
+	t.Setenv(constants.OktetoGitBranchEnvVar, "main")
+	t.Setenv(constants.OktetoGitHeadEnvVar, "abc123")
+	t.Setenv(constants.OktetoGitDirtyEnvVar, "true")
+
+	s := &model.Stack{
+		Name: "stack",
+		Services: map[string]*model.Service{
+			"svc": {
+				Image:       "image",
+				Annotations: model.Annotations{"key": "value"},
+				Ports:       []model.Port{{ContainerPort: 80, Protocol: apiv1.ProtocolTCP}},
+			},
+		},
+		Volumes: map[string]*model.VolumeSpec{
+			"data": {Size: model.Quantity{Value: resource.MustParse("1Gi")}},
+		},
+	}
+	expected := map[string]string{
+		"key":                           "value",
+		model.OktetoGitBranchAnnotation: "main",
+		model.OktetoGitCommitAnnotation: "abc123",
+		model.OktetoGitDirtyAnnotation:  "true",
+	}
+
+	d := translateDeployment("svc", s)
+	assert.Equal(t, expected, d.Annotations)
+	assert.Equal(t, map[string]string{"key": "value"}, d.Spec.Template.Annotations)
+
+	svc := translateService("svc", s)
+	assert.Equal(t, expected, svc.Annotations)
+
+	pvc := translatePersistentVolumeClaim("data", s)
+	assert.Equal(t, "abc123", pvc.Annotations[model.OktetoGitCommitAnnotation])
+}
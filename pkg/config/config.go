@@ -18,12 +18,14 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 
 	"github.com/okteto/okteto/pkg/constants"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/filesystem"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/afero"
 	"gopkg.in/yaml.v2"
 )
 
@@ -33,6 +35,9 @@ type UpState string
 const (
 	deprecatedAnalyticsFile = ".noanalytics"
 	analyticsFile           = "analytics.json"
+	analyticsEventsFile     = "analytics-events.json"
+	localMetricsFile        = "metrics.json"
+	auditLogFile            = "audit.log"
 	tokenFile               = ".token.json"
 	contextDir              = "context"
 	contextsStoreFile       = "config.json"
@@ -67,6 +72,9 @@ const (
 var (
 	// VersionString the version of the cli
 	VersionString string
+
+	// ReleasePublicKey is the base64 encoded ed25519 public key that verifies the signature of the okteto releases
+	ReleasePublicKey string
 )
 
 // GetBinaryName returns the name of the binary
@@ -125,6 +133,11 @@ func GetAppHome(namespace, name string) string {
 
 // UpdateStateFile updates the state file of a given dev environment
 func UpdateStateFile(devName, devNamespace string, state UpState) error {
+	return UpdateStateFileWithFilesystem(devName, devNamespace, state, afero.NewOsFs())
+}
+
+// UpdateStateFileWithFilesystem updates the state file of a given dev environment in the given filesystem
+func UpdateStateFileWithFilesystem(devName, devNamespace string, state UpState, fs afero.Fs) error {
 	if devNamespace == "" {
 		return fmt.Errorf("can't update state file, namespace is empty")
 	}
@@ -133,10 +146,14 @@ func UpdateStateFile(devName, devNamespace string, state UpState) error {
 		return fmt.Errorf("can't update state file, name is empty")
 	}
 
-	s := filepath.Join(GetAppHome(devNamespace, devName), stateFile)
+	appHome := filepath.Join(GetOktetoHome(), devNamespace, devName)
+	if err := fs.MkdirAll(appHome, 0700); err != nil {
+		return fmt.Errorf("failed to create %s: %s", appHome, err)
+	}
+	s := filepath.Join(appHome, stateFile)
 
 	oktetoLog.Infof("updating file '%s'", s)
-	if err := os.WriteFile(s, []byte(state), 0600); err != nil {
+	if err := afero.WriteFile(fs, s, []byte(state), 0600); err != nil {
 		return fmt.Errorf("failed to update state file: %s", err)
 	}
 	oktetoLog.Infof("file '%s' updated successfully", s)
@@ -146,6 +163,11 @@ func UpdateStateFile(devName, devNamespace string, state UpState) error {
 
 // DeleteStateFile deletes the state file of a given dev environment
 func DeleteStateFile(devName, devNamespace string) error {
+	return DeleteStateFileWithFilesystem(devName, devNamespace, afero.NewOsFs())
+}
+
+// DeleteStateFileWithFilesystem deletes the state file of a given dev environment from the given filesystem
+func DeleteStateFileWithFilesystem(devName, devNamespace string, fs afero.Fs) error {
 	if devNamespace == "" {
 		return fmt.Errorf("can't delete state file, namespace is empty")
 	}
@@ -154,12 +176,17 @@ func DeleteStateFile(devName, devNamespace string) error {
 		return fmt.Errorf("can't delete state file, name is empty")
 	}
 
-	s := filepath.Join(GetAppHome(devNamespace, devName), stateFile)
-	return os.Remove(s)
+	s := filepath.Join(GetOktetoHome(), devNamespace, devName, stateFile)
+	return fs.Remove(s)
 }
 
 // GetState returns the state of a given dev environment
 func GetState(devName, devNamespace string) (UpState, error) {
+	return GetStateWithFilesystem(devName, devNamespace, afero.NewOsFs())
+}
+
+// GetStateWithFilesystem returns the state of a given dev environment stored in the given filesystem
+func GetStateWithFilesystem(devName, devNamespace string, fs afero.Fs) (UpState, error) {
 	var result UpState
 	if devNamespace == "" {
 		return Failed, fmt.Errorf("can't update state file, namespace is empty")
@@ -169,8 +196,8 @@ func GetState(devName, devNamespace string) (UpState, error) {
 		return Failed, fmt.Errorf("can't update state file, name is empty")
 	}
 
-	statePath := filepath.Join(GetAppHome(devNamespace, devName), stateFile)
-	stateBytes, err := os.ReadFile(statePath)
+	statePath := filepath.Join(GetOktetoHome(), devNamespace, devName, stateFile)
+	stateBytes, err := afero.ReadFile(fs, statePath)
 	if err != nil {
 		oktetoLog.Infof("error reading state file: %s", err.Error())
 		return Failed, oktetoErrors.UserError{
@@ -258,6 +285,21 @@ func GetAnalyticsPath() string {
 	return filepath.Join(GetOktetoHome(), analyticsFile)
 }
 
+// GetAnalyticsEventsPath returns the path of the file with the last analytics events sent
+func GetAnalyticsEventsPath() string {
+	return filepath.Join(GetOktetoHome(), analyticsEventsFile)
+}
+
+// GetLocalMetricsPath returns the path of the file with the local command execution metrics
+func GetLocalMetricsPath() string {
+	return filepath.Join(GetOktetoHome(), localMetricsFile)
+}
+
+// GetAuditLogPath returns the path of the file with the audit records of the shell sessions
+func GetAuditLogPath() string {
+	return filepath.Join(GetOktetoHome(), auditLogFile)
+}
+
 func GetOktetoContextFolder() string {
 	return filepath.Join(GetOktetoHome(), contextDir)
 }
@@ -286,6 +328,23 @@ func GetDeployOrigin() (src string) {
 	return
 }
 
+// IsOffline returns true if okteto runs in air-gapped mode: version checks, analytics and external downloads are disabled
+func IsOffline() bool {
+	offline, err := strconv.ParseBool(os.Getenv(constants.OktetoOfflineEnvVar))
+	return err == nil && offline
+}
+
+// CheckOnline returns an error if okteto runs in offline mode. action describes the operation that needs network access
+func CheckOnline(action string) error {
+	if !IsOffline() {
+		return nil
+	}
+	return oktetoErrors.UserError{
+		E:    fmt.Errorf("%s requires network access, but okteto is running in offline mode", action),
+		Hint: fmt.Sprintf("Run it without the '--offline' flag and with '%s' unset", constants.OktetoOfflineEnvVar),
+	}
+}
+
 func RunningInInstaller() bool {
 	return os.Getenv(oktetoInInstaller) == "true"
 }
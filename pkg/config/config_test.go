@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/okteto/okteto/pkg/constants"
+	"github.com/spf13/afero"
 )
 
 func TestGetUserHomeDir(t *testing.T) {
@@ -135,3 +136,56 @@ func TestGetAppHome(t *testing.T) {
 		t.Errorf("expected %s, got %s", expected, got)
 	}
 }
+
+func TestStateFileWithFilesystem(t *testing.T) {
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+	fs := afero.NewMemMapFs()
+
+	if _, err := GetStateWithFilesystem("dp", "ns", fs); err == nil {
+		t.Fatal("expected an error reading a missing state file")
+	}
+	if err := UpdateStateFileWithFilesystem("dp", "ns", Synchronizing, fs); err != nil {
+		t.Fatal(err)
+	}
+	got, err := GetStateWithFilesystem("dp", "ns", fs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != Synchronizing {
+		t.Errorf("expected %s, got %s", Synchronizing, got)
+	}
+	if err := DeleteStateFileWithFilesystem("dp", "ns", fs); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GetState("dp", "ns"); err == nil {
+		t.Error("the state file was written to the local filesystem")
+	}
+}
+
+func TestCheckOnline(t *testing.T) {
+	var tests = []struct {
+		value   string
+		offline bool
+	}{
+		{value: "", offline: false},
+		{value: "false", offline: false},
+		{value: "invalid", offline: false},
+		{value: "true", offline: true},
+		{value: "1", offline: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv(constants.OktetoOfflineEnvVar, tt.value)
+			if got := IsOffline(); got != tt.offline {
+				t.Errorf("expected offline %t, got %t", tt.offline, got)
+			}
+			err := CheckOnline("downloading syncthing")
+			if tt.offline && err == nil {
+				t.Error("expected an error in offline mode")
+			}
+			if !tt.offline && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
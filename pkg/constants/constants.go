@@ -33,6 +33,9 @@ const (
 	// with the okteto credentials
 	OktetoSkipConfigCredentialsUpdate = "OKTETO_SKIP_CONFIG_CREDENTIALS_UPDATE"
 
+	// OktetoSkipContextStoreUpdate keeps the context selected by a command in memory instead of saving it in the okteto context store
+	OktetoSkipContextStoreUpdate = "OKTETO_SKIP_CONTEXT_STORE_UPDATE"
+
 	// OktetoHomeEnvVar defines the path of okteto folder
 	OktetoHomeEnvVar = "OKTETO_HOME"
 
@@ -63,6 +66,15 @@ const (
 	// OktetoTlsCertBase64EnvVar defines the TLS certificate in base64 for --remote
 	OktetoTlsCertBase64EnvVar = "OKTETO_TLS_CERT_BASE64"
 
+	// OktetoOfflineEnvVar defines if okteto runs in air-gapped mode, without accessing external services
+	OktetoOfflineEnvVar = "OKTETO_OFFLINE"
+
+	// OktetoStrictEnvEnvVar defines if the manifest expansion fails when an undefined variable is referenced
+	OktetoStrictEnvEnvVar = "OKTETO_STRICT_ENV"
+
+	// OktetoCABundleEnvVar defines the path to a PEM file with additional certificate authorities trusted by okteto
+	OktetoCABundleEnvVar = "OKTETO_CA_BUNDLE"
+
 	// OktetoInternalServerNameEnvVar defines the internal server name for --remote
 	OktetoInternalServerNameEnvVar = "INTERNAL_SERVER_NAME"
 
@@ -87,6 +99,12 @@ const (
 	// NamespaceStatusSleeping indicates that the namespace is sleeping
 	NamespaceStatusSleeping = "Sleeping"
 
+	// NamespaceTTLAnnotation annotation added to namespaces to indicate the time to live set with 'okteto namespace set-ttl'
+	NamespaceTTLAnnotation = "space.okteto.com/ttl"
+
+	// NamespaceExpiresAtAnnotation annotation added to namespaces to indicate when their time to live expires, in RFC3339 format
+	NamespaceExpiresAtAnnotation = "space.okteto.com/expires-at"
+
 	// DevRegistry alias url for okteto registry
 	DevRegistry = "okteto.dev"
 
@@ -102,6 +120,17 @@ const (
 	// OktetoGitCommitEnvVar is the SHA1 hash of the last commit of the branch.
 	OktetoGitCommitEnvVar = "OKTETO_GIT_COMMIT"
 
+	// OktetoGitHeadEnvVar is the SHA1 hash of the HEAD commit of the code being deployed.
+	// Unlike OktetoGitCommitEnvVar, it isn't randomized when the code has uncommitted changes
+	OktetoGitHeadEnvVar = "OKTETO_GIT_HEAD"
+
+	// OktetoGitDirtyEnvVar indicates if the code being deployed has uncommitted changes
+	OktetoGitDirtyEnvVar = "OKTETO_GIT_DIRTY"
+
+	// OktetoDefaultManifestEnvVar is the content of the default okteto manifest of the repository, fetched from okteto
+	// when the checkout has none. It's passed along to the commands run by okteto so they don't need to fetch it again
+	OktetoDefaultManifestEnvVar = "OKTETO_DEFAULT_MANIFEST"
+
 	// OktetoNamespaceLabel is the label used to identify the namespace where the resource lives
 	OktetoNamespaceLabel = "dev.okteto.com/namespace"
 
@@ -137,4 +166,13 @@ const (
 
 	// EnvironmentLabelKeyPrefix represents the prefix for the preview and pipeline labels
 	EnvironmentLabelKeyPrefix = "label.okteto.com"
+
+	// OktetoGitStatusTokenEnvVar defines the token used to report commit statuses to GitHub/GitLab on pipeline deploys
+	OktetoGitStatusTokenEnvVar = "OKTETO_GIT_STATUS_TOKEN"
+
+	// OktetoComposeWarningsAsErrorsEnvVar makes deploys fail if the compose file has fields not supported by okteto
+	OktetoComposeWarningsAsErrorsEnvVar = "OKTETO_COMPOSE_WARNINGS_AS_ERRORS"
+
+	// ComposeProfilesEnvVar defines the compose profiles enabled when no profile is passed as flag
+	ComposeProfilesEnvVar = "COMPOSE_PROFILES"
 )
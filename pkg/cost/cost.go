@@ -0,0 +1,179 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cost estimates the cost of the resources requested by a development environment
+package cost
+
+import (
+	"fmt"
+	"math"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// hoursPerMonth is the average number of hours of a month
+const hoursPerMonth = 730
+
+const gigabyte = 1 << 30
+
+// Pricing is the price of the resources of a cluster
+type Pricing struct {
+	Currency string `json:"currency" yaml:"currency"`
+	// CPU is the price of a CPU per hour
+	CPU float64 `json:"cpu" yaml:"cpu"`
+	// Memory is the price of a GB of memory per hour
+	Memory float64 `json:"memory" yaml:"memory"`
+	// Storage is the price of a GB of storage per month
+	Storage float64 `json:"storage" yaml:"storage"`
+}
+
+// Item is the resources requested by an object of a development environment
+type Item struct {
+	Kind     string `json:"kind" yaml:"kind"`
+	Name     string `json:"name" yaml:"name"`
+	Replicas int32  `json:"replicas" yaml:"replicas"`
+	// CPU is the number of CPUs requested by all the replicas
+	CPU float64 `json:"cpu" yaml:"cpu"`
+	// Memory is the number of GB of memory requested by all the replicas
+	Memory float64 `json:"memory" yaml:"memory"`
+	// Storage is the number of GB of storage requested by all the replicas
+	Storage float64 `json:"storage" yaml:"storage"`
+	// Monthly is the cost of the item per month. It's only set if there is a pricing
+	Monthly *float64 `json:"monthly,omitempty" yaml:"monthly,omitempty"`
+}
+
+// Estimate is the estimation of the resources requested by a development environment
+type Estimate struct {
+	Items    []Item   `json:"items" yaml:"items"`
+	Total    Item     `json:"total" yaml:"total"`
+	Currency string   `json:"currency,omitempty" yaml:"currency,omitempty"`
+	Pricing  *Pricing `json:"-" yaml:"-"`
+}
+
+// NewEstimate returns the estimation of the resources requested by the objects.
+// Objects that don't request resources are skipped. Containers without requests count their limits, as kubernetes does
+func NewEstimate(objects []runtime.Object, pricing *Pricing) *Estimate {
+	e := &Estimate{
+		Items:   []Item{},
+		Total:   Item{Kind: "Total"},
+		Pricing: pricing,
+	}
+	if pricing != nil {
+		e.Currency = pricing.Currency
+	}
+	for _, obj := range objects {
+		item, ok := getItem(obj)
+		if !ok {
+			continue
+		}
+		e.add(item)
+	}
+	return e
+}
+
+func (e *Estimate) add(item Item) {
+	if e.Pricing != nil {
+		item.Monthly = e.Pricing.monthly(item)
+	}
+	e.Items = append(e.Items, item)
+	e.Total.Replicas += item.Replicas
+	e.Total.CPU += item.CPU
+	e.Total.Memory += item.Memory
+	e.Total.Storage += item.Storage
+	if e.Pricing != nil {
+		e.Total.Monthly = e.Pricing.monthly(e.Total)
+	}
+}
+
+func (p *Pricing) monthly(item Item) *float64 {
+	cost := item.CPU*p.CPU*hoursPerMonth + item.Memory*p.Memory*hoursPerMonth + item.Storage*p.Storage
+	cost = math.Round(cost*100) / 100
+	return &cost
+}
+
+func getItem(obj runtime.Object) (Item, bool) {
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		return podItem("Deployment", o.Name, replicas(o.Spec.Replicas), o.Spec.Template.Spec), true
+	case *appsv1.StatefulSet:
+		item := podItem("StatefulSet", o.Name, replicas(o.Spec.Replicas), o.Spec.Template.Spec)
+		for _, pvc := range o.Spec.VolumeClaimTemplates {
+			item.Storage += toGB(pvc.Spec.Resources.Requests[apiv1.ResourceStorage]) * float64(item.Replicas)
+		}
+		return item, true
+	case *appsv1.DaemonSet:
+		return podItem("DaemonSet", o.Name, 1, o.Spec.Template.Spec), true
+	case *batchv1.Job:
+		return podItem("Job", o.Name, replicas(o.Spec.Parallelism), o.Spec.Template.Spec), true
+	case *apiv1.Pod:
+		if len(o.OwnerReferences) > 0 {
+			return Item{}, false
+		}
+		return podItem("Pod", o.Name, 1, o.Spec), true
+	case *apiv1.PersistentVolumeClaim:
+		return Item{
+			Kind:    "PersistentVolumeClaim",
+			Name:    o.Name,
+			Storage: toGB(o.Spec.Resources.Requests[apiv1.ResourceStorage]),
+		}, true
+	default:
+		return Item{}, false
+	}
+}
+
+func replicas(r *int32) int32 {
+	if r == nil {
+		return 1
+	}
+	return *r
+}
+
+func podItem(kind, name string, replicas int32, spec apiv1.PodSpec) Item {
+	item := Item{Kind: kind, Name: name, Replicas: replicas}
+	for _, c := range spec.Containers {
+		cpu, memory := getRequests(c.Resources)
+		item.CPU += float64(cpu.MilliValue()) / 1000 * float64(replicas)
+		item.Memory += toGB(memory) * float64(replicas)
+	}
+	return item
+}
+
+func getRequests(r apiv1.ResourceRequirements) (resource.Quantity, resource.Quantity) {
+	cpu, ok := r.Requests[apiv1.ResourceCPU]
+	if !ok {
+		cpu = r.Limits[apiv1.ResourceCPU]
+	}
+	memory, ok := r.Requests[apiv1.ResourceMemory]
+	if !ok {
+		memory = r.Limits[apiv1.ResourceMemory]
+	}
+	return cpu, memory
+}
+
+func toGB(q resource.Quantity) float64 {
+	return float64(q.Value()) / gigabyte
+}
+
+// FormatCPU returns the number of CPUs in a human readable format
+func FormatCPU(cpu float64) string {
+	return fmt.Sprintf("%.2f", cpu)
+}
+
+// FormatGB returns a number of GB in a human readable format
+func FormatGB(gb float64) string {
+	return fmt.Sprintf("%.2fGi", gb)
+}
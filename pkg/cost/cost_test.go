@@ -0,0 +1,111 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func container(cpu, memory string) apiv1.Container {
+	return apiv1.Container{
+		Name: "app",
+		Resources: apiv1.ResourceRequirements{
+			Requests: apiv1.ResourceList{
+				apiv1.ResourceCPU:    resource.MustParse(cpu),
+				apiv1.ResourceMemory: resource.MustParse(memory),
+			},
+		},
+	}
+}
+
+func testObjects() []runtime.Object {
+	return []runtime.Object{
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: int32Ptr(2),
+				Template: apiv1.PodTemplateSpec{
+					Spec: apiv1.PodSpec{
+						Containers: []apiv1.Container{
+							container("500m", "512Mi"),
+							{
+								Name: "sidecar",
+								Resources: apiv1.ResourceRequirements{
+									Limits: apiv1.ResourceList{
+										apiv1.ResourceCPU:    resource.MustParse("250m"),
+										apiv1.ResourceMemory: resource.MustParse("256Mi"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "mongodb"},
+			Spec: appsv1.StatefulSetSpec{
+				Template: apiv1.PodTemplateSpec{
+					Spec: apiv1.PodSpec{Containers: []apiv1.Container{container("1", "1Gi")}},
+				},
+				VolumeClaimTemplates: []apiv1.PersistentVolumeClaim{
+					{
+						Spec: apiv1.PersistentVolumeClaimSpec{
+							Resources: apiv1.ResourceRequirements{
+								Requests: apiv1.ResourceList{apiv1.ResourceStorage: resource.MustParse("10Gi")},
+							},
+						},
+					},
+				},
+			},
+		},
+		&apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "api-1234",
+				OwnerReferences: []metav1.OwnerReference{{Name: "api-rs"}},
+			},
+			Spec: apiv1.PodSpec{Containers: []apiv1.Container{container("500m", "512Mi")}},
+		},
+		&apiv1.Service{ObjectMeta: metav1.ObjectMeta{Name: "api"}},
+	}
+}
+
+func TestNewEstimate(t *testing.T) {
+	e := NewEstimate(testObjects(), nil)
+	assert.Equal(t, []Item{
+		{Kind: "Deployment", Name: "api", Replicas: 2, CPU: 1.5, Memory: 1.5},
+		{Kind: "StatefulSet", Name: "mongodb", Replicas: 1, CPU: 1, Memory: 1, Storage: 10},
+	}, e.Items)
+	assert.Equal(t, Item{Kind: "Total", Replicas: 3, CPU: 2.5, Memory: 2.5, Storage: 10}, e.Total)
+	assert.Nil(t, e.Total.Monthly)
+}
+
+func TestNewEstimateWithPricing(t *testing.T) {
+	e := NewEstimate(testObjects(), &Pricing{Currency: "USD", CPU: 0.03, Memory: 0.004, Storage: 0.1})
+	assert.Equal(t, "USD", e.Currency)
+	// 2.5 CPUs * 0.03 * 730 + 2.5 GB * 0.004 * 730 + 10 GB * 0.1
+	assert.Equal(t, 63.05, *e.Total.Monthly)
+	assert.Equal(t, 37.23, *e.Items[0].Monthly)
+}
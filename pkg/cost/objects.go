@@ -0,0 +1,152 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/afero"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+)
+
+// LoadPricing reads the pricing of a yaml file
+func LoadPricing(fs afero.Fs, path string) (*Pricing, error) {
+	b, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the pricing file '%s': %w", path, err)
+	}
+	p := &Pricing{}
+	if err := yaml.UnmarshalStrict(b, p); err != nil {
+		return nil, fmt.Errorf("failed to read the pricing file '%s': %w", path, err)
+	}
+	if p.CPU < 0 || p.Memory < 0 || p.Storage < 0 {
+		return nil, fmt.Errorf("the prices of the pricing file '%s' can't be negative", path)
+	}
+	return p, nil
+}
+
+// GetObjectsFromDir returns the kubernetes objects of the yaml files of a folder, like the ones written by 'okteto deploy --export-dir'.
+// Files with several documents are supported and the objects of unknown kinds are skipped
+func GetObjectsFromDir(fs afero.Fs, dir string) ([]runtime.Object, error) {
+	files, err := afero.ReadDir(fs, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the folder '%s': %w", dir, err)
+	}
+	names := []string{}
+	for _, f := range files {
+		ext := filepath.Ext(f.Name())
+		if !f.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			names = append(names, f.Name())
+		}
+	}
+	sort.Strings(names)
+
+	decoder := scheme.Codecs.UniversalDeserializer()
+	result := []runtime.Object{}
+	for _, name := range names {
+		content, err := afero.ReadFile(fs, filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read '%s': %w", name, err)
+		}
+		for _, doc := range strings.Split(string(content), "\n---") {
+			if strings.TrimSpace(doc) == "" {
+				continue
+			}
+			obj, _, err := decoder.Decode([]byte(doc), nil, nil)
+			if err != nil {
+				oktetoLog.Infof("skipping object of '%s': %s", name, err)
+				continue
+			}
+			result = append(result, obj)
+		}
+	}
+	return result, nil
+}
+
+// GetObjectsFromNamespace returns the objects of a namespace that request resources
+func GetObjectsFromNamespace(ctx context.Context, namespace, labelSelector string, c kubernetes.Interface) ([]runtime.Object, error) {
+	opts := metav1.ListOptions{LabelSelector: labelSelector}
+	result := []runtime.Object{}
+
+	dList, err := c.AppsV1().Deployments(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the deployments: %w", err)
+	}
+	for i := range dList.Items {
+		result = append(result, &dList.Items[i])
+	}
+
+	sfsList, err := c.AppsV1().StatefulSets(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the statefulsets: %w", err)
+	}
+	for i := range sfsList.Items {
+		result = append(result, &sfsList.Items[i])
+	}
+
+	dsList, err := c.AppsV1().DaemonSets(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the daemonsets: %w", err)
+	}
+	for i := range dsList.Items {
+		result = append(result, &dsList.Items[i])
+	}
+
+	podList, err := c.CoreV1().Pods(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the pods: %w", err)
+	}
+	for i := range podList.Items {
+		switch podList.Items[i].Status.Phase {
+		case apiv1.PodSucceeded, apiv1.PodFailed:
+			continue
+		}
+		result = append(result, &podList.Items[i])
+	}
+
+	pvcList, err := c.CoreV1().PersistentVolumeClaims(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the persistent volume claims: %w", err)
+	}
+	for i := range pvcList.Items {
+		// the volumes of the statefulsets are already counted by their volume claim templates
+		if !isStatefulSetVolume(pvcList.Items[i].Name, sfsList.Items) {
+			result = append(result, &pvcList.Items[i])
+		}
+	}
+	return result, nil
+}
+
+// isStatefulSetVolume returns if a persistent volume claim was created from the volume claim templates of a statefulset
+func isStatefulSetVolume(pvcName string, statefulsets []appsv1.StatefulSet) bool {
+	for _, sfs := range statefulsets {
+		for _, template := range sfs.Spec.VolumeClaimTemplates {
+			if strings.HasPrefix(pvcName, fmt.Sprintf("%s-%s-", template.Name, sfs.Name)) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestLoadPricing(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "pricing.yml", []byte("currency: EUR\ncpu: 0.02\nmemory: 0.003\nstorage: 0.05\n"), 0600))
+	require.NoError(t, afero.WriteFile(fs, "unknown.yml", []byte("gpu: 1\n"), 0600))
+	require.NoError(t, afero.WriteFile(fs, "negative.yml", []byte("cpu: -1\n"), 0600))
+
+	p, err := LoadPricing(fs, "pricing.yml")
+	require.NoError(t, err)
+	assert.Equal(t, &Pricing{Currency: "EUR", CPU: 0.02, Memory: 0.003, Storage: 0.05}, p)
+
+	for _, path := range []string{"unknown.yml", "negative.yml", "missing.yml"} {
+		_, err := LoadPricing(fs, path)
+		assert.Error(t, err, path)
+	}
+}
+
+func TestGetObjectsFromDir(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	deployment := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: api
+spec:
+  replicas: 2
+  selector:
+    matchLabels:
+      app: api
+  template:
+    spec:
+      containers:
+      - name: api
+        image: api
+        resources:
+          requests:
+            cpu: 250m
+            memory: 256Mi
+`
+	pvc := `apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: data
+spec:
+  resources:
+    requests:
+      storage: 5Gi
+---
+apiVersion: example.com/v1
+kind: Unknown
+metadata:
+  name: unknown
+`
+	require.NoError(t, afero.WriteFile(fs, "out/deployment-api.yaml", []byte(deployment), 0600))
+	require.NoError(t, afero.WriteFile(fs, "out/pvc.yml", []byte(pvc), 0600))
+	require.NoError(t, afero.WriteFile(fs, "out/README.md", []byte("# ignored"), 0600))
+
+	objects, err := GetObjectsFromDir(fs, "out")
+	require.NoError(t, err)
+	e := NewEstimate(objects, nil)
+	assert.Equal(t, []Item{
+		{Kind: "Deployment", Name: "api", Replicas: 2, CPU: 0.5, Memory: 0.5},
+		{Kind: "PersistentVolumeClaim", Name: "data", Storage: 5},
+	}, e.Items)
+
+	_, err = GetObjectsFromDir(fs, "missing")
+	assert.Error(t, err)
+}
+
+func TestGetObjectsFromNamespace(t *testing.T) {
+	labels := map[string]string{"dev.okteto.com/deployed-by": "movies"}
+	c := fake.NewSimpleClientset(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "ns", Labels: labels}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "ns"}},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "mongodb", Namespace: "ns", Labels: labels},
+			Spec: appsv1.StatefulSetSpec{
+				VolumeClaimTemplates: []apiv1.PersistentVolumeClaim{{ObjectMeta: metav1.ObjectMeta{Name: "data"}}},
+			},
+		},
+		&apiv1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "data-mongodb-0", Namespace: "ns", Labels: labels}},
+		&apiv1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "cache", Namespace: "ns", Labels: labels},
+			Spec: apiv1.PersistentVolumeClaimSpec{
+				Resources: apiv1.ResourceRequirements{
+					Requests: apiv1.ResourceList{apiv1.ResourceStorage: resource.MustParse("1Gi")},
+				},
+			},
+		},
+		&apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "ns", Labels: labels},
+			Status:     apiv1.PodStatus{Phase: apiv1.PodSucceeded},
+		},
+	)
+
+	objects, err := GetObjectsFromNamespace(context.Background(), "ns", "dev.okteto.com/deployed-by=movies", c)
+	require.NoError(t, err)
+	names := []string{}
+	for _, item := range NewEstimate(objects, nil).Items {
+		names = append(names, item.Kind+"/"+item.Name)
+	}
+	assert.Equal(t, []string{"Deployment/api", "StatefulSet/mongodb", "PersistentVolumeClaim/cache"}, names)
+}
@@ -0,0 +1,315 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package devcontainer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alessio/shellescape"
+	"github.com/okteto/okteto/pkg/format"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/spf13/afero"
+)
+
+var (
+	// ErrNotFound is raised when there is no devcontainer.json in the folder
+	ErrNotFound = errors.New("devcontainer.json not found")
+
+	// paths where VS Code looks for the devcontainer definition, in order of precedence
+	devcontainerPaths = []string{
+		filepath.Join(".devcontainer", "devcontainer.json"),
+		".devcontainer.json",
+	}
+)
+
+const (
+	defaultWorkspacesFolder = "/workspaces"
+
+	// stateFolder is persisted across 'okteto up' sessions to keep track of the lifecycle commands already run
+	stateFolder = "/var/okteto/devcontainer"
+
+	// postCreateMarker is created once the postCreateCommand succeeds, so it only runs when the dev container is created
+	postCreateMarker = stateFolder + "/.postCreateCommand"
+)
+
+// DevContainer represents the subset of the devcontainer.json spec that can be translated into an okteto dev container
+type DevContainer struct {
+	Name              string            `json:"name"`
+	Image             string            `json:"image"`
+	Build             *Build            `json:"build"`
+	DockerFile        string            `json:"dockerFile"`
+	Context           string            `json:"context"`
+	ForwardPorts      []interface{}     `json:"forwardPorts"`
+	PostCreateCommand interface{}       `json:"postCreateCommand"`
+	WorkspaceFolder   string            `json:"workspaceFolder"`
+	ContainerEnv      map[string]string `json:"containerEnv"`
+	RemoteEnv         map[string]string `json:"remoteEnv"`
+
+	// dir is the folder containing the devcontainer.json file, relative to the workspace
+	dir string
+}
+
+// Build represents the build section of a devcontainer.json
+type Build struct {
+	Dockerfile string            `json:"dockerfile"`
+	Context    string            `json:"context"`
+	Args       map[string]string `json:"args"`
+	Target     string            `json:"target"`
+}
+
+// Load reads the devcontainer.json of the workspace folder
+func Load(fs afero.Fs, workspace string) (*DevContainer, error) {
+	for _, p := range devcontainerPaths {
+		b, err := afero.ReadFile(fs, filepath.Join(workspace, p))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		dc := &DevContainer{}
+		if err := json.Unmarshal(standardizeJSON(b), dc); err != nil {
+			return nil, fmt.Errorf("error parsing '%s': %w", p, err)
+		}
+		dc.dir = filepath.Dir(p)
+		return dc, nil
+	}
+	return nil, ErrNotFound
+}
+
+// ToDev translates the devcontainer definition into an okteto dev container
+func (dc *DevContainer) ToDev(workspace string) (*model.Dev, error) {
+	name := format.ResourceK8sMetaString(dc.Name)
+	if name == "" {
+		var err error
+		name, err = model.GetValidNameFromFolder(workspace)
+		if err != nil {
+			return nil, err
+		}
+		name = format.ResourceK8sMetaString(name)
+	}
+
+	remotePath := dc.WorkspaceFolder
+	if remotePath == "" {
+		remotePath = path.Join(defaultWorkspacesFolder, filepath.Base(workspace))
+	}
+
+	dev := model.NewDev()
+	dev.Name = name
+	dev.Autocreate = true
+	dev.Image = dc.getImage()
+	dev.Workdir = remotePath
+	dev.Sync = model.Sync{
+		RescanInterval: model.DefaultSyncthingRescanInterval,
+		Folders: []model.SyncFolder{
+			{
+				LocalPath:  workspace,
+				RemotePath: remotePath,
+			},
+		},
+	}
+	dev.Environment = dc.getEnvironment()
+	if command := dc.getPostCreateCommand(); command != "" {
+		dev.Command = model.Command{Values: []string{"sh", "-c", getRunOnceCommand(command)}}
+		dev.Volumes = append(dev.Volumes, model.Volume{RemotePath: stateFolder})
+	}
+
+	forwards, err := dc.getForwards()
+	if err != nil {
+		return nil, err
+	}
+	dev.Forward = forwards
+	return dev, nil
+}
+
+// getImage translates the image or the build section of the devcontainer.json into the image of the dev container
+func (dc *DevContainer) getImage() *model.BuildInfo {
+	if dc.Image != "" {
+		return &model.BuildInfo{Name: dc.Image}
+	}
+	b := dc.Build
+	if b == nil && dc.DockerFile != "" {
+		b = &Build{Dockerfile: dc.DockerFile, Context: dc.Context}
+	}
+	if b == nil || b.Dockerfile == "" {
+		return &model.BuildInfo{}
+	}
+
+	// paths of the devcontainer.json are relative to the folder containing it
+	buildContext := b.Context
+	if buildContext == "" {
+		buildContext = "."
+	}
+	args := model.BuildArgs{}
+	for k, v := range b.Args {
+		args = append(args, model.BuildArg{Name: k, Value: v})
+	}
+	sort.Slice(args, func(i, j int) bool {
+		return args[i].Name < args[j].Name
+	})
+	return &model.BuildInfo{
+		Context:    filepath.Join(dc.dir, buildContext),
+		Dockerfile: filepath.Join(dc.dir, b.Dockerfile),
+		Target:     b.Target,
+		Args:       args,
+	}
+}
+
+// getEnvironment merges containerEnv and remoteEnv, sorted by name. remoteEnv takes precedence
+func (dc *DevContainer) getEnvironment() model.Environment {
+	envs := map[string]string{}
+	for k, v := range dc.ContainerEnv {
+		envs[k] = v
+	}
+	for k, v := range dc.RemoteEnv {
+		envs[k] = v
+	}
+	result := model.Environment{}
+	for k, v := range envs {
+		result = append(result, model.EnvVar{Name: k, Value: v})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// getPostCreateCommand returns the postCreateCommand as a shell command.
+// It can be defined as a string, an array of arguments or an object of named commands.
+// The arguments of the array form are run without a shell, so they are quoted to keep them as a single argument
+func (dc *DevContainer) getPostCreateCommand() string {
+	switch cmd := dc.PostCreateCommand.(type) {
+	case string:
+		return cmd
+	case []interface{}:
+		args := []string{}
+		for _, arg := range cmd {
+			args = append(args, shellescape.Quote(fmt.Sprint(arg)))
+		}
+		return strings.Join(args, " ")
+	case map[string]interface{}:
+		names := []string{}
+		for name := range cmd {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		commands := []string{}
+		for _, name := range names {
+			d := &DevContainer{PostCreateCommand: cmd[name]}
+			if c := d.getPostCreateCommand(); c != "" {
+				commands = append(commands, c)
+			}
+		}
+		return strings.Join(commands, " && ")
+	case nil:
+		return ""
+	default:
+		oktetoLog.Infof("ignoring postCreateCommand of type %T", cmd)
+		return ""
+	}
+}
+
+// getRunOnceCommand wraps the postCreateCommand to skip it when it already succeeded in a previous session
+func getRunOnceCommand(command string) string {
+	return fmt.Sprintf("if [ ! -f %[1]s ]; then (%[2]s) && touch %[1]s || exit 1; fi && exec sh", postCreateMarker, command)
+}
+
+// getForwards translates forwardPorts, defined as ports of the dev container or as "service:port"
+func (dc *DevContainer) getForwards() ([]forward.Forward, error) {
+	result := []forward.Forward{}
+	for _, p := range dc.ForwardPorts {
+		switch port := p.(type) {
+		case float64:
+			result = append(result, forward.Forward{Local: int(port), Remote: int(port)})
+		case string:
+			host, portStr, found := strings.Cut(port, ":")
+			if !found {
+				portStr = host
+				host = ""
+			}
+			n, err := strconv.Atoi(portStr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid forwardPorts value '%s'", port)
+			}
+			f := forward.Forward{Local: n, Remote: n}
+			if host != "" && host != "localhost" {
+				f.Service = true
+				f.ServiceName = host
+			}
+			result = append(result, f)
+		default:
+			return nil, fmt.Errorf("invalid forwardPorts value '%v'", p)
+		}
+	}
+	return result, nil
+}
+
+// standardizeJSON removes the comments and trailing commas allowed in devcontainer.json files
+func standardizeJSON(b []byte) []byte {
+	result := make([]byte, 0, len(b))
+	inString := false
+	for i := 0; i < len(b); i++ {
+		c := b[i]
+		if inString {
+			result = append(result, c)
+			if c == '\\' && i+1 < len(b) {
+				i++
+				result = append(result, b[i])
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+		switch {
+		case c == '"':
+			inString = true
+			result = append(result, c)
+		case c == '/' && i+1 < len(b) && b[i+1] == '/':
+			for i < len(b) && b[i] != '\n' {
+				i++
+			}
+			if i < len(b) {
+				result = append(result, '\n')
+			}
+		case c == '/' && i+1 < len(b) && b[i+1] == '*':
+			i += 2
+			for i+1 < len(b) && !(b[i] == '*' && b[i+1] == '/') {
+				i++
+			}
+			i++
+		case c == '}' || c == ']':
+			j := len(result) - 1
+			for j >= 0 && strings.ContainsRune(" \t\r\n", rune(result[j])) {
+				j--
+			}
+			if j >= 0 && result[j] == ',' {
+				result = append(result[:j], result[j+1:]...)
+			}
+			result = append(result, c)
+		default:
+			result = append(result, c)
+		}
+	}
+	return result
+}
@@ -0,0 +1,135 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package devcontainer
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadAndToDev(t *testing.T) {
+	workspace := filepath.Join("/", "src", "movies")
+	fs := afero.NewMemMapFs()
+	content := `{
+	// VS Code devcontainers allow comments
+	"name": "Movies API",
+	"build": {
+		"dockerfile": "Dockerfile",
+		"context": "..",
+		"args": {"VARIANT": "18"}, /* and trailing commas */
+	},
+	"forwardPorts": [3000, "db:5432", "localhost:8080"],
+	"postCreateCommand": "npm install",
+	"containerEnv": {"NODE_ENV": "development"},
+	"remoteEnv": {"URL": "http://localhost"},
+}`
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(workspace, ".devcontainer", "devcontainer.json"), []byte(content), 0600))
+
+	dc, err := Load(fs, workspace)
+	require.NoError(t, err)
+
+	dev, err := dc.ToDev(workspace)
+	require.NoError(t, err)
+	assert.Equal(t, "movies-api", dev.Name)
+	assert.True(t, dev.Autocreate)
+	assert.Equal(t, &model.BuildInfo{
+		Context:    ".",
+		Dockerfile: filepath.Join(".devcontainer", "Dockerfile"),
+		Args:       model.BuildArgs{{Name: "VARIANT", Value: "18"}},
+	}, dev.Image)
+	assert.Equal(t, []model.SyncFolder{{LocalPath: workspace, RemotePath: "/workspaces/movies"}}, dev.Sync.Folders)
+	assert.Equal(t, []forward.Forward{
+		{Local: 3000, Remote: 3000},
+		{Local: 5432, Remote: 5432, Service: true, ServiceName: "db"},
+		{Local: 8080, Remote: 8080},
+	}, dev.Forward)
+	assert.Equal(t, model.Environment{{Name: "NODE_ENV", Value: "development"}, {Name: "URL", Value: "http://localhost"}}, dev.Environment)
+	assert.Equal(t, []string{"sh", "-c", "if [ ! -f /var/okteto/devcontainer/.postCreateCommand ]; then (npm install) && touch /var/okteto/devcontainer/.postCreateCommand || exit 1; fi && exec sh"}, dev.Command.Values)
+	assert.Equal(t, []model.Volume{{RemotePath: "/var/okteto/devcontainer"}}, dev.Volumes)
+}
+
+func TestToDevWithoutPostCreateCommand(t *testing.T) {
+	dc := &DevContainer{Name: "api", Image: "node:18"}
+	dev, err := dc.ToDev(filepath.Join("/", "src", "movies"))
+	require.NoError(t, err)
+	assert.Empty(t, dev.Command.Values)
+	assert.Empty(t, dev.Volumes)
+}
+
+func TestLoadNotFound(t *testing.T) {
+	_, err := Load(afero.NewMemMapFs(), "/src")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestGetPostCreateCommand(t *testing.T) {
+	var tests = []struct {
+		name     string
+		command  interface{}
+		expected string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:     "string",
+			command:  "make setup",
+			expected: "make setup",
+		},
+		{
+			name:     "array",
+			command:  []interface{}{"yarn", "install"},
+			expected: "yarn install",
+		},
+		{
+			name:     "array with spaces",
+			command:  []interface{}{"echo", "hello world", "$HOME"},
+			expected: "echo 'hello world' '$HOME'",
+		},
+		{
+			name:     "object",
+			command:  map[string]interface{}{"server": "npm install", "client": []interface{}{"yarn", "install"}},
+			expected: "yarn install && npm install",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dc := &DevContainer{PostCreateCommand: tt.command}
+			assert.Equal(t, tt.expected, dc.getPostCreateCommand())
+		})
+	}
+}
+
+func TestStandardizeJSON(t *testing.T) {
+	input := `{
+  "url": "http://okteto.com", // comment
+  "escaped": "a \"quoted\" // value",
+  /* block
+     comment */
+  "list": [1, 2,],
+}`
+	result := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(standardizeJSON([]byte(input)), &result))
+	assert.Equal(t, map[string]interface{}{
+		"url":     "http://okteto.com",
+		"escaped": `a "quoted" // value`,
+		"list":    []interface{}{float64(1), float64(2)},
+	}, result)
+}
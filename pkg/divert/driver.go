@@ -25,8 +25,10 @@ import (
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	istioNetworkingV1beta1 "istio.io/api/networking/v1beta1"
+	istioclientset "istio.io/client-go/pkg/clientset/versioned"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 )
 
 type Driver interface {
@@ -37,6 +39,17 @@ type Driver interface {
 }
 
 func New(m *model.Manifest, c kubernetes.Interface) (Driver, error) {
+	return newDriver(m, c, virtualservices.GetIstioClient)
+}
+
+// NewWithConfig returns the divert driver of a manifest, creating its istio client from the given config
+func NewWithConfig(m *model.Manifest, c kubernetes.Interface, cfg *rest.Config) (Driver, error) {
+	return newDriver(m, c, func() (*istioclientset.Clientset, error) {
+		return istioclientset.NewForConfig(cfg)
+	})
+}
+
+func newDriver(m *model.Manifest, c kubernetes.Interface, getIstioClient func() (*istioclientset.Clientset, error)) (Driver, error) {
 	if !okteto.IsOkteto() {
 		return nil, oktetoErrors.ErrDivertNotSupported
 	}
@@ -45,7 +58,7 @@ func New(m *model.Manifest, c kubernetes.Interface) (Driver, error) {
 		return weaver.New(m, c), nil
 	}
 
-	ic, err := virtualservices.GetIstioClient()
+	ic, err := getIstioClient()
 	if err != nil {
 		return nil, fmt.Errorf("error creating istio client: %w", err)
 	}
@@ -16,6 +16,7 @@ package istio
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/okteto/okteto/pkg/k8s/virtualservices"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
@@ -38,12 +39,15 @@ type Driver struct {
 	divert      model.DivertDeploy
 	client      kubernetes.Interface
 	istioClient istioclientset.Interface
+	now         func() time.Time
 }
 
 // DivertTransformation represents the annotation for the okteto mutation webhook to divert a virtual service
 type DivertTransformation struct {
-	Namespace string   `json:"namespace"`
-	Routes    []string `json:"routes,omitempty"`
+	Namespace string             `json:"namespace"`
+	Routes    []string           `json:"routes,omitempty"`
+	Rules     []model.DivertRule `json:"rules,omitempty"`
+	CreatedAt string             `json:"createdAt,omitempty"`
 }
 
 func New(m *model.Manifest, c kubernetes.Interface, ic istioclientset.Interface) *Driver {
@@ -53,6 +57,7 @@ func New(m *model.Manifest, c kubernetes.Interface, ic istioclientset.Interface)
 		divert:      *m.Deploy.Divert,
 		client:      c,
 		istioClient: ic,
+		now:         time.Now,
 	}
 }
 
@@ -134,7 +139,7 @@ func (d *Driver) retryTranslateDivertVirtualService(ctx context.Context, divertV
 		if err != nil {
 			return err
 		}
-		translatedVS, err := d.translateDivertVirtualService(vs, divertVS.Routes)
+		translatedVS, err := d.translateDivertVirtualService(vs, divertVS.Routes, divertVS.Rules)
 		if err != nil {
 			return err
 		}
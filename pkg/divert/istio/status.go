@@ -0,0 +1,162 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/k8s/configmaps"
+	"github.com/okteto/okteto/pkg/k8s/virtualservices"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	istioclientset "istio.io/client-go/pkg/clientset/versioned"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DivertStatus represents a virtual service diverted to a developer namespace
+type DivertStatus struct {
+	// CreatedAt is zero for diverts created by previous versions of the CLI
+	CreatedAt       time.Time
+	VirtualService  string
+	Namespace       string
+	TargetNamespace string
+	Annotation      string
+	Stale           bool
+}
+
+// ListDiverts returns the diverts found in the virtual services of namespaces that send traffic to any of the target namespaces
+func ListDiverts(ctx context.Context, namespaces []string, targets map[string]bool, ic istioclientset.Interface) ([]DivertStatus, error) {
+	annotationPrefix := strings.Split(constants.OktetoDivertAnnotationTemplate, "%s")[0]
+	result := []DivertStatus{}
+	for _, ns := range namespaces {
+		vsList, err := virtualservices.List(ctx, ns, ic)
+		if err != nil {
+			if k8sErrors.IsForbidden(err) || k8sErrors.IsNotFound(err) {
+				oktetoLog.Infof("skipping virtual services of namespace '%s': %s", ns, err)
+				continue
+			}
+			return nil, fmt.Errorf("error listing virtual services of namespace '%s': %w", ns, err)
+		}
+		for _, vs := range vsList {
+			for key, value := range vs.Annotations {
+				if !strings.HasPrefix(key, annotationPrefix) {
+					continue
+				}
+				transformation := DivertTransformation{}
+				if err := json.Unmarshal([]byte(value), &transformation); err != nil {
+					oktetoLog.Infof("ignoring annotation '%s' of virtual service '%s/%s': %s", key, vs.Namespace, vs.Name, err)
+					continue
+				}
+				if !targets[transformation.Namespace] {
+					continue
+				}
+				status := DivertStatus{
+					VirtualService:  vs.Name,
+					Namespace:       vs.Namespace,
+					TargetNamespace: transformation.Namespace,
+					Annotation:      key,
+				}
+				if transformation.CreatedAt != "" {
+					if createdAt, err := time.Parse(time.RFC3339, transformation.CreatedAt); err == nil {
+						status.CreatedAt = createdAt
+					}
+				}
+				result = append(result, status)
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		if result[i].VirtualService != result[j].VirtualService {
+			return result[i].VirtualService < result[j].VirtualService
+		}
+		return result[i].TargetNamespace < result[j].TargetNamespace
+	})
+	return result, nil
+}
+
+// MarkStaleDiverts flags the diverts whose target namespace or dev environment doesn't exist anymore
+func MarkStaleDiverts(ctx context.Context, diverts []DivertStatus, c kubernetes.Interface) error {
+	activeAnnotations := map[string]map[string]bool{}
+	for i := range diverts {
+		target := diverts[i].TargetNamespace
+		if _, ok := activeAnnotations[target]; !ok {
+			annotations, err := getActiveDivertAnnotations(ctx, target, c)
+			if err != nil {
+				return err
+			}
+			activeAnnotations[target] = annotations
+		}
+		diverts[i].Stale = !activeAnnotations[target][diverts[i].Annotation]
+	}
+	return nil
+}
+
+// getActiveDivertAnnotations returns the divert annotations of the dev environments deployed in namespace
+func getActiveDivertAnnotations(ctx context.Context, namespace string, c kubernetes.Interface) (map[string]bool, error) {
+	result := map[string]bool{}
+	if _, err := c.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{}); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return result, nil
+		}
+		return nil, fmt.Errorf("error getting namespace '%s': %w", namespace, err)
+	}
+
+	cfgList, err := configmaps.List(ctx, namespace, fmt.Sprintf("%s=true", model.GitDeployLabel), c)
+	if err != nil {
+		return nil, fmt.Errorf("error listing dev environments of namespace '%s': %w", namespace, err)
+	}
+	for _, cfg := range cfgList {
+		if name := cfg.Data["name"]; name != "" {
+			result[divertAnnotationName(namespace, name)] = true
+		}
+	}
+	return result, nil
+}
+
+// CleanDivert restores the virtual service removing the divert annotation
+func CleanDivert(ctx context.Context, divert DivertStatus, ic istioclientset.Interface) error {
+	for retries := 0; retries < UPDATE_CONFLICT_RETRIES; retries++ {
+		vs, err := virtualservices.Get(ctx, divert.VirtualService, divert.Namespace, ic)
+		if err != nil {
+			if k8sErrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		if _, ok := vs.Annotations[divert.Annotation]; !ok {
+			return nil
+		}
+		restoredVS := vs.DeepCopy()
+		delete(restoredVS.Annotations, divert.Annotation)
+		err = virtualservices.Update(ctx, restoredVS, ic)
+		if err == nil {
+			return nil
+		}
+		if !k8sErrors.IsConflict(err) {
+			return err
+		}
+	}
+	return fmt.Errorf("error restoring virtual service '%s/%s': too many conflicts", divert.Namespace, divert.VirtualService)
+}
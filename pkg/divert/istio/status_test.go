@@ -0,0 +1,108 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istio
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	istioV1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	istioFake "istio.io/client-go/pkg/clientset/versioned/fake"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_ListAndCleanDiverts(t *testing.T) {
+	ctx := context.Background()
+	activeAnnotation := divertAnnotationName("cindy", "movies")
+	staleAnnotation := divertAnnotationName("cindy", "old")
+	otherAnnotation := divertAnnotationName("john", "movies")
+
+	vs := &istioV1beta1.VirtualService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "frontend",
+			Namespace: "staging",
+			Annotations: map[string]string{
+				activeAnnotation: `{"namespace":"cindy","createdAt":"2023-06-01T10:00:00Z"}`,
+				staleAnnotation:  `{"namespace":"cindy"}`,
+				otherAnnotation:  `{"namespace":"john"}`,
+				"other":          "value",
+			},
+		},
+	}
+	ic := istioFake.NewSimpleClientset(vs)
+	c := fake.NewSimpleClientset(
+		&apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "cindy"}},
+		&apiv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "okteto-git-movies",
+				Namespace: "cindy",
+				Labels:    map[string]string{model.GitDeployLabel: "true"},
+			},
+			Data: map[string]string{"name": "movies"},
+		},
+	)
+
+	diverts, err := ListDiverts(ctx, []string{"staging"}, map[string]bool{"cindy": true}, ic)
+	require.NoError(t, err)
+	require.Len(t, diverts, 2)
+
+	require.NoError(t, MarkStaleDiverts(ctx, diverts, c))
+	stale := map[string]bool{}
+	for _, d := range diverts {
+		assert.Equal(t, "frontend", d.VirtualService)
+		assert.Equal(t, "cindy", d.TargetNamespace)
+		stale[d.Annotation] = d.Stale
+		if d.Annotation == activeAnnotation {
+			assert.Equal(t, time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC), d.CreatedAt)
+		}
+	}
+	assert.Equal(t, map[string]bool{activeAnnotation: false, staleAnnotation: true}, stale)
+
+	for _, d := range diverts {
+		if d.Stale {
+			require.NoError(t, CleanDivert(ctx, d, ic))
+		}
+	}
+	result, err := ic.NetworkingV1beta1().VirtualServices("staging").Get(ctx, "frontend", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotContains(t, result.Annotations, staleAnnotation)
+	assert.Contains(t, result.Annotations, activeAnnotation)
+	assert.Contains(t, result.Annotations, otherAnnotation)
+}
+
+func Test_translateDivertVirtualServiceCreatedAt(t *testing.T) {
+	now := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+	d := &Driver{
+		name:      "test",
+		namespace: "cindy",
+		now:       func() time.Time { return now },
+	}
+	vs := &istioV1beta1.VirtualService{
+		ObjectMeta: metav1.ObjectMeta{Name: "service-a", Namespace: "staging"},
+	}
+	result, err := d.translateDivertVirtualService(vs, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, `{"namespace":"cindy","createdAt":"2023-06-01T10:00:00Z"}`, result.Annotations[d.getDivertAnnotationName()])
+
+	d.now = func() time.Time { return now.Add(time.Hour) }
+	result, err = d.translateDivertVirtualService(result, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, `{"namespace":"cindy","createdAt":"2023-06-01T10:00:00Z"}`, result.Annotations[d.getDivertAnnotationName()])
+}
@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/k8s/labels"
@@ -30,7 +31,12 @@ import (
 )
 
 func (d *Driver) getDivertAnnotationName() string {
-	divertHash := sha256.Sum256([]byte(fmt.Sprintf("%s-%s", d.namespace, d.name)))
+	return divertAnnotationName(d.namespace, d.name)
+}
+
+// divertAnnotationName returns the annotation set in the diverted virtual services by the dev environment name in namespace
+func divertAnnotationName(namespace, name string) string {
+	divertHash := sha256.Sum256([]byte(fmt.Sprintf("%s-%s", namespace, name)))
 
 	return fmt.Sprintf(constants.OktetoDivertAnnotationTemplate, hex.EncodeToString(divertHash[:20]))
 }
@@ -39,7 +45,7 @@ func (d *Driver) getDeprecatedDivertAnnotationName() string {
 	return fmt.Sprintf(constants.OktetoDeprecatedDivertAnnotationTemplate, d.namespace, d.name)
 }
 
-func (d *Driver) translateDivertVirtualService(vs *istioV1beta1.VirtualService, routes []string) (*istioV1beta1.VirtualService, error) {
+func (d *Driver) translateDivertVirtualService(vs *istioV1beta1.VirtualService, routes []string, rules []model.DivertRule) (*istioV1beta1.VirtualService, error) {
 	result := vs.DeepCopy()
 	if result.Annotations == nil {
 		result.Annotations = map[string]string{}
@@ -47,6 +53,16 @@ func (d *Driver) translateDivertVirtualService(vs *istioV1beta1.VirtualService,
 	annotation := DivertTransformation{
 		Namespace: d.namespace,
 		Routes:    routes,
+		Rules:     rules,
+	}
+	if d.now != nil {
+		annotation.CreatedAt = d.now().UTC().Format(time.RFC3339)
+		if previous, ok := result.Annotations[d.getDivertAnnotationName()]; ok {
+			prevAnnotation := DivertTransformation{}
+			if err := json.Unmarshal([]byte(previous), &prevAnnotation); err == nil && prevAnnotation.CreatedAt != "" {
+				annotation.CreatedAt = prevAnnotation.CreatedAt
+			}
+		}
 	}
 	bytes, err := json.Marshal(annotation)
 	if err != nil {
@@ -104,5 +120,23 @@ func (d *Driver) injectDivertHeader(vsSpec *istioNetworkingV1beta1.VirtualServic
 			vsSpec.Http[i].Headers.Request.Add = map[string]string{}
 		}
 		vsSpec.Http[i].Headers.Request.Add[constants.OktetoDivertBaggageHeader] = fmt.Sprintf("%s=%s", constants.OktetoDivertHeaderName, d.namespace)
+		for name, value := range d.getHeaderRules() {
+			vsSpec.Http[i].Headers.Request.Add[name] = value
+		}
 	}
 }
+
+// getHeaderRules returns the headers defined by the custom divert rules.
+// They are injected in the developer virtual services so the requests match the rules of the diverted services
+func (d *Driver) getHeaderRules() map[string]string {
+	result := map[string]string{}
+	for i := range d.divert.VirtualServices {
+		for _, rule := range d.divert.VirtualServices[i].Rules {
+			if rule.Header == nil {
+				continue
+			}
+			result[rule.Header.Name] = rule.Header.Value
+		}
+	}
+	return result
+}
@@ -33,6 +33,7 @@ func Test_translateDivertVirtualService(t *testing.T) {
 		name     string
 		vs       *istioV1beta1.VirtualService
 		routes   []string
+		rules    []model.DivertRule
 		expected *istioV1beta1.VirtualService
 	}{
 		{
@@ -80,6 +81,28 @@ func Test_translateDivertVirtualService(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "add-divert-annotation-with-rules",
+			vs: &istioV1beta1.VirtualService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "service-a",
+					Namespace: "staging",
+				},
+			},
+			rules: []model.DivertRule{
+				{Header: &model.DivertHeaderRule{Name: "x-team", Value: "cindy"}},
+				{GRPCMethodPrefix: "/movies.Catalog/"},
+			},
+			expected: &istioV1beta1.VirtualService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "service-a",
+					Namespace: "staging",
+					Annotations: map[string]string{
+						fmt.Sprintf(constants.OktetoDivertAnnotationTemplate, "2615052508acbfaddeba0eeded4131631ea31a02"): `{"namespace":"cindy","rules":[{"header":{"name":"x-team","value":"cindy"}},{"grpcMethodPrefix":"/movies.Catalog/"}]}`,
+					},
+				},
+			},
+		},
 	}
 
 	d := &Driver{
@@ -96,7 +119,7 @@ func Test_translateDivertVirtualService(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := d.translateDivertVirtualService(tt.vs, tt.routes)
+			result, err := d.translateDivertVirtualService(tt.vs, tt.routes, tt.rules)
 			assert.NoError(t, err)
 			assert.Equal(t, result.Annotations, tt.expected.Annotations)
 		})
@@ -388,3 +411,30 @@ func Test_translateDivertHost(t *testing.T) {
 		})
 	}
 }
+
+func Test_injectDivertHeaderWithRules(t *testing.T) {
+	d := &Driver{
+		name:      "test",
+		namespace: "cindy",
+		divert: model.DivertDeploy{
+			VirtualServices: []model.DivertVirtualService{
+				{
+					Name:      "virtual-service-a",
+					Namespace: "staging",
+					Rules: []model.DivertRule{
+						{Header: &model.DivertHeaderRule{Name: "x-team", Value: "cindy"}},
+						{GRPCMethodPrefix: "/movies.Catalog/"},
+					},
+				},
+			},
+		},
+	}
+	vsSpec := &istioNetworkingV1beta1.VirtualService{
+		Http: []*istioNetworkingV1beta1.HTTPRoute{{}},
+	}
+	d.injectDivertHeader(vsSpec)
+	assert.Equal(t, map[string]string{
+		constants.OktetoDivertBaggageHeader: "okteto-divert=cindy",
+		"x-team":                            "cindy",
+	}, vsSpec.Http[0].Headers.Request.Add)
+}
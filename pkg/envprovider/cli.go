@@ -0,0 +1,119 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package envprovider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// cliTimeout is the maximum time to resolve a reference
+const cliTimeout = 30 * time.Second
+
+// cliProvider resolves references with the CLI of a secret manager, using the credentials of its current session
+type cliProvider struct {
+	name       string
+	binary     string
+	installURL string
+	syntax     string
+	args       func(ref string) ([]string, bool)
+}
+
+func newVaultProvider() *cliProvider {
+	return &cliProvider{
+		name:       "vault",
+		binary:     "vault",
+		installURL: "https://developer.hashicorp.com/vault/install",
+		syntax:     "${vault:<path>#<key>}",
+		args: func(ref string) ([]string, bool) {
+			path, key, ok := strings.Cut(ref, "#")
+			if !ok || path == "" || key == "" {
+				return nil, false
+			}
+			return []string{"kv", "get", fmt.Sprintf("-field=%s", key), path}, true
+		},
+	}
+}
+
+func newAWSSecretsManagerProvider() *cliProvider {
+	return &cliProvider{
+		name:       "aws-sm",
+		binary:     "aws",
+		installURL: "https://aws.amazon.com/cli/",
+		syntax:     "${aws-sm:<name>}",
+		args: func(ref string) ([]string, bool) {
+			return []string{"secretsmanager", "get-secret-value", "--secret-id", ref, "--query", "SecretString", "--output", "text"}, true
+		},
+	}
+}
+
+func newOnePasswordProvider() *cliProvider {
+	return &cliProvider{
+		name:       "op",
+		binary:     "op",
+		installURL: "https://developer.1password.com/docs/cli/get-started/",
+		syntax:     "${op://<vault>/<item>/<field>}",
+		args: func(ref string) ([]string, bool) {
+			if !strings.HasPrefix(ref, "//") {
+				return nil, false
+			}
+			return []string{"read", "--no-newline", fmt.Sprintf("op:%s", ref)}, true
+		},
+	}
+}
+
+// Name returns the prefix of the references of the provider
+func (p *cliProvider) Name() string {
+	return p.name
+}
+
+// Resolve runs the CLI of the provider to get the value of the reference
+func (p *cliProvider) Resolve(ref string) (string, error) {
+	args, ok := p.args(ref)
+	if !ok {
+		return "", oktetoErrors.UserError{
+			E:    fmt.Errorf("invalid %s reference", p.name),
+			Hint: fmt.Sprintf("References to %s must follow the syntax '%s'", p.name, p.syntax),
+		}
+	}
+	if _, err := exec.LookPath(p.binary); err != nil {
+		return "", oktetoErrors.UserError{
+			E:    fmt.Errorf("the %s provider is not available: '%s' is not installed", p.name, p.binary),
+			Hint: fmt.Sprintf("Install it following %s and log in, or remove the reference from your okteto manifest", p.installURL),
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cliTimeout)
+	defer cancel()
+
+	oktetoLog.Infof("resolving %s reference with '%s'", p.name, p.binary)
+	cmd := exec.CommandContext(ctx, p.binary, args...)
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", oktetoErrors.UserError{
+			E:    fmt.Errorf("%s failed: %w: %s", p.binary, err, strings.TrimSpace(stderr.String())),
+			Hint: fmt.Sprintf("Make sure you are logged in with '%s' and have access to the secret", p.binary),
+		}
+	}
+	return strings.TrimRight(string(out), "\r\n"), nil
+}
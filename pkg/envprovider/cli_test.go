@@ -0,0 +1,76 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package envprovider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFakeBinary(t *testing.T, name, script string) {
+	t.Helper()
+	bin := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(bin, name), []byte("#!/bin/sh\n"+script), 0700))
+	t.Setenv("PATH", bin)
+}
+
+func TestCLIProviderResolve(t *testing.T) {
+	t.Run("vault", func(t *testing.T) {
+		writeFakeBinary(t, "vault", "echo \"$@\"\n")
+		got, err := newVaultProvider().Resolve("secret/db#password")
+		require.NoError(t, err)
+		assert.Equal(t, "kv get -field=password secret/db", got)
+	})
+
+	t.Run("aws secrets manager", func(t *testing.T) {
+		writeFakeBinary(t, "aws", "echo \"$@\"\n")
+		got, err := newAWSSecretsManagerProvider().Resolve("prod/db")
+		require.NoError(t, err)
+		assert.Equal(t, "secretsmanager get-secret-value --secret-id prod/db --query SecretString --output text", got)
+	})
+
+	t.Run("1password", func(t *testing.T) {
+		writeFakeBinary(t, "op", "echo \"$@\"\n")
+		got, err := newOnePasswordProvider().Resolve("//dev/db/password")
+		require.NoError(t, err)
+		assert.Equal(t, "read --no-newline op://dev/db/password", got)
+	})
+
+	t.Run("invalid reference", func(t *testing.T) {
+		_, err := newVaultProvider().Resolve("secret/db")
+		var uErr oktetoErrors.UserError
+		require.ErrorAs(t, err, &uErr)
+		assert.Contains(t, uErr.Hint, "${vault:<path>#<key>}")
+	})
+
+	t.Run("not installed", func(t *testing.T) {
+		t.Setenv("PATH", t.TempDir())
+		_, err := newVaultProvider().Resolve("secret/db#password")
+		var uErr oktetoErrors.UserError
+		require.ErrorAs(t, err, &uErr)
+		assert.EqualError(t, uErr, "the vault provider is not available: 'vault' is not installed")
+	})
+
+	t.Run("command error", func(t *testing.T) {
+		writeFakeBinary(t, "vault", "echo 'permission denied' >&2\nexit 2\n")
+		_, err := newVaultProvider().Resolve("secret/db#password")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "permission denied")
+	})
+}
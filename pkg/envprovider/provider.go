@@ -0,0 +1,190 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package envprovider resolves the references to external secret managers used in the okteto manifest, like '${vault:secret/db#password}'
+package envprovider
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// Provider resolves the references of a secret manager
+type Provider interface {
+	// Name is the prefix of the references resolved by the provider, e.g. 'vault' for '${vault:secret/db#password}'
+	Name() string
+
+	// Resolve returns the value of a reference without its prefix
+	Resolve(ref string) (string, error)
+}
+
+// referenceRegex matches '${<provider>:<ref>}'. References whose prefix is not a registered provider are left for the env var expansion,
+// so the notation '${VAR:-default}' keeps working
+var referenceRegex = regexp.MustCompile(`\$\{([a-z][a-z0-9-]*):([^}]+)\}`)
+
+// commandEnvVarPrefix is the prefix of the env vars that replace the references of the commands
+const commandEnvVarPrefix = "OKTETO_SECRET_REF_"
+
+var (
+	mu        sync.Mutex
+	providers = map[string]Provider{}
+	cache     = map[string]string{}
+)
+
+func init() {
+	Register(newVaultProvider())
+	Register(newAWSSecretsManagerProvider())
+	Register(newOnePasswordProvider())
+}
+
+// Register adds a provider. A provider with the same name is replaced
+func Register(p Provider) {
+	mu.Lock()
+	defer mu.Unlock()
+	providers[p.Name()] = p
+}
+
+// HasReferences returns true if value has references to a registered provider
+func HasReferences(value string) bool {
+	for _, m := range referenceRegex.FindAllStringSubmatch(value, -1) {
+		if getProvider(m[1]) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// StripReferences removes the references to registered providers from value
+func StripReferences(value string) string {
+	return referenceRegex.ReplaceAllStringFunc(value, func(ref string) string {
+		m := referenceRegex.FindStringSubmatch(ref)
+		if getProvider(m[1]) == nil {
+			return ref
+		}
+		return ""
+	})
+}
+
+// Expand resolves the provider references of value. The rest of value is expanded with expandFunc.
+// The resolved values are not expanded, so secrets can contain '$'
+func Expand(value string, expandFunc func(string) (string, error)) (string, error) {
+	var result strings.Builder
+	last := 0
+	for _, loc := range referenceRegex.FindAllStringSubmatchIndex(value, -1) {
+		p := getProvider(value[loc[2]:loc[3]])
+		if p == nil {
+			continue
+		}
+		expanded, err := expandFunc(value[last:loc[0]])
+		if err != nil {
+			return "", err
+		}
+		resolved, err := resolve(p, value[loc[4]:loc[5]])
+		if err != nil {
+			return "", err
+		}
+		result.WriteString(expanded)
+		result.WriteString(resolved)
+		last = loc[1]
+	}
+	if last == 0 {
+		return expandFunc(value)
+	}
+	expanded, err := expandFunc(value[last:])
+	if err != nil {
+		return "", err
+	}
+	result.WriteString(expanded)
+	return result.String(), nil
+}
+
+// ExpandCommand resolves the provider references of a shell command, like the commands of the 'deploy' and 'destroy' sections.
+// The references are replaced by references to env vars set to the resolved values, so the shell doesn't interpret the values.
+// It returns the command and the env vars in 'NAME=value' format
+func ExpandCommand(command string) (string, []string, error) {
+	envVars := []string{}
+	names := map[string]string{}
+	var result strings.Builder
+	last := 0
+	for _, loc := range referenceRegex.FindAllStringSubmatchIndex(command, -1) {
+		p := getProvider(command[loc[2]:loc[3]])
+		if p == nil {
+			continue
+		}
+		ref := command[loc[0]:loc[1]]
+		name, ok := names[ref]
+		if !ok {
+			value, err := resolve(p, command[loc[4]:loc[5]])
+			if err != nil {
+				return "", nil, err
+			}
+			name = fmt.Sprintf("%s%d", commandEnvVarPrefix, len(names))
+			names[ref] = name
+			envVars = append(envVars, fmt.Sprintf("%s=%s", name, value))
+		}
+		result.WriteString(command[last:loc[0]])
+		result.WriteString(fmt.Sprintf("${%s}", name))
+		last = loc[1]
+	}
+	if last == 0 {
+		return command, envVars, nil
+	}
+	result.WriteString(command[last:])
+	return result.String(), envVars, nil
+}
+
+// ClearCache removes the resolved values
+func ClearCache() {
+	mu.Lock()
+	defer mu.Unlock()
+	cache = map[string]string{}
+}
+
+func getProvider(name string) Provider {
+	mu.Lock()
+	defer mu.Unlock()
+	return providers[name]
+}
+
+// resolve returns the value of a reference. Values are cached, so every reference is resolved once per command
+func resolve(p Provider, ref string) (string, error) {
+	key := fmt.Sprintf("%s:%s", p.Name(), ref)
+	mu.Lock()
+	value, ok := cache[key]
+	mu.Unlock()
+	if ok {
+		return value, nil
+	}
+
+	value, err := p.Resolve(ref)
+	if err != nil {
+		var uErr oktetoErrors.UserError
+		if errors.As(err, &uErr) {
+			uErr.E = fmt.Errorf("failed to resolve '${%s}': %w", key, uErr.E)
+			return "", uErr
+		}
+		return "", fmt.Errorf("failed to resolve '${%s}': %w", key, err)
+	}
+
+	oktetoLog.AddMaskedSecret(value)
+	mu.Lock()
+	cache[key] = value
+	mu.Unlock()
+	return value, nil
+}
@@ -44,6 +44,31 @@ func (u CommandError) Error() string {
 	return fmt.Sprintf("%s: %s", u.E.Error(), strings.ToLower(u.Reason.Error()))
 }
 
+// ExitCodeError is returned when a command run by okteto finishes with a non-zero exit code.
+// The okteto process exits with the same code
+type ExitCodeError struct {
+	E    error
+	Code int
+}
+
+// Error returns the error message
+func (e ExitCodeError) Error() string {
+	return e.E.Error()
+}
+
+func (e ExitCodeError) Unwrap() error {
+	return e.E
+}
+
+// GetExitCode returns the exit code of the okteto process for the given error
+func GetExitCode(err error) int {
+	var exitErr ExitCodeError
+	if errors.As(err, &exitErr) && exitErr.Code > 0 {
+		return exitErr.Code
+	}
+	return 1
+}
+
 const (
 	// InvalidDockerfile text error
 	InvalidDockerfile = "invalid Dockerfile"
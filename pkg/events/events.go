@@ -0,0 +1,209 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Package events persists the events of the 'okteto up' sessions of a folder as JSON lines,
+// so they can be displayed by 'okteto status --events' when troubleshooting a session
+package events
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// Activation is the type of the events of the activation of a development container
+	Activation = "activation"
+	// Sync is the type of the events of the file synchronization
+	Sync = "sync"
+	// Reconnect is the type of the events of the reconnections to a development container
+	Reconnect = "reconnect"
+	// Error is the type of the events of errors
+	Error = "error"
+
+	logExtension = ".log"
+
+	// maxSessions is the number of session logs kept per development container
+	maxSessions = 10
+
+	// sessionsGitignore keeps the session logs out of the git repository
+	sessionsGitignore = "*\n"
+
+	idTimeFormat = "20060102T150405.000Z"
+)
+
+// Event is an entry of a session log
+type Event struct {
+	Time    time.Time `json:"time"`
+	Type    string    `json:"type"`
+	Message string    `json:"message"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// Log writes the events of a session
+type Log struct {
+	ID   string
+	path string
+	mu   sync.Mutex
+	f    *os.File
+}
+
+// GetSessionsDir returns the folder of the session logs of a working directory
+func GetSessionsDir(wd string) string {
+	return filepath.Join(wd, ".okteto", "sessions")
+}
+
+// Open creates the log of a new session of a development container, removing the oldest ones
+func Open(dir, devName string) (*Log, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create the sessions folder: %w", err)
+	}
+	gitignorePath := filepath.Join(dir, ".gitignore")
+	if err := os.WriteFile(gitignorePath, []byte(sessionsGitignore), 0600); err != nil {
+		oktetoLog.Infof("could not write %s: %s", gitignorePath, err)
+	}
+
+	id := fmt.Sprintf("%s-%s", devName, time.Now().UTC().Format(idTimeFormat))
+	path := filepath.Join(dir, id+logExtension)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the session log: %w", err)
+	}
+	prune(dir, devName)
+	return &Log{ID: id, path: path, f: f}, nil
+}
+
+// Record writes an event to the session log. It is a no-op for a nil log
+func (l *Log) Record(eventType, message string, err error) {
+	if l == nil {
+		return
+	}
+	e := Event{Time: time.Now().UTC(), Type: eventType, Message: message}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	b, mErr := json.Marshal(e)
+	if mErr != nil {
+		oktetoLog.Infof("failed to marshal session event: %s", mErr)
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		return
+	}
+	if _, err := l.f.Write(append(b, '\n')); err != nil {
+		oktetoLog.Infof("failed to write session event to %s: %s", l.path, err)
+	}
+}
+
+// Close closes the session log
+func (l *Log) Close() {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		return
+	}
+	if err := l.f.Close(); err != nil {
+		oktetoLog.Infof("failed to close session log %s: %s", l.path, err)
+	}
+	l.f = nil
+}
+
+// List returns the ids of the sessions of a development container, from the oldest to the newest
+func List(dir, devName string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	ids := []string{}
+	prefix := devName + "-"
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, logExtension) {
+			continue
+		}
+		// dev names can contain dashes, the rest of the id must be a timestamp
+		if _, err := time.Parse(idTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, prefix), logExtension)); err != nil {
+			continue
+		}
+		ids = append(ids, strings.TrimSuffix(name, logExtension))
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// Read returns the events of a session
+func Read(dir, id string) ([]Event, error) {
+	path := filepath.Join(dir, id+logExtension)
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("session '%s' not found", id)
+		}
+		return nil, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			oktetoLog.Debugf("Error closing file %s: %s", path, err)
+		}
+	}()
+
+	result := []Event{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		e := Event{}
+		if err := json.Unmarshal(line, &e); err != nil {
+			// the last line might be incomplete if okteto was killed while writing it
+			oktetoLog.Infof("ignoring invalid event in %s: %s", path, err)
+			continue
+		}
+		result = append(result, e)
+	}
+	return result, scanner.Err()
+}
+
+// prune removes the oldest session logs of a development container
+func prune(dir, devName string) {
+	ids, err := List(dir, devName)
+	if err != nil {
+		oktetoLog.Infof("failed to list session logs: %s", err)
+		return
+	}
+	for len(ids) > maxSessions {
+		path := filepath.Join(dir, ids[0]+logExtension)
+		if err := os.Remove(path); err != nil {
+			oktetoLog.Infof("failed to remove session log %s: %s", path, err)
+		}
+		ids = ids[1:]
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package events
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAndRead(t *testing.T) {
+	dir := GetSessionsDir(t.TempDir())
+	l, err := Open(dir, "api")
+	require.NoError(t, err)
+
+	l.Record(Activation, "Activating development container 'api'", nil)
+	l.Record(Error, "Activation failed", errors.New("pod not found"))
+	l.Close()
+	// events recorded after closing the log are ignored
+	l.Record(Sync, "Synchronizing files", nil)
+
+	ids, err := List(dir, "api")
+	require.NoError(t, err)
+	assert.Equal(t, []string{l.ID}, ids)
+
+	result, err := Read(dir, l.ID)
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	assert.Equal(t, Activation, result[0].Type)
+	assert.Equal(t, "Activating development container 'api'", result[0].Message)
+	assert.Empty(t, result[0].Error)
+	assert.Equal(t, Error, result[1].Type)
+	assert.Equal(t, "pod not found", result[1].Error)
+
+	gitignore, err := os.ReadFile(filepath.Join(dir, ".gitignore"))
+	require.NoError(t, err)
+	assert.Equal(t, sessionsGitignore, string(gitignore))
+}
+
+func TestNilLog(t *testing.T) {
+	var l *Log
+	l.Record(Activation, "ignored", nil)
+	l.Close()
+}
+
+func TestReadIgnoresInvalidLines(t *testing.T) {
+	dir := t.TempDir()
+	content := `{"time":"2023-01-01T00:00:00Z","type":"sync","message":"Synchronizing files"}
+{"time":"2023-01-01T00:00:0`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "api-20230101T000000.000Z.log"), []byte(content), 0600))
+	result, err := Read(dir, "api-20230101T000000.000Z")
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, Sync, result[0].Type)
+
+	_, err = Read(dir, "api-20230102T000000.000Z")
+	assert.Error(t, err)
+}
+
+func TestListAndPrune(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < maxSessions+2; i++ {
+		id := "api-" + start.Add(time.Duration(i)*time.Hour).Format(idTimeFormat)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, id+logExtension), nil, 0600))
+	}
+	// sessions of other development containers
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "api-v2-"+start.Format(idTimeFormat)+logExtension), nil, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "api-notes.log"), nil, 0600))
+
+	prune(dir, "api")
+	ids, err := List(dir, "api")
+	require.NoError(t, err)
+	require.Len(t, ids, maxSessions)
+	assert.Equal(t, "api-"+start.Add(2*time.Hour).Format(idTimeFormat), ids[0])
+
+	ids, err = List(dir, "api-v2")
+	require.NoError(t, err)
+	assert.Len(t, ids, 1)
+
+	ids, err = List(filepath.Join(dir, "missing"), "api")
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
@@ -86,6 +86,11 @@ func (c *K8sControl) List(ctx context.Context, ns string, labelSelector string)
 	return result, nil
 }
 
+// Translate returns the custom resource of an external resource
+func Translate(name, namespace string, externalResource *ExternalResource) *k8s.External {
+	return translate(name, namespace, externalResource, time.Now())
+}
+
 func translate(name, namespace string, externalResource *ExternalResource, now time.Time) *k8s.External {
 	var externalEndpointsSpec []k8s.Endpoint
 	for _, endpoint := range externalResource.Endpoints {
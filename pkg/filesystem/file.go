@@ -90,6 +90,15 @@ func FileExistsAndNotDir(filename string) bool {
 	return !info.IsDir()
 }
 
+// FileExistsAndNotDirWithFilesystem checks if the file exists in the given filesystem and its not a dir
+func FileExistsAndNotDirWithFilesystem(filename string, fs afero.Fs) bool {
+	info, err := fs.Stat(filename)
+	if err != nil {
+		return false
+	}
+	return !info.IsDir()
+}
+
 // GetFilePathFromWdAndFiles joins the cwd with the files and returns it if
 // one of them exists and is not a directory
 func GetFilePathFromWdAndFiles(cwd string, files []string) string {
@@ -18,6 +18,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/spf13/afero"
 )
 
 func TestCopyFile(t *testing.T) {
@@ -70,3 +72,23 @@ func TestFileExists(t *testing.T) {
 		t.Errorf("fail to detect existing file")
 	}
 }
+
+func TestFileExistsAndNotDirWithFilesystem(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	if FileExistsAndNotDirWithFilesystem("/app/okteto.yml", fs) {
+		t.Errorf("fail to detect non-existing file")
+	}
+
+	if err := afero.WriteFile(fs, "/app/okteto.yml", []byte("hello-world"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if !FileExistsAndNotDirWithFilesystem("/app/okteto.yml", fs) {
+		t.Errorf("fail to detect existing file")
+	}
+
+	if FileExistsAndNotDirWithFilesystem("/app", fs) {
+		t.Errorf("fail to detect directory")
+	}
+}
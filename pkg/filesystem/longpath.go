@@ -0,0 +1,47 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"runtime"
+	"strings"
+)
+
+const (
+	// extendedLengthPrefix lifts the 260 characters limit of the windows paths
+	extendedLengthPrefix = `\\?\`
+
+	// extendedLengthUNCPrefix is the extended-length form of the '\\server\share' network paths
+	extendedLengthUNCPrefix = `\\?\UNC\`
+)
+
+// LongPath returns the extended-length form of an absolute windows path, so it can be longer than 260 characters.
+// Network paths like '\\server\share\folder' are converted to '\\?\UNC\server\share\folder'. Other platforms don't need it
+func LongPath(path string) string {
+	return toLongPath(path, runtime.GOOS)
+}
+
+func toLongPath(path, goos string) string {
+	if goos != "windows" || strings.HasPrefix(path, extendedLengthPrefix) {
+		return path
+	}
+	path = strings.ReplaceAll(path, "/", `\`)
+	if strings.HasPrefix(path, `\\`) {
+		return extendedLengthUNCPrefix + strings.TrimPrefix(path, `\\`)
+	}
+	if len(path) >= 3 && path[1] == ':' && path[2] == '\\' {
+		return extendedLengthPrefix + path
+	}
+	return path
+}
@@ -0,0 +1,41 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_toLongPath(t *testing.T) {
+	var tests = []struct {
+		name     string
+		path     string
+		goos     string
+		expected string
+	}{
+		{name: "linux", path: "/home/okteto/app", goos: "linux", expected: "/home/okteto/app"},
+		{name: "drive", path: `C:\Users\okteto\app`, goos: "windows", expected: `\\?\C:\Users\okteto\app`},
+		{name: "forward slashes", path: "C:/Users/okteto/app", goos: "windows", expected: `\\?\C:\Users\okteto\app`},
+		{name: "unc", path: `\\server\share\app`, goos: "windows", expected: `\\?\UNC\server\share\app`},
+		{name: "already extended", path: `\\?\C:\app`, goos: "windows", expected: `\\?\C:\app`},
+		{name: "relative", path: `app\src`, goos: "windows", expected: `app\src`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, toLongPath(tt.path, tt.goos))
+		})
+	}
+}
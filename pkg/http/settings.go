@@ -0,0 +1,289 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// Settings are the proxy and the custom certificate authorities used by all the okteto clients
+type Settings struct {
+	// Proxy is the proxy of HTTP and HTTPS requests. If empty, the HTTP_PROXY and HTTPS_PROXY env vars are used
+	Proxy string
+	// NoProxy is the list of hosts excluded from the proxy. If empty, the NO_PROXY env var is used
+	NoProxy string
+	// CABundle is the path to a PEM file with certificate authorities trusted in addition to the system ones
+	CABundle string
+	// CertificateFingerprint is the sha256 fingerprint of a server certificate trusted even if it isn't signed by a trusted CA
+	CertificateFingerprint string
+}
+
+var (
+	settingsMu       sync.RWMutex
+	settingsProvider func() Settings
+
+	caBundleCache sync.Map
+
+	// pinnedCertCache caches the pinned certificates served by each address
+	pinnedCertCache sync.Map
+)
+
+// SetSettingsProvider sets the function returning the settings of the current okteto context
+func SetSettingsProvider(f func() Settings) {
+	settingsMu.Lock()
+	defer settingsMu.Unlock()
+	settingsProvider = f
+}
+
+func getSettings() Settings {
+	settingsMu.RLock()
+	defer settingsMu.RUnlock()
+	if settingsProvider == nil {
+		return Settings{}
+	}
+	return settingsProvider()
+}
+
+// ProxyFromSettings returns the proxy of a request: the one of the current settings, or the one of the env vars
+func ProxyFromSettings(req *http.Request) (*url.URL, error) {
+	s := getSettings()
+	if s.Proxy == "" {
+		return http.ProxyFromEnvironment(req)
+	}
+	cfg := &httpproxy.Config{
+		HTTPProxy:  s.Proxy,
+		HTTPSProxy: s.Proxy,
+		NoProxy:    s.NoProxy,
+	}
+	if cfg.NoProxy == "" {
+		cfg.NoProxy = httpproxy.FromEnvironment().NoProxy
+	}
+	return cfg.ProxyFunc()(req.URL)
+}
+
+// CACertificates returns the certificates of the CA bundle of the current settings
+func CACertificates() ([]*x509.Certificate, error) {
+	path := getSettings().CABundle
+	if path == "" {
+		return nil, nil
+	}
+	if certs, ok := caBundleCache.Load(path); ok {
+		return certs.([]*x509.Certificate), nil
+	}
+	certs, err := LoadCABundle(path)
+	if err != nil {
+		return nil, err
+	}
+	caBundleCache.Store(path, certs)
+	return certs, nil
+}
+
+// CABundlePEM returns the content of the CA bundle of the current settings
+func CABundlePEM() ([]byte, error) {
+	path := getSettings().CABundle
+	if path == "" {
+		return nil, nil
+	}
+	if _, err := CACertificates(); err != nil {
+		return nil, err
+	}
+	return os.ReadFile(path)
+}
+
+// LoadCABundle returns the certificates of a PEM file
+func LoadCABundle(path string) ([]*x509.Certificate, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the CA bundle '%s': %w", path, err)
+	}
+	certs := []*x509.Certificate{}
+	for {
+		var block *pem.Block
+		block, b = pem.Decode(b)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the CA bundle '%s': %w", path, err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("the CA bundle '%s' doesn't contain any PEM certificate", path)
+	}
+	return certs, nil
+}
+
+// ParseCertificateFingerprint normalizes a sha256 certificate fingerprint like 'sha256:AB:CD:...' or 'sha256 Fingerprint=AB:CD:...' to lowercase hex
+func ParseCertificateFingerprint(fingerprint string) (string, error) {
+	normalized := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(fingerprint)), "sha256:")
+	if i := strings.LastIndex(normalized, "="); i >= 0 {
+		// the output of 'openssl x509 -fingerprint -sha256'
+		normalized = normalized[i+1:]
+	}
+	normalized = strings.ReplaceAll(normalized, ":", "")
+	if b, err := hex.DecodeString(normalized); err != nil || len(b) != sha256.Size {
+		return "", fmt.Errorf("invalid certificate fingerprint '%s': it must be the sha256 hash of the certificate in hex", fingerprint)
+	}
+	return normalized, nil
+}
+
+// CertificateFingerprint returns the sha256 fingerprint of a certificate in lowercase hex
+func CertificateFingerprint(cert *x509.Certificate) string {
+	h := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(h[:])
+}
+
+// verifyPinnedConnection trusts the server certificates matching the pinned fingerprint, and verifies the rest with the roots
+func verifyPinnedConnection(roots *x509.CertPool, pin string) func(tls.ConnectionState) error {
+	return func(cs tls.ConnectionState) error {
+		if len(cs.PeerCertificates) == 0 {
+			return fmt.Errorf("the server didn't send any certificate")
+		}
+		leaf := cs.PeerCertificates[0]
+		if CertificateFingerprint(leaf) == pin {
+			return nil
+		}
+		intermediates := x509.NewCertPool()
+		for _, cert := range cs.PeerCertificates[1:] {
+			intermediates.AddCert(cert)
+		}
+		_, err := leaf.Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+			DNSName:       cs.ServerName,
+		})
+		return err
+	}
+}
+
+// PinnedCertificatePEM returns the PEM of the certificate served by addr if it matches the certificate pinned in the current settings.
+// It's added to the trusted certificates of the clients that don't use StrictSSLTransport, like the kubernetes and BuildKit clients
+func PinnedCertificatePEM(ctx context.Context, addr string) ([]byte, error) {
+	fingerprint := getSettings().CertificateFingerprint
+	if fingerprint == "" {
+		return nil, nil
+	}
+	pin, err := ParseCertificateFingerprint(fingerprint)
+	if err != nil {
+		return nil, err
+	}
+	key := addr + "#" + pin
+	if cert, ok := pinnedCertCache.Load(key); ok {
+		return cert.([]byte), nil
+	}
+
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := DialContextThroughProxy(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	var leaf *x509.Certificate
+	tlsConn := tls.Client(conn, &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: host,
+		// the certificate is only returned if it matches the pinned fingerprint
+		InsecureSkipVerify: true, // skipcq: GSC-G402
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			if len(cs.PeerCertificates) == 0 {
+				return fmt.Errorf("the server didn't send any certificate")
+			}
+			if CertificateFingerprint(cs.PeerCertificates[0]) != pin {
+				return fmt.Errorf("the certificate of '%s' doesn't match the pinned certificate", addr)
+			}
+			leaf = cs.PeerCertificates[0]
+			return nil
+		},
+	})
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		return nil, fmt.Errorf("tls handshake failed for %s: %w", addr, err)
+	}
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leaf.Raw})
+	pinnedCertCache.Store(key, cert)
+	return cert, nil
+}
+
+// DialContextThroughProxy opens a TCP connection to addr, tunneled with 'CONNECT' through the proxy of the current settings.
+// It is used by clients that don't use an *http.Transport, like the gRPC client of BuildKit
+func DialContextThroughProxy(ctx context.Context, addr string) (net.Conn, error) {
+	proxyURL, err := ProxyFromSettings(&http.Request{URL: &url.URL{Scheme: "https", Host: addr}})
+	if err != nil {
+		return nil, err
+	}
+	d := &net.Dialer{}
+	if proxyURL == nil {
+		return d.DialContext(ctx, "tcp", addr)
+	}
+
+	proxyAddr := proxyURL.Host
+	if proxyURL.Port() == "" {
+		proxyAddr = net.JoinHostPort(proxyURL.Hostname(), "80")
+	}
+	conn, err := d.DialContext(ctx, "tcp", proxyAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to proxy %s: %w", proxyURL.Redacted(), err)
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: http.Header{},
+	}
+	if u := proxyURL.User; u != nil {
+		password, _ := u.Password()
+		req.SetBasicAuth(u.Username(), password)
+		req.Header.Set("Proxy-Authorization", req.Header.Get("Authorization"))
+		req.Header.Del("Authorization")
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to send CONNECT to proxy %s: %w", proxyURL.Redacted(), err)
+	}
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to read CONNECT response from proxy %s: %w", proxyURL.Redacted(), err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("proxy %s refused the connection to %s: %s", proxyURL.Redacted(), addr, resp.Status)
+	}
+	if br.Buffered() > 0 {
+		return &bufferedConn{Conn: conn, r: br}, nil
+	}
+	return conn, nil
+}
+
+// bufferedConn is a connection with data already read by a buffered reader
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
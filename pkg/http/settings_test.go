@@ -0,0 +1,230 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setTestSettings(t *testing.T, s Settings) {
+	SetSettingsProvider(func() Settings { return s })
+	t.Cleanup(func() { SetSettingsProvider(nil) })
+}
+
+func writeTestCABundle(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "okteto test CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	return path
+}
+
+func TestProxyFromSettings(t *testing.T) {
+	var tests = []struct {
+		name     string
+		settings Settings
+		url      string
+		expected string
+	}{
+		{
+			name:     "no proxy",
+			url:      "https://okteto.example.com",
+			expected: "",
+		},
+		{
+			name:     "proxy",
+			settings: Settings{Proxy: "http://proxy.example.com:3128"},
+			url:      "https://okteto.example.com",
+			expected: "http://proxy.example.com:3128",
+		},
+		{
+			name:     "excluded host",
+			settings: Settings{Proxy: "http://proxy.example.com:3128", NoProxy: "internal.example.com,.cluster.local"},
+			url:      "https://registry.cluster.local",
+			expected: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HTTP_PROXY", "")
+			t.Setenv("HTTPS_PROXY", "")
+			t.Setenv("NO_PROXY", "")
+			setTestSettings(t, tt.settings)
+			u, err := url.Parse(tt.url)
+			require.NoError(t, err)
+			proxy, err := ProxyFromSettings(&http.Request{URL: u})
+			require.NoError(t, err)
+			if tt.expected == "" {
+				assert.Nil(t, proxy)
+				return
+			}
+			require.NotNil(t, proxy)
+			assert.Equal(t, tt.expected, proxy.String())
+		})
+	}
+}
+
+func TestLoadCABundle(t *testing.T) {
+	path := writeTestCABundle(t)
+	certs, err := LoadCABundle(path)
+	require.NoError(t, err)
+	require.Len(t, certs, 1)
+	assert.Equal(t, "okteto test CA", certs[0].Subject.CommonName)
+
+	invalid := filepath.Join(t.TempDir(), "invalid.pem")
+	require.NoError(t, os.WriteFile(invalid, []byte("not a certificate"), 0600))
+	_, err = LoadCABundle(invalid)
+	assert.Error(t, err)
+
+	_, err = LoadCABundle(filepath.Join(t.TempDir(), "missing.pem"))
+	assert.Error(t, err)
+}
+
+func TestCACertificates(t *testing.T) {
+	setTestSettings(t, Settings{})
+	certs, err := CACertificates()
+	require.NoError(t, err)
+	assert.Empty(t, certs)
+
+	path := writeTestCABundle(t)
+	setTestSettings(t, Settings{CABundle: path})
+	certs, err = CACertificates()
+	require.NoError(t, err)
+	assert.Len(t, certs, 1)
+
+	bundle, err := CABundlePEM()
+	require.NoError(t, err)
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, expected, bundle)
+}
+
+func TestDialContextThroughProxy(t *testing.T) {
+	proxy, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer proxy.Close()
+	connected := make(chan string, 1)
+	go func() {
+		conn, err := proxy.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		req, err := http.ReadRequest(bufio.NewReader(conn))
+		if err != nil {
+			return
+		}
+		connected <- req.Method + " " + req.Host + " " + req.Header.Get("Proxy-Authorization")
+		_, _ = conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\nhello"))
+	}()
+
+	t.Setenv("NO_PROXY", "")
+	setTestSettings(t, Settings{Proxy: "http://user:pass@" + proxy.Addr().String()})
+	conn, err := DialContextThroughProxy(context.Background(), "buildkit.okteto.example.com:443")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	assert.Equal(t, "CONNECT buildkit.okteto.example.com:443 Basic dXNlcjpwYXNz", <-connected)
+	b, err := io.ReadAll(conn)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(b))
+}
+
+func TestParseCertificateFingerprint(t *testing.T) {
+	expected := "ab" + strings.Repeat("0", 62)
+	var tests = []struct {
+		name        string
+		fingerprint string
+		wantErr     bool
+	}{
+		{name: "hex", fingerprint: expected},
+		{name: "openssl format", fingerprint: "sha256 Fingerprint=AB" + strings.Repeat(":00", 31)},
+		{name: "colons", fingerprint: "AB" + strings.Repeat(":00", 31)},
+		{name: "prefix", fingerprint: "sha256:AB" + strings.Repeat(":00", 31)},
+		{name: "too short", fingerprint: "ab:cd", wantErr: true},
+		{name: "not hex", fingerprint: strings.Repeat("z", 64), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCertificateFingerprint(tt.fingerprint)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, expected, got)
+		})
+	}
+}
+
+func TestStrictSSLTransportPinnedCertificate(t *testing.T) {
+	s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer s.Close()
+
+	setTestSettings(t, Settings{})
+	_, err := (&http.Client{Transport: StrictSSLTransport(nil)}).Get(s.URL)
+	assert.Error(t, err)
+
+	setTestSettings(t, Settings{CertificateFingerprint: strings.Repeat("0", 64)})
+	_, err = (&http.Client{Transport: StrictSSLTransport(nil)}).Get(s.URL)
+	assert.Error(t, err)
+
+	setTestSettings(t, Settings{CertificateFingerprint: CertificateFingerprint(s.Certificate())})
+	resp, err := (&http.Client{Transport: StrictSSLTransport(nil)}).Get(s.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestPinnedCertificatePEM(t *testing.T) {
+	s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer s.Close()
+	addr := s.Listener.Addr().String()
+
+	setTestSettings(t, Settings{})
+	cert, err := PinnedCertificatePEM(context.Background(), addr)
+	require.NoError(t, err)
+	assert.Nil(t, cert)
+
+	setTestSettings(t, Settings{CertificateFingerprint: strings.Repeat("0", 64)})
+	_, err = PinnedCertificatePEM(context.Background(), addr)
+	assert.Error(t, err)
+
+	setTestSettings(t, Settings{CertificateFingerprint: CertificateFingerprint(s.Certificate())})
+	cert, err = PinnedCertificatePEM(context.Background(), addr)
+	require.NoError(t, err)
+	assert.Equal(t, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.Certificate().Raw}), cert)
+}
@@ -8,10 +8,13 @@ import (
 	"net"
 	"net/http"
 	"time"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
 )
 
 // DefaultTransport returns an *http.Transport lifted from http.DefaultTransport
 // Main differentes vs empty &http.Client{} are http2 preference, min TLS version set to 1.2, timeouts and connection limits.
+// The proxy is the one of the current Settings.
 //
 // dev: reason why not doing pointer cloning is because not safe after init():
 // - https://github.com/golang/go/issues/26013
@@ -19,7 +22,7 @@ import (
 // - https://github.com/kubernetes-retired/go-open-service-broker-client/pull/133
 func DefaultTransport() *http.Transport {
 	return &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
+		Proxy: ProxyFromSettings,
 		DialContext: (&net.Dialer{
 			Timeout:   30 * time.Second,
 			KeepAlive: 30 * time.Second,
@@ -48,7 +51,8 @@ type TLSConn interface {
 	Handshake() error
 }
 
-// StrictSSLTransport returns an *http.Transport with RootCAs set with both the SystemCertPool and the given *x509.Certificates
+// StrictSSLTransport returns an *http.Transport with RootCAs set with the SystemCertPool, the CA bundle of the current Settings and the given *x509.Certificates.
+// The certificate pinned in the current Settings is trusted too
 // If obtaining SystemCertPool fails, it uses an empty *x509.CertPool as base
 func StrictSSLTransport(opts *SSLTransportOption) *http.Transport {
 	pool, err := x509.SystemCertPool()
@@ -67,12 +71,31 @@ func StrictSSLTransport(opts *SSLTransportOption) *http.Transport {
 		pool.AddCert(cert)
 	}
 
+	caCerts, err := CACertificates()
+	if err != nil {
+		oktetoLog.Infof("ignoring CA bundle: %s", err)
+	}
+	for _, cert := range caCerts {
+		pool.AddCert(cert)
+	}
+
 	toIntercept := Intercept{}
 	toIntercept.AppendURLs(opts.URLsToIntercept...)
 
 	transport := DefaultTransport()
 	transport.TLSClientConfig.RootCAs = pool
 
+	if fingerprint := getSettings().CertificateFingerprint; fingerprint != "" {
+		pin, err := ParseCertificateFingerprint(fingerprint)
+		if err != nil {
+			oktetoLog.Infof("ignoring pinned certificate: %s", err)
+		} else {
+			// the default verification is replaced by verifyPinnedConnection, which also verifies the certificates not pinned
+			transport.TLSClientConfig.InsecureSkipVerify = true // skipcq: GSC-G402
+			transport.TLSClientConfig.VerifyConnection = verifyPinnedConnection(pool, pin)
+		}
+	}
+
 	transport.DialTLSContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
 		if toIntercept.ShouldInterceptAddr(addr) && opts.ServerName != "" {
 			host, _, err := net.SplitHostPort(addr)
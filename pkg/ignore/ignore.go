@@ -0,0 +1,197 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ignore reads the .dockerignore and .oktetoignore files and explains which of their rules match a file.
+//
+// The .oktetoignore of a build context has the same syntax as the .dockerignore, and its patterns are added to the
+// ones of the .dockerignore for the files uploaded by okteto. It can be split in per-service sections: the rules before
+// the first '[service]' header apply to all the services, and the rules after a '[service]' header only apply to the
+// build of that service of the okteto manifest:
+//
+//	# applies to all the services
+//	node_modules
+//
+//	[api]
+//	frontend
+//
+//	[frontend]
+//	api
+package ignore
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/docker/docker/pkg/fileutils"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/afero"
+)
+
+const (
+	// DockerIgnoreFile is the file with the patterns excluded from the build context by the builder
+	DockerIgnoreFile = ".dockerignore"
+	// OktetoIgnoreFile is the file with the patterns excluded from the files uploaded by okteto
+	OktetoIgnoreFile = ".oktetoignore"
+)
+
+// sectionRegex matches the headers of the sections of the .oktetoignore, i.e. '[api]'
+var sectionRegex = regexp.MustCompile(`^\[([a-zA-Z0-9][a-zA-Z0-9_.-]*)\]$`)
+
+// Rule is a pattern of an ignore file
+type Rule struct {
+	// File is the path of the ignore file of the rule
+	File string
+	Line int
+	// Section is the service of the section of the rule. It's empty for the rules that apply to all the services
+	Section string
+	// Pattern is the normalized pattern, prefixed with '!' for exceptions
+	Pattern string
+}
+
+// IsException returns true if the rule includes again the files excluded by a previous rule
+func (r Rule) IsException() bool {
+	return strings.HasPrefix(r.Pattern, "!")
+}
+
+// Location returns the file and line of the rule, i.e. '.oktetoignore:3 [api]'
+func (r Rule) Location() string {
+	location := fmt.Sprintf("%s:%d", r.File, r.Line)
+	if r.Section != "" {
+		location = fmt.Sprintf("%s [%s]", location, r.Section)
+	}
+	return location
+}
+
+// File are the rules of an ignore file
+type File struct {
+	rules []Rule
+}
+
+// ReadDockerIgnore reads the rules of a .dockerignore file. It returns an empty file if it doesn't exist
+func ReadDockerIgnore(fs afero.Fs, path string) (*File, error) {
+	return readFile(fs, path, false)
+}
+
+// ReadOktetoIgnore reads the rules of the .oktetoignore of a folder. It returns an empty file if it doesn't exist
+func ReadOktetoIgnore(fs afero.Fs, dir string) (*File, error) {
+	return readFile(fs, filepath.Join(dir, OktetoIgnoreFile), true)
+}
+
+func readFile(fs afero.Fs, path string, withSections bool) (*File, error) {
+	f, err := fs.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &File{}, nil
+		}
+		return nil, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			oktetoLog.Debugf("Error closing file %s: %s", path, err)
+		}
+	}()
+	return parse(filepath.Base(path), f, withSections)
+}
+
+// parse reads the patterns the same way as the .dockerignore parser of buildkit, keeping their lines and sections
+func parse(name string, r io.Reader, withSections bool) (*File, error) {
+	result := &File{}
+	scanner := bufio.NewScanner(r)
+	section := ""
+	line := 0
+	for scanner.Scan() {
+		scanned := scanner.Bytes()
+		if line == 0 {
+			scanned = bytes.TrimPrefix(scanned, []byte{0xEF, 0xBB, 0xBF})
+		}
+		line++
+		pattern := string(scanned)
+		if strings.HasPrefix(pattern, "#") {
+			continue
+		}
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if withSections {
+			if m := sectionRegex.FindStringSubmatch(pattern); m != nil {
+				section = m[1]
+				continue
+			}
+		}
+		invert := pattern[0] == '!'
+		if invert {
+			pattern = strings.TrimSpace(pattern[1:])
+		}
+		if len(pattern) > 0 {
+			pattern = filepath.ToSlash(filepath.Clean(pattern))
+			if len(pattern) > 1 && pattern[0] == '/' {
+				pattern = pattern[1:]
+			}
+		}
+		if invert {
+			pattern = "!" + pattern
+		}
+		result.rules = append(result.rules, Rule{File: name, Line: line, Section: section, Pattern: pattern})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", name, err)
+	}
+	return result, nil
+}
+
+// Rules returns the rules that apply to a service: the ones outside of any section followed by the ones of the section of the service.
+// The rules of all the sections are ignored if the service is empty
+func (f *File) Rules(service string) []Rule {
+	result := []Rule{}
+	for _, r := range f.rules {
+		if r.Section == "" || r.Section == service {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// Patterns returns the patterns of the rules that apply to a service
+func (f *File) Patterns(service string) []string {
+	rules := f.Rules(service)
+	result := make([]string, 0, len(rules))
+	for _, r := range rules {
+		result = append(result, r.Pattern)
+	}
+	return result
+}
+
+// Match returns the rule that decides if a path is excluded, which is the last rule that matches the path or one of its parent folders.
+// The path is excluded unless the rule is an exception. It returns nil if no rule matches the path
+func Match(rules []Rule, path string) (*Rule, error) {
+	path = filepath.Clean(filepath.FromSlash(path))
+	var result *Rule
+	for i := range rules {
+		pattern := strings.TrimPrefix(rules[i].Pattern, "!")
+		matches, err := fileutils.Matches(path, []string{pattern})
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern '%s' at %s: %w", rules[i].Pattern, rules[i].Location(), err)
+		}
+		if matches {
+			result = &rules[i]
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ignore
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const oktetoignore = `# all the services
+node_modules
+/tmp/
+
+[api]
+frontend
+!frontend/shared
+
+[frontend]
+api
+`
+
+func TestParse(t *testing.T) {
+	f, err := parse(OktetoIgnoreFile, strings.NewReader(oktetoignore), true)
+	require.NoError(t, err)
+	assert.Equal(t, []Rule{
+		{File: ".oktetoignore", Line: 2, Pattern: "node_modules"},
+		{File: ".oktetoignore", Line: 3, Pattern: "tmp"},
+		{File: ".oktetoignore", Line: 6, Section: "api", Pattern: "frontend"},
+		{File: ".oktetoignore", Line: 7, Section: "api", Pattern: "!frontend/shared"},
+	}, f.Rules("api"))
+	assert.Equal(t, []string{"node_modules", "tmp", "api"}, f.Patterns("frontend"))
+	assert.Equal(t, []string{"node_modules", "tmp"}, f.Patterns(""))
+
+	f, err = parse(DockerIgnoreFile, strings.NewReader("[api]\nweb"), false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"[api]", "web"}, f.Patterns("api"))
+}
+
+func TestReadOktetoIgnore(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	f, err := ReadOktetoIgnore(fs, "app")
+	require.NoError(t, err)
+	assert.Empty(t, f.Rules(""))
+
+	require.NoError(t, afero.WriteFile(fs, "app/.oktetoignore", []byte(oktetoignore), 0600))
+	f, err = ReadOktetoIgnore(fs, "app")
+	require.NoError(t, err)
+	assert.Len(t, f.Rules("api"), 4)
+}
+
+func TestMatch(t *testing.T) {
+	f, err := parse(OktetoIgnoreFile, strings.NewReader(oktetoignore), true)
+	require.NoError(t, err)
+	rules := f.Rules("api")
+
+	tests := []struct {
+		path      string
+		line      int
+		exception bool
+	}{
+		{path: "main.go"},
+		{path: "node_modules/react/index.js", line: 2},
+		{path: "frontend/index.js", line: 6},
+		{path: "frontend/shared/types.ts", line: 7, exception: true},
+		{path: "./tmp", line: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rule, err := Match(rules, tt.path)
+			require.NoError(t, err)
+			if tt.line == 0 {
+				assert.Nil(t, rule)
+				return
+			}
+			require.NotNil(t, rule)
+			assert.Equal(t, tt.line, rule.Line)
+			assert.Equal(t, tt.exception, rule.IsException())
+		})
+	}
+}
+
+func TestRuleLocation(t *testing.T) {
+	assert.Equal(t, ".dockerignore:4", Rule{File: ".dockerignore", Line: 4}.Location())
+	assert.Equal(t, ".oktetoignore:7 [api]", Rule{File: ".oktetoignore", Line: 7, Section: "api"}.Location())
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// FieldManager is the field manager of the objects applied by okteto.
+	// Its fields are force-applied, and the fields applied before but not anymore are removed by the API server
+	FieldManager = "okteto"
+)
+
+// Object is a kubernetes object that can be applied
+type Object interface {
+	metav1.Object
+	runtime.Object
+}
+
+// RESTClient returns rc if it can send server-side apply requests, or nil otherwise, like for fake clients
+func RESTClient(rc rest.Interface) rest.Interface {
+	if r, ok := rc.(*rest.RESTClient); !ok || r == nil {
+		return nil
+	}
+	return rc
+}
+
+// Apply creates or updates obj with server-side apply and decodes the applied object into result.
+// The fields managed by other managers, like operators or autoscalers, are kept
+func Apply(ctx context.Context, rc rest.Interface, resource string, gvk schema.GroupVersionKind, obj Object, result runtime.Object) error {
+	body, err := getApplyBody(obj, gvk)
+	if err != nil {
+		return fmt.Errorf("error encoding %s '%s': %w", resource, obj.GetName(), err)
+	}
+	return rc.Patch(types.ApplyPatchType).
+		Namespace(obj.GetNamespace()).
+		Resource(resource).
+		Name(obj.GetName()).
+		Param("fieldManager", FieldManager).
+		Param("force", "true").
+		Body(body).
+		Do(ctx).
+		Into(result)
+}
+
+// getApplyBody returns the json of the applied configuration of an object.
+// The fields owned by the API server, like the resource version or the status, are removed
+func getApplyBody(obj Object, gvk schema.GroupVersionKind) ([]byte, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	body := map[string]interface{}{}
+	if err := json.Unmarshal(b, &body); err != nil {
+		return nil, err
+	}
+	body["apiVersion"] = gvk.GroupVersion().String()
+	body["kind"] = gvk.Kind
+	delete(body, "status")
+	if metadata, ok := body["metadata"].(map[string]interface{}); ok {
+		for _, field := range []string{"resourceVersion", "managedFields", "creationTimestamp", "uid", "generation"} {
+			delete(metadata, field)
+		}
+	}
+	return json.Marshal(body)
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/pointer"
+)
+
+func newTestDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "api",
+			Namespace:       "test",
+			ResourceVersion: "123",
+			UID:             "uid",
+			Labels:          map[string]string{"app": "api"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.Int32(2),
+		},
+		Status: appsv1.DeploymentStatus{ReadyReplicas: 2},
+	}
+}
+
+func TestApply(t *testing.T) {
+	var request *http.Request
+	var body map[string]interface{}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = r
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(b, &body))
+		w.Header().Set("Content-Type", "application/json")
+		_, err = w.Write([]byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"api","namespace":"test","resourceVersion":"124"}}`))
+		require.NoError(t, err)
+	}))
+	defer s.Close()
+
+	c, err := kubernetes.NewForConfig(&rest.Config{Host: s.URL})
+	require.NoError(t, err)
+	rc := RESTClient(c.AppsV1().RESTClient())
+	require.NotNil(t, rc)
+
+	result := &appsv1.Deployment{}
+	err = Apply(context.Background(), rc, "deployments", appsv1.SchemeGroupVersion.WithKind("Deployment"), newTestDeployment(), result)
+	require.NoError(t, err)
+	assert.Equal(t, "124", result.ResourceVersion)
+
+	assert.Equal(t, http.MethodPatch, request.Method)
+	assert.Equal(t, "/apis/apps/v1/namespaces/test/deployments/api", request.URL.Path)
+	assert.Equal(t, "application/apply-patch+yaml", request.Header.Get("Content-Type"))
+	assert.Equal(t, FieldManager, request.URL.Query().Get("fieldManager"))
+	assert.Equal(t, "true", request.URL.Query().Get("force"))
+
+	assert.Equal(t, "apps/v1", body["apiVersion"])
+	assert.Equal(t, "Deployment", body["kind"])
+	assert.NotContains(t, body, "status")
+	metadata := body["metadata"].(map[string]interface{})
+	assert.NotContains(t, metadata, "resourceVersion")
+	assert.NotContains(t, metadata, "uid")
+	assert.NotContains(t, metadata, "creationTimestamp")
+	assert.Equal(t, "api", metadata["name"])
+	assert.Equal(t, map[string]interface{}{"app": "api"}, metadata["labels"])
+}
+
+func TestRESTClient(t *testing.T) {
+	assert.Nil(t, RESTClient(fake.NewSimpleClientset().AppsV1().RESTClient()))
+
+	c, err := kubernetes.NewForConfig(&rest.Config{Host: "https://localhost"})
+	require.NoError(t, err)
+	assert.NotNil(t, RESTClient(c.AppsV1().RESTClient()))
+}
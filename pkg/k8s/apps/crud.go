@@ -20,10 +20,14 @@ import (
 
 	"github.com/okteto/okteto/pkg/constants"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/cronjobs"
+	"github.com/okteto/okteto/pkg/k8s/daemonsets"
 	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/k8s/jobs"
 	"github.com/okteto/okteto/pkg/k8s/statefulsets"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
 )
@@ -39,7 +43,7 @@ func Get(ctx context.Context, dev *model.Dev, namespace string, c kubernetes.Int
 	d, err := deployments.GetByDev(ctx, dev, namespace, c)
 
 	if err == nil {
-		return &DeploymentApp{d: d}, nil
+		return &DeploymentApp{d: d, extensions: getPodTemplateExtensions(ctx, deploymentsResource, d.Name, d.Namespace, c)}, nil
 	}
 
 	if !oktetoErrors.IsNotFound(err) {
@@ -47,13 +51,40 @@ func Get(ctx context.Context, dev *model.Dev, namespace string, c kubernetes.Int
 	}
 
 	sfs, err := statefulsets.GetByDev(ctx, dev, namespace, c)
+	if err == nil {
+		return &StatefulSetApp{sfs: sfs, extensions: getPodTemplateExtensions(ctx, statefulsetsResource, sfs.Name, sfs.Namespace, c)}, nil
+	}
+
+	if !oktetoErrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	ds, err := daemonsets.GetByDev(ctx, dev, namespace, c)
+	if err == nil {
+		return &DaemonSetApp{kind: okteto.DaemonSet, ds: ds, extensions: getPodTemplateExtensions(ctx, daemonsetsResource, ds.Name, ds.Namespace, c)}, nil
+	}
+
+	if !oktetoErrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	job, err := jobs.GetByDev(ctx, dev, namespace, c)
+	if err == nil {
+		return NewJobApp(job), nil
+	}
+
+	if !oktetoErrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	cj, err := cronjobs.GetByDev(ctx, dev, namespace, c)
 	if err != nil {
 		if oktetoErrors.IsNotFound(err) {
 			return nil, ErrApplicationNotFound{Name: dev.Name}
 		}
 		return nil, err
 	}
-	return &StatefulSetApp{sfs: sfs}, nil
+	return NewCronJobApp(cj), nil
 }
 
 // IsDevModeOn returns if a statefulset is in devmode
@@ -61,37 +92,44 @@ func IsDevModeOn(app App) bool {
 	return app.ObjectMeta().Labels[constants.DevLabel] == "true" || len(app.ObjectMeta().Labels[model.DevCloneLabel]) > 0
 }
 
+// IsDevModeActive returns if the development container of an app is active.
+// Apps with the 'untouched' originalWorkload are not labeled, so the dev clone is checked too
+func IsDevModeActive(ctx context.Context, app App, c kubernetes.Interface) bool {
+	if IsDevModeOn(app) {
+		return true
+	}
+	_, err := app.GetDevClone(ctx, c)
+	return err == nil
+}
+
 // SetLastBuiltAnnotation sets the app timestamp
 func SetLastBuiltAnnotation(app App) {
 	app.ObjectMeta().Annotations[model.LastBuiltAnnotation] = time.Now().UTC().Format(constants.TimeFormat)
 }
 
-// GetRunningPodInLoop returns the dev pod for an app and loops until it success
-func GetRunningPodInLoop(ctx context.Context, dev *model.Dev, app App, c kubernetes.Interface) (*apiv1.Pod, error) {
+// GetRunningPodInLoop returns the dev pod for an app and loops until it success.
+// Transient errors of the Kubernetes API are retried up to apiRetries consecutive times
+func GetRunningPodInLoop(ctx context.Context, dev *model.Dev, app App, apiRetries int, c kubernetes.Interface) (*apiv1.Pod, error) {
 	ticker := time.NewTicker(500 * time.Millisecond)
 	start := time.Now()
 	to := start.Add(dev.Timeout.Resources)
 
+	failures := 0
 	for retries := 0; ; retries++ {
-		err := app.Refresh(ctx, c)
-		if err != nil {
-			return nil, err
-		}
-		if err = app.CheckConditionErrors(dev); err != nil {
-			return nil, err
-		}
-
-		pod, err := app.GetRunningPod(ctx, c)
-
-		if err == nil {
+		pod, running, err := getRunningPod(ctx, dev, app, c)
+		switch {
+		case running:
 			return pod, nil
-		}
-
-		if !oktetoErrors.IsNotFound(err) {
+		case err == nil:
+			failures = 0
+		case oktetoErrors.IsTransient(err) && failures < apiRetries:
+			failures++
+			oktetoLog.Infof("failed to get the development container, will retry: %s", err.Error())
+		default:
 			return nil, err
 		}
 
-		if time.Now().After(to) && retries > 10 {
+		if time.Now().After(to) && retries > apiRetries {
 			return nil, oktetoErrors.ErrKubernetesLongTimeToCreateDevContainer
 		}
 
@@ -108,6 +146,24 @@ func GetRunningPodInLoop(ctx context.Context, dev *model.Dev, app App, c kuberne
 	}
 }
 
+// getRunningPod returns the running dev pod of an app, or false if it isn't running yet
+func getRunningPod(ctx context.Context, dev *model.Dev, app App, c kubernetes.Interface) (*apiv1.Pod, bool, error) {
+	if err := app.Refresh(ctx, c); err != nil {
+		return nil, false, err
+	}
+	if err := app.CheckConditionErrors(dev); err != nil {
+		return nil, false, err
+	}
+	pod, err := app.GetRunningPod(ctx, c)
+	if oktetoErrors.IsNotFound(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return pod, true, nil
+}
+
 // GetTranslations fills all the deployments pointed by a development container
 func GetTranslations(ctx context.Context, dev *model.Dev, app App, reset bool, c kubernetes.Interface) (map[string]*Translation, error) {
 	mainTr := &Translation{
@@ -174,7 +230,7 @@ func TranslateDevMode(trMap map[string]*Translation) error {
 	return nil
 }
 
-// ListDevModeOn returns a list of strings with the names of deployments or statefulsets in DevMode.
+// ListDevModeOn returns a list of strings with the names of the apps in DevMode.
 // If no app is found in dev mode, an empty slice is returned
 func ListDevModeOn(ctx context.Context, manifest *model.Manifest, c kubernetes.Interface) []string {
 	devModeApps := make([]string, 0)
@@ -0,0 +1,225 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"fmt"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/daemonsets"
+	"github.com/okteto/okteto/pkg/k8s/deployments"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/pointer"
+)
+
+// DaemonSetApp is a daemonset in development mode.
+// Its development container runs in a deployment with a single replica, and the daemonset is scaled down with a node selector that doesn't match any node
+type DaemonSetApp struct {
+	kind       string
+	ds         *appsv1.DaemonSet
+	extensions *podTemplateExtensions
+}
+
+func NewDaemonSetApp(ds *appsv1.DaemonSet) *DaemonSetApp {
+	return &DaemonSetApp{kind: okteto.DaemonSet, ds: ds}
+}
+
+func (i *DaemonSetApp) Kind() string {
+	return i.kind
+}
+
+func (i *DaemonSetApp) ObjectMeta() metav1.ObjectMeta {
+	if i.ds.ObjectMeta.Annotations == nil {
+		i.ds.ObjectMeta.Annotations = map[string]string{}
+	}
+	if i.ds.ObjectMeta.Labels == nil {
+		i.ds.ObjectMeta.Labels = map[string]string{}
+	}
+	return i.ds.ObjectMeta
+}
+
+// Replicas returns 1 if the daemonset runs its pods, and 0 if it's scaled down
+func (i *DaemonSetApp) Replicas() int32 {
+	if daemonsets.IsScaledDown(i.ds) {
+		return 0
+	}
+	return 1
+}
+
+// SetReplicas scales down the daemonset for 0 replicas, and restores it for any other value
+func (i *DaemonSetApp) SetReplicas(n int32) {
+	if n == 0 {
+		daemonsets.ScaleDown(i.ds)
+		return
+	}
+	daemonsets.ScaleUp(i.ds)
+}
+
+func (i *DaemonSetApp) TemplateObjectMeta() metav1.ObjectMeta {
+	if i.ds.Spec.Template.ObjectMeta.Annotations == nil {
+		i.ds.Spec.Template.ObjectMeta.Annotations = map[string]string{}
+	}
+	if i.ds.Spec.Template.ObjectMeta.Labels == nil {
+		i.ds.Spec.Template.ObjectMeta.Labels = map[string]string{}
+	}
+	return i.ds.Spec.Template.ObjectMeta
+}
+
+func (i *DaemonSetApp) PodSpec() *apiv1.PodSpec {
+	return &i.ds.Spec.Template.Spec
+}
+
+// DevClone returns a deployment with a single replica of the daemonset pod
+func (i *DaemonSetApp) DevClone() App {
+	clone := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        model.DevCloneName(i.ds.Name),
+			Namespace:   i.ds.Namespace,
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.Int32Ptr(1),
+			Selector: i.ds.Spec.Selector.DeepCopy(),
+			Template: *i.ds.Spec.Template.DeepCopy(),
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RecreateDeploymentStrategyType,
+			},
+		},
+	}
+	clone.Labels[model.DevCloneLabel] = string(i.ds.UID)
+	for k, v := range i.ds.Labels {
+		clone.Labels[k] = v
+	}
+	for k, v := range i.ds.Annotations {
+		clone.Annotations[k] = v
+	}
+	delete(clone.Spec.Template.Spec.NodeSelector, model.DaemonSetScaledDownNodeSelector)
+	return &DeploymentApp{kind: okteto.Deployment, d: clone, extensions: i.extensions}
+}
+
+// CheckConditionErrors returns nil, daemonsets don't report errors in conditions
+func (*DaemonSetApp) CheckConditionErrors(_ *model.Dev) error {
+	return nil
+}
+
+func (i *DaemonSetApp) GetRunningPod(ctx context.Context, c kubernetes.Interface) (*apiv1.Pod, error) {
+	return getPodByOwner(ctx, i.ds.UID, i.ds.Namespace, c)
+}
+
+// RestoreOriginal is a no-op, daemonsets were never in the deprecated dev mode
+func (*DaemonSetApp) RestoreOriginal() error {
+	return nil
+}
+
+func (i *DaemonSetApp) Refresh(ctx context.Context, c kubernetes.Interface) error {
+	ds, err := daemonsets.Get(ctx, i.ds.Name, i.ds.Namespace, c)
+	if err == nil {
+		i.ds = ds
+	}
+	return err
+}
+
+func (i *DaemonSetApp) Watch(ctx context.Context, result chan error, c kubernetes.Interface) {
+	optsWatch := metav1.ListOptions{
+		Watch:         true,
+		FieldSelector: fmt.Sprintf("metadata.name=%s", i.ds.Name),
+	}
+
+	watcher, err := c.AppsV1().DaemonSets(i.ds.Namespace).Watch(ctx, optsWatch)
+	if err != nil {
+		result <- err
+		return
+	}
+
+	for {
+		select {
+		case e := <-watcher.ResultChan():
+			oktetoLog.Debugf("Received daemonset '%s' event: %s", i.ds.Name, e)
+			if e.Object == nil {
+				oktetoLog.Debugf("Recreating daemonset '%s' watcher", i.ds.Name)
+				watcher, err = c.AppsV1().DaemonSets(i.ds.Namespace).Watch(ctx, optsWatch)
+				if err != nil {
+					result <- err
+					return
+				}
+				continue
+			}
+			switch e.Type {
+			case watch.Deleted:
+				result <- oktetoErrors.ErrDeleteToApp
+				return
+			case watch.Modified:
+				ds, ok := e.Object.(*appsv1.DaemonSet)
+				if !ok {
+					oktetoLog.Debugf("Failed to parse daemonset event: %s", e)
+					continue
+				}
+				if ds.Generation != i.ds.Generation {
+					result <- oktetoErrors.ErrApplyToApp
+					return
+				}
+			}
+		case err := <-ctx.Done():
+			oktetoLog.Debugf("call to up.applyToApp cancelled: %v", err)
+			return
+		}
+	}
+}
+
+func (i *DaemonSetApp) Deploy(ctx context.Context, c kubernetes.Interface) error {
+	if !i.extensions.isEmpty() {
+		i.ds.ResourceVersion = ""
+		ds := &appsv1.DaemonSet{}
+		err := i.extensions.deploy(ctx, daemonsetsResource, okteto.DaemonSet, i.ds.Name, i.ds.Namespace, i.ds, ds, c)
+		if err == nil {
+			i.ds = ds
+		}
+		return err
+	}
+
+	ds, err := daemonsets.Deploy(ctx, i.ds, c)
+	if err == nil {
+		i.ds = ds
+	}
+	return err
+}
+
+func (i *DaemonSetApp) PatchAnnotations(ctx context.Context, c kubernetes.Interface) error {
+	return daemonsets.PatchAnnotations(ctx, i.ds, c)
+}
+
+func (i *DaemonSetApp) Destroy(ctx context.Context, c kubernetes.Interface) error {
+	return daemonsets.Destroy(ctx, i.ds.Name, i.ds.Namespace, c)
+}
+
+// GetDevClone returns from Kubernetes the deployment cloned from the daemonset
+func (i *DaemonSetApp) GetDevClone(ctx context.Context, c kubernetes.Interface) (App, error) {
+	clonedName := model.DevCloneName(i.ds.Name)
+	d, err := deployments.Get(ctx, clonedName, i.ds.Namespace, c)
+	if err == nil {
+		app := NewDeploymentApp(d)
+		app.extensions = getPodTemplateExtensions(ctx, deploymentsResource, d.Name, d.Namespace, c)
+		return app, nil
+	}
+	return nil, err
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestDaemonSet() *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "agent",
+			Namespace: "test",
+			UID:       "uid",
+			Labels:    map[string]string{"app": "agent"},
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "agent"}},
+			Template: apiv1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "agent"}},
+				Spec: apiv1.PodSpec{
+					NodeSelector: map[string]string{"kubernetes.io/os": "linux"},
+					Containers:   []apiv1.Container{{Name: "agent", Image: "agent"}},
+				},
+			},
+		},
+	}
+}
+
+func TestDaemonSetSetReplicas(t *testing.T) {
+	app := NewDaemonSetApp(newTestDaemonSet())
+	assert.Equal(t, int32(1), app.Replicas())
+
+	app.SetReplicas(0)
+	assert.Equal(t, int32(0), app.Replicas())
+	assert.Equal(t, "true", app.PodSpec().NodeSelector[model.DaemonSetScaledDownNodeSelector])
+
+	app.SetReplicas(1)
+	assert.Equal(t, int32(1), app.Replicas())
+	assert.Equal(t, map[string]string{"kubernetes.io/os": "linux"}, app.PodSpec().NodeSelector)
+}
+
+func TestDaemonSetDevClone(t *testing.T) {
+	app := NewDaemonSetApp(newTestDaemonSet())
+	app.SetReplicas(0)
+
+	clone, ok := app.DevClone().(*DeploymentApp)
+	require.True(t, ok)
+	assert.Equal(t, okteto.Deployment, clone.Kind())
+	assert.Equal(t, "agent-okteto", clone.ObjectMeta().Name)
+	assert.Equal(t, "uid", clone.ObjectMeta().Labels[model.DevCloneLabel])
+	assert.Equal(t, int32(1), clone.Replicas())
+	assert.Equal(t, appsv1.RecreateDeploymentStrategyType, clone.d.Spec.Strategy.Type)
+	assert.Equal(t, map[string]string{"app": "agent"}, clone.d.Spec.Selector.MatchLabels)
+	assert.Equal(t, map[string]string{"kubernetes.io/os": "linux"}, clone.PodSpec().NodeSelector)
+}
+
+func TestDaemonSetGetDevClone(t *testing.T) {
+	ctx := context.Background()
+	app := NewDaemonSetApp(newTestDaemonSet())
+
+	_, err := app.GetDevClone(ctx, fake.NewSimpleClientset())
+	require.Error(t, err)
+
+	cloned := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "agent-okteto",
+			Namespace: "test",
+		},
+	}
+	result, err := app.GetDevClone(ctx, fake.NewSimpleClientset(cloned))
+	require.NoError(t, err)
+	assert.Equal(t, NewDeploymentApp(cloned), result)
+}
+
+func TestGetDaemonSet(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset(newTestDaemonSet())
+
+	app, err := Get(ctx, &model.Dev{Name: "agent"}, "test", c)
+	require.NoError(t, err)
+	assert.IsType(t, &DaemonSetApp{}, app)
+	assert.Equal(t, okteto.DaemonSet, app.Kind())
+
+	_, err = Get(ctx, &model.Dev{Name: "not-found"}, "test", c)
+	assert.ErrorAs(t, err, &ErrApplicationNotFound{})
+}
@@ -35,8 +35,9 @@ import (
 )
 
 type DeploymentApp struct {
-	kind string
-	d    *appsv1.Deployment
+	kind       string
+	d          *appsv1.Deployment
+	extensions *podTemplateExtensions
 }
 
 func NewDeploymentApp(d *appsv1.Deployment) *DeploymentApp {
@@ -104,7 +105,7 @@ func (i *DeploymentApp) DevClone() App {
 	clone.Spec.Strategy = appsv1.DeploymentStrategy{
 		Type: appsv1.RecreateDeploymentStrategyType,
 	}
-	return NewDeploymentApp(clone)
+	return &DeploymentApp{kind: okteto.Deployment, d: clone, extensions: i.extensions}
 }
 
 func (i *DeploymentApp) CheckConditionErrors(dev *model.Dev) error {
@@ -193,6 +194,16 @@ func (i *DeploymentApp) Deploy(ctx context.Context, c kubernetes.Interface) erro
 		return nil
 	}
 
+	if !i.extensions.isEmpty() {
+		i.d.ResourceVersion = ""
+		d := &appsv1.Deployment{}
+		err := i.extensions.deploy(ctx, deploymentsResource, okteto.Deployment, i.d.Name, i.d.Namespace, i.d, d, c)
+		if err == nil {
+			i.d = d
+		}
+		return err
+	}
+
 	d, err := deployments.Deploy(ctx, i.d, c)
 	if err == nil {
 		i.d = d
@@ -213,7 +224,9 @@ func (i *DeploymentApp) GetDevClone(ctx context.Context, c kubernetes.Interface)
 	clonedName := model.DevCloneName(i.d.Name)
 	d, err := deployments.Get(ctx, clonedName, i.d.Namespace, c)
 	if err == nil {
-		return NewDeploymentApp(d), nil
+		app := NewDeploymentApp(d)
+		app.extensions = getPodTemplateExtensions(ctx, deploymentsResource, d.Name, d.Namespace, c)
+		return app, nil
 	}
 	return nil, err
 }
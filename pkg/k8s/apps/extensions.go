@@ -0,0 +1,162 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/apply"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	deploymentsResource  = "deployments"
+	statefulsetsResource = "statefulsets"
+	daemonsetsResource   = "daemonsets"
+)
+
+// podTemplateExtensions are pod template fields of Kubernetes 1.28+ that are not part of the Kubernetes API vendored by okteto.
+// They are dropped when a workload is decoded, so they are read from the raw workload and added back when the workload is deployed
+type podTemplateExtensions struct {
+	// sidecars are the names of the init containers with restartPolicy 'Always' (native sidecar containers)
+	sidecars []string
+}
+
+type rawPodTemplateWorkload struct {
+	Spec struct {
+		Template struct {
+			Spec struct {
+				InitContainers []struct {
+					Name          string `json:"name"`
+					RestartPolicy string `json:"restartPolicy,omitempty"`
+				} `json:"initContainers,omitempty"`
+			} `json:"spec"`
+		} `json:"template"`
+	} `json:"spec"`
+}
+
+func (e *podTemplateExtensions) isEmpty() bool {
+	return e == nil || len(e.sidecars) == 0
+}
+
+// parsePodTemplateExtensions returns the pod template extensions of a raw workload
+func parsePodTemplateExtensions(raw []byte) (*podTemplateExtensions, error) {
+	w := &rawPodTemplateWorkload{}
+	if err := json.Unmarshal(raw, w); err != nil {
+		return nil, err
+	}
+	result := &podTemplateExtensions{}
+	for _, c := range w.Spec.Template.Spec.InitContainers {
+		if c.RestartPolicy == "Always" {
+			result.sidecars = append(result.sidecars, c.Name)
+		}
+	}
+	if result.isEmpty() {
+		return nil, nil
+	}
+	return result, nil
+}
+
+// getRESTClient returns the REST client of the apps API, or nil if the client doesn't have one, like fake clients
+func getRESTClient(c kubernetes.Interface) rest.Interface {
+	return apply.RESTClient(c.AppsV1().RESTClient())
+}
+
+// getPodTemplateExtensions returns the pod template extensions of a workload. Errors are logged and ignored
+func getPodTemplateExtensions(ctx context.Context, resource, name, namespace string, c kubernetes.Interface) *podTemplateExtensions {
+	rc := getRESTClient(c)
+	if rc == nil {
+		return nil
+	}
+	raw, err := rc.Get().Namespace(namespace).Resource(resource).Name(name).Do(ctx).Raw()
+	if err != nil {
+		oktetoLog.Infof("failed to get the raw %s '%s': %s", resource, name, err)
+		return nil
+	}
+	e, err := parsePodTemplateExtensions(raw)
+	if err != nil {
+		oktetoLog.Infof("failed to parse the raw %s '%s': %s", resource, name, err)
+		return nil
+	}
+	return e
+}
+
+// apply returns the json of a workload with the pod template extensions
+func (e *podTemplateExtensions) apply(obj runtime.Object, kind string) ([]byte, error) {
+	bytes, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	w := map[string]interface{}{}
+	if err := json.Unmarshal(bytes, &w); err != nil {
+		return nil, err
+	}
+	w["apiVersion"] = "apps/v1"
+	w["kind"] = kind
+
+	spec, ok := w["spec"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s without spec", kind)
+	}
+	template, ok := spec["template"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s without pod template", kind)
+	}
+	podSpec, ok := template["spec"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s without pod spec", kind)
+	}
+
+	sidecars := map[string]bool{}
+	for _, name := range e.sidecars {
+		sidecars[name] = true
+	}
+	initContainers, _ := podSpec["initContainers"].([]interface{})
+	for _, ic := range initContainers {
+		container, ok := ic.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if name, _ := container["name"].(string); sidecars[name] {
+			container["restartPolicy"] = "Always"
+		}
+	}
+	return json.Marshal(w)
+}
+
+// deploy creates or updates a workload with the pod template extensions
+func (e *podTemplateExtensions) deploy(ctx context.Context, resource, kind, name, namespace string, obj, result runtime.Object, c kubernetes.Interface) error {
+	rc := getRESTClient(c)
+	if rc == nil {
+		return fmt.Errorf("the kubernetes client doesn't support %s with native sidecars", resource)
+	}
+	body, err := e.apply(obj, kind)
+	if err != nil {
+		return err
+	}
+	err = rc.Put().Namespace(namespace).Resource(resource).Name(name).Body(body).Do(ctx).Into(result)
+	if err == nil {
+		return nil
+	}
+	if !oktetoErrors.IsNotFound(err) {
+		return err
+	}
+	return rc.Post().Namespace(namespace).Resource(resource).Body(body).Do(ctx).Into(result)
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParsePodTemplateExtensions(t *testing.T) {
+	var tests = []struct {
+		name     string
+		raw      string
+		expected *podTemplateExtensions
+	}{
+		{
+			name: "no extensions",
+			raw: `{"spec":{"template":{"spec":{
+				"initContainers":[{"name":"init","image":"busybox"}]
+			}}}}`,
+			expected: nil,
+		},
+		{
+			name: "native sidecars",
+			raw: `{"spec":{"template":{"spec":{
+				"initContainers":[{"name":"init","image":"busybox"},{"name":"proxy","image":"envoy","restartPolicy":"Always"}]
+			}}}}`,
+			expected: &podTemplateExtensions{sidecars: []string{"proxy"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parsePodTemplateExtensions([]byte(tt.raw))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestPodTemplateExtensionsApply(t *testing.T) {
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "test"},
+		Spec: appsv1.DeploymentSpec{
+			Template: apiv1.PodTemplateSpec{
+				Spec: apiv1.PodSpec{
+					InitContainers: []apiv1.Container{
+						{Name: "proxy", Image: "envoy"},
+						{Name: OktetoBinName, Image: "okteto/bin"},
+					},
+					Containers: []apiv1.Container{{Name: "api", Image: "api"}},
+				},
+			},
+		},
+	}
+	e := &podTemplateExtensions{sidecars: []string{"proxy"}}
+
+	body, err := e.apply(d, "Deployment")
+	require.NoError(t, err)
+
+	result := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(body, &result))
+	assert.Equal(t, "apps/v1", result["apiVersion"])
+	assert.Equal(t, "Deployment", result["kind"])
+
+	podSpec := result["spec"].(map[string]interface{})["template"].(map[string]interface{})["spec"].(map[string]interface{})
+	initContainers := podSpec["initContainers"].([]interface{})
+	assert.Equal(t, "Always", initContainers[0].(map[string]interface{})["restartPolicy"])
+	assert.NotContains(t, initContainers[1].(map[string]interface{}), "restartPolicy")
+}
+
+func TestDevCloneKeepsPodTemplateExtensions(t *testing.T) {
+	e := &podTemplateExtensions{sidecars: []string{"proxy"}}
+
+	d := &DeploymentApp{d: &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api"}}, extensions: e}
+	assert.Equal(t, e, d.DevClone().(*DeploymentApp).extensions)
+
+	sfs := &StatefulSetApp{sfs: &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "db"}}, extensions: e}
+	assert.Equal(t, e, sfs.DevClone().(*StatefulSetApp).extensions)
+}
@@ -0,0 +1,404 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"fmt"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/cronjobs"
+	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/k8s/jobs"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/pointer"
+)
+
+// jobControllerLabels are the labels added by the job controller to the pods of a job
+var jobControllerLabels = []string{
+	"controller-uid",
+	"job-name",
+	"batch.kubernetes.io/controller-uid",
+	"batch.kubernetes.io/job-name",
+}
+
+// JobApp is a job in development mode.
+// Its development container runs in a deployment with a single replica, and the job is suspended
+type JobApp struct {
+	kind string
+	job  *batchv1.Job
+}
+
+// CronJobApp is a cronjob in development mode.
+// Its development container runs in a deployment with a single replica, and the schedule of the cronjob is suspended
+type CronJobApp struct {
+	kind string
+	cj   *batchv1.CronJob
+}
+
+func NewJobApp(job *batchv1.Job) *JobApp {
+	return &JobApp{kind: okteto.Job, job: job}
+}
+
+func NewCronJobApp(cj *batchv1.CronJob) *CronJobApp {
+	return &CronJobApp{kind: okteto.CronJob, cj: cj}
+}
+
+// jobDevClone returns a deployment with a single replica running the pod template of a job
+func jobDevClone(meta metav1.ObjectMeta, template *apiv1.PodTemplateSpec) *DeploymentApp {
+	clone := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        model.DevCloneName(meta.Name),
+			Namespace:   meta.Namespace,
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.Int32Ptr(1),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{model.JobDevLabel: meta.Name},
+			},
+			Template: *template.DeepCopy(),
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RecreateDeploymentStrategyType,
+			},
+		},
+	}
+	clone.Labels[model.DevCloneLabel] = string(meta.UID)
+	for k, v := range meta.Labels {
+		clone.Labels[k] = v
+	}
+	for k, v := range meta.Annotations {
+		clone.Annotations[k] = v
+	}
+
+	if clone.Spec.Template.Labels == nil {
+		clone.Spec.Template.Labels = map[string]string{}
+	}
+	for _, l := range jobControllerLabels {
+		delete(clone.Spec.Template.Labels, l)
+	}
+	clone.Spec.Template.Labels[model.JobDevLabel] = meta.Name
+	clone.Spec.Template.Spec.RestartPolicy = apiv1.RestartPolicyAlways
+	clone.Spec.Template.Spec.ActiveDeadlineSeconds = nil
+	return &DeploymentApp{kind: okteto.Deployment, d: clone}
+}
+
+// getJobDevClone returns from Kubernetes the deployment cloned from a job or a cronjob
+func getJobDevClone(ctx context.Context, name, namespace string, c kubernetes.Interface) (App, error) {
+	d, err := deployments.Get(ctx, model.DevCloneName(name), namespace, c)
+	if err != nil {
+		return nil, err
+	}
+	return NewDeploymentApp(d), nil
+}
+
+func (i *JobApp) Kind() string {
+	return i.kind
+}
+
+func (i *JobApp) ObjectMeta() metav1.ObjectMeta {
+	if i.job.ObjectMeta.Annotations == nil {
+		i.job.ObjectMeta.Annotations = map[string]string{}
+	}
+	if i.job.ObjectMeta.Labels == nil {
+		i.job.ObjectMeta.Labels = map[string]string{}
+	}
+	return i.job.ObjectMeta
+}
+
+// Replicas returns 0 if the job is suspended, and 1 otherwise
+func (i *JobApp) Replicas() int32 {
+	if i.job.Spec.Suspend != nil && *i.job.Spec.Suspend {
+		return 0
+	}
+	return 1
+}
+
+// SetReplicas suspends the job for 0 replicas, and resumes it for any other value
+func (i *JobApp) SetReplicas(n int32) {
+	i.job.Spec.Suspend = pointer.BoolPtr(n == 0)
+}
+
+// TemplateObjectMeta returns a copy of the metadata of the pod template, the pod template of a job is immutable
+func (i *JobApp) TemplateObjectMeta() metav1.ObjectMeta {
+	meta := *i.job.Spec.Template.ObjectMeta.DeepCopy()
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	if meta.Labels == nil {
+		meta.Labels = map[string]string{}
+	}
+	return meta
+}
+
+func (i *JobApp) PodSpec() *apiv1.PodSpec {
+	return &i.job.Spec.Template.Spec
+}
+
+func (i *JobApp) DevClone() App {
+	return jobDevClone(i.job.ObjectMeta, &i.job.Spec.Template)
+}
+
+// CheckConditionErrors returns nil, the job is suspended while the development container is active
+func (*JobApp) CheckConditionErrors(_ *model.Dev) error {
+	return nil
+}
+
+func (i *JobApp) GetRunningPod(ctx context.Context, c kubernetes.Interface) (*apiv1.Pod, error) {
+	return getPodByOwner(ctx, i.job.UID, i.job.Namespace, c)
+}
+
+// RestoreOriginal is a no-op, jobs were never in the deprecated dev mode
+func (*JobApp) RestoreOriginal() error {
+	return nil
+}
+
+func (i *JobApp) Refresh(ctx context.Context, c kubernetes.Interface) error {
+	job, err := jobs.Get(ctx, i.job.Name, i.job.Namespace, c)
+	if err == nil {
+		i.job = job
+	}
+	return err
+}
+
+func (i *JobApp) Watch(ctx context.Context, result chan error, c kubernetes.Interface) {
+	optsWatch := metav1.ListOptions{
+		Watch:         true,
+		FieldSelector: fmt.Sprintf("metadata.name=%s", i.job.Name),
+	}
+
+	watcher, err := c.BatchV1().Jobs(i.job.Namespace).Watch(ctx, optsWatch)
+	if err != nil {
+		result <- err
+		return
+	}
+
+	for {
+		select {
+		case e := <-watcher.ResultChan():
+			oktetoLog.Debugf("Received job '%s' event: %s", i.job.Name, e)
+			if e.Object == nil {
+				oktetoLog.Debugf("Recreating job '%s' watcher", i.job.Name)
+				watcher, err = c.BatchV1().Jobs(i.job.Namespace).Watch(ctx, optsWatch)
+				if err != nil {
+					result <- err
+					return
+				}
+				continue
+			}
+			if e.Type == watch.Deleted {
+				result <- oktetoErrors.ErrDeleteToApp
+				return
+			}
+		case err := <-ctx.Done():
+			oktetoLog.Debugf("call to up.applyToApp cancelled: %v", err)
+			return
+		}
+	}
+}
+
+func (i *JobApp) Deploy(ctx context.Context, c kubernetes.Interface) error {
+	job, err := jobs.Deploy(ctx, i.job, c)
+	if err == nil {
+		i.job = job
+	}
+	return err
+}
+
+func (i *JobApp) PatchAnnotations(ctx context.Context, c kubernetes.Interface) error {
+	return jobs.PatchAnnotations(ctx, i.job, c)
+}
+
+func (i *JobApp) Destroy(ctx context.Context, c kubernetes.Interface) error {
+	return jobs.Destroy(ctx, i.job.Name, i.job.Namespace, c)
+}
+
+// GetDevClone returns from Kubernetes the deployment cloned from the job
+func (i *JobApp) GetDevClone(ctx context.Context, c kubernetes.Interface) (App, error) {
+	return getJobDevClone(ctx, i.job.Name, i.job.Namespace, c)
+}
+
+func (i *CronJobApp) Kind() string {
+	return i.kind
+}
+
+func (i *CronJobApp) ObjectMeta() metav1.ObjectMeta {
+	if i.cj.ObjectMeta.Annotations == nil {
+		i.cj.ObjectMeta.Annotations = map[string]string{}
+	}
+	if i.cj.ObjectMeta.Labels == nil {
+		i.cj.ObjectMeta.Labels = map[string]string{}
+	}
+	return i.cj.ObjectMeta
+}
+
+// Replicas returns 0 if the schedule of the cronjob is suspended, and 1 otherwise
+func (i *CronJobApp) Replicas() int32 {
+	if i.cj.Spec.Suspend != nil && *i.cj.Spec.Suspend {
+		return 0
+	}
+	return 1
+}
+
+// SetReplicas suspends the schedule of the cronjob for 0 replicas, and resumes it for any other value
+func (i *CronJobApp) SetReplicas(n int32) {
+	i.cj.Spec.Suspend = pointer.BoolPtr(n == 0)
+}
+
+func (i *CronJobApp) TemplateObjectMeta() metav1.ObjectMeta {
+	template := &i.cj.Spec.JobTemplate.Spec.Template
+	if template.ObjectMeta.Annotations == nil {
+		template.ObjectMeta.Annotations = map[string]string{}
+	}
+	if template.ObjectMeta.Labels == nil {
+		template.ObjectMeta.Labels = map[string]string{}
+	}
+	return template.ObjectMeta
+}
+
+func (i *CronJobApp) PodSpec() *apiv1.PodSpec {
+	return &i.cj.Spec.JobTemplate.Spec.Template.Spec
+}
+
+func (i *CronJobApp) DevClone() App {
+	return jobDevClone(i.cj.ObjectMeta, &i.cj.Spec.JobTemplate.Spec.Template)
+}
+
+// CheckConditionErrors returns nil, cronjobs don't report errors in conditions
+func (*CronJobApp) CheckConditionErrors(_ *model.Dev) error {
+	return nil
+}
+
+// GetRunningPod returns a running pod of the active jobs of the cronjob
+func (i *CronJobApp) GetRunningPod(ctx context.Context, c kubernetes.Interface) (*apiv1.Pod, error) {
+	for _, active := range i.cj.Status.Active {
+		pod, err := getPodByOwner(ctx, active.UID, i.cj.Namespace, c)
+		if err == nil {
+			return pod, nil
+		}
+		if !oktetoErrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	return nil, oktetoErrors.ErrNotFound
+}
+
+// RestoreOriginal is a no-op, cronjobs were never in the deprecated dev mode
+func (*CronJobApp) RestoreOriginal() error {
+	return nil
+}
+
+func (i *CronJobApp) Refresh(ctx context.Context, c kubernetes.Interface) error {
+	cj, err := cronjobs.Get(ctx, i.cj.Name, i.cj.Namespace, c)
+	if err == nil {
+		i.cj = cj
+	}
+	return err
+}
+
+func (i *CronJobApp) Watch(ctx context.Context, result chan error, c kubernetes.Interface) {
+	optsWatch := metav1.ListOptions{
+		Watch:         true,
+		FieldSelector: fmt.Sprintf("metadata.name=%s", i.cj.Name),
+	}
+
+	watcher, err := c.BatchV1().CronJobs(i.cj.Namespace).Watch(ctx, optsWatch)
+	if err != nil {
+		result <- err
+		return
+	}
+
+	for {
+		select {
+		case e := <-watcher.ResultChan():
+			oktetoLog.Debugf("Received cronjob '%s' event: %s", i.cj.Name, e)
+			if e.Object == nil {
+				oktetoLog.Debugf("Recreating cronjob '%s' watcher", i.cj.Name)
+				watcher, err = c.BatchV1().CronJobs(i.cj.Namespace).Watch(ctx, optsWatch)
+				if err != nil {
+					result <- err
+					return
+				}
+				continue
+			}
+			switch e.Type {
+			case watch.Deleted:
+				result <- oktetoErrors.ErrDeleteToApp
+				return
+			case watch.Modified:
+				cj, ok := e.Object.(*batchv1.CronJob)
+				if !ok {
+					oktetoLog.Debugf("Failed to parse cronjob event: %s", e)
+					continue
+				}
+				if cj.Generation != i.cj.Generation {
+					result <- oktetoErrors.ErrApplyToApp
+					return
+				}
+			}
+		case err := <-ctx.Done():
+			oktetoLog.Debugf("call to up.applyToApp cancelled: %v", err)
+			return
+		}
+	}
+}
+
+func (i *CronJobApp) Deploy(ctx context.Context, c kubernetes.Interface) error {
+	cj, err := cronjobs.Deploy(ctx, i.cj, c)
+	if err == nil {
+		i.cj = cj
+	}
+	return err
+}
+
+func (i *CronJobApp) PatchAnnotations(ctx context.Context, c kubernetes.Interface) error {
+	return cronjobs.PatchAnnotations(ctx, i.cj, c)
+}
+
+func (i *CronJobApp) Destroy(ctx context.Context, c kubernetes.Interface) error {
+	return cronjobs.Destroy(ctx, i.cj.Name, i.cj.Namespace, c)
+}
+
+// GetDevClone returns from Kubernetes the deployment cloned from the cronjob
+func (i *CronJobApp) GetDevClone(ctx context.Context, c kubernetes.Interface) (App, error) {
+	return getJobDevClone(ctx, i.cj.Name, i.cj.Namespace, c)
+}
+
+// GetJobCommand returns the command of the container of a job or a cronjob, to run it in its development container
+func GetJobCommand(app App, container string) ([]string, error) {
+	switch app.(type) {
+	case *JobApp, *CronJobApp:
+	default:
+		return nil, fmt.Errorf("'%s' is not a job or a cronjob", app.ObjectMeta().Name)
+	}
+	c := GetDevContainer(app.PodSpec(), container)
+	if c == nil {
+		return nil, fmt.Errorf("container '%s' not found in '%s'", container, app.ObjectMeta().Name)
+	}
+	command := append([]string{}, c.Command...)
+	command = append(command, c.Args...)
+	if len(command) == 0 {
+		return nil, fmt.Errorf("the container '%s' of '%s' doesn't define a command", c.Name, app.ObjectMeta().Name)
+	}
+	return command, nil
+}
@@ -0,0 +1,204 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
+)
+
+func newTestJobTemplate() apiv1.PodTemplateSpec {
+	return apiv1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				"app":            "migrate",
+				"controller-uid": "uid",
+				"job-name":       "migrate",
+			},
+		},
+		Spec: apiv1.PodSpec{
+			RestartPolicy:         apiv1.RestartPolicyNever,
+			ActiveDeadlineSeconds: pointer.Int64(60),
+			Containers: []apiv1.Container{
+				{
+					Name:    "migrate",
+					Image:   "migrate",
+					Command: []string{"python", "manage.py"},
+					Args:    []string{"migrate", "--noinput"},
+				},
+			},
+		},
+	}
+}
+
+func newTestJob() *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "migrate",
+			Namespace: "test",
+			UID:       "uid",
+			Labels:    map[string]string{"app": "migrate"},
+		},
+		Spec: batchv1.JobSpec{Template: newTestJobTemplate()},
+	}
+}
+
+func newTestCronJob() *batchv1.CronJob {
+	return &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "migrate",
+			Namespace: "test",
+			UID:       "uid",
+			Labels:    map[string]string{"app": "migrate"},
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule: "*/5 * * * *",
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{Template: newTestJobTemplate()},
+			},
+		},
+	}
+}
+
+func TestJobSetReplicas(t *testing.T) {
+	var tests = []struct {
+		name string
+		app  App
+	}{
+		{name: "job", app: NewJobApp(newTestJob())},
+		{name: "cronjob", app: NewCronJobApp(newTestCronJob())},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, int32(1), tt.app.Replicas())
+			tt.app.SetReplicas(0)
+			assert.Equal(t, int32(0), tt.app.Replicas())
+			tt.app.SetReplicas(1)
+			assert.Equal(t, int32(1), tt.app.Replicas())
+		})
+	}
+
+	cj := newTestCronJob()
+	NewCronJobApp(cj).SetReplicas(0)
+	assert.True(t, *cj.Spec.Suspend)
+}
+
+func TestJobDevClone(t *testing.T) {
+	var tests = []struct {
+		name string
+		app  App
+	}{
+		{name: "job", app: NewJobApp(newTestJob())},
+		{name: "cronjob", app: NewCronJobApp(newTestCronJob())},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clone, ok := tt.app.DevClone().(*DeploymentApp)
+			require.True(t, ok)
+			assert.Equal(t, okteto.Deployment, clone.Kind())
+			assert.Equal(t, "migrate-okteto", clone.ObjectMeta().Name)
+			assert.Equal(t, "uid", clone.ObjectMeta().Labels[model.DevCloneLabel])
+			assert.Equal(t, int32(1), clone.Replicas())
+			assert.Equal(t, appsv1.RecreateDeploymentStrategyType, clone.d.Spec.Strategy.Type)
+			assert.Equal(t, map[string]string{model.JobDevLabel: "migrate"}, clone.d.Spec.Selector.MatchLabels)
+			assert.Equal(t, map[string]string{"app": "migrate", model.JobDevLabel: "migrate"}, clone.TemplateObjectMeta().Labels)
+			assert.Equal(t, apiv1.RestartPolicyAlways, clone.PodSpec().RestartPolicy)
+			assert.Nil(t, clone.PodSpec().ActiveDeadlineSeconds)
+
+			// the pod template of the original workload is not modified
+			assert.Equal(t, apiv1.RestartPolicyNever, tt.app.PodSpec().RestartPolicy)
+			assert.Equal(t, "uid", tt.app.TemplateObjectMeta().Labels["controller-uid"])
+		})
+	}
+}
+
+func TestGetJobs(t *testing.T) {
+	ctx := context.Background()
+
+	app, err := Get(ctx, &model.Dev{Name: "migrate"}, "test", fake.NewSimpleClientset(newTestJob()))
+	require.NoError(t, err)
+	assert.IsType(t, &JobApp{}, app)
+	assert.Equal(t, okteto.Job, app.Kind())
+
+	app, err = Get(ctx, &model.Dev{Name: "migrate"}, "test", fake.NewSimpleClientset(newTestCronJob()))
+	require.NoError(t, err)
+	assert.IsType(t, &CronJobApp{}, app)
+	assert.Equal(t, okteto.CronJob, app.Kind())
+}
+
+func TestGetJobCommand(t *testing.T) {
+	var tests = []struct {
+		name      string
+		app       App
+		container string
+		expected  []string
+		wantErr   bool
+	}{
+		{
+			name:     "job",
+			app:      NewJobApp(newTestJob()),
+			expected: []string{"python", "manage.py", "migrate", "--noinput"},
+		},
+		{
+			name:      "cronjob",
+			app:       NewCronJobApp(newTestCronJob()),
+			container: "migrate",
+			expected:  []string{"python", "manage.py", "migrate", "--noinput"},
+		},
+		{
+			name:      "container not found",
+			app:       NewJobApp(newTestJob()),
+			container: "other",
+			wantErr:   true,
+		},
+		{
+			name:    "not a job",
+			app:     NewDeploymentApp(&appsv1.Deployment{Spec: appsv1.DeploymentSpec{Template: newTestJobTemplate()}}),
+			wantErr: true,
+		},
+		{
+			name: "no command",
+			app: NewJobApp(&batchv1.Job{
+				Spec: batchv1.JobSpec{
+					Template: apiv1.PodTemplateSpec{
+						Spec: apiv1.PodSpec{Containers: []apiv1.Container{{Name: "migrate"}}},
+					},
+				},
+			}),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command, err := GetJobCommand(tt.app, tt.container)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, command)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"k8s.io/client-go/kubernetes"
+)
+
+// defaultRepairReplicas are the replicas restored when the replicas of the original workload were lost
+const defaultRepairReplicas = 1
+
+// Diagnosis is the state of the dev mode translation of an app in the cluster
+type Diagnosis struct {
+	App      App
+	DevClone App
+	// Issues are the inconsistencies of a translation that didn't complete, empty if the translation is consistent
+	Issues []string
+}
+
+// IsHalfTranslated returns if the translation of the app didn't complete or was partially reverted
+func (d *Diagnosis) IsHalfTranslated() bool {
+	return len(d.Issues) > 0
+}
+
+// Diagnose checks that the dev mode labels, annotations and replicas of app and its dev clone are consistent
+func Diagnose(ctx context.Context, dev *model.Dev, app App, c kubernetes.Interface) (*Diagnosis, error) {
+	d := &Diagnosis{App: app}
+	devClone, err := app.GetDevClone(ctx, c)
+	if err != nil && !oktetoErrors.IsNotFound(err) {
+		return nil, err
+	}
+	if err == nil {
+		d.DevClone = devClone
+	}
+
+	tr := &Translation{MainDev: dev, Dev: dev, App: app}
+	kind := app.Kind()
+	name := app.ObjectMeta().Name
+	labeled := app.ObjectMeta().Labels[constants.DevLabel] == "true"
+	_, hasReplicas := app.ObjectMeta().Annotations[model.AppReplicasAnnotation]
+	autocreated := app.ObjectMeta().Annotations[model.OktetoAutoCreateAnnotation] == model.OktetoUpCmd
+
+	if !autocreated {
+		if labeled && !hasReplicas {
+			d.Issues = append(d.Issues, fmt.Sprintf("%s '%s' is in dev mode but its original replicas were lost", kind, name))
+		}
+		if hasReplicas && !labeled {
+			d.Issues = append(d.Issues, fmt.Sprintf("%s '%s' has dev mode annotations but it isn't in dev mode", kind, name))
+		}
+		if labeled && d.DevClone == nil {
+			d.Issues = append(d.Issues, fmt.Sprintf("%s '%s' is in dev mode but its development container doesn't exist", kind, name))
+		}
+		if labeled && tr.scalesDownApp() && app.Replicas() > 0 {
+			d.Issues = append(d.Issues, fmt.Sprintf("%s '%s' is in dev mode but it has %d replicas instead of 0", kind, name, app.Replicas()))
+		}
+		if !labeled && d.DevClone != nil && tr.ModifiesApp() {
+			d.Issues = append(d.Issues, fmt.Sprintf("the development container of %s '%s' exists but '%s' isn't in dev mode", kind, name, name))
+		}
+	}
+	if d.DevClone != nil && d.DevClone.Replicas() == 0 {
+		d.Issues = append(d.Issues, fmt.Sprintf("the development container of %s '%s' has 0 replicas", kind, name))
+	}
+	return d, nil
+}
+
+// PrepareRestore fixes the annotations needed to restore the original state of a half-translated app
+func (d *Diagnosis) PrepareRestore() {
+	meta := d.App.ObjectMeta()
+	if meta.Labels[constants.DevLabel] != "true" {
+		return
+	}
+	if _, ok := meta.Annotations[model.AppReplicasAnnotation]; ok {
+		return
+	}
+	if getPreviousAppReplicas(d.App) > 0 {
+		return
+	}
+	oktetoLog.Infof("the original replicas of '%s' were lost, restoring %d replicas", meta.Name, defaultRepairReplicas)
+	meta.Annotations[model.AppReplicasAnnotation] = strconv.Itoa(defaultRepairReplicas)
+}
@@ -0,0 +1,126 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
+)
+
+func newRepairDeployment(name string, replicas int32, labels, annotations map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "test",
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: appsv1.DeploymentSpec{Replicas: pointer.Int32(replicas)},
+	}
+}
+
+func TestDiagnose(t *testing.T) {
+	devModeLabels := map[string]string{constants.DevLabel: "true"}
+	devModeAnnotations := map[string]string{model.AppReplicasAnnotation: "2"}
+	var tests = []struct {
+		name             string
+		originalWorkload string
+		app              *appsv1.Deployment
+		devClone         *appsv1.Deployment
+		expectedIssues   int
+	}{
+		{
+			name: "not in dev mode",
+			app:  newRepairDeployment("api", 2, nil, nil),
+		},
+		{
+			name:     "in dev mode",
+			app:      newRepairDeployment("api", 0, devModeLabels, devModeAnnotations),
+			devClone: newRepairDeployment("api-okteto", 1, nil, nil),
+		},
+		{
+			name:           "dev clone missing",
+			app:            newRepairDeployment("api", 0, devModeLabels, devModeAnnotations),
+			expectedIssues: 1,
+		},
+		{
+			name:           "replicas mismatch",
+			app:            newRepairDeployment("api", 2, devModeLabels, devModeAnnotations),
+			devClone:       newRepairDeployment("api-okteto", 1, nil, nil),
+			expectedIssues: 1,
+		},
+		{
+			name:           "original replicas lost",
+			app:            newRepairDeployment("api", 0, devModeLabels, nil),
+			devClone:       newRepairDeployment("api-okteto", 1, nil, nil),
+			expectedIssues: 1,
+		},
+		{
+			name:           "app redeployed while in dev mode",
+			app:            newRepairDeployment("api", 2, nil, nil),
+			devClone:       newRepairDeployment("api-okteto", 1, nil, nil),
+			expectedIssues: 1,
+		},
+		{
+			name:             "untouched original workload",
+			originalWorkload: model.OriginalWorkloadUntouched,
+			app:              newRepairDeployment("api", 2, nil, nil),
+			devClone:         newRepairDeployment("api-okteto", 1, nil, nil),
+		},
+		{
+			name:           "dev clone scaled down",
+			app:            newRepairDeployment("api", 0, devModeLabels, devModeAnnotations),
+			devClone:       newRepairDeployment("api-okteto", 0, nil, nil),
+			expectedIssues: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects := []runtime.Object{tt.app}
+			if tt.devClone != nil {
+				objects = append(objects, tt.devClone)
+			}
+			c := fake.NewSimpleClientset(objects...)
+			dev := &model.Dev{Name: "api", Namespace: "test", OriginalWorkload: tt.originalWorkload}
+
+			d, err := Diagnose(context.Background(), dev, NewDeploymentApp(tt.app), c)
+			require.NoError(t, err)
+			assert.Len(t, d.Issues, tt.expectedIssues, d.Issues)
+			assert.Equal(t, tt.expectedIssues > 0, d.IsHalfTranslated())
+			assert.Equal(t, tt.devClone != nil, d.DevClone != nil)
+		})
+	}
+}
+
+func TestDiagnosisPrepareRestore(t *testing.T) {
+	app := NewDeploymentApp(newRepairDeployment("api", 0, map[string]string{constants.DevLabel: "true"}, nil))
+	d := &Diagnosis{App: app}
+	d.PrepareRestore()
+	assert.Equal(t, "1", app.ObjectMeta().Annotations[model.AppReplicasAnnotation])
+
+	app = NewDeploymentApp(newRepairDeployment("api", 0, map[string]string{constants.DevLabel: "true"}, map[string]string{model.AppReplicasAnnotation: "3"}))
+	d = &Diagnosis{App: app}
+	d.PrepareRestore()
+	assert.Equal(t, "3", app.ObjectMeta().Annotations[model.AppReplicasAnnotation])
+}
@@ -33,8 +33,9 @@ import (
 )
 
 type StatefulSetApp struct {
-	kind string
-	sfs  *appsv1.StatefulSet
+	kind       string
+	sfs        *appsv1.StatefulSet
+	extensions *podTemplateExtensions
 }
 
 func NewStatefulSetApp(sfs *appsv1.StatefulSet) *StatefulSetApp {
@@ -94,7 +95,7 @@ func (i *StatefulSetApp) DevClone() App {
 	for k, v := range i.sfs.Annotations {
 		clone.Annotations[k] = v
 	}
-	return NewStatefulSetApp(clone)
+	return &StatefulSetApp{kind: okteto.StatefulSet, sfs: clone, extensions: i.extensions}
 }
 
 func (i *StatefulSetApp) CheckConditionErrors(dev *model.Dev) error {
@@ -178,6 +179,16 @@ func (i *StatefulSetApp) Watch(ctx context.Context, result chan error, c kuberne
 }
 
 func (i *StatefulSetApp) Deploy(ctx context.Context, c kubernetes.Interface) error {
+	if !i.extensions.isEmpty() {
+		i.sfs.ResourceVersion = ""
+		sfs := &appsv1.StatefulSet{}
+		err := i.extensions.deploy(ctx, statefulsetsResource, okteto.StatefulSet, i.sfs.Name, i.sfs.Namespace, i.sfs, sfs, c)
+		if err == nil {
+			i.sfs = sfs
+		}
+		return err
+	}
+
 	sfs, err := statefulsets.Deploy(ctx, i.sfs, c)
 	if err == nil {
 		i.sfs = sfs
@@ -198,7 +209,9 @@ func (i *StatefulSetApp) GetDevClone(ctx context.Context, c kubernetes.Interface
 	clonedName := model.DevCloneName(i.sfs.Name)
 	sfs, err := statefulsets.Get(ctx, clonedName, i.sfs.Namespace, c)
 	if err == nil {
-		return NewStatefulSetApp(sfs), nil
+		app := NewStatefulSetApp(sfs)
+		app.extensions = getPodTemplateExtensions(ctx, statefulsetsResource, sfs.Name, sfs.Namespace, c)
+		return app, nil
 	}
 	return nil, err
 }
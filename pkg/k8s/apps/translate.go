@@ -16,6 +16,7 @@ package apps
 import (
 	"fmt"
 	"path"
+	"reflect"
 	"strconv"
 	"strings"
 
@@ -23,6 +24,7 @@ import (
 	"github.com/okteto/okteto/pkg/model"
 
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
 	resource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -40,6 +42,8 @@ const (
 	oktetoSyncSecretVolume = "okteto-sync-secret" // skipcq GSC-G101  not a secret
 	oktetoDevSecretVolume  = "okteto-dev-secret"  // skipcq GSC-G101  not a secret
 	oktetoSecretTemplate   = "okteto-%s"
+
+	oktetoGPUDriverVolumeTemplate = "okteto-gpu-driver-%d"
 )
 
 // Translation represents the information for translating an application
@@ -63,6 +67,16 @@ func (tr *Translation) getDevName() string {
 	return tr.Dev.Name
 }
 
+// scalesDownApp returns if the original workload is scaled to zero replicas while the development container is active
+func (tr *Translation) scalesDownApp() bool {
+	return tr.Dev.OriginalWorkload == "" || tr.Dev.OriginalWorkload == model.OriginalWorkloadScaleDown
+}
+
+// ModifiesApp returns if the original workload has to be updated to activate the development container
+func (tr *Translation) ModifiesApp() bool {
+	return tr.Dev.OriginalWorkload != model.OriginalWorkloadUntouched
+}
+
 func (tr *Translation) translate() error {
 	if err := tr.DevModeOff(); err != nil {
 		oktetoLog.Infof("failed to translate dev mode off: %s", err)
@@ -72,15 +86,21 @@ func (tr *Translation) translate() error {
 
 	tr.DevApp = tr.App.DevClone()
 
-	tr.App.ObjectMeta().Annotations[model.AppReplicasAnnotation] = strconv.Itoa(int(replicas))
-	tr.App.ObjectMeta().Labels[constants.DevLabel] = "true"
-	tr.App.ObjectMeta().Annotations[constants.OktetoDevModeAnnotation] = tr.Dev.Mode
+	if tr.ModifiesApp() {
+		tr.App.ObjectMeta().Annotations[model.AppReplicasAnnotation] = strconv.Itoa(int(replicas))
+		tr.App.ObjectMeta().Labels[constants.DevLabel] = "true"
+		tr.App.ObjectMeta().Annotations[constants.OktetoDevModeAnnotation] = tr.Dev.Mode
+	}
 	tr.DevApp.ObjectMeta().Annotations[constants.OktetoDevModeAnnotation] = tr.Dev.Mode
-	tr.App.SetReplicas(0)
+	if tr.scalesDownApp() {
+		tr.App.SetReplicas(0)
+	}
 
 	for k, v := range tr.Dev.Metadata.Annotations {
-		tr.App.ObjectMeta().Annotations[k] = v
-		tr.App.TemplateObjectMeta().Annotations[k] = v
+		if tr.ModifiesApp() {
+			tr.App.ObjectMeta().Annotations[k] = v
+			tr.App.TemplateObjectMeta().Annotations[k] = v
+		}
 		tr.DevApp.ObjectMeta().Annotations[k] = v
 		tr.DevApp.TemplateObjectMeta().Annotations[k] = v
 	}
@@ -91,6 +111,17 @@ func (tr *Translation) translate() error {
 
 	TranslateDevTolerations(tr.DevApp.PodSpec(), tr.Dev.Tolerations)
 
+	switch tr.App.(type) {
+	case *StatefulSetApp:
+		if tr.scalesDownApp() {
+			translateVolumeClaimTemplates(tr.DevApp.(*StatefulSetApp).sfs, tr.App.ObjectMeta().Name)
+		}
+	case *DaemonSetApp:
+		if !tr.scalesDownApp() {
+			translateHostMounts(tr.DevApp.PodSpec())
+		}
+	}
+
 	if tr.MainDev == tr.Dev {
 		tr.DevApp.SetReplicas(1)
 		tr.DevApp.TemplateObjectMeta().Labels[model.InteractiveDevLabel] = tr.getDevName()
@@ -111,6 +142,7 @@ func (tr *Translation) translate() error {
 		TranslateDevContainer(devContainer, rule)
 		TranslatePodSpec(tr.DevApp.PodSpec(), rule)
 		TranslateOktetoDevSecret(tr.DevApp.PodSpec(), tr.Dev.Name, rule.Secrets)
+		TranslateOktetoDevSecretEnvVars(devContainer, tr.Dev.Name, rule.SecretEnvVars)
 
 		if rule.IsMainDevContainer() {
 			TranslateOktetoBinVolumeMounts(devContainer)
@@ -185,6 +217,58 @@ func TranslatePodAffinity(spec *apiv1.PodSpec, name string) {
 	)
 }
 
+// translateVolumeClaimTemplates mounts the persistent volume claims of the first replica of a statefulset in its dev clone.
+// The original statefulset is scaled down, so the development container keeps the data and the ordinal of the first replica
+func translateVolumeClaimTemplates(clone *appsv1.StatefulSet, originalName string) {
+	for _, template := range clone.Spec.VolumeClaimTemplates {
+		found := false
+		for _, v := range clone.Spec.Template.Spec.Volumes {
+			if v.Name == template.Name {
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		clone.Spec.Template.Spec.Volumes = append(clone.Spec.Template.Spec.Volumes, apiv1.Volume{
+			Name: template.Name,
+			VolumeSource: apiv1.VolumeSource{
+				PersistentVolumeClaim: &apiv1.PersistentVolumeClaimVolumeSource{
+					ClaimName: fmt.Sprintf("%s-%s-0", template.Name, originalName),
+				},
+			},
+		})
+	}
+	clone.Spec.VolumeClaimTemplates = nil
+}
+
+// translateHostMounts makes the host path volumes of a daemonset read-only in its dev clone when the daemonset keeps running,
+// so the development container doesn't write the files of the daemonset pod running in the same node.
+// Host ports are removed because they are already used by the daemonset pod
+func translateHostMounts(spec *apiv1.PodSpec) {
+	hostVolumes := map[string]bool{}
+	for _, v := range spec.Volumes {
+		if v.HostPath != nil {
+			hostVolumes[v.Name] = true
+		}
+	}
+	translateContainers := func(containers []apiv1.Container) {
+		for i := range containers {
+			for j := range containers[i].VolumeMounts {
+				if hostVolumes[containers[i].VolumeMounts[j].Name] {
+					containers[i].VolumeMounts[j].ReadOnly = true
+				}
+			}
+			for j := range containers[i].Ports {
+				containers[i].Ports[j].HostPort = 0
+			}
+		}
+	}
+	translateContainers(spec.InitContainers)
+	translateContainers(spec.Containers)
+}
+
 // TranslateDevContainer translates a dev container
 func TranslateDevContainer(c *apiv1.Container, rule *model.TranslationRule) {
 	c.Image = rule.Image
@@ -205,6 +289,7 @@ func TranslateDevContainer(c *apiv1.Container, rule *model.TranslationRule) {
 	TranslateResources(c, rule.Resources)
 	TranslateEnvVars(c, rule)
 	TranslateVolumeMounts(c, rule)
+	TranslateGPUDriverMounts(c, rule.GPU)
 	TranslateContainerSecurityContext(c, rule.SecurityContext)
 }
 
@@ -214,6 +299,8 @@ func TranslatePodSpec(podSpec *apiv1.PodSpec, rule *model.TranslationRule) {
 	TranslatePodServiceAccount(podSpec, rule.ServiceAccount)
 
 	TranslateOktetoNodeSelector(podSpec, rule.NodeSelector)
+	TranslateRuntimeClassName(podSpec, rule.RuntimeClassName)
+	TranslateGPUDriverVolumes(podSpec, rule.GPU)
 	TranslateOktetoAffinity(podSpec, rule.Affinity)
 }
 
@@ -238,14 +325,25 @@ func TranslateLifecycle(c *apiv1.Container, l *model.Lifecycle) {
 	if l == nil {
 		return
 	}
-	if c.Lifecycle == nil {
+	if c.Lifecycle != nil {
+		if !l.PostStart {
+			c.Lifecycle.PostStart = nil
+		}
+		if !l.PostStop {
+			c.Lifecycle.PreStop = nil
+		}
+	}
+	if l.PostStartCommand == nil && l.PreStop == nil {
 		return
 	}
-	if !l.PostStart {
-		c.Lifecycle.PostStart = nil
+	if c.Lifecycle == nil {
+		c.Lifecycle = &apiv1.Lifecycle{}
+	}
+	if l.PostStartCommand != nil {
+		c.Lifecycle.PostStart = &apiv1.LifecycleHandler{Exec: &apiv1.ExecAction{Command: l.PostStartCommand.Values}}
 	}
-	if !l.PostStart {
-		c.Lifecycle.PostStart = nil
+	if l.PreStop != nil {
+		c.Lifecycle.PreStop = &apiv1.LifecycleHandler{Exec: &apiv1.ExecAction{Command: l.PreStop.Values}}
 	}
 }
 
@@ -596,6 +694,32 @@ func TranslateOktetoSyncSecret(spec *apiv1.PodSpec, name string) {
 	spec.Volumes = append(spec.Volumes, v)
 }
 
+// TranslateOktetoDevSecretEnvVars injects the secrets from the Okteto platform as environment variables of the dev container
+func TranslateOktetoDevSecretEnvVars(c *apiv1.Container, secret string, secrets []model.Secret) {
+	for _, s := range secrets {
+		envVar := apiv1.EnvVar{
+			Name: s.FromPlatform,
+			ValueFrom: &apiv1.EnvVarSource{
+				SecretKeyRef: &apiv1.SecretKeySelector{
+					LocalObjectReference: apiv1.LocalObjectReference{Name: fmt.Sprintf(oktetoSecretTemplate, secret)},
+					Key:                  s.GetKeyName(),
+				},
+			},
+		}
+		found := false
+		for i := range c.Env {
+			if c.Env[i].Name == envVar.Name {
+				c.Env[i] = envVar
+				found = true
+				break
+			}
+		}
+		if !found {
+			c.Env = append(c.Env, envVar)
+		}
+	}
+}
+
 // TranslateOktetoDevSecret translates the devs secret of a pod
 func TranslateOktetoDevSecret(spec *apiv1.PodSpec, secret string, secrets []model.Secret) {
 	if len(secrets) == 0 {
@@ -644,11 +768,113 @@ func TranslateOktetoNodeSelector(spec *apiv1.PodSpec, nodeSelector map[string]st
 	spec.NodeSelector = nodeSelector
 }
 
+// TranslateRuntimeClassName sets the runtime class of the development container, so it can run in nodes with a specific container runtime
+func TranslateRuntimeClassName(spec *apiv1.PodSpec, runtimeClassName string) {
+	if runtimeClassName != "" {
+		spec.RuntimeClassName = &runtimeClassName
+	}
+}
+
+// TranslateGPUDriverVolumes adds the host paths of the GPU drivers listed in the manifest as volumes of the development container
+func TranslateGPUDriverVolumes(spec *apiv1.PodSpec, gpu *model.GPU) {
+	if gpu == nil {
+		return
+	}
+	hostPathDirectory := apiv1.HostPathDirectory
+	for i, p := range gpu.DriverPaths {
+		name := fmt.Sprintf(oktetoGPUDriverVolumeTemplate, i)
+		found := false
+		for _, v := range spec.Volumes {
+			if v.Name == name {
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		spec.Volumes = append(spec.Volumes, apiv1.Volume{
+			Name: name,
+			VolumeSource: apiv1.VolumeSource{
+				// the pod fails to start instead of creating an empty directory if the drivers are not in the node
+				HostPath: &apiv1.HostPathVolumeSource{Path: p, Type: &hostPathDirectory},
+			},
+		})
+	}
+}
+
+// TranslateGPUDriverMounts mounts the GPU drivers in the development container, in the same path as in the host
+func TranslateGPUDriverMounts(c *apiv1.Container, gpu *model.GPU) {
+	if gpu == nil {
+		return
+	}
+	for i, p := range gpu.DriverPaths {
+		c.VolumeMounts = append(c.VolumeMounts, apiv1.VolumeMount{
+			Name:      fmt.Sprintf(oktetoGPUDriverVolumeTemplate, i),
+			MountPath: p,
+			ReadOnly:  true,
+		})
+	}
+}
+
+// TranslateOktetoAffinity sets the affinity of the development container.
+// The required node affinity of the original workload is kept, so the pod is scheduled in the same kind of nodes
 func TranslateOktetoAffinity(spec *apiv1.PodSpec, affinity *apiv1.Affinity) {
-	if affinity != nil {
-		if affinity.NodeAffinity == nil && affinity.PodAffinity == nil && affinity.PodAntiAffinity == nil {
-			return
+	if affinity == nil {
+		return
+	}
+	if affinity.NodeAffinity == nil && affinity.PodAffinity == nil && affinity.PodAntiAffinity == nil {
+		return
+	}
+
+	var required *apiv1.NodeSelector
+	if spec.Affinity != nil && spec.Affinity.NodeAffinity != nil {
+		required = spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	}
+	spec.Affinity = affinity.DeepCopy()
+	if required == nil {
+		return
+	}
+	if spec.Affinity.NodeAffinity == nil {
+		spec.Affinity.NodeAffinity = &apiv1.NodeAffinity{}
+	}
+	spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = mergeNodeSelectors(required, spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution)
+}
+
+// mergeNodeSelectors returns a node selector that matches the nodes matched by both node selectors.
+// The terms of a node selector are ORed, so the result has a term for every pair of terms
+func mergeNodeSelectors(a, b *apiv1.NodeSelector) *apiv1.NodeSelector {
+	if b == nil || len(b.NodeSelectorTerms) == 0 {
+		return a
+	}
+	if len(a.NodeSelectorTerms) == 0 {
+		return b
+	}
+	result := &apiv1.NodeSelector{NodeSelectorTerms: []apiv1.NodeSelectorTerm{}}
+	for _, termA := range a.NodeSelectorTerms {
+		for _, termB := range b.NodeSelectorTerms {
+			term := *termA.DeepCopy()
+			for _, expr := range termB.MatchExpressions {
+				if !containsNodeSelectorRequirement(term.MatchExpressions, expr) {
+					term.MatchExpressions = append(term.MatchExpressions, expr)
+				}
+			}
+			for _, field := range termB.MatchFields {
+				if !containsNodeSelectorRequirement(term.MatchFields, field) {
+					term.MatchFields = append(term.MatchFields, field)
+				}
+			}
+			result.NodeSelectorTerms = append(result.NodeSelectorTerms, term)
+		}
+	}
+	return result
+}
+
+func containsNodeSelectorRequirement(requirements []apiv1.NodeSelectorRequirement, r apiv1.NodeSelectorRequirement) bool {
+	for _, req := range requirements {
+		if reflect.DeepEqual(req, r) {
+			return true
 		}
-		spec.Affinity = affinity
 	}
+	return false
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/k8s/daemonsets"
 	"github.com/okteto/okteto/pkg/k8s/deployments"
 	"github.com/okteto/okteto/pkg/k8s/statefulsets"
 	"github.com/okteto/okteto/pkg/model"
@@ -2149,3 +2150,442 @@ func Test_getDevName(t *testing.T) {
 		})
 	}
 }
+
+func Test_translateOriginalWorkload(t *testing.T) {
+	var tests = []struct {
+		name             string
+		originalWorkload string
+		expectedReplicas int32
+		expectedDevLabel bool
+		expectedModifies bool
+	}{
+		{name: "default", expectedReplicas: 0, expectedDevLabel: true, expectedModifies: true},
+		{name: "scaleDown", originalWorkload: model.OriginalWorkloadScaleDown, expectedReplicas: 0, expectedDevLabel: true, expectedModifies: true},
+		{name: "clone", originalWorkload: model.OriginalWorkloadClone, expectedReplicas: 3, expectedDevLabel: true, expectedModifies: true},
+		{name: "untouched", originalWorkload: model.OriginalWorkloadUntouched, expectedReplicas: 3, expectedModifies: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev := &model.Dev{
+				Name:             "web",
+				Namespace:        "n",
+				Image:            &model.BuildInfo{Name: "web:latest"},
+				OriginalWorkload: tt.originalWorkload,
+				Metadata:         &model.Metadata{Annotations: model.Annotations{"key": "value"}},
+			}
+			d := deployments.Sandbox(dev)
+			d.Spec.Replicas = pointer.Int32Ptr(3)
+			delete(d.Annotations, model.OktetoAutoCreateAnnotation)
+
+			trMap, err := GetTranslations(context.Background(), dev, NewDeploymentApp(d), false, fake.NewSimpleClientset())
+			require.NoError(t, err)
+			tr := trMap[dev.Name]
+			require.NoError(t, tr.translate())
+
+			assert.Equal(t, tt.expectedReplicas, tr.App.Replicas())
+			assert.Equal(t, int32(1), tr.DevApp.Replicas())
+			assert.Equal(t, tt.expectedModifies, tr.ModifiesApp())
+			_, ok := tr.App.ObjectMeta().Labels[constants.DevLabel]
+			assert.Equal(t, tt.expectedDevLabel, ok)
+			_, ok = tr.App.ObjectMeta().Annotations["key"]
+			assert.Equal(t, tt.expectedModifies, ok)
+			assert.Equal(t, "value", tr.DevApp.ObjectMeta().Annotations["key"])
+		})
+	}
+}
+
+func Test_translateKeepsSchedulingConstraints(t *testing.T) {
+	dev := &model.Dev{
+		Name:      "web",
+		Namespace: "n",
+		Image:     &model.BuildInfo{Name: "web:latest"},
+		Metadata:  &model.Metadata{},
+		Affinity: &model.Affinity{
+			NodeAffinity: &apiv1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &apiv1.NodeSelector{
+					NodeSelectorTerms: []apiv1.NodeSelectorTerm{
+						{MatchExpressions: []apiv1.NodeSelectorRequirement{{Key: "gpu", Operator: apiv1.NodeSelectorOpExists}}},
+					},
+				},
+			},
+		},
+	}
+	d := deployments.Sandbox(dev)
+	delete(d.Annotations, model.OktetoAutoCreateAnnotation)
+	zoneTerm := apiv1.NodeSelectorTerm{
+		MatchExpressions: []apiv1.NodeSelectorRequirement{{Key: "topology.kubernetes.io/zone", Operator: apiv1.NodeSelectorOpIn, Values: []string{"a", "b"}}},
+	}
+	d.Spec.Template.Spec.Affinity = &apiv1.Affinity{
+		NodeAffinity: &apiv1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &apiv1.NodeSelector{NodeSelectorTerms: []apiv1.NodeSelectorTerm{zoneTerm}},
+		},
+	}
+	tsc := []apiv1.TopologySpreadConstraint{
+		{MaxSkew: 1, TopologyKey: "kubernetes.io/hostname", WhenUnsatisfiable: apiv1.ScheduleAnyway, MatchLabelKeys: []string{"pod-template-hash"}},
+	}
+	d.Spec.Template.Spec.TopologySpreadConstraints = tsc
+	d.Spec.Template.Spec.InitContainers = []apiv1.Container{{Name: "proxy", Image: "envoy"}}
+
+	trMap, err := GetTranslations(context.Background(), dev, NewDeploymentApp(d), false, fake.NewSimpleClientset())
+	require.NoError(t, err)
+	tr := trMap[dev.Name]
+	require.NoError(t, tr.translate())
+
+	podSpec := tr.DevApp.PodSpec()
+	assert.Equal(t, tsc, podSpec.TopologySpreadConstraints)
+	assert.Equal(t, "proxy", podSpec.InitContainers[0].Name)
+	expectedRequired := &apiv1.NodeSelector{
+		NodeSelectorTerms: []apiv1.NodeSelectorTerm{
+			{
+				MatchExpressions: []apiv1.NodeSelectorRequirement{
+					zoneTerm.MatchExpressions[0],
+					{Key: "gpu", Operator: apiv1.NodeSelectorOpExists},
+				},
+			},
+		},
+	}
+	assert.Equal(t, expectedRequired, podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution)
+	// the affinity of the manifest is not modified
+	assert.Len(t, dev.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions, 1)
+}
+
+func Test_translateNodePoolScheduling(t *testing.T) {
+	var tests = []struct {
+		name             string
+		runtimeClassName string
+		expected         string
+	}{
+		{name: "runtime class of the manifest", runtimeClassName: "nvidia", expected: "nvidia"},
+		{name: "runtime class of the workload", expected: "kata"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev := &model.Dev{
+				Name:             "web",
+				Namespace:        "n",
+				Image:            &model.BuildInfo{Name: "web:latest"},
+				Metadata:         &model.Metadata{},
+				NodeSelector:     map[string]string{"kubernetes.io/arch": "arm64"},
+				Tolerations:      []apiv1.Toleration{{Key: "nvidia.com/gpu", Operator: apiv1.TolerationOpExists, Effect: apiv1.TaintEffectNoSchedule}},
+				RuntimeClassName: tt.runtimeClassName,
+			}
+			d := deployments.Sandbox(dev)
+			delete(d.Annotations, model.OktetoAutoCreateAnnotation)
+			d.Spec.Template.Spec.RuntimeClassName = pointer.String("kata")
+
+			trMap, err := GetTranslations(context.Background(), dev, NewDeploymentApp(d), false, fake.NewSimpleClientset())
+			require.NoError(t, err)
+			tr := trMap[dev.Name]
+			require.NoError(t, tr.translate())
+
+			podSpec := tr.DevApp.PodSpec()
+			assert.Equal(t, dev.NodeSelector, podSpec.NodeSelector)
+			assert.Equal(t, dev.Tolerations, podSpec.Tolerations)
+			require.NotNil(t, podSpec.RuntimeClassName)
+			assert.Equal(t, tt.expected, *podSpec.RuntimeClassName)
+		})
+	}
+}
+
+func Test_translateGPU(t *testing.T) {
+	dev := &model.Dev{
+		Name:      "web",
+		Namespace: "n",
+		Image:     &model.BuildInfo{Name: "web:latest"},
+		Metadata:  &model.Metadata{},
+		GPU:       &model.GPU{Count: 1, DriverPaths: []string{"/usr/local/nvidia"}},
+	}
+	require.NoError(t, dev.SetDefaults())
+	d := deployments.Sandbox(dev)
+	delete(d.Annotations, model.OktetoAutoCreateAnnotation)
+
+	trMap, err := GetTranslations(context.Background(), dev, NewDeploymentApp(d), false, fake.NewSimpleClientset())
+	require.NoError(t, err)
+	tr := trMap[dev.Name]
+	require.NoError(t, tr.translate())
+
+	podSpec := tr.DevApp.PodSpec()
+	hostPathDirectory := apiv1.HostPathDirectory
+	assert.Contains(t, podSpec.Volumes, apiv1.Volume{
+		Name:         "okteto-gpu-driver-0",
+		VolumeSource: apiv1.VolumeSource{HostPath: &apiv1.HostPathVolumeSource{Path: "/usr/local/nvidia", Type: &hostPathDirectory}},
+	})
+	assert.Contains(t, podSpec.Tolerations, apiv1.Toleration{Key: "nvidia.com/gpu", Operator: apiv1.TolerationOpExists, Effect: apiv1.TaintEffectNoSchedule})
+	c := podSpec.Containers[0]
+	assert.Contains(t, c.VolumeMounts, apiv1.VolumeMount{Name: "okteto-gpu-driver-0", MountPath: "/usr/local/nvidia", ReadOnly: true})
+	gpus := c.Resources.Limits[model.NvidiaGPUResource]
+	assert.Equal(t, int64(1), gpus.Value())
+}
+
+func Test_translateGPUWithoutDriverPaths(t *testing.T) {
+	dev := &model.Dev{
+		Name:      "web",
+		Namespace: "n",
+		Image:     &model.BuildInfo{Name: "web:latest"},
+		Metadata:  &model.Metadata{},
+		GPU:       &model.GPU{Count: 1},
+	}
+	require.NoError(t, dev.SetDefaults())
+	d := deployments.Sandbox(dev)
+	delete(d.Annotations, model.OktetoAutoCreateAnnotation)
+
+	trMap, err := GetTranslations(context.Background(), dev, NewDeploymentApp(d), false, fake.NewSimpleClientset())
+	require.NoError(t, err)
+	tr := trMap[dev.Name]
+	require.NoError(t, tr.translate())
+
+	// the drivers injected by the device plugin are not shadowed by host paths
+	for _, v := range tr.DevApp.PodSpec().Volumes {
+		assert.Nil(t, v.HostPath)
+	}
+}
+
+func Test_translateVolumeClaimTemplates(t *testing.T) {
+	var tests = []struct {
+		name             string
+		originalWorkload string
+		expectTemplates  bool
+	}{
+		{name: "scale down", originalWorkload: model.OriginalWorkloadScaleDown},
+		{name: "untouched", originalWorkload: model.OriginalWorkloadUntouched, expectTemplates: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev := &model.Dev{
+				Name:             "db",
+				Namespace:        "n",
+				Image:            &model.BuildInfo{Name: "db:latest"},
+				Metadata:         &model.Metadata{},
+				OriginalWorkload: tt.originalWorkload,
+			}
+			sfs := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "n"},
+				Spec: appsv1.StatefulSetSpec{
+					Replicas: pointer.Int32Ptr(3),
+					Template: apiv1.PodTemplateSpec{
+						Spec: apiv1.PodSpec{
+							Containers: []apiv1.Container{
+								{Name: "db", VolumeMounts: []apiv1.VolumeMount{{Name: "data", MountPath: "/var/lib/data"}}},
+							},
+						},
+					},
+					VolumeClaimTemplates: []apiv1.PersistentVolumeClaim{
+						{ObjectMeta: metav1.ObjectMeta{Name: "data"}},
+					},
+				},
+			}
+
+			trMap, err := GetTranslations(context.Background(), dev, NewStatefulSetApp(sfs), false, fake.NewSimpleClientset())
+			require.NoError(t, err)
+			tr := trMap[dev.Name]
+			require.NoError(t, tr.translate())
+
+			clone := tr.DevApp.(*StatefulSetApp).sfs
+			if tt.expectTemplates {
+				assert.Len(t, clone.Spec.VolumeClaimTemplates, 1)
+				return
+			}
+			assert.Empty(t, clone.Spec.VolumeClaimTemplates)
+			assert.Contains(t, clone.Spec.Template.Spec.Volumes, apiv1.Volume{
+				Name: "data",
+				VolumeSource: apiv1.VolumeSource{
+					PersistentVolumeClaim: &apiv1.PersistentVolumeClaimVolumeSource{ClaimName: "data-db-0"},
+				},
+			})
+			// the original statefulset keeps its volume claim templates
+			assert.Len(t, tr.App.(*StatefulSetApp).sfs.Spec.VolumeClaimTemplates, 1)
+		})
+	}
+}
+
+func Test_translateDaemonSet(t *testing.T) {
+	var tests = []struct {
+		name             string
+		originalWorkload string
+		expectedReadOnly bool
+		expectedHostPort int32
+	}{
+		{name: "scale down", originalWorkload: model.OriginalWorkloadScaleDown, expectedReadOnly: false, expectedHostPort: 9100},
+		{name: "untouched", originalWorkload: model.OriginalWorkloadUntouched, expectedReadOnly: true, expectedHostPort: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev := &model.Dev{
+				Name:             "agent",
+				Namespace:        "n",
+				Image:            &model.BuildInfo{Name: "agent:latest"},
+				Metadata:         &model.Metadata{},
+				OriginalWorkload: tt.originalWorkload,
+			}
+			ds := &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "n"},
+				Spec: appsv1.DaemonSetSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "agent"}},
+					Template: apiv1.PodTemplateSpec{
+						Spec: apiv1.PodSpec{
+							Containers: []apiv1.Container{
+								{
+									Name:         "agent",
+									Ports:        []apiv1.ContainerPort{{ContainerPort: 9100, HostPort: 9100}},
+									VolumeMounts: []apiv1.VolumeMount{{Name: "logs", MountPath: "/var/log"}},
+								},
+							},
+							Volumes: []apiv1.Volume{
+								{Name: "logs", VolumeSource: apiv1.VolumeSource{HostPath: &apiv1.HostPathVolumeSource{Path: "/var/log"}}},
+							},
+						},
+					},
+				},
+			}
+
+			trMap, err := GetTranslations(context.Background(), dev, NewDaemonSetApp(ds), false, fake.NewSimpleClientset())
+			require.NoError(t, err)
+			tr := trMap[dev.Name]
+			require.NoError(t, tr.translate())
+
+			assert.Equal(t, tt.originalWorkload != model.OriginalWorkloadUntouched, daemonsets.IsScaledDown(ds))
+			require.IsType(t, &DeploymentApp{}, tr.DevApp)
+			assert.Equal(t, int32(1), tr.DevApp.Replicas())
+			devContainer := tr.DevApp.PodSpec().Containers[0]
+			assert.Equal(t, tt.expectedReadOnly, devContainer.VolumeMounts[0].ReadOnly)
+			assert.Equal(t, tt.expectedHostPort, devContainer.Ports[0].HostPort)
+			assert.NotContains(t, tr.DevApp.PodSpec().NodeSelector, model.DaemonSetScaledDownNodeSelector)
+		})
+	}
+}
+
+func Test_translateCronJob(t *testing.T) {
+	dev := &model.Dev{
+		Name:      "migrate",
+		Namespace: "test",
+		Image:     &model.BuildInfo{Name: "migrate:latest"},
+		Metadata:  &model.Metadata{},
+	}
+	cj := newTestCronJob()
+
+	trMap, err := GetTranslations(context.Background(), dev, NewCronJobApp(cj), false, fake.NewSimpleClientset())
+	require.NoError(t, err)
+	tr := trMap[dev.Name]
+	require.NoError(t, tr.translate())
+
+	assert.True(t, *cj.Spec.Suspend)
+	require.IsType(t, &DeploymentApp{}, tr.DevApp)
+	assert.Equal(t, int32(1), tr.DevApp.Replicas())
+	assert.Equal(t, apiv1.RestartPolicyAlways, tr.DevApp.PodSpec().RestartPolicy)
+	assert.Equal(t, "migrate", tr.DevApp.TemplateObjectMeta().Labels[model.JobDevLabel])
+	assert.Equal(t, "migrate:latest", tr.DevApp.PodSpec().Containers[0].Image)
+}
+
+func TestMergeNodeSelectors(t *testing.T) {
+	termA := apiv1.NodeSelectorTerm{MatchExpressions: []apiv1.NodeSelectorRequirement{{Key: "a", Operator: apiv1.NodeSelectorOpExists}}}
+	termB := apiv1.NodeSelectorTerm{MatchExpressions: []apiv1.NodeSelectorRequirement{{Key: "b", Operator: apiv1.NodeSelectorOpExists}}}
+	termC := apiv1.NodeSelectorTerm{MatchFields: []apiv1.NodeSelectorRequirement{{Key: "metadata.name", Operator: apiv1.NodeSelectorOpIn, Values: []string{"node"}}}}
+
+	a := &apiv1.NodeSelector{NodeSelectorTerms: []apiv1.NodeSelectorTerm{termA, termB}}
+	assert.Equal(t, a, mergeNodeSelectors(a, nil))
+	assert.Equal(t, a, mergeNodeSelectors(&apiv1.NodeSelector{}, a))
+
+	expected := &apiv1.NodeSelector{
+		NodeSelectorTerms: []apiv1.NodeSelectorTerm{
+			{MatchExpressions: termA.MatchExpressions, MatchFields: termC.MatchFields},
+			{MatchExpressions: termB.MatchExpressions, MatchFields: termC.MatchFields},
+		},
+	}
+	merged := mergeNodeSelectors(a, &apiv1.NodeSelector{NodeSelectorTerms: []apiv1.NodeSelectorTerm{termC}})
+	assert.Equal(t, expected, merged)
+	// merging the same selector again doesn't duplicate requirements
+	assert.Equal(t, expected, mergeNodeSelectors(merged, &apiv1.NodeSelector{NodeSelectorTerms: []apiv1.NodeSelectorTerm{termC}}))
+}
+
+func TestTranslateLifecycle(t *testing.T) {
+	originalLifecycle := func() *apiv1.Lifecycle {
+		return &apiv1.Lifecycle{
+			PostStart: &apiv1.LifecycleHandler{Exec: &apiv1.ExecAction{Command: []string{"start"}}},
+			PreStop:   &apiv1.LifecycleHandler{Exec: &apiv1.ExecAction{Command: []string{"stop"}}},
+		}
+	}
+	var tests = []struct {
+		name      string
+		original  *apiv1.Lifecycle
+		lifecycle *model.Lifecycle
+		expected  *apiv1.Lifecycle
+	}{
+		{
+			name:      "original hooks removed",
+			original:  originalLifecycle(),
+			lifecycle: &model.Lifecycle{},
+			expected:  &apiv1.Lifecycle{},
+		},
+		{
+			name:      "original hooks kept by default",
+			original:  originalLifecycle(),
+			lifecycle: &model.Lifecycle{PostStart: true, PostStop: true},
+			expected:  originalLifecycle(),
+		},
+		{
+			name:     "only the overridden hook is replaced",
+			original: originalLifecycle(),
+			lifecycle: &model.Lifecycle{
+				PostStart: true,
+				PreStop:   &model.Command{Values: []string{"./cleanup.sh"}},
+			},
+			expected: &apiv1.Lifecycle{
+				PostStart: &apiv1.LifecycleHandler{Exec: &apiv1.ExecAction{Command: []string{"start"}}},
+				PreStop:   &apiv1.LifecycleHandler{Exec: &apiv1.ExecAction{Command: []string{"./cleanup.sh"}}},
+			},
+		},
+		{
+			name:     "dev hooks",
+			original: originalLifecycle(),
+			lifecycle: &model.Lifecycle{
+				PostStartCommand: &model.Command{Values: []string{"sh", "-c", "yarn install"}},
+				PreStop:          &model.Command{Values: []string{"./cleanup.sh"}},
+			},
+			expected: &apiv1.Lifecycle{
+				PostStart: &apiv1.LifecycleHandler{Exec: &apiv1.ExecAction{Command: []string{"sh", "-c", "yarn install"}}},
+				PreStop:   &apiv1.LifecycleHandler{Exec: &apiv1.ExecAction{Command: []string{"./cleanup.sh"}}},
+			},
+		},
+		{
+			name: "dev hooks without original hooks",
+			lifecycle: &model.Lifecycle{
+				PreStop: &model.Command{Values: []string{"./cleanup.sh"}},
+			},
+			expected: &apiv1.Lifecycle{
+				PreStop: &apiv1.LifecycleHandler{Exec: &apiv1.ExecAction{Command: []string{"./cleanup.sh"}}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &apiv1.Container{Lifecycle: tt.original}
+			TranslateLifecycle(c, tt.lifecycle)
+			assert.Equal(t, tt.expected, c.Lifecycle)
+		})
+	}
+}
+
+func TestTranslateOktetoDevSecretEnvVars(t *testing.T) {
+	c := &apiv1.Container{
+		Env: []apiv1.EnvVar{
+			{Name: "GITHUB_TOKEN", Value: "old"},
+			{Name: "A", Value: "B"},
+		},
+	}
+	TranslateOktetoDevSecretEnvVars(c, "web", []model.Secret{{FromPlatform: "GITHUB_TOKEN"}, {FromPlatform: "NPM_TOKEN"}})
+
+	secretRef := func(key string) *apiv1.EnvVarSource {
+		return &apiv1.EnvVarSource{
+			SecretKeyRef: &apiv1.SecretKeySelector{
+				LocalObjectReference: apiv1.LocalObjectReference{Name: "okteto-web"},
+				Key:                  key,
+			},
+		}
+	}
+	expected := []apiv1.EnvVar{
+		{Name: "GITHUB_TOKEN", ValueFrom: secretRef("dev-secret-env-GITHUB_TOKEN")},
+		{Name: "A", Value: "B"},
+		{Name: "NPM_TOKEN", ValueFrom: secretRef("dev-secret-env-NPM_TOKEN")},
+	}
+	assert.Equal(t, expected, c.Env)
+}
@@ -14,12 +14,17 @@
 package apps
 
 import (
+	"context"
 	"encoding/json"
 	"strconv"
 
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 )
 
 type stateBeforeSleeping struct {
@@ -62,3 +67,23 @@ func GetDevContainer(spec *apiv1.PodSpec, containerName string) *apiv1.Container
 
 	return nil
 }
+
+// getPodByOwner returns a running pod owned by uid
+func getPodByOwner(ctx context.Context, uid types.UID, namespace string, c kubernetes.Interface) (*apiv1.Pod, error) {
+	podList, err := c.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.DeletionTimestamp != nil || pod.Status.Phase != apiv1.PodRunning {
+			continue
+		}
+		for _, or := range pod.OwnerReferences {
+			if or.UID == uid {
+				return pod, nil
+			}
+		}
+	}
+	return nil, oktetoErrors.ErrNotFound
+}
@@ -0,0 +1,195 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// DefaultNamespace is the namespace where Argo CD is installed by default
+	DefaultNamespace = "argocd"
+
+	// DefaultProject is the Argo CD project of the applications by default
+	DefaultProject = "default"
+
+	// PreviewLabel is the label of the applications with the name of the preview environment they deploy
+	PreviewLabel = "dev.okteto.com/preview"
+
+	// SyncedStatus is the sync status of an application whose resources match the target revision
+	SyncedStatus = "Synced"
+
+	// HealthyStatus is the health status of an application whose resources are healthy
+	HealthyStatus = "Healthy"
+
+	// DegradedStatus is the health status of an application with failed resources
+	DegradedStatus = "Degraded"
+
+	inClusterServer = "https://kubernetes.default.svc"
+)
+
+var (
+	applicationResource = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "applications"}
+
+	// pollInterval is the interval between checks of the status of an application
+	pollInterval = 5 * time.Second
+)
+
+// ApplicationOptions defines the Argo CD application of a preview environment
+type ApplicationOptions struct {
+	Name       string
+	Namespace  string
+	Project    string
+	Repository string
+	Branch     string
+	Path       string
+	// Destination is the namespace where the resources of the application are deployed
+	Destination string
+}
+
+// ApplicationStatus is the status of an Argo CD application
+type ApplicationStatus struct {
+	Sync     string
+	Health   string
+	Revision string
+	Message  string
+	// URLs are the external URLs of the resources of the application, like the hosts of its ingresses
+	URLs []string
+}
+
+// Translate returns the Argo CD application that syncs the branch of a repository to the destination namespace
+func Translate(opts ApplicationOptions) *unstructured.Unstructured {
+	path := opts.Path
+	if path == "" {
+		path = "."
+	}
+	project := opts.Project
+	if project == "" {
+		project = DefaultProject
+	}
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": applicationResource.GroupVersion().String(),
+			"kind":       "Application",
+			"metadata": map[string]interface{}{
+				"name":      opts.Name,
+				"namespace": opts.Namespace,
+				"labels": map[string]interface{}{
+					PreviewLabel: opts.Destination,
+				},
+			},
+			"spec": map[string]interface{}{
+				"project": project,
+				"source": map[string]interface{}{
+					"repoURL":        opts.Repository,
+					"targetRevision": opts.Branch,
+					"path":           path,
+				},
+				"destination": map[string]interface{}{
+					"server":    inClusterServer,
+					"namespace": opts.Destination,
+				},
+				"syncPolicy": map[string]interface{}{
+					"automated": map[string]interface{}{
+						"prune":    true,
+						"selfHeal": true,
+					},
+					"syncOptions": []interface{}{"CreateNamespace=true"},
+				},
+			},
+		},
+	}
+}
+
+// Deploy creates or updates an Argo CD application
+func Deploy(ctx context.Context, app *unstructured.Unstructured, c dynamic.Interface) error {
+	client := c.Resource(applicationResource).Namespace(app.GetNamespace())
+	old, err := client.Get(ctx, app.GetName(), metav1.GetOptions{})
+	if err != nil {
+		if !k8sErrors.IsNotFound(err) {
+			return fmt.Errorf("error getting argo cd application '%s': %w", app.GetName(), err)
+		}
+		if _, err := client.Create(ctx, app, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("error creating argo cd application '%s': %w", app.GetName(), err)
+		}
+		return nil
+	}
+
+	app.SetResourceVersion(old.GetResourceVersion())
+	if _, err := client.Update(ctx, app, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("error updating argo cd application '%s': %w", app.GetName(), err)
+	}
+	return nil
+}
+
+// GetStatus returns the status of an Argo CD application
+func GetStatus(ctx context.Context, name, namespace string, c dynamic.Interface) (*ApplicationStatus, error) {
+	app, err := c.Resource(applicationResource).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	status := &ApplicationStatus{}
+	status.Sync, _, _ = unstructured.NestedString(app.Object, "status", "sync", "status")
+	status.Revision, _, _ = unstructured.NestedString(app.Object, "status", "sync", "revision")
+	status.Health, _, _ = unstructured.NestedString(app.Object, "status", "health", "status")
+	status.URLs, _, _ = unstructured.NestedStringSlice(app.Object, "status", "summary", "externalURLs")
+	status.Message, _, _ = unstructured.NestedString(app.Object, "status", "operationState", "message")
+	if status.Message == "" {
+		status.Message, _, _ = unstructured.NestedString(app.Object, "status", "health", "message")
+	}
+	return status, nil
+}
+
+// WaitUntilSynced waits until an Argo CD application is synced and healthy. A zero timeout waits forever
+func WaitUntilSynced(ctx context.Context, name, namespace string, c dynamic.Interface, timeout time.Duration) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	// a nil channel never fires, so there is no timeout unless it's set
+	var timeoutCh <-chan time.Time
+	if timeout > 0 {
+		to := time.NewTimer(timeout)
+		defer to.Stop()
+		timeoutCh = to.C
+	}
+
+	for {
+		status, err := GetStatus(ctx, name, namespace, c)
+		if err != nil {
+			return fmt.Errorf("error getting argo cd application '%s': %w", name, err)
+		}
+		if status.Sync == SyncedStatus && status.Health == HealthyStatus {
+			return nil
+		}
+		if status.Health == DegradedStatus {
+			return fmt.Errorf("argo cd application '%s' is degraded: %s", name, status.Message)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeoutCh:
+			return fmt.Errorf("argo cd application '%s' didn't sync after %s: the sync status is '%s' and the health status is '%s'", name, timeout.String(), status.Sync, status.Health)
+		case <-ticker.C:
+		}
+	}
+}
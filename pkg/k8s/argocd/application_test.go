@@ -0,0 +1,121 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+)
+
+func newFakeClient(objects ...runtime.Object) *dynamicFake.FakeDynamicClient {
+	return dynamicFake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{applicationResource: "ApplicationList"},
+		objects...,
+	)
+}
+
+func newTestApplication(sync, health string, urls ...interface{}) *unstructured.Unstructured {
+	app := Translate(ApplicationOptions{Name: "pr-1", Namespace: DefaultNamespace, Repository: "https://github.com/okteto/movies", Branch: "main", Destination: "pr-1"})
+	app.Object["status"] = map[string]interface{}{
+		"sync":    map[string]interface{}{"status": sync, "revision": "abc"},
+		"health":  map[string]interface{}{"status": health, "message": "pod failed"},
+		"summary": map[string]interface{}{"externalURLs": urls},
+	}
+	return app
+}
+
+func TestTranslate(t *testing.T) {
+	app := Translate(ApplicationOptions{
+		Name:        "pr-1",
+		Namespace:   "argo",
+		Repository:  "https://github.com/okteto/movies",
+		Branch:      "feature",
+		Destination: "pr-1",
+	})
+
+	assert.Equal(t, "argoproj.io/v1alpha1", app.GetAPIVersion())
+	assert.Equal(t, "Application", app.GetKind())
+	assert.Equal(t, "argo", app.GetNamespace())
+	assert.Equal(t, map[string]string{PreviewLabel: "pr-1"}, app.GetLabels())
+
+	project, _, _ := unstructured.NestedString(app.Object, "spec", "project")
+	assert.Equal(t, DefaultProject, project)
+	source, _, _ := unstructured.NestedStringMap(app.Object, "spec", "source")
+	assert.Equal(t, map[string]string{"repoURL": "https://github.com/okteto/movies", "targetRevision": "feature", "path": "."}, source)
+	destination, _, _ := unstructured.NestedStringMap(app.Object, "spec", "destination")
+	assert.Equal(t, map[string]string{"server": inClusterServer, "namespace": "pr-1"}, destination)
+}
+
+func TestDeploy(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeClient()
+
+	app := Translate(ApplicationOptions{Name: "pr-1", Namespace: DefaultNamespace, Branch: "main", Destination: "pr-1"})
+	require.NoError(t, Deploy(ctx, app, c))
+
+	app = Translate(ApplicationOptions{Name: "pr-1", Namespace: DefaultNamespace, Branch: "feature", Destination: "pr-1"})
+	require.NoError(t, Deploy(ctx, app, c))
+
+	result, err := c.Resource(applicationResource).Namespace(DefaultNamespace).Get(ctx, "pr-1", metav1.GetOptions{})
+	require.NoError(t, err)
+	revision, _, _ := unstructured.NestedString(result.Object, "spec", "source", "targetRevision")
+	assert.Equal(t, "feature", revision)
+}
+
+func TestGetStatus(t *testing.T) {
+	c := newFakeClient(newTestApplication(SyncedStatus, HealthyStatus, "https://movies-pr-1.okteto.example.com"))
+
+	status, err := GetStatus(context.Background(), "pr-1", DefaultNamespace, c)
+	require.NoError(t, err)
+	assert.Equal(t, &ApplicationStatus{
+		Sync:     SyncedStatus,
+		Health:   HealthyStatus,
+		Revision: "abc",
+		Message:  "pod failed",
+		URLs:     []string{"https://movies-pr-1.okteto.example.com"},
+	}, status)
+}
+
+func TestWaitUntilSynced(t *testing.T) {
+	ctx := context.Background()
+	pollInterval = 10 * time.Millisecond
+
+	c := newFakeClient(newTestApplication(SyncedStatus, HealthyStatus))
+	assert.NoError(t, WaitUntilSynced(ctx, "pr-1", DefaultNamespace, c, time.Second))
+
+	c = newFakeClient(newTestApplication(SyncedStatus, DegradedStatus))
+	assert.ErrorContains(t, WaitUntilSynced(ctx, "pr-1", DefaultNamespace, c, time.Second), "pod failed")
+
+	c = newFakeClient(newTestApplication("OutOfSync", "Progressing"))
+	assert.ErrorContains(t, WaitUntilSynced(ctx, "pr-1", DefaultNamespace, c, 50*time.Millisecond), "didn't sync")
+
+	c = newFakeClient()
+	assert.Error(t, WaitUntilSynced(ctx, "pr-1", DefaultNamespace, c, time.Second))
+
+	// a zero timeout waits until the context is done
+	c = newFakeClient(newTestApplication("OutOfSync", "Progressing"))
+	cancelCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, WaitUntilSynced(cancelCtx, "pr-1", DefaultNamespace, c, 0), context.DeadlineExceeded)
+}
@@ -0,0 +1,119 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cronjobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+type patchAnnotations struct {
+	Op    string            `json:"op"`
+	Path  string            `json:"path"`
+	Value map[string]string `json:"value"`
+}
+
+// Deploy creates or updates a cronjob
+func Deploy(ctx context.Context, cj *batchv1.CronJob, c kubernetes.Interface) (*batchv1.CronJob, error) {
+	cj.ResourceVersion = ""
+	result, err := c.BatchV1().CronJobs(cj.Namespace).Update(ctx, cj, metav1.UpdateOptions{})
+	if err == nil {
+		return result, nil
+	}
+
+	if !oktetoErrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	return c.BatchV1().CronJobs(cj.Namespace).Create(ctx, cj, metav1.CreateOptions{})
+}
+
+// List returns the list of cronjobs
+func List(ctx context.Context, namespace, labels string, c kubernetes.Interface) ([]batchv1.CronJob, error) {
+	cjList, err := c.BatchV1().CronJobs(namespace).List(
+		ctx,
+		metav1.ListOptions{
+			LabelSelector: labels,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return cjList.Items, nil
+}
+
+// Get returns a cronjob object by name
+func Get(ctx context.Context, name, namespace string, c kubernetes.Interface) (*batchv1.CronJob, error) {
+	return c.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+// GetByDev returns a cronjob object given a dev struct (by name or by labels)
+func GetByDev(ctx context.Context, dev *model.Dev, namespace string, c kubernetes.Interface) (*batchv1.CronJob, error) {
+	if len(dev.Selector) == 0 {
+		return Get(ctx, dev.Name, namespace, c)
+	}
+
+	cjList, err := List(ctx, namespace, dev.LabelsSelector(), c)
+	if err != nil {
+		return nil, err
+	}
+	if len(cjList) == 0 {
+		return nil, oktetoErrors.ErrNotFound
+	}
+	if len(cjList) > 1 {
+		return nil, fmt.Errorf("found '%d' cronjobs for labels '%s' instead of 1", len(cjList), dev.LabelsSelector())
+	}
+	return &cjList[0], nil
+}
+
+// Destroy removes a cronjob object given its name and namespace
+func Destroy(ctx context.Context, name, namespace string, c kubernetes.Interface) error {
+	deletePropagation := metav1.DeletePropagationBackground
+	if err := c.BatchV1().CronJobs(namespace).Delete(ctx, name, metav1.DeleteOptions{PropagationPolicy: &deletePropagation}); err != nil {
+		if oktetoErrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("error deleting kubernetes cronjob: %s", err)
+	}
+	oktetoLog.Infof("cronjob '%s' deleted", name)
+	return nil
+}
+
+// PatchAnnotations patches the cronjob annotations
+func PatchAnnotations(ctx context.Context, cj *batchv1.CronJob, c kubernetes.Interface) error {
+	payload := []patchAnnotations{
+		{
+			Op:    "replace",
+			Path:  "/metadata/annotations",
+			Value: cj.Annotations,
+		},
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	if _, err := c.BatchV1().CronJobs(cj.Namespace).Patch(ctx, cj.Name, types.JSONPatchType, payloadBytes, metav1.PatchOptions{}); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cronjobs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
+)
+
+func newTestCronJob() *batchv1.CronJob {
+	return &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "report",
+			Namespace: "test",
+			Labels:    map[string]string{"app": "report"},
+		},
+		Spec: batchv1.CronJobSpec{Schedule: "*/5 * * * *"},
+	}
+}
+
+func TestGetByDev(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset(newTestCronJob())
+
+	result, err := GetByDev(ctx, &model.Dev{Name: "report"}, "test", clientset)
+	require.NoError(t, err)
+	assert.Equal(t, "report", result.Name)
+
+	result, err = GetByDev(ctx, &model.Dev{Name: "dev", Selector: model.Selector{"app": "report"}}, "test", clientset)
+	require.NoError(t, err)
+	assert.Equal(t, "report", result.Name)
+
+	_, err = GetByDev(ctx, &model.Dev{Name: "dev", Selector: model.Selector{"app": "other"}}, "test", clientset)
+	assert.Error(t, err)
+}
+
+func TestDeploy(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset()
+	cj := newTestCronJob()
+
+	_, err := Deploy(ctx, cj, clientset)
+	require.NoError(t, err)
+
+	cj.Spec.Suspend = pointer.Bool(true)
+	_, err = Deploy(ctx, cj, clientset)
+	require.NoError(t, err)
+
+	retrieved, err := Get(ctx, "report", "test", clientset)
+	require.NoError(t, err)
+	assert.True(t, *retrieved.Spec.Suspend)
+}
+
+func TestDestroy(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset(newTestCronJob())
+
+	require.NoError(t, Destroy(ctx, "report", "test", clientset))
+	_, err := Get(ctx, "report", "test", clientset)
+	assert.Error(t, err)
+
+	assert.NoError(t, Destroy(ctx, "report", "test", clientset))
+}
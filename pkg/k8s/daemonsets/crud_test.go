@@ -0,0 +1,88 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemonsets
+
+import (
+	"context"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetByDev(t *testing.T) {
+	ctx := context.Background()
+	agent := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "test", Labels: map[string]string{"app": "agent"}},
+	}
+	exporter := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "exporter", Namespace: "test", Labels: map[string]string{"app": "exporter", "tier": "monitoring"}},
+	}
+	logs := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "logs", Namespace: "test", Labels: map[string]string{"app": "logs", "tier": "monitoring"}},
+	}
+	c := fake.NewSimpleClientset(agent, exporter, logs)
+
+	var tests = []struct {
+		name        string
+		dev         *model.Dev
+		expected    string
+		expectedErr bool
+	}{
+		{name: "by name", dev: &model.Dev{Name: "agent"}, expected: "agent"},
+		{name: "by labels", dev: &model.Dev{Name: "dev", Selector: model.Selector{"app": "exporter"}}, expected: "exporter"},
+		{name: "not found", dev: &model.Dev{Name: "dev", Selector: model.Selector{"app": "none"}}, expectedErr: true},
+		{name: "several daemonsets", dev: &model.Dev{Name: "dev", Selector: model.Selector{"tier": "monitoring"}}, expectedErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ds, err := GetByDev(ctx, tt.dev, "test", c)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ds.Name)
+		})
+	}
+}
+
+func TestScaleDownAndUp(t *testing.T) {
+	ds := &appsv1.DaemonSet{}
+	assert.False(t, IsScaledDown(ds))
+
+	ScaleDown(ds)
+	assert.True(t, IsScaledDown(ds))
+
+	ScaleUp(ds)
+	assert.False(t, IsScaledDown(ds))
+	assert.Nil(t, ds.Spec.Template.Spec.NodeSelector)
+}
+
+func TestDestroy(t *testing.T) {
+	ctx := context.Background()
+	ds := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "test"}}
+	c := fake.NewSimpleClientset(ds)
+
+	require.NoError(t, Destroy(ctx, "agent", "test", c))
+	_, err := Get(ctx, "agent", "test", c)
+	assert.True(t, oktetoErrors.IsNotFound(err))
+
+	assert.NoError(t, Destroy(ctx, "agent", "test", c))
+}
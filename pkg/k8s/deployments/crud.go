@@ -19,9 +19,11 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/okteto/okteto/pkg/constants"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/apply"
 	"github.com/okteto/okteto/pkg/k8s/labels"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
@@ -219,6 +221,20 @@ func Deploy(ctx context.Context, d *appsv1.Deployment, c kubernetes.Interface) (
 	return c.AppsV1().Deployments(d.Namespace).Create(ctx, d, metav1.CreateOptions{})
 }
 
+// Apply creates or updates a deployment with server-side apply, keeping the fields managed by other managers.
+// Clients that don't support server-side apply, like fake clients, fall back to Deploy
+func Apply(ctx context.Context, d *appsv1.Deployment, c kubernetes.Interface) (*appsv1.Deployment, error) {
+	rc := apply.RESTClient(c.AppsV1().RESTClient())
+	if rc == nil {
+		return Deploy(ctx, d, c)
+	}
+	result := &appsv1.Deployment{}
+	if err := apply.Apply(ctx, rc, "deployments", appsv1.SchemeGroupVersion.WithKind("Deployment"), d, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // IsDevModeOn returns if a deployment is in devmode
 func IsDevModeOn(d *appsv1.Deployment) bool {
 	return labels.Get(d.GetObjectMeta(), constants.DevLabel) != ""
@@ -266,3 +282,38 @@ func PatchAnnotations(ctx context.Context, d *appsv1.Deployment, c kubernetes.In
 	}
 	return nil
 }
+
+// RolloutRestart restarts the pods of a deployment following its update strategy
+func RolloutRestart(ctx context.Context, name, namespace string, c kubernetes.Interface) error {
+	payload := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{
+						model.RestartedAtAnnotation: time.Now().Format(time.RFC3339),
+					},
+				},
+			},
+		},
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	if _, err := c.AppsV1().Deployments(namespace).Patch(ctx, name, types.MergePatchType, payloadBytes, metav1.PatchOptions{}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// IsRolledOut returns true if all the replicas of the deployment are updated and ready
+func IsRolledOut(d *appsv1.Deployment) bool {
+	if d.Generation > d.Status.ObservedGeneration {
+		return false
+	}
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	return d.Status.UpdatedReplicas == replicas && d.Status.ReadyReplicas == replicas && d.Status.Replicas == replicas
+}
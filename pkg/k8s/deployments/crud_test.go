@@ -479,3 +479,114 @@ func TestCheckConditionErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestRolloutRestart(t *testing.T) {
+	ctx := context.Background()
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "api",
+			Namespace: "test",
+		},
+	}
+	c := fake.NewSimpleClientset(d)
+
+	if err := RolloutRestart(ctx, "api", "test", c); err != nil {
+		t.Fatal(err)
+	}
+	restarted, err := Get(ctx, "api", "test", c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restarted.Spec.Template.Annotations[model.RestartedAtAnnotation] == "" {
+		t.Fatalf("annotation '%s' not set", model.RestartedAtAnnotation)
+	}
+
+	if err := RolloutRestart(ctx, "not-found", "test", c); !oktetoErrors.IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestIsRolledOut(t *testing.T) {
+	replicas := int32(2)
+	var tests = []struct {
+		name       string
+		deployment *appsv1.Deployment
+		expected   bool
+	}{
+		{
+			name: "rolled out",
+			deployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, ReadyReplicas: 2},
+			},
+			expected: true,
+		},
+		{
+			name: "default replicas",
+			deployment: &appsv1.Deployment{
+				Status: appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, ReadyReplicas: 1},
+			},
+			expected: true,
+		},
+		{
+			name: "generation not observed",
+			deployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 3},
+				Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, ReadyReplicas: 2},
+			},
+			expected: false,
+		},
+		{
+			name: "old replicas running",
+			deployment: &appsv1.Deployment{
+				Spec:   appsv1.DeploymentSpec{Replicas: &replicas},
+				Status: appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 2, ReadyReplicas: 2},
+			},
+			expected: false,
+		},
+		{
+			name: "replicas not ready",
+			deployment: &appsv1.Deployment{
+				Spec:   appsv1.DeploymentSpec{Replicas: &replicas},
+				Status: appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, ReadyReplicas: 1},
+			},
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRolledOut(tt.deployment); got != tt.expected {
+				t.Fatalf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestApplyWithFakeClient(t *testing.T) {
+	ctx := context.Background()
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "api",
+			Namespace: "test",
+			Labels:    map[string]string{"app": "api"},
+		},
+	}
+	c := fake.NewSimpleClientset()
+
+	if _, err := Apply(ctx, d, c); err != nil {
+		t.Fatal(err)
+	}
+	d.Labels["version"] = "2"
+	if _, err := Apply(ctx, d, c); err != nil {
+		t.Fatal(err)
+	}
+	applied, err := Get(ctx, "api", "test", c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if applied.Labels["version"] != "2" {
+		t.Fatalf("deployment not updated: %v", applied.Labels)
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
 	"strings"
 
 	dockerterm "github.com/moby/term"
@@ -47,6 +48,12 @@ func Exec(ctx context.Context, c kubernetes.Interface, config *rest.Config, podN
 
 	var sizeQueue remotecommand.TerminalSizeQueue
 	if t.Raw {
+		// SetupTTY replaces the output with the terminal. The terminal is still used to monitor its size,
+		// but the output of the caller is kept when it isn't a file, for example to record the session
+		if _, ok := stdout.(*os.File); !ok {
+			p.Out = stdout
+		}
+
 		// this call spawns a goroutine to monitor/update the terminal size
 		sizeQueue = t.MonitorSize(t.GetSize())
 
@@ -0,0 +1,175 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package images discovers the container images used by the workloads of a namespace
+package images
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/k8s/cronjobs"
+	"github.com/okteto/okteto/pkg/k8s/daemonsets"
+	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/k8s/jobs"
+	"github.com/okteto/okteto/pkg/k8s/statefulsets"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// InUse are the images referenced by the workloads of a namespace, by reference and by digest
+type InUse struct {
+	images  map[string]map[string]bool
+	digests map[string]map[string]bool
+}
+
+// NewInUse returns an empty set of images in use
+func NewInUse() *InUse {
+	return &InUse{
+		images:  map[string]map[string]bool{},
+		digests: map[string]map[string]bool{},
+	}
+}
+
+// ListInUse returns the images of the pods of the namespace and of the workloads that might not have running pods:
+// deployments and statefulsets scaled to zero, daemonsets, jobs and cronjobs. Pods are reported as the workload that controls them
+func ListInUse(ctx context.Context, namespace string, c kubernetes.Interface) (*InUse, error) {
+	inUse := NewInUse()
+
+	pods, err := c.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range pods.Items {
+		workload := getPodWorkload(&pods.Items[i])
+		inUse.addPodSpec(workload, pods.Items[i].Spec)
+		for _, s := range pods.Items[i].Status.InitContainerStatuses {
+			inUse.Add(workload, s.ImageID)
+		}
+		for _, s := range pods.Items[i].Status.ContainerStatuses {
+			inUse.Add(workload, s.ImageID)
+		}
+	}
+
+	deploymentList, err := deployments.List(ctx, namespace, "", c)
+	if err != nil {
+		return nil, err
+	}
+	for i := range deploymentList {
+		inUse.addPodSpec(fmt.Sprintf("deployment/%s", deploymentList[i].Name), deploymentList[i].Spec.Template.Spec)
+	}
+
+	sfsList, err := statefulsets.List(ctx, namespace, "", c)
+	if err != nil {
+		return nil, err
+	}
+	for i := range sfsList {
+		inUse.addPodSpec(fmt.Sprintf("statefulset/%s", sfsList[i].Name), sfsList[i].Spec.Template.Spec)
+	}
+
+	dsList, err := daemonsets.List(ctx, namespace, "", c)
+	if err != nil {
+		return nil, err
+	}
+	for i := range dsList {
+		inUse.addPodSpec(fmt.Sprintf("daemonset/%s", dsList[i].Name), dsList[i].Spec.Template.Spec)
+	}
+
+	jobList, err := jobs.List(ctx, namespace, "", c)
+	if err != nil {
+		return nil, err
+	}
+	for i := range jobList {
+		inUse.addPodSpec(fmt.Sprintf("job/%s", jobList[i].Name), jobList[i].Spec.Template.Spec)
+	}
+
+	cronjobList, err := cronjobs.List(ctx, namespace, "", c)
+	if err != nil {
+		return nil, err
+	}
+	for i := range cronjobList {
+		inUse.addPodSpec(fmt.Sprintf("cronjob/%s", cronjobList[i].Name), cronjobList[i].Spec.JobTemplate.Spec.Template.Spec)
+	}
+	return inUse, nil
+}
+
+// getPodWorkload returns the workload that controls the pod, or the pod itself if it doesn't have a controller
+func getPodWorkload(pod *apiv1.Pod) string {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return fmt.Sprintf("pod/%s", pod.Name)
+	}
+	if owner.Kind == "ReplicaSet" {
+		// the replicasets created by a deployment are named after the deployment and the pod template hash
+		if hash := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; hash != "" && strings.HasSuffix(owner.Name, "-"+hash) {
+			return fmt.Sprintf("deployment/%s", strings.TrimSuffix(owner.Name, "-"+hash))
+		}
+	}
+	return fmt.Sprintf("%s/%s", strings.ToLower(owner.Kind), owner.Name)
+}
+
+func (u *InUse) addPodSpec(workload string, spec apiv1.PodSpec) {
+	for _, container := range spec.InitContainers {
+		u.Add(workload, container.Image)
+	}
+	for _, container := range spec.Containers {
+		u.Add(workload, container.Image)
+	}
+}
+
+// Add registers an image reference or the image id of a container status, i.e. 'docker-pullable://registry/ns/api@sha256:...'
+func (u *InUse) Add(workload, image string) {
+	if image == "" {
+		return
+	}
+	if i := strings.Index(image, "://"); i >= 0 {
+		image = image[i+3:]
+	}
+	add(u.images, image, workload)
+	if i := strings.LastIndex(image, "@"); i >= 0 {
+		add(u.digests, image[i+1:], workload)
+	}
+}
+
+func add(m map[string]map[string]bool, key, workload string) {
+	if _, ok := m[key]; !ok {
+		m[key] = map[string]bool{}
+	}
+	m[key][workload] = true
+}
+
+// IsInUse returns true if an image is used by its reference or by its digest
+func (u *InUse) IsInUse(image, digest string) bool {
+	return len(u.images[image]) > 0 || len(u.digests[digest]) > 0
+}
+
+// Workloads returns the sorted workloads that use an image by its reference or by its digest
+func (u *InUse) Workloads(image, digest string) []string {
+	workloads := map[string]bool{}
+	for w := range u.images[image] {
+		workloads[w] = true
+	}
+	for w := range u.digests[digest] {
+		workloads[w] = true
+	}
+	result := make([]string, 0, len(workloads))
+	for w := range workloads {
+		result = append(result, w)
+	}
+	sort.Strings(result)
+	return result
+}
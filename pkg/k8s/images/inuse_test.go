@@ -0,0 +1,121 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	repo    = "registry.okteto.dev/cindy/api"
+	digest1 = "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	digest2 = "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+)
+
+func TestListInUse(t *testing.T) {
+	c := fake.NewSimpleClientset(
+		&apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "api-7d9f8-x2v4k",
+				Namespace: "cindy",
+				Labels:    map[string]string{appsv1.DefaultDeploymentUniqueLabelKey: "7d9f8"},
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "ReplicaSet", Name: "api-7d9f8", Controller: pointer.Bool(true)},
+				},
+			},
+			Spec: apiv1.PodSpec{
+				InitContainers: []apiv1.Container{{Image: "busybox"}},
+				Containers:     []apiv1.Container{{Image: repo + ":okteto"}},
+			},
+			Status: apiv1.PodStatus{
+				ContainerStatuses: []apiv1.ContainerStatus{{ImageID: "docker-pullable://" + repo + "@" + digest1}},
+			},
+		},
+		&apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "migrate-abcde",
+				Namespace:       "cindy",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "Job", Name: "migrate", Controller: pointer.Bool(true)}},
+			},
+			Spec: apiv1.PodSpec{Containers: []apiv1.Container{{Image: repo + "@" + digest1}}},
+		},
+		&apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: "cindy"},
+			Spec:       apiv1.PodSpec{Containers: []apiv1.Container{{Image: "busybox"}}},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "cindy"},
+			Spec: appsv1.DeploymentSpec{
+				Template: apiv1.PodTemplateSpec{Spec: apiv1.PodSpec{Containers: []apiv1.Container{{Image: repo + "@" + digest2}}}},
+			},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "cindy"},
+			Spec: appsv1.StatefulSetSpec{
+				Template: apiv1.PodTemplateSpec{Spec: apiv1.PodSpec{Containers: []apiv1.Container{{Image: "postgres:14"}}}},
+			},
+		},
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "cindy"},
+			Spec: appsv1.DaemonSetSpec{
+				Template: apiv1.PodTemplateSpec{Spec: apiv1.PodSpec{Containers: []apiv1.Container{{Image: "fluentd:1"}}}},
+			},
+		},
+		&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "seed", Namespace: "cindy"},
+			Spec: batchv1.JobSpec{
+				Template: apiv1.PodTemplateSpec{Spec: apiv1.PodSpec{Containers: []apiv1.Container{{Image: repo + ":seed"}}}},
+			},
+		},
+		&batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "cindy"},
+			Spec: batchv1.CronJobSpec{
+				JobTemplate: batchv1.JobTemplateSpec{
+					Spec: batchv1.JobSpec{
+						Template: apiv1.PodTemplateSpec{Spec: apiv1.PodSpec{Containers: []apiv1.Container{{Image: repo + ":backup"}}}},
+					},
+				},
+			},
+		},
+		&apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "other"},
+			Spec:       apiv1.PodSpec{Containers: []apiv1.Container{{Image: "registry.okteto.dev/other/api:okteto"}}},
+		},
+	)
+
+	inUse, err := ListInUse(context.Background(), "cindy", c)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"deployment/api", "job/migrate"}, inUse.Workloads(repo+":okteto", digest1))
+	assert.Equal(t, []string{"deployment/web"}, inUse.Workloads(repo+":v2", digest2))
+	assert.Equal(t, []string{"deployment/api", "pod/debug"}, inUse.Workloads("busybox", ""))
+	assert.Equal(t, []string{"statefulset/db"}, inUse.Workloads("postgres:14", ""))
+	assert.Equal(t, []string{"daemonset/agent"}, inUse.Workloads("fluentd:1", ""))
+	assert.Equal(t, []string{"job/seed"}, inUse.Workloads(repo+":seed", ""))
+	assert.Equal(t, []string{"cronjob/backup"}, inUse.Workloads(repo+":backup", ""))
+	assert.Empty(t, inUse.Workloads("registry.okteto.dev/other/api:okteto", ""))
+
+	assert.True(t, inUse.IsInUse(repo+":v2", digest2))
+	assert.False(t, inUse.IsInUse(repo+":v3", "sha256:3333"))
+}
@@ -18,10 +18,12 @@ import (
 	"fmt"
 
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/apply"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	networkingv1 "k8s.io/api/networking/v1"
 	networkingv1beta1 "k8s.io/api/networking/v1beta1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -220,3 +222,39 @@ func (iClient *Client) Deploy(ctx context.Context, ingress *Ingress) error {
 	oktetoLog.Success("Endpoint '%s' updated", ingress.GetName())
 	return nil
 }
+
+// Apply creates or updates an ingress with server-side apply, keeping the fields managed by other managers, like ingress controllers.
+// Clients that don't support server-side apply, like fake clients, fall back to Deploy
+func (iClient *Client) Apply(ctx context.Context, ingress *Ingress) error {
+	var rc rest.Interface
+	if iClient.isV1 {
+		rc = apply.RESTClient(iClient.c.NetworkingV1().RESTClient())
+	} else {
+		rc = apply.RESTClient(iClient.c.NetworkingV1beta1().RESTClient())
+	}
+	if rc == nil {
+		return iClient.Deploy(ctx, ingress)
+	}
+
+	_, err := iClient.Get(ctx, ingress.GetName(), ingress.GetNamespace())
+	if err != nil && !oktetoErrors.IsNotFound(err) {
+		return fmt.Errorf("error getting ingress '%s': %v", ingress.GetName(), err)
+	}
+	isNew := err != nil
+
+	if iClient.isV1 {
+		err = apply.Apply(ctx, rc, "ingresses", networkingv1.SchemeGroupVersion.WithKind("Ingress"), ingress.V1, &networkingv1.Ingress{})
+	} else {
+		err = apply.Apply(ctx, rc, "ingresses", networkingv1beta1.SchemeGroupVersion.WithKind("Ingress"), ingress.V1Beta1, &networkingv1beta1.Ingress{})
+	}
+	if err != nil {
+		return fmt.Errorf("error applying ingress '%s': %w", ingress.GetName(), err)
+	}
+
+	if isNew {
+		oktetoLog.Success("Endpoint '%s' created", ingress.GetName())
+	} else {
+		oktetoLog.Success("Endpoint '%s' updated", ingress.GetName())
+	}
+	return nil
+}
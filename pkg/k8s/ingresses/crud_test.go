@@ -58,6 +58,34 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+func TestApplyWithoutServerSideApply(t *testing.T) {
+	ctx := context.Background()
+	i := &Ingress{
+		V1: &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "fake",
+				Namespace: "test",
+			},
+		},
+	}
+
+	clientset := fake.NewSimpleClientset()
+	iClient := Client{
+		c:    clientset,
+		isV1: true,
+	}
+	if err := iClient.Apply(ctx, i); err != nil {
+		t.Fatal(err)
+	}
+	retrieved, err := clientset.NetworkingV1().Ingresses(i.V1.Namespace).Get(ctx, i.V1.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(retrieved, i.V1) {
+		t.Fatalf("Didn't applied correctly")
+	}
+}
+
 func TestUpdate(t *testing.T) {
 	ctx := context.Background()
 	labels := map[string]string{"key": "value"}
@@ -19,7 +19,9 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/okteto/okteto/pkg/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
@@ -237,3 +239,48 @@ func TestIsFailed(t *testing.T) {
 		t.Fatal("not failed job declared as failed")
 	}
 }
+
+func TestGetByDev(t *testing.T) {
+	ctx := context.Background()
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "migrate",
+			Namespace: "test",
+			Labels:    map[string]string{"app": "migrate"},
+		},
+	}
+	clientset := fake.NewSimpleClientset(job)
+
+	result, err := GetByDev(ctx, &model.Dev{Name: "migrate"}, "test", clientset)
+	require.NoError(t, err)
+	assert.Equal(t, "migrate", result.Name)
+
+	result, err = GetByDev(ctx, &model.Dev{Name: "dev", Selector: model.Selector{"app": "migrate"}}, "test", clientset)
+	require.NoError(t, err)
+	assert.Equal(t, "migrate", result.Name)
+
+	_, err = GetByDev(ctx, &model.Dev{Name: "dev", Selector: model.Selector{"app": "other"}}, "test", clientset)
+	assert.Error(t, err)
+}
+
+func TestDeploy(t *testing.T) {
+	ctx := context.Background()
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "migrate",
+			Namespace: "test",
+		},
+	}
+	clientset := fake.NewSimpleClientset()
+
+	_, err := Deploy(ctx, job, clientset)
+	require.NoError(t, err)
+
+	job.Spec.Suspend = pointer.Bool(true)
+	_, err = Deploy(ctx, job, clientset)
+	require.NoError(t, err)
+
+	retrieved, err := Get(ctx, "migrate", "test", clientset)
+	require.NoError(t, err)
+	assert.True(t, *retrieved.Spec.Suspend)
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfig
+
+import (
+	"os"
+	"strings"
+
+	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// InClusterContext is the name of the context that uses the credentials of the pod running okteto
+	InClusterContext = "in-cluster"
+
+	defaultNamespace = "default"
+)
+
+var serviceAccountNamespacePath = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// GetInCluster returns a kubeconfig with the credentials of the service account of the pod running okteto.
+// It isn't written to disk, and the token is read from its file so the rotations done by Kubernetes are honored
+func GetInCluster(namespace string) (*clientcmdapi.Config, error) {
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, err
+	}
+	if namespace == "" {
+		namespace = GetInClusterNamespace()
+	}
+	return newInClusterConfig(restConfig, namespace), nil
+}
+
+func newInClusterConfig(restConfig *rest.Config, namespace string) *clientcmdapi.Config {
+	cfg := Create()
+
+	cluster := clientcmdapi.NewCluster()
+	cluster.Server = restConfig.Host
+	cluster.CertificateAuthority = restConfig.TLSClientConfig.CAFile
+	cluster.CertificateAuthorityData = restConfig.TLSClientConfig.CAData
+	cfg.Clusters[InClusterContext] = cluster
+
+	user := clientcmdapi.NewAuthInfo()
+	user.TokenFile = restConfig.BearerTokenFile
+	if user.TokenFile == "" {
+		user.Token = restConfig.BearerToken
+	}
+	cfg.AuthInfos[InClusterContext] = user
+
+	context := clientcmdapi.NewContext()
+	context.Cluster = InClusterContext
+	context.AuthInfo = InClusterContext
+	context.Namespace = namespace
+	cfg.Contexts[InClusterContext] = context
+
+	cfg.CurrentContext = InClusterContext
+	return cfg
+}
+
+// GetInClusterNamespace returns the namespace of the pod running okteto, or 'default' if it can't be read
+func GetInClusterNamespace() string {
+	b, err := os.ReadFile(serviceAccountNamespacePath)
+	if err != nil {
+		return defaultNamespace
+	}
+	if ns := strings.TrimSpace(string(b)); ns != "" {
+		return ns
+	}
+	return defaultNamespace
+}
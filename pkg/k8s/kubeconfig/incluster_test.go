@@ -0,0 +1,53 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+)
+
+func TestNewInClusterConfig(t *testing.T) {
+	restConfig := &rest.Config{
+		Host:            "https://10.0.0.1:443",
+		BearerTokenFile: "/var/run/secrets/kubernetes.io/serviceaccount/token",
+		TLSClientConfig: rest.TLSClientConfig{CAFile: "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"},
+	}
+	cfg := newInClusterConfig(restConfig, "ci")
+
+	assert.Equal(t, InClusterContext, cfg.CurrentContext)
+	assert.Equal(t, "https://10.0.0.1:443", cfg.Clusters[InClusterContext].Server)
+	assert.Equal(t, "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt", cfg.Clusters[InClusterContext].CertificateAuthority)
+	assert.Equal(t, "/var/run/secrets/kubernetes.io/serviceaccount/token", cfg.AuthInfos[InClusterContext].TokenFile)
+	assert.Empty(t, cfg.AuthInfos[InClusterContext].Token)
+	assert.Equal(t, "ci", cfg.Contexts[InClusterContext].Namespace)
+	assert.Equal(t, InClusterContext, cfg.Contexts[InClusterContext].Cluster)
+	assert.Equal(t, InClusterContext, cfg.Contexts[InClusterContext].AuthInfo)
+}
+
+func TestGetInClusterNamespace(t *testing.T) {
+	previous := serviceAccountNamespacePath
+	defer func() { serviceAccountNamespacePath = previous }()
+
+	serviceAccountNamespacePath = filepath.Join(t.TempDir(), "namespace")
+	assert.Equal(t, "default", GetInClusterNamespace())
+
+	require.NoError(t, os.WriteFile(serviceAccountNamespacePath, []byte("ci\n"), 0600))
+	assert.Equal(t, "ci", GetInClusterNamespace())
+}
@@ -0,0 +1,192 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespaces
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	namespacedScope = "Namespaced"
+)
+
+var (
+	crdResource = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+
+	// customResourcesTimeout is the time to wait for the operators to finalize the deleted custom resources
+	customResourcesTimeout = 2 * time.Minute
+
+	// customResourcesPollInterval is the interval between checks of the deleted custom resources
+	customResourcesPollInterval = time.Second
+)
+
+// DestroyCustomResources deletes the custom resources that match opts.LabelSelector and waits until they are gone.
+// Custom resources are deleted before the rest of the resources so the operators managing them, that might be
+// deployed by the same development environment, are still running to process their finalizers
+func (n *Namespaces) DestroyCustomResources(ctx context.Context, ns string, opts DeleteAllOptions) error {
+	resources, err := n.listCustomResourceTypes(ctx)
+	if err != nil {
+		if k8sErrors.IsForbidden(err) || k8sErrors.IsNotFound(err) {
+			oktetoLog.Infof("skipping deletion of custom resources, the custom resource definitions can't be listed: %s", err)
+			return nil
+		}
+		return fmt.Errorf("error getting custom resource definitions: %w", err)
+	}
+
+	pending := []schema.GroupVersionResource{}
+	for _, gvr := range resources {
+		deleted, err := n.destroyCustomResources(ctx, gvr, ns, opts)
+		if err != nil {
+			return err
+		}
+		if deleted {
+			pending = append(pending, gvr)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	return n.waitForCustomResources(ctx, pending, ns, opts)
+}
+
+// listCustomResourceTypes returns the namespaced resources defined by custom resource definitions
+func (n *Namespaces) listCustomResourceTypes(ctx context.Context) ([]schema.GroupVersionResource, error) {
+	crds, err := n.dynClient.Resource(crdResource).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := []schema.GroupVersionResource{}
+	for _, crd := range crds.Items {
+		scope, _, _ := unstructured.NestedString(crd.Object, "spec", "scope")
+		if scope != namespacedScope {
+			continue
+		}
+		group, _, _ := unstructured.NestedString(crd.Object, "spec", "group")
+		plural, _, _ := unstructured.NestedString(crd.Object, "spec", "names", "plural")
+		version := getCustomResourceVersion(crd)
+		if group == "" || plural == "" || version == "" {
+			oktetoLog.Debugf("skipping custom resource definition '%s': missing group, plural or served version", crd.GetName())
+			continue
+		}
+		result = append(result, schema.GroupVersionResource{Group: group, Version: version, Resource: plural})
+	}
+	return result, nil
+}
+
+// getCustomResourceVersion returns the storage version of a custom resource definition, or its first served version
+func getCustomResourceVersion(crd unstructured.Unstructured) string {
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	served := ""
+	for _, v := range versions {
+		version, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(version, "name")
+		isServed, _, _ := unstructured.NestedBool(version, "served")
+		if !isServed {
+			continue
+		}
+		if isStorage, _, _ := unstructured.NestedBool(version, "storage"); isStorage {
+			return name
+		}
+		if served == "" {
+			served = name
+		}
+	}
+	return served
+}
+
+// destroyCustomResources deletes the custom resources of a type. It returns true if any resource was deleted
+func (n *Namespaces) destroyCustomResources(ctx context.Context, gvr schema.GroupVersionResource, ns string, opts DeleteAllOptions) (bool, error) {
+	list, err := n.dynClient.Resource(gvr).Namespace(ns).List(ctx, metav1.ListOptions{LabelSelector: opts.LabelSelector})
+	if err != nil {
+		if k8sErrors.IsForbidden(err) || k8sErrors.IsNotFound(err) {
+			oktetoLog.Debugf("skipping deletion of '%s': %s", gvr.String(), err)
+			return false, nil
+		}
+		return false, fmt.Errorf("error listing '%s': %w", gvr.String(), err)
+	}
+
+	deleted := false
+	deletePropagation := metav1.DeletePropagationBackground
+	for _, cr := range list.Items {
+		if cr.GetAnnotations()[resourcePolicyAnnotation] == keepPolicy {
+			oktetoLog.Debugf("skipping deletion of %s '%s' because of policy annotation", cr.GetKind(), cr.GetName())
+			continue
+		}
+		err := n.dynClient.Resource(gvr).Namespace(ns).Delete(ctx, cr.GetName(), metav1.DeleteOptions{PropagationPolicy: &deletePropagation})
+		if err != nil && !k8sErrors.IsNotFound(err) {
+			return false, fmt.Errorf("error deleting %s '%s': %w", cr.GetKind(), cr.GetName(), err)
+		}
+		oktetoLog.Debugf("successfully deleted %s '%s'", cr.GetKind(), cr.GetName())
+		deleted = true
+	}
+	return deleted, nil
+}
+
+// waitForCustomResources waits until the deleted custom resources are finalized by their operators
+func (n *Namespaces) waitForCustomResources(ctx context.Context, resources []schema.GroupVersionResource, ns string, opts DeleteAllOptions) error {
+	ticker := time.NewTicker(customResourcesPollInterval)
+	defer ticker.Stop()
+	timeout := time.NewTimer(customResourcesTimeout)
+	defer timeout.Stop()
+
+	for {
+		remaining, err := n.countCustomResources(ctx, resources, ns, opts)
+		if err != nil {
+			return err
+		}
+		if remaining == 0 {
+			return nil
+		}
+		oktetoLog.Debugf("waiting for %d custom resources to be deleted", remaining)
+
+		select {
+		case <-ticker.C:
+		case <-timeout.C:
+			oktetoLog.Warning("%d custom resources are still being deleted, check the finalizers of their operators", remaining)
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// countCustomResources returns the number of custom resources not yet deleted, ignoring the ones kept by policy
+func (n *Namespaces) countCustomResources(ctx context.Context, resources []schema.GroupVersionResource, ns string, opts DeleteAllOptions) (int, error) {
+	count := 0
+	for _, gvr := range resources {
+		list, err := n.dynClient.Resource(gvr).Namespace(ns).List(ctx, metav1.ListOptions{LabelSelector: opts.LabelSelector})
+		if err != nil {
+			return 0, fmt.Errorf("error listing '%s': %w", gvr.String(), err)
+		}
+		for _, cr := range list.Items {
+			if cr.GetAnnotations()[resourcePolicyAnnotation] != keepPolicy {
+				count++
+			}
+		}
+	}
+	return count, nil
+}
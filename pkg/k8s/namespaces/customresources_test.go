@@ -0,0 +1,135 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespaces
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+)
+
+var topicResource = schema.GroupVersionResource{Group: "kafka.strimzi.io", Version: "v1beta2", Resource: "kafkatopics"}
+
+func newTestCRD(scope string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "apiextensions.k8s.io/v1",
+			"kind":       "CustomResourceDefinition",
+			"metadata":   map[string]interface{}{"name": "kafkatopics.kafka.strimzi.io"},
+			"spec": map[string]interface{}{
+				"group": "kafka.strimzi.io",
+				"scope": scope,
+				"names": map[string]interface{}{"plural": "kafkatopics", "kind": "KafkaTopic"},
+				"versions": []interface{}{
+					map[string]interface{}{"name": "v1beta1", "served": true, "storage": false},
+					map[string]interface{}{"name": "v1beta2", "served": true, "storage": true},
+				},
+			},
+		},
+	}
+}
+
+func newTestTopic(name string, labels map[string]string, annotations map[string]string) *unstructured.Unstructured {
+	topic := &unstructured.Unstructured{}
+	topic.SetAPIVersion("kafka.strimzi.io/v1beta2")
+	topic.SetKind("KafkaTopic")
+	topic.SetName(name)
+	topic.SetNamespace("test")
+	topic.SetLabels(labels)
+	topic.SetAnnotations(annotations)
+	return topic
+}
+
+func TestDestroyCustomResources(t *testing.T) {
+	ctx := context.Background()
+	deployedBy := map[string]string{model.DeployedByLabel: "app"}
+	objects := []runtime.Object{
+		newTestCRD(namespacedScope),
+		newTestTopic("orders", deployedBy, nil),
+		newTestTopic("payments", deployedBy, map[string]string{resourcePolicyAnnotation: keepPolicy}),
+		newTestTopic("shared", nil, nil),
+	}
+	dynClient := dynamicFake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			crdResource:   "CustomResourceDefinitionList",
+			topicResource: "KafkaTopicList",
+		},
+		objects...,
+	)
+	n := NewNamespace(dynClient, nil, nil, nil)
+
+	err := n.DestroyCustomResources(ctx, "test", DeleteAllOptions{LabelSelector: model.DeployedByLabel + "=app"})
+	require.NoError(t, err)
+
+	list, err := dynClient.Resource(topicResource).Namespace("test").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	names := []string{}
+	for _, item := range list.Items {
+		names = append(names, item.GetName())
+	}
+	assert.ElementsMatch(t, []string{"payments", "shared"}, names)
+}
+
+func TestListCustomResourceTypes(t *testing.T) {
+	ctx := context.Background()
+	var tests = []struct {
+		name     string
+		crd      *unstructured.Unstructured
+		expected []schema.GroupVersionResource
+	}{
+		{
+			name:     "namespaced",
+			crd:      newTestCRD(namespacedScope),
+			expected: []schema.GroupVersionResource{topicResource},
+		},
+		{
+			name:     "cluster",
+			crd:      newTestCRD("Cluster"),
+			expected: []schema.GroupVersionResource{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dynClient := dynamicFake.NewSimpleDynamicClientWithCustomListKinds(
+				runtime.NewScheme(),
+				map[schema.GroupVersionResource]string{crdResource: "CustomResourceDefinitionList"},
+				tt.crd,
+			)
+			n := NewNamespace(dynClient, nil, nil, nil)
+			result, err := n.listCustomResourceTypes(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestGetCustomResourceVersion(t *testing.T) {
+	crd := newTestCRD(namespacedScope)
+	assert.Equal(t, "v1beta2", getCustomResourceVersion(*crd))
+
+	require.NoError(t, unstructured.SetNestedSlice(crd.Object, []interface{}{
+		map[string]interface{}{"name": "v1alpha1", "served": false, "storage": true},
+		map[string]interface{}{"name": "v1beta1", "served": true, "storage": false},
+	}, "spec", "versions"))
+	assert.Equal(t, "v1beta1", getCustomResourceVersion(*crd))
+}
@@ -246,9 +246,9 @@ func NewTrip(restConfig *rest.Config, opts *Options) (*Trip, error) {
 		opts = &Options{}
 	}
 
-	if restConfig.RateLimiter == nil {
-		restConfig.RateLimiter = &noneRateLimiter{}
-	}
+	// the okteto clients share a rate limiter, but the deletion of the resources of a namespace isn't rate limited
+	restConfig = rest.CopyConfig(restConfig)
+	restConfig.RateLimiter = &noneRateLimiter{}
 
 	k8s, err := kubernetes.NewForConfig(restConfig)
 	if err != nil {
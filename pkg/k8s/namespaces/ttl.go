@@ -0,0 +1,62 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespaces
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/okteto/okteto/pkg/constants"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// SetTTL annotates the namespace with its time to live, counting from now. A zero ttl removes it.
+// The namespace isn't deleted when it expires: "okteto namespace delete --expired" deletes expired namespaces
+func SetTTL(ctx context.Context, name string, ttl time.Duration, c kubernetes.Interface) error {
+	annotations := map[string]interface{}{
+		constants.NamespaceTTLAnnotation:       nil,
+		constants.NamespaceExpiresAtAnnotation: nil,
+	}
+	if ttl > 0 {
+		annotations[constants.NamespaceTTLAnnotation] = ttl.String()
+		annotations[constants.NamespaceExpiresAtAnnotation] = time.Now().Add(ttl).UTC().Format(time.RFC3339)
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": annotations,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = c.CoreV1().Namespaces().Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// GetExpiration returns when the namespace expires. The second value is false if the namespace has no time to live
+func GetExpiration(ns *apiv1.Namespace) (time.Time, bool) {
+	value, ok := ns.Annotations[constants.NamespaceExpiresAtAnnotation]
+	if !ok {
+		return time.Time{}, false
+	}
+	expiresAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return expiresAt, true
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespaces
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSetTTL(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset(&apiv1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Annotations: map[string]string{"key": "value"},
+		},
+	})
+
+	before := time.Now().Add(72 * time.Hour).Truncate(time.Second)
+	require.NoError(t, SetTTL(ctx, "test", 72*time.Hour, c))
+	ns, err := c.CoreV1().Namespaces().Get(ctx, "test", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "72h0m0s", ns.Annotations[constants.NamespaceTTLAnnotation])
+	assert.Equal(t, "value", ns.Annotations["key"])
+	expiresAt, ok := GetExpiration(ns)
+	require.True(t, ok)
+	assert.False(t, expiresAt.Before(before))
+	assert.False(t, expiresAt.After(time.Now().Add(72*time.Hour)))
+
+	require.NoError(t, SetTTL(ctx, "test", 0, c))
+	ns, err = c.CoreV1().Namespaces().Get(ctx, "test", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"key": "value"}, ns.Annotations)
+	_, ok = GetExpiration(ns)
+	assert.False(t, ok)
+}
+
+func TestGetExpiration(t *testing.T) {
+	ns := &apiv1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{constants.NamespaceExpiresAtAnnotation: "2023-10-01T10:00:00Z"},
+		},
+	}
+	expiresAt, ok := GetExpiration(ns)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC), expiresAt)
+
+	ns.Annotations[constants.NamespaceExpiresAtAnnotation] = "tomorrow"
+	_, ok = GetExpiration(ns)
+	assert.False(t, ok)
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pods
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// podMetricsList is the subset of the metrics.k8s.io/v1beta1 PodMetricsList used by okteto
+type podMetricsList struct {
+	Items []podMetrics `json:"items"`
+}
+
+type podMetrics struct {
+	Metadata struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	Containers []struct {
+		Name  string             `json:"name"`
+		Usage apiv1.ResourceList `json:"usage"`
+	} `json:"containers"`
+}
+
+// GetMetrics returns the cpu and memory usage of the pods of a namespace, as reported by metrics-server
+func GetMetrics(ctx context.Context, namespace string, c kubernetes.Interface) (map[string]apiv1.ResourceList, error) {
+	b, err := c.CoreV1().RESTClient().Get().AbsPath("/apis/metrics.k8s.io/v1beta1/namespaces", namespace, "pods").DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the metrics of the pods of namespace '%s': %w", namespace, err)
+	}
+	return parseMetrics(b)
+}
+
+// parseMetrics returns the usage of each pod of a PodMetricsList, adding the usage of its containers
+func parseMetrics(b []byte) (map[string]apiv1.ResourceList, error) {
+	list := &podMetricsList{}
+	if err := json.Unmarshal(b, list); err != nil {
+		return nil, fmt.Errorf("failed to parse the pod metrics: %w", err)
+	}
+	result := map[string]apiv1.ResourceList{}
+	for _, item := range list.Items {
+		usage := apiv1.ResourceList{}
+		for _, c := range item.Containers {
+			for name, q := range c.Usage {
+				total := usage[name]
+				total.Add(q)
+				usage[name] = total
+			}
+		}
+		result[item.Metadata.Name] = usage
+	}
+	return result, nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pods
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseMetrics(t *testing.T) {
+	b := []byte(`{
+  "kind": "PodMetricsList",
+  "apiVersion": "metrics.k8s.io/v1beta1",
+  "items": [
+    {
+      "metadata": {"name": "api-1", "namespace": "test"},
+      "containers": [
+        {"name": "api", "usage": {"cpu": "125m", "memory": "64Mi"}},
+        {"name": "sidecar", "usage": {"cpu": "2500000n", "memory": "16Mi"}}
+      ]
+    }
+  ]
+}`)
+	metrics, err := parseMetrics(b)
+	require.NoError(t, err)
+	require.Contains(t, metrics, "api-1")
+	usage := metrics["api-1"]
+	assert.Equal(t, int64(128), usage.Cpu().MilliValue())
+	assert.Equal(t, int64(80*1024*1024), usage.Memory().Value())
+
+	_, err = parseMetrics([]byte("{"))
+	assert.Error(t, err)
+}
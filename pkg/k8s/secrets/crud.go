@@ -53,8 +53,8 @@ func Get(ctx context.Context, name, namespace string, c kubernetes.Interface) (*
 	return secret, nil
 }
 
-// Create creates the syncthing config secret
-func Create(ctx context.Context, dev *model.Dev, c kubernetes.Interface, s *syncthing.Syncthing) error {
+// Create creates the syncthing config secret. platformSecrets has the values of the secrets from the Okteto platform used by dev
+func Create(ctx context.Context, dev *model.Dev, c kubernetes.Interface, s *syncthing.Syncthing, platformSecrets map[string]string) error {
 	secretName := GetSecretName(dev)
 
 	sct, err := Get(ctx, secretName, dev.Namespace, c)
@@ -83,6 +83,14 @@ func Create(ctx context.Context, dev *model.Dev, c kubernetes.Interface, s *sync
 
 	idx := 0
 	for _, s := range dev.Secrets {
+		if s.FromPlatform != "" {
+			value, ok := platformSecrets[s.FromPlatform]
+			if !ok {
+				return fmt.Errorf("secret '%s' not found in the Okteto platform", s.FromPlatform)
+			}
+			data.Data[s.GetKeyName()] = []byte(value)
+			continue
+		}
 		content, err := os.ReadFile(s.LocalPath)
 		if err != nil {
 			return fmt.Errorf("error reading secret '%s': %s", s.LocalPath, err)
@@ -19,6 +19,7 @@ import (
 	"strings"
 
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/apply"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	apiv1 "k8s.io/api/core/v1"
@@ -61,6 +62,21 @@ func Deploy(ctx context.Context, s *apiv1.Service, c kubernetes.Interface) error
 	return nil
 }
 
+// Apply creates or updates a k8s service with server-side apply, keeping the fields managed by other managers.
+// Clients that don't support server-side apply, like fake clients, fall back to Deploy
+func Apply(ctx context.Context, s *apiv1.Service, c kubernetes.Interface) error {
+	rc := apply.RESTClient(c.CoreV1().RESTClient())
+	if rc == nil {
+		return Deploy(ctx, s, c)
+	}
+	oktetoLog.Infof("applying service '%s'", s.Name)
+	if err := apply.Apply(ctx, rc, "services", apiv1.SchemeGroupVersion.WithKind("Service"), s, &apiv1.Service{}); err != nil {
+		return fmt.Errorf("error applying kubernetes service: %w", err)
+	}
+	oktetoLog.Infof("applied service '%s'", s.Name)
+	return nil
+}
+
 // Get returns a kubernetes service by the name, or an error if it doesn't exist
 func Get(ctx context.Context, name, namespace string, c kubernetes.Interface) (*apiv1.Service, error) {
 	return c.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
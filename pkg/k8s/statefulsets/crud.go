@@ -19,12 +19,14 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/utils/pointer"
 
 	"github.com/okteto/okteto/pkg/constants"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/apply"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	appsv1 "k8s.io/api/apps/v1"
@@ -100,6 +102,20 @@ func Deploy(ctx context.Context, sfs *appsv1.StatefulSet, c kubernetes.Interface
 	return c.AppsV1().StatefulSets(sfs.Namespace).Create(ctx, sfs, metav1.CreateOptions{})
 }
 
+// Apply creates or updates a statefulset with server-side apply, keeping the fields managed by other managers.
+// Clients that don't support server-side apply, like fake clients, fall back to Deploy
+func Apply(ctx context.Context, sfs *appsv1.StatefulSet, c kubernetes.Interface) (*appsv1.StatefulSet, error) {
+	rc := apply.RESTClient(c.AppsV1().RESTClient())
+	if rc == nil {
+		return Deploy(ctx, sfs, c)
+	}
+	result := &appsv1.StatefulSet{}
+	if err := apply.Apply(ctx, rc, "statefulsets", appsv1.SchemeGroupVersion.WithKind("StatefulSet"), sfs, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // List returns the list of statefulsets
 func List(ctx context.Context, namespace, labels string, c kubernetes.Interface) ([]appsv1.StatefulSet, error) {
 	sfsList, err := c.AppsV1().StatefulSets(namespace).List(
@@ -250,3 +266,41 @@ func PatchAnnotations(ctx context.Context, sfs *appsv1.StatefulSet, c kubernetes
 	}
 	return nil
 }
+
+// RolloutRestart restarts the pods of a statefulset following its update strategy
+func RolloutRestart(ctx context.Context, name, namespace string, c kubernetes.Interface) error {
+	payload := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{
+						model.RestartedAtAnnotation: time.Now().Format(time.RFC3339),
+					},
+				},
+			},
+		},
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	if _, err := c.AppsV1().StatefulSets(namespace).Patch(ctx, name, types.MergePatchType, payloadBytes, metav1.PatchOptions{}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// IsRolledOut returns true if all the replicas of the statefulset are updated and ready
+func IsRolledOut(sfs *appsv1.StatefulSet) bool {
+	if sfs.Generation > sfs.Status.ObservedGeneration {
+		return false
+	}
+	replicas := int32(1)
+	if sfs.Spec.Replicas != nil {
+		replicas = *sfs.Spec.Replicas
+	}
+	if sfs.Status.UpdateRevision != "" && sfs.Status.CurrentRevision != sfs.Status.UpdateRevision {
+		return false
+	}
+	return sfs.Status.ReadyReplicas == replicas && sfs.Status.Replicas == replicas
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/okteto/okteto/pkg/model"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
@@ -100,3 +101,74 @@ func TestList(t *testing.T) {
 	}
 
 }
+
+func TestRolloutRestart(t *testing.T) {
+	ctx := context.Background()
+	sfs := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "db",
+			Namespace: "test",
+		},
+	}
+	c := fake.NewSimpleClientset(sfs)
+
+	if err := RolloutRestart(ctx, "db", "test", c); err != nil {
+		t.Fatal(err)
+	}
+	restarted, err := Get(ctx, "db", "test", c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restarted.Spec.Template.Annotations[model.RestartedAtAnnotation] == "" {
+		t.Fatalf("annotation '%s' not set", model.RestartedAtAnnotation)
+	}
+}
+
+func TestIsRolledOut(t *testing.T) {
+	replicas := int32(2)
+	var tests = []struct {
+		name     string
+		sfs      *appsv1.StatefulSet
+		expected bool
+	}{
+		{
+			name: "rolled out",
+			sfs: &appsv1.StatefulSet{
+				Spec:   appsv1.StatefulSetSpec{Replicas: &replicas},
+				Status: appsv1.StatefulSetStatus{Replicas: 2, ReadyReplicas: 2, CurrentRevision: "db-2", UpdateRevision: "db-2"},
+			},
+			expected: true,
+		},
+		{
+			name: "revision not updated",
+			sfs: &appsv1.StatefulSet{
+				Spec:   appsv1.StatefulSetSpec{Replicas: &replicas},
+				Status: appsv1.StatefulSetStatus{Replicas: 2, ReadyReplicas: 2, CurrentRevision: "db-1", UpdateRevision: "db-2"},
+			},
+			expected: false,
+		},
+		{
+			name: "generation not observed",
+			sfs: &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       appsv1.StatefulSetSpec{Replicas: &replicas},
+				Status:     appsv1.StatefulSetStatus{ObservedGeneration: 1, Replicas: 2, ReadyReplicas: 2},
+			},
+			expected: false,
+		},
+		{
+			name: "replicas not ready",
+			sfs: &appsv1.StatefulSet{
+				Status: appsv1.StatefulSetStatus{Replicas: 1, ReadyReplicas: 0},
+			},
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRolledOut(tt.sfs); got != tt.expected {
+				t.Fatalf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
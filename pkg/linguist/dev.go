@@ -14,6 +14,7 @@
 package linguist
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"sort"
@@ -26,6 +27,9 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 )
 
+// denoEntrypoints are the files that start a deno app, in order of preference
+var denoEntrypoints = []string{"main.ts", "main.tsx", "main.js", "mod.ts", "mod.js", "server.ts", "server.js", "index.ts", "index.js", "app.ts", "app.js"}
+
 type languageDefault struct {
 	image           string
 	path            string
@@ -49,6 +53,8 @@ const (
 	Csharp     = "csharp"
 	Php        = "php"
 	Rust       = "rust"
+	Elixir     = "elixir"
+	Deno       = "deno"
 
 	// Unrecognized is the option returned when the linguist couldn't detect a language
 	Unrecognized = "other"
@@ -113,13 +119,13 @@ func init() {
 	languageDefaults[Rust] = languageDefault{
 		image:   "okteto/rust:1",
 		path:    "/usr/src/app",
-		command: []string{"bash"},
+		command: []string{"cargo", "watch", "-x", "run"},
 		volumes: []model.Volume{
 			{
 				RemotePath: "/usr/local/cargo/registry",
 			},
 			{
-				RemotePath: "/home/root/app/target",
+				RemotePath: "/usr/src/app/target",
 			},
 		},
 	}
@@ -130,6 +136,61 @@ func init() {
 		},
 	}
 
+	languageDefaults[Elixir] = languageDefault{
+		image:   "elixir:1",
+		path:    "/usr/src/app",
+		command: []string{"mix", "phx.server"},
+		environment: []model.EnvVar{
+			{
+				Name:  "MIX_ENV",
+				Value: "dev",
+			},
+		},
+		volumes: []model.Volume{
+			{
+				RemotePath: "/root/.mix",
+			},
+			{
+				RemotePath: "/root/.hex",
+			},
+			{
+				RemotePath: "/usr/src/app/deps",
+			},
+			{
+				RemotePath: "/usr/src/app/_build",
+			},
+		},
+	}
+	forwardDefaults[Elixir] = []forward.Forward{
+		{
+			Local:  4000,
+			Remote: 4000,
+		},
+	}
+
+	languageDefaults[Deno] = languageDefault{
+		image:   "denoland/deno:debian",
+		path:    "/usr/src/app",
+		command: []string{"deno", "run", "--watch", "--allow-all", "main.ts"},
+		environment: []model.EnvVar{
+			{
+				Name:  "DENO_DIR",
+				Value: "/deno-dir",
+			},
+		},
+		volumes: []model.Volume{
+			{
+				RemotePath: "/deno-dir",
+			},
+		},
+	}
+	forwardDefaults[Deno] = []forward.Forward{
+		{
+			Local:  8000,
+			Remote: 8000,
+		},
+	}
+
 	languageDefaults[Python] = languageDefault{
 		image:   "okteto/python:3",
 		path:    "/usr/src/app",
@@ -225,7 +286,7 @@ func init() {
 	languageDefaults[Csharp] = languageDefault{
 		image:   "okteto/dotnetcore:3",
 		path:    "/usr/src/app",
-		command: []string{"bash"},
+		command: []string{"dotnet", "watch", "run"},
 		environment: []model.EnvVar{
 			{
 				Name:  "ASPNETCORE_ENVIRONMENT",
@@ -240,6 +301,11 @@ func init() {
 				Value: "0",
 			},
 		},
+		volumes: []model.Volume{
+			{
+				RemotePath: "/root/.nuget/packages",
+			},
+		},
 		forward: []forward.Forward{},
 		remote:  22000,
 	}
@@ -322,12 +388,17 @@ func GetDevDefaults(language, workdir string, imageConfig registry.ImageMetadata
 		}
 	}
 
+	command := vals.command
+	if language == Deno {
+		command = getDenoCommand(workdir, command)
+	}
+
 	dev := &model.Dev{
 		Image: &model.BuildInfo{
 			Name: vals.image,
 		},
 		Command: model.Command{
-			Values: vals.command,
+			Values: command,
 		},
 		Environment: vals.environment,
 		Volumes:     vals.volumes,
@@ -356,6 +427,33 @@ func GetDevDefaults(language, workdir string, imageConfig registry.ImageMetadata
 	return dev, nil
 }
 
+// getDenoCommand returns the command that runs the deno app of workdir with hot-reload:
+// the 'dev' task of its deno config file, or else its entrypoint. It returns defaultCommand if it finds none of them
+func getDenoCommand(workdir string, defaultCommand []string) []string {
+	for _, name := range []string{"deno.json", "deno.jsonc"} {
+		b, err := readFile(filepath.Join(workdir, name), 1<<20)
+		if err != nil {
+			continue
+		}
+		config := struct {
+			Tasks map[string]string `json:"tasks"`
+		}{}
+		if err := json.Unmarshal(b, &config); err != nil {
+			continue
+		}
+		if _, ok := config.Tasks["dev"]; ok {
+			return []string{"deno", "task", "dev"}
+		}
+	}
+
+	for _, entrypoint := range denoEntrypoints {
+		if _, err := os.Stat(filepath.Join(workdir, entrypoint)); err == nil {
+			return []string{"deno", "run", "--watch", "--allow-all", entrypoint}
+		}
+	}
+	return defaultCommand
+}
+
 // SetForwardDefaults set port forward default values for the specified language
 func SetForwardDefaults(dev *model.Dev, language string) {
 	language = NormalizeLanguage(language)
@@ -366,6 +464,11 @@ func SetForwardDefaults(dev *model.Dev, language string) {
 	dev.Forward = append(dev.Forward, vals...)
 }
 
+// IsSupported returns if the language or framework has defaults for okteto init
+func IsSupported(language string) bool {
+	return strings.EqualFold(language, Unrecognized) || NormalizeLanguage(language) != Unrecognized
+}
+
 func NormalizeLanguage(language string) string {
 	lower := strings.ToLower(language)
 	switch lower {
@@ -383,14 +486,16 @@ func NormalizeLanguage(language string) string {
 		return Ruby
 	case "go", "golang":
 		return golang
-	case "c#":
-		return Csharp
-	case "csharp":
+	case "c#", "csharp", "f#", "fsharp", "dotnet", ".net", "aspnet":
 		return Csharp
 	case "php":
 		return Php
-	case "rust":
+	case "rust", "cargo":
 		return Rust
+	case "elixir", "phoenix":
+		return Elixir
+	case "deno":
+		return Deno
 	default:
 		return Unrecognized
 	}
@@ -34,12 +34,33 @@ const (
 
 var (
 	errAnalysisTimeOut = errors.New("analysis timed out")
+
+	// buildToolFiles are the files at the root of a project that identify its language
+	buildToolFiles = map[string]string{
+		"Cargo.toml":  Rust,
+		"mix.exs":     Elixir,
+		"deno.json":   Deno,
+		"deno.jsonc":  Deno,
+		"global.json": Csharp,
+	}
+
+	// buildToolExtensions are the file extensions at the root of a project that identify its language
+	buildToolExtensions = map[string]string{
+		".csproj": Csharp,
+		".fsproj": Csharp,
+		".sln":    Csharp,
+	}
 )
 
 // this is all based on enry's main command https://github.com/src-d/enry
 
 // ProcessDirectory walks a directory and returns a list of guess for the programming language
 func ProcessDirectory(root string) (string, error) {
+	if language := detectBuildTool(root); language != "" {
+		oktetoLog.Infof("language '%s' inferred from the build tool of '%s'", language, root)
+		return language, nil
+	}
+
 	out := make(map[string][]string)
 	analysisTimeout := false
 
@@ -134,6 +155,27 @@ func ProcessDirectory(root string) (string, error) {
 	return NormalizeLanguage(chosen), nil
 }
 
+// detectBuildTool returns the language of the build tool files found at root, if any
+func detectBuildTool(root string) string {
+	files, err := os.ReadDir(root)
+	if err != nil {
+		oktetoLog.Infof("failed to read %s: %s", root, err)
+		return ""
+	}
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		if language, ok := buildToolFiles[f.Name()]; ok {
+			return language
+		}
+		if language, ok := buildToolExtensions[filepath.Ext(f.Name())]; ok {
+			return language
+		}
+	}
+	return ""
+}
+
 func refineJavaChoice(root string) string {
 	p := filepath.Join(root, "build.gradle")
 	_, err := os.Stat(p)
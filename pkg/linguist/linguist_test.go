@@ -16,6 +16,7 @@ package linguist
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -65,6 +66,26 @@ func TestProcessDirectory(t *testing.T) {
 			want:  Ruby,
 			files: []string{"Gemfile", "Rakefile", "application_controller.rb"},
 		},
+		{
+			name:  "rust",
+			want:  Rust,
+			files: []string{"Cargo.toml", "build.py"},
+		},
+		{
+			name:  "elixir",
+			want:  Elixir,
+			files: []string{"mix.exs", "router.ex"},
+		},
+		{
+			name:  "deno",
+			want:  Deno,
+			files: []string{"deno.json", "main.ts", "routes.ts"},
+		},
+		{
+			name:  "dotnet",
+			want:  Csharp,
+			files: []string{"api.fsproj", "Program.fs"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -95,3 +116,52 @@ func TestProcessDirectory(t *testing.T) {
 		})
 	}
 }
+
+func TestIsSupported(t *testing.T) {
+	for _, l := range []string{"phoenix", "Deno", "dotnet", "cargo", Unrecognized} {
+		if !IsSupported(l) {
+			t.Errorf("IsSupported(%s) = false, want true", l)
+		}
+	}
+	if IsSupported("cobol") {
+		t.Errorf("IsSupported(cobol) = true, want false")
+	}
+}
+
+func TestGetDenoCommand(t *testing.T) {
+	defaultCommand := []string{"deno", "run", "--watch", "--allow-all", "main.ts"}
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []string
+	}{
+		{
+			name:  "dev task",
+			files: map[string]string{"deno.json": `{"tasks": {"dev": "deno run --watch server.ts"}}`, "server.ts": ""},
+			want:  []string{"deno", "task", "dev"},
+		},
+		{
+			name:  "entrypoint",
+			files: map[string]string{"deno.json": `{"tasks": {"test": "deno test"}}`, "server.ts": ""},
+			want:  []string{"deno", "run", "--watch", "--allow-all", "server.ts"},
+		},
+		{
+			name:  "no entrypoint",
+			files: map[string]string{"routes.ts": ""},
+			want:  defaultCommand,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := getDenoCommand(dir, defaultCommand); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getDenoCommand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
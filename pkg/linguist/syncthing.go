@@ -280,6 +280,17 @@ core.*.*.*
 `)
 
 	stignore[Rust] = []byte(`.git
+target
+`)
+
+	stignore[Elixir] = []byte(`.git
+_build
+deps
+*.ez
+erl_crash.dump
+`)
+
+	stignore[Deno] = []byte(`.git
 `)
 
 }
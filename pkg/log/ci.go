@@ -0,0 +1,279 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// OktetoLogFormatEnvVar defines the format of the logs for CI systems. One of: ['plain', 'json']
+	OktetoLogFormatEnvVar = "OKTETO_LOG_FORMAT"
+)
+
+// ciNow returns the timestamp of the lines written by the CIWriter
+var ciNow = time.Now
+
+// getCIFormat returns the format set by OKTETO_LOG_FORMAT, or empty if it's not set or not supported
+func getCIFormat() string {
+	format := strings.ToLower(strings.TrimSpace(os.Getenv(OktetoLogFormatEnvVar)))
+	switch format {
+	case "":
+		return ""
+	case PlainFormat, JSONFormat:
+		return format
+	default:
+		fmt.Fprintf(os.Stderr, "%s '%s' is not supported. One of: ['%s', '%s']\n", OktetoLogFormatEnvVar, format, PlainFormat, JSONFormat)
+		return ""
+	}
+}
+
+// formatCILines returns a timestamped, level-tagged and color-free line for every line of the message
+func formatCILines(format, level, message string) string {
+	message = strings.TrimRight(ansiRegex.ReplaceAllString(message, ""), " \t\r\n")
+	if strings.TrimSpace(message) == "" {
+		return ""
+	}
+	now := ciNow()
+	result := &strings.Builder{}
+	for _, line := range strings.Split(message, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if format == JSONFormat {
+			msg, err := json.Marshal(jsonMessage{Level: level, Stage: log.stage, Message: line, Timestamp: now.Unix()})
+			if err != nil {
+				continue
+			}
+			result.Write(msg)
+			result.WriteString("\n")
+			continue
+		}
+		result.WriteString(now.UTC().Format(time.RFC3339))
+		result.WriteString(" ")
+		result.WriteString(fmt.Sprintf("%-5s", strings.ToUpper(level)))
+		if log.stage != "" {
+			result.WriteString(fmt.Sprintf(" [%s]", log.stage))
+		}
+		result.WriteString(" ")
+		result.WriteString(line)
+		result.WriteString("\n")
+	}
+	return result.String()
+}
+
+// ciFormatter formats the logrus entries of the debug and info levels as the CIWriter
+type ciFormatter struct {
+	format string
+}
+
+// Format formats the entry
+func (f *ciFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	level := entry.Level.String()
+	if entry.Level == logrus.WarnLevel {
+		level = WarningLevel
+	}
+	return []byte(formatCILines(f.format, level, entry.Message)), nil
+}
+
+// CIWriter writes timestamped, level-tagged and color-free lines in plain or json format for CI systems
+type CIWriter struct {
+	out    *logrus.Logger
+	file   *logrus.Entry
+	format string
+}
+
+// newCIWriter creates a new CIWriter
+func newCIWriter(out *logrus.Logger, file *logrus.Entry, format string) *CIWriter {
+	out.SetFormatter(&ciFormatter{format: format})
+	return &CIWriter{
+		out:    out,
+		file:   file,
+		format: format,
+	}
+}
+
+func (w *CIWriter) writeLines(writer io.Writer, level, message string) {
+	lines := formatCILines(w.format, level, message)
+	if lines == "" {
+		return
+	}
+	fmt.Fprint(writer, lines)
+	if writer == w.out.Out {
+		if msg := convertToJSON(level, log.stage, message); msg != "" {
+			log.buf.WriteString(msg)
+			log.buf.WriteString("\n")
+		}
+	}
+}
+
+// Debug writes a debug-level log
+func (w *CIWriter) Debug(args ...interface{}) {
+	w.out.Debug(args...)
+	if log.file != nil {
+		log.file.Debug(args...)
+	}
+}
+
+// Debugf writes a debug-level log with a format
+func (w *CIWriter) Debugf(format string, args ...interface{}) {
+	w.out.Debugf(format, args...)
+	if log.file != nil {
+		log.file.Debugf(format, args...)
+	}
+}
+
+// Info writes a info-level log
+func (w *CIWriter) Info(args ...interface{}) {
+	w.out.Info(args...)
+	if log.file != nil {
+		log.file.Info(args...)
+	}
+}
+
+// Infof writes a info-level log with a format
+func (w *CIWriter) Infof(format string, args ...interface{}) {
+	w.out.Infof(format, args...)
+	if log.file != nil {
+		log.file.Infof(format, args...)
+	}
+}
+
+// Error writes a error-level log
+func (w *CIWriter) Error(args ...interface{}) {
+	w.out.Error(args...)
+	if log.file != nil {
+		log.file.Error(args...)
+	}
+}
+
+// Errorf writes a error-level log with a format
+func (w *CIWriter) Errorf(format string, args ...interface{}) {
+	w.out.Errorf(format, args...)
+	if log.file != nil {
+		log.file.Errorf(format, args...)
+	}
+}
+
+// Fatalf writes a error-level log with a format
+func (w *CIWriter) Fatalf(format string, args ...interface{}) {
+	if log.file != nil {
+		log.file.Errorf(format, args...)
+	}
+
+	w.out.Fatalf(format, args...)
+}
+
+// Green writes an info line
+func (w *CIWriter) Green(format string, args ...interface{}) {
+	w.writeLines(w.out.Out, InfoLevel, fmt.Sprintf(format, args...))
+}
+
+// Yellow writes a warning line
+func (w *CIWriter) Yellow(format string, args ...interface{}) {
+	w.writeLines(w.out.Out, WarningLevel, fmt.Sprintf(format, args...))
+}
+
+// Success writes an info line
+func (w *CIWriter) Success(format string, args ...interface{}) {
+	w.writeLines(w.out.Out, InfoLevel, fmt.Sprintf(format, args...))
+}
+
+// Information writes an info line
+func (w *CIWriter) Information(format string, args ...interface{}) {
+	w.writeLines(w.out.Out, InfoLevel, fmt.Sprintf(format, args...))
+}
+
+// Question writes the question as an info line. Questions can't be answered in json format
+func (w *CIWriter) Question(format string, args ...interface{}) error {
+	if w.format == JSONFormat {
+		return fmt.Errorf("can't ask questions on json mode")
+	}
+	w.writeLines(w.out.Out, InfoLevel, fmt.Sprintf(format, args...))
+	return nil
+}
+
+// Warning writes a warning line
+func (w *CIWriter) Warning(format string, args ...interface{}) {
+	w.writeLines(w.out.Out, WarningLevel, fmt.Sprintf(format, args...))
+}
+
+// FWarning writes a warning line into an specific writer
+func (w *CIWriter) FWarning(writer io.Writer, format string, args ...interface{}) {
+	w.writeLines(writer, WarningLevel, fmt.Sprintf(format, args...))
+}
+
+// Hint writes an info line
+func (w *CIWriter) Hint(format string, args ...interface{}) {
+	w.writeLines(w.out.Out, InfoLevel, fmt.Sprintf(format, args...))
+}
+
+// Fail writes an error line
+func (w *CIWriter) Fail(format string, args ...interface{}) {
+	w.writeLines(w.out.Out, ErrorLevel, fmt.Sprintf(format, args...))
+}
+
+// Println writes an info line
+func (w *CIWriter) Println(args ...interface{}) {
+	w.writeLines(w.out.Out, InfoLevel, fmt.Sprint(args...))
+}
+
+// FPrintln writes an info line into an specific writer
+func (w *CIWriter) FPrintln(writer io.Writer, args ...interface{}) {
+	w.writeLines(writer, InfoLevel, fmt.Sprint(args...))
+}
+
+// Print writes an info line
+func (w *CIWriter) Print(args ...interface{}) {
+	w.writeLines(w.out.Out, InfoLevel, fmt.Sprint(args...))
+}
+
+// Fprintf writes an info line with format into an specific writer
+func (w *CIWriter) Fprintf(writer io.Writer, format string, a ...interface{}) {
+	w.writeLines(writer, InfoLevel, fmt.Sprintf(format, a...))
+}
+
+// Printf writes an info line with format
+func (w *CIWriter) Printf(format string, a ...interface{}) {
+	w.writeLines(w.out.Out, InfoLevel, fmt.Sprintf(format, a...))
+}
+
+// IsInteractive checks if the writer is interactive
+func (*CIWriter) IsInteractive() bool {
+	return false
+}
+
+// AddToBuffer logs into the buffer. The lines are also written in json format, as the JSONWriter does
+func (w *CIWriter) AddToBuffer(level, format string, a ...interface{}) {
+	msg := fmt.Sprintf(format, a...)
+	if w.format == JSONFormat {
+		w.writeLines(w.out.Out, level, msg)
+		return
+	}
+	if msg = convertToJSON(level, log.stage, msg); msg != "" {
+		log.buf.WriteString(msg)
+		log.buf.WriteString("\n")
+	}
+}
+
+// Write writes the lines of the streamed logs of the build and deploy commands
+func (w *CIWriter) Write(p []byte) (n int, err error) {
+	w.writeLines(w.out.Out, InfoLevel, redactChunk(p))
+	return len(p), nil
+}
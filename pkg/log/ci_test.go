@@ -0,0 +1,108 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func setCIFormat(t *testing.T, format string) *bytes.Buffer {
+	t.Helper()
+	noColor := color.NoColor
+	t.Setenv(OktetoLogFormatEnvVar, format)
+	ciNow = func() time.Time {
+		return time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+	}
+	t.Cleanup(func() {
+		ciNow = time.Now
+		color.NoColor = noColor
+		t.Setenv(OktetoLogFormatEnvVar, "")
+		Init(logrus.WarnLevel)
+	})
+	Init(logrus.WarnLevel)
+	out := &bytes.Buffer{}
+	SetOutput(out)
+	return out
+}
+
+func TestCIPlainFormat(t *testing.T) {
+	out := setCIFormat(t, "plain")
+	SetOutputFormat(TTYFormat)
+	assert.Equal(t, PlainFormat, GetOutputFormat())
+	assert.False(t, IsInteractive())
+
+	Information("Building %s", BlueString("api"))
+	Warning("image not found")
+	Fail("build failed:\nstep 2")
+	Println()
+	Infof("hidden at the warn level")
+	SetStage("Deploy")
+	Println("deploying")
+	Debugf("hidden at the warn level")
+	SetStage("")
+
+	assert.Equal(t, `2023-06-01T10:00:00Z INFO  Building api
+2023-06-01T10:00:00Z WARN  image not found
+2023-06-01T10:00:00Z ERROR build failed:
+2023-06-01T10:00:00Z ERROR step 2
+2023-06-01T10:00:00Z INFO  [Deploy] deploying
+`, out.String())
+}
+
+func TestCIJSONFormat(t *testing.T) {
+	out := setCIFormat(t, "json")
+	SetOutputFormat(PlainFormat)
+	SetLevel(InfoLevel)
+	assert.Equal(t, JSONFormat, GetOutputFormat())
+
+	Success("image built")
+	Infof("pushing image")
+	_, err := GetOutputWriter().Write([]byte("#1 [internal] load build definition\n#2 DONE 0.1s\n"))
+	assert.NoError(t, err)
+	assert.Error(t, Question("continue?"))
+
+	assert.Equal(t, `{"level":"info","stage":"","message":"image built","timestamp":1685613600}
+{"level":"info","stage":"","message":"pushing image","timestamp":1685613600}
+{"level":"info","stage":"","message":"#1 [internal] load build definition","timestamp":1685613600}
+{"level":"info","stage":"","message":"#2 DONE 0.1s","timestamp":1685613600}
+`, out.String())
+}
+
+func TestCIFormatWithExplicitOutputFormat(t *testing.T) {
+	out := setCIFormat(t, "plain")
+	SetExplicitOutputFormat(JSONFormat)
+	SetLevel(InfoLevel)
+	assert.Equal(t, JSONFormat, GetOutputFormat())
+	assert.False(t, IsCIFormat())
+
+	Information("deploying")
+	assert.Contains(t, out.String(), `"message":"deploying"`)
+	assert.NotContains(t, out.String(), "2023-06-01T10:00:00Z INFO")
+}
+
+func TestCIFormatIsNotSet(t *testing.T) {
+	setCIFormat(t, "yaml")
+	SetOutputFormat(PlainFormat)
+	assert.False(t, IsCIFormat())
+	assert.IsType(t, &PlainWriter{}, GetOutputWriter())
+
+	SetOutputFormat(SilentFormat)
+	assert.IsType(t, &SilentWriter{}, GetOutputWriter())
+}
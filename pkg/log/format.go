@@ -62,6 +62,10 @@ const (
 )
 
 func (l *logger) getWriter(format string) OktetoWriter {
+	if l.ciFormat != "" && format != SilentFormat {
+		l.outputMode = l.ciFormat
+		return newCIWriter(l.out, l.file, l.ciFormat)
+	}
 	switch format {
 	case TTYFormat:
 		l.outputMode = TTYFormat
@@ -296,7 +296,10 @@ func (w *JSONWriter) AddToBuffer(level, format string, a ...interface{}) {
 
 // Write logs into the buffer but does not print anything
 func (w *JSONWriter) Write(p []byte) (n int, err error) {
-	msg := string(p)
+	msg := redactChunk(p)
+	if msg == "" {
+		return len(p), nil
+	}
 	msg = convertToJSON(InfoLevel, log.stage, msg)
 	if msg != "" {
 		if _, err := w.out.Out.Write([]byte("")); err != nil {
@@ -19,10 +19,12 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/fatih/color"
 	"github.com/google/uuid"
@@ -71,6 +73,10 @@ type logger struct {
 
 	stage      string
 	outputMode string
+	// ciFormat is the format set by OKTETO_LOG_FORMAT, which overrides the output format of every command unless it's set explicitly
+	ciFormat string
+	// noColor is the value of color.NoColor before OKTETO_LOG_FORMAT disabled the colors
+	noColor bool
 
 	buf *bytes.Buffer
 
@@ -78,6 +84,19 @@ type logger struct {
 	isMasked    bool
 	replacer    *strings.Replacer
 
+	// maskedSecrets are masked even when the masking of the variables is disabled
+	maskedSecrets  []string
+	maskedPatterns []*regexp.Regexp
+	// maskedEnvVars are the env vars already checked for secret values
+	maskedEnvVars map[string]bool
+	// replacerWords are the words replaced by replacer, longest first
+	replacerWords []string
+	maskMu        sync.RWMutex
+
+	// pendingOutput is the end of the last chunk written to the output, held back because it could be the beginning of a masked word
+	pendingOutput string
+	pendingMu     sync.Mutex
+
 	spinner *spinnerLogger
 }
 
@@ -96,8 +115,14 @@ func init() {
 func Init(level logrus.Level) {
 	log.out.SetOutput(os.Stdout)
 	log.out.SetLevel(level)
+	log.ciFormat = getCIFormat()
+	if log.ciFormat != "" {
+		log.noColor = color.NoColor
+		color.NoColor = true
+	}
 	log.writer = log.getWriter(TTYFormat)
 	log.maskedWords = []string{}
+	initMasking()
 	log.buf = &bytes.Buffer{}
 	log.spinner = &spinnerLogger{
 		sp:             newSpinner(),
@@ -166,6 +191,37 @@ func SetOutputFormat(format string) {
 	log.writer = log.getWriter(format)
 }
 
+// SetExplicitOutputFormat sets the output format chosen by the user, which takes precedence over OKTETO_LOG_FORMAT
+func SetExplicitOutputFormat(format string) {
+	if log.ciFormat != "" {
+		log.ciFormat = ""
+		color.NoColor = log.noColor
+	}
+	SetOutputFormat(format)
+}
+
+// RedirectOutput writes the logs to output in plain format and disables the spinner until the returned function is called
+func RedirectOutput(output io.Writer) func() {
+	previousOutput := log.out.Out
+	previousFormat := log.outputMode
+	previousSpinnerSupport := log.spinner.spinnerSupport
+
+	StopSpinner()
+	log.spinner.spinnerSupport = false
+	log.out.SetOutput(output)
+	log.writer = log.getWriter(PlainFormat)
+	return func() {
+		log.out.SetOutput(previousOutput)
+		log.writer = log.getWriter(previousFormat)
+		log.spinner.spinnerSupport = previousSpinnerSupport
+	}
+}
+
+// IsCIFormat returns true if the logs are written as timestamped lines for CI systems because of OKTETO_LOG_FORMAT
+func IsCIFormat() bool {
+	return log.ciFormat != ""
+}
+
 // GetOutputWriter sets the output format
 func GetOutputWriter() OktetoWriter {
 	return log.writer
@@ -183,47 +239,47 @@ func IsDebug() bool {
 
 // Debug writes a debug-level log
 func Debug(args ...interface{}) {
-	log.writer.Debug(args...)
+	log.writer.Debug(redact(args))
 }
 
 // Debugf writes a debug-level log with a format
 func Debugf(format string, args ...interface{}) {
-	log.writer.Debugf(format, args...)
+	log.writer.Debugf("%s", redactf(format, args))
 }
 
 // Info writes a info-level log
 func Info(args ...interface{}) {
-	log.writer.Info(args...)
+	log.writer.Info(redact(args))
 }
 
 // Infof writes a info-level log with a format
 func Infof(format string, args ...interface{}) {
-	log.writer.Infof(format, args...)
+	log.writer.Infof("%s", redactf(format, args))
 }
 
 // Error writes a error-level log
 func Error(args ...interface{}) {
-	log.writer.Error(args...)
+	log.writer.Error(redact(args))
 }
 
 // Errorf writes a error-level log with a format
 func Errorf(format string, args ...interface{}) {
-	log.writer.Errorf(format, args...)
+	log.writer.Errorf("%s", redactf(format, args))
 }
 
 // Fatalf writes a error-level log with a format
 func Fatalf(format string, args ...interface{}) {
-	log.writer.Fatalf(format, args...)
+	log.writer.Fatalf("%s", redactf(format, args))
 }
 
 // Yellow writes a line in yellow
 func Yellow(format string, args ...interface{}) {
-	log.writer.Yellow(format, args...)
+	log.writer.Yellow("%s", redactf(format, args))
 }
 
 // Green writes a line in green
 func Green(format string, args ...interface{}) {
-	log.writer.Green(format, args...)
+	log.writer.Green("%s", redactf(format, args))
 }
 
 // BlueString returns a string in blue
@@ -243,39 +299,39 @@ func BlueBackgroundString(format string, args ...interface{}) string {
 
 // Success prints a message with the success symbol first, and the text in green
 func Success(format string, args ...interface{}) {
-	log.writer.Success(format, args...)
+	log.writer.Success("%s", redactf(format, args))
 }
 
 // Information prints a message with the information symbol first, and the text in blue
 func Information(format string, args ...interface{}) {
-	log.writer.Information(format, args...)
+	log.writer.Information("%s", redactf(format, args))
 }
 
 // Question prints a message with the question symbol first, and the text in magenta
 func Question(format string, args ...interface{}) error {
-	return log.writer.Question(format, args...)
+	return log.writer.Question("%s", redactf(format, args))
 }
 
 // Warning prints a message with the warning symbol first, and the text in yellow
 func Warning(format string, args ...interface{}) {
-	log.writer.Warning(format, args...)
+	log.writer.Warning("%s", redactf(format, args))
 }
 
 // FWarning prints a message with the warning symbol first, and the text in yellow to a specific writer
 func FWarning(w io.Writer, format string, args ...interface{}) {
-	log.writer.FWarning(w, format, args...)
+	log.writer.FWarning(w, "%s", redactf(format, args))
 }
 
 // Hint prints a message with the text in blue
 func Hint(format string, args ...interface{}) {
-	log.writer.Hint(format, args...)
+	log.writer.Hint("%s", redactf(format, args))
 }
 
 // Fail prints a message with the error symbol first, and the text in red
 func Fail(format string, args ...interface{}) {
 	msg := fmt.Sprintf(format, args...)
 	msg = redactMessage(msg)
-	log.writer.Fail(msg)
+	log.writer.Fail("%s", msg)
 }
 
 // Println writes a line with colors
@@ -311,35 +367,62 @@ func IsInteractive() bool {
 	return log.writer.IsInteractive()
 }
 
-// AddMaskedWord adds a new word to be redacted
+// AddMaskedWord adds a new word to be redacted while the masking is enabled
 func AddMaskedWord(word string) {
 	if strings.TrimSpace(word) != "" {
+		log.maskMu.Lock()
+		defer log.maskMu.Unlock()
 		log.maskedWords = append(log.maskedWords, word)
+		updateReplacer()
 	}
 }
 
 // EnableMasking starts redacting all variables
 func EnableMasking() {
+	log.maskMu.Lock()
+	defer log.maskMu.Unlock()
 	log.isMasked = true
-	sort.Slice(log.maskedWords, func(i, j int) bool {
-		return len(log.maskedWords[i]) > len(log.maskedWords[j])
-	})
-	oldnew := []string{}
-	for _, maskWord := range log.maskedWords {
-		oldnew = append(oldnew, maskWord)
-		oldnew = append(oldnew, "***")
-	}
-	log.replacer = strings.NewReplacer(oldnew...)
+	updateReplacer()
 }
 
 // DisableMasking will stop showing secrets and vars
 func DisableMasking() {
+	log.maskMu.Lock()
+	defer log.maskMu.Unlock()
 	log.isMasked = false
+	updateReplacer()
 }
 
-func redactMessage(message string) string {
+// updateReplacer builds the replacer of the masked secrets and, while the masking is enabled, the masked words.
+// It must be called with maskMu held
+func updateReplacer() {
+	words := append([]string{}, log.maskedSecrets...)
 	if log.isMasked {
-		return log.replacer.Replace(message)
+		words = append(words, log.maskedWords...)
+	}
+	// longer words are replaced first, in case a word contains another one
+	sort.SliceStable(words, func(i, j int) bool {
+		return len(words[i]) > len(words[j])
+	})
+	oldnew := []string{}
+	for _, maskWord := range words {
+		oldnew = append(oldnew, maskWord)
+		oldnew = append(oldnew, redactedValue)
+	}
+	log.replacerWords = words
+	log.replacer = strings.NewReplacer(oldnew...)
+}
+
+func redactMessage(message string) string {
+	if message == "" {
+		return message
+	}
+	maskSecretEnvVars()
+	log.maskMu.RLock()
+	defer log.maskMu.RUnlock()
+	message = log.replacer.Replace(message)
+	for _, re := range log.maskedPatterns {
+		message = redactPattern(re, message)
 	}
 	return message
 }
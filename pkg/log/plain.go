@@ -229,5 +229,5 @@ func (*PlainWriter) AddToBuffer(level, format string, a ...interface{}) {
 
 // Write logs into the buffer but does not print anything
 func (w *PlainWriter) Write(p []byte) (n int, err error) {
-	return w.out.Out.Write(p)
+	return writeRedacted(w.out.Out, p)
 }
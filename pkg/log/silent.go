@@ -181,5 +181,5 @@ func (*SilentWriter) AddToBuffer(level, format string, a ...interface{}) {
 
 // Write logs into the buffer but does not print anything
 func (w *SilentWriter) Write(p []byte) (n int, err error) {
-	return w.buffer.Write(p)
+	return writeRedacted(w.buffer, p)
 }
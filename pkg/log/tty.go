@@ -254,5 +254,5 @@ func (*TTYWriter) AddToBuffer(level, format string, a ...interface{}) {
 
 // Write logs into the buffer but does not print anything
 func (w *TTYWriter) Write(p []byte) (n int, err error) {
-	return w.out.Out.Write(p)
+	return writeRedacted(w.out.Out, p)
 }
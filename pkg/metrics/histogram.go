@@ -0,0 +1,116 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"math"
+	"time"
+)
+
+// defaultBounds are the upper bounds in seconds of the buckets of the histograms. Observations greater than the last bound go to an extra bucket
+var defaultBounds = []float64{1, 2, 5, 10, 20, 30, 60, 120, 300, 600, 1200}
+
+// Histogram counts the observations of a metric in buckets of durations
+type Histogram struct {
+	// Bounds are the upper bounds in seconds of the buckets
+	Bounds []float64 `json:"bounds"`
+	// Counts has one count per bound plus the count of the observations greater than the last bound
+	Counts []uint64 `json:"counts"`
+	// Count is the number of observations
+	Count uint64 `json:"count"`
+	// Sum is the sum in seconds of the observations
+	Sum float64 `json:"sum"`
+	// Min is the lowest observation in seconds
+	Min float64 `json:"min"`
+	// Max is the highest observation in seconds
+	Max float64 `json:"max"`
+	// LastObserved is when the last observation was recorded
+	LastObserved time.Time `json:"lastObserved"`
+}
+
+func newHistogram() *Histogram {
+	return &Histogram{
+		Bounds: append([]float64{}, defaultBounds...),
+		Counts: make([]uint64, len(defaultBounds)+1),
+	}
+}
+
+// isValid returns if the histogram was recorded with the current bounds
+func (h *Histogram) isValid() bool {
+	if len(h.Bounds) != len(defaultBounds) || len(h.Counts) != len(defaultBounds)+1 {
+		return false
+	}
+	for i := range h.Bounds {
+		if h.Bounds[i] != defaultBounds[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (h *Histogram) observe(d time.Duration, now time.Time) {
+	seconds := d.Seconds()
+	i := 0
+	for i < len(h.Bounds) && seconds > h.Bounds[i] {
+		i++
+	}
+	h.Counts[i]++
+
+	if h.Count == 0 || seconds < h.Min {
+		h.Min = seconds
+	}
+	if seconds > h.Max {
+		h.Max = seconds
+	}
+	h.Count++
+	h.Sum += seconds
+	h.LastObserved = now
+}
+
+// Mean returns the average of the observations
+func (h *Histogram) Mean() time.Duration {
+	if h.Count == 0 {
+		return 0
+	}
+	return toDuration(h.Sum / float64(h.Count))
+}
+
+// Quantile returns an estimation of the q quantile (0 <= q <= 1) of the observations.
+// It returns the upper bound of the bucket that contains the quantile, capped by the highest observation
+func (h *Histogram) Quantile(q float64) time.Duration {
+	if h.Count == 0 {
+		return 0
+	}
+	rank := uint64(math.Ceil(q * float64(h.Count)))
+	if rank == 0 {
+		return toDuration(h.Min)
+	}
+
+	cumulative := uint64(0)
+	for i, c := range h.Counts {
+		cumulative += c
+		if cumulative < rank {
+			continue
+		}
+		if i == len(h.Bounds) || h.Bounds[i] > h.Max {
+			return toDuration(h.Max)
+		}
+		return toDuration(h.Bounds[i])
+	}
+	return toDuration(h.Max)
+}
+
+func toDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,89 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistogramObserve(t *testing.T) {
+	h := newHistogram()
+	now := time.Now()
+	for _, d := range []time.Duration{500 * time.Millisecond, 3 * time.Second, 4 * time.Second, time.Hour} {
+		h.observe(d, now)
+	}
+
+	assert.Equal(t, uint64(4), h.Count)
+	assert.Equal(t, 0.5, h.Min)
+	assert.Equal(t, 3600.0, h.Max)
+	assert.Equal(t, 3607.5, h.Sum)
+	assert.Equal(t, now, h.LastObserved)
+	assert.Equal(t, []uint64{1, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0, 1}, h.Counts)
+}
+
+func TestHistogramQuantile(t *testing.T) {
+	var tests = []struct {
+		name         string
+		observations []time.Duration
+		q            float64
+		expected     time.Duration
+	}{
+		{
+			name:     "no observations",
+			q:        0.5,
+			expected: 0,
+		},
+		{
+			name:         "median",
+			observations: []time.Duration{time.Second, 3 * time.Second, 4 * time.Second, 15 * time.Second},
+			q:            0.5,
+			expected:     5 * time.Second,
+		},
+		{
+			name:         "capped by the maximum",
+			observations: []time.Duration{time.Second, 3 * time.Second, 4 * time.Second, 15 * time.Second},
+			q:            0.99,
+			expected:     15 * time.Second,
+		},
+		{
+			name:         "greater than the last bound",
+			observations: []time.Duration{time.Hour},
+			q:            0.9,
+			expected:     time.Hour,
+		},
+		{
+			name:         "zero quantile",
+			observations: []time.Duration{3 * time.Second, 4 * time.Second},
+			q:            0,
+			expected:     3 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHistogram()
+			for _, d := range tt.observations {
+				h.observe(d, time.Now())
+			}
+			assert.Equal(t, tt.expected, h.Quantile(tt.q))
+		})
+	}
+}
+
+func TestHistogramIsValid(t *testing.T) {
+	assert.True(t, newHistogram().isValid())
+	assert.False(t, (&Histogram{Bounds: []float64{1, 2}, Counts: []uint64{0, 0, 0}}).isValid())
+}
@@ -0,0 +1,218 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics records local metrics of the execution of okteto commands.
+// They are opt-in, never leave the machine and are summarized by 'okteto metrics report'
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+)
+
+// Name is the name of a local metric
+type Name string
+
+const (
+	// UpActivation is the time from running 'okteto up' until the development container is ready
+	UpActivation Name = "up.activation"
+
+	// UpSync is the time of the initial file synchronization of 'okteto up'
+	UpSync Name = "up.sync"
+
+	// Build is the time to build an image of the manifest
+	Build Name = "build"
+)
+
+var mu sync.Mutex
+
+// File is the content of the local metrics file
+type File struct {
+	// Repositories are the histograms of each metric by repository
+	Repositories map[string]map[Name]*Histogram `json:"repositories"`
+}
+
+// IsEnabled returns if the local metrics are recorded
+func IsEnabled() bool {
+	return okteto.ContextStore().LocalMetrics
+}
+
+// Enable starts recording the local metrics. The preference is saved in the okteto context config
+func Enable() error {
+	return savePreference(true)
+}
+
+// Disable stops recording the local metrics. The metrics already recorded are kept
+func Disable() error {
+	return savePreference(false)
+}
+
+func savePreference(enabled bool) error {
+	ctxStore := okteto.ContextStore()
+	if ctxStore.LocalMetrics == enabled {
+		return nil
+	}
+	ctxStore.LocalMetrics = enabled
+	return okteto.NewContextConfigWriter().Write()
+}
+
+// Observe records an observation of a metric for the repository of the working directory, if the local metrics are enabled
+func Observe(name Name, d time.Duration) {
+	if !IsEnabled() {
+		return
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		oktetoLog.Infof("failed to record the local metric %s: %s", name, err)
+		return
+	}
+	if err := observe(config.GetLocalMetricsPath(), getRepository(wd), name, d, time.Now().UTC()); err != nil {
+		oktetoLog.Infof("failed to record the local metric %s: %s", name, err)
+	}
+}
+
+func observe(path, repository string, name Name, d time.Duration, now time.Time) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	f, err := read(path)
+	if err != nil {
+		oktetoLog.Infof("discarding the local metrics: %s", err)
+		f = &File{}
+	}
+	if f.Repositories == nil {
+		f.Repositories = map[string]map[Name]*Histogram{}
+	}
+	if f.Repositories[repository] == nil {
+		f.Repositories[repository] = map[Name]*Histogram{}
+	}
+	h := f.Repositories[repository][name]
+	if h == nil || !h.isValid() {
+		h = newHistogram()
+		f.Repositories[repository][name] = h
+	}
+	h.observe(d, now)
+
+	b, err := json.MarshalIndent(f, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0600)
+}
+
+// Read returns the local metrics recorded by this machine
+func Read() (*File, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	return read(config.GetLocalMetricsPath())
+}
+
+func read(path string) (*File, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &File{}, nil
+		}
+		return nil, fmt.Errorf("failed to read the local metrics: %w", err)
+	}
+
+	f := &File{}
+	if err := json.Unmarshal(b, f); err != nil {
+		return nil, fmt.Errorf("failed to read the local metrics: %w", err)
+	}
+	return f, nil
+}
+
+// Reset deletes the local metrics recorded by this machine
+func Reset() error {
+	mu.Lock()
+	defer mu.Unlock()
+	if err := os.Remove(config.GetLocalMetricsPath()); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete the local metrics: %w", err)
+	}
+	return nil
+}
+
+// Summary is the summary of a metric of a repository. Durations are in seconds
+type Summary struct {
+	Repository   string    `json:"repository"`
+	Metric       Name      `json:"metric"`
+	Count        uint64    `json:"count"`
+	Mean         float64   `json:"meanSeconds"`
+	P50          float64   `json:"p50Seconds"`
+	P90          float64   `json:"p90Seconds"`
+	P99          float64   `json:"p99Seconds"`
+	Min          float64   `json:"minSeconds"`
+	Max          float64   `json:"maxSeconds"`
+	LastObserved time.Time `json:"lastObserved"`
+}
+
+// Summarize returns the summaries of the metrics of the repositories, sorted by repository and metric.
+// If repository isn't empty, only the metrics of that repository are returned
+func (f *File) Summarize(repository string) []Summary {
+	result := []Summary{}
+	for repo, histograms := range f.Repositories {
+		if repository != "" && repo != repository {
+			continue
+		}
+		for name, h := range histograms {
+			if h == nil || h.Count == 0 {
+				continue
+			}
+			result = append(result, Summary{
+				Repository:   repo,
+				Metric:       name,
+				Count:        h.Count,
+				Mean:         h.Mean().Seconds(),
+				P50:          h.Quantile(0.5).Seconds(),
+				P90:          h.Quantile(0.9).Seconds(),
+				P99:          h.Quantile(0.99).Seconds(),
+				Min:          h.Min,
+				Max:          h.Max,
+				LastObserved: h.LastObserved,
+			})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Repository != result[j].Repository {
+			return result[i].Repository < result[j].Repository
+		}
+		return result[i].Metric < result[j].Metric
+	})
+	return result
+}
+
+// getRepository returns the name used to group the metrics of a folder: the url of its git remote without credentials, or the folder name
+func getRepository(path string) string {
+	repoURL, err := model.GetRepositoryURL(path)
+	if err != nil || repoURL == "" {
+		return filepath.Base(path)
+	}
+	if u, err := url.Parse(repoURL); err == nil && u.User != nil {
+		u.User = nil
+		repoURL = u.String()
+	}
+	return strings.TrimSuffix(repoURL, ".git")
+}
@@ -21,6 +21,10 @@ const (
 	// AppReplicasAnnotation indicates the number of replicas before dev mode was activated
 	AppReplicasAnnotation = "dev.okteto.com/replicas"
 
+	// DaemonSetScaledDownNodeSelector is the node selector that scales down a daemonset while the development container is active.
+	// No node has this label, so the daemonset doesn't run any pod
+	DaemonSetScaledDownNodeSelector = "dev.okteto.com/daemonset-scaled-down"
+
 	// InteractiveDevLabel indicates the interactive dev pod
 	InteractiveDevLabel = "interactive.dev.okteto.com"
 
@@ -33,9 +37,15 @@ const (
 	// DetachedDevLabel indicates the detached dev pods
 	DetachedDevLabel = "detached.dev.okteto.com"
 
+	// JobDevLabel selects the dev pods of a job or a cronjob
+	JobDevLabel = "job.dev.okteto.com"
+
 	// DeploymentRevisionAnnotation indicates the revision when the development container was activated
 	DeploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
 
+	// RestartedAtAnnotation is the pod template annotation changed to restart the pods of a workload following its update strategy
+	RestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
 	// OktetoRevisionAnnotation indicates the revision when the development container was activated
 	OktetoRevisionAnnotation = "dev.okteto.com/revision"
 
@@ -57,6 +67,15 @@ const (
 	// OktetoRepositoryAnnotation indicates the git repo url with the source code of this component
 	OktetoRepositoryAnnotation = "dev.okteto.com/repository"
 
+	// OktetoGitBranchAnnotation indicates the git branch of the source code deployed in this resource
+	OktetoGitBranchAnnotation = "dev.okteto.com/git-branch"
+
+	// OktetoGitCommitAnnotation indicates the git commit of the source code deployed in this resource
+	OktetoGitCommitAnnotation = "dev.okteto.com/git-commit"
+
+	// OktetoGitDirtyAnnotation indicates if the source code deployed in this resource had uncommitted changes
+	OktetoGitDirtyAnnotation = "dev.okteto.com/git-dirty"
+
 	// OktetoDevNameAnnotation indicates the name of the dev to be deployed
 	OktetoDevNameAnnotation = "dev.okteto.com/name"
 
@@ -75,6 +94,9 @@ const (
 	// DeployedByLabel indicates the service account that deployed an object
 	DeployedByLabel = "dev.okteto.com/deployed-by"
 
+	// RunJobLabel indicates the name of the manifest job executed by 'okteto run'
+	RunJobLabel = "dev.okteto.com/run-job"
+
 	// GitDeployLabel indicates the object is an app
 	GitDeployLabel = "dev.okteto.com/git-deploy"
 
@@ -134,6 +156,24 @@ const (
 	SyncthingSubPath = "syncthing"
 	// DefaultSyncthingRescanInterval default syncthing re-scan interval
 	DefaultSyncthingRescanInterval = 300
+	// SyncCompressionAlways compresses all the data sent by syncthing
+	SyncCompressionAlways = "always"
+	// SyncCompressionMetadata compresses only the metadata sent by syncthing
+	SyncCompressionMetadata = "metadata"
+	// SyncCompressionNever disables the syncthing compression
+	SyncCompressionNever = "never"
+	// SyncCRLFAuto keeps the line endings of the synchronized files and warns about the files with CRLF line endings. The files are only checked on Windows
+	SyncCRLFAuto = "auto"
+	// SyncCRLFKeep keeps the line endings of the synchronized files
+	SyncCRLFKeep = "keep"
+	// SyncCRLFConvert converts the CRLF line endings of the synchronized text files to LF before the initial synchronization
+	SyncCRLFConvert = "convert"
+	// OriginalWorkloadScaleDown scales the original workload to zero replicas while the development container is active
+	OriginalWorkloadScaleDown = "scaleDown"
+	// OriginalWorkloadClone keeps the replicas of the original workload running next to the development container
+	OriginalWorkloadClone = "clone"
+	// OriginalWorkloadUntouched doesn't modify the original workload, not even its labels and annotations
+	OriginalWorkloadUntouched = "untouched"
 	// RemoteSubPath subpath in the development container persistent volume for the remote data
 	RemoteSubPath = "okteto-remote"
 	// OktetoAutoCreateAnnotation indicates if the deployment was auto generatted by okteto up
@@ -200,6 +240,9 @@ const (
 	// SyncthingVersionEnvVar defines the syncthing version okteto should use
 	SyncthingVersionEnvVar = "OKTETO_SYNCTHING_VERSION"
 
+	// SyncthingPathEnvVar defines the path of a system-installed syncthing binary okteto should use instead of downloading it
+	SyncthingPathEnvVar = "OKTETO_SYNCTHING_PATH"
+
 	// OktetoSkipContextTestEnvVar if set skips the context test
 	OktetoSkipContextTestEnvVar = "OKTETO_SKIP_CONTEXT_TEST"
 
@@ -0,0 +1,169 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/model/forward"
+)
+
+const (
+	// DebugLanguageGo debugs go processes with delve
+	DebugLanguageGo = "go"
+	// DebugLanguageNode debugs node processes with the node inspector
+	DebugLanguageNode = "node"
+	// DebugLanguagePython debugs python processes with debugpy
+	DebugLanguagePython = "python"
+	// DebugLanguageJava debugs java processes with JDWP
+	DebugLanguageJava = "java"
+
+	nodeOptionsEnvVar     = "NODE_OPTIONS"
+	javaToolOptionsEnvVar = "JAVA_TOOL_OPTIONS"
+)
+
+var debugDefaultPorts = map[string]int{
+	DebugLanguageGo:     2345,
+	DebugLanguageNode:   9229,
+	DebugLanguagePython: 5678,
+	DebugLanguageJava:   5005,
+}
+
+// commands that start an interactive shell, where the debugger can't be injected into the command
+var shellCommands = map[string]bool{
+	"sh":   true,
+	"bash": true,
+	"zsh":  true,
+	"ash":  true,
+	"fish": true,
+}
+
+// Debug defines the debugger attached to the command of a development container
+type Debug struct {
+	Language string `json:"language,omitempty" yaml:"language,omitempty"`
+	Port     int    `json:"port,omitempty" yaml:"port,omitempty"`
+}
+
+func (d *Debug) validate() error {
+	if _, ok := debugDefaultPorts[d.Language]; !ok {
+		return fmt.Errorf("'debug.language' must be one of: %s, %s, %s, %s", DebugLanguageGo, DebugLanguageNode, DebugLanguagePython, DebugLanguageJava)
+	}
+	if d.Port <= 0 || d.Port > 65535 {
+		return fmt.Errorf("'debug.port' must be between 1 and 65535")
+	}
+	return nil
+}
+
+// setDebugDefaults injects the debugger into the command or the environment of the dev container and forwards its port.
+// It can be called more than once for the same dev container
+func (dev *Dev) setDebugDefaults() {
+	d := dev.Debug
+	d.Language = strings.ToLower(d.Language)
+	defaultPort, ok := debugDefaultPorts[d.Language]
+	if !ok {
+		return
+	}
+	if d.Port == 0 {
+		d.Port = defaultPort
+	}
+
+	switch d.Language {
+	case DebugLanguageGo:
+		dev.Command.Values = getDelveCommand(dev.Command.Values, d.Port)
+	case DebugLanguageNode:
+		dev.appendEnvironmentFlag(nodeOptionsEnvVar, "--inspect", fmt.Sprintf("--inspect=0.0.0.0:%d", d.Port))
+	case DebugLanguagePython:
+		dev.Command.Values = getDebugpyCommand(dev.Command.Values, d.Port)
+	case DebugLanguageJava:
+		dev.appendEnvironmentFlag(javaToolOptionsEnvVar, "-agentlib:jdwp", fmt.Sprintf("-agentlib:jdwp=transport=dt_socket,server=y,suspend=n,address=*:%d", d.Port))
+	}
+
+	for _, f := range dev.Forward {
+		if f.Local == d.Port {
+			return
+		}
+	}
+	dev.Forward = append(dev.Forward, forward.Forward{Local: d.Port, Remote: d.Port})
+}
+
+// IsDebuggerInCommand returns if the debugger is started by the command of the dev container.
+// Go and python debuggers can't be injected when the command starts a shell
+func (dev *Dev) IsDebuggerInCommand() bool {
+	if dev.Debug == nil {
+		return false
+	}
+	switch dev.Debug.Language {
+	case DebugLanguageGo:
+		return len(dev.Command.Values) > 0 && dev.Command.Values[0] == "dlv"
+	case DebugLanguagePython:
+		return len(dev.Command.Values) > 2 && dev.Command.Values[2] == "debugpy"
+	default:
+		return true
+	}
+}
+
+// getDelveCommand runs the command with delve: 'go run' commands are translated into 'dlv debug' and binaries into 'dlv exec'
+func getDelveCommand(command []string, port int) []string {
+	if len(command) == 0 || command[0] == "dlv" || shellCommands[filepath.Base(command[0])] {
+		return command
+	}
+	flags := []string{"--headless", fmt.Sprintf("--listen=:%d", port), "--api-version=2", "--accept-multiclient", "--continue"}
+	if command[0] == "go" && len(command) > 1 && command[1] == "run" {
+		result := append([]string{"dlv", "debug"}, flags...)
+		if len(command) > 2 {
+			result = append(result, command[2])
+		}
+		if len(command) > 3 {
+			result = append(result, "--")
+			result = append(result, command[3:]...)
+		}
+		return result
+	}
+	result := append([]string{"dlv", "exec"}, flags...)
+	result = append(result, command[0])
+	if len(command) > 1 {
+		result = append(result, "--")
+		result = append(result, command[1:]...)
+	}
+	return result
+}
+
+// getDebugpyCommand runs python commands with debugpy listening on the given port
+func getDebugpyCommand(command []string, port int) []string {
+	if len(command) == 0 || !strings.HasPrefix(filepath.Base(command[0]), "python") {
+		return command
+	}
+	if len(command) > 2 && command[1] == "-m" && command[2] == "debugpy" {
+		return command
+	}
+	result := []string{command[0], "-m", "debugpy", "--listen", fmt.Sprintf("0.0.0.0:%d", port)}
+	return append(result, command[1:]...)
+}
+
+// appendEnvironmentFlag adds the flag to the env var of the dev container unless there is already a flag with the same prefix
+func (dev *Dev) appendEnvironmentFlag(name, prefix, flag string) {
+	for i, e := range dev.Environment {
+		if e.Name != name {
+			continue
+		}
+		if strings.Contains(e.Value, prefix) {
+			return
+		}
+		dev.Environment[i].Value = strings.TrimSpace(fmt.Sprintf("%s %s", e.Value, flag))
+		return
+	}
+	dev.Environment = append(dev.Environment, EnvVar{Name: name, Value: flag})
+}
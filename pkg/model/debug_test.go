@@ -0,0 +1,162 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestDebugValidate(t *testing.T) {
+	var tests = []struct {
+		name    string
+		debug   *Debug
+		wantErr bool
+	}{
+		{
+			name:  "valid",
+			debug: &Debug{Language: DebugLanguageGo, Port: 2345},
+		},
+		{
+			name:    "unsupported language",
+			debug:   &Debug{Language: "ruby", Port: 1234},
+			wantErr: true,
+		},
+		{
+			name:    "invalid port",
+			debug:   &Debug{Language: DebugLanguageNode, Port: 70000},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.debug.validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSetDebugDefaults(t *testing.T) {
+	var tests = []struct {
+		name            string
+		dev             *Dev
+		expectedCommand []string
+		expectedEnv     Environment
+		expectedForward []forward.Forward
+		expectedPort    int
+	}{
+		{
+			name: "go run",
+			dev: &Dev{
+				Command: Command{Values: []string{"go", "run", "main.go", "--verbose"}},
+				Debug:   &Debug{Language: "Go"},
+			},
+			expectedCommand: []string{"dlv", "debug", "--headless", "--listen=:2345", "--api-version=2", "--accept-multiclient", "--continue", "main.go", "--", "--verbose"},
+			expectedForward: []forward.Forward{{Local: 2345, Remote: 2345}},
+			expectedPort:    2345,
+		},
+		{
+			name: "go binary",
+			dev: &Dev{
+				Command: Command{Values: []string{"/app/server"}},
+				Debug:   &Debug{Language: DebugLanguageGo, Port: 4000},
+			},
+			expectedCommand: []string{"dlv", "exec", "--headless", "--listen=:4000", "--api-version=2", "--accept-multiclient", "--continue", "/app/server"},
+			expectedForward: []forward.Forward{{Local: 4000, Remote: 4000}},
+			expectedPort:    4000,
+		},
+		{
+			name: "go shell",
+			dev: &Dev{
+				Command: Command{Values: []string{"bash"}},
+				Debug:   &Debug{Language: DebugLanguageGo},
+			},
+			expectedCommand: []string{"bash"},
+			expectedForward: []forward.Forward{{Local: 2345, Remote: 2345}},
+			expectedPort:    2345,
+		},
+		{
+			name: "node with existing options and forward",
+			dev: &Dev{
+				Command:     Command{Values: []string{"npm", "start"}},
+				Environment: Environment{{Name: "NODE_OPTIONS", Value: "--max-old-space-size=4096"}},
+				Forward:     []forward.Forward{{Local: 9229, Remote: 9230}},
+				Debug:       &Debug{Language: DebugLanguageNode},
+			},
+			expectedCommand: []string{"npm", "start"},
+			expectedEnv:     Environment{{Name: "NODE_OPTIONS", Value: "--max-old-space-size=4096 --inspect=0.0.0.0:9229"}},
+			expectedForward: []forward.Forward{{Local: 9229, Remote: 9230}},
+			expectedPort:    9229,
+		},
+		{
+			name: "python",
+			dev: &Dev{
+				Command: Command{Values: []string{"python3", "app.py"}},
+				Debug:   &Debug{Language: DebugLanguagePython},
+			},
+			expectedCommand: []string{"python3", "-m", "debugpy", "--listen", "0.0.0.0:5678", "app.py"},
+			expectedForward: []forward.Forward{{Local: 5678, Remote: 5678}},
+			expectedPort:    5678,
+		},
+		{
+			name: "java",
+			dev: &Dev{
+				Command: Command{Values: []string{"./gradlew", "bootRun"}},
+				Debug:   &Debug{Language: DebugLanguageJava},
+			},
+			expectedCommand: []string{"./gradlew", "bootRun"},
+			expectedEnv:     Environment{{Name: "JAVA_TOOL_OPTIONS", Value: "-agentlib:jdwp=transport=dt_socket,server=y,suspend=n,address=*:5005"}},
+			expectedForward: []forward.Forward{{Local: 5005, Remote: 5005}},
+			expectedPort:    5005,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.dev.setDebugDefaults()
+			// defaults are applied more than once when loading a manifest
+			tt.dev.setDebugDefaults()
+			assert.Equal(t, tt.expectedCommand, tt.dev.Command.Values)
+			assert.Equal(t, tt.expectedEnv, tt.dev.Environment)
+			assert.Equal(t, tt.expectedForward, tt.dev.Forward)
+			assert.Equal(t, tt.expectedPort, tt.dev.Debug.Port)
+		})
+	}
+}
+
+func TestDebugUnmarshal(t *testing.T) {
+	manifest := []byte(`name: api
+command: ["node", "index.js"]
+debug:
+  language: node
+  port: 9230`)
+	m, err := Read(manifest)
+	require.NoError(t, err)
+	require.Contains(t, m.Dev, "api")
+	dev := m.Dev["api"]
+	assert.Equal(t, &Debug{Language: DebugLanguageNode, Port: 9230}, dev.Debug)
+	assert.Contains(t, dev.Forward, forward.Forward{Local: 9230, Remote: 9230})
+	assert.True(t, dev.IsDebuggerInCommand())
+
+	out, err := yaml.Marshal(dev.Debug)
+	require.NoError(t, err)
+	assert.Equal(t, "language: node\nport: 9230\n", string(out))
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultReadinessTimeout is the time a service has to pass its readiness gate
+	defaultReadinessTimeout = 5 * time.Minute
+
+	// defaultReadinessInterval is the time between two attempts of the readiness gate
+	defaultReadinessInterval = 5 * time.Second
+)
+
+// DeployServices defines the services of the deploy section. Each service is deployed once all its dependencies are ready
+type DeployServices map[string]*DeployService
+
+// DeployService represents a service deployed by its own commands
+type DeployService struct {
+	Commands  []DeployCommand `json:"commands,omitempty" yaml:"commands,omitempty"`
+	DependsOn []string        `json:"depends_on,omitempty" yaml:"depends_on,omitempty"`
+	Readiness *ReadinessGate  `json:"readiness,omitempty" yaml:"readiness,omitempty"`
+}
+
+// ReadinessGate is a command that must succeed before the services that depend on a service are deployed
+type ReadinessGate struct {
+	Command  string        `json:"command,omitempty" yaml:"command,omitempty"`
+	Timeout  time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	Interval time.Duration `json:"interval,omitempty" yaml:"interval,omitempty"`
+}
+
+// GetTimeout returns the timeout of the readiness gate or its default value
+func (r *ReadinessGate) GetTimeout() time.Duration {
+	if r.Timeout != 0 {
+		return r.Timeout
+	}
+	return defaultReadinessTimeout
+}
+
+// GetInterval returns the interval of the readiness gate or its default value
+func (r *ReadinessGate) GetInterval() time.Duration {
+	if r.Interval != 0 {
+		return r.Interval
+	}
+	return defaultReadinessInterval
+}
+
+func (s DeployServices) validate() error {
+	for name, service := range s {
+		if service == nil || len(service.Commands) == 0 {
+			return fmt.Errorf("the service '%s' of the deploy section must define at least one command", name)
+		}
+		for _, dependency := range service.DependsOn {
+			if _, ok := s[dependency]; !ok {
+				return fmt.Errorf("the service '%s' depends on '%s', which is not defined in the deploy section", name, dependency)
+			}
+		}
+		if service.Readiness != nil {
+			if service.Readiness.Command == "" {
+				return fmt.Errorf("the field 'deploy.services.%s.readiness.command' is mandatory", name)
+			}
+			if service.Readiness.Timeout < 0 || service.Readiness.Interval < 0 {
+				return fmt.Errorf("the readiness gate of the service '%s' must have a positive timeout and interval", name)
+			}
+		}
+	}
+
+	cycle := getDependentCyclic(s.toGraph())
+	if len(cycle) == 1 {
+		return fmt.Errorf("manifest deploy validation failed: service '%s' is referenced on its dependencies", cycle[0])
+	} else if len(cycle) > 1 {
+		return fmt.Errorf("manifest deploy validation failed: cyclic dependency found between %s and %s", strings.Join(cycle[:len(cycle)-1], ", "), cycle[len(cycle)-1])
+	}
+	return nil
+}
+
+func (s DeployServices) toGraph() graph {
+	g := graph{}
+	for k, v := range s {
+		g[k] = v.DependsOn
+	}
+	return g
+}
+
+// GetDeployOrder returns the names of the services sorted so every service goes after its dependencies
+func (s DeployServices) GetDeployOrder() []string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := []string{}
+	added := map[string]bool{}
+	for len(result) < len(names) {
+		for _, name := range names {
+			if added[name] {
+				continue
+			}
+			if !areDependenciesAdded(s[name].DependsOn, added) {
+				continue
+			}
+			result = append(result, name)
+			added[name] = true
+		}
+	}
+	return result
+}
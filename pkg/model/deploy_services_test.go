@@ -0,0 +1,130 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestDeployServicesUnmarshal(t *testing.T) {
+	manifest := []byte(`
+deploy:
+  commands:
+    - make setup
+  services:
+    db:
+      commands:
+        - helm upgrade --install db charts/db
+      readiness: kubectl rollout status statefulset/db
+    api:
+      commands:
+        - name: deploy api
+          command: kubectl apply -f api
+      depends_on:
+        - db
+      readiness:
+        command: curl -f http://api:8080/healthz
+        timeout: 2m
+        interval: 10s
+`)
+	m := &Manifest{}
+	require.NoError(t, yaml.UnmarshalStrict(manifest, m))
+	assert.Equal(t, []DeployCommand{{Name: "make setup", Command: "make setup"}}, m.Deploy.Commands)
+	assert.Equal(t, DeployServices{
+		"db": {
+			Commands:  []DeployCommand{{Name: "helm upgrade --install db charts/db", Command: "helm upgrade --install db charts/db"}},
+			Readiness: &ReadinessGate{Command: "kubectl rollout status statefulset/db"},
+		},
+		"api": {
+			Commands:  []DeployCommand{{Name: "deploy api", Command: "kubectl apply -f api"}},
+			DependsOn: []string{"db"},
+			Readiness: &ReadinessGate{Command: "curl -f http://api:8080/healthz", Timeout: 2 * time.Minute, Interval: 10 * time.Second},
+		},
+	}, m.Deploy.Services)
+
+	assert.Equal(t, defaultReadinessTimeout, m.Deploy.Services["db"].Readiness.GetTimeout())
+	assert.Equal(t, defaultReadinessInterval, m.Deploy.Services["db"].Readiness.GetInterval())
+	assert.Equal(t, 2*time.Minute, m.Deploy.Services["api"].Readiness.GetTimeout())
+	assert.Equal(t, 10*time.Second, m.Deploy.Services["api"].Readiness.GetInterval())
+}
+
+func TestDeployServicesValidate(t *testing.T) {
+	command := []DeployCommand{{Name: "make", Command: "make"}}
+	var tests = []struct {
+		name      string
+		services  DeployServices
+		expectErr bool
+	}{
+		{
+			name:     "valid",
+			services: DeployServices{"db": {Commands: command, Readiness: &ReadinessGate{Command: "pg_isready"}}, "api": {Commands: command, DependsOn: []string{"db"}}},
+		},
+		{
+			name:      "no commands",
+			services:  DeployServices{"api": {}},
+			expectErr: true,
+		},
+		{
+			name:      "unknown dependency",
+			services:  DeployServices{"api": {Commands: command, DependsOn: []string{"db"}}},
+			expectErr: true,
+		},
+		{
+			name:      "self dependency",
+			services:  DeployServices{"api": {Commands: command, DependsOn: []string{"api"}}},
+			expectErr: true,
+		},
+		{
+			name:      "cyclic dependency",
+			services:  DeployServices{"a": {Commands: command, DependsOn: []string{"b"}}, "b": {Commands: command, DependsOn: []string{"a"}}},
+			expectErr: true,
+		},
+		{
+			name:      "readiness without command",
+			services:  DeployServices{"api": {Commands: command, Readiness: &ReadinessGate{Timeout: time.Minute}}},
+			expectErr: true,
+		},
+		{
+			name:      "negative readiness timeout",
+			services:  DeployServices{"api": {Commands: command, Readiness: &ReadinessGate{Command: "true", Timeout: -time.Minute}}},
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.services.validate()
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDeployServicesGetDeployOrder(t *testing.T) {
+	command := []DeployCommand{{Name: "make", Command: "make"}}
+	services := DeployServices{
+		"frontend": {Commands: command, DependsOn: []string{"api"}},
+		"api":      {Commands: command, DependsOn: []string{"db", "queue"}},
+		"db":       {Commands: command},
+		"queue":    {Commands: command},
+	}
+	assert.Equal(t, []string{"db", "queue", "api", "frontend"}, services.GetDeployOrder())
+}
@@ -29,7 +29,9 @@ import (
 	"github.com/compose-spec/godotenv"
 	"github.com/google/uuid"
 	"github.com/okteto/okteto/pkg/cache"
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/envprovider"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/filesystem"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
@@ -88,6 +90,7 @@ type Dev struct {
 	InitFromImage        bool                  `json:"initFromImage,omitempty" yaml:"initFromImage,omitempty"`
 	Timeout              Timeout               `json:"timeout,omitempty" yaml:"timeout,omitempty"`
 	NodeSelector         map[string]string     `json:"nodeSelector,omitempty" yaml:"nodeSelector,omitempty"`
+	RuntimeClassName     string                `json:"runtimeClassName,omitempty" yaml:"runtimeClassName,omitempty"`
 	Affinity             *Affinity             `json:"affinity,omitempty" yaml:"affinity,omitempty"`
 	Metadata             *Metadata             `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 	Autocreate           bool                  `json:"autocreate,omitempty" yaml:"autocreate,omitempty"`
@@ -95,6 +98,9 @@ type Dev struct {
 	Environment          Environment           `json:"environment,omitempty" yaml:"environment,omitempty"`
 	Volumes              []Volume              `json:"volumes,omitempty" yaml:"volumes,omitempty"`
 	Mode                 string                `json:"mode,omitempty" yaml:"mode,omitempty"`
+	OriginalWorkload     string                `json:"originalWorkload,omitempty" yaml:"originalWorkload,omitempty"`
+	Debug                *Debug                `json:"debug,omitempty" yaml:"debug,omitempty"`
+	GPU                  *GPU                  `json:"gpu,omitempty" yaml:"gpu,omitempty"`
 
 	Replicas *int `json:"replicas,omitempty" yaml:"replicas,omitempty"`
 	// Deprecated fields
@@ -132,6 +138,24 @@ type BuildInfo struct {
 	ExportCache      cache.ExportCache `yaml:"export_cache,omitempty"`
 	DependsOn        BuildDependsOn    `yaml:"depends_on,omitempty"`
 	Secrets          BuildSecrets      `yaml:"secrets,omitempty"`
+	CacheInputs      []string          `yaml:"cache_inputs,omitempty"`
+	Scan             *BuildScan        `yaml:"scan,omitempty"`
+	Builder          string            `yaml:"builder,omitempty"`
+}
+
+const (
+	// EarthlyBuilder builds the image running an earthly target
+	EarthlyBuilder = "earthly"
+	// BazelBuilder builds the image running a bazel push target
+	BazelBuilder = "bazel"
+)
+
+// BuildScan defines the vulnerability scan policy of a built image
+type BuildScan struct {
+	// FailOn is the minimum severity of the vulnerabilities that fail the build
+	FailOn string `yaml:"failOn,omitempty"`
+	// WarnOn is the minimum severity of the vulnerabilities reported as warnings
+	WarnOn string `yaml:"warnOn,omitempty"`
 }
 
 // BuildArg is an argument used on the build step.
@@ -157,6 +181,117 @@ type BuildDependsOn []string
 // BuildSecrets represents the secrets to be injected to the build of the image
 type BuildSecrets map[string]string
 
+// ScanSeverities are the severities of the vulnerabilities sorted from lower to higher
+var ScanSeverities = []string{"UNKNOWN", "LOW", "MEDIUM", "HIGH", "CRITICAL"}
+
+const defaultScanWarnOn = "HIGH"
+
+func (b *BuildInfo) validateBuilder() error {
+	switch b.Builder {
+	case "", EarthlyBuilder:
+		return nil
+	case BazelBuilder:
+		if b.Target == "" {
+			return fmt.Errorf("'target' is required to build with bazel")
+		}
+		return nil
+	default:
+		return fmt.Errorf("builder '%s' is not supported. Supported values are: [%s, %s]", b.Builder, EarthlyBuilder, BazelBuilder)
+	}
+}
+
+func (s *BuildScan) validate() error {
+	for _, severity := range []string{s.FailOn, s.WarnOn} {
+		if severity != "" && GetScanSeverityLevel(severity) < 0 {
+			return fmt.Errorf("severity '%s' is not supported. Supported values are: [%s]", severity, strings.Join(ScanSeverities, ", "))
+		}
+	}
+	return nil
+}
+
+// GetWarnOn returns the minimum severity reported as a warning, HIGH by default
+func (s *BuildScan) GetWarnOn() string {
+	if s.WarnOn == "" && s.FailOn == "" {
+		return defaultScanWarnOn
+	}
+	return s.WarnOn
+}
+
+// GetScanSeverityLevel returns the position of the severity in ScanSeverities or -1 if it isn't valid
+func GetScanSeverityLevel(severity string) int {
+	for i, s := range ScanSeverities {
+		if strings.EqualFold(s, severity) {
+			return i
+		}
+	}
+	return -1
+}
+
+// IsRemoteBuildContext returns true if the build context is a git repository, i.e. 'https://github.com/org/repo#branch:subdir'.
+// Remote contexts are cloned by the builder instead of being uploaded
+func IsRemoteBuildContext(buildContext string) bool {
+	if strings.HasPrefix(buildContext, "git@") || strings.HasPrefix(buildContext, "git://") {
+		return true
+	}
+	uri, err := url.ParseRequestURI(buildContext)
+	return err == nil && uri.Scheme != "" && uri.Host != ""
+}
+
+// gitHostingProviders are the hosts whose http urls are always git repositories
+var gitHostingProviders = []string{"github.com", "gitlab.com", "bitbucket.org"}
+
+// GetRemoteBuildContext returns the git url of a remote context in the format detected by the builders, which require the '.git' suffix on http urls.
+// Only urls of git hosting providers or with a '#ref' fragment are rewritten, any other url (i.e. a tarball) is returned as is
+func GetRemoteBuildContext(buildContext string) string {
+	if !strings.HasPrefix(buildContext, "http://") && !strings.HasPrefix(buildContext, "https://") {
+		return buildContext
+	}
+	repository, fragment, hasFragment := strings.Cut(buildContext, "#")
+	if !hasFragment && !isGitHostingURL(repository) {
+		return buildContext
+	}
+	repository = strings.TrimSuffix(repository, "/")
+	if !strings.HasSuffix(repository, ".git") {
+		repository = fmt.Sprintf("%s.git", repository)
+	}
+	if hasFragment {
+		return fmt.Sprintf("%s#%s", repository, fragment)
+	}
+	return repository
+}
+
+// IsGitBuildContext returns true if the build context is a git repository, as opposed to a local folder or a tarball url
+func IsGitBuildContext(buildContext string) bool {
+	if strings.HasPrefix(buildContext, "git@") || strings.HasPrefix(buildContext, "git://") {
+		return true
+	}
+	if !IsRemoteBuildContext(buildContext) {
+		return false
+	}
+	repository, _, _ := strings.Cut(GetRemoteBuildContext(buildContext), "#")
+	return strings.HasSuffix(repository, ".git")
+}
+
+// ParseGitBuildContext splits a git build context into the repository url and the ref of the '#ref:subdir' fragment
+func ParseGitBuildContext(buildContext string) (string, string) {
+	repository, fragment, _ := strings.Cut(GetRemoteBuildContext(buildContext), "#")
+	ref, _, _ := strings.Cut(fragment, ":")
+	return repository, ref
+}
+
+func isGitHostingURL(repository string) bool {
+	uri, err := url.Parse(repository)
+	if err != nil {
+		return false
+	}
+	for _, host := range gitHostingProviders {
+		if strings.EqualFold(uri.Hostname(), host) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetDockerfilePath returns the path to the Dockerfile
 func (b *BuildInfo) GetDockerfilePath() string {
 	if filepath.IsAbs(b.Dockerfile) {
@@ -233,10 +368,25 @@ type Sync struct {
 	Verbose        bool         `json:"verbose" yaml:"verbose"`
 	RescanInterval int          `json:"rescanInterval,omitempty" yaml:"rescanInterval,omitempty"`
 	Folders        []SyncFolder `json:"folders,omitempty" yaml:"folders,omitempty"`
+	Options        *SyncOptions `json:"options,omitempty" yaml:"options,omitempty"`
+	SkipLargeFiles string       `json:"skipLargeFiles,omitempty" yaml:"skipLargeFiles,omitempty"`
+	CRLF           string       `json:"crlf,omitempty" yaml:"crlf,omitempty"`
 	LocalPath      string
 	RemotePath     string
 }
 
+// SyncOptions tunes the file synchronization of large repositories
+type SyncOptions struct {
+	// FsWatcherDelay is the number of seconds to aggregate file changes before syncing them
+	FsWatcherDelay int `json:"fsWatcherDelay,omitempty" yaml:"fsWatcherDelay,omitempty"`
+	// Compression is the syncthing compression mode: always, metadata or never
+	Compression string `json:"compression,omitempty" yaml:"compression,omitempty"`
+	// RescanInterval is the number of seconds between full rescans of the sync folders
+	RescanInterval int `json:"rescanInterval,omitempty" yaml:"rescanInterval,omitempty"`
+	// MaxFileSize is the size of the files okteto warns about, so they can be added to the .stignore file
+	MaxFileSize string `json:"maxFileSize,omitempty" yaml:"maxFileSize,omitempty"`
+}
+
 // SyncFolder represents a sync folder in the development container
 type SyncFolder struct {
 	LocalPath  string
@@ -303,11 +453,14 @@ func (v *EnvVar) String() string {
 	return fmt.Sprintf("%s=%s", v.Name, v.Value)
 }
 
-// Secret represents a development secret
+// Secret represents a development secret.
+// Secrets with FromPlatform are read from the Okteto platform instead of LocalPath,
+// they are mounted as a file in RemotePath or as an environment variable if RemotePath is empty
 type Secret struct {
-	LocalPath  string
-	RemotePath string
-	Mode       int32
+	LocalPath    string
+	RemotePath   string
+	Mode         int32
+	FromPlatform string
 }
 
 // Reverse represents a remote forward port
@@ -329,10 +482,36 @@ type Probes struct {
 	Startup   bool `json:"startup,omitempty" yaml:"startup,omitempty"`
 }
 
-// Lifecycle defines the lifecycle for containers
+// Lifecycle defines the lifecycle for containers.
+// PostStart and PostStop keep the lifecycle hooks of the original container and are enabled unless the manifest disables them,
+// PostStartCommand, PreStop and PostSync are commands to run in the development container
 type Lifecycle struct {
-	PostStart bool `json:"postStart,omitempty" yaml:"postStart,omitempty"`
-	PostStop  bool `json:"postStop,omitempty" yaml:"postStop,omitempty"`
+	PostStart        bool     `json:"postStart,omitempty" yaml:"postStart,omitempty"`
+	PostStop         bool     `json:"postStop,omitempty" yaml:"postStop,omitempty"`
+	PostStartCommand *Command `json:"-" yaml:"-"`
+	PreStop          *Command `json:"preStop,omitempty" yaml:"preStop,omitempty"`
+	PostSync         *Command `json:"postSync,omitempty" yaml:"postSync,omitempty"`
+}
+
+// HasHooks returns if the lifecycle defines commands to run in the development container
+func (l *Lifecycle) HasHooks() bool {
+	return l != nil && (l.PostStartCommand != nil || l.PreStop != nil || l.PostSync != nil)
+}
+
+func (l *Lifecycle) validate() error {
+	if l == nil {
+		return nil
+	}
+	hooks := map[string]*Command{"postStart": l.PostStartCommand, "preStop": l.PreStop, "postSync": l.PostSync}
+	for _, name := range []string{"postStart", "preStop", "postSync"} {
+		if hooks[name] != nil && len(hooks[name].Values) == 0 {
+			return fmt.Errorf("'lifecycle.%s' command cannot be empty", name)
+		}
+	}
+	if l.PostStop && l.PreStop != nil {
+		return fmt.Errorf("'lifecycle.postStop' and 'lifecycle.preStop' cannot be set at the same time")
+	}
+	return nil
 }
 
 // ResourceList is a set of (resource name, quantity) pairs.
@@ -397,7 +576,7 @@ func NewDev() *Dev {
 		Services:             make([]*Dev, 0),
 		PersistentVolumeInfo: &PersistentVolumeInfo{Enabled: true},
 		Probes:               &Probes{},
-		Lifecycle:            &Lifecycle{},
+		Lifecycle:            &Lifecycle{PostStart: true, PostStop: true},
 		InitContainer:        InitContainer{Image: OktetoBinImageTag},
 		Metadata: &Metadata{
 			Labels:      Labels{},
@@ -533,6 +712,12 @@ func (dev *Dev) SetDefaults() error {
 	if dev.Command.Values == nil {
 		dev.Command.Values = []string{"sh"}
 	}
+	if dev.Debug != nil {
+		dev.setDebugDefaults()
+	}
+	if dev.GPU != nil {
+		dev.setGPUDefaults()
+	}
 	if len(dev.Forward) > 0 {
 		sort.SliceStable(dev.Forward, func(i, j int) bool {
 			return dev.Forward[i].Less(&dev.Forward[j])
@@ -552,7 +737,7 @@ func (dev *Dev) SetDefaults() error {
 	}
 
 	if dev.ImagePullPolicy == "" {
-		dev.ImagePullPolicy = apiv1.PullAlways
+		dev.ImagePullPolicy = getDefaultImagePullPolicy()
 	}
 	if dev.Metadata == nil {
 		dev.Metadata = &Metadata{}
@@ -580,7 +765,7 @@ func (dev *Dev) SetDefaults() error {
 		dev.Probes = &Probes{}
 	}
 	if dev.Lifecycle == nil {
-		dev.Lifecycle = &Lifecycle{}
+		dev.Lifecycle = &Lifecycle{PostStart: true, PostStop: true}
 	}
 	if dev.Interface == "" {
 		dev.Interface = Localhost
@@ -603,7 +788,7 @@ func (dev *Dev) SetDefaults() error {
 
 	for _, s := range dev.Services {
 		if s.ImagePullPolicy == "" {
-			s.ImagePullPolicy = apiv1.PullAlways
+			s.ImagePullPolicy = getDefaultImagePullPolicy()
 		}
 		if s.Metadata == nil {
 			s.Metadata = &Metadata{
@@ -629,6 +814,9 @@ func (dev *Dev) SetDefaults() error {
 		s.Namespace = ""
 		s.Context = ""
 		s.setRunAsUserDefaults(dev)
+		if s.GPU != nil {
+			s.setGPUDefaults()
+		}
 		s.Forward = make([]forward.Forward, 0)
 		s.Reverse = make([]Reverse, 0)
 		s.Secrets = make([]Secret, 0)
@@ -639,7 +827,7 @@ func (dev *Dev) SetDefaults() error {
 			s.Probes = &Probes{}
 		}
 		if s.Lifecycle == nil {
-			s.Lifecycle = &Lifecycle{}
+			s.Lifecycle = &Lifecycle{PostStart: true, PostStop: true}
 		}
 	}
 
@@ -655,7 +843,7 @@ func (b *BuildInfo) setBuildDefaults() {
 		b.Context = "."
 	}
 
-	if _, err := url.ParseRequestURI(b.Context); err != nil && b.Dockerfile == "" {
+	if _, err := url.ParseRequestURI(b.Context); err != nil && b.Dockerfile == "" && b.Builder == "" {
 		b.Dockerfile = "Dockerfile"
 	}
 
@@ -699,46 +887,116 @@ func (dev *Dev) setTimeout() error {
 	return nil
 }
 
+// getDefaultImagePullPolicy returns the pull policy of the dev containers. In offline mode the images must be pre-seeded in the cluster
+func getDefaultImagePullPolicy() apiv1.PullPolicy {
+	if config.IsOffline() {
+		return apiv1.PullIfNotPresent
+	}
+	return apiv1.PullAlways
+}
+
+// expandEnvFiles adds the variables of the envFiles of the development container and its services to their environment.
+// The variables defined in 'environment' take precedence over the ones defined in 'envFiles'
 func (dev *Dev) expandEnvFiles() error {
-	for _, envFile := range dev.EnvFiles {
-		filename, err := ExpandEnv(envFile, true)
-		if err != nil {
+	envFileVars, err := LoadEnvFiles(dev.EnvFiles)
+	if err != nil {
+		return err
+	}
+
+	defined := map[string]bool{}
+	for _, e := range dev.Environment {
+		defined[e.Name] = true
+	}
+	for _, e := range envFileVars {
+		if !defined[e.Name] {
+			dev.Environment = append(dev.Environment, e)
+		}
+	}
+
+	for _, s := range dev.Services {
+		if err := s.expandEnvFiles(); err != nil {
 			return err
 		}
+	}
+	return nil
+}
 
-		f, err := os.Open(filename)
+// LoadEnvFiles returns the variables defined in a list of env files.
+// When a variable is defined in several files the last one wins. The values can reference
+// variables of the local environment or variables defined in the previous files
+func LoadEnvFiles(envFiles EnvFiles) (Environment, error) {
+	result := Environment{}
+	index := map[string]int{}
+	lookup := func(name string) (string, bool) {
+		if value, ok := os.LookupEnv(name); ok {
+			return value, true
+		}
+		if i, ok := index[name]; ok {
+			return result[i].Value, true
+		}
+		return "", false
+	}
+
+	for _, envFile := range envFiles {
+		filename, err := ExpandEnv(envFile, true)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		defer func() {
-			if err := f.Close(); err != nil {
-				oktetoLog.Debugf("Error closing file %s: %s", filename, err)
-			}
-		}()
 
-		envMap, err := godotenv.ParseWithLookup(f, os.LookupEnv)
+		envMap, err := parseEnvFile(filename, lookup)
 		if err != nil {
-			return fmt.Errorf("error parsing env_file %s: %s", filename, err.Error())
+			return nil, err
 		}
 
-		for _, e := range dev.Environment {
-			delete(envMap, e.Name)
+		names := make([]string, 0, len(envMap))
+		for name := range envMap {
+			names = append(names, name)
 		}
+		sort.Strings(names)
 
-		for name, value := range envMap {
+		for _, name := range names {
+			value, err := envprovider.Expand(envMap[name], noExpand)
+			if err != nil {
+				return nil, err
+			}
 			if value == "" {
 				value = os.Getenv(name)
 			}
-			if value != "" {
-				dev.Environment = append(
-					dev.Environment,
-					EnvVar{Name: name, Value: value},
-				)
+			if value == "" {
+				continue
+			}
+			if i, ok := index[name]; ok {
+				result[i].Value = value
+				continue
 			}
+			index[name] = len(result)
+			result = append(result, EnvVar{Name: name, Value: value})
 		}
 	}
+	return result, nil
+}
 
-	return nil
+// noExpand returns the value unchanged, env files are already expanded when they are parsed
+func noExpand(value string) (string, error) {
+	return value, nil
+}
+
+func parseEnvFile(filename string, lookup func(string) (string, bool)) (map[string]string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			oktetoLog.Debugf("Error closing file %s: %s", filename, err)
+		}
+	}()
+
+	envMap, err := godotenv.ParseWithLookup(f, lookup)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing env_file %s: %s", filename, err.Error())
+	}
+	return envMap, nil
 }
 
 // Validate validates if a dev environment is correctly formed
@@ -797,19 +1055,69 @@ func (dev *Dev) Validate() error {
 		return fmt.Errorf("'sshServerPort' must be > 0")
 	}
 
+	if dev.Debug != nil {
+		if err := dev.Debug.validate(); err != nil {
+			return err
+		}
+	}
+
+	if dev.GPU != nil {
+		if err := dev.GPU.validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := validateOriginalWorkload(dev.OriginalWorkload); err != nil {
+		return err
+	}
+
+	if err := dev.Lifecycle.validate(); err != nil {
+		return err
+	}
+
 	for _, s := range dev.Services {
 		if err := validatePullPolicy(s.ImagePullPolicy); err != nil {
 			return err
 		}
+		if err := validateOriginalWorkload(s.OriginalWorkload); err != nil {
+			return err
+		}
 		if err := s.validateVolumes(dev); err != nil {
 			return err
 		}
+		if s.GPU != nil {
+			if err := s.GPU.validate(); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
 }
 
 func (dev *Dev) validateSync() error {
+	if _, err := dev.Sync.GetSkipLargeFiles(); err != nil {
+		return oktetoErrors.UserError{
+			E:    err,
+			Hint: "Update the 'sync.skipLargeFiles' field in your okteto manifest file to a valid size, like '100Mi'",
+		}
+	}
+	switch dev.Sync.CRLF {
+	case "", SyncCRLFAuto, SyncCRLFKeep, SyncCRLFConvert:
+	default:
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("'sync.crlf' must be one of: %s, %s, %s", SyncCRLFAuto, SyncCRLFKeep, SyncCRLFConvert),
+			Hint: "Update the 'sync.crlf' field in your okteto manifest file",
+		}
+	}
+	if dev.Sync.Options != nil {
+		if err := dev.Sync.Options.validate(); err != nil {
+			return oktetoErrors.UserError{
+				E:    err,
+				Hint: "Update the 'sync.options' field in your okteto manifest file",
+			}
+		}
+	}
 	for _, folder := range dev.Sync.Folders {
 		validPath, err := os.Stat(folder.LocalPath)
 
@@ -838,6 +1146,61 @@ func (dev *Dev) validateSync() error {
 	return nil
 }
 
+func (o *SyncOptions) validate() error {
+	if o.FsWatcherDelay < 0 {
+		return fmt.Errorf("'sync.options.fsWatcherDelay' must be a positive number of seconds")
+	}
+	if o.RescanInterval < 0 {
+		return fmt.Errorf("'sync.options.rescanInterval' must be a positive number of seconds")
+	}
+	switch o.Compression {
+	case "", SyncCompressionAlways, SyncCompressionMetadata, SyncCompressionNever:
+	default:
+		return fmt.Errorf("'sync.options.compression' must be one of: %s, %s, %s", SyncCompressionAlways, SyncCompressionMetadata, SyncCompressionNever)
+	}
+	if o.MaxFileSize != "" {
+		if _, err := o.GetMaxFileSize(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetMaxFileSize returns the value of 'maxFileSize' in bytes, or 0 if it is not set
+func (o *SyncOptions) GetMaxFileSize() (int64, error) {
+	if o == nil || o.MaxFileSize == "" {
+		return 0, nil
+	}
+	return parseFileSize("sync.options.maxFileSize", o.MaxFileSize)
+}
+
+// GetCRLF returns the value of 'crlf', or 'auto' if it is not set
+func (s *Sync) GetCRLF() string {
+	if s.CRLF == "" {
+		return SyncCRLFAuto
+	}
+	return s.CRLF
+}
+
+// GetSkipLargeFiles returns the value of 'skipLargeFiles' in bytes, or 0 if it is not set
+func (s *Sync) GetSkipLargeFiles() (int64, error) {
+	if s.SkipLargeFiles == "" {
+		return 0, nil
+	}
+	return parseFileSize("sync.skipLargeFiles", s.SkipLargeFiles)
+}
+
+func parseFileSize(field, value string) (int64, error) {
+	q, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, fmt.Errorf("'%s' is not a valid size: %s", field, value)
+	}
+	if q.Sign() <= 0 {
+		return 0, fmt.Errorf("'%s' must be greater than zero", field)
+	}
+	return q.Value(), nil
+}
+
 func validatePullPolicy(pullPolicy apiv1.PullPolicy) error {
 	switch pullPolicy {
 	case apiv1.PullAlways:
@@ -849,9 +1212,26 @@ func validatePullPolicy(pullPolicy apiv1.PullPolicy) error {
 	return nil
 }
 
+func validateOriginalWorkload(originalWorkload string) error {
+	switch originalWorkload {
+	case "", OriginalWorkloadScaleDown, OriginalWorkloadClone, OriginalWorkloadUntouched:
+	default:
+		return fmt.Errorf("supported values for 'originalWorkload' are: '%s', '%s' or '%s'", OriginalWorkloadScaleDown, OriginalWorkloadClone, OriginalWorkloadUntouched)
+	}
+	return nil
+}
+
 func validateSecrets(secrets []Secret) error {
 	seen := map[string]bool{}
+	seenEnvs := map[string]bool{}
 	for _, s := range secrets {
+		if s.IsEnvVar() {
+			if _, ok := seenEnvs[s.FromPlatform]; ok {
+				return fmt.Errorf("Secret '%s' from the Okteto platform is defined more than once as an environment variable", s.FromPlatform)
+			}
+			seenEnvs[s.FromPlatform] = true
+			continue
+		}
 		if _, ok := seen[s.GetFileName()]; ok {
 			return fmt.Errorf("Secrets with the same basename '%s' are not supported", s.GetFileName())
 		}
@@ -1002,7 +1382,6 @@ func (dev *Dev) ToTranslationRule(main *Dev, reset bool) *TranslationRule {
 		Container:        dev.Container,
 		ImagePullPolicy:  dev.ImagePullPolicy,
 		Environment:      dev.Environment,
-		Secrets:          dev.Secrets,
 		WorkDir:          dev.Workdir,
 		PersistentVolume: main.PersistentVolumeEnabled(),
 		Volumes:          []VolumeMount{},
@@ -1014,8 +1393,11 @@ func (dev *Dev) ToTranslationRule(main *Dev, reset bool) *TranslationRule {
 		Probes:           dev.Probes,
 		Lifecycle:        dev.Lifecycle,
 		NodeSelector:     dev.NodeSelector,
+		RuntimeClassName: dev.RuntimeClassName,
+		GPU:              dev.GPU,
 		Affinity:         (*apiv1.Affinity)(dev.Affinity),
 	}
+	rule.Secrets, rule.SecretEnvVars = splitSecrets(dev.Secrets)
 
 	if dev.IsHybridModeEnabled() {
 		rule.WorkDir = "/okteto"
@@ -1220,19 +1602,77 @@ func (dev *Dev) RemoteModeEnabled() bool {
 
 // GetKeyName returns the secret key name
 func (s *Secret) GetKeyName() string {
+	if s.IsEnvVar() {
+		return fmt.Sprintf("dev-secret-env-%s", s.FromPlatform)
+	}
 	return fmt.Sprintf("dev-secret-%s", filepath.Base(s.RemotePath))
 }
 
+// IsEnvVar returns if the secret is injected as an environment variable instead of a file
+func (s *Secret) IsEnvVar() bool {
+	return s.FromPlatform != "" && s.RemotePath == ""
+}
+
+// HasPlatformSecrets returns if the development container uses secrets from the Okteto platform
+func (dev *Dev) HasPlatformSecrets() bool {
+	for _, s := range dev.Secrets {
+		if s.FromPlatform != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// splitSecrets returns the secrets mounted as files and the secrets injected as environment variables
+func splitSecrets(secrets []Secret) ([]Secret, []Secret) {
+	var envSecrets []Secret
+	for _, s := range secrets {
+		if s.IsEnvVar() {
+			envSecrets = append(envSecrets, s)
+		}
+	}
+	if len(envSecrets) == 0 {
+		return secrets, nil
+	}
+	fileSecrets := []Secret{}
+	for _, s := range secrets {
+		if !s.IsEnvVar() {
+			fileSecrets = append(fileSecrets, s)
+		}
+	}
+	return fileSecrets, envSecrets
+}
+
 // GetFileName returns the secret file name
 func (s *Secret) GetFileName() string {
 	return filepath.Base(s.RemotePath)
 }
 
-// ExpandEnv expands the environments supporting the notation "${var:-$DEFAULT}"
+// ExpandEnv expands the environments of a field of the manifest supporting the notation "${var:-$DEFAULT}" and the references to secret managers like "${vault:path#key}".
+// The referenced variables are reported and, in strict mode, the expansion fails if any of them is undefined
 func ExpandEnv(value string, expandIfEmpty bool) (string, error) {
-	result, err := envsubst.String(value)
+	if err := checkEnvReferences(value); err != nil {
+		return "", err
+	}
+	return expandEnv(value, expandIfEmpty, fmt.Sprintf(" on '%s'", value))
+}
+
+// ExpandEnvValue expands the environments of a value that isn't a field of the manifest, like the lines of a build secret file or a flag.
+// The variables are neither reported nor checked in strict mode, and the value isn't included in the errors
+func ExpandEnvValue(value string, expandIfEmpty bool) (string, error) {
+	return expandEnv(value, expandIfEmpty, "")
+}
+
+func expandEnv(value string, expandIfEmpty bool, errContext string) (string, error) {
+	result, err := envprovider.Expand(value, func(s string) (string, error) {
+		expanded, err := envsubst.String(s)
+		if err != nil {
+			return "", fmt.Errorf("error expanding environment%s: %s", errContext, err.Error())
+		}
+		return expanded, nil
+	})
 	if err != nil {
-		return "", fmt.Errorf("error expanding environment on '%s': %s", value, err.Error())
+		return "", err
 	}
 	if result == "" && !expandIfEmpty {
 		return value, nil
@@ -1376,6 +1816,7 @@ func (b *BuildInfo) Copy() *BuildInfo {
 		Target:      b.Target,
 		Image:       b.Image,
 		ExportCache: b.ExportCache,
+		Builder:     b.Builder,
 	}
 
 	// copy to new pointers
@@ -1401,6 +1842,15 @@ func (b *BuildInfo) Copy() *BuildInfo {
 	dependsOn = append(dependsOn, b.DependsOn...)
 	result.DependsOn = dependsOn
 
+	if len(b.CacheInputs) > 0 {
+		result.CacheInputs = append([]string{}, b.CacheInputs...)
+	}
+
+	if b.Scan != nil {
+		scan := *b.Scan
+		result.Scan = &scan
+	}
+
 	return result
 }
 
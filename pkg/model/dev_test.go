@@ -22,8 +22,11 @@ import (
 	"time"
 
 	"github.com/compose-spec/godotenv"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/envprovider"
 	"github.com/okteto/okteto/pkg/model/forward"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	apiv1 "k8s.io/api/core/v1"
 )
 
@@ -739,6 +742,31 @@ func Test_LoadForcePull(t *testing.T) {
 	}
 }
 
+func Test_OfflineImagePullPolicy(t *testing.T) {
+	t.Setenv(constants.OktetoOfflineEnvVar, "true")
+	manifestBytes := []byte(`
+  name: a
+  services:
+    - name: b
+    - name: c
+      imagePullPolicy: Always`)
+	manifest, err := Read(manifestBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dev := manifest.Dev["a"]
+	if dev.ImagePullPolicy != apiv1.PullIfNotPresent {
+		t.Errorf("wrong image pull policy for main container: %s", dev.ImagePullPolicy)
+	}
+	if dev.Services[0].ImagePullPolicy != apiv1.PullIfNotPresent {
+		t.Errorf("wrong image pull policy for services: %s", dev.Services[0].ImagePullPolicy)
+	}
+	if dev.Services[1].ImagePullPolicy != apiv1.PullAlways {
+		t.Errorf("wrong image pull policy for services: %s", dev.Services[1].ImagePullPolicy)
+	}
+}
+
 func Test_validate(t *testing.T) {
 	file, err := os.CreateTemp("", "okteto-secret-test")
 	if err != nil {
@@ -1658,6 +1686,7 @@ func Test_BuildInfoCopy(t *testing.T) {
 			},
 		},
 		DependsOn: BuildDependsOn{"other"},
+		Builder:   EarthlyBuilder,
 	}
 
 	copyB := b.Copy()
@@ -1820,3 +1849,217 @@ func TestExpandBuildArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildScanValidate(t *testing.T) {
+	assert.NoError(t, (&BuildScan{FailOn: "critical", WarnOn: "MEDIUM"}).validate())
+	assert.Error(t, (&BuildScan{FailOn: "severe"}).validate())
+	assert.Equal(t, "HIGH", (&BuildScan{}).GetWarnOn())
+	assert.Equal(t, "", (&BuildScan{FailOn: "CRITICAL"}).GetWarnOn())
+}
+
+func TestBuildInfoValidateBuilder(t *testing.T) {
+	assert.NoError(t, (&BuildInfo{}).validateBuilder())
+	assert.NoError(t, (&BuildInfo{Builder: EarthlyBuilder}).validateBuilder())
+	assert.NoError(t, (&BuildInfo{Builder: BazelBuilder, Target: "//api:push"}).validateBuilder())
+	assert.Error(t, (&BuildInfo{Builder: BazelBuilder}).validateBuilder())
+	assert.Error(t, (&BuildInfo{Builder: "pants"}).validateBuilder())
+}
+
+func TestSyncOptionsValidate(t *testing.T) {
+	var tests = []struct {
+		name    string
+		options SyncOptions
+		wantErr bool
+	}{
+		{name: "empty", options: SyncOptions{}},
+		{name: "valid", options: SyncOptions{FsWatcherDelay: 10, Compression: SyncCompressionNever, RescanInterval: 3600, MaxFileSize: "100Mi"}},
+		{name: "negative fsWatcherDelay", options: SyncOptions{FsWatcherDelay: -1}, wantErr: true},
+		{name: "negative rescanInterval", options: SyncOptions{RescanInterval: -1}, wantErr: true},
+		{name: "invalid compression", options: SyncOptions{Compression: "gzip"}, wantErr: true},
+		{name: "invalid maxFileSize", options: SyncOptions{MaxFileSize: "big"}, wantErr: true},
+		{name: "zero maxFileSize", options: SyncOptions{MaxFileSize: "0"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.options.validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSyncOptionsUnmarshal(t *testing.T) {
+	manifest := []byte(`name: api
+image: golang
+sync:
+  folders:
+    - .:/app
+  options:
+    fsWatcherDelay: 10
+    compression: never
+    rescanInterval: 3600
+    maxFileSize: 100Mi
+`)
+	m, err := Read(manifest)
+	assert.NoError(t, err)
+	dev := m.Dev["api"]
+	assert.Equal(t, &SyncOptions{FsWatcherDelay: 10, Compression: SyncCompressionNever, RescanInterval: 3600, MaxFileSize: "100Mi"}, dev.Sync.Options)
+	size, err := dev.Sync.Options.GetMaxFileSize()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100*1024*1024), size)
+}
+
+func TestSyncCRLF(t *testing.T) {
+	manifest := []byte(`name: api
+image: golang
+sync:
+  folders:
+    - .:/app
+  crlf: convert
+`)
+	m, err := Read(manifest)
+	assert.NoError(t, err)
+	assert.Equal(t, SyncCRLFConvert, m.Dev["api"].Sync.GetCRLF())
+	assert.Equal(t, SyncCRLFAuto, (&Sync{}).GetCRLF())
+
+	dev := &Dev{Sync: Sync{CRLF: "lf"}}
+	assert.Error(t, dev.validateSync())
+}
+
+func Test_validateOriginalWorkload(t *testing.T) {
+	for _, v := range []string{"", OriginalWorkloadScaleDown, OriginalWorkloadClone, OriginalWorkloadUntouched} {
+		assert.NoError(t, validateOriginalWorkload(v))
+	}
+	assert.Error(t, validateOriginalWorkload("delete"))
+}
+
+func TestLifecycleValidate(t *testing.T) {
+	var tests = []struct {
+		name      string
+		lifecycle *Lifecycle
+		wantErr   bool
+	}{
+		{name: "nil"},
+		{name: "original hooks", lifecycle: &Lifecycle{PostStart: true, PostStop: true}},
+		{name: "dev hooks", lifecycle: &Lifecycle{PostSync: &Command{Values: []string{"yarn"}}, PreStop: &Command{Values: []string{"./cleanup.sh"}}}},
+		{name: "empty hook", lifecycle: &Lifecycle{PostSync: &Command{}}, wantErr: true},
+		{name: "postStop and preStop", lifecycle: &Lifecycle{PostStop: true, PreStop: &Command{Values: []string{"./cleanup.sh"}}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.lifecycle.validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSplitSecrets(t *testing.T) {
+	fileSecret := Secret{LocalPath: "/local", RemotePath: "/remote", Mode: 420}
+	platformFileSecret := Secret{FromPlatform: "NPMRC", RemotePath: "/root/.npmrc", Mode: 420}
+	platformEnvSecret := Secret{FromPlatform: "GITHUB_TOKEN"}
+
+	files, envs := splitSecrets([]Secret{fileSecret, platformEnvSecret, platformFileSecret})
+	assert.Equal(t, []Secret{fileSecret, platformFileSecret}, files)
+	assert.Equal(t, []Secret{platformEnvSecret}, envs)
+
+	files, envs = splitSecrets([]Secret{fileSecret})
+	assert.Equal(t, []Secret{fileSecret}, files)
+	assert.Nil(t, envs)
+
+	assert.Error(t, validateSecrets([]Secret{platformEnvSecret, platformEnvSecret}))
+	assert.NoError(t, validateSecrets([]Secret{fileSecret, platformEnvSecret, platformFileSecret}))
+	assert.Equal(t, "dev-secret-env-GITHUB_TOKEN", platformEnvSecret.GetKeyName())
+	assert.Equal(t, "dev-secret-.npmrc", platformFileSecret.GetKeyName())
+}
+
+func TestLoadEnvFiles(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, ".env")
+	second := filepath.Join(dir, ".env.local")
+	require.NoError(t, os.WriteFile(first, []byte("B=first\nA=first\nHOST=db\n"), 0600))
+	require.NoError(t, os.WriteFile(second, []byte("A=second\nURL=postgres://${HOST}:${PORT}\n"), 0600))
+	t.Setenv("PORT", "5432")
+
+	result, err := LoadEnvFiles(EnvFiles{first, second})
+	require.NoError(t, err)
+	assert.Equal(t, Environment{
+		{Name: "A", Value: "second"},
+		{Name: "B", Value: "first"},
+		{Name: "HOST", Value: "db"},
+		{Name: "URL", Value: "postgres://db:5432"},
+	}, result)
+
+	_, err = LoadEnvFiles(EnvFiles{filepath.Join(dir, "missing")})
+	assert.Error(t, err)
+}
+
+type fakeEnvProvider struct{}
+
+func (fakeEnvProvider) Name() string { return "fake" }
+
+func (fakeEnvProvider) Resolve(ref string) (string, error) { return "resolved-" + ref, nil }
+
+func TestLoadEnvFilesWithProviderReferences(t *testing.T) {
+	envprovider.Register(fakeEnvProvider{})
+	dir := t.TempDir()
+	envFile := filepath.Join(dir, ".env")
+	require.NoError(t, os.WriteFile(envFile, []byte("PASSWORD='${fake:db#password}'\n"), 0600))
+
+	result, err := LoadEnvFiles(EnvFiles{envFile})
+	require.NoError(t, err)
+	assert.Equal(t, Environment{{Name: "PASSWORD", Value: "resolved-db#password"}}, result)
+
+	value, err := ExpandEnv("${fake:db#user}@${HOST:-localhost}", true)
+	require.NoError(t, err)
+	assert.Equal(t, "resolved-db#user@localhost", value)
+}
+
+func Test_expandEnvFilesServices(t *testing.T) {
+	envFile := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(envFile, []byte("A=file\nB=file\n"), 0600))
+
+	dev := &Dev{
+		Environment: Environment{{Name: "A", Value: "manifest"}},
+		EnvFiles:    EnvFiles{envFile},
+		Services: []*Dev{
+			{EnvFiles: EnvFiles{envFile}},
+		},
+	}
+	require.NoError(t, dev.expandEnvFiles())
+	assert.Equal(t, Environment{{Name: "A", Value: "manifest"}, {Name: "B", Value: "file"}}, dev.Environment)
+	assert.Equal(t, Environment{{Name: "A", Value: "file"}, {Name: "B", Value: "file"}}, dev.Services[0].Environment)
+}
+
+func TestRemoteBuildContext(t *testing.T) {
+	assert.True(t, IsRemoteBuildContext("https://github.com/okteto/movies#main:api"))
+	assert.True(t, IsRemoteBuildContext("git@github.com:okteto/movies.git"))
+	assert.False(t, IsRemoteBuildContext("api"))
+	assert.False(t, IsRemoteBuildContext("/home/cindy/movies"))
+
+	assert.Equal(t, "https://github.com/okteto/movies.git#main:api", GetRemoteBuildContext("https://github.com/okteto/movies#main:api"))
+	assert.Equal(t, "https://github.com/okteto/movies.git", GetRemoteBuildContext("https://github.com/okteto/movies/"))
+	assert.Equal(t, "https://github.com/okteto/movies.git#main", GetRemoteBuildContext("https://github.com/okteto/movies.git#main"))
+	assert.Equal(t, "git@github.com:okteto/movies.git", GetRemoteBuildContext("git@github.com:okteto/movies.git"))
+	assert.Equal(t, "https://example.com/movies.tar.gz", GetRemoteBuildContext("https://example.com/movies.tar.gz"))
+	assert.Equal(t, "https://git.example.com/movies.git#main", GetRemoteBuildContext("https://git.example.com/movies#main"))
+
+	assert.True(t, IsGitBuildContext("https://github.com/okteto/movies#main:api"))
+	assert.True(t, IsGitBuildContext("https://git.example.com/movies.git"))
+	assert.True(t, IsGitBuildContext("git@github.com:okteto/movies.git"))
+	assert.False(t, IsGitBuildContext("https://example.com/movies.tar.gz"))
+	assert.False(t, IsGitBuildContext("api"))
+
+	repository, ref := ParseGitBuildContext("https://github.com/okteto/movies#main:api")
+	assert.Equal(t, "https://github.com/okteto/movies.git", repository)
+	assert.Equal(t, "main", ref)
+	repository, ref = ParseGitBuildContext("git@github.com:okteto/movies.git")
+	assert.Equal(t, "git@github.com:okteto/movies.git", repository)
+	assert.Equal(t, "", ref)
+}
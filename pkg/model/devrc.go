@@ -176,6 +176,16 @@ func MergeDevWithDevRc(dev *Dev, devRc *DevRC) {
 		dev.Sync.RescanInterval = devRc.Sync.RescanInterval
 	}
 
+	if devRc.Sync.Options != nil {
+		dev.Sync.Options = devRc.Sync.Options
+	}
+	if devRc.Sync.SkipLargeFiles != "" {
+		dev.Sync.SkipLargeFiles = devRc.Sync.SkipLargeFiles
+	}
+	if devRc.Sync.CRLF != "" {
+		dev.Sync.CRLF = devRc.Sync.CRLF
+	}
+
 	dev.Sync.Folders = append(dev.Sync.Folders, devRc.Sync.Folders...)
 
 	if devRc.Timeout.Default != 0 {
@@ -0,0 +1,113 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/envprovider"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	envVarSet     = "set"
+	envVarEmpty   = "empty"
+	envVarDefault = "default"
+	envVarUnset   = "unset"
+)
+
+// envVarRegex matches the variables of a value: '${VAR}', '${VAR<modifier>}' and '$VAR'
+var envVarRegex = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)([^}]*)\}|\$([A-Za-z_][A-Za-z0-9_]*)`)
+
+// envVarReference is a variable referenced by a value of the manifest
+type envVarReference struct {
+	name   string
+	status string
+}
+
+// IsStrictEnv returns true if the manifest expansion must fail when an undefined variable is referenced
+func IsStrictEnv() bool {
+	strict, err := strconv.ParseBool(os.Getenv(constants.OktetoStrictEnvEnvVar))
+	return err == nil && strict
+}
+
+// checkEnvReferences reports the variables referenced by value and, in strict mode, fails if any of them is undefined.
+// The report includes the variable names and how they are resolved, but not their values.
+// References to secret providers like '${vault:path#key}' are resolved by envprovider, so they aren't variables
+func checkEnvReferences(value string) error {
+	refs := getEnvVarReferences(envprovider.StripReferences(value))
+	if len(refs) == 0 {
+		return nil
+	}
+
+	report := make([]string, 0, len(refs))
+	undefined := []string{}
+	for _, ref := range refs {
+		report = append(report, fmt.Sprintf("%s (%s)", ref.name, ref.status))
+		if ref.status == envVarUnset {
+			undefined = append(undefined, ref.name)
+		}
+	}
+	if envprovider.HasReferences(value) {
+		report = append(report, "secret provider references")
+	}
+	oktetoLog.Infof("env expansion: %s", strings.Join(report, ", "))
+
+	if len(undefined) > 0 && IsStrictEnv() {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("undefined variables in the manifest: %s", strings.Join(undefined, ", ")),
+			Hint: "Define them in your environment or with '--var', or set a default value with '${VAR:-default}'. Run with '--log-level=info' to see every expansion performed",
+		}
+	}
+	return nil
+}
+
+// getEnvVarReferences returns the variables referenced by value. Escaped variables ('$$VAR') and the variables set by okteto during the build are ignored
+func getEnvVarReferences(value string) []envVarReference {
+	value = strings.ReplaceAll(value, "$$", "")
+	result := []envVarReference{}
+	for _, m := range envVarRegex.FindAllStringSubmatch(value, -1) {
+		name, modifier := m[1], m[2]
+		if name == "" {
+			name = m[3]
+		}
+		if strings.HasPrefix(name, "OKTETO_BUILD_") {
+			continue
+		}
+		result = append(result, envVarReference{name: name, status: getEnvVarStatus(name, modifier)})
+	}
+	return result
+}
+
+func getEnvVarStatus(name, modifier string) string {
+	value, ok := os.LookupEnv(name)
+	switch {
+	case ok && value != "":
+		return envVarSet
+	case strings.HasPrefix(modifier, ":-"), strings.HasPrefix(modifier, ":="):
+		return envVarDefault
+	case ok:
+		return envVarEmpty
+	case strings.HasPrefix(modifier, "-"), strings.HasPrefix(modifier, "="), strings.HasPrefix(modifier, "+"), strings.HasPrefix(modifier, ":+"):
+		return envVarDefault
+	default:
+		return envVarUnset
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_getEnvVarReferences(t *testing.T) {
+	t.Setenv("HOST", "db")
+	t.Setenv("EMPTY", "")
+
+	got := getEnvVarReferences("$HOST:${PORT:-5432}/${EMPTY}/${NAME}/$${ESCAPED}/${OKTETO_BUILD_API_IMAGE}/${OPTIONAL-}")
+	assert.Equal(t, []envVarReference{
+		{name: "HOST", status: envVarSet},
+		{name: "PORT", status: envVarDefault},
+		{name: "EMPTY", status: envVarEmpty},
+		{name: "NAME", status: envVarUnset},
+		{name: "OPTIONAL", status: envVarDefault},
+	}, got)
+}
+
+func TestExpandEnvStrict(t *testing.T) {
+	t.Setenv("HOST", "db")
+
+	t.Run("not strict", func(t *testing.T) {
+		got, err := ExpandEnv("${HOST}:${PORT}", true)
+		require.NoError(t, err)
+		assert.Equal(t, "db:", got)
+	})
+
+	t.Run("strict with undefined variables", func(t *testing.T) {
+		t.Setenv(constants.OktetoStrictEnvEnvVar, "true")
+		_, err := ExpandEnv("${HOST}:${PORT}/${NAME}", true)
+		var uErr oktetoErrors.UserError
+		require.ErrorAs(t, err, &uErr)
+		assert.EqualError(t, uErr, "undefined variables in the manifest: PORT, NAME")
+	})
+
+	t.Run("strict with defaults", func(t *testing.T) {
+		t.Setenv(constants.OktetoStrictEnvEnvVar, "true")
+		got, err := ExpandEnv("${HOST}:${PORT:-5432}", true)
+		require.NoError(t, err)
+		assert.Equal(t, "db:5432", got)
+	})
+
+	t.Run("strict applies to values kept when empty", func(t *testing.T) {
+		t.Setenv(constants.OktetoStrictEnvEnvVar, "true")
+		_, err := ExpandEnv("${IMAGE}", false)
+		assert.EqualError(t, err, "undefined variables in the manifest: IMAGE")
+	})
+
+	t.Run("strict doesn't apply to values that aren't manifest fields", func(t *testing.T) {
+		t.Setenv(constants.OktetoStrictEnvEnvVar, "true")
+		got, err := ExpandEnvValue("pa$word", true)
+		require.NoError(t, err)
+		assert.Equal(t, "pa", got)
+	})
+}
+
+func TestCheckEnvReferencesDoesNotLogValues(t *testing.T) {
+	out := &bytes.Buffer{}
+	oktetoLog.Init(logrus.InfoLevel)
+	oktetoLog.SetOutputFormat(oktetoLog.PlainFormat)
+	oktetoLog.SetOutput(out)
+	t.Cleanup(func() {
+		oktetoLog.Init(logrus.WarnLevel)
+	})
+	t.Setenv("HOST", "db")
+
+	require.NoError(t, checkEnvReferences("postgres://admin:hardcoded@${HOST}"))
+	assert.Contains(t, out.String(), "env expansion: HOST (set)")
+	assert.NotContains(t, out.String(), "hardcoded")
+}
+
+func TestCheckEnvReferencesWithProviders(t *testing.T) {
+	t.Setenv(constants.OktetoStrictEnvEnvVar, "true")
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{
+			name:  "vault",
+			value: "${vault:secret/db#password}",
+		},
+		{
+			name:  "aws secrets manager",
+			value: "${aws-sm:db}",
+		},
+		{
+			name:  "1password",
+			value: "${op://vault/item/field}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NoError(t, checkEnvReferences(tt.value))
+			assert.EqualError(t, checkEnvReferences(tt.value+"/${NAME}"), "undefined variables in the manifest: NAME")
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"path/filepath"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// NvidiaGPUResource is the resource of the nvidia device plugin
+	NvidiaGPUResource apiv1.ResourceName = "nvidia.com/gpu"
+
+	nvidiaDriverCapabilitiesEnvVar = "NVIDIA_DRIVER_CAPABILITIES"
+	nvidiaDriverCapabilities       = "compute,utility"
+)
+
+// GPU defines the GPUs requested by a development container
+type GPU struct {
+	Count    int64              `json:"count,omitempty" yaml:"count,omitempty"`
+	Resource apiv1.ResourceName `json:"resource,omitempty" yaml:"resource,omitempty"`
+	// DriverPaths are host paths mounted in the development container, for clusters whose device plugin doesn't inject the drivers.
+	// Nothing is mounted by default: host paths are forbidden in many clusters, and they would shadow the injected drivers
+	DriverPaths []string `json:"driverPaths,omitempty" yaml:"driverPaths,omitempty"`
+}
+
+type gpuRaw GPU
+
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+func (g *GPU) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var count int64
+	if err := unmarshal(&count); err == nil {
+		g.Count = count
+		return nil
+	}
+
+	var raw gpuRaw
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+	*g = GPU(raw)
+	return nil
+}
+
+func (g *GPU) validate() error {
+	if g.Count <= 0 {
+		return fmt.Errorf("'gpu.count' must be > 0")
+	}
+	for _, p := range g.DriverPaths {
+		if !filepath.IsAbs(p) {
+			return fmt.Errorf("'gpu.driverPaths' must be absolute paths: '%s' is not", p)
+		}
+	}
+	return nil
+}
+
+// setGPUDefaults requests the GPUs as a limit of the dev container and makes it tolerate the taint of the GPU nodes.
+// It can be called more than once for the same dev container
+func (dev *Dev) setGPUDefaults() {
+	g := dev.GPU
+	if g.Resource == "" {
+		g.Resource = NvidiaGPUResource
+	}
+
+	if dev.Resources.Limits == nil {
+		dev.Resources.Limits = ResourceList{}
+	}
+	dev.Resources.Limits[g.Resource] = *resource.NewQuantity(g.Count, resource.DecimalSI)
+
+	hasToleration := false
+	for _, t := range dev.Tolerations {
+		if t.Key == string(g.Resource) {
+			hasToleration = true
+			break
+		}
+	}
+	if !hasToleration {
+		dev.Tolerations = append(dev.Tolerations, apiv1.Toleration{
+			Key:      string(g.Resource),
+			Operator: apiv1.TolerationOpExists,
+			Effect:   apiv1.TaintEffectNoSchedule,
+		})
+	}
+
+	if g.Resource != NvidiaGPUResource {
+		return
+	}
+	for _, e := range dev.Environment {
+		if e.Name == nvidiaDriverCapabilitiesEnvVar {
+			return
+		}
+	}
+	dev.Environment = append(dev.Environment, EnvVar{Name: nvidiaDriverCapabilitiesEnvVar, Value: nvidiaDriverCapabilities})
+}
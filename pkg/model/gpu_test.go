@@ -0,0 +1,87 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestGPUUnmarshal(t *testing.T) {
+	var tests = []struct {
+		name     string
+		manifest string
+		expected GPU
+	}{
+		{
+			name:     "shorthand",
+			manifest: "gpu: 2",
+			expected: GPU{Count: 2},
+		},
+		{
+			name: "extended",
+			manifest: `gpu:
+  count: 1
+  resource: amd.com/gpu
+  driverPaths:
+  - /opt/rocm`,
+			expected: GPU{Count: 1, Resource: "amd.com/gpu", DriverPaths: []string{"/opt/rocm"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev := &Dev{}
+			require.NoError(t, yaml.Unmarshal([]byte(tt.manifest), dev))
+			require.NotNil(t, dev.GPU)
+			assert.Equal(t, tt.expected, *dev.GPU)
+		})
+	}
+}
+
+func TestGPUValidate(t *testing.T) {
+	assert.NoError(t, (&GPU{Count: 1, DriverPaths: []string{"/usr/local/nvidia"}}).validate())
+	assert.Error(t, (&GPU{}).validate())
+	assert.Error(t, (&GPU{Count: 1, DriverPaths: []string{"nvidia"}}).validate())
+}
+
+func TestSetGPUDefaults(t *testing.T) {
+	dev := &Dev{
+		GPU:         &GPU{Count: 2},
+		Resources:   ResourceRequirements{Limits: ResourceList{apiv1.ResourceCPU: resource.MustParse("1")}},
+		Environment: Environment{{Name: "DEBUG", Value: "true"}},
+	}
+	dev.setGPUDefaults()
+	// it can be called more than once
+	dev.setGPUDefaults()
+
+	assert.Equal(t, NvidiaGPUResource, dev.GPU.Resource)
+	assert.Nil(t, dev.GPU.DriverPaths)
+	assert.Equal(t, ResourceList{
+		apiv1.ResourceCPU: resource.MustParse("1"),
+		NvidiaGPUResource: *resource.NewQuantity(2, resource.DecimalSI),
+	}, dev.Resources.Limits)
+	assert.Equal(t, []apiv1.Toleration{{Key: "nvidia.com/gpu", Operator: apiv1.TolerationOpExists, Effect: apiv1.TaintEffectNoSchedule}}, dev.Tolerations)
+	assert.Equal(t, Environment{{Name: "DEBUG", Value: "true"}, {Name: "NVIDIA_DRIVER_CAPABILITIES", Value: "compute,utility"}}, dev.Environment)
+
+	other := &Dev{GPU: &GPU{Count: 1, Resource: "amd.com/gpu"}}
+	other.setGPUDefaults()
+	assert.Nil(t, other.GPU.DriverPaths)
+	assert.Empty(t, other.Environment)
+	assert.Len(t, other.Tolerations, 1)
+}
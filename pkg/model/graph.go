@@ -0,0 +1,190 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ManifestNodeKind is the section of the manifest where a node of the manifest graph is defined
+type ManifestNodeKind string
+
+const (
+	// BuildNode is an image of the build section
+	BuildNode ManifestNodeKind = "build"
+
+	// ServiceNode is a service of the compose file or of the deploy services
+	ServiceNode ManifestNodeKind = "service"
+
+	// DependencyNode is a repository of the dependencies section
+	DependencyNode ManifestNodeKind = "dependency"
+
+	// ExternalNode is a resource of the external section
+	ExternalNode ManifestNodeKind = "external"
+)
+
+// ManifestNodeKinds are the kinds of nodes in the order they are rendered
+var ManifestNodeKinds = []ManifestNodeKind{BuildNode, ServiceNode, DependencyNode, ExternalNode}
+
+// ManifestNode is a build, service, dependency or external resource of a manifest
+type ManifestNode struct {
+	Kind ManifestNodeKind
+	Name string
+}
+
+// ID returns the identifier of the node in the graph, i.e. 'build/api'
+func (n ManifestNode) ID() string {
+	return fmt.Sprintf("%s/%s", n.Kind, n.Name)
+}
+
+// ManifestEdge means that the node From needs the node To
+type ManifestEdge struct {
+	From string
+	To   string
+}
+
+// ManifestGraph are the relations between the builds, services, dependencies and external resources of a manifest
+type ManifestGraph struct {
+	Nodes []ManifestNode
+	Edges []ManifestEdge
+}
+
+// Graph returns the graph of the builds, services, dependencies and external resources of the manifest.
+// Services are linked to the builds and external resources whose environment variables they reference
+func (m *Manifest) Graph() *ManifestGraph {
+	g := &ManifestGraph{}
+	builds := map[string]bool{}
+	for name := range m.Build {
+		builds[name] = true
+		g.Nodes = append(g.Nodes, ManifestNode{Kind: BuildNode, Name: name})
+	}
+	for name, b := range m.Build {
+		if b == nil {
+			continue
+		}
+		for _, dependency := range b.DependsOn {
+			g.addEdge(BuildNode, name, BuildNode, dependency)
+		}
+	}
+	for name := range m.Dependencies {
+		g.Nodes = append(g.Nodes, ManifestNode{Kind: DependencyNode, Name: name})
+	}
+	for name := range m.External {
+		g.Nodes = append(g.Nodes, ManifestNode{Kind: ExternalNode, Name: name})
+	}
+
+	references := func(service, text string) {
+		for build := range builds {
+			if strings.Contains(text, fmt.Sprintf("OKTETO_BUILD_%s_", toEnvName(build))) {
+				g.addEdge(ServiceNode, service, BuildNode, build)
+			}
+		}
+		for external := range m.External {
+			if strings.Contains(text, fmt.Sprintf("OKTETO_EXTERNAL_%s_", toEnvName(external))) {
+				g.addEdge(ServiceNode, service, ExternalNode, external)
+			}
+		}
+	}
+
+	if m.Deploy != nil {
+		for name, svc := range m.Deploy.Services {
+			g.Nodes = append(g.Nodes, ManifestNode{Kind: ServiceNode, Name: name})
+			if svc == nil {
+				continue
+			}
+			for _, dependency := range svc.DependsOn {
+				g.addEdge(ServiceNode, name, ServiceNode, dependency)
+			}
+			for _, c := range svc.Commands {
+				references(name, c.Command)
+			}
+		}
+		if m.Deploy.ComposeSection != nil && m.Deploy.ComposeSection.Stack != nil {
+			for name, svc := range m.Deploy.ComposeSection.Stack.Services {
+				if _, ok := m.Deploy.Services[name]; !ok {
+					g.Nodes = append(g.Nodes, ManifestNode{Kind: ServiceNode, Name: name})
+				}
+				if svc == nil {
+					continue
+				}
+				for dependency := range svc.DependsOn {
+					g.addEdge(ServiceNode, name, ServiceNode, dependency)
+				}
+				if svc.Build != nil && builds[name] {
+					g.addEdge(ServiceNode, name, BuildNode, name)
+				}
+				references(name, svc.Image)
+				for _, e := range svc.Environment {
+					references(name, e.Value)
+				}
+			}
+		}
+	}
+
+	g.sort()
+	return g
+}
+
+func (g *ManifestGraph) addEdge(fromKind ManifestNodeKind, from string, toKind ManifestNodeKind, to string) {
+	e := ManifestEdge{
+		From: ManifestNode{Kind: fromKind, Name: from}.ID(),
+		To:   ManifestNode{Kind: toKind, Name: to}.ID(),
+	}
+	for _, existing := range g.Edges {
+		if existing == e {
+			return
+		}
+	}
+	g.Edges = append(g.Edges, e)
+}
+
+func (g *ManifestGraph) sort() {
+	kindOrder := map[ManifestNodeKind]int{}
+	for i, k := range ManifestNodeKinds {
+		kindOrder[k] = i
+	}
+	sort.Slice(g.Nodes, func(i, j int) bool {
+		if g.Nodes[i].Kind != g.Nodes[j].Kind {
+			return kindOrder[g.Nodes[i].Kind] < kindOrder[g.Nodes[j].Kind]
+		}
+		return g.Nodes[i].Name < g.Nodes[j].Name
+	})
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		return g.Edges[i].To < g.Edges[j].To
+	})
+}
+
+// Cycle returns the sorted identifiers of the nodes of a cycle of the graph, or an empty list if the graph doesn't have cycles
+func (g *ManifestGraph) Cycle() []string {
+	dependencies := graph{}
+	for _, n := range g.Nodes {
+		dependencies[n.ID()] = []string{}
+	}
+	for _, e := range g.Edges {
+		dependencies[e.From] = append(dependencies[e.From], e.To)
+	}
+	cycle := getDependentCyclic(dependencies)
+	sort.Strings(cycle)
+	return cycle
+}
+
+// toEnvName returns the name used in the environment variables of builds and external resources
+func toEnvName(name string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "_", " ", "_").Replace(name))
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/externalresource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManifestGraph(t *testing.T) {
+	m := &Manifest{
+		Build: ManifestBuild{
+			"api":  &BuildInfo{Context: "api", DependsOn: BuildDependsOn{"base"}},
+			"base": &BuildInfo{Context: "base"},
+			"web":  &BuildInfo{Context: "web"},
+		},
+		Dependencies: ManifestDependencies{
+			"auth": &Dependency{},
+		},
+		External: externalresource.ExternalResourceSection{
+			"docs-site": &externalresource.ExternalResource{},
+		},
+		Deploy: &DeployInfo{
+			Services: DeployServices{
+				"migrations": &DeployService{
+					Commands:  []DeployCommand{{Command: "helm upgrade --set image=${OKTETO_BUILD_API_IMAGE} migrations chart"}},
+					DependsOn: []string{"db"},
+				},
+			},
+			ComposeSection: &ComposeSectionInfo{
+				Stack: &Stack{
+					Services: ComposeServices{
+						"api": &Service{
+							Build:       &BuildInfo{Context: "api"},
+							DependsOn:   DependsOn{"db": DependsOnConditionSpec{}},
+							Environment: Environment{{Name: "DOCS", Value: "${OKTETO_EXTERNAL_DOCS_SITE_ENDPOINTS_WEB_URL}"}},
+						},
+						"web": &Service{Image: "${OKTETO_BUILD_WEB_IMAGE}"},
+						"db":  &Service{Image: "postgres:14"},
+					},
+				},
+			},
+		},
+	}
+
+	g := m.Graph()
+	assert.Equal(t, []ManifestNode{
+		{Kind: BuildNode, Name: "api"},
+		{Kind: BuildNode, Name: "base"},
+		{Kind: BuildNode, Name: "web"},
+		{Kind: ServiceNode, Name: "api"},
+		{Kind: ServiceNode, Name: "db"},
+		{Kind: ServiceNode, Name: "migrations"},
+		{Kind: ServiceNode, Name: "web"},
+		{Kind: DependencyNode, Name: "auth"},
+		{Kind: ExternalNode, Name: "docs-site"},
+	}, g.Nodes)
+	assert.Equal(t, []ManifestEdge{
+		{From: "build/api", To: "build/base"},
+		{From: "service/api", To: "build/api"},
+		{From: "service/api", To: "external/docs-site"},
+		{From: "service/api", To: "service/db"},
+		{From: "service/migrations", To: "build/api"},
+		{From: "service/migrations", To: "service/db"},
+		{From: "service/web", To: "build/web"},
+	}, g.Edges)
+	assert.Empty(t, g.Cycle())
+}
+
+func TestManifestGraphCycle(t *testing.T) {
+	g := &ManifestGraph{
+		Nodes: []ManifestNode{
+			{Kind: ServiceNode, Name: "a"},
+			{Kind: ServiceNode, Name: "b"},
+		},
+		Edges: []ManifestEdge{
+			{From: "service/a", To: "service/b"},
+			{From: "service/b", To: "service/a"},
+		},
+	}
+	assert.Equal(t, []string{"service/a", "service/b"}, g.Cycle())
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"path"
+	"time"
+)
+
+// ManifestJobs defines the jobs section of the manifest
+type ManifestJobs map[string]*ManifestJob
+
+// ManifestJob represents a command that 'okteto run' executes as a Kubernetes job in the namespace
+type ManifestJob struct {
+	Image       string        `json:"image,omitempty" yaml:"image,omitempty"`
+	Command     Command       `json:"command,omitempty" yaml:"command,omitempty"`
+	Environment Environment   `json:"environment,omitempty" yaml:"environment,omitempty"`
+	Mounts      []JobMount    `json:"mounts,omitempty" yaml:"mounts,omitempty"`
+	Timeout     time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+// JobMount mounts a secret, a configmap or a persistent volume claim of the namespace in the job container
+type JobMount struct {
+	Path      string `json:"path,omitempty" yaml:"path,omitempty"`
+	Secret    string `json:"secret,omitempty" yaml:"secret,omitempty"`
+	ConfigMap string `json:"configmap,omitempty" yaml:"configmap,omitempty"`
+	Volume    string `json:"volume,omitempty" yaml:"volume,omitempty"`
+}
+
+func (j ManifestJobs) validate() error {
+	for name, job := range j {
+		if job == nil || job.Image == "" {
+			return fmt.Errorf("the field 'jobs.%s.image' is mandatory", name)
+		}
+		if len(job.Command.Values) == 0 {
+			return fmt.Errorf("the field 'jobs.%s.command' is mandatory", name)
+		}
+		if job.Timeout < 0 {
+			return fmt.Errorf("the field 'jobs.%s.timeout' must be a positive duration", name)
+		}
+		for _, m := range job.Mounts {
+			if err := m.validate(); err != nil {
+				return fmt.Errorf("the mount '%s' of the job '%s' is not valid: %w", m.Path, name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (m JobMount) validate() error {
+	if !path.IsAbs(m.Path) {
+		return fmt.Errorf("the path must be absolute")
+	}
+	sources := 0
+	for _, s := range []string{m.Secret, m.ConfigMap, m.Volume} {
+		if s != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return fmt.Errorf("exactly one of 'secret', 'configmap' or 'volume' must be defined")
+	}
+	return nil
+}
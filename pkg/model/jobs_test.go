@@ -0,0 +1,110 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestManifestJobsUnmarshal(t *testing.T) {
+	manifest := []byte(`
+jobs:
+  seed-db:
+    image: postgres:15
+    command: psql -f seed.sql
+    environment:
+      PGHOST: db
+    mounts:
+      - path: /seed
+        configmap: seed
+    timeout: 5m
+`)
+	m := &Manifest{}
+	require.NoError(t, yaml.UnmarshalStrict(manifest, m))
+	assert.Equal(t, ManifestJobs{
+		"seed-db": {
+			Image:       "postgres:15",
+			Command:     Command{Values: []string{"sh", "-c", "psql -f seed.sql"}},
+			Environment: Environment{{Name: "PGHOST", Value: "db"}},
+			Mounts:      []JobMount{{Path: "/seed", ConfigMap: "seed"}},
+			Timeout:     5 * time.Minute,
+		},
+	}, m.Jobs)
+}
+
+func TestManifestJobsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		jobs    ManifestJobs
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			jobs: ManifestJobs{
+				"seed-db": {
+					Image:   "postgres:15",
+					Command: Command{Values: []string{"psql"}},
+					Mounts:  []JobMount{{Path: "/data", Volume: "data"}},
+				},
+			},
+		},
+		{
+			name:    "missing image",
+			jobs:    ManifestJobs{"seed-db": {Command: Command{Values: []string{"psql"}}}},
+			wantErr: true,
+		},
+		{
+			name:    "missing command",
+			jobs:    ManifestJobs{"seed-db": {Image: "postgres:15"}},
+			wantErr: true,
+		},
+		{
+			name: "relative mount path",
+			jobs: ManifestJobs{
+				"seed-db": {
+					Image:   "postgres:15",
+					Command: Command{Values: []string{"psql"}},
+					Mounts:  []JobMount{{Path: "data", Volume: "data"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "mount with several sources",
+			jobs: ManifestJobs{
+				"seed-db": {
+					Image:   "postgres:15",
+					Command: Command{Values: []string{"psql"}},
+					Mounts:  []JobMount{{Path: "/data", Volume: "data", Secret: "creds"}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.jobs.validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -17,13 +17,16 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"reflect"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/a8m/envsubst"
 	"github.com/a8m/envsubst/parse"
 	"github.com/okteto/okteto/pkg/constants"
@@ -89,6 +92,9 @@ const (
 
 var (
 	priorityOrder = []string{"dev", "dependencies", "deploy", "build", "name"}
+
+	// variableNameRegex is the regex to validate the name of a manifest variable
+	variableNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
 )
 
 // Manifest represents an okteto manifest
@@ -104,18 +110,113 @@ type Manifest struct {
 	Dependencies  ManifestDependencies                     `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
 	GlobalForward []forward.GlobalForward                  `json:"forward,omitempty" yaml:"forward,omitempty"`
 	External      externalresource.ExternalResourceSection `json:"external,omitempty" yaml:"external,omitempty"`
+	Test          ManifestTests                            `json:"test,omitempty" yaml:"test,omitempty"`
+	Jobs          ManifestJobs                             `json:"jobs,omitempty" yaml:"jobs,omitempty"`
+	Variables     []ManifestVariable                       `json:"variables,omitempty" yaml:"variables,omitempty"`
+	Outputs       []ManifestOutput                         `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+	Links         ManifestLinks                            `json:"link,omitempty" yaml:"link,omitempty"`
+	Timeouts      *Timeouts                                `json:"timeouts,omitempty" yaml:"timeouts,omitempty"`
+	Webhooks      []Webhook                                `json:"webhooks,omitempty" yaml:"webhooks,omitempty"`
 
 	Type     Archetype `json:"-" yaml:"-"`
 	Manifest []byte    `json:"-" yaml:"-"`
 	IsV2     bool      `json:"-" yaml:"-"`
 }
 
+// ManifestVariable is a variable declared in the manifest. 'okteto deploy' asks for its value if it isn't set
+type ManifestVariable struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Default     string `json:"default,omitempty" yaml:"default,omitempty"`
+	Required    bool   `json:"required,omitempty" yaml:"required,omitempty"`
+	Secret      bool   `json:"secret,omitempty" yaml:"secret,omitempty"`
+}
+
+// OutputType is the type of the value of an output
+type OutputType string
+
+const (
+	// OutputTypeString is an output with any value
+	OutputTypeString OutputType = "string"
+	// OutputTypeURL is an output whose value is an absolute url
+	OutputTypeURL OutputType = "url"
+	// OutputTypeSecret is an output whose value is masked in the logs
+	OutputTypeSecret OutputType = "secret"
+)
+
+// ManifestOutput is a value that the deploy commands of a development environment export to $OKTETO_ENV,
+// so the environments that declare it as a dependency can use it
+type ManifestOutput struct {
+	Name        string     `json:"name" yaml:"name"`
+	Description string     `json:"description,omitempty" yaml:"description,omitempty"`
+	Type        OutputType `json:"type,omitempty" yaml:"type,omitempty"`
+	Required    bool       `json:"required,omitempty" yaml:"required,omitempty"`
+}
+
+// IsSecret returns true if the value of the output must be masked
+func (o ManifestOutput) IsSecret() bool {
+	return o.Type == OutputTypeSecret
+}
+
+func (o ManifestOutput) validateValue(value string) error {
+	if o.Type != OutputTypeURL {
+		return nil
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("the output '%s' must be an absolute url", o.Name)
+	}
+	return nil
+}
+
+// ValidateOutputs checks that the values exported by the deploy commands satisfy the outputs declared in the manifest
+func (m *Manifest) ValidateOutputs(values map[string]string) error {
+	missing := []string{}
+	for _, o := range m.Outputs {
+		value := values[o.Name]
+		if value == "" {
+			if o.Required {
+				missing = append(missing, o.Name)
+			}
+			continue
+		}
+		if err := o.validateValue(value); err != nil {
+			return err
+		}
+	}
+	if len(missing) == 1 {
+		return fmt.Errorf("the required output '%s' was not exported to $OKTETO_ENV", missing[0])
+	}
+	if len(missing) > 1 {
+		return fmt.Errorf("the required outputs '%s' were not exported to $OKTETO_ENV", strings.Join(missing, "', '"))
+	}
+	return nil
+}
+
 // ManifestDevs defines all the dev section
 type ManifestDevs map[string]*Dev
 
 // ManifestBuild defines all the build section
 type ManifestBuild map[string]*BuildInfo
 
+// ManifestLinks represents the map of links at a manifest
+type ManifestLinks map[string]*Link
+
+// Link is a development environment deployed in another namespace, like a shared staging database,
+// whose outputs and endpoints are used by the deploy commands of this one
+type Link struct {
+	Namespace string `json:"namespace" yaml:"namespace"`
+	Name      string `json:"name,omitempty" yaml:"name,omitempty"`
+}
+
+// GetName returns the name of the linked development environment, the name of the link if it's not set
+func (l *Link) GetName(linkName string) string {
+	if l.Name != "" {
+		return l.Name
+	}
+	return linkName
+}
+
 // ManifestDependencies represents the map of dependencies at a manifest
 type ManifestDependencies map[string]*Dependency
 
@@ -182,10 +283,13 @@ func NewManifestFromDev(dev *Dev) *Manifest {
 type DeployInfo struct {
 	Image          string              `json:"image,omitempty" yaml:"image,omitempty"`
 	Commands       []DeployCommand     `json:"commands,omitempty" yaml:"commands,omitempty"`
+	Services       DeployServices      `json:"services,omitempty" yaml:"services,omitempty"`
 	ComposeSection *ComposeSectionInfo `json:"compose,omitempty" yaml:"compose,omitempty"`
 	Endpoints      EndpointSpec        `json:"endpoints,omitempty" yaml:"endpoints,omitempty"`
 	Divert         *DivertDeploy       `json:"divert,omitempty" yaml:"divert,omitempty"`
 	Remote         bool                `json:"remote,omitempty" yaml:"remote,omitempty"`
+	EnvFiles       EnvFiles            `json:"envFiles,omitempty" yaml:"envFiles,omitempty"`
+	PinDigests     bool                `json:"pinDigests,omitempty" yaml:"pinDigests,omitempty"`
 }
 
 // DestroyInfo represents what must be destroyed for the app
@@ -208,9 +312,10 @@ type DivertDeploy struct {
 
 // DivertVirtualService represents a virtual service in a namespace to be diverted
 type DivertVirtualService struct {
-	Name      string   `json:"name,omitempty" yaml:"name,omitempty"`
-	Namespace string   `json:"namespace,omitempty" yaml:"namespace,omitempty"`
-	Routes    []string `json:"routes,omitempty" yaml:"routes,omitempty"`
+	Name      string       `json:"name,omitempty" yaml:"name,omitempty"`
+	Namespace string       `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Routes    []string     `json:"routes,omitempty" yaml:"routes,omitempty"`
+	Rules     []DivertRule `json:"rules,omitempty" yaml:"rules,omitempty"`
 }
 
 // DivertHost represents a host from a virtual service in a namespace to be diverted
@@ -219,6 +324,18 @@ type DivertHost struct {
 	Namespace      string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
 }
 
+// DivertRule represents a custom routing rule used to divert traffic instead of the default baggage header
+type DivertRule struct {
+	Header           *DivertHeaderRule `json:"header,omitempty" yaml:"header,omitempty"`
+	GRPCMethodPrefix string            `json:"grpcMethodPrefix,omitempty" yaml:"grpcMethodPrefix,omitempty"`
+}
+
+// DivertHeaderRule matches requests with a given header value
+type DivertHeaderRule struct {
+	Name  string `json:"name,omitempty" yaml:"name,omitempty"`
+	Value string `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
 // ComposeSectionInfo represents information about compose file
 type ComposeSectionInfo struct {
 	ComposesInfo ComposeInfoList `json:"manifest,omitempty" yaml:"manifest,omitempty"`
@@ -254,15 +371,15 @@ func NewDestroyInfo() *DestroyInfo {
 	}
 }
 
-func getManifestFromOktetoFile(cwd string) (*Manifest, error) {
-	manifestPath, err := discovery.GetOktetoManifestPath(cwd)
+func getManifestFromOktetoFile(cwd string, fs afero.Fs) (*Manifest, error) {
+	manifestPath, err := discovery.GetOktetoManifestPathWithFilesystem(cwd, fs)
 	if err != nil {
 		return nil, err
 	}
 	oktetoLog.Infof("Found okteto manifest file on path: %s", manifestPath)
 	oktetoLog.AddToBuffer(oktetoLog.InfoLevel, "Found okteto manifest on %s", manifestPath)
 	oktetoLog.AddToBuffer(oktetoLog.InfoLevel, "Unmarshalling manifest...")
-	devManifest, err := getManifestFromFile(cwd, manifestPath)
+	devManifest, err := getManifestFromFile(cwd, manifestPath, fs)
 	if err != nil {
 		return nil, err
 	}
@@ -270,12 +387,12 @@ func getManifestFromOktetoFile(cwd string) (*Manifest, error) {
 	return devManifest, nil
 }
 
-func getManifestFromDevFilePath(cwd, manifestPath string) (*Manifest, error) {
+func getManifestFromDevFilePath(cwd, manifestPath string, fs afero.Fs) (*Manifest, error) {
 	if manifestPath != "" && !filepath.IsAbs(manifestPath) {
 		manifestPath = filepath.Join(cwd, manifestPath)
 	}
-	if manifestPath != "" && filesystem.FileExistsAndNotDir(manifestPath) {
-		return getManifestFromFile(cwd, manifestPath)
+	if manifestPath != "" && filesystem.FileExistsAndNotDirWithFilesystem(manifestPath, fs) {
+		return getManifestFromFile(cwd, manifestPath, fs)
 	}
 
 	return nil, discovery.ErrOktetoManifestNotFound
@@ -283,12 +400,17 @@ func getManifestFromDevFilePath(cwd, manifestPath string) (*Manifest, error) {
 
 // GetManifestV1 gets a manifest from a path or search for the files to generate it
 func GetManifestV1(manifestPath string) (*Manifest, error) {
+	return GetManifestV1WithFilesystem(manifestPath, afero.NewOsFs())
+}
+
+// GetManifestV1WithFilesystem gets a manifest from a path or search for the files to generate it in the given filesystem
+func GetManifestV1WithFilesystem(manifestPath string, fs afero.Fs) (*Manifest, error) {
 	cwd, err := os.Getwd()
 	if err != nil {
 		return nil, err
 	}
 
-	manifest, err := getManifestFromDevFilePath(cwd, manifestPath)
+	manifest, err := getManifestFromDevFilePath(cwd, manifestPath, fs)
 	if err != nil {
 		if !errors.Is(err, discovery.ErrOktetoManifestNotFound) {
 			return nil, err
@@ -299,11 +421,11 @@ func GetManifestV1(manifestPath string) (*Manifest, error) {
 		return manifest, nil
 	}
 
-	if manifestPath != "" && pathExistsAndDir(manifestPath) {
+	if manifestPath != "" && pathExistsAndDirWithFilesystem(manifestPath, fs) {
 		cwd = manifestPath
 	}
 
-	manifest, err = getManifestFromOktetoFile(cwd)
+	manifest, err = getManifestFromOktetoFile(cwd, fs)
 	if err != nil {
 		return nil, err
 	}
@@ -313,12 +435,17 @@ func GetManifestV1(manifestPath string) (*Manifest, error) {
 
 // GetManifestV2 gets a manifest from a path or search for the files to generate it
 func GetManifestV2(manifestPath string) (*Manifest, error) {
+	return GetManifestV2WithFilesystem(manifestPath, afero.NewOsFs())
+}
+
+// GetManifestV2WithFilesystem gets a manifest from a path or search for the files to generate it in the given filesystem
+func GetManifestV2WithFilesystem(manifestPath string, fs afero.Fs) (*Manifest, error) {
 	cwd, err := os.Getwd()
 	if err != nil {
 		return nil, err
 	}
 
-	manifest, err := getManifestFromDevFilePath(cwd, manifestPath)
+	manifest, err := getManifestFromDevFilePath(cwd, manifestPath, fs)
 	if err != nil {
 		if !errors.Is(err, discovery.ErrOktetoManifestNotFound) {
 			return nil, err
@@ -329,11 +456,11 @@ func GetManifestV2(manifestPath string) (*Manifest, error) {
 		return manifest, nil
 	}
 
-	if manifestPath != "" && pathExistsAndDir(manifestPath) {
+	if manifestPath != "" && pathExistsAndDirWithFilesystem(manifestPath, fs) {
 		cwd = manifestPath
 	}
 
-	manifest, err = getManifestFromOktetoFile(cwd)
+	manifest, err = getManifestFromOktetoFile(cwd, fs)
 	if err != nil {
 		if !errors.Is(err, discovery.ErrOktetoManifestNotFound) {
 			return nil, err
@@ -346,6 +473,11 @@ func GetManifestV2(manifestPath string) (*Manifest, error) {
 
 	inferredManifest, err := GetInferredManifest(cwd)
 	if err != nil {
+		// the default manifest of the repository passed along by a previous okteto command is used when the checkout has none
+		content := os.Getenv(constants.OktetoDefaultManifestEnvVar)
+		if manifest == nil && manifestPath == "" && content != "" && errors.Is(err, oktetoErrors.ErrCouldNotInferAnyManifest) {
+			return GetDefaultManifestWithFilesystem(cwd, []byte(content), fs)
+		}
 		return nil, err
 	}
 	if inferredManifest != nil {
@@ -390,9 +522,20 @@ func GetManifestV2(manifestPath string) (*Manifest, error) {
 	return nil, discovery.ErrOktetoManifestNotFound
 }
 
+// GetDefaultManifestWithFilesystem loads the content of a default okteto manifest as if it was in cwd,
+// so its relative paths point to the local checkout. The manifest is never written to disk
+func GetDefaultManifestWithFilesystem(cwd string, content []byte, fs afero.Fs) (*Manifest, error) {
+	overlay := afero.NewCopyOnWriteFs(afero.NewReadOnlyFs(fs), afero.NewMemMapFs())
+	manifestPath := filepath.Join(cwd, "okteto.yml")
+	if err := afero.WriteFile(overlay, manifestPath, content, 0600); err != nil {
+		return nil, err
+	}
+	return GetManifestV2WithFilesystem(manifestPath, overlay)
+}
+
 // getManifestFromFile retrieves the manifest from a given file, okteto manifest or docker-compose
-func getManifestFromFile(cwd, manifestPath string) (*Manifest, error) {
-	devManifest, err := getOktetoManifest(manifestPath)
+func getManifestFromFile(cwd, manifestPath string, fs afero.Fs) (*Manifest, error) {
+	devManifest, err := getOktetoManifest(manifestPath, fs)
 	if err != nil {
 		oktetoLog.Info("devManifest err, fallback to stack unmarshall")
 		stackManifest := &Manifest{
@@ -611,8 +754,8 @@ func inferHelmTags(path string) string {
 }
 
 // getOktetoManifest returns an okteto object from a given file
-func getOktetoManifest(devPath string) (*Manifest, error) {
-	b, err := os.ReadFile(devPath)
+func getOktetoManifest(devPath string, fs afero.Fs) (*Manifest, error) {
+	b, err := afero.ReadFile(fs, devPath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, discovery.ErrOktetoManifestNotFound
@@ -633,7 +776,7 @@ func getOktetoManifest(devPath string) (*Manifest, error) {
 	}
 
 	ef := externalresource.ERFilesystemManager{
-		Fs: afero.NewOsFs(),
+		Fs: fs,
 	}
 
 	for name, external := range manifest.External {
@@ -707,9 +850,81 @@ func (m *Manifest) validate() error {
 	if err := m.Build.validate(); err != nil {
 		return err
 	}
+	if err := m.Test.validate(); err != nil {
+		return err
+	}
+	if err := m.Jobs.validate(); err != nil {
+		return err
+	}
+	if m.Deploy != nil {
+		if err := m.Deploy.Services.validate(); err != nil {
+			return err
+		}
+	}
+	if err := m.validateVariables(); err != nil {
+		return err
+	}
+	if err := m.validateOutputs(); err != nil {
+		return err
+	}
+	if err := m.validateWebhooks(); err != nil {
+		return err
+	}
+	for name, l := range m.Links {
+		if l == nil || l.Namespace == "" {
+			return fmt.Errorf("the field 'link.%s.namespace' is mandatory", name)
+		}
+	}
+	for name, d := range m.Dependencies {
+		if d == nil {
+			continue
+		}
+		if err := d.validate(); err != nil {
+			return fmt.Errorf("the dependency '%s' is not valid: %w", name, err)
+		}
+	}
 	return m.validateDivert()
 }
 
+func (m *Manifest) validateOutputs() error {
+	names := map[string]bool{}
+	for i, o := range m.Outputs {
+		if o.Name == "" {
+			return fmt.Errorf("the field 'outputs[%d].name' is mandatory", i)
+		}
+		if !variableNameRegex.MatchString(o.Name) {
+			return fmt.Errorf("the field 'outputs[%d].name' is not valid: '%s' is not a valid environment variable name", i, o.Name)
+		}
+		if names[o.Name] {
+			return fmt.Errorf("the output '%s' is declared more than once", o.Name)
+		}
+		names[o.Name] = true
+		switch o.Type {
+		case "", OutputTypeString, OutputTypeURL, OutputTypeSecret:
+		default:
+			return fmt.Errorf("the field 'outputs[%d].type' is not valid: supported values are '%s', '%s' and '%s'", i, OutputTypeString, OutputTypeURL, OutputTypeSecret)
+		}
+	}
+	return nil
+}
+
+func (m *Manifest) validateVariables() error {
+	names := map[string]bool{}
+	for i, v := range m.Variables {
+		if v.Name == "" {
+			return fmt.Errorf("the field 'variables[%d].name' is mandatory", i)
+		}
+		if !variableNameRegex.MatchString(v.Name) {
+			return fmt.Errorf("the field 'variables[%d].name' is not valid: '%s' is not a valid environment variable name", i, v.Name)
+		}
+		if names[v.Name] {
+			return fmt.Errorf("the variable '%s' is declared more than once", v.Name)
+		}
+		names[v.Name] = true
+	}
+	return nil
+}
+
 func (b *ManifestBuild) validate() error {
 	cycle := getDependentCyclic(b.toGraph())
 	if len(cycle) == 1 { // depends on the same node
@@ -718,6 +933,20 @@ func (b *ManifestBuild) validate() error {
 		svcsDependents := fmt.Sprintf("%s and %s", strings.Join(cycle[:len(cycle)-1], ", "), cycle[len(cycle)-1])
 		return fmt.Errorf("manifest validation failed: cyclic dependendecy found between %s", svcsDependents)
 	}
+	for name, buildInfo := range *b {
+		if buildInfo == nil {
+			continue
+		}
+		if err := buildInfo.validateBuilder(); err != nil {
+			return fmt.Errorf("the field 'build.%s.builder' is not valid: %w", name, err)
+		}
+		if buildInfo.Scan == nil {
+			continue
+		}
+		if err := buildInfo.Scan.validate(); err != nil {
+			return fmt.Errorf("the field 'build.%s.scan' is not valid: %w", name, err)
+		}
+	}
 	return nil
 }
 
@@ -820,6 +1049,11 @@ func (m *Manifest) validateDivert() error {
 			if m.Deploy.Divert.VirtualServices[i].Namespace == "" {
 				return fmt.Errorf("the field 'deploy.divert.virtualServices[%d].namespace' is mandatory", i)
 			}
+			for j, rule := range m.Deploy.Divert.VirtualServices[i].Rules {
+				if err := rule.validate(); err != nil {
+					return fmt.Errorf("the field 'deploy.divert.virtualServices[%d].rules[%d]' is not valid: %w", i, j, err)
+				}
+			}
 		}
 		for i := range m.Deploy.Divert.Hosts {
 			if m.Deploy.Divert.Hosts[i].VirtualService == "" {
@@ -835,6 +1069,27 @@ func (m *Manifest) validateDivert() error {
 	return nil
 }
 
+func (r DivertRule) validate() error {
+	if r.Header == nil && r.GRPCMethodPrefix == "" {
+		return fmt.Errorf("one of 'header' or 'grpcMethodPrefix' must be set")
+	}
+	if r.Header != nil && r.GRPCMethodPrefix != "" {
+		return fmt.Errorf("'header' and 'grpcMethodPrefix' can not be set in the same rule")
+	}
+	if r.Header != nil {
+		if r.Header.Name == "" {
+			return fmt.Errorf("'header.name' is mandatory")
+		}
+		if r.Header.Value == "" {
+			return fmt.Errorf("'header.value' is mandatory")
+		}
+	}
+	if r.GRPCMethodPrefix != "" && !strings.HasPrefix(r.GRPCMethodPrefix, "/") {
+		return fmt.Errorf("'grpcMethodPrefix' must start with '/', for example '/package.Service/'")
+	}
+	return nil
+}
+
 func (m *Manifest) setDefaults() error {
 	if m.Deploy != nil && m.Deploy.Divert != nil {
 		var err error
@@ -998,6 +1253,8 @@ type Dependency struct {
 	Repository   string        `json:"repository" yaml:"repository"`
 	ManifestPath string        `json:"manifest,omitempty" yaml:"manifest,omitempty"`
 	Branch       string        `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Tag          string        `json:"tag,omitempty" yaml:"tag,omitempty"`
+	Version      string        `json:"version,omitempty" yaml:"version,omitempty"`
 	Variables    Environment   `json:"variables,omitempty" yaml:"variables,omitempty"`
 	Wait         bool          `json:"wait,omitempty" yaml:"wait,omitempty"`
 	Timeout      time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
@@ -1012,6 +1269,25 @@ func (d *Dependency) GetTimeout(defaultTimeout time.Duration) time.Duration {
 	return defaultTimeout
 }
 
+func (d *Dependency) validate() error {
+	refs := 0
+	for _, ref := range []string{d.Branch, d.Tag, d.Version} {
+		if ref != "" {
+			refs++
+		}
+	}
+	if refs > 1 {
+		return fmt.Errorf("only one of the fields 'branch', 'tag' or 'version' can be set")
+	}
+	// the version might have variables that are expanded when the dependency is deployed
+	if d.Version != "" && !strings.Contains(d.Version, "$") {
+		if _, err := semver.NewConstraint(d.Version); err != nil {
+			return fmt.Errorf("the field 'version' is not a valid semver constraint: %w", err)
+		}
+	}
+	return nil
+}
+
 // ExpandVars sets dependencies values if values fits with list params
 func (d *Dependency) ExpandVars(variables []string) error {
 	parser := parse.New("string", append(os.Environ(), variables...), &parse.Restrictions{})
@@ -1024,6 +1300,22 @@ func (d *Dependency) ExpandVars(variables []string) error {
 		d.Branch = expandedBranch
 	}
 
+	expandedTag, err := parser.Parse(d.Tag)
+	if err != nil {
+		return fmt.Errorf("error expanding 'tag': %w", err)
+	}
+	if expandedTag != "" {
+		d.Tag = expandedTag
+	}
+
+	expandedVersion, err := parser.Parse(d.Version)
+	if err != nil {
+		return fmt.Errorf("error expanding 'version': %w", err)
+	}
+	if expandedVersion != "" {
+		d.Version = expandedVersion
+	}
+
 	expandedRepository, err := parser.Parse(d.Repository)
 	if err != nil {
 		return fmt.Errorf("error expanding 'repository': %w", err)
@@ -1150,8 +1442,13 @@ func (m *Manifest) InferFromStack(cwd string) (*Manifest, error) {
 
 // WriteToFile writes a manifest to a file with comments to make it easier to understand
 func (m *Manifest) WriteToFile(filePath string) error {
+	return m.WriteToFileWithFilesystem(filePath, afero.NewOsFs())
+}
+
+// WriteToFileWithFilesystem writes a manifest to a file of fs with comments to make it easier to understand
+func (m *Manifest) WriteToFileWithFilesystem(filePath string, fs afero.Fs) error {
 	if m.Deploy != nil {
-		if len(m.Deploy.Commands) == 0 && m.Deploy.ComposeSection == nil {
+		if len(m.Deploy.Commands) == 0 && len(m.Deploy.Services) == 0 && m.Deploy.ComposeSection == nil {
 			m.Deploy.Commands = []DeployCommand{
 				{
 					Name:    FakeCommand,
@@ -1237,8 +1534,8 @@ func (m *Manifest) WriteToFile(filePath string) error {
 		return err
 	}
 	out := addEmptyLineBetweenSections(buffer.Bytes())
-	if err := os.WriteFile(filePath, out, 0600); err != nil {
-		oktetoLog.Infof("failed to write stignore file: %s", err)
+	if err := afero.WriteFile(fs, filePath, out, 0600); err != nil {
+		oktetoLog.Infof("failed to write okteto manifest: %s", err)
 		return err
 	}
 	return nil
@@ -1452,6 +1749,7 @@ func (m *Manifest) HasDeploySection() bool {
 	return m.IsV2 &&
 		m.Deploy != nil &&
 		(len(m.Deploy.Commands) > 0 ||
+			len(m.Deploy.Services) > 0 ||
 			(m.Deploy.ComposeSection != nil &&
 				m.Deploy.ComposeSection.ComposesInfo != nil))
 }
@@ -143,35 +143,51 @@ func TestGetStructKeys(t *testing.T) {
 			expected: map[string][]string{
 				"forward.Forward":            {"localPort", "remotePort", "name", "labels"},
 				"forward.GlobalForward":      {"localPort", "remotePort", "name", "labels"},
-				"model.BuildInfo":            {"name", "context", "dockerfile", "cache_from", "target", "image", "export_cache", "depends_on", "secrets"},
+				"model.BuildInfo":            {"name", "context", "dockerfile", "cache_from", "target", "image", "export_cache", "depends_on", "secrets", "cache_inputs", "builder"},
+				"model.BuildScan":            {"failOn", "warnOn"},
 				"model.Capabilities":         {"add", "drop"},
 				"model.ComposeInfo":          {"file", "services"},
-				"model.Dependency":           {"repository", "manifest", "branch", "wait", "timeout", "namespace"},
+				"model.Dependency":           {"repository", "manifest", "branch", "tag", "version", "wait", "timeout", "namespace"},
 				"model.DeployCommand":        {"name", "command"},
-				"model.DeployInfo":           {"image", "endpoints", "remote"},
+				"model.DeployInfo":           {"image", "services", "endpoints", "remote", "envFiles", "pinDigests"},
+				"model.DeployService":        {"depends_on"},
 				"model.DestroyInfo":          {"image", "remote"},
-				"model.Dev":                  {"name", "selector", "annotations", "context", "namespace", "container", "imagePullPolicy", "workdir", "serviceAccount", "remote", "sshServerPort", "interface", "services", "initFromImage", "nodeSelector", "autocreate", "envFiles", "mode", "replicas", "healthchecks", "labels"},
+				"model.Dev":                  {"name", "selector", "annotations", "context", "namespace", "container", "imagePullPolicy", "workdir", "serviceAccount", "remote", "sshServerPort", "interface", "services", "initFromImage", "nodeSelector", "runtimeClassName", "autocreate", "envFiles", "mode", "originalWorkload", "replicas", "healthchecks", "labels"},
+				"model.Debug":                {"language", "port"},
 				"model.DivertDeploy":         {"driver", "namespace", "service", "port", "deployment"},
+				"model.DivertHeaderRule":     {"name", "value"},
 				"model.DivertHost":           {"virtualService", "namespace"},
+				"model.DivertRule":           {"grpcMethodPrefix"},
 				"model.DivertVirtualService": {"name", "namespace", "routes"},
 				"model.EnvVar":               {"name", "value"},
+				"model.GPU":                  {"count", "resource", "driverPaths"},
 				"model.HTTPHealtcheck":       {"path", "port"},
 				"model.HealthCheck":          {"test", "interval", "timeout", "retries", "start_period", "disable", "x-okteto-liveness", "x-okteto-readiness"},
 				"model.InitContainer":        {"image"},
 				"model.Lifecycle":            {"postStart", "postStop"},
-				"model.Manifest":             {"name", "namespace", "context", "icon", "dev", "build", "dependencies", "external"},
+				"model.Link":                 {"namespace", "name"},
+				"model.Manifest":             {"name", "namespace", "context", "icon", "dev", "build", "dependencies", "external", "test", "jobs", "link"},
+				"model.ManifestOutput":       {"name", "description", "type", "required"},
+				"model.ManifestVariable":     {"name", "description", "default", "required", "secret"},
 				"model.Metadata":             {"labels", "annotations"},
 				"model.PersistentVolumeInfo": {"enabled", "storageClass", "size"},
 				"model.Probes":               {"liveness", "readiness", "startup"},
+				"model.ReadinessGate":        {"command", "timeout", "interval"},
 				"model.ResourceRequirements": {"limits", "requests"},
 				"model.SecurityContext":      {"runAsUser", "runAsGroup", "fsGroup", "runAsNonRoot", "allowPrivilegeEscalation"},
-				"model.Service":              {"cap_add", "cap_drop", "env_file", "depends_on", "image", "labels", "annotations", "x-node-selector", "restart", "stop_grace_period", "workdir", "max_attempts", "public", "replicas"},
+				"model.Service":              {"cap_add", "cap_drop", "env_file", "depends_on", "image", "labels", "annotations", "x-node-selector", "restart", "stop_grace_period", "workdir", "max_attempts", "profiles", "public", "replicas"},
 				"model.Stack":                {"name", "volumes", "namespace", "context", "services", "endpoints"},
 				"model.StackSecurityContext": {"runAsUser", "runAsGroup"},
 				"model.StorageResource":      {"class"},
-				"model.Sync":                 {"compression", "verbose", "rescanInterval"},
+				"model.Sync":                 {"compression", "verbose", "rescanInterval", "skipLargeFiles", "crlf"},
+				"model.SyncOptions":          {"fsWatcherDelay", "compression", "rescanInterval", "maxFileSize"},
+				"model.Test":                 {"image", "context", "artifacts", "depends_on"},
+				"model.ManifestJob":          {"image", "timeout"},
+				"model.JobMount":             {"path", "secret", "configmap", "volume"},
 				"model.Timeout":              {"default", "resources"},
+				"model.Timeouts":             {"activation", "rollout", "portForward", "apiRetries"},
 				"model.VolumeSpec":           {"labels", "annotations", "class"},
+				"model.Webhook":              {"url", "type", "events", "headers", "payload"},
 			},
 		},
 	}
@@ -24,6 +24,7 @@ import (
 	"github.com/okteto/okteto/pkg/discovery"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	apiv1 "k8s.io/api/core/v1"
@@ -316,6 +317,39 @@ func Test_validateDivert(t *testing.T) {
 			},
 			expectedErr: fmt.Errorf("the field 'deploy.divert.namespace' is mandatory"),
 		},
+		{
+			name: "divert-ok-istio-with-rules",
+			divert: DivertDeploy{
+				Driver: constants.OktetoDivertIstioDriver,
+				VirtualServices: []DivertVirtualService{
+					{
+						Name:      "api",
+						Namespace: "staging",
+						Rules: []DivertRule{
+							{Header: &DivertHeaderRule{Name: "x-team", Value: "cindy"}},
+							{GRPCMethodPrefix: "/movies.Catalog/"},
+						},
+					},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "divert-ko-istio-with-invalid-grpc-rule",
+			divert: DivertDeploy{
+				Driver: constants.OktetoDivertIstioDriver,
+				VirtualServices: []DivertVirtualService{
+					{
+						Name:      "api",
+						Namespace: "staging",
+						Rules: []DivertRule{
+							{GRPCMethodPrefix: "movies.Catalog"},
+						},
+					},
+				},
+			},
+			expectedErr: fmt.Errorf("the field 'deploy.divert.virtualServices[0].rules[0]' is not valid: %w", fmt.Errorf("'grpcMethodPrefix' must start with '/', for example '/package.Service/'")),
+		},
 	}
 
 	for _, tt := range tests {
@@ -411,6 +445,136 @@ func Test_validateManifestBuild(t *testing.T) {
 	}
 }
 
+func Test_validateVariables(t *testing.T) {
+	tests := []struct {
+		name        string
+		variables   []ManifestVariable
+		expectedErr bool
+	}{
+		{
+			name: "no variables",
+		},
+		{
+			name:      "valid variables",
+			variables: []ManifestVariable{{Name: "REGION"}, {Name: "api_token", Secret: true}},
+		},
+		{
+			name:        "missing name",
+			variables:   []ManifestVariable{{Description: "the region"}},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid name",
+			variables:   []ManifestVariable{{Name: "MY-REGION"}},
+			expectedErr: true,
+		},
+		{
+			name:        "duplicated name",
+			variables:   []ManifestVariable{{Name: "REGION"}, {Name: "REGION"}},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Manifest{
+				Variables: tt.variables,
+			}
+			assert.Equal(t, tt.expectedErr, m.validate() != nil)
+		})
+	}
+}
+
+func Test_validateOutputs(t *testing.T) {
+	tests := []struct {
+		name        string
+		outputs     []ManifestOutput
+		expectedErr bool
+	}{
+		{
+			name: "no outputs",
+		},
+		{
+			name:    "valid outputs",
+			outputs: []ManifestOutput{{Name: "DB_HOST"}, {Name: "API_URL", Type: OutputTypeURL}, {Name: "DB_PASSWORD", Type: OutputTypeSecret}},
+		},
+		{
+			name:        "invalid type",
+			outputs:     []ManifestOutput{{Name: "DB_PORT", Type: "int"}},
+			expectedErr: true,
+		},
+		{
+			name:        "duplicated name",
+			outputs:     []ManifestOutput{{Name: "DB_HOST"}, {Name: "DB_HOST"}},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Manifest{
+				Outputs: tt.outputs,
+			}
+			assert.Equal(t, tt.expectedErr, m.validate() != nil)
+		})
+	}
+}
+
+func Test_validateLinks(t *testing.T) {
+	m := &Manifest{Links: ManifestLinks{"db": &Link{Namespace: "staging"}}}
+	assert.NoError(t, m.validate())
+	assert.Equal(t, "db", m.Links["db"].GetName("db"))
+
+	m = &Manifest{Links: ManifestLinks{"db": &Link{Name: "database"}}}
+	assert.Error(t, m.validate())
+}
+
+func TestManifestValidateOutputs(t *testing.T) {
+	m := &Manifest{
+		Outputs: []ManifestOutput{
+			{Name: "API_URL", Type: OutputTypeURL, Required: true},
+			{Name: "DB_PASSWORD", Type: OutputTypeSecret, Required: true},
+			{Name: "DEBUG"},
+		},
+	}
+	tests := []struct {
+		name        string
+		values      map[string]string
+		expectedErr string
+	}{
+		{
+			name:   "all outputs",
+			values: map[string]string{"API_URL": "https://api.okteto.dev?a=b", "DB_PASSWORD": "secret"},
+		},
+		{
+			name:        "missing output",
+			values:      map[string]string{"API_URL": "https://api.okteto.dev"},
+			expectedErr: "the required output 'DB_PASSWORD' was not exported to $OKTETO_ENV",
+		},
+		{
+			name:        "missing outputs",
+			values:      map[string]string{"API_URL": ""},
+			expectedErr: "the required outputs 'API_URL', 'DB_PASSWORD' were not exported to $OKTETO_ENV",
+		},
+		{
+			name:        "invalid url",
+			values:      map[string]string{"API_URL": "api.okteto.dev", "DB_PASSWORD": "secret"},
+			expectedErr: "the output 'API_URL' must be an absolute url",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := m.ValidateOutputs(tt.values)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
 func TestInferFromStack(t *testing.T) {
 	dirtest := filepath.Clean("/stack/dir/")
 	devInterface := Localhost
@@ -608,7 +772,7 @@ func TestInferFromStack(t *testing.T) {
 						ImagePullPolicy: apiv1.PullAlways,
 						InitContainer:   InitContainer{Image: OktetoBinImageTag},
 						Probes:          &Probes{},
-						Lifecycle:       &Lifecycle{},
+						Lifecycle:       &Lifecycle{PostStart: true, PostStop: true},
 						Workdir:         "/okteto",
 						SecurityContext: &SecurityContext{
 							RunAsUser:  pointer.Int64(0),
@@ -900,13 +1064,60 @@ sync:
 				}
 				assert.NoError(t, os.WriteFile(filepath.Join(dir, "docker-compose.yml"), tt.composeBytes, 0600))
 			}
-			_, err := getManifestFromFile(dir, file)
+			_, err := getManifestFromFile(dir, file, afero.NewOsFs())
 
 			assert.ErrorIs(t, err, tt.expectedErr)
 		})
 	}
 }
 
+func TestGetManifestV2WithFilesystem(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	dir := filepath.Join(string(filepath.Separator), "in-memory", "app")
+	manifest := []byte(`deploy:
+  - okteto build
+dev:
+  api:
+    image: okteto/golang:1
+    sync:
+      - .:/app`)
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "okteto.yml"), manifest, 0600))
+
+	m, err := GetManifestV2WithFilesystem(dir, fs)
+	require.NoError(t, err)
+	assert.True(t, m.IsV2)
+	assert.Equal(t, OktetoManifestType, m.Type)
+	require.Contains(t, m.Dev, "api")
+	assert.Equal(t, dir, m.Dev["api"].Sync.Folders[0].LocalPath)
+
+	_, err = GetManifestV1WithFilesystem(filepath.Join(dir, "missing.yml"), afero.NewMemMapFs())
+	assert.ErrorIs(t, err, discovery.ErrOktetoManifestNotFound)
+}
+
+func TestGetManifestV2FromDefaultManifestEnv(t *testing.T) {
+	initialCWD, err := os.Getwd()
+	require.NoError(t, err)
+	dir := t.TempDir()
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() {
+		require.NoError(t, os.Chdir(initialCWD))
+	})
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+
+	t.Setenv(constants.OktetoDefaultManifestEnvVar, "")
+	_, err = GetManifestV2("")
+	assert.ErrorIs(t, err, oktetoErrors.ErrCouldNotInferAnyManifest)
+
+	t.Setenv(constants.OktetoDefaultManifestEnvVar, "deploy:\n  - okteto build\ndev:\n  api:\n    image: okteto/golang:1\n    sync:\n      - api:/app\n")
+	m, err := GetManifestV2("")
+	require.NoError(t, err)
+	assert.True(t, m.IsV2)
+	require.Contains(t, m.Dev, "api")
+	assert.Equal(t, filepath.Join(cwd, "api"), m.Dev["api"].Sync.Folders[0].LocalPath)
+	assert.NoFileExists(t, filepath.Join(dir, "okteto.yml"))
+}
+
 func TestHasDev(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -1152,6 +1363,7 @@ func Test_ExpandVars(t *testing.T) {
 	dependency := Dependency{
 		Repository:   "${REPO}",
 		Branch:       "${NOBRANCHSET-$BRANCH}",
+		Version:      "${VERSION}",
 		ManifestPath: "${NOMPATHSET=$MPATH}",
 		Namespace:    "${FOO+$SOME_NS_DEP_EXP}",
 		Variables: Environment{
@@ -1168,6 +1380,7 @@ func Test_ExpandVars(t *testing.T) {
 	expected := Dependency{
 		Repository:   "my/repo",
 		Branch:       "myBranch",
+		Version:      "^1.2.0",
 		ManifestPath: "api/okteto.yml",
 		Namespace:    "oktetoNs",
 		Variables: Environment{
@@ -1185,6 +1398,7 @@ func Test_ExpandVars(t *testing.T) {
 		"FOO=BAR",
 		"REPO=my/repo",
 		"BRANCH=myBranch",
+		"VERSION=^1.2.0",
 		"MPATH=api/okteto.yml",
 		"SOME_NS_DEP_EXP=oktetoNs",
 		"AVARVALUE=thisIsAValue",
@@ -1195,6 +1409,50 @@ func Test_ExpandVars(t *testing.T) {
 	assert.Equal(t, expected, dependency)
 }
 
+func Test_validateDependency(t *testing.T) {
+	tests := []struct {
+		name        string
+		dependency  *Dependency
+		expectedErr bool
+	}{
+		{
+			name:       "branch",
+			dependency: &Dependency{Repository: "https://github.com/okteto/movies", Branch: "main"},
+		},
+		{
+			name:       "tag",
+			dependency: &Dependency{Repository: "https://github.com/okteto/movies", Tag: "v1.2.0"},
+		},
+		{
+			name:       "version constraint",
+			dependency: &Dependency{Repository: "https://github.com/okteto/movies", Version: "~1.2"},
+		},
+		{
+			name:       "version with variables",
+			dependency: &Dependency{Repository: "https://github.com/okteto/movies", Version: "${MOVIES_VERSION}"},
+		},
+		{
+			name:        "invalid version constraint",
+			dependency:  &Dependency{Repository: "https://github.com/okteto/movies", Version: "latest"},
+			expectedErr: true,
+		},
+		{
+			name:        "branch and tag",
+			dependency:  &Dependency{Repository: "https://github.com/okteto/movies", Branch: "main", Tag: "v1.2.0"},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Manifest{
+				Dependencies: ManifestDependencies{"movies": tt.dependency},
+			}
+			assert.Equal(t, tt.expectedErr, m.validate() != nil)
+		})
+	}
+}
+
 func Test_Manifest_HasDeploySection(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1456,3 +1714,41 @@ func Test_getInferredManifestWhenNoManifestExist(t *testing.T) {
 	assert.Empty(t, result)
 	assert.ErrorIs(t, err, oktetoErrors.ErrCouldNotInferAnyManifest)
 }
+
+func Test_validateDivertRule(t *testing.T) {
+	tests := []struct {
+		name        string
+		rule        DivertRule
+		expectedErr bool
+	}{
+		{
+			name: "header rule",
+			rule: DivertRule{Header: &DivertHeaderRule{Name: "x-team", Value: "cindy"}},
+		},
+		{
+			name: "grpc rule",
+			rule: DivertRule{GRPCMethodPrefix: "/movies.Catalog/"},
+		},
+		{
+			name:        "empty rule",
+			rule:        DivertRule{},
+			expectedErr: true,
+		},
+		{
+			name:        "header and grpc rule",
+			rule:        DivertRule{Header: &DivertHeaderRule{Name: "x-team", Value: "cindy"}, GRPCMethodPrefix: "/movies.Catalog/"},
+			expectedErr: true,
+		},
+		{
+			name:        "header without value",
+			rule:        DivertRule{Header: &DivertHeaderRule{Name: "x-team"}},
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.validate()
+			assert.Equal(t, tt.expectedErr, err != nil)
+		})
+	}
+}
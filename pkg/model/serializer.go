@@ -57,6 +57,9 @@ type buildInfoRaw struct {
 	ExportCache      cache.ExportCache `yaml:"export_cache,omitempty"`
 	DependsOn        BuildDependsOn    `yaml:"depends_on,omitempty"`
 	Secrets          BuildSecrets      `yaml:"secrets,omitempty"`
+	CacheInputs      []string          `yaml:"cache_inputs,omitempty"`
+	Scan             *BuildScan        `yaml:"scan,omitempty"`
+	Builder          string            `yaml:"builder,omitempty"`
 }
 
 type syncRaw struct {
@@ -64,6 +67,9 @@ type syncRaw struct {
 	Verbose        bool         `json:"verbose" yaml:"verbose"`
 	RescanInterval int          `json:"rescanInterval,omitempty" yaml:"rescanInterval,omitempty"`
 	Folders        []SyncFolder `json:"folders,omitempty" yaml:"folders,omitempty"`
+	Options        *SyncOptions `json:"options,omitempty" yaml:"options,omitempty"`
+	SkipLargeFiles string       `json:"skipLargeFiles,omitempty" yaml:"skipLargeFiles,omitempty"`
+	CRLF           string       `json:"crlf,omitempty" yaml:"crlf,omitempty"`
 	LocalPath      string
 	RemotePath     string
 }
@@ -80,10 +86,25 @@ type probesRaw struct {
 	Startup   bool `json:"startup,omitempty" yaml:"startup,omitempty"`
 }
 
+// platformSecretRaw represents a secret from the Okteto platform for serialization
+type platformSecretRaw struct {
+	FromPlatform string `yaml:"fromPlatform,omitempty"`
+	RemotePath   string `yaml:"remotePath,omitempty"`
+	Mode         int32  `yaml:"mode,omitempty"`
+}
+
 // lifecycleRaw represents the lifecycle info for serialization
 type lifecycleRaw struct {
-	PostStart bool `json:"postStart,omitempty" yaml:"postStart,omitempty"`
-	PostStop  bool `json:"postStop,omitempty" yaml:"postStop,omitempty"`
+	PostStart *lifecycleHookRaw `json:"postStart,omitempty" yaml:"postStart,omitempty"`
+	PostStop  *bool             `json:"postStop,omitempty" yaml:"postStop,omitempty"`
+	PreStop   *Command          `json:"preStop,omitempty" yaml:"preStop,omitempty"`
+	PostSync  *Command          `json:"postSync,omitempty" yaml:"postSync,omitempty"`
+}
+
+// lifecycleHookRaw represents a lifecycle hook that is either a bool or a command for serialization
+type lifecycleHookRaw struct {
+	Enabled bool
+	Command *Command
 }
 
 type AffinityRaw struct {
@@ -313,12 +334,15 @@ func (sync *Sync) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	sync.Verbose = rawSync.Verbose
 	sync.RescanInterval = rawSync.RescanInterval
 	sync.Folders = rawSync.Folders
+	sync.Options = rawSync.Options
+	sync.SkipLargeFiles = rawSync.SkipLargeFiles
+	sync.CRLF = rawSync.CRLF
 	return nil
 }
 
 // MarshalYAML Implements the marshaler interface of the yaml pkg.
 func (sync Sync) MarshalYAML() (interface{}, error) {
-	if !sync.Compression && sync.RescanInterval == DefaultSyncthingRescanInterval {
+	if !sync.Compression && sync.RescanInterval == DefaultSyncthingRescanInterval && sync.Options == nil && sync.SkipLargeFiles == "" && sync.CRLF == "" {
 		return sync.Folders, nil
 	}
 	return syncRaw(sync), nil
@@ -367,6 +391,9 @@ func (buildInfo *BuildInfo) UnmarshalYAML(unmarshal func(interface{}) error) err
 	buildInfo.ExportCache = rawBuildInfo.ExportCache
 	buildInfo.DependsOn = rawBuildInfo.DependsOn
 	buildInfo.Secrets = rawBuildInfo.Secrets
+	buildInfo.CacheInputs = rawBuildInfo.CacheInputs
+	buildInfo.Scan = rawBuildInfo.Scan
+	buildInfo.Builder = rawBuildInfo.Builder
 	return nil
 }
 
@@ -384,6 +411,9 @@ func (buildInfo *BuildInfo) MarshalYAML() (interface{}, error) {
 	if buildInfo.Args != nil && len(buildInfo.Args) != 0 {
 		return buildInfoRaw(*buildInfo), nil
 	}
+	if buildInfo.Builder != "" {
+		return buildInfoRaw(*buildInfo), nil
+	}
 	return buildInfo.Name, nil
 }
 
@@ -440,7 +470,11 @@ func (s *Secret) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	var raw string
 	err := unmarshal(&raw)
 	if err != nil {
-		return err
+		var platformSecret platformSecretRaw
+		if err := unmarshal(&platformSecret); err != nil {
+			return fmt.Errorf("secrets must follow the syntax 'LOCAL_PATH:REMOTE_PATH:MODE' or define 'fromPlatform'")
+		}
+		return s.fromPlatformSecretRaw(platformSecret)
 	}
 
 	rawExpanded, err := ExpandEnv(raw, true)
@@ -480,8 +514,40 @@ func (s *Secret) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return nil
 }
 
+func (s *Secret) fromPlatformSecretRaw(raw platformSecretRaw) error {
+	if raw.FromPlatform == "" {
+		return fmt.Errorf("secrets must follow the syntax 'LOCAL_PATH:REMOTE_PATH:MODE' or define 'fromPlatform'")
+	}
+	if strings.Contains(raw.FromPlatform, ".") {
+		return fmt.Errorf("Secret '%s' from the Okteto platform cannot contain '.'", raw.FromPlatform)
+	}
+	s.FromPlatform = raw.FromPlatform
+	s.RemotePath = raw.RemotePath
+	if s.RemotePath == "" {
+		if raw.Mode != 0 {
+			return fmt.Errorf("Secret '%s' from the Okteto platform sets 'mode' but not 'remotePath'", raw.FromPlatform)
+		}
+		return nil
+	}
+	if !strings.HasPrefix(s.RemotePath, "/") {
+		return fmt.Errorf("Secret remote path '%s' must be an absolute path", s.RemotePath)
+	}
+	s.Mode = raw.Mode
+	if s.Mode == 0 {
+		s.Mode = 420
+	}
+	return nil
+}
+
 // MarshalYAML Implements the marshaler interface of the yaml pkg.
 func (s Secret) MarshalYAML() (interface{}, error) {
+	if s.FromPlatform != "" {
+		raw := platformSecretRaw{FromPlatform: s.FromPlatform, RemotePath: s.RemotePath}
+		if s.Mode != 420 {
+			raw.Mode = s.Mode
+		}
+		return raw, nil
+	}
 	if s.Mode == 420 {
 		return fmt.Sprintf("%s:%s:%s", s.LocalPath, s.RemotePath, strconv.FormatInt(int64(s.Mode), 8)), nil
 	}
@@ -707,17 +773,61 @@ func (l *Lifecycle) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		return err
 	}
 
-	l.PostStart = lifecycleRaw.PostStart
-	l.PostStop = lifecycleRaw.PostStop
+	// the hooks of the original container are kept unless they are explicitly disabled or replaced by a command
+	l.PostStart = true
+	if lifecycleRaw.PostStart != nil {
+		l.PostStart = lifecycleRaw.PostStart.Enabled
+		l.PostStartCommand = lifecycleRaw.PostStart.Command
+	}
+	l.PostStop = lifecycleRaw.PreStop == nil
+	if lifecycleRaw.PostStop != nil {
+		l.PostStop = *lifecycleRaw.PostStop
+	}
+	l.PreStop = lifecycleRaw.PreStop
+	l.PostSync = lifecycleRaw.PostSync
 	return nil
 }
 
 // MarshalYAML Implements the marshaler interface of the yaml pkg.
 func (l Lifecycle) MarshalYAML() (interface{}, error) {
-	if l.PostStart && l.PostStop {
+	if l.PostStart && l.PostStop && !l.HasHooks() {
 		return true, nil
 	}
-	return lifecycleRaw(l), nil
+	raw := lifecycleRaw{
+		PreStop:  l.PreStop,
+		PostSync: l.PostSync,
+	}
+	if !l.PostStart || l.PostStartCommand != nil {
+		raw.PostStart = &lifecycleHookRaw{Enabled: l.PostStart, Command: l.PostStartCommand}
+	}
+	if l.PostStop != (l.PreStop == nil) {
+		raw.PostStop = &l.PostStop
+	}
+	return raw, nil
+}
+
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+func (h *lifecycleHookRaw) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var rawBool bool
+	if err := unmarshal(&rawBool); err == nil {
+		h.Enabled = rawBool
+		return nil
+	}
+
+	var command Command
+	if err := unmarshal(&command); err != nil {
+		return err
+	}
+	h.Command = &command
+	return nil
+}
+
+// MarshalYAML Implements the marshaler interface of the yaml pkg.
+func (h lifecycleHookRaw) MarshalYAML() (interface{}, error) {
+	if h.Command != nil {
+		return h.Command, nil
+	}
+	return h.Enabled, nil
 }
 
 func checkFileAndNotDirectory(path string) error {
@@ -772,6 +882,7 @@ var hybridUnsupportedFields = []string{
 	"affinity",
 	"context",
 	"externalVolumes",
+	"gpu",
 	"image",
 	"imagePullPolicy",
 	"initContainer",
@@ -782,6 +893,7 @@ var hybridUnsupportedFields = []string{
 	"persistentVolume",
 	"push",
 	"replicas",
+	"runtimeClassName",
 	"secrets",
 	"securityContext",
 	"serviceAccount",
@@ -888,6 +1000,13 @@ type manifestRaw struct {
 	Dependencies  ManifestDependencies                     `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
 	GlobalForward []forward.GlobalForward                  `json:"forward,omitempty" yaml:"forward,omitempty"`
 	External      externalresource.ExternalResourceSection `json:"external,omitempty" yaml:"external,omitempty"`
+	Test          ManifestTests                            `json:"test,omitempty" yaml:"test,omitempty"`
+	Jobs          ManifestJobs                             `json:"jobs,omitempty" yaml:"jobs,omitempty"`
+	Variables     []ManifestVariable                       `json:"variables,omitempty" yaml:"variables,omitempty"`
+	Outputs       []ManifestOutput                         `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+	Links         ManifestLinks                            `json:"link,omitempty" yaml:"link,omitempty"`
+	Timeouts      *Timeouts                                `json:"timeouts,omitempty" yaml:"timeouts,omitempty"`
+	Webhooks      []Webhook                                `json:"webhooks,omitempty" yaml:"webhooks,omitempty"`
 
 	DeprecatedDevs []string `yaml:"devs"`
 }
@@ -980,6 +1099,13 @@ func (m *Manifest) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	m.Name = manifest.Name
 	m.GlobalForward = manifest.GlobalForward
 	m.External = manifest.External
+	m.Test = manifest.Test
+	m.Jobs = manifest.Jobs
+	m.Variables = manifest.Variables
+	m.Outputs = manifest.Outputs
+	m.Links = manifest.Links
+	m.Timeouts = manifest.Timeouts
+	m.Webhooks = manifest.Webhooks
 
 	err = m.SanitizeSvcNames()
 	if err != nil {
@@ -1059,6 +1185,22 @@ func (d *DeployInfo) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return nil
 }
 
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+func (r *ReadinessGate) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var command string
+	if err := unmarshal(&command); err == nil {
+		r.Command = command
+		return nil
+	}
+	type readinessGateRaw ReadinessGate
+	var readiness readinessGateRaw
+	if err := unmarshal(&readiness); err != nil {
+		return err
+	}
+	*r = ReadinessGate(readiness)
+	return nil
+}
+
 // UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
 func (c *ComposeSectionInfo) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	var composeInfoList ComposeInfoList
@@ -1185,7 +1327,7 @@ func (d *ManifestDevs) UnmarshalYAML(unmarshal func(interface{}) error) error {
 }
 
 func isManifestFieldNotFound(err error) bool {
-	manifestFields := []string{"devs", "dev", "name", "icon", "variables", "deploy", "destroy", "build", "namespace", "context", "dependencies"}
+	manifestFields := []string{"devs", "dev", "name", "icon", "variables", "deploy", "destroy", "build", "namespace", "context", "dependencies", "test", "jobs"}
 	for _, field := range manifestFields {
 		if strings.Contains(err.Error(), fmt.Sprintf("field %s not found", field)) {
 			return true
@@ -1265,7 +1407,7 @@ func (d *Dev) MarshalYAML() (interface{}, error) {
 	if toMarshall.ImagePullPolicy == apiv1.PullAlways {
 		toMarshall.ImagePullPolicy = ""
 	}
-	if toMarshall.Lifecycle != nil && (!toMarshall.Lifecycle.PostStart || !toMarshall.Lifecycle.PostStop) {
+	if toMarshall.Lifecycle != nil && toMarshall.Lifecycle.PostStart && toMarshall.Lifecycle.PostStop && !toMarshall.Lifecycle.HasHooks() {
 		toMarshall.Lifecycle = nil
 	}
 	if toMarshall.Metadata != nil && len(toMarshall.Metadata.Annotations) == 0 && len(toMarshall.Metadata.Labels) == 0 {
@@ -365,13 +365,39 @@ func TestLifecycleMarshalling(t *testing.T) {
 		{
 			name:      "true-and-false",
 			lifecycle: Lifecycle{PostStart: true},
-			expected:  "postStart: true\n",
+			expected:  "postStop: false\n",
+		},
+		{
+			name:      "all-lifecycle-false",
+			lifecycle: Lifecycle{},
+			expected:  "postStart: false\npostStop: false\n",
 		},
 		{
 			name:      "all-lifecycle-true",
 			lifecycle: Lifecycle{PostStart: true, PostStop: true},
 			expected:  "true\n",
 		},
+		{
+			name:      "post-stop",
+			lifecycle: Lifecycle{PostStop: true},
+			expected:  "postStart: false\n",
+		},
+		{
+			name: "hooks",
+			lifecycle: Lifecycle{
+				PostStartCommand: &Command{Values: []string{"sh", "-c", "echo started"}},
+				PostSync:         &Command{Values: []string{"yarn"}},
+			},
+			expected: "postStart:\n- sh\n- -c\n- echo started\npostStop: false\npostSync: yarn\n",
+		},
+		{
+			name: "pre-stop",
+			lifecycle: Lifecycle{
+				PostStart: true,
+				PreStop:   &Command{Values: []string{"./cleanup.sh"}},
+			},
+			expected: "preStop: ./cleanup.sh\n",
+		},
 	}
 
 	for _, tt := range tests {
@@ -388,6 +414,47 @@ func TestLifecycleMarshalling(t *testing.T) {
 	}
 }
 
+func TestLifecycleUnmarshalling(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected Lifecycle
+	}{
+		{
+			name:     "bool",
+			data:     "true",
+			expected: Lifecycle{PostStart: true, PostStop: true},
+		},
+		{
+			name:     "original-hooks-by-default",
+			data:     "postSync: make deps",
+			expected: Lifecycle{PostStart: true, PostStop: true, PostSync: &Command{Values: []string{"sh", "-c", "make deps"}}},
+		},
+		{
+			name:     "original-hooks",
+			data:     "postStart: true\npostStop: false",
+			expected: Lifecycle{PostStart: true},
+		},
+		{
+			name: "hooks",
+			data: "postStart: yarn install\npreStop: [\"./cleanup.sh\"]\npostSync: make deps",
+			expected: Lifecycle{
+				PostStartCommand: &Command{Values: []string{"sh", "-c", "yarn install"}},
+				PreStop:          &Command{Values: []string{"./cleanup.sh"}},
+				PostSync:         &Command{Values: []string{"sh", "-c", "make deps"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result Lifecycle
+			require.NoError(t, yaml.Unmarshal([]byte(tt.data), &result))
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
 func TestSecretMarshalling(t *testing.T) {
 	file, err := os.CreateTemp("", "okteto-secret-test")
 	if err != nil {
@@ -451,6 +518,42 @@ func TestSecretMarshalling(t *testing.T) {
 			nil,
 			true,
 		},
+		{
+			"from-platform-env",
+			"fromPlatform: GITHUB_TOKEN",
+			&Secret{FromPlatform: "GITHUB_TOKEN"},
+			false,
+		},
+		{
+			"from-platform-file",
+			"fromPlatform: NPMRC\nremotePath: /root/.npmrc\nmode: 0400",
+			&Secret{FromPlatform: "NPMRC", RemotePath: "/root/.npmrc", Mode: 256},
+			false,
+		},
+		{
+			"from-platform-default-mode",
+			"fromPlatform: NPMRC\nremotePath: /root/.npmrc",
+			&Secret{FromPlatform: "NPMRC", RemotePath: "/root/.npmrc", Mode: 420},
+			false,
+		},
+		{
+			"from-platform-wrong-remote",
+			"fromPlatform: NPMRC\nremotePath: root/.npmrc",
+			nil,
+			true,
+		},
+		{
+			"from-platform-mode-without-remote",
+			"fromPlatform: NPMRC\nmode: 0400",
+			nil,
+			true,
+		},
+		{
+			"from-platform-empty",
+			"remotePath: /root/.npmrc",
+			nil,
+			true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -474,6 +577,9 @@ func TestSecretMarshalling(t *testing.T) {
 			if result.Mode != tt.expected.Mode {
 				t.Errorf("didn't unmarshal correctly Mode. Actual %d, Expected %d", result.Mode, tt.expected.Mode)
 			}
+			if result.FromPlatform != tt.expected.FromPlatform {
+				t.Errorf("didn't unmarshal correctly FromPlatform. Actual %s, Expected %s", result.FromPlatform, tt.expected.FromPlatform)
+			}
 
 			_, err := yaml.Marshal(&result)
 			if err != nil {
@@ -1081,6 +1187,55 @@ services:
 			expected:        nil,
 			isErrorExpected: true,
 		},
+		{
+			name: "manifest with variables, outputs and links",
+			manifest: []byte(`
+variables:
+  - name: REGION
+    description: the region to deploy to
+    default: us-east-1
+  - name: API_TOKEN
+    required: true
+    secret: true
+outputs:
+  - name: API_URL
+    type: url
+    required: true
+link:
+  db:
+    namespace: staging
+    name: database
+deploy:
+  - okteto stack deploy`),
+			expected: &Manifest{
+				Build: map[string]*BuildInfo{},
+				Deploy: &DeployInfo{
+					Commands: []DeployCommand{
+						{
+							Name:    "okteto stack deploy",
+							Command: "okteto stack deploy",
+						},
+					},
+				},
+				Destroy:      &DestroyInfo{},
+				Dev:          map[string]*Dev{},
+				Dependencies: map[string]*Dependency{},
+				External:     externalresource.ExternalResourceSection{},
+				Variables: []ManifestVariable{
+					{Name: "REGION", Description: "the region to deploy to", Default: "us-east-1"},
+					{Name: "API_TOKEN", Required: true, Secret: true},
+				},
+				Outputs: []ManifestOutput{
+					{Name: "API_URL", Type: OutputTypeURL, Required: true},
+				},
+				Links: ManifestLinks{
+					"db": &Link{Namespace: "staging", Name: "database"},
+				},
+				IsV2: true,
+				Type: OktetoManifestType,
+			},
+			isErrorExpected: false,
+		},
 		{
 			name: "manifest with namespace and context",
 			manifest: []byte(`
@@ -1179,8 +1334,8 @@ dev:
 							Startup:   false,
 						},
 						Lifecycle: &Lifecycle{
-							PostStart: false,
-							PostStop:  false,
+							PostStart: true,
+							PostStop:  true,
 						},
 						SecurityContext: &SecurityContext{
 							RunAsUser:    pointer.Int64(0),
@@ -1244,8 +1399,8 @@ dev:
 							Startup:   false,
 						},
 						Lifecycle: &Lifecycle{
-							PostStart: false,
-							PostStop:  false,
+							PostStart: true,
+							PostStop:  true,
 						},
 						SecurityContext: &SecurityContext{
 							RunAsUser:    pointer.Int64(0),
@@ -1329,8 +1484,8 @@ sync:
 							Startup:   false,
 						},
 						Lifecycle: &Lifecycle{
-							PostStart: false,
-							PostStop:  false,
+							PostStart: true,
+							PostStop:  true,
 						},
 						SecurityContext: &SecurityContext{
 							RunAsUser:    pointer.Int64(0),
@@ -1415,8 +1570,8 @@ services:
 							Startup:   false,
 						},
 						Lifecycle: &Lifecycle{
-							PostStart: false,
-							PostStop:  false,
+							PostStart: true,
+							PostStop:  true,
 						},
 						SecurityContext: &SecurityContext{
 							RunAsUser:    pointer.Int64(0),
@@ -1440,8 +1595,8 @@ services:
 									Startup:   false,
 								},
 								Lifecycle: &Lifecycle{
-									PostStart: false,
-									PostStop:  false,
+									PostStart: true,
+									PostStop:  true,
 								},
 								SecurityContext: &SecurityContext{
 									RunAsUser:    pointer.Int64(0),
@@ -1549,8 +1704,8 @@ dev:
 							Startup:   false,
 						},
 						Lifecycle: &Lifecycle{
-							PostStart: false,
-							PostStop:  false,
+							PostStart: true,
+							PostStop:  true,
 						},
 						SecurityContext: &SecurityContext{
 							RunAsUser:    pointer.Int64(0),
@@ -1638,8 +1793,8 @@ dev:
 							Startup:   false,
 						},
 						Lifecycle: &Lifecycle{
-							PostStart: false,
-							PostStop:  false,
+							PostStart: true,
+							PostStop:  true,
 						},
 						SecurityContext: &SecurityContext{
 							RunAsUser:    pointer.Int64(0),
@@ -1704,8 +1859,8 @@ dev:
 							Startup:   false,
 						},
 						Lifecycle: &Lifecycle{
-							PostStart: false,
-							PostStop:  false,
+							PostStart: true,
+							PostStop:  true,
 						},
 						SecurityContext: &SecurityContext{
 							RunAsUser:    pointer.Int64(0),
@@ -1916,7 +2071,7 @@ reverse:
 				Push:      &BuildInfo{},
 				Secrets:   []Secret{},
 				Probes:    &Probes{},
-				Lifecycle: &Lifecycle{},
+				Lifecycle: &Lifecycle{PostStart: true, PostStop: true},
 				Sync: Sync{
 					Folders: []SyncFolder{},
 				},
@@ -1965,7 +2120,7 @@ forward:
 				Push:      &BuildInfo{},
 				Secrets:   []Secret{},
 				Probes:    &Probes{},
-				Lifecycle: &Lifecycle{},
+				Lifecycle: &Lifecycle{PostStart: true, PostStop: true},
 				Sync: Sync{
 					Compression:    true,
 					RescanInterval: 300,
@@ -2029,7 +2184,7 @@ forward:
 				Push:      &BuildInfo{},
 				Secrets:   []Secret{},
 				Probes:    &Probes{},
-				Lifecycle: &Lifecycle{},
+				Lifecycle: &Lifecycle{PostStart: true, PostStop: true},
 				Sync: Sync{
 					Compression:    true,
 					RescanInterval: 300,
@@ -2089,7 +2244,7 @@ forward:
 				Push:      &BuildInfo{},
 				Secrets:   []Secret{},
 				Probes:    &Probes{},
-				Lifecycle: &Lifecycle{},
+				Lifecycle: &Lifecycle{PostStart: true, PostStop: true},
 				Sync: Sync{
 					Compression:    true,
 					RescanInterval: 300,
@@ -2422,6 +2577,23 @@ func TestDeployInfoUnmarshalling(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "commands with env files",
+			deployInfoManifest: []byte(`commands:
+- okteto stack deploy
+envFiles:
+- .env
+- .env.local`),
+			expected: &DeployInfo{
+				Commands: []DeployCommand{
+					{
+						Name:    "okteto stack deploy",
+						Command: "okteto stack deploy",
+					},
+				},
+				EnvFiles: EnvFiles{".env", ".env.local"},
+			},
+		},
 		{
 			name: "compose with endpoints",
 			deployInfoManifest: []byte(`compose:
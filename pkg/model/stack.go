@@ -14,6 +14,7 @@
 package model
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"net/url"
@@ -90,6 +91,7 @@ type Service struct {
 	BackOffLimit    int32                 `yaml:"max_attempts,omitempty"`
 	Healtcheck      *HealthCheck          `yaml:"healthcheck,omitempty"`
 	User            *StackSecurityContext `yaml:"user,omitempty"`
+	Profiles        []string              `yaml:"profiles,omitempty"`
 
 	// Fields only for okteto stacks
 	Public    bool            `yaml:"public,omitempty"`
@@ -128,6 +130,8 @@ type HealthCheck struct {
 	Disable     bool            `yaml:"disable,omitempty"`
 	Liveness    bool            `yaml:"x-okteto-liveness,omitempty"`
 	Readiness   bool            `default:"true" yaml:"x-okteto-readiness,omitempty"`
+
+	StartInterval *WarningType `yaml:"start_interval,omitempty"`
 }
 
 type HTTPHealtcheck struct {
@@ -613,6 +617,45 @@ func IsAlreadyAddedExpose(p Port, ports []Port) bool {
 	return false
 }
 
+// GetServicesEnabledByProfiles returns the services enabled for the active profiles.
+// As in docker compose, services without profiles are always enabled and the profile "*" enables all the services
+func (s *Stack) GetServicesEnabledByProfiles(profiles []string) []string {
+	active := map[string]bool{}
+	for _, p := range profiles {
+		active[p] = true
+	}
+	result := []string{}
+	for name, svc := range s.Services {
+		if len(svc.Profiles) == 0 || active["*"] {
+			result = append(result, name)
+			continue
+		}
+		for _, p := range svc.Profiles {
+			if active[p] {
+				result = append(result, name)
+				break
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// ValidateNotSupportedFields returns an error if the compose file has fields ignored by okteto
+func (s *Stack) ValidateNotSupportedFields() error {
+	fields := GroupWarningsBySvc(s.Warnings.NotSupportedFields)
+	if len(fields) == 0 {
+		return nil
+	}
+	sort.Strings(fields)
+	if len(fields) == 1 {
+		return fmt.Errorf("Invalid compose manifest: Field '%s' is not supported.\n    More information is available here: https://okteto.com/docs/reference/compose/", fields[0])
+	}
+	return fmt.Errorf(`Invalid compose manifest: The following fields are not supported.
+    - %s
+    More information is available here: https://okteto.com/docs/reference/compose/`, strings.Join(fields, "\n    - "))
+}
+
 func GroupWarningsBySvc(fields []string) []string {
 	notSupportedMap := make(map[string][]string)
 	result := make([]string, 0)
@@ -673,6 +716,7 @@ func (stack *Stack) Merge(otherStack *Stack) *Stack {
 		stack.Volumes = otherStack.Volumes
 	}
 	stack.Paths = append(stack.Paths, otherStack.Paths...)
+	stack.Warnings.NotSupportedFields = mergeAndSortUnique(stack.Warnings.NotSupportedFields, otherStack.Warnings.NotSupportedFields)
 	stack = stack.mergeServices(otherStack)
 	return stack
 }
@@ -728,6 +772,9 @@ func (stack *Stack) mergeServices(otherStack *Stack) *Stack {
 		if len(svc.DependsOn) > 0 {
 			resultSvc.DependsOn = svc.DependsOn
 		}
+		if len(svc.Profiles) > 0 {
+			resultSvc.Profiles = svc.Profiles
+		}
 		if len(svc.Environment) > 0 {
 			resultSvc.Environment = svc.Environment
 		}
@@ -872,7 +919,8 @@ func loadEnvFiles(svc *Service, svcName string) error {
 	for i := len(svc.EnvFiles) - 1; i >= 0; i-- {
 		envFilepath := svc.EnvFiles[i]
 		if err := setEnvironmentFromFile(svc, envFilepath); err != nil {
-			if filepath.Base(envFilepath) == ".env" {
+			var uErr oktetoErrors.UserError
+			if filepath.Base(envFilepath) == ".env" && !errors.As(err, &uErr) {
 				oktetoLog.Warning("Skipping '.env' file from %s service", svcName)
 				continue
 			}
@@ -893,17 +941,12 @@ func setEnvironmentFromFile(svc *Service, filename string) error {
 		return err
 	}
 
-	f, err := os.Open(filename)
+	content, err := readEnvFile(filename)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		if err := f.Close(); err != nil {
-			oktetoLog.Debugf("Error closing file %s: %s", filename, err)
-		}
-	}()
 
-	envMap, err := godotenv.ParseWithLookup(f, os.LookupEnv)
+	envMap, err := godotenv.ParseWithLookup(bytes.NewReader(content), os.LookupEnv)
 	if err != nil {
 		return fmt.Errorf("error parsing env_file %s: %s", filename, err.Error())
 	}
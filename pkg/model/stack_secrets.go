@@ -0,0 +1,95 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/compose-spec/godotenv"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	sopsBinary = "sops"
+
+	// sopsMetadataPrefix is the prefix of the keys added by sops to the dotenv files it encrypts
+	sopsMetadataPrefix = "sops_"
+)
+
+// readEnvFile returns the content of an env file. Env files encrypted with sops are decrypted client-side,
+// so encrypted secrets can be committed alongside the compose file
+func readEnvFile(filename string) ([]byte, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	if !isSopsEncrypted(content) {
+		return content, nil
+	}
+	return decryptSopsEnvFile(filename)
+}
+
+// isSopsEncrypted returns true if the dotenv content has the metadata added by sops
+func isSopsEncrypted(content []byte) bool {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, sopsMetadataPrefix+"version=") || strings.HasPrefix(line, sopsMetadataPrefix+"mac=") {
+			return true
+		}
+	}
+	return false
+}
+
+// decryptSopsEnvFile decrypts a dotenv file with sops. The keys are resolved by sops, e.g. SOPS_AGE_KEY_FILE for age keys
+func decryptSopsEnvFile(filename string) ([]byte, error) {
+	if _, err := exec.LookPath(sopsBinary); err != nil {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("env_file '%s' is encrypted with sops", filename),
+			Hint: "Install sops following https://github.com/getsops/sops#download to decrypt it",
+		}
+	}
+
+	oktetoLog.Infof("decrypting env_file '%s' with sops", filename)
+	cmd := exec.Command(sopsBinary, "--decrypt", "--input-type", "dotenv", "--output-type", "dotenv", filename)
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("error decrypting env_file '%s': %w: %s", filename, err, strings.TrimSpace(stderr.String())),
+			Hint: "Make sure the decryption key is available, e.g. set 'SOPS_AGE_KEY_FILE' to the path of your age key",
+		}
+	}
+	maskDecryptedValues(out)
+	return out, nil
+}
+
+// maskDecryptedValues masks the values of a decrypted dotenv file from the output and the logs
+func maskDecryptedValues(content []byte) {
+	values, err := godotenv.UnmarshalBytes(content)
+	if err != nil {
+		oktetoLog.Infof("error parsing decrypted env_file: %s", err)
+		return
+	}
+	for _, v := range values {
+		oktetoLog.AddMaskedSecret(v)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const encryptedEnvFile = `DB_PASSWORD=ENC[AES256_GCM,data:mKZs,iv:aa,tag:bb,type:str]
+sops_age__list_0__map_recipient=age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p
+sops_lastmodified=2023-06-01T10:00:00Z
+sops_mac=ENC[AES256_GCM,data:cc,iv:dd,tag:ee,type:str]
+sops_version=3.7.3
+`
+
+func Test_isSopsEncrypted(t *testing.T) {
+	assert.True(t, isSopsEncrypted([]byte(encryptedEnvFile)))
+	assert.False(t, isSopsEncrypted([]byte("DB_PASSWORD=secret\nSOPS_URL=value\n")))
+}
+
+func Test_setEnvironmentFromEncryptedFile(t *testing.T) {
+	dir := t.TempDir()
+	envFile := filepath.Join(dir, "secrets.env")
+	require.NoError(t, os.WriteFile(envFile, []byte(encryptedEnvFile), 0600))
+
+	t.Run("sops not installed", func(t *testing.T) {
+		t.Setenv("PATH", t.TempDir())
+		svc := &Service{}
+		err := setEnvironmentFromFile(svc, envFile)
+		var uErr oktetoErrors.UserError
+		require.ErrorAs(t, err, &uErr)
+		assert.Contains(t, uErr.Hint, "Install sops")
+	})
+
+	t.Run("decrypted with sops", func(t *testing.T) {
+		bin := t.TempDir()
+		script := "#!/bin/sh\necho DB_PASSWORD=secret\n"
+		require.NoError(t, os.WriteFile(filepath.Join(bin, sopsBinary), []byte(script), 0700))
+		t.Setenv("PATH", bin)
+
+		svc := &Service{}
+		require.NoError(t, setEnvironmentFromFile(svc, envFile))
+		assert.Equal(t, Environment{{Name: "DB_PASSWORD", Value: "secret"}}, svc.Environment)
+
+		t.Cleanup(func() {
+			oktetoLog.Init(logrus.WarnLevel)
+		})
+		out := &bytes.Buffer{}
+		oktetoLog.SetOutputFormat(oktetoLog.PlainFormat)
+		oktetoLog.SetOutput(out)
+		oktetoLog.Information("connecting with secret")
+		assert.NotContains(t, out.String(), "secret")
+	})
+
+	t.Run("decryption error is not skipped for .env", func(t *testing.T) {
+		bin := t.TempDir()
+		script := "#!/bin/sh\necho 'no key found' >&2\nexit 128\n"
+		require.NoError(t, os.WriteFile(filepath.Join(bin, sopsBinary), []byte(script), 0700))
+		t.Setenv("PATH", bin)
+
+		dotEnv := filepath.Join(dir, ".env")
+		require.NoError(t, os.WriteFile(dotEnv, []byte(encryptedEnvFile), 0600))
+		svc := &Service{EnvFiles: EnvFiles{dotEnv}}
+		err := loadEnvFiles(svc, "api")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no key found")
+	})
+}
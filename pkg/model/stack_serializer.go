@@ -55,6 +55,7 @@ type StackRaw struct {
 
 	Configs *WarningType `yaml:"configs,omitempty"`
 	Secrets *WarningType `yaml:"secrets,omitempty"`
+	Include *WarningType `yaml:"include,omitempty"`
 
 	Warnings StackWarnings
 }
@@ -98,6 +99,7 @@ type ServiceRaw struct {
 	Replicas  *int32          `yaml:"replicas"`
 	Resources *StackResources `yaml:"resources,omitempty"`
 
+	Attach            *WarningType `yaml:"attach,omitempty"`
 	BlkioConfig       *WarningType `yaml:"blkio_config,omitempty"`
 	CpuPercent        *WarningType `yaml:"cpu_percent,omitempty"`
 	CpuShares         *WarningType `yaml:"cpu_shares,omitempty"`
@@ -106,10 +108,12 @@ type ServiceRaw struct {
 	CpuRtRuntime      *WarningType `yaml:"cpu_rt_runtime,omitempty"`
 	CpuRtPeriod       *WarningType `yaml:"cpu_rt_period,omitempty"`
 	Cpuset            *WarningType `yaml:"cpuset,omitempty"`
+	Cgroup            *WarningType `yaml:"cgroup,omitempty"`
 	CgroupParent      *WarningType `yaml:"cgroup_parent,omitempty"`
 	Configs           *WarningType `yaml:"configs,omitempty"`
 	ContainerName     *WarningType `yaml:"container_name,omitempty"`
 	CredentialSpec    *WarningType `yaml:"credential_spec,omitempty"`
+	Develop           *WarningType `yaml:"develop,omitempty"`
 	DeviceCgroupRules *WarningType `yaml:"device_cgroup_rules,omitempty"`
 	Devices           *WarningType `yaml:"devices,omitempty"`
 	Dns               *WarningType `yaml:"dns,omitempty"`
@@ -119,6 +123,7 @@ type ServiceRaw struct {
 	Extends           *WarningType `yaml:"extends,omitempty"`
 	ExternalLinks     *WarningType `yaml:"external_links,omitempty"`
 	ExtraHosts        *WarningType `yaml:"extra_hosts,omitempty"`
+	Gpus              *WarningType `yaml:"gpus,omitempty"`
 	GroupAdd          *WarningType `yaml:"group_add,omitempty"`
 	Hostname          *WarningType `yaml:"hostname,omitempty"`
 	Init              *WarningType `yaml:"init,omitempty"`
@@ -135,9 +140,10 @@ type ServiceRaw struct {
 	OomScoreAdj       *WarningType `yaml:"oom_score_adj,omitempty"`
 	Pid               *WarningType `yaml:"pid,omitempty"`
 	PidLimit          *WarningType `yaml:"pid_limit,omitempty"`
+	PidsLimit         *WarningType `yaml:"pids_limit,omitempty"`
 	Platform          *WarningType `yaml:"platform,omitempty"`
 	Privileged        *WarningType `yaml:"privileged,omitempty"`
-	Profiles          *WarningType `yaml:"profiles,omitempty"`
+	Profiles          []string     `yaml:"profiles,omitempty"`
 	PullPolicy        *WarningType `yaml:"pull_policy,omitempty"`
 	ReadOnly          *WarningType `yaml:"read_only,omitempty"`
 	Runtime           *WarningType `yaml:"runtime,omitempty"`
@@ -146,12 +152,14 @@ type ServiceRaw struct {
 	ShmSize           *WarningType `yaml:"shm_size,omitempty"`
 	StdinOpen         *WarningType `yaml:"stdin_open,omitempty"`
 	StopSignal        *WarningType `yaml:"stop_signal,omitempty"`
+	StorageOpt        *WarningType `yaml:"storage_opt,omitempty"`
 	StorageOpts       *WarningType `yaml:"storage_opts,omitempty"`
 	Sysctls           *WarningType `yaml:"sysctls,omitempty"`
 	Tmpfs             *WarningType `yaml:"tmpfs,omitempty"`
 	Tty               *WarningType `yaml:"tty,omitempty"`
 	Ulimits           *WarningType `yaml:"ulimits,omitempty"`
 	UsernsMode        *WarningType `yaml:"userns_mode,omitempty"`
+	Uts               *WarningType `yaml:"uts,omitempty"`
 	VolumesFrom       *WarningType `yaml:"volumes_from,omitempty"`
 
 	// Extensions
@@ -217,6 +225,9 @@ type DeployComposeResources struct {
 	Memory  Quantity     `json:"memory,omitempty" yaml:"memory,omitempty"`
 	Devices *WarningType `json:"devices,omitempty" yaml:"devices,omitempty"`
 
+	Pids             *WarningType `json:"pids,omitempty" yaml:"pids,omitempty"`
+	GenericResources *WarningType `json:"generic_resources,omitempty" yaml:"generic_resources,omitempty"`
+
 	// Extensions
 	Extensions map[string]interface{} `yaml:",inline" json:"-"`
 }
@@ -396,6 +407,7 @@ func (serviceRaw *ServiceRaw) ToService(svcName string, stack *Stack) (*Service,
 
 	svc.Image = serviceRaw.Image
 	svc.Build = serviceRaw.Build.toBuildInfo()
+	svc.Profiles = serviceRaw.Profiles
 
 	svc.CapAdd = serviceRaw.CapAdd
 	if len(serviceRaw.CapAddSneakCase) > 0 {
@@ -527,6 +539,8 @@ type healthCheckunmarshaller struct {
 	Disable     bool            `yaml:"disable,omitempty"`
 	Liveness    bool            `yaml:"x-okteto-liveness,omitempty"`
 	Readiness   *bool           `yaml:"x-okteto-readiness,omitempty"`
+
+	StartInterval *WarningType `yaml:"start_interval,omitempty"`
 }
 
 // UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
@@ -558,6 +572,8 @@ func (hc *HealthCheck) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		Disable:     rawHealthcheck.Disable,
 		Liveness:    rawHealthcheck.Liveness,
 		Readiness:   readiness,
+
+		StartInterval: rawHealthcheck.StartInterval,
 	}
 	return nil
 }
@@ -1337,6 +1353,9 @@ func getTopLevelNotSupportedFields(s *StackRaw) []string {
 	if s.Secrets != nil {
 		notSupported = append(notSupported, "secrets")
 	}
+	if s.Include != nil {
+		notSupported = append(notSupported, "include")
+	}
 	return notSupported
 }
 
@@ -1346,6 +1365,13 @@ func getServiceNotSupportedFields(svcName string, svcInfo *ServiceRaw) []string
 		notSupported = append(notSupported, getDeployNotSupportedFields(svcName, svcInfo.Deploy)...)
 	}
 
+	if svcInfo.Healthcheck != nil && svcInfo.Healthcheck.StartInterval != nil {
+		notSupported = append(notSupported, fmt.Sprintf("services[%s].healthcheck.start_interval", svcName))
+	}
+
+	if svcInfo.Attach != nil {
+		notSupported = append(notSupported, fmt.Sprintf("services[%s].attach", svcName))
+	}
 	if svcInfo.BlkioConfig != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].blkio_config", svcName))
 	}
@@ -1370,6 +1396,9 @@ func getServiceNotSupportedFields(svcName string, svcInfo *ServiceRaw) []string
 	if svcInfo.Cpuset != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].cpuset", svcName))
 	}
+	if svcInfo.Cgroup != nil {
+		notSupported = append(notSupported, fmt.Sprintf("services[%s].cgroup", svcName))
+	}
 	if svcInfo.CgroupParent != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].cgroup_parent", svcName))
 	}
@@ -1379,6 +1408,9 @@ func getServiceNotSupportedFields(svcName string, svcInfo *ServiceRaw) []string
 	if svcInfo.CredentialSpec != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].credential_spec", svcName))
 	}
+	if svcInfo.Develop != nil {
+		notSupported = append(notSupported, fmt.Sprintf("services[%s].develop", svcName))
+	}
 	if svcInfo.DeviceCgroupRules != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].device_cgroup_rules", svcName))
 	}
@@ -1406,6 +1438,9 @@ func getServiceNotSupportedFields(svcName string, svcInfo *ServiceRaw) []string
 	if svcInfo.ExtraHosts != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].extra_hosts", svcName))
 	}
+	if svcInfo.Gpus != nil {
+		notSupported = append(notSupported, fmt.Sprintf("services[%s].gpus", svcName))
+	}
 	if svcInfo.GroupAdd != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].group_add", svcName))
 	}
@@ -1454,15 +1489,15 @@ func getServiceNotSupportedFields(svcName string, svcInfo *ServiceRaw) []string
 	if svcInfo.PidLimit != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].pid_limit", svcName))
 	}
+	if svcInfo.PidsLimit != nil {
+		notSupported = append(notSupported, fmt.Sprintf("services[%s].pids_limit", svcName))
+	}
 	if svcInfo.Platform != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].platform", svcName))
 	}
 	if svcInfo.Privileged != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].privileged", svcName))
 	}
-	if svcInfo.Profiles != nil {
-		notSupported = append(notSupported, fmt.Sprintf("services[%s].profiles", svcName))
-	}
 	if svcInfo.PullPolicy != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].pull_policy", svcName))
 	}
@@ -1487,6 +1522,9 @@ func getServiceNotSupportedFields(svcName string, svcInfo *ServiceRaw) []string
 	if svcInfo.StopSignal != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].stop_signal", svcName))
 	}
+	if svcInfo.StorageOpt != nil {
+		notSupported = append(notSupported, fmt.Sprintf("services[%s].storage_opt", svcName))
+	}
 	if svcInfo.StorageOpts != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].storage_opts", svcName))
 	}
@@ -1505,6 +1543,9 @@ func getServiceNotSupportedFields(svcName string, svcInfo *ServiceRaw) []string
 	if svcInfo.UsernsMode != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].userns_mode", svcName))
 	}
+	if svcInfo.Uts != nil {
+		notSupported = append(notSupported, fmt.Sprintf("services[%s].uts", svcName))
+	}
 	if svcInfo.VolumesFrom != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].volumes_from", svcName))
 	}
@@ -1520,6 +1561,12 @@ func getDeployNotSupportedFields(svcName string, deploy *DeployInfoRaw) []string
 	if deploy.Resources.Reservations.Devices != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].deploy.resources.reservations.devices", svcName))
 	}
+	if deploy.Resources.Limits.Pids != nil {
+		notSupported = append(notSupported, fmt.Sprintf("services[%s].deploy.resources.limits.pids", svcName))
+	}
+	if deploy.Resources.Reservations.GenericResources != nil {
+		notSupported = append(notSupported, fmt.Sprintf("services[%s].deploy.resources.reservations.generic_resources", svcName))
+	}
 
 	if deploy.RestartPolicy != nil {
 		if deploy.RestartPolicy.Delay != nil {
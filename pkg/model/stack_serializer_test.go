@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	yaml "gopkg.in/yaml.v2"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -2063,3 +2064,69 @@ func Test_TranslateOktetoStackPortsToComposePorts(t *testing.T) {
 		})
 	}
 }
+
+func Test_ComposeSpecNotSupportedFields(t *testing.T) {
+	manifest := []byte(`include:
+  - other.yml
+services:
+  app:
+    image: okteto/vote:1
+    attach: false
+    cgroup: host
+    develop:
+      watch:
+        - action: sync
+          path: ./src
+          target: /src
+    gpus: all
+    pids_limit: 10
+    storage_opt:
+      size: 1G
+    uts: host
+    healthcheck:
+      test: ["CMD", "true"]
+      start_period: 30s
+      start_interval: 5s
+    deploy:
+      update_config:
+        parallelism: 2
+      resources:
+        limits:
+          pids: 10
+        reservations:
+          generic_resources:
+            - discrete_resource_spec:
+                kind: gpu
+                value: 2`)
+	s, err := ReadStack(manifest, true)
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, s.Services["app"].Healtcheck.StartPeriod)
+	assert.ElementsMatch(t, []string{
+		"include",
+		"services[app].attach",
+		"services[app].cgroup",
+		"services[app].develop",
+		"services[app].gpus",
+		"services[app].pids_limit",
+		"services[app].storage_opt",
+		"services[app].uts",
+		"services[app].healthcheck.start_interval",
+		"services[app].deploy.update_config",
+		"services[app].deploy.resources.limits.pids",
+		"services[app].deploy.resources.reservations.generic_resources",
+	}, s.Warnings.NotSupportedFields)
+}
+
+func Test_ValidateNotSupportedFields(t *testing.T) {
+	s := &Stack{}
+	assert.NoError(t, s.ValidateNotSupportedFields())
+
+	s.Warnings.NotSupportedFields = []string{"services[app].uts"}
+	assert.EqualError(t, s.ValidateNotSupportedFields(), "Invalid compose manifest: Field 'services[app].uts' is not supported.\n    More information is available here: https://okteto.com/docs/reference/compose/")
+
+	s.Warnings.NotSupportedFields = []string{"services[db].uts", "configs", "services[app].uts"}
+	assert.EqualError(t, s.ValidateNotSupportedFields(), `Invalid compose manifest: The following fields are not supported.
+    - configs
+    - services[db, app].uts
+    More information is available here: https://okteto.com/docs/reference/compose/`)
+}
@@ -1421,3 +1421,44 @@ func TestValidateServices(t *testing.T) {
 	}
 
 }
+
+func TestGetServicesEnabledByProfiles(t *testing.T) {
+	s := &Stack{
+		Services: ComposeServices{
+			"api":      &Service{},
+			"db":       &Service{},
+			"debugger": &Service{Profiles: []string{"debug"}},
+			"e2e":      &Service{Profiles: []string{"test", "debug"}},
+		},
+	}
+	var tests = []struct {
+		name     string
+		profiles []string
+		expected []string
+	}{
+		{
+			name:     "no profiles",
+			expected: []string{"api", "db"},
+		},
+		{
+			name:     "debug profile",
+			profiles: []string{"debug"},
+			expected: []string{"api", "db", "debugger", "e2e"},
+		},
+		{
+			name:     "test profile",
+			profiles: []string{"test"},
+			expected: []string{"api", "db", "e2e"},
+		},
+		{
+			name:     "all profiles",
+			profiles: []string{"*"},
+			expected: []string{"api", "db", "debugger", "e2e"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, s.GetServicesEnabledByProfiles(tt.profiles))
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ManifestTests defines the test section of the manifest
+type ManifestTests map[string]*Test
+
+// Test represents a test suite that runs remotely in the namespace
+type Test struct {
+	Image     string          `json:"image,omitempty" yaml:"image,omitempty"`
+	Context   string          `json:"context,omitempty" yaml:"context,omitempty"`
+	Commands  []DeployCommand `json:"commands,omitempty" yaml:"commands,omitempty"`
+	Artifacts []string        `json:"artifacts,omitempty" yaml:"artifacts,omitempty"`
+	DependsOn []string        `json:"depends_on,omitempty" yaml:"depends_on,omitempty"`
+}
+
+func (t ManifestTests) validate() error {
+	for name, test := range t {
+		if test == nil || len(test.Commands) == 0 {
+			return fmt.Errorf("the test '%s' must define at least one command", name)
+		}
+		for _, dependency := range test.DependsOn {
+			if _, ok := t[dependency]; !ok {
+				return fmt.Errorf("the test '%s' depends on '%s', which is not defined in the test section", name, dependency)
+			}
+		}
+		for _, artifact := range test.Artifacts {
+			if filepath.IsAbs(artifact) || strings.HasPrefix(filepath.Clean(artifact), "..") {
+				return fmt.Errorf("the artifact '%s' of the test '%s' must be a path relative to the test context", artifact, name)
+			}
+		}
+	}
+
+	cycle := getDependentCyclic(t.toGraph())
+	if len(cycle) == 1 {
+		return fmt.Errorf("manifest test validation failed: test '%s' is referenced on its dependencies", cycle[0])
+	} else if len(cycle) > 1 {
+		return fmt.Errorf("manifest test validation failed: cyclic dependency found between %s and %s", strings.Join(cycle[:len(cycle)-1], ", "), cycle[len(cycle)-1])
+	}
+	return nil
+}
+
+func (t ManifestTests) toGraph() graph {
+	g := graph{}
+	for k, v := range t {
+		g[k] = v.DependsOn
+	}
+	return g
+}
+
+// GetTestsToRun returns the tests to run and their dependencies, sorted so every test runs after its dependencies.
+// If no test is given, all of them are returned
+func (t ManifestTests) GetTestsToRun(names []string) ([]string, error) {
+	if len(names) == 0 {
+		for name := range t {
+			names = append(names, name)
+		}
+	}
+	for _, name := range names {
+		if _, ok := t[name]; !ok {
+			return nil, fmt.Errorf("test '%s' is not defined in the manifest", name)
+		}
+	}
+
+	toRun := getDependentNodes(t.toGraph(), names)
+	sort.Strings(toRun)
+
+	result := []string{}
+	added := map[string]bool{}
+	for len(result) < len(toRun) {
+		for _, name := range toRun {
+			if added[name] {
+				continue
+			}
+			if !areDependenciesAdded(t[name].DependsOn, added) {
+				continue
+			}
+			result = append(result, name)
+			added[name] = true
+		}
+	}
+	return result, nil
+}
+
+func areDependenciesAdded(dependencies []string, added map[string]bool) bool {
+	for _, dependency := range dependencies {
+		if !added[dependency] {
+			return false
+		}
+	}
+	return true
+}
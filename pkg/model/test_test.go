@@ -0,0 +1,125 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestManifestTestsUnmarshal(t *testing.T) {
+	manifest := []byte(`
+test:
+  unit:
+    image: golang:1.20
+    context: api
+    commands:
+      - go test ./... -coverprofile=coverage.out
+      - name: junit
+        command: go-junit-report > report.xml
+    artifacts:
+      - coverage.out
+      - report.xml
+  e2e:
+    commands:
+      - make e2e
+    depends_on:
+      - unit
+`)
+	m := &Manifest{}
+	require.NoError(t, yaml.UnmarshalStrict(manifest, m))
+	assert.Equal(t, ManifestTests{
+		"unit": {
+			Image:   "golang:1.20",
+			Context: "api",
+			Commands: []DeployCommand{
+				{Name: "go test ./... -coverprofile=coverage.out", Command: "go test ./... -coverprofile=coverage.out"},
+				{Name: "junit", Command: "go-junit-report > report.xml"},
+			},
+			Artifacts: []string{"coverage.out", "report.xml"},
+		},
+		"e2e": {
+			Commands:  []DeployCommand{{Name: "make e2e", Command: "make e2e"}},
+			DependsOn: []string{"unit"},
+		},
+	}, m.Test)
+}
+
+func TestManifestTestsValidate(t *testing.T) {
+	command := []DeployCommand{{Name: "make", Command: "make"}}
+	var tests = []struct {
+		name      string
+		tests     ManifestTests
+		expectErr bool
+	}{
+		{
+			name:  "valid",
+			tests: ManifestTests{"unit": {Commands: command, Artifacts: []string{"reports/junit.xml"}}, "e2e": {Commands: command, DependsOn: []string{"unit"}}},
+		},
+		{
+			name:      "no commands",
+			tests:     ManifestTests{"unit": {}},
+			expectErr: true,
+		},
+		{
+			name:      "unknown dependency",
+			tests:     ManifestTests{"e2e": {Commands: command, DependsOn: []string{"unit"}}},
+			expectErr: true,
+		},
+		{
+			name:      "cyclic dependency",
+			tests:     ManifestTests{"a": {Commands: command, DependsOn: []string{"b"}}, "b": {Commands: command, DependsOn: []string{"a"}}},
+			expectErr: true,
+		},
+		{
+			name:      "artifact outside of the context",
+			tests:     ManifestTests{"unit": {Commands: command, Artifacts: []string{"../coverage.out"}}},
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tests.validate()
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGetTestsToRun(t *testing.T) {
+	command := []DeployCommand{{Name: "make", Command: "make"}}
+	tests := ManifestTests{
+		"unit":        {Commands: command},
+		"integration": {Commands: command, DependsOn: []string{"unit"}},
+		"e2e":         {Commands: command, DependsOn: []string{"integration"}},
+		"lint":        {Commands: command},
+	}
+
+	result, err := tests.GetTestsToRun(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"lint", "unit", "integration", "e2e"}, result)
+
+	result, err = tests.GetTestsToRun([]string{"e2e"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"unit", "integration", "e2e"}, result)
+
+	_, err = tests.GetTestsToRun([]string{"smoke"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultActivationTimeout is the default time to wait for a development container to be ready and synchronized,
+	// including waking up a sleeping development environment
+	DefaultActivationTimeout = 15 * time.Minute
+
+	// DefaultRolloutTimeout is the default time to wait for the pods of the compose services to be running
+	DefaultRolloutTimeout = 10 * time.Minute
+
+	// DefaultPortForwardTimeout is the default time to wait for the port forwards of a development container
+	DefaultPortForwardTimeout = 10 * time.Second
+
+	// DefaultAPIRetries is the default number of times a call to the Kubernetes API is retried while waiting for a resource
+	DefaultAPIRetries = 10
+)
+
+// Timeouts represents how long okteto waits for the Kubernetes operations. It can be set in the manifest and in the okteto context
+type Timeouts struct {
+	Activation  time.Duration `json:"activation,omitempty" yaml:"activation,omitempty"`
+	Rollout     time.Duration `json:"rollout,omitempty" yaml:"rollout,omitempty"`
+	PortForward time.Duration `json:"portForward,omitempty" yaml:"portForward,omitempty"`
+	APIRetries  int           `json:"apiRetries,omitempty" yaml:"apiRetries,omitempty"`
+}
+
+// jsonDuration is a duration serialized to JSON as a string, like '5m0s'
+type jsonDuration time.Duration
+
+type timeoutsJSON struct {
+	Activation  jsonDuration `json:"activation,omitempty"`
+	Rollout     jsonDuration `json:"rollout,omitempty"`
+	PortForward jsonDuration `json:"portForward,omitempty"`
+	APIRetries  int          `json:"apiRetries,omitempty"`
+}
+
+// MarshalJSON serializes the durations of the timeouts as strings
+func (t Timeouts) MarshalJSON() ([]byte, error) {
+	return json.Marshal(timeoutsJSON{
+		Activation:  jsonDuration(t.Activation),
+		Rollout:     jsonDuration(t.Rollout),
+		PortForward: jsonDuration(t.PortForward),
+		APIRetries:  t.APIRetries,
+	})
+}
+
+// UnmarshalJSON reads the durations of the timeouts from strings, or from nanoseconds as they were serialized before
+func (t *Timeouts) UnmarshalJSON(b []byte) error {
+	result := timeoutsJSON{}
+	if err := json.Unmarshal(b, &result); err != nil {
+		return err
+	}
+	t.Activation = time.Duration(result.Activation)
+	t.Rollout = time.Duration(result.Rollout)
+	t.PortForward = time.Duration(result.PortForward)
+	t.APIRetries = result.APIRetries
+	return nil
+}
+
+func (d jsonDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *jsonDuration) UnmarshalJSON(b []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(b, &value); err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case float64:
+		*d = jsonDuration(v)
+	case string:
+		duration, err := time.ParseDuration(v)
+		if err != nil {
+			return err
+		}
+		*d = jsonDuration(duration)
+	default:
+		return fmt.Errorf("invalid duration: %s", string(b))
+	}
+	return nil
+}
+
+// ResolveTimeouts returns the timeouts to use. The first value set in the list wins, in order of precedence, and the defaults are used for the rest
+func ResolveTimeouts(timeouts ...*Timeouts) *Timeouts {
+	result := &Timeouts{}
+	for _, t := range timeouts {
+		if t == nil {
+			continue
+		}
+		if result.Activation == 0 {
+			result.Activation = t.Activation
+		}
+		if result.Rollout == 0 {
+			result.Rollout = t.Rollout
+		}
+		if result.PortForward == 0 {
+			result.PortForward = t.PortForward
+		}
+		if result.APIRetries == 0 {
+			result.APIRetries = t.APIRetries
+		}
+	}
+
+	if result.Activation <= 0 {
+		result.Activation = DefaultActivationTimeout
+	}
+	if result.Rollout <= 0 {
+		result.Rollout = DefaultRolloutTimeout
+	}
+	if result.PortForward <= 0 {
+		result.PortForward = DefaultPortForwardTimeout
+	}
+	if result.APIRetries <= 0 {
+		result.APIRetries = DefaultAPIRetries
+	}
+	return result
+}
@@ -0,0 +1,134 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveTimeouts(t *testing.T) {
+	var tests = []struct {
+		name     string
+		timeouts []*Timeouts
+		expected *Timeouts
+	}{
+		{
+			name: "defaults",
+			expected: &Timeouts{
+				Activation:  DefaultActivationTimeout,
+				Rollout:     DefaultRolloutTimeout,
+				PortForward: DefaultPortForwardTimeout,
+				APIRetries:  DefaultAPIRetries,
+			},
+		},
+		{
+			name:     "nil timeouts",
+			timeouts: []*Timeouts{nil, nil},
+			expected: &Timeouts{
+				Activation:  DefaultActivationTimeout,
+				Rollout:     DefaultRolloutTimeout,
+				PortForward: DefaultPortForwardTimeout,
+				APIRetries:  DefaultAPIRetries,
+			},
+		},
+		{
+			name: "manifest overrides context",
+			timeouts: []*Timeouts{
+				{Rollout: 20 * time.Minute},
+				{Rollout: 15 * time.Minute, PortForward: 30 * time.Second},
+			},
+			expected: &Timeouts{
+				Activation:  DefaultActivationTimeout,
+				Rollout:     20 * time.Minute,
+				PortForward: 30 * time.Second,
+				APIRetries:  DefaultAPIRetries,
+			},
+		},
+		{
+			name: "context only",
+			timeouts: []*Timeouts{
+				nil,
+				{Activation: 10 * time.Minute, APIRetries: 30},
+			},
+			expected: &Timeouts{
+				Activation:  10 * time.Minute,
+				Rollout:     DefaultRolloutTimeout,
+				PortForward: DefaultPortForwardTimeout,
+				APIRetries:  30,
+			},
+		},
+		{
+			name:     "negative values",
+			timeouts: []*Timeouts{{Activation: -time.Second, APIRetries: -1}},
+			expected: &Timeouts{
+				Activation:  DefaultActivationTimeout,
+				Rollout:     DefaultRolloutTimeout,
+				PortForward: DefaultPortForwardTimeout,
+				APIRetries:  DefaultAPIRetries,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ResolveTimeouts(tt.timeouts...))
+		})
+	}
+}
+
+func TestReadManifestTimeouts(t *testing.T) {
+	manifest := []byte(`
+deploy:
+  - kubectl apply -f k8s.yml
+timeouts:
+  activation: 10m
+  rollout: 15m
+  portForward: 30s
+  apiRetries: 20
+`)
+	m, err := Read(manifest)
+	require.NoError(t, err)
+	assert.Equal(t, &Timeouts{
+		Activation:  10 * time.Minute,
+		Rollout:     15 * time.Minute,
+		PortForward: 30 * time.Second,
+		APIRetries:  20,
+	}, m.Timeouts)
+}
+
+func TestTimeoutsJSON(t *testing.T) {
+	timeouts := &Timeouts{
+		Activation:  10 * time.Minute,
+		PortForward: 30 * time.Second,
+		APIRetries:  20,
+	}
+	b, err := json.Marshal(timeouts)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"activation":"10m0s","portForward":"30s","apiRetries":20}`, string(b))
+
+	result := &Timeouts{}
+	require.NoError(t, json.Unmarshal(b, result))
+	assert.Equal(t, timeouts, result)
+
+	// timeouts serialized as nanoseconds by previous versions
+	result = &Timeouts{}
+	require.NoError(t, json.Unmarshal([]byte(`{"activation":600000000000,"rollout":"15m"}`), result))
+	assert.Equal(t, &Timeouts{Activation: 10 * time.Minute, Rollout: 15 * time.Minute}, result)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"activation":"10 minutes"}`), &Timeouts{}))
+}
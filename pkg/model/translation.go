@@ -28,6 +28,7 @@ type TranslationRule struct {
 	ImagePullPolicy   apiv1.PullPolicy     `json:"imagePullPolicy,omitempty" yaml:"imagePullPolicy,omitempty"`
 	Environment       Environment          `json:"environment,omitempty"`
 	Secrets           []Secret             `json:"secrets,omitempty"`
+	SecretEnvVars     []Secret             `json:"secretEnvVars,omitempty"`
 	Command           []string             `json:"command,omitempty"`
 	Args              []string             `json:"args,omitempty"`
 	WorkDir           string               `json:"workdir"`
@@ -41,6 +42,8 @@ type TranslationRule struct {
 	Probes            *Probes              `json:"probes" yaml:"probes"`
 	Lifecycle         *Lifecycle           `json:"lifecycle" yaml:"lifecycle"`
 	NodeSelector      map[string]string    `json:"nodeSelector" yaml:"nodeSelector"`
+	RuntimeClassName  string               `json:"runtimeClassName,omitempty" yaml:"runtimeClassName,omitempty"`
+	GPU               *GPU                 `json:"gpu,omitempty" yaml:"gpu,omitempty"`
 	Affinity          *apiv1.Affinity      `json:"affinity" yaml:"affinity"`
 }
 
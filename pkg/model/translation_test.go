@@ -47,6 +47,7 @@ resources:
     amd.com/gpu: 1
 nodeSelector:
   disktype: ssd
+runtimeClassName: nvidia
 affinity:
   podAffinity:
     requiredDuringSchedulingIgnoredDuringExecution:
@@ -82,7 +83,7 @@ services:
 		Command:           []string{"/var/okteto/bin/start.sh"},
 		Args:              []string{"-r"},
 		Probes:            &Probes{},
-		Lifecycle:         &Lifecycle{},
+		Lifecycle:         &Lifecycle{PostStart: true, PostStop: true},
 		Environment: Environment{
 			{
 				Name:  "OKTETO_NAMESPACE",
@@ -145,6 +146,7 @@ services:
 		NodeSelector: map[string]string{
 			"disktype": "ssd",
 		},
+		RuntimeClassName: "nvidia",
 		Affinity: &apiv1.Affinity{
 			PodAffinity: &apiv1.PodAffinity{
 				RequiredDuringSchedulingIgnoredDuringExecution: []apiv1.PodAffinityTerm{
@@ -185,7 +187,7 @@ services:
 		Args:            nil,
 		Healthchecks:    false,
 		Probes:          &Probes{},
-		Lifecycle:       &Lifecycle{},
+		Lifecycle:       &Lifecycle{PostStart: true, PostStop: true},
 		SecurityContext: &SecurityContext{
 			RunAsUser:  pointer.Int64Ptr(0),
 			RunAsGroup: pointer.Int64Ptr(0),
@@ -256,7 +258,7 @@ initContainer:
 		Command:           []string{"/var/okteto/bin/start.sh"},
 		Args:              []string{"-r"},
 		Probes:            &Probes{},
-		Lifecycle:         &Lifecycle{},
+		Lifecycle:         &Lifecycle{PostStart: true, PostStop: true},
 		Environment: Environment{
 			{
 				Name:  "OKTETO_NAMESPACE",
@@ -351,7 +353,7 @@ sync:
 		Command:           []string{"/var/okteto/bin/start.sh"},
 		Args:              []string{"-r", "-v"},
 		Probes:            &Probes{},
-		Lifecycle:         &Lifecycle{},
+		Lifecycle:         &Lifecycle{PostStart: true, PostStop: true},
 		Environment: Environment{
 			{
 				Name:  "OKTETO_NAMESPACE",
@@ -21,6 +21,7 @@ import (
 
 	"github.com/go-git/go-git/v5"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/afero"
 )
 
 type graph map[string][]string
@@ -177,6 +178,11 @@ func pathExistsAndDir(path string) bool {
 	return info.IsDir()
 }
 
+func pathExistsAndDirWithFilesystem(path string, fs afero.Fs) bool {
+	isDir, err := afero.IsDir(fs, path)
+	return err == nil && isDir
+}
+
 func getListDiff(l1, l2 []string) []string {
 	var (
 		longerList  []string
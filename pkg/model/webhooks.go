@@ -0,0 +1,114 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/template"
+)
+
+// WebhookType is the kind of endpoint called by a webhook
+type WebhookType string
+
+const (
+	// WebhookTypeHTTP sends the event as a JSON document to any HTTP endpoint
+	WebhookTypeHTTP WebhookType = "http"
+	// WebhookTypeSlack sends the event as a message to a Slack incoming webhook
+	WebhookTypeSlack WebhookType = "slack"
+)
+
+// WebhookEvent is a lifecycle event of a development environment
+type WebhookEvent string
+
+const (
+	// WebhookEventDeployStart is sent when 'okteto deploy' starts running the deploy section
+	WebhookEventDeployStart WebhookEvent = "deploy.start"
+	// WebhookEventDeploySuccess is sent when 'okteto deploy' finishes successfully
+	WebhookEventDeploySuccess WebhookEvent = "deploy.success"
+	// WebhookEventDeployFailure is sent when 'okteto deploy' fails
+	WebhookEventDeployFailure WebhookEvent = "deploy.failure"
+	// WebhookEventUpActivate is sent when 'okteto up' activates a development container
+	WebhookEventUpActivate WebhookEvent = "up.activate"
+)
+
+var webhookEvents = []WebhookEvent{WebhookEventDeployStart, WebhookEventDeploySuccess, WebhookEventDeployFailure, WebhookEventUpActivate}
+
+// Webhook is an endpoint called on the lifecycle events of the development environment.
+// The url and the headers are expanded with the local environment variables when the webhook is called
+type Webhook struct {
+	URL     string            `json:"url" yaml:"url"`
+	Type    WebhookType       `json:"type,omitempty" yaml:"type,omitempty"`
+	Events  []WebhookEvent    `json:"events,omitempty" yaml:"events,omitempty"`
+	Headers map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	// Payload is a go template of the body of the request. The fields of the event are available to the template
+	Payload string `json:"payload,omitempty" yaml:"payload,omitempty"`
+}
+
+// Subscribes returns if the webhook is called on an event. Webhooks without events are called on all of them
+func (w *Webhook) Subscribes(event WebhookEvent) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// ParsePayload returns the template of the payload of the webhook, or nil if it uses the default payload
+func (w *Webhook) ParsePayload() (*template.Template, error) {
+	if w.Payload == "" {
+		return nil, nil
+	}
+	return template.New("payload").Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+	}).Option("missingkey=error").Parse(w.Payload)
+}
+
+func (m *Manifest) validateWebhooks() error {
+	for i, w := range m.Webhooks {
+		if w.URL == "" {
+			return fmt.Errorf("the field 'webhooks[%d].url' is mandatory", i)
+		}
+		switch w.Type {
+		case "", WebhookTypeHTTP, WebhookTypeSlack:
+		default:
+			return fmt.Errorf("the field 'webhooks[%d].type' is not valid: supported values are '%s' and '%s'", i, WebhookTypeHTTP, WebhookTypeSlack)
+		}
+		for _, e := range w.Events {
+			if !isWebhookEvent(e) {
+				return fmt.Errorf("the field 'webhooks[%d].events' is not valid: '%s' is not a supported event", i, e)
+			}
+		}
+		if _, err := w.ParsePayload(); err != nil {
+			return fmt.Errorf("the field 'webhooks[%d].payload' is not a valid template: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func isWebhookEvent(event WebhookEvent) bool {
+	for _, e := range webhookEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhooksUnmarshal(t *testing.T) {
+	manifest, err := Read([]byte(`deploy:
+  - echo
+webhooks:
+  - url: ${SLACK_WEBHOOK}
+    type: slack
+    events: [deploy.success, deploy.failure]
+  - url: https://hooks.example.com
+    headers:
+      Authorization: Bearer ${TOKEN}
+    payload: '{"text": {{ json .Message }}}'
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []Webhook{
+		{URL: "${SLACK_WEBHOOK}", Type: WebhookTypeSlack, Events: []WebhookEvent{WebhookEventDeploySuccess, WebhookEventDeployFailure}},
+		{URL: "https://hooks.example.com", Headers: map[string]string{"Authorization": "Bearer ${TOKEN}"}, Payload: `{"text": {{ json .Message }}}`},
+	}, manifest.Webhooks)
+	assert.NoError(t, manifest.validateWebhooks())
+}
+
+func TestValidateWebhooks(t *testing.T) {
+	tests := []struct {
+		name     string
+		webhooks []Webhook
+		expected string
+	}{
+		{
+			name:     "missing url",
+			webhooks: []Webhook{{Type: WebhookTypeSlack}},
+			expected: "the field 'webhooks[0].url' is mandatory",
+		},
+		{
+			name:     "invalid type",
+			webhooks: []Webhook{{URL: "https://hooks.example.com", Type: "teams"}},
+			expected: "the field 'webhooks[0].type' is not valid",
+		},
+		{
+			name:     "invalid event",
+			webhooks: []Webhook{{URL: "https://hooks.example.com", Events: []WebhookEvent{"destroy.start"}}},
+			expected: "'destroy.start' is not a supported event",
+		},
+		{
+			name:     "invalid payload",
+			webhooks: []Webhook{{URL: "https://hooks.example.com", Payload: "{{ .Message "}},
+			expected: "the field 'webhooks[0].payload' is not a valid template",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Manifest{Webhooks: tt.webhooks}
+			assert.ErrorContains(t, m.validateWebhooks(), tt.expected)
+		})
+	}
+}
+
+func TestWebhookSubscribes(t *testing.T) {
+	all := &Webhook{URL: "https://hooks.example.com"}
+	assert.True(t, all.Subscribes(WebhookEventUpActivate))
+
+	failures := &Webhook{URL: "https://hooks.example.com", Events: []WebhookEvent{WebhookEventDeployFailure}}
+	assert.True(t, failures.Subscribes(WebhookEventDeployFailure))
+	assert.False(t, failures.Subscribes(WebhookEventDeploySuccess))
+}
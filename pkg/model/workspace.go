@@ -0,0 +1,169 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// WorkspaceFile is the name of the file that defines the okteto manifests of a monorepo
+const WorkspaceFile = "okteto-workspace.yml"
+
+// Workspace groups the okteto manifests of the subfolders of a monorepo, so they are deployed, developed and destroyed together in the same namespace
+type Workspace struct {
+	Manifests map[string]*WorkspaceManifest `json:"manifests,omitempty" yaml:"manifests,omitempty"`
+
+	// dir is the folder of the workspace file, the paths of the manifests are relative to it
+	dir string
+}
+
+// WorkspaceManifest is an okteto manifest of a workspace
+type WorkspaceManifest struct {
+	// Path is the folder of the manifest, relative to the workspace file
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	// File is the path of the okteto manifest inside Path. The manifest is discovered if it's empty
+	File      string   `json:"file,omitempty" yaml:"file,omitempty"`
+	DependsOn []string `json:"depends_on,omitempty" yaml:"depends_on,omitempty"`
+	// Variables are passed to the manifest with '--var'. They are expanded right before the manifest is deployed,
+	// so they can reference the variables of its dependencies
+	Variables map[string]string `json:"variables,omitempty" yaml:"variables,omitempty"`
+}
+
+// GetWorkspaceFromFile reads and validates a workspace file
+func GetWorkspaceFromFile(fs afero.Fs, path string) (*Workspace, error) {
+	b, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, err
+	}
+	w := &Workspace{}
+	if err := yaml.UnmarshalStrict(b, w); err != nil {
+		return nil, fmt.Errorf("invalid workspace file '%s': %w", path, err)
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	w.dir = filepath.Dir(absPath)
+	if err := w.validate(fs); err != nil {
+		return nil, fmt.Errorf("invalid workspace file '%s': %w", path, err)
+	}
+	return w, nil
+}
+
+func (w *Workspace) validate(fs afero.Fs) error {
+	if len(w.Manifests) == 0 {
+		return fmt.Errorf("the workspace must define at least one manifest")
+	}
+	for name, m := range w.Manifests {
+		if m == nil || m.Path == "" {
+			return fmt.Errorf("the field 'manifests.%s.path' is mandatory", name)
+		}
+		if filepath.IsAbs(m.Path) || strings.HasPrefix(filepath.Clean(m.Path), "..") {
+			return fmt.Errorf("the path '%s' of the manifest '%s' must be a subfolder of the workspace", m.Path, name)
+		}
+		if info, err := fs.Stat(w.GetDir(name)); err != nil || !info.IsDir() {
+			return fmt.Errorf("the path '%s' of the manifest '%s' is not a folder", m.Path, name)
+		}
+		for _, dependency := range m.DependsOn {
+			if _, ok := w.Manifests[dependency]; !ok {
+				return fmt.Errorf("the manifest '%s' depends on '%s', which is not defined in the workspace", name, dependency)
+			}
+		}
+	}
+
+	cycle := getDependentCyclic(w.toGraph())
+	if len(cycle) == 1 {
+		return fmt.Errorf("the manifest '%s' is referenced on its dependencies", cycle[0])
+	} else if len(cycle) > 1 {
+		return fmt.Errorf("cyclic dependency found between %s and %s", strings.Join(cycle[:len(cycle)-1], ", "), cycle[len(cycle)-1])
+	}
+	return nil
+}
+
+func (w *Workspace) toGraph() graph {
+	g := graph{}
+	for k, v := range w.Manifests {
+		g[k] = v.DependsOn
+	}
+	return g
+}
+
+// GetDir returns the absolute path of the folder of a manifest of the workspace
+func (w *Workspace) GetDir(name string) string {
+	return filepath.Join(w.dir, w.Manifests[name].Path)
+}
+
+// GetDeployOrder returns the manifests to deploy and their dependencies, sorted so every manifest goes after its dependencies.
+// If no manifest is given, all of them are returned
+func (w *Workspace) GetDeployOrder(names []string) ([]string, error) {
+	if len(names) == 0 {
+		for name := range w.Manifests {
+			names = append(names, name)
+		}
+	}
+	for _, name := range names {
+		if _, ok := w.Manifests[name]; !ok {
+			return nil, fmt.Errorf("manifest '%s' is not defined in the workspace", name)
+		}
+	}
+
+	toDeploy := getDependentNodes(w.toGraph(), names)
+	sort.Strings(toDeploy)
+
+	result := []string{}
+	added := map[string]bool{}
+	for len(result) < len(toDeploy) {
+		for _, name := range toDeploy {
+			if added[name] {
+				continue
+			}
+			if !areDependenciesAdded(w.Manifests[name].DependsOn, added) {
+				continue
+			}
+			result = append(result, name)
+			added[name] = true
+		}
+	}
+	return result, nil
+}
+
+// GetDestroyOrder returns the manifests to destroy, sorted so every manifest goes before its dependencies.
+// Dependencies of the given manifests are not included because other manifests might depend on them. If no manifest is given, all of them are returned
+func (w *Workspace) GetDestroyOrder(names []string) ([]string, error) {
+	all, err := w.GetDeployOrder(nil)
+	if err != nil {
+		return nil, err
+	}
+	selected := map[string]bool{}
+	for _, name := range names {
+		if _, ok := w.Manifests[name]; !ok {
+			return nil, fmt.Errorf("manifest '%s' is not defined in the workspace", name)
+		}
+		selected[name] = true
+	}
+
+	result := []string{}
+	for i := len(all) - 1; i >= 0; i-- {
+		if len(selected) == 0 || selected[all[i]] {
+			result = append(result, all[i])
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newWorkspaceFs(t *testing.T, content string, dirs ...string) (afero.Fs, string) {
+	t.Helper()
+	fs := afero.NewMemMapFs()
+	root, err := filepath.Abs("/monorepo")
+	require.NoError(t, err)
+	for _, dir := range dirs {
+		require.NoError(t, fs.MkdirAll(filepath.Join(root, dir), 0700))
+	}
+	path := filepath.Join(root, WorkspaceFile)
+	require.NoError(t, afero.WriteFile(fs, path, []byte(content), 0600))
+	return fs, path
+}
+
+func TestGetWorkspaceFromFile(t *testing.T) {
+	var tests = []struct {
+		name    string
+		content string
+		dirs    []string
+		err     string
+	}{
+		{
+			name: "valid workspace",
+			content: `manifests:
+  api:
+    path: services/api
+    depends_on: [db]
+    variables:
+      DB_HOST: ${OKTETO_DEPENDENCY_DB_VARIABLE_HOST}
+  db:
+    path: services/db
+    file: okteto.db.yml`,
+			dirs: []string{"services/api", "services/db"},
+		},
+		{
+			name:    "no manifests",
+			content: `manifests: {}`,
+			err:     "the workspace must define at least one manifest",
+		},
+		{
+			name: "missing path",
+			content: `manifests:
+  api: {}`,
+			err: "the field 'manifests.api.path' is mandatory",
+		},
+		{
+			name: "path outside the workspace",
+			content: `manifests:
+  api:
+    path: ../api`,
+			err: "must be a subfolder of the workspace",
+		},
+		{
+			name: "path is not a folder",
+			content: `manifests:
+  api:
+    path: api`,
+			err: "is not a folder",
+		},
+		{
+			name: "unknown dependency",
+			content: `manifests:
+  api:
+    path: api
+    depends_on: [db]`,
+			dirs: []string{"api"},
+			err:  "the manifest 'api' depends on 'db', which is not defined in the workspace",
+		},
+		{
+			name: "cyclic dependency",
+			content: `manifests:
+  api:
+    path: api
+    depends_on: [api]`,
+			dirs: []string{"api"},
+			err:  "the manifest 'api' is referenced on its dependencies",
+		},
+		{
+			name: "unknown field",
+			content: `manifests:
+  api:
+    path: api
+    unknown: true`,
+			dirs: []string{"api"},
+			err:  "field unknown not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs, path := newWorkspaceFs(t, tt.content, tt.dirs...)
+			w, err := GetWorkspaceFromFile(fs, path)
+			if tt.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Join(filepath.Dir(path), "services", "db"), w.GetDir("db"))
+			assert.Equal(t, map[string]string{"DB_HOST": "${OKTETO_DEPENDENCY_DB_VARIABLE_HOST}"}, w.Manifests["api"].Variables)
+		})
+	}
+}
+
+func TestWorkspaceOrder(t *testing.T) {
+	w := &Workspace{
+		Manifests: map[string]*WorkspaceManifest{
+			"frontend": {DependsOn: []string{"api"}},
+			"api":      {DependsOn: []string{"db"}},
+			"db":       {},
+			"docs":     {},
+		},
+	}
+
+	order, err := w.GetDeployOrder(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"db", "docs", "api", "frontend"}, order)
+
+	order, err = w.GetDeployOrder([]string{"api"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"db", "api"}, order)
+
+	_, err = w.GetDeployOrder([]string{"unknown"})
+	assert.Error(t, err)
+
+	order, err = w.GetDestroyOrder(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"frontend", "api", "docs", "db"}, order)
+
+	order, err = w.GetDestroyOrder([]string{"db", "frontend"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"frontend", "db"}, order)
+
+	_, err = w.GetDestroyOrder([]string{"unknown"})
+	assert.Error(t, err)
+}
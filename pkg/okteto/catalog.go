@@ -0,0 +1,90 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/shurcooL/graphql"
+)
+
+// ErrCatalogNotSupported is raised when the okteto instance doesn't expose the catalog
+var ErrCatalogNotSupported = oktetoErrors.UserError{
+	E:    fmt.Errorf("the catalog is not available in your okteto instance"),
+	Hint: "Please upgrade to the latest version of Okteto or contact your system administrator",
+}
+
+type catalogClient struct {
+	client graphqlClientInterface
+}
+
+type listCatalogItemsQuery struct {
+	Response []catalogItem `graphql:"catalogItems"`
+}
+
+type catalogItem struct {
+	Id            graphql.String
+	Name          graphql.String
+	RepositoryUrl graphql.String
+	Branch        graphql.String
+	ManifestPath  graphql.String
+	Variables     []catalogVariable
+	ReadOnly      graphql.Boolean
+}
+
+type catalogVariable struct {
+	Name  graphql.String
+	Value graphql.String
+}
+
+func newCatalogClient(client graphqlClientInterface) *catalogClient {
+	return &catalogClient{client: client}
+}
+
+// List lists the development environment templates of the catalog
+func (c *catalogClient) List(ctx context.Context) ([]types.CatalogItem, error) {
+	var queryStruct listCatalogItemsQuery
+	err := query(ctx, &queryStruct, nil, c.client)
+	if err != nil {
+		if strings.Contains(err.Error(), "Cannot query field \"catalogItems\"") {
+			return nil, ErrCatalogNotSupported
+		}
+		return nil, fmt.Errorf("failed to list the catalog: %w", err)
+	}
+
+	result := make([]types.CatalogItem, 0, len(queryStruct.Response))
+	for _, item := range queryStruct.Response {
+		variables := make([]types.Variable, 0, len(item.Variables))
+		for _, v := range item.Variables {
+			variables = append(variables, types.Variable{
+				Name:  string(v.Name),
+				Value: string(v.Value),
+			})
+		}
+		result = append(result, types.CatalogItem{
+			ID:            string(item.Id),
+			Name:          string(item.Name),
+			RepositoryURL: string(item.RepositoryUrl),
+			Branch:        string(item.Branch),
+			ManifestPath:  string(item.ManifestPath),
+			Variables:     variables,
+			ReadOnly:      bool(item.ReadOnly),
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListCatalog(t *testing.T) {
+	testCases := []struct {
+		name     string
+		client   *fakeGraphQLClient
+		expected []types.CatalogItem
+		err      error
+	}{
+		{
+			name:   "error",
+			client: &fakeGraphQLClient{err: assert.AnError},
+			err:    assert.AnError,
+		},
+		{
+			name:   "not supported",
+			client: &fakeGraphQLClient{err: fmt.Errorf("Cannot query field \"catalogItems\" on type \"Query\"")},
+			err:    ErrCatalogNotSupported,
+		},
+		{
+			name: "items",
+			client: &fakeGraphQLClient{
+				queryResult: &listCatalogItemsQuery{
+					Response: []catalogItem{
+						{
+							Id:            "1",
+							Name:          "movies",
+							RepositoryUrl: "https://github.com/okteto/movies",
+							Branch:        "main",
+							ManifestPath:  "okteto.yml",
+							Variables: []catalogVariable{
+								{Name: "DB", Value: "mongo"},
+							},
+							ReadOnly: true,
+						},
+					},
+				},
+			},
+			expected: []types.CatalogItem{
+				{
+					ID:            "1",
+					Name:          "movies",
+					RepositoryURL: "https://github.com/okteto/movies",
+					Branch:        "main",
+					ManifestPath:  "okteto.yml",
+					Variables: []types.Variable{
+						{Name: "DB", Value: "mongo"},
+					},
+					ReadOnly: true,
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cc := catalogClient{
+				client: tc.client,
+			}
+			items, err := cc.List(context.Background())
+			assert.ErrorIs(t, err, tc.err)
+			assert.Equal(t, tc.expected, items)
+		})
+	}
+}
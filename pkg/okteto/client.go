@@ -44,6 +44,7 @@ type OktetoClient struct {
 	pipeline  types.PipelineInterface
 	stream    types.StreamInterface
 	kubetoken types.KubetokenInterface
+	catalog   types.CatalogInterface
 }
 
 type OktetoClientProvider struct{}
@@ -248,6 +249,7 @@ func newOktetoClientFromGraphqlClient(url string, httpClient *http.Client) (*Okt
 	c.pipeline = newPipelineClient(c.client, url)
 	c.stream = newStreamClient(httpClient)
 	c.kubetoken = newKubeTokenClient(httpClient)
+	c.catalog = newCatalogClient(c.client)
 	return c, nil
 }
 
@@ -291,6 +293,11 @@ func translateAPIErr(err error) error {
 		return fmt.Errorf("unauthorized. Please run 'okteto context url' and try again")
 	case "not-found":
 		return oktetoErrors.ErrNotFound
+	case "forbidden":
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("you don't have permission to perform this operation in context '%s'", Context().Name),
+			Hint: fmt.Sprintf("This requires the Okteto %s role or being the %s of the namespace. Ask an administrator of your Okteto instance to grant it to you", adminRole, ownerRole),
+		}
 
 	default:
 		switch {
@@ -403,6 +410,11 @@ func (c *OktetoClient) Kubetoken() types.KubetokenInterface {
 	return c.kubetoken
 }
 
+// Catalog retrieves the Catalog client
+func (c *OktetoClient) Catalog() types.CatalogInterface {
+	return c.catalog
+}
+
 func SetInsecureSkipTLSVerifyPolicy(isInsecure bool) {
 	oktetoLog.Debugf("insecure mode: %t", isInsecure)
 	if isInsecure {
@@ -5,6 +5,8 @@ const (
 	Deployment = "Deployment"
 	// StatefulSet k8s statefulset kind
 	StatefulSet = "StatefulSet"
+	// DaemonSet k8s daemonset kind
+	DaemonSet = "DaemonSet"
 	// Job k8s Job kind
 	Job = "job"
 	// CronJob k8s CronJob kind
@@ -34,6 +34,7 @@ import (
 	"github.com/okteto/okteto/pkg/filesystem"
 	"github.com/okteto/okteto/pkg/k8s/kubeconfig"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/types"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -45,8 +46,11 @@ import (
 )
 
 type OktetoContextStore struct {
-	Contexts       map[string]*OktetoContext `json:"contexts"`
-	CurrentContext string                    `json:"current-context"`
+	Contexts         map[string]*OktetoContext `json:"contexts"`
+	CurrentContext   string                    `json:"current-context"`
+	DisableAnalytics bool                      `json:"disableAnalytics,omitempty"`
+	LocalMetrics     bool                      `json:"localMetrics,omitempty"`
+	AuditLog         bool                      `json:"auditLog,omitempty"`
 }
 
 const (
@@ -75,8 +79,16 @@ type OktetoContext struct {
 	Analytics          bool                 `json:"-" yaml:"-"`
 	ClusterType        string               `json:"-" yaml:"-"`
 	IsOkteto           bool                 `json:"isOkteto,omitempty" yaml:"isOkteto,omitempty"`
+	IsInCluster        bool                 `json:"isInCluster,omitempty" yaml:"isInCluster,omitempty"`
 	IsStoredAsInsecure bool                 `json:"isInsecure,omitempty" yaml:"isInsecure,omitempty"`
 	IsInsecure         bool                 `json:"-" yaml:"-"`
+	Proxy              string               `json:"proxy,omitempty" yaml:"proxy,omitempty"`
+	NoProxy            string               `json:"noProxy,omitempty" yaml:"noProxy,omitempty"`
+	CABundle           string               `json:"caBundle,omitempty" yaml:"caBundle,omitempty"`
+	CertFingerprint    string               `json:"certificateFingerprint,omitempty" yaml:"certificateFingerprint,omitempty"`
+	Timeouts           *model.Timeouts      `json:"timeouts,omitempty" yaml:"timeouts,omitempty"`
+	AuditEndpoint      string               `json:"auditEndpoint,omitempty" yaml:"auditEndpoint,omitempty"`
+	AuditToken         string               `json:"auditToken,omitempty" yaml:"auditToken,omitempty"`
 	CompanyName        string               `json:"-" yaml:"-"`
 	IsTrial            bool                 `json:"-" yaml:"-"`
 }
@@ -246,6 +258,9 @@ func ContextStore() *OktetoContextStore {
 			oktetoLog.Errorf("error decoding okteto contexts: %v", err)
 			oktetoLog.Fatalf(oktetoErrors.ErrCorruptedOktetoContexts, config.GetOktetoContextFolder())
 		}
+		for _, octx := range ctxStore.Contexts {
+			oktetoLog.AddMaskedSecret(octx.Token)
+		}
 		CurrentStore = ctxStore
 
 		return CurrentStore
@@ -272,6 +287,18 @@ func Context() *OktetoContext {
 	return octx
 }
 
+// GetTimeouts returns the timeouts set in the manifest, falling back to the ones of the current okteto context and to the defaults
+func GetTimeouts(manifest *model.Manifest) *model.Timeouts {
+	var manifestTimeouts, contextTimeouts *model.Timeouts
+	if manifest != nil {
+		manifestTimeouts = manifest.Timeouts
+	}
+	if IsContextInitialized() {
+		contextTimeouts = Context().Timeouts
+	}
+	return model.ResolveTimeouts(manifestTimeouts, contextTimeouts)
+}
+
 func HasBeenLogged(oktetoURL string) bool {
 	octxStore := ContextStore()
 	_, ok := octxStore.Contexts[oktetoURL]
@@ -302,15 +329,31 @@ func AddOktetoContext(name string, u *types.User, namespace, personalNamespace s
 
 func AddKubernetesContext(name, namespace, buildkitURL string) {
 	CurrentStore = ContextStore()
-	CurrentStore.Contexts[name] = &OktetoContext{
+	okCtx := &OktetoContext{
 		Name:      name,
 		Namespace: namespace,
 		Builder:   buildkitURL,
 		Analytics: true,
 	}
+	if previous, ok := CurrentStore.Contexts[name]; ok && previous != nil {
+		okCtx.Proxy = previous.Proxy
+		okCtx.NoProxy = previous.NoProxy
+		okCtx.CABundle = previous.CABundle
+		okCtx.CertFingerprint = previous.CertFingerprint
+		okCtx.Timeouts = previous.Timeouts
+		okCtx.AuditEndpoint = previous.AuditEndpoint
+		okCtx.AuditToken = previous.AuditToken
+	}
+	CurrentStore.Contexts[name] = okCtx
 	CurrentStore.CurrentContext = name
 }
 
+// AddInClusterContext adds the context that uses the credentials of the pod running okteto
+func AddInClusterContext(namespace, buildkitURL string) {
+	AddKubernetesContext(kubeconfig.InClusterContext, namespace, buildkitURL)
+	CurrentStore.Contexts[kubeconfig.InClusterContext].IsInCluster = true
+}
+
 type ContextConfigWriterInterface interface {
 	Write() error
 }
@@ -323,6 +366,12 @@ func NewContextConfigWriter() *ContextConfigWriter {
 }
 
 func (*ContextConfigWriter) Write() error {
+	// commands running at the same time, like the deploys to several targets, would overwrite the store of each other
+	if os.Getenv(constants.OktetoSkipContextStoreUpdate) == "true" {
+		oktetoLog.Infof("skipping the update of the context store")
+		return nil
+	}
+
 	marshalled, err := json.MarshalIndent(ContextStore(), "", "\t")
 	if err != nil {
 		oktetoLog.Infof("failed to marshal context: %s", err)
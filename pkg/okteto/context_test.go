@@ -15,9 +15,15 @@ package okteto
 
 import (
 	"context"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/okteto/okteto/internal/test"
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
 )
 
 func Test_UrlToKubernetesContext(t *testing.T) {
@@ -122,3 +128,45 @@ func Test_RemoveSchema(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetTimeouts(t *testing.T) {
+	CurrentStore = &OktetoContextStore{
+		CurrentContext: "test",
+		Contexts: map[string]*OktetoContext{
+			"test": {
+				Name:     "test",
+				Timeouts: &model.Timeouts{Rollout: 15 * time.Minute, APIRetries: 20},
+			},
+		},
+	}
+	defer func() { CurrentStore = nil }()
+
+	manifest := &model.Manifest{Timeouts: &model.Timeouts{Rollout: 20 * time.Minute}}
+	assert.Equal(t, &model.Timeouts{
+		Activation:  model.DefaultActivationTimeout,
+		Rollout:     20 * time.Minute,
+		PortForward: model.DefaultPortForwardTimeout,
+		APIRetries:  20,
+	}, GetTimeouts(manifest))
+
+	assert.Equal(t, &model.Timeouts{
+		Activation:  model.DefaultActivationTimeout,
+		Rollout:     15 * time.Minute,
+		PortForward: model.DefaultPortForwardTimeout,
+		APIRetries:  20,
+	}, GetTimeouts(nil))
+}
+
+func Test_ContextConfigWriterSkipsTheStoreUpdate(t *testing.T) {
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+	t.Setenv(constants.OktetoSkipContextStoreUpdate, "true")
+	CurrentStore = &OktetoContextStore{
+		CurrentContext: "prod-eu",
+		Contexts:       map[string]*OktetoContext{"prod-eu": {Name: "prod-eu", Namespace: "default"}},
+	}
+	t.Cleanup(func() { CurrentStore = nil })
+
+	assert.NoError(t, NewContextConfigWriter().Write())
+	_, err := os.Stat(config.GetOktetoContextsStorePath())
+	assert.True(t, os.IsNotExist(err))
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 	"os"
+	"strconv"
 	"sync"
 	"time"
 
@@ -21,6 +22,10 @@ var (
 	timeout time.Duration
 	tOnce   sync.Once
 
+	// rateLimiters are the rate limiters shared by the Kubernetes clients, by qps and burst
+	rateLimiters   = map[rateLimiterKey]*instrumentedRateLimiter{}
+	rateLimitersMu sync.Mutex
+
 	// ErrK8sUnauthorised is returned when the kubernetes API call returns a 401 error
 	ErrK8sUnauthorised = errors.New("k8s unauthorized error")
 )
@@ -28,6 +33,20 @@ var (
 const (
 	// oktetoKubernetesTimeoutEnvVar defines the timeout for kubernetes operations
 	oktetoKubernetesTimeoutEnvVar = "OKTETO_KUBERNETES_TIMEOUT"
+
+	// oktetoKubernetesQPSEnvVar defines the maximum queries per second to the Kubernetes API
+	oktetoKubernetesQPSEnvVar = "OKTETO_KUBERNETES_QPS"
+
+	// oktetoKubernetesBurstEnvVar defines the maximum burst of queries to the Kubernetes API
+	oktetoKubernetesBurstEnvVar = "OKTETO_KUBERNETES_BURST"
+
+	// oktetoKubernetesSlowRequestEnvVar defines the latency from which the calls to the Kubernetes API are logged as slow. Zero disables it
+	oktetoKubernetesSlowRequestEnvVar = "OKTETO_KUBERNETES_SLOW_REQUEST_THRESHOLD"
+
+	// the client-go defaults (5 and 10) throttle the deployment of large manifests
+	defaultKubernetesQPS         = 50
+	defaultKubernetesBurst       = 100
+	defaultKubernetesSlowRequest = 2 * time.Second
 )
 
 type K8sClientProvider interface {
@@ -102,22 +121,16 @@ func GetKubernetesTimeout() time.Duration {
 }
 
 func getK8sClientWithApiConfig(clientApiConfig *clientcmdapi.Config) (*kubernetes.Clientset, *rest.Config, error) {
-	clientConfig := clientcmd.NewDefaultClientConfig(*clientApiConfig, nil)
-	config, err := clientConfig.ClientConfig()
+	config, err := getRESTConfig(clientApiConfig)
 	if err != nil {
 		return nil, nil, err
 	}
-	config.WarningHandler = rest.NoWarnings{}
 
-	config.Timeout = GetKubernetesTimeout()
-
-	var client *kubernetes.Clientset
-
-	config.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
 		return newTokenRotationTransport(rt)
-	}
+	})
 
-	client, err = kubernetes.NewForConfig(config)
+	client, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -125,15 +138,10 @@ func getK8sClientWithApiConfig(clientApiConfig *clientcmdapi.Config) (*kubernete
 }
 
 func getDynamicClient(clientAPIConfig *clientcmdapi.Config) (dynamic.Interface, *rest.Config, error) {
-	clientConfig := clientcmd.NewDefaultClientConfig(*clientAPIConfig, nil)
-
-	config, err := clientConfig.ClientConfig()
+	config, err := getRESTConfig(clientAPIConfig)
 	if err != nil {
 		return nil, nil, err
 	}
-	config.WarningHandler = rest.NoWarnings{}
-
-	config.Timeout = GetKubernetesTimeout()
 
 	dc, err := dynamic.NewForConfig(config)
 	if err != nil {
@@ -144,15 +152,10 @@ func getDynamicClient(clientAPIConfig *clientcmdapi.Config) (dynamic.Interface,
 }
 
 func getDiscoveryClient(clientAPIConfig *clientcmdapi.Config) (discovery.DiscoveryInterface, *rest.Config, error) {
-	clientConfig := clientcmd.NewDefaultClientConfig(*clientAPIConfig, nil)
-
-	config, err := clientConfig.ClientConfig()
+	config, err := getRESTConfig(clientAPIConfig)
 	if err != nil {
 		return nil, nil, err
 	}
-	config.WarningHandler = rest.NoWarnings{}
-
-	config.Timeout = GetKubernetesTimeout()
 
 	dc, err := discovery.NewDiscoveryClientForConfig(config)
 	if err != nil {
@@ -161,3 +164,84 @@ func getDiscoveryClient(clientAPIConfig *clientcmdapi.Config) (discovery.Discove
 
 	return dc, config, err
 }
+
+// getRESTConfig returns the config shared by all the Kubernetes clients created by okteto
+func getRESTConfig(clientAPIConfig *clientcmdapi.Config) (*rest.Config, error) {
+	clientConfig := clientcmd.NewDefaultClientConfig(*clientAPIConfig, nil)
+	config, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	config.WarningHandler = rest.NoWarnings{}
+
+	config.Timeout = GetKubernetesTimeout()
+	applyNetworkSettings(config)
+
+	config.QPS = getKubernetesQPS()
+	config.Burst = getKubernetesBurst()
+	config.RateLimiter = getSharedRateLimiter(config.QPS, config.Burst)
+
+	slowThreshold := getKubernetesSlowRequestThreshold()
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return newInstrumentedTransport(rt, slowThreshold)
+	})
+	return config, nil
+}
+
+type rateLimiterKey struct {
+	qps   float32
+	burst int
+}
+
+// getSharedRateLimiter returns the rate limiter shared by all the Kubernetes clients with the same qps and burst,
+// so the limits apply to the whole command instead of to each client
+func getSharedRateLimiter(qps float32, burst int) *instrumentedRateLimiter {
+	rateLimitersMu.Lock()
+	defer rateLimitersMu.Unlock()
+	key := rateLimiterKey{qps: qps, burst: burst}
+	if l, ok := rateLimiters[key]; ok {
+		return l
+	}
+	l := newInstrumentedRateLimiter(qps, burst)
+	rateLimiters[key] = l
+	return l
+}
+
+func getKubernetesQPS() float32 {
+	v, ok := os.LookupEnv(oktetoKubernetesQPSEnvVar)
+	if !ok {
+		return defaultKubernetesQPS
+	}
+	qps, err := strconv.ParseFloat(v, 32)
+	if err != nil || qps <= 0 {
+		oktetoLog.Infof("'%s' is not a valid value for %s, ignoring", v, oktetoKubernetesQPSEnvVar)
+		return defaultKubernetesQPS
+	}
+	return float32(qps)
+}
+
+func getKubernetesBurst() int {
+	v, ok := os.LookupEnv(oktetoKubernetesBurstEnvVar)
+	if !ok {
+		return defaultKubernetesBurst
+	}
+	burst, err := strconv.Atoi(v)
+	if err != nil || burst <= 0 {
+		oktetoLog.Infof("'%s' is not a valid value for %s, ignoring", v, oktetoKubernetesBurstEnvVar)
+		return defaultKubernetesBurst
+	}
+	return burst
+}
+
+func getKubernetesSlowRequestThreshold() time.Duration {
+	v, ok := os.LookupEnv(oktetoKubernetesSlowRequestEnvVar)
+	if !ok {
+		return defaultKubernetesSlowRequest
+	}
+	threshold, err := time.ParseDuration(v)
+	if err != nil {
+		oktetoLog.Infof("'%s' is not a valid duration for %s, ignoring", v, oktetoKubernetesSlowRequestEnvVar)
+		return defaultKubernetesSlowRequest
+	}
+	return threshold
+}
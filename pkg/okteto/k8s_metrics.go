@@ -0,0 +1,182 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// K8sClientMetrics contains the metrics of the calls to the Kubernetes API made by the command
+type K8sClientMetrics struct {
+	Requests      int
+	Errors        int
+	SlowRequests  int
+	Latency       time.Duration
+	Throttled     int
+	ThrottledTime time.Duration
+	ByStatus      map[string]int
+}
+
+var (
+	k8sMetrics   = K8sClientMetrics{ByStatus: map[string]int{}}
+	k8sMetricsMu sync.Mutex
+)
+
+// GetK8sClientMetrics returns a copy of the metrics of the calls to the Kubernetes API
+func GetK8sClientMetrics() K8sClientMetrics {
+	k8sMetricsMu.Lock()
+	defer k8sMetricsMu.Unlock()
+	result := k8sMetrics
+	result.ByStatus = make(map[string]int, len(k8sMetrics.ByStatus))
+	for k, v := range k8sMetrics.ByStatus {
+		result.ByStatus[k] = v
+	}
+	return result
+}
+
+// LogK8sClientMetrics logs a summary of the calls to the Kubernetes API made by the command
+func LogK8sClientMetrics() {
+	m := GetK8sClientMetrics()
+	if m.Requests == 0 {
+		return
+	}
+
+	statuses := make([]string, 0, len(m.ByStatus))
+	for k, v := range m.ByStatus {
+		statuses = append(statuses, fmt.Sprintf("%s=%d", k, v))
+	}
+	sort.Strings(statuses)
+
+	oktetoLog.Infof("kubernetes API calls: %d requests (%s), %d errors, %d slow, average latency %s, throttled %d times for %s",
+		m.Requests, strings.Join(statuses, ", "), m.Errors, m.SlowRequests, (m.Latency / time.Duration(m.Requests)).Round(time.Millisecond), m.Throttled, m.ThrottledTime.Round(time.Millisecond))
+	if m.ThrottledTime > time.Second {
+		oktetoLog.Debugf("the Kubernetes client was throttled for %s, increase %s and %s to make more calls per second", m.ThrottledTime.Round(time.Millisecond), oktetoKubernetesQPSEnvVar, oktetoKubernetesBurstEnvVar)
+	}
+}
+
+func recordK8sRequest(method string, statusCode int, latency time.Duration, slow, failed bool) {
+	k8sMetricsMu.Lock()
+	defer k8sMetricsMu.Unlock()
+	k8sMetrics.Requests++
+	k8sMetrics.Latency += latency
+	if slow {
+		k8sMetrics.SlowRequests++
+	}
+	if failed {
+		k8sMetrics.Errors++
+		return
+	}
+	k8sMetrics.ByStatus[fmt.Sprintf("%s:%d", method, statusCode)]++
+}
+
+func recordK8sThrottling(waited time.Duration) {
+	k8sMetricsMu.Lock()
+	defer k8sMetricsMu.Unlock()
+	k8sMetrics.Throttled++
+	k8sMetrics.ThrottledTime += waited
+}
+
+// instrumentedTransport records the metrics of the calls to the Kubernetes API and logs the slow ones
+type instrumentedTransport struct {
+	rt            http.RoundTripper
+	slowThreshold time.Duration
+}
+
+func newInstrumentedTransport(rt http.RoundTripper, slowThreshold time.Duration) *instrumentedTransport {
+	return &instrumentedTransport{
+		rt:            rt,
+		slowThreshold: slowThreshold,
+	}
+}
+
+// RoundTrip records the latency and the status code of the request
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.rt.RoundTrip(req)
+	latency := time.Since(start)
+
+	// watches and streams are long-running requests by design
+	slow := t.slowThreshold > 0 && latency > t.slowThreshold && !isLongRunningRequest(req)
+	if err != nil {
+		recordK8sRequest(req.Method, 0, latency, slow, true)
+		if slow {
+			oktetoLog.Debugf("slow kubernetes API call: %s %s failed after %s: %s", req.Method, req.URL.Path, latency.Round(time.Millisecond), err)
+		}
+		return nil, err
+	}
+
+	recordK8sRequest(req.Method, resp.StatusCode, latency, slow, false)
+	if slow {
+		oktetoLog.Debugf("slow kubernetes API call: %s %s returned %d after %s", req.Method, req.URL.Path, resp.StatusCode, latency.Round(time.Millisecond))
+	}
+	return resp, nil
+}
+
+func isLongRunningRequest(req *http.Request) bool {
+	if req.URL.Query().Get("watch") == "true" || req.URL.Query().Get("follow") == "true" {
+		return true
+	}
+	for _, subresource := range []string{"/exec", "/attach", "/portforward", "/log", "/proxy"} {
+		if strings.HasSuffix(req.URL.Path, subresource) {
+			return true
+		}
+	}
+	return false
+}
+
+// instrumentedRateLimiter records the time the calls to the Kubernetes API wait due to client-side throttling
+type instrumentedRateLimiter struct {
+	flowcontrol.RateLimiter
+}
+
+func newInstrumentedRateLimiter(qps float32, burst int) *instrumentedRateLimiter {
+	return &instrumentedRateLimiter{
+		RateLimiter: flowcontrol.NewTokenBucketRateLimiter(qps, burst),
+	}
+}
+
+// Accept returns once a token becomes available
+func (l *instrumentedRateLimiter) Accept() {
+	start := time.Now()
+	l.RateLimiter.Accept()
+	l.record(time.Since(start))
+}
+
+// Wait returns nil if a token is taken before the context is done
+func (l *instrumentedRateLimiter) Wait(ctx context.Context) error {
+	start := time.Now()
+	err := l.RateLimiter.Wait(ctx)
+	l.record(time.Since(start))
+	return err
+}
+
+func (*instrumentedRateLimiter) record(waited time.Duration) {
+	// waits shorter than this are the regular cost of taking a token
+	if waited < 50*time.Millisecond {
+		return
+	}
+	recordK8sThrottling(waited)
+	if waited > time.Second {
+		oktetoLog.Debugf("kubernetes API call waited %s due to client-side throttling", waited.Round(time.Millisecond))
+	}
+}
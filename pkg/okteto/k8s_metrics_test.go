@@ -0,0 +1,116 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func resetK8sClientMetrics() {
+	k8sMetricsMu.Lock()
+	defer k8sMetricsMu.Unlock()
+	k8sMetrics = K8sClientMetrics{ByStatus: map[string]int{}}
+}
+
+func TestInstrumentedTransport(t *testing.T) {
+	resetK8sClientMetrics()
+	defer resetK8sClientMetrics()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			time.Sleep(20 * time.Millisecond)
+		}
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer s.Close()
+
+	client := &http.Client{Transport: newInstrumentedTransport(http.DefaultTransport, 10*time.Millisecond)}
+	for _, path := range []string{"/fast", "/slow", "/missing"} {
+		resp, err := client.Get(s.URL + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	_, err := client.Get("http://127.0.0.1:0/unreachable")
+	require.Error(t, err)
+
+	m := GetK8sClientMetrics()
+	assert.Equal(t, 4, m.Requests)
+	assert.Equal(t, 1, m.Errors)
+	assert.Equal(t, 1, m.SlowRequests)
+	assert.Equal(t, map[string]int{"GET:200": 2, "GET:404": 1}, m.ByStatus)
+	assert.Greater(t, m.Latency, 20*time.Millisecond)
+}
+
+func TestIsLongRunningRequest(t *testing.T) {
+	var tests = []struct {
+		url      string
+		expected bool
+	}{
+		{url: "https://k8s/api/v1/namespaces/test/pods", expected: false},
+		{url: "https://k8s/api/v1/namespaces/test/pods?watch=true", expected: true},
+		{url: "https://k8s/api/v1/namespaces/test/pods/api/log?follow=true", expected: true},
+		{url: "https://k8s/api/v1/namespaces/test/pods/api/exec?command=sh", expected: true},
+		{url: "https://k8s/api/v1/namespaces/test/pods/api/portforward", expected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, isLongRunningRequest(req))
+		})
+	}
+}
+
+func TestInstrumentedRateLimiter(t *testing.T) {
+	resetK8sClientMetrics()
+	defer resetK8sClientMetrics()
+
+	l := newInstrumentedRateLimiter(10, 1)
+	require.NoError(t, l.Wait(context.Background()))
+	assert.Equal(t, 0, GetK8sClientMetrics().Throttled)
+
+	require.NoError(t, l.Wait(context.Background()))
+	m := GetK8sClientMetrics()
+	assert.Equal(t, 1, m.Throttled)
+	assert.GreaterOrEqual(t, m.ThrottledTime, 50*time.Millisecond)
+}
+
+func TestGetKubernetesRateLimits(t *testing.T) {
+	assert.Equal(t, float32(defaultKubernetesQPS), getKubernetesQPS())
+	assert.Equal(t, defaultKubernetesBurst, getKubernetesBurst())
+	assert.Equal(t, defaultKubernetesSlowRequest, getKubernetesSlowRequestThreshold())
+
+	t.Setenv(oktetoKubernetesQPSEnvVar, "200")
+	t.Setenv(oktetoKubernetesBurstEnvVar, "400")
+	t.Setenv(oktetoKubernetesSlowRequestEnvVar, "500ms")
+	assert.Equal(t, float32(200), getKubernetesQPS())
+	assert.Equal(t, 400, getKubernetesBurst())
+	assert.Equal(t, 500*time.Millisecond, getKubernetesSlowRequestThreshold())
+
+	t.Setenv(oktetoKubernetesQPSEnvVar, "-1")
+	t.Setenv(oktetoKubernetesBurstEnvVar, "many")
+	t.Setenv(oktetoKubernetesSlowRequestEnvVar, "slow")
+	assert.Equal(t, float32(defaultKubernetesQPS), getKubernetesQPS())
+	assert.Equal(t, defaultKubernetesBurst, getKubernetesBurst())
+	assert.Equal(t, defaultKubernetesSlowRequest, getKubernetesSlowRequestThreshold())
+}
@@ -194,7 +194,16 @@ func TestGetK8sClientWithApiConfig(t *testing.T) {
 			require.Equal(t, tc.expected.cfg.Host, cfg.Host)
 			require.NotNil(t, cfg.WrapTransport)
 			require.Equal(t, cfg.WarningHandler, rest.NoWarnings{})
+			require.Equal(t, float32(defaultKubernetesQPS), cfg.QPS)
+			require.Equal(t, defaultKubernetesBurst, cfg.Burst)
+			require.IsType(t, &instrumentedRateLimiter{}, cfg.RateLimiter)
 		})
 	}
 
 }
+
+func TestGetSharedRateLimiter(t *testing.T) {
+	l := getSharedRateLimiter(10, 5)
+	require.Same(t, l, getSharedRateLimiter(10, 5))
+	require.NotSame(t, l, getSharedRateLimiter(20, 5))
+}
@@ -14,6 +14,7 @@
 package okteto
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -26,6 +27,9 @@ import (
 const (
 	// kubetokenPathTemplate (baseURL, namespace)
 	kubetokenPathTemplate = "%s/auth/kubetoken/%s"
+
+	// execTokenPathTemplate (baseURL, namespace)
+	execTokenPathTemplate = "%s/auth/kubetoken/%s/exec"
 )
 
 var (
@@ -33,8 +37,17 @@ var (
 	errStatus                = errors.New("status error")
 	errUnauthorized          = errors.New("unauthorized")
 	errKubetokenNotAvailable = errors.New("kubetoken service not found")
+
+	// ErrExecTokenNotAvailable is returned when the okteto instance doesn't issue exec tokens
+	ErrExecTokenNotAvailable = errors.New("exec tokens are not available")
 )
 
+// execTokenRequest is the pod and container an exec token is scoped to
+type execTokenRequest struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+}
+
 type kubeTokenClient struct {
 	httpClient *http.Client
 }
@@ -68,6 +81,39 @@ func (c *kubeTokenClient) GetKubeToken(baseURL, namespace string) (types.KubeTok
 		return types.KubeTokenResponse{}, fmt.Errorf("GetKubeToken %w: %s", errStatus, resp.Status)
 	}
 
+	return decodeKubeTokenResponse(resp)
+}
+
+// GetExecToken requests a short-lived token that only allows to exec into the container of a pod of the namespace
+func (c *kubeTokenClient) GetExecToken(baseURL, namespace, pod, container string) (types.KubeTokenResponse, error) {
+	endpoint, err := url.Parse(fmt.Sprintf(execTokenPathTemplate, baseURL, namespace))
+	if err != nil {
+		return types.KubeTokenResponse{}, err
+	}
+
+	body, err := json.Marshal(execTokenRequest{Pod: pod, Container: container})
+	if err != nil {
+		return types.KubeTokenResponse{}, err
+	}
+
+	resp, err := c.httpClient.Post(endpoint.String(), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return types.KubeTokenResponse{}, fmt.Errorf("GetExecToken %w: %w", errRequest, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return decodeKubeTokenResponse(resp)
+	case http.StatusUnauthorized:
+		return types.KubeTokenResponse{}, fmt.Errorf("GetExecToken %w", errUnauthorized)
+	case http.StatusNotFound, http.StatusMethodNotAllowed:
+		return types.KubeTokenResponse{}, fmt.Errorf("GetExecToken %w: %s", ErrExecTokenNotAvailable, baseURL)
+	}
+	return types.KubeTokenResponse{}, fmt.Errorf("GetExecToken %w: %s", errStatus, resp.Status)
+}
+
+func decodeKubeTokenResponse(resp *http.Response) (types.KubeTokenResponse, error) {
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return types.KubeTokenResponse{}, fmt.Errorf("failed to read kubetoken response: %w", err)
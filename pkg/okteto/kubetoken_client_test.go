@@ -111,3 +111,70 @@ func Test_CheckService(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetExecToken(t *testing.T) {
+	tests := []struct {
+		name            string
+		httpFakeHandler http.Handler
+		expectedToken   string
+		expectedErr     error
+	}{
+		{
+			name: "error request status unauthorized",
+			httpFakeHandler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+			}),
+			expectedErr: errUnauthorized,
+		},
+		{
+			name: "error exec tokens not available",
+			httpFakeHandler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			}),
+			expectedErr: ErrExecTokenNotAvailable,
+		},
+		{
+			name: "error request not success",
+			httpFakeHandler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+			}),
+			expectedErr: errStatus,
+		},
+		{
+			name: "success response",
+			httpFakeHandler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req execTokenRequest
+				if r.Method != http.MethodPost || r.URL.Path != "/auth/kubetoken/ns/exec" || json.NewDecoder(r.Body).Decode(&req) != nil || req.Pod != "pod" || req.Container != "dev" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				mockResponse := types.KubeTokenResponse{
+					TokenRequest: authenticationv1.TokenRequest{
+						Status: authenticationv1.TokenRequestStatus{
+							Token: "exec-token",
+						},
+					},
+				}
+				jsonBytes, _ := json.Marshal(mockResponse)
+				w.Write(jsonBytes)
+			}),
+			expectedToken: "exec-token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeHttpServer := httptest.NewServer(tt.httpFakeHandler)
+			defer fakeHttpServer.Close()
+
+			fakeKubetokenClient := &kubeTokenClient{
+				httpClient: fakeHttpServer.Client(),
+			}
+
+			got, err := fakeKubetokenClient.GetExecToken(fakeHttpServer.URL, "ns", "pod", "dev")
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedToken, got.Status.Token)
+		})
+	}
+}
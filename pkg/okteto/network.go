@@ -0,0 +1,126 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"os"
+
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoHttp "github.com/okteto/okteto/pkg/http"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"k8s.io/client-go/rest"
+)
+
+func init() {
+	oktetoHttp.SetSettingsProvider(GetNetworkSettings)
+}
+
+// GetNetworkSettings returns the proxy, the CA bundle and the pinned certificate of the current okteto context.
+// The CA bundle defaults to the value of OKTETO_CA_BUNDLE
+func GetNetworkSettings() oktetoHttp.Settings {
+	s := oktetoHttp.Settings{
+		CABundle: os.Getenv(constants.OktetoCABundleEnvVar),
+	}
+	// the store is not loaded here: this is called on every request and loading the store might exit on errors
+	store := CurrentStore
+	if store == nil || store.Contexts == nil {
+		return s
+	}
+	okCtx, ok := store.Contexts[store.CurrentContext]
+	if !ok || okCtx == nil {
+		return s
+	}
+	s.Proxy = okCtx.Proxy
+	s.NoProxy = okCtx.NoProxy
+	s.CertificateFingerprint = okCtx.CertFingerprint
+	if okCtx.CABundle != "" {
+		s.CABundle = okCtx.CABundle
+	}
+	return s
+}
+
+// applyNetworkSettings configures the proxy, the CA bundle and the pinned certificate of the current okteto context in a kubernetes client config
+func applyNetworkSettings(config *rest.Config) {
+	s := GetNetworkSettings()
+	if s.Proxy != "" {
+		config.Proxy = oktetoHttp.ProxyFromSettings
+	}
+	if config.Insecure {
+		return
+	}
+
+	var bundle []byte
+	if s.CABundle != "" {
+		var err error
+		bundle, err = oktetoHttp.CABundlePEM()
+		if err != nil {
+			oktetoLog.Infof("ignoring CA bundle: %s", err)
+		}
+	}
+	var pinned []byte
+	if s.CertificateFingerprint != "" {
+		pinned = getPinnedCertificate(config)
+	}
+	if len(bundle) == 0 && len(pinned) == 0 {
+		return
+	}
+
+	caData := config.TLSClientConfig.CAData
+	if len(caData) == 0 && config.TLSClientConfig.CAFile != "" {
+		var err error
+		caData, err = os.ReadFile(config.TLSClientConfig.CAFile)
+		if err != nil {
+			oktetoLog.Infof("ignoring CA bundle: failed to read '%s': %s", config.TLSClientConfig.CAFile, err)
+			return
+		}
+	}
+	if len(caData) == 0 && len(pinned) == 0 {
+		// the cluster is trusted with the system roots, which are replaced if CAData is set
+		return
+	}
+	if len(caData) > 0 && caData[len(caData)-1] != '\n' {
+		caData = append(caData, '\n')
+	}
+	caData = append(caData, bundle...)
+	config.TLSClientConfig.CAData = append(caData, pinned...)
+	config.TLSClientConfig.CAFile = ""
+}
+
+// getPinnedCertificate returns the certificate of the kubernetes API server if it matches the pinned certificate of the current okteto context
+func getPinnedCertificate(config *rest.Config) []byte {
+	u, err := url.Parse(config.Host)
+	if err != nil || u.Hostname() == "" {
+		oktetoLog.Infof("ignoring pinned certificate: invalid host '%s'", config.Host)
+		return nil
+	}
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), "443")
+	}
+	ctx := context.Background()
+	if config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.Timeout)
+		defer cancel()
+	}
+	pinned, err := oktetoHttp.PinnedCertificatePEM(ctx, addr)
+	if err != nil {
+		oktetoLog.Infof("ignoring pinned certificate: %s", err)
+		return nil
+	}
+	return pinned
+}
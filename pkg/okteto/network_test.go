@@ -0,0 +1,147 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoHttp "github.com/okteto/okteto/pkg/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+)
+
+func writeTestCABundle(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "okteto test CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	return path
+}
+
+func TestGetNetworkSettings(t *testing.T) {
+	var tests = []struct {
+		name     string
+		store    *OktetoContextStore
+		env      string
+		expected oktetoHttp.Settings
+	}{
+		{
+			name:     "no store",
+			env:      "/etc/ssl/corp.pem",
+			expected: oktetoHttp.Settings{CABundle: "/etc/ssl/corp.pem"},
+		},
+		{
+			name: "context settings",
+			store: &OktetoContextStore{
+				Contexts: map[string]*OktetoContext{
+					"test": {Name: "test", Proxy: "http://proxy:3128", NoProxy: ".local", CABundle: "/ctx.pem"},
+				},
+				CurrentContext: "test",
+			},
+			env:      "/etc/ssl/corp.pem",
+			expected: oktetoHttp.Settings{Proxy: "http://proxy:3128", NoProxy: ".local", CABundle: "/ctx.pem"},
+		},
+		{
+			name: "ca bundle from env var",
+			store: &OktetoContextStore{
+				Contexts: map[string]*OktetoContext{
+					"test": {Name: "test", Proxy: "http://proxy:3128"},
+				},
+				CurrentContext: "test",
+			},
+			env:      "/etc/ssl/corp.pem",
+			expected: oktetoHttp.Settings{Proxy: "http://proxy:3128", CABundle: "/etc/ssl/corp.pem"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(constants.OktetoCABundleEnvVar, tt.env)
+			CurrentStore = tt.store
+			assert.Equal(t, tt.expected, GetNetworkSettings())
+		})
+	}
+}
+
+func TestApplyNetworkSettings(t *testing.T) {
+	caBundle := writeTestCABundle(t)
+	CurrentStore = &OktetoContextStore{
+		Contexts: map[string]*OktetoContext{
+			"test": {Name: "test", Proxy: "http://proxy:3128", CABundle: caBundle},
+		},
+		CurrentContext: "test",
+	}
+	bundle, err := os.ReadFile(caBundle)
+	require.NoError(t, err)
+
+	config := &rest.Config{TLSClientConfig: rest.TLSClientConfig{CAData: []byte("cluster-ca")}}
+	applyNetworkSettings(config)
+	assert.NotNil(t, config.Proxy)
+	assert.Equal(t, append([]byte("cluster-ca\n"), bundle...), config.TLSClientConfig.CAData)
+
+	// clusters trusted with the system roots are not modified
+	config = &rest.Config{}
+	applyNetworkSettings(config)
+	assert.Empty(t, config.TLSClientConfig.CAData)
+
+	config = &rest.Config{TLSClientConfig: rest.TLSClientConfig{Insecure: true}}
+	applyNetworkSettings(config)
+	assert.Empty(t, config.TLSClientConfig.CAData)
+}
+
+func TestApplyNetworkSettingsPinnedCertificate(t *testing.T) {
+	s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer s.Close()
+	CurrentStore = &OktetoContextStore{
+		Contexts: map[string]*OktetoContext{
+			"test": {Name: "test", CertFingerprint: oktetoHttp.CertificateFingerprint(s.Certificate())},
+		},
+		CurrentContext: "test",
+	}
+	pinned := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.Certificate().Raw})
+
+	config := &rest.Config{Host: s.URL, TLSClientConfig: rest.TLSClientConfig{CAData: []byte("cluster-ca")}}
+	applyNetworkSettings(config)
+	assert.Equal(t, append([]byte("cluster-ca\n"), pinned...), config.TLSClientConfig.CAData)
+
+	// the pinned certificate is trusted even if the cluster is trusted with the system roots
+	config = &rest.Config{Host: s.URL}
+	applyNetworkSettings(config)
+	assert.Equal(t, pinned, config.TLSClientConfig.CAData)
+}
@@ -80,6 +80,18 @@ type deprecatedDestroyPipelineWithoutVolumesMutation struct {
 	Response deprecatedDestroyPipelineResponse `graphql:"destroyGitRepository(name: $name, space: $space)"`
 }
 
+type getDefaultManifestQuery struct {
+	Response defaultManifestResponse `graphql:"space(id: $id)"`
+}
+
+type defaultManifestResponse struct {
+	DefaultManifest defaultManifest `graphql:"defaultManifest(repository: $repository)"`
+}
+
+type defaultManifest struct {
+	Content graphql.String
+}
+
 type getPipelineResources struct {
 	Response previewResourcesStatus `graphql:"space(id: $id)"`
 }
@@ -221,6 +233,29 @@ func (c *pipelineClient) GetByName(ctx context.Context, name, namespace string)
 	return nil, oktetoErrors.ErrNotFound
 }
 
+// GetDefaultManifest returns the default okteto manifest stored in okteto for a repository in a namespace.
+// It returns ErrNotFound if the repository doesn't have a default manifest or the okteto instance doesn't support them
+func (c *pipelineClient) GetDefaultManifest(ctx context.Context, namespace, repository string) ([]byte, error) {
+	oktetoLog.Infof("getting the default manifest of '%s' in namespace '%s'", repository, namespace)
+	var queryStruct getDefaultManifestQuery
+	variables := map[string]interface{}{
+		"id":         graphql.String(namespace),
+		"repository": graphql.String(repository),
+	}
+	err := query(ctx, &queryStruct, variables, c.client)
+	if err != nil {
+		if strings.Contains(err.Error(), "Cannot query field \"defaultManifest\"") {
+			return nil, oktetoErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get the default manifest: %w", err)
+	}
+	content := string(queryStruct.Response.DefaultManifest.Content)
+	if strings.TrimSpace(content) == "" {
+		return nil, oktetoErrors.ErrNotFound
+	}
+	return []byte(content), nil
+}
+
 // Destroy destroys a pipeline
 func (c *pipelineClient) Destroy(ctx context.Context, name, namespace string, destroyVolumes bool) (*types.GitDeployResponse, error) {
 	oktetoLog.Infof("destroy pipeline: %s/%s", namespace, name)
@@ -353,6 +353,54 @@ func TestGetPipelineByName(t *testing.T) {
 	}
 }
 
+func TestGetDefaultManifest(t *testing.T) {
+	testCases := []struct {
+		name     string
+		client   *fakeGraphQLClient
+		expected []byte
+		err      error
+	}{
+		{
+			name:   "error",
+			client: &fakeGraphQLClient{err: assert.AnError},
+			err:    assert.AnError,
+		},
+		{
+			name:   "not supported",
+			client: &fakeGraphQLClient{err: fmt.Errorf("Cannot query field \"defaultManifest\" on type \"Space\"")},
+			err:    oktetoErrors.ErrNotFound,
+		},
+		{
+			name: "not found",
+			client: &fakeGraphQLClient{
+				queryResult: &getDefaultManifestQuery{},
+			},
+			err: oktetoErrors.ErrNotFound,
+		},
+		{
+			name: "found",
+			client: &fakeGraphQLClient{
+				queryResult: &getDefaultManifestQuery{
+					Response: defaultManifestResponse{
+						DefaultManifest: defaultManifest{Content: "deploy:\n  - okteto build\n"},
+					},
+				},
+			},
+			expected: []byte("deploy:\n  - okteto build\n"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pc := pipelineClient{
+				client: tc.client,
+			}
+			response, err := pc.GetDefaultManifest(context.Background(), "cindy", "https://github.com/okteto/movies")
+			assert.ErrorIs(t, err, tc.err)
+			assert.Equal(t, tc.expected, response)
+		})
+	}
+}
+
 func TestDestroyPipeline(t *testing.T) {
 	type input struct {
 		client         *fakeGraphQLMultipleCallsClient
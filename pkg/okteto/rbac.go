@@ -0,0 +1,135 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	memberRole = "member"
+	ownerRole  = "owner"
+	adminRole  = "administrator"
+)
+
+// forbiddenRegex matches the message of the Forbidden errors returned by the kubernetes API server
+var forbiddenRegex = regexp.MustCompile(`is forbidden: User "([^"]*)" cannot (\S+) resource "([^"]*)" in API group "([^"]*)" (?:in the namespace "([^"]*)"|at the cluster scope)`)
+
+// adminResources are the namespaced resources that are managed by the Okteto administrators
+var adminResources = map[string]bool{
+	"limitranges":     true,
+	"networkpolicies": true,
+	"resourcequotas":  true,
+	"rolebindings":    true,
+	"roles":           true,
+}
+
+// forbiddenRequest is the request rejected by the kubernetes API server
+type forbiddenRequest struct {
+	user      string
+	verb      string
+	resource  string
+	group     string
+	namespace string
+}
+
+// ExplainForbidden translates a Forbidden error of the kubernetes API server into the permission that is missing and who can grant it.
+// Any other error is returned unchanged
+func ExplainForbidden(err error) error {
+	if err == nil {
+		return nil
+	}
+	var uErr oktetoErrors.UserError
+	if errors.As(err, &uErr) {
+		return err
+	}
+	req, ok := parseForbidden(err)
+	if !ok {
+		return err
+	}
+	oktetoLog.Infof("forbidden request: %s", err)
+
+	ctxStore := ContextStore()
+	return explainForbidden(req, IsOktetoContext(ctxStore.CurrentContext))
+}
+
+func parseForbidden(err error) (forbiddenRequest, bool) {
+	m := forbiddenRegex.FindStringSubmatch(err.Error())
+	if m == nil {
+		return forbiddenRequest{}, false
+	}
+	return forbiddenRequest{
+		user:      m[1],
+		verb:      m[2],
+		resource:  m[3],
+		group:     m[4],
+		namespace: m[5],
+	}, true
+}
+
+func explainForbidden(req forbiddenRequest, isOkteto bool) error {
+	resource := req.resource
+	if req.group != "" {
+		resource = fmt.Sprintf("%s.%s", req.resource, req.group)
+	}
+	scope := "at the cluster scope"
+	if req.namespace != "" {
+		scope = fmt.Sprintf("in namespace '%s'", req.namespace)
+	}
+	e := fmt.Errorf("permission denied: '%s' can't %s '%s' %s", req.user, req.verb, resource, scope)
+
+	if !isOkteto {
+		canI := fmt.Sprintf("kubectl auth can-i %s %s", req.verb, resource)
+		if req.namespace != "" {
+			canI = fmt.Sprintf("%s --namespace %s", canI, req.namespace)
+		}
+		return oktetoErrors.UserError{
+			E:    e,
+			Hint: fmt.Sprintf("Ask your cluster administrator to grant you the '%s' permission on '%s'. Run '%s' to check your permissions", req.verb, resource, canI),
+		}
+	}
+
+	var hint string
+	switch requiredRole(req) {
+	case adminRole:
+		hint = fmt.Sprintf("This requires the Okteto %s role. Ask an administrator of your Okteto instance to grant it to you", adminRole)
+	case ownerRole:
+		hint = fmt.Sprintf("This requires the %s role of namespace '%s'. Only its owner or an Okteto %s can %s it", ownerRole, req.namespace, adminRole, req.verb)
+	default:
+		hint = fmt.Sprintf("This requires the %s role of namespace '%s'. Ask the owner of the namespace to add you as a member", memberRole, req.namespace)
+	}
+	return oktetoErrors.UserError{E: e, Hint: hint}
+}
+
+// requiredRole returns the okteto role that grants the request
+func requiredRole(req forbiddenRequest) string {
+	if req.namespace == "" {
+		return adminRole
+	}
+	resource, _, _ := strings.Cut(req.resource, "/")
+	switch {
+	case resource == "namespaces":
+		return ownerRole
+	case adminResources[resource] && req.verb != "get" && req.verb != "list" && req.verb != "watch":
+		return adminRole
+	default:
+		return memberRole
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestParseForbidden(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected forbiddenRequest
+		ok       bool
+	}{
+		{
+			name: "namespaced",
+			err:  k8sErrors.NewForbidden(schema.GroupResource{Group: "apps", Resource: "deployments"}, "api", fmt.Errorf(`User "cindy" cannot delete resource "deployments" in API group "apps" in the namespace "test"`)),
+			expected: forbiddenRequest{
+				user:      "cindy",
+				verb:      "delete",
+				resource:  "deployments",
+				group:     "apps",
+				namespace: "test",
+			},
+			ok: true,
+		},
+		{
+			name: "cluster scope wrapped as string",
+			err:  fmt.Errorf("failed to list nodes: %s", `nodes is forbidden: User "cindy" cannot list resource "nodes" in API group "" at the cluster scope`),
+			expected: forbiddenRequest{
+				user:     "cindy",
+				verb:     "list",
+				resource: "nodes",
+			},
+			ok: true,
+		},
+		{
+			name: "not forbidden",
+			err:  errors.New("connection refused"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseForbidden(tt.err)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestRequiredRole(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      forbiddenRequest
+		expected string
+	}{
+		{
+			name:     "cluster scope",
+			req:      forbiddenRequest{verb: "list", resource: "nodes"},
+			expected: adminRole,
+		},
+		{
+			name:     "namespace",
+			req:      forbiddenRequest{verb: "delete", resource: "namespaces", namespace: "test"},
+			expected: ownerRole,
+		},
+		{
+			name:     "update quota",
+			req:      forbiddenRequest{verb: "update", resource: "resourcequotas", namespace: "test"},
+			expected: adminRole,
+		},
+		{
+			name:     "get quota",
+			req:      forbiddenRequest{verb: "get", resource: "resourcequotas", namespace: "test"},
+			expected: memberRole,
+		},
+		{
+			name:     "exec",
+			req:      forbiddenRequest{verb: "create", resource: "pods/exec", namespace: "test"},
+			expected: memberRole,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, requiredRole(tt.req))
+		})
+	}
+}
+
+func TestExplainForbidden(t *testing.T) {
+	req := forbiddenRequest{user: "cindy", verb: "create", resource: "pods/exec", namespace: "test"}
+
+	t.Run("okteto context", func(t *testing.T) {
+		var uErr oktetoErrors.UserError
+		require.ErrorAs(t, explainForbidden(req, true), &uErr)
+		assert.Equal(t, "permission denied: 'cindy' can't create 'pods/exec' in namespace 'test'", uErr.Error())
+		assert.Equal(t, "This requires the member role of namespace 'test'. Ask the owner of the namespace to add you as a member", uErr.Hint)
+	})
+
+	t.Run("vanilla context", func(t *testing.T) {
+		var uErr oktetoErrors.UserError
+		require.ErrorAs(t, explainForbidden(forbiddenRequest{user: "cindy", verb: "list", resource: "deployments", group: "apps"}, false), &uErr)
+		assert.Equal(t, "permission denied: 'cindy' can't list 'deployments.apps' at the cluster scope", uErr.Error())
+		assert.Contains(t, uErr.Hint, "kubectl auth can-i list deployments.apps'")
+	})
+
+	t.Run("user errors are not changed", func(t *testing.T) {
+		err := oktetoErrors.UserError{E: errors.New(`pods is forbidden: User "cindy" cannot list resource "pods" in API group "" in the namespace "test"`), Hint: "hint"}
+		assert.Equal(t, err, ExplainForbidden(err))
+	})
+
+	t.Run("other errors are not changed", func(t *testing.T) {
+		err := errors.New("connection refused")
+		assert.Equal(t, err, ExplainForbidden(err))
+	})
+}
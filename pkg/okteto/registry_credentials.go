@@ -90,11 +90,13 @@ func (r *externalRegistryCredentialsReader) read(ctx context.Context, registryOr
 		if err != nil {
 			return "", "", err
 		}
+		oktetoLog.AddMaskedSecret(ac.Password)
 		r.cache.Set(registry, ac.Username, ac.Password)
 		return ac.Username, ac.Password, err
 	}
 
 	ac, err := r.getter(ctx, registry)
+	oktetoLog.AddMaskedSecret(ac.Password)
 	return ac.Username, ac.Password, err
 }
 
@@ -47,6 +47,10 @@ type getSecretsQuery struct {
 	Secrets []secretQuery `graphql:"getGitDeploySecrets"`
 }
 
+type getNamespaceSecretsQuery struct {
+	Secrets []secretQuery `graphql:"getNamespaceSecrets(namespace: $namespace)"`
+}
+
 type getContextFileQuery struct {
 	ContextFileJSON string `graphql:"contextFile"`
 }
@@ -203,6 +207,33 @@ func (c *userClient) GetUserSecrets(ctx context.Context) ([]types.Secret, error)
 	return secrets, nil
 }
 
+// GetNamespaceSecrets returns the secrets shared by the members of a namespace
+func (c *userClient) GetNamespaceSecrets(ctx context.Context, namespace string) ([]types.Secret, error) {
+	var queryStruct getNamespaceSecretsQuery
+	vars := map[string]interface{}{
+		"namespace": graphql.String(namespace),
+	}
+	err := query(ctx, &queryStruct, vars, c.client)
+	if err != nil {
+		if strings.Contains(err.Error(), "Cannot query field \"getNamespaceSecrets\" on type \"Query\"") {
+			// namespace secrets are not supported by the backend
+			return []types.Secret{}, nil
+		}
+		return nil, err
+	}
+
+	secrets := make([]types.Secret, 0)
+	for _, secret := range queryStruct.Secrets {
+		if !strings.Contains(string(secret.Name), ".") {
+			secrets = append(secrets, types.Secret{
+				Name:  string(secret.Name),
+				Value: string(secret.Value),
+			})
+		}
+	}
+	return secrets, nil
+}
+
 // TODO: Remove this code when users are in okteto chart > 0.10.8
 func (c *userClient) deprecatedGetUserContext(ctx context.Context) (*types.UserContext, error) {
 	var queryStruct getDeprecatedContextQuery
@@ -322,6 +353,8 @@ func (c *userClient) GetClusterMetadata(ctx context.Context, ns string) (types.C
 			metadata.IsTrialLicense = string(v.Value) == "true"
 		case "companyName":
 			metadata.CompanyName = string(v.Value)
+		case "minimumCLIVersion":
+			metadata.MinimumCLIVersion = string(v.Value)
 		}
 	}
 	if metadata.PipelineRunnerImage == "" {
@@ -293,6 +293,84 @@ func TestGetUserSecrets(t *testing.T) {
 	}
 }
 
+func TestGetNamespaceSecrets(t *testing.T) {
+	type input struct {
+		client *fakeGraphQLClient
+	}
+	type expected struct {
+		secrets []types.Secret
+		err     error
+	}
+	testCases := []struct {
+		name     string
+		cfg      input
+		expected expected
+	}{
+		{
+			name: "error in graphql",
+			cfg: input{
+				client: &fakeGraphQLClient{
+					err: assert.AnError,
+				},
+			},
+			expected: expected{
+				secrets: nil,
+				err:     assert.AnError,
+			},
+		},
+		{
+			name: "query not supported by the backend",
+			cfg: input{
+				client: &fakeGraphQLClient{
+					err: fmt.Errorf("Cannot query field \"getNamespaceSecrets\" on type \"Query\""),
+				},
+			},
+			expected: expected{
+				secrets: []types.Secret{},
+			},
+		},
+		{
+			name: "query get namespace secrets",
+			cfg: input{
+				client: &fakeGraphQLClient{
+					queryResult: &getNamespaceSecretsQuery{
+						Secrets: []secretQuery{
+							{
+								Name:  "DB_PASSWORD",
+								Value: "test",
+							},
+							{
+								Name:  "db.password",
+								Value: "test",
+							},
+						},
+					},
+				},
+			},
+			expected: expected{
+				secrets: []types.Secret{
+					{
+						Name:  "DB_PASSWORD",
+						Value: "test",
+					},
+				},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uc := &userClient{
+				client: tc.cfg.client,
+			}
+			secrets, err := uc.GetNamespaceSecrets(ctx, "test")
+			assert.ErrorIs(t, err, tc.expected.err)
+			assert.Equal(t, tc.expected.secrets, secrets)
+		})
+	}
+}
+
 func TestGetDeprecatedContext(t *testing.T) {
 	type input struct {
 		client *fakeGraphQLClient
@@ -14,6 +14,7 @@
 package registry
 
 import (
+	"context"
 	"crypto/x509"
 	"errors"
 	"fmt"
@@ -72,6 +73,9 @@ type client struct {
 	config  ClientConfigInterface
 	get     func(ref name.Reference, options ...remote.Option) (*remote.Descriptor, error)
 	write   func(ref name.Reference, image v1.Image, options ...remote.Option) error
+	catalog func(ctx context.Context, target name.Registry, options ...remote.Option) ([]string, error)
+	list    func(ctx context.Context, repo name.Repository, options ...remote.Option) ([]string, error)
+	delete  func(ref name.Reference, options ...remote.Option) error
 	tlsDial oktetoHttp.TLSDialFunc
 }
 
@@ -80,6 +84,9 @@ func newOktetoRegistryClient(config ClientConfigInterface) client {
 		config:  config,
 		get:     remote.Get,
 		write:   remote.Write,
+		catalog: remote.Catalog,
+		list:    remote.ListWithContext,
+		delete:  remote.Delete,
 		tlsDial: oktetoHttp.DefaultTLSDial,
 	}
 }
@@ -136,6 +143,46 @@ func (c client) GetImageConfig(image string) (*v1.ConfigFile, error) {
 	return cfg, nil
 }
 
+// ListRepositories returns the repositories of a registry
+func (c client) ListRepositories(ctx context.Context, registry string) ([]string, error) {
+	reg, err := name.NewRegistry(registry)
+	if err != nil {
+		return nil, err
+	}
+	options := []remote.Option{c.getRegistryAuthentication(reg.RegistryStr()), c.getTransportOption()}
+	repositories, err := c.catalog(ctx, reg, options...)
+	if err != nil {
+		return nil, fmt.Errorf("error listing the repositories of %s: %w", registry, err)
+	}
+	return repositories, nil
+}
+
+// ListTags returns the tags of a repository
+func (c client) ListTags(ctx context.Context, repository string) ([]string, error) {
+	repo, err := name.NewRepository(repository)
+	if err != nil {
+		return nil, err
+	}
+	options := []remote.Option{c.getRegistryAuthentication(repo.RegistryStr()), c.getTransportOption()}
+	tags, err := c.list(ctx, repo, options...)
+	if err != nil {
+		return nil, fmt.Errorf("error listing the tags of %s: %w", repository, err)
+	}
+	return tags, nil
+}
+
+// Delete deletes the manifest of an image from the registry
+func (c client) Delete(image string) error {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return err
+	}
+	if err := c.delete(ref, c.getOptions(ref)...); err != nil {
+		return fmt.Errorf("error deleting image %s: %w", image, err)
+	}
+	return nil
+}
+
 func (c client) HasPushAccess(image string) (bool, error) {
 	ref, err := name.ParseReference(image)
 	if err != nil {
@@ -167,7 +214,10 @@ func (c client) getAuthHelper(_ name.Reference) authn.Keychain {
 }
 
 func (c client) getAuthentication(ref name.Reference) remote.Option {
-	registry := ref.Context().RegistryStr()
+	return c.getRegistryAuthentication(ref.Context().RegistryStr())
+}
+
+func (c client) getRegistryAuthentication(registry string) remote.Option {
 	oktetoLog.Debugf("calling registry %s", registry)
 
 	okRegistry := c.config.GetRegistryURL()
@@ -14,11 +14,13 @@
 package registry
 
 import (
+	"bytes"
 	"crypto/x509"
 	"fmt"
 	"strings"
 
 	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 )
 
@@ -197,3 +199,41 @@ func (or OktetoRegistry) CloneGlobalImageToDev(imageWithDigest, tag string) (str
 
 	return devImage, nil
 }
+
+// GetImagePlatforms returns the platforms of an image in 'os/arch[/variant]' format. Multi-platform images return the platforms of their index
+func (or OktetoRegistry) GetImagePlatforms(image string) ([]string, error) {
+	expandedImage := or.imageCtrl.expandImageRegistries(image)
+	descriptor, err := or.client.GetDescriptor(expandedImage)
+	if err != nil {
+		return nil, fmt.Errorf("error getting image platforms: %w", err)
+	}
+
+	if descriptor.MediaType.IsIndex() {
+		index, err := v1.ParseIndexManifest(bytes.NewReader(descriptor.Manifest))
+		if err != nil {
+			return nil, fmt.Errorf("error getting image platforms: %w", err)
+		}
+		platforms := []string{}
+		for _, m := range index.Manifests {
+			// attestation manifests of buildkit are stored with an unknown platform
+			if m.Platform == nil || m.Platform.OS == "unknown" {
+				continue
+			}
+			platforms = append(platforms, formatPlatform(m.Platform.OS, m.Platform.Architecture, m.Platform.Variant))
+		}
+		return platforms, nil
+	}
+
+	cfg, err := or.client.GetImageConfig(expandedImage)
+	if err != nil {
+		return nil, fmt.Errorf("error getting image platforms: %w", err)
+	}
+	return []string{formatPlatform(cfg.OS, cfg.Architecture, "")}, nil
+}
+
+func formatPlatform(os, arch, variant string) string {
+	if variant == "" {
+		return fmt.Sprintf("%s/%s", os, arch)
+	}
+	return fmt.Sprintf("%s/%s/%s", os, arch, variant)
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/types"
 	"github.com/stretchr/testify/assert"
 	apiv1 "k8s.io/api/core/v1"
 )
@@ -703,3 +704,68 @@ func TestGetImageReference(t *testing.T) {
 		})
 	}
 }
+
+func TestGetImagePlatforms(t *testing.T) {
+	index := []byte(`{
+  "schemaVersion": 2,
+  "mediaType": "application/vnd.oci.image.index.v1+json",
+  "manifests": [
+    {"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:1111111111111111111111111111111111111111111111111111111111111111", "size": 1, "platform": {"os": "linux", "architecture": "amd64"}},
+    {"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:2222222222222222222222222222222222222222222222222222222222222222", "size": 1, "platform": {"os": "linux", "architecture": "arm64", "variant": "v8"}},
+    {"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:3333333333333333333333333333333333333333333333333333333333333333", "size": 1, "platform": {"os": "unknown", "architecture": "unknown"}}
+  ]
+}`)
+	var tests = []struct {
+		name     string
+		client   fakeClient
+		expected []string
+		wantErr  bool
+	}{
+		{
+			name: "multi-platform image",
+			client: fakeClient{
+				MockGetDescriptor: mockGetDescriptor{
+					Result: &remote.Descriptor{
+						Descriptor: v1.Descriptor{MediaType: types.OCIImageIndex},
+						Manifest:   index,
+					},
+				},
+			},
+			expected: []string{"linux/amd64", "linux/arm64/v8"},
+		},
+		{
+			name: "single platform image",
+			client: fakeClient{
+				MockGetDescriptor: mockGetDescriptor{
+					Result: &remote.Descriptor{Descriptor: v1.Descriptor{MediaType: types.DockerManifestSchema2}},
+				},
+				GetConfig: getConfig{Result: &v1.ConfigFile{OS: "linux", Architecture: "amd64"}},
+			},
+			expected: []string{"linux/amd64"},
+		},
+		{
+			name: "image not found",
+			client: fakeClient{
+				MockGetDescriptor: mockGetDescriptor{Err: assert.AnError},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := FakeConfig{}
+			or := OktetoRegistry{
+				imageCtrl: NewImageCtrl(cfg),
+				config:    cfg,
+				client:    tt.client,
+			}
+			platforms, err := or.GetImagePlatforms("okteto/golang:1")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, platforms)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// repositoryClient lists and deletes the images of the registry
+type repositoryClient interface {
+	ListRepositories(ctx context.Context, registry string) ([]string, error)
+	ListTags(ctx context.Context, repository string) ([]string, error)
+	GetDescriptor(image string) (*remote.Descriptor, error)
+	GetImageConfig(image string) (*v1.ConfigFile, error)
+	Delete(image string) error
+}
+
+// ImageTag is a tag of a repository of the okteto registry
+type ImageTag struct {
+	// Repository is the repository including the registry, i.e. 'registry.okteto.example.com/cindy/api'
+	Repository string
+	Tag        string
+	Digest     string
+	// Created is the creation time of the image. It's zero if the image config doesn't have it
+	Created time.Time
+	// Size is the compressed size of the config and the layers of the image. It's zero for image indexes
+	Size int64
+}
+
+// Image returns the reference of the tag
+func (t ImageTag) Image() string {
+	return fmt.Sprintf("%s:%s", t.Repository, t.Tag)
+}
+
+// ShortDigest returns the first 12 characters of the hash of the digest
+func (t ImageTag) ShortDigest() string {
+	hash := strings.TrimPrefix(t.Digest, "sha256:")
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
+
+// ImageWithDigest returns the reference of the image by digest
+func (t ImageTag) ImageWithDigest() string {
+	return fmt.Sprintf("%s@%s", t.Repository, t.Digest)
+}
+
+// RepositoryCtrl manages the repositories of a namespace in the okteto registry
+type RepositoryCtrl struct {
+	client repositoryClient
+	config configInterface
+}
+
+// NewRepositoryCtrl returns a controller of the repositories of the okteto registry
+func NewRepositoryCtrl(config configInterface) RepositoryCtrl {
+	return RepositoryCtrl{
+		client: newOktetoRegistryClient(config),
+		config: config,
+	}
+}
+
+// ListNamespaceTags returns the tags of all the repositories of a namespace
+func (rc RepositoryCtrl) ListNamespaceTags(ctx context.Context, namespace string) ([]ImageTag, error) {
+	registryURL := rc.config.GetRegistryURL()
+	repositories, err := rc.client.ListRepositories(ctx, registryURL)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []ImageTag{}
+	prefix := fmt.Sprintf("%s/", namespace)
+	for _, repo := range repositories {
+		if !strings.HasPrefix(repo, prefix) {
+			continue
+		}
+		repository := fmt.Sprintf("%s/%s", registryURL, repo)
+		tags, err := rc.client.ListTags(ctx, repository)
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tags {
+			imageTag := ImageTag{Repository: repository, Tag: tag}
+			descriptor, err := rc.client.GetDescriptor(imageTag.Image())
+			if err != nil {
+				return nil, err
+			}
+			imageTag.Digest = descriptor.Digest.String()
+			imageTag.Size = getImageSize(descriptor)
+			cfg, err := rc.client.GetImageConfig(imageTag.ImageWithDigest())
+			if err != nil {
+				// image indexes and other artifacts don't have a config, they are listed without creation time
+				oktetoLog.Infof("could not get the creation time of %s: %s", imageTag.Image(), err)
+			} else {
+				imageTag.Created = cfg.Created.Time
+			}
+			result = append(result, imageTag)
+		}
+	}
+	return result, nil
+}
+
+// getImageSize returns the size of the config and the layers of an image manifest
+func getImageSize(descriptor *remote.Descriptor) int64 {
+	if !descriptor.MediaType.IsImage() {
+		return 0
+	}
+	manifest, err := v1.ParseManifest(bytes.NewReader(descriptor.Manifest))
+	if err != nil {
+		oktetoLog.Infof("could not parse the manifest of %s: %s", descriptor.Digest, err)
+		return 0
+	}
+	size := manifest.Config.Size
+	for _, l := range manifest.Layers {
+		size += l.Size
+	}
+	return size
+}
+
+// DeleteImage deletes the manifest of an image by digest. All the tags of the manifest are deleted
+func (rc RepositoryCtrl) DeleteImage(imageWithDigest string) error {
+	return rc.client.Delete(imageWithDigest)
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRepositoryClient struct {
+	repositories []string
+	tags         map[string][]string
+	digests      map[string]string
+	created      map[string]time.Time
+	manifests    map[string]string
+	deleted      []string
+}
+
+func (f *fakeRepositoryClient) ListRepositories(_ context.Context, _ string) ([]string, error) {
+	return f.repositories, nil
+}
+
+func (f *fakeRepositoryClient) ListTags(_ context.Context, repository string) ([]string, error) {
+	return f.tags[repository], nil
+}
+
+func (f *fakeRepositoryClient) GetDescriptor(image string) (*remote.Descriptor, error) {
+	digest, err := v1.NewHash(f.digests[image])
+	if err != nil {
+		return nil, err
+	}
+	d := &remote.Descriptor{Descriptor: v1.Descriptor{Digest: digest}}
+	if manifest, ok := f.manifests[image]; ok {
+		d.MediaType = types.DockerManifestSchema2
+		d.Manifest = []byte(manifest)
+	}
+	return d, nil
+}
+
+func (f *fakeRepositoryClient) GetImageConfig(image string) (*v1.ConfigFile, error) {
+	created, ok := f.created[image]
+	if !ok {
+		return nil, errors.New("not an image")
+	}
+	return &v1.ConfigFile{Created: v1.Time{Time: created}}, nil
+}
+
+func (f *fakeRepositoryClient) Delete(image string) error {
+	f.deleted = append(f.deleted, image)
+	return nil
+}
+
+func TestListNamespaceTags(t *testing.T) {
+	apiDigest := "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	webDigest := "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+	created := time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)
+	c := &fakeRepositoryClient{
+		repositories: []string{"cindy/api", "cindy/web", "cindyx/api", "other/api"},
+		tags: map[string][]string{
+			"registry.okteto.dev/cindy/api": {"okteto", "v1"},
+			"registry.okteto.dev/cindy/web": {"okteto"},
+		},
+		digests: map[string]string{
+			"registry.okteto.dev/cindy/api:okteto": apiDigest,
+			"registry.okteto.dev/cindy/api:v1":     apiDigest,
+			"registry.okteto.dev/cindy/web:okteto": webDigest,
+		},
+		created: map[string]time.Time{
+			"registry.okteto.dev/cindy/api@" + apiDigest: created,
+		},
+		manifests: map[string]string{
+			"registry.okteto.dev/cindy/api:okteto": `{"schemaVersion":2,"config":{"size":100},"layers":[{"size":1000},{"size":2000}]}`,
+		},
+	}
+	rc := RepositoryCtrl{client: c, config: FakeConfig{RegistryURL: "registry.okteto.dev"}}
+
+	tags, err := rc.ListNamespaceTags(context.Background(), "cindy")
+	require.NoError(t, err)
+	assert.Equal(t, []ImageTag{
+		{Repository: "registry.okteto.dev/cindy/api", Tag: "okteto", Digest: apiDigest, Created: created, Size: 3100},
+		{Repository: "registry.okteto.dev/cindy/api", Tag: "v1", Digest: apiDigest, Created: created},
+		{Repository: "registry.okteto.dev/cindy/web", Tag: "okteto", Digest: webDigest},
+	}, tags)
+
+	assert.Equal(t, "111111111111", tags[0].ShortDigest())
+
+	require.NoError(t, rc.DeleteImage(tags[2].ImageWithDigest()))
+	assert.Equal(t, []string{"registry.okteto.dev/cindy/web@" + webDigest}, c.deleted)
+}
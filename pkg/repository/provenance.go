@@ -0,0 +1,85 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/model"
+	giturls "github.com/whilp/git-urls"
+)
+
+// Provenance is the version of the source code of a deployment
+type Provenance struct {
+	// Repository is the url of the repository without credentials
+	Repository string
+	Branch     string
+	Commit     string
+	// Dirty is true when the code had uncommitted changes over Commit
+	Dirty bool
+}
+
+// IsEmpty returns if nothing is known about the source code
+func (p Provenance) IsEmpty() bool {
+	return p == Provenance{}
+}
+
+// ProvenanceFromEnv returns the provenance of the code being deployed. It's propagated with the git env vars of okteto deploy,
+// so it's also available inside remote deploys, where the repository isn't available
+func ProvenanceFromEnv() Provenance {
+	p := Provenance{
+		Branch: os.Getenv(constants.OktetoGitBranchEnvVar),
+		Commit: os.Getenv(constants.OktetoGitHeadEnvVar),
+	}
+	if repo := os.Getenv(model.GithubRepositoryEnvVar); repo != "" {
+		if u, err := giturls.Parse(repo); err == nil {
+			p.Repository = repositoryURL{*u}.String()
+		}
+	}
+	if p.Commit == "" {
+		p.Commit = os.Getenv(constants.OktetoGitCommitEnvVar)
+	}
+	p.Dirty, _ = strconv.ParseBool(os.Getenv(constants.OktetoGitDirtyEnvVar))
+	return p
+}
+
+// ProvenanceFromAnnotations returns the provenance of a deployed resource
+func ProvenanceFromAnnotations(annotations map[string]string) Provenance {
+	p := Provenance{
+		Repository: annotations[model.OktetoRepositoryAnnotation],
+		Branch:     annotations[model.OktetoGitBranchAnnotation],
+		Commit:     annotations[model.OktetoGitCommitAnnotation],
+	}
+	p.Dirty, _ = strconv.ParseBool(annotations[model.OktetoGitDirtyAnnotation])
+	return p
+}
+
+// SetAnnotations adds the provenance to the annotations of a deployed resource. Unknown values aren't set
+func (p Provenance) SetAnnotations(annotations map[string]string) {
+	if p.IsEmpty() {
+		return
+	}
+	if p.Repository != "" {
+		annotations[model.OktetoRepositoryAnnotation] = p.Repository
+	}
+	if p.Branch != "" {
+		annotations[model.OktetoGitBranchAnnotation] = p.Branch
+	}
+	if p.Commit != "" {
+		annotations[model.OktetoGitCommitAnnotation] = p.Commit
+	}
+	annotations[model.OktetoGitDirtyAnnotation] = strconv.FormatBool(p.Dirty)
+}
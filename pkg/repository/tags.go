@@ -0,0 +1,178 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/go-git/go-git/v5/storage/memory"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+var commitSHARegex = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// gitTokens are the environment variables with the tokens used to access the private https repositories of a git host,
+// and the username sent with them
+var gitTokens = map[string]struct {
+	envVar   string
+	username string
+}{
+	"github.com": {envVar: "GITHUB_TOKEN", username: "x-access-token"},
+	"gitlab.com": {envVar: "GITLAB_TOKEN", username: "oauth2"},
+}
+
+// listRemoteRefs returns the references of a remote git repository without cloning it
+func listRemoteRefs(ctx context.Context, repositoryURL string) ([]*plumbing.Reference, error) {
+	auth, err := getRemoteAuth(repositoryURL)
+	if err != nil {
+		return nil, err
+	}
+	remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{
+		Name: "origin",
+		URLs: []string{repositoryURL},
+	})
+	return remote.ListContext(ctx, &git.ListOptions{Auth: auth})
+}
+
+// getRemoteAuth returns the credentials to access a remote git repository: the credentials of its url,
+// the token of its git host for https urls, or the keys of the ssh agent for ssh urls
+func getRemoteAuth(repositoryURL string) (transport.AuthMethod, error) {
+	ep, err := transport.NewEndpoint(repositoryURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository url '%s': %w", repositoryURL, err)
+	}
+	switch ep.Protocol {
+	case "http", "https":
+		if ep.User != "" {
+			return &githttp.BasicAuth{Username: ep.User, Password: ep.Password}, nil
+		}
+		token, ok := gitTokens[ep.Host]
+		if !ok || os.Getenv(token.envVar) == "" {
+			return nil, nil
+		}
+		oktetoLog.Infof("using %s to access '%s'", token.envVar, repositoryURL)
+		return &githttp.BasicAuth{Username: token.username, Password: os.Getenv(token.envVar)}, nil
+	case "ssh":
+		auth, err := gitssh.NewSSHAgentAuth(ep.User)
+		if err != nil {
+			oktetoLog.Infof("failed to use the ssh agent to access '%s': %s", repositoryURL, err)
+			return nil, nil
+		}
+		return auth, nil
+	}
+	return nil, nil
+}
+
+// newRemoteError returns the error of a failed access to a remote git repository, with a hint when its credentials are missing
+func newRemoteError(msg string, err error) error {
+	err = fmt.Errorf("%s: %w", msg, err)
+	if errors.Is(err, transport.ErrAuthenticationRequired) || errors.Is(err, transport.ErrAuthorizationFailed) {
+		return oktetoErrors.UserError{
+			E:    err,
+			Hint: "Add the credentials of the repository to its url, set the GITHUB_TOKEN or GITLAB_TOKEN environment variables, or use an ssh url with a key loaded in your ssh agent",
+		}
+	}
+	return err
+}
+
+// ListRemoteTags returns the tags of a remote git repository without cloning it
+func ListRemoteTags(ctx context.Context, repositoryURL string) ([]string, error) {
+	refs, err := listRemoteRefs(ctx, repositoryURL)
+	if err != nil {
+		return nil, newRemoteError(fmt.Sprintf("failed to list the tags of '%s'", repositoryURL), err)
+	}
+	tags := []string{}
+	for _, ref := range refs {
+		if ref.Name().IsTag() {
+			tags = append(tags, ref.Name().Short())
+		}
+	}
+	return tags, nil
+}
+
+// GetRemoteCommit returns the commit a branch or tag of a remote git repository points to, without cloning it.
+// The default branch is used when ref is empty
+func GetRemoteCommit(ctx context.Context, repositoryURL, ref string) (string, error) {
+	if commitSHARegex.MatchString(ref) {
+		return ref, nil
+	}
+	refs, err := listRemoteRefs(ctx, repositoryURL)
+	if err != nil {
+		return "", newRemoteError(fmt.Sprintf("failed to list the refs of '%s'", repositoryURL), err)
+	}
+	return findRemoteCommit(refs, ref)
+}
+
+func findRemoteCommit(refs []*plumbing.Reference, ref string) (string, error) {
+	names := []plumbing.ReferenceName{plumbing.NewBranchReferenceName(ref), plumbing.NewTagReferenceName(ref)}
+	if ref == "" {
+		names = []plumbing.ReferenceName{plumbing.HEAD}
+	}
+	byName := map[plumbing.ReferenceName]*plumbing.Reference{}
+	for _, r := range refs {
+		byName[r.Name()] = r
+	}
+	for _, name := range names {
+		r, ok := byName[name]
+		if !ok {
+			continue
+		}
+		if r.Type() == plumbing.SymbolicReference {
+			if r, ok = byName[r.Target()]; !ok {
+				continue
+			}
+		}
+		return r.Hash().String(), nil
+	}
+	return "", fmt.Errorf("ref '%s' not found", ref)
+}
+
+// ResolveVersion returns the highest tag that satisfies a semver constraint. Tags that are not semver versions are ignored
+func ResolveVersion(tags []string, constraint string) (string, error) {
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return "", fmt.Errorf("'%s' is not a valid semver constraint: %w", constraint, err)
+	}
+
+	versions := map[*semver.Version]string{}
+	candidates := []*semver.Version{}
+	for _, tag := range tags {
+		v, err := semver.NewVersion(tag)
+		if err != nil {
+			continue
+		}
+		if c.Check(v) {
+			versions[v] = tag
+			candidates = append(candidates, v)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("no tag satisfies the version constraint '%s'", constraint)
+	}
+	sort.Sort(semver.Collection(candidates))
+	return versions[candidates[len(candidates)-1]], nil
+}
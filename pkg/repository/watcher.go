@@ -0,0 +1,368 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// WatchEventType is the type of the events of a repository watcher
+type WatchEventType string
+
+const (
+	// HeadMoved is the type of the events of new commits, branch switches, rebases and resets
+	HeadMoved WatchEventType = "head-moved"
+	// WorktreeDirty is the type of the events of a clean worktree that gets uncommitted changes
+	WorktreeDirty WatchEventType = "worktree-dirty"
+	// WorktreeClean is the type of the events of a dirty worktree that gets clean
+	WorktreeClean WatchEventType = "worktree-clean"
+	// RemoteCommits is the type of the events of new commits on the upstream of the current branch
+	RemoteCommits WatchEventType = "remote-commits"
+)
+
+// WatchEvent is a change of the state of a repository
+type WatchEvent struct {
+	Type WatchEventType
+	// Head is the change of HEAD for HeadMoved events, and the current HEAD for the rest of events
+	Head HeadChange
+	// Remote is the remote-tracking branch with new commits for RemoteCommits events, e.g. origin/main
+	Remote string
+	// RemoteSHA is the commit of the remote-tracking branch for RemoteCommits events
+	RemoteSHA string
+}
+
+// HeadChange is a change of the HEAD of a repository
+type HeadChange struct {
+	PreviousBranch string
+	Branch         string
+	PreviousSHA    string
+	SHA            string
+	// FastForward is true when HEAD moved to a descendant commit of the same branch, e.g. a new commit or a pull
+	FastForward bool
+}
+
+// IsBranchSwitch returns if the change is a checkout of another branch
+func (c HeadChange) IsBranchSwitch() bool {
+	return c.PreviousBranch != c.Branch
+}
+
+// RewritesWorktree returns if the change can replace the files of the working tree, which happens for branch switches, rebases and resets
+func (c HeadChange) RewritesWorktree() bool {
+	return c.PreviousSHA != c.SHA && !c.FastForward
+}
+
+// String returns a description of the change
+func (c HeadChange) String() string {
+	if c.IsBranchSwitch() {
+		return fmt.Sprintf("switched from %s to %s", describeHead(c.PreviousBranch, c.PreviousSHA), describeHead(c.Branch, c.SHA))
+	}
+	if c.FastForward {
+		return fmt.Sprintf("%s moved from %s to %s", describeHead(c.Branch, ""), shortSHA(c.PreviousSHA), shortSHA(c.SHA))
+	}
+	return fmt.Sprintf("the history of %s was rewritten from %s to %s", describeHead(c.Branch, ""), shortSHA(c.PreviousSHA), shortSHA(c.SHA))
+}
+
+func describeHead(branch, sha string) string {
+	if branch == "" {
+		return fmt.Sprintf("commit '%s'", shortSHA(sha))
+	}
+	return fmt.Sprintf("branch '%s'", branch)
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+// Watcher watches a git repository and emits its changes as events
+type Watcher struct {
+	path          string
+	interval      time.Duration
+	events        map[WatchEventType]bool
+	fetchInterval time.Duration
+	exec          CommandExecutor
+}
+
+// WatcherOption configures a Watcher
+type WatcherOption func(*Watcher)
+
+// WithWatchEvents limits the events emitted by the watcher. Checking the worktree status is expensive in big repositories,
+// so watchers only interested in HEAD should use WithWatchEvents(HeadMoved)
+func WithWatchEvents(types ...WatchEventType) WatcherOption {
+	return func(w *Watcher) {
+		w.events = map[WatchEventType]bool{}
+		for _, t := range types {
+			w.events[t] = true
+		}
+	}
+}
+
+// WithRemoteFetch fetches the upstream of the current branch periodically, so RemoteCommits events don't depend on the user fetching it
+func WithRemoteFetch(interval time.Duration) WatcherOption {
+	return func(w *Watcher) {
+		w.fetchInterval = interval
+	}
+}
+
+// NewWatcher returns a watcher of the repository that contains path. It checks the repository every interval and emits all the events by default
+func NewWatcher(path string, interval time.Duration, opts ...WatcherOption) *Watcher {
+	w := &Watcher{
+		path:     path,
+		interval: interval,
+		events: map[WatchEventType]bool{
+			HeadMoved:     true,
+			WorktreeDirty: true,
+			WorktreeClean: true,
+			RemoteCommits: true,
+		},
+		exec: &LocalExec{},
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// watchState is the state of the repository compared on every check
+type watchState struct {
+	head headState
+	// dirty is nil until the worktree status is known
+	dirty *bool
+	// remote is the remote-tracking branch of head and remoteHash its commit
+	remote     string
+	remoteHash plumbing.Hash
+}
+
+type headState struct {
+	branch string
+	hash   plumbing.Hash
+}
+
+// Watch checks the repository periodically and sends its events to the returned channel until ctx is done.
+// The state of the repository when Watch is called doesn't emit any event. It returns an error if path is not in a git repository
+func (w *Watcher) Watch(ctx context.Context) (<-chan WatchEvent, error) {
+	repo, err := git.PlainOpenWithOptions(w.path, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the git repository of '%s': %w", w.path, err)
+	}
+	head, err := getHeadState(repo)
+	if err != nil {
+		return nil, err
+	}
+	current := watchState{head: head}
+	w.checkWorktree(ctx, repo, &current)
+	w.checkRemote(repo, &current)
+
+	events := make(chan WatchEvent, 1)
+	go func() {
+		defer close(events)
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		lastFetch := time.Now()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if w.fetchInterval > 0 && time.Since(lastFetch) >= w.fetchInterval {
+					w.fetch(ctx, repo, current)
+					lastFetch = time.Now()
+				}
+				for _, e := range w.check(ctx, repo, &current) {
+					select {
+					case events <- e:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+	return events, nil
+}
+
+// check updates the state of the repository and returns its events
+func (w *Watcher) check(ctx context.Context, repo *git.Repository, current *watchState) []WatchEvent {
+	result := []WatchEvent{}
+	next, err := getHeadState(repo)
+	if err != nil {
+		// HEAD can't be read in the middle of some git operations
+		oktetoLog.Debugf("failed to read the HEAD of the repository: %s", err)
+		return result
+	}
+	change, moved := getHeadChange(repo, current.head, next)
+	current.head = next
+	if moved && w.events[HeadMoved] {
+		result = append(result, WatchEvent{Type: HeadMoved, Head: change})
+	}
+	if !moved {
+		change = HeadChange{PreviousBranch: next.branch, Branch: next.branch, PreviousSHA: next.hash.String(), SHA: next.hash.String()}
+	}
+
+	wasDirty := current.dirty
+	w.checkWorktree(ctx, repo, current)
+	if wasDirty != nil && current.dirty != nil && *wasDirty != *current.dirty {
+		eventType := WorktreeClean
+		if *current.dirty {
+			eventType = WorktreeDirty
+		}
+		if w.events[eventType] {
+			result = append(result, WatchEvent{Type: eventType, Head: change})
+		}
+	}
+
+	previousRemote, previousRemoteHash := current.remote, current.remoteHash
+	w.checkRemote(repo, current)
+	if current.remote != "" && current.remote == previousRemote && current.remoteHash != previousRemoteHash && w.events[RemoteCommits] {
+		// commits pushed from this repository don't count as new commits on the remote
+		pushed, err := isAncestor(repo, current.remoteHash, current.head.hash)
+		if err != nil || !pushed {
+			result = append(result, WatchEvent{Type: RemoteCommits, Head: change, Remote: current.remote, RemoteSHA: current.remoteHash.String()})
+		}
+	}
+	return result
+}
+
+// checkWorktree updates if the worktree has uncommitted changes
+func (w *Watcher) checkWorktree(ctx context.Context, repo *git.Repository, current *watchState) {
+	if !w.events[WorktreeDirty] && !w.events[WorktreeClean] {
+		return
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		oktetoLog.Debugf("failed to get the worktree of the repository: %s", err)
+		return
+	}
+	status, err := oktetoGitWorktree{worktree: worktree}.Status(ctx, NewLocalGit("git", w.exec))
+	if err != nil {
+		oktetoLog.Debugf("failed to get the status of the repository: %s", err)
+		return
+	}
+	dirty := !status.IsClean()
+	current.dirty = &dirty
+}
+
+// checkRemote updates the remote-tracking branch of the current branch and its commit
+func (w *Watcher) checkRemote(repo *git.Repository, current *watchState) {
+	current.remote, current.remoteHash = "", plumbing.ZeroHash
+	if !w.events[RemoteCommits] {
+		return
+	}
+	_, ref := getUpstream(repo, current.head.branch)
+	if ref == "" {
+		return
+	}
+	remoteRef, err := repo.Reference(ref, true)
+	if err != nil {
+		return
+	}
+	current.remote = ref.Short()
+	current.remoteHash = remoteRef.Hash()
+}
+
+// fetch updates the remote-tracking branch of the current branch
+func (w *Watcher) fetch(ctx context.Context, repo *git.Repository, current watchState) {
+	remoteName, ref := getUpstream(repo, current.head.branch)
+	if ref == "" {
+		return
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return
+	}
+	if _, err := w.exec.RunCommand(ctx, worktree.Filesystem.Root(), "git", "fetch", "--quiet", remoteName); err != nil {
+		oktetoLog.Debugf("failed to fetch '%s': %s", remoteName, err)
+	}
+}
+
+// getUpstream returns the remote and the remote-tracking reference of a branch.
+// Branches without upstream configuration are tracked on origin
+func getUpstream(repo *git.Repository, branch string) (string, plumbing.ReferenceName) {
+	if branch == "" {
+		return "", ""
+	}
+	remoteName, merge := "origin", plumbing.NewBranchReferenceName(branch)
+	if cfg, err := repo.Config(); err == nil {
+		if b, ok := cfg.Branches[branch]; ok && b.Remote != "" && b.Merge != "" {
+			remoteName, merge = b.Remote, b.Merge
+		}
+	}
+	if _, err := repo.Remote(remoteName); err != nil {
+		return "", ""
+	}
+	return remoteName, plumbing.NewRemoteReferenceName(remoteName, merge.Short())
+}
+
+func getHeadState(repo *git.Repository) (headState, error) {
+	head, err := repo.Head()
+	if err != nil {
+		return headState{}, fmt.Errorf("failed to read the HEAD of the repository: %w", err)
+	}
+	state := headState{hash: head.Hash()}
+	if head.Name().IsBranch() {
+		state.branch = head.Name().Short()
+	}
+	return state, nil
+}
+
+// getHeadChange returns the change between two states of HEAD and if HEAD moved
+func getHeadChange(repo *git.Repository, previous, next headState) (HeadChange, bool) {
+	if previous == next {
+		return HeadChange{}, false
+	}
+	change := HeadChange{
+		PreviousBranch: previous.branch,
+		Branch:         next.branch,
+		PreviousSHA:    previous.hash.String(),
+		SHA:            next.hash.String(),
+	}
+	if change.IsBranchSwitch() {
+		return change, true
+	}
+
+	isAncestor, err := isAncestor(repo, previous.hash, next.hash)
+	if err != nil {
+		oktetoLog.Debugf("failed to compare commits %s and %s: %s", previous.hash, next.hash, err)
+		return change, true
+	}
+	change.FastForward = isAncestor
+	return change, true
+}
+
+func isAncestor(repo *git.Repository, ancestor, descendant plumbing.Hash) (bool, error) {
+	if ancestor == descendant {
+		return true, nil
+	}
+	a, err := repo.CommitObject(ancestor)
+	if err != nil {
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	d, err := repo.CommitObject(descendant)
+	if err != nil {
+		return false, err
+	}
+	return a.IsAncestor(d)
+}
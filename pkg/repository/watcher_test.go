@@ -0,0 +1,233 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func commitFile(t *testing.T, repo *git.Repository, dir, content string) plumbing.Hash {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "file"), []byte(content), 0600))
+	w, err := repo.Worktree()
+	require.NoError(t, err)
+	_, err = w.Add("file")
+	require.NoError(t, err)
+	hash, err := w.Commit(content, &git.CommitOptions{
+		Author: &object.Signature{Name: "okteto", Email: "okteto@okteto.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+	return hash
+}
+
+func TestGetHeadChange(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	first := commitFile(t, repo, dir, "first")
+	second := commitFile(t, repo, dir, "second")
+
+	w, err := repo.Worktree()
+	require.NoError(t, err)
+	require.NoError(t, w.Checkout(&git.CheckoutOptions{Hash: first, Branch: plumbing.NewBranchReferenceName("rewritten"), Create: true}))
+	rewritten := commitFile(t, repo, dir, "rewritten")
+
+	var tests = []struct {
+		name             string
+		previous         headState
+		next             headState
+		moved            bool
+		rewritesWorktree bool
+	}{
+		{
+			name:     "same head",
+			previous: headState{branch: "master", hash: second},
+			next:     headState{branch: "master", hash: second},
+		},
+		{
+			name:     "new commit on the branch",
+			previous: headState{branch: "master", hash: first},
+			next:     headState{branch: "master", hash: second},
+			moved:    true,
+		},
+		{
+			name:             "branch switch",
+			previous:         headState{branch: "master", hash: second},
+			next:             headState{branch: "feature", hash: rewritten},
+			moved:            true,
+			rewritesWorktree: true,
+		},
+		{
+			name:             "detached head",
+			previous:         headState{branch: "master", hash: second},
+			next:             headState{hash: first},
+			moved:            true,
+			rewritesWorktree: true,
+		},
+		{
+			name:             "rebase of the branch",
+			previous:         headState{branch: "master", hash: second},
+			next:             headState{branch: "master", hash: rewritten},
+			moved:            true,
+			rewritesWorktree: true,
+		},
+		{
+			name:             "reset to a previous commit",
+			previous:         headState{branch: "master", hash: second},
+			next:             headState{branch: "master", hash: first},
+			moved:            true,
+			rewritesWorktree: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change, moved := getHeadChange(repo, tt.previous, tt.next)
+			assert.Equal(t, tt.moved, moved)
+			assert.Equal(t, tt.rewritesWorktree, change.RewritesWorktree())
+		})
+	}
+}
+
+func TestHeadChangeString(t *testing.T) {
+	switchChange := HeadChange{PreviousBranch: "main", Branch: "feature", PreviousSHA: "1234567890", SHA: "0987654321"}
+	assert.Equal(t, "switched from branch 'main' to branch 'feature'", switchChange.String())
+
+	detached := HeadChange{PreviousBranch: "main", PreviousSHA: "1234567890", SHA: "0987654321"}
+	assert.Equal(t, "switched from branch 'main' to commit '0987654'", detached.String())
+
+	rebase := HeadChange{PreviousBranch: "main", Branch: "main", PreviousSHA: "1234567890", SHA: "0987654321"}
+	assert.Equal(t, "the history of branch 'main' was rewritten from 1234567 to 0987654", rebase.String())
+
+	fastForward := HeadChange{PreviousBranch: "main", Branch: "main", PreviousSHA: "1234567890", SHA: "0987654321", FastForward: true}
+	assert.Equal(t, "branch 'main' moved from 1234567 to 0987654", fastForward.String())
+}
+
+func TestHeadWatcherWatch(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	commitFile(t, repo, dir, "first")
+	head, err := repo.Head()
+	require.NoError(t, err)
+
+	subdir := filepath.Join(dir, "api")
+	require.NoError(t, os.Mkdir(subdir, 0700))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := NewWatcher(subdir, 10*time.Millisecond, WithWatchEvents(HeadMoved)).Watch(ctx)
+	require.NoError(t, err)
+
+	w, err := repo.Worktree()
+	require.NoError(t, err)
+	require.NoError(t, w.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("feature"), Create: true}))
+
+	select {
+	case e := <-events:
+		assert.Equal(t, HeadMoved, e.Type)
+		assert.Equal(t, head.Name().Short(), e.Head.PreviousBranch)
+		assert.Equal(t, "feature", e.Head.Branch)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the branch switch wasn't detected")
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestHeadWatcherWatchNoRepository(t *testing.T) {
+	_, err := NewWatcher(t.TempDir(), time.Second).Watch(context.Background())
+	assert.Error(t, err)
+}
+
+func newGoGitWatcher(path string, types ...WatchEventType) *Watcher {
+	// without git in the path the status is calculated by go-git
+	exec := &mockLocalExec{
+		lookPath: func(string) (string, error) {
+			return "", assert.AnError
+		},
+	}
+	w := NewWatcher(path, time.Second, WithWatchEvents(types...))
+	w.exec = exec
+	return w
+}
+
+func TestWatcherCheckWorktree(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	commitFile(t, repo, dir, "first")
+
+	w := newGoGitWatcher(dir, WorktreeDirty, WorktreeClean)
+	head, err := getHeadState(repo)
+	require.NoError(t, err)
+	current := watchState{head: head}
+	w.checkWorktree(context.Background(), repo, &current)
+	assert.Empty(t, w.check(context.Background(), repo, &current))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "file"), []byte("changed"), 0600))
+	events := w.check(context.Background(), repo, &current)
+	require.Len(t, events, 1)
+	assert.Equal(t, WorktreeDirty, events[0].Type)
+	assert.Equal(t, head.hash.String(), events[0].Head.SHA)
+
+	commitFile(t, repo, dir, "changed")
+	events = w.check(context.Background(), repo, &current)
+	require.Len(t, events, 1)
+	assert.Equal(t, WorktreeClean, events[0].Type)
+}
+
+func TestWatcherCheckRemote(t *testing.T) {
+	remoteDir := t.TempDir()
+	remote, err := git.PlainInit(remoteDir, false)
+	require.NoError(t, err)
+	commitFile(t, remote, remoteDir, "first")
+	remoteHead, err := remote.Head()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	repo, err := git.PlainClone(dir, false, &git.CloneOptions{URL: remoteDir})
+	require.NoError(t, err)
+
+	w := newGoGitWatcher(dir, RemoteCommits)
+	head, err := getHeadState(repo)
+	require.NoError(t, err)
+	current := watchState{head: head}
+	w.checkRemote(repo, &current)
+	assert.Equal(t, "origin/"+remoteHead.Name().Short(), current.remote)
+
+	// a local commit pushed to the remote isn't a remote commit
+	pushed := commitFile(t, repo, dir, "pushed")
+	require.NoError(t, repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewRemoteReferenceName("origin", remoteHead.Name().Short()), pushed)))
+	assert.Empty(t, w.check(context.Background(), repo, &current))
+
+	commitFile(t, remote, remoteDir, "second")
+	require.NoError(t, repo.Fetch(&git.FetchOptions{Force: true, RefSpecs: []config.RefSpec{"+refs/heads/*:refs/remotes/origin/*"}}))
+	events := w.check(context.Background(), repo, &current)
+	require.Len(t, events, 1)
+	assert.Equal(t, RemoteCommits, events[0].Type)
+	assert.Equal(t, "origin/"+remoteHead.Name().Short(), events[0].Remote)
+}
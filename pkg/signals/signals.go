@@ -0,0 +1,93 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signals
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// ShutdownSignals are the signals that start the shutdown sequence of a command:
+// CTRL+C, SIGTERM (e.g. the laptop is shutting down) and SIGHUP (e.g. the terminal is closed)
+var ShutdownSignals = []os.Signal{os.Interrupt, syscall.SIGTERM, syscall.SIGHUP}
+
+// Notify relays the shutdown signals to c
+func Notify(c chan<- os.Signal) {
+	signal.Notify(c, ShutdownSignals...)
+}
+
+// NotifyContext returns a copy of ctx that is canceled when a shutdown signal is received
+func NotifyContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(ctx, ShutdownSignals...)
+}
+
+// cleanup is a function registered to release local processes or temporary resources on shutdown
+type cleanup struct {
+	name string
+	fn   func()
+}
+
+var (
+	mu       sync.Mutex
+	cleanups = []*cleanup{}
+)
+
+// RegisterCleanup registers a function to be called by RunCleanups. It returns a function that unregisters it,
+// to be called once the resource has been released by the regular execution of the command
+func RegisterCleanup(name string, fn func()) func() {
+	mu.Lock()
+	defer mu.Unlock()
+	c := &cleanup{name: name, fn: fn}
+	cleanups = append(cleanups, c)
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		for i := range cleanups {
+			if cleanups[i] == c {
+				cleanups = append(cleanups[:i], cleanups[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// RegisterRelease registers fn like RegisterCleanup and returns a function that calls and unregisters it,
+// to release the resource in the regular execution of the command. fn is called only once
+func RegisterRelease(name string, fn func()) func() {
+	var once sync.Once
+	release := func() { once.Do(fn) }
+	unregister := RegisterCleanup(name, release)
+	return func() {
+		unregister()
+		release()
+	}
+}
+
+// RunCleanups calls the registered cleanup functions in reverse order of registration. Each of them is called only once
+func RunCleanups() {
+	mu.Lock()
+	pending := cleanups
+	cleanups = []*cleanup{}
+	mu.Unlock()
+
+	for i := len(pending) - 1; i >= 0; i-- {
+		oktetoLog.Infof("running cleanup '%s'", pending[i].name)
+		pending[i].fn()
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signals
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunCleanups(t *testing.T) {
+	calls := []string{}
+	RegisterCleanup("first", func() { calls = append(calls, "first") })
+	unregister := RegisterCleanup("second", func() { calls = append(calls, "second") })
+	RegisterCleanup("third", func() { calls = append(calls, "third") })
+
+	unregister()
+	RunCleanups()
+	assert.Equal(t, []string{"third", "first"}, calls)
+
+	// cleanups are only called once
+	RunCleanups()
+	assert.Equal(t, []string{"third", "first"}, calls)
+}
+
+func TestRegisterRelease(t *testing.T) {
+	calls := 0
+	release := RegisterRelease("resource", func() { calls++ })
+	release()
+	assert.Equal(t, 1, calls)
+	RunCleanups()
+	assert.Equal(t, 1, calls)
+
+	release = RegisterRelease("resource", func() { calls++ })
+	RunCleanups()
+	assert.Equal(t, 2, calls)
+	release()
+	assert.Equal(t, 2, calls)
+}
@@ -40,10 +40,11 @@ type ForwardManager struct {
 	pf              *k8sForward.PortForwardManager
 	pool            *pool
 	namespace       string
+	timeout         time.Duration
 }
 
 // NewForwardManager returns a newly initialized instance of ForwardManager
-func NewForwardManager(ctx context.Context, sshAddr, localInterface, remoteInterface string, pf *k8sForward.PortForwardManager, namespace string) *ForwardManager {
+func NewForwardManager(ctx context.Context, sshAddr, localInterface, remoteInterface string, pf *k8sForward.PortForwardManager, namespace string, timeout time.Duration) *ForwardManager {
 	return &ForwardManager{
 		ctx:             ctx,
 		localInterface:  localInterface,
@@ -54,6 +55,7 @@ func NewForwardManager(ctx context.Context, sshAddr, localInterface, remoteInter
 		sshAddr:         sshAddr,
 		pf:              pf,
 		namespace:       namespace,
+		timeout:         timeout,
 	}
 }
 
@@ -123,7 +125,7 @@ func (fm *ForwardManager) Start(devPod, namespace string) error {
 	oktetoLog.Info("starting SSH forward manager")
 
 	ticker := time.NewTicker(200 * time.Millisecond)
-	to := time.Now().Add(10 * time.Second)
+	to := time.Now().Add(fm.timeout)
 	retries := 0
 
 	for {
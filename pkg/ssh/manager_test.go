@@ -80,7 +80,7 @@ func TestForward(t *testing.T) {
 	sshAddr := fmt.Sprintf("localhost:%d", sshPort)
 	ssh := testSSHHandler{}
 	go ssh.listenAndServe(sshAddr)
-	fm := NewForwardManager(ctx, sshAddr, model.Localhost, "0.0.0.0", nil, "")
+	fm := NewForwardManager(ctx, sshAddr, model.Localhost, "0.0.0.0", nil, "", model.DefaultPortForwardTimeout)
 
 	if err := startServers(fm); err != nil {
 		t.Fatal(err)
@@ -121,7 +121,7 @@ func TestReverse(t *testing.T) {
 	sshAddr := fmt.Sprintf("localhost:%d", sshPort)
 	ssh := testSSHHandler{}
 	go ssh.listenAndServe(sshAddr)
-	fm := NewForwardManager(ctx, sshAddr, model.Localhost, "0.0.0.0", nil, "")
+	fm := NewForwardManager(ctx, sshAddr, model.Localhost, "0.0.0.0", nil, "", model.DefaultPortForwardTimeout)
 
 	if err := connectReverseForwards(fm); err != nil {
 		t.Fatal(err)
@@ -346,7 +346,7 @@ func (fm *ForwardManager) waitForwardsDisconnected() error {
 
 func TestAdd(t *testing.T) {
 
-	pf := NewForwardManager(context.Background(), "0.0.0.0:22000", "0.0.0.0", "0.0.0.0", nil, "")
+	pf := NewForwardManager(context.Background(), "0.0.0.0:22000", "0.0.0.0", "0.0.0.0", nil, "", model.DefaultPortForwardTimeout)
 	if err := pf.Add(forwardModel.Forward{Local: 10010, Remote: 1010}); err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,173 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncthing
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+
+	getter "github.com/hashicorp/go-getter"
+	oktetoHttp "github.com/okteto/okteto/pkg/http"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// checksumsURLFormat is the file with the sha256 checksums of the packages of a syncthing release.
+	// Its PGP signature is not verified, the checksums protect against corrupted and tampered downloads from mirrors and proxies
+	checksumsURLFormat = "https://github.com/syncthing/syncthing/releases/download/v%[1]s/sha256sum.txt.asc"
+
+	// maxChecksumsSize is the maximum size of the checksums file
+	maxChecksumsSize = 1 << 20
+)
+
+var sha256Regex = regexp.MustCompile(`^[a-fA-F0-9]{64}$`)
+
+// newHTTPClient returns the client used to download syncthing. It honors the proxy and the CA bundle of the okteto context
+func newHTTPClient() *http.Client {
+	return oktetoHttp.StrictSSLHTTPClient(nil)
+}
+
+// downloadFile downloads url into dst. If dst already contains part of the file, only the remaining bytes are downloaded
+func downloadFile(ctx context.Context, client *http.Client, url, dst string, p getter.ProgressTracker) error {
+	f, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", dst, err)
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			oktetoLog.Debugf("Error closing file %s: %s", dst, err)
+		}
+	}()
+
+	offset, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", dst, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		oktetoLog.Infof("resuming download of %s from byte %d", url, offset)
+	case http.StatusOK:
+		// the server doesn't support ranges or there was nothing to resume
+		if err := f.Truncate(0); err != nil {
+			return fmt.Errorf("failed to truncate %s: %w", dst, err)
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to truncate %s: %w", dst, err)
+		}
+		offset = 0
+	case http.StatusRequestedRangeNotSatisfiable:
+		// the file was already completely downloaded, the checksum validates it
+		return nil
+	default:
+		return fmt.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+
+	var body io.ReadCloser = resp.Body
+	if p != nil {
+		total := int64(0)
+		if resp.ContentLength > 0 {
+			total = offset + resp.ContentLength
+		}
+		body = p.TrackProgress(url, offset, total, resp.Body)
+		defer body.Close()
+	}
+
+	if _, err := io.Copy(f, body); err != nil {
+		return fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	return nil
+}
+
+// getChecksum returns the sha256 checksum of a package of a syncthing release
+func getChecksum(ctx context.Context, client *http.Client, checksumsURL, filename string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, checksumsURL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to download the syncthing checksums from %s: %w", checksumsURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download the syncthing checksums from %s: %s", checksumsURL, resp.Status)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxChecksumsSize))
+	if err != nil {
+		return "", fmt.Errorf("failed to read the syncthing checksums from %s: %w", checksumsURL, err)
+	}
+	return parseChecksum(content, filename)
+}
+
+// parseChecksum returns the checksum of filename from a file in 'sha256sum' format. Other lines, like the ones of a PGP signature, are ignored
+func parseChecksum(content []byte, filename string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		parts := strings.Fields(scanner.Text())
+		if len(parts) != 2 || !sha256Regex.MatchString(parts[0]) {
+			continue
+		}
+		if strings.TrimPrefix(parts[1], "*") == filename {
+			return strings.ToLower(parts[0]), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("checksum of %s not found", filename)
+}
+
+// computeChecksum returns the sha256 checksum of a file
+func computeChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			oktetoLog.Debugf("Error closing file %s: %s", path, err)
+		}
+	}()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncthing
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testArchiveName = "syncthing-linux-amd64-v1.24.0.tar.gz"
+
+func newTestArchive(t *testing.T) []byte {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	content := []byte("#!/bin/sh\necho syncthing v1.24.0\n")
+	require.NoError(t, tw.WriteHeader(&tar.Header{
+		Name: fmt.Sprintf("%s/%s", strings.TrimSuffix(testArchiveName, ".tar.gz"), getBinaryName()),
+		Mode: 0700,
+		Size: int64(len(content)),
+	}))
+	_, err := tw.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func sha256Hex(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+func newTestServer(t *testing.T, archive []byte, checksum string) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/"+testArchiveName, func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, testArchiveName, time.Time{}, bytes.NewReader(archive))
+	})
+	mux.HandleFunc("/sha256sum.txt.asc", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "-----BEGIN PGP SIGNED MESSAGE-----\nHash: SHA256\n\n%s  syncthing-windows-amd64-v1.24.0.zip\n%s  %s\n-----BEGIN PGP SIGNATURE-----\n", strings.Repeat("a", 64), checksum, testArchiveName)
+	})
+	s := httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestParseChecksum(t *testing.T) {
+	content := []byte(`-----BEGIN PGP SIGNED MESSAGE-----
+Hash: SHA256
+
+4c4ef5e4d3c36cc1fe0ed7e5d8b0c4afc7b2ed4ca1fe0c1d7c5b0c7bd0dc27a1  syncthing-linux-amd64-v1.24.0.tar.gz
+5c4ef5e4d3c36cc1fe0ed7e5d8b0c4afc7b2ed4ca1fe0c1d7c5b0c7bd0dc27a1 *syncthing-windows-amd64-v1.24.0.zip
+-----BEGIN PGP SIGNATURE-----
+`)
+	checksum, err := parseChecksum(content, "syncthing-linux-amd64-v1.24.0.tar.gz")
+	require.NoError(t, err)
+	assert.Equal(t, "4c4ef5e4d3c36cc1fe0ed7e5d8b0c4afc7b2ed4ca1fe0c1d7c5b0c7bd0dc27a1", checksum)
+
+	checksum, err = parseChecksum(content, "syncthing-windows-amd64-v1.24.0.zip")
+	require.NoError(t, err)
+	assert.Equal(t, "5c4ef5e4d3c36cc1fe0ed7e5d8b0c4afc7b2ed4ca1fe0c1d7c5b0c7bd0dc27a1", checksum)
+
+	_, err = parseChecksum(content, "syncthing-macos-amd64-v1.24.0.zip")
+	assert.Error(t, err)
+}
+
+func TestDownloadFileResume(t *testing.T) {
+	archive := newTestArchive(t)
+	s := newTestServer(t, archive, sha256Hex(archive))
+
+	dst := filepath.Join(t.TempDir(), testArchiveName)
+	// simulate an interrupted download
+	require.NoError(t, os.WriteFile(dst, archive[:10], 0600))
+
+	require.NoError(t, downloadFile(context.Background(), s.Client(), s.URL+"/"+testArchiveName, dst, nil))
+	downloaded, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	assert.Equal(t, archive, downloaded)
+
+	// an already downloaded file is not downloaded again
+	require.NoError(t, downloadFile(context.Background(), s.Client(), s.URL+"/"+testArchiveName, dst, nil))
+	downloaded, err = os.ReadFile(dst)
+	require.NoError(t, err)
+	assert.Equal(t, archive, downloaded)
+}
+
+func TestInstallerInstall(t *testing.T) {
+	archive := newTestArchive(t)
+	var tests = []struct {
+		name     string
+		checksum string
+		wantErr  bool
+	}{
+		{
+			name:     "valid checksum",
+			checksum: sha256Hex(archive),
+		},
+		{
+			name:     "invalid checksum",
+			checksum: strings.Repeat("b", 64),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t, archive, tt.checksum)
+			dir := t.TempDir()
+			i := &installer{
+				client:       s.Client(),
+				downloadURL:  s.URL + "/" + testArchiveName,
+				checksumsURL: s.URL + "/sha256sum.txt.asc",
+				downloadDir:  filepath.Join(dir, "downloads"),
+				installPath:  filepath.Join(dir, "syncthing"),
+			}
+			err := i.install(context.Background(), nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.NoFileExists(t, i.installPath)
+				assert.NoFileExists(t, filepath.Join(i.downloadDir, testArchiveName))
+				return
+			}
+			require.NoError(t, err)
+			assert.FileExists(t, i.installPath)
+			assert.NoFileExists(t, filepath.Join(i.downloadDir, testArchiveName))
+			assert.NoError(t, verifyChecksum(i.installPath))
+
+			require.NoError(t, os.WriteFile(i.installPath, []byte("modified"), 0600))
+			assert.Error(t, verifyChecksum(i.installPath))
+		})
+	}
+}
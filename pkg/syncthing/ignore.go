@@ -0,0 +1,211 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncthing
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// IgnoreFile is the file with the patterns of a sync folder that syncthing doesn't synchronize
+	IgnoreFile = ".stignore"
+
+	// maxIncludeDepth is the maximum nesting of the '#include' lines of a .stignore file
+	maxIncludeDepth = 10
+)
+
+// Ignore are the patterns of the .stignore file of a sync folder, matched the way syncthing does:
+// the first matching pattern decides, patterns not starting with '/' match at any depth and
+// a pattern matching a folder matches everything inside it
+type Ignore struct {
+	patterns      []ignorePattern
+	hasExceptions bool
+}
+
+type ignorePattern struct {
+	regex     *regexp.Regexp
+	exception bool
+}
+
+// LoadIgnore reads the .stignore file of a sync folder. It returns an empty Ignore if it doesn't exist
+func LoadIgnore(folder string) (*Ignore, error) {
+	result := &Ignore{}
+	if err := result.load(filepath.Join(folder, IgnoreFile), 0); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (i *Ignore) load(path string, depth int) error {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && depth == 0 {
+			return nil
+		}
+		return err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			oktetoLog.Debugf("Error closing file %s: %s", path, err)
+		}
+	}()
+
+	escape := `\`
+	if runtime.GOOS == "windows" {
+		escape = "|"
+	}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "", strings.HasPrefix(line, "//"):
+			continue
+		case strings.HasPrefix(line, "#escape="):
+			escape = strings.TrimPrefix(line, "#escape=")
+			continue
+		case strings.HasPrefix(line, "#include "):
+			if depth >= maxIncludeDepth {
+				return fmt.Errorf("too many nested includes in %s", path)
+			}
+			include := filepath.Join(filepath.Dir(path), strings.TrimSpace(strings.TrimPrefix(line, "#include ")))
+			if err := i.load(include, depth+1); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := i.add(line, escape); err != nil {
+			oktetoLog.Infof("ignoring the pattern '%s' of %s: %s", line, path, err)
+		}
+	}
+	return scanner.Err()
+}
+
+func (i *Ignore) add(line, escape string) error {
+	exception := false
+	caseInsensitive := false
+	for {
+		switch {
+		case strings.HasPrefix(line, "!"):
+			exception = true
+			line = line[1:]
+			continue
+		case strings.HasPrefix(line, "(?i)"):
+			caseInsensitive = true
+			line = line[4:]
+			continue
+		case strings.HasPrefix(line, "(?d)"):
+			line = line[4:]
+			continue
+		}
+		break
+	}
+	if line == "" {
+		return nil
+	}
+
+	expr := ""
+	if strings.HasPrefix(line, "/") {
+		expr = "^" + globToRegex(strings.TrimPrefix(line, "/"), escape)
+	} else {
+		expr = "^(.*/)?" + globToRegex(line, escape)
+	}
+	expr += "(/.*)?$"
+	if caseInsensitive {
+		expr = "(?i)" + expr
+	}
+	regex, err := regexp.Compile(expr)
+	if err != nil {
+		return err
+	}
+	i.patterns = append(i.patterns, ignorePattern{regex: regex, exception: exception})
+	if exception {
+		i.hasExceptions = true
+	}
+	return nil
+}
+
+// Match returns true if syncthing doesn't synchronize the path, relative to the sync folder
+func (i *Ignore) Match(rel string) bool {
+	if i == nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	for _, p := range i.patterns {
+		if p.regex.MatchString(rel) {
+			return !p.exception
+		}
+	}
+	return false
+}
+
+// SkipDir returns true if nothing inside the folder, relative to the sync folder, is synchronized
+func (i *Ignore) SkipDir(rel string) bool {
+	return i.Match(rel) && !i.hasExceptions
+}
+
+// globToRegex translates the glob syntax of syncthing to a regular expression
+func globToRegex(glob, escape string) string {
+	var b strings.Builder
+	for j := 0; j < len(glob); j++ {
+		c := glob[j]
+		switch {
+		case strings.HasPrefix(glob[j:], escape) && j+len(escape) < len(glob):
+			j += len(escape)
+			b.WriteString(regexp.QuoteMeta(glob[j : j+1]))
+		case strings.HasPrefix(glob[j:], "**"):
+			j++
+			b.WriteString(".*")
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(glob[j:], ']')
+			if end == -1 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := glob[j+1 : j+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			j += end
+		case c == '{':
+			end := strings.IndexByte(glob[j:], '}')
+			if end == -1 {
+				b.WriteString(`\{`)
+				continue
+			}
+			alternatives := strings.Split(glob[j+1:j+end], ",")
+			for k := range alternatives {
+				alternatives[k] = globToRegex(alternatives[k], escape)
+			}
+			b.WriteString("(" + strings.Join(alternatives, "|") + ")")
+			j += end
+		default:
+			b.WriteString(regexp.QuoteMeta(glob[j : j+1]))
+		}
+	}
+	return b.String()
+}
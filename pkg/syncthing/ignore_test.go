@@ -0,0 +1,78 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncthing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIgnoreMatch(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, IgnoreFile), []byte(`// comment
+!node_modules/keep.js
+node_modules
+/build
+*.log
+(?i)secret.txt
+#include .stignore-extra
+`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".stignore-extra"), []byte("vendor/**/*.a\n{tmp,cache}\n"), 0600))
+
+	ignore, err := LoadIgnore(dir)
+	require.NoError(t, err)
+
+	var tests = []struct {
+		path     string
+		expected bool
+	}{
+		{path: "node_modules", expected: true},
+		{path: "web/node_modules/react/index.js", expected: true},
+		{path: "node_modules/keep.js", expected: false},
+		{path: "build/app", expected: true},
+		{path: "web/build/app", expected: false},
+		{path: "logs/debug.log", expected: true},
+		{path: "logs/debug.logs", expected: false},
+		{path: "config/SECRET.txt", expected: true},
+		{path: "vendor/lib/x/libz.a", expected: true},
+		{path: "cache", expected: true},
+		{path: "src/tmp/file", expected: true},
+		{path: "main.go", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ignore.Match(tt.path))
+		})
+	}
+	assert.False(t, ignore.SkipDir("node_modules"), "folders can't be skipped when there are exceptions")
+}
+
+func TestIgnoreSkipDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, IgnoreFile), []byte("node_modules\n"), 0600))
+	ignore, err := LoadIgnore(dir)
+	require.NoError(t, err)
+	assert.True(t, ignore.SkipDir("node_modules"))
+	assert.False(t, ignore.SkipDir("src"))
+}
+
+func TestLoadIgnoreWithoutFile(t *testing.T) {
+	ignore, err := LoadIgnore(t.TempDir())
+	require.NoError(t, err)
+	assert.False(t, ignore.Match("main.go"))
+}
@@ -14,9 +14,12 @@
 package syncthing
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"regexp"
 	"runtime"
@@ -24,6 +27,7 @@ import (
 
 	"github.com/Masterminds/semver/v3"
 	getter "github.com/hashicorp/go-getter"
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/filesystem"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
@@ -45,44 +49,91 @@ var (
 	}
 )
 
+// installer downloads and installs the syncthing binary
+type installer struct {
+	client       *http.Client
+	downloadURL  string
+	checksumsURL string
+	downloadDir  string
+	installPath  string
+}
+
+func newInstaller(version string) (*installer, error) {
+	downloadURL, err := GetDownloadURL(runtime.GOOS, runtime.GOARCH, version)
+	if err != nil {
+		return nil, err
+	}
+	return &installer{
+		client:       newHTTPClient(),
+		downloadURL:  downloadURL,
+		checksumsURL: fmt.Sprintf(checksumsURLFormat, version),
+		downloadDir:  filepath.Join(config.GetOktetoHome(), "downloads"),
+		installPath:  getInstallPath(),
+	}, nil
+}
+
 // Install installs syncthing locally
 func Install(p getter.ProgressTracker) error {
+	if err := config.CheckOnline("downloading syncthing"); err != nil {
+		return err
+	}
 	oktetoLog.Infof("installing syncthing for %s/%s", runtime.GOOS, runtime.GOARCH)
 
 	minimum := GetMinimumVersion()
-	downloadURL, err := GetDownloadURL(runtime.GOOS, runtime.GOARCH, minimum.String())
+	i, err := newInstaller(minimum.String())
 	if err != nil {
 		return err
 	}
-
-	opts := []getter.ClientOption{}
-	if p != nil {
-		opts = []getter.ClientOption{getter.WithProgress(p)}
+	if err := i.install(context.Background(), p); err != nil {
+		return err
 	}
 
-	dir, err := os.MkdirTemp("", "")
+	oktetoLog.Infof("downloaded syncthing %s to %s", minimum.String(), i.installPath)
+	return nil
+}
+
+func (i *installer) install(ctx context.Context, p getter.ProgressTracker) error {
+	archiveName := path.Base(i.downloadURL)
+	expectedChecksum, err := getChecksum(ctx, i.client, i.checksumsURL, archiveName)
 	if err != nil {
-		return fmt.Errorf("failed to create temp download dir")
+		return err
 	}
 
-	client := &getter.Client{
-		Src:     downloadURL,
-		Dst:     dir,
-		Mode:    getter.ClientModeDir,
-		Options: opts,
+	if err := os.MkdirAll(i.downloadDir, 0700); err != nil {
+		return fmt.Errorf("failed to create download dir: %w", err)
 	}
 
+	// the archive is kept in the download dir until it's installed, so a failed download can be resumed
+	archive := filepath.Join(i.downloadDir, archiveName)
+	if err := downloadFile(ctx, i.client, i.downloadURL, archive, p); err != nil {
+		return err
+	}
+
+	checksum, err := computeChecksum(archive)
+	if err != nil {
+		return fmt.Errorf("failed to compute the checksum of %s: %w", archive, err)
+	}
+	if checksum != expectedChecksum {
+		if err := os.Remove(archive); err != nil {
+			oktetoLog.Infof("failed to delete %s: %s", archive, err)
+		}
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", i.downloadURL, expectedChecksum, checksum)
+	}
+
+	dir, err := os.MkdirTemp("", "")
+	if err != nil {
+		return fmt.Errorf("failed to create temp download dir")
+	}
 	defer os.RemoveAll(dir)
 
-	if err := client.Get(); err != nil {
-		return fmt.Errorf("failed to download syncthing from %s: %s", client.Src, err)
+	if err := decompress(archive, dir); err != nil {
+		return fmt.Errorf("failed to extract %s: %w", archive, err)
 	}
 
-	i := getInstallPath()
-	b := getBinaryPathInDownload(dir, downloadURL)
+	b := getBinaryPathInDownload(dir, i.downloadURL)
 
 	if _, err := os.Stat(b); err != nil {
-		return fmt.Errorf("%s didn't include the syncthing binary: %s", downloadURL, err)
+		return fmt.Errorf("%s didn't include the syncthing binary: %s", i.downloadURL, err)
 	}
 
 	// skipcq GSC-G302 syncthing is a binary so it needs exec permissions
@@ -90,41 +141,136 @@ func Install(p getter.ProgressTracker) error {
 		return fmt.Errorf("failed to set permissions to %s: %s", b, err)
 	}
 
-	if filesystem.FileExists(i) {
-		if err := os.Remove(i); err != nil {
-			oktetoLog.Infof("failed to delete %s, will try to overwrite: %s", i, err)
+	if filesystem.FileExists(i.installPath) {
+		if err := os.Remove(i.installPath); err != nil {
+			oktetoLog.Infof("failed to delete %s, will try to overwrite: %s", i.installPath, err)
 		}
 	}
 
-	if err := filesystem.CopyFile(b, i); err != nil {
-		return fmt.Errorf("failed to write %s: %s", i, err)
+	if err := filesystem.CopyFile(b, i.installPath); err != nil {
+		return fmt.Errorf("failed to write %s: %s", i.installPath, err)
+	}
+
+	// the checksum of the binary is saved to verify it hasn't been modified after the installation
+	binaryChecksum, err := computeChecksum(i.installPath)
+	if err != nil {
+		return fmt.Errorf("failed to compute the checksum of %s: %w", i.installPath, err)
+	}
+	if err := os.WriteFile(getChecksumPath(i.installPath), []byte(binaryChecksum), 0600); err != nil {
+		return fmt.Errorf("failed to write the checksum of %s: %w", i.installPath, err)
 	}
 
-	oktetoLog.Infof("downloaded syncthing %s to %s", syncthingVersion, i)
+	if err := os.Remove(archive); err != nil {
+		oktetoLog.Infof("failed to delete %s: %s", archive, err)
+	}
 	return nil
 }
 
+// decompress extracts the syncthing package into dir
+func decompress(archive, dir string) error {
+	switch {
+	case strings.HasSuffix(archive, ".tar.gz"):
+		return getter.Decompressors["tar.gz"].Decompress(dir, archive, true, 0)
+	case strings.HasSuffix(archive, ".zip"):
+		return getter.Decompressors["zip"].Decompress(dir, archive, true, 0)
+	}
+	return fmt.Errorf("unsupported package format")
+}
+
 // IsInstalled returns true if syncthing is installed
 func IsInstalled() bool {
-	_, err := os.Stat(getInstallPath())
+	_, err := os.Stat(GetBinaryPath())
 	return !os.IsNotExist(err)
 }
 
-// ShouldUpgrade returns true if syncthing should be upgraded
+// IsSystemInstalled returns true if okteto uses a system-installed syncthing binary instead of downloading it
+func IsSystemInstalled() bool {
+	return os.Getenv(model.SyncthingPathEnvVar) != ""
+}
+
+// IsVersionPinned returns true if the syncthing version is defined by the user
+func IsVersionPinned() bool {
+	return os.Getenv(model.SyncthingVersionEnvVar) != ""
+}
+
+// GetBinaryPath returns the path of the syncthing binary used by okteto
+func GetBinaryPath() string {
+	if p := os.Getenv(model.SyncthingPathEnvVar); p != "" {
+		return p
+	}
+	return getInstallPath()
+}
+
+// ShouldUpgrade returns true if syncthing should be upgraded.
+// A system-installed syncthing is never upgraded, and a pinned version is also downgraded if needed
 func ShouldUpgrade() bool {
+	if IsSystemInstalled() {
+		return false
+	}
 	if !IsInstalled() {
 		return true
 	}
-	current := getInstalledVersion()
+	current := GetInstalledVersion()
 	if current == nil {
 		return true
 	}
 
 	minimum := GetMinimumVersion()
+	if IsVersionPinned() {
+		return !minimum.Equal(current)
+	}
 
 	return minimum.GreaterThan(current)
 }
 
+// Verify checks that the syncthing binary is installed with the expected version and that it hasn't been modified since it was installed
+func Verify() error {
+	binaryPath := GetBinaryPath()
+	if !IsInstalled() {
+		return fmt.Errorf("syncthing is not installed at %s", binaryPath)
+	}
+
+	current := GetInstalledVersion()
+	if current == nil {
+		return fmt.Errorf("failed to get the version of %s", binaryPath)
+	}
+	minimum := GetMinimumVersion()
+	if IsVersionPinned() && !IsSystemInstalled() && !minimum.Equal(current) {
+		return fmt.Errorf("syncthing %s is installed, but the pinned version is %s", current.String(), minimum.String())
+	}
+	if minimum.GreaterThan(current) {
+		return fmt.Errorf("syncthing %s is installed, but the minimum supported version is %s", current.String(), minimum.String())
+	}
+
+	if IsSystemInstalled() {
+		return nil
+	}
+	return verifyChecksum(binaryPath)
+}
+
+// verifyChecksum compares the checksum of the binary with the one saved when it was installed
+func verifyChecksum(binaryPath string) error {
+	expected, err := os.ReadFile(getChecksumPath(binaryPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("the checksum of %s is unknown, run 'okteto sync upgrade' to reinstall it", binaryPath)
+		}
+		return err
+	}
+	checksum, err := computeChecksum(binaryPath)
+	if err != nil {
+		return fmt.Errorf("failed to compute the checksum of %s: %w", binaryPath, err)
+	}
+	if checksum != strings.TrimSpace(string(expected)) {
+		return fmt.Errorf("%s has been modified since it was installed, run 'okteto sync upgrade' to reinstall it", binaryPath)
+	}
+	return nil
+}
+
+func getChecksumPath(binaryPath string) string {
+	return fmt.Sprintf("%s.sha256", binaryPath)
+}
+
 func GetMinimumVersion() *semver.Version {
 	v := os.Getenv(model.SyncthingVersionEnvVar)
 	if v == "" {
@@ -134,8 +280,9 @@ func GetMinimumVersion() *semver.Version {
 	return semver.MustParse(v)
 }
 
-func getInstalledVersion() *semver.Version {
-	cmd := exec.Command(getInstallPath(), "--version")
+// GetInstalledVersion returns the version of the syncthing binary used by okteto
+func GetInstalledVersion() *semver.Version {
+	cmd := exec.Command(GetBinaryPath(), "--version")
 	output, err := cmd.Output()
 	if err != nil {
 		oktetoLog.Errorf("failed to get the current syncthing version `%s`: %s", output, err)
@@ -34,7 +34,7 @@ func TestInstall(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	v := getInstalledVersion()
+	v := GetInstalledVersion()
 	if v == nil {
 		t.Fatal("failed to get version")
 	}
@@ -208,3 +208,16 @@ func TestGetMinimumVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestSystemInstalledSyncthing(t *testing.T) {
+	t.Setenv(model.SyncthingPathEnvVar, "/usr/bin/syncthing")
+	if !IsSystemInstalled() {
+		t.Fatal("expected a system-installed syncthing")
+	}
+	if GetBinaryPath() != "/usr/bin/syncthing" {
+		t.Fatalf("unexpected binary path %s", GetBinaryPath())
+	}
+	if ShouldUpgrade() {
+		t.Fatal("a system-installed syncthing should never be upgraded")
+	}
+}
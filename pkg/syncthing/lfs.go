@@ -0,0 +1,96 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncthing
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// DefaultWeakHashThresholdPct is the percentage of changed blocks of a file from which syncthing looks for shifted blocks
+	DefaultWeakHashThresholdPct = 25
+
+	// lfsPointerPrefix is the first line of a git LFS pointer file
+	lfsPointerPrefix = "version https://git-lfs.github.com/spec/v1"
+
+	// lfsPointerMaxSize is the maximum size of a git LFS pointer file
+	lfsPointerMaxSize = 1024
+)
+
+// UsesLFS returns true if the '.gitattributes' file of a folder tracks files with git LFS
+func UsesLFS(folder string) bool {
+	f, err := os.Open(filepath.Join(folder, ".gitattributes"))
+	if err != nil {
+		return false
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			oktetoLog.Debugf("Error closing file %s: %s", f.Name(), err)
+		}
+	}()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		for _, attr := range strings.Fields(line) {
+			if attr == "filter=lfs" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsLFSPointer returns true if a file is a git LFS pointer, meaning its content hasn't been fetched by 'git lfs pull'
+func IsLFSPointer(path string, size int64) bool {
+	if size > lfsPointerMaxSize {
+		return false
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			oktetoLog.Debugf("Error closing file %s: %s", path, err)
+		}
+	}()
+
+	header := make([]byte, len(lfsPointerPrefix))
+	if _, err := io.ReadFull(f, header); err != nil {
+		return false
+	}
+	return bytes.Equal(header, []byte(lfsPointerPrefix))
+}
+
+// getWeakHashThresholdPct returns 0 if any folder tracks files with git LFS.
+// Large binary assets are usually modified in place, and the weak hash lets syncthing reuse their shifted blocks instead of transferring them again
+func getWeakHashThresholdPct(folders []*Folder) int {
+	for _, folder := range folders {
+		if UsesLFS(folder.LocalPath) {
+			return 0
+		}
+	}
+	return DefaultWeakHashThresholdPct
+}
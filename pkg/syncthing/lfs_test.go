@@ -0,0 +1,63 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncthing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsesLFS(t *testing.T) {
+	var tests = []struct {
+		name          string
+		gitattributes string
+		expected      bool
+	}{
+		{name: "no gitattributes"},
+		{name: "no lfs", gitattributes: "*.sh text eol=lf\n"},
+		{name: "commented lfs", gitattributes: "# *.psd filter=lfs diff=lfs merge=lfs -text\n"},
+		{name: "lfs", gitattributes: "*.sh text eol=lf\n*.psd filter=lfs diff=lfs merge=lfs -text\n", expected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tt.gitattributes != "" {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, ".gitattributes"), []byte(tt.gitattributes), 0600))
+			}
+			assert.Equal(t, tt.expected, UsesLFS(dir))
+
+			expectedThreshold := DefaultWeakHashThresholdPct
+			if tt.expected {
+				expectedThreshold = 0
+			}
+			assert.Equal(t, expectedThreshold, getWeakHashThresholdPct([]*Folder{{LocalPath: dir}}))
+		})
+	}
+}
+
+func TestIsLFSPointer(t *testing.T) {
+	dir := t.TempDir()
+	pointer := filepath.Join(dir, "design.psd")
+	require.NoError(t, os.WriteFile(pointer, []byte("version https://git-lfs.github.com/spec/v1\noid sha256:4d7a\nsize 12345\n"), 0600))
+	assert.True(t, IsLFSPointer(pointer, 60))
+	assert.False(t, IsLFSPointer(pointer, 2048))
+
+	content := filepath.Join(dir, "logo.png")
+	require.NoError(t, os.WriteFile(content, []byte("png"), 0600))
+	assert.False(t, IsLFSPointer(content, 3))
+}
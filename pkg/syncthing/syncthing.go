@@ -32,6 +32,7 @@ import (
 
 	"github.com/okteto/okteto/pkg/config"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/filesystem"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"golang.org/x/crypto/bcrypt"
@@ -60,7 +61,7 @@ const (
 	LocalDeviceID = "ABKAVQF-RUO4CYO-FSC2VIP-VRX4QDA-TQQRN2J-MRDXJUC-FXNWP6N-S6ZSAAR"
 
 	// DefaultFileWatcherDelay how much to wait before starting a sync after a file change
-	DefaultFileWatcherDelay = 5
+	DefaultFileWatcherDelay = 1
 
 	// ClusterPort is the port used by syncthing in the cluster
 	ClusterPort = 22000
@@ -71,34 +72,35 @@ const (
 
 // Syncthing represents the local syncthing process.
 type Syncthing struct {
-	APIKey           string        `yaml:"apikey"`
-	GUIPassword      string        `yaml:"password"`
-	GUIPasswordHash  string        `yaml:"-"`
-	binPath          string        `yaml:"-"`
-	Client           *http.Client  `yaml:"-"`
-	cmd              *exec.Cmd     `yaml:"-"`
-	Folders          []*Folder     `yaml:"folders"`
-	FileWatcherDelay int           `yaml:"-"`
-	ForceSendOnly    bool          `yaml:"-"`
-	ResetDatabase    bool          `yaml:"-"`
-	GUIAddress       string        `yaml:"local"`
-	Home             string        `yaml:"-"`
-	LogPath          string        `yaml:"-"`
-	ListenAddress    string        `yaml:"-"`
-	RemoteAddress    string        `yaml:"-"`
-	RemoteDeviceID   string        `yaml:"-"`
-	RemoteGUIAddress string        `yaml:"remote"`
-	RemoteGUIPort    int           `yaml:"-"`
-	RemotePort       int           `yaml:"-"`
-	LocalGUIPort     int           `yaml:"-"`
-	LocalPort        int           `yaml:"-"`
-	Type             string        `yaml:"-"`
-	IgnoreDelete     bool          `yaml:"-"`
-	Verbose          bool          `yaml:"-"`
-	pid              int           `yaml:"-"`
-	RescanInterval   string        `yaml:"-"`
-	Compression      string        `yaml:"-"`
-	timeout          time.Duration `yaml:"-"`
+	APIKey               string        `yaml:"apikey"`
+	GUIPassword          string        `yaml:"password"`
+	GUIPasswordHash      string        `yaml:"-"`
+	binPath              string        `yaml:"-"`
+	Client               *http.Client  `yaml:"-"`
+	cmd                  *exec.Cmd     `yaml:"-"`
+	Folders              []*Folder     `yaml:"folders"`
+	FileWatcherDelay     int           `yaml:"-"`
+	ForceSendOnly        bool          `yaml:"-"`
+	ResetDatabase        bool          `yaml:"-"`
+	GUIAddress           string        `yaml:"local"`
+	Home                 string        `yaml:"-"`
+	LogPath              string        `yaml:"-"`
+	ListenAddress        string        `yaml:"-"`
+	RemoteAddress        string        `yaml:"-"`
+	RemoteDeviceID       string        `yaml:"-"`
+	RemoteGUIAddress     string        `yaml:"remote"`
+	RemoteGUIPort        int           `yaml:"-"`
+	RemotePort           int           `yaml:"-"`
+	LocalGUIPort         int           `yaml:"-"`
+	LocalPort            int           `yaml:"-"`
+	Type                 string        `yaml:"-"`
+	IgnoreDelete         bool          `yaml:"-"`
+	Verbose              bool          `yaml:"-"`
+	pid                  int           `yaml:"-"`
+	RescanInterval       string        `yaml:"-"`
+	Compression          string        `yaml:"-"`
+	WeakHashThresholdPct int           `yaml:"-"`
+	timeout              time.Duration `yaml:"-"`
 }
 
 // Folder represents a sync folder
@@ -165,6 +167,13 @@ type ItemEvent struct {
 // Connections represents syncthing connections.
 type Connections struct {
 	Connections map[string]Connection `json:"connections"`
+	Total       ConnectionsTotal      `json:"total"`
+}
+
+// ConnectionsTotal represents the bytes transferred by all the syncthing connections
+type ConnectionsTotal struct {
+	InBytesTotal  int64 `json:"inBytesTotal"`
+	OutBytesTotal int64 `json:"outBytesTotal"`
 }
 
 // Connection represents syncthing connection.
@@ -179,7 +188,7 @@ type DownloadProgressData struct {
 
 // New constructs a new Syncthing.
 func New(dev *model.Dev) (*Syncthing, error) {
-	fullPath := getInstallPath()
+	fullPath := GetBinaryPath()
 	remotePort, err := model.GetAvailablePort(dev.Interface)
 	if err != nil {
 		return nil, err
@@ -207,9 +216,22 @@ func New(dev *model.Dev) (*Syncthing, error) {
 		hash = []byte("")
 	}
 
-	compression := "metadata"
+	compression := model.SyncCompressionMetadata
 	if dev.Sync.Compression {
-		compression = "always"
+		compression = model.SyncCompressionAlways
+	}
+	fileWatcherDelay := DefaultFileWatcherDelay
+	rescanInterval := dev.Sync.RescanInterval
+	if o := dev.Sync.Options; o != nil {
+		if o.Compression != "" {
+			compression = o.Compression
+		}
+		if o.FsWatcherDelay > 0 {
+			fileWatcherDelay = o.FsWatcherDelay
+		}
+		if o.RescanInterval > 0 {
+			rescanInterval = o.RescanInterval
+		}
 	}
 	s := &Syncthing{
 		APIKey:           "cnd",
@@ -217,7 +239,7 @@ func New(dev *model.Dev) (*Syncthing, error) {
 		GUIPasswordHash:  string(hash),
 		binPath:          fullPath,
 		Client:           NewAPIClient(),
-		FileWatcherDelay: DefaultFileWatcherDelay,
+		FileWatcherDelay: fileWatcherDelay,
 		GUIAddress:       net.JoinHostPort(dev.Interface, strconv.Itoa(guiPort)),
 		Home:             config.GetAppHome(dev.Namespace, dev.Name),
 		LogPath:          GetLogFile(dev.Namespace, dev.Name),
@@ -233,7 +255,7 @@ func New(dev *model.Dev) (*Syncthing, error) {
 		IgnoreDelete:     true,
 		Verbose:          dev.Sync.Verbose,
 		Folders:          []*Folder{},
-		RescanInterval:   strconv.Itoa(dev.Sync.RescanInterval),
+		RescanInterval:   strconv.Itoa(rescanInterval),
 		Compression:      compression,
 		timeout:          time.Duration(dev.Timeout.Default),
 	}
@@ -248,13 +270,14 @@ func New(dev *model.Dev) (*Syncthing, error) {
 				s.Folders,
 				&Folder{
 					Name:       strconv.Itoa(index),
-					LocalPath:  sync.LocalPath,
+					LocalPath:  filesystem.LongPath(sync.LocalPath),
 					RemotePath: sync.RemotePath,
 				},
 			)
 			index++
 		}
 	}
+	s.WeakHashThresholdPct = getWeakHashThresholdPct(s.Folders)
 
 	return s, nil
 }
@@ -391,6 +414,23 @@ func (s *Syncthing) Overwrite(ctx context.Context) error {
 	return nil
 }
 
+// Rescan requests the local syncthing to scan all the folders, so changes of the working tree are synchronized without waiting for the file watcher
+func (s *Syncthing) Rescan(ctx context.Context) error {
+	for _, folder := range s.Folders {
+		oktetoLog.Infof("rescanning local syncthing path=%s", folder.LocalPath)
+		params := map[string]string{"folder": GetFolderName(folder)}
+		_, err := s.APICall(ctx, "rest/db/scan", "POST", 200, params, true, nil, false, 3)
+		if err != nil {
+			oktetoLog.Infof("error posting 'rest/db/scan' syncthing API: %s", err)
+			if strings.Contains(err.Error(), "Client.Timeout") {
+				return oktetoErrors.ErrBusySyncthing
+			}
+			return oktetoErrors.ErrLostSyncthing
+		}
+	}
+	return nil
+}
+
 // IsAllOverwritten checks if all overwrite operations has been completed
 func (s *Syncthing) IsAllOverwritten() bool {
 	for _, folder := range s.Folders {
@@ -510,6 +550,24 @@ func (s *Syncthing) GetCompletion(ctx context.Context, local bool, device string
 	return completion, nil
 }
 
+// GetConnections returns the connections of the local syncthing
+func (s *Syncthing) GetConnections(ctx context.Context) (*Connections, error) {
+	connections := &Connections{}
+	body, err := s.APICall(ctx, "rest/system/connections", "GET", 200, nil, true, nil, true, 3)
+	if err != nil {
+		oktetoLog.Infof("error calling 'rest/system/connections' syncthing API: %s", err)
+		if strings.Contains(err.Error(), "Client.Timeout") {
+			return nil, oktetoErrors.ErrBusySyncthing
+		}
+		return nil, oktetoErrors.ErrLostSyncthing
+	}
+	if err := json.Unmarshal(body, connections); err != nil {
+		oktetoLog.Infof("error unmarshalling 'rest/system/connections' syncthing API: %s", err)
+		return nil, oktetoErrors.ErrLostSyncthing
+	}
+	return connections, nil
+}
+
 // IsHealthy returns the syncthing error or nil
 func (s *Syncthing) IsHealthy(ctx context.Context, local bool, max int) error {
 	pullErrors, err := s.GetPullErrors(ctx, local)
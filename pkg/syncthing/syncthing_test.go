@@ -19,6 +19,9 @@ import (
 	"testing"
 
 	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetFiles(t *testing.T) {
@@ -41,3 +44,47 @@ func TestGetFiles(t *testing.T) {
 		t.Errorf("got %s, expected %s", info, expected)
 	}
 }
+
+func TestNewSyncOptions(t *testing.T) {
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+	var tests = []struct {
+		name                     string
+		sync                     model.Sync
+		expectedCompression      string
+		expectedRescanInterval   string
+		expectedFileWatcherDelay int
+	}{
+		{
+			name:                     "defaults",
+			sync:                     model.Sync{Compression: true, RescanInterval: model.DefaultSyncthingRescanInterval},
+			expectedCompression:      model.SyncCompressionAlways,
+			expectedRescanInterval:   "300",
+			expectedFileWatcherDelay: DefaultFileWatcherDelay,
+		},
+		{
+			name: "options",
+			sync: model.Sync{
+				Compression:    true,
+				RescanInterval: model.DefaultSyncthingRescanInterval,
+				Options: &model.SyncOptions{
+					FsWatcherDelay: 10,
+					Compression:    model.SyncCompressionNever,
+					RescanInterval: 3600,
+				},
+			},
+			expectedCompression:      model.SyncCompressionNever,
+			expectedRescanInterval:   "3600",
+			expectedFileWatcherDelay: 10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev := &model.Dev{Name: "test", Namespace: "ns", Interface: model.Localhost, Sync: tt.sync}
+			s, err := New(dev)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCompression, s.Compression)
+			assert.Equal(t, tt.expectedRescanInterval, s.RescanInterval)
+			assert.Equal(t, tt.expectedFileWatcherDelay, s.FileWatcherDelay)
+		})
+	}
+}
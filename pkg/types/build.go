@@ -14,6 +14,8 @@
 package types
 
 import (
+	"context"
+
 	"github.com/okteto/okteto/pkg/model"
 )
 
@@ -48,6 +50,10 @@ type BuildOptions struct {
 	BuildToGlobal bool
 	K8sContext    string
 	ExportCache   []string
+	// Service is the name of the service of the okteto manifest being built. It selects its section of the .oktetoignore
+	Service string
+	// Project is the name of the okteto manifest being built. It identifies the build context of the project in the cache of the builder
+	Project string
 	// CommandArgs comes from the user input on the command
 	CommandArgs  []string
 	EnableStages bool
@@ -58,4 +64,39 @@ type BuildOptions struct {
 	DevTag   string
 
 	ExtraHosts []HostMap
+
+	// SBOM is the format of the software bill of materials generated for the built images
+	SBOM string
+	// SBOMOutput is the folder where the SBOM documents are written
+	SBOMOutput string
+	// SBOMAttest attaches the SBOM documents as attestations of the images
+	SBOMAttest bool
+	// SBOMKey is the cosign key used to sign the attestations
+	SBOMKey string
+
+	// Sign signs the pushed images with cosign
+	Sign bool
+	// SignKey is the cosign key used to sign the images. Keyless OIDC signing is used if it is empty
+	SignKey string
+
+	// LocalOutputPath exports the files of the resulting image into this local folder instead of pushing an image
+	LocalOutputPath string
+
+	// Strict fails the build if the Dockerfile doesn't follow the best practices
+	Strict bool
+	// NoLint skips the best practices checks of the Dockerfile, i.e. for the Dockerfiles generated by okteto
+	NoLint bool
+
+	// ShowContext lists the files of the build context uploaded to the builder instead of building the image
+	ShowContext bool
+	// ContextCompression is the compression of the build context sent to the docker daemon: gzip or zstd. It's not compressed by default
+	ContextCompression string
+
+	// PushQueue pushes the image in the background instead of waiting for the push. It's only used by the docker daemon builds
+	PushQueue ImagePushQueue
+}
+
+// ImagePushQueue pushes images in the background
+type ImagePushQueue interface {
+	Enqueue(ctx context.Context, tag string)
 }
@@ -20,4 +20,6 @@ type ClusterMetadata struct {
 	PipelineRunnerImage string
 	IsTrialLicense      bool
 	CompanyName         string
+	// MinimumCLIVersion is the oldest version of the okteto cli supported by the server
+	MinimumCLIVersion string
 }
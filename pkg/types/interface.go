@@ -28,11 +28,13 @@ type OktetoInterface interface {
 	Pipeline() PipelineInterface
 	Stream() StreamInterface
 	Kubetoken() KubetokenInterface
+	Catalog() CatalogInterface
 }
 
 // UserInterface represents the client that connects to the user functions
 type UserInterface interface {
 	GetUserSecrets(ctx context.Context) ([]Secret, error)
+	GetNamespaceSecrets(ctx context.Context, namespace string) ([]Secret, error)
 	GetContext(ctx context.Context, ns string) (*UserContext, error)
 	GetClusterCertificate(ctx context.Context, cluster, ns string) ([]byte, error)
 	GetClusterMetadata(ctx context.Context, ns string) (ClusterMetadata, error)
@@ -67,6 +69,7 @@ type PipelineInterface interface {
 	GetResourcesStatus(ctx context.Context, name, namespace string) (map[string]string, error)
 	GetByName(ctx context.Context, name, namespace string) (*GitDeploy, error)
 	WaitForActionProgressing(ctx context.Context, pipelineName, namespace, actionName string, timeout time.Duration) error
+	GetDefaultManifest(ctx context.Context, namespace, repository string) ([]byte, error)
 }
 
 // OktetoClientProvider provides an okteto client ready to use or fail
@@ -83,5 +86,11 @@ type StreamInterface interface {
 // KubetokenInterface represents the kubetoken client
 type KubetokenInterface interface {
 	GetKubeToken(baseURL, namespace string) (KubeTokenResponse, error)
+	GetExecToken(baseURL, namespace, pod, container string) (KubeTokenResponse, error)
 	CheckService(baseURL, namespace string) error
 }
+
+// CatalogInterface represents the client that connects to the catalog functions
+type CatalogInterface interface {
+	List(ctx context.Context) ([]CatalogItem, error)
+}
@@ -0,0 +1,157 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+const webhookTimeout = 10 * time.Second
+
+// Event is the information of a lifecycle event sent to the webhooks. Its fields are available to the payload templates
+type Event struct {
+	Event     model.WebhookEvent `json:"event"`
+	Name      string             `json:"name"`
+	Namespace string             `json:"namespace"`
+	Context   string             `json:"context,omitempty"`
+	User      string             `json:"user,omitempty"`
+	Dev       string             `json:"dev,omitempty"`
+	Error     string             `json:"error,omitempty"`
+	Timestamp time.Time          `json:"timestamp"`
+}
+
+// Message returns a human readable description of the event
+func (e Event) Message() string {
+	switch e.Event {
+	case model.WebhookEventDeployStart:
+		return fmt.Sprintf("Deploying development environment '%s' in namespace '%s'", e.Name, e.Namespace)
+	case model.WebhookEventDeploySuccess:
+		return fmt.Sprintf("Development environment '%s' successfully deployed in namespace '%s'", e.Name, e.Namespace)
+	case model.WebhookEventDeployFailure:
+		return fmt.Sprintf("Development environment '%s' failed to deploy in namespace '%s': %s", e.Name, e.Namespace, e.Error)
+	case model.WebhookEventUpActivate:
+		return fmt.Sprintf("Development container '%s' activated in namespace '%s'", e.Dev, e.Namespace)
+	default:
+		return string(e.Event)
+	}
+}
+
+// Notifier calls the webhooks of a manifest
+type Notifier struct {
+	client   *http.Client
+	webhooks []model.Webhook
+}
+
+// NewNotifier returns a notifier for the webhooks of a manifest
+func NewNotifier(webhooks []model.Webhook) *Notifier {
+	return &Notifier{
+		client:   &http.Client{Timeout: webhookTimeout},
+		webhooks: webhooks,
+	}
+}
+
+// Notify calls the webhooks subscribed to the event.
+// Webhooks never fail the command: errors are shown as warnings
+func (n *Notifier) Notify(ctx context.Context, e Event) {
+	if n == nil {
+		return
+	}
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now().UTC()
+	}
+	for i := range n.webhooks {
+		w := &n.webhooks[i]
+		if !w.Subscribes(e.Event) {
+			continue
+		}
+		if err := n.send(ctx, w, e); err != nil {
+			oktetoLog.Warning("Webhook for event '%s' failed: %s", e.Event, err)
+		}
+	}
+}
+
+func (n *Notifier) send(ctx context.Context, w *model.Webhook, e Event) error {
+	body, err := getPayload(w, e)
+	if err != nil {
+		return err
+	}
+
+	endpoint, err := model.ExpandEnv(w.URL, false)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range w.Headers {
+		value, err := model.ExpandEnv(v, false)
+		if err != nil {
+			return err
+		}
+		req.Header.Set(k, value)
+	}
+
+	oktetoLog.Infof("calling webhook for event '%s'", e.Event)
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("webhook returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(b)))
+	}
+	return nil
+}
+
+// payloadData is the data available to the payload templates
+type payloadData struct {
+	Event
+	Message string
+}
+
+func getPayload(w *model.Webhook, e Event) ([]byte, error) {
+	tmpl, err := w.ParsePayload()
+	if err != nil {
+		return nil, fmt.Errorf("invalid payload template: %w", err)
+	}
+	data := payloadData{Event: e, Message: e.Message()}
+	if tmpl != nil {
+		buf := &bytes.Buffer{}
+		if err := tmpl.Execute(buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render the payload template: %w", err)
+		}
+		return buf.Bytes(), nil
+	}
+
+	if w.Type == model.WebhookTypeSlack {
+		return json.Marshal(map[string]string{"text": data.Message})
+	}
+	return json.Marshal(struct {
+		Event
+		Message string `json:"message"`
+	}{Event: e, Message: data.Message})
+}
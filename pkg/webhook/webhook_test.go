@@ -0,0 +1,77 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type receivedRequest struct {
+	path  string
+	token string
+	body  string
+}
+
+func TestNotify(t *testing.T) {
+	var received []receivedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		received = append(received, receivedRequest{path: r.URL.Path, token: r.Header.Get("Authorization"), body: string(b)})
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("WEBHOOK_TOKEN", "secret")
+	t.Setenv("WEBHOOK_URL", server.URL)
+
+	n := NewNotifier([]model.Webhook{
+		{URL: "${WEBHOOK_URL}/slack", Type: model.WebhookTypeSlack, Events: []model.WebhookEvent{model.WebhookEventDeployFailure}},
+		{URL: server.URL + "/http", Headers: map[string]string{"Authorization": "Bearer ${WEBHOOK_TOKEN}"}},
+		{URL: server.URL + "/custom", Payload: `{"msg": {{ json .Message }}, "error": {{ json .Error }}}`},
+		{URL: server.URL + "/fail", Events: []model.WebhookEvent{model.WebhookEventUpActivate}},
+	})
+
+	ts := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	n.Notify(context.Background(), Event{Event: model.WebhookEventDeployFailure, Name: "movies", Namespace: "ns", Error: `exit "1"`, Timestamp: ts})
+
+	require.Len(t, received, 3)
+	assert.Equal(t, "/slack", received[0].path)
+	assert.JSONEq(t, `{"text": "Development environment 'movies' failed to deploy in namespace 'ns': exit \"1\""}`, received[0].body)
+	assert.Equal(t, "/http", received[1].path)
+	assert.Equal(t, "Bearer secret", received[1].token)
+	assert.JSONEq(t, `{"event": "deploy.failure", "name": "movies", "namespace": "ns", "error": "exit \"1\"", "timestamp": "2023-01-01T00:00:00Z", "message": "Development environment 'movies' failed to deploy in namespace 'ns': exit \"1\""}`, received[1].body)
+	assert.Equal(t, "/custom", received[2].path)
+	assert.JSONEq(t, `{"msg": "Development environment 'movies' failed to deploy in namespace 'ns': exit \"1\"", "error": "exit \"1\""}`, received[2].body)
+
+	received = nil
+	n.Notify(context.Background(), Event{Event: model.WebhookEventUpActivate, Name: "movies", Namespace: "ns", Dev: "api"})
+	require.Len(t, received, 3)
+	assert.Equal(t, "/fail", received[2].path)
+}
+
+func TestNotifyNil(t *testing.T) {
+	var n *Notifier
+	n.Notify(context.Background(), Event{Event: model.WebhookEventDeployStart})
+}
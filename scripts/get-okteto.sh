@@ -79,6 +79,7 @@
                         stable) OKTETO_CHANNEL="$current" ;;
                         beta) OKTETO_CHANNEL="$current" ;;
                         dev) OKTETO_CHANNEL="$current" ;;
+                        nightly) OKTETO_CHANNEL="$current" ;;
                         *) OKTETO_CHANNEL="stable" ;;
                         esac
                 fi